@@ -0,0 +1,30 @@
+#!/bin/bash -eu
+# Copyright 2023 OpenSSF Scorecard Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Builds the native Go fuzz targets of the file parsers for OSS-Fuzz and
+# ClusterFuzzLite. The same script is used by the OSS-Fuzz project.
+
+# compile_native_go_fuzzer needs go-118-fuzz-build in the module graph.
+go get github.com/AdamKorcz/go-118-fuzz-build/testing
+
+compile_native_go_fuzzer github.com/ossf/scorecard/v4/checks FuzzSonarConfig fuzz_sonar_config
+
+raw=github.com/ossf/scorecard/v4/checks/raw
+compile_native_go_fuzzer $raw FuzzDangerousWorkflow fuzz_dangerous_workflow
+compile_native_go_fuzzer $raw FuzzTokenPermissions fuzz_token_permissions
+compile_native_go_fuzzer $raw FuzzGitHubActionWorkflowPinning fuzz_github_action_workflow_pinning
+compile_native_go_fuzzer $raw FuzzDockerfile fuzz_dockerfile
+compile_native_go_fuzzer $raw FuzzShellScript fuzz_shell_script
+compile_native_go_fuzzer $raw FuzzSecurityPolicy fuzz_security_policy
@@ -0,0 +1,120 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/grafeas/kritis/pkg/attestlib"
+	"github.com/grafeas/kritis/pkg/kritis/signer"
+)
+
+type awsKMSSigner struct {
+	client    kmsiface.KMSAPI
+	keyARN    string
+	algorithm string
+	digestAlg signer.DigestAlgorithm
+}
+
+// parseAWSKeyRef splits a reference of the form [ENDPOINT]/[ID/ALIAS/ARN].
+func parseAWSKeyRef(ref string) (endpoint, keyID string, err error) {
+	i := strings.Index(ref, "/")
+	if i < 0 || i == len(ref)-1 {
+		return "", "", EncryptionParamError{fmt.Sprintf("invalid aws kms key reference: %s", ref)}
+	}
+	return ref[:i], ref[i+1:], nil
+}
+
+func newAWSKMSSigner(ref string, digestAlg signer.DigestAlgorithm) (attestlib.Signer, error) {
+	endpoint, keyID, err := parseAWSKeyRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	cfg := aws.NewConfig()
+	if endpoint != "" {
+		cfg = cfg.WithEndpoint(endpoint)
+	}
+	if a, err := arn.Parse(keyID); err == nil {
+		cfg = cfg.WithRegion(a.Region)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *cfg,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating aws session failed: %w", err)
+	}
+	client := kms.New(sess)
+
+	// Resolve aliases to the key they currently point to, so attestations
+	// keep referring to the key that signed them once the alias is moved.
+	out, err := client.DescribeKey(&kms.DescribeKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return nil, fmt.Errorf("describing aws kms key failed: %w", err)
+	}
+	algorithm, err := awsSigningAlgorithm(aws.StringValueSlice(out.KeyMetadata.SigningAlgorithms), digestAlg)
+	if err != nil {
+		return nil, err
+	}
+	return awsKMSSigner{
+		client:    client,
+		keyARN:    aws.StringValue(out.KeyMetadata.Arn),
+		algorithm: algorithm,
+		digestAlg: digestAlg,
+	}, nil
+}
+
+// awsSigningAlgorithm picks the signing algorithm of the key which uses the digest algorithm.
+func awsSigningAlgorithm(supported []string, digestAlg signer.DigestAlgorithm) (string, error) {
+	suffix := "_SHA_" + strings.TrimPrefix(string(digestAlg), "SHA")
+	// PKCS #1 v1.5 is preferred over PSS for RSA keys, since it is deterministic.
+	for _, prefix := range []string{"ECDSA", "RSASSA_PKCS1_V1_5", "RSASSA_PSS"} {
+		for _, alg := range supported {
+			if alg == prefix+suffix {
+				return alg, nil
+			}
+		}
+	}
+	return "", EncryptionParamError{
+		fmt.Sprintf("aws kms key does not support digest algorithm %s, supported algorithms: %s",
+			digestAlg, strings.Join(supported, ", ")),
+	}
+}
+
+func (s awsKMSSigner) CreateAttestation(payload []byte) (*attestlib.Attestation, error) {
+	digest, err := digestPayload(s.digestAlg, payload)
+	if err != nil {
+		return nil, err
+	}
+	out, err := s.client.Sign(&kms.SignInput{
+		KeyId:            aws.String(s.keyARN),
+		Message:          digest,
+		MessageType:      aws.String(kms.MessageTypeDigest),
+		SigningAlgorithm: aws.String(s.algorithm),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signing with aws kms failed: %w", err)
+	}
+	return &attestlib.Attestation{
+		PublicKeyID:       awsKMSScheme + "/" + s.keyARN,
+		Signature:         out.Signature,
+		SerializedPayload: payload,
+	}, nil
+}
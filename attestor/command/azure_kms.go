@@ -0,0 +1,210 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/grafeas/kritis/pkg/attestlib"
+	"github.com/grafeas/kritis/pkg/kritis/signer"
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+const (
+	azureKeyVaultResource   = "https://vault.azure.net"
+	azureKeyVaultAPIVersion = "7.3"
+	azureKeyVaultDomain     = ".vault.azure.net"
+)
+
+type azureKMSSigner struct {
+	token *adal.ServicePrincipalToken
+	// kid is the ID of the key version, e.g. https://myvault.vault.azure.net/keys/mykey/0123abcd.
+	kid       string
+	keyType   string
+	digestAlg signer.DigestAlgorithm
+}
+
+type azureKey struct {
+	Key struct {
+		KID     string `json:"kid"`
+		KeyType string `json:"kty"`
+	} `json:"key"`
+}
+
+type azureSignRequest struct {
+	Algorithm string `json:"alg"`
+	Value     string `json:"value"`
+}
+
+type azureSignResponse struct {
+	Value string `json:"value"`
+}
+
+// parseAzureKeyRef returns the URL of a key (version) from a reference
+// of the form [VAULT_NAME][VAULT_URI]/[KEY][/VERSION].
+func parseAzureKeyRef(ref string) (string, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return "", EncryptionParamError{fmt.Sprintf("invalid azure key vault key reference: %s", ref)}
+	}
+	vault := parts[0]
+	if !strings.Contains(vault, ".") {
+		vault += azureKeyVaultDomain
+	}
+	return "https://" + vault + "/keys/" + strings.Join(parts[1:], "/"), nil
+}
+
+// azureToken authenticates with a service principal if AZURE_TENANT_ID, AZURE_CLIENT_ID
+// and AZURE_CLIENT_SECRET are set, and with the managed identity otherwise.
+func azureToken() (*adal.ServicePrincipalToken, error) {
+	tenantID, clientID, secret := os.Getenv("AZURE_TENANT_ID"), os.Getenv("AZURE_CLIENT_ID"), os.Getenv("AZURE_CLIENT_SECRET")
+	if tenantID == "" || clientID == "" || secret == "" {
+		token, err := adal.NewServicePrincipalTokenFromManagedIdentity(azureKeyVaultResource, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating azure managed identity token failed: %w", err)
+		}
+		return token, nil
+	}
+	config, err := adal.NewOAuthConfig("https://login.microsoftonline.com/", tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("creating azure oauth config failed: %w", err)
+	}
+	token, err := adal.NewServicePrincipalToken(*config, clientID, secret, azureKeyVaultResource)
+	if err != nil {
+		return nil, fmt.Errorf("creating azure service principal token failed: %w", err)
+	}
+	return token, nil
+}
+
+func newAzureKMSSigner(ref string, digestAlg signer.DigestAlgorithm) (attestlib.Signer, error) {
+	keyURL, err := parseAzureKeyRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	token, err := azureToken()
+	if err != nil {
+		return nil, err
+	}
+	s := azureKMSSigner{
+		token:     token,
+		digestAlg: digestAlg,
+	}
+	// Resolve the current key version if none is given, so attestations keep
+	// referring to the version that signed them once the key is rotated.
+	var key azureKey
+	if err := s.do(http.MethodGet, keyURL, nil, &key); err != nil {
+		return nil, fmt.Errorf("fetching azure key vault key failed: %w", err)
+	}
+	s.kid = key.Key.KID
+	s.keyType = key.Key.KeyType
+	return s, nil
+}
+
+func (s azureKMSSigner) do(method, url string, body, v interface{}) error {
+	if err := s.token.EnsureFreshWithContext(context.Background()); err != nil {
+		return fmt.Errorf("refreshing azure token failed: %w", err)
+	}
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return fmt.Errorf("encoding request failed: %w", err)
+		}
+	}
+	req, err := http.NewRequestWithContext(context.Background(), method,
+		url+"?api-version="+azureKeyVaultAPIVersion, &buf)
+	if err != nil {
+		return fmt.Errorf("creating request failed: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token.OAuthToken())
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s returned %s", errAzureKeyVault, url, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding response failed: %w", err)
+	}
+	return nil
+}
+
+// azureSigningAlgorithm returns the JSON Web Algorithm for the key type and digest algorithm.
+func azureSigningAlgorithm(keyType string, digestAlg signer.DigestAlgorithm) (string, error) {
+	bits := strings.TrimPrefix(string(digestAlg), "SHA")
+	switch strings.TrimSuffix(keyType, "-HSM") {
+	case "EC":
+		return "ES" + bits, nil
+	case "RSA":
+		return "RS" + bits, nil
+	default:
+		return "", EncryptionParamError{fmt.Sprintf("unsupported azure key vault key type: %s", keyType)}
+	}
+}
+
+func (s azureKMSSigner) CreateAttestation(payload []byte) (*attestlib.Attestation, error) {
+	alg, err := azureSigningAlgorithm(s.keyType, s.digestAlg)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := digestPayload(s.digestAlg, payload)
+	if err != nil {
+		return nil, err
+	}
+	var resp azureSignResponse
+	req := azureSignRequest{
+		Algorithm: alg,
+		Value:     base64.RawURLEncoding.EncodeToString(digest),
+	}
+	if err := s.do(http.MethodPost, s.kid+"/sign", req, &resp); err != nil {
+		return nil, fmt.Errorf("signing with azure key vault failed: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(resp.Value)
+	if err != nil {
+		return nil, fmt.Errorf("decoding azure key vault signature failed: %w", err)
+	}
+	if strings.HasPrefix(alg, "ES") {
+		signature = ecdsaSignatureToASN1(signature)
+	}
+	return &attestlib.Attestation{
+		PublicKeyID:       s.kid,
+		Signature:         signature,
+		SerializedPayload: payload,
+	}, nil
+}
+
+// ecdsaSignatureToASN1 converts an ECDSA signature from the r || s form returned
+// by Key Vault to the ASN.1 form used for PKIX attestations.
+func ecdsaSignatureToASN1(sig []byte) []byte {
+	half := len(sig) / 2
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1BigInt(new(big.Int).SetBytes(sig[:half]))
+		b.AddASN1BigInt(new(big.Int).SetBytes(sig[half:]))
+	})
+	// Adding integers never fails.
+	return b.BytesOrPanic()
+}
@@ -41,6 +41,11 @@ var (
 	// input flags: kms flags.
 	kmsKeyName   string
 	kmsDigestAlg string
+
+	// input flags: keyless signing flags.
+	keyless           bool
+	fulcioURL         string
+	identityTokenFlag string
 )
 
 //nolint:lll
@@ -61,12 +66,15 @@ func addSignFlags(cmd *cobra.Command) {
 	cmd.MarkPersistentFlagRequired("image")
 	cmd.PersistentFlags().StringVar(&attestationProject, "attestation-project", "", "project id for GCP project that stores attestation, use image project if set to empty")
 	cmd.PersistentFlags().BoolVar(&overwrite, "overwrite", false, "overwrite attestation if already existed (default false)")
-	cmd.PersistentFlags().StringVar(&kmsKeyName, "kms-key-name", "", "kms key name, in the format of in the format projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*")
+	cmd.PersistentFlags().StringVar(&kmsKeyName, "kms-key-name", "", "kms key reference, one of gcpkms://projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/* (the scheme may be omitted), awskms://[ENDPOINT]/[ID/ALIAS/ARN], or azurekms://[VAULT_NAME][VAULT_URI]/[KEY][/VERSION]")
 	cmd.PersistentFlags().StringVar(&kmsDigestAlg, "kms-digest-alg", "", "kms digest algorithm, must be one of SHA256|SHA384|SHA512, and the same as specified by the key version's algorithm")
 	cmd.PersistentFlags().StringVar(&pgpPriKeyPath, "pgp-private-key", "", "pgp private signing key path, e.g., /dev/shm/key.pgp")
 	cmd.PersistentFlags().StringVar(&pgpPassphrase, "pgp-passphrase", "", "passphrase for pgp private key, if any")
 	cmd.PersistentFlags().StringVar(&pkixPriKeyPath, "pkix-private-key", "", "pkix private signing key path, e.g., /dev/shm/key.pem")
 	cmd.PersistentFlags().StringVar(&pkixAlg, "pkix-alg", "", "pkix signature algorithm, e.g., ecdsa-p256-sha256")
+	cmd.PersistentFlags().BoolVar(&keyless, "keyless", false, "sign with an ephemeral key certified by Fulcio for the identity of the build, instead of a key")
+	cmd.PersistentFlags().StringVar(&fulcioURL, "fulcio-url", defaultFulcioURL, "Fulcio instance issuing the certificates of keyless signing")
+	cmd.PersistentFlags().StringVar(&identityTokenFlag, "identity-token", "", "OIDC token for keyless signing, defaults to SIGSTORE_ID_TOKEN, the token of the GitHub Actions workflow or of the Google Cloud service account")
 }
 
 var RootCmd = &cobra.Command{
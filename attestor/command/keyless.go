@@ -0,0 +1,264 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/grafeas/kritis/pkg/attestlib"
+)
+
+const (
+	defaultFulcioURL = "https://fulcio.sigstore.dev"
+	// sigstoreAudience is the audience Fulcio expects identity tokens for.
+	sigstoreAudience = "sigstore"
+	// gcpIdentityURL is the metadata server endpoint returning identity tokens
+	// of the service account of the build, e.g. in Cloud Build.
+	gcpIdentityURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/identity"
+)
+
+var (
+	errFulcio        = errors.New("fulcio error")
+	errIdentityToken = errors.New("identity token error")
+
+	keylessClient = &http.Client{Timeout: 30 * time.Second}
+)
+
+// keylessSigner signs with an ephemeral key, certified by Fulcio for the
+// identity of the OIDC token of the build. There is no long-lived key to
+// rotate: each attestation carries the certificate of the key that signed it.
+type keylessSigner struct {
+	key *ecdsa.PrivateKey
+	// certificate is the PEM encoded certificate Fulcio issued for key.
+	certificate string
+}
+
+type fulcioCertRequest struct {
+	Credentials struct {
+		OIDCIdentityToken string `json:"oidcIdentityToken"`
+	} `json:"credentials"`
+	PublicKeyRequest struct {
+		PublicKey struct {
+			Algorithm string `json:"algorithm"`
+			Content   string `json:"content"`
+		} `json:"publicKey"`
+		ProofOfPossession []byte `json:"proofOfPossession"`
+	} `json:"publicKeyRequest"`
+}
+
+type fulcioCertChain struct {
+	Chain struct {
+		Certificates []string `json:"certificates"`
+	} `json:"chain"`
+}
+
+type fulcioCertResponse struct {
+	EmbeddedSCT *fulcioCertChain `json:"signedCertificateEmbeddedSct"`
+	DetachedSCT *fulcioCertChain `json:"signedCertificateDetachedSct"`
+}
+
+// newKeylessSigner returns a signer whose ephemeral key is certified by the
+// Fulcio instance at fulcioURL for the identity of idToken.
+func newKeylessSigner(fulcioURL, idToken string) (attestlib.Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating ephemeral key failed: %w", err)
+	}
+	subject, err := tokenSubject(idToken)
+	if err != nil {
+		return nil, err
+	}
+	// Fulcio checks the key is held by the requester from a signature of
+	// the subject of the token.
+	subjectDigest := sha256.Sum256([]byte(subject))
+	proof, err := ecdsa.SignASN1(rand.Reader, key, subjectDigest[:])
+	if err != nil {
+		return nil, fmt.Errorf("signing proof of possession failed: %w", err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling ephemeral key failed: %w", err)
+	}
+
+	var req fulcioCertRequest
+	req.Credentials.OIDCIdentityToken = idToken
+	req.PublicKeyRequest.PublicKey.Algorithm = "ECDSA"
+	req.PublicKeyRequest.PublicKey.Content = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}))
+	req.PublicKeyRequest.ProofOfPossession = proof
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encoding request failed: %w", err)
+	}
+	certURL := strings.TrimSuffix(fulcioURL, "/") + "/api/v2/signingCert"
+	httpReq, err := http.NewRequestWithContext(context.Background(), http.MethodPost, certURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating request failed: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := keylessClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", certURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%w: %s returned %s", errFulcio, certURL, resp.Status)
+	}
+	var certResp fulcioCertResponse
+	if err := json.NewDecoder(resp.Body).Decode(&certResp); err != nil {
+		return nil, fmt.Errorf("decoding response failed: %w", err)
+	}
+	chain := certResp.EmbeddedSCT
+	if chain == nil {
+		chain = certResp.DetachedSCT
+	}
+	if chain == nil || len(chain.Chain.Certificates) == 0 {
+		return nil, fmt.Errorf("%w: %s returned no certificate", errFulcio, certURL)
+	}
+	return keylessSigner{key: key, certificate: chain.Chain.Certificates[0]}, nil
+}
+
+// tokenSubject returns the identity Fulcio certifies for a token: its email
+// if it has one, its subject otherwise. The token is verified by Fulcio.
+func tokenSubject(idToken string) (string, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("%w: malformed token", errIdentityToken)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("%w: decoding token failed: %v", errIdentityToken, err)
+	}
+	var claims struct {
+		Subject string `json:"sub"`
+		Email   string `json:"email"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("%w: decoding token claims failed: %v", errIdentityToken, err)
+	}
+	if claims.Email != "" {
+		return claims.Email, nil
+	}
+	if claims.Subject == "" {
+		return "", fmt.Errorf("%w: token has no subject", errIdentityToken)
+	}
+	return claims.Subject, nil
+}
+
+// identityToken returns the OIDC token to request a certificate with: token
+// if set, SIGSTORE_ID_TOKEN, the token of the GitHub Actions workflow, or the
+// token of the service account of the build on Google Cloud.
+func identityToken(token string) (string, error) {
+	if token != "" {
+		return token, nil
+	}
+	if token := os.Getenv("SIGSTORE_ID_TOKEN"); token != "" {
+		return token, nil
+	}
+	if requestURL := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"); requestURL != "" {
+		return githubActionsToken(requestURL, os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN"))
+	}
+	return gcpIdentityToken(gcpIdentityURL)
+}
+
+// githubActionsToken requests an identity token for Fulcio from GitHub
+// Actions, which requires the id-token: write permission.
+func githubActionsToken(requestURL, requestToken string) (string, error) {
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: parsing ACTIONS_ID_TOKEN_REQUEST_URL failed: %v", errIdentityToken, err)
+	}
+	q := u.Query()
+	q.Set("audience", sigstoreAudience)
+	u.RawQuery = q.Encode()
+	body, err := getIdentity(u.String(), map[string]string{"Authorization": "Bearer " + requestToken})
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("%w: decoding github actions token failed: %v", errIdentityToken, err)
+	}
+	return resp.Value, nil
+}
+
+// gcpIdentityToken requests an identity token for Fulcio from the metadata
+// server at identityURL.
+func gcpIdentityToken(identityURL string) (string, error) {
+	body, err := getIdentity(identityURL+"?audience="+sigstoreAudience+"&format=full",
+		map[string]string{"Metadata-Flavor": "Google"})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func getIdentity(identityURL string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, identityURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request failed: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := keylessClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: no identity token, set --identity-token or SIGSTORE_ID_TOKEN: %v",
+			errIdentityToken, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s returned %s", errIdentityToken, req.URL.Host, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading identity token failed: %w", err)
+	}
+	return body, nil
+}
+
+// CreateAttestation signs payload with the ephemeral key. The ID of the
+// public key is the PEM encoded certificate of the key, which verifiers check
+// against the Fulcio roots and the expected identity.
+func (s keylessSigner) CreateAttestation(payload []byte) (*attestlib.Attestation, error) {
+	digest := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("signing with ephemeral key failed: %w", err)
+	}
+	return &attestlib.Attestation{
+		PublicKeyID:       s.certificate,
+		Signature:         signature,
+		SerializedPayload: payload,
+	}, nil
+}
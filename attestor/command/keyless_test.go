@@ -0,0 +1,188 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testToken returns an unsigned token with claims, which is all the signer
+// reads of it.
+func testToken(claims string) string {
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".sig"
+}
+
+// fakeFulcio certifies the keys of the requests whose proof of possession is
+// signed for subject.
+func fakeFulcio(t *testing.T, subject string) *httptest.Server {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fulcio"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/signingCert" {
+			http.NotFound(w, r)
+			return
+		}
+		var req fulcioCertRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		block, _ := pem.Decode([]byte(req.PublicKeyRequest.PublicKey.Content))
+		if block == nil {
+			http.Error(w, "invalid public key", http.StatusBadRequest)
+			return
+		}
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		digest := sha256.Sum256([]byte(subject))
+		if !ecdsa.VerifyASN1(pub.(*ecdsa.PublicKey), digest[:], req.PublicKeyRequest.ProofOfPossession) {
+			http.Error(w, "invalid proof of possession", http.StatusBadRequest)
+			return
+		}
+		leaf := &x509.Certificate{
+			SerialNumber:   big.NewInt(2),
+			NotBefore:      time.Now(),
+			NotAfter:       time.Now().Add(10 * time.Minute),
+			EmailAddresses: []string{subject},
+			KeyUsage:       x509.KeyUsageDigitalSignature,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, leaf, ca, pub, caKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		var resp fulcioCertResponse
+		resp.EmbeddedSCT = &fulcioCertChain{}
+		resp.EmbeddedSCT.Chain.Certificates = []string{
+			string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		}
+		w.WriteHeader(http.StatusCreated)
+		//nolint:errcheck
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestKeylessSigner(t *testing.T) {
+	t.Parallel()
+	fulcio := fakeFulcio(t, "builder@example.com")
+	defer fulcio.Close()
+
+	s, err := newKeylessSigner(fulcio.URL, testToken(`{"sub":"1234","email":"builder@example.com"}`))
+	if err != nil {
+		t.Fatalf("newKeylessSigner: %v", err)
+	}
+	attestation, err := s.CreateAttestation([]byte("payload"))
+	if err != nil {
+		t.Fatalf("CreateAttestation: %v", err)
+	}
+	block, _ := pem.Decode([]byte(attestation.PublicKeyID))
+	if block == nil {
+		t.Fatalf("PublicKeyID = %q, want a PEM certificate", attestation.PublicKeyID)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("ParseCertificate: %v", err)
+	}
+	if len(cert.EmailAddresses) != 1 || cert.EmailAddresses[0] != "builder@example.com" {
+		t.Errorf("certificate identity = %v, want builder@example.com", cert.EmailAddresses)
+	}
+	digest := sha256.Sum256(attestation.SerializedPayload)
+	if !ecdsa.VerifyASN1(cert.PublicKey.(*ecdsa.PublicKey), digest[:], attestation.Signature) {
+		t.Errorf("CreateAttestation() signature does not verify with the certificate")
+	}
+
+	// Fulcio rejects keys proven for another identity.
+	if _, err := newKeylessSigner(fulcio.URL, testToken(`{"sub":"other"}`)); !errors.Is(err, errFulcio) {
+		t.Errorf("newKeylessSigner() error = %v, want %v", err, errFulcio)
+	}
+}
+
+func TestTokenSubject(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		wantErr error
+		name    string
+		token   string
+		want    string
+	}{
+		{name: "email", token: testToken(`{"sub":"1234","email":"builder@example.com"}`), want: "builder@example.com"},
+		{name: "subject", token: testToken(`{"sub":"repo:owner/repo:ref:refs/heads/main"}`), want: "repo:owner/repo:ref:refs/heads/main"},
+		{name: "no subject", token: testToken(`{}`), wantErr: errIdentityToken},
+		{name: "malformed", token: "token", wantErr: errIdentityToken},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tokenSubject(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("tokenSubject() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("tokenSubject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGithubActionsToken(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer request-token" || r.URL.Query().Get("audience") != sigstoreAudience {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		//nolint:errcheck
+		w.Write([]byte(`{"value":"id-token"}`))
+	}))
+	defer server.Close()
+
+	got, err := githubActionsToken(server.URL+"/token?api-version=2.0", "request-token")
+	if err != nil || got != "id-token" {
+		t.Errorf("githubActionsToken() = %q, %v, want id-token", got, err)
+	}
+	if _, err := githubActionsToken(server.URL+"/token", "other"); !errors.Is(err, errIdentityToken) {
+		t.Errorf("githubActionsToken() error = %v, want %v", err, errIdentityToken)
+	}
+}
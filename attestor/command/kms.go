@@ -0,0 +1,79 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package command
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/grafeas/kritis/pkg/attestlib"
+	"github.com/grafeas/kritis/pkg/kritis/signer"
+)
+
+// Key references follow the format used by cosign, so the same reference
+// can be used to sign Scorecard results and attestations.
+const (
+	gcpKMSScheme   = "gcpkms://"
+	awsKMSScheme   = "awskms://"
+	azureKMSScheme = "azurekms://"
+)
+
+var errAzureKeyVault = errors.New("azure key vault error")
+
+// newKMSSigner returns a signer for a key reference of the form:
+//   - gcpkms://projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*,
+//     the scheme may be omitted.
+//   - awskms://[ENDPOINT]/[ID/ALIAS/ARN]
+//   - azurekms://[VAULT_NAME][VAULT_URI]/[KEY][/VERSION]
+//
+// Attestations record the ID of the exact key (version) which signed them, so
+// rotating to a new key or key version does not invalidate older attestations
+// as long as the attestor keeps the public keys they were signed with.
+func newKMSSigner(keyRef string, digestAlg signer.DigestAlgorithm) (attestlib.Signer, error) {
+	switch {
+	case strings.HasPrefix(keyRef, awsKMSScheme):
+		return newAWSKMSSigner(strings.TrimPrefix(keyRef, awsKMSScheme), digestAlg)
+	case strings.HasPrefix(keyRef, azureKMSScheme):
+		return newAzureKMSSigner(strings.TrimPrefix(keyRef, azureKMSScheme), digestAlg)
+	case strings.Contains(keyRef, "://") && !strings.HasPrefix(keyRef, gcpKMSScheme):
+		return nil, EncryptionParamError{fmt.Sprintf("unsupported kms key reference: %s", keyRef)}
+	default:
+		s, err := signer.NewCloudKmsSigner(strings.TrimPrefix(keyRef, gcpKMSScheme), digestAlg)
+		if err != nil {
+			return nil, fmt.Errorf("creating cloud kms signer failed: %w", err)
+		}
+		return s, nil
+	}
+}
+
+func digestPayload(digestAlg signer.DigestAlgorithm, payload []byte) ([]byte, error) {
+	var h hash.Hash
+	switch digestAlg {
+	case signer.SHA256:
+		h = sha256.New()
+	case signer.SHA384:
+		h = sha512.New384()
+	case signer.SHA512:
+		h = sha512.New()
+	default:
+		return nil, EncryptionParamError{fmt.Sprintf("unsupported digest algorithm: %s", digestAlg)}
+	}
+	// hash.Hash never returns an error.
+	h.Write(payload)
+	return h.Sum(nil), nil
+}
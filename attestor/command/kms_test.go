@@ -0,0 +1,95 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package command
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/grafeas/kritis/pkg/kritis/signer"
+)
+
+func TestParseKeyRefs(t *testing.T) {
+	t.Parallel()
+	endpoint, keyID, err := parseAWSKeyRef("/alias/scorecard")
+	if err != nil || endpoint != "" || keyID != "alias/scorecard" {
+		t.Errorf("parseAWSKeyRef() = %q, %q, %v", endpoint, keyID, err)
+	}
+	endpoint, keyID, err = parseAWSKeyRef("localhost:4566/1234abcd-12ab-34cd-56ef-1234567890ab")
+	if err != nil || endpoint != "localhost:4566" || keyID != "1234abcd-12ab-34cd-56ef-1234567890ab" {
+		t.Errorf("parseAWSKeyRef() = %q, %q, %v", endpoint, keyID, err)
+	}
+	if _, _, err := parseAWSKeyRef("alias"); !errors.As(err, &EncryptionParamError{}) {
+		t.Errorf("parseAWSKeyRef(alias) error = %v", err)
+	}
+
+	tests := map[string]string{
+		"myvault/mykey":                              "https://myvault.vault.azure.net/keys/mykey",
+		"myvault/mykey/0123abcd":                     "https://myvault.vault.azure.net/keys/mykey/0123abcd",
+		"myvault.vault.usgovcloudapi.net/mykey/0123": "https://myvault.vault.usgovcloudapi.net/keys/mykey/0123",
+	}
+	for ref, want := range tests {
+		if got, err := parseAzureKeyRef(ref); err != nil || got != want {
+			t.Errorf("parseAzureKeyRef(%s) = %q, %v, want %q", ref, got, err, want)
+		}
+	}
+	if _, err := parseAzureKeyRef("myvault"); !errors.As(err, &EncryptionParamError{}) {
+		t.Errorf("parseAzureKeyRef(myvault) error = %v", err)
+	}
+
+	if _, err := newKMSSigner("hashivault://scorecard", signer.SHA256); !errors.As(err, &EncryptionParamError{}) {
+		t.Errorf("newKMSSigner(hashivault) error = %v", err)
+	}
+}
+
+func TestSigningAlgorithms(t *testing.T) {
+	t.Parallel()
+	alg, err := awsSigningAlgorithm([]string{"RSASSA_PSS_SHA_256", "RSASSA_PKCS1_V1_5_SHA_256"}, signer.SHA256)
+	if err != nil || alg != "RSASSA_PKCS1_V1_5_SHA_256" {
+		t.Errorf("awsSigningAlgorithm() = %q, %v", alg, err)
+	}
+	if _, err := awsSigningAlgorithm([]string{"ECDSA_SHA_256"}, signer.SHA512); err == nil {
+		t.Errorf("awsSigningAlgorithm() expected an error")
+	}
+	alg, err = azureSigningAlgorithm("EC-HSM", signer.SHA384)
+	if err != nil || alg != "ES384" {
+		t.Errorf("azureSigningAlgorithm() = %q, %v", alg, err)
+	}
+	if _, err := azureSigningAlgorithm("oct", signer.SHA256); err == nil {
+		t.Errorf("azureSigningAlgorithm() expected an error")
+	}
+}
+
+func TestECDSASignatureToASN1(t *testing.T) {
+	t.Parallel()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("payload"))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := make([]byte, 64)
+	r.FillBytes(raw[:32])
+	s.FillBytes(raw[32:])
+	if !ecdsa.VerifyASN1(&key.PublicKey, digest[:], ecdsaSignatureToASN1(raw)) {
+		t.Errorf("ecdsaSignatureToASN1() signature does not verify")
+	}
+}
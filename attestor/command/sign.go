@@ -47,12 +47,22 @@ func runSign() error {
 	}
 
 	// Read the signing credentials
-	// Either kmsKeyName or pgpPriKeyPath needs to be set
-	if kmsKeyName == "" && pgpPriKeyPath == "" && pkixPriKeyPath == "" {
-		return EncryptionParamError{"neither kms_key_name, pgp_private_key, or pkix_private_key is specified"}
+	// Either kmsKeyName, pgpPriKeyPath, pkixPriKeyPath or keyless needs to be set
+	if kmsKeyName == "" && pgpPriKeyPath == "" && pkixPriKeyPath == "" && !keyless {
+		return EncryptionParamError{"neither kms_key_name, pgp_private_key, pkix_private_key, or keyless is specified"}
 	}
 	var cSigner attestlib.Signer
 	switch {
+	case keyless:
+		logger.Info(fmt.Sprintf("Using keyless signing with %s.", fulcioURL))
+		token, err := identityToken(identityTokenFlag)
+		if err != nil {
+			return fmt.Errorf("getting identity token failed: %w", err)
+		}
+		cSigner, err = newKeylessSigner(fulcioURL, token)
+		if err != nil {
+			return fmt.Errorf("creating keyless signer failed: %w", err)
+		}
 	case kmsKeyName != "":
 		logger.Info(fmt.Sprintf("Using kms key %s for signing.", kmsKeyName))
 		if kmsDigestAlg == "" {
@@ -60,7 +70,7 @@ func runSign() error {
 			return EncryptionParamError{"kms_digest_alg is unspecified, must be one of SHA256|SHA384|SHA512, and the same as specified by the key version's algorithm"}
 		}
 		kmsDigestAlg = strings.ToUpper(kmsDigestAlg)
-		cSigner, err = signer.NewCloudKmsSigner(kmsKeyName, signer.DigestAlgorithm(kmsDigestAlg))
+		cSigner, err = newKMSSigner(kmsKeyName, signer.DigestAlgorithm(kmsDigestAlg))
 		if err != nil {
 			return fmt.Errorf("creating kms signer failed: %w", err)
 		}
@@ -0,0 +1,71 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+// CheckMetadata documents a check. It is registered with the check, and
+// docs/checks.md and docs/checks.json are generated from it.
+type CheckMetadata struct {
+	// Risk is the risk of a low score: Critical, High, Medium or Low.
+	Risk string
+	// Short is the one-line description of the check.
+	Short string
+	// Description is the description of the check, in Markdown.
+	Description string
+	Tags        []string
+	// Repos lists the types of repos supported by the check, e.g. GitHub or local.
+	Repos []string
+	// Inputs lists the client methods the check reads the repo with, e.g.
+	// RepoClient.ListCommits.
+	Inputs []string
+	// Scoring lists the criteria the score of the check is computed from.
+	Scoring []ScoringRule
+	// Remediation lists the steps to improve the score, in Markdown.
+	Remediation []string
+	// Algorithm is the version of the scoring algorithm of the check. It is
+	// increased whenever a change of the scoring changes the scores of repos
+	// whose data did not change. Zero means the first version.
+	Algorithm int
+	// Previous keeps scoring the check with the algorithm Algorithm replaced,
+	// for a few releases after the change, so that both scores are reported.
+	Previous *PreviousAlgorithm
+}
+
+// AlgorithmVersion returns the version of the scoring algorithm of the check.
+func (m *CheckMetadata) AlgorithmVersion() int {
+	if m.Algorithm < 1 {
+		return 1
+	}
+	return m.Algorithm
+}
+
+// PreviousAlgorithm is a scoring algorithm replaced by a newer version, which
+// is still reported next to the new score until a later release.
+type PreviousAlgorithm struct {
+	// Evaluate scores the raw results of the check with the previous algorithm.
+	Evaluate ExperimentFn
+	// Until is the last release of Scorecard reporting the previous score,
+	// e.g. v4.12.0. Builds which are not releases always report it.
+	Until   string
+	Version int
+}
+
+// ScoringRule is a criterion of the scoring rubric of a check.
+type ScoringRule struct {
+	// Score is the score awarded when the criterion is met, e.g. "10", a
+	// change of the score, e.g. "+3" or "-1", a range, e.g. "0-10" for
+	// proportional scores, or "inconclusive".
+	Score    string
+	Criteria string
+}
@@ -18,9 +18,12 @@ import (
 	"context"
 
 	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/registry"
 )
 
 // CheckRequest struct encapsulates all data to be passed into a CheckFn.
+// It is all a check may use to access the repo: checks do not read the
+// environment nor make network requests of their own.
 type CheckRequest struct {
 	Ctx                   context.Context
 	RepoClient            clients.RepoClient
@@ -29,6 +32,32 @@ type CheckRequest struct {
 	Dlogger               DetailLogger
 	Repo                  clients.Repo
 	VulnerabilitiesClient clients.VulnerabilitiesClient
+	// NewOrgRepoClient returns an uninitialized client for the repos of the
+	// owner of Repo, e.g. the one holding its default community health files.
+	// It is nil if such repos are not supported. Checks must not create clients
+	// of their own, as those would read credentials from the environment.
+	NewOrgRepoClient func() clients.RepoClient
+	// WorkflowRules are the custom rules checked by Dangerous-Workflow.
+	WorkflowRules []WorkflowRule
+	// Denylist are the dependencies known to be malicious or compromised,
+	// checked by Compromised-Dependencies.
+	Denylist []DenylistEntry
+	// Package is the registry metadata of the package whose source repo is
+	// scanned, if the scan was started from a package.
+	Package *registry.Package
+	// NewPackageClient returns the client of the registry of an ecosystem,
+	// e.g. to look up the packages declared by the manifests of the repo. It
+	// is nil if registries are not queried.
+	NewPackageClient func(registry.Ecosystem) (registry.PackageClient, error)
+	// ActionTags lists the tags of the repos of GitHub Actions, to verify
+	// the commits the workflows pin actions to. It is nil unless the
+	// verification is enabled.
+	ActionTags clients.ActionTagsClient
+	// FetchChecksum downloads the digest published at a URL, e.g. to verify
+	// the wrapper jars found by Binary-Artifacts against their official
+	// checksums. It is nil if checksums are not downloaded, and the jars are
+	// then reported as unverified.
+	FetchChecksum func(ctx context.Context, url string) (string, error)
 	// UPGRADEv6: return raw results instead of scores.
 	RawResults    *RawResults
 	RequiredTypes []RequestType
@@ -62,6 +62,29 @@ type CheckResult struct {
 	Details []CheckDetail
 	// Structured results.
 	Rules []string // TODO(X): add support.
+	// Incomplete is set when the check was scored on incomplete data, e.g.
+	// settings the credentials used could not read.
+	Incomplete bool
+	// NotApplicable is set when the check was not run because it makes no
+	// sense for the repo, e.g. Fuzzing for a repo without code. Its score is
+	// inconclusive.
+	NotApplicable bool
+	// Source is the URI of the mirror the result was taken from when the
+	// results of a project mirrored across forges were merged.
+	Source string
+	// Algorithm is the version of the scoring algorithm of the check the
+	// result was scored with.
+	Algorithm int
+	// PreviousScore is the score with the previous scoring algorithm of the
+	// check, while it is still reported after a change of the algorithm.
+	PreviousScore *AlgorithmScore
+}
+
+// AlgorithmScore is a score computed with a given version of the scoring
+// algorithm of a check.
+type AlgorithmScore struct {
+	Algorithm int
+	Score     int
 }
 
 // CheckDetail contains information for each detail.
@@ -187,6 +210,14 @@ func CreateInconclusiveResult(name, reason string) CheckResult {
 	}
 }
 
+// CreateNotApplicableResult is used when the check is not run because it
+// does not apply to the repo.
+func CreateNotApplicableResult(name, reason string) CheckResult {
+	ret := CreateInconclusiveResult(name, "not applicable: "+reason)
+	ret.NotApplicable = true
+	return ret
+}
+
 // CreateRuntimeErrorResult is used when the check fails to run because of a runtime error.
 func CreateRuntimeErrorResult(name string, e error) CheckResult {
 	return CheckResult{
@@ -18,6 +18,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime/debug"
+	"runtime/pprof"
 	"time"
 
 	opencensusstats "go.opencensus.io/stats"
@@ -29,6 +31,9 @@ import (
 
 const checkRetries = 3
 
+// profileLabelCheck is the pprof label holding the name of the running check.
+const profileLabelCheck = "check"
+
 // Runner runs a check with retries.
 type Runner struct {
 	CheckName    string
@@ -63,10 +68,12 @@ func (r *Runner) SetCheckRequest(checkReq *CheckRequest) {
 // CheckFn defined for convenience.
 type CheckFn func(*CheckRequest) CheckResult
 
-// Check defines a Scorecard check fn and its supported request types.
+// Check defines a Scorecard check fn, its supported request types and its
+// documentation.
 type Check struct {
 	Fn                    CheckFn
 	SupportedRequestTypes []RequestType
+	Metadata              CheckMetadata
 }
 
 // CheckNameToFnMap defined here for convenience.
@@ -86,6 +93,23 @@ func logStats(ctx context.Context, startTime time.Time, result *CheckResult) err
 	return nil
 }
 
+// runCheckFn runs fn, converting a panic into a runtime error result so that
+// one misbehaving check does not take down the whole scan.
+// The panic's stack trace is kept as a debug detail of the result.
+func runCheckFn(ctx context.Context, name string, fn CheckFn, req *CheckRequest) (res CheckResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			req.Dlogger.Debug(&LogMessage{
+				Text: fmt.Sprintf("panic: %v\n%s", r, debug.Stack()),
+			})
+			opencensusstats.Record(ctx, stats.CheckPanics.M(1))
+			res = CreateRuntimeErrorResult(name,
+				sce.WithMessage(sce.ErrorCheckRuntime, fmt.Sprintf("check panicked: %v", r)))
+		}
+	}()
+	return fn(req)
+}
+
 // Run runs a given check.
 func (r *Runner) Run(ctx context.Context, c Check) CheckResult {
 	// Sanity check.
@@ -108,7 +132,12 @@ func (r *Runner) Run(ctx context.Context, c Check) CheckResult {
 		checkRequest := r.CheckRequest
 		checkRequest.Ctx = ctx
 		checkRequest.Dlogger = l
-		res = c.Fn(&checkRequest)
+		sandbox(&checkRequest)
+		// Labeled so that continuous profiles can be broken down by check.
+		pprof.Do(ctx, pprof.Labels(profileLabelCheck, r.CheckName), func(ctx context.Context) {
+			checkRequest.Ctx = ctx
+			res = runCheckFn(ctx, r.CheckName, c.Fn, &checkRequest)
+		})
 		if res.Error != nil && errors.Is(res.Error, sce.ErrRepoUnreachable) {
 			checkRequest.Dlogger.Warn(&LogMessage{
 				Text: fmt.Sprintf("%v", res.Error),
@@ -121,6 +150,7 @@ func (r *Runner) Run(ctx context.Context, c Check) CheckResult {
 	// Set details.
 	// TODO(#1393): Remove.
 	res.Details = l.Flush()
+	res.Algorithm = c.Metadata.AlgorithmVersion()
 
 	if err := logStats(ctx, startTime, &res); err != nil {
 		panic(err)
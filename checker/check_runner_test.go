@@ -0,0 +1,98 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"runtime/pprof"
+	"strings"
+	"testing"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+func TestRunnerRecoversPanic(t *testing.T) {
+	t.Parallel()
+	runner := NewRunner("Panicky", "github.com/owner/repo", &CheckRequest{})
+	res := runner.Run(context.Background(), Check{
+		Fn: func(*CheckRequest) CheckResult {
+			var m map[string]int
+			m["boom"]++ // Writing to a nil map panics.
+			return CheckResult{}
+		},
+	})
+	if !errors.Is(res.Error, sce.ErrorCheckRuntime) {
+		t.Fatalf("expected ErrorCheckRuntime, got %v", res.Error)
+	}
+	if res.Score != InconclusiveResultScore {
+		t.Errorf("expected inconclusive score, got %d", res.Score)
+	}
+	if res.Name != "Panicky" {
+		t.Errorf("expected check name Panicky, got %q", res.Name)
+	}
+	if len(res.Details) != 1 || res.Details[0].Type != DetailDebug ||
+		!strings.Contains(res.Details[0].Msg.Text, "runtime/debug.Stack") {
+		t.Errorf("expected a debug detail with the stack trace, got %v", res.Details)
+	}
+}
+
+func TestRunnerLabelsProfiles(t *testing.T) {
+	t.Parallel()
+	var label string
+	runner := NewRunner("Labeled", "github.com/owner/repo", &CheckRequest{})
+	runner.Run(context.Background(), Check{
+		Fn: func(c *CheckRequest) CheckResult {
+			label, _ = pprof.Label(c.Ctx, profileLabelCheck)
+			return CheckResult{}
+		},
+	})
+	if label != "Labeled" {
+		t.Errorf("expected check label Labeled, got %q", label)
+	}
+}
+
+func TestRunnerSetsAlgorithm(t *testing.T) {
+	t.Parallel()
+	fn := func(*CheckRequest) CheckResult {
+		return CreateMaxScoreResult("Versioned", "reason")
+	}
+	tests := []struct {
+		name     string
+		metadata CheckMetadata
+		want     int
+	}{
+		{
+			name: "first version by default",
+			want: 1,
+		},
+		{
+			name:     "changed algorithm",
+			metadata: CheckMetadata{Algorithm: 3},
+			want:     3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			runner := NewRunner("Versioned", "github.com/owner/repo", &CheckRequest{})
+			res := runner.Run(context.Background(), Check{Fn: fn, Metadata: tt.metadata})
+			if res.Algorithm != tt.want {
+				t.Errorf("expected algorithm %d, got %d", tt.want, res.Algorithm)
+			}
+		})
+	}
+}
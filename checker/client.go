@@ -17,11 +17,13 @@ package checker
 import (
 	"context"
 	"fmt"
-	"os"
+	"strings"
 
 	"github.com/ossf/scorecard/v4/clients"
 	ghrepo "github.com/ossf/scorecard/v4/clients/githubrepo"
+	ghroundtripper "github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper"
 	glrepo "github.com/ossf/scorecard/v4/clients/gitlabrepo"
+	glroundtripper "github.com/ossf/scorecard/v4/clients/gitlabrepo/roundtripper"
 	"github.com/ossf/scorecard/v4/clients/localdir"
 	"github.com/ossf/scorecard/v4/clients/ossfuzz"
 	"github.com/ossf/scorecard/v4/log"
@@ -54,11 +56,10 @@ func GetClients(ctx context.Context, repoURI, localURI string, logger *log.Logge
 			retErr
 	}
 
-	_, experimental := os.LookupEnv("SCORECARD_EXPERIMENTAL")
 	var repoClient clients.RepoClient
 
 	//nolint:nestif
-	if experimental && glrepo.DetectGitLab(repoURI) {
+	if IsExperimentalEnabled() && glrepo.DetectGitLab(repoURI) {
 		repo, makeRepoError = glrepo.MakeGitlabRepo(repoURI)
 		if makeRepoError != nil {
 			return repo,
@@ -70,7 +71,7 @@ func GetClients(ctx context.Context, repoURI, localURI string, logger *log.Logge
 		}
 
 		var err error
-		repoClient, err = glrepo.CreateGitlabClientWithToken(ctx, os.Getenv("GITLAB_AUTH_TOKEN"), repo)
+		repoClient, err = glrepo.CreateGitlabClient(ctx, repo)
 		if err != nil {
 			return repo,
 				nil,
@@ -99,3 +100,18 @@ func GetClients(ctx context.Context, repoURI, localURI string, logger *log.Logge
 		clients.DefaultVulnerabilitiesClient(), /*vulnClient*/
 		nil
 }
+
+// ClientType returns the type of the client GetClients creates for repo,
+// "github", "gitlab" or "localdir", and the type of the credentials it
+// authenticates with, without revealing them.
+func ClientType(repo clients.Repo) (clientType, credentialType string) {
+	host := repo.Host()
+	switch {
+	case host == "":
+		return "localdir", "none"
+	case strings.Contains(host, "://"):
+		return "gitlab", glroundtripper.CredentialType(host[strings.Index(host, "://")+len("://"):])
+	default:
+		return "github", ghroundtripper.CredentialType()
+	}
+}
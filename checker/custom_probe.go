@@ -0,0 +1,56 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"regexp"
+
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/rule"
+)
+
+// CustomProbe is a probe defined by users in YAML rather than in Go, see
+// config.ParseCustomProbes. It runs after its check and its findings are
+// reported in the details of the check, without changing its score.
+type CustomProbe struct {
+	ID          string
+	Description string
+	// Check is the name of the check the findings are reported with.
+	Check string
+	// Files are the globs of the files the probe looks at, matched as
+	// fileparser.PathMatcher patterns.
+	Files []string
+	// Conditions must all hold for a file to match. A probe without
+	// conditions matches every file its globs match.
+	Conditions []CustomProbeCondition
+	// OnMatch is the outcome of the findings for the matching files and
+	// OnNoMatch the outcome of the single finding if no file matches.
+	OnMatch     finding.Outcome
+	OnNoMatch   finding.Outcome
+	Risk        rule.Risk
+	Remediation *rule.Remediation
+}
+
+// CustomProbeCondition is a condition on the content of a file. Exactly one
+// of Regex and YAMLPath is set.
+type CustomProbeCondition struct {
+	// Regex matches the content of the file.
+	Regex *regexp.Regexp
+	// YAMLPath are the keys leading to a node of a YAML file, "*" matching
+	// any key or item.
+	YAMLPath []string
+	// Value matches the scalar at YAMLPath. Any node matches if it is nil.
+	Value *regexp.Regexp
+}
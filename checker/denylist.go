@@ -0,0 +1,95 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DenylistEcosystem is the ecosystem of the dependencies of a denylist entry.
+type DenylistEcosystem string
+
+const (
+	// DenylistGitHubActions are the actions and reusable workflows used by
+	// GitHub workflows, named by owner/repo.
+	DenylistGitHubActions DenylistEcosystem = "github-actions"
+	// DenylistNPM are the packages locked by package-lock.json and yarn.lock.
+	DenylistNPM DenylistEcosystem = "npm"
+	// DenylistPyPI are the packages locked by poetry.lock and pinned by
+	// requirements files.
+	DenylistPyPI DenylistEcosystem = "pypi"
+	// DenylistGo are the modules locked by go.sum.
+	DenylistGo DenylistEcosystem = "go"
+	// DenylistCratesIO are the crates locked by Cargo.lock.
+	DenylistCratesIO DenylistEcosystem = "crates.io"
+	// DenylistRubyGems are the gems locked by Gemfile.lock.
+	DenylistRubyGems DenylistEcosystem = "rubygems"
+)
+
+// DenylistEntry is a dependency known to be malicious or compromised, read
+// from a denylist feed, see config.ParseDenylist. Compromised-Dependencies
+// reports the actions used by the workflows and the packages locked by the
+// lockfiles of the repo which match an entry.
+type DenylistEntry struct {
+	ID          string
+	Description string
+	Ecosystem   DenylistEcosystem
+	// Name is the name of the package, or the owner/repo of the action.
+	Name string
+	// Versions are the compromised versions of the package, or the tags and
+	// commits of the action. Every version matches if it is empty.
+	Versions []string
+}
+
+// Matches returns whether the dependency name at version of the ecosystem
+// of the entry is denylisted by it. Names are compared the way the registry
+// of the ecosystem does, e.g. ignoring case for actions and PyPI packages.
+func (e *DenylistEntry) Matches(name, version string) bool {
+	if normalizeDependencyName(e.Ecosystem, name) != normalizeDependencyName(e.Ecosystem, e.Name) {
+		return false
+	}
+	if len(e.Versions) == 0 {
+		return true
+	}
+	for _, v := range e.Versions {
+		// Commits are written in either case.
+		if v == version || (e.Ecosystem == DenylistGitHubActions && strings.EqualFold(v, version)) {
+			return true
+		}
+	}
+	return false
+}
+
+// pypiNameSeparators are normalized away as by PEP 503.
+var pypiNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+func normalizeDependencyName(ecosystem DenylistEcosystem, name string) string {
+	switch ecosystem {
+	case DenylistGitHubActions:
+		// Actions in a subdirectory of a repo are released with the repo.
+		parts := strings.SplitN(name, "/", 3)
+		if len(parts) > 2 {
+			name = parts[0] + "/" + parts[1]
+		}
+		return strings.ToLower(name)
+	case DenylistPyPI:
+		return pypiNameSeparators.ReplaceAllString(strings.ToLower(name), "-")
+	case DenylistNPM, DenylistGo, DenylistCratesIO, DenylistRubyGems:
+		return name
+	default:
+		return name
+	}
+}
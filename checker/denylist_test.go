@@ -0,0 +1,81 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import "testing"
+
+func TestDenylistEntry_Matches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		entry   DenylistEntry
+		dep     string
+		version string
+		want    bool
+	}{
+		{
+			name:    "any version",
+			entry:   DenylistEntry{Ecosystem: DenylistNPM, Name: "flatmap-stream"},
+			dep:     "flatmap-stream",
+			version: "0.1.1",
+			want:    true,
+		},
+		{
+			name:    "other version",
+			entry:   DenylistEntry{Ecosystem: DenylistNPM, Name: "ua-parser-js", Versions: []string{"0.7.29"}},
+			dep:     "ua-parser-js",
+			version: "0.7.30",
+		},
+		{
+			name:    "npm names are case-sensitive",
+			entry:   DenylistEntry{Ecosystem: DenylistNPM, Name: "flatmap-stream"},
+			dep:     "Flatmap-Stream",
+			version: "0.1.1",
+		},
+		{
+			name:    "normalized PyPI name",
+			entry:   DenylistEntry{Ecosystem: DenylistPyPI, Name: "python-dateutil"},
+			dep:     "Python_DateUtil",
+			version: "2.8.2",
+			want:    true,
+		},
+		{
+			name: "action in a subdirectory at a commit",
+			entry: DenylistEntry{
+				Ecosystem: DenylistGitHubActions,
+				Name:      "Owner/Action",
+				Versions:  []string{"0e58ed8671d6b60d0890c21b07f8835ace038e67"},
+			},
+			dep:     "owner/action/sub/dir",
+			version: "0E58ED8671D6B60D0890C21B07F8835ACE038E67",
+			want:    true,
+		},
+		{
+			name:    "action of another repo",
+			entry:   DenylistEntry{Ecosystem: DenylistGitHubActions, Name: "owner/action"},
+			dep:     "owner/action-fork",
+			version: "v1",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.entry.Matches(tt.dep, tt.version); got != tt.want {
+				t.Errorf("Matches(%q, %q) = %v, want %v", tt.dep, tt.version, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"os"
+	"strings"
+)
+
+// EnvVarExperiments is the environment variable holding the comma-separated
+// names of the experiments to run, or "all" to run every experiment.
+const EnvVarExperiments = "SCORECARD_EXPERIMENTS"
+
+// ExperimentFn evaluates the experimental variant of a check from the raw
+// results collected by the check.
+type ExperimentFn func(name string, dl DetailLogger, raw *RawResults) CheckResult
+
+// Experiment is a new probe or scoring variant of a check shipped dark: its
+// outcome is recorded next to the result of the check in the raw results,
+// but does not affect any score.
+type Experiment struct {
+	Fn ExperimentFn
+	// Check is the name of the check the experiment is a variant of. The
+	// experiment only runs when the check succeeded.
+	Check       string
+	Description string
+}
+
+// ExperimentNameToFnMap defined here for convenience.
+type ExperimentNameToFnMap map[string]Experiment
+
+// ExperimentOutcome is the outcome of a check or of its experimental variant.
+type ExperimentOutcome struct {
+	Reason string
+	Score  int
+}
+
+// ExperimentResult records the outcomes of a check and of an experimental
+// variant of it on the same raw results, so they can be compared.
+type ExperimentResult struct {
+	Name  string
+	Check string
+	// Control is the outcome of the check.
+	Control ExperimentOutcome
+	// Treatment is the outcome of the experiment.
+	Treatment ExperimentOutcome
+}
+
+// EnabledExperiments returns the names of the experiments enabled via the
+// SCORECARD_EXPERIMENTS environment variable. Checks must use it rather than
+// reading the environment, see checks/sandbox_test.go.
+func EnabledExperiments() []string {
+	return parseExperiments(os.Getenv(EnvVarExperiments))
+}
+
+func parseExperiments(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// pathScopedRepoClient only lists the files under dir, so that file-based
+// checks score a single project of a monorepo. Checks of repo settings and
+// history are not affected.
+type pathScopedRepoClient struct {
+	clients.RepoClient
+	dir string
+}
+
+// NewPathScopedRepoClient restricts the files listed by c to those under dir.
+// An empty dir, or the root of the repo, returns c unchanged.
+func NewPathScopedRepoClient(c clients.RepoClient, dir string) clients.RepoClient {
+	dir = path.Clean(strings.Trim(dir, "/"))
+	if dir == "." || dir == "" {
+		return c
+	}
+	return &pathScopedRepoClient{RepoClient: c, dir: dir}
+}
+
+func (c *pathScopedRepoClient) inScope(filename string) bool {
+	return filename == c.dir || strings.HasPrefix(filename, c.dir+"/")
+}
+
+func (c *pathScopedRepoClient) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
+	files, err := c.RepoClient.ListFiles(func(filename string) (bool, error) {
+		if !c.inScope(filename) {
+			return false, nil
+		}
+		return predicate(filename)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error during ListFiles: %w", err)
+	}
+	return files, nil
+}
+
+// fileScopedRepoClient only lists the given files, so that file-based checks
+// only score the files changed by a pull request.
+type fileScopedRepoClient struct {
+	clients.RepoClient
+	files map[string]bool
+}
+
+// NewFileScopedRepoClient restricts the files listed by c to files. No files
+// returns c unchanged.
+func NewFileScopedRepoClient(c clients.RepoClient, files []string) clients.RepoClient {
+	if len(files) == 0 {
+		return c
+	}
+	ret := &fileScopedRepoClient{RepoClient: c, files: map[string]bool{}}
+	for _, f := range files {
+		ret.files[strings.TrimPrefix(path.Clean(f), "/")] = true
+	}
+	return ret
+}
+
+func (c *fileScopedRepoClient) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
+	files, err := c.RepoClient.ListFiles(func(filename string) (bool, error) {
+		if !c.files[filename] {
+			return false, nil
+		}
+		return predicate(filename)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error during ListFiles: %w", err)
+	}
+	return files, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func TestPathScopedRepoClient(t *testing.T) {
+	t.Parallel()
+	files := []string{"README.md", "cron/main.go", "cron/data/blob.go", "cronjob/main.go"}
+	tests := []struct {
+		name string
+		dir  string
+		want []string
+	}{
+		{name: "no scope", dir: "", want: files},
+		{name: "root", dir: "/", want: files},
+		{name: "directory", dir: "cron", want: []string{"cron/main.go", "cron/data/blob.go"}},
+		{name: "trailing slash", dir: "/cron/data/", want: []string{"cron/data/blob.go"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mockrepo.NewMockRepoClient(ctrl)
+			mockRepo.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					var ret []string
+					for _, f := range files {
+						ok, err := predicate(f)
+						if err != nil {
+							return nil, err
+						}
+						if ok {
+							ret = append(ret, f)
+						}
+					}
+					return ret, nil
+				})
+			c := NewPathScopedRepoClient(mockRepo, tt.dir)
+			got, err := c.ListFiles(func(string) (bool, error) { return true, nil })
+			if err != nil {
+				t.Fatalf("ListFiles: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ListFiles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFileScopedRepoClient(t *testing.T) {
+	t.Parallel()
+	files := []string{"README.md", ".github/workflows/ci.yml", "cron/main.go", "cron/main_test.go"}
+	tests := []struct {
+		name  string
+		scope []string
+		want  []string
+	}{
+		{name: "no scope", want: files},
+		{name: "files", scope: []string{".github/workflows/ci.yml", "cron/main.go"}, want: []string{".github/workflows/ci.yml", "cron/main.go"}},
+		{name: "directory", scope: []string{"cron"}, want: nil},
+		{name: "removed file", scope: []string{"cron/removed.go", "/README.md"}, want: []string{"README.md"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mockrepo.NewMockRepoClient(ctrl)
+			mockRepo.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					var ret []string
+					for _, f := range files {
+						ok, err := predicate(f)
+						if err != nil {
+							return nil, err
+						}
+						if ok {
+							ret = append(ret, f)
+						}
+					}
+					return ret, nil
+				})
+			c := NewFileScopedRepoClient(mockRepo, tt.scope)
+			got, err := c.ListFiles(func(string) (bool, error) { return true, nil })
+			if err != nil {
+				t.Fatalf("ListFiles: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ListFiles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
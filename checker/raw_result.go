@@ -15,9 +15,11 @@
 package checker
 
 import (
+	"regexp"
 	"time"
 
 	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/registry"
 	"github.com/ossf/scorecard/v4/finding"
 )
 
@@ -25,24 +27,31 @@ import (
 // is applied.
 // nolint
 type RawResults struct {
-	PackagingResults            PackagingData
-	CIIBestPracticesResults     CIIBestPracticesData
-	DangerousWorkflowResults    DangerousWorkflowData
-	VulnerabilitiesResults      VulnerabilitiesData
-	BinaryArtifactResults       BinaryArtifactData
-	SecurityPolicyResults       SecurityPolicyData
-	DependencyUpdateToolResults DependencyUpdateToolData
-	BranchProtectionResults     BranchProtectionsData
-	CodeReviewResults           CodeReviewData
-	PinningDependenciesResults  PinningDependenciesData
-	WebhookResults              WebhooksData
-	ContributorsResults         ContributorsData
-	MaintainedResults           MaintainedData
-	SignedReleasesResults       SignedReleasesData
-	FuzzingResults              FuzzingData
-	LicenseResults              LicenseData
-	TokenPermissionsResults     TokenPermissionsData
-	CITestResults               CITestData
+	PackagingResults               PackagingData
+	CIIBestPracticesResults        CIIBestPracticesData
+	DangerousWorkflowResults       DangerousWorkflowData
+	VulnerabilitiesResults         VulnerabilitiesData
+	BinaryArtifactResults          BinaryArtifactData
+	SecurityPolicyResults          SecurityPolicyData
+	DependencyUpdateToolResults    DependencyUpdateToolData
+	BranchProtectionResults        BranchProtectionsData
+	CodeReviewResults              CodeReviewData
+	PinningDependenciesResults     PinningDependenciesData
+	WebhookResults                 WebhooksData
+	ActionsPolicyResults           ActionsPolicyData
+	DeploymentProtectionResults    DeploymentProtectionData
+	SelfHostedRunnersResults       SelfHostedRunnersData
+	HiddenCharactersResults        HiddenCharactersData
+	CompromisedDependenciesResults CompromisedDependenciesData
+	ContributorsResults            ContributorsData
+	MaintainedResults              MaintainedData
+	SignedReleasesResults          SignedReleasesData
+	FuzzingResults                 FuzzingData
+	LicenseResults                 LicenseData
+	TokenPermissionsResults        TokenPermissionsData
+	CITestResults                  CITestData
+	// Experiments holds the outcomes of the experiments run alongside the checks.
+	Experiments []ExperimentResult
 }
 
 type RevisionCIInfo struct {
@@ -54,18 +63,59 @@ type RevisionCIInfo struct {
 
 type CITestData struct {
 	CIInfo []RevisionCIInfo
+	// Configs are the configuration files of CI systems in the repository.
+	Configs []CIConfig
+}
+
+// CIConfig is the configuration file of a CI system, like a Jenkinsfile.
+type CIConfig struct {
+	System string
+	File   File
+	// RunsTests is set if the scripts of the configuration run tests.
+	RunsTests bool
 }
 
 // FuzzingData represents different fuzzing done.
 type FuzzingData struct {
-	Fuzzers []Tool
+	// OSSFuzzBuild is the status of the OSS-Fuzz builds of the project,
+	// nil if it is not integrated in OSS-Fuzz or the status is unknown.
+	OSSFuzzBuild *OSSFuzzBuildStatus
+	Fuzzers      []Tool
+}
+
+// OSSFuzzBuildStatus summarizes the recent OSS-Fuzz builds of a project.
+type OSSFuzzBuildStatus struct {
+	// LastBuild is the time of the most recent build.
+	LastBuild time.Time
+	// LastSuccessfulBuild is the time of the most recent successful build,
+	// zero if no successful build is known.
+	LastSuccessfulBuild time.Time
+	// LastBuildSucceeded is true if the most recent build succeeded.
+	LastBuildSucceeded bool
 }
 
 // TODO: Add Msg to all results.
 
 // PackagingData contains results for the Packaging check.
 type PackagingData struct {
+	// Registry is the registry metadata of the package the scan was started
+	// from, e.g. with --npm, if any.
+	Registry *registry.Package
 	Packages []Package
+	// RegistryPackages are the packages the repo declares, e.g. in its
+	// package.json, which are published to a registry.
+	RegistryPackages []RegistryPackage
+}
+
+// RegistryPackage is a package declared by a manifest of the repo and
+// published to a registry.
+type RegistryPackage struct {
+	// Manifest is the file declaring the package.
+	Manifest File
+	Package  *registry.Package
+	// RepoMatches is true when the package declares the scanned repo as its
+	// source repo.
+	RepoMatches bool
 }
 
 // Package represents a package.
@@ -99,11 +149,47 @@ const (
 	DependencyUseTypeNpmCommand DependencyUseType = "npmCommand"
 	// DependencyUseTypePipCommand is a pipp command.
 	DependencyUseTypePipCommand DependencyUseType = "pipCommand"
+	// DependencyUseTypeCircleCIOrb is an orb imported by a CircleCI configuration.
+	DependencyUseTypeCircleCIOrb DependencyUseType = "circleCIOrb"
 )
 
 // PinningDependenciesData represents pinned dependency data.
 type PinningDependenciesData struct {
 	Dependencies []Dependency
+	// Suppressed are the unpinned dependencies suppressed by a
+	// `scorecard:ignore` comment, which are not scored.
+	Suppressed []Suppression
+	// ActionPins are the GitHub Actions pinned by commit SHA, verified
+	// against the tags of their repos if CheckRequest.ActionTags is set.
+	ActionPins []ActionPin
+}
+
+// ActionPin is a GitHub Action pinned to a commit by its SHA.
+type ActionPin struct {
+	Location *File
+	// Repo is the repo of the action, e.g. actions/checkout.
+	Repo string
+	SHA  string
+	// Tags are the tags of Repo pointing at SHA. None means the commit is
+	// not tagged, or not even part of the repo, if Verified.
+	Tags []string
+	// Verified is set if the tags of Repo were listed.
+	Verified bool
+	// Msg is why the tags were not listed, if not Verified.
+	Msg *string
+}
+
+// Suppression is a finding suppressed by a `scorecard:ignore` comment next to
+// the line it flags.
+type Suppression struct {
+	// ID is the identifier named by the comment, e.g. Pinned-Dependencies or
+	// Pinned-Dependencies/GitHubAction.
+	ID     string
+	Reason string
+	// Type is the type of the finding, e.g. the DependencyUseType of an
+	// unpinned dependency.
+	Type string
+	File File
 }
 
 // Dependency represents a dependency.
@@ -124,6 +210,34 @@ type MaintainedData struct {
 	Issues               []clients.Issue
 	DefaultBranchCommits []clients.Commit
 	ArchivedStatus       ArchivedStatus
+	// MaintenanceDeclaration is the maintenance status declared
+	// by the maintainers, if any.
+	MaintenanceDeclaration *MaintenanceDeclaration
+	// AuditLog holds the dismissals of secret scanning alerts, if the audit
+	// log of the organization is available.
+	AuditLog *AuditLogData
+}
+
+// MaintenanceStatus is a maintenance status declared by the maintainers.
+type MaintenanceStatus string
+
+const (
+	// MaintenanceActive means the project is actively developed.
+	MaintenanceActive MaintenanceStatus = "active"
+	// MaintenanceFeatureComplete means the project is stable and only receives bug and security fixes.
+	MaintenanceFeatureComplete MaintenanceStatus = "feature-complete"
+	// MaintenanceSecurityFixesOnly means the project only receives security fixes.
+	MaintenanceSecurityFixesOnly MaintenanceStatus = "security-fixes-only"
+	// MaintenanceUnmaintained means the project is no longer maintained.
+	MaintenanceUnmaintained MaintenanceStatus = "unmaintained"
+)
+
+// MaintenanceDeclaration is a maintenance status declared in a file of the repo,
+// e.g. the scorecard config or SECURITY-INSIGHTS.yml.
+type MaintenanceDeclaration struct {
+	Status MaintenanceStatus
+	Reason string
+	File   File
 }
 
 type LicenseAttributionType string
@@ -154,12 +268,48 @@ type LicenseFile struct {
 // Some repos may have more than one license.
 type LicenseData struct {
 	LicenseFiles []LicenseFile
+	// SubdirectoryLicenses contains the license files found below the
+	// top-level directory, e.g. for the components of a monorepo.
+	SubdirectoryLicenses []LicenseFile
 }
 
 // CodeReviewData contains the raw results
 // for the Code-Review check.
 type CodeReviewData struct {
 	DefaultBranchChangesets []Changeset
+	Summary                 CodeReviewSummary
+	Integrity               CommitIntegrity
+}
+
+// CommitIntegrity lists the commits of the default branch whose origin
+// cannot be vouched for by a pull request or an account of the forge.
+type CommitIntegrity struct {
+	// WebEdits are the SHAs of the commits created by editing files in the
+	// web interface of the forge, e.g. GitHub's web-flow commits, which were
+	// not merged from a pull request.
+	WebEdits []string
+	// UnlinkedAuthors are the SHAs of the commits whose author email is not
+	// associated with any account of the forge, nil if the forge does not
+	// link commits to accounts.
+	UnlinkedAuthors []string
+}
+
+// CodeReviewSummary breaks down the changesets of the default branch by
+// who approved them.
+type CodeReviewSummary struct {
+	// Changesets is the number of changesets summarized.
+	Changesets int
+	// SelfMerged is the number of GitHub changesets merged by their author
+	// without approval from anyone else.
+	SelfMerged int
+	// SingleMaintainerApproved is the number of GitHub changesets approved by
+	// a single person other than their author, usually the maintainer who
+	// merged them.
+	SingleMaintainerApproved int
+	// IndependentlyReviewed is the number of GitHub changesets approved by at
+	// least two people other than their author, i.e., by a reviewer besides
+	// the maintainer who merged them.
+	IndependentlyReviewed int
 }
 type ReviewPlatform = string
 
@@ -188,8 +338,51 @@ type ContributorsData struct {
 // for the Vulnerabilities check.
 type VulnerabilitiesData struct {
 	Vulnerabilities []clients.Vulnerability
+	// DependencyAlerts holds the open dependency alerts of the code host,
+	// e.g., Dependabot alerts on GitHub.
+	DependencyAlerts       []clients.SecurityAlert
+	DependencyAlertsAccess AlertsAccess
+	// VEXStatements holds the statements of the VEX documents
+	// published in the repository.
+	VEXStatements []VEXStatement
+}
+
+// VEXStatus is the status of a vulnerability in a VEX statement.
+type VEXStatus string
+
+const (
+	// VEXStatusNotAffected means the project is not affected by the vulnerability.
+	VEXStatusNotAffected VEXStatus = "not_affected"
+	// VEXStatusAffected means the project is affected by the vulnerability.
+	VEXStatusAffected VEXStatus = "affected"
+	// VEXStatusFixed means the project contains a fix for the vulnerability.
+	VEXStatusFixed VEXStatus = "fixed"
+	// VEXStatusUnderInvestigation means the project does not know yet if it is affected.
+	VEXStatusUnderInvestigation VEXStatus = "under_investigation"
+)
+
+// VEXStatement is a statement about a vulnerability from a VEX document.
+type VEXStatement struct {
+	Vulnerability string
+	Status        VEXStatus
+	Justification string
+	Aliases       []string
+	File          File
 }
 
+// AlertsAccess describes whether the security alerts
+// of the code host could be read.
+type AlertsAccess string
+
+const (
+	// AlertsUnsupported is used when the code host has no such alerts.
+	AlertsUnsupported AlertsAccess = ""
+	// AlertsAvailable is used when the alerts were read.
+	AlertsAvailable AlertsAccess = "available"
+	// AlertsPermissionDenied is used when the token does not permit reading the alerts.
+	AlertsPermissionDenied AlertsAccess = "permission-denied"
+)
+
 type SecurityPolicyInformationType string
 
 const (
@@ -220,7 +413,21 @@ type SecurityPolicyFile struct {
 // SecurityPolicyData contains the raw results
 // for the Security-Policy check.
 type SecurityPolicyData struct {
+	// PolicyURL is the URL of the security policy reported by the hosting
+	// platform, only set when no policy file was found in the repo or its org.
+	PolicyURL   string
 	PolicyFiles []SecurityPolicyFile
+	// SecurityTxt is the security.txt file of the repo, if any. It does not
+	// count as a security policy, but lists the contacts of the project.
+	SecurityTxt *SecurityTxtFile
+}
+
+// SecurityTxtFile is a security.txt file (RFC 9116) committed to the repo.
+type SecurityTxtFile struct {
+	// Contacts are the values of the Contact fields of the file, e.g.
+	// mailto: or https: URIs, in the order of preference of the file.
+	Contacts []string
+	File     File
 }
 
 // BinaryArtifactData contains the raw results
@@ -228,12 +435,67 @@ type SecurityPolicyData struct {
 type BinaryArtifactData struct {
 	// Files contains a list of files.
 	Files []File
+	// LFSFiles contains the binaries stored in Git LFS, detected by their
+	// name since only their pointer files are available. Their FileSize is
+	// the size of the stored file.
+	LFSFiles []File
+	// ReleaseFiles contains the binaries found in the archives published
+	// with the latest release, with paths of the form <archive>/<path>.
+	ReleaseFiles []File
+	// VerifiedWrappers contains the gradle-wrapper.jar and maven-wrapper.jar
+	// files matching the checksums of the official distributions.
+	VerifiedWrappers []File
 }
 
 // SignedReleasesData contains the raw results
 // for the Signed-Releases check.
 type SignedReleasesData struct {
 	Releases []clients.Release
+	// Checksums contains the results of verifying the assets of the
+	// releases against the checksum files published with them.
+	Checksums []ReleaseChecksum
+	// Provenance contains the content of the SLSA provenance files
+	// published with the releases.
+	Provenance []ReleaseProvenance
+}
+
+// ReleaseProvenance is the content of a SLSA provenance file of a release.
+type ReleaseProvenance struct {
+	// Tag is the tag of the release.
+	Tag string
+	// File is the name of the provenance file.
+	File string
+	// BuilderID is the identity of the builder which generated the provenance.
+	BuilderID string
+	// SourceURI is the source the artifacts were built from,
+	// e.g. git+https://github.com/owner/repo@refs/tags/v1.0.0.
+	SourceURI string
+	// RepoMatches is whether the artifacts were built from the scanned repo.
+	RepoMatches bool
+	// RefMatches is whether the artifacts were built from the tag of the release.
+	RefMatches bool
+	// Subjects are the subjects of the provenance which are assets of the release.
+	Subjects []ProvenanceSubject
+}
+
+// ProvenanceSubject is an artifact described by a provenance file.
+type ProvenanceSubject struct {
+	Name string
+	// Verified is whether the digest in the provenance matches the released asset.
+	Verified bool
+}
+
+// ReleaseChecksum is the result of verifying an asset of a release against
+// its entry in a checksum file of the release, e.g. SHA256SUMS.
+type ReleaseChecksum struct {
+	// Tag is the tag of the release.
+	Tag string
+	// File is the name of the checksum file.
+	File string
+	// Asset is the name of the asset.
+	Asset string
+	// Verified is whether the content of the asset matches its checksum.
+	Verified bool
 }
 
 // DependencyUpdateToolData contains the raw results
@@ -242,6 +504,19 @@ type DependencyUpdateToolData struct {
 	// Tools contains a list of tools.
 	// Note: we only populate one entry at most.
 	Tools []Tool
+	// Manifests are the dependency manifests found in the repo, one per ecosystem
+	// and directory, and whether the configuration of the tool covers them.
+	// It is empty when the coverage of the tool cannot be determined.
+	Manifests []DependencyManifest
+}
+
+// DependencyManifest is a dependency manifest of an ecosystem, e.g. a go.mod file.
+type DependencyManifest struct {
+	// Ecosystem is the Dependabot name of the ecosystem, e.g. "gomod" or "github-actions".
+	Ecosystem string
+	File      File
+	// Covered is true if the dependency update tool is configured to update the manifest.
+	Covered bool
 }
 
 // WebhooksData contains the raw results
@@ -250,11 +525,83 @@ type WebhooksData struct {
 	Webhooks []clients.Webhook
 }
 
+// ActionsPolicyData contains the raw results
+// for the Actions-Policy check.
+type ActionsPolicyData struct {
+	// Policy is nil if the policy could not be retrieved.
+	Policy *clients.ActionsPolicy
+}
+
+// DeploymentProtectionData contains the raw results
+// for the Deployment-Protection check.
+type DeploymentProtectionData struct {
+	Deployments []Deployment
+	// Environments is nil if the environments could not be retrieved.
+	Environments []clients.Environment
+}
+
+// Deployment represents a workflow job which deploys to an environment.
+type Deployment struct {
+	Job         *WorkflowJob
+	Environment string
+	File        File
+}
+
+// SelfHostedRunnersData contains the raw results
+// for the Self-Hosted-Runners check.
+type SelfHostedRunnersData struct {
+	Jobs []RunnerJob
+	// Private is false if the visibility of the repo could not be determined.
+	Private bool
+}
+
+// RunnerJob represents a workflow job which does not run on a standard GitHub-hosted runner.
+type RunnerJob struct {
+	Job    *WorkflowJob
+	Labels []string
+	// ForkTriggers are the events of the workflow through which
+	// pull requests from forks can run the job.
+	ForkTriggers []string
+	File         File
+	// SelfHosted is true if the job requests the "self-hosted" label. Otherwise
+	// the job runs on a custom label, which may be a self-hosted runner or
+	// a larger GitHub-hosted runner.
+	SelfHosted bool
+}
+
 // BranchProtectionsData contains the raw results
 // for the Branch-Protection check.
 type BranchProtectionsData struct {
+	// AuditLog holds the changes and overrides of branch protections, if
+	// the audit log of the organization is available.
+	AuditLog        *AuditLogData
 	Branches        []clients.BranchRef
 	CodeownersFiles []string
+	// ReleaseBranches are the branches named like release branches, e.g.
+	// release/v1 or 2.x, nil if the branches could not be listed.
+	ReleaseBranches []clients.BranchInfo
+	// DeployBranches are the branches whose pushes run workflows deploying
+	// to an environment.
+	DeployBranches []DeployBranch
+	// ForcePushes are the recent force pushes to the protected branches, nil
+	// if the activity of the repo is not available.
+	ForcePushes []clients.ForcePush
+}
+
+// DeployBranch is a branch whose pushes run a workflow deploying to an
+// environment.
+type DeployBranch struct {
+	Name string
+	// File is the workflow triggered by the pushes.
+	File File
+	// Protected is nil if the protection of the branch is unknown.
+	Protected *bool
+}
+
+// AuditLogData holds the events of the audit log of the organization owning
+// the repo which are relevant to a check.
+type AuditLogData struct {
+	Events []clients.AuditLogEvent
 }
 
 // Tool represents a tool.
@@ -275,7 +622,13 @@ type Tool struct {
 
 // Run represents a run.
 type Run struct {
-	URL string
+	CreatedAt time.Time
+	URL       string
+	// HeadSHA is the commit the run built.
+	HeadSHA string
+	// Version is the release tag the run built, empty if the run was
+	// not triggered for a tag of a published release.
+	Version string
 }
 
 // ArchivedStatus definess the archived status.
@@ -284,6 +637,59 @@ type ArchivedStatus struct {
 	// TODO: add fields, e.g., date of archival.
 }
 
+// HiddenCharactersData contains the raw results
+// for the Hidden-Characters check.
+type HiddenCharactersData struct {
+	Characters []HiddenCharacter
+}
+
+// HiddenCharacterType is the type of a hidden character.
+type HiddenCharacterType string
+
+const (
+	// HiddenCharacterBidi is a bidirectional control character, which
+	// reorders how the source code around it is displayed.
+	HiddenCharacterBidi HiddenCharacterType = "bidi"
+	// HiddenCharacterInvisible is a character which is not displayed, such
+	// as a zero width space.
+	HiddenCharacterInvisible HiddenCharacterType = "invisible"
+	// HiddenCharacterHomoglyph is a Cyrillic or Greek letter in an identifier
+	// which also contains Latin letters, which it may look alike.
+	HiddenCharacterHomoglyph HiddenCharacterType = "homoglyph"
+)
+
+// HiddenCharacter is a character of a source file which is displayed
+// differently from how it is compiled or interpreted.
+type HiddenCharacter struct {
+	Type HiddenCharacterType
+	// File.Offset is the line of the character, and File.Snippet the
+	// surrounding code with the hidden characters escaped.
+	File File
+	// Column is the column of the character in the line, counted in
+	// characters from 1.
+	Column    uint
+	CodePoint rune
+}
+
+// CompromisedDependenciesData contains the raw results
+// for the Compromised-Dependencies check.
+type CompromisedDependenciesData struct {
+	Dependencies []CompromisedDependency
+	// Entries is the number of entries of the denylist the dependencies were
+	// checked against, 0 if no denylist is configured.
+	Entries int
+}
+
+// CompromisedDependency is an action used by a workflow, or a package locked
+// by a lockfile, which matches an entry of the denylist.
+type CompromisedDependency struct {
+	Entry *DenylistEntry
+	// File.Offset is the line the dependency is used or locked at, if known.
+	File    File
+	Name    string
+	Version string
+}
+
 // File represents a file.
 type File struct {
 	Path      string
@@ -308,21 +714,41 @@ const (
 	DangerousWorkflowScriptInjection DangerousWorkflowType = "scriptInjection"
 	// DangerousWorkflowUntrustedCheckout represents an untrusted checkout.
 	DangerousWorkflowUntrustedCheckout DangerousWorkflowType = "untrustedCheckout"
+	// DangerousWorkflowCustomRule represents a violation of a custom rule.
+	DangerousWorkflowCustomRule DangerousWorkflowType = "customRule"
 )
 
 // DangerousWorkflowData contains raw results
 // for dangerous workflow check.
 type DangerousWorkflowData struct {
 	Workflows []DangerousWorkflow
+	// Suppressed are the dangerous patterns suppressed by a
+	// `scorecard:ignore` comment, which are not scored.
+	Suppressed []Suppression
 }
 
 // DangerousWorkflow represents a dangerous workflow.
+// Rule is set for violations of custom rules.
 type DangerousWorkflow struct {
 	Job  *WorkflowJob
+	Rule *WorkflowRule
 	Type DangerousWorkflowType
 	File File
 }
 
+// WorkflowRule is an organization-specific dangerous pattern in GitHub
+// workflows, checked by Dangerous-Workflow. Exactly one of the patterns is set.
+type WorkflowRule struct {
+	// Uses matches the actions and reusable workflows that must not be used.
+	Uses *regexp.Regexp
+	// Run matches the scripts that must not be run.
+	Run *regexp.Regexp
+	// RequireUses matches an action every job with steps must use.
+	RequireUses *regexp.Regexp
+	ID          string
+	Description string
+}
+
 // WorkflowJob reprresents a workflow job.
 type WorkflowJob struct {
 	Name *string
@@ -362,6 +788,7 @@ const (
 )
 
 // TokenPermission defines a token permission result.
+// Patch, if set, is a suggested minimal `permissions:` block in YAML.
 type TokenPermission struct {
 	Job          *WorkflowJob
 	LocationType *PermissionLocation
@@ -369,5 +796,6 @@ type TokenPermission struct {
 	Value        *string
 	File         *File
 	Msg          *string
+	Patch        *string
 	Type         PermissionLevel
 }
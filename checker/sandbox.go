@@ -0,0 +1,60 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"os"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// EnvVarExperimental is the environment variable enabling experimental checks.
+const EnvVarExperimental = "SCORECARD_EXPERIMENTAL"
+
+// IsExperimentalEnabled returns true if experimental checks were enabled via
+// the SCORECARD_EXPERIMENTAL environment variable. Checks must use it rather
+// than reading the environment, see checks/sandbox_test.go.
+func IsExperimentalEnabled() bool {
+	_, enabled := os.LookupEnv(EnvVarExperimental)
+	return enabled
+}
+
+// sandboxedRepoClient exposes nothing but the clients.RepoClient interface of
+// the client it wraps, so checks cannot type-assert their way to the
+// authenticated HTTP and API clients backing it.
+type sandboxedRepoClient struct {
+	clients.RepoClient
+}
+
+func sandboxRepoClient(c clients.RepoClient) clients.RepoClient {
+	switch c.(type) {
+	case nil, sandboxedRepoClient:
+		return c
+	default:
+		return sandboxedRepoClient{RepoClient: c}
+	}
+}
+
+// sandbox restricts the clients of a request to their interfaces before it is
+// handed to a check.
+func sandbox(req *CheckRequest) {
+	req.RepoClient = sandboxRepoClient(req.RepoClient)
+	req.OssFuzzRepo = sandboxRepoClient(req.OssFuzzRepo)
+	if newOrgRepoClient := req.NewOrgRepoClient; newOrgRepoClient != nil {
+		req.NewOrgRepoClient = func() clients.RepoClient {
+			return sandboxRepoClient(newOrgRepoClient())
+		}
+	}
+}
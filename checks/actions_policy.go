@@ -0,0 +1,111 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package checks
+
+import (
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/evaluation"
+	"github.com/ossf/scorecard/v4/checks/raw"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// CheckActionsPolicy is the registered name for ActionsPolicy.
+const CheckActionsPolicy = "Actions-Policy"
+
+// actionsPolicyMetadata documents the Actions-Policy check.
+var actionsPolicyMetadata = checker.CheckMetadata{
+	Risk:  "Medium",
+	Short: "Determines if the project restricts which GitHub Actions can run and who can trigger workflow runs.",
+	Description: "Risk: `Medium` (compromised or malicious third-party actions)\n" +
+		"\n" +
+		"This check determines whether the project's GitHub Actions settings restrict\n" +
+		"the actions allowed to run in workflows, and whether workflow runs triggered\n" +
+		"by pull requests from outside collaborators require approval.\n" +
+		"\n" +
+		"Reading these settings requires a token with admin access to the repository.\n" +
+		"If the settings cannot be read, the check is inconclusive.\n" +
+		"\n" +
+		"Projects which have GitHub Actions disabled receive the highest score.\n" +
+		"Otherwise, up to 7 points are awarded for the allowed actions setting:\n" +
+		"allowing only actions from the repository or its organization (7),\n" +
+		"allowing only actions from GitHub and an allow-list (6), or additionally\n" +
+		"allowing actions from verified creators (4). Allowing all actions receives\n" +
+		"no points.\n" +
+		"Up to 3 points are awarded for requiring approval of workflow runs from\n" +
+		"pull requests of: all outside collaborators (3), first-time contributors (2),\n" +
+		"or first-time contributors who are new to GitHub (1).\n" +
+		"\n" +
+		"Note: this is an experimental check which requires `SCORECARD_EXPERIMENTAL`\n" +
+		"to be set.\n",
+	Tags:  []string{"supply-chain", "security", "infrastructure"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.GetActionsPolicy",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "GitHub Actions are disabled"},
+		{Score: "+7", Criteria: "only actions of the repository or its organization are allowed"},
+		{Score: "+6", Criteria: "only actions of GitHub and of an allow-list are allowed"},
+		{Score: "+4", Criteria: "actions of verified creators are allowed as well"},
+		{Score: "+3", Criteria: "workflow runs from pull requests of all outside collaborators require approval"},
+		{Score: "+2", Criteria: "workflow runs from pull requests of first-time contributors require approval"},
+		{Score: "+1", Criteria: "workflow runs from pull requests of first-time contributors who are new to GitHub require " +
+			"approval"},
+		{Score: "inconclusive", Criteria: "the settings cannot be read, e.g. without admin access"},
+	},
+	Remediation: []string{
+		"Restrict the actions allowed to run in the repository or organization settings, see " +
+			"[Managing GitHub Actions settings for a " +
+			"repository](https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/enabling-features-for-your-repository/managing-github-actions-settings-for-a-repository#allowing-select-actions-and-reusable-workflows-to-run).",
+		"Require approval for workflow runs from all outside collaborators, see [Controlling " +
+			"changes from forks to workflows in public " +
+			"repositories](https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/enabling-features-for-your-repository/managing-github-actions-settings-for-a-repository#controlling-changes-from-forks-to-workflows-in-public-repositories).",
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	if err := registerCheck(CheckActionsPolicy, ActionsPolicy, nil, actionsPolicyMetadata); err != nil {
+		// this should never happen
+		panic(err)
+	}
+}
+
+// ActionsPolicy runs the Actions-Policy check.
+func ActionsPolicy(c *checker.CheckRequest) checker.CheckResult {
+	// TODO: remove this check when v6 is released
+	if !checker.IsExperimentalEnabled() {
+		c.Dlogger.Warn(&checker.LogMessage{
+			Text: "SCORECARD_EXPERIMENTAL is not set, not running the Actions-Policy check",
+		})
+
+		e := sce.WithMessage(sce.ErrorUnsupportedCheck,
+			"SCORECARD_EXPERIMENTAL is not set, not running the Actions-Policy check")
+		return checker.CreateRuntimeErrorResult(CheckActionsPolicy, e)
+	}
+
+	rawData, err := raw.ActionsPolicy(c)
+	if err != nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		return checker.CreateRuntimeErrorResult(CheckActionsPolicy, e)
+	}
+
+	// Set the raw results.
+	if c.RawResults != nil {
+		c.RawResults.ActionsPolicyResults = rawData
+	}
+
+	// Return the score evaluation.
+	return evaluation.ActionsPolicy(CheckActionsPolicy, c.Dlogger, &rawData)
+}
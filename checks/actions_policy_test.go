@@ -0,0 +1,115 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestActionsPolicy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err      error
+		policy   *clients.ActionsPolicy
+		name     string
+		expected checker.CheckResult
+		wantErr  bool
+	}{
+		{
+			name: "Actions disabled",
+			policy: &clients.ActionsPolicy{
+				Enabled: false,
+			},
+			expected: checker.CheckResult{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name: "All actions allowed, no approval setting",
+			policy: &clients.ActionsPolicy{
+				Enabled:        true,
+				AllowedActions: clients.AllowedActionsAll,
+			},
+			expected: checker.CheckResult{
+				Score: checker.MinResultScore,
+			},
+		},
+		{
+			name: "Local actions only, approval for all outside collaborators",
+			policy: &clients.ActionsPolicy{
+				Enabled:        true,
+				AllowedActions: clients.AllowedActionsLocalOnly,
+				ForkPRApproval: clients.ForkPRApprovalAll,
+			},
+			expected: checker.CheckResult{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name: "Missing permissions",
+			err:  fmt.Errorf("%w: repos/owner/repo/actions/permissions", clients.ErrPermissionDenied),
+			expected: checker.CheckResult{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name:    "Client error",
+			err:     errors.New("some error"), //nolint:goerr113
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			os.Setenv("SCORECARD_EXPERIMENTAL", "true")
+			ctrl := gomock.NewController(t)
+			mockRepo := mockrepo.NewMockRepoClient(ctrl)
+			mockRepo.EXPECT().GetActionsPolicy().Return(tt.policy, tt.err).MaxTimes(1)
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				RepoClient: mockRepo,
+				Ctx:        context.TODO(),
+				Dlogger:    &dl,
+			}
+			res := ActionsPolicy(&req)
+			if tt.wantErr {
+				if res.Error == nil {
+					t.Errorf("Expected error %v, got nil", tt.err)
+				}
+				// return as we don't need to check the rest of the fields.
+				return
+			}
+
+			if res.Score != tt.expected.Score {
+				t.Errorf("Expected score %d, got %d for %v", tt.expected.Score, res.Score, tt.name)
+			}
+			ctrl.Finish()
+		})
+	}
+}
@@ -16,8 +16,6 @@
 package checks
 
 import (
-	"os"
-
 	"github.com/ossf/scorecard/v4/checker"
 )
 
@@ -35,9 +33,14 @@ func getAll(overrideExperimental bool) checker.CheckNameToFnMap {
 		return possibleChecks
 	}
 
-	if _, experimental := os.LookupEnv("SCORECARD_EXPERIMENTAL"); !experimental {
+	if !checker.IsExperimentalEnabled() {
 		// TODO: remove this check when v6 is released
 		delete(possibleChecks, CheckWebHooks)
+		delete(possibleChecks, CheckActionsPolicy)
+		delete(possibleChecks, CheckDeploymentProtection)
+		delete(possibleChecks, CheckSelfHostedRunners)
+		delete(possibleChecks, CheckHiddenCharacters)
+		delete(possibleChecks, CheckCompromisedDependencies)
 	}
 
 	return possibleChecks
@@ -54,7 +57,9 @@ func GetAllWithExperimental() checker.CheckNameToFnMap {
 	return getAll(true /*overrideExperimental*/)
 }
 
-func registerCheck(name string, fn checker.CheckFn, supportedRequestTypes []checker.RequestType) error {
+func registerCheck(name string, fn checker.CheckFn, supportedRequestTypes []checker.RequestType,
+	metadata checker.CheckMetadata,
+) error {
 	if name == "" {
 		return errInternalNameCannotBeEmpty
 	}
@@ -64,6 +69,7 @@ func registerCheck(name string, fn checker.CheckFn, supportedRequestTypes []chec
 	allChecks[name] = checker.Check{
 		Fn:                    fn,
 		SupportedRequestTypes: supportedRequestTypes,
+		Metadata:              metadata,
 	}
 	return nil
 }
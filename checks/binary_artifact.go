@@ -24,12 +24,96 @@ import (
 // CheckBinaryArtifacts is the exported name for Binary-Artifacts check.
 const CheckBinaryArtifacts string = "Binary-Artifacts"
 
+// binaryArtifactsMetadata documents the Binary-Artifacts check.
+var binaryArtifactsMetadata = checker.CheckMetadata{
+	Risk:  "High",
+	Short: "Determines if the project has generated executable (binary) artifacts in the source repository.",
+	Description: "Risk: `High` (non-reviewable code)\n" +
+		"\n" +
+		"This check determines whether the project has generated executable (binary)\n" +
+		"artifacts in the source repository.\n" +
+		"\n" +
+		"Including generated executables in the source repository increases user risk.\n" +
+		"Many programming language systems can generate executables from source code\n" +
+		"(e.g., C/C++ generated machine code, Java `.class` files, Python `.pyc` files,\n" +
+		"and minified JavaScript). Users will often directly use executables if they are\n" +
+		"included in the source repository, leading to many dangerous behaviors.\n" +
+		"\n" +
+		"Problems with generated executable (binary) artifacts:\n" +
+		"\n" +
+		"  - Binary artifacts cannot be reviewed, allowing possible obsolete or\n" +
+		"    maliciously subverted executables. Reviews generally review source code, not\n" +
+		"    executables, since it's difficult to audit executables to ensure that they\n" +
+		"    correspond to the source code. Over time the included executables might not\n" +
+		"    correspond to the source code.\n" +
+		"  - Generated executables allow the executable generation process to atrophy,\n" +
+		"    which can lead to an inability to create working executables. These problems\n" +
+		"    can be countered with verified reproducible builds, but it's easier to\n" +
+		"    implement verified reproducible builds when executables are not included in\n" +
+		"    the source repository (since the executable generation process is less\n" +
+		"    likely to have atrophied).\n" +
+		"\n" +
+		"Allowed by Scorecard:\n" +
+		"\n" +
+		"  - Files in the source repository that are simultaneously reviewable source\n" +
+		"    code and executables, since these are reviewable. (Some interpretive\n" +
+		"    systems, such as many operating system shells, don't have a mechanism for\n" +
+		"    storing generated executables that are different from the source file.)\n" +
+		"  - Source code in the source repository generated by other tools (e.g., by\n" +
+		"    bison, yacc, flex, and lex). There are potential downsides to generated\n" +
+		"    source code, but generated source code tends to be much easier to review and\n" +
+		"    thus presents a lower risk. Generated source code is also often difficult\n" +
+		"    for external tools to detect.\n" +
+		"  - Generated documentation in source repositories. Generated documentation is\n" +
+		"    intended for use by humans (not computers) who can evaluate the context.\n" +
+		"    Thus, generated documentation doesn't pose the same level of risk.\n" +
+		"  - `gradle-wrapper.jar` and `maven-wrapper.jar` files whose SHA-256 (Gradle)\n" +
+		"    or SHA-1 (Maven) checksum matches the official distribution of the\n" +
+		"    version declared in the adjacent `gradle-wrapper.properties`\n" +
+		"    (`distributionUrl`) or `maven-wrapper.properties` (`wrapperUrl`, which\n" +
+		"    must point to Maven Central) file. Gradle wrappers validated by the\n" +
+		"    " +
+		"[gradle/wrapper-validation-action](https://github.com/gradle/wrapper-validation-action)\n" +
+		"    on the latest commit are allowed as well.\n" +
+		"\n" +
+		"On GitHub, the check also lists the files in the `.tar.gz`, `.tgz` and `.zip`\n" +
+		"assets of the latest release and reports binaries found inside them. These\n" +
+		"findings are informational and do not affect the score. Assets larger than\n" +
+		"16 MiB are skipped.\n" +
+		"\n" +
+		"Binaries stored in [Git LFS](https://git-lfs.com) are committed as small text\n" +
+		"pointer files, so they are detected by their extension (e.g. `.jar` or `.exe`)\n" +
+		"and reported as LFS-stored binaries, which lower the score like other binaries.\n" +
+		"When scanning a local checkout, the content of the files fetched into its Git\n" +
+		"LFS storage is checked instead, up to 64 MiB per file.\n",
+	Tags:  []string{"supply-chain", "security", "dependencies"},
+	Repos: []string{"GitHub", "local"},
+	Inputs: []string{
+		"CheckRequest.FetchChecksum",
+		"RepoClient.GetFileContent",
+		"RepoClient.GetReleaseAsset",
+		"RepoClient.ListCommits",
+		"RepoClient.ListFiles",
+		"RepoClient.ListReleases",
+		"RepoClient.ListSuccessfulWorkflowRuns",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "no binary artifacts are found in the repository"},
+		{Score: "-1", Criteria: "for each binary artifact, including the ones stored in Git LFS"},
+	},
+	Remediation: []string{
+		"Remove the generated executable artifacts from the repository.",
+		"Build from source.",
+	},
+}
+
 //nolint
 func init() {
 	supportedRequestTypes := []checker.RequestType{
+		checker.FileBased,
 		checker.CommitBased,
 	}
-	if err := registerCheck(CheckBinaryArtifacts, BinaryArtifacts, supportedRequestTypes); err != nil {
+	if err := registerCheck(CheckBinaryArtifacts, BinaryArtifacts, supportedRequestTypes, binaryArtifactsMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
@@ -37,7 +121,7 @@ func init() {
 
 // BinaryArtifacts  will check the repository contains binary artifacts.
 func BinaryArtifacts(c *checker.CheckRequest) checker.CheckResult {
-	rawData, err := raw.BinaryArtifacts(c.RepoClient)
+	rawData, err := raw.BinaryArtifacts(c)
 	if err != nil {
 		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
 		return checker.CreateRuntimeErrorResult(CheckBinaryArtifacts, e)
@@ -24,9 +24,131 @@ import (
 // CheckBranchProtection is the exported name for Branch-Protected check.
 const CheckBranchProtection = "Branch-Protection"
 
+// branchProtectionMetadata documents the Branch-Protection check.
+var branchProtectionMetadata = checker.CheckMetadata{
+	Risk: "High",
+	Short: "Determines if the default and release branches are protected with GitHub's branch protection " +
+		"settings.",
+	Description: "Risk: `High` (vulnerable to intentional malicious code injection)\n" +
+		"\n" +
+		"This check determines whether a project's default and release branches are\n" +
+		"protected with GitHub's [branch " +
+		"protection](https://docs.github.com/en/github/administering-a-repository/defining-the-mergeability-of-pull-requests/about-protected-branches) settings.\n" +
+		"Branch protection allows maintainers to define rules that enforce\n" +
+		"certain workflows for branches, such as requiring review or passing certain\n" +
+		"status checks before acceptance into a main branch, or preventing rewriting of\n" +
+		"public history.\n" +
+		"\n" +
+		"Rules from [repository " +
+		"rulesets](https://docs.github.com/en/repositories/configuring-branches-and-merges-in-your-repository/managing-rulesets/about-rulesets)\n" +
+		"that target a branch are combined with its branch protection settings.\n" +
+		"\n" +
+		"Note: The following settings queried by the Branch-Protection check require an admin " +
+		"token: `DismissStaleReviews`, `EnforceAdmin` and `StrictStatusCheck`. If\n" +
+		"the provided token does not have admin access, the check will query the branch\n" +
+		"settings accessible to non-admins and provide results based only on these settings.\n" +
+		"Even so, we recommend using a non-admin token, which provides a thorough enough\n" +
+		"result to meet most user needs.\n" +
+		"\n" +
+		"Different types of branch protection protect against different risks:\n" +
+		"\n" +
+		"  - Require code review: requires at least one reviewer, which greatly\n" +
+		"    reduces the risk that a compromised contributor can inject malicious code.\n" +
+		"    Review also increases the likelihood that an unintentional vulnerability in\n" +
+		"    a contribution will be detected and fixed before the change is accepted.\n" +
+		"\n" +
+		"  - Prevent force push: prevents use of the `--force` command on public\n" +
+		"    branches, which overwrites code irrevocably. This protection prevents the\n" +
+		"    rewriting of public history without external notice.\n" +
+		"\n" +
+		"  - Require [status " +
+		"checks](https://docs.github.com/en/github/collaborating-with-pull-requests/collaborating-on-repositories-with-code-quality-features/about-status-checks):\n" +
+		"    ensures that all required CI tests are met before a change is accepted.\n" +
+		"\n" +
+		"Although requiring code review can greatly reduce the chance that\n" +
+		"unintentional or malicious code enters the \"main\" branch, it is not feasible for\n" +
+		"all projects, such as those that don't have many active participants. For more\n" +
+		"discussion, see [Code " +
+		"Reviews](https://github.com/ossf/scorecard/blob/main/docs/checks.md#code-reviews).\n" +
+		"\n" +
+		"Additionally, in some cases these rules will need to be suspended. For example,\n" +
+		"if a past commit includes illegal content such as child pornography, it may be\n" +
+		"necessary to use a force push to rewrite the history rather than simply hide the\n" +
+		"commit.\n" +
+		"\n" +
+		"This test has tiered scoring. Each tier must be fully satisfied to achieve points at the " +
+		"next tier. For example, if you fulfill the Tier 3 checks but do not fulfill all the Tier " +
+		"2 checks, you will not receive any points for Tier 3.\n" +
+		"\n" +
+		"Note: If Scorecard is run without an administrative access token, the requirements that " +
+		"specify \u201cFor administrators\u201d are ignored.\n" +
+		"\n" +
+		"Tier 1 Requirements (3/10 points):\n" +
+		"  - Prevent force push\n" +
+		"  - Prevent branch deletion\n" +
+		"  - For administrators: Include administrator for review\n" +
+		"\n" +
+		"Tier 2 Requirements (6/10 points):\n" +
+		"  - Required reviewers >=1\n" +
+		"  - For administrators: Last push review\n" +
+		"  - For administrators: Strict status checks (require branches to be up-to-date before " +
+		"merging), or a required merge queue\n" +
+		"\n" +
+		"Tier 3 Requirements (8/10 points):\n" +
+		"  - Status checks defined\n" +
+		"\n" +
+		"Tier 4 Requirements (9/10 points):\n" +
+		"  - Required reviewers >= 2\n" +
+		"\n" +
+		"Tier 5 Requirements (10/10 points):\n" +
+		"  - For administrators: Dismiss stale reviews\n" +
+		"  - Require CODEOWNER review, with a CODEOWNERS file in the repository\n" +
+		"\n" +
+		"On GitHub Enterprise, with `SCORECARD_GITHUB_AUDIT_LOG` set and a token of an\n" +
+		"owner of the organization, the check also reads the audit log of the\n" +
+		"organization, since the settings read now may not have been in effect all\n" +
+		"along. Each deletion of a branch protection rule or ruleset covering the\n" +
+		"branches, and each push of an administrator bypassing their protection, e.g.\n" +
+		"a force push, in the last 90 days lowers the score by one point. Other\n" +
+		"changes of the rules are listed in the details.\n" +
+		"\n" +
+		"The details also list the release branches which are 100 commits or more\n" +
+		"behind the default branch without a commit in the last 180 days, and the\n" +
+		"branches which deploy to an environment on push without being protected,\n" +
+		"as well as the force pushes to the protected branches in the last 90 days\n" +
+		"listed by the activity of the repository. Those do not affect the score.\n",
+	Tags:  []string{"supply-chain", "security", "source-code", "code-reviews"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.GetBranch",
+		"RepoClient.GetDefaultBranch",
+		"RepoClient.GetFileContent",
+		"RepoClient.ListAuditLogEvents",
+		"RepoClient.ListBranches",
+		"RepoClient.ListFiles",
+		"RepoClient.ListForcePushes",
+		"RepoClient.ListReleases",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "3", Criteria: "tier 1: force pushes and deletions are prevented, and administrators are included"},
+		{Score: "6", Criteria: "tier 2: at least one reviewer, last push review and strict status checks or a merge queue"},
+		{Score: "8", Criteria: "tier 3: status checks are defined"},
+		{Score: "9", Criteria: "tier 4: at least two reviewers"},
+		{Score: "10", Criteria: "tier 5: stale reviews are dismissed and code owners review, with a CODEOWNERS file"},
+		{Score: "-1", Criteria: "for each deletion or bypass of the protection of the branches in the audit log of the " +
+			"last 90 days"},
+	},
+	Remediation: []string{
+		"Enable branch protection settings in your source hosting provider to avoid force pushes " +
+			"or deletion of your important branches.",
+		"For GitHub, check out the steps " +
+			"[here](https://docs.github.com/en/github/administering-a-repository/managing-a-branch-protection-rule).",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
-	if err := registerCheck(CheckBranchProtection, BranchProtection, nil); err != nil {
+	if err := registerCheck(CheckBranchProtection, BranchProtection, nil, branchProtectionMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
@@ -400,6 +400,8 @@ func TestReleaseAndDevBranchProtected(t *testing.T) {
 
 			ctrl := gomock.NewController(t)
 			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListAuditLogEvents().
+				Return(nil, clients.ErrUnsupportedFeature).AnyTimes()
 			mockRepoClient.EXPECT().GetDefaultBranch().
 				DoAndReturn(func() (*clients.BranchRef, error) {
 					defaultBranch := getBranch(tt.branches, tt.defaultBranch, tt.nonadmin)
@@ -415,11 +417,17 @@ func TestReleaseAndDevBranchProtected(t *testing.T) {
 					}
 					return ret, nil
 				}).AnyTimes()
-			mockRepoClient.EXPECT().GetBranch(gomock.Any()).
-				DoAndReturn(func(b string) (*clients.BranchRef, error) {
-					return getBranch(tt.branches, b, tt.nonadmin), nil
+			mockRepoClient.EXPECT().GetBranches(gomock.Any()).
+				DoAndReturn(func(branches []string) ([]*clients.BranchRef, error) {
+					refs := make([]*clients.BranchRef, len(branches))
+					for i, b := range branches {
+						refs[i] = getBranch(tt.branches, b, tt.nonadmin)
+					}
+					return refs, nil
 				}).AnyTimes()
 			mockRepoClient.EXPECT().ListFiles(gomock.Any()).AnyTimes().Return(tt.repoFiles, nil)
+			mockRepoClient.EXPECT().ListBranches().AnyTimes().Return(nil, clients.ErrUnsupportedFeature)
+			mockRepoClient.EXPECT().ListForcePushes().AnyTimes().Return(nil, clients.ErrUnsupportedFeature)
 			dl := scut.TestDetailLogger{}
 			req := checker.CheckRequest{
 				Dlogger:    &dl,
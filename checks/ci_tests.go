@@ -24,12 +24,67 @@ import (
 // CheckCodeReview is the registered name for DoesCodeReview.
 const CheckCITests = "CI-Tests"
 
+// ciTestsMetadata documents the CI-Tests check.
+var ciTestsMetadata = checker.CheckMetadata{
+	Risk:  "Low",
+	Short: "Determines if the project runs tests before pull requests are merged.",
+	Description: "Risk: `Low` (possible unknown vulnerabilities)\n" +
+		"\n" +
+		"This check tries to determine if the project runs tests before pull requests are\n" +
+		"merged. It is currently limited to repositories hosted on GitHub, and does not\n" +
+		"support other source hosting repositories (i.e., Forges).\n" +
+		"\n" +
+		"Running tests helps developers catch mistakes early on, which can reduce the\n" +
+		"number of vulnerabilities that find their way into a project.\n" +
+		"\n" +
+		"The check works by looking for a set of CI-system names in GitHub `CheckRuns`\n" +
+		"and `Statuses` among the recent commits (~30). A CI-system is considered\n" +
+		"well-known if its name contains any of the following: appveyor, buildkite,\n" +
+		"circleci, e2e, github-actions, jenkins, mergeable, test, travis-ci, woodpecker.\n" +
+		"Statuses of Forgejo and Gitea Actions are recognized by the URL of their run.\n" +
+		"Jenkinsfiles, CircleCI, Travis CI, GitLab CI and Azure Pipelines configurations\n" +
+		"running tests are reported,\n" +
+		"but only the results of pull requests are scored.\n" +
+		"\n" +
+		"If the default branch requires a [merge " +
+		"queue](https://docs.github.com/en/repositories/configuring-branches-and-merges-in-your-repository/configuring-pull-request-merges/managing-a-merge-queue),\n" +
+		"the `CheckRuns` and `Statuses` of the merge group, which are reported on the\n" +
+		"merged commit, are also taken into account.\n" +
+		"\n" +
+		"Note: A project that fulfills this criterion with other tools may still receive\n" +
+		"a low score on this test. There are many ways to implement CI testing, and it is\n" +
+		"challenging for an automated tool like Scorecard to detect them all. A low score\n" +
+		"is therefore not a definitive indication that the project is at risk.\n" +
+		"\n" +
+		"If a project's system was not detected and you think it should be, please\n" +
+		"[open an issue in the scorecard " +
+		"project](https://github.com/ossf/scorecard/issues/new/choose).\n",
+	Tags:  []string{"supply-chain", "testing"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.GetDefaultBranch",
+		"RepoClient.ListCheckRunsForRef",
+		"RepoClient.ListCommits",
+		"RepoClient.ListStatuses",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "0-10", Criteria: "proportional to the recently merged pull requests on which a CI system ran"},
+		{Score: "inconclusive", Criteria: "no recent commit was merged with a pull request"},
+	},
+	Remediation: []string{
+		"Check-in scripts that run all the tests in your repository.",
+		"Integrate those scripts with a CI/CD platform that runs it on every pull request (e.g. if " +
+			"hosted on GitHub, [GitHub " +
+			"Actions](https://docs.github.com/en/actions/learn-github-actions/introduction-to-github-actions), [Prow](https://github.com/kubernetes/test-infra/tree/master/prow), etc).",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
 	supportedRequestTypes := []checker.RequestType{
 		checker.CommitBased,
 	}
-	if err := registerCheck(CheckCITests, CITests, supportedRequestTypes); err != nil {
+	if err := registerCheck(CheckCITests, CITests, supportedRequestTypes, ciTestsMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
@@ -24,9 +24,73 @@ import (
 // CheckCIIBestPractices is the registered name for CIIBestPractices.
 const CheckCIIBestPractices = "CII-Best-Practices"
 
+// ciiBestPracticesMetadata documents the CII-Best-Practices check.
+var ciiBestPracticesMetadata = checker.CheckMetadata{
+	Risk:  "Low",
+	Short: "Determines if the project has an OpenSSF (formerly CII) Best Practices Badge.",
+	Description: "Risk: `Low` (possibly not following security best practices)\n" +
+		"\n" +
+		"This check determines whether the project has earned an [OpenSSF (formerly CII) Best " +
+		"Practices Badge](https://bestpractices.coreinfrastructure.org/),\n" +
+		"which indicates that the project uses a set of security-focused best development " +
+		"practices for open\n" +
+		"source software. The check uses the URL for the Git repo and the OpenSSF Best Practices " +
+		"badge API.\n" +
+		"Badge levels are cached for 24 hours, so repeated scans do not query the API\n" +
+		"again. For runs without network access, set `SCORECARD_CII_SNAPSHOT` to a file\n" +
+		"holding one or more pages of the API's\n" +
+		"[projects list](https://www.bestpractices.dev/projects.json?page=1) to read the\n" +
+		"badges from it instead.\n" +
+		"\n" +
+		"The OpenSSF Best Practices badge has 3 tiers: passing, silver, and gold. We give\n" +
+		"full credit to projects that meet the [gold " +
+		"criteria](https://bestpractices.coreinfrastructure.org/criteria/2), which is a\n" +
+		"significant achievement for many projects. Lower scores represent a project that\n" +
+		"is at least working to achieve a badge, with increasingly more points awarded as\n" +
+		"more criteria are met.\n" +
+		"\n" +
+		"- [gold badge](https://bestpractices.coreinfrastructure.org/en/criteria/2): 10\n" +
+		"- [silver badge](https://bestpractices.coreinfrastructure.org/en/criteria/1): 7\n" +
+		"- [passing badge](https://bestpractices.coreinfrastructure.org/en/criteria/0): 5\n" +
+		"- in progress badge: 2\n" +
+		"\n" +
+		"To earn the passing badge, the project MUST:\n" +
+		"\n" +
+		"  - publish the process for reporting vulnerabilities on the project site\n" +
+		"  - provide a working build system that can automatically rebuild the software\n" +
+		"    from source code (where applicable)\n" +
+		"  - have a general policy that tests will be added to an automated test suite\n" +
+		"    when major new functionality is added\n" +
+		"  - meet various cryptography criteria where applicable\n" +
+		"  - have at least one primary developer who knows how to design secure software\n" +
+		"  - have at least one primary developer who knows of common kinds of errors\n" +
+		"    that lead to vulnerabilities in this kind of software (and at least one\n" +
+		"    method to counter or mitigate each of them)\n" +
+		"  - apply at least one static code analysis tool (beyond compiler warnings and\n" +
+		"    \"safe\" language modes) to any proposed major production release.\n" +
+		"\n" +
+		"Some of these criteria overlap with other Scorecard checks.\n",
+	Tags:  []string{"security-awareness", "security-training", "security"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"CIIBestPracticesClient.GetBadgeLevel",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "gold badge"},
+		{Score: "7", Criteria: "silver badge"},
+		{Score: "5", Criteria: "passing badge"},
+		{Score: "2", Criteria: "badge in progress"},
+		{Score: "0", Criteria: "no badge"},
+	},
+	Remediation: []string{
+		"Sign up for the [OpenSSF Best Practices " +
+			"program](https://bestpractices.coreinfrastructure.org/).",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
-	if err := registerCheck(CheckCIIBestPractices, CIIBestPractices, nil); err != nil {
+	if err := registerCheck(CheckCIIBestPractices, CIIBestPractices, nil, ciiBestPracticesMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
@@ -21,20 +21,119 @@ import (
 	sce "github.com/ossf/scorecard/v4/errors"
 )
 
-// CheckCodeReview is the registered name for DoesCodeReview.
-const CheckCodeReview = "Code-Review"
+const (
+	// CheckCodeReview is the registered name for DoesCodeReview.
+	CheckCodeReview = "Code-Review"
+	// ExperimentIndependentCodeReview is the registered name of the experiment
+	// scoring Code-Review by the share of independently reviewed changesets.
+	ExperimentIndependentCodeReview = "independent-code-review"
+)
+
+// codeReviewMetadata documents the Code-Review check.
+var codeReviewMetadata = checker.CheckMetadata{
+	Risk:  "High",
+	Short: "Determines if the project requires code review before pull requests (aka merge requests) are merged.",
+	Description: "Risk: `High` (unintentional vulnerabilities or possible injection of malicious\n" +
+		"code)\n" +
+		"\n" +
+		"This check determines whether the project requires code review before pull\n" +
+		"requests (merge requests) are merged.\n" +
+		"\n" +
+		"Reviews detect various unintentional problems, including vulnerabilities that\n" +
+		"can be fixed immediately before they are merged, which improves the quality of\n" +
+		"the code. Reviews may also detect or deter an attacker trying to insert\n" +
+		"malicious code (either as a malicious contributor or as an attacker who has\n" +
+		"subverted a contributor's account), because a reviewer might detect the\n" +
+		"subversion.\n" +
+		"\n" +
+		"The check determines whether the most recent changes (over the last ~30 commits) have \n" +
+		"an approval on GitHub\n" +
+		"or if the merger is different from the committer (implicit review). It also\n" +
+		"performs a similar check for reviews using\n" +
+		"[Prow](https://github.com/kubernetes/test-infra/tree/master/prow#readme) (labels\n" +
+		"\"lgtm\" or \"approved\") and [Gerrit](https://www.gerritcodereview.com/) (\"Reviewed-on\" and " +
+		"\"Reviewed-by\").\n" +
+		"If recent changes are solely bot activity (e.g. dependabot, renovatebot, or custom bots),\n" +
+		"the check returns inconclusively.\n" +
+		"\n" +
+		"Scoring is leveled instead of proportional to make the check more predictable.\n" +
+		"If any bot-originated changes are unreviewed, 3 points are deducted. If any human\n" +
+		"changes are unreviewed, 7 points are deducted if a single change is unreviewed, and\n" +
+		"another 3 are deducted if multiple changes are unreviewed.\n" +
+		"\n" +
+		"The raw results also break the GitHub changesets down by who approved them:\n" +
+		"the share that was self-merged by its author without any other approval, the\n" +
+		"share approved by a single maintainer (usually the one who merged it), and the\n" +
+		"share independently reviewed by at least two people other than the author.\n" +
+		"These sub-metrics do not affect the score, but let policies require\n" +
+		"independent review for critical projects.\n" +
+		"\n" +
+		"The details and raw results also list the commits made by editing files in\n" +
+		"the GitHub web interface without a pull request, and the commits whose author\n" +
+		"email is not associated with any GitHub account. Neither affects the score.\n" +
+		"\n" +
+		"Note: Requiring reviews for all changes is infeasible for some projects, such as\n" +
+		"those with only one active participant. Even a project with multiple active\n" +
+		"contributors may not have enough active participation to be able to require\n" +
+		"review of all proposed changes. Projects with a small number of active\n" +
+		"participants instead sometimes aim for a review of a\n" +
+		"percentage of proposals (e.g., \"at least half of all proposed changes are\n" +
+		"reviewed\").\n" +
+		"\n" +
+		"Requiring review does not eliminate all risks. The other reviewers might fail to\n" +
+		"notice unintentional vulnerabilities or malicious code, be colluding with a\n" +
+		"malicious developer, or even be the same person (using a \"[sock\n" +
+		"puppet](https://en.wikipedia.org/wiki/Sock_puppet_account)\" account).\n",
+	Tags:  []string{"supply-chain", "security", "source-code", "code-reviews"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.ListCommits",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "all recent changesets are reviewed"},
+		{Score: "7", Criteria: "all human changesets are reviewed, but some bot changesets are not"},
+		{Score: "3", Criteria: "a single human changeset is unreviewed"},
+		{Score: "0", Criteria: "several human changesets, or human and bot changesets, are unreviewed"},
+		{Score: "inconclusive", Criteria: "only bot changesets are reviewed"},
+	},
+	Remediation: []string{
+		"If the project has only one contributor, or does not have enough reviewers to practically " +
+			"require that all contributions be reviewed, try to recruit more maintainers to the " +
+			"project who will be willing to review others' work. Ideally at least some of these people " +
+			"will be from different organizations (see [Contributors](checks.md#contributors)). If the " +
+			"project has very limited utility, consider expanding its intended utility so more people " +
+			"will be interested in improving it, and make that larger scope clear to potential " +
+			"contributors.",
+		"Follow security best practices by performing strict code reviews for every new pull " +
+			"request / merge request.",
+		"Make \"code reviews\" mandatory in your repository configuration. ([Instructions for " +
+			"GitHub.](https://docs.github.com/en/github/administering-a-repository/about-protected-branches#require-pull-request-reviews-before-merging))",
+		"Enforce the rule for administrators / code owners as well. ([Instructions for " +
+			"GitHub.](https://docs.github.com/en/github/administering-a-repository/about-protected-branches#include-administrators))",
+	},
+}
 
 //nolint:gochecknoinits
 func init() {
 	supportedRequestTypes := []checker.RequestType{
 		checker.CommitBased,
 	}
-	if err := registerCheck(CheckCodeReview, CodeReview, supportedRequestTypes); err != nil {
+	if err := registerCheck(CheckCodeReview, CodeReview, supportedRequestTypes, codeReviewMetadata); err != nil {
+		// this should never happen
+		panic(err)
+	}
+	if err := registerExperiment(ExperimentIndependentCodeReview, CheckCodeReview,
+		"scores Code-Review by the share of changesets approved by two people other than their author",
+		independentCodeReview); err != nil {
 		// this should never happen
 		panic(err)
 	}
 }
 
+func independentCodeReview(name string, dl checker.DetailLogger, r *checker.RawResults) checker.CheckResult {
+	return evaluation.IndependentCodeReview(name, dl, &r.CodeReviewResults)
+}
+
 // CodeReview will check if the maintainers perform code review.
 func CodeReview(c *checker.CheckRequest) checker.CheckResult {
 	rawData, err := raw.CodeReview(c.RepoClient)
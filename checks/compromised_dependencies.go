@@ -0,0 +1,113 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/evaluation"
+	"github.com/ossf/scorecard/v4/checks/raw"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// CheckCompromisedDependencies is the registered name for CompromisedDependencies.
+const CheckCompromisedDependencies = "Compromised-Dependencies"
+
+// compromisedDependenciesMetadata documents the Compromised-Dependencies check.
+var compromisedDependenciesMetadata = checker.CheckMetadata{
+	Risk:  "Critical",
+	Short: "Determines if the project depends on actions or packages known to be malicious or compromised.",
+	Description: "Risk: `Critical` (malicious code run by the builds of the project or of its users)\n" +
+		"\n" +
+		"This check compares the dependencies of the project against a denylist of\n" +
+		"actions and packages known to be malicious, or versions of them known to be\n" +
+		"compromised, configured with `--denylist`. The denylist is a YAML or JSON feed\n" +
+		"read from a file or a URL, see the\n" +
+		"[README](https://github.com/ossf/scorecard#denylists-of-compromised-dependencies).\n" +
+		"\n" +
+		"The check looks at:\n" +
+		"- the actions and reusable workflows used by GitHub workflows, matched by\n" +
+		"  owner/repo and by tag or commit;\n" +
+		"- the packages locked by `package-lock.json`, `yarn.lock`, `poetry.lock`,\n" +
+		"  `requirements*.txt`, `go.sum`, `Cargo.lock` and `Gemfile.lock` files,\n" +
+		"  matched by name and version.\n" +
+		"\n" +
+		"Unlike Vulnerabilities, which reports the advisories of a vulnerability\n" +
+		"database, the denylist can list incidents as soon as they are known, e.g. a\n" +
+		"compromised action whose tags were moved to a malicious commit.\n" +
+		"\n" +
+		"The check is inconclusive if no denylist is configured.\n" +
+		"\n" +
+		"Note: this is an experimental check which requires `SCORECARD_EXPERIMENTAL`\n" +
+		"to be set.\n",
+	Tags:  []string{"supply-chain", "security", "dependencies"},
+	Repos: []string{"GitHub", "local"},
+	Inputs: []string{
+		"CheckRequest.Denylist",
+		"RepoClient.GetFileContent",
+		"RepoClient.ListFiles",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "no dependency matches an entry of the denylist"},
+		{Score: "0", Criteria: "a dependency matches an entry of the denylist"},
+		{Score: "inconclusive", Criteria: "no denylist is configured"},
+	},
+	Remediation: []string{
+		"Remove the reported dependencies, or update them to a version which is not compromised, and " +
+			"regenerate the lockfiles.",
+		"Rotate the secrets the builds using a compromised dependency had access to, and review the " +
+			"artifacts they published.",
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	supportedRequestTypes := []checker.RequestType{
+		checker.FileBased,
+		checker.CommitBased,
+	}
+	if err := registerCheck(CheckCompromisedDependencies, CompromisedDependencies, supportedRequestTypes,
+		compromisedDependenciesMetadata); err != nil {
+		// this should never happen
+		panic(err)
+	}
+}
+
+// CompromisedDependencies runs the Compromised-Dependencies check.
+func CompromisedDependencies(c *checker.CheckRequest) checker.CheckResult {
+	// TODO: remove this check when v6 is released
+	if !checker.IsExperimentalEnabled() {
+		c.Dlogger.Warn(&checker.LogMessage{
+			Text: "SCORECARD_EXPERIMENTAL is not set, not running the Compromised-Dependencies check",
+		})
+
+		e := sce.WithMessage(sce.ErrorUnsupportedCheck,
+			"SCORECARD_EXPERIMENTAL is not set, not running the Compromised-Dependencies check")
+		return checker.CreateRuntimeErrorResult(CheckCompromisedDependencies, e)
+	}
+
+	rawData, err := raw.CompromisedDependencies(c)
+	if err != nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		return checker.CreateRuntimeErrorResult(CheckCompromisedDependencies, e)
+	}
+
+	// Set the raw results.
+	if c.RawResults != nil {
+		c.RawResults.CompromisedDependenciesResults = rawData
+	}
+
+	// Return the score evaluation.
+	return evaluation.CompromisedDependencies(CheckCompromisedDependencies, c.Dlogger, &rawData)
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v4/checker"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestCompromisedDependencies(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		files    map[string]string
+		denylist []checker.DenylistEntry
+		expected checker.CheckResult
+	}{
+		{
+			name:  "no denylist",
+			files: map[string]string{"requirements.txt": "ctx==0.2.6\n"},
+			expected: checker.CheckResult{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name:  "no compromised dependency",
+			files: map[string]string{"requirements.txt": "requests==2.31.0\n"},
+			denylist: []checker.DenylistEntry{
+				{ID: "ctx-2022", Ecosystem: checker.DenylistPyPI, Name: "ctx"},
+			},
+			expected: checker.CheckResult{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name:  "compromised dependency",
+			files: map[string]string{"requirements.txt": "ctx==0.2.6\n"},
+			denylist: []checker.DenylistEntry{
+				{ID: "ctx-2022", Ecosystem: checker.DenylistPyPI, Name: "ctx"},
+			},
+			expected: checker.CheckResult{
+				Score: checker.MinResultScore,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			os.Setenv("SCORECARD_EXPERIMENTAL", "true")
+			ctrl := gomock.NewController(t)
+			mockRepo := mockrepo.NewMockRepoClient(ctrl)
+			mockRepo.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					var files []string
+					for f := range tt.files {
+						if ok, _ := predicate(f); ok {
+							files = append(files, f)
+						}
+					}
+					return files, nil
+				}).AnyTimes()
+			mockRepo.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(f string) ([]byte, error) {
+				return []byte(tt.files[f]), nil
+			}).AnyTimes()
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				RepoClient: mockRepo,
+				Ctx:        context.TODO(),
+				Dlogger:    &dl,
+				Denylist:   tt.denylist,
+			}
+			res := CompromisedDependencies(&req)
+			if res.Error != nil {
+				t.Errorf("unexpected error: %v", res.Error)
+			}
+			if res.Score != tt.expected.Score {
+				t.Errorf("Expected score %d, got %d for %v", tt.expected.Score, res.Score, tt.name)
+			}
+			ctrl.Finish()
+		})
+	}
+}
@@ -24,9 +24,54 @@ import (
 // CheckContributors is the registered name for Contributors.
 const CheckContributors = "Contributors"
 
+// contributorsMetadata documents the Contributors check.
+var contributorsMetadata = checker.CheckMetadata{
+	Risk:  "Low",
+	Short: "Determines if the project has a set of contributors from multiple organizations (e.g., companies).",
+	Description: "Risk: `Low` (lower number of trusted code reviewers)\n" +
+		"\n" +
+		"This check tries to determine if the project has recent contributors from\n" +
+		"multiple organizations (e.g., companies). It is currently limited to\n" +
+		"repositories hosted on GitHub, and does not support other source hosting\n" +
+		"repositories (i.e., Forges).\n" +
+		"\n" +
+		"The check looks at the `Company` field on the GitHub user profile for authors of\n" +
+		"recent commits. To receive the highest score, the project must have had\n" +
+		"contributors from at least 3 different companies in the last 30 commits; each of\n" +
+		"those contributors must have had at least 5 commits in the last 30 commits.\n" +
+		"\n" +
+		"Besides the `Company` field, the check counts the organizations contributors\n" +
+		"are verified members of, including organizations claimed with an `@handle`\n" +
+		"in the `Company` field, and the corporate domains of the email addresses they\n" +
+		"author commits with. Only addresses GitHub verified for the author's account\n" +
+		"are considered, and free email providers are ignored. A company and an email\n" +
+		"domain with the same name, e.g. `example` and `example.com`, count as a\n" +
+		"single organization.\n" +
+		"\n" +
+		"Note: Some projects cannot meet this requirement, such as small projects with\n" +
+		"only one active participant, or projects with a narrow scope that cannot attract\n" +
+		"the interest of multiple organizations. See\n" +
+		"[Code Reviews](https://github.com/ossf/scorecard/blob/main/docs/checks.md#code-reviews)\n" +
+		"for more information about evaluating projects with a small number of\n" +
+		"participants.\n",
+	Tags:  []string{"source-code"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.ListCommits",
+		"RepoClient.ListContributors",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "0-10", Criteria: "proportional to the organizations of the recent contributors, 10 from 3 organizations"},
+	},
+	Remediation: []string{
+		"Ask contributors to [join their respective " +
+			"organizations](https://docs.github.com/en/organizations/managing-membership-in-your-organization/inviting-users-to-join-your-organization), if they have not already. Otherwise, there is no remediation for this check; it simply provides insight into which organizations have contributed so that you can make a trust-based decision based on that information.",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
-	if err := registerCheck(CheckContributors, Contributors, nil); err != nil {
+	if err := registerCheck(CheckContributors, Contributors, nil, contributorsMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
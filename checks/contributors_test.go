@@ -35,6 +35,7 @@ func TestContributors(t *testing.T) {
 		err      error
 		name     string
 		contrib  []clients.User
+		commits  []clients.Commit
 		expected checker.CheckResult
 	}{
 		{
@@ -137,6 +138,35 @@ func TestContributors(t *testing.T) {
 				Score: 10,
 			},
 		},
+		{
+			err:  nil,
+			name: "Email domains of verified commit authors",
+			contrib: []clients.User{
+				{
+					Login:            "user1",
+					Companies:        []string{"company1"},
+					NumContributions: 10,
+				},
+				{
+					Login:            "user2",
+					NumContributions: 10,
+				},
+				{
+					Login:            "user3",
+					NumContributions: 10,
+				},
+			},
+			commits: []clients.Commit{
+				{Author: clients.User{Login: "user1", Email: "user1@eng.company1.com"}},
+				{Author: clients.User{Login: "user2", Email: "user2@company2.co.uk"}},
+				{Author: clients.User{Login: "user2", Email: "user2@gmail.com"}},
+				{Author: clients.User{Login: "user3", Email: "user3@users.noreply.github.com"}},
+				{Author: clients.User{Email: "someone@company3.com"}},
+			},
+			expected: checker.CheckResult{
+				Score: 6,
+			},
+		},
 		{
 			err:     nil,
 			name:    "No contributors",
@@ -167,6 +197,7 @@ func TestContributors(t *testing.T) {
 				}
 				return tt.contrib, nil
 			})
+			mockRepo.EXPECT().ListCommits().Return(tt.commits, nil).AnyTimes()
 
 			req := checker.CheckRequest{
 				RepoClient: mockRepo,
@@ -0,0 +1,207 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// WithCustomProbes returns check with the custom probes of the check named
+// name run after it, see checker.CustomProbe.
+func WithCustomProbes(name string, check checker.Check, probes []checker.CustomProbe) checker.Check {
+	var attached []*checker.CustomProbe
+	for i := range probes {
+		if probes[i].Check == name {
+			attached = append(attached, &probes[i])
+		}
+	}
+	if len(attached) == 0 {
+		return check
+	}
+	fn := check.Fn
+	check.Fn = func(c *checker.CheckRequest) checker.CheckResult {
+		res := fn(c)
+		if res.Error != nil {
+			return res
+		}
+		for _, p := range attached {
+			runCustomProbe(c, p)
+		}
+		return res
+	}
+	return check
+}
+
+// runCustomProbe logs the findings of the probe. An error only skips the
+// probe, which must not fail the check it is reported with.
+func runCustomProbe(c *checker.CheckRequest, p *checker.CustomProbe) {
+	findings, err := evaluateCustomProbe(c.RepoClient, p)
+	if err != nil {
+		c.Dlogger.Debug(&checker.LogMessage{
+			Text: fmt.Sprintf("custom probe %s: %v", p.ID, err),
+		})
+		return
+	}
+	for i := range findings {
+		if findings[i].Outcome == finding.OutcomeNegative {
+			c.Dlogger.Warn(&checker.LogMessage{Finding: &findings[i]})
+		} else {
+			c.Dlogger.Info(&checker.LogMessage{Finding: &findings[i]})
+		}
+	}
+}
+
+func evaluateCustomProbe(c clients.RepoClient, p *checker.CustomProbe) ([]finding.Finding, error) {
+	message := p.Description
+	if message == "" {
+		message = "custom probe " + p.ID
+	}
+	newFinding := func(outcome finding.Outcome) finding.Finding {
+		f := finding.Finding{
+			Rule:    p.ID,
+			Outcome: outcome,
+			Risk:    p.Risk,
+			Message: message,
+		}
+		if outcome == finding.OutcomeNegative && p.Remediation != nil {
+			remediation := *p.Remediation
+			f.Remediation = &remediation
+		}
+		return f
+	}
+
+	var findings []finding.Finding
+	seen := make(map[string]bool)
+	for _, glob := range p.Files {
+		err := fileparser.OnMatchingFileContentDo(c, fileparser.PathMatcher{
+			Pattern:       glob,
+			CaseSensitive: true,
+		}, func(path string, content []byte, args ...interface{}) (bool, error) {
+			if seen[path] {
+				return true, nil
+			}
+			seen[path] = true
+			line, ok := matchCustomProbeConditions(content, p.Conditions)
+			if !ok {
+				return true, nil
+			}
+			f := newFinding(p.OnMatch)
+			f.Location = &finding.Location{
+				Type:  finding.FileTypeText,
+				Value: path,
+			}
+			if line > 0 {
+				f.Location.LineStart = &line
+			}
+			findings = append(findings, f)
+			return true, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", glob, err)
+		}
+	}
+	if len(findings) == 0 {
+		f := newFinding(p.OnNoMatch)
+		f.Message += ": no matching file"
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
+
+// matchCustomProbeConditions returns whether content meets all conditions,
+// and the line of the first one which has a location.
+func matchCustomProbeConditions(content []byte, conditions []checker.CustomProbeCondition) (uint, bool) {
+	var line uint
+	var doc *yaml.Node
+	for i := range conditions {
+		c := &conditions[i]
+		var l uint
+		if c.Regex != nil {
+			loc := c.Regex.FindIndex(content)
+			if loc == nil {
+				return 0, false
+			}
+			l = uint(bytes.Count(content[:loc[0]], []byte("\n")) + 1)
+		} else {
+			if doc == nil {
+				doc = &yaml.Node{}
+				if err := yaml.Unmarshal(content, doc); err != nil {
+					return 0, false
+				}
+			}
+			node := findYAMLPath(doc, c.YAMLPath, c)
+			if node == nil {
+				return 0, false
+			}
+			l = uint(node.Line)
+		}
+		if line == 0 {
+			line = l
+		}
+	}
+	return line, true
+}
+
+// findYAMLPath returns the first node at path which meets the value
+// condition, if any.
+func findYAMLPath(node *yaml.Node, path []string, c *checker.CustomProbeCondition) *yaml.Node {
+	for node.Kind == yaml.DocumentNode || node.Kind == yaml.AliasNode {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		} else if len(node.Content) > 0 {
+			node = node.Content[0]
+		} else {
+			return nil
+		}
+	}
+	if len(path) == 0 {
+		if c.Value == nil || (node.Kind == yaml.ScalarNode && c.Value.MatchString(node.Value)) {
+			return node
+		}
+		return nil
+	}
+
+	key, rest := path[0], path[1:]
+	var children []*yaml.Node
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if key == "*" || node.Content[i].Value == key {
+				children = append(children, node.Content[i+1])
+			}
+		}
+	case yaml.SequenceNode:
+		if key == "*" {
+			children = node.Content
+		} else if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(node.Content) {
+			children = node.Content[i : i+1]
+		}
+	}
+	for _, child := range children {
+		if n := findYAMLPath(child, rest, c); n != nil {
+			return n
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package checks
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/finding"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+const customProbeWorkflow = `name: ci
+permissions: write-all
+jobs:
+  build:
+    steps:
+      - uses: actions/checkout@v3
+      - run: curl https://example.com/install.sh | sh
+`
+
+func TestEvaluateCustomProbe(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		".github/workflows/ci.yml": customProbeWorkflow,
+		"README.md":                "# project\n",
+	}
+	tests := []struct {
+		name  string
+		probe checker.CustomProbe
+		want  []finding.Finding
+	}{
+		{
+			name: "missing file",
+			probe: checker.CustomProbe{
+				ID:        "codeowners",
+				Files:     []string{"CODEOWNERS", ".github/CODEOWNERS"},
+				OnMatch:   finding.OutcomePositive,
+				OnNoMatch: finding.OutcomeNegative,
+			},
+			want: []finding.Finding{
+				{Rule: "codeowners", Outcome: finding.OutcomeNegative, Message: "custom probe codeowners: no matching file"},
+			},
+		},
+		{
+			name: "regex",
+			probe: checker.CustomProbe{
+				ID:          "no-curl-bash",
+				Description: "scripts must not be piped into a shell",
+				Files:       []string{"*.yml", ".github/workflows/*"},
+				Conditions:  []checker.CustomProbeCondition{{Regex: regexp.MustCompile(`curl[^|]*\|\s*sh`)}},
+				OnMatch:     finding.OutcomeNegative,
+				OnNoMatch:   finding.OutcomePositive,
+			},
+			want: []finding.Finding{
+				{
+					Rule:     "no-curl-bash",
+					Outcome:  finding.OutcomeNegative,
+					Message:  "scripts must not be piped into a shell",
+					Location: &finding.Location{Type: finding.FileTypeText, Value: ".github/workflows/ci.yml", LineStart: uintPointer(7)},
+				},
+			},
+		},
+		{
+			name: "yaml path with a wildcard",
+			probe: checker.CustomProbe{
+				ID:    "checkout",
+				Files: []string{"*.yml"},
+				Conditions: []checker.CustomProbeCondition{{
+					YAMLPath: []string{"jobs", "*", "steps", "*", "uses"},
+					Value:    regexp.MustCompile(`^actions/checkout@`),
+				}},
+				OnMatch:   finding.OutcomePositive,
+				OnNoMatch: finding.OutcomeNegative,
+			},
+			want: []finding.Finding{
+				{
+					Rule:     "checkout",
+					Outcome:  finding.OutcomePositive,
+					Message:  "custom probe checkout",
+					Location: &finding.Location{Type: finding.FileTypeText, Value: ".github/workflows/ci.yml", LineStart: uintPointer(6)},
+				},
+			},
+		},
+		{
+			name: "conditions which do not all hold",
+			probe: checker.CustomProbe{
+				ID:    "read-only",
+				Files: []string{"*.yml"},
+				Conditions: []checker.CustomProbeCondition{
+					{YAMLPath: []string{"permissions"}},
+					{YAMLPath: []string{"permissions"}, Value: regexp.MustCompile(`^read-all$`)},
+				},
+				OnMatch:   finding.OutcomePositive,
+				OnNoMatch: finding.OutcomeNotApplicable,
+			},
+			want: []finding.Finding{
+				{Rule: "read-only", Outcome: finding.OutcomeNotApplicable, Message: "custom probe read-only: no matching file"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mockrepo.NewMockRepoClient(ctrl)
+			mockRepo.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					var matched []string
+					for fn := range files {
+						if ok, err := predicate(fn); ok && err == nil {
+							matched = append(matched, fn)
+						}
+					}
+					return matched, nil
+				}).AnyTimes()
+			mockRepo.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(fn string) ([]byte, error) {
+				return []byte(files[fn]), nil
+			}).AnyTimes()
+
+			got, err := evaluateCustomProbe(mockRepo, &tt.probe)
+			if err != nil {
+				t.Fatalf("evaluateCustomProbe: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("evaluateCustomProbe() (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestWithCustomProbes(t *testing.T) {
+	t.Parallel()
+	check := checker.Check{Fn: func(*checker.CheckRequest) checker.CheckResult {
+		return checker.CheckResult{Score: checker.MaxResultScore}
+	}}
+	probes := []checker.CustomProbe{{ID: "codeowners", Check: CheckCodeReview, Files: []string{"CODEOWNERS"}}}
+	if got := WithCustomProbes(CheckLicense, check, probes); got.Fn == nil {
+		t.Fatal("WithCustomProbes() dropped the check")
+	}
+	wrapped := WithCustomProbes(CheckCodeReview, check, probes)
+
+	ctrl := gomock.NewController(t)
+	mockRepo := mockrepo.NewMockRepoClient(ctrl)
+	mockRepo.EXPECT().ListFiles(gomock.Any()).Return(nil, nil).AnyTimes()
+	dl := scut.TestDetailLogger{}
+	res := wrapped.Fn(&checker.CheckRequest{RepoClient: mockRepo, Dlogger: &dl})
+	if res.Score != checker.MaxResultScore {
+		t.Errorf("score = %d, want %d", res.Score, checker.MaxResultScore)
+	}
+	details := dl.Flush()
+	if len(details) != 1 || details[0].Type != checker.DetailWarn || details[0].Msg.Finding.Rule != "codeowners" {
+		t.Errorf("details = %+v", details)
+	}
+}
+
+func uintPointer(u uint) *uint {
+	return &u
+}
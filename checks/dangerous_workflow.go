@@ -24,13 +24,69 @@ import (
 // CheckDangerousWorkflow is the exported name for Dangerous-Workflow check.
 const CheckDangerousWorkflow = "Dangerous-Workflow"
 
+// dangerousWorkflowMetadata documents the Dangerous-Workflow check.
+var dangerousWorkflowMetadata = checker.CheckMetadata{
+	Risk:  "Critical",
+	Short: "Determines if the project's GitHub Action workflows avoid dangerous patterns.",
+	Description: "Risk: `Critical`  (vulnerable to repository compromise)\n" +
+		"\n" +
+		"This check determines whether the project's GitHub Action workflows has dangerous\n" +
+		"code patterns. Some examples of these patterns are untrusted code checkouts,\n" +
+		"logging github context and secrets, or use of potentially untrusted inputs in scripts.\n" +
+		"The following patterns are checked:\n" +
+		"\n" +
+		"Untrusted Code Checkout: This is the misuse of potentially dangerous triggers.\n" +
+		"This checks if a `pull_request_target` or `workflow_run` workflow trigger was used in " +
+		"conjunction\n" +
+		"with an explicit pull request checkout. Workflows triggered with `pull_request_target` / " +
+		"`workflow_run`\n" +
+		"have write permission to the target repository and access to target repository\n" +
+		"secrets. With the PR checkout, PR authors may compromise the repository, for\n" +
+		"example, by using build scripts controlled by the author of the PR or reading\n" +
+		"token in memory. This check does not detect whether untrusted code checkouts are\n" +
+		"used safely, for example, only on pull request that have been assigned a label.\n" +
+		"\n" +
+		"Script Injection with Untrusted Context Variables: This pattern detects whether a\n" +
+		"workflow's inline script may execute untrusted input from attackers. This occurs when\n" +
+		"an attacker adds malicious commands and scripts to a context. When a workflow runs,\n" +
+		"these strings may be interpreted as code that is executed on the runner. Attackers\n" +
+		"can add their own content to certain github context variables that are considered\n" +
+		"untrusted, for example, `github.event.issue.title`. These values should not flow\n" +
+		"directly into executable code.\n" +
+		"The scripts of Jenkinsfiles, CircleCI and Azure Pipelines configurations are also\n" +
+		"checked for untrusted input substituted by the CI system, like `${env.CHANGE_TITLE}`\n" +
+		"in a double-quoted Groovy string, `<< pipeline.trigger_parameters.github_app.commit_message >>`\n" +
+		"or `$(Build.SourceVersionMessage)`.\n" +
+		"\n" +
+		"Custom Rules: Additional patterns, such as banned actions, banned inline scripts or\n" +
+		"actions each job must use, can be given to Scorecard with `--workflow-rules`.\n" +
+		"\n" +
+		"The highest score is awarded when all workflows avoid the dangerous code patterns.\n",
+	Tags:  []string{"supply-chain", "security", "infrastructure"},
+	Repos: []string{"GitHub", "local"},
+	Inputs: []string{
+		"RepoClient.GetFileContent",
+		"RepoClient.ListFiles",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "no workflow has a dangerous pattern"},
+		{Score: "0", Criteria: "a workflow has an untrusted code checkout, a script injection or breaks a custom rule"},
+	},
+	Remediation: []string{
+		"Avoid the dangerous workflow patterns. See this " +
+			"[post](https://securitylab.github.com/research/github-actions-preventing-pwn-requests/) " +
+			"for information on avoiding untrusted code checkouts. See this " +
+			"[document](https://docs.github.com/en/actions/security-guides/security-hardening-for-github-actions#understanding-the-risk-of-script-injections) for information on avoiding and mitigating the risk of script injections.",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
 	supportedRequestTypes := []checker.RequestType{
 		checker.FileBased,
 		checker.CommitBased,
 	}
-	if err := registerCheck(CheckDangerousWorkflow, DangerousWorkflow, supportedRequestTypes); err != nil {
+	if err := registerCheck(CheckDangerousWorkflow, DangerousWorkflow, supportedRequestTypes, dangerousWorkflowMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
@@ -38,7 +94,7 @@ func init() {
 
 // DangerousWorkflow  will check the repository contains Dangerous-Workflow.
 func DangerousWorkflow(c *checker.CheckRequest) checker.CheckResult {
-	rawData, err := raw.DangerousWorkflow(c.RepoClient)
+	rawData, err := raw.DangerousWorkflow(c.RepoClient, c.WorkflowRules)
 	if err != nil {
 		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
 		return checker.CreateRuntimeErrorResult(CheckDangerousWorkflow, e)
@@ -24,12 +24,73 @@ import (
 // CheckDependencyUpdateTool is the exported name for Automatic-Depdendency-Update.
 const CheckDependencyUpdateTool = "Dependency-Update-Tool"
 
+// dependencyUpdateToolMetadata documents the Dependency-Update-Tool check.
+var dependencyUpdateToolMetadata = checker.CheckMetadata{
+	Risk:  "High",
+	Short: "Determines if the project uses a dependency update tool.",
+	Description: "Risk: `High` (possibly vulnerable to attacks on known flaws)\n" +
+		"\n" +
+		"This check tries to determine if the project uses a dependency update tool,\n" +
+		"specifically one of:\n" +
+		"- " +
+		"[dependabot](https://docs.github.com/en/code-security/supply-chain-security/keeping-your-dependencies-updated-automatically/configuration-options-for-dependency-updates)\n" +
+		"- [renovatebot](https://docs.renovatebot.com/configuration-options/)\n" +
+		"- [Sonatype Lift](https://help.sonatype.com/lift/getting-started)\n" +
+		"- [PyUp](https://docs.pyup.io/docs) (Python)\n" +
+		"Out-of-date dependencies make a project vulnerable to known flaws and prone to attacks.\n" +
+		"These tools automate the process of updating dependencies by scanning for\n" +
+		"outdated or insecure requirements, and opening a pull request to update them if\n" +
+		"found.\n" +
+		"\n" +
+		"This check can determine only whether the dependency update tool is enabled; it\n" +
+		"does not ensure that the tool is run or that the tool's pull requests are\n" +
+		"merged.\n" +
+		"\n" +
+		"For Dependabot and Renovate, the check also compares the configured ecosystems\n" +
+		"and directories with the dependency manifests of the repository (e.g. `go.mod`,\n" +
+		"`package.json`, Dockerfiles or GitHub Actions workflows). Each ecosystem and\n" +
+		"directory not updated by the tool, e.g. Dockerfiles updated but GitHub Actions not,\n" +
+		"is reported and the score is proportional to the covered manifests.\n" +
+		"The subprojects of a Gradle or Maven build are covered by an entry for the\n" +
+		"directory of its root build.\n" +
+		"\n" +
+		"Note: A project that fulfills this criterion with other tools may still receive\n" +
+		"a low score on this test. There are many ways to implement dependency updates,\n" +
+		"and it is challenging for an automated tool like Scorecard to detect them all. A\n" +
+		"low score is therefore not a definitive indication that the project is at risk.\n",
+	Tags:  []string{"supply-chain", "security", "dependencies"},
+	Repos: []string{"GitHub", "local"},
+	Inputs: []string{
+		"RepoClient.GetFileContent",
+		"RepoClient.ListFiles",
+		"RepoClient.SearchCommits",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "an update tool covering all the dependency manifests is configured"},
+		{Score: "0-10", Criteria: "proportional to the manifests covered by the Dependabot or Renovate configuration"},
+		{Score: "0", Criteria: "no update tool is detected"},
+	},
+	Remediation: []string{
+		"Signup for automatic dependency updates with one of the previously listed dependency " +
+			"update tools and place the config file in the locations that are recommended by these " +
+			"tools. Due to https://github.com/dependabot/dependabot-core/issues/2804 Dependabot can be " +
+			"enabled for forks where security updates have ever been turned on so projects maintaining " +
+			"stable forks should evaluate whether this behavior is satisfactory before turning it on.",
+		"Unlike dependabot, renovatebot has support to migrate dockerfiles' dependencies from " +
+			"version pinning to hash pinning via the [pinDigests " +
+			"setting](https://docs.renovatebot.com/configuration-options/#pindigests) without " +
+			"aditional manual effort.",
+		"Add an entry to the `updates` of your Dependabot config (or to the `enabledManagers` of " +
+			"your Renovate config) for each ecosystem and directory reported as not updated.",
+	},
+}
+
 //nolint
 func init() {
 	supportedRequestTypes := []checker.RequestType{
 		checker.FileBased,
 	}
-	if err := registerCheck(CheckDependencyUpdateTool, DependencyUpdateTool, supportedRequestTypes); err != nil {
+	if err := registerCheck(CheckDependencyUpdateTool, DependencyUpdateTool, supportedRequestTypes, dependencyUpdateToolMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
@@ -39,6 +39,7 @@ func TestDependencyUpdateTool(t *testing.T) {
 		SearchCommits     []clients.Commit
 		CallSearchCommits int
 		files             []string
+		contents          map[string]string
 		want              checker.CheckResult
 		expected          scut.TestReturn
 	}{
@@ -66,6 +67,33 @@ func TestDependencyUpdateTool(t *testing.T) {
 				Score:        10,
 			},
 		},
+		{
+			name:    "dependabot not covering all manifests",
+			wantErr: false,
+			files: []string{
+				".github/dependabot.yml",
+				".github/workflows/ci.yml",
+				"Dockerfile",
+				"go.mod",
+				"docs/go.mod",
+			},
+			contents: map[string]string{
+				".github/dependabot.yml": `
+version: 2
+updates:
+  - package-ecosystem: gomod
+    directory: /
+  - package-ecosystem: docker
+    directory: /
+`,
+			},
+			CallSearchCommits: 0,
+			expected: scut.TestReturn{
+				NumberOfInfo: 1,
+				NumberOfWarn: 2,
+				Score:        5,
+			},
+		},
 		{
 			name:    "foo bar",
 			wantErr: false,
@@ -143,6 +171,9 @@ func TestDependencyUpdateTool(t *testing.T) {
 			mockRepo := mockrepo.NewMockRepoClient(ctrl)
 			mockRepo.EXPECT().ListFiles(gomock.Any()).Return(tt.files, nil)
 			mockRepo.EXPECT().SearchCommits(gomock.Any()).Return(tt.SearchCommits, nil).Times(tt.CallSearchCommits)
+			mockRepo.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(name string) ([]byte, error) {
+				return []byte(tt.contents[name]), nil
+			}).AnyTimes()
 			dl := scut.TestDetailLogger{}
 			c := &checker.CheckRequest{
 				RepoClient: mockRepo,
@@ -0,0 +1,108 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package checks
+
+import (
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/evaluation"
+	"github.com/ossf/scorecard/v4/checks/raw"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// CheckDeploymentProtection is the registered name for DeploymentProtection.
+const CheckDeploymentProtection = "Deployment-Protection"
+
+// deploymentProtectionMetadata documents the Deployment-Protection check.
+var deploymentProtectionMetadata = checker.CheckMetadata{
+	Risk:  "High",
+	Short: "Determines if the project protects the environments its workflows deploy to.",
+	Description: "Risk: `High` (unreviewed or compromised releases)\n" +
+		"\n" +
+		"This check looks for GitHub workflow jobs which deploy to a production-like\n" +
+		"[environment](https://docs.github.com/en/actions/deployment/targeting-different-environments/using-environments-for-deployment),\n" +
+		"i.e., an environment whose name contains a word such as `prod`, `production`,\n" +
+		"`release`, `publish` or `live`, and determines whether those environments are\n" +
+		"protected. Environments that are chosen at runtime through an expression are\n" +
+		"not considered.\n" +
+		"\n" +
+		"Each production-like environment receives up to 10 points: requiring a review\n" +
+		"of deployments (5), restricting deployments to protected branches or branches\n" +
+		"matching name patterns (3), and delaying deployments with a wait timer (2).\n" +
+		"Environments which have not been configured in the repository settings receive\n" +
+		"no points. The check scores the least protected environment.\n" +
+		"If no workflow deploys to a production-like environment, the check is inconclusive.\n" +
+		"\n" +
+		"The details also list the environments which hold secrets but which no workflow\n" +
+		"deploys to any more. Those do not affect the score.\n" +
+		"\n" +
+		"Note: this is an experimental check which requires `SCORECARD_EXPERIMENTAL`\n" +
+		"to be set.\n",
+	Tags:  []string{"supply-chain", "security", "infrastructure"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.GetFileContent",
+		"RepoClient.ListEnvironments",
+		"RepoClient.ListFiles",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "+5", Criteria: "deployments to the least protected production environment require a review"},
+		{Score: "+3", Criteria: "its deployments are restricted to protected or matching branches"},
+		{Score: "+2", Criteria: "its deployments wait for a timer"},
+		{Score: "0", Criteria: "the environment is not configured in the repository settings"},
+		{Score: "inconclusive", Criteria: "no workflow deploys to a production-like environment"},
+	},
+	Remediation: []string{
+		"Configure required reviewers and deployment branches for the production environments, see " +
+			"[Using environments for " +
+			"deployment](https://docs.github.com/en/actions/deployment/targeting-different-environments/using-environments-for-deployment#environment-protection-rules).",
+		"Optionally, add a wait timer to leave time for a review of the deployment before it " +
+			"starts.",
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	if err := registerCheck(CheckDeploymentProtection, DeploymentProtection, nil, deploymentProtectionMetadata); err != nil {
+		// this should never happen
+		panic(err)
+	}
+}
+
+// DeploymentProtection runs the Deployment-Protection check.
+func DeploymentProtection(c *checker.CheckRequest) checker.CheckResult {
+	// TODO: remove this check when v6 is released
+	if !checker.IsExperimentalEnabled() {
+		c.Dlogger.Warn(&checker.LogMessage{
+			Text: "SCORECARD_EXPERIMENTAL is not set, not running the Deployment-Protection check",
+		})
+
+		e := sce.WithMessage(sce.ErrorUnsupportedCheck,
+			"SCORECARD_EXPERIMENTAL is not set, not running the Deployment-Protection check")
+		return checker.CreateRuntimeErrorResult(CheckDeploymentProtection, e)
+	}
+
+	rawData, err := raw.DeploymentProtection(c)
+	if err != nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		return checker.CreateRuntimeErrorResult(CheckDeploymentProtection, e)
+	}
+
+	// Set the raw results.
+	if c.RawResults != nil {
+		c.RawResults.DeploymentProtectionResults = rawData
+	}
+
+	// Return the score evaluation.
+	return evaluation.DeploymentProtection(CheckDeploymentProtection, c.Dlogger, &rawData)
+}
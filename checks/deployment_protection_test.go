@@ -0,0 +1,133 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestDeploymentProtection(t *testing.T) {
+	t.Parallel()
+	protected := func(name string) clients.Environment {
+		return clients.Environment{
+			Name:              name,
+			RequiredReviewers: 1,
+			WaitTimer:         10,
+			ProtectedBranches: true,
+		}
+	}
+	tests := []struct {
+		err          error
+		name         string
+		filename     string
+		environments []clients.Environment
+		expected     scut.TestReturn
+		wantErr      bool
+	}{
+		{
+			name:     "No deployments",
+			filename: "./testdata/.github/workflows/github-workflow-permissions-none.yaml",
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name:         "Protected production environments",
+			filename:     "./testdata/.github/workflows/github-workflow-deployment-environments.yaml",
+			environments: []clients.Environment{{Name: "staging"}, protected("Production"), protected("pypi-release")},
+			expected: scut.TestReturn{
+				Score:         checker.MaxResultScore,
+				NumberOfInfo:  6,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name:         "Unconfigured production environment",
+			filename:     "./testdata/.github/workflows/github-workflow-deployment-environments.yaml",
+			environments: []clients.Environment{protected("Production")},
+			expected: scut.TestReturn{
+				Score:         checker.MinResultScore,
+				NumberOfInfo:  3,
+				NumberOfWarn:  1,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name:     "Environments not supported",
+			filename: "./testdata/.github/workflows/github-workflow-deployment-environments.yaml",
+			err:      fmt.Errorf("ListEnvironments: %w", clients.ErrUnsupportedFeature),
+			expected: scut.TestReturn{
+				Score:         checker.InconclusiveResultScore,
+				NumberOfDebug: 2,
+			},
+		},
+		{
+			name:     "Client error",
+			filename: "./testdata/.github/workflows/github-workflow-deployment-environments.yaml",
+			err:      errors.New("some error"), //nolint:goerr113
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			os.Setenv("SCORECARD_EXPERIMENTAL", "true")
+			ctrl := gomock.NewController(t)
+			mockRepo := mockrepo.NewMockRepoClient(ctrl)
+			mockRepo.EXPECT().ListFiles(gomock.Any()).Return([]string{".github/workflows/release.yaml"}, nil)
+			mockRepo.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(fn string) ([]byte, error) {
+				content, err := os.ReadFile(tt.filename)
+				if err != nil {
+					return nil, fmt.Errorf("%w", err)
+				}
+				return content, nil
+			})
+			mockRepo.EXPECT().ListEnvironments().Return(tt.environments, tt.err).MaxTimes(1)
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				RepoClient: mockRepo,
+				Ctx:        context.TODO(),
+				Dlogger:    &dl,
+			}
+			res := DeploymentProtection(&req)
+			if tt.wantErr {
+				if res.Error == nil {
+					t.Errorf("Expected error %v, got nil", tt.err)
+				}
+				// return as we don't need to check the rest of the fields.
+				return
+			}
+
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+			ctrl.Finish()
+		})
+	}
+}
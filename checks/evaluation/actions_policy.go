@@ -0,0 +1,112 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package evaluation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+const (
+	allowedActionsLocalOnlyScore = 7
+	allowedActionsSelectedScore  = 6
+	allowedActionsVerifiedScore  = 4
+	forkPRApprovalAllScore       = 3
+	forkPRApprovalFirstTimeScore = 2
+	forkPRApprovalNewUserScore   = 1
+)
+
+// ActionsPolicy applies the score policy for the Actions-Policy check.
+func ActionsPolicy(name string, dl checker.DetailLogger,
+	r *checker.ActionsPolicyData,
+) checker.CheckResult {
+	if r == nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "empty raw data")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	policy := r.Policy
+	if policy == nil {
+		return checker.CreateInconclusiveResult(name,
+			"could not retrieve the actions policy, reading it requires admin access to the repository")
+	}
+	if !policy.Enabled {
+		return checker.CreateMaxScoreResult(name, "GitHub Actions is disabled")
+	}
+
+	score := 0
+	switch policy.AllowedActions {
+	case clients.AllowedActionsLocalOnly:
+		dl.Info(&checker.LogMessage{
+			Text: "only actions from the repository or its organization are allowed",
+		})
+		score += allowedActionsLocalOnlyScore
+	case clients.AllowedActionsSelected:
+		if policy.VerifiedAllowed {
+			dl.Info(&checker.LogMessage{
+				Text: "only selected actions are allowed, including actions from verified creators",
+			})
+			score += allowedActionsVerifiedScore
+		} else {
+			dl.Info(&checker.LogMessage{
+				Text: fmt.Sprintf("only selected actions are allowed: github-owned: %t, patterns: %s",
+					policy.GithubOwnedAllowed, strings.Join(policy.PatternsAllowed, ", ")),
+			})
+			score += allowedActionsSelectedScore
+		}
+	case clients.AllowedActionsAll:
+		dl.Warn(&checker.LogMessage{
+			Text: "all actions are allowed to run",
+		})
+	default:
+		dl.Warn(&checker.LogMessage{
+			Text: fmt.Sprintf("unknown allowed actions setting: '%s'", policy.AllowedActions),
+		})
+	}
+
+	switch policy.ForkPRApproval {
+	case clients.ForkPRApprovalAll:
+		dl.Info(&checker.LogMessage{
+			Text: "workflow runs from all outside collaborators require approval",
+		})
+		score += forkPRApprovalAllScore
+	case clients.ForkPRApprovalFirstTime:
+		dl.Info(&checker.LogMessage{
+			Text: "workflow runs from first-time contributors require approval",
+		})
+		score += forkPRApprovalFirstTimeScore
+	case clients.ForkPRApprovalNewToGitHub:
+		dl.Warn(&checker.LogMessage{
+			Text: "workflow runs only require approval for first-time contributors who are new to GitHub",
+		})
+		score += forkPRApprovalNewUserScore
+	case clients.ForkPRApprovalUnknown:
+		dl.Debug(&checker.LogMessage{
+			Text: "could not determine which workflow runs from outside collaborators require approval",
+		})
+	default:
+		dl.Warn(&checker.LogMessage{
+			Text: fmt.Sprintf("unknown workflow run approval setting: '%s'", policy.ForkPRApproval),
+		})
+	}
+
+	if score == checker.MaxResultScore {
+		return checker.CreateMaxScoreResult(name, "actions policy restricts actions and outside workflow runs")
+	}
+	return checker.CreateResultWithScore(name, "actions policy is not maximal", score)
+}
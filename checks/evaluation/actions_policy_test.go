@@ -0,0 +1,105 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestActionsPolicy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		r    *checker.ActionsPolicyData
+		want scut.TestReturn
+	}{
+		{
+			name: "nil raw data",
+			want: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+				Error: sce.ErrScorecardInternal,
+			},
+		},
+		{
+			name: "policy not retrieved",
+			r:    &checker.ActionsPolicyData{},
+			want: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name: "selected actions including verified creators",
+			r: &checker.ActionsPolicyData{
+				Policy: &clients.ActionsPolicy{
+					Enabled:            true,
+					AllowedActions:     clients.AllowedActionsSelected,
+					GithubOwnedAllowed: true,
+					VerifiedAllowed:    true,
+					ForkPRApproval:     clients.ForkPRApprovalNewToGitHub,
+				},
+			},
+			want: scut.TestReturn{
+				Score:        allowedActionsVerifiedScore + forkPRApprovalNewUserScore,
+				NumberOfInfo: 1,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name: "selected actions from an allow-list",
+			r: &checker.ActionsPolicyData{
+				Policy: &clients.ActionsPolicy{
+					Enabled:         true,
+					AllowedActions:  clients.AllowedActionsSelected,
+					PatternsAllowed: []string{"actions/checkout@*"},
+					ForkPRApproval:  clients.ForkPRApprovalFirstTime,
+				},
+			},
+			want: scut.TestReturn{
+				Score:        allowedActionsSelectedScore + forkPRApprovalFirstTimeScore,
+				NumberOfInfo: 2,
+			},
+		},
+		{
+			name: "all actions allowed",
+			r: &checker.ActionsPolicyData{
+				Policy: &clients.ActionsPolicy{
+					Enabled:        true,
+					AllowedActions: clients.AllowedActionsAll,
+					ForkPRApproval: clients.ForkPRApprovalAll,
+				},
+			},
+			want: scut.TestReturn{
+				Score:        forkPRApprovalAllScore,
+				NumberOfInfo: 1,
+				NumberOfWarn: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			got := ActionsPolicy(tt.name, &dl, tt.r)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.want, &got, &dl) {
+				t.Errorf("ActionsPolicy() = %v", got)
+			}
+		})
+	}
+}
@@ -29,8 +29,24 @@ func BinaryArtifacts(name string, dl checker.DetailLogger,
 		return checker.CreateRuntimeErrorResult(name, e)
 	}
 
+	// Binaries are expected in release archives, so they are reported
+	// without affecting the score.
+	for _, f := range r.ReleaseFiles {
+		dl.Info(&checker.LogMessage{
+			Path: f.Path, Type: finding.FileTypeBinary,
+			Text: "binary detected in release archive",
+		})
+	}
+
+	for _, f := range r.VerifiedWrappers {
+		dl.Info(&checker.LogMessage{
+			Path: f.Path, Type: finding.FileTypeBinary,
+			Text: "wrapper jar matches the checksum of the official distribution",
+		})
+	}
+
 	// Apply the policy evaluation.
-	if r.Files == nil || len(r.Files) == 0 {
+	if len(r.Files) == 0 && len(r.LFSFiles) == 0 {
 		return checker.CreateMaxScoreResult(name, "no binaries found in the repo")
 	}
 
@@ -44,6 +60,15 @@ func BinaryArtifacts(name string, dl checker.DetailLogger,
 		// We remove one point for each binary.
 		score--
 	}
+	// Binaries stored in Git LFS are checked out with the repo like any other.
+	for _, f := range r.LFSFiles {
+		dl.Warn(&checker.LogMessage{
+			Path: f.Path, Type: finding.FileTypeBinary,
+			Offset: f.Offset,
+			Text:   "binary stored in Git LFS detected",
+		})
+		score--
+	}
 
 	if score < checker.MinResultScore {
 		score = checker.MinResultScore
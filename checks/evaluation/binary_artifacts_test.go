@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
 	scut "github.com/ossf/scorecard/v4/utests"
 )
 
@@ -55,6 +56,39 @@ func TestBinaryArtifacts(t *testing.T) {
 				Score: checker.MaxResultScore,
 			},
 		},
+		{
+			name: "binaries in release archives only",
+			args: args{
+				name: "binaries in release archives only",
+				dl:   &scut.TestDetailLogger{},
+				r: &checker.BinaryArtifactData{
+					ReleaseFiles: []checker.File{
+						{Path: "tool-1.0.0.tar.gz/bin/tool", Type: finding.FileTypeBinary},
+					},
+				},
+			},
+			want: checker.CheckResult{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name: "binaries stored in Git LFS",
+			args: args{
+				name: "binaries stored in Git LFS",
+				dl:   &scut.TestDetailLogger{},
+				r: &checker.BinaryArtifactData{
+					Files: []checker.File{
+						{Path: "bin/tool", Type: finding.FileTypeBinary},
+					},
+					LFSFiles: []checker.File{
+						{Path: "lib/vendor.jar", Type: finding.FileTypeBinary, FileSize: 1 << 20},
+					},
+				},
+			},
+			want: checker.CheckResult{
+				Score: checker.MaxResultScore - 2,
+			},
+		},
 		{
 			name: "1 binary artifact",
 			args: args{
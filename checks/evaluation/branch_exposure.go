@@ -0,0 +1,107 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+const (
+	releaseBranchesRule = "ReleaseBranchesMaintained"
+	deployBranchesRule  = "DeployBranchesProtected"
+	forcePushesRule     = "NoForcePushesToProtectedBranches"
+
+	// staleBranchDays and staleBranchBehindBy are the age of the last commit
+	// and the lag behind the default branch of stale release branches.
+	staleBranchDays     = 180
+	staleBranchBehindBy = 100
+)
+
+// logBranchExposure logs the findings on the branches Branch-Protection does
+// not score: stale release branches and unprotected deploy branches, along
+// with the force pushes to the protected branches. They do not affect the
+// score.
+func logBranchExposure(dl checker.DetailLogger, r *checker.BranchProtectionsData) error {
+	threshold := time.Now().AddDate(0 /*years*/, 0 /*months*/, -1*staleBranchDays /*days*/)
+	for i := range r.ReleaseBranches {
+		b := &r.ReleaseBranches[i]
+		f, err := finding.New(rules, releaseBranchesRule)
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		}
+		f = f.WithRemediationMetadata(map[string]string{"branch": b.Name})
+		if b.BehindBy < staleBranchBehindBy || b.LastCommit.After(threshold) {
+			f = f.WithMessage(fmt.Sprintf("release branch '%s' is maintained", b.Name)).
+				WithOutcome(finding.OutcomePositive)
+			dl.Info(&checker.LogMessage{Finding: f})
+			continue
+		}
+		f = f.WithMessage(fmt.Sprintf("release branch '%s' is %d commits behind the default branch "+
+			"and has had no commit since %s", b.Name, b.BehindBy, b.LastCommit.Format("2006-01-02")))
+		dl.Warn(&checker.LogMessage{Finding: f})
+	}
+
+	// Several workflows may deploy from the same branch.
+	seen := map[string]bool{}
+	for i := range r.DeployBranches {
+		b := &r.DeployBranches[i]
+		if seen[b.Name] {
+			continue
+		}
+		seen[b.Name] = true
+		if b.Protected == nil {
+			dl.Debug(&checker.LogMessage{
+				Path: b.File.Path,
+				Type: b.File.Type,
+				Text: fmt.Sprintf("could not read the protection of deploy branch '%s'", b.Name),
+			})
+			continue
+		}
+		f, err := finding.New(rules, deployBranchesRule)
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		}
+		f = f.WithLocation(&finding.Location{
+			Type:  b.File.Type,
+			Value: b.File.Path,
+		}).WithRemediationMetadata(map[string]string{"branch": b.Name, "workflow": b.File.Path})
+		if *b.Protected {
+			f = f.WithMessage(fmt.Sprintf("deploy branch '%s' is protected", b.Name)).
+				WithOutcome(finding.OutcomePositive)
+			dl.Info(&checker.LogMessage{Finding: f})
+			continue
+		}
+		f = f.WithMessage(fmt.Sprintf("branch protection not enabled for deploy branch '%s'", b.Name))
+		dl.Warn(&checker.LogMessage{Finding: f})
+	}
+
+	for i := range r.ForcePushes {
+		p := &r.ForcePushes[i]
+		f, err := finding.New(rules, forcePushesRule)
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		}
+		f = f.WithMessage(fmt.Sprintf("'%s' force pushed protected branch '%s' from %s to %s on %s",
+			p.Actor, p.Branch, p.Before, p.After, p.CreatedAt.Format("2006-01-02"))).
+			WithRemediationMetadata(map[string]string{"actor": p.Actor, "branch": p.Branch})
+		dl.Warn(&checker.LogMessage{Finding: f})
+	}
+	return nil
+}
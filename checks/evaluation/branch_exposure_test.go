@@ -0,0 +1,83 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestLogBranchExposure(t *testing.T) {
+	t.Parallel()
+	protected, unprotected := true, false
+	old := time.Now().AddDate(-1, 0, 0)
+	recent := time.Now().AddDate(0, 0, -7)
+	workflow := checker.File{Path: ".github/workflows/deploy.yml"}
+	tests := []struct {
+		name     string
+		r        *checker.BranchProtectionsData
+		expected scut.TestReturn
+	}{
+		{
+			name: "branches not listed",
+			r:    &checker.BranchProtectionsData{},
+		},
+		{
+			name: "release branches",
+			r: &checker.BranchProtectionsData{ReleaseBranches: []clients.BranchInfo{
+				{Name: "release/1.0", LastCommit: old, BehindBy: 500},
+				// Behind, but still backported to.
+				{Name: "release/2.0", LastCommit: recent, BehindBy: 500},
+				// Old, but nothing to backport.
+				{Name: "1.x", LastCommit: old, BehindBy: 3},
+			}},
+			expected: scut.TestReturn{NumberOfWarn: 1, NumberOfInfo: 2},
+		},
+		{
+			name: "deploy branches",
+			r: &checker.BranchProtectionsData{DeployBranches: []checker.DeployBranch{
+				{Name: "main", File: workflow, Protected: &protected},
+				{Name: "production", File: workflow, Protected: &unprotected},
+				{Name: "production", File: checker.File{Path: ".github/workflows/other.yml"}, Protected: &unprotected},
+				{Name: "gh-pages", File: workflow},
+			}},
+			expected: scut.TestReturn{NumberOfWarn: 1, NumberOfInfo: 1, NumberOfDebug: 1},
+		},
+		{
+			name: "force pushes",
+			r: &checker.BranchProtectionsData{ForcePushes: []clients.ForcePush{
+				{CreatedAt: recent, Actor: "admin", Branch: "main", Before: "aaa", After: "bbb"},
+			}},
+			expected: scut.TestReturn{NumberOfWarn: 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			if err := logBranchExposure(&dl, tt.r); err != nil {
+				t.Fatalf("logBranchExposure() = %v", err)
+			}
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &checker.CheckResult{}, &dl) {
+				t.Fail()
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package evaluation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/clients"
@@ -55,11 +56,20 @@ func BranchProtection(name string, dl checker.DetailLogger,
 	r *checker.BranchProtectionsData,
 ) checker.CheckResult {
 	var scores []levelScore
+	// Settings only readable with admin access are nil without it.
+	incomplete := false
+
+	if err := logBranchExposure(dl, r); err != nil {
+		return checker.CreateRuntimeErrorResult(name, err)
+	}
 
 	// Check protections on all the branches.
 	for i := range r.Branches {
 		var score levelScore
 		b := r.Branches[i]
+		if b.Protected != nil && *b.Protected && b.BranchProtectionRule.EnforceAdmins == nil {
+			incomplete = true
+		}
 
 		// Protected field only indates that the branch matches
 		// one `Branch protection rules`. All settings may be disabled,
@@ -91,18 +101,74 @@ func BranchProtection(name string, dl checker.DetailLogger,
 	if err != nil {
 		return checker.CreateRuntimeErrorResult(name, err)
 	}
+	bypasses := auditLogBypasses(dl, r)
+	score -= bypasses
+	if score < checker.MinResultScore {
+		score = checker.MinResultScore
+	}
 
-	switch score {
-	case checker.MinResultScore:
-		return checker.CreateMinScoreResult(name,
+	var result checker.CheckResult
+	switch {
+	case bypasses > 0:
+		result = checker.CreateResultWithScore(name, fmt.Sprintf(
+			"branch protection was removed or bypassed %d time(s) in the last %d days", bypasses, lookBackDays), score)
+	case score == checker.MinResultScore:
+		result = checker.CreateMinScoreResult(name,
 			"branch protection not enabled on development/release branches")
-	case checker.MaxResultScore:
-		return checker.CreateMaxScoreResult(name,
+	case score == checker.MaxResultScore:
+		result = checker.CreateMaxScoreResult(name,
 			"branch protection is fully enabled on development and all release branches")
 	default:
-		return checker.CreateResultWithScore(name,
+		result = checker.CreateResultWithScore(name,
 			"branch protection is not maximal on development and all release branches", score)
 	}
+	result.Incomplete = incomplete
+	return result
+}
+
+// auditLogBypasses logs the changes of the protection of the branches found
+// in the audit log, and returns the number of times it was removed or bypassed.
+// Each of them costs a point since the settings read now may not have been in
+// effect all along.
+func auditLogBypasses(dl checker.DetailLogger, r *checker.BranchProtectionsData) int {
+	if r.AuditLog == nil {
+		return 0
+	}
+	bypasses := 0
+	for i := range r.AuditLog.Events {
+		e := &r.AuditLog.Events[i]
+		if !appliesToBranches(e, r.Branches) {
+			continue
+		}
+		msg := &checker.LogMessage{
+			Text: fmt.Sprintf("audit log: %s on branch '%s' by %s on %s",
+				e.Action, e.Branch, e.Actor, e.CreatedAt.Format(time.RFC3339)),
+		}
+		if e.Branch == "" {
+			msg.Text = fmt.Sprintf("audit log: %s by %s on %s", e.Action, e.Actor, e.CreatedAt.Format(time.RFC3339))
+		}
+		if e.IsBranchProtectionBypass() {
+			bypasses++
+			dl.Warn(msg)
+		} else {
+			dl.Debug(msg)
+		}
+	}
+	if bypasses == 0 {
+		dl.Info(&checker.LogMessage{
+			Text: fmt.Sprintf("audit log: branch protection not removed or bypassed in the last %d days", lookBackDays),
+		})
+	}
+	return bypasses
+}
+
+func appliesToBranches(e *clients.AuditLogEvent, branches []clients.BranchRef) bool {
+	for i := range branches {
+		if branches[i].Name != nil && e.AppliesToBranch(*branches[i].Name) {
+			return true
+		}
+	}
+	return false
 }
 
 func computeNonAdminBasicScore(scores []levelScore) int {
@@ -223,24 +289,25 @@ func computeScore(scores []levelScore) (int, error) {
 	}
 
 	// Fourth, check the thorough non-admin reviews.
-	// Also check whether this repo requires codeowner review
 	maxThoroughReviewScore := maxScore.thoroughReview * len(scores)
-	maxCodeownerReviewScore := maxScore.codeownerReview * len(scores)
 	thoroughReviewScore := computeNonAdminThoroughReviewScore(scores)
-	codeownerReviewScore := computeCodeownerThoroughReviewScore(scores)
-	score += noarmalizeScore(thoroughReviewScore+codeownerReviewScore, maxThoroughReviewScore+maxCodeownerReviewScore,
-		nonAdminThoroughReviewLevel)
+	score += noarmalizeScore(thoroughReviewScore, maxThoroughReviewScore, nonAdminThoroughReviewLevel)
 	if thoroughReviewScore != maxThoroughReviewScore {
 		return int(score), nil
 	}
 
-	// Lastly, check the thorough admin review config.
-	// This one is controversial and has usability issues
+	// Lastly, check the thorough admin review config and whether
+	// codeowners must approve changes to the paths they own.
+	// The admin config is controversial and has usability issues
 	// https://github.com/ossf/scorecard/issues/1027, so we may remove it.
 	maxAdminThoroughReviewScore := maxScore.adminThoroughReview * len(scores)
+	maxCodeownerReviewScore := maxScore.codeownerReview * len(scores)
 	adminThoroughReviewScore := computeAdminThoroughReviewScore(scores)
-	score += noarmalizeScore(adminThoroughReviewScore, maxAdminThoroughReviewScore, adminThoroughReviewLevel)
-	if adminThoroughReviewScore != maxAdminThoroughReviewScore {
+	codeownerReviewScore := computeCodeownerThoroughReviewScore(scores)
+	score += noarmalizeScore(adminThoroughReviewScore+codeownerReviewScore,
+		maxAdminThoroughReviewScore+maxCodeownerReviewScore, adminThoroughReviewLevel)
+	if adminThoroughReviewScore != maxAdminThoroughReviewScore ||
+		codeownerReviewScore != maxCodeownerReviewScore {
 		return int(score), nil
 	}
 
@@ -374,9 +441,16 @@ func adminReviewProtection(branch *clients.BranchRef, dl checker.DetailLogger) (
 	log := branch.Protected != nil && *branch.Protected
 
 	// Process UpToDateBeforeMerge value.
-	if branch.BranchProtectionRule.CheckRules.UpToDateBeforeMerge == nil {
+	// A merge queue tests each change against the latest version of the branch,
+	// so it makes up-to-date branches unnecessary.
+	switch {
+	case branch.BranchProtectionRule.RequireMergeQueue != nil && *branch.BranchProtectionRule.RequireMergeQueue:
+		max++
+		info(dl, log, "merge queue is required on branch '%s'", *branch.Name)
+		score++
+	case branch.BranchProtectionRule.CheckRules.UpToDateBeforeMerge == nil:
 		debug(dl, log, "unable to retrieve whether up-to-date branches are needed to merge on branch '%s'", *branch.Name)
-	} else {
+	default:
 		// Note: `This setting will not take effect unless at least one status check is enabled`.
 		max++
 		if *branch.BranchProtectionRule.CheckRules.UpToDateBeforeMerge {
@@ -16,6 +16,7 @@ package evaluation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/clients"
@@ -42,6 +43,7 @@ func TestIsBranchProtected(t *testing.T) {
 	falseVal := false
 	var zeroVal int32
 	var oneVal int32 = 1
+	var twoVal int32 = 2
 	branchVal := "branch-name"
 	tests := []struct {
 		name            string
@@ -406,6 +408,102 @@ func TestIsBranchProtected(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Fully protected with codeowner review",
+			expected: scut.TestReturn{
+				Error:         nil,
+				Score:         10,
+				NumberOfWarn:  0,
+				NumberOfInfo:  9,
+				NumberOfDebug: 0,
+			},
+			branch: &clients.BranchRef{
+				Name:      &branchVal,
+				Protected: &trueVal,
+				BranchProtectionRule: clients.BranchProtectionRule{
+					EnforceAdmins:           &trueVal,
+					RequireLastPushApproval: &trueVal,
+					RequireLinearHistory:    &trueVal,
+					AllowForcePushes:        &falseVal,
+					AllowDeletions:          &falseVal,
+					CheckRules: clients.StatusChecksRule{
+						RequiresStatusChecks: &trueVal,
+						UpToDateBeforeMerge:  &trueVal,
+						Contexts:             []string{"foo"},
+					},
+					RequiredPullRequestReviews: clients.PullRequestReviewRule{
+						DismissStaleReviews:          &trueVal,
+						RequireCodeOwnerReviews:      &trueVal,
+						RequiredApprovingReviewCount: &twoVal,
+					},
+				},
+			},
+			codeownersFiles: []string{".github/CODEOWNERS"},
+		},
+		{
+			name: "Fully protected without codeowner review",
+			expected: scut.TestReturn{
+				Error:         nil,
+				Score:         9,
+				NumberOfWarn:  1,
+				NumberOfInfo:  8,
+				NumberOfDebug: 0,
+			},
+			branch: &clients.BranchRef{
+				Name:      &branchVal,
+				Protected: &trueVal,
+				BranchProtectionRule: clients.BranchProtectionRule{
+					EnforceAdmins:           &trueVal,
+					RequireLastPushApproval: &trueVal,
+					RequireLinearHistory:    &trueVal,
+					AllowForcePushes:        &falseVal,
+					AllowDeletions:          &falseVal,
+					CheckRules: clients.StatusChecksRule{
+						RequiresStatusChecks: &trueVal,
+						UpToDateBeforeMerge:  &trueVal,
+						Contexts:             []string{"foo"},
+					},
+					RequiredPullRequestReviews: clients.PullRequestReviewRule{
+						DismissStaleReviews:          &trueVal,
+						RequireCodeOwnerReviews:      &falseVal,
+						RequiredApprovingReviewCount: &twoVal,
+					},
+				},
+			},
+			codeownersFiles: []string{".github/CODEOWNERS"},
+		},
+		{
+			name: "Merge queue required instead of up-to-date branches",
+			expected: scut.TestReturn{
+				Error:         nil,
+				Score:         10,
+				NumberOfWarn:  0,
+				NumberOfInfo:  9,
+				NumberOfDebug: 0,
+			},
+			branch: &clients.BranchRef{
+				Name:      &branchVal,
+				Protected: &trueVal,
+				BranchProtectionRule: clients.BranchProtectionRule{
+					EnforceAdmins:           &trueVal,
+					RequireLastPushApproval: &trueVal,
+					RequireLinearHistory:    &trueVal,
+					RequireMergeQueue:       &trueVal,
+					AllowForcePushes:        &falseVal,
+					AllowDeletions:          &falseVal,
+					CheckRules: clients.StatusChecksRule{
+						RequiresStatusChecks: &trueVal,
+						Contexts:             []string{"foo"},
+					},
+					RequiredPullRequestReviews: clients.PullRequestReviewRule{
+						DismissStaleReviews:          &trueVal,
+						RequireCodeOwnerReviews:      &trueVal,
+						RequiredApprovingReviewCount: &twoVal,
+					},
+				},
+			},
+			codeownersFiles: []string{".github/CODEOWNERS"},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -423,3 +521,55 @@ func TestIsBranchProtected(t *testing.T) {
 		})
 	}
 }
+
+func TestAuditLogBypasses(t *testing.T) {
+	t.Parallel()
+	main, release := "main", "release/1.0"
+	createdAt := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		auditLog *checker.AuditLogData
+		want     int
+		expected scut.TestReturn
+	}{
+		{
+			name: "audit log not available",
+		},
+		{
+			name:     "no events",
+			auditLog: &checker.AuditLogData{},
+			expected: scut.TestReturn{NumberOfInfo: 1},
+		},
+		{
+			name: "rules updated, deleted and bypassed",
+			auditLog: &checker.AuditLogData{Events: []clients.AuditLogEvent{
+				{Action: "protected_branch.update", Branch: "main", Actor: "admin", CreatedAt: createdAt},
+				{Action: clients.AuditLogBranchProtectionDestroy, Branch: "release/*", Actor: "admin", CreatedAt: createdAt},
+				{Action: clients.AuditLogBranchProtectionOverride, Branch: "main", Actor: "admin", CreatedAt: createdAt},
+				{Action: clients.AuditLogRulesetDestroy, Actor: "admin", CreatedAt: createdAt},
+				// Not one of the branches scored.
+				{Action: clients.AuditLogBranchProtectionDestroy, Branch: "dev", Actor: "admin", CreatedAt: createdAt},
+			}},
+			want:     3,
+			expected: scut.TestReturn{NumberOfWarn: 3, NumberOfDebug: 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			r := &checker.BranchProtectionsData{
+				AuditLog: tt.auditLog,
+				Branches: []clients.BranchRef{{Name: &main}, {Name: &release}},
+			}
+			got := auditLogBypasses(&dl, r)
+			if got != tt.want {
+				t.Errorf("auditLogBypasses() = %d, want %d", got, tt.want)
+			}
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &checker.CheckResult{}, &dl) {
+				t.Fail()
+			}
+		})
+	}
+}
@@ -29,6 +29,18 @@ const (
 )
 
 func CITests(name string, c *checker.CITestData, dl checker.DetailLogger) checker.CheckResult {
+	for _, config := range c.Configs {
+		text := fmt.Sprintf("%s configuration found", config.System)
+		if config.RunsTests {
+			text = fmt.Sprintf("%s configuration running tests found", config.System)
+		}
+		dl.Info(&checker.LogMessage{
+			Path: config.File.Path,
+			Type: config.File.Type,
+			Text: text,
+		})
+	}
+
 	totalMerged := 0
 	totalTested := 0
 	for i := range c.CIInfo {
@@ -125,10 +137,14 @@ func isTest(s string) bool {
 	l := strings.ToLower(s)
 
 	// Add more patterns here!
+	// Statuses of Forgejo and Gitea Actions are only recognizable by the
+	// URL of their run, while the ones of Woodpecker CI have a context like
+	// ci/woodpecker/pr/build.
 	for _, pattern := range []string{
 		"appveyor", "buildkite", "circleci", "e2e", "github-actions", "jenkins",
 		"mergeable", "packit-as-a-service", "semaphoreci", "test", "travis-ci",
-		"flutter-dashboard", "Cirrus CI", "azure-pipelines",
+		"flutter-dashboard", "Cirrus CI", "azure-pipelines", "woodpecker",
+		"/actions/runs/",
 	} {
 		if strings.Contains(l, pattern) {
 			return true
@@ -108,6 +108,20 @@ func Test_isTest(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "woodpecker",
+			args: args{
+				s: "ci/woodpecker/pr/build",
+			},
+			want: true,
+		},
+		{
+			name: "forgejo actions",
+			args: args{
+				s: "https://codeberg.org/owner/repo/actions/runs/12/jobs/0",
+			},
+			want: true,
+		},
 		{
 			name: "non-existing",
 			args: args{
@@ -201,3 +215,21 @@ func Test_prHasSuccessfulCheck(t *testing.T) {
 		}
 	}
 }
+
+func TestCITestsConfigs(t *testing.T) {
+	t.Parallel()
+	dl := scut.TestDetailLogger{}
+	got := CITests(CheckCITests, &checker.CITestData{
+		Configs: []checker.CIConfig{
+			{System: "Jenkins", File: checker.File{Path: "Jenkinsfile"}, RunsTests: true},
+			{System: "Travis CI", File: checker.File{Path: ".travis.yml"}},
+		},
+	}, &dl)
+	want := scut.TestReturn{
+		Score:        checker.InconclusiveResultScore,
+		NumberOfInfo: 2,
+	}
+	if !scut.ValidateTestReturn(t, "configs", &want, &got, &dl) {
+		t.Errorf("CITests() = %v", got)
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/ossf/scorecard/v4/checker"
 	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
 )
 
 type reviewScore int
@@ -31,6 +32,11 @@ const (
 	reviewedOutsideGithub reviewScore = 1 // Full marks until we can check review platforms outside of GitHub
 )
 
+const (
+	webEditsRule        = "NoDirectWebEdits"
+	unlinkedAuthorsRule = "CommitAuthorsLinked"
+)
+
 // CodeReview applies the score policy for the Code-Review check.
 func CodeReview(name string, dl checker.DetailLogger, r *checker.CodeReviewData) checker.CheckResult {
 	if r == nil {
@@ -42,6 +48,18 @@ func CodeReview(name string, dl checker.DetailLogger, r *checker.CodeReviewData)
 		return checker.CreateInconclusiveResult(name, "no commits found")
 	}
 
+	if err := logCommitIntegrity(dl, &r.Integrity); err != nil {
+		return checker.CreateRuntimeErrorResult(name, err)
+	}
+
+	if r.Summary.Changesets > 0 {
+		dl.Info(&checker.LogMessage{
+			Text: fmt.Sprintf("%d out of %d changesets self-merged, %d approved by a single maintainer, "+
+				"%d independently reviewed", r.Summary.SelfMerged, r.Summary.Changesets,
+				r.Summary.SingleMaintainerApproved, r.Summary.IndependentlyReviewed),
+		})
+	}
+
 	foundHumanReviewActivity := false
 	foundBotReviewActivity := false
 	nUnreviewedHumanChanges := 0
@@ -95,6 +113,23 @@ func CodeReview(name string, dl checker.DetailLogger, r *checker.CodeReviewData)
 	return checker.CreateMaxScoreResult(name, "all changesets reviewed")
 }
 
+// IndependentCodeReview scores the Code-Review check by the share of GitHub
+// changesets that were independently reviewed.
+func IndependentCodeReview(name string, dl checker.DetailLogger, r *checker.CodeReviewData) checker.CheckResult {
+	if r == nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "empty raw data")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	s := r.Summary
+	total := s.SelfMerged + s.SingleMaintainerApproved + s.IndependentlyReviewed
+	if total == 0 {
+		return checker.CreateInconclusiveResult(name, "no GitHub changesets found")
+	}
+	reason := fmt.Sprintf("%d out of %d GitHub changesets independently reviewed", s.IndependentlyReviewed, total)
+	return checker.CreateProportionalScoreResult(name, reason, s.IndependentlyReviewed, total)
+}
+
 func reviewScoreForChangeset(changeset *checker.Changeset) (score reviewScore) {
 	if changeset.ReviewPlatform != "" && changeset.ReviewPlatform != checker.ReviewPlatformGitHub {
 		return reviewedOutsideGithub
@@ -111,3 +146,29 @@ func reviewScoreForChangeset(changeset *checker.Changeset) (score reviewScore) {
 
 	return noReview
 }
+
+// logCommitIntegrity logs the web edits and the commits by authors without
+// an account. They do not affect the score.
+func logCommitIntegrity(dl checker.DetailLogger, integrity *checker.CommitIntegrity) error {
+	log := func(rule, sha, text string) error {
+		f, err := finding.New(rules, rule)
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		}
+		f = f.WithMessage(fmt.Sprintf("commit %s %s", sha, text)).
+			WithRemediationMetadata(map[string]string{"commit": sha})
+		dl.Warn(&checker.LogMessage{Finding: f})
+		return nil
+	}
+	for _, sha := range integrity.WebEdits {
+		if err := log(webEditsRule, sha, "was made in the web interface without a pull request"); err != nil {
+			return err
+		}
+	}
+	for _, sha := range integrity.UnlinkedAuthors {
+		if err := log(unlinkedAuthorsRule, sha, "is authored by an email not associated with any account"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -211,3 +211,68 @@ func TestCodeReview(t *testing.T) {
 		})
 	}
 }
+
+func TestIndependentCodeReview(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		rawData  *checker.CodeReviewData
+		expected scut.TestReturn
+	}{
+		{
+			name: "NullRawData",
+			expected: scut.TestReturn{
+				Error: sce.ErrScorecardInternal,
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name: "NoGitHubChangesets",
+			rawData: &checker.CodeReviewData{
+				Summary: checker.CodeReviewSummary{Changesets: 2},
+			},
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name: "HalfIndependentlyReviewed",
+			rawData: &checker.CodeReviewData{
+				Summary: checker.CodeReviewSummary{
+					Changesets:               5,
+					SelfMerged:               1,
+					SingleMaintainerApproved: 1,
+					IndependentlyReviewed:    2,
+				},
+			},
+			expected: scut.TestReturn{
+				Score: 5,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			res := IndependentCodeReview(tt.name, &dl, tt.rawData)
+			scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl)
+		})
+	}
+}
+
+func TestLogCommitIntegrity(t *testing.T) {
+	t.Parallel()
+	dl := scut.TestDetailLogger{}
+	integrity := checker.CommitIntegrity{
+		WebEdits:        []string{"abc"},
+		UnlinkedAuthors: []string{"def", "ghi"},
+	}
+	if err := logCommitIntegrity(&dl, &integrity); err != nil {
+		t.Fatalf("logCommitIntegrity() = %v", err)
+	}
+	expected := scut.TestReturn{NumberOfWarn: 3}
+	if !scut.ValidateTestReturn(t, "commit integrity", &expected, &checker.CheckResult{}, &dl) {
+		t.Fail()
+	}
+}
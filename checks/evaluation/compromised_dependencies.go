@@ -0,0 +1,60 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// CompromisedDependencies applies the score policy for the
+// Compromised-Dependencies check.
+func CompromisedDependencies(name string, dl checker.DetailLogger,
+	r *checker.CompromisedDependenciesData,
+) checker.CheckResult {
+	if r == nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "empty raw data")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+	if r.Entries == 0 {
+		return checker.CreateInconclusiveResult(name, "no denylist of compromised dependencies is configured")
+	}
+
+	for i := range r.Dependencies {
+		d := &r.Dependencies[i]
+		text := fmt.Sprintf("%s %s@%s is denylisted by %s", d.Entry.Ecosystem, d.Name, d.Version, d.Entry.ID)
+		if d.Entry.Description != "" {
+			text += ": " + d.Entry.Description
+		}
+		dl.Warn(&checker.LogMessage{
+			Path:    d.File.Path,
+			Type:    d.File.Type,
+			Offset:  d.File.Offset,
+			Snippet: d.File.Snippet,
+			Text:    text,
+		})
+	}
+
+	// A single compromised dependency can run malicious code in the builds
+	// of the project, or in those of its users.
+	if len(r.Dependencies) > 0 {
+		return checker.CreateMinScoreResult(name,
+			fmt.Sprintf("%d dependencies are known to be compromised", len(r.Dependencies)))
+	}
+	return checker.CreateMaxScoreResult(name,
+		fmt.Sprintf("no dependency matches the %d entries of the denylist", r.Entries))
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestCompromisedDependencies(t *testing.T) {
+	t.Parallel()
+	entry := &checker.DenylistEntry{
+		ID:          "ctx-2022",
+		Description: "ctx was hijacked to leak environment variables",
+		Ecosystem:   checker.DenylistPyPI,
+		Name:        "ctx",
+	}
+	tests := []struct {
+		name string
+		r    *checker.CompromisedDependenciesData
+		want scut.TestReturn
+	}{
+		{
+			name: "nil raw data",
+			want: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+				Error: sce.ErrScorecardInternal,
+			},
+		},
+		{
+			name: "no denylist",
+			r:    &checker.CompromisedDependenciesData{},
+			want: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name: "no compromised dependency",
+			r:    &checker.CompromisedDependenciesData{Entries: 3},
+			want: scut.TestReturn{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name: "compromised dependencies",
+			r: &checker.CompromisedDependenciesData{
+				Entries: 3,
+				Dependencies: []checker.CompromisedDependency{
+					{
+						Entry:   entry,
+						File:    checker.File{Path: "requirements.txt", Offset: 1},
+						Name:    "ctx",
+						Version: "0.2.6",
+					},
+					{
+						Entry:   entry,
+						File:    checker.File{Path: "docs/requirements.txt", Offset: 4},
+						Name:    "ctx",
+						Version: "0.2.2",
+					},
+				},
+			},
+			want: scut.TestReturn{
+				Score:        checker.MinResultScore,
+				NumberOfWarn: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			got := CompromisedDependencies(tt.name, &dl, tt.r)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.want, &got, &dl) {
+				t.Errorf("CompromisedDependencies() = %v", got)
+			}
+		})
+	}
+}
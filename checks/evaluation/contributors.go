@@ -51,6 +51,10 @@ func Contributors(name string, dl checker.DetailLogger,
 		for _, comp := range user.Companies {
 			entities[comp] = true
 		}
+
+		for _, domain := range user.EmailDomains {
+			entities[domainEntity(domain)] = true
+		}
 	}
 
 	names := []string{}
@@ -73,3 +77,11 @@ func Contributors(name string, dl checker.DetailLogger,
 	reason := fmt.Sprintf("%d different organizations found", len(entities))
 	return checker.CreateProportionalScoreResult(name, reason, len(entities), numberCompaniesForTopScore)
 }
+
+// domainEntity returns the name of the organization owning an email domain,
+// so that "example" claimed as a company and "example.com" used in commits
+// count as the same organization.
+func domainEntity(domain string) string {
+	name, _, _ := strings.Cut(domain, ".")
+	return name
+}
@@ -37,6 +37,18 @@ func DangerousWorkflow(name string, dl checker.DetailLogger,
 			text = fmt.Sprintf("untrusted code checkout '%v'", e.File.Snippet)
 		case checker.DangerousWorkflowScriptInjection:
 			text = fmt.Sprintf("script injection with untrusted input '%v'", e.File.Snippet)
+		case checker.DangerousWorkflowCustomRule:
+			if e.Rule == nil {
+				err := sce.WithMessage(sce.ErrScorecardInternal, "custom rule is nil")
+				return checker.CreateRuntimeErrorResult(name, err)
+			}
+			text = fmt.Sprintf("custom rule '%s' violated", e.Rule.ID)
+			if e.Rule.Description != "" {
+				text = fmt.Sprintf("%s: %s", text, e.Rule.Description)
+			}
+			if e.File.Snippet != "" {
+				text = fmt.Sprintf("%s '%v'", text, e.File.Snippet)
+			}
 		default:
 			err := sce.WithMessage(sce.ErrScorecardInternal, "invalid type")
 			return checker.CreateRuntimeErrorResult(name, err)
@@ -51,6 +63,8 @@ func DangerousWorkflow(name string, dl checker.DetailLogger,
 		})
 	}
 
+	logSuppressions(dl, r.Suppressed)
+
 	if len(r.Workflows) > 0 {
 		return createResult(name, checker.MinResultScore)
 	}
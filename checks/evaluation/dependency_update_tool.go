@@ -60,6 +60,27 @@ func DependencyUpdateTool(name string, dl checker.DetailLogger,
 		})
 	}
 
+	// Score the coverage of the manifests when the configuration could be analyzed.
+	covered := 0
+	for i := range r.Manifests {
+		m := &r.Manifests[i]
+		if m.Covered {
+			covered++
+			continue
+		}
+		dl.Warn(&checker.LogMessage{
+			Path:   m.File.Path,
+			Type:   m.File.Type,
+			Offset: m.File.Offset,
+			Text:   fmt.Sprintf("%s dependencies are not updated by %s", m.Ecosystem, r.Tools[0].Name),
+		})
+	}
+	if covered < len(r.Manifests) {
+		return checker.CreateProportionalScoreResult(name,
+			fmt.Sprintf("update tool covers %d out of %d dependency manifests", covered, len(r.Manifests)),
+			covered, len(r.Manifests))
+	}
+
 	// High score result.
 	return checker.CreateMaxScoreResult(name, "update tool detected")
 }
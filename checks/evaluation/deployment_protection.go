@@ -0,0 +1,185 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package evaluation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+const (
+	requiredReviewersScore = 5
+	waitTimerScore         = 2
+	restrictedBranchScore  = 3
+
+	environmentsRule = "EnvironmentsWithSecretsUsed"
+)
+
+// productionWords are the words of environment names, e.g. "pypi-release"
+// or "Production", which identify production-like environments.
+var productionWords = map[string]bool{
+	"prod":       true,
+	"prd":        true,
+	"production": true,
+	"live":       true,
+	"release":    true,
+	"publish":    true,
+}
+
+// DeploymentProtection applies the score policy for the Deployment-Protection check.
+func DeploymentProtection(name string, dl checker.DetailLogger,
+	r *checker.DeploymentProtectionData,
+) checker.CheckResult {
+	if r == nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "empty raw data")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	if err := logUnusedEnvironments(dl, r); err != nil {
+		return checker.CreateRuntimeErrorResult(name, err)
+	}
+
+	// Several jobs often deploy to the same environment,
+	// so each environment is only scored once.
+	deployments := map[string]*checker.Deployment{}
+	var names []string
+	for i := range r.Deployments {
+		d := &r.Deployments[i]
+		if !isProductionEnvironment(d.Environment) {
+			dl.Debug(&checker.LogMessage{
+				Path:   d.File.Path,
+				Type:   d.File.Type,
+				Offset: d.File.Offset,
+				Text:   fmt.Sprintf("deployment to non-production environment '%s' ignored", d.Environment),
+			})
+			continue
+		}
+		key := strings.ToLower(d.Environment)
+		if _, ok := deployments[key]; !ok {
+			deployments[key] = d
+			names = append(names, key)
+		}
+	}
+	if len(names) == 0 {
+		return checker.CreateInconclusiveResult(name, "no deployments to production environments detected")
+	}
+	if r.Environments == nil {
+		return checker.CreateInconclusiveResult(name, "could not retrieve the deployment environments")
+	}
+
+	envs := map[string]*clients.Environment{}
+	for i := range r.Environments {
+		envs[strings.ToLower(r.Environments[i].Name)] = &r.Environments[i]
+	}
+
+	score := checker.MaxResultScore
+	for _, key := range names {
+		s := environmentScore(dl, deployments[key], envs[key])
+		if s < score {
+			score = s
+		}
+	}
+
+	if score == checker.MaxResultScore {
+		return checker.CreateMaxScoreResult(name, "production deployment environments are protected")
+	}
+	return checker.CreateResultWithScore(name, "production deployment environments are not fully protected", score)
+}
+
+func environmentScore(dl checker.DetailLogger, d *checker.Deployment, env *clients.Environment) int {
+	msg := func(text string) *checker.LogMessage {
+		return &checker.LogMessage{
+			Path:   d.File.Path,
+			Type:   d.File.Type,
+			Offset: d.File.Offset,
+			Text:   text,
+		}
+	}
+	if env == nil {
+		// GitHub creates environments without any protection on first use.
+		dl.Warn(msg(fmt.Sprintf("environment '%s' has no protection rules configured", d.Environment)))
+		return checker.MinResultScore
+	}
+
+	score := 0
+	if env.RequiredReviewers > 0 {
+		dl.Info(msg(fmt.Sprintf("environment '%s' requires a review of deployments", env.Name)))
+		score += requiredReviewersScore
+	} else {
+		dl.Warn(msg(fmt.Sprintf("environment '%s' does not require a review of deployments", env.Name)))
+	}
+
+	if env.WaitTimer > 0 {
+		dl.Info(msg(fmt.Sprintf("environment '%s' delays deployments by %d minutes", env.Name, env.WaitTimer)))
+		score += waitTimerScore
+	} else {
+		dl.Warn(msg(fmt.Sprintf("environment '%s' has no wait timer", env.Name)))
+	}
+
+	if env.ProtectedBranches || env.CustomBranchPolicies {
+		dl.Info(msg(fmt.Sprintf("environment '%s' restricts the branches which can deploy", env.Name)))
+		score += restrictedBranchScore
+	} else {
+		dl.Warn(msg(fmt.Sprintf("environment '%s' allows deployments from any branch", env.Name)))
+	}
+	return score
+}
+
+// logUnusedEnvironments logs whether the environments with secrets are
+// deployed to by the workflows. It does not affect the score.
+func logUnusedEnvironments(dl checker.DetailLogger, r *checker.DeploymentProtectionData) error {
+	used := map[string]bool{}
+	for i := range r.Deployments {
+		used[strings.ToLower(r.Deployments[i].Environment)] = true
+	}
+	for i := range r.Environments {
+		env := &r.Environments[i]
+		if env.Secrets == nil || *env.Secrets == 0 {
+			continue
+		}
+		f, err := finding.New(rules, environmentsRule)
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		}
+		f = f.WithRemediationMetadata(map[string]string{"environment": env.Name})
+		if used[strings.ToLower(env.Name)] {
+			f = f.WithMessage(fmt.Sprintf("environment '%s' with %d secret(s) is deployed to", env.Name, *env.Secrets)).
+				WithOutcome(finding.OutcomePositive)
+			dl.Info(&checker.LogMessage{Finding: f})
+			continue
+		}
+		f = f.WithMessage(fmt.Sprintf("environment '%s' holds %d secret(s) but no workflow deploys to it",
+			env.Name, *env.Secrets))
+		dl.Warn(&checker.LogMessage{Finding: f})
+	}
+	return nil
+}
+
+func isProductionEnvironment(name string) bool {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if productionWords[w] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestDeploymentProtection(t *testing.T) {
+	t.Parallel()
+	secrets := 2
+	deploy := func(env string) checker.Deployment {
+		return checker.Deployment{
+			Environment: env,
+			File:        checker.File{Path: ".github/workflows/release.yml"},
+		}
+	}
+	tests := []struct {
+		name string
+		r    *checker.DeploymentProtectionData
+		want scut.TestReturn
+	}{
+		{
+			name: "nil raw data",
+			want: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+				Error: sce.ErrScorecardInternal,
+			},
+		},
+		{
+			name: "no production deployments",
+			r: &checker.DeploymentProtectionData{
+				Deployments:  []checker.Deployment{deploy("github-pages")},
+				Environments: []clients.Environment{},
+			},
+			want: scut.TestReturn{
+				Score:         checker.InconclusiveResultScore,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "environments with secrets",
+			r: &checker.DeploymentProtectionData{
+				Deployments: []checker.Deployment{deploy("github-pages")},
+				Environments: []clients.Environment{
+					{Name: "GitHub-Pages", Secrets: &secrets},
+					{Name: "npm", Secrets: &secrets},
+					{Name: "staging"},
+				},
+			},
+			want: scut.TestReturn{
+				Score:         checker.InconclusiveResultScore,
+				NumberOfInfo:  1,
+				NumberOfWarn:  1,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "environments not retrieved",
+			r: &checker.DeploymentProtectionData{
+				Deployments: []checker.Deployment{deploy("prod")},
+			},
+			want: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name: "reviewers only, deployed by several jobs",
+			r: &checker.DeploymentProtectionData{
+				Deployments:  []checker.Deployment{deploy("production"), deploy("Production")},
+				Environments: []clients.Environment{{Name: "Production", RequiredReviewers: 2}},
+			},
+			want: scut.TestReturn{
+				Score:        requiredReviewersScore,
+				NumberOfInfo: 1,
+				NumberOfWarn: 2,
+			},
+		},
+		{
+			name: "lowest environment score",
+			r: &checker.DeploymentProtectionData{
+				Deployments: []checker.Deployment{deploy("npm-publish"), deploy("live")},
+				Environments: []clients.Environment{
+					{Name: "npm-publish", RequiredReviewers: 1, WaitTimer: 5, CustomBranchPolicies: true},
+					{Name: "live", ProtectedBranches: true},
+				},
+			},
+			want: scut.TestReturn{
+				Score:        restrictedBranchScore,
+				NumberOfInfo: 4,
+				NumberOfWarn: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			got := DeploymentProtection(tt.name, &dl, tt.r)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.want, &got, &dl) {
+				t.Fatalf("DeploymentProtection() = %v", got)
+			}
+		})
+	}
+}
+
+func Test_isProductionEnvironment(t *testing.T) {
+	t.Parallel()
+	tests := map[string]bool{
+		"production":   true,
+		"Prod":         true,
+		"pypi-release": true,
+		"prod_eu":      true,
+		"staging":      false,
+		"github-pages": false,
+		"product-docs": false,
+	}
+	for name, want := range tests {
+		if got := isProductionEnvironment(name); got != want {
+			t.Errorf("isProductionEnvironment(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -16,11 +16,21 @@ package evaluation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ossf/scorecard/v4/checker"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
 
+const (
+	// staleOSSFuzzBuildDays is the number of days after which an OSS-Fuzz
+	// integration without a successful build is considered broken.
+	staleOSSFuzzBuildDays = 90
+	// staleOSSFuzzBuildScore is the score of projects only fuzzed by a broken
+	// OSS-Fuzz integration.
+	staleOSSFuzzBuildScore = 5
+)
+
 // Fuzzing applies the score policy for the Fuzzing check.
 func Fuzzing(name string, dl checker.DetailLogger,
 	r *checker.FuzzingData,
@@ -49,6 +59,46 @@ func Fuzzing(name string, dl checker.DetailLogger,
 		}
 		fuzzers = append(fuzzers, fuzzer.Name)
 	}
+	if stale := ossFuzzBuildStale(dl, r); stale && len(fuzzers) == 1 {
+		return checker.CreateResultWithScore(name,
+			fmt.Sprintf("project is fuzzed with %v but its OSS-Fuzz build is broken", fuzzers),
+			staleOSSFuzzBuildScore)
+	}
 	return checker.CreateMaxScoreResult(name,
 		fmt.Sprintf("project is fuzzed with %v", fuzzers))
 }
+
+// ossFuzzBuildStale logs the OSS-Fuzz build status of the project and returns
+// whether it has not built successfully for staleOSSFuzzBuildDays.
+// Fuzzing a stale build gives a false assurance.
+func ossFuzzBuildStale(dl checker.DetailLogger, r *checker.FuzzingData) bool {
+	status := r.OSSFuzzBuild
+	if status == nil {
+		return false
+	}
+	const dateFormat = "2006-01-02"
+	threshold := time.Now().AddDate(0 /*years*/, 0 /*months*/, -1*staleOSSFuzzBuildDays /*days*/)
+	switch {
+	case status.LastSuccessfulBuild.IsZero():
+		dl.Warn(&checker.LogMessage{
+			Text: "no successful OSS-Fuzz build found",
+		})
+		return true
+	case status.LastSuccessfulBuild.Before(threshold):
+		dl.Warn(&checker.LogMessage{
+			Text: fmt.Sprintf("OSS-Fuzz build broken since %s",
+				status.LastSuccessfulBuild.Format(dateFormat)),
+		})
+		return true
+	case !status.LastBuildSucceeded:
+		dl.Info(&checker.LogMessage{
+			Text: fmt.Sprintf("latest OSS-Fuzz build failed, last successful build on %s",
+				status.LastSuccessfulBuild.Format(dateFormat)),
+		})
+	default:
+		dl.Info(&checker.LogMessage{
+			Text: fmt.Sprintf("OSS-Fuzz built successfully on %s", status.LastSuccessfulBuild.Format(dateFormat)),
+		})
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+var hiddenCharacterDescriptions = map[checker.HiddenCharacterType]string{
+	checker.HiddenCharacterBidi:      "bidirectional control character",
+	checker.HiddenCharacterInvisible: "invisible character",
+	checker.HiddenCharacterHomoglyph: "letter of another script in an identifier with Latin letters",
+}
+
+// HiddenCharacters applies the score policy for the Hidden-Characters check.
+func HiddenCharacters(name string, dl checker.DetailLogger,
+	r *checker.HiddenCharactersData,
+) checker.CheckResult {
+	if r == nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "empty raw data")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	// Bidirectional control characters can hide code from reviewers, while
+	// the other characters may also be typos or mangled copies.
+	score := checker.MaxResultScore
+	bidi := false
+	for i := range r.Characters {
+		c := &r.Characters[i]
+		dl.Warn(&checker.LogMessage{
+			Path:    c.File.Path,
+			Type:    c.File.Type,
+			Offset:  c.File.Offset,
+			Snippet: c.File.Snippet,
+			Text: fmt.Sprintf("%s U+%04X found at column %d",
+				hiddenCharacterDescriptions[c.Type], c.CodePoint, c.Column),
+		})
+		if c.Type == checker.HiddenCharacterBidi {
+			bidi = true
+		}
+		if score > checker.MinResultScore {
+			score--
+		}
+	}
+
+	switch {
+	case bidi:
+		return checker.CreateMinScoreResult(name, "bidirectional control characters found in source files")
+	case score == checker.MaxResultScore:
+		return checker.CreateMaxScoreResult(name, "no hidden characters found in source files")
+	default:
+		return checker.CreateResultWithScore(name,
+			fmt.Sprintf("%d hidden or confusable characters found in source files", len(r.Characters)), score)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestHiddenCharacters(t *testing.T) {
+	t.Parallel()
+	homoglyph := checker.HiddenCharacter{
+		Type:      checker.HiddenCharacterHomoglyph,
+		File:      checker.File{Path: "Dockerfile", Offset: 1},
+		Column:    19,
+		CodePoint: 0x430,
+	}
+	tests := []struct {
+		name string
+		r    *checker.HiddenCharactersData
+		want scut.TestReturn
+	}{
+		{
+			name: "nil raw data",
+			want: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+				Error: sce.ErrScorecardInternal,
+			},
+		},
+		{
+			name: "no hidden characters",
+			r:    &checker.HiddenCharactersData{},
+			want: scut.TestReturn{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name: "confusable characters",
+			r: &checker.HiddenCharactersData{
+				Characters: []checker.HiddenCharacter{
+					homoglyph,
+					{
+						Type:      checker.HiddenCharacterInvisible,
+						File:      checker.File{Path: "setup.py", Offset: 2},
+						Column:    7,
+						CodePoint: 0x200B,
+					},
+				},
+			},
+			want: scut.TestReturn{
+				Score:        checker.MaxResultScore - 2,
+				NumberOfWarn: 2,
+			},
+		},
+		{
+			name: "bidirectional control character",
+			r: &checker.HiddenCharactersData{
+				Characters: []checker.HiddenCharacter{
+					homoglyph,
+					{
+						Type:      checker.HiddenCharacterBidi,
+						File:      checker.File{Path: "src/auth.js", Offset: 2},
+						Column:    19,
+						CodePoint: 0x202E,
+					},
+				},
+			},
+			want: scut.TestReturn{
+				Score:        checker.MinResultScore,
+				NumberOfWarn: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			got := HiddenCharacters(tt.name, &dl, tt.r)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.want, &got, &dl) {
+				t.Errorf("HiddenCharacters() = %v", got)
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package evaluation
 
 import (
+	"fmt"
+
 	"github.com/ossf/scorecard/v4/checker"
 	sce "github.com/ossf/scorecard/v4/errors"
 	"github.com/ossf/scorecard/v4/finding"
@@ -78,6 +80,17 @@ func License(name string, dl checker.DetailLogger,
 		return checker.CreateRuntimeErrorResult(name, e)
 	}
 
+	// Licenses of subdirectories are reported without affecting the score.
+	for idx := range r.SubdirectoryLicenses {
+		f := &r.SubdirectoryLicenses[idx]
+		dl.Info(&checker.LogMessage{
+			Path:   f.File.Path,
+			Type:   finding.FileTypeSource,
+			Offset: 1,
+			Text:   fmt.Sprintf("license file found in subdirectory: %s", f.LicenseInformation.SpdxID),
+		})
+	}
+
 	// Apply the policy evaluation.
 	if r.LicenseFiles == nil || len(r.LicenseFiles) == 0 {
 		return checker.CreateMinScoreResult(name, "license file not detected")
@@ -40,6 +40,29 @@ func Maintained(name string, dl checker.DetailLogger, r *checker.MaintainedData)
 		return checker.CreateMinScoreResult(name, "repo is marked as archived")
 	}
 
+	mode := ""
+	if d := r.MaintenanceDeclaration; d != nil {
+		text := fmt.Sprintf("maintainers declare the maintenance status: %s", d.Status)
+		if d.Reason != "" {
+			text = fmt.Sprintf("%s (%s)", text, d.Reason)
+		}
+		msg := &checker.LogMessage{
+			Path: d.File.Path,
+			Type: d.File.Type,
+			Text: text,
+		}
+		switch d.Status {
+		case checker.MaintenanceUnmaintained:
+			dl.Warn(msg)
+			return checker.CreateMinScoreResult(name, "repo is declared unmaintained")
+		case checker.MaintenanceFeatureComplete:
+			mode = " (feature-complete: bug and security fixes only)"
+		case checker.MaintenanceSecurityFixesOnly:
+			mode = " (maintenance mode: security fixes only)"
+		}
+		dl.Info(msg)
+	}
+
 	// If not explicitly marked archived, look for activity in past `lookBackDays`.
 	threshold := time.Now().AddDate(0 /*years*/, 0 /*months*/, -1*lookBackDays /*days*/)
 	commitsWithinThreshold := 0
@@ -69,10 +92,44 @@ func Maintained(name string, dl checker.DetailLogger, r *checker.MaintainedData)
 		}
 	}
 
-	return checker.CreateProportionalScoreResult(name, fmt.Sprintf(
-		"%d commit(s) out of %d and %d issue activity out of %d found in the last %d days",
-		commitsWithinThreshold, len(r.DefaultBranchCommits), issuesUpdatedWithinThreshold, len(r.Issues), lookBackDays),
+	result := checker.CreateProportionalScoreResult(name, fmt.Sprintf(
+		"%d commit(s) out of %d and %d issue activity out of %d found in the last %d days%s",
+		commitsWithinThreshold, len(r.DefaultBranchCommits), issuesUpdatedWithinThreshold, len(r.Issues), lookBackDays,
+		mode),
 		commitsWithinThreshold+issuesUpdatedWithinThreshold, activityPerWeek*lookBackDays/daysInOneWeek)
+	if dismissals := secretScanningDismissals(dl, r.AuditLog); dismissals > 0 {
+		result.Score -= dismissals
+		if result.Score < checker.MinResultScore {
+			result.Score = checker.MinResultScore
+		}
+		result.Reason = fmt.Sprintf("%s, %d secret scanning alert(s) dismissed", result.Reason, dismissals)
+	}
+	return result
+}
+
+// secretScanningDismissals logs the secret scanning alerts closed without
+// revoking the secret found in the audit log, and returns their number.
+func secretScanningDismissals(dl checker.DetailLogger, auditLog *checker.AuditLogData) int {
+	if auditLog == nil {
+		return 0
+	}
+	dismissals := 0
+	for i := range auditLog.Events {
+		e := &auditLog.Events[i]
+		if !e.IsSecretScanningDismissal() {
+			continue
+		}
+		dismissals++
+		resolution := e.Resolution
+		if resolution == "" {
+			resolution = "unknown"
+		}
+		dl.Warn(&checker.LogMessage{
+			Text: fmt.Sprintf("audit log: secret scanning alert dismissed by %s on %s (resolution: %s)",
+				e.Actor, e.CreatedAt.Format(time.RFC3339), resolution),
+		})
+	}
+	return dismissals
 }
 
 // hasActivityByCollaboratorOrHigher returns true if the issue was created or commented on by an
@@ -0,0 +1,69 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestMaintainedSecretScanningDismissals(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	commits := make([]clients.Commit, 0, 20)
+	for i := 0; i < 20; i++ {
+		commits = append(commits, clients.Commit{CommittedDate: now.AddDate(0, 0, -i)})
+	}
+	tests := []struct {
+		name     string
+		auditLog *checker.AuditLogData
+		expected scut.TestReturn
+	}{
+		{
+			name:     "audit log not available",
+			expected: scut.TestReturn{Score: checker.MaxResultScore},
+		},
+		{
+			name: "alerts dismissed",
+			auditLog: &checker.AuditLogData{Events: []clients.AuditLogEvent{
+				{Action: clients.AuditLogSecretScanningAlertResolve, Actor: "dev", Resolution: "wont_fix"},
+				{Action: clients.AuditLogSecretScanningAlertResolve, Actor: "dev"},
+				// Revoking the secret is the expected fix.
+				{Action: clients.AuditLogSecretScanningAlertResolve, Actor: "dev", Resolution: "revoked"},
+			}},
+			expected: scut.TestReturn{Score: checker.MaxResultScore - 2, NumberOfWarn: 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			r := &checker.MaintainedData{
+				CreatedAt:            now.AddDate(-1, 0, 0),
+				DefaultBranchCommits: commits,
+				AuditLog:             tt.auditLog,
+			}
+			got := Maintained(tt.name, &dl, r)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &got, &dl) {
+				t.Errorf("Maintained() = %v", got)
+			}
+		})
+	}
+}
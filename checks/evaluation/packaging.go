@@ -15,12 +15,23 @@
 package evaluation
 
 import (
+	"embed"
 	"fmt"
+	"strings"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients/registry"
 	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
 )
 
+//go:embed *.yml
+var rules embed.FS
+
+// packageRepoRule is the rule of the findings on whether the packages
+// declared by the repo point back at it.
+const packageRepoRule = "PackageRepoMatchesRepo"
+
 // Packaging applies the score policy for the Packaging check.
 func Packaging(name string, dl checker.DetailLogger, r *checker.PackagingData) checker.CheckResult {
 	if r == nil {
@@ -47,9 +58,16 @@ func Packaging(name string, dl checker.DetailLogger, r *checker.PackagingData) c
 			return checker.CreateRuntimeErrorResult(name, err)
 		}
 		dl.Info(&msg)
+		logPackagingVersions(dl, p)
+	}
+
+	matches, err := logRegistryPackages(dl, r.RegistryPackages)
+	if err != nil {
+		return checker.CreateRuntimeErrorResult(name, err)
 	}
 
 	if pass {
+		logRegistryPackage(dl, r.Registry)
 		return checker.CreateMaxScoreResult(name,
 			"publishing workflow detected")
 	}
@@ -58,10 +76,107 @@ func Packaging(name string, dl checker.DetailLogger, r *checker.PackagingData) c
 		Text: "no GitHub publishing workflow detected",
 	})
 
+	// The repo was scanned from its package, or declares a package pointing
+	// back at it, which is therefore published.
+	if r.Registry != nil {
+		logRegistryPackage(dl, r.Registry)
+		return checker.CreateMaxScoreResult(name,
+			fmt.Sprintf("package published to %s", r.Registry.Ecosystem))
+	}
+	if matches != nil {
+		return checker.CreateMaxScoreResult(name,
+			fmt.Sprintf("package published to %s", matches.Ecosystem))
+	}
+
 	return checker.CreateInconclusiveResult(name,
 		"no published package detected")
 }
 
+// logPackagingVersions reports the releases built by the publishing
+// workflow runs, linking published versions back to the run and commit
+// that produced them.
+func logPackagingVersions(dl checker.DetailLogger, p checker.Package) {
+	for _, run := range p.Runs {
+		if run.Version == "" {
+			continue
+		}
+		dl.Info(&checker.LogMessage{
+			Path:   p.File.Path,
+			Type:   p.File.Type,
+			Offset: p.File.Offset,
+			Text: fmt.Sprintf("version %s published by run %s from commit %s",
+				run.Version, run.URL, run.HeadSHA),
+		})
+	}
+}
+
+// logRegistryPackages reports whether the packages declared by the
+// manifests of the repo point back at the repo, returning the first one which
+// does, if any.
+func logRegistryPackages(dl checker.DetailLogger, pkgs []checker.RegistryPackage) (*registry.Package, error) {
+	var matches *registry.Package
+	for i := range pkgs {
+		rp := &pkgs[i]
+		f, err := finding.New(rules, packageRepoRule)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		}
+		f = f.WithLocation(&finding.Location{
+			Type:  rp.Manifest.Type,
+			Value: rp.Manifest.Path,
+		}).WithRemediationMetadata(map[string]string{"manifest": rp.Manifest.Path})
+		p := rp.Package
+		if rp.RepoMatches {
+			if matches == nil {
+				matches = p
+			}
+			f = f.WithMessage(fmt.Sprintf("%s package %s declares this repo as its source repo",
+				p.Ecosystem, p.Name)).WithOutcome(finding.OutcomePositive)
+			dl.Info(&checker.LogMessage{Finding: f})
+			continue
+		}
+		declared := "no source repo"
+		switch {
+		case p.Repo != "":
+			declared = fmt.Sprintf("%s as its source repo", p.Repo)
+		case len(p.RepoCandidates) > 0:
+			declared = fmt.Sprintf("%s as its source repos", strings.Join(p.RepoCandidates, ", "))
+		}
+		f = f.WithMessage(fmt.Sprintf("%s package %s declared by %s is published with %s, not this repo: "+
+			"it may be published from a fork, hijacked or a vanity package", p.Ecosystem, p.Name, rp.Manifest.Path, declared))
+		dl.Warn(&checker.LogMessage{Finding: f})
+	}
+	return matches, nil
+}
+
+// logRegistryPackage reports the registry metadata of the package the scan
+// was started from, if any.
+func logRegistryPackage(dl checker.DetailLogger, p *registry.Package) {
+	if p == nil {
+		return
+	}
+	text := fmt.Sprintf("package %s published to %s, latest version %s",
+		p.Name, p.Ecosystem, p.LatestVersion)
+	if p.RepoConfidence != "" {
+		text += fmt.Sprintf(", mapped to its source repo with %s confidence", p.RepoConfidence)
+	}
+	dl.Info(&checker.LogMessage{Text: text})
+	if p.Maintainers >= 0 {
+		dl.Info(&checker.LogMessage{
+			Text: fmt.Sprintf("package %s has %d maintainers", p.Name, p.Maintainers),
+		})
+	}
+	if p.Provenance {
+		dl.Info(&checker.LogMessage{
+			Text: fmt.Sprintf("version %s of package %s published with provenance", p.LatestVersion, p.Name),
+		})
+		return
+	}
+	dl.Debug(&checker.LogMessage{
+		Text: fmt.Sprintf("no provenance found for version %s of package %s", p.LatestVersion, p.Name),
+	})
+}
+
 func createLogMessage(p checker.Package) (checker.LogMessage, error) {
 	var msg checker.LogMessage
 
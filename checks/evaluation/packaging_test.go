@@ -0,0 +1,141 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients/registry"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestPackaging(t *testing.T) {
+	t.Parallel()
+	workflow := checker.Package{
+		File: &checker.File{Path: ".github/workflows/publish.yml", Type: finding.FileTypeSource},
+		Runs: []checker.Run{{URL: "https://example.com/run/1"}},
+	}
+	pkg := &registry.Package{
+		Ecosystem:     registry.NPM,
+		Name:          "pkg",
+		LatestVersion: "1.0.0",
+		Maintainers:   2,
+		Provenance:    true,
+	}
+	manifest := checker.File{Path: "package.json", Type: finding.FileTypeSource}
+	tests := []struct {
+		name string
+		r    *checker.PackagingData
+		want scut.TestReturn
+	}{
+		{
+			name: "nil raw data",
+			want: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+				Error: sce.ErrScorecardInternal,
+			},
+		},
+		{
+			name: "no publishing workflow",
+			r:    &checker.PackagingData{},
+			want: scut.TestReturn{
+				Score:        checker.InconclusiveResultScore,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name: "publishing workflow",
+			r:    &checker.PackagingData{Packages: []checker.Package{workflow}},
+			want: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 1,
+			},
+		},
+		{
+			name: "publishing workflow of a registry package",
+			r:    &checker.PackagingData{Registry: pkg, Packages: []checker.Package{workflow}},
+			want: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 4,
+			},
+		},
+		{
+			name: "registry package without provenance",
+			r: &checker.PackagingData{Registry: &registry.Package{
+				Ecosystem:   registry.PyPI,
+				Name:        "pkg",
+				Maintainers: -1,
+			}},
+			want: scut.TestReturn{
+				Score:         checker.MaxResultScore,
+				NumberOfInfo:  1,
+				NumberOfWarn:  1,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "declared package pointing back at the repo",
+			r: &checker.PackagingData{RegistryPackages: []checker.RegistryPackage{
+				{Manifest: manifest, Package: pkg, RepoMatches: true},
+			}},
+			want: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 1,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name: "declared package pointing at another repo",
+			r: &checker.PackagingData{
+				Packages: []checker.Package{workflow},
+				RegistryPackages: []checker.RegistryPackage{
+					{Manifest: manifest, Package: &registry.Package{
+						Ecosystem: registry.NPM,
+						Name:      "pkg",
+						Repo:      "https://github.com/attacker/pkg",
+					}},
+				},
+			},
+			want: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 1,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name: "declared package without source repo",
+			r: &checker.PackagingData{RegistryPackages: []checker.RegistryPackage{
+				{Manifest: manifest, Package: &registry.Package{Ecosystem: registry.NPM, Name: "pkg"}},
+			}},
+			want: scut.TestReturn{
+				Score:        checker.InconclusiveResultScore,
+				NumberOfWarn: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			got := Packaging(tt.name, &dl, tt.r)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.want, &got, &dl) {
+				t.Errorf("Packaging() = %v", got)
+			}
+		})
+	}
+}
@@ -108,7 +108,7 @@ func applyScorePolicy(results *checker.TokenPermissionsData, c *checker.CheckReq
 
 			// We warn only for top-level.
 			if *r.LocationType == checker.PermissionLocationTop {
-				warnWithRemediation(dl, msg, remediationMetadata, loc, negativeRuleResults)
+				warnWithRemediation(dl, msg, remediationMetadata, loc, r.Patch, negativeRuleResults)
 			} else {
 				dl.Debug(msg)
 			}
@@ -119,7 +119,7 @@ func applyScorePolicy(results *checker.TokenPermissionsData, c *checker.CheckReq
 			}
 
 		case checker.PermissionLevelWrite:
-			warnWithRemediation(dl, msg, remediationMetadata, loc, negativeRuleResults)
+			warnWithRemediation(dl, msg, remediationMetadata, loc, r.Patch, negativeRuleResults)
 
 			// Group results by workflow name for score computation.
 			if err := updateWorkflowHashMap(hm, r); err != nil {
@@ -196,9 +196,12 @@ func createLogMsg(loct *checker.PermissionLocation) (*checker.LogMessage, error)
 }
 
 func warnWithRemediation(logger checker.DetailLogger, msg *checker.LogMessage,
-	rem *remediation.RemediationMetadata, loc *finding.Location,
+	rem *remediation.RemediationMetadata, loc *finding.Location, patch *string,
 	negativeRuleResults map[string]bool,
 ) {
+	if patch != nil && msg.Finding.Remediation != nil {
+		msg.Finding = msg.Finding.WithPatch(patch)
+	}
 	if loc != nil && loc.Value != "" {
 		msg.Finding = msg.Finding.WithRemediationMetadata(map[string]string{
 			"repo":     rem.Repo,
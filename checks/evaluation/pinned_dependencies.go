@@ -17,6 +17,7 @@ package evaluation
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks/fileparser"
@@ -96,6 +97,9 @@ func PinningDependencies(name string, c *checker.CheckRequest,
 		}
 	}
 
+	logSuppressions(dl, r.Suppressed)
+	logActionPins(dl, r.ActionPins)
+
 	// Generate scores and Info results.
 	// GitHub actions.
 	actionScore, err := createReturnForIsGitHubActionsWorkflowPinned(wp, dl)
@@ -138,6 +142,36 @@ func PinningDependencies(name string, c *checker.CheckRequest,
 		"dependency not pinned by hash detected", score, checker.MaxResultScore)
 }
 
+// logActionPins reports whether the commits actions are pinned to are
+// tagged by the repos of the actions. It does not change the score.
+func logActionPins(dl checker.DetailLogger, pins []checker.ActionPin) {
+	for i := range pins {
+		pin := &pins[i]
+		msg := &checker.LogMessage{
+			Path:      pin.Location.Path,
+			Type:      pin.Location.Type,
+			Offset:    pin.Location.Offset,
+			EndOffset: pin.Location.EndOffset,
+			Snippet:   pin.Location.Snippet,
+		}
+		switch {
+		case !pin.Verified:
+			if pin.Msg != nil {
+				msg.Text = *pin.Msg
+				dl.Debug(msg)
+			}
+		case len(pin.Tags) == 0:
+			msg.Text = fmt.Sprintf("%s pinned to a commit which is not tagged by %s: %s",
+				checker.DependencyUseTypeGHAction, pin.Repo, pin.SHA)
+			dl.Warn(msg)
+		default:
+			msg.Text = fmt.Sprintf("%s pinned to the commit of tag %s of %s",
+				checker.DependencyUseTypeGHAction, strings.Join(pin.Tags, ", "), pin.Repo)
+			dl.Info(msg)
+		}
+	}
+}
+
 func generateRemediation(remediationMd *remediation.RemediationMetadata, rr *checker.Dependency) *rule.Remediation {
 	switch rr.Type {
 	case checker.DependencyUseTypeGHAction:
@@ -94,6 +94,8 @@ func Test_PinningDependencies(t *testing.T) {
 	tests := []struct {
 		name         string
 		dependencies []checker.Dependency
+		suppressed   []checker.Suppression
+		actionPins   []checker.ActionPin
 		expected     scut.TestReturn
 	}{
 		{
@@ -162,6 +164,53 @@ func Test_PinningDependencies(t *testing.T) {
 				NumberOfDebug: 1,
 			},
 		},
+		{
+			name: "suppressed download then run",
+			suppressed: []checker.Suppression{
+				{
+					ID:     "Pinned-Dependencies",
+					Reason: "vendored installer",
+					Type:   string(checker.DependencyUseTypeDownloadThenRun),
+				},
+			},
+			expected: scut.TestReturn{
+				Error:        nil,
+				Score:        checker.MaxResultScore,
+				NumberOfWarn: 0,
+				NumberOfInfo: 6,
+			},
+		},
+		{
+			name: "verified action pins",
+			actionPins: []checker.ActionPin{
+				{
+					Location: &checker.File{},
+					Repo:     "actions/checkout",
+					SHA:      "8f4b7f84864484a7bf31766abe9204da3cbe65b3",
+					Tags:     []string{"v3.5.0", "v3"},
+					Verified: true,
+				},
+				{
+					Location: &checker.File{},
+					Repo:     "actions/checkout",
+					SHA:      "ee0669bd1cc54295c223e0bb666b733df41de1c5",
+					Verified: true,
+				},
+				{
+					Location: &checker.File{},
+					Repo:     "actions/setup-go",
+					SHA:      "4d34df0c2316fe8122ab82dc22947d607c0c91f9",
+					Msg:      asPointer("could not list the tags of actions/setup-go"),
+				},
+			},
+			expected: scut.TestReturn{
+				Error:         nil,
+				Score:         checker.MaxResultScore,
+				NumberOfWarn:  1,
+				NumberOfInfo:  6,
+				NumberOfDebug: 1,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -174,6 +223,8 @@ func Test_PinningDependencies(t *testing.T) {
 			actual := PinningDependencies("checkname", &c,
 				&checker.PinningDependenciesData{
 					Dependencies: tt.dependencies,
+					Suppressed:   tt.suppressed,
+					ActionPins:   tt.actionPins,
 				})
 
 			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &actual, &dl) {
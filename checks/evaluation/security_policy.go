@@ -20,6 +20,10 @@ import (
 	"github.com/ossf/scorecard/v4/finding"
 )
 
+// platformSecurityPolicyScore is the score of a security policy only known to
+// the hosting platform: its presence is credited like linked content.
+const platformSecurityPolicyScore = 6
+
 func scoreSecurityCriteria(f checker.File,
 	info []checker.SecurityPolicyInformation,
 	dl checker.DetailLogger,
@@ -126,6 +130,22 @@ func SecurityPolicy(name string, dl checker.DetailLogger, r *checker.SecurityPol
 	}
 
 	// Apply the policy evaluation.
+	if len(r.PolicyFiles) == 0 && r.PolicyURL != "" {
+		// The content of policies only known to the hosting platform
+		// cannot be evaluated, so they only receive partial credit.
+		dl.Info(&checker.LogMessage{
+			Path: r.PolicyURL,
+			Type: finding.FileTypeURL,
+			Text: "security policy detected by the hosting platform",
+		})
+		dl.Warn(&checker.LogMessage{
+			Path: r.PolicyURL,
+			Type: finding.FileTypeURL,
+			Text: "security policy file not found in the repo or its org, content not evaluated",
+		})
+		return checker.CreateResultWithScore(name, "security policy detected by the hosting platform",
+			platformSecurityPolicyScore)
+	}
 	if len(r.PolicyFiles) == 0 {
 		// If the file is unset, directly return as not detected.
 		return checker.CreateMinScoreResult(name, "security policy file not detected")
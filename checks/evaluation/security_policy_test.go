@@ -94,6 +94,18 @@ func TestSecurityPolicy(t *testing.T) {
 				Score: 0,
 			},
 		},
+		{
+			name: "test_security_policy_5",
+			args: args{
+				name: "test_security_policy_5",
+				r: &checker.SecurityPolicyData{
+					PolicyURL: "https://github.com/owner/repo/security/policy",
+				},
+			},
+			want: checker.CheckResult{
+				Score: platformSecurityPolicyScore,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,85 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package evaluation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+const (
+	// Jobs on custom labels may run on larger GitHub-hosted runners instead.
+	customRunnerForkScore = 5
+	selfHostedRunnerScore = 7
+)
+
+// SelfHostedRunners applies the score policy for the Self-Hosted-Runners check.
+func SelfHostedRunners(name string, dl checker.DetailLogger,
+	r *checker.SelfHostedRunnersData,
+) checker.CheckResult {
+	if r == nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "empty raw data")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	if r.Private {
+		return checker.CreateMaxScoreResult(name, "repository is private, its runners are not exposed to the public")
+	}
+
+	score := checker.MaxResultScore
+	for i := range r.Jobs {
+		job := &r.Jobs[i]
+		msg := &checker.LogMessage{
+			Path:   job.File.Path,
+			Type:   job.File.Type,
+			Offset: job.File.Offset,
+		}
+		labels := strings.Join(job.Labels, ", ")
+		jobScore := checker.MaxResultScore
+		switch {
+		case job.SelfHosted && len(job.ForkTriggers) > 0:
+			msg.Text = fmt.Sprintf("job runs on a self-hosted runner (%s) "+
+				"and can be triggered by pull requests from forks (%s)", labels, strings.Join(job.ForkTriggers, ", "))
+			dl.Warn(msg)
+			jobScore = checker.MinResultScore
+		case job.SelfHosted:
+			msg.Text = fmt.Sprintf("job runs on a self-hosted runner (%s) in a public repository", labels)
+			dl.Warn(msg)
+			jobScore = selfHostedRunnerScore
+		case len(job.ForkTriggers) > 0:
+			msg.Text = fmt.Sprintf("job runs on a custom runner (%s), which may be self-hosted, "+
+				"and can be triggered by pull requests from forks (%s)", labels, strings.Join(job.ForkTriggers, ", "))
+			dl.Warn(msg)
+			jobScore = customRunnerForkScore
+		default:
+			msg.Text = fmt.Sprintf("job runs on a custom runner (%s)", labels)
+			dl.Debug(msg)
+		}
+		if jobScore < score {
+			score = jobScore
+		}
+	}
+
+	switch score {
+	case checker.MaxResultScore:
+		return checker.CreateMaxScoreResult(name, "no self-hosted runners are exposed to the public")
+	case checker.MinResultScore:
+		return checker.CreateMinScoreResult(name, "self-hosted runners can be reached by pull requests from forks")
+	default:
+		return checker.CreateResultWithScore(name, "workflows run on self-hosted runners in a public repository", score)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestSelfHostedRunners(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		r    *checker.SelfHostedRunnersData
+		want scut.TestReturn
+	}{
+		{
+			name: "nil raw data",
+			want: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+				Error: sce.ErrScorecardInternal,
+			},
+		},
+		{
+			name: "no custom runners",
+			r:    &checker.SelfHostedRunnersData{},
+			want: scut.TestReturn{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name: "custom runner without fork triggers",
+			r: &checker.SelfHostedRunnersData{
+				Jobs: []checker.RunnerJob{{Labels: []string{"gpu-large"}}},
+			},
+			want: scut.TestReturn{
+				Score:         checker.MaxResultScore,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "custom runner reachable from forks",
+			r: &checker.SelfHostedRunnersData{
+				Jobs: []checker.RunnerJob{
+					{Labels: []string{"gpu-large"}, ForkTriggers: []string{"pull_request"}},
+				},
+			},
+			want: scut.TestReturn{
+				Score:        customRunnerForkScore,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name: "self-hosted runner without fork triggers",
+			r: &checker.SelfHostedRunnersData{
+				Jobs: []checker.RunnerJob{
+					{Labels: []string{"self-hosted", "linux"}, SelfHosted: true},
+					{Labels: []string{"gpu-large"}},
+				},
+			},
+			want: scut.TestReturn{
+				Score:         selfHostedRunnerScore,
+				NumberOfWarn:  1,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "self-hosted runner reachable from forks",
+			r: &checker.SelfHostedRunnersData{
+				Jobs: []checker.RunnerJob{
+					{Labels: []string{"self-hosted"}, SelfHosted: true, ForkTriggers: []string{"pull_request_target"}},
+				},
+			},
+			want: scut.TestReturn{
+				Score:        checker.MinResultScore,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name: "private repository",
+			r: &checker.SelfHostedRunnersData{
+				Jobs: []checker.RunnerJob{
+					{Labels: []string{"self-hosted"}, SelfHosted: true, ForkTriggers: []string{"pull_request"}},
+				},
+				Private: true,
+			},
+			want: scut.TestReturn{
+				Score: checker.MaxResultScore,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			got := SelfHostedRunners(tt.name, &dl, tt.r)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.want, &got, &dl) {
+				t.Fatalf("SelfHostedRunners() = %v", got)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
 	sce "github.com/ossf/scorecard/v4/errors"
 	"github.com/ossf/scorecard/v4/finding"
 )
@@ -29,6 +30,14 @@ var (
 	provenanceExtensions = []string{".intoto.jsonl"}
 )
 
+// trustedBuilders are the prefixes of the identities of the builders whose
+// provenance is accepted.
+var trustedBuilders = []string{
+	"https://github.com/slsa-framework/slsa-github-generator/",
+	"https://github.com/actions/runner/github-hosted",
+	"https://cloudbuild.googleapis.com/GoogleHostedWorker",
+}
+
 const releaseLookBack = 5
 
 // SignedReleases applies the score policy for the Signed-Releases check.
@@ -59,6 +68,9 @@ func SignedReleases(name string, dl checker.DetailLogger, r *checker.SignedRelea
 		for _, asset := range release.Assets {
 			for _, suffix := range provenanceExtensions {
 				if strings.HasSuffix(asset.Name, suffix) {
+					if !validProvenance(dl, r.Provenance, release.TagName, &asset) {
+						continue
+					}
 					dl.Info(&checker.LogMessage{
 						Path: asset.URL,
 						Type: finding.FileTypeURL,
@@ -119,6 +131,20 @@ func SignedReleases(name string, dl checker.DetailLogger, r *checker.SignedRelea
 		}
 	}
 
+	// Checksums do not authenticate artifacts, so they are reported without
+	// affecting the score.
+	for _, c := range r.Checksums {
+		if c.Verified {
+			dl.Info(&checker.LogMessage{
+				Text: fmt.Sprintf("release artifact %s of %s matches its checksum in %s", c.Asset, c.Tag, c.File),
+			})
+		} else {
+			dl.Warn(&checker.LogMessage{
+				Text: fmt.Sprintf("release artifact %s of %s does not match its checksum in %s", c.Asset, c.Tag, c.File),
+			})
+		}
+	}
+
 	if totalReleases == 0 {
 		dl.Warn(&checker.LogMessage{
 			Text: "no GitHub releases found",
@@ -131,3 +157,50 @@ func SignedReleases(name string, dl checker.DetailLogger, r *checker.SignedRelea
 	reason := fmt.Sprintf("%d out of %d artifacts are signed or have provenance", total, totalReleases)
 	return checker.CreateResultWithScore(name, reason, score)
 }
+
+// validProvenance reports the mismatches of the content of a provenance file and
+// returns whether it is valid. Provenance files whose content could not be read are
+// considered valid.
+func validProvenance(dl checker.DetailLogger, provenance []checker.ReleaseProvenance,
+	tag string, asset *clients.ReleaseAsset,
+) bool {
+	valid := true
+	warn := func(text string) {
+		valid = false
+		dl.Warn(&checker.LogMessage{
+			Path: asset.URL,
+			Type: finding.FileTypeURL,
+			Text: fmt.Sprintf("provenance %s of %s: %s", asset.Name, tag, text),
+		})
+	}
+	for i := range provenance {
+		p := &provenance[i]
+		if p.Tag != tag || p.File != asset.Name {
+			continue
+		}
+		if !trustedBuilder(p.BuilderID) {
+			warn(fmt.Sprintf("untrusted builder %q", p.BuilderID))
+		}
+		if !p.RepoMatches {
+			warn(fmt.Sprintf("built from another repository: %q", p.SourceURI))
+		}
+		if !p.RefMatches {
+			warn(fmt.Sprintf("not built from the release tag: %q", p.SourceURI))
+		}
+		for _, subject := range p.Subjects {
+			if !subject.Verified {
+				warn(fmt.Sprintf("digest of %s does not match the released artifact", subject.Name))
+			}
+		}
+	}
+	return valid
+}
+
+func trustedBuilder(id string) bool {
+	for _, prefix := range trustedBuilders {
+		if strings.HasPrefix(id, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+// logSuppressions records the findings suppressed by `scorecard:ignore`
+// comments, so suppressions are visible in the output.
+func logSuppressions(dl checker.DetailLogger, suppressions []checker.Suppression) {
+	for i := range suppressions {
+		s := &suppressions[i]
+		dl.Info(&checker.LogMessage{
+			Path:      s.File.Path,
+			Type:      s.File.Type,
+			Offset:    s.File.Offset,
+			EndOffset: s.File.EndOffset,
+			Snippet:   s.File.Snippet,
+			Text:      fmt.Sprintf("%s finding suppressed by scorecard:ignore %s: %s", s.Type, s.ID, s.Reason),
+		})
+	}
+}
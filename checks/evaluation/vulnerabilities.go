@@ -33,9 +33,33 @@ func Vulnerabilities(name string, dl checker.DetailLogger,
 		return checker.CreateRuntimeErrorResult(name, e)
 	}
 
+	if r.DependencyAlertsAccess == checker.AlertsPermissionDenied {
+		dl.Info(&checker.LogMessage{
+			Text: "dependency alerts not considered: the token does not permit reading them",
+		})
+	}
+
+	statements := vexStatementsByID(r.VEXStatements)
 	aliasVulnerabilities := []grouper.IDAliases{}
+	seen := map[string]bool{}
 	for _, vuln := range r.Vulnerabilities {
+		if excludedByVEX(dl, statements, vuln.ID, vuln.Aliases) {
+			continue
+		}
 		aliasVulnerabilities = append(aliasVulnerabilities, grouper.IDAliases(vuln))
+		seen[vuln.ID] = true
+	}
+	// Dependency alerts usually overlap with the OSV results,
+	// grouping by aliases avoids counting them twice.
+	for _, alert := range r.DependencyAlerts {
+		if seen[alert.ID] || excludedByVEX(dl, statements, alert.ID, alert.Aliases) {
+			continue
+		}
+		seen[alert.ID] = true
+		aliasVulnerabilities = append(aliasVulnerabilities, grouper.IDAliases{
+			ID:      alert.ID,
+			Aliases: alert.Aliases,
+		})
 	}
 
 	IDs := grouper.Group(aliasVulnerabilities)
@@ -58,3 +82,44 @@ func Vulnerabilities(name string, dl checker.DetailLogger,
 
 	return checker.CreateMaxScoreResult(name, "no vulnerabilities detected")
 }
+
+// vexStatementsByID indexes the VEX statements by vulnerability ID and alias.
+// Later statements override earlier ones.
+func vexStatementsByID(statements []checker.VEXStatement) map[string]*checker.VEXStatement {
+	byID := map[string]*checker.VEXStatement{}
+	for i := range statements {
+		s := &statements[i]
+		byID[strings.ToUpper(s.Vulnerability)] = s
+		for _, alias := range s.Aliases {
+			byID[strings.ToUpper(alias)] = s
+		}
+	}
+	return byID
+}
+
+// excludedByVEX returns true if a VEX statement of the project marks
+// the vulnerability as not affecting the project or as fixed.
+func excludedByVEX(dl checker.DetailLogger, statements map[string]*checker.VEXStatement,
+	id string, aliases []string,
+) bool {
+	for _, key := range append([]string{id}, aliases...) {
+		s, ok := statements[strings.ToUpper(key)]
+		if !ok {
+			continue
+		}
+		if s.Status != checker.VEXStatusNotAffected && s.Status != checker.VEXStatusFixed {
+			return false
+		}
+		text := fmt.Sprintf("%s not considered: VEX statement marks it as %s", id, s.Status)
+		if s.Justification != "" {
+			text = fmt.Sprintf("%s (%s)", text, s.Justification)
+		}
+		dl.Info(&checker.LogMessage{
+			Path: s.File.Path,
+			Type: s.File.Type,
+			Text: text,
+		})
+		return true
+	}
+	return false
+}
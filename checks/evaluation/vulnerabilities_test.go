@@ -66,6 +66,96 @@ func TestVulnerabilities(t *testing.T) {
 				Score: 9,
 			},
 		},
+		{
+			name: "dependency alerts overlapping with vulnerabilities",
+			args: args{
+				name: "vulnerabilities_test.go",
+				r: &checker.VulnerabilitiesData{
+					Vulnerabilities: []clients.Vulnerability{
+						{
+							ID:      "GHSA-xxxx-yyyy-zzzz",
+							Aliases: []string{"CVE-2019-1234"},
+						},
+					},
+					DependencyAlerts: []clients.SecurityAlert{
+						{
+							ID: "GHSA-xxxx-yyyy-zzzz",
+						},
+						{
+							ID:      "GHSA-aaaa-bbbb-cccc",
+							Aliases: []string{"CVE-2019-1234"},
+						},
+						{
+							ID: "GHSA-dddd-eeee-ffff",
+						},
+					},
+					DependencyAlertsAccess: checker.AlertsAvailable,
+				},
+			},
+			want: checker.CheckResult{
+				Score: 8,
+			},
+		},
+		{
+			name: "vulnerabilities excluded by VEX statements",
+			args: args{
+				name: "vulnerabilities_test.go",
+				r: &checker.VulnerabilitiesData{
+					Vulnerabilities: []clients.Vulnerability{
+						{
+							ID:      "GHSA-xxxx-yyyy-zzzz",
+							Aliases: []string{"CVE-2019-1234"},
+						},
+						{
+							ID: "GHSA-gggg-hhhh-iiii",
+						},
+						{
+							ID: "CVE-2020-5678",
+						},
+					},
+					DependencyAlerts: []clients.SecurityAlert{
+						{
+							ID: "GHSA-dddd-eeee-ffff",
+						},
+					},
+					DependencyAlertsAccess: checker.AlertsAvailable,
+					VEXStatements: []checker.VEXStatement{
+						{
+							Vulnerability: "CVE-2019-1234",
+							Status:        checker.VEXStatusNotAffected,
+							Justification: "vulnerable_code_not_in_execute_path",
+						},
+						{
+							Vulnerability: "GHSA-dddd-eeee-ffff",
+							Status:        checker.VEXStatusFixed,
+						},
+						{
+							Vulnerability: "CVE-2020-5678",
+							Status:        checker.VEXStatusNotAffected,
+						},
+						{
+							Vulnerability: "CVE-2020-5678",
+							Status:        checker.VEXStatusAffected,
+						},
+					},
+				},
+			},
+			want: checker.CheckResult{
+				Score: 8,
+			},
+		},
+		{
+			name: "dependency alerts not readable",
+			args: args{
+				name: "vulnerabilities_test.go",
+				r: &checker.VulnerabilitiesData{
+					DependencyAlertsAccess: checker.AlertsPermissionDenied,
+				},
+			},
+			want: checker.CheckResult{
+				Score: 10,
+			},
+		},
 		{
 			name: "one vulnerability",
 			args: args{
@@ -0,0 +1,64 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+// experimentsAll enables every registered experiment.
+const experimentsAll = "all"
+
+// allExperiments is the list of all registered experiments.
+var allExperiments = checker.ExperimentNameToFnMap{}
+
+// GetExperiments returns the registered experiments enabled via the
+// SCORECARD_EXPERIMENTS environment variable.
+func GetExperiments() checker.ExperimentNameToFnMap {
+	return getExperiments(checker.EnabledExperiments())
+}
+
+func getExperiments(names []string) checker.ExperimentNameToFnMap {
+	experiments := checker.ExperimentNameToFnMap{}
+	for _, name := range names {
+		if strings.EqualFold(name, experimentsAll) {
+			for n, e := range allExperiments {
+				experiments[n] = e
+			}
+			continue
+		}
+		if e, ok := allExperiments[name]; ok {
+			experiments[name] = e
+		}
+	}
+	return experiments
+}
+
+func registerExperiment(name, check, description string, fn checker.ExperimentFn) error {
+	if name == "" {
+		return errInternalNameCannotBeEmpty
+	}
+	if fn == nil {
+		return errInternalCheckFuncCannotBeNil
+	}
+	allExperiments[name] = checker.Experiment{
+		Fn:          fn,
+		Check:       check,
+		Description: description,
+	}
+	return nil
+}
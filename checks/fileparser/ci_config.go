@@ -0,0 +1,468 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileparser
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// CISystem is a CI system whose configuration files are parsed by
+// ParseCIConfig.
+type CISystem string
+
+const (
+	// CISystemJenkins is Jenkins, configured by declarative Jenkinsfiles.
+	CISystemJenkins CISystem = "Jenkins"
+	// CISystemCircleCI is CircleCI, configured by .circleci/config.yml.
+	CISystemCircleCI CISystem = "CircleCI"
+	// CISystemTravis is Travis CI, configured by .travis.yml.
+	CISystemTravis CISystem = "Travis CI"
+	// CISystemGitLab is GitLab CI/CD, configured by .gitlab-ci.yml.
+	CISystemGitLab CISystem = "GitLab CI"
+	// CISystemAzurePipelines is Azure Pipelines, configured by
+	// azure-pipelines.yml files or the files of .azure-pipelines.
+	CISystemAzurePipelines CISystem = "Azure Pipelines"
+)
+
+// ciConfigPatterns are the PathMatcher patterns of the files of CIConfigSystem.
+var ciConfigPatterns = []string{
+	"Jenkinsfile", ".circleci/*", ".travis.y*ml", ".gitlab-ci.y*ml",
+	"azure-pipelines.y*ml", ".azure-pipelines/*",
+}
+
+// CIConfigValue is a value of a CI configuration file, and its line.
+type CIConfigValue struct {
+	Value string
+	Line  uint
+}
+
+// CIScript is a shell script run by a CI job. Templated is set if the CI
+// system substitutes expressions in the script before running it, such as
+// `${...}` in the double-quoted Groovy strings of a Jenkinsfile, or `<< ... >>`
+// in a CircleCI configuration.
+type CIScript struct {
+	CIConfigValue
+	Templated bool
+}
+
+// CIConfig is what is analyzed of the configuration of a CI system: the container
+// images its jobs run in, the scripts they run and the orbs CircleCI imports.
+type CIConfig struct {
+	System  CISystem
+	Images  []CIConfigValue
+	Orbs    []CIConfigValue
+	Scripts []CIScript
+}
+
+// CIConfigSystem returns the CI system configured by the file, if any.
+func CIConfigSystem(pathfn string) (CISystem, bool) {
+	p := strings.ToLower(pathfn)
+	switch {
+	case path.Base(p) == "jenkinsfile":
+		return CISystemJenkins, true
+	case p == ".circleci/config.yml" || p == ".circleci/config.yaml":
+		return CISystemCircleCI, true
+	case p == ".travis.yml" || p == ".travis.yaml":
+		return CISystemTravis, true
+	case p == ".gitlab-ci.yml" || p == ".gitlab-ci.yaml":
+		return CISystemGitLab, true
+	case path.Base(p) == "azure-pipelines.yml" || path.Base(p) == "azure-pipelines.yaml":
+		return CISystemAzurePipelines, true
+	case path.Dir(p) == ".azure-pipelines" && (path.Ext(p) == ".yml" || path.Ext(p) == ".yaml"):
+		return CISystemAzurePipelines, true
+	default:
+		return "", false
+	}
+}
+
+// OnCIConfigContentDo calls onFileContent on the configuration files of the
+// CI systems of CIConfigSystem.
+func OnCIConfigContentDo(repoClient clients.RepoClient, onFileContent DoWhileTrueOnFileContent,
+	args ...interface{},
+) error {
+	onCIConfig := func(path string, content []byte, args ...interface{}) (bool, error) {
+		if _, ok := CIConfigSystem(path); !ok {
+			return true, nil
+		}
+		return onFileContent(path, content, args...)
+	}
+	for _, pattern := range ciConfigPatterns {
+		if err := OnMatchingFileContentDo(repoClient, PathMatcher{
+			Pattern:       pattern,
+			CaseSensitive: false,
+		}, onCIConfig, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseCIConfig parses the configuration file of a CI system of
+// CIConfigSystem.
+func ParseCIConfig(pathfn string, content []byte) (*CIConfig, error) {
+	system, ok := CIConfigSystem(pathfn)
+	if !ok {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("not a CI configuration: %s", pathfn))
+	}
+	if system == CISystemJenkins {
+		return parseJenkinsfile(content), nil
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("yaml.Unmarshal: %v", err))
+	}
+	config := &CIConfig{System: system}
+	if len(root.Content) == 0 {
+		// Empty file.
+		return config, nil
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, "configuration is not a mapping")
+	}
+	switch system {
+	case CISystemCircleCI:
+		parseCircleCIConfig(doc, config)
+	case CISystemGitLab:
+		parseGitLabCIConfig(doc, config)
+	case CISystemAzurePipelines:
+		parseAzurePipelinesConfig(doc, config)
+	default:
+		parseTravisConfig(doc, config)
+	}
+	return config, nil
+}
+
+// https://www.jenkins.io/doc/book/pipeline/syntax/#agent
+var (
+	jenkinsImageRegex = regexp.MustCompile(`\b(?:image|docker\.image\(|docker)\s*['"]([^'"\n]+)['"]`)
+	jenkinsShRegex    = regexp.MustCompile(`\bsh\s*(?:\(\s*)?(?:script\s*:\s*)?('''|"""|'|")`)
+)
+
+// parseJenkinsfile collects the docker agents and the `sh` steps of a
+// Jenkinsfile. Groovy is not parsed: steps are found by their syntax in the
+// declarative pipelines documented by Jenkins.
+func parseJenkinsfile(content []byte) *CIConfig {
+	config := &CIConfig{System: CISystemJenkins}
+	// Blank out line comments, keeping line numbers.
+	lines := strings.Split(string(content), "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(strings.TrimSpace(l), "//") {
+			lines[i] = ""
+		}
+	}
+	src := strings.Join(lines, "\n")
+	lineOf := func(offset int) uint {
+		return uint(strings.Count(src[:offset], "\n") + 1)
+	}
+
+	for _, m := range jenkinsImageRegex.FindAllStringSubmatchIndex(src, -1) {
+		config.Images = append(config.Images, CIConfigValue{
+			Value: src[m[2]:m[3]],
+			Line:  lineOf(m[2]),
+		})
+	}
+	for _, m := range jenkinsShRegex.FindAllStringSubmatchIndex(src, -1) {
+		quote := src[m[2]:m[3]]
+		script, ok := groovyString(src[m[3]:], quote)
+		if !ok {
+			continue
+		}
+		config.Scripts = append(config.Scripts, CIScript{
+			CIConfigValue: CIConfigValue{Value: script, Line: lineOf(m[3])},
+			// Only double-quoted strings are interpolated by Groovy.
+			Templated: quote[0] == '"',
+		})
+	}
+	return config
+}
+
+// groovyString returns the Groovy string literal at the start of s, quoted
+// by quote, without its escapes.
+func groovyString(s, quote string) (string, bool) {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], quote):
+			return sb.String(), true
+		case s[i] == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(s[i])
+			}
+		case s[i] == '\n' && len(quote) == 1:
+			// Single-line strings cannot span lines.
+			return "", false
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return "", false
+}
+
+// parseCircleCIConfig collects the orbs of a CircleCI configuration, the
+// docker images of its executors and jobs, and the run steps of its jobs
+// and reusable commands.
+// https://circleci.com/docs/configuration-reference/
+func parseCircleCIConfig(doc *yaml.Node, config *CIConfig) {
+	forEachMappingValue(mappingValue(doc, "orbs"), func(orb *yaml.Node) {
+		// Inline orbs are mappings.
+		if orb.Kind == yaml.ScalarNode {
+			config.Orbs = append(config.Orbs, CIConfigValue{Value: orb.Value, Line: uint(orb.Line)})
+		}
+	})
+	collectImages := func(node *yaml.Node) {
+		docker := mappingValue(node, "docker")
+		if docker == nil || docker.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, d := range docker.Content {
+			if image := mappingValue(d, "image"); image != nil && image.Kind == yaml.ScalarNode {
+				config.Images = append(config.Images, CIConfigValue{Value: image.Value, Line: uint(image.Line)})
+			}
+		}
+	}
+	forEachMappingValue(mappingValue(doc, "executors"), collectImages)
+	forEachMappingValue(mappingValue(doc, "jobs"), func(job *yaml.Node) {
+		collectImages(job)
+		collectCircleCISteps(mappingValue(job, "steps"), config)
+	})
+	forEachMappingValue(mappingValue(doc, "commands"), func(command *yaml.Node) {
+		collectCircleCISteps(mappingValue(command, "steps"), config)
+	})
+}
+
+func collectCircleCISteps(steps *yaml.Node, config *CIConfig) {
+	if steps == nil || steps.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, step := range steps.Content {
+		if step.Kind != yaml.MappingNode {
+			// E.g. `- checkout`.
+			continue
+		}
+		run := mappingValue(step, "run")
+		if command := mappingValue(run, "command"); command != nil {
+			run = command
+		}
+		if run != nil && run.Kind == yaml.ScalarNode {
+			config.Scripts = append(config.Scripts, CIScript{
+				CIConfigValue: CIConfigValue{Value: run.Value, Line: uint(run.Line)},
+				Templated:     true,
+			})
+		}
+		for _, condition := range []string{"when", "unless"} {
+			collectCircleCISteps(mappingValue(mappingValue(step, condition), "steps"), config)
+		}
+	}
+}
+
+// travisPhases are the phases of a Travis CI job running shell commands.
+// https://docs.travis-ci.com/user/job-lifecycle/
+var travisPhases = []string{
+	"before_install", "install", "before_script", "script",
+	"after_success", "after_failure", "after_script", "before_deploy", "after_deploy",
+}
+
+// parseTravisConfig collects the commands of the phases of a Travis CI
+// configuration, and of the jobs it includes.
+func parseTravisConfig(doc *yaml.Node, config *CIConfig) {
+	collectPhases := func(node *yaml.Node) {
+		for _, phase := range travisPhases {
+			commands := mappingValue(node, phase)
+			if commands == nil {
+				continue
+			}
+			switch commands.Kind {
+			case yaml.ScalarNode:
+				config.Scripts = append(config.Scripts, CIScript{
+					CIConfigValue: CIConfigValue{Value: commands.Value, Line: uint(commands.Line)},
+				})
+			case yaml.SequenceNode:
+				for _, c := range commands.Content {
+					if c.Kind == yaml.ScalarNode {
+						config.Scripts = append(config.Scripts, CIScript{
+							CIConfigValue: CIConfigValue{Value: c.Value, Line: uint(c.Line)},
+						})
+					}
+				}
+			}
+		}
+	}
+	collectPhases(doc)
+	for _, key := range []string{"jobs", "matrix"} {
+		include := mappingValue(mappingValue(doc, key), "include")
+		if include == nil || include.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, job := range include.Content {
+			collectPhases(job)
+		}
+	}
+}
+
+// gitlabKeywords are the top-level keys of a GitLab CI configuration which
+// are not jobs.
+// https://docs.gitlab.com/ee/ci/yaml/
+var gitlabKeywords = map[string]bool{
+	"default": true, "include": true, "stages": true, "variables": true, "workflow": true,
+	"image": true, "services": true, "cache": true, "before_script": true, "after_script": true,
+	"spec": true,
+}
+
+// parseGitLabCIConfig collects the images and services of a GitLab CI
+// configuration, its default ones and the ones of its jobs, and their
+// scripts. Variables are passed to scripts in their environment, so scripts
+// are not templated.
+func parseGitLabCIConfig(doc *yaml.Node, config *CIConfig) {
+	collectJob := func(job *yaml.Node) {
+		collectGitLabImage(mappingValue(job, "image"), config)
+		if services := mappingValue(job, "services"); services != nil && services.Kind == yaml.SequenceNode {
+			for _, service := range services.Content {
+				collectGitLabImage(service, config)
+			}
+		}
+		for _, key := range []string{"before_script", "script", "after_script"} {
+			collectGitLabScripts(mappingValue(job, key), config)
+		}
+	}
+	collectJob(doc)
+	collectJob(mappingValue(doc, "default"))
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if !gitlabKeywords[doc.Content[i].Value] && doc.Content[i+1].Kind == yaml.MappingNode {
+			collectJob(doc.Content[i+1])
+		}
+	}
+}
+
+// collectGitLabImage collects an image given by its name, or by a mapping
+// with a name.
+func collectGitLabImage(image *yaml.Node, config *CIConfig) {
+	if name := mappingValue(image, "name"); name != nil {
+		image = name
+	}
+	if image != nil && image.Kind == yaml.ScalarNode && image.Value != "" {
+		config.Images = append(config.Images, CIConfigValue{Value: image.Value, Line: uint(image.Line)})
+	}
+}
+
+// collectGitLabScripts collects the lines of a script, which may be nested
+// sequences of lines when anchors are used. Lines from `!reference` tags are
+// collected where they are defined.
+func collectGitLabScripts(script *yaml.Node, config *CIConfig) {
+	if script == nil || script.Tag == "!reference" {
+		return
+	}
+	switch script.Kind {
+	case yaml.ScalarNode:
+		config.Scripts = append(config.Scripts, CIScript{
+			CIConfigValue: CIConfigValue{Value: script.Value, Line: uint(script.Line)},
+		})
+	case yaml.SequenceNode:
+		for _, s := range script.Content {
+			collectGitLabScripts(s, config)
+		}
+	case yaml.AliasNode:
+		collectGitLabScripts(script.Alias, config)
+	}
+}
+
+// parseAzurePipelinesConfig collects the container images of an Azure
+// Pipelines configuration and the scripts of the steps of its stages and
+// jobs. Macros like $(Build.SourceBranch) are substituted in scripts before
+// they run, so scripts are templated.
+// https://learn.microsoft.com/en-us/azure/devops/pipelines/yaml-schema/
+func parseAzurePipelinesConfig(doc *yaml.Node, config *CIConfig) {
+	// Containers of jobs may be the aliases of container resources.
+	aliases := map[string]bool{}
+	if containers := mappingValue(mappingValue(doc, "resources"), "containers"); containers != nil &&
+		containers.Kind == yaml.SequenceNode {
+		for _, c := range containers.Content {
+			if alias := mappingValue(c, "container"); alias != nil {
+				aliases[alias.Value] = true
+			}
+			collectAzureContainer(c, aliases, config)
+		}
+	}
+	collectAzureJob(doc, aliases, config)
+	for _, stage := range sequenceValues(mappingValue(doc, "stages")) {
+		for _, job := range sequenceValues(mappingValue(stage, "jobs")) {
+			collectAzureJob(job, aliases, config)
+		}
+	}
+	for _, job := range sequenceValues(mappingValue(doc, "jobs")) {
+		collectAzureJob(job, aliases, config)
+	}
+}
+
+func collectAzureJob(job *yaml.Node, aliases map[string]bool, config *CIConfig) {
+	collectAzureContainer(mappingValue(job, "container"), aliases, config)
+	for _, step := range sequenceValues(mappingValue(job, "steps")) {
+		// Other steps run tasks, or PowerShell.
+		for _, key := range []string{"script", "bash"} {
+			if script := mappingValue(step, key); script != nil && script.Kind == yaml.ScalarNode {
+				config.Scripts = append(config.Scripts, CIScript{
+					CIConfigValue: CIConfigValue{Value: script.Value, Line: uint(script.Line)},
+					Templated:     true,
+				})
+			}
+		}
+	}
+}
+
+// collectAzureContainer collects a container image given by its name, or by
+// a mapping with an image, unless it is the alias of a container resource.
+func collectAzureContainer(container *yaml.Node, aliases map[string]bool, config *CIConfig) {
+	if image := mappingValue(container, "image"); image != nil {
+		container = image
+	} else if container != nil && aliases[container.Value] {
+		return
+	}
+	if container != nil && container.Kind == yaml.ScalarNode && container.Value != "" {
+		config.Images = append(config.Images, CIConfigValue{Value: container.Value, Line: uint(container.Line)})
+	}
+}
+
+// sequenceValues returns the items of a sequence node, or nil.
+func sequenceValues(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node.Content
+}
+
+// forEachMappingValue calls fn on the values of a mapping node.
+func forEachMappingValue(node *yaml.Node, fn func(*yaml.Node)) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fn(node.Content[i+1])
+	}
+}
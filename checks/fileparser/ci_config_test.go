@@ -0,0 +1,217 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileparser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCIConfigSystem(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path string
+		want CISystem
+	}{
+		{path: "Jenkinsfile", want: CISystemJenkins},
+		{path: "ci/Jenkinsfile", want: CISystemJenkins},
+		{path: ".circleci/config.yml", want: CISystemCircleCI},
+		{path: ".circleci/README.md"},
+		{path: ".travis.yml", want: CISystemTravis},
+		{path: "docs/.travis.yml"},
+		{path: ".gitlab-ci.yml", want: CISystemGitLab},
+		{path: "azure-pipelines.yml", want: CISystemAzurePipelines},
+		{path: "ci/azure-pipelines.yaml", want: CISystemAzurePipelines},
+		{path: ".azure-pipelines/release.yml", want: CISystemAzurePipelines},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			got, ok := CIConfigSystem(tt.path)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("CIConfigSystem(%q) = %q, %v, want %q", tt.path, got, ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCIConfig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    *CIConfig
+		wantErr bool
+	}{
+		{
+			name: "Jenkinsfile",
+			path: "Jenkinsfile",
+			content: `pipeline {
+  agent { docker 'golang:1.21' }
+  stages {
+    stage('Build') {
+      steps {
+        // sh 'make clean'
+        sh 'go build ./...'
+        sh(script: "echo \"${env.BRANCH_NAME}\"", returnStdout: true)
+        sh """
+          go test ./...
+        """
+      }
+    }
+  }
+}
+`,
+			want: &CIConfig{
+				System: CISystemJenkins,
+				Images: []CIConfigValue{{Value: "golang:1.21", Line: 2}},
+				Scripts: []CIScript{
+					{CIConfigValue: CIConfigValue{Value: "go build ./...", Line: 7}},
+					{CIConfigValue: CIConfigValue{Value: `echo "${env.BRANCH_NAME}"`, Line: 8}, Templated: true},
+					{CIConfigValue: CIConfigValue{Value: "\n          go test ./...\n        ", Line: 9}, Templated: true},
+				},
+			},
+		},
+		{
+			name: "CircleCI",
+			path: ".circleci/config.yml",
+			content: `orbs:
+  go: circleci/go@1.9.0
+jobs:
+  build:
+    docker:
+      - image: cimg/go:1.21
+    steps:
+      - checkout
+      - run: go build ./...
+      - when:
+          condition: << pipeline.parameters.test >>
+          steps:
+            - run:
+                command: go test ./...
+commands:
+  lint:
+    steps:
+      - run: golangci-lint run
+`,
+			want: &CIConfig{
+				System: CISystemCircleCI,
+				Images: []CIConfigValue{{Value: "cimg/go:1.21", Line: 6}},
+				Orbs:   []CIConfigValue{{Value: "circleci/go@1.9.0", Line: 2}},
+				Scripts: []CIScript{
+					{CIConfigValue: CIConfigValue{Value: "go build ./...", Line: 9}, Templated: true},
+					{CIConfigValue: CIConfigValue{Value: "go test ./...", Line: 14}, Templated: true},
+					{CIConfigValue: CIConfigValue{Value: "golangci-lint run", Line: 18}, Templated: true},
+				},
+			},
+		},
+		{
+			name: "Travis CI",
+			path: ".travis.yml",
+			content: `install: go mod download
+script:
+  - go test ./...
+matrix:
+  include:
+    - after_success: bash codecov.sh
+`,
+			want: &CIConfig{
+				System: CISystemTravis,
+				Scripts: []CIScript{
+					{CIConfigValue: CIConfigValue{Value: "go mod download", Line: 1}},
+					{CIConfigValue: CIConfigValue{Value: "go test ./...", Line: 3}},
+					{CIConfigValue: CIConfigValue{Value: "bash codecov.sh", Line: 6}},
+				},
+			},
+		},
+		{
+			name: "GitLab CI",
+			path: ".gitlab-ci.yml",
+			content: `default:
+  image: alpine
+.lines: &lines
+  - make
+  - make test
+build:
+  services: [redis]
+  script:
+    - *lines
+    - !reference [.setup, script]
+stages: [build]
+`,
+			want: &CIConfig{
+				System: CISystemGitLab,
+				Images: []CIConfigValue{{Value: "alpine", Line: 2}, {Value: "redis", Line: 7}},
+				Scripts: []CIScript{
+					{CIConfigValue: CIConfigValue{Value: "make", Line: 4}},
+					{CIConfigValue: CIConfigValue{Value: "make test", Line: 5}},
+				},
+			},
+		},
+		{
+			name: "Azure Pipelines",
+			path: "azure-pipelines.yml",
+			content: `resources:
+  containers:
+    - container: linux
+      image: ubuntu:22.04
+container: linux
+jobs:
+  - job: build
+    container: golang:1.21
+    steps:
+      - bash: go build ./...
+      - pwsh: Write-Host done
+steps:
+  - script: make
+`,
+			want: &CIConfig{
+				System: CISystemAzurePipelines,
+				Images: []CIConfigValue{{Value: "ubuntu:22.04", Line: 4}, {Value: "golang:1.21", Line: 8}},
+				Scripts: []CIScript{
+					{CIConfigValue: CIConfigValue{Value: "make", Line: 13}, Templated: true},
+					{CIConfigValue: CIConfigValue{Value: "go build ./...", Line: 10}, Templated: true},
+				},
+			},
+		},
+		{
+			name:    "invalid YAML",
+			path:    ".travis.yml",
+			content: "script: [",
+			wantErr: true,
+		},
+		{
+			name:    "not a CI configuration",
+			path:    "Makefile",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseCIConfig(tt.path, []byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCIConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseCIConfig() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -300,6 +300,31 @@ func IsWorkflowFile(pathfn string) bool {
 	}
 }
 
+// ActionsWorkflowPattern is the PathMatcher pattern of the directories of
+// the workflows of IsActionsWorkflowFile.
+const ActionsWorkflowPattern = "*/workflows/*"
+
+// actionsWorkflowDirs are the directories of GitHub workflows, and of the
+// workflows of Forgejo and Gitea Actions, which share their syntax.
+var actionsWorkflowDirs = []string{".github/workflows", ".forgejo/workflows", ".gitea/workflows"}
+
+// IsActionsWorkflowFile returns true if this is a GitHub, Forgejo Actions or
+// Gitea Actions workflow file.
+func IsActionsWorkflowFile(pathfn string) bool {
+	switch path.Ext(pathfn) {
+	case ".yml", ".yaml":
+	default:
+		return false
+	}
+	dir := filepath.Dir(strings.ToLower(pathfn))
+	for _, d := range actionsWorkflowDirs {
+		if dir == d {
+			return true
+		}
+	}
+	return false
+}
+
 // IsGithubWorkflowFileCb determines if a file is a workflow
 // as a callback to use for repo client's ListFiles() API.
 func IsGithubWorkflowFileCb(pathfn string) (bool, error) {
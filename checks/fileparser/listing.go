@@ -21,34 +21,8 @@ import (
 	"strings"
 
 	"github.com/ossf/scorecard/v4/clients"
-	sce "github.com/ossf/scorecard/v4/errors"
 )
 
-// isMatchingPath uses 'pattern' to shell-match the 'path' and its filename
-// 'caseSensitive' indicates the match should be case-sensitive. Default: no.
-func isMatchingPath(fullpath string, matchPathTo PathMatcher) (bool, error) {
-	pattern := matchPathTo.Pattern
-	if !matchPathTo.CaseSensitive {
-		pattern = strings.ToLower(matchPathTo.Pattern)
-		fullpath = strings.ToLower(fullpath)
-	}
-
-	filename := path.Base(fullpath)
-	match, err := path.Match(pattern, fullpath)
-	if err != nil {
-		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%v: %v", errInternalFilenameMatch, err))
-	}
-
-	// No match on the fullpath, let's try on the filename only.
-	if !match {
-		if match, err = path.Match(pattern, filename); err != nil {
-			return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%v: %v", errInternalFilenameMatch, err))
-		}
-	}
-
-	return match, nil
-}
-
 func isTestdataFile(fullpath string) bool {
 	// testdata/ or /some/dir/testdata/some/other
 	return strings.HasPrefix(fullpath, "testdata/") ||
@@ -57,12 +31,6 @@ func isTestdataFile(fullpath string) bool {
 		strings.Contains(fullpath, "/src/test/")
 }
 
-// PathMatcher represents a query for a filepath.
-type PathMatcher struct {
-	Pattern       string
-	CaseSensitive bool
-}
-
 // DoWhileTrueOnFileContent takes a filepath, its content and
 // optional variadic args. It returns a boolean indicating whether
 // iterating over next files should continue.
@@ -81,7 +49,7 @@ func OnMatchingFileContentDo(repoClient clients.RepoClient, matchPathTo PathMatc
 			return false, nil
 		}
 		// Filter out files based on path/names using the pattern.
-		b, err := isMatchingPath(filepath, matchPathTo)
+		b, err := matchPathTo.Match(filepath)
 		if err != nil {
 			return false, err
 		}
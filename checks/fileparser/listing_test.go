@@ -179,7 +179,7 @@ func TestCheckFileContainsCommands(t *testing.T) {
 	}
 }
 
-func Test_isMatchingPath(t *testing.T) {
+func TestPathMatcher_Match(t *testing.T) {
 	t.Parallel()
 	type args struct {
 		pattern       string
@@ -315,16 +315,16 @@ func Test_isMatchingPath(t *testing.T) {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := isMatchingPath(tt.args.fullpath, PathMatcher{
+			got, err := PathMatcher{
 				Pattern:       tt.args.pattern,
 				CaseSensitive: tt.args.caseSensitive,
-			})
+			}.Match(tt.args.fullpath)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("isMatchingPath() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("Match() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if got != tt.want {
-				t.Errorf("isMatchingPath() = %v, want %v", got, tt.want)
+				t.Errorf("Match() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -0,0 +1,108 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileparser
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// doubleStar is the segment of a pattern matching any number of directories.
+const doubleStar = "**"
+
+// PathMatcher represents a query for a filepath.
+//
+// Pattern is a glob of slash-separated segments. Within a segment, '*', '?',
+// '[...]' and '\' have the meaning of path.Match, and a "**" segment matches
+// zero or more directories. A pattern without a slash matches the filename in
+// any directory, e.g. "Dockerfile*". A pattern with a slash matches the path
+// from the root of the repo, a leading slash being optional, e.g.
+// ".github/workflows/*.yml" or "/docs/**/*.md".
+type PathMatcher struct {
+	Pattern string
+	// CaseSensitive indicates the match should be case-sensitive. Default: no.
+	CaseSensitive bool
+}
+
+// Match returns true if fullpath, relative to the root of the repo, matches
+// the pattern.
+func (m PathMatcher) Match(fullpath string) (bool, error) {
+	pattern := m.Pattern
+	if !m.CaseSensitive {
+		pattern = strings.ToLower(pattern)
+		fullpath = strings.ToLower(fullpath)
+	}
+	var match bool
+	var err error
+	if strings.Contains(pattern, "/") {
+		match, err = matchSegments(splitPath(pattern), splitPath(fullpath))
+	} else {
+		match, err = path.Match(pattern, path.Base(fullpath))
+	}
+	if err != nil {
+		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%v: %v", errInternalFilenameMatch, err))
+	}
+	return match, nil
+}
+
+// ValidatePattern returns an error if pattern is malformed.
+func ValidatePattern(pattern string) error {
+	for _, segment := range splitPath(pattern) {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("%s: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// splitPath returns the segments of p, ignoring a leading and a trailing
+// slash. The root of the repo has no segment.
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+func matchSegments(pattern, name []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == doubleStar {
+			for len(pattern) > 0 && pattern[0] == doubleStar {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 0 {
+				return true, nil
+			}
+			for i := range name {
+				if match, err := matchSegments(pattern, name[i:]); err != nil || match {
+					return match, err
+				}
+			}
+			return false, nil
+		}
+		if len(name) == 0 {
+			return false, nil
+		}
+		if match, err := path.Match(pattern[0], name[0]); err != nil || !match {
+			return false, err
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileparser
+
+import (
+	"testing"
+)
+
+func TestPathMatcher_MatchGlobs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern  string
+		fullpath string
+		want     bool
+	}{
+		{pattern: "*.yml", fullpath: ".github/workflows/ci.yml", want: true},
+		{pattern: ".github/workflows/*.yml", fullpath: ".github/workflows/ci.yml", want: true},
+		{pattern: ".github/workflows/*.yml", fullpath: ".github/workflows/sub/ci.yml", want: false},
+		{pattern: "/.github/workflows/*.yml", fullpath: ".github/workflows/ci.yml", want: true},
+		{pattern: "docs/*.md", fullpath: "src/docs/a.md", want: false},
+		{pattern: "docs/**/*.md", fullpath: "docs/a.md", want: true},
+		{pattern: "docs/**/*.md", fullpath: "docs/a/b/c.md", want: true},
+		{pattern: "docs/**/*.md", fullpath: "src/docs/a.md", want: false},
+		{pattern: "**/docs/*.md", fullpath: "src/docs/a.md", want: true},
+		{pattern: "docs/**", fullpath: "docs/a/b", want: true},
+		{pattern: "docs/**", fullpath: "docsa/b", want: false},
+		{pattern: "**/**/Dockerfile", fullpath: "Dockerfile", want: true},
+		{pattern: "a/?/c", fullpath: "a/b/c", want: true},
+		{pattern: "a/[bc]/d", fullpath: "a/c/d", want: true},
+		{pattern: "a/**b/c", fullpath: "a/x/b/c", want: false},
+		{pattern: "/", fullpath: "", want: true},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.pattern+" "+tt.fullpath, func(t *testing.T) {
+			t.Parallel()
+			got, err := PathMatcher{Pattern: tt.pattern, CaseSensitive: true}.Match(tt.fullpath)
+			if err != nil {
+				t.Fatalf("Match() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathMatcher_MatchBadPattern(t *testing.T) {
+	t.Parallel()
+	for _, pattern := range []string{"[", "a/[/b", "**/[-]"} {
+		if _, err := (PathMatcher{Pattern: pattern}).Match("a/[/b"); err == nil {
+			t.Errorf("Match(%q) error = nil", pattern)
+		}
+		if err := ValidatePattern(pattern); err == nil {
+			t.Errorf("ValidatePattern(%q) = nil", pattern)
+		}
+	}
+	if err := ValidatePattern("docs/**/*.md"); err != nil {
+		t.Errorf("ValidatePattern() = %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileparser
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// ignoreCommentRegex matches `# scorecard:ignore <id> reason=<reason>`
+// comments, or `//` ones in Jenkinsfiles. The reason may be quoted and is
+// required, so every suppression is explained.
+var ignoreCommentRegex = regexp.MustCompile(
+	`(?:^|\s)(?:#|//)\s*scorecard:ignore\s+([A-Za-z0-9/_.-]+)\s+reason=(?:"([^"]*)"|(.*?))\s*$`)
+
+// IgnoreComment is a `scorecard:ignore` comment suppressing a finding.
+type IgnoreComment struct {
+	// ID names what is suppressed, e.g. a check name.
+	ID     string
+	Reason string
+}
+
+// ParseIgnoreComments returns the `scorecard:ignore` comments of a workflow,
+// CI configuration, Dockerfile or shell script by the line they apply to. A comment applies to
+// its own line if it follows code, and to the next line if it is on a line
+// of its own. Lines are numbered from 1.
+func ParseIgnoreComments(content []byte) map[uint][]IgnoreComment {
+	comments := map[uint][]IgnoreComment{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	var line uint
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		match := ignoreCommentRegex.FindStringSubmatchIndex(text)
+		if match == nil {
+			continue
+		}
+		comment := IgnoreComment{ID: text[match[2]:match[3]]}
+		if match[4] >= 0 {
+			comment.Reason = text[match[4]:match[5]]
+		} else {
+			comment.Reason = text[match[6]:match[7]]
+		}
+		if strings.TrimSpace(comment.Reason) == "" {
+			continue
+		}
+		target := line
+		if strings.TrimSpace(text[:match[0]]) == "" {
+			target++
+		}
+		comments[target] = append(comments[target], comment)
+	}
+	return comments
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileparser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseIgnoreComments(t *testing.T) {
+	t.Parallel()
+	content := []byte(`FROM python:3.7
+# scorecard:ignore Pinned-Dependencies reason=rebuilt nightly from the latest base
+FROM node:18
+RUN curl https://example.com/install.sh | sh # scorecard:ignore Pinned-Dependencies/downloadThenRun reason="vendor script"
+# scorecard:ignore Pinned-Dependencies
+RUN pip install -r requirements.txt
+RUN echo "# scorecard:ignore is just text here reason=x"
+    // scorecard:ignore Dangerous-Workflow reason=only built for trusted branches
+    sh "echo ${env.CHANGE_TITLE}"
+`)
+	want := map[uint][]IgnoreComment{
+		3: {{ID: "Pinned-Dependencies", Reason: "rebuilt nightly from the latest base"}},
+		4: {{ID: "Pinned-Dependencies/downloadThenRun", Reason: "vendor script"}},
+		9: {{ID: "Dangerous-Workflow", Reason: "only built for trusted branches"}},
+	}
+	got := ParseIgnoreComments(content)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseIgnoreComments() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileparser
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// woodpeckerSections are the top-level keys of a Woodpecker CI pipeline
+// listing containers: `pipeline` is the deprecated name of `steps`.
+var woodpeckerSections = []string{"clone", "steps", "pipeline", "services"}
+
+// WoodpeckerStep is a step, a service or a clone step of a Woodpecker CI
+// pipeline.
+// https://woodpecker-ci.org/docs/usage/workflow-syntax
+type WoodpeckerStep struct {
+	Name string
+	// Image is the container image the step runs in, and ImageLine its line.
+	Image     string
+	ImageLine uint
+	// Commands are the commands of the step, one per line, as they are run
+	// by the shell of the image. CommandsLine is the line of the first one.
+	Commands     string
+	CommandsLine uint
+}
+
+// IsWoodpeckerFile returns true if this is a Woodpecker CI pipeline, i.e.,
+// a .woodpecker.yml file at the root of the repository or a YAML file of
+// the .woodpecker directory.
+func IsWoodpeckerFile(pathfn string) bool {
+	p := strings.ToLower(pathfn)
+	switch path.Ext(p) {
+	case ".yml", ".yaml":
+	default:
+		return false
+	}
+	return path.Dir(p) == ".woodpecker" ||
+		p == ".woodpecker.yml" || p == ".woodpecker.yaml"
+}
+
+// ParseWoodpeckerSteps returns the steps of a Woodpecker CI pipeline, in the
+// order of the file. Both the map and the list syntax of steps are supported.
+func ParseWoodpeckerSteps(content []byte) ([]WoodpeckerStep, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("yaml.Unmarshal: %v", err))
+	}
+	if len(root.Content) == 0 {
+		// Empty file.
+		return nil, nil
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, "pipeline is not a mapping")
+	}
+	var steps []WoodpeckerStep
+	for _, section := range woodpeckerSections {
+		node := mappingValue(doc, section)
+		if node == nil {
+			continue
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if step, ok := woodpeckerStep(node.Content[i].Value, node.Content[i+1]); ok {
+					steps = append(steps, step)
+				}
+			}
+		case yaml.SequenceNode:
+			for _, n := range node.Content {
+				var name string
+				if nameNode := mappingValue(n, "name"); nameNode != nil {
+					name = nameNode.Value
+				}
+				if step, ok := woodpeckerStep(name, n); ok {
+					steps = append(steps, step)
+				}
+			}
+		}
+	}
+	return steps, nil
+}
+
+func woodpeckerStep(name string, node *yaml.Node) (WoodpeckerStep, bool) {
+	if node.Kind != yaml.MappingNode {
+		return WoodpeckerStep{}, false
+	}
+	step := WoodpeckerStep{Name: name}
+	if image := mappingValue(node, "image"); image != nil && image.Kind == yaml.ScalarNode {
+		step.Image = image.Value
+		step.ImageLine = uint(image.Line)
+	}
+	if commands := mappingValue(node, "commands"); commands != nil {
+		switch commands.Kind {
+		case yaml.ScalarNode:
+			step.Commands = commands.Value
+			step.CommandsLine = uint(commands.Line)
+		case yaml.SequenceNode:
+			lines := make([]string, 0, len(commands.Content))
+			for _, c := range commands.Content {
+				if c.Kind == yaml.ScalarNode {
+					lines = append(lines, c.Value)
+				}
+			}
+			step.Commands = strings.Join(lines, "\n")
+			if len(commands.Content) > 0 {
+				step.CommandsLine = uint(commands.Content[0].Line)
+			}
+		}
+	}
+	return step, true
+}
+
+// mappingValue returns the value of key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
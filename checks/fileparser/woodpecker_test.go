@@ -0,0 +1,115 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileparser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsWoodpeckerFile(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: ".woodpecker.yml", want: true},
+		{path: ".woodpecker.yaml", want: true},
+		{path: ".woodpecker/build.yaml", want: true},
+		{path: ".woodpecker/README.md"},
+		{path: "docs/.woodpecker.yml"},
+		{path: ".github/workflows/build.yml"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			if got := IsWoodpeckerFile(tt.path); got != tt.want {
+				t.Errorf("IsWoodpeckerFile(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWoodpeckerSteps(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		want    []WoodpeckerStep
+		wantErr bool
+	}{
+		{
+			name: "empty file",
+		},
+		{
+			name: "map of steps and services",
+			content: `clone:
+  git:
+    image: woodpeckerci/plugin-git
+steps:
+  build:
+    image: golang
+    commands:
+      - go build
+      - go test
+services:
+  db:
+    image: postgres
+`,
+			want: []WoodpeckerStep{
+				{Name: "git", Image: "woodpeckerci/plugin-git", ImageLine: 3},
+				{
+					Name: "build", Image: "golang", ImageLine: 6,
+					Commands: "go build\ngo test", CommandsLine: 8,
+				},
+				{Name: "db", Image: "postgres", ImageLine: 12},
+			},
+		},
+		{
+			name: "list of legacy pipeline steps",
+			content: `pipeline:
+  - name: lint
+    image: alpine
+    commands: make lint
+`,
+			want: []WoodpeckerStep{
+				{
+					Name: "lint", Image: "alpine", ImageLine: 3,
+					Commands: "make lint", CommandsLine: 4,
+				},
+			},
+		},
+		{
+			name:    "not a mapping",
+			content: "- build\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseWoodpeckerSteps([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWoodpeckerSteps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseWoodpeckerSteps() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
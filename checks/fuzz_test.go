@@ -0,0 +1,41 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// FuzzSonarConfig ensures that parsing an untrusted pom.xml never panics.
+func FuzzSonarConfig(f *testing.F) {
+	matches, err := filepath.Glob("testdata/pom-*.xml")
+	if err != nil {
+		f.Fatalf("filepath.Glob: %v", err)
+	}
+	for _, m := range matches {
+		content, err := os.ReadFile(m)
+		if err != nil {
+			f.Fatalf("os.ReadFile: %v", err)
+		}
+		f.Add(content)
+	}
+	f.Fuzz(func(t *testing.T, content []byte) {
+		var config []sonarConfig
+		//nolint:errcheck
+		validateSonarConfig("pom.xml", content, &config)
+	})
+}
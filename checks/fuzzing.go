@@ -24,9 +24,65 @@ import (
 // CheckFuzzing is the registered name for Fuzzing.
 const CheckFuzzing = "Fuzzing"
 
+// fuzzingMetadata documents the Fuzzing check.
+var fuzzingMetadata = checker.CheckMetadata{
+	Risk:  "Medium",
+	Short: "Determines if the project uses fuzzing.",
+	Description: "Risk: `Medium` (possible vulnerabilities in code)\n" +
+		"\n" +
+		"This check tries to determine if the project uses\n" +
+		"[fuzzing](https://owasp.org/www-community/Fuzzing) by checking:\n" +
+		"1. if the repository name is included in the " +
+		"[OSS-Fuzz](https://github.com/google/oss-fuzz) project list;\n" +
+		"2. if [ClusterFuzzLite](https://google.github.io/clusterfuzzlite/) is deployed in the " +
+		"repository;\n" +
+		"3. if there are user-defined language-specified fuzzing functions (currently only " +
+		"supports [Go fuzzing](https://go.dev/doc/fuzz/)) in the repository.\n" +
+		"4. if it contains a [OneFuzz](https://github.com/microsoft/onefuzz) integration " +
+		"[detection " +
+		"file](https://github.com/microsoft/onefuzz/blob/main/docs/getting-started.md#detecting-the-use-of-onefuzz);\n" +
+		"\n" +
+		"Fuzzing, or fuzz testing, is the practice of feeding unexpected or random data\n" +
+		"into a program to expose bugs. Regular fuzzing is important to detect\n" +
+		"vulnerabilities that may be exploited by others, especially since attackers can\n" +
+		"also use fuzzing to find the same flaws.\n" +
+		"\n" +
+		"For projects integrated in OSS-Fuzz, the check also reads the build history of\n" +
+		"the project from the OSS-Fuzz status file. If no build succeeded in the last 90\n" +
+		"days, the integration is considered broken: stale fuzzing gives a false\n" +
+		"assurance, so projects only fuzzed by OSS-Fuzz receive a score of 5 instead\n" +
+		"of 10.\n" +
+		"\n" +
+		"Note: A project that fulfills this criterion with other tools may still receive\n" +
+		"a low score on this test. There are many ways to implement fuzzing, and it is\n" +
+		"challenging for an automated tool like Scorecard to detect them all. A low score\n" +
+		"is therefore not a definitive indication that the project is at risk.\n",
+	Tags:  []string{"supply-chain", "security", "testing"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"OssFuzzRepo.ListCheckRunsForRef",
+		"OssFuzzRepo.Search",
+		"RepoClient.GetFileContent",
+		"RepoClient.ListFiles",
+		"RepoClient.ListProgrammingLanguages",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "the project is fuzzed"},
+		{Score: "5", Criteria: "the project is only fuzzed by OSS-Fuzz, which built none of it in the last 90 days"},
+		{Score: "0", Criteria: "the project is not fuzzed"},
+	},
+	Remediation: []string{
+		"Integrate the project with OSS-Fuzz by following the instructions " +
+			"[here](https://google.github.io/oss-fuzz/).",
+		"If the OSS-Fuzz build of the project is broken, fix it using the build logs linked from " +
+			"the [OSS-Fuzz build " +
+			"status](https://oss-fuzz-build-logs.storage.googleapis.com/index.html).",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
-	if err := registerCheck(CheckFuzzing, Fuzzing, nil); err != nil {
+	if err := registerCheck(CheckFuzzing, Fuzzing, nil, fuzzingMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
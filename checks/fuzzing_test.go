@@ -17,6 +17,7 @@ package checks
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
@@ -36,6 +37,7 @@ func TestFuzzing(t *testing.T) {
 		want        checker.CheckResult
 		langs       []clients.Language
 		response    clients.SearchResponse
+		builds      []clients.CheckRun
 		wantErr     bool
 		wantFuzzErr bool
 		fileName    []string
@@ -77,6 +79,36 @@ func TestFuzzing(t *testing.T) {
 				Score:         10,
 			},
 		},
+		{
+			name: "OSS-Fuzz build broken for months",
+			response: clients.SearchResponse{
+				Hits: 1,
+			},
+			builds: []clients.CheckRun{
+				{Conclusion: "failure", CompletedAt: time.Now().AddDate(0, 0, -1)},
+				{Conclusion: "success", CompletedAt: time.Now().AddDate(0, -6, 0)},
+			},
+			want: checker.CheckResult{Score: 5},
+			expected: scut.TestReturn{
+				NumberOfWarn: 1,
+				Score:        5,
+			},
+		},
+		{
+			name: "OSS-Fuzz build recently broken",
+			response: clients.SearchResponse{
+				Hits: 1,
+			},
+			builds: []clients.CheckRun{
+				{Conclusion: "failure", CompletedAt: time.Now().AddDate(0, 0, -1)},
+				{Conclusion: "success", CompletedAt: time.Now().AddDate(0, 0, -2)},
+			},
+			want: checker.CheckResult{Score: 10},
+			expected: scut.TestReturn{
+				NumberOfInfo: 1,
+				Score:        10,
+			},
+		},
 		{
 			name: "nil response",
 			langs: []clients.Language{
@@ -128,6 +160,7 @@ func TestFuzzing(t *testing.T) {
 					}
 					return tt.response, nil
 				}).AnyTimes()
+			mockFuzz.EXPECT().ListCheckRunsForRef("github.com/ossf/scorecard").Return(tt.builds, nil).AnyTimes()
 			mockFuzz.EXPECT().ListProgrammingLanguages().Return(tt.langs, nil).AnyTimes()
 			mockFuzz.EXPECT().ListFiles(gomock.Any()).Return(tt.fileName, nil).AnyTimes()
 			mockFuzz.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(f string) (string, error) {
@@ -0,0 +1,112 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/evaluation"
+	"github.com/ossf/scorecard/v4/checks/raw"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// CheckHiddenCharacters is the registered name for HiddenCharacters.
+const CheckHiddenCharacters = "Hidden-Characters"
+
+// hiddenCharactersMetadata documents the Hidden-Characters check.
+var hiddenCharactersMetadata = checker.CheckMetadata{
+	Risk:  "High",
+	Short: "Determines if the project's source files contain hidden or confusable characters.",
+	Description: "Risk: `High` (malicious code hidden from reviewers)\n" +
+		"\n" +
+		"This check scans the source files of the project for characters which change\n" +
+		"how the code is displayed without changing how it is compiled or interpreted,\n" +
+		"as used by [Trojan Source](https://trojansource.codes) attacks. A reviewer may\n" +
+		"then approve code which does something else than it appears to.\n" +
+		"\n" +
+		"The check reports the line and column of:\n" +
+		"- bidirectional control characters, such as `U+202E` (right-to-left override),\n" +
+		"  which reorder how the code around them is displayed;\n" +
+		"- invisible characters, such as zero width spaces, word joiners, Hangul fillers\n" +
+		"  or Unicode tags;\n" +
+		"- Cyrillic or Greek letters in identifiers which also contain Latin letters,\n" +
+		"  such as `paypal` spelled with a Cyrillic `a`.\n" +
+		"\n" +
+		"Files are selected by extension, e.g. `.go`, `.js`, `.py` or `.yml`, and\n" +
+		"documentation such as Markdown files is not scanned. Files in test data\n" +
+		"directories, and files which are not valid UTF-8, are skipped. A byte order\n" +
+		"mark at the start of a file is allowed.\n" +
+		"\n" +
+		"Bidirectional control characters receive the lowest score. Each other hidden or\n" +
+		"confusable character lowers the score by one.\n" +
+		"\n" +
+		"Note: this is an experimental check which requires `SCORECARD_EXPERIMENTAL`\n" +
+		"to be set.\n",
+	Tags:  []string{"supply-chain", "security", "source-code"},
+	Repos: []string{"GitHub", "local"},
+	Inputs: []string{
+		"RepoClient.GetFileContent",
+		"RepoClient.ListFiles",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "no hidden or confusable characters are found"},
+		{Score: "-1", Criteria: "for each invisible or confusable character"},
+		{Score: "0", Criteria: "a bidirectional control character is found"},
+	},
+	Remediation: []string{
+		"Remove the reported characters, or replace them with escape sequences if they are needed " +
+			"in string literals.",
+		"Enable the warnings of your editor and code review tools for bidirectional and invisible " +
+			"characters, e.g. GitHub shows a warning on files which contain bidirectional text.",
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	supportedRequestTypes := []checker.RequestType{
+		checker.FileBased,
+	}
+	if err := registerCheck(CheckHiddenCharacters, HiddenCharacters, supportedRequestTypes, hiddenCharactersMetadata); err != nil {
+		// this should never happen
+		panic(err)
+	}
+}
+
+// HiddenCharacters runs the Hidden-Characters check.
+func HiddenCharacters(c *checker.CheckRequest) checker.CheckResult {
+	// TODO: remove this check when v6 is released
+	if !checker.IsExperimentalEnabled() {
+		c.Dlogger.Warn(&checker.LogMessage{
+			Text: "SCORECARD_EXPERIMENTAL is not set, not running the Hidden-Characters check",
+		})
+
+		e := sce.WithMessage(sce.ErrorUnsupportedCheck,
+			"SCORECARD_EXPERIMENTAL is not set, not running the Hidden-Characters check")
+		return checker.CreateRuntimeErrorResult(CheckHiddenCharacters, e)
+	}
+
+	rawData, err := raw.HiddenCharacters(c.RepoClient)
+	if err != nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		return checker.CreateRuntimeErrorResult(CheckHiddenCharacters, e)
+	}
+
+	// Set the raw results.
+	if c.RawResults != nil {
+		c.RawResults.HiddenCharactersResults = rawData
+	}
+
+	// Return the score evaluation.
+	return evaluation.HiddenCharacters(CheckHiddenCharacters, c.Dlogger, &rawData)
+}
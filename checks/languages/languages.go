@@ -0,0 +1,275 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package languages detects the programming languages and package ecosystems
+// of a repo, so that checks with language-conditional logic agree on them.
+package languages
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// Ecosystem is the name of a package ecosystem.
+type Ecosystem string
+
+const (
+	// Go modules.
+	Go Ecosystem = "go"
+	// NPM packages.
+	NPM Ecosystem = "npm"
+	// Maven artifacts.
+	Maven Ecosystem = "maven"
+	// Gradle projects.
+	Gradle Ecosystem = "gradle"
+	// Cargo crates.
+	Cargo Ecosystem = "cargo"
+	// PyPI packages.
+	PyPI Ecosystem = "pypi"
+	// RubyGems gems.
+	RubyGems Ecosystem = "rubygems"
+	// Composer packages.
+	Composer Ecosystem = "composer"
+	// Swift packages.
+	Swift Ecosystem = "swift"
+	// Hex packages.
+	Hex Ecosystem = "hex"
+	// Pub packages.
+	Pub Ecosystem = "pub"
+)
+
+// manifests maps the base name of a project manifest to its ecosystem.
+var manifests = map[string]Ecosystem{
+	"go.mod":           Go,
+	"package.json":     NPM,
+	"pom.xml":          Maven,
+	"build.gradle":     Gradle,
+	"build.gradle.kts": Gradle,
+	"Cargo.toml":       Cargo,
+	"pyproject.toml":   PyPI,
+	"setup.py":         PyPI,
+	"Gemfile":          RubyGems,
+	"composer.json":    Composer,
+	"Package.swift":    Swift,
+	"mix.exs":          Hex,
+	"pubspec.yaml":     Pub,
+}
+
+// extensions maps file extensions to the language of the file.
+var extensions = map[string]clients.LanguageName{
+	".go":    clients.Go,
+	".py":    clients.Python,
+	".js":    clients.JavaScript,
+	".mjs":   clients.JavaScript,
+	".cjs":   clients.JavaScript,
+	".ts":    clients.TypeScript,
+	".tsx":   clients.TypeScript,
+	".c":     clients.C,
+	".h":     clients.C,
+	".cc":    clients.Cpp,
+	".cpp":   clients.Cpp,
+	".cxx":   clients.Cpp,
+	".hh":    clients.Cpp,
+	".hpp":   clients.Cpp,
+	".java":  clients.Java,
+	".cs":    clients.CSharp,
+	".rb":    clients.Ruby,
+	".php":   clients.PHP,
+	".bzl":   clients.StarLark,
+	".star":  clients.StarLark,
+	".scala": clients.Scala,
+	".kt":    clients.Kotlin,
+	".kts":   clients.Kotlin,
+	".swift": clients.Swift,
+	".rs":    clients.Rust,
+	".cmake": clients.CMake,
+}
+
+// filenames maps file names without a telling extension to the language of the file.
+var filenames = map[string]clients.LanguageName{
+	"Dockerfile":     clients.Dockerfile,
+	"CMakeLists.txt": clients.CMake,
+	"BUILD":          clients.StarLark,
+	"BUILD.bazel":    clients.StarLark,
+	"WORKSPACE":      clients.StarLark,
+}
+
+// nonCode holds the languages, as reported by repo clients, of documentation
+// and configuration rather than code.
+var nonCode = map[clients.LanguageName]bool{
+	"css":        true,
+	"dockerfile": true,
+	"hcl":        true,
+	"html":       true,
+	"jsonnet":    true,
+	"less":       true,
+	"makefile":   true,
+	"markdown":   true,
+	"nix":        true,
+	"roff":       true,
+	"scss":       true,
+	"tex":        true,
+}
+
+// ignoredDirs holds directories which contain third-party or test code
+// rather than code of the repo.
+var ignoredDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"testdata":     true,
+	"third_party":  true,
+}
+
+// EcosystemOf returns the ecosystem of a project manifest, if `fullpath` is one.
+func EcosystemOf(fullpath string) (Ecosystem, bool) {
+	e, ok := manifests[path.Base(fullpath)]
+	return e, ok
+}
+
+// LanguageOf returns the language of a source file, if it is recognized.
+func LanguageOf(fullpath string) (clients.LanguageName, bool) {
+	base := path.Base(fullpath)
+	if l, ok := filenames[base]; ok {
+		return l, true
+	}
+	l, ok := extensions[strings.ToLower(path.Ext(base))]
+	return l, ok
+}
+
+// IsVendored returns true if `fullpath` is inside a directory of third-party or test code.
+func IsVendored(fullpath string) bool {
+	for _, dir := range strings.Split(path.Dir(fullpath), "/") {
+		if ignoredDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// Prominent returns the prominent languages of the repo. The languages reported by
+// the repo client are used if available, otherwise languages are detected from the
+// extensions of the files in the repo.
+func Prominent(c clients.RepoClient) ([]clients.LanguageName, error) {
+	langs, err := List(c)
+	if err != nil {
+		return nil, err
+	}
+	return ProminentOf(langs), nil
+}
+
+// List returns the languages of the repo. The languages reported by the repo client
+// are used if available, otherwise languages are detected from the extensions of
+// the files in the repo, with NumLines holding the number of files.
+func List(c clients.RepoClient) ([]clients.Language, error) {
+	langs, err := c.ListProgrammingLanguages()
+	if err == nil {
+		return langs, nil
+	}
+	if !errors.Is(err, clients.ErrUnsupportedFeature) {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListProgrammingLanguages: %v", err))
+	}
+
+	counts := map[clients.LanguageName]int{}
+	_, err = c.ListFiles(func(fullpath string) (bool, error) {
+		if IsVendored(fullpath) {
+			return false, nil
+		}
+		if l, ok := LanguageOf(fullpath); ok {
+			counts[l]++
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListFiles: %v", err))
+	}
+	langs = make([]clients.Language, 0, len(counts))
+	for name, n := range counts {
+		langs = append(langs, clients.Language{Name: name, NumLines: n})
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		return langs[i].Name < langs[j].Name
+	})
+	return langs, nil
+}
+
+// HasCode returns true if the repo has code, rather than only documentation
+// or configuration.
+func HasCode(c clients.RepoClient) (bool, error) {
+	langs, err := List(c)
+	if err != nil {
+		return false, err
+	}
+	for _, l := range langs {
+		if !nonCode[clients.LanguageName(strings.ToLower(string(l.Name)))] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ProminentOf returns the languages that have at least the average lines of code.
+// Language names are lowercased.
+func ProminentOf(langs []clients.Language) []clients.LanguageName {
+	numLangs := len(langs)
+	if numLangs == 0 {
+		return nil
+	}
+	totalLoC := 0
+	// Use a map to record languages and their lines of code to drop potential duplicates.
+	langMap := map[clients.LanguageName]int{}
+	for _, l := range langs {
+		totalLoC += l.NumLines
+		langMap[l.Name] += l.NumLines
+	}
+	// Calculate the average lines of code in the current repo.
+	// This var can stay as an int, no need for a precise float value.
+	avgLoC := totalLoC / numLangs
+	// Languages that have lines of code above average will be considered prominent.
+	ret := []clients.LanguageName{}
+	for lName, loC := range langMap {
+		if loC >= avgLoC {
+			lang := clients.LanguageName(strings.ToLower(string(lName)))
+			ret = append(ret, lang)
+		}
+	}
+	return ret
+}
+
+// Ecosystems returns the ecosystems of the project manifests found in the repo.
+func Ecosystems(c clients.RepoClient) ([]Ecosystem, error) {
+	found := map[Ecosystem]bool{}
+	_, err := c.ListFiles(func(fullpath string) (bool, error) {
+		if e, ok := EcosystemOf(fullpath); ok && !IsVendored(fullpath) {
+			found[e] = true
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListFiles: %v", err))
+	}
+	ret := make([]Ecosystem, 0, len(found))
+	for e := range found {
+		ret = append(ret, e)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i] < ret[j]
+	})
+	return ret, nil
+}
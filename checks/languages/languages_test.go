@@ -0,0 +1,268 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package languages
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func TestProminentOf(t *testing.T) {
+	t.Parallel()
+	//nolint
+	tests := []struct {
+		name      string
+		languages []clients.Language
+		expected  []clients.LanguageName
+	}{
+		{
+			name: "case1",
+			languages: []clients.Language{
+				{
+					Name:     clients.Go,
+					NumLines: 1000,
+				},
+				{
+					Name:     clients.Python,
+					NumLines: 40,
+				},
+				{
+					Name:     clients.JavaScript,
+					NumLines: 800,
+				},
+			},
+			expected: []clients.LanguageName{
+				clients.Go, clients.JavaScript,
+			},
+		},
+		{
+			// This test case simulates the situation when the GitHub language API returns
+			// duplicated languages, but we can still drop them and get the correct result.
+			name: "case2: drop duplicates",
+			languages: []clients.Language{
+				{
+					Name:     clients.Go,
+					NumLines: 1000,
+				},
+				{
+					Name:     clients.Python,
+					NumLines: 40,
+				},
+				{
+					Name:     clients.JavaScript,
+					NumLines: 800,
+				},
+				{
+					Name:     clients.Go,
+					NumLines: 1000,
+				},
+				{
+					Name:     clients.Python,
+					NumLines: 40,
+				},
+				{
+					Name:     clients.JavaScript,
+					NumLines: 800,
+				},
+				{
+					Name:     clients.Go,
+					NumLines: 1000,
+				},
+				{
+					Name:     clients.Python,
+					NumLines: 40,
+				},
+				{
+					Name:     clients.JavaScript,
+					NumLines: 800,
+				},
+			},
+			expected: []clients.LanguageName{
+				clients.Go, clients.JavaScript,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ProminentOf(tt.languages)
+			if !unorderedEqual(got, tt.expected) {
+				t.Errorf(
+					"got (%s) != expected (%s)",
+					got, tt.expected,
+				)
+			}
+		})
+	}
+}
+
+func unorderedEqual(l1, l2 []clients.LanguageName) bool {
+	if len(l1) != len(l2) {
+		return false
+	}
+	l1Map, l2Map := map[clients.LanguageName]bool{}, map[clients.LanguageName]bool{}
+	for _, l := range l1 {
+		l1Map[l] = true
+	}
+	for _, l := range l2 {
+		l2Map[l] = true
+		if !l1Map[l] {
+			return false
+		}
+	}
+	for k := range l1Map {
+		if !l2Map[k] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+	files := []string{
+		"main.go",
+		"pkg/util.go",
+		"web/index.ts",
+		"Dockerfile",
+		"vendor/github.com/foo/bar.go",
+		"README.md",
+	}
+	tests := []struct {
+		name    string
+		apiErr  error
+		api     []clients.Language
+		want    []clients.Language
+		wantErr bool
+	}{
+		{
+			name: "languages from repo client",
+			api:  []clients.Language{{Name: clients.Java, NumLines: 100}},
+			want: []clients.Language{{Name: clients.Java, NumLines: 100}},
+		},
+		{
+			name:   "fallback to file extensions",
+			apiErr: fmt.Errorf("ListProgrammingLanguages: %w", clients.ErrUnsupportedFeature),
+			want: []clients.Language{
+				{Name: clients.Dockerfile, NumLines: 1},
+				{Name: clients.Go, NumLines: 2},
+				{Name: clients.TypeScript, NumLines: 1},
+			},
+		},
+		{
+			name:    "repo client error",
+			apiErr:  errors.New("some error"), //nolint:goerr113
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListProgrammingLanguages().Return(tt.api, tt.apiErr)
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					for _, f := range files {
+						if _, err := predicate(f); err != nil {
+							return nil, err
+						}
+					}
+					return nil, nil
+				}).AnyTimes()
+
+			got, err := List(mockRepoClient)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("List() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("List() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEcosystems(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+		func(predicate func(string) (bool, error)) ([]string, error) {
+			for _, f := range []string{
+				"go.mod", "tools/go.mod", "web/package.json",
+				"web/node_modules/left-pad/package.json", "vendor/Cargo.toml",
+			} {
+				if _, err := predicate(f); err != nil {
+					return nil, err
+				}
+			}
+			return nil, nil
+		})
+
+	got, err := Ecosystems(mockRepoClient)
+	if err != nil {
+		t.Fatalf("Ecosystems() error = %v", err)
+	}
+	if diff := cmp.Diff([]Ecosystem{Go, NPM}, got); diff != "" {
+		t.Errorf("Ecosystems() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHasCode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		langs []clients.Language
+		want  bool
+	}{
+		{
+			name: "no languages",
+		},
+		{
+			name:  "documentation only",
+			langs: []clients.Language{{Name: "HTML", NumLines: 100}, {Name: "CSS", NumLines: 10}},
+		},
+		{
+			name:  "configuration and code",
+			langs: []clients.Language{{Name: clients.Dockerfile, NumLines: 10}, {Name: clients.Go, NumLines: 1}},
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListProgrammingLanguages().Return(tt.langs, nil)
+			got, err := HasCode(mockRepoClient)
+			if err != nil || got != tt.want {
+				t.Errorf("HasCode() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
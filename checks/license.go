@@ -24,12 +24,78 @@ import (
 // CheckLicense is the registered name for License.
 const CheckLicense = "License"
 
+// licenseMetadata documents the License check.
+var licenseMetadata = checker.CheckMetadata{
+	Risk:  "Low",
+	Short: "Determines if the project has defined a license.",
+	Description: "Risk: `Low` (possible impediment to security review)\n" +
+		"\n" +
+		"This check tries to determine if the project has published a license. It\n" +
+		"works by using either hosting APIs or by checking standard locations\n" +
+		"for a file named according to common conventions for licenses.\n" +
+		"\n" +
+		"A license can give users information about how the source code may or may\n" +
+		"not be used. The lack of a license will impede any kind of security review\n" +
+		"or audit and creates a legal risk for potential users.\n" +
+		"\n" +
+		"Scorecard uses the\n" +
+		"[GitHub License " +
+		"API](https://docs.github.com/en/rest/licenses#get-the-license-for-a-repository)\n" +
+		"for GitHub hosted projects. Otherwise, Scorecard uses its own heuristics to\n" +
+		"detect a published license file.\n" +
+		"\n" +
+		"On its own, this check will detect files in the top-level directory with\n" +
+		"any combination of the following names and extensions:`LICENSE`, `LICENCE`,\n" +
+		"`COPYING`, `COPYRIGHT` and having common extensions such as `.html`, `.txt`,\n" +
+		"or `.md`. It will also detect these files in a directory named `LICENSES`.\n" +
+		"(Files in a `LICENSES` directory are typically named as their\n" +
+		"[SPDX](https://spdx.org/licenses/) license identifier followed by an\n" +
+		"appropriate file extension, as described in the [REUSE](https://reuse.software/spec/) " +
+		"Specification.)\n" +
+		"\n" +
+		"License Requirements:\n" +
+		"  - A detected `LICENSE`, `COPYRIGHT`, or `COPYING` filename (6/10 points)\n" +
+		"  - The detected file is at the top-level directory (3/10 points)\n" +
+		"  - A [FSF or OSI](https://spdx.org/licenses/) license is specified (1/10 points)\n" +
+		"\n" +
+		"License files in subdirectories, e.g. for the components of a monorepo,\n" +
+		"are reported with their path and the license they declare, taken from an\n" +
+		"SPDX identifier in the file name or content, or recognized from the license\n" +
+		"text. Files under `vendor`, `third_party`, `node_modules` and `testdata`\n" +
+		"directories are ignored. These findings do not affect the score.\n",
+	Tags:  []string{"license"},
+	Repos: []string{"GitHub", "local"},
+	Inputs: []string{
+		"RepoClient.GetFileContent",
+		"RepoClient.ListFiles",
+		"RepoClient.ListLicenses",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "+6", Criteria: "a license file is detected"},
+		{Score: "+3", Criteria: "the license file is in the top-level directory"},
+		{Score: "+1", Criteria: "the license is approved by the FSF or OSI"},
+	},
+	Remediation: []string{
+		"Determine [which " +
+			"license](https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/customizing-your-repository/licensing-a-repository) to apply to your project. For GitHub hosted projects, follow those instructions to establish a license for your project.",
+		"For other hosting environments, create the license in a `.adoc`, `.asc`, `.docx`, `.doc`, " +
+			"`.ext`, `.html`, `.markdown`, `.md`, `.rst`, `.txt`, or `.xml`, named `LICENSE`, " +
+			"`COPYRIGHT`, or `COPYING`, and place it in the top-level directory. To identify a " +
+			"specific license, use an [SPDX license identifier](https://spdx.org/licenses/) in the " +
+			"filename. Examples include `LICENSE.md`, `Apache-2.0-LICENSE.md` or `LICENSE-Apache-2.0`.",
+		"Alternately, create a `LICENSE` directory and add a license file(s) with a name that " +
+			"matches your [SPDX license identifier](https://spdx.org/licenses/). such as " +
+			"`LICENSES/Apache-2.0.txt`.",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
 	supportedRequestTypes := []checker.RequestType{
+		checker.FileBased,
 		checker.CommitBased,
 	}
-	if err := registerCheck(CheckLicense, License, supportedRequestTypes); err != nil {
+	if err := registerCheck(CheckLicense, License, supportedRequestTypes, licenseMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
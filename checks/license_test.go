@@ -48,6 +48,17 @@ func TestLicenseFileSubdirectory(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name:        "With LICENSE files in subdirectories",
+			inputFolder: "testdata/licensedir/monorepo",
+			expected: scut.TestReturn{
+				Error:        nil,
+				Score:        checker.MaxResultScore - 1,
+				NumberOfInfo: 3,
+				NumberOfWarn: 1,
+			},
+			err: nil,
+		},
 		{
 			name:        "Without LICENSE",
 			inputFolder: "testdata/licensedir/withoutlicense",
@@ -24,9 +24,75 @@ import (
 // CheckMaintained is the exported check name for Maintained.
 const CheckMaintained = "Maintained"
 
+// maintainedMetadata documents the Maintained check.
+var maintainedMetadata = checker.CheckMetadata{
+	Risk:  "High",
+	Short: "Determines if the project is \"actively maintained\".",
+	Description: "Risk: `High` (possibly unpatched vulnerabilities)\n" +
+		"\n" +
+		"This check determines whether the project is actively maintained. If the project\n" +
+		"is archived, it receives the lowest score. If there is at least one commit per\n" +
+		"week during the previous 90 days, the project receives the highest score.  If there\n" +
+		"is activity on issues from users who are collaborators, members, or owners of the\n" +
+		"project, the project receives a partial score.\n" +
+		"\n" +
+		"A project which is not active might not be patched, have its\n" +
+		"dependencies patched, or be actively tested and used. However, a lack\n" +
+		"of active maintenance is not necessarily always a problem. Some software,\n" +
+		"especially smaller utility functions, does not normally need to be maintained.\n" +
+		"For example, a library that determines if an integer is even would not normally\n" +
+		"need maintenance unless an underlying implementation language definition\n" +
+		"changed. A lack of active maintenance should signal that potential users should\n" +
+		"investigate further to judge the situation.\n" +
+		"\n" +
+		"Maintainers can declare the maintenance status of the project in their\n" +
+		"`scorecard.yml` config (`maintenance: {status: ..., reason: ...}`, with a status of\n" +
+		"`active`, `feature-complete`, `security-fixes-only` or `unmaintained`) or in the\n" +
+		"`project-lifecycle` of their `SECURITY-INSIGHTS.yml`. The declared status is shown\n" +
+		"in the details and the reason, e.g. \"maintenance mode: security fixes only\", and\n" +
+		"does not change the score, except for projects declared unmaintained which\n" +
+		"receive the lowest score.\n" +
+		"\n" +
+		"On GitHub Enterprise, with `SCORECARD_GITHUB_AUDIT_LOG` set and a token of an\n" +
+		"owner of the organization, the audit log of the organization is read as well:\n" +
+		"each secret scanning alert closed in the last 90 days without revoking the\n" +
+		"secret, e.g. as a false positive or won't fix, lowers the score by one point.\n" +
+		"\n" +
+		"This check will only succeed if a Github project is >90 days old. Projects\n" +
+		"that are younger than this are too new to assess whether they are maintained\n" +
+		"or not, and users should inspect the contents of those projects to ensure they\n" +
+		"are as expected.\n",
+	Tags:  []string{"supply-chain", "security"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.GetCreatedAt",
+		"RepoClient.GetFileContent",
+		"RepoClient.IsArchived",
+		"RepoClient.ListAuditLogEvents",
+		"RepoClient.ListCommits",
+		"RepoClient.ListIssues",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "0-10", Criteria: "proportional to the commits and the issue activity of collaborators in the last 90 " +
+			"days, 10 from one per week"},
+		{Score: "-1", Criteria: "for each secret scanning alert dismissed without revoking the secret in the audit log of " +
+			"the last 90 days"},
+		{Score: "0", Criteria: "the repository is archived, declared unmaintained or created less than 90 days ago"},
+	},
+	Remediation: []string{
+		"There is no remediation work needed from projects with a low score; this check simply " +
+			"provides insight into the project activity and maintenance commitment. External users " +
+			"should determine whether the software is the type that would not normally need active " +
+			"maintenance.",
+		"Projects that are stable and only receive bug or security fixes can declare it with " +
+			"`maintenance: {status: feature-complete}` or `maintenance: {status: security-fixes-only}` " +
+			"in their `scorecard.yml`, so that consumers can interpret low activity correctly.",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
-	if err := registerCheck(CheckMaintained, Maintained, nil); err != nil {
+	if err := registerCheck(CheckMaintained, Maintained, nil, maintainedMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
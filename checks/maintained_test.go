@@ -56,6 +56,7 @@ func Test_Maintained(t *testing.T) {
 		issues     []clients.Issue
 		issueerr   error
 		createdat  time.Time
+		files      map[string]string
 		expected   checker.CheckResult
 	}{
 		{
@@ -92,6 +93,37 @@ func Test_Maintained(t *testing.T) {
 				Score: -1,
 			},
 		},
+		{
+			name:       "declared unmaintained in SECURITY-INSIGHTS.yml",
+			isarchived: false,
+			commits: []clients.Commit{
+				{
+					CommittedDate: time.Now().AddDate(0, 0, -1),
+				},
+			},
+			issues: []clients.Issue{},
+			files: map[string]string{
+				"SECURITY-INSIGHTS.yml": "project-lifecycle:\n  status: inactive\n",
+			},
+			expected: checker.CheckResult{
+				Score:  0,
+				Reason: "repo is declared unmaintained",
+			},
+		},
+		{
+			name:       "declared security fixes only in the scorecard config",
+			isarchived: false,
+			commits:    []clients.Commit{},
+			issues:     []clients.Issue{},
+			files: map[string]string{
+				".github/scorecard.yml": "maintenance:\n  status: security-fixes-only\n",
+				"SECURITY-INSIGHTS.yml": "project-lifecycle:\n  status: inactive\n",
+			},
+			expected: checker.CheckResult{
+				Score:  0,
+				Reason: "0 commit(s) out of 0 and 0 issue activity out of 0 found in the last 90 days (maintenance mode: security fixes only) -- score normalized to 0",
+			},
+		},
 		{
 			name:       "repo with no commits or issues",
 			isarchived: false,
@@ -360,6 +392,16 @@ func Test_Maintained(t *testing.T) {
 						},
 					).MinTimes(1)
 
+					mockRepo.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(name string) ([]byte, error) {
+						content, ok := tt.files[name]
+						if !ok {
+							return nil, errors.New("file not found")
+						}
+						return []byte(content), nil
+					}).AnyTimes()
+
+					mockRepo.EXPECT().ListAuditLogEvents().Return(nil, clients.ErrUnsupportedFeature).AnyTimes()
+
 					if tt.issueerr == nil {
 						mockRepo.EXPECT().GetCreatedAt().DoAndReturn(func() (time.Time, error) {
 							if tt.createdat.IsZero() {
@@ -389,6 +431,9 @@ func Test_Maintained(t *testing.T) {
 			if res.Score != tt.expected.Score {
 				t.Errorf("Expected score %d, got %d for %v", tt.expected.Score, res.Score, tt.name)
 			}
+			if tt.expected.Reason != "" && res.Reason != tt.expected.Reason {
+				t.Errorf("Expected reason %q, got %q for %v", tt.expected.Reason, res.Reason, tt.name)
+			}
 			ctrl.Finish()
 		})
 	}
@@ -24,9 +24,87 @@ import (
 // CheckPackaging is the registered name for Packaging.
 const CheckPackaging = "Packaging"
 
+// packagingMetadata documents the Packaging check.
+var packagingMetadata = checker.CheckMetadata{
+	Risk: "Medium",
+	Short: "Determines if the project is published as a package that others can easily download, install, " +
+		"easily update, and uninstall.",
+	Description: "Risk: `Medium` (users possibly missing security updates)\n" +
+		"\n" +
+		"This check tries to determine if the project is published as a package. It is\n" +
+		"currently limited to repositories hosted on GitHub, and does not support other\n" +
+		"source hosting repositories (i.e., Forges).\n" +
+		"\n" +
+		"Packages give users of a project an easy way to download, install, update, and\n" +
+		"uninstall the software by a package manager. In particular, they make it easy\n" +
+		"for users to receive security patches as updates.\n" +
+		"\n" +
+		"The check currently looks for\n" +
+		"[GitHub packaging " +
+		"workflows](https://docs.github.com/en/packages/learn-github-packages/publishing-a-package)\n" +
+		"and language-specific GitHub Actions that upload the package to a corresponding\n" +
+		"hub, e.g., [Npm](https://www.npmjs.com/).\n" +
+		"\n" +
+		"When Scorecard is run on a package, e.g. with `--npm`, `--pypi`, `--rubygems`,\n" +
+		"`--cargo` or `--maven`, the repo is resolved from the registry, so the\n" +
+		"project is published as a package. The check then reports the latest version,\n" +
+		"the number of maintainers and whether the latest version was published with\n" +
+		"provenance, as told by the registry.\n" +
+		"\n" +
+		"The packages declared by the manifests at the root of the repo, e.g.\n" +
+		"`package.json`, `pyproject.toml`, `Cargo.toml`, `pom.xml` or a gemspec, are\n" +
+		"looked up in their registry. A published package which does not declare the\n" +
+		"repo as its source repo is reported with a warning, as it may be published\n" +
+		"from a fork, or be a hijacked or vanity package taking over the name of the\n" +
+		"project. Such a package does not lower the score.\n" +
+		"\n" +
+		"When a publishing workflow is found, the check records its recent successful\n" +
+		"runs together with the commit each one built. Runs triggered by a release, or\n" +
+		"for the tag of a published release, are linked to that version, so consumers\n" +
+		"can trace a published artifact back to the exact workflow run that produced it.\n" +
+		"\n" +
+		"You can create a package in several ways:\n" +
+		"\n" +
+		"  - Many program language ecosystems have a generally-used packaging format\n" +
+		"    supported by a language-level package manager tool and public package\n" +
+		"    repository.\n" +
+		"  - Many operating system platforms also have at least one package format,\n" +
+		"    tool, and public repository (in some cases the source repository generates\n" +
+		"    system-independent source packages, which are then used by others to\n" +
+		"    generate system executable packages).\n" +
+		"  - Using container images.\n" +
+		"\n" +
+		"Note: A project that fulfills this criterion with other tools may still receive\n" +
+		"a low score on this test. There are many ways to package software, and it is\n" +
+		"challenging for an automated tool like Scorecard to detect them all. A low\n" +
+		"score is therefore not a definitive indication that the project is at risk. If\n" +
+		"Scorecard fails to detect the way you publish a package and you think we should\n" +
+		"support your use case, please let us know by [opening an\n" +
+		"issue](https://github.com/ossf/scorecard/issues/new/choose).\n",
+	Tags:  []string{"supply-chain", "security", "releases"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.GetFileContent",
+		"RepoClient.ListFiles",
+		"RepoClient.ListReleases",
+		"RepoClient.ListSuccessfulWorkflowRuns",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "a publishing workflow is detected"},
+		{Score: "inconclusive", Criteria: "no publishing workflow is detected"},
+	},
+	Remediation: []string{
+		"Publish your project as a downloadable package, e.g., if hosted on GitHub, use [GitHub's " +
+			"mechanisms for publishing a " +
+			"package](https://docs.github.com/en/packages/learn-github-packages/publishing-a-package).",
+		"If hosted on GitHub, use a GitHub action to release your package to language-specific " +
+			"hubs.",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
-	if err := registerCheck(CheckPackaging, Packaging, nil); err != nil {
+	if err := registerCheck(CheckPackaging, Packaging, nil, packagingMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
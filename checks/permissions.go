@@ -24,13 +24,93 @@ import (
 // CheckTokenPermissions is the exported name for Token-Permissions check.
 const CheckTokenPermissions = "Token-Permissions"
 
+// tokenPermissionsMetadata documents the Token-Permissions check.
+var tokenPermissionsMetadata = checker.CheckMetadata{
+	Risk:  "High",
+	Short: "Determines if the project's workflows follow the principle of least privilege.",
+	Description: "Risk: `High` (vulnerable to malicious code additions)\n" +
+		"\n" +
+		"This check determines whether the project's automated workflows tokens are set\n" +
+		"to read-only by default. It is currently limited to repositories hosted on\n" +
+		"GitHub, and does not support other source hosting repositories (i.e., Forges).\n" +
+		"Forgejo and Gitea Actions workflows, which share the syntax of GitHub workflows,\n" +
+		"are checked the same way.\n" +
+		"\n" +
+		"Setting token permissions to read-only follows the principle of least privilege.\n" +
+		"This is important because attackers may use a compromised token with write\n" +
+		"access to push malicious code into the project.\n" +
+		"\n" +
+		"The highest score is awarded when the permissions definitions in each workflow's\n" +
+		"yaml file are set as read-only at the\n" +
+		"[top " +
+		"level](https://docs.github.com/en/actions/reference/workflow-syntax-for-github-actions#permissions)\n" +
+		"and the required write permissions are declared at the\n" +
+		"[run-level](https://docs.github.com/en/actions/reference/workflow-syntax-for-github-actions#jobsjob_idpermissions).\n" +
+		"One point is reduced from the score if all jobs have their permissions defined but the " +
+		"top level permissions are not defined.\n" +
+		"This configuration is secure, but there is a chance that when a new job is added to the " +
+		"workflow, its job permissions could be\n" +
+		"left undefined because of human error.\n" +
+		"\n" +
+		"The check cannot detect if the \"read-only\" GitHub permission setting is\n" +
+		"enabled, as there is no API available.\n" +
+		"\n" +
+		"Additionally, points are reduced if certain write permissions are defined for a job.\n" +
+		"\n" +
+		"### Write permissions causing a small reduction\n" +
+		"* `statuses` - May allow an attacker to change the result of pre-submit checks and get a " +
+		"PR merged.\n" +
+		"* `checks` - May allow an attacker to remove pre-submit checks and introduce a bug.\n" +
+		"* `security-events` - May allow an attacker to read vulnerability reports before a patch " +
+		"is available. However, points are not reduced if the job utilizes a recognized action for " +
+		"uploading SARIF results.\n" +
+		"* `deployments` - May allow an attacker to charge repo owner by triggering VM runs, and " +
+		"tiny chance an attacker can trigger a remote service with code they own if server accepts " +
+		"code/location variables unsanitized.\n" +
+		"\n" +
+		"### Write permissions causing a large reduction\n" +
+		"* `contents` - Allows an attacker to commit unreviewed code. However, points are not " +
+		"reduced if the job utilizes a recognized packaging action or command.\n" +
+		"* `packages` - Allows an attacker to publish packages. However, points are not reduced if " +
+		"the job utilizes a recognized packaging action or command.\n" +
+		"* `actions` - May allow an attacker to steal GitHub secrets by approving to run an action " +
+		"that needs approval.\n",
+	Tags:  []string{"supply-chain", "security", "infrastructure"},
+	Repos: []string{"GitHub", "local"},
+	Inputs: []string{
+		"RepoClient.GetDefaultBranchName",
+		"RepoClient.GetFileContent",
+		"RepoClient.ListFiles",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "tokens are read-only at the top level of each workflow, with write permissions declared " +
+			"by the jobs"},
+		{Score: "-0.5", Criteria: "for each workflow without top-level permissions whose jobs all declare theirs"},
+		{Score: "-0.5", Criteria: "for each workflow with statuses or checks write permissions"},
+		{Score: "-1", Criteria: "for each workflow with security-events or deployments write permissions"},
+		{Score: "-10", Criteria: "for each workflow with top-level contents, packages or actions write permissions"},
+		{Score: "0", Criteria: "a workflow declares no permissions at any level"},
+	},
+	Remediation: []string{
+		"Set permissions as `read-all` or `contents: read` as described in GitHub's " +
+			"[documentation](https://docs.github.com/en/actions/reference/workflow-syntax-for-github-actions#permissions).",
+		"Scorecard suggests a minimal `permissions:` block for each finding, based on the " +
+			"well-known actions used by the workflow's jobs. It is available as the finding's " +
+			"remediation patch in the JSON and SARIF results.",
+		"To help determine the permissions needed for your workflows, you may use [StepSecurity's " +
+			"online tool](https://app.stepsecurity.io/) by ticking the \"Restrict permissions for " +
+			"GITHUB_TOKEN\". You may also tick the \"Pin actions to a full length commit SHA\" to fix " +
+			"issues found by the Pinned-dependencies check.",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
 	supportedRequestTypes := []checker.RequestType{
 		checker.FileBased,
 		checker.CommitBased,
 	}
-	if err := registerCheck(CheckTokenPermissions, TokenPermissions, supportedRequestTypes); err != nil {
+	if err := registerCheck(CheckTokenPermissions, TokenPermissions, supportedRequestTypes, tokenPermissionsMetadata); err != nil {
 		// This should never happen.
 		panic(err)
 	}
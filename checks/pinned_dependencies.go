@@ -24,13 +24,108 @@ import (
 // CheckPinnedDependencies is the registered name for FrozenDeps.
 const CheckPinnedDependencies = "Pinned-Dependencies"
 
+// pinnedDependenciesMetadata documents the Pinned-Dependencies check.
+var pinnedDependenciesMetadata = checker.CheckMetadata{
+	Risk:  "Medium",
+	Short: "Determines if the project has declared and pinned the dependencies of its build process.",
+	Description: "Risk: `Medium` (possible compromised dependencies)\n" +
+		"\n" +
+		"This check tries to determine if the project pins dependencies used during its build and " +
+		"release process.\n" +
+		"A \"pinned dependency\" is a dependency that is explicitly set to a specific hash instead " +
+		"of\n" +
+		"allowing a mutable version or range of versions. It\n" +
+		"is currently limited to repositories hosted on GitHub, and does not support\n" +
+		"other source hosting repositories (i.e., Forges).\n" +
+		"\n" +
+		"The check works by looking for unpinned dependencies in Dockerfiles, shell scripts, " +
+		"GitHub workflows, Forgejo and Gitea Actions workflows, and the images and commands of\n" +
+		"Woodpecker CI pipelines, Jenkinsfiles, and CircleCI, Travis CI, GitLab CI and Azure\n" +
+		"Pipelines configurations\n" +
+		"which are used during the build and release process of a project.\n" +
+		"Special considerations for Go modules treat full semantic versions as pinned\n" +
+		"due to how the Go tool verifies downloaded content against the hashes when anyone first " +
+		"downloaded the module.\n" +
+		"CircleCI orbs are reported unless they are pinned to a full version, e.g., `circleci/node@5.1.0`,\n" +
+		"since published orb versions are immutable. They do not affect the score.\n" +
+		"With `--verify-action-pins`, the commits GitHub Actions are pinned to are looked up in the tags of\n" +
+		"the repos of the actions. Pins to commits which are not tagged, e.g. commits of forks, are\n" +
+		"reported. They do not affect the score either.\n" +
+		"\n" +
+		"Pinned dependencies reduce several security risks:\n" +
+		"\n" +
+		"  - They ensure that checking and deployment are all done with the same\n" +
+		"    software, reducing deployment risks, simplifying debugging, and enabling\n" +
+		"    reproducibility.\n" +
+		"  - They can help mitigate compromised dependencies from undermining the\n" +
+		"    security of the project (in the case where you've evaluated the pinned\n" +
+		"    dependency, you are confident it's not compromised, and a later version is\n" +
+		"    released that is compromised).\n" +
+		"  - They are one way to [counter dependency confusion (aka substitution) " +
+		"attacks](https://azure.microsoft.com/en-us/resources/3-ways-to-mitigate-risk-using-private-package-feeds/),\n" +
+		"    in which an application uses multiple feeds to acquire software packages (a\n" +
+		"    \"hybrid configuration\"), and attackers fool the user into using a malicious\n" +
+		"    package via a feed that was not expected for that package.\n" +
+		"\n" +
+		"However, pinning dependencies can inhibit software updates, either because of a\n" +
+		"security vulnerability or because the pinned version is compromised. Mitigate\n" +
+		"this risk by:\n" +
+		"\n" +
+		"  - using automated tools to notify applications when their dependencies are\n" +
+		"    outdated;\n" +
+		"  - quickly updating applications that do pin dependencies.\n" +
+		"\n" +
+		"For projects hosted on GitHub, you can learn more about\n" +
+		"dependencies using the [GitHub dependency " +
+		"graph](https://docs.github.com/en/code-security/supply-chain-security/understanding-your-software-supply-chain/about-the-dependency-graph).\n",
+	Tags:  []string{"supply-chain", "security", "dependencies"},
+	Repos: []string{"GitHub", "local"},
+	Inputs: []string{
+		"ActionTags.ListActionTags",
+		"RepoClient.GetDefaultBranchName",
+		"RepoClient.GetFileContent",
+		"RepoClient.ListFiles",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "0-10", Criteria: "average of the scores of GitHub Actions, Dockerfile images, Dockerfile downloads and " +
+			"script downloads"},
+		{Score: "+2", Criteria: "GitHub-owned actions are pinned by hash"},
+		{Score: "+8", Criteria: "third-party actions are pinned by hash"},
+		{Score: "10", Criteria: "for Dockerfile images, Dockerfile downloads and script downloads which are all pinned"},
+	},
+	Remediation: []string{
+		"If your project is producing an application, declare all your dependencies with specific " +
+			"versions in your package format file (e.g. `package.json` for npm, `requirements.txt` for " +
+			"python). For C/C++, check in the code from a trusted source and add a `README` on the " +
+			"specific version used (and the archive SHA hashes).",
+		"If your project is producing an application and the package manager supports lock files " +
+			"(e.g. `package-lock.json` for npm), make sure to check these in the source code as well. " +
+			"These files maintain signatures for the entire dependency tree and saves from future " +
+			"exploitation in case the package is compromised.",
+		"For Dockerfiles used in building and releasing your project, pin dependencies by hash. " +
+			"See " +
+			"[Dockerfile](https://github.com/ossf/scorecard/blob/main/cron/internal/worker/Dockerfile) " +
+			"for example. If you are using a manifest list to support builds across multiple " +
+			"architectures, you can pin to the manifest list hash instead of a single image hash. You " +
+			"can use a tool like " +
+			"[crane](https://github.com/google/go-containerregistry/blob/main/cmd/crane/README.md) to " +
+			"obtain the hash of the manifest list like in this " +
+			"[example](https://github.com/ossf/scorecard/issues/1773#issuecomment-1076699039).",
+		"For GitHub workflows used in building and releasing your project, pin dependencies by " +
+			"hash. See " +
+			"[main.yaml](https://github.com/ossf/scorecard/blob/f55b86d6627cc3717e3a0395e03305e81b9a09be/.github/workflows/main.yml#L27) for example. To determine the permissions needed for your workflows, you may use [StepSecurity's online tool](https://app.stepsecurity.io/) by ticking the \"Pin actions to a full length commit SHA\". You may also tick the \"Restrict permissions for GITHUB_TOKEN\" to fix issues found by the Token-Permissions check.",
+		"To help update your dependencies after pinning them, use tools such as those listed for " +
+			"the dependency update tool check.",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
 	supportedRequestTypes := []checker.RequestType{
 		checker.FileBased,
 		checker.CommitBased,
 	}
-	if err := registerCheck(CheckPinnedDependencies, PinningDependencies, supportedRequestTypes); err != nil {
+	if err := registerCheck(CheckPinnedDependencies, PinningDependencies, supportedRequestTypes, pinnedDependenciesMetadata); err != nil {
 		// This should never happen.
 		panic(err)
 	}
@@ -0,0 +1,41 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package raw
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// ActionsPolicy retrieves the raw data for the Actions-Policy check.
+func ActionsPolicy(c *checker.CheckRequest) (checker.ActionsPolicyData, error) {
+	policy, err := c.RepoClient.GetActionsPolicy()
+	switch {
+	case errors.Is(err, clients.ErrUnsupportedFeature), errors.Is(err, clients.ErrPermissionDenied):
+		c.Dlogger.Debug(&checker.LogMessage{
+			Text: fmt.Sprintf("could not retrieve the actions policy: %v", err),
+		})
+		return checker.ActionsPolicyData{}, nil
+	case err != nil:
+		return checker.ActionsPolicyData{},
+			sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.GetActionsPolicy: %v", err))
+	}
+	return checker.ActionsPolicyData{
+		Policy: policy,
+	}, nil
+}
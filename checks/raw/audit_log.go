@@ -0,0 +1,46 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// auditLogEvents returns the events of the audit log of the organization
+// selected by include. It returns nil when the audit log is not available,
+// e.g. outside of GitHub Enterprise or without an org owner token, in which
+// case the checks are scored without it.
+func auditLogEvents(c clients.RepoClient,
+	include func(*clients.AuditLogEvent) bool,
+) (*checker.AuditLogData, error) {
+	events, err := c.ListAuditLogEvents()
+	switch {
+	case errors.Is(err, clients.ErrUnsupportedFeature), errors.Is(err, clients.ErrPermissionDenied):
+		return nil, nil //nolint:nilnil
+	case err != nil:
+		return nil, fmt.Errorf("RepoClient.ListAuditLogEvents: %w", err)
+	}
+	data := &checker.AuditLogData{}
+	for i := range events {
+		if include(&events[i]) {
+			data.Events = append(data.Events, events[i])
+		}
+	}
+	return data, nil
+}
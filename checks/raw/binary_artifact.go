@@ -15,6 +15,7 @@
 package raw
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"regexp"
@@ -48,12 +49,23 @@ func mustParseConstraint(c string) *semver.Constraints {
 }
 
 // BinaryArtifacts retrieves the raw data for the Binary-Artifacts check.
-func BinaryArtifacts(c clients.RepoClient) (checker.BinaryArtifactData, error) {
+func BinaryArtifacts(c *checker.CheckRequest) (checker.BinaryArtifactData, error) {
+	var fetch checksumFetcher
+	if c.FetchChecksum != nil {
+		fetch = func(url string) (string, error) {
+			return c.FetchChecksum(c.Ctx, url)
+		}
+	}
+	return binaryArtifacts(c.RepoClient, fetch)
+}
+
+func binaryArtifacts(c clients.RepoClient, fetch checksumFetcher) (checker.BinaryArtifactData, error) {
 	files := []checker.File{}
+	var lfsFiles []checker.File
 	err := fileparser.OnMatchingFileContentDo(c, fileparser.PathMatcher{
 		Pattern:       "*",
 		CaseSensitive: false,
-	}, checkBinaryFileContent, &files)
+	}, checkBinaryFileContent, &files, &lfsFiles)
 	if err != nil {
 		return checker.BinaryArtifactData{}, fmt.Errorf("%w", err)
 	}
@@ -63,8 +75,21 @@ func BinaryArtifacts(c clients.RepoClient) (checker.BinaryArtifactData, error) {
 		return checker.BinaryArtifactData{}, fmt.Errorf("%w", err)
 	}
 
+	// Ignore wrapper jars matching the official Gradle and Maven checksums.
+	files, verifiedWrappers := verifyWrapperJars(c, fetch, files)
+
+	releaseFiles, err := releaseBinaries(c)
+	if err != nil {
+		return checker.BinaryArtifactData{}, err
+	}
+
 	// No error, return the files.
-	return checker.BinaryArtifactData{Files: files}, nil
+	return checker.BinaryArtifactData{
+		Files:            files,
+		LFSFiles:         lfsFiles,
+		ReleaseFiles:     releaseFiles,
+		VerifiedWrappers: verifiedWrappers,
+	}, nil
 }
 
 // excludeValidatedGradleWrappers returns the subset of files not confirmed
@@ -99,15 +124,23 @@ func excludeValidatedGradleWrappers(c clients.RepoClient, files []checker.File)
 var checkBinaryFileContent fileparser.DoWhileTrueOnFileContent = func(path string, content []byte,
 	args ...interface{},
 ) (bool, error) {
-	if len(args) != 1 {
+	// The optional second argument collects the binaries stored in Git LFS.
+	if len(args) != 1 && len(args) != 2 {
 		return false, fmt.Errorf(
-			"checkBinaryFileContent requires exactly one argument: %w", errInvalidArgLength)
+			"checkBinaryFileContent requires one or two arguments: %w", errInvalidArgLength)
 	}
 	pfiles, ok := args[0].(*[]checker.File)
 	if !ok {
 		return false, fmt.Errorf(
 			"checkBinaryFileContent requires argument of type *[]checker.File: %w", errInvalidArgType)
 	}
+	var plfsFiles *[]checker.File
+	if len(args) == 2 {
+		if plfsFiles, ok = args[1].(*[]checker.File); !ok {
+			return false, fmt.Errorf(
+				"checkBinaryFileContent requires argument of type *[]checker.File: %w", errInvalidArgType)
+		}
+	}
 
 	binaryFileTypes := map[string]bool{
 		"crx":    true,
@@ -142,6 +175,21 @@ var checkBinaryFileContent fileparser.DoWhileTrueOnFileContent = func(path strin
 	if len(content) == 0 {
 		return true, nil
 	}
+
+	// Files stored in Git LFS are replaced by a text pointer, so only their
+	// name tells whether they are binaries.
+	ext := strings.ReplaceAll(filepath.Ext(path), ".", "")
+	if pointer, isPointer := clients.ParseLFSPointer(content); isPointer {
+		if plfsFiles != nil && binaryFileTypes[strings.ToLower(ext)] {
+			*plfsFiles = append(*plfsFiles, checker.File{
+				Path:     path,
+				Type:     finding.FileTypeBinary,
+				Offset:   checker.OffsetDefault,
+				FileSize: uint(pointer.Size),
+			})
+		}
+		return true, nil
+	}
 	if t, err = filetype.Get(content); err != nil {
 		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("filetype.Get:%v", err))
 	}
@@ -156,7 +204,7 @@ var checkBinaryFileContent fileparser.DoWhileTrueOnFileContent = func(path strin
 		return true, nil
 	}
 
-	exists2 := binaryFileTypes[strings.ReplaceAll(filepath.Ext(path), ".", "")]
+	exists2 := binaryFileTypes[ext]
 	if !isText(content) && exists2 {
 		*pfiles = append(*pfiles, checker.File{
 			Path:   path,
@@ -202,12 +250,20 @@ func gradleWrapperValidated(c clients.RepoClient) (bool, error) {
 		return false, fmt.Errorf("%w", err)
 	}
 	if gradleWrapperValidatingWorkflowFile != "" {
-		// If validated, check that latest commit has a relevant successful run
+		// If validated, check that latest commit has a relevant successful run.
+		// Runs and commits are unknown for local directories, so the wrapper
+		// is not considered validated there.
 		runs, err := c.ListSuccessfulWorkflowRuns(gradleWrapperValidatingWorkflowFile)
+		if errors.Is(err, clients.ErrUnsupportedFeature) {
+			return false, nil
+		}
 		if err != nil {
 			return false, fmt.Errorf("failure listing workflow runs: %w", err)
 		}
 		commits, err := c.ListCommits()
+		if errors.Is(err, clients.ErrUnsupportedFeature) {
+			return false, nil
+		}
 		if err != nil {
 			return false, fmt.Errorf("failure listing commits: %w", err)
 		}
@@ -15,12 +15,14 @@
 package raw
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/clients"
 	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
 )
@@ -40,6 +42,7 @@ func TestBinaryArtifacts(t *testing.T) {
 		commits                []clients.Commit
 		getFileContentCount    int
 		expect                 int
+		expectLFS              int
 	}{
 		{
 			name: "Wasm file",
@@ -68,6 +71,19 @@ func TestBinaryArtifacts(t *testing.T) {
 			getFileContentCount: 1,
 			expect:              1,
 		},
+		{
+			name: "Binary stored in Git LFS",
+			err:  nil,
+			files: [][]string{
+				{
+					"../testdata/binaryartifacts/lfs/vendor.jar",
+					"../testdata/binaryartifacts/lfs/logo.png",
+				},
+			},
+			getFileContentCount: 2,
+			expect:              0,
+			expectLFS:           1,
+		},
 		{
 			name: "non binary file",
 			err:  nil,
@@ -99,7 +115,7 @@ func TestBinaryArtifacts(t *testing.T) {
 				{"../testdata/binaryartifacts/jars/gradle-wrapper.jar"},
 				{},
 			},
-			getFileContentCount: 1,
+			getFileContentCount: 2,
 			expect:              1,
 		},
 		{
@@ -135,7 +151,7 @@ func TestBinaryArtifacts(t *testing.T) {
 				{"../testdata/binaryartifacts/jars/gradle-wrapper.jar"},
 				{"../testdata/binaryartifacts/workflows/nonverify.yaml"},
 			},
-			getFileContentCount: 2,
+			getFileContentCount: 3,
 			expect:              1,
 		},
 		{
@@ -158,7 +174,7 @@ func TestBinaryArtifacts(t *testing.T) {
 					SHA: "sha-old",
 				},
 			},
-			getFileContentCount: 2,
+			getFileContentCount: 3,
 			expect:              1,
 		},
 		{
@@ -171,7 +187,7 @@ func TestBinaryArtifacts(t *testing.T) {
 					"../testdata/binaryartifacts/workflows/verify-outdated-action.yaml",
 				},
 			},
-			getFileContentCount: 3,
+			getFileContentCount: 4,
 			expect:              1,
 		},
 		{
@@ -183,7 +199,7 @@ func TestBinaryArtifacts(t *testing.T) {
 					"../testdata/binaryartifacts/workflows/invalid.yaml",
 				},
 			},
-			getFileContentCount: 2,
+			getFileContentCount: 3,
 			expect:              1,
 		},
 		{
@@ -239,8 +255,15 @@ func TestBinaryArtifacts(t *testing.T) {
 			if tt.commits != nil {
 				mockRepoClient.EXPECT().ListCommits().Return(tt.commits, nil)
 			}
+			mockRepoClient.EXPECT().ListReleases().Return(nil, nil).AnyTimes()
 
-			f, err := BinaryArtifacts(mockRepoClient)
+			f, err := BinaryArtifacts(&checker.CheckRequest{
+				Ctx:        context.Background(),
+				RepoClient: mockRepoClient,
+				FetchChecksum: func(context.Context, string) (string, error) {
+					return "", clients.ErrInvalidChecksum
+				},
+			})
 
 			if tt.err != nil {
 				// If we expect an error, make sure it is the same
@@ -252,6 +275,9 @@ func TestBinaryArtifacts(t *testing.T) {
 				if len(f.Files) != tt.expect {
 					t.Errorf("expected %d files, got %d test %v", tt.expect, len(f.Files), tt.name)
 				}
+				if len(f.LFSFiles) != tt.expectLFS {
+					t.Errorf("expected %d LFS files, got %d test %v", tt.expectLFS, len(f.LFSFiles), tt.name)
+				}
 			}
 		})
 	}
@@ -0,0 +1,163 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1" //nolint:gosec // Maven Central only publishes SHA-1 checksums for older wrappers.
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+const (
+	gradleWrapperJar        = "gradle-wrapper.jar"
+	gradleWrapperProperties = "gradle-wrapper.properties"
+	mavenWrapperJar         = "maven-wrapper.jar"
+	mavenWrapperProperties  = "maven-wrapper.properties"
+	gradleDistributionsURL  = "https://services.gradle.org/distributions"
+)
+
+var (
+	gradleDistributionVersionRegex = regexp.MustCompile(`gradle-([^/]+?)-(bin|all)\.zip$`)
+	// Hosts from which the official Maven wrapper is distributed.
+	mavenCentralHosts = map[string]bool{
+		"repo.maven.apache.org": true,
+		"repo1.maven.org":       true,
+	}
+)
+
+// checksumFetcher returns the hex-encoded digest published at a URL. Wrapper
+// jars are not verified if it is nil.
+type checksumFetcher func(url string) (string, error)
+
+// wrapperChecksum describes where the official checksum of a wrapper jar is
+// published and which hash it uses.
+type wrapperChecksum struct {
+	newHash func() hash.Hash
+	url     string
+}
+
+// verifyWrapperJars splits files into the gradle-wrapper.jar and
+// maven-wrapper.jar files whose checksum matches the one published for the
+// version declared next to them, and the remaining files.
+func verifyWrapperJars(c clients.RepoClient, fetch checksumFetcher,
+	files []checker.File,
+) (remaining, verified []checker.File) {
+	if fetch == nil {
+		return files, nil
+	}
+	for _, f := range files {
+		if verifyWrapperJar(c, fetch, f.Path) {
+			verified = append(verified, f)
+			continue
+		}
+		remaining = append(remaining, f)
+	}
+	return remaining, verified
+}
+
+// verifyWrapperJar returns whether the file at jarPath is a wrapper jar
+// matching its official checksum. Wrappers that cannot be verified, e.g.
+// because the checksum cannot be retrieved, are reported as unverified.
+func verifyWrapperJar(c clients.RepoClient, fetch checksumFetcher, jarPath string) bool {
+	var properties string
+	var checksumFor func(map[string]string) (wrapperChecksum, bool)
+	switch path.Base(jarPath) {
+	case gradleWrapperJar:
+		properties = gradleWrapperProperties
+		checksumFor = gradleWrapperChecksum
+	case mavenWrapperJar:
+		properties = mavenWrapperProperties
+		checksumFor = mavenWrapperChecksum
+	default:
+		return false
+	}
+
+	content, err := c.GetFileContent(path.Join(path.Dir(jarPath), properties))
+	if err != nil {
+		return false
+	}
+	checksum, ok := checksumFor(parseProperties(content))
+	if !ok {
+		return false
+	}
+	expected, err := fetch(checksum.url)
+	if err != nil {
+		return false
+	}
+	jar, err := c.GetFileContent(jarPath)
+	if err != nil {
+		return false
+	}
+	h := checksum.newHash()
+	h.Write(jar)
+	return hex.EncodeToString(h.Sum(nil)) == expected
+}
+
+// gradleWrapperChecksum returns the checksum of the official wrapper jar of
+// the Gradle version referenced by distributionUrl.
+func gradleWrapperChecksum(properties map[string]string) (wrapperChecksum, bool) {
+	sms := gradleDistributionVersionRegex.FindStringSubmatch(properties["distributionUrl"])
+	if len(sms) < 2 {
+		return wrapperChecksum{}, false
+	}
+	return wrapperChecksum{
+		url:     fmt.Sprintf("%s/gradle-%s-wrapper.jar.sha256", gradleDistributionsURL, sms[1]),
+		newHash: sha256.New,
+	}, true
+}
+
+// mavenWrapperChecksum returns the checksum of the wrapper jar referenced by
+// wrapperUrl, as long as it is downloaded from Maven Central.
+func mavenWrapperChecksum(properties map[string]string) (wrapperChecksum, bool) {
+	u, err := url.Parse(properties["wrapperUrl"])
+	if err != nil || u.Scheme != "https" || !mavenCentralHosts[u.Host] ||
+		!strings.HasSuffix(u.Path, ".jar") {
+		return wrapperChecksum{}, false
+	}
+	return wrapperChecksum{
+		url:     u.String() + ".sha1",
+		newHash: sha1.New,
+	}, true
+}
+
+// parseProperties parses the key=value pairs of a Java properties file.
+func parseProperties(content []byte) map[string]string {
+	properties := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		// Properties files escape characters such as ':' with a backslash.
+		properties[strings.TrimSpace(key)] = strings.ReplaceAll(strings.TrimSpace(value), `\`, "")
+	}
+	return properties
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+const (
+	wrapperJarSHA256 = "3dc39ad650d40f6c029bd8ff605c6d95865d657dbfdeacdb079db0ddfffedf9f"
+	wrapperJarSHA1   = "90ea36649cf1e4139133dbafb3a595a46d258226"
+)
+
+func TestVerifyWrapperJars(t *testing.T) {
+	t.Parallel()
+	jar, err := os.ReadFile("../testdata/binaryartifacts/jars/gradle-wrapper.jar")
+	if err != nil {
+		t.Fatalf("os.ReadFile: %v", err)
+	}
+	//nolint:lll
+	tests := []struct {
+		name       string
+		files      map[string]string
+		checksums  map[string]string
+		binaries   []string
+		wantRemain []string
+		wantValid  []string
+		noFetcher  bool
+	}{
+		{
+			name: "gradle wrapper matching the official checksum",
+			files: map[string]string{
+				"gradle/wrapper/gradle-wrapper.properties": `distributionUrl=https\://services.gradle.org/distributions/gradle-8.1.1-bin.zip`,
+			},
+			checksums: map[string]string{
+				"https://services.gradle.org/distributions/gradle-8.1.1-wrapper.jar.sha256": wrapperJarSHA256,
+			},
+			binaries:   []string{"gradle/wrapper/gradle-wrapper.jar", "lib/foo.jar"},
+			wantRemain: []string{"lib/foo.jar"},
+			wantValid:  []string{"gradle/wrapper/gradle-wrapper.jar"},
+		},
+		{
+			name: "gradle wrapper not matching the official checksum",
+			files: map[string]string{
+				"gradle/wrapper/gradle-wrapper.properties": "distributionUrl=https\\://services.gradle.org/distributions/gradle-8.1.1-all.zip",
+			},
+			checksums: map[string]string{
+				"https://services.gradle.org/distributions/gradle-8.1.1-wrapper.jar.sha256": strings.Repeat("0", 64),
+			},
+			binaries:   []string{"gradle/wrapper/gradle-wrapper.jar"},
+			wantRemain: []string{"gradle/wrapper/gradle-wrapper.jar"},
+		},
+		{
+			name: "checksums not downloaded",
+			files: map[string]string{
+				"gradle/wrapper/gradle-wrapper.properties": `distributionUrl=https\://services.gradle.org/distributions/gradle-8.1.1-bin.zip`,
+			},
+			binaries:   []string{"gradle/wrapper/gradle-wrapper.jar"},
+			wantRemain: []string{"gradle/wrapper/gradle-wrapper.jar"},
+			noFetcher:  true,
+		},
+		{
+			name:       "gradle wrapper without properties",
+			binaries:   []string{"gradle/wrapper/gradle-wrapper.jar"},
+			wantRemain: []string{"gradle/wrapper/gradle-wrapper.jar"},
+		},
+		{
+			name: "gradle wrapper of an unknown version",
+			files: map[string]string{
+				"gradle/wrapper/gradle-wrapper.properties": "distributionUrl=https\\://services.gradle.org/distributions/gradle-0.0-bin.zip",
+			},
+			binaries:   []string{"gradle/wrapper/gradle-wrapper.jar"},
+			wantRemain: []string{"gradle/wrapper/gradle-wrapper.jar"},
+		},
+		{
+			name: "maven wrapper from Maven Central",
+			files: map[string]string{
+				".mvn/wrapper/maven-wrapper.properties": "# comment\nwrapperUrl=https://repo.maven.apache.org/maven2/org/apache/maven/wrapper/maven-wrapper/3.2.0/maven-wrapper-3.2.0.jar\n",
+			},
+			checksums: map[string]string{
+				"https://repo.maven.apache.org/maven2/org/apache/maven/wrapper/maven-wrapper/3.2.0/maven-wrapper-3.2.0.jar.sha1": wrapperJarSHA1,
+			},
+			binaries:  []string{".mvn/wrapper/maven-wrapper.jar"},
+			wantValid: []string{".mvn/wrapper/maven-wrapper.jar"},
+		},
+		{
+			name: "maven wrapper from another host",
+			files: map[string]string{
+				".mvn/wrapper/maven-wrapper.properties": "wrapperUrl=https://example.com/maven-wrapper-3.2.0.jar",
+			},
+			checksums: map[string]string{
+				"https://example.com/maven-wrapper-3.2.0.jar.sha1": wrapperJarSHA1,
+			},
+			binaries:   []string{".mvn/wrapper/maven-wrapper.jar"},
+			wantRemain: []string{".mvn/wrapper/maven-wrapper.jar"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(file string) ([]byte, error) {
+				if strings.HasSuffix(file, "-wrapper.jar") {
+					return jar, nil
+				}
+				content, ok := tt.files[file]
+				if !ok {
+					return nil, fmt.Errorf("%w: %s", os.ErrNotExist, file)
+				}
+				return []byte(content), nil
+			}).AnyTimes()
+			var fetch checksumFetcher = func(url string) (string, error) {
+				checksum, ok := tt.checksums[url]
+				if !ok {
+					return "", fmt.Errorf("%w: %s", clients.ErrInvalidChecksum, url)
+				}
+				return checksum, nil
+			}
+			if tt.noFetcher {
+				fetch = nil
+			}
+
+			var files []checker.File
+			for _, b := range tt.binaries {
+				files = append(files, checker.File{Path: b, Type: finding.FileTypeBinary})
+			}
+			remaining, verified := verifyWrapperJars(mockRepoClient, fetch, files)
+			if diff := cmp.Diff(tt.wantRemain, paths(remaining)); diff != "" {
+				t.Errorf("unexpected remaining files (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantValid, paths(verified)); diff != "" {
+				t.Errorf("unexpected verified files (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func paths(files []checker.File) []string {
+	var ret []string
+	for _, f := range files {
+		ret = append(ret, f.Path)
+	}
+	return ret
+}
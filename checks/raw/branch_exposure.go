@@ -0,0 +1,181 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rhysd/actionlint"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// releaseBranchPattern matches the names of release and maintenance
+// branches, e.g. release/v1, release-2.3, stable, 1.x or v2.4-lts.
+var releaseBranchPattern = regexp.MustCompile(
+	`(?i)^(release|releases|rel|stable|hotfix|maint|maintenance|support|lts)([-/_.].+)?$` +
+		`|^v?\d+(\.\d+)*(\.x)?([-/_.](stable|lts))?$` +
+		`|[-/_.](stable|lts|release)$`)
+
+// listReleaseBranches returns the branches of the repo named like release
+// branches, or targeted by releases, or nil if they could not be listed.
+// Failing to list them must not fail Branch-Protection.
+func listReleaseBranches(c clients.RepoClient, targets []string) []clients.BranchInfo {
+	all, err := c.ListBranches()
+	if err != nil {
+		return nil
+	}
+	isTarget := map[string]bool{}
+	for _, t := range targets {
+		isTarget[t] = true
+	}
+	ret := []clients.BranchInfo{}
+	for i := range all {
+		if releaseBranchPattern.MatchString(all[i].Name) || isTarget[all[i].Name] {
+			ret = append(ret, all[i])
+		}
+	}
+	return ret
+}
+
+// collectDeployBranches returns the branches whose pushes run workflows with
+// jobs deploying to an environment, along with their protection. Branch
+// filters with wildcards are skipped, as are workflows which do not parse.
+func collectDeployBranches(c clients.RepoClient, branches *branchSet) ([]checker.DeployBranch, error) {
+	var deploys []checker.DeployBranch
+	err := fileparser.OnMatchingFileContentDo(c, fileparser.PathMatcher{
+		Pattern:       ".github/workflows/*",
+		CaseSensitive: false,
+	}, func(path string, content []byte, args ...interface{}) (bool, error) {
+		if !fileparser.IsWorkflowFile(path) {
+			return true, nil
+		}
+		workflow, errs := actionlint.Parse(content)
+		if len(errs) > 0 && workflow == nil {
+			return true, nil
+		}
+		if !deploysToEnvironment(workflow) {
+			return true, nil
+		}
+		for _, name := range pushBranches(workflow) {
+			deploys = append(deploys, checker.DeployBranch{
+				Name: name,
+				File: checker.File{
+					Path: path,
+					Type: finding.FileTypeSource,
+				},
+			})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(deploys, func(i, j int) bool {
+		return deploys[i].Name < deploys[j].Name
+	})
+
+	var unknown []string
+	protection := map[string]*bool{}
+	for i := range branches.set {
+		b := &branches.set[i]
+		protection[*b.Name] = b.Protected
+	}
+	for i := range deploys {
+		if _, ok := protection[deploys[i].Name]; !ok {
+			protection[deploys[i].Name] = nil
+			unknown = append(unknown, deploys[i].Name)
+		}
+	}
+	// Deleted branches are dropped, and the protection of the branches is
+	// left unknown if it cannot be read.
+	deleted := map[string]bool{}
+	if len(unknown) > 0 {
+		if refs, err := c.GetBranches(unknown); err == nil {
+			for i, name := range unknown {
+				if i >= len(refs) || refs[i] == nil {
+					deleted[name] = true
+					continue
+				}
+				protection[name] = refs[i].Protected
+			}
+		}
+	}
+	var ret []checker.DeployBranch
+	for i := range deploys {
+		if deleted[deploys[i].Name] {
+			continue
+		}
+		deploys[i].Protected = protection[deploys[i].Name]
+		ret = append(ret, deploys[i])
+	}
+	return ret, nil
+}
+
+// listForcePushes returns the force pushes to the protected branches, or nil
+// if the activity of the repo is not available.
+func listForcePushes(c clients.RepoClient, branches *branchSet) []clients.ForcePush {
+	pushes, err := c.ListForcePushes()
+	if err != nil {
+		return nil
+	}
+	protected := map[string]bool{}
+	for i := range branches.set {
+		b := &branches.set[i]
+		if b.Protected != nil && *b.Protected {
+			protected[*b.Name] = true
+		}
+	}
+	ret := []clients.ForcePush{}
+	for i := range pushes {
+		if protected[pushes[i].Branch] {
+			ret = append(ret, pushes[i])
+		}
+	}
+	return ret
+}
+
+func deploysToEnvironment(workflow *actionlint.Workflow) bool {
+	for _, job := range workflow.Jobs {
+		if job != nil && job.Environment != nil && job.Environment.Name != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// pushBranches returns the branches named by the push trigger of the
+// workflow, without the patterns.
+func pushBranches(workflow *actionlint.Workflow) []string {
+	var ret []string
+	for _, event := range workflow.On {
+		webhook, ok := event.(*actionlint.WebhookEvent)
+		if !ok || webhook.Hook == nil || webhook.Hook.Value != "push" || webhook.Branches.IsEmpty() {
+			continue
+		}
+		for _, b := range webhook.Branches.Values {
+			if b == nil || b.Value == "" || strings.ContainsAny(b.Value, "*?[!+") {
+				continue
+			}
+			ret = append(ret, b.Value)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+func TestListReleaseBranches(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().ListBranches().Return([]clients.BranchInfo{
+		{Name: "main"},
+		{Name: "release/v1"},
+		{Name: "releases-2.3"},
+		{Name: "2.x"},
+		{Name: "v3.1"},
+		{Name: "v4-lts"},
+		{Name: "foo-stable"},
+		{Name: "feature/release-notes"},
+		{Name: "dependabot/npm/minimist"},
+		{Name: "shipping"},
+	}, nil)
+	var names []string
+	for _, b := range listReleaseBranches(mockRepoClient, []string{"shipping"}) {
+		names = append(names, b.Name)
+	}
+	want := []string{"release/v1", "releases-2.3", "2.x", "v3.1", "v4-lts", "foo-stable", "shipping"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("listReleaseBranches() mismatch (-want +got):\n%s", diff)
+	}
+
+	mockRepoClient.EXPECT().ListBranches().Return(nil, clients.ErrUnsupportedFeature)
+	if got := listReleaseBranches(mockRepoClient, nil); got != nil {
+		t.Errorf("listReleaseBranches() = %v, want nil", got)
+	}
+}
+
+func TestCollectDeployBranches(t *testing.T) {
+	t.Parallel()
+	main, production := "main", "production"
+	protected, unprotected := true, false
+	content, err := os.ReadFile("testdata/.github/workflows/deploy-branches.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+		func(predicate func(string) (bool, error)) ([]string, error) {
+			return []string{".github/workflows/deploy-branches.yaml"}, nil
+		})
+	mockRepoClient.EXPECT().GetFileContent(gomock.Any()).Return(content, nil)
+	mockRepoClient.EXPECT().GetBranches([]string{"gone", "production"}).Return(
+		[]*clients.BranchRef{nil, {Name: &production, Protected: &unprotected}}, nil)
+
+	branches := branchSet{exists: map[string]bool{}}
+	branches.add(&clients.BranchRef{Name: &main, Protected: &protected})
+	got, err := collectDeployBranches(mockRepoClient, &branches)
+	if err != nil {
+		t.Fatalf("collectDeployBranches() = %v", err)
+	}
+	file := checker.File{Path: ".github/workflows/deploy-branches.yaml", Type: finding.FileTypeSource}
+	want := []checker.DeployBranch{
+		{Name: "main", File: file, Protected: &protected},
+		{Name: "production", File: file, Protected: &unprotected},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("collectDeployBranches() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCollectDeployBranchesErr(t *testing.T) {
+	t.Parallel()
+	errList := errors.New("list failed")
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().ListFiles(gomock.Any()).Return(nil, errList)
+	if _, err := collectDeployBranches(mockRepoClient, &branchSet{exists: map[string]bool{}}); !errors.Is(err, errList) {
+		t.Errorf("collectDeployBranches() = %v, want %v", err, errList)
+	}
+}
+
+func TestListForcePushes(t *testing.T) {
+	t.Parallel()
+	main, dev := "main", "dev"
+	protected, unprotected := true, false
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().ListForcePushes().Return([]clients.ForcePush{
+		{Branch: "main", Actor: "admin"},
+		{Branch: "dev", Actor: "admin"},
+		{Branch: "feature", Actor: "dev"},
+	}, nil)
+	branches := branchSet{exists: map[string]bool{}}
+	branches.add(&clients.BranchRef{Name: &main, Protected: &protected})
+	branches.add(&clients.BranchRef{Name: &dev, Protected: &unprotected})
+	want := []clients.ForcePush{{Branch: "main", Actor: "admin"}}
+	if diff := cmp.Diff(want, listForcePushes(mockRepoClient, &branches)); diff != "" {
+		t.Errorf("listForcePushes() mismatch (-want +got):\n%s", diff)
+	}
+
+	mockRepoClient.EXPECT().ListForcePushes().Return(nil, clients.ErrUnsupportedFeature)
+	if got := listForcePushes(mockRepoClient, &branches); got != nil {
+		t.Errorf("listForcePushes() = %v, want nil", got)
+	}
+}
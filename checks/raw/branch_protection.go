@@ -67,6 +67,8 @@ func BranchProtection(c clients.RepoClient) (checker.BranchProtectionsData, erro
 	if err != nil {
 		return checker.BranchProtectionsData{}, fmt.Errorf("%w", err)
 	}
+	var releaseBranches []string
+	queued := make(map[string]bool)
 	for _, release := range releases {
 		if release.TargetCommitish == "" {
 			// Log with a named error if target_commitish is nil.
@@ -79,32 +81,23 @@ func BranchProtection(c clients.RepoClient) (checker.BranchProtectionsData, erro
 		}
 
 		if branches.contains(release.TargetCommitish) ||
-			branches.contains(branchRedirect(release.TargetCommitish)) {
-			continue
-		}
-
-		// Get the associated release branch.
-		branchRef, err := c.GetBranch(release.TargetCommitish)
-		if err != nil {
-			return checker.BranchProtectionsData{},
-				fmt.Errorf("error during GetBranch(%s): %w", release.TargetCommitish, err)
-		}
-		if branches.add(branchRef) {
+			branches.contains(branchRedirect(release.TargetCommitish)) ||
+			queued[release.TargetCommitish] {
 			continue
 		}
+		queued[release.TargetCommitish] = true
+		releaseBranches = append(releaseBranches, release.TargetCommitish)
+	}
 
-		// Couldn't find the branch check for redirects.
-		redirectBranch := branchRedirect(release.TargetCommitish)
-		if redirectBranch == "" {
-			continue
-		}
-		branchRef, err = c.GetBranch(redirectBranch)
-		if err != nil {
-			return checker.BranchProtectionsData{},
-				fmt.Errorf("error during GetBranch(%s) %w", redirectBranch, err)
-		}
-		branches.add(branchRef)
-		// Branch doesn't exist or was deleted. Continue.
+	// Get the associated release branches at once.
+	redirectBranches, err := addBranches(c, &branches, releaseBranches)
+	if err != nil {
+		return checker.BranchProtectionsData{}, err
+	}
+	// Couldn't find some branches, check for redirects.
+	// Branches which don't exist or were deleted are ignored.
+	if _, err := addBranches(c, &branches, redirectBranches); err != nil {
+		return checker.BranchProtectionsData{}, err
 	}
 
 	codeownersFiles := []string{}
@@ -112,13 +105,56 @@ func BranchProtection(c clients.RepoClient) (checker.BranchProtectionsData, erro
 		return checker.BranchProtectionsData{}, err
 	}
 
+	auditLog, err := auditLogEvents(c, func(e *clients.AuditLogEvent) bool {
+		return e.IsBranchProtectionChange() || e.IsBranchProtectionBypass()
+	})
+	if err != nil {
+		return checker.BranchProtectionsData{}, err
+	}
+
+	deployBranches, err := collectDeployBranches(c, &branches)
+	if err != nil {
+		return checker.BranchProtectionsData{}, err
+	}
+
 	// No error, return the data.
 	return checker.BranchProtectionsData{
+		AuditLog:        auditLog,
 		Branches:        branches.set,
 		CodeownersFiles: codeownersFiles,
+		ReleaseBranches: listReleaseBranches(c, releaseBranches),
+		DeployBranches:  deployBranches,
+		ForcePushes:     listForcePushes(c, &branches),
 	}, nil
 }
 
+// addBranches adds the existing branches among names to the set, and returns
+// the redirects of the ones which were not found.
+func addBranches(c clients.RepoClient, branches *branchSet, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	branchRefs, err := c.GetBranches(names)
+	if err != nil {
+		return nil, fmt.Errorf("error during GetBranches(%v): %w", names, err)
+	}
+	var redirects []string
+	for i, name := range names {
+		var branchRef *clients.BranchRef
+		if i < len(branchRefs) {
+			branchRef = branchRefs[i]
+		}
+		if branches.add(branchRef) {
+			continue
+		}
+		redirect := branchRedirect(name)
+		if redirect != "" && !branches.contains(redirect) {
+			redirects = append(redirects, redirect)
+		}
+	}
+	return redirects, nil
+}
+
 func collectCodeownersFiles(c clients.RepoClient, codeownersFiles *[]string) error {
 	return fileparser.OnMatchingFileContentDo(c, fileparser.PathMatcher{
 		Pattern:       "CODEOWNERS",
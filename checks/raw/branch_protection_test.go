@@ -264,15 +264,26 @@ func TestBranchProtection(t *testing.T) {
 				AnyTimes().DoAndReturn(func() (*clients.BranchRef, error) {
 				return tt.branches.getDefaultBranch()
 			})
-			mockRepoClient.EXPECT().GetBranch(gomock.Any()).AnyTimes().
-				DoAndReturn(func(branch string) (*clients.BranchRef, error) {
-					return tt.branches.getBranch(branch)
+			mockRepoClient.EXPECT().GetBranches(gomock.Any()).AnyTimes().
+				DoAndReturn(func(branches []string) ([]*clients.BranchRef, error) {
+					refs := make([]*clients.BranchRef, len(branches))
+					for i, branch := range branches {
+						ref, err := tt.branches.getBranch(branch)
+						if err != nil {
+							return nil, err
+						}
+						refs[i] = ref
+					}
+					return refs, nil
 				})
 			mockRepoClient.EXPECT().ListReleases().AnyTimes().
 				DoAndReturn(func() ([]clients.Release, error) {
 					return tt.releases, tt.releasesErr
 				})
 			mockRepoClient.EXPECT().ListFiles(gomock.Any()).AnyTimes().Return(tt.repoFiles, nil)
+			mockRepoClient.EXPECT().ListAuditLogEvents().AnyTimes().Return(nil, clients.ErrUnsupportedFeature)
+			mockRepoClient.EXPECT().ListBranches().AnyTimes().Return(nil, clients.ErrUnsupportedFeature)
+			mockRepoClient.EXPECT().ListForcePushes().AnyTimes().Return(nil, clients.ErrUnsupportedFeature)
 			rawData, err := BranchProtection(mockRepoClient)
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("failed. expected: %v, got: %v", tt.wantErr, err)
@@ -15,12 +15,20 @@ package raw
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
 	"github.com/ossf/scorecard/v4/clients"
 	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
 )
 
+// testCommandRegex matches the commands of CI scripts which run tests, e.g.,
+// `go test ./...`, `make check` or `mvn verify`.
+var testCommandRegex = regexp.MustCompile(
+	`(?i)\b(?:test|tests|check|verify|pytest|tox|ctest|rspec|jest|mocha|phpunit)\b`)
+
 func CITests(c clients.RepoClient) (checker.CITestData, error) {
 	commits, err := c.ListCommits()
 	if err != nil {
@@ -31,6 +39,7 @@ func CITests(c clients.RepoClient) (checker.CITestData, error) {
 		return checker.CITestData{}, e
 	}
 
+	mergeQueue := usesMergeQueue(c)
 	runs := make(map[string][]clients.CheckRun)
 	commitStatuses := make(map[string][]clients.Status)
 	prNos := make(map[string]int)
@@ -67,6 +76,29 @@ func CITests(c clients.RepoClient) (checker.CITestData, error) {
 		}
 
 		commitStatuses[pr.HeadSHA] = append(commitStatuses[pr.HeadSHA], statuses...)
+
+		// Merge queues test the commit that lands on the branch, which is
+		// where the check runs and statuses of the merge group are reported.
+		if !mergeQueue || commits[i].SHA == "" || commits[i].SHA == pr.HeadSHA {
+			continue
+		}
+		crs, err := c.ListCheckRunsForRef(commits[i].SHA)
+		if err != nil {
+			return checker.CITestData{}, sce.WithMessage(
+				sce.ErrScorecardInternal,
+				fmt.Sprintf("Client.Repositories.ListCheckRunsForRef: %v", err),
+			)
+		}
+		runs[pr.HeadSHA] = append(runs[pr.HeadSHA], crs...)
+
+		statuses, err = c.ListStatuses(commits[i].SHA)
+		if err != nil {
+			return checker.CITestData{}, sce.WithMessage(
+				sce.ErrScorecardInternal,
+				fmt.Sprintf("Client.Repositories.ListStatuses: %v", err),
+			)
+		}
+		commitStatuses[pr.HeadSHA] = append(commitStatuses[pr.HeadSHA], statuses...)
 	}
 
 	// Collate
@@ -82,5 +114,57 @@ func CITests(c clients.RepoClient) (checker.CITestData, error) {
 		})
 	}
 
-	return checker.CITestData{CIInfo: infos}, nil
+	var configs []checker.CIConfig
+	if err := fileparser.OnCIConfigContentDo(c, collectCIConfig, &configs); err != nil {
+		return checker.CITestData{}, err
+	}
+
+	return checker.CITestData{CIInfo: infos, Configs: configs}, nil
+}
+
+// collectCIConfig records a configuration file of a CI system, and whether
+// it runs tests. Files which cannot be parsed are ignored.
+var collectCIConfig fileparser.DoWhileTrueOnFileContent = func(
+	path string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if len(args) != 1 {
+		return false, fmt.Errorf("collectCIConfig requires exactly 1 arguments: %w", errInvalidArgLength)
+	}
+	configs, ok := args[0].(*[]checker.CIConfig)
+	if !ok {
+		return false, fmt.Errorf("collectCIConfig expects arg[0] of type *[]checker.CIConfig: %w", errInvalidArgType)
+	}
+	config, err := fileparser.ParseCIConfig(path, content)
+	if err != nil {
+		return true, nil
+	}
+	ci := checker.CIConfig{
+		System: string(config.System),
+		File: checker.File{
+			Path: path,
+			Type: finding.FileTypeSource,
+		},
+	}
+	for _, script := range config.Scripts {
+		if testCommandRegex.MatchString(script.Value) {
+			ci.RunsTests = true
+			break
+		}
+	}
+	*configs = append(*configs, ci)
+	return true, nil
+}
+
+// usesMergeQueue returns true if changes are merged onto the default branch
+// through a merge queue. Branches that cannot be retrieved, e.g., for local
+// directories, are treated as not using one.
+func usesMergeQueue(c clients.RepoClient) bool {
+	branch, err := c.GetDefaultBranch()
+	if err != nil || branch == nil {
+		return false
+	}
+	mq := branch.BranchProtectionRule.RequireMergeQueue
+	return mq != nil && *mq
 }
@@ -0,0 +1,136 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+func TestCITestsMergeQueue(t *testing.T) {
+	t.Parallel()
+	trueVal := true
+	headRun := clients.CheckRun{Status: "completed", Conclusion: "success", App: clients.CheckRunApp{Slug: "dco"}}
+	queueRun := clients.CheckRun{Status: "completed", Conclusion: "success", App: clients.CheckRunApp{Slug: "github-actions"}}
+	tests := []struct {
+		name       string
+		mergeQueue *bool
+		want       []clients.CheckRun
+	}{
+		{
+			name: "no merge queue",
+			want: []clients.CheckRun{headRun},
+		},
+		{
+			name:       "merge queue",
+			mergeQueue: &trueVal,
+			want:       []clients.CheckRun{headRun, queueRun},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepo := mockrepo.NewMockRepoClient(ctrl)
+			mockRepo.EXPECT().ListCommits().Return([]clients.Commit{
+				{
+					SHA: "merged",
+					AssociatedMergeRequest: clients.PullRequest{
+						Number:   1,
+						HeadSHA:  "head",
+						MergedAt: time.Now(),
+					},
+				},
+			}, nil)
+			mockRepo.EXPECT().GetDefaultBranch().Return(&clients.BranchRef{
+				BranchProtectionRule: clients.BranchProtectionRule{RequireMergeQueue: tt.mergeQueue},
+			}, nil)
+			mockRepo.EXPECT().ListCheckRunsForRef("head").Return([]clients.CheckRun{headRun}, nil)
+			mockRepo.EXPECT().ListCheckRunsForRef("merged").Return([]clients.CheckRun{queueRun}, nil).MaxTimes(1)
+			mockRepo.EXPECT().ListStatuses(gomock.Any()).Return(nil, nil).AnyTimes()
+			mockRepo.EXPECT().ListFiles(gomock.Any()).Return(nil, nil).AnyTimes()
+
+			got, err := CITests(mockRepo)
+			if err != nil {
+				t.Fatalf("CITests: %v", err)
+			}
+			want := checker.CITestData{CIInfo: []checker.RevisionCIInfo{
+				{HeadSHA: "head", PullRequestNumber: 1, CheckRuns: tt.want},
+			}}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("CITests() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCITestsConfigs(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	mockRepo := mockrepo.NewMockRepoClient(ctrl)
+	mockRepo.EXPECT().ListCommits().Return(nil, nil)
+	mockRepo.EXPECT().GetDefaultBranch().Return(nil, clients.ErrUnsupportedFeature)
+	files := []string{
+		"Jenkinsfile", ".circleci/config.yml", ".travis.yml", ".gitlab-ci.yml", "azure-pipelines.yml", "README.md",
+	}
+	mockRepo.EXPECT().ListFiles(gomock.Any()).AnyTimes().DoAndReturn(
+		func(predicate func(string) (bool, error)) ([]string, error) {
+			var matched []string
+			for _, f := range files {
+				if ok, err := predicate(f); err != nil {
+					return nil, err
+				} else if ok {
+					matched = append(matched, f)
+				}
+			}
+			return matched, nil
+		})
+	mockRepo.EXPECT().GetFileContent(gomock.Any()).AnyTimes().DoAndReturn(func(file string) ([]byte, error) {
+		content, err := os.ReadFile("./testdata/" + file)
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+		return content, nil
+	})
+
+	got, err := CITests(mockRepo)
+	if err != nil {
+		t.Fatalf("CITests: %v", err)
+	}
+	want := []checker.CIConfig{
+		{System: "Jenkins", File: checker.File{Path: "Jenkinsfile", Type: finding.FileTypeSource}, RunsTests: true},
+		{System: "CircleCI", File: checker.File{Path: ".circleci/config.yml", Type: finding.FileTypeSource}, RunsTests: true},
+		{System: "Travis CI", File: checker.File{Path: ".travis.yml", Type: finding.FileTypeSource}},
+		{System: "GitLab CI", File: checker.File{Path: ".gitlab-ci.yml", Type: finding.FileTypeSource}, RunsTests: true},
+		{
+			System: "Azure Pipelines", File: checker.File{Path: "azure-pipelines.yml", Type: finding.FileTypeSource},
+			RunsTests: true,
+		},
+	}
+	if diff := cmp.Diff(want, got.Configs); diff != "" {
+		t.Errorf("CITests() configs mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -40,9 +40,68 @@ func CodeReview(c clients.RepoClient) (checker.CodeReviewData, error) {
 
 	return checker.CodeReviewData{
 		DefaultBranchChangesets: changesets,
+		Summary:                 summarizeReviews(changesets),
+		Integrity:               commitIntegrity(commits),
 	}, nil
 }
 
+// commitIntegrity lists the web edits and the commits by unlinked authors.
+// Authors are only deemed unlinked if the forge linked any of the commits
+// to an account, since the other clients never do.
+func commitIntegrity(commits []clients.Commit) checker.CommitIntegrity {
+	var integrity checker.CommitIntegrity
+	linked := false
+	var unlinked []string
+	for i := range commits {
+		c := &commits[i]
+		if c.Signature.Type == clients.SignatureTypeWebFlow && c.AssociatedMergeRequest.MergedAt.IsZero() {
+			integrity.WebEdits = append(integrity.WebEdits, c.SHA)
+		}
+		if c.Author.Login != "" {
+			linked = true
+		} else {
+			unlinked = append(unlinked, c.SHA)
+		}
+	}
+	if linked {
+		integrity.UnlinkedAuthors = unlinked
+		if integrity.UnlinkedAuthors == nil {
+			integrity.UnlinkedAuthors = []string{}
+		}
+	}
+	return integrity
+}
+
+// summarizeReviews classifies the GitHub changesets by the number of people
+// other than the author that approved them. The user who merged a changeset
+// is recorded as an approving reviewer.
+func summarizeReviews(changesets []checker.Changeset) checker.CodeReviewSummary {
+	summary := checker.CodeReviewSummary{Changesets: len(changesets)}
+	for i := range changesets {
+		cs := &changesets[i]
+		if cs.ReviewPlatform != checker.ReviewPlatformGitHub {
+			continue
+		}
+		approvers := make(map[string]bool)
+		for _, review := range cs.Reviews {
+			if review.State != "APPROVED" || review.Author == nil ||
+				review.Author.Login == "" || review.Author.Login == cs.Author.Login {
+				continue
+			}
+			approvers[review.Author.Login] = true
+		}
+		switch len(approvers) {
+		case 0:
+			summary.SelfMerged++
+		case 1:
+			summary.SingleMaintainerApproved++
+		default:
+			summary.IndependentlyReviewed++
+		}
+	}
+	return summary
+}
+
 func getGithubRevisionID(c *clients.Commit) string {
 	mr := c.AssociatedMergeRequest
 	if !c.AssociatedMergeRequest.MergedAt.IsZero() && mr.Number != 0 {
@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"golang.org/x/exp/slices"
 
 	"github.com/ossf/scorecard/v4/checker"
@@ -385,3 +386,83 @@ func Test_getChangesets(t *testing.T) {
 		assertChangesetArrEq(t, changesets, tt.expected)
 	}
 }
+
+func Test_summarizeReviews(t *testing.T) {
+	t.Parallel()
+	author := clients.User{Login: "author"}
+	approval := func(login string) clients.Review {
+		return clients.Review{State: "APPROVED", Author: &clients.User{Login: login}}
+	}
+	changesets := []checker.Changeset{
+		{
+			// Merged by its author.
+			ReviewPlatform: checker.ReviewPlatformGitHub,
+			Author:         author,
+			Reviews:        []clients.Review{approval("author")},
+		},
+		{
+			// Merged by a maintainer, no other review.
+			ReviewPlatform: checker.ReviewPlatformGitHub,
+			Author:         author,
+			Reviews:        []clients.Review{approval("maintainer")},
+		},
+		{
+			// Approved by its author's peer, then merged by the author.
+			ReviewPlatform: checker.ReviewPlatformGitHub,
+			Author:         author,
+			Reviews: []clients.Review{
+				{State: "COMMENTED", Author: &clients.User{Login: "outsider"}},
+				approval("reviewer"),
+				approval("author"),
+			},
+		},
+		{
+			// Approved by a reviewer, then merged by a maintainer.
+			ReviewPlatform: checker.ReviewPlatformGitHub,
+			Author:         author,
+			Reviews:        []clients.Review{approval("reviewer"), approval("maintainer")},
+		},
+		{
+			ReviewPlatform: checker.ReviewPlatformGerrit,
+		},
+		{
+			// Pushed directly.
+			Commits: []clients.Commit{{SHA: "abc"}},
+		},
+	}
+	want := checker.CodeReviewSummary{
+		Changesets:               6,
+		SelfMerged:               1,
+		SingleMaintainerApproved: 2,
+		IndependentlyReviewed:    1,
+	}
+	if got := summarizeReviews(changesets); got != want {
+		t.Errorf("summarizeReviews() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_commitIntegrity(t *testing.T) {
+	t.Parallel()
+	webFlow := clients.CommitSignature{Type: clients.SignatureTypeWebFlow, Valid: true}
+	merged := clients.PullRequest{Number: 1, MergedAt: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)}
+	commits := []clients.Commit{
+		// Edited on the web.
+		{SHA: "web", Signature: webFlow, Author: clients.User{Login: "dev"}},
+		// Merged from a pull request on the web.
+		{SHA: "merge", Signature: webFlow, AssociatedMergeRequest: merged, Author: clients.User{Login: "dev"}},
+		{SHA: "unlinked"},
+	}
+	want := checker.CommitIntegrity{
+		WebEdits:        []string{"web"},
+		UnlinkedAuthors: []string{"unlinked"},
+	}
+	if diff := cmp.Diff(want, commitIntegrity(commits)); diff != "" {
+		t.Errorf("commitIntegrity() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Clients which do not link commits to accounts.
+	got := commitIntegrity([]clients.Commit{{SHA: "a"}, {SHA: "b"}})
+	if got.UnlinkedAuthors != nil {
+		t.Errorf("commitIntegrity().UnlinkedAuthors = %v, want nil", got.UnlinkedAuthors)
+	}
+}
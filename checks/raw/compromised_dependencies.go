@@ -0,0 +1,381 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/rhysd/actionlint"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// lockedPackage is a package locked by a lockfile, at the line it is locked
+// at if known.
+type lockedPackage struct {
+	name    string
+	version string
+	line    uint
+}
+
+type lockfileParser struct {
+	parse     func([]byte) ([]lockedPackage, error)
+	ecosystem checker.DenylistEcosystem
+}
+
+// lockfileParsers parse the lockfiles checked against the denylist, by
+// PathMatcher pattern.
+var lockfileParsers = map[string]lockfileParser{
+	"package-lock.json": {packageLockPackages, checker.DenylistNPM},
+	"yarn.lock":         {yarnLockPackages, checker.DenylistNPM},
+	"poetry.lock":       {tomlLockPackages, checker.DenylistPyPI},
+	"requirements*.txt": {requirementsPackages, checker.DenylistPyPI},
+	"go.sum":            {goSumModules, checker.DenylistGo},
+	"Cargo.lock":        {tomlLockPackages, checker.DenylistCratesIO},
+	"Gemfile.lock":      {gemfileLockGems, checker.DenylistRubyGems},
+}
+
+// denylistMatcher collects the dependencies matching the entries of the
+// denylist.
+type denylistMatcher struct {
+	entries map[checker.DenylistEcosystem][]*checker.DenylistEntry
+	data    *checker.CompromisedDependenciesData
+}
+
+// CompromisedDependencies retrieves the raw data for the
+// Compromised-Dependencies check: the actions used by the workflows of the
+// repo, and the packages locked by its lockfiles, which are denylisted.
+func CompromisedDependencies(c *checker.CheckRequest) (checker.CompromisedDependenciesData, error) {
+	data := checker.CompromisedDependenciesData{Entries: len(c.Denylist)}
+	if len(c.Denylist) == 0 {
+		return data, nil
+	}
+	m := denylistMatcher{
+		entries: map[checker.DenylistEcosystem][]*checker.DenylistEntry{},
+		data:    &data,
+	}
+	for i := range c.Denylist {
+		e := &c.Denylist[i]
+		m.entries[e.Ecosystem] = append(m.entries[e.Ecosystem], e)
+	}
+
+	if len(m.entries[checker.DenylistGitHubActions]) > 0 {
+		if err := fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
+			Pattern:       fileparser.ActionsWorkflowPattern,
+			CaseSensitive: true,
+		}, m.onWorkflow); err != nil {
+			return checker.CompromisedDependenciesData{}, err
+		}
+	}
+
+	patterns := make([]string, 0, len(lockfileParsers))
+	for pattern := range lockfileParsers {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		parser := lockfileParsers[pattern]
+		if len(m.entries[parser.ecosystem]) == 0 {
+			continue
+		}
+		if err := fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
+			Pattern:       pattern,
+			CaseSensitive: true,
+		}, m.onLockfile, parser); err != nil {
+			return checker.CompromisedDependenciesData{}, err
+		}
+	}
+	return data, nil
+}
+
+func (m *denylistMatcher) report(ecosystem checker.DenylistEcosystem, pathfn, name, version string,
+	line uint, snippet string,
+) {
+	for _, e := range m.entries[ecosystem] {
+		if !e.Matches(name, version) {
+			continue
+		}
+		if line == 0 {
+			line = checker.OffsetDefault
+		}
+		m.data.Dependencies = append(m.data.Dependencies, checker.CompromisedDependency{
+			Entry: e,
+			File: checker.File{
+				Path:    pathfn,
+				Type:    finding.FileTypeSource,
+				Offset:  line,
+				Snippet: snippet,
+			},
+			Name:    name,
+			Version: version,
+		})
+	}
+}
+
+// onWorkflow reports the actions and reusable workflows used by the jobs of
+// a workflow which are denylisted.
+func (m *denylistMatcher) onWorkflow(pathfn string, content []byte, args ...interface{}) (bool, error) {
+	if !fileparser.IsActionsWorkflowFile(pathfn) {
+		return true, nil
+	}
+	workflow, errs := actionlint.Parse(content)
+	if len(errs) > 0 && workflow == nil {
+		return false, fileparser.FormatActionlintError(errs)
+	}
+	ids := make([]string, 0, len(workflow.Jobs))
+	for id := range workflow.Jobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		job := workflow.Jobs[id]
+		if job == nil {
+			continue
+		}
+		uses := []*actionlint.String{}
+		if job.WorkflowCall != nil && job.WorkflowCall.Uses != nil {
+			uses = append(uses, job.WorkflowCall.Uses)
+		}
+		for _, step := range job.Steps {
+			if u := fileparser.GetUses(step); u != nil {
+				uses = append(uses, u)
+			}
+		}
+		for _, u := range uses {
+			// Local actions and container images are not released as actions.
+			if strings.HasPrefix(u.Value, "./") || strings.HasPrefix(u.Value, "docker://") {
+				continue
+			}
+			i := strings.LastIndex(u.Value, "@")
+			if i < 0 {
+				continue
+			}
+			m.report(checker.DenylistGitHubActions, pathfn, u.Value[:i], u.Value[i+1:],
+				fileparser.GetLineNumber(u.Pos), u.Value)
+		}
+	}
+	return true, nil
+}
+
+// onLockfile reports the packages locked by a lockfile which are denylisted.
+// Lockfiles which fail to parse are ignored.
+func (m *denylistMatcher) onLockfile(pathfn string, content []byte, args ...interface{}) (bool, error) {
+	if len(args) != 1 {
+		return false, fmt.Errorf("onLockfile requires exactly 1 arguments: %w", errInvalidArgLength)
+	}
+	parser, ok := args[0].(lockfileParser)
+	if !ok {
+		return false, fmt.Errorf("onLockfile expects arg[0] of type lockfileParser: %w", errInvalidArgType)
+	}
+	// Installed packages are locked by the lockfile of the repo.
+	if strings.HasPrefix(pathfn, "node_modules/") || strings.Contains(pathfn, "/node_modules/") {
+		return true, nil
+	}
+	packages, err := parser.parse(content)
+	if err != nil {
+		//nolint:nilerr
+		return true, nil
+	}
+	for _, p := range packages {
+		m.report(parser.ecosystem, pathfn, p.name, p.version, p.line, p.name+"@"+p.version)
+	}
+	return true, nil
+}
+
+type packageLockDependency struct {
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+	Version      string                           `json:"version"`
+}
+
+// packageLockPackages returns the packages of a package-lock.json, from its
+// packages in version 2 and later, or its dependencies in version 1.
+func packageLockPackages(content []byte) ([]lockedPackage, error) {
+	var lock struct {
+		Packages map[string]struct {
+			// Name is the name of the package installed under an alias.
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			Link    bool   `json:"link"`
+		} `json:"packages"`
+		Dependencies map[string]packageLockDependency `json:"dependencies"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	var packages []lockedPackage
+	if len(lock.Packages) > 0 {
+		for key, p := range lock.Packages {
+			i := strings.LastIndex(key, "node_modules/")
+			// The root package and links to directories are not installed.
+			if i < 0 || p.Link || p.Version == "" {
+				continue
+			}
+			name := p.Name
+			if name == "" {
+				name = key[i+len("node_modules/"):]
+			}
+			packages = append(packages, lockedPackage{name: name, version: p.Version})
+		}
+	} else {
+		var walk func(map[string]packageLockDependency)
+		walk = func(deps map[string]packageLockDependency) {
+			for name, d := range deps {
+				packages = append(packages, lockedPackage{name: name, version: d.Version})
+				walk(d.Dependencies)
+			}
+		}
+		walk(lock.Dependencies)
+	}
+	sortLockedPackages(packages)
+	return packages, nil
+}
+
+// yarnLockPackages returns the packages of a yarn.lock, a list of entries
+// such as:
+//
+//	"@babel/core@^7.0.0", "@babel/core@^7.1.0":
+//	  version "7.1.0"
+func yarnLockPackages(content []byte) ([]lockedPackage, error) {
+	var packages []lockedPackage
+	var names []string
+	var line uint
+	for i, l := range strings.Split(string(content), "\n") {
+		l = strings.TrimRight(l, "\r")
+		switch {
+		case l == "" || strings.HasPrefix(l, "#"):
+		case !strings.HasPrefix(l, " ") && strings.HasSuffix(l, ":"):
+			names = yarnLockNames(strings.TrimSuffix(l, ":"))
+			line = uint(i + 1)
+		case names != nil && strings.HasPrefix(strings.TrimSpace(l), "version"):
+			version := strings.TrimPrefix(strings.TrimSpace(l), "version")
+			version = strings.Trim(strings.TrimPrefix(strings.TrimSpace(version), ":"), ` "`)
+			for _, name := range names {
+				packages = append(packages, lockedPackage{name: name, version: version, line: line})
+			}
+			names = nil
+		}
+	}
+	return packages, nil
+}
+
+// yarnLockNames returns the names of the packages of the specs of an entry
+// of a yarn.lock, e.g. "@babel/core@^7.0.0", "@babel/core@npm:^7.1.0".
+func yarnLockNames(specs string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, spec := range strings.Split(specs, ",") {
+		spec = strings.Trim(strings.TrimSpace(spec), `"`)
+		// The @ of a scope is not the separator of the version.
+		if i := strings.LastIndex(spec, "@"); i > 0 {
+			spec = spec[:i]
+		}
+		if spec != "" && !seen[spec] {
+			seen[spec] = true
+			names = append(names, spec)
+		}
+	}
+	return names
+}
+
+// tomlLockPackages returns the packages of a Cargo.lock or a poetry.lock,
+// which both list them as [[package]] tables.
+func tomlLockPackages(content []byte) ([]lockedPackage, error) {
+	var lock struct {
+		Package []struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("toml.Unmarshal: %w", err)
+	}
+	packages := make([]lockedPackage, 0, len(lock.Package))
+	for _, p := range lock.Package {
+		packages = append(packages, lockedPackage{name: p.Name, version: p.Version})
+	}
+	return packages, nil
+}
+
+// requirementRegex matches the packages pinned by a requirements file, e.g.
+// requests[socks]==2.31.0 ; python_version >= "3.8".
+var requirementRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*===?\s*([^\s;\\#]+)`)
+
+// requirementsPackages returns the packages pinned to a version by a
+// requirements file. Unpinned requirements are not locked.
+func requirementsPackages(content []byte) ([]lockedPackage, error) {
+	var packages []lockedPackage
+	for i, l := range strings.Split(string(content), "\n") {
+		if match := requirementRegex.FindStringSubmatch(strings.TrimSpace(l)); match != nil {
+			packages = append(packages, lockedPackage{name: match[1], version: match[2], line: uint(i + 1)})
+		}
+	}
+	return packages, nil
+}
+
+// goSumModules returns the modules of a go.sum, which lists the hashes of
+// the content and of the go.mod of each version.
+func goSumModules(content []byte) ([]lockedPackage, error) {
+	var packages []lockedPackage
+	seen := map[string]bool{}
+	for i, l := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(l)
+		if len(fields) < 2 {
+			continue
+		}
+		version := strings.TrimSuffix(fields[1], "/go.mod")
+		if key := fields[0] + "@" + version; !seen[key] {
+			seen[key] = true
+			packages = append(packages, lockedPackage{name: fields[0], version: version, line: uint(i + 1)})
+		}
+	}
+	return packages, nil
+}
+
+// gemfileLockGemRegex matches the gems of the specs of a Gemfile.lock, e.g.
+// "    nokogiri (1.15.4-x86_64-linux)", but not their dependencies which are
+// indented further.
+var gemfileLockGemRegex = regexp.MustCompile(`^    ([^\s(]+) \(([^)]+)\)\s*$`)
+
+// gemfileLockGems returns the gems of a Gemfile.lock.
+func gemfileLockGems(content []byte) ([]lockedPackage, error) {
+	var packages []lockedPackage
+	for i, l := range strings.Split(string(content), "\n") {
+		match := gemfileLockGemRegex.FindStringSubmatch(l)
+		if match == nil {
+			continue
+		}
+		// Gems built for a platform have it appended to their version.
+		version, _, _ := strings.Cut(match[2], "-")
+		packages = append(packages, lockedPackage{name: match[1], version: version, line: uint(i + 1)})
+	}
+	return packages, nil
+}
+
+func sortLockedPackages(packages []lockedPackage) {
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].name != packages[j].name {
+			return packages[i].name < packages[j].name
+		}
+		return packages[i].version < packages[j].version
+	})
+}
@@ -0,0 +1,224 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func TestLockfileParsers(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		pattern string
+		content string
+		want    []lockedPackage
+	}{
+		{
+			name:    "package-lock.json v3",
+			pattern: "package-lock.json",
+			content: `{
+				"lockfileVersion": 3,
+				"packages": {
+					"": {"name": "app", "version": "1.0.0"},
+					"node_modules/event-stream": {"version": "3.3.6"},
+					"node_modules/event-stream/node_modules/flatmap-stream": {"version": "0.1.1"},
+					"node_modules/alias": {"name": "ua-parser-js", "version": "0.7.29"},
+					"node_modules/local": {"resolved": "packages/local", "link": true}
+				}
+			}`,
+			want: []lockedPackage{
+				{name: "event-stream", version: "3.3.6"},
+				{name: "flatmap-stream", version: "0.1.1"},
+				{name: "ua-parser-js", version: "0.7.29"},
+			},
+		},
+		{
+			name:    "package-lock.json v1",
+			pattern: "package-lock.json",
+			content: `{
+				"lockfileVersion": 1,
+				"dependencies": {
+					"event-stream": {
+						"version": "3.3.6",
+						"dependencies": {"flatmap-stream": {"version": "0.1.1"}}
+					}
+				}
+			}`,
+			want: []lockedPackage{
+				{name: "event-stream", version: "3.3.6"},
+				{name: "flatmap-stream", version: "0.1.1"},
+			},
+		},
+		{
+			name:    "yarn.lock",
+			pattern: "yarn.lock",
+			content: "# yarn lockfile v1\n\n" +
+				"\"@babel/core@^7.0.0\", \"@babel/core@^7.1.0\":\n" +
+				"  version \"7.1.0\"\n" +
+				"  dependencies:\n" +
+				"    debug \"^4.1.0\"\n\n" +
+				"debug@^4.1.0:\n" +
+				"  version \"4.3.4\"\n",
+			want: []lockedPackage{
+				{name: "@babel/core", version: "7.1.0", line: 3},
+				{name: "debug", version: "4.3.4", line: 8},
+			},
+		},
+		{
+			name:    "yarn berry",
+			pattern: "yarn.lock",
+			content: "\"ctx@npm:^1.0.0\":\n  version: 1.0.1\n  resolution: \"ctx@npm:1.0.1\"\n",
+			want:    []lockedPackage{{name: "ctx", version: "1.0.1", line: 1}},
+		},
+		{
+			name:    "requirements",
+			pattern: "requirements*.txt",
+			content: "# pinned\nrequests[socks]==2.31.0 \\\n    --hash=sha256:abc\nctx == 0.2.6 ; python_version >= \"3.8\"\nflask>=2.0\n-r base.txt\n",
+			want: []lockedPackage{
+				{name: "requests", version: "2.31.0", line: 2},
+				{name: "ctx", version: "0.2.6", line: 4},
+			},
+		},
+		{
+			name:    "go.sum",
+			pattern: "go.sum",
+			content: "github.com/foo/bar v1.2.3 h1:abc=\ngithub.com/foo/bar v1.2.3/go.mod h1:def=\ngithub.com/foo/bar v1.2.2/go.mod h1:ghi=\n",
+			want: []lockedPackage{
+				{name: "github.com/foo/bar", version: "v1.2.3", line: 1},
+				{name: "github.com/foo/bar", version: "v1.2.2", line: 3},
+			},
+		},
+		{
+			name:    "Cargo.lock",
+			pattern: "Cargo.lock",
+			content: "version = 3\n\n[[package]]\nname = \"rustdecimal\"\nversion = \"1.23.1\"\n",
+			want:    []lockedPackage{{name: "rustdecimal", version: "1.23.1"}},
+		},
+		{
+			name:    "Gemfile.lock",
+			pattern: "Gemfile.lock",
+			content: "GEM\n  remote: https://rubygems.org/\n  specs:\n    nokogiri (1.15.4-x86_64-linux)\n      racc (~> 1.4)\n    racc (1.7.1)\n\nDEPENDENCIES\n  nokogiri\n",
+			want: []lockedPackage{
+				{name: "nokogiri", version: "1.15.4", line: 4},
+				{name: "racc", version: "1.7.1", line: 6},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := lockfileParsers[tt.pattern].parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(lockedPackage{})); diff != "" {
+				t.Errorf("parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompromisedDependencies(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		".github/workflows/ci.yml": `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: tj-actions/changed-files@0E58ED8671D6B60D0890C21B07F8835ACE038E67
+      - uses: ./.github/actions/local
+  reuse:
+    uses: evil/workflows/.github/workflows/build.yml@main
+`,
+		"requirements.txt":                       "ctx==0.2.6\nrequests==2.31.0\n",
+		"web/package-lock.json":                  `{"packages": {"node_modules/flatmap-stream": {"version": "0.1.1"}}}`,
+		"web/node_modules/x/package-lock.json":   `{"packages": {"node_modules/flatmap-stream": {"version": "0.1.1"}}}`,
+		"testdata/requirements.txt":              "ctx==0.2.6\n",
+		"Cargo.lock":                             "[[package]]\nname = \"rustdecimal\"\nversion = \"1.23.1\"\n",
+		"docs/requirements-docs.txt":             "ctx==0.2.2\n",
+		".github/workflows/unrelated-readme.txt": "uses: evil/workflows@main\n",
+	}
+	denylist := []checker.DenylistEntry{
+		{
+			ID:        "GHSA-mrrh-fwg8-r2c3",
+			Ecosystem: checker.DenylistGitHubActions,
+			Name:      "tj-actions/changed-files",
+			Versions:  []string{"0e58ed8671d6b60d0890c21b07f8835ace038e67"},
+		},
+		{ID: "evil-workflows", Ecosystem: checker.DenylistGitHubActions, Name: "evil/workflows"},
+		{ID: "ctx-2022", Ecosystem: checker.DenylistPyPI, Name: "CTX", Versions: []string{"0.2.6"}},
+		{ID: "flatmap-stream", Ecosystem: checker.DenylistNPM, Name: "flatmap-stream"},
+		{ID: "rustdecimal", Ecosystem: checker.DenylistCratesIO, Name: "rust_decimal"},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(func(predicate func(string) (bool, error)) ([]string, error) {
+		var ret []string
+		for f := range files {
+			if ok, _ := predicate(f); ok {
+				ret = append(ret, f)
+			}
+		}
+		return ret, nil
+	}).AnyTimes()
+	mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(f string) ([]byte, error) {
+		return []byte(files[f]), nil
+	}).AnyTimes()
+
+	data, err := CompromisedDependencies(&checker.CheckRequest{RepoClient: mockRepoClient, Denylist: denylist})
+	if err != nil {
+		t.Fatalf("CompromisedDependencies: %v", err)
+	}
+	type match struct {
+		ID, Path, Name, Version string
+		Offset                  uint
+	}
+	got := make([]match, 0, len(data.Dependencies))
+	for _, d := range data.Dependencies {
+		got = append(got, match{d.Entry.ID, d.File.Path, d.Name, d.Version, d.File.Offset})
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+	want := []match{
+		{"GHSA-mrrh-fwg8-r2c3", ".github/workflows/ci.yml", "tj-actions/changed-files",
+			"0E58ED8671D6B60D0890C21B07F8835ACE038E67", 7},
+		{"ctx-2022", "requirements.txt", "ctx", "0.2.6", 1},
+		{"evil-workflows", ".github/workflows/ci.yml", "evil/workflows/.github/workflows/build.yml", "main", 10},
+		{"flatmap-stream", "web/package-lock.json", "flatmap-stream", "0.1.1", checker.OffsetDefault},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CompromisedDependencies() mismatch (-want +got):\n%s", diff)
+	}
+	if data.Entries != len(denylist) {
+		t.Errorf("Entries = %d, want %d", data.Entries, len(denylist))
+	}
+
+	// Without a denylist, the repo is not read.
+	data, err = CompromisedDependencies(&checker.CheckRequest{})
+	if err != nil || data.Entries != 0 || len(data.Dependencies) != 0 {
+		t.Errorf("CompromisedDependencies() without denylist = %v, %v", data, err)
+	}
+}
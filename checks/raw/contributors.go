@@ -15,6 +15,7 @@
 package raw
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -31,10 +32,16 @@ func Contributors(c clients.RepoClient) (checker.ContributorsData, error) {
 		return checker.ContributorsData{}, fmt.Errorf("Client.Repositories.ListContributors: %w", err)
 	}
 
+	domains, err := authorEmailDomains(c)
+	if err != nil {
+		return checker.ContributorsData{}, err
+	}
+
 	for _, contrib := range contribs {
 		user := clients.User{
 			Login:            contrib.Login,
 			NumContributions: contrib.NumContributions,
+			EmailDomains:     domains[contrib.Login],
 		}
 
 		for _, org := range contrib.Organizations {
@@ -64,6 +71,90 @@ func Contributors(c clients.RepoClient) (checker.ContributorsData, error) {
 	return checker.ContributorsData{Users: users}, nil
 }
 
+// freeEmailDomains are email providers that say nothing about the
+// affiliation of their users.
+var freeEmailDomains = map[string]bool{
+	"163.com":        true,
+	"aol.com":        true,
+	"fastmail.com":   true,
+	"github.com":     true,
+	"gmail.com":      true,
+	"gmx.de":         true,
+	"gmx.net":        true,
+	"googlemail.com": true,
+	"hey.com":        true,
+	"hotmail.com":    true,
+	"icloud.com":     true,
+	"live.com":       true,
+	"mail.ru":        true,
+	"me.com":         true,
+	"outlook.com":    true,
+	"pm.me":          true,
+	"proton.me":      true,
+	"protonmail.com": true,
+	"qq.com":         true,
+	"yahoo.com":      true,
+	"yandex.ru":      true,
+}
+
+// authorEmailDomains maps the logins of commit authors to the corporate
+// domains of the verified addresses they authored commits with.
+func authorEmailDomains(c clients.RepoClient) (map[string][]string, error) {
+	commits, err := c.ListCommits()
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Client.Repositories.ListCommits: %w", err)
+	}
+
+	// Authors usually commit with the same few addresses, so cache the
+	// domain of each address.
+	cache := make(map[string]string)
+	ret := make(map[string][]string)
+	for _, commit := range commits {
+		login, email := commit.Author.Login, strings.ToLower(commit.Author.Email)
+		if login == "" || email == "" {
+			continue
+		}
+		domain, ok := cache[email]
+		if !ok {
+			domain = emailDomain(email)
+			cache[email] = domain
+		}
+		if domain != "" && !companyContains(ret[login], domain) {
+			ret[login] = append(ret[login], domain)
+		}
+	}
+	return ret, nil
+}
+
+// emailDomain returns the registrable domain of an address, e.g. "example.com"
+// for "me@eng.example.com", or "" for addresses of free email providers.
+func emailDomain(email string) string {
+	i := strings.LastIndex(email, "@")
+	if i < 0 {
+		return ""
+	}
+	labels := strings.Split(strings.Trim(email[i+1:], "."), ".")
+	if len(labels) < 2 {
+		return ""
+	}
+	n := 2
+	// Keep the second-level label of country domains such as "co.uk".
+	if len(labels) > 2 && len(labels[len(labels)-1]) == 2 {
+		switch labels[len(labels)-2] {
+		case "ac", "co", "com", "edu", "gov", "net", "org":
+			n = 3
+		}
+	}
+	domain := strings.Join(labels[len(labels)-n:], ".")
+	if freeEmailDomains[domain] {
+		return ""
+	}
+	return domain
+}
+
 func companyContains(cs []string, name string) bool {
 	for _, a := range cs {
 		if a == name {
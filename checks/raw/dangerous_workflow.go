@@ -59,6 +59,7 @@ func containsUntrustedContextPattern(variable string) bool {
 type triggerName string
 
 var (
+	triggerPullRequest              = triggerName("pull_request")
 	triggerPullRequestTarget        = triggerName("pull_request_target")
 	triggerWorkflowRun              = triggerName("workflow_run")
 	checkoutUntrustedPullRequestRef = "github.event.pull_request"
@@ -66,17 +67,87 @@ var (
 )
 
 // DangerousWorkflow retrieves the raw data for the DangerousWorkflow check.
-func DangerousWorkflow(c clients.RepoClient) (checker.DangerousWorkflowData, error) {
+// Workflows are also checked against the custom rules given.
+func DangerousWorkflow(c clients.RepoClient, rules []checker.WorkflowRule) (checker.DangerousWorkflowData, error) {
 	// data is shared across all GitHub workflows.
 	var data checker.DangerousWorkflowData
 	err := fileparser.OnMatchingFileContentDo(c, fileparser.PathMatcher{
 		Pattern:       ".github/workflows/*",
 		CaseSensitive: false,
-	}, validateGitHubActionWorkflowPatterns, &data)
+	}, suppressingWorkflows(validateGitHubActionWorkflowPatterns), &data, rules)
+	if err != nil {
+		return data, err
+	}
 
+	// Scripts of other CI systems.
+	err = fileparser.OnCIConfigContentDo(c, suppressingWorkflows(validateCIConfigPatterns), &data)
 	return data, err
 }
 
+// untrustedCIInputPatterns match the expressions substituted by CI systems
+// in scripts which may be attacker controlled, like the title of a pull
+// request.
+var untrustedCIInputPatterns = map[fileparser.CISystem]*regexp.Regexp{
+	// Variables of multibranch and GitHub pull request builder pipelines,
+	// interpolated by Groovy, e.g., "${env.CHANGE_TITLE}" or "$CHANGE_BRANCH".
+	fileparser.CISystemJenkins: regexp.MustCompile(
+		`\$\{?\s*(?:env\.)?(?:CHANGE_TITLE|CHANGE_BRANCH|CHANGE_FORK|CHANGE_AUTHOR(?:_DISPLAY_NAME|_EMAIL)?|` +
+			`ghprbPullTitle|ghprbPullDescription|ghprbPullLongDescription|ghprbSourceBranch|ghprbCommentBody|` +
+			`ghprbActualCommitAuthor(?:Email)?)\b\s*\}?`),
+	// Trigger parameters of pipelines, e.g. << pipeline.trigger_parameters.github_app.commit_message >>.
+	fileparser.CISystemCircleCI: regexp.MustCompile(
+		`<<\s*pipeline\.trigger_parameters\.[\w.]*(?:commit_message|commit_title|commit_author_name|branch)\s*>>`),
+	// Macros of predefined variables, e.g. $(Build.SourceVersionMessage), and
+	// their template expressions, e.g. ${{ variables['Build.SourceVersionMessage'] }}.
+	fileparser.CISystemAzurePipelines: regexp.MustCompile(
+		`(?i)\$\(\s*(?:Build\.SourceVersionMessage|Build\.RequestedFor|System\.PullRequest\.SourceBranch)\s*\)|` +
+			`\${{[^{}]*(?:Build\.SourceVersionMessage|Build\.RequestedFor|System\.PullRequest\.SourceBranch)[^{}]*}}`),
+}
+
+// validateCIConfigPatterns checks the scripts of Jenkinsfiles, CircleCI and
+// Azure Pipelines configurations for script injections of untrusted input.
+// Travis CI and GitLab CI do not substitute expressions in scripts.
+var validateCIConfigPatterns fileparser.DoWhileTrueOnFileContent = func(path string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"validateCIConfigPatterns requires exactly 1 arguments: %w", errInvalidArgLength)
+	}
+	pdata, ok := args[0].(*checker.DangerousWorkflowData)
+	if !ok {
+		return false, fmt.Errorf(
+			"validateCIConfigPatterns expects arg[0] of type *checker.DangerousWorkflowData: %w", errInvalidArgType)
+	}
+
+	config, err := fileparser.ParseCIConfig(path, content)
+	if err != nil {
+		return false, err
+	}
+	untrusted, ok := untrustedCIInputPatterns[config.System]
+	if !ok {
+		return true, nil
+	}
+	for _, script := range config.Scripts {
+		if !script.Templated {
+			continue
+		}
+		for _, m := range untrusted.FindAllStringIndex(script.Value, -1) {
+			pdata.Workflows = append(pdata.Workflows, checker.DangerousWorkflow{
+				File: checker.File{
+					Path:    path,
+					Type:    finding.FileTypeSource,
+					Offset:  script.Line + uint(strings.Count(script.Value[:m[0]], "\n")),
+					Snippet: script.Value[m[0]:m[1]],
+				},
+				Type: checker.DangerousWorkflowScriptInjection,
+			})
+		}
+	}
+	return true, nil
+}
+
 // Check file content.
 var validateGitHubActionWorkflowPatterns fileparser.DoWhileTrueOnFileContent = func(path string,
 	content []byte,
@@ -86,9 +157,9 @@ var validateGitHubActionWorkflowPatterns fileparser.DoWhileTrueOnFileContent = f
 		return true, nil
 	}
 
-	if len(args) != 1 {
+	if len(args) != 1 && len(args) != 2 {
 		return false, fmt.Errorf(
-			"validateGitHubActionWorkflowPatterns requires exactly 2 arguments: %w", errInvalidArgLength)
+			"validateGitHubActionWorkflowPatterns requires 1 or 2 arguments: %w", errInvalidArgLength)
 	}
 
 	// Verify the type of the data.
@@ -97,6 +168,14 @@ var validateGitHubActionWorkflowPatterns fileparser.DoWhileTrueOnFileContent = f
 		return false, fmt.Errorf(
 			"validateGitHubActionWorkflowPatterns expects arg[0] of type *patternCbData: %w", errInvalidArgType)
 	}
+	var rules []checker.WorkflowRule
+	if len(args) == 2 {
+		rules, ok = args[1].([]checker.WorkflowRule)
+		if !ok {
+			return false, fmt.Errorf(
+				"validateGitHubActionWorkflowPatterns expects arg[1] of type []checker.WorkflowRule: %w", errInvalidArgType)
+		}
+	}
 
 	if !fileparser.CheckFileContainsCommands(content, "#") {
 		return true, nil
@@ -117,6 +196,9 @@ var validateGitHubActionWorkflowPatterns fileparser.DoWhileTrueOnFileContent = f
 		return false, err
 	}
 
+	// 3. Check for the custom rules.
+	validateWorkflowRules(workflow, path, rules, pdata)
+
 	// TODO: Check other dangerous patterns.
 	return true, nil
 }
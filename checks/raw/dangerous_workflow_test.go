@@ -24,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	"github.com/ossf/scorecard/v4/checker"
 	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
 )
 
@@ -156,8 +157,14 @@ func TestGithubDangerousWorkflow(t *testing.T) {
 
 			ctrl := gomock.NewController(t)
 			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
-			mockRepoClient.EXPECT().ListFiles(gomock.Any()).Return([]string{tt.filename}, nil)
-			mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(file string) ([]byte, error) {
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).AnyTimes().DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					if ok, err := predicate(tt.filename); err != nil || !ok {
+						return nil, err
+					}
+					return []string{tt.filename}, nil
+				})
+			mockRepoClient.EXPECT().GetFileContent(gomock.Any()).AnyTimes().DoAndReturn(func(file string) ([]byte, error) {
 				// This will read the file and return the content
 				content, err := os.ReadFile("../testdata/" + file)
 				if err != nil {
@@ -166,7 +173,7 @@ func TestGithubDangerousWorkflow(t *testing.T) {
 				return content, nil
 			})
 
-			dw, err := DangerousWorkflow(mockRepoClient)
+			dw, err := DangerousWorkflow(mockRepoClient, nil)
 
 			if !errCmp(err, tt.expected.err) {
 				t.Errorf(cmp.Diff(err, tt.expected.err, cmpopts.EquateErrors()))
@@ -182,3 +189,60 @@ func TestGithubDangerousWorkflow(t *testing.T) {
 		})
 	}
 }
+
+func TestCIConfigScriptInjection(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		filename string
+		want     []string
+	}{
+		{
+			name:     "Jenkinsfile",
+			filename: "Jenkinsfile",
+			want:     []string{"${env.CHANGE_TITLE}"},
+		},
+		{
+			name:     "CircleCI",
+			filename: ".circleci/config.yml",
+			want:     []string{"<< pipeline.trigger_parameters.github_app.commit_message >>"},
+		},
+		{
+			name:     "Travis CI",
+			filename: ".travis.yml",
+		},
+		{
+			name:     "GitLab CI",
+			filename: ".gitlab-ci.yml",
+		},
+		{
+			name:     "Azure Pipelines",
+			filename: "azure-pipelines.yml",
+			want:     []string{"$(Build.SourceVersionMessage)"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content, err := os.ReadFile("./testdata/" + tt.filename)
+			if err != nil {
+				t.Fatalf("cannot read file: %v", err)
+			}
+			var dw checker.DangerousWorkflowData
+			if _, err := validateCIConfigPatterns(tt.filename, content, &dw); err != nil {
+				t.Fatalf("validateCIConfigPatterns: %v", err)
+			}
+			var got []string
+			for _, w := range dw.Workflows {
+				if w.Type != checker.DangerousWorkflowScriptInjection {
+					t.Errorf("unexpected type %v", w.Type)
+				}
+				got = append(got, w.File.Snippet)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("validateCIConfigPatterns() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
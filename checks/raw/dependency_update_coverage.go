@@ -0,0 +1,245 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// renovateManagers maps the Renovate managers to the Dependabot ecosystems.
+// See https://docs.renovatebot.com/modules/manager/.
+var renovateManagers = map[string]string{
+	"bundler":          "bundler",
+	"cargo":            "cargo",
+	"composer":         "composer",
+	"dockerfile":       "docker",
+	"git-submodules":   "gitsubmodule",
+	"github-actions":   "github-actions",
+	"gomod":            "gomod",
+	"gradle":           "gradle",
+	"maven":            "maven",
+	"mix":              "mix",
+	"npm":              "npm",
+	"nuget":            "nuget",
+	"pep621":           "pip",
+	"pip-compile":      "pip",
+	"pip_requirements": "pip",
+	"pip_setup":        "pip",
+	"pipenv":           "pip",
+	"poetry":           "pip",
+	"pub":              "pub",
+	"terraform":        "terraform",
+}
+
+type dependabotConfig struct {
+	Updates []struct {
+		PackageEcosystem string   `yaml:"package-ecosystem"`
+		Directory        string   `yaml:"directory"`
+		Directories      []string `yaml:"directories"`
+	} `yaml:"updates"`
+}
+
+// buildRootEcosystems are the ecosystems whose build tools resolve the
+// subprojects of a multi-module build from its root, so Dependabot updates
+// them all from the directory of the root.
+var buildRootEcosystems = map[string]bool{
+	"gradle": true,
+	"maven":  true,
+}
+
+type renovateConfig struct {
+	EnabledManagers []string `json:"enabledManagers"`
+}
+
+// coverageFn returns true if the tool updates the manifests of an ecosystem in a directory.
+type coverageFn func(ecosystem, dir string) bool
+
+// dependencyManifests returns the dependency manifests in files and whether
+// the configuration of the tool covers them.
+func dependencyManifests(c clients.RepoClient, tool *checker.Tool,
+	files []string,
+) ([]checker.DependencyManifest, error) {
+	if len(tool.Files) == 0 || tool.Files[0].Path == "" {
+		return nil, nil
+	}
+	var covered coverageFn
+	switch tool.Name {
+	case "Dependabot", "RenovateBot":
+		content, err := c.GetFileContent(tool.Files[0].Path)
+		if err != nil {
+			return nil, fmt.Errorf("RepoClient.GetFileContent: %w", err)
+		}
+		if tool.Name == "Dependabot" {
+			covered = dependabotCoverage(content)
+		} else {
+			covered = renovateCoverage(content)
+		}
+	}
+	if covered == nil {
+		return nil, nil
+	}
+
+	var manifests []checker.DependencyManifest
+	seen := map[string]bool{}
+	for _, name := range files {
+		ecosystem, dir, ok := manifestEcosystem(name)
+		if !ok || seen[ecosystem+":"+dir] {
+			continue
+		}
+		seen[ecosystem+":"+dir] = true
+		manifests = append(manifests, checker.DependencyManifest{
+			Ecosystem: ecosystem,
+			File: checker.File{
+				Path:   name,
+				Type:   finding.FileTypeSource,
+				Offset: checker.OffsetDefault,
+			},
+			Covered: covered(ecosystem, dir),
+		})
+	}
+	return manifests, nil
+}
+
+// dependabotCoverage returns the coverage of a Dependabot configuration,
+// or nil if it cannot be parsed.
+func dependabotCoverage(content []byte) coverageFn {
+	var config dependabotConfig
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil
+	}
+	return func(ecosystem, dir string) bool {
+		for _, u := range config.Updates {
+			if u.PackageEcosystem != ecosystem {
+				continue
+			}
+			for _, pattern := range append([]string{u.Directory}, u.Directories...) {
+				if pattern != "" && matchesBuildDirectory(ecosystem, normalizeDirectory(pattern), dir) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// matchesBuildDirectory returns true if dir matches a directory of a Dependabot
+// configuration for the ecosystem, or, for the ecosystems of buildRootEcosystems,
+// is a subproject of a directory matching it.
+func matchesBuildDirectory(ecosystem, pattern, dir string) bool {
+	if !buildRootEcosystems[ecosystem] {
+		return matchesDirectory(pattern, dir)
+	}
+	for {
+		if matchesDirectory(pattern, dir) {
+			return true
+		}
+		if dir == "/" {
+			return false
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// renovateCoverage returns the coverage of a Renovate configuration.
+// Renovate updates all the ecosystems it detects unless enabledManagers is set.
+func renovateCoverage(content []byte) coverageFn {
+	var config renovateConfig
+	if err := json.Unmarshal(content, &config); err != nil || len(config.EnabledManagers) == 0 {
+		return func(string, string) bool { return true }
+	}
+	enabled := map[string]bool{}
+	for _, m := range config.EnabledManagers {
+		if ecosystem, ok := renovateManagers[m]; ok {
+			enabled[ecosystem] = true
+		}
+	}
+	return func(ecosystem, _ string) bool {
+		return enabled[ecosystem]
+	}
+}
+
+// manifestEcosystem returns the ecosystem of a dependency manifest and the directory
+// Dependabot expects in its configuration, e.g. "/" for the workflows of GitHub Actions.
+func manifestEcosystem(name string) (ecosystem, dir string, ok bool) {
+	for _, segment := range strings.Split(path.Dir(name), "/") {
+		switch segment {
+		case "testdata", "vendor", "node_modules", "third_party":
+			return "", "", false
+		}
+	}
+	dir = normalizeDirectory(path.Dir(name))
+	base := strings.ToLower(path.Base(name))
+	ext := path.Ext(base)
+	switch {
+	case path.Dir(name) == ".github/workflows" && (ext == ".yml" || ext == ".yaml"):
+		return "github-actions", "/", true
+	case base == "dockerfile" || strings.HasPrefix(base, "dockerfile.") || ext == ".dockerfile":
+		return "docker", dir, true
+	case base == "go.mod":
+		return "gomod", dir, true
+	case base == "package.json":
+		return "npm", dir, true
+	case base == "setup.py" || base == "pyproject.toml" || base == "pipfile" ||
+		(strings.HasPrefix(base, "requirements") && ext == ".txt"):
+		return "pip", dir, true
+	case base == "gemfile":
+		return "bundler", dir, true
+	case base == "cargo.toml":
+		return "cargo", dir, true
+	case base == "composer.json":
+		return "composer", dir, true
+	case base == "pom.xml":
+		return "maven", dir, true
+	case base == "build.gradle" || base == "build.gradle.kts":
+		return "gradle", dir, true
+	case base == "packages.config" || ext == ".csproj" || ext == ".fsproj" || ext == ".vbproj":
+		return "nuget", dir, true
+	case base == "mix.exs":
+		return "mix", dir, true
+	case base == "pubspec.yaml":
+		return "pub", dir, true
+	case ext == ".tf":
+		return "terraform", dir, true
+	case name == ".gitmodules":
+		return "gitsubmodule", "/", true
+	default:
+		return "", "", false
+	}
+}
+
+// normalizeDirectory returns dir with a leading and without a trailing slash.
+func normalizeDirectory(dir string) string {
+	if dir == "." {
+		return "/"
+	}
+	return path.Clean("/" + dir)
+}
+
+// matchesDirectory returns true if dir matches a directory of a Dependabot configuration,
+// which may contain glob patterns, e.g. "/services/*" or "/**".
+func matchesDirectory(pattern, dir string) bool {
+	match, err := fileparser.PathMatcher{Pattern: pattern, CaseSensitive: true}.Match(dir)
+	return err == nil && match
+}
@@ -15,6 +15,7 @@
 package raw
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -30,17 +31,36 @@ const (
 
 // DependencyUpdateTool is the exported name for Depdendency-Update-Tool.
 func DependencyUpdateTool(c clients.RepoClient) (checker.DependencyUpdateToolData, error) {
-	var tools []checker.Tool
-	err := fileparser.OnAllFilesDo(c, checkDependencyFileExists, &tools)
+	// The list of files is kept to find the manifests the tool should cover.
+	files, err := c.ListFiles(func(string) (bool, error) { return true, nil })
 	if err != nil {
-		return checker.DependencyUpdateToolData{}, fmt.Errorf("%w", err)
+		return checker.DependencyUpdateToolData{}, fmt.Errorf("error during ListFiles: %w", err)
+	}
+	var tools []checker.Tool
+	for _, name := range files {
+		continueIter, err := checkDependencyFileExists(name, &tools)
+		if err != nil {
+			return checker.DependencyUpdateToolData{}, err
+		}
+		if !continueIter {
+			break
+		}
 	}
 
 	if len(tools) != 0 {
-		return checker.DependencyUpdateToolData{Tools: tools}, nil
+		manifests, err := dependencyManifests(c, &tools[0], files)
+		if err != nil {
+			return checker.DependencyUpdateToolData{}, err
+		}
+		return checker.DependencyUpdateToolData{Tools: tools, Manifests: manifests}, nil
 	}
 
+	// Local directories have no commit history to search, so only the
+	// configuration files of the tools are detected there.
 	commits, err := c.SearchCommits(clients.SearchCommitsOptions{Author: "dependabot[bot]"})
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return checker.DependencyUpdateToolData{}, nil
+	}
 	if err != nil {
 		return checker.DependencyUpdateToolData{}, fmt.Errorf("%w", err)
 	}
@@ -18,10 +18,12 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/ossf/scorecard/v4/checker"
 	clients "github.com/ossf/scorecard/v4/clients"
 	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/finding"
 )
 
 func Test_checkDependencyFileExists(t *testing.T) {
@@ -165,6 +167,8 @@ func TestDependencyUpdateTool(t *testing.T) {
 		SearchCommits     []clients.Commit
 		CallSearchCommits int
 		files             []string
+		contents          map[string]string
+		manifests         []checker.DependencyManifest
 	}{
 		{
 			name:              "dependency update tool",
@@ -184,6 +188,93 @@ func TestDependencyUpdateTool(t *testing.T) {
 				".github/dependabot.yaml",
 			},
 		},
+		{
+			name:              "dependabot coverage gaps",
+			want:              1,
+			CallSearchCommits: 0,
+			files: []string{
+				".github/dependabot.yml",
+				".github/workflows/ci.yml",
+				".github/workflows/release.yml",
+				"Dockerfile",
+				"go.mod",
+				"tools/go.mod",
+				"services/api/go.mod",
+				"vendor/github.com/foo/bar/go.mod",
+			},
+			contents: map[string]string{
+				".github/dependabot.yml": `
+version: 2
+updates:
+  - package-ecosystem: docker
+    directory: /
+  - package-ecosystem: gomod
+    directories:
+      - /
+      - /services/*
+`,
+			},
+			manifests: []checker.DependencyManifest{
+				{Ecosystem: "github-actions", File: manifestFile(".github/workflows/ci.yml"), Covered: false},
+				{Ecosystem: "docker", File: manifestFile("Dockerfile"), Covered: true},
+				{Ecosystem: "gomod", File: manifestFile("go.mod"), Covered: true},
+				{Ecosystem: "gomod", File: manifestFile("tools/go.mod"), Covered: false},
+				{Ecosystem: "gomod", File: manifestFile("services/api/go.mod"), Covered: true},
+			},
+		},
+		{
+			name:              "dependabot multi-module builds",
+			want:              1,
+			CallSearchCommits: 0,
+			files: []string{
+				".github/dependabot.yml",
+				"build.gradle",
+				"core/build.gradle",
+				"plugins/web/build.gradle.kts",
+				"java/pom.xml",
+				"java/api/pom.xml",
+				"tools/pom.xml",
+				"tools/go.mod",
+			},
+			contents: map[string]string{
+				".github/dependabot.yml": `
+version: 2
+updates:
+  - package-ecosystem: gradle
+    directory: /
+  - package-ecosystem: maven
+    directory: /java
+  - package-ecosystem: gomod
+    directory: /
+`,
+			},
+			manifests: []checker.DependencyManifest{
+				{Ecosystem: "gradle", File: manifestFile("build.gradle"), Covered: true},
+				{Ecosystem: "gradle", File: manifestFile("core/build.gradle"), Covered: true},
+				{Ecosystem: "gradle", File: manifestFile("plugins/web/build.gradle.kts"), Covered: true},
+				{Ecosystem: "maven", File: manifestFile("java/pom.xml"), Covered: true},
+				{Ecosystem: "maven", File: manifestFile("java/api/pom.xml"), Covered: true},
+				{Ecosystem: "maven", File: manifestFile("tools/pom.xml"), Covered: false},
+				{Ecosystem: "gomod", File: manifestFile("tools/go.mod"), Covered: false},
+			},
+		},
+		{
+			name:              "renovate enabled managers",
+			want:              1,
+			CallSearchCommits: 0,
+			files: []string{
+				"renovate.json",
+				"package.json",
+				".github/workflows/ci.yml",
+			},
+			contents: map[string]string{
+				"renovate.json": `{"enabledManagers": ["npm"]}`,
+			},
+			manifests: []checker.DependencyManifest{
+				{Ecosystem: "npm", File: manifestFile("package.json"), Covered: true},
+				{Ecosystem: "github-actions", File: manifestFile(".github/workflows/ci.yml"), Covered: false},
+			},
+		},
 		{
 			name:              "foo bar",
 			wantErr:           false,
@@ -223,6 +314,9 @@ func TestDependencyUpdateTool(t *testing.T) {
 			mockRepo := mockrepo.NewMockRepoClient(ctrl)
 			mockRepo.EXPECT().ListFiles(gomock.Any()).Return(tt.files, nil)
 			mockRepo.EXPECT().SearchCommits(gomock.Any()).Return(tt.SearchCommits, nil).Times(tt.CallSearchCommits)
+			mockRepo.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(name string) ([]byte, error) {
+				return []byte(tt.contents[name]), nil
+			}).AnyTimes()
 
 			got, err := DependencyUpdateTool(mockRepo)
 			if (err != nil) != tt.wantErr {
@@ -233,7 +327,18 @@ func TestDependencyUpdateTool(t *testing.T) {
 				if len(got.Tools) != tt.want {
 					t.Errorf("DependencyUpdateTool() = %v, want %v", got.Tools, tt.want)
 				}
+				if diff := cmp.Diff(tt.manifests, got.Manifests); diff != "" {
+					t.Errorf("DependencyUpdateTool() manifests mismatch (-want +got):\n%s", diff)
+				}
 			}
 		})
 	}
 }
+
+func manifestFile(path string) checker.File {
+	return checker.File{
+		Path:   path,
+		Type:   finding.FileTypeSource,
+		Offset: checker.OffsetDefault,
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package raw
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rhysd/actionlint"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// DeploymentProtection retrieves the raw data for the Deployment-Protection check.
+func DeploymentProtection(c *checker.CheckRequest) (checker.DeploymentProtectionData, error) {
+	var data checker.DeploymentProtectionData
+	err := fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
+		Pattern:       ".github/workflows/*",
+		CaseSensitive: false,
+	}, collectDeployments, &data.Deployments)
+	if err != nil {
+		return checker.DeploymentProtectionData{}, err
+	}
+	// The environments are listed even without deployments so that the
+	// environments no workflow deploys to can be reported.
+	envs, err := c.RepoClient.ListEnvironments()
+	switch {
+	case errors.Is(err, clients.ErrUnsupportedFeature), errors.Is(err, clients.ErrPermissionDenied):
+		c.Dlogger.Debug(&checker.LogMessage{
+			Text: fmt.Sprintf("could not retrieve the environments: %v", err),
+		})
+		return data, nil
+	case err != nil:
+		return checker.DeploymentProtectionData{},
+			sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.ListEnvironments: %v", err))
+	}
+	data.Environments = envs
+	return data, nil
+}
+
+var collectDeployments fileparser.DoWhileTrueOnFileContent = func(path string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if !fileparser.IsWorkflowFile(path) {
+		return true, nil
+	}
+
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"collectDeployments requires exactly 1 arguments: %w", errInvalidArgLength)
+	}
+	pdata, ok := args[0].(*[]checker.Deployment)
+	if !ok {
+		return false, fmt.Errorf(
+			"collectDeployments expects arg[0] of type *[]checker.Deployment: %w", errInvalidArgType)
+	}
+
+	if !fileparser.CheckFileContainsCommands(content, "#") {
+		return true, nil
+	}
+
+	workflow, errs := actionlint.Parse(content)
+	if len(errs) > 0 && workflow == nil {
+		return false, fileparser.FormatActionlintError(errs)
+	}
+
+	for _, job := range workflow.Jobs {
+		if job == nil || job.Environment == nil || job.Environment.Name == nil {
+			continue
+		}
+		name := job.Environment.Name.Value
+		// Environments chosen at runtime can't be resolved.
+		if strings.Contains(name, "${{") {
+			continue
+		}
+		*pdata = append(*pdata, checker.Deployment{
+			Job:         createJob(job),
+			Environment: name,
+			File: checker.File{
+				Path:   path,
+				Type:   finding.FileTypeSource,
+				Offset: fileparser.GetLineNumber(job.Environment.Pos),
+			},
+		})
+	}
+	return true, nil
+}
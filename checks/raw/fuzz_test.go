@@ -0,0 +1,109 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+// The parsers below consume untrusted repository content, so they must not
+// panic on any input. Errors are expected and ignored.
+
+// addSeeds adds the content of the files matching patterns to the seed corpus.
+func addSeeds(f *testing.F, patterns ...string) {
+	f.Helper()
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			f.Fatalf("filepath.Glob: %v", err)
+		}
+		for _, m := range matches {
+			content, err := os.ReadFile(m)
+			if err != nil {
+				f.Fatalf("os.ReadFile: %v", err)
+			}
+			f.Add(content)
+		}
+	}
+}
+
+var workflowSeeds = []string{
+	"testdata/.github/workflows/*.yaml",
+	"../testdata/.github/workflows/*.yaml",
+	"../testdata/.github/workflows/*.yml",
+}
+
+func FuzzDangerousWorkflow(f *testing.F) {
+	addSeeds(f, workflowSeeds...)
+	f.Fuzz(func(t *testing.T, content []byte) {
+		var r checker.DangerousWorkflowData
+		//nolint:errcheck
+		validateGitHubActionWorkflowPatterns(".github/workflows/fuzz.yaml", content, &r)
+	})
+}
+
+func FuzzTokenPermissions(f *testing.F) {
+	addSeeds(f, workflowSeeds...)
+	f.Fuzz(func(t *testing.T, content []byte) {
+		var r permissionCbData
+		//nolint:errcheck
+		validateGitHubActionTokenPermissions(".github/workflows/fuzz.yaml", content, &r)
+	})
+}
+
+func FuzzGitHubActionWorkflowPinning(f *testing.F) {
+	addSeeds(f, workflowSeeds...)
+	f.Fuzz(func(t *testing.T, content []byte) {
+		var r checker.PinningDependenciesData
+		//nolint:errcheck
+		validateGitHubActionWorkflow(".github/workflows/fuzz.yaml", content, &r)
+		//nolint:errcheck
+		validateGitHubWorkflowIsFreeOfInsecureDownloads(".github/workflows/fuzz.yaml", content, &r)
+	})
+}
+
+func FuzzDockerfile(f *testing.F) {
+	addSeeds(f, "testdata/Dockerfile-*")
+	f.Fuzz(func(t *testing.T, content []byte) {
+		var r checker.PinningDependenciesData
+		//nolint:errcheck
+		validateDockerfilesPinning("Dockerfile", content, &r)
+		//nolint:errcheck
+		validateDockerfileInsecureDownloads("Dockerfile", content, &r)
+	})
+}
+
+func FuzzShellScript(f *testing.F) {
+	addSeeds(f, "testdata/*.sh", "testdata/script-*")
+	f.Fuzz(func(t *testing.T, content []byte) {
+		var r checker.PinningDependenciesData
+		//nolint:errcheck
+		validateShellFile("fuzz.sh", 0, 0, content, map[string]bool{}, &r)
+	})
+}
+
+func FuzzSecurityPolicy(f *testing.F) {
+	addSeeds(f, "../testdata/securitypolicy/*")
+	f.Fuzz(func(t *testing.T, content []byte) {
+		file := checker.File{Path: "SECURITY.md"}
+		info := []checker.SecurityPolicyInformation{}
+		//nolint:errcheck
+		checkSecurityPolicyFileContent("SECURITY.md", content, &file, &info)
+	})
+}
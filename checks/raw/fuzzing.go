@@ -16,12 +16,13 @@ package raw
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"regexp"
-	"strings"
 
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/checks/languages"
 	"github.com/ossf/scorecard/v4/clients"
 	sce "github.com/ossf/scorecard/v4/errors"
 	"github.com/ossf/scorecard/v4/finding"
@@ -110,11 +111,10 @@ func Fuzzing(c *checker.CheckRequest) (checker.FuzzingData, error) {
 		)
 	}
 
-	langs, err := c.RepoClient.ListProgrammingLanguages()
+	prominentLangs, err := languages.Prominent(c.RepoClient)
 	if err != nil {
 		return checker.FuzzingData{}, fmt.Errorf("cannot get langs of repo: %w", err)
 	}
-	prominentLangs := getProminentLanguages(langs)
 	for _, lang := range prominentLangs {
 		usingFuzzFunc, files, e := checkFuzzFunc(c, lang)
 		if e != nil {
@@ -131,7 +131,14 @@ func Fuzzing(c *checker.CheckRequest) (checker.FuzzingData, error) {
 			)
 		}
 	}
-	return checker.FuzzingData{Fuzzers: fuzzers}, nil
+	data := checker.FuzzingData{Fuzzers: fuzzers}
+	if usingOSSFuzz {
+		data.OSSFuzzBuild, e = ossFuzzBuildStatus(c)
+		if e != nil {
+			return checker.FuzzingData{}, e
+		}
+	}
+	return data, nil
 }
 
 func checkCFLite(c *checker.CheckRequest) (bool, error) {
@@ -183,6 +190,34 @@ func checkOSSFuzz(c *checker.CheckRequest) (bool, error) {
 	return result.Hits > 0, nil
 }
 
+// ossFuzzBuildStatus summarizes the OSS-Fuzz builds of the project. It
+// returns nil if the build history is not available.
+func ossFuzzBuildStatus(c *checker.CheckRequest) (*checker.OSSFuzzBuildStatus, error) {
+	builds, err := c.OssFuzzRepo.ListCheckRunsForRef(c.RepoClient.URI())
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return nil, nil
+	}
+	if err != nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.ListCheckRunsForRef: %v", err))
+		return nil, e
+	}
+	if len(builds) == 0 {
+		return nil, nil
+	}
+	// Builds are listed most recent first.
+	status := checker.OSSFuzzBuildStatus{
+		LastBuild:          builds[0].CompletedAt,
+		LastBuildSucceeded: builds[0].Conclusion == "success",
+	}
+	for i := range builds {
+		if builds[i].Conclusion == "success" {
+			status.LastSuccessfulBuild = builds[i].CompletedAt
+			break
+		}
+	}
+	return &status, nil
+}
+
 func checkFuzzFunc(c *checker.CheckRequest, lang clients.LanguageName) (bool, []checker.File, error) {
 	if c.RepoClient == nil {
 		return false, nil, nil
@@ -249,29 +284,3 @@ var getFuzzFunc fileparser.DoWhileTrueOnFileContent = func(
 	}
 	return true, nil
 }
-
-func getProminentLanguages(langs []clients.Language) []clients.LanguageName {
-	numLangs := len(langs)
-	if numLangs == 0 {
-		return nil
-	}
-	totalLoC := 0
-	// Use a map to record languages and their lines of code to drop potential duplicates.
-	langMap := map[clients.LanguageName]int{}
-	for _, l := range langs {
-		totalLoC += l.NumLines
-		langMap[l.Name] += l.NumLines
-	}
-	// Calculate the average lines of code in the current repo.
-	// This var can stay as an int, no need for a precise float value.
-	avgLoC := totalLoC / numLangs
-	// Languages that have lines of code above average will be considered prominent.
-	ret := []clients.LanguageName{}
-	for lName, loC := range langMap {
-		if loC >= avgLoC {
-			lang := clients.LanguageName(strings.ToLower(string(lName)))
-			ret = append(ret, lang)
-		}
-	}
-	return ret
-}
@@ -0,0 +1,206 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// snippetContext is the number of characters of a line kept on each side of
+// a hidden character in its snippet, as minified files have long lines.
+const snippetContext = 40
+
+// sourceExtensions are the extensions of the files scanned for hidden
+// characters. Documentation is not scanned, as invisible joiners and
+// non-Latin letters are common in prose.
+var sourceExtensions = map[string]bool{
+	".bash": true, ".c": true, ".cc": true, ".cjs": true, ".cpp": true, ".cs": true,
+	".cxx": true, ".dart": true, ".ex": true, ".exs": true, ".go": true, ".gradle": true,
+	".groovy": true, ".h": true, ".hpp": true, ".hs": true, ".java": true, ".js": true,
+	".json": true, ".jsx": true, ".kt": true, ".kts": true, ".lua": true, ".m": true,
+	".mjs": true, ".php": true, ".pl": true, ".ps1": true, ".py": true, ".r": true,
+	".rb": true, ".rs": true, ".scala": true, ".sh": true, ".sql": true, ".swift": true,
+	".tf": true, ".toml": true, ".ts": true, ".tsx": true, ".vue": true, ".yaml": true,
+	".yml": true, ".zig": true, ".zsh": true,
+}
+
+// sourceFilenames are the names of source files without a distinct extension.
+var sourceFilenames = map[string]bool{
+	"dockerfile": true, "makefile": true, "gemfile": true, "rakefile": true, "jenkinsfile": true,
+}
+
+// HiddenCharacters retrieves the raw data for the Hidden-Characters check.
+func HiddenCharacters(c clients.RepoClient) (checker.HiddenCharactersData, error) {
+	var data checker.HiddenCharactersData
+	err := fileparser.OnMatchingFileContentDo(c, fileparser.PathMatcher{
+		Pattern:       "*",
+		CaseSensitive: false,
+	}, collectHiddenCharacters, &data.Characters)
+	if err != nil {
+		return checker.HiddenCharactersData{}, fmt.Errorf("%w", err)
+	}
+	return data, nil
+}
+
+func isSourceFile(pathfn string) bool {
+	name := strings.ToLower(path.Base(pathfn))
+	return sourceExtensions[path.Ext(name)] || sourceFilenames[name]
+}
+
+var collectHiddenCharacters fileparser.DoWhileTrueOnFileContent = func(path string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"collectHiddenCharacters requires exactly 1 arguments: %w", errInvalidArgLength)
+	}
+	pdata, ok := args[0].(*[]checker.HiddenCharacter)
+	if !ok {
+		return false, fmt.Errorf(
+			"collectHiddenCharacters expects arg[0] of type *[]checker.HiddenCharacter: %w", errInvalidArgType)
+	}
+
+	// Files which are not valid UTF-8 are binary files or use another
+	// encoding, in which the characters below cannot be told apart.
+	if !isSourceFile(path) || !utf8.Valid(content) {
+		return true, nil
+	}
+	for i, line := range strings.Split(string(content), "\n") {
+		*pdata = append(*pdata, findHiddenCharacters(path, uint(i+1), []rune(line))...)
+	}
+	return true, nil
+}
+
+// isBidiControl returns true for the bidirectional control characters used
+// by "Trojan Source" attacks, see https://trojansource.codes.
+func isBidiControl(r rune) bool {
+	return r >= '\u202a' && r <= '\u202e' || r >= '\u2066' && r <= '\u2069'
+}
+
+// isInvisible returns true for characters which are not displayed and are
+// not needed in source code.
+func isInvisible(r rune) bool {
+	switch {
+	case r >= '\u200b' && r <= '\u200d', // zero width space and (non-)joiner.
+		r >= '\u2060' && r <= '\u2064',         // word joiner and invisible operators.
+		r == '\u00ad',                          // soft hyphen.
+		r == '\u180e',                          // mongolian vowel separator.
+		r == '\ufeff',                          // zero width no-break space, or byte order mark.
+		r == '\u3164' || r == '\uffa0',         // hangul fillers, which are valid in identifiers.
+		r >= '\U000E0000' && r <= '\U000E007F': // tags, which can hide ASCII text.
+		return true
+	default:
+		return false
+	}
+}
+
+func isConfusableScript(r rune) bool {
+	return unicode.In(r, unicode.Cyrillic, unicode.Greek)
+}
+
+func isIdentifier(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// findHiddenCharacters returns the hidden characters of line number lineNum.
+func findHiddenCharacters(pathfn string, lineNum uint, line []rune) []checker.HiddenCharacter {
+	var found []checker.HiddenCharacter
+	add := func(t checker.HiddenCharacterType, i int) {
+		found = append(found, checker.HiddenCharacter{
+			Type: t,
+			File: checker.File{
+				Path:    pathfn,
+				Type:    finding.FileTypeSource,
+				Offset:  lineNum,
+				Snippet: escapedSnippet(line, i),
+			},
+			Column:    uint(i + 1),
+			CodePoint: line[i],
+		})
+	}
+
+	for i := 0; i < len(line); i++ {
+		r := line[i]
+		switch {
+		case isBidiControl(r):
+			add(checker.HiddenCharacterBidi, i)
+		case r == '\ufeff' && lineNum == 1 && i == 0:
+			// A byte order mark at the start of the file.
+		case isInvisible(r):
+			add(checker.HiddenCharacterInvisible, i)
+		case isIdentifier(r):
+			// Report the first confusable letter of identifiers mixing
+			// scripts, such as "paypal" spelled with a Cyrillic "a" (U+0430).
+			start := i
+			for i < len(line) && isIdentifier(line[i]) {
+				i++
+			}
+			if i := confusableLetter(line[start:i]); i >= 0 {
+				add(checker.HiddenCharacterHomoglyph, start+i)
+			}
+			i--
+		}
+	}
+	return found
+}
+
+// confusableLetter returns the index of the first Cyrillic or Greek letter of
+// identifier if it also contains Latin letters, or -1 otherwise.
+func confusableLetter(identifier []rune) int {
+	latin, confusable := false, -1
+	for i, r := range identifier {
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin = true
+		case confusable < 0 && isConfusableScript(r):
+			confusable = i
+		}
+	}
+	if !latin {
+		return -1
+	}
+	return confusable
+}
+
+// escapedSnippet returns the code around line[i], with its hidden and
+// confusable characters escaped so that they are visible in the results.
+func escapedSnippet(line []rune, i int) string {
+	start, end := i-snippetContext, i+snippetContext+1
+	if start < 0 {
+		start = 0
+	}
+	if end > len(line) {
+		end = len(line)
+	}
+	var b strings.Builder
+	for _, r := range line[start:end] {
+		if isBidiControl(r) || isInvisible(r) || isConfusableScript(r) {
+			fmt.Fprintf(&b, "\\u%04X", r)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.TrimSpace(b.String())
+}
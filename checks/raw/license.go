@@ -110,11 +110,13 @@ func License(c *checker.CheckRequest) (checker.LicenseData, error) {
 
 	// prepare case insensitive map to map approved licenses matched in repo.
 	setCiMap()
+	// prepare map to index into regex named groups.
+	setGroupIdxsMap()
 
 	licensesFound, lerr := c.RepoClient.ListLicenses()
 	switch {
 	// repo API for licenses is supported
-	// go the work and return from immediate (no searching repo).
+	// go the work (no searching repo for the top-level license).
 	case lerr == nil:
 		for _, v := range licensesFound {
 			results.LicenseFiles = append(results.LicenseFiles,
@@ -131,26 +133,33 @@ func License(c *checker.CheckRequest) (checker.LicenseData, error) {
 					},
 				})
 		}
-		return results, nil
 	// if repo API for listing licenses is not support
 	// continue on using the repo search for a license file.
 	case errors.Is(lerr, clients.ErrUnsupportedFeature):
-		break
+		if err := licenseFileHeuristics(c, &results); err != nil {
+			return results, err
+		}
 	// something else failed, done.
 	default:
 		return results, fmt.Errorf("RepoClient.ListLicenses: %w", lerr)
 	}
 
-	// prepare map to index into regex named groups
-	// only needs to be done once for the non-repo
-	// license check.
-	setGroupIdxsMap()
+	subdirectoryLicenses, err := subdirectoryLicenses(c.RepoClient)
+	if err != nil {
+		return results, err
+	}
+	results.SubdirectoryLicenses = subdirectoryLicenses
+	return results, nil
+}
 
+// licenseFileHeuristics looks for a license file at the top-level directory
+// when the repo API does not support listing licenses.
+func licenseFileHeuristics(c *checker.CheckRequest, results *checker.LicenseData) error {
 	// no repo API for listing licenses, continue looking for files
 	path := checker.LicenseFile{}
 	err := fileparser.OnAllFilesDo(c.RepoClient, isLicenseFile, &path)
 	if err != nil {
-		return results, fmt.Errorf("fileparser.OnAllFilesDo: %w", err)
+		return fmt.Errorf("fileparser.OnAllFilesDo: %w", err)
 	}
 
 	// scorecard search stops at first candidate (isLicenseFile) license file found
@@ -175,7 +184,7 @@ func License(c *checker.CheckRequest) (checker.LicenseData, error) {
 		results.LicenseFiles = append(results.LicenseFiles, path)
 	}
 
-	return results, nil
+	return nil
 }
 
 // TestLicense used for testing purposes.
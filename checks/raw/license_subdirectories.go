@@ -0,0 +1,152 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// maxSubdirectoryLicenses bounds the number of license files read in
+// subdirectories.
+const maxSubdirectoryLicenses = 100
+
+// Directories holding third-party code rather than components of the repo.
+var licenseIgnoredDirs = map[string]bool{
+	"node_modules": true,
+	"testdata":     true,
+	"third_party":  true,
+	"vendor":       true,
+}
+
+var reSpdxLicenseIdentifier = regexp.MustCompile(`SPDX-License-Identifier:\s*([0-9A-Za-z.+-]+)`)
+
+// licenseTexts identify well-known licenses from their text. More specific
+// texts come first, e.g. the LGPL and AGPL mention the GPL.
+var licenseTexts = []struct {
+	re     *regexp.Regexp
+	spdxID string
+}{
+	{regexp.MustCompile(`(?i)Apache License,?\s+Version 2\.0`), "Apache-2.0"},
+	{regexp.MustCompile(`(?i)Mozilla Public License,?\s+(Version|v\.)\s*2\.0`), "MPL-2.0"},
+	{regexp.MustCompile(`(?i)GNU AFFERO GENERAL PUBLIC LICENSE\s+Version 3`), "AGPL-3.0"},
+	{regexp.MustCompile(`(?i)GNU LESSER GENERAL PUBLIC LICENSE\s+Version 3`), "LGPL-3.0"},
+	{regexp.MustCompile(`(?i)GNU LESSER GENERAL PUBLIC LICENSE\s+Version 2\.1`), "LGPL-2.1"},
+	{regexp.MustCompile(`(?i)GNU GENERAL PUBLIC LICENSE\s+Version 3`), "GPL-3.0"},
+	{regexp.MustCompile(`(?i)GNU GENERAL PUBLIC LICENSE\s+Version 2`), "GPL-2.0"},
+	{regexp.MustCompile(`(?i)Eclipse Public License\s+-?\s*v(ersion)?\s*2\.0`), "EPL-2.0"},
+	{regexp.MustCompile(`(?i)This is free and unencumbered software released into the public domain`), "Unlicense"},
+	{regexp.MustCompile(`(?i)Permission to use, copy, modify, and(/or)? distribute this software for any`), "ISC"},
+	{regexp.MustCompile(`(?i)Neither the name of`), "BSD-3-Clause"},
+	{regexp.MustCompile(`(?i)Redistribution and use in source and binary forms`), "BSD-2-Clause"},
+	{regexp.MustCompile(`(?i)Permission is hereby granted, free of charge`), "MIT"},
+}
+
+// subdirectoryLicenses returns the license files of the subdirectories of the
+// repo, e.g. the components of a monorepo, with the license they declare.
+func subdirectoryLicenses(c clients.RepoClient) ([]checker.LicenseFile, error) {
+	files, err := c.ListFiles(func(name string) (bool, error) {
+		return isSubdirectoryLicenseFile(name), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("RepoClient.ListFiles: %w", err)
+	}
+	if len(files) > maxSubdirectoryLicenses {
+		files = files[:maxSubdirectoryLicenses]
+	}
+
+	var licenses []checker.LicenseFile
+	for _, file := range files {
+		lf, _ := checkLicense(path.Base(file))
+		spdxID := lf.LicenseInformation.SpdxID
+		if spdxID == "" {
+			content, err := c.GetFileContent(file)
+			if err != nil {
+				return nil, fmt.Errorf("RepoClient.GetFileContent: %w", err)
+			}
+			spdxID = licenseFromContent(content)
+		}
+		licenses = append(licenses, checker.LicenseFile{
+			File: checker.File{
+				Path: file,
+				Type: finding.FileTypeSource,
+			},
+			LicenseInformation: licenseInformation(spdxID),
+		})
+	}
+	return licenses, nil
+}
+
+// isSubdirectoryLicenseFile returns whether name is a license file below the
+// top-level directory of the repo.
+func isSubdirectoryLicenseFile(name string) bool {
+	dir, base := path.Split(name)
+	if dir == "" || strings.Contains(strings.ToUpper(base), "PATENT") {
+		return false
+	}
+	for _, d := range strings.Split(strings.Trim(dir, "/"), "/") {
+		// Files of a LICENSES/ folder belong to the license of its parent.
+		if _, ok := checkLicense(d); ok || licenseIgnoredDirs[strings.ToLower(d)] {
+			return false
+		}
+	}
+	_, ok := checkLicense(base)
+	return ok
+}
+
+// licenseFromContent returns the SPDX identifier of the license of a file,
+// either declared with an SPDX-License-Identifier tag or recognized from its
+// text.
+func licenseFromContent(content []byte) string {
+	if sms := reSpdxLicenseIdentifier.FindSubmatch(content); len(sms) > 1 {
+		return string(sms[1])
+	}
+	for _, lt := range licenseTexts {
+		if lt.re.Match(content) {
+			return lt.spdxID
+		}
+	}
+	return ""
+}
+
+// licenseInformation returns the license details of an SPDX identifier, with
+// the same conventions as the top-level license file.
+func licenseInformation(spdxID string) checker.License {
+	key := strings.ToUpper(spdxID)
+	// The unlicense is indexed as 'UN', see setCiMap.
+	if key == "UNLICENSE" {
+		key = "UN"
+	}
+	info := checker.License{
+		SpdxID:      spdxID,
+		Name:        fsfOsiApprovedLicenseCiMap[key].Name,
+		Attribution: checker.LicenseAttributionTypeHeuristics,
+		Approved:    len(fsfOsiApprovedLicenseCiMap[key].Name) > 0,
+	}
+	switch {
+	case key == "UN":
+		info.SpdxID = "UNLICENSE"
+	case spdxID == "":
+		info.SpdxID = "NOASSERTION"
+		info.Name = "Other"
+	}
+	return info
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+func TestIsSubdirectoryLicenseFile(t *testing.T) {
+	t.Parallel()
+	setGroupIdxsMap()
+	tests := []struct {
+		name     string
+		filename string
+		want     bool
+	}{
+		{name: "top-level license", filename: "LICENSE", want: false},
+		{name: "component license", filename: "packages/api/LICENSE", want: true},
+		{name: "component license with SPDX ID", filename: "packages/api/LICENSE-MIT.txt", want: true},
+		{name: "component copying file", filename: "packages/web/COPYING", want: true},
+		{name: "component patents file", filename: "packages/api/PATENTS", want: false},
+		{name: "vendored dependency", filename: "vendor/github.com/foo/bar/LICENSE", want: false},
+		{name: "node module", filename: "web/node_modules/foo/LICENSE", want: false},
+		{name: "file of a licenses folder", filename: "LICENSES/MIT.txt", want: false},
+		{name: "source file", filename: "packages/api/license.go", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isSubdirectoryLicenseFile(tt.filename); got != tt.want {
+				t.Errorf("isSubdirectoryLicenseFile(%q) = %v, want %v", tt.filename, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLicenseFromContent(t *testing.T) {
+	t.Parallel()
+	setCiMap()
+	tests := []struct {
+		name    string
+		content string
+		want    checker.License
+	}{
+		{
+			name:    "SPDX identifier",
+			content: "// SPDX-License-Identifier: Apache-2.0\n",
+			want: checker.License{
+				Name: "Apache License 2.0", SpdxID: "Apache-2.0",
+				Attribution: checker.LicenseAttributionTypeHeuristics, Approved: true,
+			},
+		},
+		{
+			name:    "MIT text",
+			content: "MIT License\n\nPermission is hereby granted, free of charge, to any person",
+			want: checker.License{
+				Name: "MIT License", SpdxID: "MIT",
+				Attribution: checker.LicenseAttributionTypeHeuristics, Approved: true,
+			},
+		},
+		{
+			name:    "LGPL text mentioning the GPL",
+			content: "GNU LESSER GENERAL PUBLIC LICENSE\n                       Version 3, 29 June 2007\n\nGNU General Public License",
+			want: checker.License{
+				Name: "GNU Lesser General Public License v3.0", SpdxID: "LGPL-3.0",
+				Attribution: checker.LicenseAttributionTypeHeuristics, Approved: true,
+			},
+		},
+		{
+			name:    "unlicense text",
+			content: "This is free and unencumbered software released into the public domain.",
+			want: checker.License{
+				Name: "The Unlicense", SpdxID: "UNLICENSE",
+				Attribution: checker.LicenseAttributionTypeHeuristics, Approved: true,
+			},
+		},
+		{
+			name:    "unknown text",
+			content: "All rights reserved.",
+			want: checker.License{
+				Name: "Other", SpdxID: "NOASSERTION",
+				Attribution: checker.LicenseAttributionTypeHeuristics,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := licenseInformation(licenseFromContent([]byte(tt.content)))
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected license (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -15,11 +15,24 @@
 package raw
 
 import (
+	"bytes"
 	"fmt"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/config"
+	"github.com/ossf/scorecard/v4/finding"
 )
 
+// securityInsightsFiles are the paths of the SECURITY-INSIGHTS.yml file.
+// See https://github.com/ossf/security-insights-spec.
+var securityInsightsFiles = []string{
+	"SECURITY-INSIGHTS.yml",
+	".github/SECURITY-INSIGHTS.yml",
+}
+
 // Maintained checks for maintenance.
 func Maintained(c *checker.CheckRequest) (checker.MaintainedData, error) {
 	var result checker.MaintainedData
@@ -51,5 +64,74 @@ func Maintained(c *checker.CheckRequest) (checker.MaintainedData, error) {
 	}
 	result.CreatedAt = createdAt
 
+	result.MaintenanceDeclaration = maintenanceDeclaration(c.RepoClient)
+
+	result.AuditLog, err = auditLogEvents(c.RepoClient, (*clients.AuditLogEvent).IsSecretScanningDismissal)
+	if err != nil {
+		return result, err
+	}
+
 	return result, nil
 }
+
+// maintenanceDeclaration returns the maintenance status declared in the scorecard
+// config of the repo or, failing that, in its SECURITY-INSIGHTS.yml.
+// Files which cannot be parsed are ignored.
+func maintenanceDeclaration(c clients.RepoClient) *checker.MaintenanceDeclaration {
+	for _, file := range config.Files {
+		content, err := c.GetFileContent(file)
+		if err != nil {
+			continue
+		}
+		cfg, err := config.Parse(bytes.NewReader(content))
+		if err == nil && cfg.Maintenance != nil {
+			return &checker.MaintenanceDeclaration{
+				Status: checker.MaintenanceStatus(cfg.Maintenance.Status),
+				Reason: cfg.Maintenance.Reason,
+				File:   checker.File{Path: file, Type: finding.FileTypeSource},
+			}
+		}
+		// Only the first config found is used.
+		break
+	}
+
+	for _, file := range securityInsightsFiles {
+		content, err := c.GetFileContent(file)
+		if err != nil {
+			continue
+		}
+		if status, ok := securityInsightsStatus(content); ok {
+			return &checker.MaintenanceDeclaration{
+				Status: status,
+				File:   checker.File{Path: file, Type: finding.FileTypeSource},
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// securityInsightsStatus maps the project lifecycle of a SECURITY-INSIGHTS.yml file
+// to a maintenance status.
+func securityInsightsStatus(content []byte) (checker.MaintenanceStatus, bool) {
+	var insights struct {
+		ProjectLifecycle struct {
+			Status       string `yaml:"status"`
+			BugFixesOnly bool   `yaml:"bug-fixes-only"`
+		} `yaml:"project-lifecycle"`
+	}
+	if err := yaml.Unmarshal(content, &insights); err != nil {
+		return "", false
+	}
+	switch insights.ProjectLifecycle.Status {
+	case "active":
+		if insights.ProjectLifecycle.BugFixesOnly {
+			return checker.MaintenanceFeatureComplete, true
+		}
+		return checker.MaintenanceActive, true
+	case "inactive", "abandoned":
+		return checker.MaintenanceUnmaintained, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/languages"
+	"github.com/ossf/scorecard/v4/clients/registry"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// packageManifest is a package declared by a manifest of the repo.
+type packageManifest struct {
+	ecosystem registry.Ecosystem
+	name      string
+	path      string
+}
+
+// packageManifestParsers parse the manifests at the root of the repo
+// declaring packages, by file name. They return "" for manifests which do
+// not declare a published package.
+var packageManifestParsers = map[string]func([]byte) (string, error){
+	"package.json":   npmPackageName,
+	"pyproject.toml": pypiPackageName,
+	"Cargo.toml":     cratePackageName,
+	"pom.xml":        mavenPackageName,
+}
+
+// registryEcosystems are the registries of the package ecosystems.
+var registryEcosystems = map[languages.Ecosystem]registry.Ecosystem{
+	languages.NPM:      registry.NPM,
+	languages.PyPI:     registry.PyPI,
+	languages.Cargo:    registry.CratesIO,
+	languages.Maven:    registry.Maven,
+	languages.RubyGems: registry.RubyGems,
+}
+
+var gemspecNameRegex = regexp.MustCompile(`(?m)^\s*\w+\.name\s*=\s*["']([^"']+)["']`)
+
+func isPackageManifest(p string) (bool, error) {
+	if strings.Contains(p, "/") {
+		return false, nil
+	}
+	_, ok := packageManifestParsers[p]
+	return ok || path.Ext(p) == ".gemspec", nil
+}
+
+// registryPackages looks up the packages declared by the manifests at the
+// root of the repo in their registry, and whether they declare the repo as
+// their source repo. A package pointing to another repo is either published
+// from a fork or a copy of the repo, or hijacks or impersonates the project.
+func registryPackages(c *checker.CheckRequest, data *checker.PackagingData) error {
+	if c.NewPackageClient == nil || c.Repo == nil || strings.HasPrefix(c.Repo.URI(), "file://") {
+		return nil
+	}
+	files, err := c.RepoClient.ListFiles(isPackageManifest)
+	if err != nil {
+		return fmt.Errorf("RepoClient.ListFiles: %w", err)
+	}
+	for _, f := range files {
+		content, err := c.RepoClient.GetFileContent(f)
+		if err != nil {
+			return fmt.Errorf("RepoClient.GetFileContent: %w", err)
+		}
+		m, ok := parsePackageManifest(f, content)
+		if !ok {
+			continue
+		}
+		pkg, err := lookupPackage(c, m)
+		if errors.Is(err, registry.ErrPackageNotFound) {
+			continue
+		}
+		if err != nil {
+			data.Packages = append(data.Packages, checker.Package{
+				Msg: stringPointer(fmt.Sprintf("looking up %s package %s: %v", m.ecosystem, m.name, err)),
+			})
+			continue
+		}
+		data.RegistryPackages = append(data.RegistryPackages, checker.RegistryPackage{
+			Manifest: checker.File{
+				Path:   m.path,
+				Type:   finding.FileTypeSource,
+				Offset: checker.OffsetDefault,
+			},
+			Package:     pkg,
+			RepoMatches: declaresRepo(pkg, c.Repo.URI()),
+		})
+	}
+	return nil
+}
+
+// parsePackageManifest returns the package declared by the manifest, if any.
+// Manifests which fail to parse are ignored.
+func parsePackageManifest(p string, content []byte) (packageManifest, bool) {
+	m := packageManifest{path: p}
+	var err error
+	if path.Ext(p) == ".gemspec" {
+		m.ecosystem = registryEcosystems[languages.RubyGems]
+		if match := gemspecNameRegex.FindSubmatch(content); match != nil {
+			m.name = string(match[1])
+		}
+	} else {
+		ecosystem, _ := languages.EcosystemOf(p)
+		m.ecosystem = registryEcosystems[ecosystem]
+		m.name, err = packageManifestParsers[p](content)
+	}
+	return m, err == nil && m.name != ""
+}
+
+// lookupPackage returns the registry metadata of the package, reusing that
+// of the package the scan was started from.
+func lookupPackage(c *checker.CheckRequest, m packageManifest) (*registry.Package, error) {
+	if p := c.Package; p != nil && p.Ecosystem == m.ecosystem && p.Name == m.name {
+		return p, nil
+	}
+	client, err := c.NewPackageClient(m.ecosystem)
+	if err != nil {
+		return nil, fmt.Errorf("NewPackageClient: %w", err)
+	}
+	p, err := client.GetPackage(c.Ctx, m.name)
+	if err != nil {
+		return nil, fmt.Errorf("GetPackage: %w", err)
+	}
+	return p, nil
+}
+
+// declaresRepo returns whether the package declares the repo with the given
+// URI, e.g. github.com/owner/repo, as its source repo.
+func declaresRepo(p *registry.Package, uri string) bool {
+	repos := p.RepoCandidates
+	if p.Repo != "" {
+		repos = []string{p.Repo}
+	}
+	for _, r := range repos {
+		if strings.EqualFold(strings.TrimPrefix(r, "https://"), uri) {
+			return true
+		}
+	}
+	return false
+}
+
+func npmPackageName(content []byte) (string, error) {
+	var manifest struct {
+		Name    string `json:"name"`
+		Private bool   `json:"private"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return "", fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	if manifest.Private {
+		return "", nil
+	}
+	return manifest.Name, nil
+}
+
+func pypiPackageName(content []byte) (string, error) {
+	var manifest struct {
+		Project struct {
+			Name string `toml:"name"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Name string `toml:"name"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if err := toml.Unmarshal(content, &manifest); err != nil {
+		return "", fmt.Errorf("toml.Unmarshal: %w", err)
+	}
+	if manifest.Project.Name != "" {
+		return manifest.Project.Name, nil
+	}
+	return manifest.Tool.Poetry.Name, nil
+}
+
+func cratePackageName(content []byte) (string, error) {
+	var manifest struct {
+		Package struct {
+			Name string `toml:"name"`
+			// Publish is false, or the list of registries the crate may be
+			// published to.
+			Publish interface{} `toml:"publish"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(content, &manifest); err != nil {
+		return "", fmt.Errorf("toml.Unmarshal: %w", err)
+	}
+	if publish, ok := manifest.Package.Publish.(bool); ok && !publish {
+		return "", nil
+	}
+	return manifest.Package.Name, nil
+}
+
+func mavenPackageName(content []byte) (string, error) {
+	var manifest struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Parent     struct {
+			GroupID string `xml:"groupId"`
+		} `xml:"parent"`
+	}
+	if err := xml.Unmarshal(content, &manifest); err != nil {
+		return "", fmt.Errorf("xml.Unmarshal: %w", err)
+	}
+	group := manifest.GroupID
+	if group == "" {
+		group = manifest.Parent.GroupID
+	}
+	// Properties, e.g. ${project.groupId}, are not resolved.
+	if group == "" || manifest.ArtifactID == "" || strings.Contains(group+manifest.ArtifactID, "${") {
+		return "", nil
+	}
+	return group + ":" + manifest.ArtifactID, nil
+}
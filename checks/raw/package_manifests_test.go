@@ -0,0 +1,196 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/clients/registry"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+func TestParsePackageManifest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path    string
+		content string
+		want    packageManifest
+		wantOK  bool
+	}{
+		{
+			path:    "package.json",
+			content: `{"name": "@scope/pkg", "version": "1.0.0"}`,
+			want:    packageManifest{ecosystem: registry.NPM, name: "@scope/pkg", path: "package.json"},
+			wantOK:  true,
+		},
+		{
+			path:    "package.json",
+			content: `{"name": "monorepo-root", "private": true}`,
+		},
+		{
+			path:    "pyproject.toml",
+			content: "[build-system]\nrequires = [\"setuptools\"]\n\n[project]\nname = \"pkg\"\n",
+			want:    packageManifest{ecosystem: registry.PyPI, name: "pkg", path: "pyproject.toml"},
+			wantOK:  true,
+		},
+		{
+			path:    "pyproject.toml",
+			content: "[tool.poetry]\nname = \"poetry-pkg\"\n",
+			want:    packageManifest{ecosystem: registry.PyPI, name: "poetry-pkg", path: "pyproject.toml"},
+			wantOK:  true,
+		},
+		{
+			path:    "Cargo.toml",
+			content: "[package]\nname = \"krate\"\nversion = \"0.1.0\"\n",
+			want:    packageManifest{ecosystem: registry.CratesIO, name: "krate", path: "Cargo.toml"},
+			wantOK:  true,
+		},
+		{
+			path:    "Cargo.toml",
+			content: "[package]\nname = \"internal\"\npublish = false\n",
+		},
+		{
+			path:    "Cargo.toml",
+			content: "[workspace]\nmembers = [\"a\", \"b\"]\n",
+		},
+		{
+			path: "pom.xml",
+			content: `<project><parent><groupId>org.example</groupId><artifactId>parent</artifactId></parent>` +
+				`<artifactId>artifact</artifactId></project>`,
+			want:   packageManifest{ecosystem: registry.Maven, name: "org.example:artifact", path: "pom.xml"},
+			wantOK: true,
+		},
+		{
+			path:    "pom.xml",
+			content: `<project><groupId>org.example</groupId><artifactId>${artifact.name}</artifactId></project>`,
+		},
+		{
+			path:    "gem.gemspec",
+			content: "Gem::Specification.new do |spec|\n  spec.name    = \"gem\"\n  spec.version = Gem::VERSION\nend\n",
+			want:    packageManifest{ecosystem: registry.RubyGems, name: "gem", path: "gem.gemspec"},
+			wantOK:  true,
+		},
+		{
+			path:    "package.json",
+			content: `{"name": `,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path+" "+tt.content, func(t *testing.T) {
+			t.Parallel()
+			got, ok := parsePackageManifest(tt.path, []byte(tt.content))
+			if ok != tt.wantOK {
+				t.Fatalf("parsePackageManifest() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("parsePackageManifest() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakePackageClient returns the packages of a registry by name.
+type fakePackageClient map[string]*registry.Package
+
+func (c fakePackageClient) GetPackage(ctx context.Context, name string) (*registry.Package, error) {
+	if p, ok := c[name]; ok {
+		return p, nil
+	}
+	return nil, registry.ErrPackageNotFound
+}
+
+func TestRegistryPackages(t *testing.T) {
+	t.Parallel()
+	npmPkg := &registry.Package{Ecosystem: registry.NPM, Name: "pkg", Repo: "https://github.com/Owner/Repo"}
+	cratePkg := &registry.Package{Ecosystem: registry.CratesIO, Name: "krate", Repo: "https://github.com/attacker/krate"}
+	packages := map[registry.Ecosystem]fakePackageClient{
+		registry.NPM:      {"pkg": npmPkg},
+		registry.CratesIO: {"krate": cratePkg},
+		registry.PyPI:     {},
+	}
+	files := map[string]string{
+		"package.json":     `{"name": "pkg"}`,
+		"Cargo.toml":       "[package]\nname = \"krate\"\n",
+		"pyproject.toml":   "[project]\nname = \"unpublished\"\n",
+		"pom.xml":          `<project><groupId>org.example</groupId><artifactId>artifact</artifactId></project>`,
+		"sub/package.json": `{"name": "nested"}`,
+	}
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(func(predicate func(string) (bool, error)) ([]string, error) {
+		var ret []string
+		for f := range files {
+			if ok, _ := predicate(f); ok {
+				ret = append(ret, f)
+			}
+		}
+		return ret, nil
+	})
+	mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(f string) ([]byte, error) {
+		return []byte(files[f]), nil
+	}).AnyTimes()
+	mockRepo := mockrepo.NewMockRepo(ctrl)
+	mockRepo.EXPECT().URI().Return("github.com/owner/repo").AnyTimes()
+
+	req := checker.CheckRequest{
+		Ctx:        context.Background(),
+		RepoClient: mockRepoClient,
+		Repo:       mockRepo,
+		NewPackageClient: func(e registry.Ecosystem) (registry.PackageClient, error) {
+			if c, ok := packages[e]; ok {
+				return c, nil
+			}
+			return nil, errors.New("registry unreachable") //nolint:goerr113
+		},
+	}
+	var data checker.PackagingData
+	if err := registryPackages(&req, &data); err != nil {
+		t.Fatalf("registryPackages: %v", err)
+	}
+	want := []checker.RegistryPackage{
+		{
+			Manifest:    checker.File{Path: "Cargo.toml", Type: finding.FileTypeSource, Offset: checker.OffsetDefault},
+			Package:     cratePkg,
+			RepoMatches: false,
+		},
+		{
+			Manifest:    checker.File{Path: "package.json", Type: finding.FileTypeSource, Offset: checker.OffsetDefault},
+			Package:     npmPkg,
+			RepoMatches: true,
+		},
+	}
+	sortRegistryPackages := cmp.Transformer("sort", func(in []checker.RegistryPackage) map[string]checker.RegistryPackage {
+		ret := map[string]checker.RegistryPackage{}
+		for _, p := range in {
+			ret[p.Manifest.Path] = p
+		}
+		return ret
+	})
+	if diff := cmp.Diff(want, data.RegistryPackages, sortRegistryPackages); diff != "" {
+		t.Errorf("registryPackages() mismatch (-want +got):\n%s", diff)
+	}
+	// The registry of pom.xml is unreachable, which is logged at debug level.
+	if len(data.Packages) != 1 || data.Packages[0].Msg == nil {
+		t.Errorf("registryPackages() packages = %+v, want a debug message", data.Packages)
+	}
+}
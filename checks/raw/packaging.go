@@ -15,37 +15,55 @@
 package raw
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/rhysd/actionlint"
 
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
 	"github.com/ossf/scorecard/v4/finding"
 )
 
+// maxPackagingRuns is the number of recent successful runs of a publishing
+// workflow recorded for traceability.
+const maxPackagingRuns = 10
+
 // Packaging checks for packages.
 func Packaging(c *checker.CheckRequest) (checker.PackagingData, error) {
-	var data checker.PackagingData
+	data := checker.PackagingData{Registry: c.Package}
+	if err := packagingWorkflows(c, &data); err != nil {
+		return data, err
+	}
+	if err := registryPackages(c, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// packagingWorkflows looks for the GitHub workflows publishing packages.
+func packagingWorkflows(c *checker.CheckRequest, data *checker.PackagingData) error {
 	matchedFiles, err := c.RepoClient.ListFiles(fileparser.IsGithubWorkflowFileCb)
 	if err != nil {
-		return data, fmt.Errorf("%w", err)
+		return fmt.Errorf("%w", err)
 	}
 	if err != nil {
-		return data, fmt.Errorf("RepoClient.ListFiles: %w", err)
+		return fmt.Errorf("RepoClient.ListFiles: %w", err)
 	}
 
 	for _, fp := range matchedFiles {
 		fc, err := c.RepoClient.GetFileContent(fp)
 		if err != nil {
-			return data, fmt.Errorf("RepoClient.GetFileContent: %w", err)
+			return fmt.Errorf("RepoClient.GetFileContent: %w", err)
 		}
 
 		workflow, errs := actionlint.Parse(fc)
 		if len(errs) > 0 && workflow == nil {
 			e := fileparser.FormatActionlintError(errs)
-			return data, e
+			return e
 		}
 
 		// Check if it's a packaging workflow.
@@ -67,10 +85,14 @@ func Packaging(c *checker.CheckRequest) (checker.PackagingData, error) {
 
 		runs, err := c.RepoClient.ListSuccessfulWorkflowRuns(filepath.Base(fp))
 		if err != nil {
-			return data, fmt.Errorf("Client.Actions.ListWorkflowRunsByFileName: %w", err)
+			return fmt.Errorf("Client.Actions.ListWorkflowRunsByFileName: %w", err)
 		}
 
 		if len(runs) > 0 {
+			versions, err := releaseTags(c.RepoClient)
+			if err != nil {
+				return err
+			}
 			// Create package.
 			pkg := checker.Package{
 				File: &checker.File{
@@ -78,23 +100,17 @@ func Packaging(c *checker.CheckRequest) (checker.PackagingData, error) {
 					Type:   finding.FileTypeSource,
 					Offset: match.File.Offset,
 				},
-				Runs: []checker.Run{
-					{
-						URL: runs[0].URL,
-					},
-				},
 			}
 			// Create runs.
-			for _, run := range runs {
-				pkg.Runs = append(pkg.Runs,
-					checker.Run{
-						URL: run.URL,
-					},
-				)
+			for i := range runs {
+				if i == maxPackagingRuns {
+					break
+				}
+				pkg.Runs = append(pkg.Runs, packagingRun(&runs[i], versions))
 			}
 			data.Packages = append(data.Packages, pkg)
 
-			return data, nil
+			return nil
 		}
 
 		data.Packages = append(data.Packages,
@@ -111,8 +127,41 @@ func Packaging(c *checker.CheckRequest) (checker.PackagingData, error) {
 		)
 	}
 
-	// Return raw results.
-	return data, nil
+	return nil
+}
+
+// releaseTags returns the tag names of the repo's releases.
+func releaseTags(c clients.RepoClient) (map[string]bool, error) {
+	releases, err := c.ListReleases()
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("RepoClient.ListReleases: %w", err)
+	}
+	tags := make(map[string]bool, len(releases))
+	for _, r := range releases {
+		tags[r.TagName] = true
+	}
+	return tags, nil
+}
+
+// packagingRun links a run of a publishing workflow to the release it built.
+// A run is considered to have built a release when it was triggered by a
+// release event or for the tag of a published release.
+func packagingRun(run *clients.WorkflowRun, versions map[string]bool) checker.Run {
+	r := checker.Run{
+		URL:       run.URL,
+		CreatedAt: run.CreatedAt,
+	}
+	if run.HeadSHA != nil {
+		r.HeadSHA = *run.HeadSHA
+	}
+	ref := strings.TrimPrefix(run.Ref, "refs/tags/")
+	if ref != "" && (run.Event == "release" || versions[ref]) {
+		r.Version = ref
+	}
+	return r
 }
 
 func stringPointer(s string) *string {
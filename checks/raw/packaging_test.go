@@ -0,0 +1,102 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func TestPackagingRuns(t *testing.T) {
+	t.Parallel()
+	sha := "1234567890abcdef"
+	created := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		releases []clients.Release
+		runs     []clients.WorkflowRun
+		want     []checker.Run
+	}{
+		{
+			name:     "run built a release tag",
+			releases: []clients.Release{{TagName: "v1.0.0"}},
+			runs: []clients.WorkflowRun{
+				{URL: "https://example.com/run/2", HeadSHA: &sha, Ref: "v1.0.0", Event: "push", CreatedAt: created},
+				{URL: "https://example.com/run/1", HeadSHA: &sha, Ref: "main", Event: "push", CreatedAt: created},
+			},
+			want: []checker.Run{
+				{URL: "https://example.com/run/2", HeadSHA: sha, Version: "v1.0.0", CreatedAt: created},
+				{URL: "https://example.com/run/1", HeadSHA: sha, CreatedAt: created},
+			},
+		},
+		{
+			name: "run triggered by a release event",
+			runs: []clients.WorkflowRun{
+				{URL: "https://example.com/run/1", Ref: "v2.0.0", Event: "release"},
+			},
+			want: []checker.Run{
+				{URL: "https://example.com/run/1", Version: "v2.0.0"},
+			},
+		},
+		{
+			name:     "tag is not a release",
+			releases: []clients.Release{{TagName: "v1.0.0"}},
+			runs: []clients.WorkflowRun{
+				{URL: "https://example.com/run/1", Ref: "nightly", Event: "push"},
+			},
+			want: []checker.Run{
+				{URL: "https://example.com/run/1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			workflow := ".github/workflows/github-workflow-packaging-npm.yaml"
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).Return([]string{workflow}, nil)
+			mockRepoClient.EXPECT().GetFileContent(workflow).DoAndReturn(func(file string) ([]byte, error) {
+				//nolint:wrapcheck
+				return os.ReadFile("../testdata/" + file)
+			})
+			mockRepoClient.EXPECT().ListSuccessfulWorkflowRuns(gomock.Any()).Return(tt.runs, nil)
+			mockRepoClient.EXPECT().ListReleases().Return(tt.releases, nil)
+
+			data, err := Packaging(&checker.CheckRequest{RepoClient: mockRepoClient})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []checker.Run
+			for _, p := range data.Packages {
+				if p.Msg == nil {
+					got = p.Runs
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("runs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
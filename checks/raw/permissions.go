@@ -54,7 +54,7 @@ func TokenPermissions(c *checker.CheckRequest) (checker.TokenPermissionsData, er
 	var data permissionCbData
 
 	err := fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
-		Pattern:       ".github/workflows/*",
+		Pattern:       fileparser.ActionsWorkflowPattern,
 		CaseSensitive: false,
 	}, validateGitHubActionTokenPermissions, &data)
 
@@ -66,7 +66,7 @@ var validateGitHubActionTokenPermissions fileparser.DoWhileTrueOnFileContent = f
 	content []byte,
 	args ...interface{},
 ) (bool, error) {
-	if !fileparser.IsWorkflowFile(path) {
+	if !fileparser.IsActionsWorkflowFile(path) {
 		return true, nil
 	}
 	// Verify the type of the data.
@@ -94,9 +94,11 @@ var validateGitHubActionTokenPermissions fileparser.DoWhileTrueOnFileContent = f
 	// https://docs.github.com/en/actions/reference/authentication-in-a-workflow#example-1-passing-the-github_token-as-an-input,
 	// https://github.blog/changelog/2021-04-20-github-actions-control-permissions-for-github_token/,
 	// https://docs.github.com/en/actions/reference/authentication-in-a-workflow#modifying-the-permissions-for-the-github_token.
-	if err := validateTopLevelPermissions(workflow, path, pdata); err != nil {
+	start := len(pdata.results.TokenPermissions)
+	if err := validateTopLevelPermissions(workflow, path, jobsLine(content), pdata); err != nil {
 		return false, err
 	}
+	addPermissionsPatch(pdata.results.TokenPermissions[start:], topLevelPermissionsPatch(workflow), nil)
 
 	// 2. Run-level permission definitions,
 	// see https://docs.github.com/en/actions/reference/workflow-syntax-for-github-actions#jobsjob_idpermissions.
@@ -261,7 +263,7 @@ func validatePermissions(permissions *actionlint.Permissions, permLoc checker.Pe
 	return nil
 }
 
-func validateTopLevelPermissions(workflow *actionlint.Workflow, path string,
+func validateTopLevelPermissions(workflow *actionlint.Workflow, path string, offset uint,
 	pdata *permissionCbData,
 ) error {
 	// Check if permissions are set explicitly.
@@ -272,11 +274,10 @@ func validateTopLevelPermissions(workflow *actionlint.Workflow, path string,
 				File: &checker.File{
 					Path:   path,
 					Type:   finding.FileTypeSource,
-					Offset: checker.OffsetDefault,
+					Offset: offset,
 				},
 				LocationType: &permLoc,
 				Type:         checker.PermissionLevelUndeclared,
-				// TODO: Job
 			})
 
 		return nil
@@ -290,7 +291,8 @@ func validatejobLevelPermissions(workflow *actionlint.Workflow, path string,
 	pdata *permissionCbData,
 	ignoredPermissions map[permission]bool,
 ) error {
-	for _, job := range workflow.Jobs {
+	for id, job := range workflow.Jobs {
+		start := len(pdata.results.TokenPermissions)
 		// Run-level permissions may be left undefined.
 		// For most workflows, no write permissions are needed,
 		// so only top-level read-only permissions need to be declared.
@@ -306,16 +308,13 @@ func validatejobLevelPermissions(workflow *actionlint.Workflow, path string,
 					LocationType: &permLoc,
 					Type:         checker.PermissionLevelUndeclared,
 					Msg:          stringPointer(fmt.Sprintf("no %s permission defined", permLoc)),
-					// TODO: Job
 				})
-
-			continue
-		}
-		err := validatePermissions(job.Permissions, checker.PermissionLocationJob,
-			path, pdata, ignoredPermissions)
-		if err != nil {
+		} else if err := validatePermissions(job.Permissions, checker.PermissionLocationJob,
+			path, pdata, ignoredPermissions); err != nil {
 			return err
 		}
+		addPermissionsPatch(pdata.results.TokenPermissions[start:],
+			formatPermissions(requiredPermissions(job), 0), workflowJob(id, job))
 	}
 	return nil
 }
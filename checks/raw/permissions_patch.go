@@ -0,0 +1,162 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rhysd/actionlint"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+)
+
+const (
+	permissionRead  = "read"
+	permissionWrite = "write"
+)
+
+// Permissions needed by the GITHUB_TOKEN for well-known actions and reusable
+// workflows, keyed by name without the version.
+// Every job is assumed to need to read the repository contents.
+var actionPermissions = map[string]map[string]string{
+	"actions/attest-build-provenance":   {"attestations": permissionWrite, "id-token": permissionWrite},
+	"actions/deploy-pages":              {"pages": permissionWrite, "id-token": permissionWrite},
+	"actions/labeler":                   {"pull-requests": permissionWrite},
+	"actions/stale":                     {"issues": permissionWrite, "pull-requests": permissionWrite},
+	"github/codeql-action/analyze":      {"security-events": permissionWrite, "actions": permissionRead},
+	"github/codeql-action/upload-sarif": {"security-events": permissionWrite},
+	"goreleaser/goreleaser-action":      {"contents": permissionWrite},
+	"ossf/scorecard-action":             {"security-events": permissionWrite, "id-token": permissionWrite},
+	"peaceiris/actions-gh-pages":        {"contents": permissionWrite},
+	"peter-evans/create-pull-request":   {"contents": permissionWrite, "pull-requests": permissionWrite},
+	"relekang/python-semantic-release":  {"contents": permissionWrite},
+	"softprops/action-gh-release":       {"contents": permissionWrite},
+	"slsa-framework/slsa-github-generator/.github/workflows/builder_go_slsa3.yml": {
+		"actions": permissionRead, "contents": permissionWrite, "id-token": permissionWrite,
+	},
+	"slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml": {
+		"actions": permissionRead, "contents": permissionWrite, "id-token": permissionWrite,
+	},
+}
+
+var jobsKey = regexp.MustCompile(`(?m)^jobs\s*:`)
+
+// jobsLine returns the line of the top-level `jobs` key, before which the
+// top-level permissions of a workflow go.
+func jobsLine(content []byte) uint {
+	loc := jobsKey.FindIndex(content)
+	if loc == nil {
+		return checker.OffsetDefault
+	}
+	return uint(strings.Count(string(content[:loc[0]]), "\n") + 1)
+}
+
+// requiredPermissions returns the minimal permissions a job needs, based on
+// the actions and reusable workflows it uses.
+func requiredPermissions(job *actionlint.Job) map[string]string {
+	perms := map[string]string{"contents": permissionRead}
+	var uses []string
+	if job.WorkflowCall != nil && job.WorkflowCall.Uses != nil {
+		uses = append(uses, job.WorkflowCall.Uses.Value)
+	}
+	for _, step := range job.Steps {
+		if u := fileparser.GetUses(step); u != nil {
+			uses = append(uses, u.Value)
+		}
+	}
+	for _, u := range uses {
+		name := strings.ToLower(strings.Split(u, "@")[0])
+		for scope, level := range actionPermissions[name] {
+			if perms[scope] != permissionWrite {
+				perms[scope] = level
+			}
+		}
+	}
+	return perms
+}
+
+// formatPermissions returns a `permissions:` block indented by indent spaces.
+func formatPermissions(perms map[string]string, indent int) string {
+	scopes := make([]string, 0, len(perms))
+	for scope := range perms {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+
+	pad := strings.Repeat(" ", indent)
+	var sb strings.Builder
+	sb.WriteString(pad + "permissions:\n")
+	for _, scope := range scopes {
+		sb.WriteString(fmt.Sprintf("%s  %s: %s\n", pad, scope, perms[scope]))
+	}
+	return sb.String()
+}
+
+// topLevelPermissionsPatch suggests read-only top-level permissions, along
+// with job-level permissions for the jobs without permissions that need more.
+func topLevelPermissionsPatch(workflow *actionlint.Workflow) string {
+	patch := formatPermissions(map[string]string{"contents": permissionRead}, 0)
+
+	ids := make([]string, 0, len(workflow.Jobs))
+	for id := range workflow.Jobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var jobs strings.Builder
+	for _, id := range ids {
+		job := workflow.Jobs[id]
+		if job == nil || job.Permissions != nil {
+			continue
+		}
+		perms := requiredPermissions(job)
+		if len(perms) == 1 && perms["contents"] == permissionRead {
+			continue
+		}
+		jobs.WriteString(fmt.Sprintf("  %s:\n", id))
+		jobs.WriteString(formatPermissions(perms, 4))
+	}
+	if jobs.Len() > 0 {
+		patch += "\njobs:\n" + jobs.String()
+	}
+	return patch
+}
+
+// addPermissionsPatch attaches the suggested permissions to the results
+// for undeclared or over-granted permissions.
+func addPermissionsPatch(results []checker.TokenPermission, patch string, job *checker.WorkflowJob) {
+	for i := range results {
+		r := &results[i]
+		if job != nil {
+			r.Job = job
+		}
+		if r.Type == checker.PermissionLevelUndeclared || r.Type == checker.PermissionLevelWrite {
+			p := patch
+			r.Patch = &p
+		}
+	}
+}
+
+func workflowJob(id string, job *actionlint.Job) *checker.WorkflowJob {
+	j := &checker.WorkflowJob{ID: stringPointer(id)}
+	if job.Name != nil && job.Name.Value != "" {
+		j.Name = stringPointer(job.Name.Value)
+	}
+	return j
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/rhysd/actionlint"
+)
+
+func TestTopLevelPermissionsPatch(t *testing.T) {
+	t.Parallel()
+	content := []byte(`name: release
+on: push
+
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v3
+  release:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v3
+      - uses: goreleaser/goreleaser-action@v4
+  analyze:
+    runs-on: ubuntu-latest
+    permissions:
+      security-events: write
+    steps:
+      - uses: github/codeql-action/analyze@v2
+`)
+	workflow, errs := actionlint.Parse(content)
+	if len(errs) > 0 {
+		t.Fatalf("actionlint.Parse: %v", errs)
+	}
+
+	if got := jobsLine(content); got != 4 {
+		t.Errorf("jobsLine() = %d, want 4", got)
+	}
+
+	want := `permissions:
+  contents: read
+
+jobs:
+  release:
+    permissions:
+      contents: write
+`
+	if diff := cmp.Diff(want, topLevelPermissionsPatch(workflow)); diff != "" {
+		t.Errorf("topLevelPermissionsPatch() mismatch (-want +got):\n%s", diff)
+	}
+
+	want = `permissions:
+  actions: read
+  contents: read
+  security-events: write
+`
+	if diff := cmp.Diff(want, formatPermissions(requiredPermissions(workflow.Jobs["analyze"]), 0)); diff != "" {
+		t.Errorf("requiredPermissions() mismatch (-want +got):\n%s", diff)
+	}
+}
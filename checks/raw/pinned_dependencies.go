@@ -58,6 +58,19 @@ func PinningDependencies(c *checker.CheckRequest) (checker.PinningDependenciesDa
 		return checker.PinningDependenciesData{}, err
 	}
 
+	// Woodpecker CI images and commands.
+	if err := collectWoodpeckerPipelinePinning(c, &results); err != nil {
+		return checker.PinningDependenciesData{}, err
+	}
+
+	// Jenkins, CircleCI and Travis CI images, orbs and commands.
+	if err := collectCIConfigPinning(c, &results); err != nil {
+		return checker.PinningDependenciesData{}, err
+	}
+
+	// Commits GitHub Actions are pinned to.
+	verifyActionPins(c, &results)
+
 	return results, nil
 }
 
@@ -74,7 +87,7 @@ func collectShellScriptInsecureDownloads(c *checker.CheckRequest, r *checker.Pin
 	return fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
 		Pattern:       "*",
 		CaseSensitive: false,
-	}, validateShellScriptIsFreeOfInsecureDownloads, r)
+	}, suppressingDependencies(validateShellScriptIsFreeOfInsecureDownloads), r)
 }
 
 var validateShellScriptIsFreeOfInsecureDownloads fileparser.DoWhileTrueOnFileContent = func(
@@ -106,7 +119,7 @@ func collectDockerfileInsecureDownloads(c *checker.CheckRequest, r *checker.Pinn
 	return fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
 		Pattern:       "*Dockerfile*",
 		CaseSensitive: false,
-	}, validateDockerfileInsecureDownloads, r)
+	}, suppressingDependencies(validateDockerfileInsecureDownloads), r)
 }
 
 var validateDockerfileInsecureDownloads fileparser.DoWhileTrueOnFileContent = func(
@@ -190,7 +203,7 @@ func collectDockerfilePinning(c *checker.CheckRequest, r *checker.PinningDepende
 	return fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
 		Pattern:       "*Dockerfile*",
 		CaseSensitive: false,
-	}, validateDockerfilesPinning, r)
+	}, suppressingDependencies(validateDockerfilesPinning), r)
 }
 
 var validateDockerfilesPinning fileparser.DoWhileTrueOnFileContent = func(
@@ -319,9 +332,9 @@ var validateDockerfilesPinning fileparser.DoWhileTrueOnFileContent = func(
 
 func collectGitHubWorkflowScriptInsecureDownloads(c *checker.CheckRequest, r *checker.PinningDependenciesData) error {
 	return fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
-		Pattern:       ".github/workflows/*",
+		Pattern:       fileparser.ActionsWorkflowPattern,
 		CaseSensitive: false,
-	}, validateGitHubWorkflowIsFreeOfInsecureDownloads, r)
+	}, suppressingDependencies(validateGitHubWorkflowIsFreeOfInsecureDownloads), r)
 }
 
 // validateGitHubWorkflowIsFreeOfInsecureDownloads checks if the workflow file downloads dependencies that are unpinned.
@@ -331,7 +344,7 @@ var validateGitHubWorkflowIsFreeOfInsecureDownloads fileparser.DoWhileTrueOnFile
 	content []byte,
 	args ...interface{},
 ) (bool, error) {
-	if !fileparser.IsWorkflowFile(pathfn) {
+	if !fileparser.IsActionsWorkflowFile(pathfn) {
 		return true, nil
 	}
 
@@ -407,9 +420,9 @@ var validateGitHubWorkflowIsFreeOfInsecureDownloads fileparser.DoWhileTrueOnFile
 // Check pinning of github actions in workflows.
 func collectGitHubActionsWorkflowPinning(c *checker.CheckRequest, r *checker.PinningDependenciesData) error {
 	return fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
-		Pattern:       ".github/workflows/*",
+		Pattern:       fileparser.ActionsWorkflowPattern,
 		CaseSensitive: true,
-	}, validateGitHubActionWorkflow, r)
+	}, suppressingDependencies(validateGitHubActionWorkflow), r)
 }
 
 // validateGitHubActionWorkflow checks if the workflow file contains unpinned actions. Returns true if the check
@@ -419,7 +432,7 @@ var validateGitHubActionWorkflow fileparser.DoWhileTrueOnFileContent = func(
 	content []byte,
 	args ...interface{},
 ) (bool, error) {
-	if !fileparser.IsWorkflowFile(pathfn) {
+	if !fileparser.IsActionsWorkflowFile(pathfn) {
 		return true, nil
 	}
 
@@ -489,6 +502,18 @@ var validateGitHubActionWorkflow fileparser.DoWhileTrueOnFileContent = func(
 					}
 				}
 				pdata.Dependencies = append(pdata.Dependencies, dep)
+			} else if repo, sha, ok := actionPinFrom(execAction.Uses.Value); ok {
+				pdata.ActionPins = append(pdata.ActionPins, checker.ActionPin{
+					Location: &checker.File{
+						Path:      pathfn,
+						Type:      finding.FileTypeSource,
+						Offset:    uint(execAction.Uses.Pos.Line),
+						EndOffset: uint(execAction.Uses.Pos.Line),
+						Snippet:   execAction.Uses.Value,
+					},
+					Repo: repo,
+					SHA:  sha,
+				})
 			}
 		}
 	}
@@ -496,6 +521,213 @@ var validateGitHubActionWorkflow fileparser.DoWhileTrueOnFileContent = func(
 	return true, nil
 }
 
+var actionPinRegex = regexp.MustCompile(`^([^/@]+/[^/@]+)(?:/[^@]*)?@([a-fA-F\d]{40})$`)
+
+// actionPinFrom returns the repo and the commit of an action pinned by SHA,
+// e.g. actions/checkout@<sha> or github/codeql-action/init@<sha>.
+func actionPinFrom(uses string) (repo, sha string, ok bool) {
+	match := actionPinRegex.FindStringSubmatch(uses)
+	if match == nil {
+		return "", "", false
+	}
+	return match[1], strings.ToLower(match[2]), true
+}
+
+// verifyActionPins looks up the tags of the repos of the actions pinned by
+// SHA, if enabled, to find pins to commits which no release of the action
+// points at: a commit of a fork of the action can be referenced through the
+// repo of the action, and untagged commits are not reviewed as releases are.
+func verifyActionPins(c *checker.CheckRequest, r *checker.PinningDependenciesData) {
+	if c.ActionTags == nil {
+		return
+	}
+	for i := range r.ActionPins {
+		pin := &r.ActionPins[i]
+		tags, err := c.ActionTags.ListActionTags(c.Ctx, pin.Repo)
+		if err != nil {
+			pin.Msg = asPointer(fmt.Sprintf("could not list the tags of %s: %v", pin.Repo, err))
+			continue
+		}
+		pin.Tags = tags[pin.SHA]
+		pin.Verified = true
+	}
+}
+
+// Check pinning of the images and downloads of the commands of Woodpecker CI
+// pipelines.
+func collectWoodpeckerPipelinePinning(c *checker.CheckRequest, r *checker.PinningDependenciesData) error {
+	for _, pattern := range []string{".woodpecker.*", ".woodpecker/*"} {
+		if err := fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
+			Pattern:       pattern,
+			CaseSensitive: false,
+		}, suppressingDependencies(validateWoodpeckerPipeline), r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateWoodpeckerPipeline checks if the pipeline runs images which are not
+// pinned by hash, or commands which download dependencies that are unpinned.
+// Returns true if the check should continue executing after this file.
+var validateWoodpeckerPipeline fileparser.DoWhileTrueOnFileContent = func(
+	pathfn string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if !fileparser.IsWoodpeckerFile(pathfn) {
+		return true, nil
+	}
+
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"validateWoodpeckerPipeline requires exactly 1 arguments: got %v: %w", len(args), errInvalidArgLength)
+	}
+	pdata := dataAsPinnedDependenciesPointer(args[0])
+
+	if !fileparser.CheckFileContainsCommands(content, "#") {
+		return true, nil
+	}
+
+	steps, err := fileparser.ParseWoodpeckerSteps(content)
+	if err != nil {
+		return false, err
+	}
+
+	// Woodpecker substitutes `${VAR}` before running the commands,
+	// while `$${VAR}` escapes it for the shell.
+	varRegex := regexp.MustCompile(`\$\$?{[^{}]*}`)
+	for i := range steps {
+		step := &steps[i]
+		if step.Image != "" {
+			addUnpinnedImage(pathfn, step.Image, step.ImageLine, pdata)
+		}
+
+		if step.Commands == "" {
+			continue
+		}
+		// Commands of a step run in the same shell, so downloads of one
+		// command taint the next ones.
+		script := varRegex.ReplaceAll([]byte(step.Commands), []byte("WOODPECKER_REDACTED_VAR"))
+		validateCIScript(pathfn, step.CommandsLine, script, pdata)
+	}
+
+	return true, nil
+}
+
+// Check pinning of the images, orbs and downloads of the scripts of the CI
+// systems of fileparser.CIConfigSystem.
+func collectCIConfigPinning(c *checker.CheckRequest, r *checker.PinningDependenciesData) error {
+	return fileparser.OnCIConfigContentDo(c.RepoClient, suppressingDependencies(validateCIConfigPinning), r)
+}
+
+// ciExpressionRegexes match the expressions substituted in scripts by CI
+// systems: Groovy `${...}` for Jenkins, `<< ... >>` for CircleCI, and macros,
+// template and runtime expressions for Azure Pipelines.
+var ciExpressionRegexes = map[fileparser.CISystem]*regexp.Regexp{
+	fileparser.CISystemJenkins:        regexp.MustCompile(`\${[^{}]*}`),
+	fileparser.CISystemCircleCI:       regexp.MustCompile(`<<[^<>]*>>`),
+	fileparser.CISystemAzurePipelines: regexp.MustCompile(`\$\([\w.]+\)|\${{[^{}]*}}|\$\[[^\[\]]*\]`),
+}
+
+// validateCIConfigPinning checks if the configuration of a CI system of
+// fileparser.CIConfigSystem uses images not pinned by hash, orbs not pinned to a version,
+// or scripts which download dependencies that are unpinned.
+// Returns true if the check should continue executing after this file.
+var validateCIConfigPinning fileparser.DoWhileTrueOnFileContent = func(
+	pathfn string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"validateCIConfigPinning requires exactly 1 arguments: got %v: %w", len(args), errInvalidArgLength)
+	}
+	pdata := dataAsPinnedDependenciesPointer(args[0])
+
+	config, err := fileparser.ParseCIConfig(pathfn, content)
+	if err != nil {
+		return false, err
+	}
+
+	for _, image := range config.Images {
+		addUnpinnedImage(pathfn, image.Value, image.Line, pdata)
+	}
+
+	// Published versions of orbs are immutable, but not their major and
+	// minor versions, nor `volatile`.
+	orbVersionRegex := regexp.MustCompile(`@\d+\.\d+\.\d+$`)
+	for _, orb := range config.Orbs {
+		if orbVersionRegex.MatchString(orb.Value) {
+			continue
+		}
+		dep := checker.Dependency{
+			Location: &checker.File{
+				Path:      pathfn,
+				Type:      finding.FileTypeSource,
+				Offset:    orb.Line,
+				EndOffset: orb.Line,
+				Snippet:   orb.Value,
+			},
+			Type: checker.DependencyUseTypeCircleCIOrb,
+		}
+		parts := strings.SplitN(orb.Value, "@", 2)
+		dep.Name = asPointer(parts[0])
+		if len(parts) > 1 {
+			dep.PinnedAt = asPointer(parts[1])
+		}
+		pdata.Dependencies = append(pdata.Dependencies, dep)
+	}
+
+	// Expressions substituted by the CI system are replaced to avoid shell
+	// parsing failures.
+	exprRegex, templated := ciExpressionRegexes[config.System]
+	for _, script := range config.Scripts {
+		s := []byte(script.Value)
+		if script.Templated && templated {
+			s = exprRegex.ReplaceAll(s, []byte("CI_REDACTED_VAR"))
+		}
+		validateCIScript(pathfn, script.Line, s, pdata)
+	}
+
+	return true, nil
+}
+
+// addUnpinnedImage records the container image of a CI job if it is not
+// pinned by hash.
+func addUnpinnedImage(pathfn, image string, line uint, pdata *checker.PinningDependenciesData) {
+	imageRegex := regexp.MustCompile(`.*@sha256:[a-f\d]{64}$`)
+	if imageRegex.MatchString(image) {
+		return
+	}
+	dep := checker.Dependency{
+		Location: &checker.File{
+			Path:      pathfn,
+			Type:      finding.FileTypeSource,
+			Offset:    line,
+			EndOffset: line,
+			Snippet:   image,
+		},
+		Type: checker.DependencyUseTypeDockerfileContainerImage,
+	}
+	parts := strings.SplitN(image, ":", 2)
+	dep.Name = asPointer(parts[0])
+	if len(parts) > 1 {
+		dep.PinnedAt = asPointer(parts[1])
+	}
+	pdata.Dependencies = append(pdata.Dependencies, dep)
+}
+
+// validateCIScript checks the downloads of a script of a CI job starting at
+// line. Scripts which cannot be parsed are recorded as debug messages.
+func validateCIScript(pathfn string, line uint, script []byte, pdata *checker.PinningDependenciesData) {
+	if err := validateShellFile(pathfn, line, line, script, map[string]bool{}, pdata); err != nil {
+		pdata.Dependencies = append(pdata.Dependencies, checker.Dependency{
+			Msg: asPointer(err.Error()),
+		})
+	}
+}
+
 func isActionDependencyPinned(actionUses string) bool {
 	localActionRegex := regexp.MustCompile(`^\..+[^/]`)
 	if localActionRegex.MatchString(actionUses) {
@@ -15,6 +15,7 @@
 package raw
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -23,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
 	scut "github.com/ossf/scorecard/v4/utests"
 )
 
@@ -65,6 +67,11 @@ func TestGithubWorkflowPinning(t *testing.T) {
 			name:     "Matrix as expression",
 			filename: "./testdata/.github/workflows/github-workflow-matrix-expression.yaml",
 		},
+		{
+			name:     "Non-pinned Forgejo workflow",
+			filename: "./testdata/.forgejo/workflows/workflow-not-pinned.yaml",
+			warns:    2,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt // Re-initializing variable so it is not changed while executing the closure below
@@ -1391,3 +1398,223 @@ func TestGitHubWorkInsecureDownloadsLineNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestWoodpeckerPipelinePinning(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		filename string
+		expected map[checker.DependencyUseType]int
+	}{
+		{
+			name:     "map of steps",
+			filename: "./testdata/.woodpecker/pipeline.yaml",
+			expected: map[checker.DependencyUseType]int{
+				checker.DependencyUseTypeDockerfileContainerImage: 2,
+				checker.DependencyUseTypeDownloadThenRun:          1,
+			},
+		},
+		{
+			name:     "list of steps",
+			filename: "./testdata/.woodpecker/pipeline-list.yml",
+			expected: map[checker.DependencyUseType]int{
+				checker.DependencyUseTypeDownloadThenRun: 1,
+			},
+		},
+		{
+			name:     "not a pipeline",
+			filename: "./testdata/.forgejo/workflows/workflow-not-pinned.yaml",
+			expected: map[checker.DependencyUseType]int{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content, err := os.ReadFile(tt.filename)
+			if err != nil {
+				t.Errorf("cannot read file: %v", err)
+			}
+
+			p := strings.Replace(tt.filename, "./testdata/", "", 1)
+			var r checker.PinningDependenciesData
+
+			if _, err := validateWoodpeckerPipeline(p, content, &r); err != nil {
+				t.Errorf("error during validateWoodpeckerPipeline: %v", err)
+			}
+
+			got := map[checker.DependencyUseType]int{}
+			for _, dep := range r.Dependencies {
+				if dep.Msg != nil {
+					t.Errorf("unexpected message: %v", *dep.Msg)
+					continue
+				}
+				got[dep.Type]++
+			}
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("validateWoodpeckerPipeline() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCIConfigPinning(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		filename string
+		expected map[checker.DependencyUseType]int
+	}{
+		{
+			name:     "Jenkinsfile",
+			filename: "./testdata/Jenkinsfile",
+			expected: map[checker.DependencyUseType]int{
+				checker.DependencyUseTypeDockerfileContainerImage: 1,
+				checker.DependencyUseTypeDownloadThenRun:          1,
+			},
+		},
+		{
+			name:     "CircleCI",
+			filename: "./testdata/.circleci/config.yml",
+			expected: map[checker.DependencyUseType]int{
+				checker.DependencyUseTypeCircleCIOrb:              1,
+				checker.DependencyUseTypeDockerfileContainerImage: 2,
+				checker.DependencyUseTypeDownloadThenRun:          1,
+			},
+		},
+		{
+			name:     "Travis CI",
+			filename: "./testdata/.travis.yml",
+			expected: map[checker.DependencyUseType]int{
+				checker.DependencyUseTypeDownloadThenRun: 1,
+			},
+		},
+		{
+			name:     "GitLab CI",
+			filename: "./testdata/.gitlab-ci.yml",
+			expected: map[checker.DependencyUseType]int{
+				checker.DependencyUseTypeDockerfileContainerImage: 2,
+				checker.DependencyUseTypeDownloadThenRun:          1,
+			},
+		},
+		{
+			name:     "Azure Pipelines",
+			filename: "./testdata/azure-pipelines.yml",
+			expected: map[checker.DependencyUseType]int{
+				checker.DependencyUseTypeDockerfileContainerImage: 1,
+				checker.DependencyUseTypeDownloadThenRun:          1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content, err := os.ReadFile(tt.filename)
+			if err != nil {
+				t.Errorf("cannot read file: %v", err)
+			}
+
+			p := strings.Replace(tt.filename, "./testdata/", "", 1)
+			var r checker.PinningDependenciesData
+
+			if _, err := validateCIConfigPinning(p, content, &r); err != nil {
+				t.Errorf("error during validateCIConfigPinning: %v", err)
+			}
+
+			got := map[checker.DependencyUseType]int{}
+			for _, dep := range r.Dependencies {
+				if dep.Msg != nil {
+					t.Errorf("unexpected message: %v", *dep.Msg)
+					continue
+				}
+				got[dep.Type]++
+			}
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("validateCIConfigPinning() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestActionPinFrom(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		uses   string
+		repo   string
+		sha    string
+		pinned bool
+	}{
+		{
+			uses:   "actions/checkout@8F4B7F84864484A7BF31766ABE9204DA3CBE65B3",
+			repo:   "actions/checkout",
+			sha:    "8f4b7f84864484a7bf31766abe9204da3cbe65b3",
+			pinned: true,
+		},
+		{
+			uses:   "github/codeql-action/init@4d34df0c2316fe8122ab82dc22947d607c0c91f9",
+			repo:   "github/codeql-action",
+			sha:    "4d34df0c2316fe8122ab82dc22947d607c0c91f9",
+			pinned: true,
+		},
+		{uses: "actions/checkout@v3"},
+		{uses: "./.github/actions/local"},
+		{uses: "docker://alpine@sha256:4edbd2beb5f78b1014028f4fbb99f3237d9561100b6881aabbf5acce2c4f9454"},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.uses, func(t *testing.T) {
+			t.Parallel()
+			repo, sha, ok := actionPinFrom(tt.uses)
+			if repo != tt.repo || sha != tt.sha || ok != tt.pinned {
+				t.Errorf("actionPinFrom(%q) = %q, %q, %v, want %q, %q, %v",
+					tt.uses, repo, sha, ok, tt.repo, tt.sha, tt.pinned)
+			}
+		})
+	}
+}
+
+type fakeActionTags map[string]map[string][]string
+
+func (f fakeActionTags) ListActionTags(ctx context.Context, repo string) (map[string][]string, error) {
+	tags, ok := f[repo]
+	if !ok {
+		return nil, clients.ErrBudgetExceeded
+	}
+	return tags, nil
+}
+
+func TestVerifyActionPins(t *testing.T) {
+	t.Parallel()
+	r := checker.PinningDependenciesData{
+		ActionPins: []checker.ActionPin{
+			{Repo: "actions/checkout", SHA: "8f4b7f84864484a7bf31766abe9204da3cbe65b3"},
+			{Repo: "actions/checkout", SHA: "ee0669bd1cc54295c223e0bb666b733df41de1c5"},
+			{Repo: "actions/setup-go", SHA: "4d34df0c2316fe8122ab82dc22947d607c0c91f9"},
+		},
+	}
+	c := checker.CheckRequest{
+		Ctx: context.Background(),
+		ActionTags: fakeActionTags{
+			"actions/checkout": {"8f4b7f84864484a7bf31766abe9204da3cbe65b3": {"v3.5.0", "v3"}},
+		},
+	}
+	verifyActionPins(&c, &r)
+	want := []checker.ActionPin{
+		{
+			Repo:     "actions/checkout",
+			SHA:      "8f4b7f84864484a7bf31766abe9204da3cbe65b3",
+			Tags:     []string{"v3.5.0", "v3"},
+			Verified: true,
+		},
+		{Repo: "actions/checkout", SHA: "ee0669bd1cc54295c223e0bb666b733df41de1c5", Verified: true},
+		{
+			Repo: "actions/setup-go",
+			SHA:  "4d34df0c2316fe8122ab82dc22947d607c0c91f9",
+			Msg:  asPointer("could not list the tags of actions/setup-go: request budget exceeded"),
+		},
+	}
+	if diff := cmp.Diff(want, r.ActionPins); diff != "" {
+		t.Errorf("verifyActionPins() mismatch (-want +got):\n%s", diff)
+	}
+}
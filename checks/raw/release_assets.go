@@ -0,0 +1,262 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+const (
+	// The archives of the latest release inspected for binaries.
+	releaseArchivesToInspect = 3
+	// The bytes of each file of an archive used to detect its type.
+	archiveFileHeaderSize = 8 << 10
+	// The releases and assets per release verified against checksum files.
+	checksumReleasesToVerify = 5
+	checksumAssetsToVerify   = 10
+)
+
+// checksumLine matches the entries of sha256sum output, "<hex> <name>" or
+// "<hex> *<name>", and of .sha256 files which may only contain the digest.
+var checksumLine = regexp.MustCompile(`^([0-9a-fA-F]{64})(?:\s+\*?(\S.*))?$`)
+
+// skipAsset reports whether err means an asset cannot be inspected, rather
+// than the inspection failed.
+func skipAsset(err error) bool {
+	return errors.Is(err, clients.ErrAssetTooLarge) ||
+		errors.Is(err, clients.ErrAssetDigestMismatch) ||
+		errors.Is(err, clients.ErrUnsupportedFeature)
+}
+
+func isReleaseArchive(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz") || strings.HasSuffix(name, ".zip")
+}
+
+func isChecksumFile(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, "sha256sums") || strings.HasSuffix(name, "sha256sums.txt") ||
+		strings.HasSuffix(name, "checksums.txt") || strings.HasSuffix(name, ".sha256")
+}
+
+// releaseBinaries returns the binaries found in the archives published with
+// the latest release.
+func releaseBinaries(c clients.RepoClient) ([]checker.File, error) {
+	releases, err := c.ListReleases()
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("RepoClient.ListReleases: %w", err)
+	}
+
+	var files []checker.File
+	for i := range releases {
+		if len(releases[i].Assets) == 0 {
+			continue
+		}
+		inspected := 0
+		for j := range releases[i].Assets {
+			asset := &releases[i].Assets[j]
+			if !isReleaseArchive(asset.Name) || inspected >= releaseArchivesToInspect {
+				continue
+			}
+			inspected++
+			content, err := c.GetReleaseAsset(asset)
+			if skipAsset(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("RepoClient.GetReleaseAsset: %w", err)
+			}
+			if err := onArchiveFiles(asset.Name, content, func(name string, header []byte) error {
+				_, err := checkBinaryFileContent(path.Join(asset.Name, name), header, &files)
+				return err
+			}); err != nil {
+				return nil, err
+			}
+		}
+		// Only the latest release with assets is inspected.
+		break
+	}
+	return files, nil
+}
+
+// onArchiveFiles calls fn with the name and the first bytes of each regular
+// file of a .tar.gz or .zip archive. Archives which cannot be read are ignored.
+func onArchiveFiles(name string, content []byte, fn func(name string, header []byte) error) error {
+	if strings.HasSuffix(strings.ToLower(name), ".zip") {
+		r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			return nil
+		}
+		for _, f := range r.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				continue
+			}
+			header, err := io.ReadAll(io.LimitReader(rc, archiveFileHeaderSize))
+			rc.Close()
+			if err != nil {
+				continue
+			}
+			if err := fn(f.Name, header); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			// io.EOF, or a truncated or corrupted archive.
+			return nil
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		header, err := io.ReadAll(io.LimitReader(tr, archiveFileHeaderSize))
+		if err != nil {
+			return nil
+		}
+		if err := fn(hdr.Name, header); err != nil {
+			return err
+		}
+	}
+}
+
+// releaseChecksums verifies the assets of the most recent releases against
+// the checksum files published with them.
+func releaseChecksums(c clients.RepoClient, releases []clients.Release) ([]checker.ReleaseChecksum, error) {
+	var checksums []checker.ReleaseChecksum
+	verifiedReleases := 0
+	for i := range releases {
+		if verifiedReleases >= checksumReleasesToVerify {
+			break
+		}
+		release := &releases[i]
+		if len(release.Assets) == 0 {
+			continue
+		}
+		verifiedReleases++
+
+		assets := make(map[string]*clients.ReleaseAsset)
+		for j := range release.Assets {
+			assets[release.Assets[j].Name] = &release.Assets[j]
+		}
+		verifiedAssets := 0
+		for j := range release.Assets {
+			file := &release.Assets[j]
+			if !isChecksumFile(file.Name) {
+				continue
+			}
+			content, err := c.GetReleaseAsset(file)
+			if skipAsset(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("RepoClient.GetReleaseAsset: %w", err)
+			}
+
+			for _, entry := range parseChecksums(file.Name, content) {
+				asset, ok := assets[entry.name]
+				if !ok || verifiedAssets >= checksumAssetsToVerify {
+					continue
+				}
+				got, err := assetSHA256(c, asset)
+				if skipAsset(err) {
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				verifiedAssets++
+				checksums = append(checksums, checker.ReleaseChecksum{
+					Tag:      release.TagName,
+					File:     file.Name,
+					Asset:    entry.name,
+					Verified: strings.EqualFold(got, entry.digest),
+				})
+			}
+		}
+	}
+	return checksums, nil
+}
+
+type checksumEntry struct {
+	name, digest string
+}
+
+// parseChecksums returns the sha256 digests listed in a checksum file. An entry
+// without a name refers to the asset the .sha256 file is for.
+func parseChecksums(file string, content []byte) []checksumEntry {
+	var ret []checksumEntry
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		m := checksumLine.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m == nil {
+			continue
+		}
+		name := path.Base(m[2])
+		if m[2] == "" {
+			if !strings.HasSuffix(strings.ToLower(file), ".sha256") {
+				continue
+			}
+			name = file[:len(file)-len(".sha256")]
+		}
+		ret = append(ret, checksumEntry{name: name, digest: m[1]})
+	}
+	return ret
+}
+
+// assetSHA256 returns the hex sha256 digest of an asset, downloading it if
+// the forge does not report it.
+func assetSHA256(c clients.RepoClient, asset *clients.ReleaseAsset) (string, error) {
+	const prefix = "sha256:"
+	if strings.HasPrefix(asset.Digest, prefix) {
+		return strings.TrimPrefix(asset.Digest, prefix), nil
+	}
+	content, err := c.GetReleaseAsset(asset)
+	if err != nil {
+		return "", fmt.Errorf("RepoClient.GetReleaseAsset: %w", err)
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+func tarGz(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range []string{"src/main.c", "bin/tool"} {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatalf("WriteHeader: %v", err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar.Close: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip.Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReleaseBinaries(t *testing.T) {
+	t.Parallel()
+	elf := append([]byte{0x7f, 'E', 'L', 'F', 2, 1, 1}, make([]byte, 64)...)
+	archive := tarGz(t, map[string][]byte{
+		"src/main.c": []byte("int main() { return 0; }\n"),
+		"bin/tool":   elf,
+	})
+	releases := []clients.Release{
+		{TagName: "v2.0.0"},
+		{
+			TagName: "v1.0.0",
+			Assets: []clients.ReleaseAsset{
+				{Name: "notes.txt"},
+				{Name: "tool-1.0.0.tar.gz"},
+				{Name: "huge.zip", Size: clients.MaxReleaseAssetSize + 1},
+			},
+		},
+		{
+			TagName: "v0.9.0",
+			Assets:  []clients.ReleaseAsset{{Name: "old.tar.gz"}},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().ListReleases().Return(releases, nil)
+	mockRepoClient.EXPECT().GetReleaseAsset(gomock.Any()).DoAndReturn(
+		func(asset *clients.ReleaseAsset) ([]byte, error) {
+			switch asset.Name {
+			case "tool-1.0.0.tar.gz":
+				return archive, nil
+			case "huge.zip":
+				return nil, fmt.Errorf("%w: %s", clients.ErrAssetTooLarge, asset.Name)
+			default:
+				t.Errorf("unexpected asset %s", asset.Name)
+				return nil, nil
+			}
+		}).Times(2)
+
+	got, err := releaseBinaries(mockRepoClient)
+	if err != nil {
+		t.Fatalf("releaseBinaries: %v", err)
+	}
+	want := []checker.File{
+		{Path: "tool-1.0.0.tar.gz/bin/tool", Type: finding.FileTypeBinary, Offset: checker.OffsetDefault},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("releaseBinaries() (-want +got):\n%s", diff)
+	}
+}
+
+func TestReleaseChecksums(t *testing.T) {
+	t.Parallel()
+	content := []byte("tool binary")
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+	other := hex.EncodeToString(make([]byte, sha256.Size))
+	sums := fmt.Sprintf("%s  tool\n%s *tool.sig\n%s  missing\n", digest, other, other)
+	releases := []clients.Release{
+		{
+			TagName: "v1.0.0",
+			Assets: []clients.ReleaseAsset{
+				{Name: "tool"},
+				{Name: "tool.sig", Digest: "sha256:" + digest},
+				{Name: "SHA256SUMS"},
+				{Name: "tool.sha256"},
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().GetReleaseAsset(gomock.Any()).DoAndReturn(
+		func(asset *clients.ReleaseAsset) ([]byte, error) {
+			switch asset.Name {
+			case "SHA256SUMS":
+				return []byte(sums), nil
+			case "tool.sha256":
+				return []byte(digest + "\n"), nil
+			case "tool":
+				return content, nil
+			default:
+				t.Errorf("unexpected asset %s", asset.Name)
+				return nil, nil
+			}
+		}).AnyTimes()
+
+	got, err := releaseChecksums(mockRepoClient, releases)
+	if err != nil {
+		t.Fatalf("releaseChecksums: %v", err)
+	}
+	want := []checker.ReleaseChecksum{
+		{Tag: "v1.0.0", File: "SHA256SUMS", Asset: "tool", Verified: true},
+		{Tag: "v1.0.0", File: "SHA256SUMS", Asset: "tool.sig", Verified: false},
+		{Tag: "v1.0.0", File: "tool.sha256", Asset: "tool", Verified: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("releaseChecksums() (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// The releases and subjects per release verified against provenance files.
+const (
+	provenanceReleasesToVerify = 5
+	provenanceSubjectsToVerify = 10
+)
+
+// dsseEnvelope is a DSSE envelope, see https://github.com/secure-systems-lab/dsse.
+type dsseEnvelope struct {
+	Payload string `json:"payload"`
+}
+
+// inTotoStatement is the subset of an in-toto statement with a SLSA provenance
+// predicate, v0.2 or v1, used by Scorecard.
+type inTotoStatement struct {
+	Subject []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	Predicate struct {
+		// SLSA v0.2.
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		Invocation struct {
+			ConfigSource struct {
+				URI string `json:"uri"`
+			} `json:"configSource"`
+		} `json:"invocation"`
+		// SLSA v1.
+		RunDetails struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+		} `json:"runDetails"`
+		BuildDefinition struct {
+			ExternalParameters struct {
+				Workflow struct {
+					Repository string `json:"repository"`
+					Ref        string `json:"ref"`
+				} `json:"workflow"`
+			} `json:"externalParameters"`
+			ResolvedDependencies []struct {
+				URI string `json:"uri"`
+			} `json:"resolvedDependencies"`
+		} `json:"buildDefinition"`
+	} `json:"predicate"`
+}
+
+func isProvenanceFile(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), ".intoto.jsonl")
+}
+
+// releaseProvenance validates the provenance files of the most recent releases
+// against the scanned repo, the tags of the releases and their assets.
+func releaseProvenance(c *checker.CheckRequest, releases []clients.Release) ([]checker.ReleaseProvenance, error) {
+	repo := ""
+	if c.Repo != nil {
+		repo = strings.ToLower(c.Repo.URI())
+	}
+	var ret []checker.ReleaseProvenance
+	verifiedReleases := 0
+	for i := range releases {
+		if verifiedReleases >= provenanceReleasesToVerify {
+			break
+		}
+		release := &releases[i]
+		if len(release.Assets) == 0 {
+			continue
+		}
+		verifiedReleases++
+
+		assets := make(map[string]*clients.ReleaseAsset)
+		for j := range release.Assets {
+			assets[release.Assets[j].Name] = &release.Assets[j]
+		}
+		for j := range release.Assets {
+			file := &release.Assets[j]
+			if !isProvenanceFile(file.Name) {
+				continue
+			}
+			content, err := c.RepoClient.GetReleaseAsset(file)
+			if skipAsset(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("RepoClient.GetReleaseAsset: %w", err)
+			}
+			for _, statement := range parseProvenance(content) {
+				p, err := validateProvenance(c.RepoClient, repo, release, file.Name, assets, &statement)
+				if err != nil {
+					return nil, err
+				}
+				ret = append(ret, p)
+			}
+		}
+	}
+	return ret, nil
+}
+
+func validateProvenance(c clients.RepoClient, repo string, release *clients.Release, file string,
+	assets map[string]*clients.ReleaseAsset, statement *inTotoStatement,
+) (checker.ReleaseProvenance, error) {
+	p := checker.ReleaseProvenance{
+		Tag:       release.TagName,
+		File:      file,
+		BuilderID: statement.Predicate.Builder.ID,
+		SourceURI: statement.Predicate.Invocation.ConfigSource.URI,
+	}
+	if p.BuilderID == "" {
+		p.BuilderID = statement.Predicate.RunDetails.Builder.ID
+	}
+	if p.SourceURI == "" {
+		w := statement.Predicate.BuildDefinition.ExternalParameters.Workflow
+		switch {
+		case w.Repository != "":
+			p.SourceURI = fmt.Sprintf("git+%s@%s", w.Repository, w.Ref)
+		case len(statement.Predicate.BuildDefinition.ResolvedDependencies) > 0:
+			p.SourceURI = statement.Predicate.BuildDefinition.ResolvedDependencies[0].URI
+		}
+	}
+	sourceRepo, sourceRef := parseSourceURI(p.SourceURI)
+	p.RepoMatches = repo != "" && sourceRepo == repo
+	p.RefMatches = sourceRef == "refs/tags/"+release.TagName || sourceRef == release.TagName
+
+	for _, subject := range statement.Subject {
+		if len(p.Subjects) >= provenanceSubjectsToVerify {
+			break
+		}
+		asset, ok := assets[path.Base(subject.Name)]
+		if !ok {
+			continue
+		}
+		got, err := assetSHA256(c, asset)
+		if skipAsset(err) {
+			continue
+		}
+		if err != nil {
+			return checker.ReleaseProvenance{}, err
+		}
+		p.Subjects = append(p.Subjects, checker.ProvenanceSubject{
+			Name:     asset.Name,
+			Verified: strings.EqualFold(got, subject.Digest["sha256"]),
+		})
+	}
+	return p, nil
+}
+
+// parseProvenance returns the in-toto statements of a .intoto.jsonl file, whose
+// lines are DSSE envelopes or, for unsigned provenance, statements.
+// Lines which cannot be parsed are ignored.
+func parseProvenance(content []byte) []inTotoStatement {
+	var ret []inTotoStatement
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, clients.MaxReleaseAssetSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var envelope dsseEnvelope
+		if err := json.Unmarshal(line, &envelope); err != nil {
+			continue
+		}
+		statement := line
+		if envelope.Payload != "" {
+			payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+			if err != nil {
+				continue
+			}
+			statement = payload
+		}
+		var s inTotoStatement
+		if err := json.Unmarshal(statement, &s); err != nil || len(s.Subject) == 0 {
+			continue
+		}
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+// parseSourceURI returns the repo, e.g. github.com/owner/repo, and the ref of a
+// source URI such as git+https://github.com/owner/repo@refs/tags/v1.0.0.
+func parseSourceURI(uri string) (repo, ref string) {
+	uri = strings.TrimPrefix(uri, "git+")
+	if i := strings.LastIndex(uri, "@"); i >= 0 {
+		uri, ref = uri[:i], uri[i+1:]
+	}
+	uri = strings.TrimPrefix(uri, "https://")
+	uri = strings.TrimPrefix(uri, "http://")
+	uri = strings.TrimSuffix(uri, ".git")
+	return strings.ToLower(uri), ref
+}
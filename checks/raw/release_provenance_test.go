@@ -0,0 +1,119 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func TestReleaseProvenance(t *testing.T) {
+	t.Parallel()
+	content := []byte("tool binary")
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+	other := hex.EncodeToString(make([]byte, sha256.Size))
+
+	// A SLSA v0.2 provenance signed in a DSSE envelope, and an unsigned SLSA v1 provenance.
+	v02 := fmt.Sprintf(`{
+		"_type": "https://in-toto.io/Statement/v0.1",
+		"predicateType": "https://slsa.dev/provenance/v0.2",
+		"subject": [{"name": "tool", "digest": {"sha256": %q}}, {"name": "other", "digest": {"sha256": %q}}],
+		"predicate": {
+			"builder": {"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.5.0"},
+			"invocation": {"configSource": {"uri": "git+https://github.com/owner/repo@refs/tags/v1.0.0"}}
+		}
+	}`, digest, other)
+	v1 := fmt.Sprintf(`{"_type": "https://in-toto.io/Statement/v1", "predicateType": "https://slsa.dev/provenance/v1",`+
+		`"subject": [{"name": "dist/tool.sig", "digest": {"sha256": %q}}],`+
+		`"predicate": {"runDetails": {"builder": {"id": "https://example.com/builder"}},`+
+		`"buildDefinition": {"externalParameters": {"workflow": {"repository": "https://github.com/fork/repo", "ref": "refs/heads/main"}}}}}`,
+		digest)
+	envelope := fmt.Sprintf(`{"payloadType": "application/vnd.in-toto+json", "payload": %q, "signatures": []}`,
+		base64.StdEncoding.EncodeToString([]byte(v02)))
+	provenance := envelope + "\n" + v1 + "\nnot json\n"
+
+	releases := []clients.Release{
+		{
+			TagName: "v1.0.0",
+			Assets: []clients.ReleaseAsset{
+				{Name: "tool"},
+				{Name: "tool.sig", Digest: "sha256:" + other},
+				{Name: "other", Digest: "sha256:" + digest},
+				{Name: "multiple.intoto.jsonl"},
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().GetReleaseAsset(gomock.Any()).DoAndReturn(
+		func(asset *clients.ReleaseAsset) ([]byte, error) {
+			switch asset.Name {
+			case "multiple.intoto.jsonl":
+				return []byte(provenance), nil
+			case "tool":
+				return content, nil
+			default:
+				t.Errorf("unexpected asset %s", asset.Name)
+				return nil, nil
+			}
+		}).AnyTimes()
+	mockRepo := mockrepo.NewMockRepo(ctrl)
+	mockRepo.EXPECT().URI().Return("github.com/owner/repo").AnyTimes()
+
+	req := checker.CheckRequest{RepoClient: mockRepoClient, Repo: mockRepo}
+	got, err := releaseProvenance(&req, releases)
+	if err != nil {
+		t.Fatalf("releaseProvenance: %v", err)
+	}
+	want := []checker.ReleaseProvenance{
+		{
+			Tag:  "v1.0.0",
+			File: "multiple.intoto.jsonl",
+			BuilderID: "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/" +
+				"generator_generic_slsa3.yml@refs/tags/v1.5.0",
+			SourceURI:   "git+https://github.com/owner/repo@refs/tags/v1.0.0",
+			RepoMatches: true,
+			RefMatches:  true,
+			Subjects: []checker.ProvenanceSubject{
+				{Name: "tool", Verified: true},
+				{Name: "other", Verified: false},
+			},
+		},
+		{
+			Tag:       "v1.0.0",
+			File:      "multiple.intoto.jsonl",
+			BuilderID: "https://example.com/builder",
+			SourceURI: "git+https://github.com/fork/repo@refs/heads/main",
+			Subjects: []checker.ProvenanceSubject{
+				{Name: "tool.sig", Verified: false},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("releaseProvenance() (-want +got):\n%s", diff)
+	}
+}
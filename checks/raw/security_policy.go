@@ -16,6 +16,7 @@ package raw
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"path"
@@ -25,15 +26,15 @@ import (
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks/fileparser"
 	"github.com/ossf/scorecard/v4/clients"
-	"github.com/ossf/scorecard/v4/clients/githubrepo"
 	sce "github.com/ossf/scorecard/v4/errors"
 	"github.com/ossf/scorecard/v4/finding"
-	"github.com/ossf/scorecard/v4/log"
 )
 
 type securityPolicyFilesWithURI struct {
 	uri   string
 	files []checker.SecurityPolicyFile
+	// securityTxt is the path of the security.txt file of the repo.
+	securityTxt string
 }
 
 // SecurityPolicy checks for presence of security policy
@@ -46,6 +47,21 @@ func SecurityPolicy(c *checker.CheckRequest) (checker.SecurityPolicyData, error)
 	if err != nil {
 		return checker.SecurityPolicyData{}, err
 	}
+	securityTxt, err := securityTxtFile(c.RepoClient, data.securityTxt)
+	if err != nil {
+		return checker.SecurityPolicyData{}, err
+	}
+	policy, err := securityPolicy(c, &data)
+	if err != nil {
+		return checker.SecurityPolicyData{}, err
+	}
+	policy.SecurityTxt = securityTxt
+	return policy, nil
+}
+
+// securityPolicy looks for the security policy of the repo in data, or else
+// in its org.
+func securityPolicy(c *checker.CheckRequest, data *securityPolicyFilesWithURI) (checker.SecurityPolicyData, error) {
 	// If we found files in the repo, return immediately.
 	if len(data.files) > 0 {
 		for idx := range data.files {
@@ -62,16 +78,17 @@ func SecurityPolicy(c *checker.CheckRequest) (checker.SecurityPolicyData, error)
 
 	// Check if present in parent org.
 	// https#://docs.github.com/en/github/building-a-strong-community/creating-a-default-community-health-file.
-	// TODO(1491): Make this non-GitHub specific.
-	logger := log.NewLogger(log.InfoLevel)
-	// HAD TO HARD CODE TO 30
-	dotGitHubClient := githubrepo.CreateGithubRepoClient(c.Ctx, logger)
-	err = dotGitHubClient.InitRepo(c.Repo.Org(), clients.HeadSHA, 0)
+	// Local directories and wikis have no parent org.
+	if c.NewOrgRepoClient == nil || c.Repo == nil || c.Repo.Org() == nil {
+		return platformSecurityPolicy(c, data.files)
+	}
+	dotGitHubClient := c.NewOrgRepoClient()
+	err := dotGitHubClient.InitRepo(c.Repo.Org(), clients.HeadSHA, 0)
 	switch {
 	case err == nil:
 		defer dotGitHubClient.Close()
 		data.uri = dotGitHubClient.URI()
-		err = fileparser.OnAllFilesDo(dotGitHubClient, isSecurityPolicyFile, &data)
+		err = fileparser.OnAllFilesDo(dotGitHubClient, isSecurityPolicyFile, data)
 		if err != nil {
 			return checker.SecurityPolicyData{}, err
 		}
@@ -82,27 +99,47 @@ func SecurityPolicy(c *checker.CheckRequest) (checker.SecurityPolicyData, error)
 		return checker.SecurityPolicyData{}, err
 	}
 
+	if len(data.files) == 0 {
+		return platformSecurityPolicy(c, data.files)
+	}
+
 	// Return raw results.
-	if len(data.files) > 0 {
-		for idx := range data.files {
-			filePattern := data.files[idx].File.Path
-			// undo path.Join in isSecurityPolicyFile just
-			// for this call to OnMatchingFileContentsDo
-			if data.files[idx].File.Type == finding.FileTypeURL {
-				filePattern = strings.Replace(filePattern, data.uri+"/", "", 1)
-			}
-			err := fileparser.OnMatchingFileContentDo(dotGitHubClient, fileparser.PathMatcher{
-				Pattern:       filePattern,
-				CaseSensitive: false,
-			}, checkSecurityPolicyFileContent, &data.files[idx].File, &data.files[idx].Information)
-			if err != nil {
-				return checker.SecurityPolicyData{}, err
-			}
+	for idx := range data.files {
+		filePattern := data.files[idx].File.Path
+		// undo path.Join in isSecurityPolicyFile just
+		// for this call to OnMatchingFileContentsDo
+		if data.files[idx].File.Type == finding.FileTypeURL {
+			filePattern = strings.Replace(filePattern, data.uri+"/", "", 1)
+		}
+		err := fileparser.OnMatchingFileContentDo(dotGitHubClient, fileparser.PathMatcher{
+			Pattern:       filePattern,
+			CaseSensitive: false,
+		}, checkSecurityPolicyFileContent, &data.files[idx].File, &data.files[idx].Information)
+		if err != nil {
+			return checker.SecurityPolicyData{}, err
 		}
 	}
 	return checker.SecurityPolicyData{PolicyFiles: data.files}, nil
 }
 
+// platformSecurityPolicy falls back to the security policy detected by the
+// hosting platform, e.g. one inherited from a private .github repo of the org,
+// when no policy file was found.
+func platformSecurityPolicy(c *checker.CheckRequest,
+	files []checker.SecurityPolicyFile,
+) (checker.SecurityPolicyData, error) {
+	policyURL, err := c.RepoClient.GetSecurityPolicyURL()
+	switch {
+	case err == nil:
+		return checker.SecurityPolicyData{PolicyFiles: files, PolicyURL: policyURL}, nil
+	case errors.Is(err, clients.ErrUnsupportedFeature):
+		return checker.SecurityPolicyData{PolicyFiles: files}, nil
+	default:
+		return checker.SecurityPolicyData{}, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("RepoClient.GetSecurityPolicyURL: %v", err))
+	}
+}
+
 // Check repository for repository-specific policy.
 // https://docs.github.com/en/github/building-a-strong-community/creating-a-default-community-health-file.
 var isSecurityPolicyFile fileparser.DoWhileTrueOnFilename = func(name string, args ...interface{}) (bool, error) {
@@ -113,7 +150,15 @@ var isSecurityPolicyFile fileparser.DoWhileTrueOnFilename = func(name string, ar
 	if !ok {
 		return false, fmt.Errorf("invalid arg type: %w", errInvalidArgType)
 	}
-	if isSecurityPolicyFilename(name) {
+	// security.txt files of the org are not looked for, the contacts of its
+	// security policy are used instead.
+	if pdata.uri == "" && pdata.securityTxt == "" && isSecurityTxtFilename(name) {
+		pdata.securityTxt = name
+		return true, nil
+	}
+	// TODO: support multiple security policy files, only the first one is
+	// used for now. The iteration continues to look for a security.txt file.
+	if len(pdata.files) == 0 && isSecurityPolicyFilename(name) {
 		tempPath := name
 		tempType := finding.FileTypeText
 		if pdata.uri != "" {
@@ -134,13 +179,62 @@ var isSecurityPolicyFile fileparser.DoWhileTrueOnFilename = func(name string, ar
 			},
 			Information: make([]checker.SecurityPolicyInformation, 0),
 		})
-		// TODO: change 'false' to 'true' when multiple security policy files are supported
-		// otherwise this check stops at the first security policy found
-		return false, nil
 	}
 	return true, nil
 }
 
+// isSecurityTxtFilename reports whether name is a security.txt file, either at
+// the root of the repo or in a .well-known directory served by a website.
+func isSecurityTxtFilename(name string) bool {
+	if !strings.EqualFold(path.Base(name), "security.txt") {
+		return false
+	}
+	dir := path.Dir(name)
+	return dir == "." || strings.EqualFold(path.Base(dir), ".well-known")
+}
+
+// securityTxtFile reads the Contact fields of the security.txt file at
+// filename, or returns nil if filename is empty.
+func securityTxtFile(c clients.RepoClient, filename string) (*checker.SecurityTxtFile, error) {
+	if filename == "" {
+		return nil, nil
+	}
+	content, err := c.GetFileContent(filename)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("RepoClient.GetFileContent: %v", err))
+	}
+	return &checker.SecurityTxtFile{
+		File: checker.File{
+			Path:     filename,
+			Type:     finding.FileTypeText,
+			Offset:   checker.OffsetDefault,
+			FileSize: uint(len(content)),
+		},
+		Contacts: parseSecurityTxtContacts(content),
+	}, nil
+}
+
+// parseSecurityTxtContacts returns the values of the Contact fields of a
+// security.txt file, in order.
+func parseSecurityTxtContacts(content []byte) []string {
+	var contacts []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		field, value, found := strings.Cut(line, ":")
+		if !found || !strings.EqualFold(strings.TrimSpace(field), "contact") {
+			continue
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			contacts = append(contacts, value)
+		}
+	}
+	return contacts
+}
+
 func isSecurityPolicyFilename(name string) bool {
 	return strings.EqualFold(name, "security.md") ||
 		strings.EqualFold(name, ".github/security.md") ||
@@ -15,13 +15,16 @@
 package raw
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
 	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
 	scut "github.com/ossf/scorecard/v4/utests"
 )
@@ -182,3 +185,136 @@ func TestSecurityPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestSecurityPolicyPlatformFallback(t *testing.T) {
+	t.Parallel()
+	//nolint
+	tests := []struct {
+		name      string
+		policyURL string
+		err       error
+		want      checker.SecurityPolicyData
+		wantErr   bool
+	}{
+		{
+			name:      "policy detected by the platform",
+			policyURL: "https://github.com/owner/repo/security/policy",
+			want: checker.SecurityPolicyData{
+				PolicyURL:   "https://github.com/owner/repo/security/policy",
+				PolicyFiles: []checker.SecurityPolicyFile{},
+			},
+		},
+		{
+			name: "no policy",
+			want: checker.SecurityPolicyData{
+				PolicyFiles: []checker.SecurityPolicyFile{},
+			},
+		},
+		{
+			name: "unsupported platform",
+			err:  clients.ErrUnsupportedFeature,
+			want: checker.SecurityPolicyData{
+				PolicyFiles: []checker.SecurityPolicyFile{},
+			},
+		},
+		{
+			name:    "error",
+			err:     errors.New("some error"), //nolint:goerr113
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepo := mockrepo.NewMockRepo(ctrl)
+
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).Return(nil, nil).AnyTimes()
+			mockRepoClient.EXPECT().GetSecurityPolicyURL().Return(tt.policyURL, tt.err)
+			mockRepo.EXPECT().Org().Return(nil).AnyTimes()
+
+			c := checker.CheckRequest{
+				RepoClient: mockRepoClient,
+				Repo:       mockRepo,
+				Dlogger:    &scut.TestDetailLogger{},
+			}
+			got, err := SecurityPolicy(&c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SecurityPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected security policy data (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSecurityPolicySecurityTxt(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"SECURITY.md": "Report vulnerabilities to security@example.com\n",
+		"web/.well-known/security.txt": "# Our security address\n" +
+			"Contact: mailto:security@example.com\n" +
+			"contact: https://example.com/security \n" +
+			"Expires: 2030-01-01T00:00:00.000Z\n",
+	}
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepo := mockrepo.NewMockRepo(ctrl)
+	mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+		func(predicate func(string) (bool, error)) ([]string, error) {
+			var matched []string
+			for _, f := range []string{"web/.well-known/security.txt", "SECURITY.md"} {
+				if ok, err := predicate(f); err == nil && ok {
+					matched = append(matched, f)
+				}
+			}
+			return matched, nil
+		}).AnyTimes()
+	mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(fn string) ([]byte, error) {
+		return []byte(files[fn]), nil
+	}).AnyTimes()
+	mockRepo.EXPECT().Org().Return(nil).AnyTimes()
+
+	c := checker.CheckRequest{
+		RepoClient: mockRepoClient,
+		Repo:       mockRepo,
+		Dlogger:    &scut.TestDetailLogger{},
+	}
+	res, err := SecurityPolicy(&c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.PolicyFiles) != 1 || res.PolicyFiles[0].File.Path != "SECURITY.md" {
+		t.Errorf("expected SECURITY.md as the policy, got %+v", res.PolicyFiles)
+	}
+	if res.SecurityTxt == nil {
+		t.Fatal("expected a security.txt file")
+	}
+	if res.SecurityTxt.File.Path != "web/.well-known/security.txt" {
+		t.Errorf("unexpected security.txt path %s", res.SecurityTxt.File.Path)
+	}
+	want := []string{"mailto:security@example.com", "https://example.com/security"}
+	if diff := cmp.Diff(want, res.SecurityTxt.Contacts); diff != "" {
+		t.Errorf("unexpected contacts (-want +got):\n%s", diff)
+	}
+}
+
+func Test_isSecurityTxtFilename(t *testing.T) {
+	t.Parallel()
+	tests := map[string]bool{
+		"security.txt":                  true,
+		"SECURITY.TXT":                  true,
+		".well-known/security.txt":      true,
+		"docs/.well-known/security.txt": true,
+		"docs/security.txt":             false,
+		"security.md":                   false,
+	}
+	for filename, want := range tests {
+		if got := isSecurityTxtFilename(filename); got != want {
+			t.Errorf("isSecurityTxtFilename(%q) = %v, want %v", filename, got, want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package raw
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rhysd/actionlint"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+const selfHostedLabel = "self-hosted"
+
+// forkTriggers are the events on which workflows run for pull requests from forks.
+var forkTriggers = []triggerName{
+	triggerPullRequest,
+	triggerPullRequestTarget,
+	triggerName("pull_request_review"),
+	triggerName("pull_request_review_comment"),
+}
+
+// gitHubHostedPrefixes are the prefixes of the labels of standard GitHub-hosted runners.
+var gitHubHostedPrefixes = []string{"ubuntu-", "windows-", "macos-"}
+
+// SelfHostedRunners retrieves the raw data for the Self-Hosted-Runners check.
+func SelfHostedRunners(c *checker.CheckRequest) (checker.SelfHostedRunnersData, error) {
+	var data checker.SelfHostedRunnersData
+	err := fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
+		Pattern:       ".github/workflows/*",
+		CaseSensitive: false,
+	}, collectRunnerJobs, &data.Jobs)
+	if err != nil {
+		return checker.SelfHostedRunnersData{}, err
+	}
+
+	private, err := c.RepoClient.IsPrivate()
+	switch {
+	case errors.Is(err, clients.ErrUnsupportedFeature):
+		c.Dlogger.Debug(&checker.LogMessage{
+			Text: fmt.Sprintf("could not determine the visibility of the repository: %v", err),
+		})
+	case err != nil:
+		return checker.SelfHostedRunnersData{},
+			sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.IsPrivate: %v", err))
+	default:
+		data.Private = private
+	}
+	return data, nil
+}
+
+var collectRunnerJobs fileparser.DoWhileTrueOnFileContent = func(path string,
+	content []byte,
+	args ...interface{},
+) (bool, error) {
+	if !fileparser.IsWorkflowFile(path) {
+		return true, nil
+	}
+
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"collectRunnerJobs requires exactly 1 arguments: %w", errInvalidArgLength)
+	}
+	pdata, ok := args[0].(*[]checker.RunnerJob)
+	if !ok {
+		return false, fmt.Errorf(
+			"collectRunnerJobs expects arg[0] of type *[]checker.RunnerJob: %w", errInvalidArgType)
+	}
+
+	if !fileparser.CheckFileContainsCommands(content, "#") {
+		return true, nil
+	}
+
+	workflow, errs := actionlint.Parse(content)
+	if len(errs) > 0 && workflow == nil {
+		return false, fileparser.FormatActionlintError(errs)
+	}
+
+	var triggers []string
+	for _, t := range forkTriggers {
+		if usesEventTrigger(workflow, t) {
+			triggers = append(triggers, string(t))
+		}
+	}
+
+	for _, job := range workflow.Jobs {
+		if job == nil || job.RunsOn == nil {
+			continue
+		}
+		// Labels which can't be resolved, e.g. an expression
+		// other than a matrix OS, are ignored.
+		labels, err := fileparser.GetOSesForJob(job)
+		if err != nil {
+			continue
+		}
+		selfHosted, custom := classifyRunnerLabels(labels)
+		if !selfHosted && !custom {
+			continue
+		}
+		offset := uint(checker.OffsetDefault)
+		if len(job.RunsOn.Labels) > 0 {
+			offset = fileparser.GetLineNumber(job.RunsOn.Labels[0].Pos)
+		}
+		*pdata = append(*pdata, checker.RunnerJob{
+			Job:          createJob(job),
+			Labels:       labels,
+			ForkTriggers: triggers,
+			SelfHosted:   selfHosted,
+			File: checker.File{
+				Path:   path,
+				Type:   finding.FileTypeSource,
+				Offset: offset,
+			},
+		})
+	}
+	return true, nil
+}
+
+// classifyRunnerLabels reports whether the labels request a self-hosted runner,
+// or a runner which is not a standard GitHub-hosted runner.
+func classifyRunnerLabels(labels []string) (selfHosted, custom bool) {
+	for _, l := range labels {
+		l = strings.ToLower(strings.TrimSpace(l))
+		switch {
+		case l == selfHostedLabel:
+			selfHosted = true
+		case strings.Contains(l, "${{"):
+		case !hasAnyPrefix(l, gitHubHostedPrefixes):
+			custom = true
+		}
+	}
+	return selfHosted, custom
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
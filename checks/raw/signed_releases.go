@@ -27,7 +27,19 @@ func SignedReleases(c *checker.CheckRequest) (checker.SignedReleasesData, error)
 		return checker.SignedReleasesData{}, fmt.Errorf("%w", err)
 	}
 
+	checksums, err := releaseChecksums(c.RepoClient, releases)
+	if err != nil {
+		return checker.SignedReleasesData{}, err
+	}
+
+	provenance, err := releaseProvenance(c, releases)
+	if err != nil {
+		return checker.SignedReleasesData{}, err
+	}
+
 	return checker.SignedReleasesData{
-		Releases: releases,
+		Releases:   releases,
+		Checksums:  checksums,
+		Provenance: provenance,
 	}, nil
 }
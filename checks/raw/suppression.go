@@ -0,0 +1,123 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+)
+
+// The IDs of `scorecard:ignore` comments match the names the checks are
+// registered under, optionally followed by the type of the finding, e.g.
+// Pinned-Dependencies/GitHubAction.
+const (
+	pinnedDependenciesID = "Pinned-Dependencies"
+	dangerousWorkflowID  = "Dangerous-Workflow"
+)
+
+// findSuppression returns the suppression of a finding of findingType at f by
+// one of comments, or nil if it is not suppressed. A comment applies to a
+// finding spanning several lines if it is next to any of them.
+func findSuppression(comments map[uint][]fileparser.IgnoreComment, id, findingType string,
+	f *checker.File,
+) *checker.Suppression {
+	end := f.EndOffset
+	if end < f.Offset {
+		end = f.Offset
+	}
+	for line := f.Offset; line <= end; line++ {
+		for _, c := range comments[line] {
+			if strings.EqualFold(c.ID, id) || strings.EqualFold(c.ID, id+"/"+findingType) {
+				return &checker.Suppression{
+					ID:     c.ID,
+					Reason: c.Reason,
+					Type:   findingType,
+					File:   *f,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// suppressingDependencies wraps a collector of unpinned dependencies, so the
+// dependencies it finds in a file are suppressed by the `scorecard:ignore`
+// comments of the file.
+func suppressingDependencies(collect fileparser.DoWhileTrueOnFileContent) fileparser.DoWhileTrueOnFileContent {
+	return func(path string, content []byte, args ...interface{}) (bool, error) {
+		if len(args) == 0 {
+			return collect(path, content, args...)
+		}
+		pdata := dataAsPinnedDependenciesPointer(args[0])
+		collected := len(pdata.Dependencies)
+		cont, err := collect(path, content, args...)
+		if err != nil || collected == len(pdata.Dependencies) {
+			return cont, err
+		}
+		comments := fileparser.ParseIgnoreComments(content)
+		if len(comments) == 0 {
+			return cont, nil
+		}
+		kept := pdata.Dependencies[:collected]
+		for _, d := range pdata.Dependencies[collected:] {
+			// Dependencies with a message are debug messages, not findings.
+			if d.Location != nil && d.Msg == nil {
+				if s := findSuppression(comments, pinnedDependenciesID, string(d.Type), d.Location); s != nil {
+					pdata.Suppressed = append(pdata.Suppressed, *s)
+					continue
+				}
+			}
+			kept = append(kept, d)
+		}
+		pdata.Dependencies = kept
+		return cont, nil
+	}
+}
+
+// suppressingWorkflows wraps the collector of dangerous workflow patterns, so
+// the patterns it finds in a workflow are suppressed by the `scorecard:ignore`
+// comments of the workflow.
+func suppressingWorkflows(collect fileparser.DoWhileTrueOnFileContent) fileparser.DoWhileTrueOnFileContent {
+	return func(path string, content []byte, args ...interface{}) (bool, error) {
+		if len(args) == 0 {
+			return collect(path, content, args...)
+		}
+		pdata, ok := args[0].(*checker.DangerousWorkflowData)
+		if !ok {
+			return collect(path, content, args...)
+		}
+		collected := len(pdata.Workflows)
+		cont, err := collect(path, content, args...)
+		if err != nil || collected == len(pdata.Workflows) {
+			return cont, err
+		}
+		comments := fileparser.ParseIgnoreComments(content)
+		if len(comments) == 0 {
+			return cont, nil
+		}
+		kept := pdata.Workflows[:collected]
+		for _, w := range pdata.Workflows[collected:] {
+			if s := findSuppression(comments, dangerousWorkflowID, string(w.Type), &w.File); s != nil {
+				pdata.Suppressed = append(pdata.Suppressed, *s)
+				continue
+			}
+			kept = append(kept, w)
+		}
+		pdata.Workflows = kept
+		return cont, nil
+	}
+}
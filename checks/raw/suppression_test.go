@@ -0,0 +1,153 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+func TestSuppressingDependencies(t *testing.T) {
+	t.Parallel()
+	//nolint
+	tests := []struct {
+		name       string
+		filename   string
+		content    string
+		collect    func(string, []byte, ...interface{}) (bool, error)
+		want       int
+		suppressed []checker.Suppression
+	}{
+		{
+			name:     "no comments",
+			filename: "Dockerfile",
+			content:  "FROM python:3.7\nFROM golang:1.19\n",
+			collect:  validateDockerfilesPinning,
+			want:     2,
+		},
+		{
+			name:     "comment on the previous line",
+			filename: "Dockerfile",
+			content: "# scorecard:ignore Pinned-Dependencies reason=\"tracks upstream\"\n" +
+				"FROM python:3.7\nFROM golang:1.19\n",
+			collect: validateDockerfilesPinning,
+			want:    1,
+			suppressed: []checker.Suppression{
+				{
+					ID:     "Pinned-Dependencies",
+					Reason: "tracks upstream",
+					Type:   string(checker.DependencyUseTypeDockerfileContainerImage),
+					File: checker.File{
+						Path:      "Dockerfile",
+						Offset:    2,
+						EndOffset: 2,
+						Snippet:   "FROM python:3.7",
+					},
+				},
+			},
+		},
+		{
+			name:     "comment for another type",
+			filename: "Dockerfile",
+			content: "# scorecard:ignore Pinned-Dependencies/GitHubAction reason=wrong type\n" +
+				"FROM python:3.7\n",
+			collect: validateDockerfilesPinning,
+			want:    1,
+		},
+		{
+			name:     "comment without a reason",
+			filename: "Dockerfile",
+			content:  "# scorecard:ignore Pinned-Dependencies\nFROM python:3.7\n",
+			collect:  validateDockerfilesPinning,
+			want:     1,
+		},
+		{
+			name:     "trailing comment with type",
+			filename: ".github/workflows/build.yaml",
+			content: "on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n    steps:\n" +
+				"      - uses: actions/checkout@v3 # scorecard:ignore Pinned-Dependencies/GitHubAction reason=trusted\n" +
+				"      - uses: actions/setup-go@v3\n",
+			collect: validateGitHubActionWorkflow,
+			want:    1,
+			suppressed: []checker.Suppression{
+				{
+					ID:     "Pinned-Dependencies/GitHubAction",
+					Reason: "trusted",
+					Type:   string(checker.DependencyUseTypeGHAction),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var r checker.PinningDependenciesData
+			if _, err := suppressingDependencies(tt.collect)(tt.filename, []byte(tt.content), &r); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(r.Dependencies) != tt.want {
+				t.Errorf("expected %d dependencies, got %d", tt.want, len(r.Dependencies))
+			}
+			if len(r.Suppressed) != len(tt.suppressed) {
+				t.Fatalf("expected %d suppressions, got %d", len(tt.suppressed), len(r.Suppressed))
+			}
+			for i, want := range tt.suppressed {
+				got := r.Suppressed[i]
+				if got.ID != want.ID || got.Reason != want.Reason || got.Type != want.Type {
+					t.Errorf("unexpected suppression: %v", cmp.Diff(want, got))
+				}
+				if want.File.Path != "" && got.File.Offset != want.File.Offset {
+					t.Errorf("expected suppression at line %d, got %d", want.File.Offset, got.File.Offset)
+				}
+			}
+		})
+	}
+}
+
+func TestSuppressingWorkflows(t *testing.T) {
+	t.Parallel()
+	content := []byte(`on: pull_request_target
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      # scorecard:ignore Dangerous-Workflow reason="only runs trusted scripts"
+      - uses: actions/checkout@v3
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+      - run: echo "${{ github.event.issue.title }}"
+`)
+	var r checker.DangerousWorkflowData
+	if _, err := suppressingWorkflows(validateGitHubActionWorkflowPatterns)(
+		".github/workflows/pr.yaml", content, &r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(r.Suppressed) != 1 {
+		t.Fatalf("expected 1 suppression, got %d", len(r.Suppressed))
+	}
+	if r.Suppressed[0].Type != string(checker.DangerousWorkflowUntrustedCheckout) {
+		t.Errorf("expected the untrusted checkout to be suppressed, got %s", r.Suppressed[0].Type)
+	}
+	if r.Suppressed[0].Reason != "only runs trusted scripts" {
+		t.Errorf("unexpected reason %q", r.Suppressed[0].Reason)
+	}
+	if len(r.Workflows) != 1 || r.Workflows[0].Type != checker.DangerousWorkflowScriptInjection {
+		t.Errorf("expected the script injection to be kept, got %v", r.Workflows)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// openVEXDocument is the subset of an OpenVEX document used by Scorecard.
+// See https://github.com/openvex/spec.
+type openVEXDocument struct {
+	Statements []openVEXStatement `json:"statements"`
+}
+
+type openVEXStatement struct {
+	// Vulnerability is a string up to OpenVEX v0.0.2,
+	// and an object with a name and aliases since v0.2.0.
+	Vulnerability json.RawMessage `json:"vulnerability"`
+	Status        string          `json:"status"`
+	Justification string          `json:"justification"`
+}
+
+type openVEXVulnerability struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+// vexStatements returns the statements of the VEX documents in the repository,
+// e.g. `vex.json`, `openvex.json` or `project.openvex.json`.
+// Files which are not valid OpenVEX documents are ignored.
+func vexStatements(c clients.RepoClient) ([]checker.VEXStatement, error) {
+	var statements []checker.VEXStatement
+	err := fileparser.OnMatchingFileContentDo(c, fileparser.PathMatcher{
+		Pattern:       "*vex.json",
+		CaseSensitive: false,
+	}, collectVEXStatements, &statements)
+	if err != nil {
+		return nil, err
+	}
+	return statements, nil
+}
+
+var collectVEXStatements fileparser.DoWhileTrueOnFileContent = func(path string, content []byte,
+	args ...interface{},
+) (bool, error) {
+	if len(args) != 1 {
+		return false, fmt.Errorf(
+			"collectVEXStatements requires exactly one argument: %w", errInvalidArgLength)
+	}
+	pdata, ok := args[0].(*[]checker.VEXStatement)
+	if !ok {
+		return false, fmt.Errorf(
+			"collectVEXStatements expects arg[0] of type *[]checker.VEXStatement: %w", errInvalidArgType)
+	}
+	*pdata = append(*pdata, parseOpenVEX(path, content)...)
+	return true, nil
+}
+
+func parseOpenVEX(file string, content []byte) []checker.VEXStatement {
+	var doc openVEXDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil
+	}
+	var statements []checker.VEXStatement
+	for i := range doc.Statements {
+		s := &doc.Statements[i]
+		var vuln openVEXVulnerability
+		if err := json.Unmarshal(s.Vulnerability, &vuln.Name); err != nil {
+			if err := json.Unmarshal(s.Vulnerability, &vuln); err != nil {
+				continue
+			}
+		}
+		if vuln.Name == "" || s.Status == "" {
+			continue
+		}
+		statements = append(statements, checker.VEXStatement{
+			Vulnerability: vuln.Name,
+			Aliases:       vuln.Aliases,
+			Status:        checker.VEXStatus(s.Status),
+			Justification: s.Justification,
+			File: checker.File{
+				Path: file,
+				Type: finding.FileTypeSource,
+			},
+		})
+	}
+	return statements
+}
@@ -15,6 +15,7 @@
 package raw
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ossf/scorecard/v4/checker"
@@ -37,9 +38,26 @@ func Vulnerabilities(c *checker.CheckRequest) (checker.VulnerabilitiesData, erro
 	if err != nil {
 		return checker.VulnerabilitiesData{}, fmt.Errorf("vulnerabilitiesClient.ListUnfixedVulnerabilities: %w", err)
 	}
-	return checker.VulnerabilitiesData{
+	data := checker.VulnerabilitiesData{
 		Vulnerabilities: resp.Vulnerabilities,
-	}, nil
+	}
+
+	alerts, err := c.RepoClient.ListDependencyAlerts()
+	switch {
+	case err == nil:
+		data.DependencyAlerts = alerts
+		data.DependencyAlertsAccess = checker.AlertsAvailable
+	case errors.Is(err, clients.ErrPermissionDenied):
+		data.DependencyAlertsAccess = checker.AlertsPermissionDenied
+	case !errors.Is(err, clients.ErrUnsupportedFeature):
+		return checker.VulnerabilitiesData{}, fmt.Errorf("RepoClient.ListDependencyAlerts: %w", err)
+	}
+
+	data.VEXStatements, err = vexStatements(c.RepoClient)
+	if err != nil {
+		return checker.VulnerabilitiesData{}, err
+	}
+	return data, nil
 }
 
 type predicateOnCommitFn func(clients.Commit) bool
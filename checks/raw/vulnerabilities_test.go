@@ -17,13 +17,16 @@ package raw
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/clients"
 	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/finding"
 	scut "github.com/ossf/scorecard/v4/utests"
 )
 
@@ -39,6 +42,9 @@ func TestVulnerabilities(t *testing.T) {
 		numberofCommits int
 		expected        scut.TestReturn
 		vulnsError      bool
+		alerts          []clients.SecurityAlert
+		alertsErr       error
+		files           map[string]string
 	}{
 		{
 			name:            "Valid response",
@@ -66,6 +72,64 @@ func TestVulnerabilities(t *testing.T) {
 			numberofCommits: 1,
 			vulnsResponse:   clients.VulnerabilitiesResponse{},
 		},
+		{
+			name:            "dependency alerts",
+			numberofCommits: 1,
+			alerts:          []clients.SecurityAlert{{ID: "GHSA-xxxx-yyyy-zzzz"}},
+			want: checker.VulnerabilitiesData{
+				DependencyAlerts:       []clients.SecurityAlert{{ID: "GHSA-xxxx-yyyy-zzzz"}},
+				DependencyAlertsAccess: checker.AlertsAvailable,
+			},
+		},
+		{
+			name:            "dependency alerts permission denied",
+			numberofCommits: 1,
+			alertsErr:       fmt.Errorf("%w: dependabot", clients.ErrPermissionDenied),
+			want: checker.VulnerabilitiesData{
+				DependencyAlertsAccess: checker.AlertsPermissionDenied,
+			},
+		},
+		{
+			name:            "VEX documents",
+			numberofCommits: 1,
+			files: map[string]string{
+				"vex.json": `{
+					"@context": "https://openvex.dev/ns/v0.2.0",
+					"statements": [{
+						"vulnerability": {"name": "CVE-2019-1234", "aliases": ["GHSA-xxxx-yyyy-zzzz"]},
+						"status": "not_affected",
+						"justification": "component_not_present"
+					}]
+				}`,
+				"security/project.openvex.json": `{
+					"statements": [{"vulnerability": "CVE-2020-5678", "status": "fixed"}]
+				}`,
+				"docs/vex.json": `not json`,
+			},
+			want: checker.VulnerabilitiesData{
+				VEXStatements: []checker.VEXStatement{
+					{
+						Vulnerability: "CVE-2019-1234",
+						Aliases:       []string{"GHSA-xxxx-yyyy-zzzz"},
+						Status:        checker.VEXStatusNotAffected,
+						Justification: "component_not_present",
+						File:          checker.File{Path: "vex.json", Type: finding.FileTypeSource},
+					},
+					{
+						Vulnerability: "CVE-2020-5678",
+						Status:        checker.VEXStatusFixed,
+						File:          checker.File{Path: "security/project.openvex.json", Type: finding.FileTypeSource},
+					},
+				},
+			},
+		},
+		{
+			name:            "dependency alerts err response",
+			wantErr:         true,
+			numberofCommits: 1,
+			//nolint
+			alertsErr: errors.New("error"),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -89,6 +153,32 @@ func TestVulnerabilities(t *testing.T) {
 				return "test_path", nil
 			}).AnyTimes()
 
+			mockRepo.EXPECT().ListDependencyAlerts().DoAndReturn(func() ([]clients.SecurityAlert, error) {
+				if tt.alerts == nil && tt.alertsErr == nil {
+					return nil, fmt.Errorf("%w", clients.ErrUnsupportedFeature)
+				}
+				return tt.alerts, tt.alertsErr
+			}).AnyTimes()
+
+			mockRepo.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					var files []string
+					for _, name := range []string{"vex.json", "security/project.openvex.json", "docs/vex.json"} {
+						if _, ok := tt.files[name]; !ok {
+							continue
+						}
+						if match, err := predicate(name); err != nil {
+							return nil, err
+						} else if match {
+							files = append(files, name)
+						}
+					}
+					return files, nil
+				}).AnyTimes()
+			mockRepo.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(name string) ([]byte, error) {
+				return []byte(tt.files[name]), nil
+			}).AnyTimes()
+
 			mockVulnClient := mockrepo.NewMockVulnerabilitiesClient(ctrl)
 			mockVulnClient.EXPECT().ListUnfixedVulnerabilities(context.TODO(), gomock.Any(), gomock.Any()).DoAndReturn(
 				func(ctx context.Context, commit string, localPath string) (clients.VulnerabilitiesResponse, error) {
@@ -114,6 +204,13 @@ func TestVulnerabilities(t *testing.T) {
 				if len(got.Vulnerabilities) != len(tt.want.Vulnerabilities) {
 					t.Errorf("Vulnerabilities() got = %v, want %v", len(got.Vulnerabilities), len(tt.want.Vulnerabilities))
 				}
+				if len(got.DependencyAlerts) != len(tt.want.DependencyAlerts) ||
+					got.DependencyAlertsAccess != tt.want.DependencyAlertsAccess {
+					t.Errorf("Vulnerabilities() got = %v, want %v", got, tt.want)
+				}
+				if diff := cmp.Diff(tt.want.VEXStatements, got.VEXStatements); diff != "" {
+					t.Errorf("Vulnerabilities() VEX statements mismatch (-want +got):\n%s", diff)
+				}
 			}
 
 			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &checker.CheckResult{}, &dl) {
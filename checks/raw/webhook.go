@@ -15,6 +15,8 @@
 package raw
 
 import (
+	"fmt"
+
 	"github.com/ossf/scorecard/v4/checker"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
@@ -24,7 +26,7 @@ func WebHook(c *checker.CheckRequest) (checker.WebhooksData, error) {
 	hooksResp, err := c.RepoClient.ListWebhooks()
 	if err != nil {
 		return checker.WebhooksData{},
-			sce.WithMessage(sce.ErrScorecardInternal, "Client.Repositories.ListWebhooks")
+			sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.Repositories.ListWebhooks: %v", err))
 	}
 	return checker.WebhooksData{
 		Webhooks: hooksResp,
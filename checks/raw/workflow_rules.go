@@ -0,0 +1,102 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"sort"
+
+	"github.com/rhysd/actionlint"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// validateWorkflowRules checks the jobs of a workflow against custom rules.
+func validateWorkflowRules(workflow *actionlint.Workflow, path string,
+	rules []checker.WorkflowRule, pdata *checker.DangerousWorkflowData,
+) {
+	if len(rules) == 0 {
+		return
+	}
+	ids := make([]string, 0, len(workflow.Jobs))
+	for id := range workflow.Jobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		job := workflow.Jobs[id]
+		if job == nil {
+			continue
+		}
+		for i := range rules {
+			rule := &rules[i]
+			report := func(pos *actionlint.Pos, snippet string) {
+				pdata.Workflows = append(pdata.Workflows, checker.DangerousWorkflow{
+					Type: checker.DangerousWorkflowCustomRule,
+					Rule: rule,
+					File: checker.File{
+						Path:    path,
+						Type:    finding.FileTypeSource,
+						Offset:  fileparser.GetLineNumber(pos),
+						Snippet: snippet,
+					},
+					Job: createJob(job),
+				})
+			}
+			switch {
+			case rule.Uses != nil:
+				if job.WorkflowCall != nil && job.WorkflowCall.Uses != nil &&
+					rule.Uses.MatchString(job.WorkflowCall.Uses.Value) {
+					report(job.WorkflowCall.Uses.Pos, job.WorkflowCall.Uses.Value)
+				}
+				for _, step := range job.Steps {
+					if uses := fileparser.GetUses(step); uses != nil && rule.Uses.MatchString(uses.Value) {
+						report(uses.Pos, uses.Value)
+					}
+				}
+			case rule.Run != nil:
+				for _, step := range job.Steps {
+					if step == nil {
+						continue
+					}
+					run, ok := step.Exec.(*actionlint.ExecRun)
+					if !ok || run.Run == nil {
+						continue
+					}
+					if match := rule.Run.FindString(run.Run.Value); match != "" {
+						report(run.Run.Pos, match)
+					}
+				}
+			case rule.RequireUses != nil:
+				// Jobs calling reusable workflows have no steps of their own.
+				if len(job.Steps) == 0 || jobUses(job, rule) {
+					continue
+				}
+				report(job.Pos, "")
+			}
+		}
+	}
+}
+
+func jobUses(job *actionlint.Job, rule *checker.WorkflowRule) bool {
+	for _, step := range job.Steps {
+		if uses := fileparser.GetUses(step); uses != nil && rule.RequireUses.MatchString(uses.Value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/rhysd/actionlint"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+func TestValidateWorkflowRules(t *testing.T) {
+	t.Parallel()
+	path := ".github/workflows/github-workflow-dangerous-pattern-custom-rules.yml"
+	content, err := os.ReadFile("../testdata/" + path)
+	if err != nil {
+		t.Fatalf("cannot read file: %v", err)
+	}
+	workflow, errs := actionlint.Parse(content)
+	if len(errs) > 0 {
+		t.Fatalf("cannot parse workflow: %v", errs)
+	}
+	rules := []checker.WorkflowRule{
+		{ID: "no-curl-bash", Run: regexp.MustCompile(`curl[^|]*\|\s*(ba)?sh`)},
+		{ID: "banned-action", Uses: regexp.MustCompile(`^some-org/deprecated-action@`)},
+		{ID: "harden-runner", RequireUses: regexp.MustCompile(`^step-security/harden-runner@`)},
+	}
+
+	type violation struct {
+		ID      string
+		Job     string
+		Snippet string
+		Offset  uint
+	}
+	want := []violation{
+		{ID: "no-curl-bash", Job: "build", Offset: 11, Snippet: "curl -sSL https://example.com/install.sh | bash"},
+		{ID: "banned-action", Job: "build", Offset: 10, Snippet: "some-org/deprecated-action@v1"},
+		{ID: "harden-runner", Job: "test", Offset: 12},
+	}
+
+	var data checker.DangerousWorkflowData
+	validateWorkflowRules(workflow, path, rules, &data)
+	got := make([]violation, 0, len(data.Workflows))
+	for i := range data.Workflows {
+		w := &data.Workflows[i]
+		if w.Type != checker.DangerousWorkflowCustomRule {
+			t.Errorf("unexpected type %q", w.Type)
+		}
+		var job string
+		if w.Job != nil && w.Job.ID != nil {
+			job = *w.Job.ID
+		}
+		got = append(got, violation{ID: w.Rule.ID, Job: job, Offset: w.File.Offset, Snippet: w.File.Snippet})
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("validateWorkflowRules() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// forbiddenImports are packages check code must not use: checks access
+// the repo only through the clients of checker.CheckRequest.
+var forbiddenImports = []string{
+	"net",
+	"net/http",
+	"os/exec",
+	"github.com/google/go-github",
+	"github.com/shurcooL/githubv4",
+	"github.com/xanzy/go-gitlab",
+	"github.com/ossf/scorecard/v4/clients/githubrepo",
+	"github.com/ossf/scorecard/v4/clients/gitlabrepo",
+}
+
+// forbiddenOSFuncs read the environment, which holds the credentials.
+var forbiddenOSFuncs = map[string]bool{
+	"Getenv":    true,
+	"LookupEnv": true,
+	"Environ":   true,
+	"ExpandEnv": true,
+}
+
+func isForbiddenImport(path string) bool {
+	for _, forbidden := range forbiddenImports {
+		// Standard library packages are matched exactly, e.g. net/url is fine.
+		if path == forbidden || (strings.Contains(forbidden, ".") && strings.HasPrefix(path, forbidden+"/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// TestChecksHaveNoAmbientCredentials enforces that checks can neither read
+// token environment variables nor create their own network clients.
+func TestChecksHaveNoAmbientCredentials(t *testing.T) {
+	t.Parallel()
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == "testdata" {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		osName := ""
+		for _, imp := range f.Imports {
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				return err
+			}
+			if isForbiddenImport(importPath) {
+				t.Errorf("%s: check code must not import %s", fset.Position(imp.Pos()), importPath)
+			}
+			if importPath == "os" {
+				osName = "os"
+				if imp.Name != nil {
+					osName = imp.Name.Name
+				}
+			}
+		}
+		if osName == "" {
+			return nil
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == osName && forbiddenOSFuncs[sel.Sel.Name] {
+				t.Errorf("%s: check code must not call os.%s, use checker.CheckRequest instead",
+					fset.Position(sel.Pos()), sel.Sel.Name)
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("filepath.WalkDir: %v", err)
+	}
+}
@@ -21,10 +21,14 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/rhysd/actionlint"
+
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/checks/languages"
 	"github.com/ossf/scorecard/v4/clients"
 	sce "github.com/ossf/scorecard/v4/errors"
 	"github.com/ossf/scorecard/v4/finding"
@@ -39,9 +43,69 @@ var sastTools = map[string]bool{"github-code-scanning": true, "lgtm-com": true,
 
 var allowedConclusions = map[string]bool{"success": true, "neutral": true}
 
+// sastMetadata documents the SAST check.
+var sastMetadata = checker.CheckMetadata{
+	Risk:  "Medium",
+	Short: "Determines if the project uses static code analysis.",
+	Description: "Risk: `Medium` (possible unknown bugs)\n" +
+		"\n" +
+		"This check tries to determine if the project uses Static Application Security\n" +
+		"Testing (SAST), also known as [static code " +
+		"analysis](https://owasp.org/www-community/controls/Static_Code_Analysis).\n" +
+		"It is currently limited to repositories hosted on GitHub, and does not support\n" +
+		"other source hosting repositories (i.e., Forges).\n" +
+		"\n" +
+		"SAST is testing run on source code before the application is run. Using SAST\n" +
+		"tools can prevent known classes of bugs from being inadvertently introduced in the\n" +
+		"codebase.\n" +
+		"\n" +
+		"The checks currently looks for known Github apps such as\n" +
+		"[CodeQL](https://codeql.github.com/) (github-code-scanning) or\n" +
+		"[SonarCloud](https://sonarcloud.io/) in the recent (~30) merged PRs, or the use\n" +
+		"of \"github/codeql-action\" in a GitHub workflow. It also checks for the deprecated\n" +
+		"[LGTM](https://lgtm.com/) service until its forthcoming shutdown.\n" +
+		"When the token permits reading the repository's code scanning alerts, uploaded\n" +
+		"code scanning results count as a SAST tool, and open alerts of critical or high\n" +
+		"severity are reported. The details note when the alerts could not be read.\n" +
+		"\n" +
+		"For CodeQL workflows, the check reads the `languages` (including those of a\n" +
+		"`matrix`) and `queries` of the `github/codeql-action/init` steps. The query suite\n" +
+		"(e.g. `default` or `security-extended`) is shown in the details, and the score is\n" +
+		"proportional to the CodeQL-supported languages of the repository which are\n" +
+		"analyzed. Workflows which let CodeQL detect the languages cover all of them.\n" +
+		"\n" +
+		"Note: A project that fulfills this criterion with other tools may still receive\n" +
+		"a low score on this test. There are many ways to implement SAST, and it is\n" +
+		"challenging for an automated tool like Scorecard to detect them all. A low score\n" +
+		"is therefore not a definitive indication that the project is at risk.\n",
+	Tags:  []string{"supply-chain", "security", "testing"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.GetFileContent",
+		"RepoClient.ListCheckRunsForRef",
+		"RepoClient.ListCodeScanningAlerts",
+		"RepoClient.ListCommits",
+		"RepoClient.ListFiles",
+		"RepoClient.ListProgrammingLanguages",
+		"RepoClient.Search",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "a SAST tool ran on all the recently merged pull requests, or SonarCloud is enabled"},
+		{Score: "0-10", Criteria: "proportional to the merged pull requests checked by a SAST tool and to the languages " +
+			"analyzed by CodeQL"},
+		{Score: "0", Criteria: "no SAST tool is detected"},
+	},
+	Remediation: []string{
+		"Run CodeQL checks in your CI/CD by following the instructions " +
+			"[here](https://github.com/github/codeql-action#usage).",
+		"List every language of the repository in the `languages` of the CodeQL workflow, and " +
+			"consider the `security-extended` query suite for broader coverage.",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
-	if err := registerCheck(CheckSAST, SAST, nil); err != nil {
+	if err := registerCheck(CheckSAST, SAST, nil, sastMetadata); err != nil {
 		// This should never happen.
 		panic(err)
 	}
@@ -58,6 +122,16 @@ func SAST(c *checker.CheckRequest) checker.CheckResult {
 	if codeQlErr != nil {
 		return checker.CreateRuntimeErrorResult(CheckSAST, codeQlErr)
 	}
+	codeScanning, codeScanningErr := codeScanningEnabled(c)
+	if codeScanningErr != nil {
+		return checker.CreateRuntimeErrorResult(CheckSAST, codeScanningErr)
+	}
+	// Code scanning results are uploaded by CodeQL and third-party tools alike,
+	// including when they are not configured in the repo's workflows.
+	if codeScanning && codeQlScore == checker.MinResultScore {
+		codeQlScore = checker.MaxResultScore
+	}
+
 	sonarScore, sonarErr := sonarEnabled(c)
 	if sonarErr != nil {
 		return checker.CreateRuntimeErrorResult(CheckSAST, sonarErr)
@@ -92,22 +166,25 @@ func SAST(c *checker.CheckRequest) checker.CheckResult {
 				checker.NormalizeReason("SAST tool is not run on all commits", sastScore), sastScore)
 
 		// codeQl is enabled and sast has 0+ (but not all) PRs checks.
-		case codeQlScore == checker.MaxResultScore:
+		default:
 			const sastWeight = 3
 			const codeQlWeight = 7
 			score := checker.AggregateScoresWithWeight(map[int]int{sastScore: sastWeight, codeQlScore: codeQlWeight})
 			return checker.CreateResultWithScore(CheckSAST, "SAST tool detected but not run on all commmits", score)
-		default:
-			return checker.CreateRuntimeErrorResult(CheckSAST, sce.WithMessage(sce.ErrScorecardInternal, "contact team"))
 		}
 	}
 
 	// Sast inconclusive.
 	if codeQlScore != checker.InconclusiveResultScore {
-		if codeQlScore == checker.MaxResultScore {
+		switch codeQlScore {
+		case checker.MaxResultScore:
 			return checker.CreateMaxScoreResult(CheckSAST, "SAST tool detected")
+		case checker.MinResultScore:
+			return checker.CreateMinScoreResult(CheckSAST, "no SAST tool detected")
+		default:
+			return checker.CreateResultWithScore(CheckSAST,
+				"SAST tool detected but does not analyze all languages", codeQlScore)
 		}
-		return checker.CreateMinScoreResult(CheckSAST, "no SAST tool detected")
 	}
 
 	// CodeQl inconclusive.
@@ -212,7 +289,7 @@ func codeQLInCheckDefinitions(c *checker.CheckRequest) (int, error) {
 		c.Dlogger.Info(&checker.LogMessage{
 			Text: "SAST tool detected: CodeQL",
 		})
-		return checker.MaxResultScore, nil
+		return codeQLCoverage(c, resp.Results)
 	}
 
 	c.Dlogger.Warn(&checker.LogMessage{
@@ -221,6 +298,41 @@ func codeQLInCheckDefinitions(c *checker.CheckRequest) (int, error) {
 	return checker.MinResultScore, nil
 }
 
+// codeScanningSeverities are the severities of open code scanning alerts which are reported.
+var codeScanningSeverities = map[string]bool{"critical": true, "high": true}
+
+func codeScanningEnabled(c *checker.CheckRequest) (bool, error) {
+	alerts, err := c.RepoClient.ListCodeScanningAlerts()
+	switch {
+	case errors.Is(err, clients.ErrUnsupportedFeature):
+		return false, nil
+	case errors.Is(err, clients.ErrPermissionDenied):
+		c.Dlogger.Info(&checker.LogMessage{
+			Text: "code scanning alerts not considered: code scanning is not enabled " +
+				"or the token does not permit reading them",
+		})
+		return false, nil
+	case err != nil:
+		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("RepoClient.ListCodeScanningAlerts: %v", err))
+	}
+
+	for _, alert := range alerts {
+		if !codeScanningSeverities[strings.ToLower(alert.Severity)] {
+			continue
+		}
+		c.Dlogger.Warn(&checker.LogMessage{
+			Path:   alert.Path,
+			Type:   finding.FileTypeSource,
+			Offset: checker.OffsetDefault,
+			Text:   fmt.Sprintf("open %s severity code scanning alert: %s", strings.ToLower(alert.Severity), alert.ID),
+		})
+	}
+	c.Dlogger.Info(&checker.LogMessage{
+		Text: fmt.Sprintf("SAST tool detected: code scanning (%d open alerts)", len(alerts)),
+	})
+	return true, nil
+}
+
 type sonarConfig struct {
 	url  string
 	file checker.File
@@ -323,3 +435,192 @@ func findLine(content, data []byte) (uint, error) {
 
 	return 0, nil
 }
+
+// codeQLLanguages maps the languages of a repo to the languages analyzed by CodeQL.
+// See https://codeql.github.com/docs/codeql-overview/supported-languages-and-frameworks/.
+var codeQLLanguages = map[clients.LanguageName]string{
+	clients.C:          "cpp",
+	clients.Cpp:        "cpp",
+	clients.CSharp:     "csharp",
+	clients.Go:         "go",
+	clients.Java:       "java",
+	clients.Kotlin:     "java",
+	clients.JavaScript: "javascript",
+	clients.TypeScript: "javascript",
+	clients.Python:     "python",
+	clients.Ruby:       "ruby",
+	clients.Swift:      "swift",
+}
+
+// codeQLLanguageAliases maps the alternative names of CodeQL languages to their canonical names.
+var codeQLLanguageAliases = map[string]string{
+	"c":                     "cpp",
+	"c++":                   "cpp",
+	"c-cpp":                 "cpp",
+	"c#":                    "csharp",
+	"kotlin":                "java",
+	"java-kotlin":           "java",
+	"typescript":            "javascript",
+	"javascript-typescript": "javascript",
+}
+
+var matrixExpression = regexp.MustCompile(`\$\{\{\s*matrix\.([\w-]+)\s*\}\}`)
+
+// codeQLConfig is the configuration of a github/codeql-action/init step.
+type codeQLConfig struct {
+	file string
+	// languages is nil when CodeQL detects the languages of the repo itself.
+	languages []string
+	queries   string
+}
+
+// codeQLCoverage returns the proportion of the CodeQL languages of the repo
+// which the CodeQL workflows analyze.
+func codeQLCoverage(c *checker.CheckRequest, results []clients.SearchResult) (int, error) {
+	var configs []codeQLConfig
+	for _, result := range results {
+		content, err := c.RepoClient.GetFileContent(strings.TrimPrefix(result.Path, "/"))
+		if err != nil {
+			continue
+		}
+		configs = append(configs, parseCodeQLConfigs(result.Path, content)...)
+	}
+	if len(configs) == 0 {
+		return checker.MaxResultScore, nil
+	}
+
+	analyzed := map[string]bool{}
+	autodetect := false
+	for _, config := range configs {
+		queries := config.queries
+		if queries == "" {
+			queries = "default"
+		}
+		c.Dlogger.Info(&checker.LogMessage{
+			Path:   config.file,
+			Type:   finding.FileTypeSource,
+			Offset: checker.OffsetDefault,
+			Text:   fmt.Sprintf("CodeQL query suite: %s", queries),
+		})
+		if config.languages == nil {
+			autodetect = true
+		}
+		for _, language := range config.languages {
+			analyzed[language] = true
+		}
+	}
+	if autodetect {
+		return checker.MaxResultScore, nil
+	}
+
+	repoLanguages, err := languages.List(c.RepoClient)
+	if err != nil {
+		return checker.InconclusiveResultScore, err
+	}
+	supported := map[string]bool{}
+	for _, language := range repoLanguages {
+		if l, ok := codeQLLanguages[clients.LanguageName(strings.ToLower(string(language.Name)))]; ok {
+			supported[l] = true
+		}
+	}
+	if len(supported) == 0 {
+		return checker.MaxResultScore, nil
+	}
+	covered := 0
+	for _, language := range sortedKeys(supported) {
+		if analyzed[language] {
+			covered++
+			continue
+		}
+		c.Dlogger.Warn(&checker.LogMessage{
+			Path:   configs[0].file,
+			Type:   finding.FileTypeSource,
+			Offset: checker.OffsetDefault,
+			Text:   fmt.Sprintf("CodeQL does not analyze the %s code of the repo", language),
+		})
+	}
+	return checker.CreateProportionalScore(covered, len(supported)), nil
+}
+
+// parseCodeQLConfigs returns the configurations of the github/codeql-action/init steps of a workflow.
+func parseCodeQLConfigs(file string, content []byte) []codeQLConfig {
+	workflow, errs := actionlint.Parse(content)
+	if len(errs) > 0 && workflow == nil {
+		return nil
+	}
+	var configs []codeQLConfig
+	for _, job := range workflow.Jobs {
+		if job == nil {
+			continue
+		}
+		for _, step := range job.Steps {
+			uses := fileparser.GetUses(step)
+			if uses == nil || !strings.HasPrefix(uses.Value, "github/codeql-action/init") {
+				continue
+			}
+			action, ok := step.Exec.(*actionlint.ExecAction)
+			if !ok {
+				continue
+			}
+			config := codeQLConfig{file: file}
+			if input, ok := action.Inputs["queries"]; ok && input.Value != nil {
+				config.queries = strings.TrimSpace(input.Value.Value)
+			}
+			if input, ok := action.Inputs["languages"]; ok && input.Value != nil {
+				config.languages = codeQLStepLanguages(job, input.Value.Value)
+			}
+			configs = append(configs, config)
+		}
+	}
+	return configs
+}
+
+// codeQLStepLanguages returns the languages of the languages input of an init step,
+// resolving `${{ matrix.<name> }}` from the matrix of the job. It returns nil
+// when the languages cannot be resolved.
+func codeQLStepLanguages(job *actionlint.Job, value string) []string {
+	var values []string
+	if m := matrixExpression.FindStringSubmatch(value); m != nil {
+		if job.Strategy == nil || job.Strategy.Matrix == nil {
+			return nil
+		}
+		row, ok := job.Strategy.Matrix.Rows[strings.ToLower(m[1])]
+		if !ok || row.Expression != nil {
+			return nil
+		}
+		for _, v := range row.Values {
+			str, ok := v.(*actionlint.RawYAMLString)
+			if !ok {
+				return nil
+			}
+			values = append(values, str.Value)
+		}
+	} else {
+		if strings.Contains(value, "${{") {
+			return nil
+		}
+		values = strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\n'
+		})
+	}
+	languages := []string{}
+	for _, v := range values {
+		language := strings.ToLower(strings.TrimSpace(v))
+		if alias, ok := codeQLLanguageAliases[language]; ok {
+			language = alias
+		}
+		if language != "" {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
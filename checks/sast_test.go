@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/clients"
@@ -42,6 +43,11 @@ func Test_SAST(t *testing.T) {
 		checkRuns     []clients.CheckRun
 		searchRequest clients.SearchRequest
 		path          string
+		alerts        []clients.SecurityAlert
+		alertsErr     error
+		languages     []clients.Language
+		languagesErr  error
+		files         []string
 		expected      checker.CheckResult
 	}{
 		{
@@ -199,6 +205,73 @@ func Test_SAST(t *testing.T) {
 				Score: 0,
 			},
 		},
+		{
+			name:    "code scanning alerts readable",
+			commits: []clients.Commit{},
+			alerts: []clients.SecurityAlert{
+				{ID: "go/sql-injection", Severity: "high", Path: "main.go"},
+				{ID: "go/unused", Severity: "note", Path: "main.go"},
+			},
+			expected: checker.CheckResult{
+				Score: 10,
+			},
+		},
+		{
+			name:      "code scanning alerts not readable",
+			commits:   []clients.Commit{},
+			alertsErr: fmt.Errorf("%w: code-scanning", clients.ErrPermissionDenied),
+			expected: checker.CheckResult{
+				Score: 0,
+			},
+		},
+		{
+			name:    "CodeQL not analyzing all languages",
+			commits: []clients.Commit{},
+			searchresult: clients.SearchResponse{Hits: 1, Results: []clients.SearchResult{{
+				Path: ".github/workflows/codeql.yml",
+			}}},
+			path: "./testdata/.github/workflows/github-workflow-codeql-languages.yml",
+			languages: []clients.Language{
+				{Name: "Go", NumLines: 1000},
+				{Name: "TypeScript", NumLines: 500},
+				{Name: "Python", NumLines: 100},
+				{Name: "Shell", NumLines: 50},
+			},
+			expected: checker.CheckResult{
+				Score: 6,
+			},
+		},
+		{
+			name:    "CodeQL detecting the languages",
+			commits: []clients.Commit{},
+			searchresult: clients.SearchResponse{Hits: 1, Results: []clients.SearchResult{{
+				Path: ".github/workflows/codeql.yml",
+			}}},
+			path: "./testdata/.github/workflows/github-workflow-codeql-autodetect.yml",
+			languages: []clients.Language{
+				{Name: "Go", NumLines: 1000},
+				{Name: "Python", NumLines: 100},
+			},
+			expected: checker.CheckResult{
+				Score: 10,
+			},
+		},
+		{
+			name:    "CodeQL not analyzing the languages detected from files",
+			commits: []clients.Commit{},
+			searchresult: clients.SearchResponse{Hits: 1, Results: []clients.SearchResult{{
+				Path: ".github/workflows/codeql.yml",
+			}}},
+			path:         "./testdata/.github/workflows/github-workflow-codeql-languages.yml",
+			languagesErr: clients.ErrUnsupportedFeature,
+			files: []string{
+				".github/workflows/codeql.yml", "main.go", "app.ts", "tool.py", "util.py",
+				"vendor/lib.java", "vendor/other.java",
+			},
+			expected: checker.CheckResult{
+				Score: 6,
+			},
+		},
 		{
 			name: "sonartype config 1 line",
 			path: "./testdata/pom-1line.xml",
@@ -232,9 +305,30 @@ func Test_SAST(t *testing.T) {
 			})
 			mockRepoClient.EXPECT().ListCheckRunsForRef("").Return(tt.checkRuns, nil).AnyTimes()
 			mockRepoClient.EXPECT().Search(searchRequest).Return(tt.searchresult, nil).AnyTimes()
+			mockRepoClient.EXPECT().ListCodeScanningAlerts().DoAndReturn(func() ([]clients.SecurityAlert, error) {
+				if tt.alerts == nil && tt.alertsErr == nil {
+					return nil, fmt.Errorf("%w", clients.ErrUnsupportedFeature)
+				}
+				return tt.alerts, tt.alertsErr
+			}).AnyTimes()
+			mockRepoClient.EXPECT().ListProgrammingLanguages().Return(tt.languages, tt.languagesErr).AnyTimes()
 			mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
 				func(predicate func(string) (bool, error)) ([]string, error) {
-					return []string{"pom.xml"}, nil
+					files := tt.files
+					if files == nil {
+						files = []string{"pom.xml"}
+					}
+					var matched []string
+					for _, f := range files {
+						ok, err := predicate(f)
+						if err != nil {
+							return nil, err
+						}
+						if ok {
+							matched = append(matched, f)
+						}
+					}
+					return matched, nil
 				}).AnyTimes()
 			mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(fn string) ([]byte, error) {
 				if tt.path == "" {
@@ -342,3 +436,41 @@ func Test_validateSonarConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseCodeQLConfigs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		path string
+		want []codeQLConfig
+	}{
+		{
+			name: "languages from the matrix",
+			path: "./testdata/.github/workflows/github-workflow-codeql-languages.yml",
+			want: []codeQLConfig{
+				{file: "codeql.yml", languages: []string{"go", "javascript"}, queries: "security-extended"},
+			},
+		},
+		{
+			name: "detected languages",
+			path: "./testdata/.github/workflows/github-workflow-codeql-autodetect.yml",
+			want: []codeQLConfig{
+				{file: "codeql.yml"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content, err := os.ReadFile(tt.path)
+			if err != nil {
+				t.Fatalf("os.ReadFile: %v", err)
+			}
+			got := parseCodeQLConfigs("codeql.yml", content)
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(codeQLConfig{})); diff != "" {
+				t.Errorf("parseCodeQLConfigs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
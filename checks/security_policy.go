@@ -24,12 +24,85 @@ import (
 // CheckSecurityPolicy is the registred name for SecurityPolicy.
 const CheckSecurityPolicy = "Security-Policy"
 
+// securityPolicyMetadata documents the Security-Policy check.
+var securityPolicyMetadata = checker.CheckMetadata{
+	Risk:  "Medium",
+	Short: "Determines if the project has published a security policy.",
+	Description: "Risk: `Medium` (possible insecure reporting of vulnerabilities)\n" +
+		"\n" +
+		"This check tries to determine if the project has published a security policy. It\n" +
+		"works by looking for a file named `SECURITY.md` (case-insensitive) in a few\n" +
+		"well-known directories.\n" +
+		"\n" +
+		"A security policy (typically a `SECURITY.md` file) can give users information\n" +
+		"about what constitutes a vulnerability and how to report one securely so that\n" +
+		"information about a bug is not publicly visible.\n" +
+		"\n" +
+		"This check examines the contents of the security policy file awarding points\n" +
+		"for those policies that express vulnerability process(es), disclosure timelines,\n" +
+		"and have links (e.g., URL(s) and email(s)) to support the users.\n" +
+		"\n" +
+		"Linking Requirements (one or more) (6/10 points):\n" +
+		"  - A valid form of an email address to contact for vulnerabilities\n" +
+		"  - A valid form of a http/https address to support vulnerability reporting\n" +
+		"\n" +
+		"Free Form Text (3/10 points):\n" +
+		"  - Free form text is present in the security policy file which is beyond\n" +
+		"    simply having a http/https address and/or email in the file\n" +
+		"  - The string length of any such links in the policy file do not count\n" +
+		"    towards detecting free form text\n" +
+		"\n" +
+		"Security Policy Specific Text (1/10 points):\n" +
+		"  - Specific text providing basic or general information about vulnerability\n" +
+		"    and disclosure practices, expectations, and/or timelines\n" +
+		"  - Text should include a total of 2 or more hits which match (case insensitive)\n" +
+		"    `vuln` and as in \"Vulnerability\" or \"vulnerabilities\";\n" +
+		"    `disclos` as \"Disclosure\" or \"disclose\";\n" +
+		"    and numbers which convey expectations of times, e.g., 30 days or 90 days\n" +
+		"\n" +
+		"When the repository has no security policy file, the check looks for one in\n" +
+		"the `.github` repository of its organization, which GitHub uses as the\n" +
+		"[default community health " +
+		"file](https://docs.github.com/en/communities/setting-up-your-project-for-healthy-contributions/creating-a-default-community-health-file).\n" +
+		"If none is found either, but the GitHub API reports a security policy for the\n" +
+		"repository (e.g., inherited from a private `.github` repository), the policy\n" +
+		"is credited with 6/10 points since its content cannot be evaluated. The\n" +
+		"details indicate whether the policy was found in the repository, its\n" +
+		"organization or through the GitHub API.\n",
+	Tags:  []string{"supply-chain", "security", "policy"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"CheckRequest.NewOrgRepoClient",
+		"RepoClient.GetFileContent",
+		"RepoClient.GetSecurityPolicyURL",
+		"RepoClient.ListFiles",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "+6", Criteria: "the security policy links to an email or web address to report vulnerabilities"},
+		{Score: "+3", Criteria: "the security policy has free form text beyond its links"},
+		{Score: "+1", Criteria: "the security policy describes the vulnerability disclosure process and timelines"},
+		{Score: "6", Criteria: "the security policy is only reported by the GitHub API, so its content cannot be evaluated"},
+		{Score: "0", Criteria: "no security policy is found"},
+	},
+	Remediation: []string{
+		"Place a security policy file `SECURITY.md` in the root directory of your repository. This " +
+			"makes it easily discoverable by a vulnerability reporter.",
+		"The file should contain information on what constitutes a vulnerability and a way to " +
+			"report it securely (e.g. issue tracker with private issue support, encrypted email with a " +
+			"published public key). Follow the [coordinated vulnerability disclosure " +
+			"guidelines](https://github.com/ossf/oss-vulnerability-guide/blob/main/maintainer-guide.md) to respond to vulnerability disclosures.",
+		"For GitHub, see more information " +
+			"[here](https://docs.github.com/en/code-security/getting-started/adding-a-security-policy-to-your-repository).",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
 	supportedRequestTypes := []checker.RequestType{
+		checker.FileBased,
 		checker.CommitBased,
 	}
-	if err := registerCheck(CheckSecurityPolicy, SecurityPolicy, supportedRequestTypes); err != nil {
+	if err := registerCheck(CheckSecurityPolicy, SecurityPolicy, supportedRequestTypes, securityPolicyMetadata); err != nil {
 		// This should never happen.
 		panic(err)
 	}
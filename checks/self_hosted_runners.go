@@ -0,0 +1,114 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package checks
+
+import (
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/evaluation"
+	"github.com/ossf/scorecard/v4/checks/raw"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// CheckSelfHostedRunners is the registered name for SelfHostedRunners.
+const CheckSelfHostedRunners = "Self-Hosted-Runners"
+
+// selfHostedRunnersMetadata documents the Self-Hosted-Runners check.
+var selfHostedRunnersMetadata = checker.CheckMetadata{
+	Risk:  "High",
+	Short: "Determines if the project exposes self-hosted runners to the public.",
+	Description: "Risk: `High` (compromised build infrastructure)\n" +
+		"\n" +
+		"This check determines whether GitHub workflow jobs of a public repository run\n" +
+		"on [self-hosted " +
+		"runners](https://docs.github.com/en/actions/hosting-your-own-runners/about-self-hosted-runners),\n" +
+		"and whether pull requests from forks can run these jobs. Anyone who can open a\n" +
+		"pull request could then run code on the runner, persist on it, and reach the\n" +
+		"network it is connected to.\n" +
+		"\n" +
+		"Jobs which request the `self-hosted` label run on self-hosted runners. Jobs which\n" +
+		"request other labels than the ones of standard GitHub-hosted runners (`ubuntu-*`,\n" +
+		"`windows-*`, `macos-*`) may run on self-hosted runners or on larger GitHub-hosted\n" +
+		"runners. Workflows triggered by `pull_request`, `pull_request_target`,\n" +
+		"`pull_request_review` or `pull_request_review_comment` can be run by pull\n" +
+		"requests from forks.\n" +
+		"\n" +
+		"The check scores the most exposed job: a self-hosted runner reachable by pull\n" +
+		"requests from forks receives the lowest score, a custom runner reachable by pull\n" +
+		"requests from forks receives 5, and a self-hosted runner which can't be reached\n" +
+		"by them receives 7. Private repositories receive the highest score. When the\n" +
+		"visibility of the repository can't be determined, e.g. for local directories,\n" +
+		"it is assumed to be public.\n" +
+		"\n" +
+		"Note: this is an experimental check which requires `SCORECARD_EXPERIMENTAL`\n" +
+		"to be set.\n",
+	Tags:  []string{"supply-chain", "security", "infrastructure"},
+	Repos: []string{"GitHub", "local"},
+	Inputs: []string{
+		"RepoClient.GetFileContent",
+		"RepoClient.IsPrivate",
+		"RepoClient.ListFiles",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "the repository is private, or no job runs on a self-hosted runner"},
+		{Score: "7", Criteria: "a self-hosted runner cannot be reached by pull requests from forks"},
+		{Score: "5", Criteria: "a custom runner can be reached by pull requests from forks"},
+		{Score: "0", Criteria: "a self-hosted runner can be reached by pull requests from forks"},
+	},
+	Remediation: []string{
+		"Use GitHub-hosted runners for workflows of public repositories, see [Self-hosted runner " +
+			"security](https://docs.github.com/en/actions/hosting-your-own-runners/about-self-hosted-runners#self-hosted-runner-security).",
+		"If self-hosted runners are needed, don't run them for events of pull requests from forks, " +
+			"require approval for workflow runs from all outside collaborators, and use ephemeral " +
+			"runners in an isolated environment.",
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	supportedRequestTypes := []checker.RequestType{
+		checker.FileBased,
+	}
+	if err := registerCheck(CheckSelfHostedRunners, SelfHostedRunners, supportedRequestTypes, selfHostedRunnersMetadata); err != nil {
+		// this should never happen
+		panic(err)
+	}
+}
+
+// SelfHostedRunners runs the Self-Hosted-Runners check.
+func SelfHostedRunners(c *checker.CheckRequest) checker.CheckResult {
+	// TODO: remove this check when v6 is released
+	if !checker.IsExperimentalEnabled() {
+		c.Dlogger.Warn(&checker.LogMessage{
+			Text: "SCORECARD_EXPERIMENTAL is not set, not running the Self-Hosted-Runners check",
+		})
+
+		e := sce.WithMessage(sce.ErrorUnsupportedCheck,
+			"SCORECARD_EXPERIMENTAL is not set, not running the Self-Hosted-Runners check")
+		return checker.CreateRuntimeErrorResult(CheckSelfHostedRunners, e)
+	}
+
+	rawData, err := raw.SelfHostedRunners(c)
+	if err != nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		return checker.CreateRuntimeErrorResult(CheckSelfHostedRunners, e)
+	}
+
+	// Set the raw results.
+	if c.RawResults != nil {
+		c.RawResults.SelfHostedRunnersResults = rawData
+	}
+
+	// Return the score evaluation.
+	return evaluation.SelfHostedRunners(CheckSelfHostedRunners, c.Dlogger, &rawData)
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestSelfHostedRunners(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err      error
+		name     string
+		filename string
+		private  bool
+		expected scut.TestReturn
+		wantErr  bool
+	}{
+		{
+			name:     "GitHub-hosted runners only",
+			filename: "./testdata/.github/workflows/github-workflow-permissions-none.yaml",
+			expected: scut.TestReturn{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name:     "Self-hosted runner reachable from forks",
+			filename: "./testdata/.github/workflows/github-workflow-self-hosted-runners.yaml",
+			expected: scut.TestReturn{
+				Score:        checker.MinResultScore,
+				NumberOfWarn: 2,
+			},
+		},
+		{
+			name:     "Private repository",
+			filename: "./testdata/.github/workflows/github-workflow-self-hosted-runners.yaml",
+			private:  true,
+			expected: scut.TestReturn{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name:     "Visibility not supported",
+			filename: "./testdata/.github/workflows/github-workflow-self-hosted-runners.yaml",
+			err:      fmt.Errorf("IsPrivate: %w", clients.ErrUnsupportedFeature),
+			expected: scut.TestReturn{
+				Score:         checker.MinResultScore,
+				NumberOfWarn:  2,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name:     "Client error",
+			filename: "./testdata/.github/workflows/github-workflow-self-hosted-runners.yaml",
+			err:      errors.New("some error"), //nolint:goerr113
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			os.Setenv("SCORECARD_EXPERIMENTAL", "true")
+			ctrl := gomock.NewController(t)
+			mockRepo := mockrepo.NewMockRepoClient(ctrl)
+			mockRepo.EXPECT().ListFiles(gomock.Any()).Return([]string{".github/workflows/ci.yaml"}, nil)
+			mockRepo.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(fn string) ([]byte, error) {
+				content, err := os.ReadFile(tt.filename)
+				if err != nil {
+					return nil, fmt.Errorf("%w", err)
+				}
+				return content, nil
+			})
+			mockRepo.EXPECT().IsPrivate().Return(tt.private, tt.err)
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				RepoClient: mockRepo,
+				Ctx:        context.TODO(),
+				Dlogger:    &dl,
+			}
+			res := SelfHostedRunners(&req)
+			if tt.wantErr {
+				if res.Error == nil {
+					t.Errorf("Expected error %v, got nil", tt.err)
+				}
+				// return as we don't need to check the rest of the fields.
+				return
+			}
+
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+			ctrl.Finish()
+		})
+	}
+}
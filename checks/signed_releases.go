@@ -24,9 +24,64 @@ import (
 // CheckSignedReleases is the registered name for SignedReleases.
 const CheckSignedReleases = "Signed-Releases"
 
+// signedReleasesMetadata documents the Signed-Releases check.
+var signedReleasesMetadata = checker.CheckMetadata{
+	Risk:  "High",
+	Short: "Determines if the project cryptographically signs release artifacts.",
+	Description: "Risk: `High` (possibility of installing malicious releases)\n" +
+		"\n" +
+		"This check tries to determine if the project cryptographically signs release\n" +
+		"artifacts. It is currently limited to repositories hosted on GitHub, and does\n" +
+		"not support other source hosting repositories (i.e., Forges).\n" +
+		"\n" +
+		"Signed releases attest to the provenance of the artifact.\n" +
+		"\n" +
+		"This check looks for the following filenames in the project's last five\n" +
+		"[release " +
+		"assets](https://docs.github.com/en/repositories/releasing-projects-on-github/about-releases):\n" +
+		"[*.minisig](https://github.com/jedisct1/minisign), *.asc (pgp),\n" +
+		"*.sig, *.sign, [*.intoto.jsonl](https://slsa.dev).\n" +
+		"\n" +
+		"If a signature is found in the assets for each release, a score of 8 is given.\n" +
+		"If a [SLSA provenance file](https://slsa.dev/spec/v0.1/index) is found in the assets for " +
+		"each release (*.intoto.jsonl), the maximum score of 10 is given.\n" +
+		"\n" +
+		"Note: The check does not verify the signatures. It does verify release assets\n" +
+		"against checksum files published alongside them (e.g., `SHA256SUMS`,\n" +
+		"`checksums.txt`, `*.sha256`) and reports mismatches, without affecting the\n" +
+		"score.\n" +
+		"\n" +
+		"The content of SLSA provenance files is validated before the maximum score\n" +
+		"is given: the provenance must be produced by a trusted builder (e.g., the\n" +
+		"[SLSA GitHub generator](https://github.com/slsa-framework/slsa-github-generator)),\n" +
+		"record this repository and the release tag as its source, and list subjects\n" +
+		"whose digests match the released artifacts. A release whose provenance does\n" +
+		"not pass these checks is scored on its signatures instead.\n",
+	Tags:  []string{"supply-chain", "security", "releases"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.GetReleaseAsset",
+		"RepoClient.ListReleases",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "for each recent release with validated SLSA provenance"},
+		{Score: "8", Criteria: "for each recent release with signatures"},
+		{Score: "0-10", Criteria: "average of the recent releases"},
+	},
+	Remediation: []string{
+		"Publish the release.",
+		"Generate a signing key.",
+		"Download the release as an archive locally.",
+		"Sign the release archive with this key (should output a signature file).",
+		"Attach the signature file next to the release archive.",
+		"If the source is hosted on GitHub, check out the steps " +
+			"[here](https://wiki.debian.org/Creating%20signed%20GitHub%20releases).",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
-	if err := registerCheck(CheckSignedReleases, SignedReleases, nil); err != nil {
+	if err := registerCheck(CheckSignedReleases, SignedReleases, nil, signedReleasesMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
@@ -16,6 +16,7 @@ package checks
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -34,6 +35,7 @@ func TestSignedRelease(t *testing.T) {
 		err      error
 		name     string
 		releases []clients.Release
+		assets   map[string]string
 		expected checker.CheckResult
 	}{
 		{
@@ -75,6 +77,35 @@ func TestSignedRelease(t *testing.T) {
 				Score: 0,
 			},
 		},
+		{
+			name: "Releases with provenance built from another repository",
+			releases: []clients.Release{
+				{
+					TagName:         "v1.0.0",
+					URL:             "http://foo.com/v1.0.0",
+					TargetCommitish: "master",
+					Assets: []clients.ReleaseAsset{
+						{
+							Name: "foo.intoto.jsonl",
+							URL:  "http://foo.com/v1.0.0/foo.intoto.jsonl",
+						},
+						{
+							Name: "foo.sig",
+							URL:  "http://foo.com/v1.0.0/foo.sig",
+						},
+					},
+				},
+			},
+			assets: map[string]string{
+				"foo.intoto.jsonl": `{"subject": [{"name": "foo", "digest": {"sha256": "abc"}}],` +
+					`"predicate": {"builder": {"id": "https://github.com/slsa-framework/slsa-github-generator/` +
+					`.github/workflows/generator_generic_slsa3.yml@refs/tags/v1.5.0"},` +
+					`"invocation": {"configSource": {"uri": "git+https://github.com/fork/repo@refs/tags/v1.0.0"}}}}`,
+			},
+			expected: checker.CheckResult{
+				Score: 8,
+			},
+		},
 		{
 			name: "Releases with assests with signed artifacts-asc",
 			releases: []clients.Release{
@@ -389,9 +420,20 @@ func TestSignedRelease(t *testing.T) {
 					return tt.releases, tt.err
 				},
 			).MinTimes(1)
+			mockRepo.EXPECT().GetReleaseAsset(gomock.Any()).DoAndReturn(
+				func(asset *clients.ReleaseAsset) ([]byte, error) {
+					content, ok := tt.assets[asset.Name]
+					if !ok {
+						return nil, fmt.Errorf("%w", clients.ErrUnsupportedFeature)
+					}
+					return []byte(content), nil
+				}).AnyTimes()
+			repo := mockrepo.NewMockRepo(ctrl)
+			repo.EXPECT().URI().Return("github.com/owner/repo").AnyTimes()
 
 			req := checker.CheckRequest{
 				RepoClient: mockRepo,
+				Repo:       repo,
 			}
 			req.Dlogger = &scut.TestDetailLogger{}
 			res := SignedReleases(&req)
@@ -24,13 +24,64 @@ import (
 // CheckVulnerabilities is the registered name for the OSV check.
 const CheckVulnerabilities = "Vulnerabilities"
 
+// vulnerabilitiesMetadata documents the Vulnerabilities check.
+var vulnerabilitiesMetadata = checker.CheckMetadata{
+	Risk:  "High",
+	Short: "Determines if the project has open, known unfixed vulnerabilities.",
+	Description: "Risk: `High`  (known vulnerabilities)\n" +
+		"\n" +
+		"This check determines whether the project has open, unfixed vulnerabilities \n" +
+		"in its own codebase or its dependencies using the [OSV (Open Source " +
+		"Vulnerabilities)](https://osv.dev/) service.\n" +
+		"An open vulnerability is readily exploited by attackers and should be fixed as soon as\n" +
+		"possible.\n" +
+		"\n" +
+		"When the token permits reading them, open\n" +
+		"[Dependabot " +
+		"alerts](https://docs.github.com/en/code-security/dependabot/dependabot-alerts/about-dependabot-alerts)\n" +
+		"are counted as well. Alerts that are aliases of vulnerabilities reported by OSV are\n" +
+		"counted once. The details note when the alerts could not be read.\n" +
+		"\n" +
+		"Advisories withdrawn from OSV, and advisories listing the scanned commit or\n" +
+		"dependency version as their fix, are not counted. Neither are vulnerabilities\n" +
+		"that an [OpenVEX](https://github.com/openvex/spec) document in the repository\n" +
+		"(e.g. `vex.json` or `project.openvex.json`) marks as `not_affected` or `fixed`.\n",
+	Tags:  []string{"supply-chain", "security", "vulnerabilities"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.GetFileContent",
+		"RepoClient.ListCommits",
+		"RepoClient.ListDependencyAlerts",
+		"RepoClient.ListFiles",
+		"RepoClient.LocalPath",
+		"VulnerabilitiesClient.ListUnfixedVulnerabilities",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "no open vulnerabilities are found"},
+		{Score: "-1", Criteria: "for each open vulnerability"},
+	},
+	Remediation: []string{
+		"Fix the vulnerabilities in your own code base. The details of each vulnerability can be " +
+			"found on <https://osv.dev>.",
+		"If the vulnerability is in a dependency, update the dependency to a non-vulnerable " +
+			"version. If no update is available, consider whether to remove the dependency.",
+		"If you believe the vulnerability does not affect your project, the  vulnerability can be " +
+			"ignored.  To ignore, create an `osv-scanner.toml` file next to the dependency manifest " +
+			"(e.g. package-lock.json) and specify the ID to ignore and reason. Details on the " +
+			"structure of `osv-scanner.toml` can be found on  [OSV-Scanner " +
+			"repository](https://github.com/google/osv-scanner#ignore-vulnerabilities-by-id). " +
+			"Alternatively, publish an OpenVEX document stating that the project is `not_affected` by " +
+			"the vulnerability, with a justification.",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
 	supportedRequestTypes := []checker.RequestType{
 		checker.CommitBased,
 		checker.FileBased,
 	}
-	if err := registerCheck(CheckVulnerabilities, Vulnerabilities, supportedRequestTypes); err != nil {
+	if err := registerCheck(CheckVulnerabilities, Vulnerabilities, supportedRequestTypes, vulnerabilitiesMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
@@ -16,6 +16,7 @@ package checks
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -59,6 +60,10 @@ func TestVulnerabilities(t *testing.T) {
 				return "test_path", nil
 			}).AnyTimes()
 
+			mockRepo.EXPECT().ListDependencyAlerts().
+				Return(nil, fmt.Errorf("%w", clients.ErrUnsupportedFeature)).AnyTimes()
+			mockRepo.EXPECT().ListFiles(gomock.Any()).Return(nil, nil).AnyTimes()
+
 			mockVulnClient := mockrepo.NewMockVulnerabilitiesClient(ctrl)
 			mockVulnClient.EXPECT().ListUnfixedVulnerabilities(context.TODO(), gomock.Any(), gomock.Any()).DoAndReturn(
 				func(ctx context.Context, commit string, localPath string) (clients.VulnerabilitiesResponse, error) {
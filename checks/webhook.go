@@ -15,8 +15,6 @@
 package checks
 
 import (
-	"os"
-
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks/evaluation"
 	"github.com/ossf/scorecard/v4/checks/raw"
@@ -28,9 +26,37 @@ const (
 	CheckWebHooks = "Webhooks"
 )
 
+// webhooksMetadata documents the Webhooks check.
+var webhooksMetadata = checker.CheckMetadata{
+	Risk:  "High",
+	Short: "This check validate if the webhook defined in the repository have a token configured.",
+	Description: "Risk: `Critical` (service possibly accessible to third parties)\n" +
+		"\n" +
+		"This check determines whether the webhook defined in the repository has a token " +
+		"configured to authenticate the origins of requests.\n",
+	Tags:  []string{"security", "infrastructure"},
+	Repos: []string{"GitHub"},
+	Inputs: []string{
+		"RepoClient.ListWebhooks",
+	},
+	Scoring: []checker.ScoringRule{
+		{Score: "10", Criteria: "no webhook is defined, or all webhooks have a secret"},
+		{Score: "0-10", Criteria: "proportional to the webhooks which have a secret"},
+	},
+	Remediation: []string{
+		"Check whether your service supports token authentication.",
+		"If there is support for token authentication, set the secret in the webhook " +
+			"configuration. See [Setting up a " +
+			"webhook](https://docs.github.com/en/developers/webhooks-and-events/webhooks/creating-webhooks#setting-up-a-webhook)",
+		"If there is no support for token authentication, consider implementing it by following " +
+			"[these " +
+			"directions](https://docs.github.com/en/developers/webhooks-and-events/webhooks/securing-your-webhooks).",
+	},
+}
+
 //nolint:gochecknoinits
 func init() {
-	if err := registerCheck(CheckWebHooks, WebHooks, nil); err != nil {
+	if err := registerCheck(CheckWebHooks, WebHooks, nil, webhooksMetadata); err != nil {
 		// this should never happen
 		panic(err)
 	}
@@ -39,8 +65,7 @@ func init() {
 // WebHooks run Webhooks check.
 func WebHooks(c *checker.CheckRequest) checker.CheckResult {
 	// TODO: remove this check when v6 is released
-	_, enabled := os.LookupEnv("SCORECARD_EXPERIMENTAL")
-	if !enabled {
+	if !checker.IsExperimentalEnabled() {
 		c.Dlogger.Warn(&checker.LogMessage{
 			Text: "SCORECARD_EXPERIMENTAL is not set, not running the Webhook check",
 		})
@@ -0,0 +1,32 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrBudgetExceeded is returned by clients which stopped making requests
+// because the budget of the scan was spent.
+var ErrBudgetExceeded = errors.New("request budget exceeded")
+
+// ActionTagsClient lists the tags of the repos of GitHub Actions, to verify
+// the commits workflows pin actions to.
+type ActionTagsClient interface {
+	// ListActionTags returns the names of the tags of repo, e.g.
+	// actions/checkout, by the SHA of the commit they point at.
+	ListActionTags(ctx context.Context, repo string) (map[string][]string, error)
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"io"
+
+	"github.com/ossf/scorecard/clients/api"
+)
+
+// The interfaces of the clients and the types they return are defined by the
+// github.com/ossf/scorecard/clients/api module, which client implementations
+// and consumers of the results can import without the dependencies of
+// scorecard. They are aliased here for the code of scorecard.
+
+type (
+	AllowedActions          = api.AllowedActions
+	ForkPRApproval          = api.ForkPRApproval
+	ActionsPolicy           = api.ActionsPolicy
+	AuditLogEvent           = api.AuditLogEvent
+	ForcePush               = api.ForcePush
+	BranchInfo              = api.BranchInfo
+	BranchRef               = api.BranchRef
+	BranchProtectionRule    = api.BranchProtectionRule
+	StatusChecksRule        = api.StatusChecksRule
+	PullRequestReviewRule   = api.PullRequestReviewRule
+	CheckRun                = api.CheckRun
+	CheckRunApp             = api.CheckRunApp
+	BadgeLevel              = api.BadgeLevel
+	CIIBestPracticesClient  = api.CIIBestPracticesClient
+	Commit                  = api.Commit
+	CoAuthor                = api.CoAuthor
+	SignatureType           = api.SignatureType
+	CommitSignature         = api.CommitSignature
+	CIStatus                = api.CIStatus
+	Environment             = api.Environment
+	Issue                   = api.Issue
+	IssueComment            = api.IssueComment
+	LanguageName            = api.LanguageName
+	Language                = api.Language
+	License                 = api.License
+	PullRequest             = api.PullRequest
+	Label                   = api.Label
+	Review                  = api.Review
+	Release                 = api.Release
+	ReleaseAsset            = api.ReleaseAsset
+	Repo                    = api.Repo
+	RepoClient              = api.RepoClient
+	SearchRequest           = api.SearchRequest
+	SearchResponse          = api.SearchResponse
+	SearchResult            = api.SearchResult
+	SearchCommitsOptions    = api.SearchCommitsOptions
+	SecurityAlert           = api.SecurityAlert
+	Status                  = api.Status
+	User                    = api.User
+	RepoAssociation         = api.RepoAssociation
+	VulnerabilitiesClient   = api.VulnerabilitiesClient
+	VulnerabilitiesResponse = api.VulnerabilitiesResponse
+	Vulnerability           = api.Vulnerability
+	Webhook                 = api.Webhook
+	WorkflowRun             = api.WorkflowRun
+)
+
+const (
+	AllowedActionsAll                   = api.AllowedActionsAll
+	AllowedActionsLocalOnly             = api.AllowedActionsLocalOnly
+	AllowedActionsSelected              = api.AllowedActionsSelected
+	ForkPRApprovalUnknown               = api.ForkPRApprovalUnknown
+	ForkPRApprovalNewToGitHub           = api.ForkPRApprovalNewToGitHub
+	ForkPRApprovalFirstTime             = api.ForkPRApprovalFirstTime
+	ForkPRApprovalAll                   = api.ForkPRApprovalAll
+	AuditLogBranchProtectionDestroy     = api.AuditLogBranchProtectionDestroy
+	AuditLogRulesetDestroy              = api.AuditLogRulesetDestroy
+	AuditLogBranchProtectionOverride    = api.AuditLogBranchProtectionOverride
+	AuditLogSecretScanningAlertResolve  = api.AuditLogSecretScanningAlertResolve
+	Unknown                             = api.Unknown
+	NotFound                            = api.NotFound
+	InProgress                          = api.InProgress
+	Passing                             = api.Passing
+	Silver                              = api.Silver
+	Gold                                = api.Gold
+	SignatureTypeNone                   = api.SignatureTypeNone
+	SignatureTypeGPG                    = api.SignatureTypeGPG
+	SignatureTypeSSH                    = api.SignatureTypeSSH
+	SignatureTypeGitsign                = api.SignatureTypeGitsign
+	SignatureTypeX509                   = api.SignatureTypeX509
+	SignatureTypeWebFlow                = api.SignatureTypeWebFlow
+	CIStatusUnknown                     = api.CIStatusUnknown
+	CIStatusSuccess                     = api.CIStatusSuccess
+	CIStatusFailure                     = api.CIStatusFailure
+	CIStatusPending                     = api.CIStatusPending
+	Go                                  = api.Go
+	Python                              = api.Python
+	JavaScript                          = api.JavaScript
+	Cpp                                 = api.Cpp
+	C                                   = api.C
+	TypeScript                          = api.TypeScript
+	Java                                = api.Java
+	CSharp                              = api.CSharp
+	Ruby                                = api.Ruby
+	PHP                                 = api.PHP
+	StarLark                            = api.StarLark
+	Scala                               = api.Scala
+	Kotlin                              = api.Kotlin
+	Swift                               = api.Swift
+	Rust                                = api.Rust
+	CMake                               = api.CMake
+	Dockerfile                          = api.Dockerfile
+	Other                               = api.Other
+	TagVerificationValid                = api.TagVerificationValid
+	MaxReleaseAssetSize                 = api.MaxReleaseAssetSize
+	HeadSHA                             = api.HeadSHA
+	DefaultCommitDepth                  = api.DefaultCommitDepth
+	MaxListedBranches                   = api.MaxListedBranches
+	RepoAssociationMannequin            = api.RepoAssociationMannequin
+	RepoAssociationNone                 = api.RepoAssociationNone
+	RepoAssociationFirstTimer           = api.RepoAssociationFirstTimer
+	RepoAssociationFirstTimeContributor = api.RepoAssociationFirstTimeContributor
+	RepoAssociationContributor          = api.RepoAssociationContributor
+	RepoAssociationCollaborator         = api.RepoAssociationCollaborator
+	RepoAssociationMember               = api.RepoAssociationMember
+	RepoAssociationMaintainer           = api.RepoAssociationMaintainer
+	RepoAssociationOwner                = api.RepoAssociationOwner
+)
+
+var (
+	ErrAssetTooLarge       = api.ErrAssetTooLarge
+	ErrAssetDigestMismatch = api.ErrAssetDigestMismatch
+	ErrUnsupportedFeature  = api.ErrUnsupportedFeature
+	ErrPermissionDenied    = api.ErrPermissionDenied
+	ErrSSORequired         = api.ErrSSORequired
+)
+
+// CoAuthorsFrom returns the co-authors credited by the Co-authored-by
+// trailers of a commit message, see api.CoAuthorsFrom.
+func CoAuthorsFrom(message string) []CoAuthor {
+	return api.CoAuthorsFrom(message)
+}
+
+// SignatureTypeFromArmor returns the type of an armored signature, see
+// api.SignatureTypeFromArmor.
+func SignatureTypeFromArmor(signature string) SignatureType {
+	return api.SignatureTypeFromArmor(signature)
+}
+
+// ReadReleaseAsset reads the content of asset from r, see api.ReadReleaseAsset.
+func ReadReleaseAsset(asset *ReleaseAsset, r io.Reader) ([]byte, error) {
+	//nolint:wrapcheck
+	return api.ReadReleaseAsset(asset, r)
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+// AllowedActions is the setting restricting which actions can run in a repo.
+type AllowedActions string
+
+const (
+	// AllowedActionsAll allows any action to run.
+	AllowedActionsAll AllowedActions = "all"
+	// AllowedActionsLocalOnly only allows actions defined in the repo or its organization.
+	AllowedActionsLocalOnly AllowedActions = "local_only"
+	// AllowedActionsSelected only allows actions matching the selected actions settings.
+	AllowedActionsSelected AllowedActions = "selected"
+)
+
+// ForkPRApproval is the setting for which outside contributors need approval
+// before workflows run on their pull requests.
+type ForkPRApproval string
+
+const (
+	// ForkPRApprovalUnknown indicates the setting could not be retrieved.
+	ForkPRApprovalUnknown ForkPRApproval = ""
+	// ForkPRApprovalNewToGitHub requires approval for first-time contributors who are new to GitHub.
+	ForkPRApprovalNewToGitHub ForkPRApproval = "first_time_contributors_new_to_github"
+	// ForkPRApprovalFirstTime requires approval for all first-time contributors.
+	ForkPRApprovalFirstTime ForkPRApproval = "first_time_contributors"
+	// ForkPRApprovalAll requires approval for all outside collaborators.
+	ForkPRApprovalAll ForkPRApproval = "all_external_contributors"
+)
+
+// ActionsPolicy represents the CI settings of a repo which restrict which
+// actions can run and who can trigger workflow runs.
+type ActionsPolicy struct {
+	AllowedActions AllowedActions
+	// Only meaningful when AllowedActions is AllowedActionsSelected.
+	PatternsAllowed    []string
+	GithubOwnedAllowed bool
+	VerifiedAllowed    bool
+	ForkPRApproval     ForkPRApproval
+	Enabled            bool
+}
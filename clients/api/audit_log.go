@@ -0,0 +1,89 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// Audit log actions used by the checks. See
+// https://docs.github.com/en/organizations/keeping-your-organization-secure/managing-security-settings-for-your-organization/audit-log-events-for-your-organization
+const (
+	// AuditLogBranchProtectionDestroy is logged when a branch protection rule is deleted.
+	AuditLogBranchProtectionDestroy = "protected_branch.destroy"
+	// AuditLogRulesetDestroy is logged when a ruleset is deleted.
+	AuditLogRulesetDestroy = "repository_ruleset.destroy"
+	// AuditLogBranchProtectionOverride is logged when an admin pushes to a
+	// protected branch bypassing its rule, e.g. a force push.
+	AuditLogBranchProtectionOverride = "protected_branch.policy_override"
+	// AuditLogSecretScanningAlertResolve is logged when a secret scanning alert is closed.
+	AuditLogSecretScanningAlertResolve = "secret_scanning_alert.resolve"
+
+	auditLogBranchProtectionCategory = "protected_branch."
+	auditLogRulesetCategory          = "repository_ruleset."
+	auditLogSecretRevoked            = "revoked"
+)
+
+// AuditLogEvent is an event of the audit log of the organization owning a repo.
+type AuditLogEvent struct {
+	CreatedAt time.Time
+	// Action is the name of the event, e.g. protected_branch.destroy.
+	Action string
+	Actor  string
+	// Branch is the branch name or pattern of branch protection events.
+	Branch string
+	// Resolution is the resolution of secret scanning alerts, e.g. revoked
+	// or false_positive, if known.
+	Resolution string
+}
+
+// IsBranchProtectionChange returns true for events changing the branch
+// protection rules or rulesets of the repo.
+func (e *AuditLogEvent) IsBranchProtectionChange() bool {
+	if e.Action == AuditLogBranchProtectionOverride {
+		return false
+	}
+	return strings.HasPrefix(e.Action, auditLogBranchProtectionCategory) ||
+		strings.HasPrefix(e.Action, auditLogRulesetCategory)
+}
+
+// IsBranchProtectionBypass returns true for events removing or bypassing
+// the protection of branches.
+func (e *AuditLogEvent) IsBranchProtectionBypass() bool {
+	switch e.Action {
+	case AuditLogBranchProtectionDestroy, AuditLogRulesetDestroy, AuditLogBranchProtectionOverride:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSecretScanningDismissal returns true for secret scanning alerts closed
+// without revoking the secret, e.g. as false positives.
+func (e *AuditLogEvent) IsSecretScanningDismissal() bool {
+	return e.Action == AuditLogSecretScanningAlertResolve && e.Resolution != auditLogSecretRevoked
+}
+
+// AppliesToBranch returns true if the event has no branch, e.g. for
+// rulesets, or if its branch name or pattern matches branch.
+func (e *AuditLogEvent) AppliesToBranch(branch string) bool {
+	if e.Branch == "" || e.Branch == branch {
+		return true
+	}
+	matched, err := path.Match(e.Branch, branch)
+	return err == nil && matched
+}
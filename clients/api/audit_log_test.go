@@ -0,0 +1,91 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import "testing"
+
+func TestAuditLogEvent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		event     AuditLogEvent
+		branch    string
+		applies   bool
+		change    bool
+		bypass    bool
+		dismissal bool
+	}{
+		{
+			name:    "rule of the branch deleted",
+			event:   AuditLogEvent{Action: AuditLogBranchProtectionDestroy, Branch: "main"},
+			branch:  "main",
+			applies: true,
+			change:  true,
+			bypass:  true,
+		},
+		{
+			name:    "rule of a matching pattern updated",
+			event:   AuditLogEvent{Action: "protected_branch.update", Branch: "release/*"},
+			branch:  "release/1.0",
+			applies: true,
+			change:  true,
+		},
+		{
+			name:   "rule of another branch overridden",
+			event:  AuditLogEvent{Action: AuditLogBranchProtectionOverride, Branch: "dev"},
+			branch: "main",
+			bypass: true,
+		},
+		{
+			name:    "ruleset deleted",
+			event:   AuditLogEvent{Action: AuditLogRulesetDestroy},
+			branch:  "main",
+			applies: true,
+			change:  true,
+			bypass:  true,
+		},
+		{
+			name:      "secret scanning alert dismissed",
+			event:     AuditLogEvent{Action: AuditLogSecretScanningAlertResolve, Resolution: "false_positive"},
+			branch:    "main",
+			applies:   true,
+			dismissal: true,
+		},
+		{
+			name:    "secret revoked",
+			event:   AuditLogEvent{Action: AuditLogSecretScanningAlertResolve, Resolution: "revoked"},
+			branch:  "main",
+			applies: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.event.AppliesToBranch(tt.branch); got != tt.applies {
+				t.Errorf("AppliesToBranch() = %v, want %v", got, tt.applies)
+			}
+			if got := tt.event.IsBranchProtectionChange(); got != tt.change {
+				t.Errorf("IsBranchProtectionChange() = %v, want %v", got, tt.change)
+			}
+			if got := tt.event.IsBranchProtectionBypass(); got != tt.bypass {
+				t.Errorf("IsBranchProtectionBypass() = %v, want %v", got, tt.bypass)
+			}
+			if got := tt.event.IsSecretScanningDismissal(); got != tt.dismissal {
+				t.Errorf("IsSecretScanningDismissal() = %v, want %v", got, tt.dismissal)
+			}
+		})
+	}
+}
@@ -12,7 +12,9 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package clients
+package api
+
+import "time"
 
 // BranchRef represents a single branch reference and its protection rules.
 type BranchRef struct {
@@ -29,6 +31,7 @@ type BranchProtectionRule struct {
 	RequireLinearHistory       *bool
 	EnforceAdmins              *bool
 	RequireLastPushApproval    *bool
+	RequireMergeQueue          *bool
 	CheckRules                 StatusChecksRule
 }
 
@@ -45,3 +48,24 @@ type PullRequestReviewRule struct {
 	DismissStaleReviews          *bool
 	RequireCodeOwnerReviews      *bool
 }
+
+// BranchInfo describes a branch of the repo relative to the default branch.
+type BranchInfo struct {
+	Name string
+	// LastCommit is when the head commit of the branch was committed.
+	LastCommit time.Time
+	// BehindBy is the number of commits of the default branch which are not
+	// on the branch.
+	BehindBy int
+}
+
+// ForcePush is a push to a branch of the repo which rewrote its history.
+type ForcePush struct {
+	CreatedAt time.Time
+	Actor     string
+	Branch    string
+	// Before and After are the SHAs the branch pointed to before and after
+	// the push.
+	Before string
+	After  string
+}
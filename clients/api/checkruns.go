@@ -12,14 +12,18 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package clients
+package api
+
+import "time"
 
 // CheckRun is a single instance of a VCS CheckRun.
 type CheckRun struct {
 	Status     string
 	Conclusion string
 	URL        string
-	App        CheckRunApp
+	// CompletedAt is the time the check run completed, if known.
+	CompletedAt time.Time
+	App         CheckRunApp
 }
 
 // CheckRunApp is the app running the Check.
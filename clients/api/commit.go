@@ -0,0 +1,125 @@
+// Copyright 2021 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Commit represents a Git commit.
+type Commit struct {
+	CommittedDate          time.Time
+	Message                string
+	SHA                    string
+	AssociatedMergeRequest PullRequest
+	Committer              User
+	// Author is the user the commit is attributed to. Its Email is only set
+	// when the forge linked the author address to the user's account.
+	Author User
+	// CoAuthors are credited by the Co-authored-by trailers of the message.
+	CoAuthors []CoAuthor
+	Signature CommitSignature
+	// CIStatus is the combined status of the CI checks which ran on the
+	// commit, if the forge reports it.
+	CIStatus CIStatus
+}
+
+// CoAuthor is a co-author of a commit. Unlike the author, it is not linked
+// to a user of the forge.
+type CoAuthor struct {
+	Name  string
+	Email string
+}
+
+// SignatureType is the type of the signature of a commit.
+type SignatureType string
+
+const (
+	// SignatureTypeNone is the type of unsigned commits, or of commits
+	// whose signature type is not known.
+	SignatureTypeNone SignatureType = ""
+	// SignatureTypeGPG is an OpenPGP signature.
+	SignatureTypeGPG SignatureType = "gpg"
+	// SignatureTypeSSH is a signature made with an SSH key.
+	SignatureTypeSSH SignatureType = "ssh"
+	// SignatureTypeGitsign is a keyless signature made with Sigstore's gitsign.
+	SignatureTypeGitsign SignatureType = "gitsign"
+	// SignatureTypeX509 is an S/MIME signature made with another certificate.
+	SignatureTypeX509 SignatureType = "x509"
+	// SignatureTypeWebFlow is a signature made by the forge, e.g. for
+	// commits created through the GitHub web interface.
+	SignatureTypeWebFlow SignatureType = "web-flow"
+)
+
+// CommitSignature describes the signature of a commit.
+type CommitSignature struct {
+	Type SignatureType
+	// Valid is true if the forge verified the signature.
+	Valid bool
+}
+
+// CIStatus is the combined status of the CI checks of a commit.
+type CIStatus string
+
+const (
+	// CIStatusUnknown is used when no CI status was reported.
+	CIStatusUnknown CIStatus = ""
+	// CIStatusSuccess is used when all checks passed.
+	CIStatusSuccess CIStatus = "success"
+	// CIStatusFailure is used when any check failed.
+	CIStatusFailure CIStatus = "failure"
+	// CIStatusPending is used when checks are still running.
+	CIStatusPending CIStatus = "pending"
+)
+
+// coAuthorTrailer is the trailer crediting co-authors, matched case-insensitively.
+const coAuthorTrailer = "co-authored-by:"
+
+// CoAuthorsFrom returns the co-authors credited by the Co-authored-by
+// trailers of a commit message.
+func CoAuthorsFrom(message string) []CoAuthor {
+	var coAuthors []CoAuthor
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) < len(coAuthorTrailer) || !strings.EqualFold(line[:len(coAuthorTrailer)], coAuthorTrailer) {
+			continue
+		}
+		address, err := mail.ParseAddress(strings.TrimSpace(line[len(coAuthorTrailer):]))
+		if err != nil {
+			continue
+		}
+		coAuthors = append(coAuthors, CoAuthor{Name: address.Name, Email: address.Address})
+	}
+	return coAuthors
+}
+
+// SignatureTypeFromArmor returns the type of an armored signature, as stored
+// in the gpgsig header of a commit object. Certificates are not parsed, so
+// keyless gitsign signatures are reported as SignatureTypeX509.
+func SignatureTypeFromArmor(signature string) SignatureType {
+	switch {
+	case strings.HasPrefix(signature, "-----BEGIN PGP SIGNATURE-----"):
+		return SignatureTypeGPG
+	case strings.HasPrefix(signature, "-----BEGIN SSH SIGNATURE-----"):
+		return SignatureTypeSSH
+	case strings.HasPrefix(signature, "-----BEGIN SIGNED MESSAGE-----"),
+		strings.HasPrefix(signature, "-----BEGIN PKCS7-----"):
+		return SignatureTypeX509
+	default:
+		return SignatureTypeNone
+	}
+}
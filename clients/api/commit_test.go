@@ -0,0 +1,48 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoAuthorsFrom(t *testing.T) {
+	t.Parallel()
+	message := "Fix the parser\n\nCo-authored-by: Jane Doe <jane@example.com>\n" +
+		"co-authored-by: bot@example.com\nCo-authored-by: not an address\nSigned-off-by: John <john@example.com>\n"
+	want := []CoAuthor{
+		{Name: "Jane Doe", Email: "jane@example.com"},
+		{Email: "bot@example.com"},
+	}
+	if got := CoAuthorsFrom(message); !reflect.DeepEqual(got, want) {
+		t.Errorf("CoAuthorsFrom() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSignatureTypeFromArmor(t *testing.T) {
+	t.Parallel()
+	tests := map[string]SignatureType{
+		"-----BEGIN PGP SIGNATURE-----\n\niQIz\n-----END PGP SIGNATURE-----\n": SignatureTypeGPG,
+		"-----BEGIN SSH SIGNATURE-----\nU1NI\n-----END SSH SIGNATURE-----\n":   SignatureTypeSSH,
+		"-----BEGIN SIGNED MESSAGE-----\nMIIE\n-----END SIGNED MESSAGE-----\n": SignatureTypeX509,
+		"": SignatureTypeNone,
+	}
+	for signature, want := range tests {
+		if got := SignatureTypeFromArmor(signature); got != want {
+			t.Errorf("SignatureTypeFromArmor(%q) = %q, want %q", signature, got, want)
+		}
+	}
+}
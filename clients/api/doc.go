@@ -0,0 +1,28 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package api defines the interfaces of the clients scorecard reads repos
+// with, e.g. RepoClient, and the types they return. It is versioned
+// separately from scorecard and only depends on the standard library, so
+// that third-party implementations of the clients, and consumers of the
+// results, don't import the dependencies of scorecard.
+//
+// Scorecard aliases the interfaces and types in its clients package, so the
+// clients implementing api.RepoClient can be passed to scorecard as is.
+// The module is tagged clients/api/vX.Y.Z, and scorecard requires a tagged
+// version of it: the replace directive of scorecard's go.mod only applies to
+// builds of scorecard itself, not to the modules depending on it. While it is
+// v0, new methods of the interfaces, which break their implementations, are
+// released as new minor versions.
+package api
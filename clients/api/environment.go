@@ -0,0 +1,29 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package api
+
+// Environment represents a deployment environment of the repo and its protection rules.
+type Environment struct {
+	Name string
+	// RequiredReviewers is the number of users or teams that can approve deployments.
+	RequiredReviewers int
+	// WaitTimer is the number of minutes deployments are delayed by.
+	WaitTimer int
+	// ProtectedBranches is true if only protected branches can deploy.
+	ProtectedBranches bool
+	// CustomBranchPolicies is true if only branches matching name patterns can deploy.
+	CustomBranchPolicies bool
+	// Secrets is the number of secrets of the environment, nil if they could not be listed.
+	Secrets *int
+}
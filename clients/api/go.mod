@@ -0,0 +1,3 @@
+module github.com/ossf/scorecard/clients/api
+
+go 1.19
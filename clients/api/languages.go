@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package clients
+package api
 
 // LanguageName is the name of a language, a customized type of string.
 type LanguageName string
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package clients
+package api
 
 // License represents a customized struct for licenses used by clients.
 // from pkg.go.dev/github.com/google/go-github/github#RepositoryLicense.
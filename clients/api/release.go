@@ -0,0 +1,93 @@
+// Copyright 2021 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Release represents a release version of a package/repo.
+type Release struct {
+	TagName         string
+	URL             string
+	TargetCommitish string
+	// Author is the user who published the release.
+	Author User
+	// TagVerification is the state of the signature of the release tag as
+	// reported by the forge, e.g. "valid" or "unsigned". Empty if unknown.
+	TagVerification string
+	Assets          []ReleaseAsset
+}
+
+// TagVerificationValid is the TagVerification of a tag with a verified signature.
+const TagVerificationValid = "valid"
+
+// ReleaseAsset is part of the Release bundle.
+type ReleaseAsset struct {
+	Name string
+	URL  string
+	// DownloadURL is the URL of the asset content, e.g. to fetch a signature
+	// or provenance file.
+	DownloadURL string
+	// Digest is the digest of the asset content reported by the forge, in
+	// the form "sha256:<hex>". Empty if not reported.
+	Digest string
+	// Size is the size of the asset in bytes, or 0 if unknown.
+	Size int64
+}
+
+// MaxReleaseAssetSize is the size of the largest release asset returned by
+// RepoClient.GetReleaseAsset.
+const MaxReleaseAssetSize = 16 << 20
+
+const sha256Prefix = "sha256:"
+
+var (
+	// ErrAssetTooLarge indicates a release asset larger than MaxReleaseAssetSize.
+	ErrAssetTooLarge = errors.New("release asset too large")
+	// ErrAssetDigestMismatch indicates a release asset whose content does not
+	// match the digest reported by the forge.
+	ErrAssetDigestMismatch = errors.New("release asset digest mismatch")
+)
+
+// ReadReleaseAsset reads the content of asset from r for implementations of
+// RepoClient.GetReleaseAsset. It fails with ErrAssetTooLarge if the content is
+// larger than MaxReleaseAssetSize and with ErrAssetDigestMismatch if it does
+// not match the sha256 digest of the asset, if any.
+func ReadReleaseAsset(asset *ReleaseAsset, r io.Reader) ([]byte, error) {
+	if asset.Size > MaxReleaseAssetSize {
+		return nil, fmt.Errorf("%w: %s is %d bytes", ErrAssetTooLarge, asset.Name, asset.Size)
+	}
+	content, err := io.ReadAll(io.LimitReader(r, MaxReleaseAssetSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
+	}
+	if len(content) > MaxReleaseAssetSize {
+		return nil, fmt.Errorf("%w: %s", ErrAssetTooLarge, asset.Name)
+	}
+	if strings.HasPrefix(asset.Digest, sha256Prefix) {
+		sum := sha256.Sum256(content)
+		want := strings.TrimPrefix(asset.Digest, sha256Prefix)
+		if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, want) {
+			return nil, fmt.Errorf("%w: %s has digest sha256:%s", ErrAssetDigestMismatch, asset.Name, got)
+		}
+	}
+	return content, nil
+}
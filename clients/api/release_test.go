@@ -0,0 +1,76 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestReadReleaseAsset(t *testing.T) {
+	t.Parallel()
+	content := []byte("release artifact")
+	sum := sha256.Sum256(content)
+	tests := []struct {
+		err     error
+		name    string
+		asset   ReleaseAsset
+		content []byte
+	}{
+		{
+			name:    "no digest",
+			asset:   ReleaseAsset{Name: "tool"},
+			content: content,
+		},
+		{
+			name:    "matching digest",
+			asset:   ReleaseAsset{Name: "tool", Digest: "sha256:" + hex.EncodeToString(sum[:])},
+			content: content,
+		},
+		{
+			name:    "digest mismatch",
+			asset:   ReleaseAsset{Name: "tool", Digest: "sha256:" + hex.EncodeToString(make([]byte, sha256.Size))},
+			content: content,
+			err:     ErrAssetDigestMismatch,
+		},
+		{
+			name:  "reported size too large",
+			asset: ReleaseAsset{Name: "tool", Size: MaxReleaseAssetSize + 1},
+			err:   ErrAssetTooLarge,
+		},
+		{
+			name:    "content too large",
+			asset:   ReleaseAsset{Name: "tool"},
+			content: make([]byte, MaxReleaseAssetSize+1),
+			err:     ErrAssetTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ReadReleaseAsset(&tt.asset, bytes.NewReader(tt.content))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ReadReleaseAsset() error = %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && !bytes.Equal(got, tt.content) {
+				t.Errorf("ReadReleaseAsset() = %q, want %q", got, tt.content)
+			}
+		})
+	}
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package clients
+package api
 
 // Repo interface uniquely identifies a repo.
 type Repo interface {
@@ -0,0 +1,101 @@
+// Copyright 2021 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clients defines the interface for RepoClient and related structs.
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrUnsupportedFeature indicates an API that is not supported by the client.
+	ErrUnsupportedFeature = errors.New("unsupported feature")
+	// ErrPermissionDenied indicates the credentials used by the client are not
+	// allowed to access an API.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrSSORequired indicates the credentials used by the client are not authorized
+	// for the SAML single sign-on of the organization owning the repo. It wraps
+	// ErrPermissionDenied, so APIs failing with it are treated as inaccessible.
+	ErrSSORequired = fmt.Errorf("%w: token not authorized for the organization's SAML single sign-on",
+		ErrPermissionDenied)
+)
+
+// HeadSHA is default commitSHA value used to denote git HEAD.
+const HeadSHA = "HEAD"
+
+// MaxListedBranches is the most branches returned by ListBranches.
+const MaxListedBranches = 100
+
+// DefaultCommitDepth is the number of commits analyzed when InitRepo is
+// called with a commitDepth <= 0.
+const DefaultCommitDepth = 30
+
+// RepoClient interface is used by Scorecard checks to access a repo.
+type RepoClient interface {
+	InitRepo(repo Repo, commitSHA string, commitDepth int) error
+	URI() string
+	IsArchived() (bool, error)
+	IsPrivate() (bool, error)
+	// Returns the repo this repo was forked from,
+	// or nil if it is not a fork.
+	GetForkParent() (Repo, error)
+	ListFiles(predicate func(string) (bool, error)) ([]string, error)
+	// Returns an absolute path to the local repository
+	// in the format that matches the local OS
+	LocalPath() (string, error)
+	GetFileContent(filename string) ([]byte, error)
+	GetBranch(branch string) (*BranchRef, error)
+	// Returns the branches in the order of the names,
+	// nil for the ones which do not exist.
+	GetBranches(branches []string) ([]*BranchRef, error)
+	// ListBranches returns the branches of the repo, at most
+	// MaxListedBranches, compared with the default branch.
+	ListBranches() ([]BranchInfo, error)
+	GetCreatedAt() (time.Time, error)
+	GetDefaultBranchName() (string, error)
+	GetDefaultBranch() (*BranchRef, error)
+	ListCommits() ([]Commit, error)
+	ListIssues() ([]Issue, error)
+	ListLicenses() ([]License, error)
+	ListReleases() ([]Release, error)
+	// GetReleaseAsset returns the content of an asset of a release returned by
+	// ListReleases, see ReadReleaseAsset for the limits applied.
+	GetReleaseAsset(asset *ReleaseAsset) ([]byte, error)
+	ListContributors() ([]User, error)
+	ListSuccessfulWorkflowRuns(filename string) ([]WorkflowRun, error)
+	ListCheckRunsForRef(ref string) ([]CheckRun, error)
+	ListStatuses(ref string) ([]Status, error)
+	ListWebhooks() ([]Webhook, error)
+	GetActionsPolicy() (*ActionsPolicy, error)
+	// GetSecurityPolicyURL returns the URL of the security policy the
+	// platform detected for the repo, including policies inherited from its
+	// organization, or "" if there is none.
+	GetSecurityPolicyURL() (string, error)
+	ListDependencyAlerts() ([]SecurityAlert, error)
+	ListCodeScanningAlerts() ([]SecurityAlert, error)
+	ListEnvironments() ([]Environment, error)
+	// ListAuditLogEvents returns the recent events of the audit log of the
+	// organization owning the repo which concern the repo.
+	ListAuditLogEvents() ([]AuditLogEvent, error)
+	// ListForcePushes returns the recent force pushes to the branches of the
+	// repo, most recent first.
+	ListForcePushes() ([]ForcePush, error)
+	ListProgrammingLanguages() ([]Language, error)
+	Search(request SearchRequest) (SearchResponse, error)
+	SearchCommits(request SearchCommitsOptions) ([]Commit, error)
+	Close() error
+}
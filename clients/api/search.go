@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package clients
+package api
 
 // SearchRequest queries a repo for `Query`.
 // If `Filename` is provided, only matching filenames are queried.
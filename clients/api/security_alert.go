@@ -0,0 +1,30 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package api
+
+// SecurityAlert represents an open alert raised by the code host, e.g., a
+// Dependabot or code scanning alert on GitHub.
+type SecurityAlert struct {
+	// ID is the advisory ID (e.g., GHSA-xxxx) for dependency alerts,
+	// and the rule ID for code scanning alerts.
+	ID string
+	// Severity is one of "critical", "high", "medium" or "low",
+	// or the raw severity reported by the code host if it has no security severity.
+	Severity string
+	Summary  string
+	// Path is the manifest or source file the alert was raised on.
+	Path    string
+	URL     string
+	Aliases []string
+}
@@ -12,12 +12,17 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package clients
+package api
 
 // User represents a Git user.
 type User struct {
-	Login            string
-	Companies        []string
+	Login string
+	// Email is the verified address a commit was authored with, if known.
+	Email     string
+	Companies []string
+	// EmailDomains are the corporate domains of the verified addresses the
+	// user authored commits with.
+	EmailDomains     []string
 	Organizations    []User
 	NumContributions int
 	ID               int64
@@ -12,26 +12,28 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package cmd
+package api
 
 import (
-	"fmt"
-	"net/http"
-	"time"
+	"context"
 )
 
-type packageManagerClient interface {
-	Get(URI string, packagename string) (*http.Response, error)
+// VulnerabilitiesClient checks for vulnerabilities in vuln DB.
+type VulnerabilitiesClient interface {
+	ListUnfixedVulnerabilities(
+		context context.Context,
+		commit string,
+		localDir string,
+	) (VulnerabilitiesResponse, error)
 }
 
-type packageManager struct{}
+// VulnerabilitiesResponse is the response from the vuln DB.
+type VulnerabilitiesResponse struct {
+	Vulnerabilities []Vulnerability
+}
 
-// nolint: noctx
-func (c *packageManager) Get(url, packageName string) (*http.Response, error) {
-	const timeout = 10
-	client := &http.Client{
-		Timeout: timeout * time.Second,
-	}
-	//nolint
-	return client.Get(fmt.Sprintf(url, packageName))
+// Vulnerability uniquely identifies a reported security vuln.
+type Vulnerability struct {
+	ID      string
+	Aliases []string
 }
@@ -12,10 +12,17 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package clients
+package api
+
+import "time"
 
 // WorkflowRun represents VCS WorkflowRun.
 type WorkflowRun struct {
-	HeadSHA *string `json:"head_sha"`
-	URL     string
+	CreatedAt time.Time
+	HeadSHA   *string `json:"head_sha"`
+	URL       string
+	// Ref is the branch or tag the run was triggered for.
+	Ref string
+	// Event is the event that triggered the run, e.g. "push" or "release".
+	Event string
 }
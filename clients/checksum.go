@@ -0,0 +1,67 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrInvalidChecksum is returned when a checksum file cannot be retrieved
+// or is empty.
+var ErrInvalidChecksum = errors.New("invalid checksum file")
+
+// maxChecksumFileSize bounds the size of the checksum files: they only hold
+// a digest, optionally followed by a file name.
+const maxChecksumFileSize = 1024
+
+// checksumClient bounds the time spent downloading a checksum file, on top of
+// the deadline of the context of the request.
+var checksumClient = &http.Client{Timeout: 30 * time.Second}
+
+// FetchChecksum downloads the hex-encoded digest published at url, e.g. the
+// .sha256 file published next to a distribution artifact.
+func FetchChecksum(ctx context.Context, url string) (string, error) {
+	return fetchChecksum(ctx, checksumClient, url)
+}
+
+func fetchChecksum(ctx context.Context, client *http.Client, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("error during http.NewRequestWithContext: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error during http.Do: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s returned %s", ErrInvalidChecksum, url, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxChecksumFileSize))
+	if err != nil {
+		return "", fmt.Errorf("error during io.ReadAll: %w", err)
+	}
+	fields := strings.Fields(string(body))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("%w: %s is empty", ErrInvalidChecksum, url)
+	}
+	return strings.ToLower(fields[0]), nil
+}
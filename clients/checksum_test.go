@@ -0,0 +1,81 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchChecksum(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/digest.sha256":
+			fmt.Fprint(w, "ABCDEF0123\n")
+		case "/named.sha1":
+			fmt.Fprint(w, "abcdef0123  artifact.jar\n")
+		case "/empty.sha1":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		err  error
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "digest only",
+			path: "/digest.sha256",
+			want: "abcdef0123",
+		},
+		{
+			name: "digest followed by the file name",
+			path: "/named.sha1",
+			want: "abcdef0123",
+		},
+		{
+			name: "empty checksum file",
+			path: "/empty.sha1",
+			err:  ErrInvalidChecksum,
+		},
+		{
+			name: "missing checksum file",
+			path: "/missing.sha256",
+			err:  ErrInvalidChecksum,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := fetchChecksum(context.Background(), server.Client(), server.URL+tt.path)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("fetchChecksum() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("fetchChecksum() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
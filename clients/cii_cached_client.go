@@ -0,0 +1,62 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultCIICacheTTL is how long DefaultCIIBestPracticesClient caches the
+// badge level of a repo.
+const DefaultCIICacheTTL = 24 * time.Hour
+
+type ciiCacheEntry struct {
+	fetched time.Time
+	level   BadgeLevel
+}
+
+// cachedClientCIIBestPractices implements the CIIBestPracticesClient interface.
+// The badge levels returned by another client are cached by repo URL until
+// their TTL expires. Errors are not cached.
+type cachedClientCIIBestPractices struct {
+	client  CIIBestPracticesClient
+	now     func() time.Time
+	entries map[string]ciiCacheEntry
+	ttl     time.Duration
+	mu      sync.Mutex
+}
+
+// GetBadgeLevel implements CIIBestPracticesClient.GetBadgeLevel.
+func (client *cachedClientCIIBestPractices) GetBadgeLevel(ctx context.Context, uri string) (BadgeLevel, error) {
+	key := normalizeCIIRepoURL(uri)
+	client.mu.Lock()
+	entry, ok := client.entries[key]
+	client.mu.Unlock()
+	if ok && client.now().Sub(entry.fetched) < client.ttl {
+		return entry.level, nil
+	}
+
+	level, err := client.client.GetBadgeLevel(ctx, uri)
+	if err != nil {
+		//nolint:wrapcheck
+		return level, err
+	}
+	client.mu.Lock()
+	client.entries[key] = ciiCacheEntry{fetched: client.now(), level: level}
+	client.mu.Unlock()
+	return level, nil
+}
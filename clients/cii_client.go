@@ -15,56 +15,63 @@
 package clients
 
 import (
-	"context"
+	"os"
+	"sync"
+	"time"
 )
 
-const (
-	// Unknown or non-parsable CII Best Practices badge.
-	Unknown BadgeLevel = iota
-	// NotFound represents when CII Best Practices returns an empty response for a project.
-	NotFound
-	// InProgress state of CII Best Practices badge.
-	InProgress
-	// Passing level for CII Best Practices badge.
-	Passing
-	// Silver level for CII Best Practices badge.
-	Silver
-	// Gold level for CII Best Practices badge.
-	Gold
+// EnvVarCIISnapshot is the environment variable pointing to an offline
+// snapshot of the CII Best Practices projects. When set,
+// DefaultCIIBestPracticesClient reads badges from it instead of the API.
+const EnvVarCIISnapshot = "SCORECARD_CII_SNAPSHOT"
+
+var (
+	defaultCIIClient   = CachedCIIBestPracticesClient(&httpClientCIIBestPractices{}, DefaultCIICacheTTL)
+	ciiSnapshotClients sync.Map
 )
 
-// BadgeLevel corresponds to CII-Best-Practices badge levels.
-// https://bestpractices.coreinfrastructure.org/en
-type BadgeLevel uint
+// DefaultCIIBestPracticesClient returns http-based implementation of the interface.
+// Badge levels are cached for DefaultCIICacheTTL across all clients of the
+// process. If EnvVarCIISnapshot is set, the snapshot it points to is used instead.
+func DefaultCIIBestPracticesClient() CIIBestPracticesClient {
+	if path := os.Getenv(EnvVarCIISnapshot); path != "" {
+		client, _ := ciiSnapshotClients.LoadOrStore(path, SnapshotCIIBestPracticesClient(path))
+		//nolint:forcetypeassert
+		return client.(CIIBestPracticesClient)
+	}
+	return defaultCIIClient
+}
 
-// String returns a string value for BadgeLevel enum.
-func (badge BadgeLevel) String() string {
-	switch badge {
-	case Unknown:
-		return "Unknown"
-	case NotFound:
-		return "not_found"
-	case InProgress:
-		return "in_progress"
-	case Passing:
-		return "passing"
-	case Silver:
-		return "silver"
-	case Gold:
-		return "gold"
-	default:
-		return ""
+// CachedCIIBestPracticesClient returns an implementation of the interface
+// caching the badge levels returned by client for ttl.
+func CachedCIIBestPracticesClient(client CIIBestPracticesClient, ttl time.Duration) CIIBestPracticesClient {
+	return &cachedClientCIIBestPractices{
+		client:  client,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]ciiCacheEntry),
 	}
 }
 
-// CIIBestPracticesClient interface returns the BadgeLevel for a repo URL.
-type CIIBestPracticesClient interface {
-	GetBadgeLevel(ctx context.Context, uri string) (BadgeLevel, error)
+// BulkCIIBestPracticesClient returns an implementation of the interface which
+// downloads all projects from the API at once and refreshes them after ttl,
+// for scans of many repos.
+func BulkCIIBestPracticesClient(ttl time.Duration) CIIBestPracticesClient {
+	return &bulkClientCIIBestPractices{
+		baseURL: ciiProjectsURL,
+		ttl:     ttl,
+		now:     time.Now,
+	}
 }
 
-// DefaultCIIBestPracticesClient returns http-based implementation of the interface.
-func DefaultCIIBestPracticesClient() CIIBestPracticesClient {
-	return &httpClientCIIBestPractices{}
+// SnapshotCIIBestPracticesClient returns an implementation of the interface
+// reading badge levels from an offline snapshot at path. The snapshot holds
+// one or more pages of the API's projects.json, e.g. as downloaded with
+// `curl https://www.bestpractices.dev/projects.json?page=1`.
+func SnapshotCIIBestPracticesClient(path string) CIIBestPracticesClient {
+	return &snapshotClientCIIBestPractices{
+		path: path,
+	}
 }
 
 // BlobCIIBestPracticesClient returns a blob-based implementation of the interface.
@@ -0,0 +1,126 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type countingCIIClient struct {
+	level BadgeLevel
+	calls int
+}
+
+func (client *countingCIIClient) GetBadgeLevel(ctx context.Context, uri string) (BadgeLevel, error) {
+	client.calls++
+	return client.level, nil
+}
+
+func TestCachedCIIBestPracticesClient(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)
+	inner := &countingCIIClient{level: Passing}
+	//nolint:forcetypeassert
+	client := CachedCIIBestPracticesClient(inner, time.Hour).(*cachedClientCIIBestPractices)
+	client.now = func() time.Time { return now }
+
+	for _, uri := range []string{"github.com/owner/repo", "https://github.com/Owner/repo/"} {
+		level, err := client.GetBadgeLevel(context.Background(), uri)
+		if err != nil || level != Passing {
+			t.Fatalf("GetBadgeLevel(%s) = %v, %v", uri, level, err)
+		}
+	}
+	if inner.calls != 1 {
+		t.Errorf("expected a single lookup, got %d", inner.calls)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := client.GetBadgeLevel(context.Background(), "github.com/owner/repo"); err != nil {
+		t.Fatalf("GetBadgeLevel: %v", err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("expected the expired entry to be looked up again, got %d lookups", inner.calls)
+	}
+}
+
+func TestSnapshotCIIBestPracticesClient(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "projects.json")
+	snapshot := `[{"badge_level":"passing","repo_url":"https://github.com/owner/passing"}]
+[{"badge_level":"gold","repo_url":"https://github.com/owner/gold.git"},{"badge_level":"in_progress"}]`
+	if err := os.WriteFile(path, []byte(snapshot), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	client := SnapshotCIIBestPracticesClient(path)
+	tests := map[string]BadgeLevel{
+		"github.com/owner/passing": Passing,
+		"github.com/owner/gold":    Gold,
+		"github.com/owner/missing": NotFound,
+	}
+	for uri, want := range tests {
+		got, err := client.GetBadgeLevel(context.Background(), uri)
+		if err != nil || got != want {
+			t.Errorf("GetBadgeLevel(%s) = %v, %v, want %v", uri, got, err, want)
+		}
+	}
+
+	missing := SnapshotCIIBestPracticesClient(filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := missing.GetBadgeLevel(context.Background(), "github.com/owner/repo"); err == nil {
+		t.Error("expected an error for a missing snapshot")
+	}
+}
+
+func TestBulkCIIBestPracticesClient(t *testing.T) {
+	t.Parallel()
+	pages := []string{
+		`[{"badge_level":"silver","repo_url":"https://github.com/owner/silver"}]`,
+		`[{"badge_level":"passing","repo_url":"https://gitlab.com/owner/passing"}]`,
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var page int
+		fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		if page < 1 || page > len(pages) {
+			fmt.Fprint(w, "[]")
+			return
+		}
+		fmt.Fprint(w, pages[page-1])
+	}))
+	t.Cleanup(server.Close)
+
+	client := &bulkClientCIIBestPractices{baseURL: server.URL, ttl: time.Hour, now: time.Now}
+	tests := map[string]BadgeLevel{
+		"github.com/owner/silver":  Silver,
+		"gitlab.com/owner/passing": Passing,
+		"github.com/owner/missing": NotFound,
+	}
+	for uri, want := range tests {
+		got, err := client.GetBadgeLevel(context.Background(), uri)
+		if err != nil || got != want {
+			t.Errorf("GetBadgeLevel(%s) = %v, %v, want %v", uri, got, err, want)
+		}
+	}
+	if requests != len(pages)+1 {
+		t.Errorf("expected the projects to be downloaded once, got %d requests", requests)
+	}
+}
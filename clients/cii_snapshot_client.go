@@ -0,0 +1,177 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ciiProjectsURL = "https://www.bestpractices.dev/projects.json"
+	// maxCIIProjectPages bounds the number of pages downloaded by the bulk client.
+	maxCIIProjectPages = 1000
+)
+
+// badgeSnapshot maps normalized repo URLs to their badge levels.
+type badgeSnapshot map[string]BadgeLevel
+
+// add records the badge levels of projects. Projects without a repo URL or
+// with an unsupported badge are skipped.
+func (s badgeSnapshot) add(projects []BadgeResponse) {
+	for _, p := range projects {
+		if p.RepoURL == "" {
+			continue
+		}
+		level, err := p.getBadgeLevel()
+		if err != nil {
+			continue
+		}
+		s[normalizeCIIRepoURL(p.RepoURL)] = level
+	}
+}
+
+func (s badgeSnapshot) badgeLevel(uri string) BadgeLevel {
+	if level, ok := s[normalizeCIIRepoURL(uri)]; ok {
+		return level
+	}
+	return NotFound
+}
+
+// parseBadgeSnapshot parses one or more JSON arrays of projects, as returned by
+// the pages of the CII Best Practices projects.json API.
+func parseBadgeSnapshot(r io.Reader) (badgeSnapshot, error) {
+	snapshot := badgeSnapshot{}
+	decoder := json.NewDecoder(r)
+	for {
+		var projects []BadgeResponse
+		err := decoder.Decode(&projects)
+		if errors.Is(err, io.EOF) {
+			return snapshot, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error during json.Decode: %w", err)
+		}
+		snapshot.add(projects)
+	}
+}
+
+// normalizeCIIRepoURL returns the comparable form of a repo URL, e.g.
+// "github.com/owner/repo" for "https://www.GitHub.com/owner/repo.git".
+func normalizeCIIRepoURL(uri string) string {
+	uri = strings.ToLower(strings.TrimSpace(uri))
+	for _, prefix := range []string{"https://", "http://", "www."} {
+		uri = strings.TrimPrefix(uri, prefix)
+	}
+	uri = strings.TrimSuffix(uri, "/")
+	return strings.TrimSuffix(uri, ".git")
+}
+
+// snapshotClientCIIBestPractices implements the CIIBestPracticesClient interface.
+// Badge levels are read from an offline snapshot of the CII Best Practices
+// projects, for runs without network access to the API.
+type snapshotClientCIIBestPractices struct {
+	err      error
+	snapshot badgeSnapshot
+	path     string
+	once     sync.Once
+}
+
+// GetBadgeLevel implements CIIBestPracticesClient.GetBadgeLevel.
+func (client *snapshotClientCIIBestPractices) GetBadgeLevel(ctx context.Context, uri string) (BadgeLevel, error) {
+	client.once.Do(func() {
+		f, err := os.Open(client.path)
+		if err != nil {
+			client.err = fmt.Errorf("error during os.Open: %w", err)
+			return
+		}
+		defer f.Close()
+		client.snapshot, client.err = parseBadgeSnapshot(f)
+	})
+	if client.err != nil {
+		return Unknown, client.err
+	}
+	return client.snapshot.badgeLevel(uri), nil
+}
+
+// bulkClientCIIBestPractices implements the CIIBestPracticesClient interface.
+// All projects are downloaded from the CII Best Practices API at once and
+// refreshed when their TTL expires, so fleet scans look up the badges of many
+// repos without a request per repo.
+type bulkClientCIIBestPractices struct {
+	fetched  time.Time
+	now      func() time.Time
+	snapshot badgeSnapshot
+	baseURL  string
+	ttl      time.Duration
+	mu       sync.Mutex
+}
+
+// GetBadgeLevel implements CIIBestPracticesClient.GetBadgeLevel.
+func (client *bulkClientCIIBestPractices) GetBadgeLevel(ctx context.Context, uri string) (BadgeLevel, error) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.snapshot == nil || client.now().Sub(client.fetched) >= client.ttl {
+		snapshot, err := client.download(ctx)
+		if err != nil {
+			return Unknown, err
+		}
+		client.snapshot, client.fetched = snapshot, client.now()
+	}
+	return client.snapshot.badgeLevel(uri), nil
+}
+
+// download fetches the pages of projects until an empty one is returned.
+func (client *bulkClientCIIBestPractices) download(ctx context.Context) (badgeSnapshot, error) {
+	httpClient := http.Client{
+		Transport: &expBackoffTransport{
+			numRetries: 3,
+		},
+	}
+	snapshot := badgeSnapshot{}
+	for page := 1; page <= maxCIIProjectPages; page++ {
+		url := fmt.Sprintf("%s?page=%d", client.baseURL, page)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error during http.NewRequestWithContext: %w", err)
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error during http.Do: %w", err)
+		}
+		jsonData, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error during io.ReadAll: %w", err)
+		}
+		projects, err := ParseBadgeResponseFromJSON(jsonData)
+		if err != nil {
+			return nil, fmt.Errorf("error during json parsing: %w", err)
+		}
+		if len(projects) == 0 {
+			break
+		}
+		snapshot.add(projects)
+	}
+	return snapshot, nil
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	cp "github.com/otiai10/copy"
 
 	"github.com/ossf/scorecard/v4/clients"
@@ -57,6 +58,9 @@ func (c *Client) InitRepo(uri, commitSHA string, commitDepth int) error {
 
 	// init
 	c.commitDepth = commitDepth
+	if c.commitDepth <= 0 {
+		c.commitDepth = clients.DefaultCommitDepth
+	}
 	tempDir, err := os.MkdirTemp("", repoDir)
 	if err != nil {
 		return fmt.Errorf("os.MkdirTemp: %w", err)
@@ -77,6 +81,11 @@ func (c *Client) InitRepo(uri, commitSHA string, commitDepth int) error {
 			URL:      uri,
 			Progress: os.Stdout,
 		})
+		// An empty repo has nothing to clone, and is scanned as a repo whose
+		// HEAD points at an unborn branch.
+		if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+			c.gitRepo, err = git.PlainInit(tempDir, false /*isBare*/)
+		}
 		if err != nil {
 			return fmt.Errorf("git.PlainClone: %w", err)
 		}
@@ -105,6 +114,11 @@ func (c *Client) ListCommits() ([]clients.Commit, error) {
 		commitIter, err := c.gitRepo.Log(&git.LogOptions{
 			Order: git.LogOrderCommitterTime,
 		})
+		// HEAD points at a branch without commits yet.
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			c.commits = []clients.Commit{}
+			return
+		}
 		if err != nil {
 			c.errListCommits = fmt.Errorf("git.CommitObjects: %w", err)
 			return
@@ -134,6 +148,10 @@ func (c *Client) ListCommits() ([]clients.Commit, error) {
 				Committer: clients.User{
 					Login: commit.Committer.Email,
 				},
+				CoAuthors: clients.CoAuthorsFrom(commit.Message),
+				Signature: clients.CommitSignature{
+					Type: clients.SignatureTypeFromArmor(commit.PGPSignature),
+				},
 			})
 		}
 	})
@@ -15,6 +15,7 @@
 package git
 
 import (
+	"github.com/go-git/go-git/v5"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -37,6 +38,20 @@ var _ = DescribeTable("Test ListCommits commit-depth for HEAD",
 	Entry("GitLab", "https://gitlab.haskell.org/haskell/filepath"),
 )
 
+var _ = Describe("Test ListCommits for an unborn HEAD", func() {
+	It("returns no commits", func() {
+		dir := GinkgoT().TempDir()
+		_, err := git.PlainInit(dir, false /*isBare*/)
+		Expect(err).To(BeNil())
+		client := &Client{}
+		Expect(client.InitRepo("file://"+dir, clients.HeadSHA, 1)).To(BeNil())
+		commits, err := client.ListCommits()
+		Expect(err).To(BeNil())
+		Expect(commits).To(BeEmpty())
+		Expect(client.Close()).To(BeNil())
+	})
+})
+
 var _ = DescribeTable("Test ListCommits commit-depth and latest commit at [0]",
 	func(uri, commitSHA string) {
 		const commitDepth = 10
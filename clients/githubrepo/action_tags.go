@@ -0,0 +1,133 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+const (
+	actionTagsPerPage = 100
+	// actionTagsMaxPages caps the tags listed for a single repo: pins to
+	// commits of older tags are reported as not tagged.
+	actionTagsMaxPages = 5
+)
+
+// ActionTagsClient lists the tags of the repos of GitHub Actions. The tags
+// of a repo are cached for the lifetime of the client, so a client shared by
+// the scans of many repos only lists the tags of a popular action once.
+type ActionTagsClient struct {
+	ghClient *github.Client
+	mu       sync.Mutex
+	// tags are the tags of the repos listed so far, by SHA of their commit.
+	tags map[string]map[string][]string
+}
+
+// CreateActionTagsClient returns an ActionTagsClient authenticated like the
+// RepoClients of GitHub.
+func CreateActionTagsClient(ctx context.Context, logger *log.Logger) *ActionTagsClient {
+	rt := roundtripper.NewTransport(ctx, logger)
+	return newActionTagsClient(github.NewClient(&http.Client{Transport: rt}))
+}
+
+func newActionTagsClient(ghClient *github.Client) *ActionTagsClient {
+	return &ActionTagsClient{
+		ghClient: ghClient,
+		tags:     map[string]map[string][]string{},
+	}
+}
+
+// ListActionTags implements clients.ActionTagsClient.
+func (client *ActionTagsClient) ListActionTags(ctx context.Context, repo string) (map[string][]string, error) {
+	return client.listActionTags(ctx, repo, nil)
+}
+
+// WithBudget returns a clients.ActionTagsClient sharing the cache of the
+// client, which lists the tags of at most budget repos which are not cached
+// and fails with clients.ErrBudgetExceeded after. It is meant to cap the
+// requests of a single scan.
+func (client *ActionTagsClient) WithBudget(budget int) clients.ActionTagsClient {
+	return &budgetedActionTagsClient{client: client, budget: budget}
+}
+
+// listActionTags lists the tags of repo unless they are cached, calling
+// spend first if not.
+func (client *ActionTagsClient) listActionTags(ctx context.Context, repo string,
+	spend func() error,
+) (map[string][]string, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid repo of action: %q", repo))
+	}
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if tags, ok := client.tags[repo]; ok {
+		return tags, nil
+	}
+	if spend != nil {
+		if err := spend(); err != nil {
+			return nil, err
+		}
+	}
+	tags := map[string][]string{}
+	opts := &github.ListOptions{PerPage: actionTagsPerPage}
+	for page := 0; page < actionTagsMaxPages; page++ {
+		repoTags, resp, err := client.ghClient.Repositories.ListTags(ctx, owner, name, opts)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Repositories.ListTags: %v", err))
+		}
+		for _, tag := range repoTags {
+			sha := tag.GetCommit().GetSHA()
+			tags[sha] = append(tags[sha], tag.GetName())
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	client.tags[repo] = tags
+	return tags, nil
+}
+
+type budgetedActionTagsClient struct {
+	client *ActionTagsClient
+	mu     sync.Mutex
+	budget int
+}
+
+func (b *budgetedActionTagsClient) ListActionTags(ctx context.Context, repo string) (map[string][]string, error) {
+	return b.client.listActionTags(ctx, repo, b.spend)
+}
+
+func (b *budgetedActionTagsClient) spend() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.budget <= 0 {
+		return fmt.Errorf("%w: listing the tags of actions", clients.ErrBudgetExceeded)
+	}
+	b.budget--
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// tagsTransport serves pages of tags by path and page, counting requests.
+type tagsTransport struct {
+	pages    map[string][]string
+	mu       sync.Mutex
+	requests int
+}
+
+func (rt *tagsTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.requests++
+	rt.mu.Unlock()
+	pages, ok := rt.pages[r.URL.Path]
+	if !ok {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Not Found"}`)),
+			Request:    r,
+		}, nil
+	}
+	page := 0
+	if p := r.URL.Query().Get("page"); p == "2" {
+		page = 1
+	}
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	if page+1 < len(pages) {
+		header.Set("Link", `<https://api.github.com`+r.URL.Path+`?page=2>; rel="next"`)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(pages[page])),
+		Request:    r,
+	}, nil
+}
+
+func newTagsTransport() *tagsTransport {
+	return &tagsTransport{
+		pages: map[string][]string{
+			"/repos/actions/checkout/tags": {
+				`[
+					{"name": "v3.5.0", "commit": {"sha": "8f4b7f84864484a7bf31766abe9204da3cbe65b3"}},
+					{"name": "v3", "commit": {"sha": "8f4b7f84864484a7bf31766abe9204da3cbe65b3"}}
+				]`,
+				`[
+					{"name": "v2.0.0", "commit": {"sha": "ee0669bd1cc54295c223e0bb666b733df41de1c5"}}
+				]`,
+			},
+			"/repos/actions/setup-go/tags": {
+				`[{"name": "v4.0.0", "commit": {"sha": "4d34df0c2316fe8122ab82dc22947d607c0c91f9"}}]`,
+			},
+		},
+	}
+}
+
+func TestListActionTags(t *testing.T) {
+	t.Parallel()
+	rt := newTagsTransport()
+	client := newActionTagsClient(github.NewClient(&http.Client{Transport: rt}))
+
+	want := map[string][]string{
+		"8f4b7f84864484a7bf31766abe9204da3cbe65b3": {"v3.5.0", "v3"},
+		"ee0669bd1cc54295c223e0bb666b733df41de1c5": {"v2.0.0"},
+	}
+	for i := 0; i < 2; i++ {
+		got, err := client.ListActionTags(context.Background(), "actions/checkout")
+		if err != nil {
+			t.Fatalf("ListActionTags: %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("ListActionTags() mismatch (-want +got):\n%s", diff)
+		}
+	}
+	// Both pages are listed once, the second lookup is cached.
+	if rt.requests != 2 {
+		t.Errorf("requests = %d, want 2", rt.requests)
+	}
+
+	for _, repo := range []string{"checkout", "actions/", "/checkout", "actions/checkout/sub"} {
+		if _, err := client.ListActionTags(context.Background(), repo); err == nil {
+			t.Errorf("ListActionTags(%q): expected an error", repo)
+		}
+	}
+	if _, err := client.ListActionTags(context.Background(), "actions/missing"); err == nil {
+		t.Error("ListActionTags of a missing repo: expected an error")
+	}
+}
+
+func TestActionTagsClient_WithBudget(t *testing.T) {
+	t.Parallel()
+	rt := newTagsTransport()
+	client := newActionTagsClient(github.NewClient(&http.Client{Transport: rt}))
+	if _, err := client.ListActionTags(context.Background(), "actions/checkout"); err != nil {
+		t.Fatalf("ListActionTags: %v", err)
+	}
+
+	budgeted := client.WithBudget(1)
+	// Cached repos do not spend the budget.
+	if _, err := budgeted.ListActionTags(context.Background(), "actions/checkout"); err != nil {
+		t.Errorf("ListActionTags of a cached repo: %v", err)
+	}
+	if _, err := budgeted.ListActionTags(context.Background(), "actions/setup-go"); err != nil {
+		t.Errorf("ListActionTags within budget: %v", err)
+	}
+	if _, err := budgeted.ListActionTags(context.Background(), "actions/missing"); !errors.Is(err, clients.ErrBudgetExceeded) {
+		t.Errorf("ListActionTags over budget: got %v, want %v", err, clients.ErrBudgetExceeded)
+	}
+
+	// Another budget shares the cache.
+	requests := rt.requests
+	if _, err := client.WithBudget(0).ListActionTags(context.Background(), "actions/setup-go"); err != nil {
+		t.Errorf("ListActionTags of a repo cached by another budget: %v", err)
+	}
+	if rt.requests != requests {
+		t.Errorf("requests = %d, want %d", rt.requests, requests)
+	}
+}
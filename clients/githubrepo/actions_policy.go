@@ -0,0 +1,125 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// The go-github version in use predates these endpoints, so the responses
+// are decoded into the structs below.
+type actionsPermissionsResponse struct {
+	Enabled        bool   `json:"enabled"`
+	AllowedActions string `json:"allowed_actions"`
+}
+
+type selectedActionsResponse struct {
+	PatternsAllowed    []string `json:"patterns_allowed"`
+	GithubOwnedAllowed bool     `json:"github_owned_allowed"`
+	VerifiedAllowed    bool     `json:"verified_allowed"`
+}
+
+type forkPRApprovalResponse struct {
+	ApprovalPolicy string `json:"approval_policy"`
+}
+
+type actionsPolicyHandler struct {
+	ghClient *github.Client
+	once     *sync.Once
+	ctx      context.Context
+	errSetup error
+	repourl  *repoURL
+	policy   *clients.ActionsPolicy
+}
+
+func (handler *actionsPolicyHandler) init(ctx context.Context, repourl *repoURL) {
+	handler.ctx = ctx
+	handler.repourl = repourl
+	handler.errSetup = nil
+	handler.once = new(sync.Once)
+	handler.policy = nil
+}
+
+func (handler *actionsPolicyHandler) get(endpoint string, v interface{}) error {
+	reqURL := path.Join("repos", handler.repourl.owner, handler.repourl.repo, "actions", "permissions", endpoint)
+	req, err := handler.ghClient.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("request for %s failed with %w", reqURL, err)
+	}
+	if resp, err := handler.ghClient.Do(handler.ctx, req, v); err != nil {
+		if ssoErr := ssoError(resp, err); ssoErr != nil {
+			return fmt.Errorf("%s: %w", reqURL, ssoErr)
+		}
+		// GitHub answers 404 rather than 403 to hide private settings.
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			return fmt.Errorf("%w: %s: %v", clients.ErrPermissionDenied, reqURL, err)
+		}
+		return fmt.Errorf("response for %s failed with %w", reqURL, err)
+	}
+	return nil
+}
+
+func (handler *actionsPolicyHandler) setup() error {
+	handler.once.Do(func() {
+		if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+			handler.errSetup = fmt.Errorf("%w: GetActionsPolicy only supported for HEAD queries", clients.ErrUnsupportedFeature)
+			return
+		}
+		// These endpoints require admin access to the repo.
+		var perms actionsPermissionsResponse
+		if err := handler.get("", &perms); err != nil {
+			handler.errSetup = err
+			return
+		}
+		policy := &clients.ActionsPolicy{
+			Enabled:        perms.Enabled,
+			AllowedActions: clients.AllowedActions(perms.AllowedActions),
+		}
+		if policy.AllowedActions == clients.AllowedActionsSelected {
+			var selected selectedActionsResponse
+			if err := handler.get("selected-actions", &selected); err != nil {
+				handler.errSetup = err
+				return
+			}
+			policy.GithubOwnedAllowed = selected.GithubOwnedAllowed
+			policy.VerifiedAllowed = selected.VerifiedAllowed
+			policy.PatternsAllowed = selected.PatternsAllowed
+		}
+		// The approval setting is not available on older GitHub Enterprise
+		// Server versions, leave it unknown if it can't be retrieved.
+		var approval forkPRApprovalResponse
+		if err := handler.get("fork-pr-contributor-approval", &approval); err == nil {
+			policy.ForkPRApproval = clients.ForkPRApproval(approval.ApprovalPolicy)
+		}
+		handler.policy = policy
+	})
+	return handler.errSetup
+}
+
+func (handler *actionsPolicyHandler) getActionsPolicy() (*clients.ActionsPolicy, error) {
+	if err := handler.setup(); err != nil {
+		return nil, fmt.Errorf("error during actionsPolicyHandler.setup: %w", err)
+	}
+	return handler.policy, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+// activityBatchSize is how many repos are queried by a single GraphQL query.
+const activityBatchSize = 50
+
+// RepoActivity is the activity of a repo which tells whether it is worth
+// scanning again.
+type RepoActivity struct {
+	// PushedAt is the time of the last push to any branch, zero for empty repos.
+	PushedAt time.Time
+	Archived bool
+}
+
+// repoActivity is the node a repo is queried as.
+type repoActivity struct {
+	PushedAt   *githubv4.DateTime
+	IsArchived bool
+}
+
+// FetchRepoActivity returns the activity of the GitHub repos, keyed by the
+// inputs they are given as, e.g. github.com/owner/repo. Many repos are
+// fetched with each GraphQL query, so that fleet scans can skip the repos
+// which did not change cheaply. Repos which could not be fetched, e.g.
+// because they don't exist or are hosted elsewhere, are missing.
+func FetchRepoActivity(ctx context.Context, logger *log.Logger, repos []string) (map[string]RepoActivity, error) {
+	rt := roundtripper.NewTransport(ctx, logger)
+	return fetchRepoActivity(ctx, githubv4.NewClient(&http.Client{Transport: rt}), repos)
+}
+
+func fetchRepoActivity(ctx context.Context, client *githubv4.Client, repos []string,
+) (map[string]RepoActivity, error) {
+	var parsed []*repoURL
+	var inputs []string
+	for _, input := range repos {
+		var r repoURL
+		if err := r.parse(input); err != nil || r.IsValid() != nil {
+			continue
+		}
+		parsed = append(parsed, &r)
+		inputs = append(inputs, input)
+	}
+
+	ret := make(map[string]RepoActivity, len(parsed))
+	for start := 0; start < len(parsed); start += activityBatchSize {
+		end := start + activityBatchSize
+		if end > len(parsed) {
+			end = len(parsed)
+		}
+		nodes, err := queryRepoActivity(ctx, client, parsed[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for i, node := range nodes {
+			if node == nil {
+				continue
+			}
+			activity := RepoActivity{Archived: node.IsArchived}
+			if node.PushedAt != nil {
+				activity.PushedAt = node.PushedAt.Time
+			}
+			ret[inputs[start+i]] = activity
+		}
+	}
+	return ret, nil
+}
+
+// queryRepoActivity queries the activity of repos with a single query, in
+// which each repo is a field aliased by its index. Repos which could not be
+// resolved are nil.
+func queryRepoActivity(ctx context.Context, client *githubv4.Client, repos []*repoURL,
+) ([]*repoActivity, error) {
+	fields := make([]reflect.StructField, len(repos))
+	vars := make(map[string]interface{}, 2*len(repos))
+	for i, r := range repos {
+		n := strconv.Itoa(i)
+		fields[i] = reflect.StructField{
+			Name: "R" + n,
+			Type: reflect.TypeOf(&repoActivity{}),
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"r%s: repository(owner: $owner%s, name: $name%s)"`, n, n, n)),
+		}
+		vars["owner"+n] = githubv4.String(r.owner)
+		vars["name"+n] = githubv4.String(r.repo)
+	}
+	query := reflect.New(reflect.StructOf(fields))
+	err := client.Query(ctx, query.Interface(), vars)
+
+	// Repos which could not be resolved fail the query, but the others are
+	// still returned.
+	nodes := make([]*repoActivity, len(repos))
+	found := false
+	for i := range nodes {
+		nodes[i], _ = query.Elem().Field(i).Interface().(*repoActivity)
+		found = found || nodes[i] != nil
+	}
+	if err != nil && !found {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+	}
+	return nodes, nil
+}
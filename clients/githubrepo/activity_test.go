@@ -0,0 +1,74 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+func TestFetchRepoActivity(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]string `json:"variables"`
+			Query     string            `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding the query: %v", err)
+		}
+		if !strings.Contains(body.Query, "r1: repository(owner: $owner1, name: $name1)") ||
+			body.Variables["owner1"] != "owner" || body.Variables["name1"] != "missing" {
+			t.Errorf("unexpected query %q with %v", body.Query, body.Variables)
+		}
+		fmt.Fprint(w, `{"data": {
+			"r0": {"pushedAt": "2023-05-01T10:00:00Z", "isArchived": false},
+			"r1": null,
+			"r2": {"pushedAt": null, "isArchived": true}
+		}, "errors": [{"message": "Could not resolve to a Repository with the name 'owner/missing'."}]}`)
+	}))
+	defer srv.Close()
+
+	client := githubv4.NewEnterpriseClient(srv.URL, srv.Client())
+	got, err := fetchRepoActivity(context.Background(), client, []string{
+		"github.com/owner/active",
+		"owner/missing",
+		"gitlab.com/owner/elsewhere",
+		"https://github.com/owner/archived",
+	})
+	if err != nil {
+		t.Fatalf("fetchRepoActivity: %v", err)
+	}
+	want := map[string]RepoActivity{
+		"github.com/owner/active":           {PushedAt: time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)},
+		"https://github.com/owner/archived": {Archived: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("fetchRepoActivity() = %v, want %v", got, want)
+	}
+	for repo, activity := range want {
+		if g := got[repo]; !g.PushedAt.Equal(activity.PushedAt) || g.Archived != activity.Archived {
+			t.Errorf("activity of %s = %+v, want %+v", repo, g, activity)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// EnvAuditLog enables reading the audit log of the organization owning the
+// repo, which requires GitHub Enterprise and an org owner token.
+const EnvAuditLog = "SCORECARD_GITHUB_AUDIT_LOG"
+
+const (
+	// auditLogLookBackDays matches the window of the Maintained check.
+	auditLogLookBackDays = 90
+	auditLogPerPage      = 100
+	auditLogMaxPages     = 10
+)
+
+// auditLogCategories are the prefixes of the actions used by the checks,
+// the other events are dropped.
+var auditLogCategories = []string{
+	"protected_branch.",
+	"repository_ruleset.",
+	"secret_scanning_alert.",
+}
+
+// go-github's AuditEntry lacks the resolution of secret scanning alerts and
+// its pagination ignores the `after` cursor of the audit log.
+type auditLogEntry struct {
+	Action     string `json:"action"`
+	Actor      string `json:"actor"`
+	Name       string `json:"name"`
+	Resolution string `json:"resolution"`
+	// Timestamp is in milliseconds since the epoch.
+	Timestamp int64 `json:"@timestamp"`
+}
+
+type auditLogHandler struct {
+	ghClient *github.Client
+	once     *sync.Once
+	ctx      context.Context
+	errSetup error
+	repourl  *repoURL
+	events   []clients.AuditLogEvent
+	isOrg    bool
+	enabled  bool
+}
+
+func (handler *auditLogHandler) init(ctx context.Context, repourl *repoURL, isOrg bool) {
+	handler.ctx = ctx
+	handler.repourl = repourl
+	handler.isOrg = isOrg
+	handler.enabled = os.Getenv(EnvAuditLog) != ""
+	handler.errSetup = nil
+	handler.once = new(sync.Once)
+	handler.events = nil
+}
+
+func (handler *auditLogHandler) setup() error {
+	handler.once.Do(func() {
+		if !handler.enabled {
+			handler.errSetup = fmt.Errorf("%w: audit log disabled, set %s to enable it",
+				clients.ErrUnsupportedFeature, EnvAuditLog)
+			return
+		}
+		if !handler.isOrg {
+			handler.errSetup = fmt.Errorf("%w: only organizations have an audit log", clients.ErrUnsupportedFeature)
+			return
+		}
+		since := time.Now().AddDate(0, 0, -auditLogLookBackDays)
+		phrase := fmt.Sprintf("repo:%s/%s created:>=%s",
+			handler.repourl.owner, handler.repourl.repo, since.Format("2006-01-02"))
+		query := url.Values{}
+		query.Set("phrase", phrase)
+		query.Set("per_page", fmt.Sprint(auditLogPerPage))
+		for page := 0; page < auditLogMaxPages; page++ {
+			entries, after, err := handler.get(query)
+			if err != nil {
+				handler.errSetup = err
+				return
+			}
+			for i := range entries {
+				if event, ok := auditLogEventFrom(&entries[i]); ok {
+					handler.events = append(handler.events, event)
+				}
+			}
+			if after == "" {
+				break
+			}
+			query.Set("after", after)
+		}
+	})
+	return handler.errSetup
+}
+
+// get returns a page of the audit log and the cursor of the next page, if any.
+func (handler *auditLogHandler) get(query url.Values) ([]auditLogEntry, string, error) {
+	reqURL := path.Join("orgs", handler.repourl.owner, "audit-log") + "?" + query.Encode()
+	req, err := handler.ghClient.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("request for %s failed with %w", reqURL, err)
+	}
+	var entries []auditLogEntry
+	resp, err := handler.ghClient.Do(handler.ctx, req, &entries)
+	if err != nil {
+		if ssoErr := ssoError(resp, err); ssoErr != nil {
+			return nil, "", fmt.Errorf("%s: %w", reqURL, ssoErr)
+		}
+		// The audit log requires GitHub Enterprise and an org owner token.
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			return nil, "", fmt.Errorf("%w: %s: %v", clients.ErrPermissionDenied, reqURL, err)
+		}
+		return nil, "", fmt.Errorf("response for %s failed with %w", reqURL, err)
+	}
+	return entries, nextAuditLogCursor(resp.Response), nil
+}
+
+func (handler *auditLogHandler) listEvents() ([]clients.AuditLogEvent, error) {
+	if err := handler.setup(); err != nil {
+		return nil, fmt.Errorf("error during auditLogHandler.setup: %w", err)
+	}
+	return handler.events, nil
+}
+
+func auditLogEventFrom(entry *auditLogEntry) (clients.AuditLogEvent, bool) {
+	relevant := false
+	for _, category := range auditLogCategories {
+		if strings.HasPrefix(entry.Action, category) {
+			relevant = true
+		}
+	}
+	if !relevant {
+		return clients.AuditLogEvent{}, false
+	}
+	event := clients.AuditLogEvent{
+		CreatedAt:  time.UnixMilli(entry.Timestamp).UTC(),
+		Action:     entry.Action,
+		Actor:      entry.Actor,
+		Resolution: entry.Resolution,
+	}
+	if strings.HasPrefix(entry.Action, "protected_branch.") {
+		event.Branch = entry.Name
+	}
+	return event, true
+}
+
+// nextAuditLogCursor returns the `after` cursor of the next page given by
+// the Link header of the response, or "" on the last page.
+func nextAuditLogCursor(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+	for _, link := range strings.Split(resp.Header.Get("Link"), ",") {
+		segments := strings.Split(strings.TrimSpace(link), ";")
+		if len(segments) < 2 || strings.TrimSpace(segments[1]) != `rel="next"` {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(segments[0], "<>"))
+		if err != nil {
+			continue
+		}
+		return u.Query().Get("after")
+	}
+	return ""
+}
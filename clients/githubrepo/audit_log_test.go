@@ -0,0 +1,144 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// auditLogTransport serves the pages of the audit log by `after` cursor,
+// linking each page to the cursor of the next one.
+type auditLogTransport struct {
+	pages  map[string]string
+	next   map[string]string
+	status int
+}
+
+func (rt *auditLogTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	if rt.status != 0 {
+		return &http.Response{
+			StatusCode: rt.status,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(`{"message": "Not Found"}`)),
+			Request:    r,
+		}, nil
+	}
+	after := r.URL.Query().Get("after")
+	if next, ok := rt.next[after]; ok {
+		header.Set("Link", `<https://api.github.com/organizations/1/audit-log?after=`+next+`&per_page=100>; rel="next"`)
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(rt.pages[after])),
+		Request:    r,
+	}, nil
+}
+
+func TestAuditLogListEvents(t *testing.T) {
+	t.Parallel()
+	rt := &auditLogTransport{
+		pages: map[string]string{
+			"": `[
+				{"action": "protected_branch.destroy", "actor": "admin", "name": "main", "@timestamp": 1700000000000},
+				{"action": "repo.access", "actor": "admin", "@timestamp": 1700000001000}
+			]`,
+			"cursor1": `[
+				{"action": "secret_scanning_alert.resolve", "actor": "dev", "resolution": "false_positive",
+				 "@timestamp": 1700000002000}
+			]`,
+		},
+		next: map[string]string{"": "cursor1"},
+	}
+	handler := &auditLogHandler{ghClient: github.NewClient(&http.Client{Transport: rt})}
+	handler.init(context.Background(), &repoURL{owner: "org", repo: "repo"}, true)
+	handler.enabled = true
+
+	got, err := handler.listEvents()
+	if err != nil {
+		t.Fatalf("listEvents: %v", err)
+	}
+	want := []clients.AuditLogEvent{
+		{
+			CreatedAt: time.UnixMilli(1700000000000).UTC(),
+			Action:    clients.AuditLogBranchProtectionDestroy,
+			Actor:     "admin",
+			Branch:    "main",
+		},
+		{
+			CreatedAt:  time.UnixMilli(1700000002000).UTC(),
+			Action:     clients.AuditLogSecretScanningAlertResolve,
+			Actor:      "dev",
+			Resolution: "false_positive",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listEvents() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAuditLogUnavailable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		want    error
+		name    string
+		status  int
+		isOrg   bool
+		enabled bool
+	}{
+		{
+			name:  "disabled",
+			isOrg: true,
+			want:  clients.ErrUnsupportedFeature,
+		},
+		{
+			name:    "user account",
+			enabled: true,
+			want:    clients.ErrUnsupportedFeature,
+		},
+		{
+			name:    "no enterprise audit log access",
+			isOrg:   true,
+			enabled: true,
+			status:  http.StatusNotFound,
+			want:    clients.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rt := &auditLogTransport{status: tt.status}
+			handler := &auditLogHandler{ghClient: github.NewClient(&http.Client{Transport: rt})}
+			handler.init(context.Background(), &repoURL{owner: "org", repo: "repo"}, tt.isOrg)
+			handler.enabled = tt.enabled
+			if _, err := handler.listEvents(); !errors.Is(err, tt.want) {
+				t.Errorf("listEvents() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
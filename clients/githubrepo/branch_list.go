@@ -0,0 +1,90 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+type branchListData struct {
+	Repository struct {
+		Refs struct {
+			Nodes []struct {
+				Name   string
+				Target struct {
+					Commit struct {
+						CommittedDate githubv4.DateTime
+					} `graphql:"... on Commit"`
+				}
+				// The comparison of the branch, as the base, with the
+				// default branch: the default branch is ahead by the
+				// commits missing from the branch.
+				Compare *struct {
+					AheadBy int
+				} `graphql:"compare(headRef: $defaultBranch)"`
+			}
+		} `graphql:"refs(first: $first, refPrefix: $refPrefix)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// listBranches lists the branches of the repo, compared with its default
+// branch.
+func (handler *branchesHandler) listBranches() ([]clients.BranchInfo, error) {
+	handler.listOnce.Do(func() {
+		if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+			handler.errList = fmt.Errorf("%w: branches only supported for HEAD queries", clients.ErrUnsupportedFeature)
+			return
+		}
+		vars := map[string]interface{}{
+			"owner":         githubv4.String(handler.repourl.owner),
+			"name":          githubv4.String(handler.repourl.repo),
+			"first":         githubv4.Int(clients.MaxListedBranches),
+			"refPrefix":     githubv4.String(refPrefix),
+			"defaultBranch": githubv4.String(handler.repourl.defaultBranch),
+		}
+		data := new(branchListData)
+		if err := handler.graphClient.Query(handler.ctx, data, vars); err != nil {
+			handler.errList = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+			return
+		}
+		handler.list = branchInfosFrom(data)
+	})
+	if handler.errList != nil {
+		return nil, fmt.Errorf("error during branchesHandler.listBranches: %w", handler.errList)
+	}
+	return handler.list, nil
+}
+
+func branchInfosFrom(data *branchListData) []clients.BranchInfo {
+	ret := []clients.BranchInfo{}
+	for i := range data.Repository.Refs.Nodes {
+		ref := &data.Repository.Refs.Nodes[i]
+		info := clients.BranchInfo{
+			Name:       ref.Name,
+			LastCommit: ref.Target.Commit.CommittedDate.Time,
+		}
+		if ref.Compare != nil {
+			info.BehindBy = ref.Compare.AheadBy
+		}
+		ret = append(ret, info)
+	}
+	return ret
+}
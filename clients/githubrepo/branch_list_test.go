@@ -0,0 +1,119 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+const testBranchListResponse = `{"data": {"repository": {"refs": {"nodes": [
+	{
+		"name": "main",
+		"target": {"committedDate": "2023-06-01T10:00:00Z"},
+		"compare": {"aheadBy": 0}
+	},
+	{
+		"name": "release/v1",
+		"target": {"committedDate": "2021-02-03T04:05:06Z"},
+		"compare": {"aheadBy": 812}
+	},
+	{
+		"name": "unrelated",
+		"target": {"committedDate": "2022-01-01T00:00:00Z"},
+		"compare": null
+	}
+]}}}}`
+
+func TestListBranches(t *testing.T) {
+	t.Parallel()
+	var query string
+	var variables map[string]interface{}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body struct {
+			Query     string
+			Variables map[string]interface{}
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("json.Decode: %v", err)
+		}
+		query, variables = body.Query, body.Variables
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(testBranchListResponse)); err != nil {
+			t.Errorf("Write: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	handler := &branchesHandler{
+		graphClient: githubv4.NewEnterpriseClient(server.URL, server.Client()),
+	}
+	handler.init(context.Background(), &repoURL{
+		owner:         "owner",
+		repo:          "repo",
+		defaultBranch: "main",
+		commitSHA:     clients.HeadSHA,
+	})
+	got, err := handler.listBranches()
+	if err != nil {
+		t.Fatalf("listBranches: %v", err)
+	}
+	if _, err := handler.listBranches(); err != nil {
+		t.Fatalf("listBranches: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("%d requests, want the branches listed once", requests)
+	}
+	if !strings.Contains(query, "compare(headRef: $defaultBranch)") {
+		t.Errorf("query %q does not compare the branches with the default branch", query)
+	}
+	if variables["defaultBranch"] != "main" || variables["refPrefix"] != refPrefix {
+		t.Errorf("variables = %v", variables)
+	}
+
+	want := []clients.BranchInfo{
+		{Name: "main", LastCommit: time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)},
+		{Name: "release/v1", LastCommit: time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC), BehindBy: 812},
+		{Name: "unrelated", LastCommit: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listBranches() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestListBranches_commit(t *testing.T) {
+	t.Parallel()
+	handler := &branchesHandler{}
+	handler.init(context.Background(), &repoURL{
+		owner:     "owner",
+		repo:      "repo",
+		commitSHA: "8e5e7e5ab8b370d6c329ec480221332ada57f0ab",
+	})
+	if _, err := handler.listBranches(); err == nil {
+		t.Errorf("listBranches() of a commit succeeded, want an error")
+	}
+}
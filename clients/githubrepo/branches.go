@@ -122,6 +122,10 @@ type branchesHandler struct {
 	errSetup         error
 	repourl          *repoURL
 	defaultBranchRef *clients.BranchRef
+	// The branches of the repo, listed once by listBranches.
+	listOnce *sync.Once
+	errList  error
+	list     []clients.BranchInfo
 }
 
 func (handler *branchesHandler) init(ctx context.Context, repourl *repoURL) {
@@ -131,6 +135,9 @@ func (handler *branchesHandler) init(ctx context.Context, repourl *repoURL) {
 	handler.once = new(sync.Once)
 	handler.defaultBranchRef = nil
 	handler.data = nil
+	handler.listOnce = new(sync.Once)
+	handler.errList = nil
+	handler.list = nil
 }
 
 func (handler *branchesHandler) setup() error {
@@ -149,6 +156,9 @@ func (handler *branchesHandler) setup() error {
 			return
 		}
 		handler.defaultBranchRef = getBranchRefFrom(handler.data.Repository.DefaultBranchRef)
+		if err := handler.applyRulesets(handler.defaultBranchRef); err != nil {
+			handler.errSetup = fmt.Errorf("error applying rulesets: %w", err)
+		}
 	})
 	return handler.errSetup
 }
@@ -166,7 +176,11 @@ func (handler *branchesHandler) query(branchName string) (*clients.BranchRef, er
 	if err := handler.graphClient.Query(handler.ctx, queryData, vars); err != nil {
 		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
 	}
-	return getBranchRefFrom(queryData.Repository.Ref), nil
+	branchRef := getBranchRefFrom(queryData.Repository.Ref)
+	if err := handler.applyRulesets(branchRef); err != nil {
+		return nil, fmt.Errorf("error applying rulesets: %w", err)
+	}
+	return branchRef, nil
 }
 
 func (handler *branchesHandler) getDefaultBranch() (*clients.BranchRef, error) {
@@ -0,0 +1,269 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+const (
+	// branchesPerQuery bounds the number of branches fetched by a batch query,
+	// to stay within the node limits of the GraphQL API.
+	branchesPerQuery  = 50
+	rulesetsToAnalyze = 100
+	rulesPerRuleset   = 100
+
+	rulesetEnforcementActive = "ACTIVE"
+	rulesetTargetBranch      = "BRANCH"
+	refNameAll               = "~ALL"
+	refNameDefaultBranch     = "~DEFAULT_BRANCH"
+)
+
+// rulesetRule is a rule of a repository ruleset. Its type is the upper-case
+// form of the type of the rules of the REST API.
+// https://docs.github.com/en/graphql/reference/objects#repositoryrule
+type rulesetRule struct {
+	Type       string
+	Parameters struct {
+		PullRequest struct {
+			RequiredApprovingReviewCount *int32
+			RequireCodeOwnerReview       *bool
+			DismissStaleReviewsOnPush    *bool
+			RequireLastPushApproval      *bool
+		} `graphql:"... on PullRequestParameters"`
+		StatusChecks struct {
+			StrictRequiredStatusChecksPolicy *bool
+			RequiredStatusChecks             []struct {
+				Context string
+			}
+		} `graphql:"... on RequiredStatusChecksParameters"`
+	}
+}
+
+// ruleset is a repository ruleset, or one of its org which applies to the
+// repository.
+// https://docs.github.com/en/graphql/reference/objects#repositoryruleset
+type ruleset struct {
+	Enforcement string
+	Target      *string
+	Conditions  struct {
+		RefName *struct {
+			Include []string
+			Exclude []string
+		}
+	}
+	Rules struct {
+		Nodes []rulesetRule
+	} `graphql:"rules(first: $rulesPerRuleset)"`
+}
+
+type rulesetsData struct {
+	Nodes []ruleset
+}
+
+// branchesQuery returns the type of a query of the branches at indexes
+// [0, n) of the branchN variables, and of the rulesets of the repository if
+// withRulesets is set. Each branch is an aliased field of the query, so that
+// branches are fetched at once rather than with one query each.
+func branchesQuery(n int, withRulesets bool) reflect.Type {
+	fields := make([]reflect.StructField, 0, n+1)
+	for i := 0; i < n; i++ {
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Branch%d", i),
+			Type: reflect.TypeOf((*branch)(nil)),
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"branch%d: ref(qualifiedName: $branch%d)"`, i, i)),
+		})
+	}
+	if withRulesets {
+		fields = append(fields, reflect.StructField{
+			Name: "Rulesets",
+			Type: reflect.TypeOf(rulesetsData{}),
+			Tag:  `graphql:"rulesets(first: $rulesetsToAnalyze, includeParents: true)"`,
+		})
+	}
+	return reflect.StructOf([]reflect.StructField{{
+		Name: "Repository",
+		Type: reflect.StructOf(fields),
+		Tag:  `graphql:"repository(owner: $owner, name: $name)"`,
+	}})
+}
+
+// getBranches fetches the branches and the rulesets applying to them with
+// as few GraphQL queries as possible. The branches are returned in the order
+// of names, nil for the ones which do not exist. If the batch query fails,
+// e.g. on GitHub Enterprise servers predating rulesets, the branches are
+// fetched one by one instead.
+func (handler *branchesHandler) getBranches(names []string) ([]*clients.BranchRef, error) {
+	if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+		return nil, fmt.Errorf("%w: branches only supported for HEAD queries", clients.ErrUnsupportedFeature)
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	refs, err := handler.queryBranches(names)
+	if err == nil {
+		return refs, nil
+	}
+	refs = make([]*clients.BranchRef, len(names))
+	for i, name := range names {
+		if refs[i], err = handler.query(name); err != nil {
+			return nil, err
+		}
+	}
+	return refs, nil
+}
+
+func (handler *branchesHandler) queryBranches(names []string) ([]*clients.BranchRef, error) {
+	refs := make([]*clients.BranchRef, 0, len(names))
+	var rulesets []ruleset
+	for start := 0; start < len(names); start += branchesPerQuery {
+		end := start + branchesPerQuery
+		if end > len(names) {
+			end = len(names)
+		}
+		withRulesets := start == 0
+		vars := map[string]interface{}{
+			"owner": githubv4.String(handler.repourl.owner),
+			"name":  githubv4.String(handler.repourl.repo),
+		}
+		if withRulesets {
+			vars["rulesetsToAnalyze"] = githubv4.Int(rulesetsToAnalyze)
+			vars["rulesPerRuleset"] = githubv4.Int(rulesPerRuleset)
+		}
+		for i, name := range names[start:end] {
+			vars[fmt.Sprintf("branch%d", i)] = githubv4.String(refPrefix + name)
+		}
+		queryData := reflect.New(branchesQuery(end-start, withRulesets))
+		if err := handler.graphClient.Query(handler.ctx, queryData.Interface(), vars); err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+		}
+		repository := queryData.Elem().Field(0)
+		for i := 0; i < end-start; i++ {
+			data, ok := repository.Field(i).Interface().(*branch)
+			if !ok {
+				return nil, sce.WithMessage(sce.ErrScorecardInternal, "unexpected branch type")
+			}
+			refs = append(refs, getBranchRefFrom(data))
+		}
+		if withRulesets {
+			data, ok := repository.Field(end - start).Interface().(rulesetsData)
+			if !ok {
+				return nil, sce.WithMessage(sce.ErrScorecardInternal, "unexpected rulesets type")
+			}
+			rulesets = data.Nodes
+		}
+	}
+	for _, ref := range refs {
+		if ref == nil || ref.Name == nil {
+			continue
+		}
+		mergeBranchRules(ref, rulesetRulesFor(rulesets, *ref.Name, handler.repourl.defaultBranch))
+	}
+	return refs, nil
+}
+
+// rulesetRulesFor returns the rules of the active branch rulesets targeting
+// the branch, in the form of the REST API.
+func rulesetRulesFor(rulesets []ruleset, branchName, defaultBranch string) []branchRule {
+	var rules []branchRule
+	for i := range rulesets {
+		rs := &rulesets[i]
+		if rs.Enforcement != rulesetEnforcementActive ||
+			(rs.Target != nil && *rs.Target != rulesetTargetBranch) ||
+			!rs.targets(branchName, defaultBranch) {
+			continue
+		}
+		for j := range rs.Rules.Nodes {
+			rules = append(rules, rs.Rules.Nodes[j].asBranchRule())
+		}
+	}
+	return rules
+}
+
+// targets reports whether the ref name conditions of the ruleset include the
+// branch and do not exclude it.
+func (rs *ruleset) targets(branchName, defaultBranch string) bool {
+	refName := rs.Conditions.RefName
+	if refName == nil {
+		return false
+	}
+	matches := func(patterns []string) bool {
+		for _, p := range patterns {
+			switch p {
+			case refNameAll:
+				return true
+			case refNameDefaultBranch:
+				if defaultBranch != "" && branchName == defaultBranch {
+					return true
+				}
+			default:
+				if refNamePattern(p).MatchString(refPrefix + branchName) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return matches(refName.Include) && !matches(refName.Exclude)
+}
+
+// refNamePattern converts an fnmatch pattern of a ruleset condition, e.g.
+// refs/heads/release/**, to a regular expression: `*` does not match `/`,
+// while `**` does.
+func refNamePattern(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			sb.WriteString("[^/]*")
+		case pattern[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+func (r *rulesetRule) asBranchRule() branchRule {
+	var rule branchRule
+	rule.Type = strings.ToLower(r.Type)
+	pr := &r.Parameters.PullRequest
+	rule.Parameters.RequiredApprovingReviewCount = pr.RequiredApprovingReviewCount
+	rule.Parameters.RequireCodeOwnerReview = pr.RequireCodeOwnerReview
+	rule.Parameters.DismissStaleReviewsOnPush = pr.DismissStaleReviewsOnPush
+	rule.Parameters.RequireLastPushApproval = pr.RequireLastPushApproval
+	checks := &r.Parameters.StatusChecks
+	rule.Parameters.StrictStatusChecks = checks.StrictRequiredStatusChecksPolicy
+	for _, c := range checks.RequiredStatusChecks {
+		rule.Parameters.RequiredStatusChecks = append(rule.Parameters.RequiredStatusChecks, struct {
+			Context string `json:"context"`
+		}{Context: c.Context})
+	}
+	return rule
+}
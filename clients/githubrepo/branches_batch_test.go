@@ -0,0 +1,202 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+const testBranchesResponse = `{"data": {"repository": {
+	"branch0": {
+		"name": "main",
+		"refUpdateRule": {"allowsDeletions": false, "allowsForcePushes": false}
+	},
+	"branch1": null,
+	"branch2": {"name": "release/v1", "refUpdateRule": null},
+	"rulesets": {"nodes": [
+		{
+			"enforcement": "ACTIVE",
+			"target": "BRANCH",
+			"conditions": {"refName": {"include": ["refs/heads/release/**"], "exclude": []}},
+			"rules": {"nodes": [
+				{"type": "DELETION", "parameters": null},
+				{
+					"type": "PULL_REQUEST",
+					"parameters": {
+						"requiredApprovingReviewCount": 1,
+						"requireCodeOwnerReview": true,
+						"dismissStaleReviewsOnPush": false,
+						"requireLastPushApproval": false
+					}
+				}
+			]}
+		},
+		{
+			"enforcement": "EVALUATE",
+			"target": "BRANCH",
+			"conditions": {"refName": {"include": ["~ALL"], "exclude": []}},
+			"rules": {"nodes": [{"type": "NON_FAST_FORWARD", "parameters": null}]}
+		}
+	]}
+}}}`
+
+func TestGetBranches(t *testing.T) {
+	t.Parallel()
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Query string }
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("json.Decode: %v", err)
+		}
+		query = body.Query
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(testBranchesResponse)); err != nil {
+			t.Errorf("Write: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	handler := &branchesHandler{
+		graphClient: githubv4.NewEnterpriseClient(server.URL, server.Client()),
+	}
+	handler.init(context.Background(), &repoURL{
+		owner:         "owner",
+		repo:          "repo",
+		defaultBranch: "main",
+		commitSHA:     clients.HeadSHA,
+	})
+	got, err := handler.getBranches([]string{"main", "deleted", "release/v1"})
+	if err != nil {
+		t.Fatalf("getBranches: %v", err)
+	}
+	for _, field := range []string{
+		"branch0: ref(qualifiedName: $branch0)",
+		"branch2: ref(qualifiedName: $branch2)",
+		"rulesets(first: $rulesetsToAnalyze, includeParents: true)",
+	} {
+		if !strings.Contains(query, field) {
+			t.Errorf("query %q does not contain %q", query, field)
+		}
+	}
+
+	trueVal, falseVal := true, false
+	var oneVal int32 = 1
+	main, release := "main", "release/v1"
+	want := []*clients.BranchRef{
+		{
+			Name:      &main,
+			Protected: &trueVal,
+			BranchProtectionRule: clients.BranchProtectionRule{
+				AllowDeletions:   &falseVal,
+				AllowForcePushes: &falseVal,
+				CheckRules: clients.StatusChecksRule{
+					Contexts: []string{},
+				},
+			},
+		},
+		nil,
+		{
+			Name:      &release,
+			Protected: &trueVal,
+			BranchProtectionRule: clients.BranchProtectionRule{
+				AllowDeletions:          &falseVal,
+				RequireLastPushApproval: &falseVal,
+				RequiredPullRequestReviews: clients.PullRequestReviewRule{
+					RequiredApprovingReviewCount: &oneVal,
+					RequireCodeOwnerReviews:      &trueVal,
+					DismissStaleReviews:          &falseVal,
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("getBranches() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRulesetTargets(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		include []string
+		exclude []string
+		branch  string
+		want    bool
+	}{
+		{
+			name:    "all branches",
+			include: []string{"~ALL"},
+			branch:  "feature",
+			want:    true,
+		},
+		{
+			name:    "default branch",
+			include: []string{"~DEFAULT_BRANCH"},
+			branch:  "main",
+			want:    true,
+		},
+		{
+			name:    "not the default branch",
+			include: []string{"~DEFAULT_BRANCH"},
+			branch:  "dev",
+		},
+		{
+			name:    "single segment wildcard",
+			include: []string{"refs/heads/release/*"},
+			branch:  "release/v1/hotfix",
+		},
+		{
+			name:    "multi segment wildcard",
+			include: []string{"refs/heads/release/**"},
+			branch:  "release/v1/hotfix",
+			want:    true,
+		},
+		{
+			name:    "excluded branch",
+			include: []string{"~ALL"},
+			exclude: []string{"refs/heads/dev"},
+			branch:  "dev",
+		},
+		{
+			name:    "pattern is not a regular expression",
+			include: []string{"refs/heads/v1.0"},
+			branch:  "v1x0",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var rs ruleset
+			rs.Conditions.RefName = &struct {
+				Include []string
+				Exclude []string
+			}{Include: tt.include, Exclude: tt.exclude}
+			if got := rs.targets(tt.branch, "main"); got != tt.want {
+				t.Errorf("targets(%q) = %v, want %v", tt.branch, got, tt.want)
+			}
+		})
+	}
+}
@@ -169,9 +169,10 @@ func checkRunsFrom(data *github.ListCheckRunsResults) []clients.CheckRun {
 	var checkRuns []clients.CheckRun
 	for _, checkRun := range data.CheckRuns {
 		checkRuns = append(checkRuns, clients.CheckRun{
-			Status:     checkRun.GetStatus(),
-			Conclusion: checkRun.GetConclusion(),
-			URL:        checkRun.GetURL(),
+			Status:      checkRun.GetStatus(),
+			Conclusion:  checkRun.GetConclusion(),
+			URL:         checkRun.GetURL(),
+			CompletedAt: checkRun.GetCompletedAt().Time,
 			App: clients.CheckRunApp{
 				Slug: checkRun.GetApp().GetSlug(),
 			},
@@ -52,11 +52,18 @@ type Client struct {
 	search        *searchHandler
 	searchCommits *searchCommitsHandler
 	webhook       *webhookHandler
+	actionsPolicy *actionsPolicyHandler
+	alerts        *securityAlertsHandler
+	environments  *environmentsHandler
+	auditLog      *auditLogHandler
+	forcePushes   *forcePushesHandler
 	languages     *languagesHandler
 	licenses      *licensesHandler
 	ctx           context.Context
 	tarball       tarballHandler
-	commitDepth   int
+	// tree replaces tarball in shallow clients.
+	tree        *treeHandler
+	commitDepth int
 }
 
 // InitRepo sets up the GitHub repo in local storage for improving performance and GitHub token usage efficiency.
@@ -67,12 +74,16 @@ func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string, commitD
 	}
 
 	// Sanity check.
-	repo, _, err := client.repoClient.Repositories.Get(client.ctx, ghRepo.owner, ghRepo.repo)
+	repo, resp, err := client.repoClient.Repositories.Get(client.ctx, ghRepo.owner, ghRepo.repo)
 	if err != nil {
+		// Fail fast rather than scoring the repo with 403s from every API.
+		if ssoErr := ssoError(resp, err); ssoErr != nil {
+			return sce.WithMessage(sce.ErrRepoUnreachable, ssoErr.Error())
+		}
 		return sce.WithMessage(sce.ErrRepoUnreachable, err.Error())
 	}
 	if commitDepth <= 0 {
-		client.commitDepth = 30 // default
+		client.commitDepth = clients.DefaultCommitDepth
 	} else {
 		client.commitDepth = commitDepth
 	}
@@ -84,8 +95,12 @@ func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string, commitD
 		commitSHA:     commitSHA,
 	}
 
-	// Init tarballHandler.
-	client.tarball.init(client.ctx, client.repo, commitSHA)
+	// Init tarballHandler, or treeHandler for shallow clients.
+	if client.tree != nil {
+		client.tree.init(client.ctx, client.repourl, commitSHA)
+	} else {
+		client.tarball.init(client.ctx, client.repo, commitSHA)
+	}
 
 	// Setup GraphQL.
 	client.graphClient.init(client.ctx, client.repourl, client.commitDepth)
@@ -103,7 +118,7 @@ func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string, commitD
 	client.workflows.init(client.ctx, client.repourl)
 
 	// Setup checkrunsHandler.
-	client.checkruns.init(client.ctx, client.repourl, commitDepth)
+	client.checkruns.init(client.ctx, client.repourl, client.commitDepth)
 
 	// Setup statusesHandler.
 	client.statuses.init(client.ctx, client.repourl)
@@ -117,6 +132,21 @@ func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string, commitD
 	// Setup webhookHandler.
 	client.webhook.init(client.ctx, client.repourl)
 
+	// Setup actionsPolicyHandler.
+	client.actionsPolicy.init(client.ctx, client.repourl)
+
+	// Setup securityAlertsHandler.
+	client.alerts.init(client.ctx, client.repourl)
+
+	// Setup environmentsHandler.
+	client.environments.init(client.ctx, client.repourl)
+
+	// Setup auditLogHandler.
+	client.auditLog.init(client.ctx, client.repourl, repo.GetOwner().GetType() == "Organization")
+
+	// Setup forcePushesHandler.
+	client.forcePushes.init(client.ctx, client.repourl)
+
 	// Setup languagesHandler.
 	client.languages.init(client.ctx, client.repourl)
 
@@ -132,16 +162,25 @@ func (client *Client) URI() string {
 
 // LocalPath implements RepoClient.LocalPath.
 func (client *Client) LocalPath() (string, error) {
+	if client.tree != nil {
+		return client.tree.getLocalPath()
+	}
 	return client.tarball.getLocalPath()
 }
 
 // ListFiles implements RepoClient.ListFiles.
 func (client *Client) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
+	if client.tree != nil {
+		return client.tree.listFiles(predicate)
+	}
 	return client.tarball.listFiles(predicate)
 }
 
 // GetFileContent implements RepoClient.GetFileContent.
 func (client *Client) GetFileContent(filename string) ([]byte, error) {
+	if client.tree != nil {
+		return client.tree.getFileContent(filename)
+	}
 	return client.tarball.getFileContent(filename)
 }
 
@@ -161,6 +200,11 @@ func (client *Client) ListReleases() ([]clients.Release, error) {
 	return client.releases.getReleases()
 }
 
+// GetReleaseAsset implements RepoClient.GetReleaseAsset.
+func (client *Client) GetReleaseAsset(asset *clients.ReleaseAsset) ([]byte, error) {
+	return client.releases.getAsset(asset)
+}
+
 // ListContributors implements RepoClient.ListContributors.
 func (client *Client) ListContributors() ([]clients.User, error) {
 	return client.contributors.getContributors()
@@ -171,6 +215,30 @@ func (client *Client) IsArchived() (bool, error) {
 	return client.graphClient.isArchived()
 }
 
+// GetSecurityPolicyURL implements RepoClient.GetSecurityPolicyURL.
+func (client *Client) GetSecurityPolicyURL() (string, error) {
+	return client.graphClient.getSecurityPolicyURL()
+}
+
+// IsPrivate implements RepoClient.IsPrivate.
+func (client *Client) IsPrivate() (bool, error) {
+	return client.repo.GetPrivate(), nil
+}
+
+// GetForkParent implements RepoClient.GetForkParent.
+func (client *Client) GetForkParent() (clients.Repo, error) {
+	if !client.repo.GetFork() || client.repo.GetParent() == nil {
+		return nil, nil
+	}
+	parent := client.repo.GetParent()
+	return &repoURL{
+		host:      "github.com",
+		owner:     parent.GetOwner().GetLogin(),
+		repo:      parent.GetName(),
+		commitSHA: clients.HeadSHA,
+	}, nil
+}
+
 // GetDefaultBranch implements RepoClient.GetDefaultBranch.
 func (client *Client) GetDefaultBranch() (*clients.BranchRef, error) {
 	return client.branches.getDefaultBranch()
@@ -190,6 +258,16 @@ func (client *Client) GetBranch(branch string) (*clients.BranchRef, error) {
 	return client.branches.getBranch(branch)
 }
 
+// GetBranches implements RepoClient.GetBranches.
+func (client *Client) GetBranches(branches []string) ([]*clients.BranchRef, error) {
+	return client.branches.getBranches(branches)
+}
+
+// ListBranches implements RepoClient.ListBranches.
+func (client *Client) ListBranches() ([]clients.BranchInfo, error) {
+	return client.branches.listBranches()
+}
+
 // GetCreatedAt is a getter for repo.CreatedAt.
 func (client *Client) GetCreatedAt() (time.Time, error) {
 	return client.repo.CreatedAt.Time, nil
@@ -200,6 +278,36 @@ func (client *Client) ListWebhooks() ([]clients.Webhook, error) {
 	return client.webhook.listWebhooks()
 }
 
+// GetActionsPolicy implements RepoClient.GetActionsPolicy.
+func (client *Client) GetActionsPolicy() (*clients.ActionsPolicy, error) {
+	return client.actionsPolicy.getActionsPolicy()
+}
+
+// ListDependencyAlerts implements RepoClient.ListDependencyAlerts.
+func (client *Client) ListDependencyAlerts() ([]clients.SecurityAlert, error) {
+	return client.alerts.listDependencyAlerts()
+}
+
+// ListCodeScanningAlerts implements RepoClient.ListCodeScanningAlerts.
+func (client *Client) ListCodeScanningAlerts() ([]clients.SecurityAlert, error) {
+	return client.alerts.listCodeScanningAlerts()
+}
+
+// ListEnvironments implements RepoClient.ListEnvironments.
+func (client *Client) ListEnvironments() ([]clients.Environment, error) {
+	return client.environments.listEnvironments()
+}
+
+// ListAuditLogEvents implements RepoClient.ListAuditLogEvents.
+func (client *Client) ListAuditLogEvents() ([]clients.AuditLogEvent, error) {
+	return client.auditLog.listEvents()
+}
+
+// ListForcePushes implements RepoClient.ListForcePushes.
+func (client *Client) ListForcePushes() ([]clients.ForcePush, error) {
+	return client.forcePushes.listForcePushes()
+}
+
 // ListSuccessfulWorkflowRuns implements RepoClient.WorkflowRunsByFilename.
 func (client *Client) ListSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
 	return client.workflows.listSuccessfulWorkflowRuns(filename)
@@ -240,8 +348,25 @@ func (client *Client) Close() error {
 	return client.tarball.cleanup()
 }
 
+// GithubClient returns the REST client used by the Client. Requests made with it
+// share the transport of the Client, including its rate limiting and tokens,
+// and are not scoped to the repo passed to InitRepo.
+func (client *Client) GithubClient() *github.Client {
+	return client.repoClient
+}
+
+// GraphQLClient returns the GraphQL client used by the Client. Like GithubClient,
+// it shares the transport of the Client.
+func (client *Client) GraphQLClient() *githubv4.Client {
+	return client.graphClient.client
+}
+
 // CreateGithubRepoClientWithTransport returns a Client which implements RepoClient interface.
 func CreateGithubRepoClientWithTransport(ctx context.Context, rt http.RoundTripper) clients.RepoClient {
+	return newClient(ctx, rt)
+}
+
+func newClient(ctx context.Context, rt http.RoundTripper) *Client {
 	httpClient := &http.Client{
 		Transport: rt,
 	}
@@ -283,6 +408,21 @@ func CreateGithubRepoClientWithTransport(ctx context.Context, rt http.RoundTripp
 		webhook: &webhookHandler{
 			ghClient: client,
 		},
+		actionsPolicy: &actionsPolicyHandler{
+			ghClient: client,
+		},
+		alerts: &securityAlertsHandler{
+			ghClient: client,
+		},
+		environments: &environmentsHandler{
+			ghClient: client,
+		},
+		auditLog: &auditLogHandler{
+			ghClient: client,
+		},
+		forcePushes: &forcePushesHandler{
+			ghClient: client,
+		},
 		languages: &languagesHandler{
 			ghclient: client,
 		},
@@ -302,6 +442,16 @@ func CreateGithubRepoClient(ctx context.Context, logger *log.Logger) clients.Rep
 	return CreateGithubRepoClientWithTransport(ctx, rt)
 }
 
+// CreateShallowGithubRepoClient returns a Client which lists the files of
+// repos from their git tree and fetches the content of files only when asked
+// for, instead of downloading the repos. LocalPath is not supported.
+func CreateShallowGithubRepoClient(ctx context.Context, logger *log.Logger) clients.RepoClient {
+	rt := roundtripper.NewTransport(ctx, logger)
+	client := newClient(ctx, rt)
+	client.tree = &treeHandler{ghClient: client.repoClient}
+	return client
+}
+
 // CreateOssFuzzRepoClient returns a RepoClient implementation
 // intialized to `google/oss-fuzz` GitHub repository.
 //
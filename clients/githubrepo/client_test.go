@@ -0,0 +1,79 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package githubrepo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type countingTransport struct {
+	paths []string
+	mu    sync.Mutex
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	c.paths = append(c.paths, r.URL.Path)
+	c.mu.Unlock()
+	body := `{"name": "scorecard"}`
+	if r.URL.Path == "/graphql" {
+		body = `{"data": {"viewer": {"login": "octocat"}}}`
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func TestClientAccessorsShareTransport(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	rt := &countingTransport{}
+	client, ok := CreateGithubRepoClientWithTransport(ctx, rt).(*Client)
+	if !ok {
+		t.Fatal("CreateGithubRepoClientWithTransport did not return a *Client")
+	}
+
+	repo, _, err := client.GithubClient().Repositories.Get(ctx, "ossf", "scorecard")
+	if err != nil {
+		t.Fatalf("Repositories.Get: %v", err)
+	}
+	if repo.GetName() != "scorecard" {
+		t.Errorf("Repositories.Get() name = %q, want %q", repo.GetName(), "scorecard")
+	}
+
+	var query struct {
+		Viewer struct {
+			Login string
+		}
+	}
+	if err := client.GraphQLClient().Query(ctx, &query, nil); err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if query.Viewer.Login != "octocat" {
+		t.Errorf("Query() login = %q, want %q", query.Viewer.Login, "octocat")
+	}
+
+	want := []string{"/repos/ossf/scorecard", "/graphql"}
+	if len(rt.paths) != len(want) || rt.paths[0] != want[0] || rt.paths[1] != want[1] {
+		t.Errorf("transport saw %v, want %v", rt.paths, want)
+	}
+}
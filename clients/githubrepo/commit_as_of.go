@@ -0,0 +1,80 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+// ErrNoCommitAsOf is returned by CommitAsOf when the default branch has no
+// commits before the date.
+var ErrNoCommitAsOf = errors.New("no commit on the default branch before the date")
+
+type commitAsOfQuery struct {
+	Repository struct {
+		DefaultBranchRef *struct {
+			Target struct {
+				Commit struct {
+					History struct {
+						Nodes []struct {
+							Oid githubv4.GitObjectID
+						}
+					} `graphql:"history(first: 1, until: $until)"`
+				} `graphql:"... on Commit"`
+			}
+		}
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// CommitAsOf returns the SHA of the last commit of the default branch of the
+// GitHub repo committed before asOf, i.e. the commit the branch was at then.
+func CommitAsOf(ctx context.Context, logger *log.Logger, repo string, asOf time.Time) (string, error) {
+	rt := roundtripper.NewTransport(ctx, logger)
+	return commitAsOf(ctx, githubv4.NewClient(&http.Client{Transport: rt}), repo, asOf)
+}
+
+func commitAsOf(ctx context.Context, client *githubv4.Client, repo string, asOf time.Time) (string, error) {
+	var r repoURL
+	if err := r.parse(repo); err != nil {
+		return "", err
+	}
+	if err := r.IsValid(); err != nil {
+		return "", err
+	}
+	var query commitAsOfQuery
+	vars := map[string]interface{}{
+		"owner": githubv4.String(r.owner),
+		"name":  githubv4.String(r.repo),
+		"until": githubv4.GitTimestamp{Time: asOf},
+	}
+	if err := client.Query(ctx, &query, vars); err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+	}
+	branch := query.Repository.DefaultBranchRef
+	if branch == nil || len(branch.Target.Commit.History.Nodes) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrNoCommitAsOf, asOf.Format(time.RFC3339))
+	}
+	return string(branch.Target.Commit.History.Nodes[0].Oid), nil
+}
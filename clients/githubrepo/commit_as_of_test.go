@@ -0,0 +1,86 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shurcooL/githubv4"
+)
+
+func TestCommitAsOf(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		wantErr  error
+		name     string
+		response string
+		want     string
+	}{
+		{
+			name:     "commit before the date",
+			response: `{"data": {"repository": {"defaultBranchRef": {"target": {"history": {"nodes": [{"oid": "abc123"}]}}}}}}`,
+			want:     "abc123",
+		},
+		{
+			name:     "repo created after the date",
+			response: `{"data": {"repository": {"defaultBranchRef": {"target": {"history": {"nodes": []}}}}}}`,
+			wantErr:  ErrNoCommitAsOf,
+		},
+		{
+			name:     "empty repo",
+			response: `{"data": {"repository": {"defaultBranchRef": null}}}`,
+			wantErr:  ErrNoCommitAsOf,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Variables map[string]string `json:"variables"`
+					Query     string            `json:"query"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("decoding the query: %v", err)
+				}
+				if !strings.Contains(body.Query, "history(first: 1, until: $until)") ||
+					body.Variables["owner"] != "owner" || body.Variables["until"] != "2023-01-01T00:00:00Z" {
+					t.Errorf("unexpected query %q with %v", body.Query, body.Variables)
+				}
+				fmt.Fprint(w, tt.response)
+			}))
+			defer srv.Close()
+
+			client := githubv4.NewEnterpriseClient(srv.URL, srv.Client())
+			got, err := commitAsOf(context.Background(), client, "github.com/owner/repo",
+				time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("commitAsOf() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("commitAsOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
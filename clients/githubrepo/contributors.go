@@ -32,6 +32,9 @@ type contributorsHandler struct {
 	errSetup     error
 	repourl      *repoURL
 	contributors []clients.User
+	// orgs caches whether a handle names an existing organization, as the
+	// same handles are typically claimed by many contributors.
+	orgs map[string]bool
 }
 
 func (handler *contributorsHandler) init(ctx context.Context, repourl *repoURL) {
@@ -40,6 +43,7 @@ func (handler *contributorsHandler) init(ctx context.Context, repourl *repoURL)
 	handler.errSetup = nil
 	handler.once = new(sync.Once)
 	handler.contributors = nil
+	handler.orgs = make(map[string]bool)
 }
 
 func (handler *contributorsHandler) setup() error {
@@ -77,6 +81,13 @@ func (handler *contributorsHandler) setup() error {
 				handler.errSetup = fmt.Errorf("error during Users.Get: %w", err)
 			}
 			contributor.Companies = append(contributor.Companies, user.GetCompany())
+			for _, handle := range orgHandles(user.GetCompany()) {
+				if handler.isVerifiedMember(handle, contributor) {
+					contributor.Organizations = append(contributor.Organizations, clients.User{
+						Login: handle,
+					})
+				}
+			}
 			handler.contributors = append(handler.contributors, contributor)
 		}
 		handler.errSetup = nil
@@ -90,3 +101,44 @@ func (handler *contributorsHandler) getContributors() ([]clients.User, error) {
 	}
 	return handler.contributors, nil
 }
+
+// isVerifiedMember reports whether the contributor is a member of the
+// organization named by a handle claimed in its company field. Organizations
+// the contributor is a public member of are already listed.
+func (handler *contributorsHandler) isVerifiedMember(handle string, contributor clients.User) bool {
+	for _, org := range contributor.Organizations {
+		if strings.EqualFold(org.Login, handle) {
+			return false
+		}
+	}
+	exists, ok := handler.orgs[handle]
+	if !ok {
+		_, _, err := handler.ghClient.Organizations.Get(handler.ctx, handle)
+		exists = err == nil
+		handler.orgs[handle] = exists
+	}
+	if !exists {
+		return false
+	}
+	// This call can fail due to token scopes. So ignore error.
+	member, _, err := handler.ghClient.Organizations.IsMember(handler.ctx, handle, contributor.Login)
+	return err == nil && member
+}
+
+// orgHandles returns the organization handles, e.g. "@ossf", claimed in a
+// company field.
+func orgHandles(company string) []string {
+	var handles []string
+	fields := strings.FieldsFunc(company, func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';' || r == '/'
+	})
+	for _, f := range fields {
+		if !strings.HasPrefix(f, "@") {
+			continue
+		}
+		if handle := strings.ToLower(strings.Trim(f, "@.")); handle != "" {
+			handles = append(handles, handle)
+		}
+	}
+	return handles
+}
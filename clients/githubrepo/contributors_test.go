@@ -0,0 +1,45 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOrgHandles(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		company string
+		want    []string
+	}{
+		{company: "", want: nil},
+		{company: "Example Inc.", want: nil},
+		{company: "@ossf", want: []string{"ossf"}},
+		{company: "@Google, @kubernetes.", want: []string{"google", "kubernetes"}},
+		{company: "Red Hat / @openshift", want: []string{"openshift"}},
+		{company: "@", want: nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.company, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.want, orgHandles(tt.company)); diff != "" {
+				t.Errorf("orgHandles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
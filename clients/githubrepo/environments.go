@@ -0,0 +1,117 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+const (
+	protectionRuleRequiredReviewers = "required_reviewers"
+	protectionRuleWaitTimer         = "wait_timer"
+)
+
+type environmentsHandler struct {
+	ghClient     *github.Client
+	once         *sync.Once
+	ctx          context.Context
+	errSetup     error
+	repourl      *repoURL
+	environments []clients.Environment
+}
+
+func (handler *environmentsHandler) init(ctx context.Context, repourl *repoURL) {
+	handler.ctx = ctx
+	handler.repourl = repourl
+	handler.errSetup = nil
+	handler.once = new(sync.Once)
+	handler.environments = nil
+}
+
+func (handler *environmentsHandler) setup() error {
+	handler.once.Do(func() {
+		if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+			handler.errSetup = fmt.Errorf("%w: ListEnvironments only supported for HEAD queries", clients.ErrUnsupportedFeature)
+			return
+		}
+		resp, ghResp, err := handler.ghClient.Repositories.ListEnvironments(
+			handler.ctx, handler.repourl.owner, handler.repourl.repo)
+		if ssoErr := ssoError(ghResp, err); ssoErr != nil {
+			handler.errSetup = fmt.Errorf("error during ListEnvironments: %w", ssoErr)
+			return
+		}
+		if err != nil {
+			handler.errSetup = fmt.Errorf("error during ListEnvironments: %w", err)
+			return
+		}
+		handler.environments = []clients.Environment{}
+		for _, env := range resp.Environments {
+			e := environmentFrom(env)
+			e.Secrets = handler.countSecrets(e.Name)
+			handler.environments = append(handler.environments, e)
+		}
+	})
+	return handler.errSetup
+}
+
+// countSecrets returns the number of secrets of the environment, or nil if
+// the token is not allowed to list them.
+func (handler *environmentsHandler) countSecrets(env string) *int {
+	u := fmt.Sprintf("repos/%s/%s/environments/%s/secrets?per_page=1",
+		handler.repourl.owner, handler.repourl.repo, url.PathEscape(env))
+	req, err := handler.ghClient.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil
+	}
+	var secrets github.Secrets
+	if _, err := handler.ghClient.Do(handler.ctx, req, &secrets); err != nil {
+		return nil
+	}
+	return &secrets.TotalCount
+}
+
+func environmentFrom(env *github.Environment) clients.Environment {
+	ret := clients.Environment{
+		Name: env.GetName(),
+	}
+	for _, rule := range env.ProtectionRules {
+		switch rule.GetType() {
+		case protectionRuleRequiredReviewers:
+			ret.RequiredReviewers = len(rule.Reviewers)
+		case protectionRuleWaitTimer:
+			ret.WaitTimer = rule.GetWaitTimer()
+		}
+	}
+	if env.DeploymentBranchPolicy != nil {
+		ret.ProtectedBranches = env.DeploymentBranchPolicy.GetProtectedBranches()
+		ret.CustomBranchPolicies = env.DeploymentBranchPolicy.GetCustomBranchPolicies()
+	}
+	return ret
+}
+
+func (handler *environmentsHandler) listEnvironments() ([]clients.Environment, error) {
+	if err := handler.setup(); err != nil {
+		return nil, fmt.Errorf("error during environmentsHandler.setup: %w", err)
+	}
+	return handler.environments, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// forcePushesPerPage caps the force pushes listed. Only the first page of
+// the activity of the last quarter is read.
+const forcePushesPerPage = 100
+
+// activityEvent is an event of the activity of a repo, which go-github
+// does not support.
+type activityEvent struct {
+	Timestamp    time.Time `json:"timestamp"`
+	Before       string    `json:"before"`
+	After        string    `json:"after"`
+	Ref          string    `json:"ref"`
+	ActivityType string    `json:"activity_type"`
+	Actor        struct {
+		Login string `json:"login"`
+	} `json:"actor"`
+}
+
+type forcePushesHandler struct {
+	ghClient    *github.Client
+	once        *sync.Once
+	ctx         context.Context
+	errSetup    error
+	repourl     *repoURL
+	forcePushes []clients.ForcePush
+}
+
+func (handler *forcePushesHandler) init(ctx context.Context, repourl *repoURL) {
+	handler.ctx = ctx
+	handler.repourl = repourl
+	handler.errSetup = nil
+	handler.once = new(sync.Once)
+	handler.forcePushes = nil
+}
+
+func (handler *forcePushesHandler) setup() error {
+	handler.once.Do(func() {
+		query := url.Values{}
+		query.Set("activity_type", "force_push")
+		query.Set("time_period", "quarter")
+		query.Set("per_page", fmt.Sprint(forcePushesPerPage))
+		reqURL := path.Join("repos", handler.repourl.owner, handler.repourl.repo, "activity") + "?" + query.Encode()
+		req, err := handler.ghClient.NewRequest(http.MethodGet, reqURL, nil)
+		if err != nil {
+			handler.errSetup = fmt.Errorf("request for %s failed with %w", reqURL, err)
+			return
+		}
+		var activity []activityEvent
+		resp, err := handler.ghClient.Do(handler.ctx, req, &activity)
+		if err != nil {
+			if ssoErr := ssoError(resp, err); ssoErr != nil {
+				handler.errSetup = fmt.Errorf("%s: %w", reqURL, ssoErr)
+				return
+			}
+			// GitHub Enterprise Server may not have the activity API yet.
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				handler.errSetup = fmt.Errorf("%w: %s: %v", clients.ErrUnsupportedFeature, reqURL, err)
+				return
+			}
+			handler.errSetup = fmt.Errorf("response for %s failed with %w", reqURL, err)
+			return
+		}
+		handler.forcePushes = []clients.ForcePush{}
+		for i := range activity {
+			a := &activity[i]
+			if a.ActivityType != "force_push" || !strings.HasPrefix(a.Ref, "refs/heads/") {
+				continue
+			}
+			handler.forcePushes = append(handler.forcePushes, clients.ForcePush{
+				CreatedAt: a.Timestamp,
+				Actor:     a.Actor.Login,
+				Branch:    strings.TrimPrefix(a.Ref, "refs/heads/"),
+				Before:    a.Before,
+				After:     a.After,
+			})
+		}
+	})
+	return handler.errSetup
+}
+
+func (handler *forcePushesHandler) listForcePushes() ([]clients.ForcePush, error) {
+	if err := handler.setup(); err != nil {
+		return nil, fmt.Errorf("error during forcePushesHandler.setup: %w", err)
+	}
+	return handler.forcePushes, nil
+}
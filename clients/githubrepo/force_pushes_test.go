@@ -0,0 +1,69 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+func TestListForcePushes(t *testing.T) {
+	t.Parallel()
+	rt := &auditLogTransport{
+		pages: map[string]string{
+			"": `[
+				{"timestamp": "2023-05-01T12:00:00Z", "before": "aaa", "after": "bbb",
+				 "ref": "refs/heads/main", "activity_type": "force_push", "actor": {"login": "admin"}},
+				{"timestamp": "2023-05-02T12:00:00Z", "before": "ccc", "after": "ddd",
+				 "ref": "refs/tags/v1", "activity_type": "force_push", "actor": {"login": "admin"}}
+			]`,
+		},
+	}
+	handler := &forcePushesHandler{ghClient: github.NewClient(&http.Client{Transport: rt})}
+	handler.init(context.Background(), &repoURL{owner: "owner", repo: "repo"})
+
+	got, err := handler.listForcePushes()
+	if err != nil {
+		t.Fatalf("listForcePushes: %v", err)
+	}
+	want := []clients.ForcePush{{
+		CreatedAt: time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+		Actor:     "admin",
+		Branch:    "main",
+		Before:    "aaa",
+		After:     "bbb",
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listForcePushes() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestListForcePushesUnsupported(t *testing.T) {
+	t.Parallel()
+	rt := &auditLogTransport{status: http.StatusNotFound}
+	handler := &forcePushesHandler{ghClient: github.NewClient(&http.Client{Transport: rt})}
+	handler.init(context.Background(), &repoURL{owner: "owner", repo: "repo"})
+	if _, err := handler.listForcePushes(); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("listForcePushes() = %v, want %v", err, clients.ErrUnsupportedFeature)
+	}
+}
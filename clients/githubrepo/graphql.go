@@ -39,8 +39,10 @@ const (
 //nolint:govet
 type graphqlData struct {
 	Repository struct {
-		IsArchived githubv4.Boolean
-		Object     struct {
+		IsArchived              githubv4.Boolean
+		IsSecurityPolicyEnabled *bool
+		SecurityPolicyURL       *githubv4.URI `graphql:"securityPolicyUrl"`
+		Object                  struct {
 			Commit struct {
 				History struct {
 					Nodes []struct {
@@ -48,7 +50,8 @@ type graphqlData struct {
 						Message       githubv4.String
 						Oid           githubv4.GitObjectID
 						Author        struct {
-							User struct {
+							Email *string
+							User  struct {
 								Login githubv4.String
 							}
 						}
@@ -59,8 +62,17 @@ type graphqlData struct {
 							}
 						}
 						Signature struct {
+							Typename          githubv4.String `graphql:"__typename"`
 							IsValid           bool
 							WasSignedByGitHub bool
+							Smime             struct {
+								Issuer struct {
+									Organization *string
+								}
+							} `graphql:"... on SmimeSignature"`
+						}
+						StatusCheckRollup *struct {
+							State githubv4.String
 						}
 						AssociatedPullRequests struct {
 							Nodes []struct {
@@ -131,16 +143,17 @@ type graphqlData struct {
 }
 
 type graphqlHandler struct {
-	client      *githubv4.Client
-	data        *graphqlData
-	setupOnce   *sync.Once
-	ctx         context.Context
-	errSetup    error
-	repourl     *repoURL
-	commits     []clients.Commit
-	issues      []clients.Issue
-	archived    bool
-	commitDepth int
+	client            *githubv4.Client
+	data              *graphqlData
+	setupOnce         *sync.Once
+	ctx               context.Context
+	errSetup          error
+	repourl           *repoURL
+	commits           []clients.Commit
+	issues            []clients.Issue
+	archived          bool
+	securityPolicyURL string
+	commitDepth       int
 }
 
 func (handler *graphqlHandler) init(ctx context.Context, repourl *repoURL, commitDepth int) {
@@ -199,6 +212,7 @@ func (handler *graphqlHandler) setup() error {
 			handler.commits, handler.errSetup = populateCommits(handler, vars)
 			handler.issues = issuesFrom(handler.data)
 			handler.archived = bool(handler.data.Repository.IsArchived)
+			handler.securityPolicyURL = securityPolicyURLFrom(handler.data)
 			return
 		}
 		if err := handler.client.Query(handler.ctx, handler.data, vars); err != nil {
@@ -208,6 +222,7 @@ func (handler *graphqlHandler) setup() error {
 		handler.commits, handler.errSetup = commitsFrom(handler.data, handler.repourl.owner, handler.repourl.repo)
 		handler.issues = issuesFrom(handler.data)
 		handler.archived = bool(handler.data.Repository.IsArchived)
+		handler.securityPolicyURL = securityPolicyURLFrom(handler.data)
 	})
 	return handler.errSetup
 }
@@ -239,7 +254,77 @@ func (handler *graphqlHandler) isArchived() (bool, error) {
 	return handler.archived, nil
 }
 
+func (handler *graphqlHandler) getSecurityPolicyURL() (string, error) {
+	if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+		return "", fmt.Errorf("%w: GetSecurityPolicyURL only supported for HEAD queries", clients.ErrUnsupportedFeature)
+	}
+	if err := handler.setup(); err != nil {
+		return "", fmt.Errorf("error during graphqlHandler.setup: %w", err)
+	}
+	return handler.securityPolicyURL, nil
+}
+
+func securityPolicyURLFrom(data *graphqlData) string {
+	repo := &data.Repository
+	if repo.IsSecurityPolicyEnabled == nil || !*repo.IsSecurityPolicyEnabled ||
+		repo.SecurityPolicyURL == nil || repo.SecurityPolicyURL.URL == nil {
+		return ""
+	}
+	return repo.SecurityPolicyURL.String()
+}
+
+// commitAuthorFrom returns the author of a commit. GitHub only links a commit
+// to a user when its author address is one of the user's verified emails, so
+// the address is dropped for commits not linked to a user.
+func commitAuthorFrom(email *string, login string) clients.User {
+	author := clients.User{Login: login}
+	if login != "" && email != nil {
+		author.Email = *email
+	}
+	return author
+}
+
 // nolint
+// sigstoreIssuer is the organization of the certificates issued by Sigstore's
+// Fulcio, which gitsign uses for keyless signatures.
+const sigstoreIssuer = "sigstore.dev"
+
+// signatureTypeFrom maps the GraphQL type of a commit signature to its type.
+func signatureTypeFrom(typename string, signedByGitHub bool, issuer *string) clients.SignatureType {
+	switch {
+	case signedByGitHub:
+		return clients.SignatureTypeWebFlow
+	case typename == "GpgSignature":
+		return clients.SignatureTypeGPG
+	case typename == "SshSignature":
+		return clients.SignatureTypeSSH
+	case typename == "SmimeSignature" && issuer != nil && *issuer == sigstoreIssuer:
+		return clients.SignatureTypeGitsign
+	case typename == "SmimeSignature":
+		return clients.SignatureTypeX509
+	default:
+		return clients.SignatureTypeNone
+	}
+}
+
+// ciStatusFrom returns the combined status of the checks and statuses of a
+// commit. Expected checks which did not report yet count as pending.
+func ciStatusFrom(rollup *struct{ State githubv4.String }) clients.CIStatus {
+	if rollup == nil {
+		return clients.CIStatusUnknown
+	}
+	switch rollup.State {
+	case "SUCCESS":
+		return clients.CIStatusSuccess
+	case "FAILURE", "ERROR":
+		return clients.CIStatusFailure
+	case "PENDING", "EXPECTED":
+		return clients.CIStatusPending
+	default:
+		return clients.CIStatusUnknown
+	}
+}
+
 func commitsFrom(data *graphqlData, repoOwner, repoName string) ([]clients.Commit, error) {
 	ret := make([]clients.Commit, 0)
 	for _, commit := range data.Repository.Object.Commit.History.Nodes {
@@ -304,6 +389,14 @@ func commitsFrom(data *graphqlData, repoOwner, repoName string) ([]clients.Commi
 				Login: committer,
 			},
 			AssociatedMergeRequest: associatedPR,
+			Author:                 commitAuthorFrom(commit.Author.Email, string(commit.Author.User.Login)),
+			CoAuthors:              clients.CoAuthorsFrom(string(commit.Message)),
+			Signature: clients.CommitSignature{
+				Type: signatureTypeFrom(string(commit.Signature.Typename), commit.Signature.WasSignedByGitHub,
+					commit.Signature.Smime.Issuer.Organization),
+				Valid: commit.Signature.IsValid,
+			},
+			CIStatus: ciStatusFrom(commit.StatusCheckRollup),
 		})
 	}
 	return ret, nil
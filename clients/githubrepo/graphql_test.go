@@ -0,0 +1,128 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+const testCommitsResponse = `{"data": {"repository": {"object": {"history": {"nodes": [
+	{
+		"oid": "a1",
+		"message": "Add parser\n\nCo-authored-by: Jane Doe <jane@example.com>",
+		"signature": {"__typename": "SmimeSignature", "isValid": true, "wasSignedByGitHub": false,
+			"issuer": {"organization": "sigstore.dev"}},
+		"statusCheckRollup": {"state": "SUCCESS"}
+	},
+	{
+		"oid": "b2",
+		"message": "Merge pull request #1",
+		"committer": {"name": "GitHub"},
+		"signature": {"__typename": "GpgSignature", "isValid": true, "wasSignedByGitHub": true},
+		"statusCheckRollup": {"state": "FAILURE"}
+	},
+	{
+		"oid": "c3",
+		"message": "Fix typo",
+		"signature": null,
+		"statusCheckRollup": null
+	}
+]}}}}}`
+
+func TestCommitsFromMetadata(t *testing.T) {
+	t.Parallel()
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Query string }
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("json.Decode: %v", err)
+		}
+		query = body.Query
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(testCommitsResponse)); err != nil {
+			t.Errorf("Write: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	var data graphqlData
+	client := githubv4.NewEnterpriseClient(server.URL, server.Client())
+	vars := map[string]interface{}{
+		"owner":                  githubv4.String("foo"),
+		"name":                   githubv4.String("bar"),
+		"pullRequestsToAnalyze":  githubv4.Int(1),
+		"issuesToAnalyze":        githubv4.Int(1),
+		"issueCommentsToAnalyze": githubv4.Int(1),
+		"reviewsToAnalyze":       githubv4.Int(1),
+		"labelsToAnalyze":        githubv4.Int(1),
+		"commitsToAnalyze":       githubv4.Int(3),
+		"commitExpression":       githubv4.String("HEAD"),
+		"historyCursor":          (*githubv4.String)(nil),
+	}
+	if err := client.Query(context.Background(), &data, vars); err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	for _, field := range []string{"__typename", "... on SmimeSignature", "statusCheckRollup"} {
+		if !strings.Contains(query, field) {
+			t.Errorf("query does not request %q: %s", field, query)
+		}
+	}
+
+	commits, err := commitsFrom(&data, "foo", "bar")
+	if err != nil {
+		t.Fatalf("commitsFrom: %v", err)
+	}
+	type metadata struct {
+		CoAuthors []clients.CoAuthor
+		Signature clients.CommitSignature
+		CIStatus  clients.CIStatus
+		Committer string
+	}
+	var got []metadata
+	for i := range commits {
+		got = append(got, metadata{
+			CoAuthors: commits[i].CoAuthors,
+			Signature: commits[i].Signature,
+			CIStatus:  commits[i].CIStatus,
+			Committer: commits[i].Committer.Login,
+		})
+	}
+	want := []metadata{
+		{
+			CoAuthors: []clients.CoAuthor{{Name: "Jane Doe", Email: "jane@example.com"}},
+			Signature: clients.CommitSignature{Type: clients.SignatureTypeGitsign, Valid: true},
+			CIStatus:  clients.CIStatusSuccess,
+		},
+		{
+			Signature: clients.CommitSignature{Type: clients.SignatureTypeWebFlow, Valid: true},
+			CIStatus:  clients.CIStatusFailure,
+			Committer: "github",
+		},
+		{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("commitsFrom() (-want +got):\n%s", diff)
+	}
+}
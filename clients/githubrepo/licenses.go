@@ -60,6 +60,10 @@ func (handler *licensesHandler) setup() error {
 		// so we can ignore the first returned variable (the entire http response object)
 		// since we only need the response body here.
 		resp, derr := client.Do(handler.ctx, req, &bodyJSON)
+		if resp == nil {
+			handler.errSetup = fmt.Errorf("response for repo license failed with %w", derr)
+			return
+		}
 		switch resp.StatusCode {
 		// Handle 400 error, perhaps the API changed.
 		case http.StatusBadRequest:
@@ -78,11 +82,15 @@ func (handler *licensesHandler) setup() error {
 		// TODO: github.RepositoryLicense{} only supports one license per repo
 		//       should that change to an array of licenses, the change would
 		//       be here to iterate over any such range.
+		licensePath := bodyJSON.GetPath()
+		if licensePath == "" {
+			licensePath = bodyJSON.GetName()
+		}
 		handler.licenses = append(handler.licenses, clients.License{
 			Key:    bodyJSON.GetLicense().GetKey(),
 			Name:   bodyJSON.GetLicense().GetName(),
 			SPDXId: bodyJSON.GetLicense().GetSPDXID(),
-			Path:   bodyJSON.GetName(),
+			Path:   licensePath,
 			Type:   bodyJSON.GetType(),
 			Size:   bodyJSON.GetSize(),
 		},
@@ -0,0 +1,95 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+// ownerReposPageSize is how many repos are listed by a single GraphQL query.
+const ownerReposPageSize = 100
+
+// OwnerRepo is a repo of an organization or user.
+type OwnerRepo struct {
+	// Name is the name of the repo, e.g. github.com/owner/repo.
+	Name     string
+	Archived bool
+	Fork     bool
+	Stars    int
+}
+
+type ownerReposQuery struct {
+	RepositoryOwner *struct {
+		Repositories struct {
+			Nodes []struct {
+				NameWithOwner  string
+				IsArchived     bool
+				IsFork         bool
+				StargazerCount int
+			}
+			PageInfo struct {
+				EndCursor   githubv4.String
+				HasNextPage bool
+			}
+		} `graphql:"repositories(first: $pageSize, after: $cursor, orderBy: {field: NAME, direction: ASC})"`
+	} `graphql:"repositoryOwner(login: $login)"`
+}
+
+// ListOwnerRepos returns the repos owned by the GitHub organization or user,
+// sorted by name.
+func ListOwnerRepos(ctx context.Context, logger *log.Logger, owner string) ([]OwnerRepo, error) {
+	rt := roundtripper.NewTransport(ctx, logger)
+	return listOwnerRepos(ctx, githubv4.NewClient(&http.Client{Transport: rt}), owner)
+}
+
+func listOwnerRepos(ctx context.Context, client *githubv4.Client, owner string) ([]OwnerRepo, error) {
+	vars := map[string]interface{}{
+		"login":    githubv4.String(owner),
+		"pageSize": githubv4.Int(ownerReposPageSize),
+		"cursor":   (*githubv4.String)(nil),
+	}
+	var ret []OwnerRepo
+	for {
+		var query ownerReposQuery
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+		}
+		if query.RepositoryOwner == nil {
+			return nil, sce.WithMessage(sce.ErrRepoUnreachable, fmt.Sprintf("owner not found: %s", owner))
+		}
+		repos := &query.RepositoryOwner.Repositories
+		for _, node := range repos.Nodes {
+			ret = append(ret, OwnerRepo{
+				Name:     "github.com/" + node.NameWithOwner,
+				Archived: node.IsArchived,
+				Fork:     node.IsFork,
+				Stars:    node.StargazerCount,
+			})
+		}
+		if !repos.PageInfo.HasNextPage {
+			return ret, nil
+		}
+		cursor := repos.PageInfo.EndCursor
+		vars["cursor"] = &cursor
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shurcooL/githubv4"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+func TestListOwnerRepos(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding the query: %v", err)
+		}
+		if body.Variables["cursor"] == nil {
+			fmt.Fprint(w, `{"data": {"repositoryOwner": {"repositories": {
+				"nodes": [{"nameWithOwner": "org/a", "isArchived": true, "stargazerCount": 3}],
+				"pageInfo": {"endCursor": "c1", "hasNextPage": true}
+			}}}}`)
+			return
+		}
+		if body.Variables["cursor"] != "c1" {
+			t.Errorf("unexpected cursor %v", body.Variables["cursor"])
+		}
+		fmt.Fprint(w, `{"data": {"repositoryOwner": {"repositories": {
+			"nodes": [{"nameWithOwner": "org/b", "isFork": true, "stargazerCount": 20}],
+			"pageInfo": {"endCursor": "c2", "hasNextPage": false}
+		}}}}`)
+	}))
+	defer srv.Close()
+
+	client := githubv4.NewEnterpriseClient(srv.URL, srv.Client())
+	got, err := listOwnerRepos(context.Background(), client, "org")
+	if err != nil {
+		t.Fatalf("listOwnerRepos: %v", err)
+	}
+	want := []OwnerRepo{
+		{Name: "github.com/org/a", Archived: true, Stars: 3},
+		{Name: "github.com/org/b", Fork: true, Stars: 20},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listOwnerRepos() (-want,+got): %s", diff)
+	}
+}
+
+func TestListOwnerRepos_notFound(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"repositoryOwner": null}}`)
+	}))
+	defer srv.Close()
+
+	client := githubv4.NewEnterpriseClient(srv.URL, srv.Client())
+	if _, err := listOwnerRepos(context.Background(), client, "missing"); !errors.Is(err, sce.ErrRepoUnreachable) {
+		t.Errorf("listOwnerRepos() error = %v, want %v", err, sce.ErrRepoUnreachable)
+	}
+}
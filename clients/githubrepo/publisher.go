@@ -0,0 +1,131 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+// Publisher commits files to the repos, or the gists, of GitHub.
+type Publisher struct {
+	ghClient *github.Client
+}
+
+// CreatePublisher returns a Publisher authenticated like the RepoClients of
+// GitHub. The token must be allowed to write the contents of the repos, or
+// the gists, published to.
+func CreatePublisher(ctx context.Context, logger *log.Logger) *Publisher {
+	rt := roundtripper.NewTransport(ctx, logger)
+	return &Publisher{ghClient: github.NewClient(&http.Client{Transport: rt})}
+}
+
+// PublishFile commits the content returned by update, given the current
+// content of the file at path, to branch of repo, e.g. owner/repo. The
+// file is created if it does not exist, in which case update is given no
+// content, and the default branch is used if branch is empty. No commit is
+// made if the content is unchanged or update fails. It returns whether a commit was made.
+func (p *Publisher) PublishFile(ctx context.Context, repo, branch, path, message string,
+	update func(current []byte) ([]byte, error),
+) (bool, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" {
+		return false, sce.WithMessage(sce.ErrorInvalidURL, repo)
+	}
+	var current []byte
+	var sha *string
+	file, _, resp, err := p.ghClient.Repositories.GetContents(ctx, owner, name, path,
+		&github.RepositoryContentGetOptions{Ref: branch})
+	switch {
+	case err == nil && file != nil:
+		content, err := file.GetContent()
+		if err != nil {
+			return false, fmt.Errorf("decoding %s: %w", path, err)
+		}
+		current, sha = []byte(content), file.SHA
+	case err == nil:
+		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s is a directory", path))
+	case resp == nil || resp.StatusCode != http.StatusNotFound:
+		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Repositories.GetContents: %v", err))
+	}
+
+	content, err := update(current)
+	if err != nil {
+		return false, fmt.Errorf("updating %s: %w", path, err)
+	}
+	if sha != nil && string(content) == string(current) {
+		return false, nil
+	}
+	opts := &github.RepositoryContentFileOptions{
+		Message: &message,
+		Content: content,
+		SHA:     sha,
+	}
+	if branch != "" {
+		opts.Branch = &branch
+	}
+	if _, _, err := p.ghClient.Repositories.CreateFile(ctx, owner, name, path, opts); err != nil {
+		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Repositories.CreateFile: %v", err))
+	}
+	return true, nil
+}
+
+// PublishGist sets the file of the gist with id to the content returned by
+// update, given its current content, or none if the gist has no such file.
+// The gist is not edited if the content is unchanged. It returns whether the
+// gist was edited.
+func (p *Publisher) PublishGist(ctx context.Context, id, file string,
+	update func(current []byte) ([]byte, error),
+) (bool, error) {
+	gist, _, err := p.ghClient.Gists.Get(ctx, id)
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+			return false, sce.WithMessage(sce.ErrRepoUnreachable, fmt.Sprintf("gist %s not found", id))
+		}
+		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Gists.Get: %v", err))
+	}
+	var current []byte
+	existing, found := gist.Files[github.GistFilename(file)]
+	if found {
+		current = []byte(existing.GetContent())
+	}
+	updated, err := update(current)
+	if err != nil {
+		return false, fmt.Errorf("updating %s: %w", file, err)
+	}
+	content := string(updated)
+	if found && content == string(current) {
+		return false, nil
+	}
+	edit := &github.Gist{
+		Files: map[github.GistFilename]github.GistFile{
+			github.GistFilename(file): {Content: &content},
+		},
+	}
+	if _, _, err := p.ghClient.Gists.Edit(ctx, id, edit); err != nil {
+		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Gists.Edit: %v", err))
+	}
+	return true, nil
+}
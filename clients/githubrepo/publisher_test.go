@@ -0,0 +1,292 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v38/github"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// publishTransport serves the contents of files and gists, and records the
+// files and gists written.
+type publishTransport struct {
+	files   map[string]string
+	gists   map[string]map[string]string
+	written map[string]string
+}
+
+func (rt *publishTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	respond := func(status int, body interface{}) (*http.Response, error) {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("json.Marshal: %w", err)
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(string(b))),
+			Request:    r,
+		}, nil
+	}
+	notFound := map[string]string{"message": "Not Found"}
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/repos/"):
+		key := r.URL.Path + "@" + r.URL.Query().Get("ref")
+		if r.Method == http.MethodPut {
+			var opts struct {
+				Content []byte  `json:"content"`
+				SHA     *string `json:"sha"`
+				Branch  string  `json:"branch"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+				return nil, fmt.Errorf("decoding request: %w", err)
+			}
+			_, exists := rt.files[r.URL.Path+"@"+opts.Branch]
+			if exists != (opts.SHA != nil) {
+				return respond(http.StatusConflict, map[string]string{"message": "sha mismatch"})
+			}
+			rt.written[r.URL.Path+"@"+opts.Branch] = string(opts.Content)
+			return respond(http.StatusOK, map[string]interface{}{})
+		}
+		content, ok := rt.files[key]
+		if !ok {
+			return respond(http.StatusNotFound, notFound)
+		}
+		return respond(http.StatusOK, map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"sha":      "3d21ec53a331a6f037a91c368710b99387d012c1",
+			"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+	case strings.HasPrefix(r.URL.Path, "/gists/"):
+		id := strings.TrimPrefix(r.URL.Path, "/gists/")
+		files, ok := rt.gists[id]
+		if !ok {
+			return respond(http.StatusNotFound, notFound)
+		}
+		if r.Method == http.MethodPatch {
+			var gist github.Gist
+			if err := json.NewDecoder(r.Body).Decode(&gist); err != nil {
+				return nil, fmt.Errorf("decoding request: %w", err)
+			}
+			for name, f := range gist.Files {
+				rt.written[id+"/"+string(name)] = f.GetContent()
+			}
+			return respond(http.StatusOK, map[string]interface{}{})
+		}
+		gist := github.Gist{Files: map[github.GistFilename]github.GistFile{}}
+		for name, content := range files {
+			content := content
+			gist.Files[github.GistFilename(name)] = github.GistFile{Content: &content}
+		}
+		return respond(http.StatusOK, gist)
+	}
+	return respond(http.StatusNotFound, notFound)
+}
+
+func newPublishTransport() *publishTransport {
+	return &publishTransport{
+		files: map[string]string{
+			"/repos/owner/repo/contents/README.md@":     "# repo\n",
+			"/repos/owner/repo/contents/README.md@docs": "# docs\n",
+			"/repos/owner/repo/contents/SCORECARD.md@":  "unchanged",
+		},
+		gists: map[string]map[string]string{
+			"aa5a315d61ae9438b18d": {"scorecard.md": "old"},
+		},
+		written: map[string]string{},
+	}
+}
+
+var errUpdate = errors.New("update failed")
+
+func appendScores(current []byte) ([]byte, error) {
+	return append(current, "scores\n"...), nil
+}
+
+func keepContent(current []byte) ([]byte, error) {
+	return current, nil
+}
+
+func failUpdate(current []byte) ([]byte, error) {
+	return nil, errUpdate
+}
+
+func TestPublishFile(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		repo       string
+		branch     string
+		path       string
+		update     func([]byte) ([]byte, error)
+		wantErr    error
+		wantKey    string
+		wantWrite  string
+		wantCommit bool
+	}{
+		{
+			name:       "existing file on the default branch",
+			repo:       "owner/repo",
+			path:       "README.md",
+			update:     appendScores,
+			wantKey:    "/repos/owner/repo/contents/README.md@",
+			wantWrite:  "# repo\nscores\n",
+			wantCommit: true,
+		},
+		{
+			name:       "existing file on a branch",
+			repo:       "owner/repo",
+			branch:     "docs",
+			path:       "README.md",
+			update:     appendScores,
+			wantKey:    "/repos/owner/repo/contents/README.md@docs",
+			wantWrite:  "# docs\nscores\n",
+			wantCommit: true,
+		},
+		{
+			name:       "new file",
+			repo:       "owner/repo",
+			path:       "NEW.md",
+			update:     appendScores,
+			wantKey:    "/repos/owner/repo/contents/NEW.md@",
+			wantWrite:  "scores\n",
+			wantCommit: true,
+		},
+		{
+			name:   "unchanged file",
+			repo:   "owner/repo",
+			path:   "SCORECARD.md",
+			update: keepContent,
+		},
+		{
+			name:    "failed update",
+			repo:    "owner/repo",
+			path:    "README.md",
+			update:  failUpdate,
+			wantErr: errUpdate,
+		},
+		{
+			name:    "invalid repo",
+			repo:    "repo",
+			path:    "README.md",
+			update:  appendScores,
+			wantErr: sce.ErrorInvalidURL,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rt := newPublishTransport()
+			p := &Publisher{ghClient: github.NewClient(&http.Client{Transport: rt})}
+			committed, err := p.PublishFile(context.Background(), tt.repo, tt.branch, tt.path, "update scores", tt.update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PublishFile() error = %v, want %v", err, tt.wantErr)
+			}
+			if committed != tt.wantCommit {
+				t.Errorf("PublishFile() = %v, want %v", committed, tt.wantCommit)
+			}
+			if tt.wantKey == "" {
+				if len(rt.written) != 0 {
+					t.Errorf("PublishFile() wrote %v, want no writes", rt.written)
+				}
+				return
+			}
+			if got := rt.written[tt.wantKey]; got != tt.wantWrite {
+				t.Errorf("PublishFile() wrote %q, want %q", got, tt.wantWrite)
+			}
+		})
+	}
+}
+
+func TestPublishGist(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		id         string
+		file       string
+		update     func([]byte) ([]byte, error)
+		wantErr    error
+		wantWrite  string
+		wantCommit bool
+	}{
+		{
+			name:       "existing file",
+			id:         "aa5a315d61ae9438b18d",
+			file:       "scorecard.md",
+			update:     appendScores,
+			wantWrite:  "oldscores\n",
+			wantCommit: true,
+		},
+		{
+			name:       "new file",
+			id:         "aa5a315d61ae9438b18d",
+			file:       "new.md",
+			update:     appendScores,
+			wantWrite:  "scores\n",
+			wantCommit: true,
+		},
+		{
+			name:   "unchanged file",
+			id:     "aa5a315d61ae9438b18d",
+			file:   "scorecard.md",
+			update: keepContent,
+		},
+		{
+			name:    "failed update",
+			id:      "aa5a315d61ae9438b18d",
+			file:    "scorecard.md",
+			update:  failUpdate,
+			wantErr: errUpdate,
+		},
+		{
+			name:    "unknown gist",
+			id:      "unknown",
+			file:    "scorecard.md",
+			update:  appendScores,
+			wantErr: sce.ErrRepoUnreachable,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rt := newPublishTransport()
+			p := &Publisher{ghClient: github.NewClient(&http.Client{Transport: rt})}
+			edited, err := p.PublishGist(context.Background(), tt.id, tt.file, tt.update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PublishGist() error = %v, want %v", err, tt.wantErr)
+			}
+			if edited != tt.wantCommit {
+				t.Errorf("PublishGist() = %v, want %v", edited, tt.wantCommit)
+			}
+			if got := rt.written[tt.id+"/"+tt.file]; got != tt.wantWrite {
+				t.Errorf("PublishGist() wrote %q, want %q", got, tt.wantWrite)
+			}
+		})
+	}
+}
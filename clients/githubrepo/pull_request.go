@@ -0,0 +1,113 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+// ErrNoPullRequest is returned by GetPullRequest when the repo has no pull
+// request with the number.
+var ErrNoPullRequest = errors.New("pull request not found")
+
+// maxPullRequestFilePages caps the pages of 100 files read of a pull
+// request. GitHub does not list more than 3000 files.
+const maxPullRequestFilePages = 30
+
+// PullRequest is a pull request of a GitHub repo.
+type PullRequest struct {
+	// BaseCommit and HeadCommit are the SHAs of the commit of the base branch
+	// the pull request is compared with, and of its last commit.
+	BaseCommit string
+	HeadCommit string
+	// Files are the paths of the files the pull request adds or modifies,
+	// in the head commit.
+	Files  []string
+	Number int
+}
+
+type pullRequestQuery struct {
+	Repository struct {
+		PullRequest *struct {
+			BaseRefOid githubv4.GitObjectID
+			HeadRefOid githubv4.GitObjectID
+			Files      struct {
+				Nodes []struct {
+					Path       string
+					ChangeType string
+				}
+				PageInfo struct {
+					EndCursor   githubv4.String
+					HasNextPage bool
+				}
+			} `graphql:"files(first: 100, after: $cursor)"`
+		} `graphql:"pullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// GetPullRequest returns the commits and the changed files of pull request
+// number of the GitHub repo.
+func GetPullRequest(ctx context.Context, logger *log.Logger, repo string, number int) (*PullRequest, error) {
+	rt := roundtripper.NewTransport(ctx, logger)
+	return getPullRequest(ctx, githubv4.NewClient(&http.Client{Transport: rt}), repo, number)
+}
+
+func getPullRequest(ctx context.Context, client *githubv4.Client, repo string, number int) (*PullRequest, error) {
+	var r repoURL
+	if err := r.parse(repo); err != nil {
+		return nil, err
+	}
+	if err := r.IsValid(); err != nil {
+		return nil, err
+	}
+	vars := map[string]interface{}{
+		"owner":  githubv4.String(r.owner),
+		"name":   githubv4.String(r.repo),
+		"number": githubv4.Int(number),
+		"cursor": (*githubv4.String)(nil),
+	}
+	ret := &PullRequest{Number: number}
+	for page := 0; page < maxPullRequestFilePages; page++ {
+		var query pullRequestQuery
+		if err := client.Query(ctx, &query, vars); err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+		}
+		pr := query.Repository.PullRequest
+		if pr == nil {
+			return nil, fmt.Errorf("%w: %s#%d", ErrNoPullRequest, repo, number)
+		}
+		ret.BaseCommit, ret.HeadCommit = string(pr.BaseRefOid), string(pr.HeadRefOid)
+		for _, f := range pr.Files.Nodes {
+			if f.ChangeType == "DELETED" {
+				continue
+			}
+			ret.Files = append(ret.Files, f.Path)
+		}
+		if !pr.Files.PageInfo.HasNextPage {
+			break
+		}
+		vars["cursor"] = githubv4.NewString(pr.Files.PageInfo.EndCursor)
+	}
+	return ret, nil
+}
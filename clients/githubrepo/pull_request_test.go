@@ -0,0 +1,82 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shurcooL/githubv4"
+)
+
+func TestGetPullRequest(t *testing.T) {
+	t.Parallel()
+	pages := map[string]string{
+		"": `{"data": {"repository": {"pullRequest": {"baseRefOid": "base", "headRefOid": "head", "files": {
+			"nodes": [{"path": "main.go", "changeType": "MODIFIED"}, {"path": "old.go", "changeType": "DELETED"}],
+			"pageInfo": {"endCursor": "c1", "hasNextPage": true}}}}}}`,
+		"c1": `{"data": {"repository": {"pullRequest": {"baseRefOid": "base", "headRefOid": "head", "files": {
+			"nodes": [{"path": ".github/workflows/ci.yml", "changeType": "ADDED"}],
+			"pageInfo": {"endCursor": "c2", "hasNextPage": false}}}}}}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding the query: %v", err)
+		}
+		if body.Variables["number"] != float64(123) {
+			t.Errorf("unexpected variables %v", body.Variables)
+		}
+		cursor, _ := body.Variables["cursor"].(string)
+		fmt.Fprint(w, pages[cursor])
+	}))
+	defer srv.Close()
+
+	client := githubv4.NewEnterpriseClient(srv.URL, srv.Client())
+	got, err := getPullRequest(context.Background(), client, "github.com/owner/repo", 123)
+	if err != nil {
+		t.Fatalf("getPullRequest() = %v", err)
+	}
+	want := &PullRequest{
+		Number:     123,
+		BaseCommit: "base",
+		HeadCommit: "head",
+		Files:      []string{"main.go", ".github/workflows/ci.yml"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("getPullRequest() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetPullRequestNotFound(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"repository": {"pullRequest": null}}}`)
+	}))
+	defer srv.Close()
+
+	client := githubv4.NewEnterpriseClient(srv.URL, srv.Client())
+	if _, err := getPullRequest(context.Background(), client, "github.com/owner/repo", 1); !errors.Is(err, ErrNoPullRequest) {
+		t.Errorf("getPullRequest() = %v, want %v", err, ErrNoPullRequest)
+	}
+}
@@ -15,8 +15,12 @@
 package githubrepo
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"path"
 	"strings"
 	"sync"
 
@@ -33,6 +37,10 @@ type releasesHandler struct {
 	errSetup error
 	repourl  *repoURL
 	releases []clients.Release
+	// assets caches the content of the assets downloaded by URL, as several
+	// checks inspect the assets of the same releases.
+	assets   map[string][]byte
+	assetsMu sync.Mutex
 }
 
 func (handler *releasesHandler) init(ctx context.Context, repourl *repoURL) {
@@ -41,6 +49,20 @@ func (handler *releasesHandler) init(ctx context.Context, repourl *repoURL) {
 	handler.errSetup = nil
 	handler.once = new(sync.Once)
 	handler.releases = nil
+	handler.assets = make(map[string][]byte)
+}
+
+// The signatures of the tags of this many most recent releases are verified,
+// which needs up to two requests per release.
+const releaseTagsToVerify = 5
+
+// releaseDigests holds the fields of the releases API which are not supported
+// by the go-github version in use.
+type releaseDigests struct {
+	Assets []struct {
+		Digest string `json:"digest"`
+		ID     int64  `json:"id"`
+	} `json:"assets"`
 }
 
 func (handler *releasesHandler) setup() error {
@@ -49,16 +71,61 @@ func (handler *releasesHandler) setup() error {
 			handler.errSetup = fmt.Errorf("%w: ListReleases only supported for HEAD queries", clients.ErrUnsupportedFeature)
 			return
 		}
-		releases, _, err := handler.client.Repositories.ListReleases(
-			handler.ctx, handler.repourl.owner, handler.repourl.repo, &github.ListOptions{})
+		reqURL := path.Join("repos", handler.repourl.owner, handler.repourl.repo, "releases")
+		req, err := handler.client.NewRequest(http.MethodGet, reqURL, nil)
 		if err != nil {
-			handler.errSetup = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+			handler.errSetup = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("NewRequest: %v", err))
+			return
+		}
+		var body bytes.Buffer
+		if _, err := handler.client.Do(handler.ctx, req, &body); err != nil {
+			handler.errSetup = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListReleases: %v", err))
+			return
+		}
+		var releases []*github.RepositoryRelease
+		var digests []releaseDigests
+		if err := json.Unmarshal(body.Bytes(), &releases); err != nil {
+			handler.errSetup = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Unmarshal: %v", err))
+			return
+		}
+		if err := json.Unmarshal(body.Bytes(), &digests); err != nil {
+			handler.errSetup = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Unmarshal: %v", err))
+			return
+		}
+		handler.releases = releasesFrom(releases, digests)
+		for i := range handler.releases {
+			if i >= releaseTagsToVerify {
+				break
+			}
+			handler.releases[i].TagVerification = handler.tagVerification(handler.releases[i].TagName)
 		}
-		handler.releases = releasesFrom(releases)
 	})
 	return handler.errSetup
 }
 
+// tagVerification returns the verification state of the signature of a tag,
+// or "" if it cannot be determined.
+func (handler *releasesHandler) tagVerification(tagName string) string {
+	ref, _, err := handler.client.Git.GetRef(handler.ctx, handler.repourl.owner, handler.repourl.repo,
+		"tags/"+tagName)
+	if err != nil {
+		return ""
+	}
+	// Lightweight tags point to a commit and cannot be signed.
+	if ref.GetObject().GetType() != "tag" {
+		return "unsigned"
+	}
+	tag, _, err := handler.client.Git.GetTag(handler.ctx, handler.repourl.owner, handler.repourl.repo,
+		ref.GetObject().GetSHA())
+	if err != nil {
+		return ""
+	}
+	if tag.GetVerification().GetVerified() {
+		return clients.TagVerificationValid
+	}
+	return tag.GetVerification().GetReason()
+}
+
 func (handler *releasesHandler) getReleases() ([]clients.Release, error) {
 	if err := handler.setup(); err != nil {
 		return nil, fmt.Errorf("error during graphqlHandler.setup: %w", err)
@@ -66,18 +133,61 @@ func (handler *releasesHandler) getReleases() ([]clients.Release, error) {
 	return handler.releases, nil
 }
 
-func releasesFrom(data []*github.RepositoryRelease) []clients.Release {
+func (handler *releasesHandler) getAsset(asset *clients.ReleaseAsset) ([]byte, error) {
+	handler.assetsMu.Lock()
+	defer handler.assetsMu.Unlock()
+	if content, ok := handler.assets[asset.URL]; ok {
+		return content, nil
+	}
+	if asset.Size > clients.MaxReleaseAssetSize {
+		return nil, fmt.Errorf("%w: %s is %d bytes", clients.ErrAssetTooLarge, asset.Name, asset.Size)
+	}
+
+	req, err := handler.client.NewRequest(http.MethodGet, asset.URL, nil)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("NewRequest: %v", err))
+	}
+	// The API redirects to the content of the asset with this media type.
+	req.Header.Set("Accept", "application/octet-stream")
+	resp, err := handler.client.BareDo(handler.ctx, req)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetReleaseAsset: %v", err))
+	}
+	defer resp.Body.Close()
+
+	content, err := clients.ReadReleaseAsset(asset, resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ReadReleaseAsset: %w", err)
+	}
+	handler.assets[asset.URL] = content
+	return content, nil
+}
+
+func releasesFrom(data []*github.RepositoryRelease, digests []releaseDigests) []clients.Release {
 	var releases []clients.Release
-	for _, r := range data {
+	for i, r := range data {
+		assetDigests := make(map[int64]string)
+		if i < len(digests) {
+			for _, a := range digests[i].Assets {
+				assetDigests[a.ID] = a.Digest
+			}
+		}
 		release := clients.Release{
 			TagName:         r.GetTagName(),
 			URL:             r.GetURL(),
 			TargetCommitish: r.GetTargetCommitish(),
+			Author: clients.User{
+				Login: r.GetAuthor().GetLogin(),
+				IsBot: r.GetAuthor().GetType() == "Bot",
+			},
 		}
 		for _, a := range r.Assets {
 			release.Assets = append(release.Assets, clients.ReleaseAsset{
-				Name: a.GetName(),
-				URL:  a.GetURL(),
+				Name:        a.GetName(),
+				URL:         a.GetURL(),
+				DownloadURL: a.GetBrowserDownloadURL(),
+				Digest:      assetDigests[a.GetID()],
+				Size:        int64(a.GetSize()),
 			})
 		}
 		releases = append(releases, release)
@@ -0,0 +1,154 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package githubrepo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+type releasesTransport struct {
+	responses map[string]string
+}
+
+func (rt *releasesTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, ok := rt.responses[r.URL.Path]
+	status := http.StatusOK
+	if !ok {
+		body = `{"message": "Not Found"}`
+		status = http.StatusNotFound
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func TestListReleases(t *testing.T) {
+	t.Parallel()
+	rt := &releasesTransport{
+		responses: map[string]string{
+			"/repos/owner/repo/releases": `[
+				{
+					"tag_name": "v2.0.0",
+					"url": "https://api.github.com/repos/owner/repo/releases/2",
+					"target_commitish": "main",
+					"author": {"login": "maintainer", "type": "User"},
+					"assets": [
+						{
+							"id": 21,
+							"name": "bin.tar.gz",
+							"url": "https://api.github.com/repos/owner/repo/releases/assets/21",
+							"browser_download_url": "https://github.com/owner/repo/releases/download/v2.0.0/bin.tar.gz",
+							"digest": "sha256:abc"
+						},
+						{
+							"id": 22,
+							"name": "bin.intoto.jsonl",
+							"url": "https://api.github.com/repos/owner/repo/releases/assets/22",
+							"browser_download_url": "https://github.com/owner/repo/releases/download/v2.0.0/bin.intoto.jsonl"
+						}
+					]
+				},
+				{
+					"tag_name": "v1.0.0",
+					"url": "https://api.github.com/repos/owner/repo/releases/1",
+					"target_commitish": "main",
+					"author": {"login": "release-bot[bot]", "type": "Bot"}
+				},
+				{
+					"tag_name": "v0.1.0",
+					"url": "https://api.github.com/repos/owner/repo/releases/0"
+				}
+			]`,
+			"/repos/owner/repo/git/ref/tags/v2.0.0": `{"object": {"type": "tag", "sha": "tag2"}}`,
+			"/repos/owner/repo/git/tags/tag2":       `{"verification": {"verified": true, "reason": "valid"}}`,
+			"/repos/owner/repo/git/ref/tags/v1.0.0": `{"object": {"type": "commit", "sha": "commit1"}}`,
+		},
+	}
+	handler := &releasesHandler{
+		client: github.NewClient(&http.Client{Transport: rt}),
+	}
+	handler.init(context.Background(), &repoURL{
+		owner:     "owner",
+		repo:      "repo",
+		commitSHA: clients.HeadSHA,
+	})
+
+	got, err := handler.getReleases()
+	if err != nil {
+		t.Fatalf("getReleases: %v", err)
+	}
+	want := []clients.Release{
+		{
+			TagName:         "v2.0.0",
+			URL:             "https://api.github.com/repos/owner/repo/releases/2",
+			TargetCommitish: "main",
+			Author:          clients.User{Login: "maintainer"},
+			TagVerification: clients.TagVerificationValid,
+			Assets: []clients.ReleaseAsset{
+				{
+					Name:        "bin.tar.gz",
+					URL:         "https://api.github.com/repos/owner/repo/releases/assets/21",
+					DownloadURL: "https://github.com/owner/repo/releases/download/v2.0.0/bin.tar.gz",
+					Digest:      "sha256:abc",
+				},
+				{
+					Name:        "bin.intoto.jsonl",
+					URL:         "https://api.github.com/repos/owner/repo/releases/assets/22",
+					DownloadURL: "https://github.com/owner/repo/releases/download/v2.0.0/bin.intoto.jsonl",
+				},
+			},
+		},
+		{
+			TagName:         "v1.0.0",
+			URL:             "https://api.github.com/repos/owner/repo/releases/1",
+			TargetCommitish: "main",
+			Author:          clients.User{Login: "release-bot[bot]", IsBot: true},
+			TagVerification: "unsigned",
+		},
+		{
+			TagName: "v0.1.0",
+			URL:     "https://api.github.com/repos/owner/repo/releases/0",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("getReleases() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestListReleasesNonHead(t *testing.T) {
+	t.Parallel()
+	handler := &releasesHandler{
+		client: github.NewClient(&http.Client{Transport: &releasesTransport{}}),
+	}
+	handler.init(context.Background(), &repoURL{
+		owner:     "owner",
+		repo:      "repo",
+		commitSHA: "abc",
+	})
+	if _, err := handler.getReleases(); err == nil {
+		t.Error("getReleases() succeeded for a non-HEAD commit, want error")
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 const (
 	githubOrgRepo = ".github"
+	gistHost      = "gist.github.com"
 )
 
 type repoURL struct {
@@ -98,10 +99,19 @@ func (r *repoURL) Org() clients.Repo {
 func (r *repoURL) IsValid() error {
 	switch r.host {
 	case "github.com":
+	case gistHost:
+		return sce.WithMessage(sce.ErrorUnsupportedHost,
+			fmt.Sprintf("%v. GitHub gists are not repositories and cannot be checked", r.URI()))
 	default:
 		return sce.WithMessage(sce.ErrorUnsupportedHost, r.host)
 	}
 
+	if _, ok := wikiRepo(r.repo); ok {
+		return sce.WithMessage(sce.ErrorInvalidURL,
+			fmt.Sprintf("%v is a wiki. Wikis can only be checked for a security policy and a license, with --wiki",
+				r.URI()))
+	}
+
 	if strings.TrimSpace(r.owner) == "" || strings.TrimSpace(r.repo) == "" {
 		return sce.WithMessage(sce.ErrorInvalidURL,
 			fmt.Sprintf("%v. Expected the full repository url", r.URI()))
@@ -48,6 +48,26 @@ func TestRepoURL_IsValid(t *testing.T) {
 			inputURL: "https://github.com/foo/kubeflow/",
 			wantErr:  false,
 		},
+		{
+			name: "GitHub gist",
+			expected: repoURL{
+				host:  "gist.github.com",
+				owner: "foo",
+				repo:  "5b9cd6ad0d9c4f6a7b3e",
+			},
+			inputURL: "https://gist.github.com/foo/5b9cd6ad0d9c4f6a7b3e",
+			wantErr:  true,
+		},
+		{
+			name: "GitHub wiki",
+			expected: repoURL{
+				host:  "github.com",
+				owner: "foo",
+				repo:  "kubeflow/wiki",
+			},
+			inputURL: "https://github.com/foo/kubeflow/wiki",
+			wantErr:  true,
+		},
 		{
 			name: "Non github repository",
 			expected: repoURL{
@@ -0,0 +1,196 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+
+	"gocloud.dev/blob"
+	// Needed to open file:// buckets.
+	_ "gocloud.dev/blob/fileblob"
+	// Needed to open gs:// buckets.
+	_ "gocloud.dev/blob/gcsblob"
+	// Needed to open mem:// buckets.
+	_ "gocloud.dev/blob/memblob"
+)
+
+const (
+	// httpCacheURL is the URL of the cache GitHub API responses are stored in,
+	// e.g. file:///path/to/dir or gs://bucket?prefix=http/.
+	httpCacheURL = "SCORECARD_HTTP_CACHE"
+	// maxCachedResponseSize is the largest response body stored in the cache,
+	// so that e.g. repository tarballs are not cached.
+	maxCachedResponseSize = 10 << 20 // 10 MiB
+)
+
+// ResponseCache stores serialized HTTP responses by cache key. Errors from
+// Get are handled as cache misses, so e.g. pkg.ResultCache can be used.
+type ResponseCache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, value []byte) error
+}
+
+// OpenBlobResponseCache opens the cache in the bucket at bucketURL, one of the
+// blob URLs of gocloud.dev, e.g. file:///path/to/dir or gs://bucket.
+func OpenBlobResponseCache(ctx context.Context, bucketURL string) (ResponseCache, error) {
+	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return nil, fmt.Errorf("blob.OpenBucket: %w", err)
+	}
+	return &blobResponseCache{bucket: bucket}, nil
+}
+
+type blobResponseCache struct {
+	bucket *blob.Bucket
+}
+
+func (b *blobResponseCache) Get(ctx context.Context, key string) ([]byte, error) {
+	content, err := b.bucket.ReadAll(ctx, key+".http")
+	if err != nil {
+		return nil, fmt.Errorf("bucket.ReadAll: %w", err)
+	}
+	return content, nil
+}
+
+func (b *blobResponseCache) Put(ctx context.Context, key string, value []byte) error {
+	if err := b.bucket.WriteAll(ctx, key+".http", value, nil); err != nil {
+		return fmt.Errorf("bucket.WriteAll: %w", err)
+	}
+	return nil
+}
+
+// MakeCachingTransport returns a RoundTripper which stores the responses to
+// GET requests with an ETag or a Last-Modified date in cache, and revalidates
+// them with conditional requests. GitHub does not count conditional requests
+// answered with 304 Not Modified against the rate limit, so responses which
+// did not change since a previous run are served from the cache for free.
+//
+// Cached responses are always revalidated, so the server ensures the token
+// used by the request is allowed to read them.
+func MakeCachingTransport(innerTransport http.RoundTripper, cache ResponseCache) http.RoundTripper {
+	return &cachingTransport{
+		innerTransport: innerTransport,
+		cache:          cache,
+	}
+}
+
+type cachingTransport struct {
+	innerTransport http.RoundTripper
+	cache          ResponseCache
+}
+
+// responseCacheKey identifies the representation of a resource requested by r.
+func responseCacheKey(r *http.Request) string {
+	sum := sha256.Sum256([]byte(r.URL.String() + "\n" + r.Header.Get("Accept")))
+	return hex.EncodeToString(sum[:])
+}
+
+func isCacheable(r *http.Request) bool {
+	return r.Method == http.MethodGet && r.Header.Get("Range") == "" &&
+		r.Header.Get("If-None-Match") == "" && r.Header.Get("If-Modified-Since") == ""
+}
+
+// RoundTrip implements http.RoundTripper.
+func (ct *cachingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if !isCacheable(r) {
+		//nolint:wrapcheck // the inner transport error is returned as-is.
+		return ct.innerTransport.RoundTrip(r)
+	}
+	key := responseCacheKey(r)
+	cached := ct.cachedResponse(r, key)
+	if cached != nil {
+		// The request must not be modified, see http.RoundTripper.
+		r = r.Clone(r.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			r.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			r.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := ct.innerTransport.RoundTrip(r)
+	if err != nil {
+		//nolint:wrapcheck // the inner transport error is returned as-is.
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		// Headers of the 304 response, e.g. the rate limits, are more recent.
+		for name, values := range resp.Header {
+			cached.Header[name] = values
+		}
+		return cached, nil
+	}
+	if cached != nil {
+		cached.Body.Close()
+	}
+	if resp.StatusCode != http.StatusOK ||
+		(resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "") ||
+		resp.ContentLength > maxCachedResponseSize {
+		return resp, nil
+	}
+	return ct.storeResponse(r, key, resp)
+}
+
+// cachedResponse returns the response stored for key, or nil.
+func (ct *cachingTransport) cachedResponse(r *http.Request, key string) *http.Response {
+	content, err := ct.cache.Get(r.Context(), key)
+	if err != nil || len(content) == 0 {
+		return nil
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(content)), r)
+	if err != nil {
+		// A corrupted entry is overwritten by the next response.
+		return nil
+	}
+	return resp
+}
+
+// storeResponse stores resp and returns a copy of it.
+func (ct *cachingTransport) storeResponse(r *http.Request, key string, resp *http.Response) (*http.Response, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedResponseSize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("error reading resp.Body: %w", err)
+	}
+	if len(body) > maxCachedResponseSize {
+		// Too large to be cached: give the rest of the body back to the caller.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	content, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return nil, fmt.Errorf("httputil.DumpResponse: %w", err)
+	}
+	// The cache is best effort: failing to store a response only costs quota.
+	//nolint:errcheck
+	ct.cache.Put(r.Context(), key, content)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// etagServer serves body with an ETag and counts the requests it answered
+// in full.
+type etagServer struct {
+	body    string
+	etag    string
+	mu      sync.Mutex
+	full    int
+	revalid int
+}
+
+func (s *etagServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("X-RateLimit-Remaining", "42")
+	if s.etag != "" && r.Header.Get("If-None-Match") == s.etag {
+		s.revalid++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	s.full++
+	if s.etag != "" {
+		w.Header().Set("ETag", s.etag)
+	}
+	io.WriteString(w, s.body) //nolint:errcheck
+}
+
+func get(t *testing.T, client *http.Client, url string) (int, string, string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("client.Do: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("io.ReadAll: %v", err)
+	}
+	return resp.StatusCode, string(body), resp.Header.Get("X-RateLimit-Remaining")
+}
+
+func TestCachingTransport(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		etag        string
+		wantFull    int
+		wantRevalid int
+	}{
+		{
+			name:        "revalidated with the ETag",
+			etag:        `"v1"`,
+			wantFull:    1,
+			wantRevalid: 2,
+		},
+		{
+			name:     "not cached without an ETag",
+			wantFull: 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := &etagServer{body: "hello", etag: tt.etag}
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+
+			cache, err := OpenBlobResponseCache(context.Background(), "mem://")
+			if err != nil {
+				t.Fatalf("OpenBlobResponseCache: %v", err)
+			}
+			client := &http.Client{Transport: MakeCachingTransport(http.DefaultTransport, cache)}
+			for i := 0; i < 3; i++ {
+				status, body, remaining := get(t, client, ts.URL)
+				if status != http.StatusOK || body != "hello" || remaining != "42" {
+					t.Errorf("request %d: got %d %q with %s remaining", i, status, body, remaining)
+				}
+			}
+			if server.full != tt.wantFull || server.revalid != tt.wantRevalid {
+				t.Errorf("got %d full and %d revalidated requests, want %d and %d",
+					server.full, server.revalid, tt.wantFull, tt.wantRevalid)
+			}
+		})
+	}
+}
+
+func TestCachingTransportChangedResource(t *testing.T) {
+	t.Parallel()
+	server := &etagServer{body: "v1", etag: `"v1"`}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	cache, err := OpenBlobResponseCache(context.Background(), "mem://")
+	if err != nil {
+		t.Fatalf("OpenBlobResponseCache: %v", err)
+	}
+	client := &http.Client{Transport: MakeCachingTransport(http.DefaultTransport, cache)}
+	if _, body, _ := get(t, client, ts.URL); body != "v1" {
+		t.Errorf("got %q, want v1", body)
+	}
+	server.mu.Lock()
+	server.body, server.etag = "v2", `"v2"`
+	server.mu.Unlock()
+	if _, body, _ := get(t, client, ts.URL); body != "v2" {
+		t.Errorf("got %q, want v2", body)
+	}
+	if _, body, _ := get(t, client, ts.URL); body != "v2" {
+		t.Errorf("got %q, want v2 from the cache", body)
+	}
+	if server.full != 2 || server.revalid != 1 {
+		t.Errorf("got %d full and %d revalidated requests, want 2 and 1", server.full, server.revalid)
+	}
+}
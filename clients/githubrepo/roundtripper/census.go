@@ -15,8 +15,10 @@
 package roundtripper
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"go.opencensus.io/plugin/ochttp"
 	opencensusstats "go.opencensus.io/stats"
@@ -26,7 +28,40 @@ import (
 	"github.com/ossf/scorecard/v4/stats"
 )
 
-const fromCacheHeader = "X-From-Cache"
+const (
+	fromCacheHeader = "X-From-Cache"
+	// statusClassError is the status class of requests which failed without a response.
+	statusClassError = "error"
+	// statusClassOther is the status class of responses with an invalid status code.
+	statusClassOther = "other"
+	// endpointCategoryOther is the category of endpoints not listed in repoEndpointCategories.
+	endpointCategoryOther = "other"
+)
+
+// repoEndpointCategories maps the resource following /repos/{owner}/{repo}/
+// to the endpoint category it is counted under. Requests are categorized
+// coarsely to keep the cardinality of the metrics low.
+var repoEndpointCategories = map[string]string{
+	"":             "repository",
+	"actions":      "actions",
+	"branches":     "branches",
+	"check-runs":   "checks",
+	"check-suites": "checks",
+	"commits":      "commits",
+	"contents":     "contents",
+	"environments": "environments",
+	"git":          "git",
+	"hooks":        "hooks",
+	"issues":       "issues",
+	"languages":    "languages",
+	"license":      "contents",
+	"pulls":        "pulls",
+	"readme":       "contents",
+	"releases":     "releases",
+	"statuses":     "statuses",
+	"tarball":      "tarball",
+	"zipball":      "tarball",
+}
 
 // MakeCensusTransport wraps input Roundtripper with monitoring logic.
 func MakeCensusTransport(innerTransport http.RoundTripper) http.RoundTripper {
@@ -49,9 +84,15 @@ func (ct *censusTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("tag.New: %v", err))
 	}
 
+	ctx, err = tag.New(ctx, tag.Upsert(stats.EndpointCategory, endpointCategory(r)))
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("tag.New: %v", err))
+	}
+
 	r = r.WithContext(ctx)
 	resp, err := ct.innerTransport.RoundTrip(r)
 	if err != nil {
+		recordRequest(ctx, statusClassError)
 		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("innerTransport.RoundTrip: %v", err))
 	}
 	if resp.Header.Get(fromCacheHeader) != "" {
@@ -60,6 +101,64 @@ func (ct *censusTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("tag.New: %v", err))
 		}
 	}
-	opencensusstats.Record(ctx, stats.HTTPRequests.M(1))
+	recordRequest(ctx, statusClass(resp.StatusCode))
 	return resp, nil
 }
+
+func recordRequest(ctx context.Context, class string) {
+	// The status class is only known after the request, so failing to tag it
+	// should not fail the request.
+	if tagged, err := tag.New(ctx, tag.Upsert(stats.StatusClass, class)); err == nil {
+		ctx = tagged
+	}
+	opencensusstats.Record(ctx, stats.HTTPRequests.M(1))
+}
+
+// statusClass returns the class of an HTTP status code, e.g. 2xx.
+func statusClass(code int) string {
+	//nolint:gomnd
+	if code < 100 || code > 599 {
+		return statusClassOther
+	}
+	return fmt.Sprintf("%dxx", code/100)
+}
+
+// endpointCategory returns the category of the GitHub API endpoint requested.
+func endpointCategory(r *http.Request) string {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v3")
+	if path == "/graphql" || path == "/api/graphql" {
+		return "graphql"
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch parts[0] {
+	case "repos":
+		//nolint:gomnd
+		if len(parts) < 3 {
+			return endpointCategoryOther
+		}
+		resource := ""
+		//nolint:gomnd
+		if len(parts) > 3 {
+			resource = parts[3]
+		}
+		// Checks and statuses of a commit are requested through
+		// /repos/{owner}/{repo}/commits/{ref}/check-runs and .../status.
+		//nolint:gomnd
+		if resource == "commits" && len(parts) > 5 {
+			switch parts[5] {
+			case "check-runs", "check-suites":
+				return "checks"
+			case "status", "statuses":
+				return "statuses"
+			}
+		}
+		if category, ok := repoEndpointCategories[resource]; ok {
+			return category
+		}
+		return endpointCategoryOther
+	case "search", "rate_limit", "users", "orgs", "app":
+		return parts[0]
+	default:
+		return endpointCategoryOther
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opencensus.io/stats/view"
+
+	"github.com/ossf/scorecard/v4/stats"
+)
+
+func TestEndpointCategory(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/graphql", want: "graphql"},
+		{path: "/api/graphql", want: "graphql"},
+		{path: "/repos/owner/repo", want: "repository"},
+		{path: "/repos/owner/repo/contents/.github/workflows", want: "contents"},
+		{path: "/repos/owner/repo/readme", want: "contents"},
+		{path: "/repos/owner/repo/pulls/12/reviews", want: "pulls"},
+		{path: "/repos/owner/repo/commits/abc/check-runs", want: "checks"},
+		{path: "/repos/owner/repo/commits/abc/status", want: "statuses"},
+		{path: "/repos/owner/repo/commits", want: "commits"},
+		{path: "/repos/owner/repo/tarball/main", want: "tarball"},
+		{path: "/api/v3/repos/owner/repo/releases", want: "releases"},
+		{path: "/repos/owner/repo/unknown", want: "other"},
+		{path: "/repos/owner", want: "other"},
+		{path: "/search/code", want: "search"},
+		{path: "/rate_limit", want: "rate_limit"},
+		{path: "/", want: "other"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			r := httptest.NewRequest(http.MethodGet, "https://api.github.com"+tt.path, nil)
+			if got := endpointCategory(r); got != tt.want {
+				t.Errorf("endpointCategory(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusClass(t *testing.T) {
+	t.Parallel()
+	tests := map[int]string{
+		http.StatusOK:                  "2xx",
+		http.StatusNotModified:         "3xx",
+		http.StatusNotFound:            "4xx",
+		http.StatusInternalServerError: "5xx",
+		0:                              "other",
+	}
+	for code, want := range tests {
+		if got := statusClass(code); got != want {
+			t.Errorf("statusClass(%d) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+type fakeTransport struct {
+	err  error
+	code int
+}
+
+func (ft fakeTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if ft.err != nil {
+		return nil, ft.err
+	}
+	return &http.Response{StatusCode: ft.code, Header: http.Header{}, Body: http.NoBody, Request: r}, nil
+}
+
+//nolint:paralleltest // views are registered globally.
+func TestCensusTransportRecordsEndpoint(t *testing.T) {
+	v := stats.OutgoingHTTPRequestsByEndpoint
+	if err := view.Register(&v); err != nil {
+		t.Fatalf("view.Register: %v", err)
+	}
+	defer view.Unregister(&v)
+
+	send := func(tr http.RoundTripper, path string) {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodGet, "https://api.github.com"+path, nil)
+		resp, err := tr.RoundTrip(r.WithContext(context.Background()))
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+	ok := &censusTransport{innerTransport: fakeTransport{code: http.StatusOK}}
+	send(ok, "/repos/owner/repo/contents/README.md")
+	send(ok, "/repos/owner/repo/contents/LICENSE")
+	send(&censusTransport{innerTransport: fakeTransport{code: http.StatusNotFound}}, "/repos/owner/repo/pulls")
+	//nolint:goerr113
+	send(&censusTransport{innerTransport: fakeTransport{err: errors.New("timeout")}}, "/graphql")
+
+	rows, err := view.RetrieveData(v.Name)
+	if err != nil {
+		t.Fatalf("view.RetrieveData: %v", err)
+	}
+	got := map[[2]string]int64{}
+	for _, row := range rows {
+		var key [2]string
+		for _, tg := range row.Tags {
+			switch tg.Key {
+			case stats.EndpointCategory:
+				key[0] = tg.Value
+			case stats.StatusClass:
+				key[1] = tg.Value
+			}
+		}
+		got[key] = row.Data.(*view.CountData).Value
+	}
+	want := map[[2]string]int64{
+		{"contents", "2xx"}:  2,
+		{"pulls", "4xx"}:     1,
+		{"graphql", "error"}: 1,
+	}
+	for k, n := range want {
+		if got[k] != n {
+			t.Errorf("requests %v: got %d, want %d", k, got[k], n)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	"cloud.google.com/go/kms/apiv1/kmspb"
+)
+
+const (
+	// githubAPIURL is the base URL installation access tokens are requested from.
+	githubAPIURL = "https://api.github.com"
+	// jwtLifetime is how long the JWTs authenticating as the app are valid.
+	// GitHub rejects JWTs valid for more than 10 minutes.
+	jwtLifetime = 9 * time.Minute
+	// jwtClockSkew backdates the JWTs to allow for clock drift.
+	jwtClockSkew = time.Minute
+	// tokenRefreshMargin is how long before its expiry an installation token is refreshed.
+	tokenRefreshMargin = time.Minute
+)
+
+// errAccessToken is returned when no installation access token can be obtained.
+var errAccessToken = errors.New("getting an installation access token")
+
+// jwtSigner signs the JWTs authenticating as a GitHub App.
+type jwtSigner interface {
+	// sign returns the RSASSA-PKCS1-v1_5 signature of a SHA-256 digest.
+	sign(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// kmsSigner signs with an asymmetric Cloud KMS key, so the private key
+// of the app never leaves KMS.
+type kmsSigner struct {
+	client *kms.KeyManagementClient
+	// name is the resource name of the key version, i.e.
+	// projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*.
+	name string
+}
+
+func newKMSSigner(ctx context.Context, name string) (*kmsSigner, error) {
+	client, err := kms.NewKeyManagementClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("kms.NewKeyManagementClient: %w", err)
+	}
+	return &kmsSigner{client: client, name: name}, nil
+}
+
+func (s *kmsSigner) sign(ctx context.Context, digest []byte) ([]byte, error) {
+	resp, err := s.client.AsymmetricSign(ctx, &kmspb.AsymmetricSignRequest{
+		Name: s.name,
+		Digest: &kmspb.Digest{
+			Digest: &kmspb.Digest_Sha256{Sha256: digest},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("AsymmetricSign: %w", err)
+	}
+	return resp.GetSignature(), nil
+}
+
+// makeGitHubAppTransport returns a RoundTripper authenticating as the given
+// installation of a GitHub App whose JWTs are signed by signer.
+func makeGitHubAppTransport(innerTransport http.RoundTripper, signer jwtSigner,
+	appID, installationID int64,
+) http.RoundTripper {
+	return &githubAppTransport{
+		innerTransport: innerTransport,
+		signer:         signer,
+		baseURL:        githubAPIURL,
+		appID:          appID,
+		installationID: installationID,
+		now:            time.Now,
+	}
+}
+
+// githubAppTransport handles authorization using installation access tokens
+// of a GitHub App during HTTP requests.
+type githubAppTransport struct {
+	expiresAt      time.Time
+	innerTransport http.RoundTripper
+	signer         jwtSigner
+	now            func() time.Time
+	baseURL        string
+	token          string
+	appID          int64
+	installationID int64
+	mu             sync.Mutex
+}
+
+// RoundTrip implements http.RoundTripper.
+func (at *githubAppTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	token, err := at.accessToken(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", fmt.Sprintf("token %s", token))
+	//nolint:wrapcheck // the inner transport error is returned as-is.
+	return at.innerTransport.RoundTrip(r)
+}
+
+func (at *githubAppTransport) accessToken(ctx context.Context) (string, error) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+	if at.token != "" && at.now().Add(tokenRefreshMargin).Before(at.expiresAt) {
+		return at.token, nil
+	}
+
+	jwt, err := at.appJWT(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%w: signing JWT: %v", errAccessToken, err)
+	}
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", strings.TrimRight(at.baseURL, "/"), at.installationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errAccessToken, err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
+	resp, err := at.innerTransport.RoundTrip(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errAccessToken, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("%w: installation %d: %s", errAccessToken, at.installationID, resp.Status)
+	}
+
+	var body struct {
+		ExpiresAt time.Time `json:"expires_at"`
+		Token     string    `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("%w: decoding response: %v", errAccessToken, err)
+	}
+	at.token, at.expiresAt = body.Token, body.ExpiresAt
+	return at.token, nil
+}
+
+// appJWT returns an RS256 JWT authenticating as the app.
+func (at *githubAppTransport) appJWT(ctx context.Context) (string, error) {
+	now := at.now()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal: %w", err)
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-jwtClockSkew).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": at.appID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := at.signer.sign(ctx, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + enc.EncodeToString(signature), nil
+}
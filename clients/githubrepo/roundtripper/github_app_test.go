@@ -0,0 +1,153 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type rsaSigner struct {
+	key *rsa.PrivateKey
+}
+
+func (s rsaSigner) sign(ctx context.Context, digest []byte) ([]byte, error) {
+	//nolint:wrapcheck
+	return rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest)
+}
+
+func verifyJWT(t *testing.T, key *rsa.PublicKey, jwt string) map[string]interface{} {
+	t.Helper()
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("invalid JWT: %q", jwt)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("decoding signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		t.Fatalf("verifying signature: %v", err)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("decoding claims: %v", err)
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	return claims
+}
+
+func TestGitHubAppTransport(t *testing.T) {
+	t.Parallel()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	now := time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	issued := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/installations/42/access_tokens" {
+			fmt.Fprint(w, r.Header.Get("Authorization"))
+			return
+		}
+		claims := verifyJWT(t, &key.PublicKey, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		if claims["iss"] != float64(7) {
+			t.Errorf("iss claim: got %v, want 7", claims["iss"])
+		}
+		if exp := int64(claims["exp"].(float64)); exp != now.Add(jwtLifetime).Unix() {
+			t.Errorf("exp claim: got %d, want %d", exp, now.Add(jwtLifetime).Unix())
+		}
+		issued++
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": "token-%d", "expires_at": %q}`, issued, now.Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer srv.Close()
+
+	tr := makeGitHubAppTransport(http.DefaultTransport, rsaSigner{key: key}, 7, 42).(*githubAppTransport)
+	tr.baseURL = srv.URL
+	tr.now = func() time.Time { return now }
+
+	get := func() string {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/repos/o/r", nil)
+		if err != nil {
+			t.Fatalf("http.NewRequest: %v", err)
+		}
+		resp, err := tr.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip: %v", err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("io.ReadAll: %v", err)
+		}
+		return string(body)
+	}
+
+	if got := get(); got != "token token-1" {
+		t.Errorf("Authorization: got %q, want %q", got, "token token-1")
+	}
+	// The token is reused until it is about to expire.
+	now = now.Add(30 * time.Minute)
+	if got := get(); got != "token token-1" {
+		t.Errorf("Authorization: got %q, want %q", got, "token token-1")
+	}
+	now = now.Add(30 * time.Minute)
+	if got := get(); got != "token token-2" {
+		t.Errorf("Authorization: got %q, want %q", got, "token token-2")
+	}
+}
+
+func TestGitHubAppTransportError(t *testing.T) {
+	t.Parallel()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	tr := makeGitHubAppTransport(http.DefaultTransport, rsaSigner{key: key}, 7, 42).(*githubAppTransport)
+	tr.baseURL = srv.URL
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/repos/o/r", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	//nolint:bodyclose // no response is returned on error.
+	if _, err := tr.RoundTrip(req); !errors.Is(err, errAccessToken) {
+		t.Errorf("RoundTrip: got %v, want %v", err, errAccessToken)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ossf/scorecard/v4/internal/githubsim"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+// envLoadTestScale multiplies the requests of TestLoad, e.g. to 20 by
+// `make loadtest`, which also prints the reports.
+const envLoadTestScale = "SCORECARD_LOADTEST_SCALE"
+
+// TestLoad sends requests through the transports of NewTransport to a
+// simulated GitHub API, to validate how they hold up to its rate limits,
+// errors and slow responses.
+func TestLoad(t *testing.T) {
+	scale := 1
+	if v := os.Getenv(envLoadTestScale); v != "" {
+		s, err := strconv.Atoi(v)
+		if err != nil || s < 1 {
+			t.Fatalf("invalid %s: %s", envLoadTestScale, v)
+		}
+		scale = s
+	}
+	t.Setenv("GITHUB_AUTH_TOKEN", "ghp_first,ghp_second")
+	t.Setenv(httpCacheURL, "")
+	t.Setenv(graphQLPersistedQueries, "")
+	t.Setenv(EnvRateLimitState, "")
+	t.Setenv(EnvRateLimitMaxWait, "")
+
+	tests := []struct {
+		name        string
+		cfg         githubsim.Config
+		requests    int
+		concurrency int
+		// check validates the report against the stats of the simulated
+		// API.
+		check func(t *testing.T, r *githubsim.Report, stats githubsim.Stats)
+	}{
+		{
+			name:        "healthy",
+			cfg:         githubsim.Config{Latency: time.Millisecond},
+			requests:    200,
+			concurrency: 20,
+			check:       allSucceeded,
+		},
+		{
+			name: "primary rate limit",
+			// Both tokens run out of quota and wait for the reset.
+			cfg:         githubsim.Config{Quota: 20, Window: time.Second},
+			requests:    60,
+			concurrency: 4,
+			check: func(t *testing.T, r *githubsim.Report, stats githubsim.Stats) {
+				t.Helper()
+				allSucceeded(t, r, stats)
+				if stats.PrimaryLimited == 0 {
+					t.Errorf("no request hit the primary rate limit")
+				}
+			},
+		},
+		{
+			name: "secondary rate limit",
+			cfg: githubsim.Config{
+				Concurrency: 2,
+				RetryAfter:  time.Second,
+				Latency:     20 * time.Millisecond,
+			},
+			requests:    12,
+			concurrency: 6,
+			check: func(t *testing.T, r *githubsim.Report, stats githubsim.Stats) {
+				t.Helper()
+				allSucceeded(t, r, stats)
+				if stats.SecondaryLimited == 0 {
+					t.Errorf("no request hit the secondary rate limit")
+				}
+			},
+		},
+		{
+			name:        "server errors",
+			cfg:         githubsim.Config{ErrorRate: 0.1, Seed: 1},
+			requests:    200,
+			concurrency: 20,
+			// Server errors are not retried by the transports, and reach
+			// the clients.
+			check: func(t *testing.T, r *githubsim.Report, stats githubsim.Stats) {
+				t.Helper()
+				if got := r.Statuses[http.StatusBadGateway]; got != stats.ServerErrors {
+					t.Errorf("%d responses with a server error, want the %d served", got, stats.ServerErrors)
+				}
+				if r.Succeeded()+stats.ServerErrors != r.Requests {
+					t.Errorf("%d requests succeeded, want all but the %d server errors", r.Succeeded(), stats.ServerErrors)
+				}
+			},
+		},
+		{
+			name: "slow responses",
+			cfg: githubsim.Config{
+				Latency:     time.Millisecond,
+				SlowRate:    0.1,
+				SlowLatency: 200 * time.Millisecond,
+				Seed:        1,
+			},
+			requests:    100,
+			concurrency: 20,
+			check: func(t *testing.T, r *githubsim.Report, stats githubsim.Stats) {
+				t.Helper()
+				allSucceeded(t, r, stats)
+				if r.Max < 200*time.Millisecond {
+					t.Errorf("max latency %s, want at least that of the slow responses", r.Max)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s := githubsim.NewServer(tt.cfg)
+			defer s.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			client := &http.Client{Transport: NewTransport(ctx, log.NewLogger(log.WarnLevel))}
+			r := githubsim.Load(ctx, client, s.URL, tt.requests*scale, tt.concurrency)
+			t.Logf("%s: %s", tt.name, r)
+			tt.check(t, r, s.Stats())
+		})
+	}
+}
+
+func allSucceeded(t *testing.T, r *githubsim.Report, stats githubsim.Stats) {
+	t.Helper()
+	if r.Succeeded() != r.Requests || r.Errors != 0 {
+		t.Errorf("%d of %d requests succeeded, %d errors, simulated API stats %+v",
+			r.Succeeded(), r.Requests, r.Errors, stats)
+	}
+}
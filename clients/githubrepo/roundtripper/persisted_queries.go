@@ -0,0 +1,220 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// graphQLPersistedQueries enables automatic persisted queries for GraphQL
+// requests, for GraphQL servers or proxies which support them.
+const graphQLPersistedQueries = "SCORECARD_GRAPHQL_PERSISTED_QUERIES"
+
+// persistedQueryNotFound is the error of servers which do not know a hash.
+const persistedQueryNotFound = "PersistedQueryNotFound"
+
+// MakePersistedQueryTransport returns a RoundTripper which sends GraphQL
+// queries as automatic persisted queries: the first request for a query
+// registers it with the server along with its sha256 hash, and the following
+// ones only send the hash and the variables, as GET requests which HTTP caches
+// and MakeCachingTransport can store and revalidate by ETag. Servers which do
+// not support persisted queries are detected, and sent full queries again.
+func MakePersistedQueryTransport(innerTransport http.RoundTripper) http.RoundTripper {
+	return &persistedQueryTransport{
+		innerTransport: innerTransport,
+		registered:     map[string]bool{},
+	}
+}
+
+type persistedQueryTransport struct {
+	innerTransport http.RoundTripper
+	registered     map[string]bool
+	mu             sync.Mutex
+	unsupported    bool
+}
+
+type graphQLRequest struct {
+	Query      string          `json:"query,omitempty"`
+	Variables  json.RawMessage `json:"variables,omitempty"`
+	Extensions json.RawMessage `json:"extensions,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code string `json:"code"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// RoundTrip implements http.RoundTripper.
+func (pt *persistedQueryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/graphql") || r.Body == nil ||
+		pt.isUnsupported() {
+		//nolint:wrapcheck // the inner transport error is returned as-is.
+		return pt.innerTransport.RoundTrip(r)
+	}
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading r.Body: %w", err)
+	}
+	var query graphQLRequest
+	if err := json.Unmarshal(body, &query); err != nil || !isQuery(query.Query) {
+		//nolint:wrapcheck // the inner transport error is returned as-is.
+		return pt.innerTransport.RoundTrip(withBody(r, body))
+	}
+	sum := sha256.Sum256([]byte(query.Query))
+	hash := hex.EncodeToString(sum[:])
+	query.Extensions = []byte(fmt.Sprintf(`{"persistedQuery":{"version":1,"sha256Hash":%q}}`, hash))
+
+	hashFailed := false
+	if pt.isRegistered(hash) {
+		resp, err := pt.innerTransport.RoundTrip(persistedQueryRequest(r, &query))
+		if err != nil {
+			//nolint:wrapcheck // the inner transport error is returned as-is.
+			return nil, err
+		}
+		notFound, failed, err := persistedQueryError(resp)
+		if err != nil {
+			return nil, err
+		}
+		if !notFound && !failed {
+			return resp, nil
+		}
+		resp.Body.Close()
+		// The server forgot the query, which is registered again, or either
+		// it does not support persisted queries or the query failed, which
+		// the full query tells.
+		hashFailed = failed
+	}
+
+	full, err := json.Marshal(&query)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+	resp, err := pt.innerTransport.RoundTrip(withBody(r, full))
+	if err != nil {
+		//nolint:wrapcheck // the inner transport error is returned as-is.
+		return nil, err
+	}
+	_, failed, err := persistedQueryError(resp)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case failed:
+		pt.setRegistered(hash, false)
+	case hashFailed:
+		pt.setUnsupported()
+	default:
+		pt.setRegistered(hash, true)
+	}
+	return resp, nil
+}
+
+// isQuery tells whether q is a query, which can be sent as a GET request,
+// rather than e.g. a mutation.
+func isQuery(q string) bool {
+	q = strings.TrimSpace(q)
+	return strings.HasPrefix(q, "{") || strings.HasPrefix(q, "query")
+}
+
+func (pt *persistedQueryTransport) isRegistered(hash string) bool {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	return pt.registered[hash]
+}
+
+func (pt *persistedQueryTransport) setRegistered(hash string, registered bool) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	pt.registered[hash] = registered
+}
+
+func (pt *persistedQueryTransport) isUnsupported() bool {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	return pt.unsupported
+}
+
+func (pt *persistedQueryTransport) setUnsupported() {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	pt.unsupported = true
+}
+
+// withBody returns a copy of r with body.
+func withBody(r *http.Request, body []byte) *http.Request {
+	// The request must not be modified, see http.RoundTripper.
+	ret := r.Clone(r.Context())
+	ret.Body = io.NopCloser(bytes.NewReader(body))
+	ret.ContentLength = int64(len(body))
+	ret.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return ret
+}
+
+// persistedQueryRequest returns the GET request of the persisted query q.
+func persistedQueryRequest(r *http.Request, q *graphQLRequest) *http.Request {
+	ret := r.Clone(r.Context())
+	ret.Method = http.MethodGet
+	ret.Body = nil
+	ret.GetBody = nil
+	ret.ContentLength = 0
+	ret.Header.Del("Content-Type")
+	values := ret.URL.Query()
+	values.Set("extensions", string(q.Extensions))
+	if len(q.Variables) > 0 {
+		values.Set("variables", string(q.Variables))
+	}
+	ret.URL.RawQuery = values.Encode()
+	return ret
+}
+
+// persistedQueryError tells whether resp is the error of a server which does
+// not know the persisted query, or another failure. The body of resp is
+// restored for the caller.
+func persistedQueryError(resp *http.Response) (notFound, failed bool, err error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return false, false, fmt.Errorf("error reading resp.Body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	var parsed graphQLResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return false, true, nil
+	}
+	for _, e := range parsed.Errors {
+		if e.Message == persistedQueryNotFound || e.Extensions.Code == "PERSISTED_QUERY_NOT_FOUND" {
+			return true, false, nil
+		}
+	}
+	failed = resp.StatusCode != http.StatusOK ||
+		(len(parsed.Errors) > 0 && (len(parsed.Data) == 0 || string(parsed.Data) == "null"))
+	return false, failed, nil
+}
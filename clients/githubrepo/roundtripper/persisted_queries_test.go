@@ -0,0 +1,163 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// apqServer is a GraphQL server which supports automatic persisted queries
+// if apq is set, and counts the requests by method.
+type apqServer struct {
+	queries map[string]bool
+	mu      sync.Mutex
+	apq     bool
+	posts   int
+	gets    int
+}
+
+func (s *apqServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var req graphQLRequest
+	if r.Method == http.MethodGet {
+		s.gets++
+		req.Extensions = json.RawMessage(r.URL.Query().Get("extensions"))
+	} else {
+		s.posts++
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	var ext struct {
+		PersistedQuery struct {
+			Hash string `json:"sha256Hash"`
+		} `json:"persistedQuery"`
+	}
+	//nolint:errcheck
+	json.Unmarshal(req.Extensions, &ext)
+	switch {
+	case req.Query != "":
+		if s.apq {
+			s.queries[ext.PersistedQuery.Hash] = true
+		}
+	case !s.apq:
+		io.WriteString(w, `{"data": null, "errors": [{"message": "query is missing"}]}`) //nolint:errcheck
+		return
+	case !s.queries[ext.PersistedQuery.Hash]:
+		io.WriteString(w, `{"errors": [{"message": "PersistedQueryNotFound"}]}`) //nolint:errcheck
+		return
+	}
+	io.WriteString(w, `{"data": {"viewer": {"login": "octocat"}}}`) //nolint:errcheck
+}
+
+func postQuery(t *testing.T, client *http.Client, url, body string) string {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("client.Do: %v", err)
+	}
+	defer resp.Body.Close()
+	got, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("io.ReadAll: %v", err)
+	}
+	return string(got)
+}
+
+func TestPersistedQueryTransport(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		body      string
+		apq       bool
+		wantPosts int
+		wantGets  int
+	}{
+		{
+			name:      "queries are persisted",
+			body:      `{"query": "query { viewer { login } }"}`,
+			apq:       true,
+			wantPosts: 1,
+			wantGets:  2,
+		},
+		{
+			name:      "unsupported by the server",
+			body:      `{"query": "query { viewer { login } }"}`,
+			wantPosts: 3,
+			wantGets:  1,
+		},
+		{
+			name:      "mutations are not persisted",
+			body:      `{"query": "mutation { addStar { clientMutationId } }"}`,
+			apq:       true,
+			wantPosts: 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := &apqServer{apq: tt.apq, queries: map[string]bool{}}
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+
+			client := &http.Client{Transport: MakePersistedQueryTransport(http.DefaultTransport)}
+			for i := 0; i < 3; i++ {
+				if got := postQuery(t, client, ts.URL+"/graphql", tt.body); !strings.Contains(got, "octocat") {
+					t.Errorf("request %d: got %s", i, got)
+				}
+			}
+			if server.posts != tt.wantPosts || server.gets != tt.wantGets {
+				t.Errorf("got %d POST and %d GET requests, want %d and %d",
+					server.posts, server.gets, tt.wantPosts, tt.wantGets)
+			}
+		})
+	}
+}
+
+func TestPersistedQueryTransportForgotten(t *testing.T) {
+	t.Parallel()
+	server := &apqServer{apq: true, queries: map[string]bool{}}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	client := &http.Client{Transport: MakePersistedQueryTransport(http.DefaultTransport)}
+	body := fmt.Sprintf(`{"query": %q, "variables": {"login": "octocat"}}`, "query($login: String!) { user(login: $login) { login } }")
+	postQuery(t, client, ts.URL+"/graphql", body)
+	// E.g. the server restarted.
+	server.mu.Lock()
+	server.queries = map[string]bool{}
+	server.mu.Unlock()
+	if got := postQuery(t, client, ts.URL+"/graphql", body); !strings.Contains(got, "octocat") {
+		t.Errorf("got %s", got)
+	}
+	if server.posts != 2 || server.gets != 1 {
+		t.Errorf("got %d POST and %d GET requests, want 2 and 1", server.posts, server.gets)
+	}
+}
@@ -15,8 +15,11 @@
 package roundtripper
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"strconv"
 	"time"
 
@@ -27,11 +30,32 @@ import (
 	"github.com/ossf/scorecard/v4/log"
 )
 
+// EnvRateLimitMaxWait is the longest duration, e.g. "10m", to wait for the
+// rate limit to reset. Requests exceeding the rate limit fail instead of
+// waiting longer, so that long scans can stop and be resumed later.
+const EnvRateLimitMaxWait = "SCORECARD_RATE_LIMIT_MAX_WAIT"
+
+// maxSecondaryRetries is how often a request rejected by the secondary rate
+// limit is retried, doubling the wait each time.
+const maxSecondaryRetries = 5
+
 // MakeRateLimitedTransport returns a RoundTripper which rate limits GitHub requests.
 func MakeRateLimitedTransport(innerTransport http.RoundTripper, logger *log.Logger) http.RoundTripper {
+	var maxWait time.Duration
+	if v := os.Getenv(EnvRateLimitMaxWait); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			logger.Info(fmt.Sprintf("ignoring invalid %s: %v", EnvRateLimitMaxWait, err))
+		} else {
+			maxWait = d
+		}
+	}
 	return &rateLimitTransport{
 		logger:         logger,
 		innerTransport: innerTransport,
+		maxWait:        maxWait,
+		// GitHub recommends waiting at least a minute before retrying.
+		secondaryBackoff: time.Minute,
 	}
 }
 
@@ -39,10 +63,73 @@ func MakeRateLimitedTransport(innerTransport http.RoundTripper, logger *log.Logg
 type rateLimitTransport struct {
 	logger         *log.Logger
 	innerTransport http.RoundTripper
+	// maxWait is the longest wait for a rate limit reset, 0 for no limit.
+	maxWait time.Duration
+	// secondaryBackoff is the first wait after exceeding the secondary
+	// rate limit.
+	secondaryBackoff time.Duration
+}
+
+// wait sleeps for duration, or until the request is canceled.
+func wait(r *http.Request, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-r.Context().Done():
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("waiting for rate limit: %v", r.Context().Err()))
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isRateLimited returns true if GitHub rejected resp for exceeding a rate limit.
+func isRateLimited(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// isSecondaryRateLimit returns true if resp was rejected by GitHub's secondary
+// rate limit, which is reported in the response body rather than the headers.
+// The body of resp is buffered so that it can still be read by the caller.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	if !isRateLimited(resp) || resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return false
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	message := bytes.ToLower(body)
+	return bytes.Contains(message, []byte("secondary rate limit")) || bytes.Contains(message, []byte("abuse"))
+}
+
+// resend returns a copy of r which can be sent again, or nil if its body
+// cannot be read again.
+func resend(r *http.Request) (*http.Request, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return r, nil
+	}
+	if r.GetBody == nil {
+		return nil, nil
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetBody: %v", err))
+	}
+	req := r.Clone(r.Context())
+	req.Body = body
+	return req, nil
 }
 
 // Roundtrip handles caching and ratelimiting of responses from GitHub.
 func (gh *rateLimitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return gh.roundTrip(r, 0)
+}
+
+// roundTrip sends r, retrying once the rate limit resets. secondaryRetries
+// is the number of times r was already rejected by the secondary rate limit.
+func (gh *rateLimitTransport) roundTrip(r *http.Request, secondaryRetries int) (*http.Response, error) {
 	resp, err := gh.innerTransport.RoundTrip(r)
 	if err != nil {
 		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("innerTransport.RoundTrip: %v", err))
@@ -52,34 +139,53 @@ func (gh *rateLimitTransport) RoundTrip(r *http.Request) (*http.Response, error)
 	if retryAfter, err := strconv.Atoi(retryValue); err == nil { // if NO error
 		stats.Record(r.Context(), githubstats.RetryAfter.M(int64(retryAfter)))
 		duration := time.Duration(retryAfter) * time.Second
-		gh.logger.Info(fmt.Sprintf("Retry-After header set. Waiting %s to retry...", duration))
-		time.Sleep(duration)
-		gh.logger.Info("Retry-After header set. Retrying...")
-		return gh.RoundTrip(r)
+		return gh.retry(r, resp, duration, secondaryRetries, "Retry-After header set")
+	}
+
+	if isSecondaryRateLimit(resp) {
+		if secondaryRetries >= maxSecondaryRetries {
+			return resp, nil
+		}
+		// GitHub asks clients to back off exponentially when it doesn't
+		// say how long to wait.
+		duration := gh.secondaryBackoff << secondaryRetries
+		stats.Record(r.Context(), githubstats.RetryAfter.M(int64(duration/time.Second)))
+		return gh.retry(r, resp, duration, secondaryRetries+1, "Secondary rate limit exceeded")
 	}
 
 	rateLimit := resp.Header.Get("X-RateLimit-Remaining")
 	remaining, err := strconv.Atoi(rateLimit)
-	if err != nil {
+	if err != nil || remaining > 0 || !isRateLimited(resp) {
 		return resp, nil
 	}
 
-	if remaining <= 0 {
-		reset, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Reset"))
-		if err != nil {
-			return resp, nil
-		}
-
-		duration := time.Until(time.Unix(int64(reset), 0))
-		// TODO(log): Previously Warn. Consider logging an error here.
-		gh.logger.Info(fmt.Sprintf("Rate limit exceeded. Waiting %s to retry...", duration))
-
-		// Retry
-		time.Sleep(duration)
-		// TODO(log): Previously Warn. Consider logging an error here.
-		gh.logger.Info("Rate limit exceeded. Retrying...")
-		return gh.RoundTrip(r)
+	reset, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Reset"))
+	if err != nil {
+		return resp, nil
 	}
+	duration := time.Until(time.Unix(int64(reset), 0))
+	// TODO(log): Previously Warn. Consider logging an error here.
+	return gh.retry(r, resp, duration, secondaryRetries, "Rate limit exceeded")
+}
 
-	return resp, nil
+// retry sends r again after duration, unless that is longer than maxWait or
+// the body of r cannot be sent again, in which case resp is returned.
+func (gh *rateLimitTransport) retry(r *http.Request, resp *http.Response, duration time.Duration,
+	secondaryRetries int, reason string,
+) (*http.Response, error) {
+	if gh.maxWait > 0 && duration > gh.maxWait {
+		gh.logger.Info(fmt.Sprintf("%s. Not waiting %s to retry.", reason, duration))
+		return resp, nil
+	}
+	req, err := resend(r)
+	if err != nil || req == nil {
+		return resp, err
+	}
+	gh.logger.Info(fmt.Sprintf("%s. Waiting %s to retry...", reason, duration))
+	resp.Body.Close()
+	if err := wait(r, duration); err != nil {
+		return nil, err
+	}
+	gh.logger.Info(fmt.Sprintf("%s. Retrying...", reason))
+	return gh.roundTrip(req, secondaryRetries)
 }
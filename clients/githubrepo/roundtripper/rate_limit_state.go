@@ -0,0 +1,99 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"gocloud.dev/blob"
+
+	"github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper/tokens"
+	"github.com/ossf/scorecard/v4/internal/redis"
+)
+
+const (
+	// EnvRateLimitState is the URL the rate limit state of tokens is persisted
+	// at, so that restarted workers and quick reruns don't trip the secondary
+	// rate limit again, e.g. redis://host:6379/0 or file:///path/to/dir.
+	EnvRateLimitState = "SCORECARD_RATE_LIMIT_STATE"
+	// redisStatePrefix prefixes the Redis keys of token states.
+	redisStatePrefix = "scorecard:ratelimit:"
+)
+
+// OpenRateLimitState opens the store at stateURL, which is either
+// redis://[:password@]host:port[/db] or one of the blob URLs of gocloud.dev.
+func OpenRateLimitState(ctx context.Context, stateURL string) (tokens.StateStore, error) {
+	u, err := url.Parse(stateURL)
+	if err != nil {
+		return nil, fmt.Errorf("url.Parse: %w", err)
+	}
+	if u.Scheme == "redis" {
+		client, err := redis.NewClient(u)
+		if err != nil {
+			return nil, fmt.Errorf("redis.NewClient: %w", err)
+		}
+		return &redisStateStore{client: client}, nil
+	}
+	bucket, err := blob.OpenBucket(ctx, stateURL)
+	if err != nil {
+		return nil, fmt.Errorf("blob.OpenBucket: %w", err)
+	}
+	return &blobStateStore{bucket: bucket}, nil
+}
+
+type redisStateStore struct {
+	client *redis.Client
+}
+
+func (s *redisStateStore) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.Do(ctx, "GET", redisStatePrefix+key)
+	if err != nil {
+		return nil, fmt.Errorf("GET: %w", err)
+	}
+	return value, nil
+}
+
+func (s *redisStateStore) Put(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.client.Do(ctx, "SET", redisStatePrefix+key, string(value),
+		"PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return fmt.Errorf("SET: %w", err)
+	}
+	return nil
+}
+
+// blobStateStore keeps states in the bucket until they are overwritten.
+type blobStateStore struct {
+	bucket *blob.Bucket
+}
+
+func (s *blobStateStore) Get(ctx context.Context, key string) ([]byte, error) {
+	content, err := s.bucket.ReadAll(ctx, key+".json")
+	if err != nil {
+		return nil, fmt.Errorf("bucket.ReadAll: %w", err)
+	}
+	return content, nil
+}
+
+func (s *blobStateStore) Put(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := s.bucket.WriteAll(ctx, key+".json", value, nil); err != nil {
+		return fmt.Errorf("bucket.WriteAll: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOpenRateLimitState(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store, err := OpenRateLimitState(ctx, "file://"+t.TempDir())
+	if err != nil {
+		t.Fatalf("OpenRateLimitState: %v", err)
+	}
+	if _, err := store.Get(ctx, "key"); err == nil {
+		t.Error("Get() of a missing key succeeded")
+	}
+	if err := store.Put(ctx, "key", []byte("value"), time.Hour); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got, err := store.Get(ctx, "key"); err != nil || string(got) != "value" {
+		t.Errorf("Get() = %q, %v, want %q", got, err, "value")
+	}
+
+	if _, err := OpenRateLimitState(ctx, "redis://localhost/db"); err == nil {
+		t.Error("OpenRateLimitState() with an invalid db succeeded")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ossf/scorecard/v4/log"
+)
+
+type exhaustedTransport struct {
+	calls int
+}
+
+func (t *exhaustedTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.calls++
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "0")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	return &http.Response{StatusCode: http.StatusForbidden, Header: header, Body: http.NoBody}, nil
+}
+
+func TestRateLimitTransportMaxWait(t *testing.T) {
+	t.Setenv(EnvRateLimitMaxWait, "1m")
+	inner := &exhaustedTransport{}
+	transport := MakeRateLimitedTransport(inner, log.NewLogger(log.DefaultLevel))
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.github.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden || inner.calls != 1 {
+		t.Errorf("expected the rate limited response without waiting, got %d after %d calls",
+			resp.StatusCode, inner.calls)
+	}
+}
+
+func TestRateLimitTransportCanceled(t *testing.T) {
+	t.Setenv(EnvRateLimitMaxWait, "")
+	transport := MakeRateLimitedTransport(&exhaustedTransport{}, log.NewLogger(log.DefaultLevel))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := transport.RoundTrip(req)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected an error once the request is canceled")
+	}
+}
+
+// secondaryLimitTransport rejects the first requests with the secondary rate limit.
+type secondaryLimitTransport struct {
+	rejections int
+	bodies     []string
+}
+
+func (t *secondaryLimitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	t.bodies = append(t.bodies, string(body))
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "4000")
+	if len(t.bodies) <= t.rejections {
+		message := `{"message": "You have exceeded a secondary rate limit."}`
+		return &http.Response{
+			StatusCode: http.StatusForbidden,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(message)),
+		}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: header, Body: http.NoBody}, nil
+}
+
+func TestRateLimitTransportSecondaryLimit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		rejections int
+		wantStatus int
+		wantCalls  int
+	}{
+		{
+			name:       "retried after backing off",
+			rejections: 2,
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+		},
+		{
+			name:       "too many rejections",
+			rejections: maxSecondaryRetries + 1,
+			wantStatus: http.StatusForbidden,
+			wantCalls:  maxSecondaryRetries + 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			inner := &secondaryLimitTransport{rejections: tt.rejections}
+			transport := &rateLimitTransport{
+				logger:           log.NewLogger(log.DefaultLevel),
+				innerTransport:   inner,
+				secondaryBackoff: time.Millisecond,
+			}
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+				"https://api.github.com/graphql", strings.NewReader("query"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus || len(inner.bodies) != tt.wantCalls {
+				t.Errorf("got %d after %d calls, want %d after %d calls",
+					resp.StatusCode, len(inner.bodies), tt.wantStatus, tt.wantCalls)
+			}
+			for _, body := range inner.bodies {
+				if body != "query" {
+					t.Errorf("request body %q was not resent", body)
+				}
+			}
+		})
+	}
+}
@@ -31,6 +31,10 @@ import (
 const (
 	// githubAppKeyPath is the path to file for GitHub App key.
 	githubAppKeyPath = "GITHUB_APP_KEY_PATH"
+	// githubAppKey is the PEM encoded GitHub App key, e.g. populated from a secret manager.
+	githubAppKey = "GITHUB_APP_KEY"
+	// githubAppKMSKey is the resource name of the Cloud KMS key version holding the GitHub App key.
+	githubAppKMSKey = "GITHUB_APP_KMS_KEY"
 	// githubAppID is the app ID for the GitHub App.
 	githubAppID = "GITHUB_APP_ID"
 	// githubAppInstallationID is the installation ID for the GitHub App.
@@ -44,24 +48,112 @@ func NewTransport(ctx context.Context, logger *log.Logger) http.RoundTripper {
 	//nolint
 	if tokenAccessor := tokens.MakeTokenAccessor(); tokenAccessor != nil {
 		// Use GitHub PAT
+		loadRateLimitState(ctx, tokenAccessor, logger)
 		transport = makeGitHubTransport(transport, tokenAccessor)
-	} else if keyPath := os.Getenv(githubAppKeyPath); keyPath != "" { // Also try a GITHUB_APP
-		appID, err := strconv.Atoi(os.Getenv(githubAppID))
+	} else if hasGitHubAppKey() { // Also try a GITHUB_APP
+		transport = makeGitHubAppTransportFromEnv(ctx, transport, logger)
+	} else {
+		// TODO(log): Improve error message
+		logger.Error(fmt.Errorf("an error occurred while getting GitHub credentials"), "GitHub token env var is not set. Please read https://github.com/ossf/scorecard#authentication")
+	}
+
+	return wrapTransport(ctx, transport, logger)
+}
+
+// NewTokenTransport returns a transport like NewTransport which
+// authenticates with the tokens of accessor instead of the configured
+// credentials.
+func NewTokenTransport(ctx context.Context, logger *log.Logger, accessor tokens.TokenAccessor) http.RoundTripper {
+	loadRateLimitState(ctx, accessor, logger)
+	return wrapTransport(ctx, makeGitHubTransport(http.DefaultTransport, accessor), logger)
+}
+
+// wrapTransport adds the response size limit, the caches and the rate limit
+// handling shared by all the transports to the authenticated transport.
+func wrapTransport(ctx context.Context, transport http.RoundTripper, logger *log.Logger) http.RoundTripper {
+	transport = MakeSizeLimitedTransport(transport, DefaultMaxResponseSize)
+	if cacheURL := os.Getenv(httpCacheURL); cacheURL != "" {
+		cache, err := OpenBlobResponseCache(ctx, cacheURL)
+		if err != nil {
+			logger.Error(err, "opening the HTTP response cache")
+		} else {
+			transport = MakeCachingTransport(transport, cache)
+		}
+	}
+	if enabled, _ := strconv.ParseBool(os.Getenv(graphQLPersistedQueries)); enabled {
+		transport = MakePersistedQueryTransport(transport)
+	}
+	return MakeCensusTransport(MakeRateLimitedTransport(transport, logger))
+}
+
+// loadRateLimitState restores the rate limit state of the tokens of accessor
+// from the store configured by EnvRateLimitState, if any.
+func loadRateLimitState(ctx context.Context, accessor tokens.TokenAccessor, logger *log.Logger) {
+	stateURL := os.Getenv(EnvRateLimitState)
+	tracker, ok := accessor.(tokens.PersistentTracker)
+	if stateURL == "" || !ok {
+		return
+	}
+	store, err := OpenRateLimitState(ctx, stateURL)
+	if err != nil {
+		logger.Error(err, "opening the rate limit state")
+		return
+	}
+	tracker.LoadState(ctx, store)
+}
+
+// CredentialType returns the type of the credentials NewTransport
+// authenticates with, without revealing them, e.g. "personal-access-token",
+// "github-app" or "none".
+func CredentialType() string {
+	if tokenType := tokens.Type(); tokenType != "" {
+		return tokenType
+	}
+	if hasGitHubAppKey() {
+		return "github-app"
+	}
+	return "none"
+}
+
+func hasGitHubAppKey() bool {
+	return os.Getenv(githubAppKeyPath) != "" || os.Getenv(githubAppKey) != "" || os.Getenv(githubAppKMSKey) != ""
+}
+
+// makeGitHubAppTransportFromEnv authenticates as a GitHub App installation using
+// the first key configured of: a KMS key, a PEM encoded key, a key file.
+func makeGitHubAppTransportFromEnv(ctx context.Context, transport http.RoundTripper,
+	logger *log.Logger,
+) http.RoundTripper {
+	appID, err := strconv.Atoi(os.Getenv(githubAppID))
+	if err != nil {
+		logger.Error(err, "getting GitHub application ID from environment")
+	}
+	installationID, err := strconv.Atoi(os.Getenv(githubAppInstallationID))
+	if err != nil {
+		logger.Error(err, "getting GitHub application installation ID")
+	}
+
+	switch {
+	case os.Getenv(githubAppKMSKey) != "":
+		signer, err := newKMSSigner(ctx, os.Getenv(githubAppKMSKey))
 		if err != nil {
-			logger.Error(err, "getting GitHub application ID from environment")
+			logger.Error(err, "creating a KMS signer")
+			return transport
 		}
-		installationID, err := strconv.Atoi(os.Getenv(githubAppInstallationID))
+		return makeGitHubAppTransport(transport, signer, int64(appID), int64(installationID))
+	case os.Getenv(githubAppKey) != "":
+		tr, err := ghinstallation.New(transport, int64(appID), int64(installationID), []byte(os.Getenv(githubAppKey)))
 		if err != nil {
-			logger.Error(err, "getting GitHub application installation ID")
+			logger.Error(err, "parsing the private key from environment")
+			return transport
 		}
-		transport, err = ghinstallation.NewKeyFromFile(transport, int64(appID), int64(installationID), keyPath)
+		return tr
+	default:
+		tr, err := ghinstallation.NewKeyFromFile(transport, int64(appID), int64(installationID), os.Getenv(githubAppKeyPath))
 		if err != nil {
 			logger.Error(err, "getting a private key from file")
+			return transport
 		}
-	} else {
-		// TODO(log): Improve error message
-		logger.Error(fmt.Errorf("an error occurred while getting GitHub credentials"), "GitHub token env var is not set. Please read https://github.com/ossf/scorecard#authentication")
+		return tr
 	}
-
-	return MakeCensusTransport(MakeRateLimitedTransport(transport, logger))
 }
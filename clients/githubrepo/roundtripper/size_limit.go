@@ -0,0 +1,89 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxResponseSize is the largest response body accepted by the transport
+// returned from NewTransport. It is large enough for repository tarballs.
+const DefaultMaxResponseSize int64 = 1 << 30 // 1 GiB
+
+// ErrResponseTooLarge is returned when a response body exceeds the configured limit.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// MakeSizeLimitedTransport returns a RoundTripper which refuses responses whose
+// body is larger than maxBytes. Responses advertising a larger Content-Length are
+// rejected upfront, other bodies fail with ErrResponseTooLarge once the limit is crossed.
+func MakeSizeLimitedTransport(innerTransport http.RoundTripper, maxBytes int64) http.RoundTripper {
+	return &sizeLimitTransport{
+		innerTransport: innerTransport,
+		maxBytes:       maxBytes,
+	}
+}
+
+type sizeLimitTransport struct {
+	innerTransport http.RoundTripper
+	maxBytes       int64
+}
+
+// RoundTrip implements http.RoundTripper.
+func (st *sizeLimitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := st.innerTransport.RoundTrip(r)
+	if err != nil {
+		//nolint:wrapcheck // the inner transport error is returned as-is.
+		return nil, err
+	}
+	if resp.ContentLength > st.maxBytes {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s: %d bytes exceeds limit of %d",
+			ErrResponseTooLarge, r.URL.Redacted(), resp.ContentLength, st.maxBytes)
+	}
+	resp.Body = &limitedBody{
+		ReadCloser: resp.Body,
+		remaining:  st.maxBytes,
+		url:        r.URL.Redacted(),
+	}
+	return resp, nil
+}
+
+// limitedBody errors out, instead of silently truncating, once more than
+// `remaining` bytes have been read.
+type limitedBody struct {
+	io.ReadCloser
+	url       string
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, fmt.Errorf("%w: %s", ErrResponseTooLarge, b.url)
+	}
+	// Allow reading one byte past the limit so we can detect the overflow.
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		return n + int(b.remaining), fmt.Errorf("%w: %s", ErrResponseTooLarge, b.url)
+	}
+	//nolint:wrapcheck // io.EOF must be returned unwrapped.
+	return n, err
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSizeLimitedTransport(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		body          string
+		setLength     bool
+		maxBytes      int64
+		wantErr       error
+		wantBodyErr   error
+		wantBodyBytes int
+	}{
+		{
+			name:          "under limit",
+			body:          "hello",
+			maxBytes:      10,
+			wantBodyBytes: 5,
+		},
+		{
+			name:          "exactly at limit",
+			body:          "hello",
+			maxBytes:      5,
+			wantBodyBytes: 5,
+		},
+		{
+			name:      "content-length over limit",
+			body:      "hello world",
+			setLength: true,
+			maxBytes:  5,
+			wantErr:   ErrResponseTooLarge,
+		},
+		{
+			name:          "streamed body over limit",
+			body:          "hello world",
+			maxBytes:      5,
+			wantBodyErr:   ErrResponseTooLarge,
+			wantBodyBytes: 5,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !tt.setLength {
+					// Force chunked encoding so no Content-Length is sent.
+					w.Header().Set("Transfer-Encoding", "chunked")
+				}
+				if _, err := io.Copy(w, strings.NewReader(tt.body)); err != nil {
+					t.Errorf("writing body: %v", err)
+				}
+			}))
+			defer srv.Close()
+
+			client := &http.Client{Transport: MakeSizeLimitedTransport(http.DefaultTransport, tt.maxBytes)}
+			//nolint:noctx
+			resp, err := client.Get(srv.URL)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Get() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer resp.Body.Close()
+			b, err := io.ReadAll(resp.Body)
+			if !errors.Is(err, tt.wantBodyErr) {
+				t.Errorf("ReadAll() error = %v, want %v", err, tt.wantBodyErr)
+			}
+			if len(b) != tt.wantBodyBytes {
+				t.Errorf("ReadAll() read %d bytes, want %d", len(b), tt.wantBodyBytes)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ package tokens
 
 import (
 	"os"
+	"sort"
 	"strings"
 )
 
@@ -40,10 +41,59 @@ func readGitHubTokens() (string, bool) {
 	return "", false
 }
 
+// tokenPrefixes maps the prefixes of GitHub tokens to their type.
+var tokenPrefixes = []struct {
+	prefix, tokenType string
+}{
+	{"ghp_", "personal-access-token"},
+	{"github_pat_", "fine-grained-personal-access-token"},
+	{"gho_", "oauth-app"},
+	{"ghu_", "github-app-user"},
+	// Also the GITHUB_TOKEN of GitHub Actions.
+	{"ghs_", "github-app-installation"},
+}
+
+// Type returns the types of the tokens configured, without revealing them,
+// e.g. "personal-access-token", "token-server" for tokens served by
+// GITHUB_AUTH_SERVER, or "" if there are none.
+func Type() string {
+	value, exists := readGitHubTokens()
+	if !exists {
+		if _, exists := os.LookupEnv(githubAuthServer); exists {
+			return "token-server"
+		}
+		return ""
+	}
+	types := map[string]bool{}
+	for _, token := range strings.Split(value, ",") {
+		tokenType := "unknown"
+		for _, p := range tokenPrefixes {
+			if strings.HasPrefix(token, p.prefix) {
+				tokenType = p.tokenType
+				break
+			}
+		}
+		types[tokenType] = true
+	}
+	ret := make([]string, 0, len(types))
+	for t := range types {
+		ret = append(ret, t)
+	}
+	sort.Strings(ret)
+	return strings.Join(ret, ",")
+}
+
+// MakeTokenPool returns a TokenAccessor handing out accessTokens, preferring
+// those with the most remaining quota, e.g. for the tokens of one tenant of
+// the serve mode.
+func MakeTokenPool(accessTokens []string) TokenAccessor {
+	return makeQuotaPoolAccessor(accessTokens)
+}
+
 // MakeTokenAccessor is a factory function of TokenAccessor.
 func MakeTokenAccessor() TokenAccessor {
 	if value, exists := readGitHubTokens(); exists {
-		return makeRoundRobinAccessor(strings.Split(value, ","))
+		return makeQuotaPoolAccessor(strings.Split(value, ","))
 	}
 	if value, exists := os.LookupEnv(githubAuthServer); exists {
 		return makeRPCAccessor(value)
@@ -0,0 +1,152 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// defaultQuota is the hourly quota of a personal access token, assumed for
+// tokens whose quota is not known yet or has been reset since.
+const defaultQuota = 5000
+
+// DefaultResource is the rate limit resource of the REST API. GitHub tracks
+// the quota of each resource, e.g. core, graphql or search, separately.
+const DefaultResource = "core"
+
+// QuotaTracker is implemented by TokenAccessors which route requests to the
+// token with the most remaining quota for the rate limit resource of the
+// request, see X-RateLimit-Resource. Callers report the quota GitHub returns
+// with each response.
+type QuotaTracker interface {
+	// NextFor returns the token with the most remaining quota for resource.
+	NextFor(resource string) (uint64, string)
+	// UpdateQuota records the remaining quota of token id for resource until reset.
+	UpdateQuota(id uint64, resource string, remaining int, reset time.Time)
+	// HasQuota returns true if any token has quota left for resource.
+	HasQuota(resource string) bool
+}
+
+// resourceQuota is the known quota of a token for a rate limit resource.
+type resourceQuota struct {
+	reset     time.Time
+	remaining int
+}
+
+type tokenQuota struct {
+	// blockedUntil is the end of the secondary rate limit backoff, which
+	// applies to all the resources.
+	blockedUntil time.Time
+	resources    map[string]resourceQuota
+	saved        tokenState
+	inFlight     int
+}
+
+// quotaPoolAccessor implements TokenAccessor and QuotaTracker.
+type quotaPoolAccessor struct {
+	ctx    context.Context
+	store  StateStore
+	now    func() time.Time
+	tokens []string
+	quotas []tokenQuota
+	// next rotates the token preferred on ties.
+	next int
+	mu   sync.Mutex
+}
+
+func makeQuotaPoolAccessor(accessTokens []string) *quotaPoolAccessor {
+	quotas := make([]tokenQuota, len(accessTokens))
+	for i := range quotas {
+		quotas[i].resources = map[string]resourceQuota{}
+	}
+	return &quotaPoolAccessor{
+		now:    time.Now,
+		tokens: accessTokens,
+		quotas: quotas,
+	}
+}
+
+// available returns the quota left for token i and resource, ignoring
+// in-flight requests. Tokens backing off from the secondary rate limit have
+// none.
+func (p *quotaPoolAccessor) available(i int, resource string) int {
+	q := &p.quotas[i]
+	if p.now().Before(q.blockedUntil) {
+		return 0
+	}
+	r, known := q.resources[resource]
+	if !known || p.now().After(r.reset) {
+		return defaultQuota
+	}
+	return r.remaining
+}
+
+// Next implements TokenAccessor.Next for requests of the REST API.
+func (p *quotaPoolAccessor) Next() (uint64, string) {
+	return p.NextFor(DefaultResource)
+}
+
+// NextFor implements QuotaTracker.NextFor. It returns the token with the most
+// remaining quota for resource once its in-flight requests are accounted for.
+func (p *quotaPoolAccessor) NextFor(resource string) (uint64, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	best, bestQuota := 0, math.MinInt
+	for n := 0; n < len(p.tokens); n++ {
+		i := (p.next + n) % len(p.tokens)
+		if quota := p.available(i, resource) - p.quotas[i].inFlight; quota > bestQuota {
+			best, bestQuota = i, quota
+		}
+	}
+	p.next = (best + 1) % len(p.tokens)
+	p.quotas[best].inFlight++
+	return uint64(best), p.tokens[best]
+}
+
+// Release implements TokenAccessor.Release.
+func (p *quotaPoolAccessor) Release(id uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if q := &p.quotas[id]; q.inFlight > 0 {
+		q.inFlight--
+	}
+}
+
+// UpdateQuota implements QuotaTracker.UpdateQuota.
+func (p *quotaPoolAccessor) UpdateQuota(id uint64, resource string, remaining int, reset time.Time) {
+	p.mu.Lock()
+	q := &p.quotas[id]
+	q.resources[resource] = resourceQuota{remaining: remaining, reset: reset}
+	stale := q.stale(resource)
+	p.mu.Unlock()
+	if stale {
+		p.save(id)
+	}
+}
+
+// HasQuota implements QuotaTracker.HasQuota.
+func (p *quotaPoolAccessor) HasQuota(resource string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.tokens {
+		if p.available(i, resource) > 0 {
+			return true
+		}
+	}
+	return false
+}
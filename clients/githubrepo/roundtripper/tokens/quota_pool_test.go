@@ -0,0 +1,100 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuotaPoolAccessor(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1700000000, 0)
+	pool := makeQuotaPoolAccessor([]string{"a", "b", "c"})
+	pool.now = func() time.Time { return now }
+
+	// Unknown quotas are tied, so tokens are used in turn.
+	for _, want := range []uint64{0, 1, 2} {
+		id, _ := pool.Next()
+		pool.Release(id)
+		if id != want {
+			t.Errorf("Next() = %d, want %d", id, want)
+		}
+	}
+
+	pool.UpdateQuota(0, DefaultResource, 10, now.Add(time.Hour))
+	pool.UpdateQuota(1, DefaultResource, 4000, now.Add(time.Hour))
+	pool.UpdateQuota(2, DefaultResource, 3999, now.Add(time.Hour))
+	id, token := pool.Next()
+	if id != 1 || token != "b" {
+		t.Errorf("Next() = %d, %q, want the token with the most quota left", id, token)
+	}
+	// Requests in flight count against the quota of their token.
+	if second, _ := pool.Next(); second != 2 {
+		t.Errorf("Next() = %d with token 1 in flight, want 2", second)
+	}
+	pool.Release(2)
+	pool.Release(id)
+
+	pool.UpdateQuota(1, DefaultResource, 0, now.Add(time.Hour))
+	pool.UpdateQuota(2, DefaultResource, 0, now.Add(time.Hour))
+	if id, _ := pool.Next(); id != 0 {
+		t.Errorf("Next() = %d, want the only token with quota left", id)
+	}
+	pool.Release(0)
+
+	pool.UpdateQuota(0, DefaultResource, 0, now.Add(time.Hour))
+	if pool.HasQuota(DefaultResource) {
+		t.Error("HasQuota() = true with all tokens exhausted")
+	}
+	// Quotas are restored once they reset.
+	pool.UpdateQuota(2, DefaultResource, 0, now.Add(-time.Second))
+	if !pool.HasQuota(DefaultResource) {
+		t.Error("HasQuota() = false after a quota reset")
+	}
+	if id, _ := pool.Next(); id != 2 {
+		t.Errorf("Next() = %d, want the token whose quota was reset", id)
+	}
+}
+
+func TestQuotaPoolAccessorResources(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1700000000, 0)
+	pool := makeQuotaPoolAccessor([]string{"a", "b"})
+	pool.now = func() time.Time { return now }
+
+	// Exhausting the graphql quota of a token leaves it in use for the REST
+	// API, and a low REST quota does not hide the graphql quota left.
+	pool.UpdateQuota(0, "graphql", 0, now.Add(time.Hour))
+	pool.UpdateQuota(0, DefaultResource, 4000, now.Add(time.Hour))
+	pool.UpdateQuota(1, "graphql", 3000, now.Add(time.Hour))
+	pool.UpdateQuota(1, DefaultResource, 10, now.Add(time.Hour))
+	if id, _ := pool.NextFor("graphql"); id != 1 {
+		t.Errorf("NextFor(graphql) = %d, want the token with graphql quota left", id)
+	}
+	pool.Release(1)
+	if id, _ := pool.Next(); id != 0 {
+		t.Errorf("Next() = %d, want the token with core quota left", id)
+	}
+	pool.Release(0)
+
+	pool.UpdateQuota(1, "graphql", 0, now.Add(time.Hour))
+	if pool.HasQuota("graphql") {
+		t.Error("HasQuota(graphql) = true with all graphql quotas exhausted")
+	}
+	if !pool.HasQuota(DefaultResource) {
+		t.Error("HasQuota(core) = false with core quota left")
+	}
+}
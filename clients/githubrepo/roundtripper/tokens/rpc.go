@@ -25,8 +25,8 @@ type TokenOverRPC struct {
 	client TokenAccessor
 }
 
-// Next requests for the next available GitHub token.
-// Server blocks the call until a token becomes available.
+// Next requests for the next available GitHub token, the one with the
+// fewest requests in flight among the tokens of the server.
 func (accessor *TokenOverRPC) Next(args struct{}, token *Token) error {
 	id, val := accessor.client.Next()
 	*token = Token{
@@ -0,0 +1,162 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// saveInterval is how many requests a token makes between saves of its
+// state, so that the store is not written on every request.
+const saveInterval = 100
+
+// StateStore persists the rate limit state of tokens across processes, so
+// that a restarted worker doesn't start from a cold limiter. Errors from Get
+// are handled as missing state.
+type StateStore interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Put stores value under key, which may be deleted after ttl.
+	Put(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// PersistentTracker is implemented by QuotaTrackers whose state can be
+// persisted.
+type PersistentTracker interface {
+	// LoadState restores the state of the tokens from store, which is then
+	// kept up to date.
+	LoadState(ctx context.Context, store StateStore)
+}
+
+// BackoffTracker is implemented by TokenAccessors which keep tokens rejected
+// by the secondary rate limit out of use.
+type BackoffTracker interface {
+	// Backoff records that token id must not be used before until.
+	Backoff(id uint64, until time.Time)
+	// BlockedUntil returns the time token id may be used again.
+	BlockedUntil(id uint64) time.Time
+}
+
+// tokenState is the persisted state of a token.
+type tokenState struct {
+	// Resources are the quotas of the rate limit resources of the token.
+	Resources    map[string]resourceState `json:"resources,omitempty"`
+	BlockedUntil int64                    `json:"blockedUntil,omitempty"`
+}
+
+// resourceState is the persisted quota of a token for a rate limit resource.
+type resourceState struct {
+	Reset     int64 `json:"reset"`
+	Remaining int   `json:"remaining"`
+}
+
+// stateKey identifies the state of token without revealing the token.
+func stateKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadState implements PersistentTracker.LoadState.
+func (p *quotaPoolAccessor) LoadState(ctx context.Context, store StateStore) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ctx, p.store = ctx, store
+	now := p.now()
+	for i, token := range p.tokens {
+		content, err := store.Get(ctx, stateKey(token))
+		if err != nil || len(content) == 0 {
+			continue
+		}
+		var s tokenState
+		if err := json.Unmarshal(content, &s); err != nil {
+			continue
+		}
+		q := &p.quotas[i]
+		for resource, r := range s.Resources {
+			if reset := time.Unix(r.Reset, 0); reset.After(now) {
+				q.resources[resource] = resourceQuota{remaining: r.Remaining, reset: reset}
+			}
+		}
+		if s.BlockedUntil != 0 {
+			q.blockedUntil = time.Unix(s.BlockedUntil, 0)
+		}
+		q.saved = s
+	}
+}
+
+// Backoff implements BackoffTracker.Backoff.
+func (p *quotaPoolAccessor) Backoff(id uint64, until time.Time) {
+	p.mu.Lock()
+	q := &p.quotas[id]
+	if until.After(q.blockedUntil) {
+		q.blockedUntil = until
+	}
+	p.mu.Unlock()
+	p.save(id)
+}
+
+// BlockedUntil implements BackoffTracker.BlockedUntil.
+func (p *quotaPoolAccessor) BlockedUntil(id uint64) time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.quotas[id].blockedUntil
+}
+
+// stale returns true if the saved quota of q for resource is outdated enough
+// to be saved again.
+func (q *tokenQuota) stale(resource string) bool {
+	r, saved := q.resources[resource], q.saved.Resources[resource]
+	return saved.Reset != r.reset.Unix() ||
+		saved.Remaining-r.remaining >= saveInterval ||
+		(r.remaining == 0 && saved.Remaining != 0)
+}
+
+// save persists the state of token id, if there is a store. Saving is best
+// effort: a state which failed to be saved only costs a colder start.
+func (p *quotaPoolAccessor) save(id uint64) {
+	p.mu.Lock()
+	if p.store == nil {
+		p.mu.Unlock()
+		return
+	}
+	q := &p.quotas[id]
+	s := tokenState{Resources: map[string]resourceState{}}
+	var expiry time.Time
+	for resource, r := range q.resources {
+		s.Resources[resource] = resourceState{Reset: r.reset.Unix(), Remaining: r.remaining}
+		if r.reset.After(expiry) {
+			expiry = r.reset
+		}
+	}
+	if !q.blockedUntil.IsZero() {
+		s.BlockedUntil = q.blockedUntil.Unix()
+		if q.blockedUntil.After(expiry) {
+			expiry = q.blockedUntil
+		}
+	}
+	q.saved = s
+	ttl := expiry.Sub(p.now())
+	p.mu.Unlock()
+
+	content, err := json.Marshal(s)
+	if err != nil || ttl <= 0 {
+		return
+	}
+	//nolint:errcheck
+	p.store.Put(p.ctx, stateKey(p.tokens[id]), content, ttl)
+}
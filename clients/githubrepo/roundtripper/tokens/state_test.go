@@ -0,0 +1,92 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+var errNotFound = errors.New("not found")
+
+type memStore struct {
+	values map[string][]byte
+	puts   int
+	mu     sync.Mutex
+}
+
+func (m *memStore) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.values[key]
+	if !ok {
+		return nil, errNotFound
+	}
+	return v, nil
+}
+
+func (m *memStore) Put(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = value
+	m.puts++
+	return nil
+}
+
+func TestQuotaPoolAccessorState(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1700000000, 0)
+	store := &memStore{values: map[string][]byte{}}
+	pool := makeQuotaPoolAccessor([]string{"a", "b"})
+	pool.now = func() time.Time { return now }
+	pool.LoadState(context.Background(), store)
+
+	pool.UpdateQuota(0, DefaultResource, 4000, now.Add(time.Hour))
+	// Small changes of the quota are not saved.
+	pool.UpdateQuota(0, DefaultResource, 3990, now.Add(time.Hour))
+	if store.puts != 1 {
+		t.Errorf("state saved %d times, want 1", store.puts)
+	}
+	pool.UpdateQuota(1, DefaultResource, 3000, now.Add(time.Hour))
+	pool.Backoff(1, now.Add(time.Minute))
+	if _, ok := store.values["a"]; ok {
+		t.Error("state stored under the token")
+	}
+
+	// A restarted process starts from the saved state.
+	restarted := makeQuotaPoolAccessor([]string{"a", "b"})
+	restarted.now = pool.now
+	restarted.LoadState(context.Background(), store)
+	if got := restarted.available(0, DefaultResource); got != 4000 {
+		t.Errorf("available(0) = %d, want 4000", got)
+	}
+	if got := restarted.BlockedUntil(1); !got.Equal(now.Add(time.Minute)) {
+		t.Errorf("BlockedUntil(1) = %v, want %v", got, now.Add(time.Minute))
+	}
+	if id, _ := restarted.Next(); id != 0 {
+		t.Errorf("Next() = %d, want the token which is not backing off", id)
+	}
+
+	// Saved quotas are forgotten once they reset.
+	later := makeQuotaPoolAccessor([]string{"a", "b"})
+	later.now = func() time.Time { return now.Add(2 * time.Hour) }
+	later.LoadState(context.Background(), store)
+	if got := later.available(0, DefaultResource); got != defaultQuota {
+		t.Errorf("available(0) after reset = %d, want %d", got, defaultQuota)
+	}
+}
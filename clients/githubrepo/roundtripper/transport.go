@@ -15,9 +15,12 @@
 package roundtripper
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -26,6 +29,9 @@ import (
 	githubstats "github.com/ossf/scorecard/v4/clients/githubrepo/stats"
 )
 
+// maxTokenRotations bounds the retries of a request with other tokens.
+const maxTokenRotations = 10
+
 // makeGitHubTransport wraps input RoundTripper with GitHub authorization logic.
 func makeGitHubTransport(innerTransport http.RoundTripper, accessor tokens.TokenAccessor) http.RoundTripper {
 	return &githubTransport{
@@ -41,29 +47,128 @@ type githubTransport struct {
 }
 
 func (gt *githubTransport) RoundTrip(r *http.Request) (*http.Response, error) {
-	id, token := gt.tokens.Next()
+	tracker, tracksQuota := gt.tokens.(tokens.QuotaTracker)
+	resource := rateLimitResource(r)
+	for attempt := 0; ; attempt++ {
+		ctx, resp, err := gt.roundTrip(r, resource, attempt > 0)
+		if err != nil {
+			return nil, err
+		}
+		if got := resp.Header.Get("X-RateLimit-Resource"); got != "" {
+			resource = got
+		}
+		// Retry requests which exhausted the quota of their token with
+		// the token with the most quota left, until none has any.
+		retry := tracksQuota && quotaExhausted(resp) && tracker.HasQuota(resource) && canRewind(r)
+		if !retry || attempt >= maxTokenRotations {
+			return resp, nil
+		}
+		resp.Body.Close()
+		stats.Record(ctx, githubstats.TokenRotations.M(1))
+	}
+}
+
+// roundTrip sends r with the next token for the rate limit resource and
+// records the quota left for it. The body of r is read again if it was sent by
+// a previous attempt.
+func (gt *githubTransport) roundTrip(r *http.Request, resource string,
+	resend bool,
+) (context.Context, *http.Response, error) {
+	tracker, tracksQuota := gt.tokens.(tokens.QuotaTracker)
+	var id uint64
+	var token string
+	if tracksQuota {
+		id, token = tracker.NextFor(resource)
+	} else {
+		id, token = gt.tokens.Next()
+	}
 	defer gt.tokens.Release(id)
+	backoff, tracksBackoff := gt.tokens.(tokens.BackoffTracker)
+	if tracksBackoff {
+		// Wait out a secondary rate limit hit by this token, possibly in a
+		// previous run, rather than tripping it again.
+		if duration := time.Until(backoff.BlockedUntil(id)); duration > 0 {
+			if err := wait(r, duration); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
 
 	ctx, err := tag.New(r.Context(), tag.Upsert(githubstats.TokenIndex, fmt.Sprint(id)))
 	if err != nil {
-		return nil, fmt.Errorf("error updating context: %w", err)
+		return nil, nil, fmt.Errorf("error updating context: %w", err)
 	}
-	*r = *r.WithContext(ctx)
-
-	r.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-	resp, err := gt.innerTransport.RoundTrip(r)
+	req := r.Clone(ctx)
+	if resend && r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, nil, fmt.Errorf("error during GetBody: %w", err)
+		}
+		req.Body = body
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	resp, err := gt.innerTransport.RoundTrip(req)
 	if err != nil {
-		return nil, fmt.Errorf("error in HTTP: %w", err)
+		return nil, nil, fmt.Errorf("error in HTTP: %w", err)
+	}
+
+	if tracksBackoff && isSecondaryRateLimit(resp) {
+		backoff.Backoff(id, time.Now().Add(secondaryBackoffOf(resp)))
 	}
 
-	ctx, err = tag.New(r.Context(), tag.Upsert(githubstats.ResourceType, resp.Header.Get("X-RateLimit-Resource")))
+	if got := resp.Header.Get("X-RateLimit-Resource"); got != "" {
+		resource = got
+	}
+	ctx, err = tag.New(ctx, tag.Upsert(githubstats.ResourceType, resp.Header.Get("X-RateLimit-Resource")))
 	if err != nil {
-		return nil, fmt.Errorf("error updating context: %w", err)
+		return nil, nil, fmt.Errorf("error updating context: %w", err)
 	}
 	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
 	if err == nil {
 		stats.Record(ctx, githubstats.RemainingTokens.M(int64(remaining)))
+		if tracksQuota {
+			reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+			if err == nil {
+				tracker.UpdateQuota(id, resource, remaining, time.Unix(reset, 0))
+			}
+		}
+	}
+	return ctx, resp, nil
+}
+
+// rateLimitResource returns the rate limit resource r is expected to count
+// against, until GitHub reports it with X-RateLimit-Resource.
+func rateLimitResource(r *http.Request) string {
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/graphql"):
+		return "graphql"
+	case strings.HasPrefix(r.URL.Path, "/search/") && strings.HasSuffix(r.URL.Path, "/code"):
+		return "code_search"
+	case strings.HasPrefix(r.URL.Path, "/search/"):
+		return "search"
+	default:
+		return tokens.DefaultResource
+	}
+}
+
+// quotaExhausted returns true if resp was rejected because the primary rate
+// limit of its token was exhausted.
+func quotaExhausted(resp *http.Response) bool {
+	return (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) &&
+		resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
+
+// secondaryBackoffOf returns how long to wait after resp was rejected by the
+// secondary rate limit.
+func secondaryBackoffOf(resp *http.Response) time.Duration {
+	if retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(retryAfter) * time.Second
 	}
+	// GitHub recommends waiting at least a minute before retrying.
+	return time.Minute
+}
 
-	return resp, nil
+// canRewind returns true if the body of r, if any, can be sent again.
+func canRewind(r *http.Request) bool {
+	return r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
 }
@@ -0,0 +1,163 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// quotaTransport rejects requests made with exhausted tokens. The quotas of
+// graphql requests are keyed by token and "graphql".
+type quotaTransport struct {
+	remaining map[string]int
+	calls     []string
+}
+
+func (t *quotaTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	auth := r.Header.Get("Authorization")
+	t.calls = append(t.calls, auth)
+	key, resource := auth, "core"
+	if r.URL.Path == "/graphql" {
+		key, resource = auth+" graphql", "graphql"
+	}
+	status := http.StatusOK
+	if t.remaining[key] == 0 {
+		status = http.StatusForbidden
+	} else {
+		t.remaining[key]--
+	}
+	header := http.Header{}
+	header.Set("X-RateLimit-Resource", resource)
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(t.remaining[key]))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	return &http.Response{StatusCode: status, Header: header, Body: http.NoBody}, nil
+}
+
+type quotaKey struct {
+	resource string
+	id       uint64
+}
+
+type fakePool struct {
+	quota     map[quotaKey]int
+	tokens    []string
+	resources []string
+	next      int
+}
+
+func (p *fakePool) Next() (uint64, string) {
+	return p.NextFor("core")
+}
+
+func (p *fakePool) NextFor(resource string) (uint64, string) {
+	p.resources = append(p.resources, resource)
+	id := p.next % len(p.tokens)
+	p.next++
+	return uint64(id), p.tokens[id]
+}
+
+func (p *fakePool) Release(uint64) {}
+
+func (p *fakePool) UpdateQuota(id uint64, resource string, remaining int, reset time.Time) {
+	p.quota[quotaKey{resource: resource, id: id}] = remaining
+}
+
+func (p *fakePool) HasQuota(resource string) bool {
+	for i := range p.tokens {
+		if remaining, ok := p.quota[quotaKey{resource: resource, id: uint64(i)}]; !ok || remaining > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGitHubTransportRotatesExhaustedTokens(t *testing.T) {
+	t.Parallel()
+	inner := &quotaTransport{remaining: map[string]int{"Bearer a": 0, "Bearer b": 1}}
+	pool := &fakePool{tokens: []string{"a", "b"}, quota: map[quotaKey]int{}}
+	transport := makeGitHubTransport(inner, pool)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.github.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(inner.calls) != 2 {
+		t.Errorf("expected a retry with the second token, got %d after %v", resp.StatusCode, inner.calls)
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Error("RoundTrip modified the request")
+	}
+
+	// Once all tokens are exhausted, the rejection is returned.
+	resp, err = transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden || len(inner.calls) != 3 {
+		t.Errorf("expected the rejection without more retries, got %d after %v", resp.StatusCode, inner.calls)
+	}
+}
+
+func TestGitHubTransportTracksQuotaPerResource(t *testing.T) {
+	t.Parallel()
+	inner := &quotaTransport{remaining: map[string]int{
+		"Bearer a": 10, "Bearer a graphql": 0,
+		"Bearer b": 10, "Bearer b graphql": 0,
+	}}
+	pool := &fakePool{tokens: []string{"a", "b"}, quota: map[quotaKey]int{}}
+	transport := makeGitHubTransport(inner, pool)
+
+	// The exhausted graphql quotas of both tokens stop the retries of graphql
+	// requests, without taking the tokens out of use for the REST API.
+	graphql, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://api.github.com/graphql", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := transport.RoundTrip(graphql)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden || len(inner.calls) != 2 {
+		t.Errorf("expected the graphql rejection after trying both tokens, got %d after %v", resp.StatusCode, inner.calls)
+	}
+
+	rest, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.github.com/repos/o/r", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = transport.RoundTrip(rest)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("REST request got %d, want it served with the core quota", resp.StatusCode)
+	}
+	if want := []string{"graphql", "graphql", "core"}; strings.Join(pool.resources, ",") != strings.Join(want, ",") {
+		t.Errorf("tokens requested for %v, want %v", pool.resources, want)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// The go-github version in use predates repository rulesets, so rules
+// active on a branch are decoded below.
+// https://docs.github.com/en/rest/repos/rules#get-rules-for-a-branch
+type branchRule struct {
+	Type       string `json:"type"`
+	Parameters struct {
+		RequiredApprovingReviewCount *int32 `json:"required_approving_review_count"`
+		RequireCodeOwnerReview       *bool  `json:"require_code_owner_review"`
+		DismissStaleReviewsOnPush    *bool  `json:"dismiss_stale_reviews_on_push"`
+		RequireLastPushApproval      *bool  `json:"require_last_push_approval"`
+		StrictStatusChecks           *bool  `json:"strict_required_status_checks_policy"`
+		RequiredStatusChecks         []struct {
+			Context string `json:"context"`
+		} `json:"required_status_checks"`
+	} `json:"parameters"`
+}
+
+const (
+	ruleDeletion      = "deletion"
+	ruleForcePush     = "non_fast_forward"
+	rulePullRequest   = "pull_request"
+	ruleLinearHistory = "required_linear_history"
+	ruleMergeQueue    = "merge_queue"
+	ruleStatusChecks  = "required_status_checks"
+)
+
+func (handler *branchesHandler) getRules(branchName string) ([]branchRule, error) {
+	reqURL := path.Join("repos", handler.repourl.owner, handler.repourl.repo, "rules", "branches", branchName)
+	req, err := handler.ghClient.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("request for %s failed with %w", reqURL, err)
+	}
+	var rules []branchRule
+	if resp, err := handler.ghClient.Do(handler.ctx, req, &rules); err != nil {
+		if ssoErr := ssoError(resp, err); ssoErr != nil {
+			return nil, fmt.Errorf("%s: %w", reqURL, ssoErr)
+		}
+		// Rulesets are not available on older GitHub Enterprise servers,
+		// and may not be visible to the token.
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("response for %s failed with %w", reqURL, err)
+	}
+	return rules, nil
+}
+
+// applyRulesets merges the rules of the rulesets that target the branch on
+// top of its branch protection settings. A ruleset can only add restrictions,
+// so settings are only ever made stricter.
+func (handler *branchesHandler) applyRulesets(branchRef *clients.BranchRef) error {
+	if branchRef == nil || branchRef.Name == nil {
+		return nil
+	}
+	rules, err := handler.getRules(*branchRef.Name)
+	if err != nil {
+		return err
+	}
+	mergeBranchRules(branchRef, rules)
+	return nil
+}
+
+// mergeBranchRules merges rules on top of the branch protection settings of
+// branchRef, which is protected if there are any.
+func mergeBranchRules(branchRef *clients.BranchRef, rules []branchRule) {
+	if len(rules) == 0 {
+		return
+	}
+	protected := true
+	branchRef.Protected = &protected
+	mergeRules(rules, &branchRef.BranchProtectionRule)
+}
+
+func mergeRules(rules []branchRule, dst *clients.BranchProtectionRule) {
+	falseVal, trueVal := false, true
+	for i := range rules {
+		rule := &rules[i]
+		switch rule.Type {
+		case ruleDeletion:
+			dst.AllowDeletions = &falseVal
+		case ruleForcePush:
+			dst.AllowForcePushes = &falseVal
+		case ruleLinearHistory:
+			dst.RequireLinearHistory = &trueVal
+		case ruleMergeQueue:
+			dst.RequireMergeQueue = &trueVal
+		case ruleStatusChecks:
+			dst.CheckRules.RequiresStatusChecks = &trueVal
+			mergeBool(rule.Parameters.StrictStatusChecks, &dst.CheckRules.UpToDateBeforeMerge)
+			for _, check := range rule.Parameters.RequiredStatusChecks {
+				dst.CheckRules.Contexts = append(dst.CheckRules.Contexts, check.Context)
+			}
+		case rulePullRequest:
+			reviews := &dst.RequiredPullRequestReviews
+			mergeBool(rule.Parameters.RequireCodeOwnerReview, &reviews.RequireCodeOwnerReviews)
+			mergeBool(rule.Parameters.DismissStaleReviewsOnPush, &reviews.DismissStaleReviews)
+			mergeBool(rule.Parameters.RequireLastPushApproval, &dst.RequireLastPushApproval)
+			if c := rule.Parameters.RequiredApprovingReviewCount; c != nil &&
+				(reviews.RequiredApprovingReviewCount == nil || *c > *reviews.RequiredApprovingReviewCount) {
+				count := *c
+				reviews.RequiredApprovingReviewCount = &count
+			}
+		}
+	}
+}
+
+func mergeBool(src *bool, dst **bool) {
+	if src == nil {
+		return
+	}
+	if *dst == nil || *src {
+		v := *src
+		*dst = &v
+	}
+}
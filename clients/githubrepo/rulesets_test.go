@@ -0,0 +1,152 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+func TestApplyRulesets(t *testing.T) {
+	t.Parallel()
+	trueVal, falseVal := true, false
+	var twoVal int32 = 2
+	main, dev, legacy := "main", "dev", "legacy"
+	tests := []struct {
+		name   string
+		branch *clients.BranchRef
+		want   *clients.BranchRef
+	}{
+		{
+			name: "rulesets protect an unprotected branch",
+			branch: &clients.BranchRef{
+				Name:      &main,
+				Protected: &falseVal,
+			},
+			want: &clients.BranchRef{
+				Name:      &main,
+				Protected: &trueVal,
+				BranchProtectionRule: clients.BranchProtectionRule{
+					AllowDeletions:          &falseVal,
+					AllowForcePushes:        &falseVal,
+					RequireLastPushApproval: &falseVal,
+					RequireMergeQueue:       &trueVal,
+					RequiredPullRequestReviews: clients.PullRequestReviewRule{
+						RequiredApprovingReviewCount: &twoVal,
+						RequireCodeOwnerReviews:      &trueVal,
+						DismissStaleReviews:          &falseVal,
+					},
+					CheckRules: clients.StatusChecksRule{
+						RequiresStatusChecks: &trueVal,
+						UpToDateBeforeMerge:  &falseVal,
+						Contexts:             []string{"test"},
+					},
+				},
+			},
+		},
+		{
+			name: "ruleset only makes branch protection stricter",
+			branch: &clients.BranchRef{
+				Name:      &dev,
+				Protected: &trueVal,
+				BranchProtectionRule: clients.BranchProtectionRule{
+					AllowDeletions: &falseVal,
+					RequiredPullRequestReviews: clients.PullRequestReviewRule{
+						RequiredApprovingReviewCount: &twoVal,
+						RequireCodeOwnerReviews:      &trueVal,
+					},
+				},
+			},
+			want: &clients.BranchRef{
+				Name:      &dev,
+				Protected: &trueVal,
+				BranchProtectionRule: clients.BranchProtectionRule{
+					AllowDeletions: &falseVal,
+					RequiredPullRequestReviews: clients.PullRequestReviewRule{
+						RequiredApprovingReviewCount: &twoVal,
+						RequireCodeOwnerReviews:      &trueVal,
+					},
+				},
+			},
+		},
+		{
+			name: "rulesets not available",
+			branch: &clients.BranchRef{
+				Name:      &legacy,
+				Protected: &falseVal,
+			},
+			want: &clients.BranchRef{
+				Name:      &legacy,
+				Protected: &falseVal,
+			},
+		},
+	}
+	rt := &releasesTransport{
+		responses: map[string]string{
+			"/repos/owner/repo/rules/branches/main": `[
+				{"type": "deletion"},
+				{"type": "non_fast_forward"},
+				{
+					"type": "pull_request",
+					"parameters": {
+						"required_approving_review_count": 2,
+						"require_code_owner_review": true,
+						"dismiss_stale_reviews_on_push": false,
+						"require_last_push_approval": false
+					}
+				},
+				{"type": "merge_queue", "parameters": {"merge_method": "SQUASH"}},
+				{
+					"type": "required_status_checks",
+					"parameters": {
+						"strict_required_status_checks_policy": false,
+						"required_status_checks": [{"context": "test"}]
+					}
+				}
+			]`,
+			"/repos/owner/repo/rules/branches/dev": `[
+				{
+					"type": "pull_request",
+					"parameters": {
+						"required_approving_review_count": 1,
+						"require_code_owner_review": false
+					}
+				}
+			]`,
+		},
+	}
+	handler := &branchesHandler{
+		ghClient: github.NewClient(&http.Client{Transport: rt}),
+	}
+	handler.init(context.Background(), &repoURL{
+		owner:     "owner",
+		repo:      "repo",
+		commitSHA: clients.HeadSHA,
+	})
+	for _, tt := range tests {
+		if err := handler.applyRulesets(tt.branch); err != nil {
+			t.Fatalf("%s: applyRulesets: %v", tt.name, err)
+		}
+		if diff := cmp.Diff(tt.want, tt.branch); diff != "" {
+			t.Errorf("%s: applyRulesets() mismatch (-want +got):\n%s", tt.name, diff)
+		}
+	}
+}
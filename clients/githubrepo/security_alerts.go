@@ -0,0 +1,172 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// Only the first page of open alerts is retrieved.
+const alertsPerPage = 100
+
+// The go-github version in use predates the Dependabot alerts API and the
+// security severity of code scanning rules, so responses are decoded below.
+type dependabotAlert struct {
+	SecurityAdvisory struct {
+		GHSAID      string `json:"ghsa_id"`
+		CVEID       string `json:"cve_id"`
+		Summary     string `json:"summary"`
+		Severity    string `json:"severity"`
+		Identifiers []struct {
+			Value string `json:"value"`
+		} `json:"identifiers"`
+	} `json:"security_advisory"`
+	Dependency struct {
+		ManifestPath string `json:"manifest_path"`
+	} `json:"dependency"`
+	HTMLURL string `json:"html_url"`
+}
+
+type codeScanningAlert struct {
+	Rule struct {
+		ID                    string `json:"id"`
+		Severity              string `json:"severity"`
+		SecuritySeverityLevel string `json:"security_severity_level"`
+		Description           string `json:"description"`
+	} `json:"rule"`
+	MostRecentInstance struct {
+		Location struct {
+			Path string `json:"path"`
+		} `json:"location"`
+	} `json:"most_recent_instance"`
+	HTMLURL string `json:"html_url"`
+}
+
+type securityAlertsHandler struct {
+	ghClient         *github.Client
+	dependabotOnce   *sync.Once
+	codeScanningOnce *sync.Once
+	ctx              context.Context
+	errDependabot    error
+	errCodeScanning  error
+	repourl          *repoURL
+	dependabot       []clients.SecurityAlert
+	codeScanning     []clients.SecurityAlert
+}
+
+func (handler *securityAlertsHandler) init(ctx context.Context, repourl *repoURL) {
+	handler.ctx = ctx
+	handler.repourl = repourl
+	handler.errDependabot = nil
+	handler.errCodeScanning = nil
+	handler.dependabotOnce = new(sync.Once)
+	handler.codeScanningOnce = new(sync.Once)
+	handler.dependabot = nil
+	handler.codeScanning = nil
+}
+
+func (handler *securityAlertsHandler) get(api string, v interface{}) error {
+	if !strings.EqualFold(handler.repourl.commitSHA, clients.HeadSHA) {
+		return fmt.Errorf("%w: %s alerts only supported for HEAD queries", clients.ErrUnsupportedFeature, api)
+	}
+	reqURL := path.Join("repos", handler.repourl.owner, handler.repourl.repo, api, "alerts") +
+		fmt.Sprintf("?state=open&per_page=%d", alertsPerPage)
+	req, err := handler.ghClient.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("request for %s failed with %w", reqURL, err)
+	}
+	if resp, err := handler.ghClient.Do(handler.ctx, req, v); err != nil {
+		if ssoErr := ssoError(resp, err); ssoErr != nil {
+			return fmt.Errorf("%s: %w", reqURL, ssoErr)
+		}
+		// GitHub answers 404 rather than 403 for private settings, and for
+		// code scanning when no analysis was uploaded yet.
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+			return fmt.Errorf("%w: %s: %v", clients.ErrPermissionDenied, reqURL, err)
+		}
+		return fmt.Errorf("response for %s failed with %w", reqURL, err)
+	}
+	return nil
+}
+
+func (handler *securityAlertsHandler) listDependencyAlerts() ([]clients.SecurityAlert, error) {
+	handler.dependabotOnce.Do(func() {
+		var alerts []dependabotAlert
+		if err := handler.get("dependabot", &alerts); err != nil {
+			handler.errDependabot = err
+			return
+		}
+		for i := range alerts {
+			advisory := &alerts[i].SecurityAdvisory
+			alert := clients.SecurityAlert{
+				ID:       advisory.GHSAID,
+				Severity: advisory.Severity,
+				Summary:  advisory.Summary,
+				Path:     alerts[i].Dependency.ManifestPath,
+				URL:      alerts[i].HTMLURL,
+			}
+			for _, id := range advisory.Identifiers {
+				if id.Value != advisory.GHSAID {
+					alert.Aliases = append(alert.Aliases, id.Value)
+				}
+			}
+			if len(alert.Aliases) == 0 && advisory.CVEID != "" {
+				alert.Aliases = []string{advisory.CVEID}
+			}
+			handler.dependabot = append(handler.dependabot, alert)
+		}
+	})
+	if handler.errDependabot != nil {
+		return nil, fmt.Errorf("error during securityAlertsHandler.listDependencyAlerts: %w", handler.errDependabot)
+	}
+	return handler.dependabot, nil
+}
+
+func (handler *securityAlertsHandler) listCodeScanningAlerts() ([]clients.SecurityAlert, error) {
+	handler.codeScanningOnce.Do(func() {
+		var alerts []codeScanningAlert
+		if err := handler.get("code-scanning", &alerts); err != nil {
+			handler.errCodeScanning = err
+			return
+		}
+		handler.codeScanning = []clients.SecurityAlert{}
+		for i := range alerts {
+			rule := &alerts[i].Rule
+			severity := rule.SecuritySeverityLevel
+			if severity == "" {
+				severity = rule.Severity
+			}
+			handler.codeScanning = append(handler.codeScanning, clients.SecurityAlert{
+				ID:       rule.ID,
+				Severity: severity,
+				Summary:  rule.Description,
+				Path:     alerts[i].MostRecentInstance.Location.Path,
+				URL:      alerts[i].HTMLURL,
+			})
+		}
+	})
+	if handler.errCodeScanning != nil {
+		return nil, fmt.Errorf("error during securityAlertsHandler.listCodeScanningAlerts: %w", handler.errCodeScanning)
+	}
+	return handler.codeScanning, nil
+}
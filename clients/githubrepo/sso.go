@@ -0,0 +1,66 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+const (
+	// ssoHeader is set by GitHub on responses to tokens which are not
+	// authorized for the SAML single sign-on of an organization, e.g.
+	// "required; url=https://github.com/orgs/<org>/sso?authorization_request=<id>".
+	ssoHeader = "X-GitHub-SSO"
+	// ssoMessage is part of the message of the errors returned by GitHub
+	// for resources protected by SAML single sign-on.
+	ssoMessage = "SAML enforcement"
+)
+
+// ssoError returns an error wrapping clients.ErrSSORequired if err is GitHub's
+// rejection of a token which is not authorized for the SAML single sign-on
+// of the organization owning the resource, and nil otherwise.
+func ssoError(resp *github.Response, err error) error {
+	if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	header := resp.Header.Get(ssoHeader)
+	var errResp *github.ErrorResponse
+	if !strings.HasPrefix(header, "required") &&
+		!(errors.As(err, &errResp) && strings.Contains(errResp.Message, ssoMessage)) {
+		return nil
+	}
+	if url := ssoAuthorizationURL(header); url != "" {
+		return fmt.Errorf("%w: authorize the token at %s", clients.ErrSSORequired, url)
+	}
+	return fmt.Errorf("%w: authorize the token for the organization", clients.ErrSSORequired)
+}
+
+// ssoAuthorizationURL returns the URL to authorize the token at from the value
+// of the X-GitHub-SSO header, or the empty string if there is none.
+func ssoAuthorizationURL(header string) string {
+	for _, part := range strings.Split(header, ";") {
+		if url := strings.TrimPrefix(strings.TrimSpace(part), "url="); url != strings.TrimSpace(part) {
+			return url
+		}
+	}
+	return ""
+}
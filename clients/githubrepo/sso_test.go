@@ -0,0 +1,95 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+func TestSSOError(t *testing.T) {
+	t.Parallel()
+	response := func(code int, header string) *github.Response {
+		resp := &http.Response{StatusCode: code, Header: http.Header{}}
+		if header != "" {
+			resp.Header.Set(ssoHeader, header)
+		}
+		return &github.Response{Response: resp}
+	}
+	tests := []struct {
+		name    string
+		resp    *github.Response
+		err     error
+		wantURL string
+		wantSSO bool
+	}{
+		{
+			name:    "header with authorization url",
+			resp:    response(http.StatusForbidden, "required; url=https://github.com/orgs/acme/sso?authorization_request=abc"),
+			err:     errors.New("403 Forbidden"), //nolint:goerr113
+			wantSSO: true,
+			wantURL: "https://github.com/orgs/acme/sso?authorization_request=abc",
+		},
+		{
+			name: "message only",
+			resp: response(http.StatusForbidden, ""),
+			err: &github.ErrorResponse{
+				Message: "Resource protected by organization SAML enforcement. You must grant your " +
+					"Personal Access token access to this organization.",
+			},
+			wantSSO: true,
+		},
+		{
+			name: "other forbidden",
+			resp: response(http.StatusForbidden, ""),
+			err:  &github.ErrorResponse{Message: "Must have admin rights to Repository."},
+		},
+		{
+			name: "not found",
+			resp: response(http.StatusNotFound, "required; url=https://github.com/orgs/acme/sso"),
+			err:  errors.New("404 Not Found"), //nolint:goerr113
+		},
+		{
+			name: "no error",
+			resp: response(http.StatusOK, ""),
+		},
+		{
+			name: "no response",
+			err:  errors.New("timeout"), //nolint:goerr113
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ssoError(tt.resp, tt.err)
+			if got := errors.Is(err, clients.ErrSSORequired); got != tt.wantSSO {
+				t.Fatalf("ssoError() = %v, want ErrSSORequired: %t", err, tt.wantSSO)
+			}
+			if tt.wantSSO && !errors.Is(err, clients.ErrPermissionDenied) {
+				t.Errorf("ssoError() = %v, want it to wrap ErrPermissionDenied", err)
+			}
+			if tt.wantURL != "" && !strings.Contains(err.Error(), tt.wantURL) {
+				t.Errorf("ssoError() = %v, want authorization url %s", err, tt.wantURL)
+			}
+		})
+	}
+}
@@ -27,6 +27,11 @@ var (
 	// RetryAfter measures the retry delay when dealing with secondary rate limits.
 	RetryAfter = stats.Int64("RetryAfter",
 		"Measures the retry delay when dealing with secondary rate limits", stats.UnitSeconds)
+	// TokenRotations measures the requests retried with another token
+	// because the quota of their token was exhausted.
+	TokenRotations = stats.Int64("TokenRotations",
+		"Measures the requests retried with another token once the quota of theirs is exhausted",
+		stats.UnitDimensionless)
 	// TokenIndex is the tag key for specifying a unique token.
 	TokenIndex = tag.MustNewKey("tokenIndex")
 	// ResourceType specifies the type of GitHub resource.
@@ -40,4 +45,13 @@ var (
 		TagKeys:     []tag.Key{TokenIndex, ResourceType},
 		Aggregation: view.LastValue(),
 	}
+
+	// GithubTokenRotations tracks the requests moved off an exhausted token per token.
+	GithubTokenRotations = view.View{
+		Name:        "GithubTokenRotations",
+		Description: "Requests retried with another token once the quota of theirs is exhausted",
+		Measure:     TokenRotations,
+		TagKeys:     []tag.Key{TokenIndex},
+		Aggregation: view.Count(),
+	}
 )
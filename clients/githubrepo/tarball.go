@@ -23,6 +23,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -37,11 +38,21 @@ import (
 const (
 	repoDir      = "repo*"
 	repoFilename = "githubrepo*.tar.gz"
+
+	// maxTarballSize is the largest compressed tarball we are willing to download.
+	maxTarballSize int64 = 1 << 30 // 1 GiB
+	// maxExtractedFileSize is the largest single file we extract from the tarball.
+	// Larger files are skipped so that GetFileContent never loads them into memory.
+	maxExtractedFileSize int64 = 100 << 20 // 100 MiB
+	// maxExtractedTotalSize bounds the total number of bytes written to disk
+	// while extracting, which protects against decompression bombs.
+	maxExtractedTotalSize int64 = 4 << 30 // 4 GiB
 )
 
 var (
 	errTarballNotFound  = errors.New("tarball not found")
 	errTarballCorrupted = errors.New("corrupted tarball")
+	errTarballTooLarge  = errors.New("tarball too large")
 	errZipSlip          = errors.New("ZipSlip path detected")
 )
 
@@ -74,6 +85,10 @@ type tarballHandler struct {
 	tempDir     string
 	tempTarFile string
 	files       []string
+	// Size limits, zero values use the package defaults.
+	maxTarballSize        int64
+	maxExtractedFileSize  int64
+	maxExtractedTotalSize int64
 }
 
 func (handler *tarballHandler) init(ctx context.Context, repo *github.Repository, commitSHA string) {
@@ -93,7 +108,7 @@ func (handler *tarballHandler) setup() error {
 		}
 
 		// Setup temp dir/files and download repo tarball.
-		if err := handler.getTarball(); errors.Is(err, errTarballNotFound) {
+		if err := handler.getTarball(); errors.Is(err, errTarballNotFound) || errors.Is(err, errTarballTooLarge) {
 			log.Printf("unable to get tarball %v. Skipping...", err)
 			return
 		} else if err != nil {
@@ -102,7 +117,7 @@ func (handler *tarballHandler) setup() error {
 		}
 
 		// Extract file names and content from tarball.
-		if err := handler.extractTarball(); errors.Is(err, errTarballCorrupted) {
+		if err := handler.extractTarball(); errors.Is(err, errTarballCorrupted) || errors.Is(err, errTarballTooLarge) {
 			log.Printf("unable to extract tarball %v. Skipping...", err)
 		} else if err != nil {
 			handler.errSetup = sce.WithMessage(sce.ErrScorecardInternal, err.Error())
@@ -111,14 +126,25 @@ func (handler *tarballHandler) setup() error {
 	return handler.errSetup
 }
 
-func (handler *tarballHandler) getTarball() error {
+// tarballURL returns the URL of the tarball of the commit. For HEAD, it asks
+// for the default branch by name, so that a renamed default branch is not
+// resolved through a redirect of its former name.
+func (handler *tarballHandler) tarballURL() string {
 	url := handler.repo.GetArchiveURL()
 	url = strings.Replace(url, "{archive_format}", "tarball/", 1)
-	if strings.EqualFold(handler.commitSHA, clients.HeadSHA) {
-		url = strings.Replace(url, "{/ref}", "", 1)
-	} else {
-		url = strings.Replace(url, "{/ref}", handler.commitSHA, 1)
+	ref := handler.commitSHA
+	if strings.EqualFold(ref, clients.HeadSHA) {
+		ref = handler.repo.GetDefaultBranch()
+	}
+	segments := strings.Split(ref, "/")
+	for i := range segments {
+		segments[i] = neturl.PathEscape(segments[i])
 	}
+	return strings.Replace(url, "{/ref}", strings.Join(segments, "/"), 1)
+}
+
+func (handler *tarballHandler) getTarball() error {
+	url := handler.tarballURL()
 	req, err := http.NewRequestWithContext(handler.ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return fmt.Errorf("http.NewRequestWithContext: %w", err)
@@ -134,6 +160,10 @@ func (handler *tarballHandler) getTarball() error {
 	case http.StatusNotFound, http.StatusBadRequest:
 		return fmt.Errorf("%w: %s", errTarballNotFound, url)
 	}
+	maxSize := limitOrDefault(handler.maxTarballSize, maxTarballSize)
+	if resp.ContentLength > maxSize {
+		return fmt.Errorf("%w: %s: %d bytes", errTarballTooLarge, url, resp.ContentLength)
+	}
 
 	// Create a temp file. This automatically appends a random number to the name.
 	tempDir, err := os.MkdirTemp("", repoDir)
@@ -145,12 +175,17 @@ func (handler *tarballHandler) getTarball() error {
 		return fmt.Errorf("os.CreateTemp: %w", err)
 	}
 	defer repoFile.Close()
-	if _, err := io.Copy(repoFile, resp.Body); err != nil {
+	handler.tempDir = tempDir
+	// Read one byte past the limit to detect oversized tarballs without a Content-Length.
+	n, err := io.Copy(repoFile, io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
 		// This can happen if the incoming tarball is corrupted/server gateway times out.
 		return fmt.Errorf("%w io.Copy: %v", errTarballNotFound, err)
 	}
+	if n > maxSize {
+		return fmt.Errorf("%w: %s: more than %d bytes", errTarballTooLarge, url, maxSize)
+	}
 
-	handler.tempDir = tempDir
 	handler.tempTarFile = repoFile.Name()
 	return nil
 }
@@ -166,6 +201,9 @@ func (handler *tarballHandler) extractTarball() error {
 		return fmt.Errorf("%w: gzip.NewReader %v %v", errTarballCorrupted, handler.tempTarFile, err)
 	}
 	tr := tar.NewReader(gz)
+	maxFileSize := limitOrDefault(handler.maxExtractedFileSize, maxExtractedFileSize)
+	maxTotalSize := limitOrDefault(handler.maxExtractedTotalSize, maxExtractedTotalSize)
+	var totalSize int64
 	for {
 		header, err := tr.Next()
 		if errors.Is(err, io.EOF) {
@@ -192,6 +230,14 @@ func (handler *tarballHandler) extractTarball() error {
 			if header.Size <= 0 {
 				continue
 			}
+			if header.Size > maxFileSize {
+				log.Printf("skipping file %s: size %d exceeds limit of %d bytes", header.Name, header.Size, maxFileSize)
+				continue
+			}
+			totalSize += header.Size
+			if totalSize > maxTotalSize {
+				return fmt.Errorf("%w: extracted content exceeds %d bytes", errTarballTooLarge, maxTotalSize)
+			}
 			filenamepath, err := extractAndValidateArchivePath(header.Name, handler.tempDir)
 			if err != nil {
 				return err
@@ -207,10 +253,9 @@ func (handler *tarballHandler) extractTarball() error {
 				return fmt.Errorf("os.Create: %w", err)
 			}
 
-			//nolint: gosec
-			// Potential for DoS vulnerability via decompression bomb.
-			// Since such an attack will only impact a single shard, ignoring this for now.
-			if _, err := io.Copy(outFile, tr); err != nil {
+			// The tar reader never returns more than header.Size bytes for an entry,
+			// and header.Size was bounded above, so this copy is bounded too.
+			if _, err := io.CopyN(outFile, tr, header.Size); err != nil {
 				return fmt.Errorf("%w io.Copy: %v", errTarballCorrupted, err)
 			}
 			outFile.Close()
@@ -226,6 +271,13 @@ func (handler *tarballHandler) extractTarball() error {
 	return nil
 }
 
+func limitOrDefault(limit, defaultLimit int64) int64 {
+	if limit > 0 {
+		return limit
+	}
+	return defaultLimit
+}
+
 func (handler *tarballHandler) listFiles(predicate func(string) (bool, error)) ([]string, error) {
 	if err := handler.setup(); err != nil {
 		return nil, fmt.Errorf("error during tarballHandler.setup: %w", err)
@@ -15,16 +15,23 @@
 package githubrepo
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
 )
 
 type listfileTest struct {
@@ -175,3 +182,150 @@ func TestExtractTarball(t *testing.T) {
 		})
 	}
 }
+
+func writeTestTarball(t *testing.T, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: "repo/", Typeflag: tar.TypeDir, Mode: 0o755}); err != nil {
+		t.Fatalf("WriteHeader: %v", err)
+	}
+	for name, content := range files {
+		hdr := &tar.Header{
+			Name:     "repo/" + name,
+			Typeflag: tar.TypeReg,
+			Mode:     0o644,
+			Size:     int64(len(content)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("WriteHeader: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar Close: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip Close: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "test.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestExtractTarballLimits(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		err          error
+		files        map[string]string
+		name         string
+		outcome      []string
+		maxFileSize  int64
+		maxTotalSize int64
+	}{
+		{
+			name:         "within limits",
+			files:        map[string]string{"a": "12345", "b": "12345"},
+			maxFileSize:  5,
+			maxTotalSize: 10,
+			outcome:      []string{"a", "b"},
+		},
+		{
+			name:         "oversized file is skipped",
+			files:        map[string]string{"small": "123", "big": "1234567890"},
+			maxFileSize:  5,
+			maxTotalSize: 100,
+			outcome:      []string{"small"},
+		},
+		{
+			name:         "total size exceeded",
+			files:        map[string]string{"a": "12345", "b": "12345", "c": "12345"},
+			maxFileSize:  5,
+			maxTotalSize: 12,
+			err:          errTarballTooLarge,
+		},
+	}
+	for _, testcase := range testcases {
+		testcase := testcase
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			handler, err := setup(writeTestTarball(t, testcase.files))
+			if err != nil {
+				t.Fatalf("test setup failed: %v", err)
+			}
+			defer handler.cleanup()
+			handler.maxExtractedFileSize = testcase.maxFileSize
+			handler.maxExtractedTotalSize = testcase.maxTotalSize
+
+			err = handler.extractTarball()
+			if !errors.Is(err, testcase.err) {
+				t.Fatalf("extractTarball() error = %v, want %v", err, testcase.err)
+			}
+			if err != nil {
+				return
+			}
+			files, err := handler.listFiles(func(string) (bool, error) { return true, nil })
+			if err != nil {
+				t.Fatalf("listFiles: %v", err)
+			}
+			if !cmp.Equal(testcase.outcome, files, cmpopts.SortSlices(isSortedString)) {
+				t.Errorf("expected - %q, got - %q", testcase.outcome, files)
+			}
+		})
+	}
+}
+
+func TestTarballURL(t *testing.T) {
+	t.Parallel()
+	const archiveURL = "https://api.github.com/repos/o/r/{archive_format}{/ref}"
+	testcases := []struct {
+		name          string
+		defaultBranch string
+		commitSHA     string
+		want          string
+	}{
+		{
+			name:      "commit",
+			commitSHA: "0123abcd",
+			want:      "https://api.github.com/repos/o/r/tarball/0123abcd",
+		},
+		{
+			name:          "renamed default branch",
+			defaultBranch: "trunk",
+			commitSHA:     clients.HeadSHA,
+			want:          "https://api.github.com/repos/o/r/tarball/trunk",
+		},
+		{
+			name:          "default branch with slashes",
+			defaultBranch: "release/v1 #2",
+			commitSHA:     clients.HeadSHA,
+			want:          "https://api.github.com/repos/o/r/tarball/release/v1%20%232",
+		},
+		{
+			name:      "no default branch",
+			commitSHA: clients.HeadSHA,
+			want:      "https://api.github.com/repos/o/r/tarball/",
+		},
+	}
+	for _, testcase := range testcases {
+		testcase := testcase
+		t.Run(testcase.name, func(t *testing.T) {
+			t.Parallel()
+			handler := tarballHandler{
+				commitSHA: testcase.commitSHA,
+				repo: &github.Repository{
+					ArchiveURL:    github.String(archiveURL),
+					DefaultBranch: github.String(testcase.defaultBranch),
+				},
+			}
+			if got := handler.tarballURL(); got != testcase.want {
+				t.Errorf("tarballURL() = %q, want %q", got, testcase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// treeHandler lists the files of a repo from its git tree and fetches their
+// content one by one when asked for, instead of downloading the tarball of
+// the repo like tarballHandler. It is much faster for checks which mostly
+// look for the presence of files.
+type treeHandler struct {
+	ghClient  *github.Client
+	once      *sync.Once
+	ctx       context.Context
+	errSetup  error
+	repourl   *repoURL
+	files     []string
+	mu        sync.Mutex
+	contents  map[string][]byte
+	commitSHA string
+}
+
+func (handler *treeHandler) init(ctx context.Context, repourl *repoURL, commitSHA string) {
+	handler.ctx = ctx
+	handler.repourl = repourl
+	handler.commitSHA = commitSHA
+	handler.errSetup = nil
+	handler.once = new(sync.Once)
+	handler.files = nil
+	handler.contents = map[string][]byte{}
+}
+
+// ref returns the ref the tree and the content of files are fetched at.
+func (handler *treeHandler) ref() string {
+	if strings.EqualFold(handler.commitSHA, clients.HeadSHA) {
+		return handler.repourl.defaultBranch
+	}
+	return handler.commitSHA
+}
+
+func (handler *treeHandler) setup() error {
+	handler.once.Do(func() {
+		// Trees of more than 100,000 entries are truncated by the API, the
+		// files listed are still a good enough answer for a shallow scan.
+		tree, _, err := handler.ghClient.Git.GetTree(handler.ctx, handler.repourl.owner, handler.repourl.repo,
+			handler.ref(), true /*recursive*/)
+		if err != nil {
+			handler.errSetup = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Git.GetTree: %v", err))
+			return
+		}
+		for _, entry := range tree.Entries {
+			if entry.GetType() == "blob" {
+				handler.files = append(handler.files, entry.GetPath())
+			}
+		}
+	})
+	return handler.errSetup
+}
+
+func (handler *treeHandler) listFiles(predicate func(string) (bool, error)) ([]string, error) {
+	if err := handler.setup(); err != nil {
+		return nil, fmt.Errorf("error during treeHandler.setup: %w", err)
+	}
+	ret := make([]string, 0)
+	for _, file := range handler.files {
+		matches, err := predicate(file)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			ret = append(ret, file)
+		}
+	}
+	return ret, nil
+}
+
+func (handler *treeHandler) getFileContent(filename string) ([]byte, error) {
+	handler.mu.Lock()
+	content, ok := handler.contents[filename]
+	handler.mu.Unlock()
+	if ok {
+		return content, nil
+	}
+	file, _, _, err := handler.ghClient.Repositories.GetContents(handler.ctx, handler.repourl.owner,
+		handler.repourl.repo, filename, &github.RepositoryContentGetOptions{Ref: handler.ref()})
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Repositories.GetContents: %v", err))
+	}
+	if file == nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("not a file: %s", filename))
+	}
+	decoded, err := file.GetContent()
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetContent: %v", err))
+	}
+	content = []byte(decoded)
+	handler.mu.Lock()
+	handler.contents[filename] = content
+	handler.mu.Unlock()
+	return content, nil
+}
+
+func (handler *treeHandler) getLocalPath() (string, error) {
+	return "", fmt.Errorf("%w: shallow scans do not download the repo", clients.ErrUnsupportedFeature)
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+func TestTreeHandler(t *testing.T) {
+	t.Parallel()
+	contentRequests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/git/trees/main", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("recursive") == "" {
+			t.Errorf("tree not listed recursively: %s", r.URL)
+		}
+		fmt.Fprint(w, `{"sha": "abc", "tree": [
+			{"path": "SECURITY.md", "type": "blob"},
+			{"path": ".github", "type": "tree"},
+			{"path": ".github/dependabot.yml", "type": "blob"},
+			{"path": "vendor/lib", "type": "commit"}
+		]}`)
+	})
+	mux.HandleFunc("/repos/owner/repo/contents/SECURITY.md", func(w http.ResponseWriter, r *http.Request) {
+		contentRequests++
+		if ref := r.URL.Query().Get("ref"); ref != "main" {
+			t.Errorf("content fetched at %q", ref)
+		}
+		// "report vulnerabilities" encoded in base64.
+		fmt.Fprint(w, `{"type": "file", "encoding": "base64", "content": "cmVwb3J0IHZ1bG5lcmFiaWxpdGllcw=="}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	baseURL, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	client.BaseURL = baseURL
+	handler := &treeHandler{ghClient: client}
+	handler.init(context.Background(), &repoURL{owner: "owner", repo: "repo", defaultBranch: "main"}, clients.HeadSHA)
+
+	files, err := handler.listFiles(func(string) (bool, error) { return true, nil })
+	if err != nil {
+		t.Fatalf("listFiles: %v", err)
+	}
+	if diff := cmp.Diff([]string{"SECURITY.md", ".github/dependabot.yml"}, files); diff != "" {
+		t.Errorf("listFiles() (-want,+got): %s", diff)
+	}
+	for i := 0; i < 2; i++ {
+		content, err := handler.getFileContent("SECURITY.md")
+		if err != nil {
+			t.Fatalf("getFileContent: %v", err)
+		}
+		if string(content) != "report vulnerabilities" {
+			t.Errorf("getFileContent() = %q", content)
+		}
+	}
+	if contentRequests != 1 {
+		t.Errorf("content fetched %d times, want once", contentRequests)
+	}
+	if _, err := handler.getLocalPath(); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("getLocalPath() error = %v, want %v", err, clients.ErrUnsupportedFeature)
+	}
+}
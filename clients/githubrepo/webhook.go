@@ -48,8 +48,12 @@ func (handler *webhookHandler) setup() error {
 			handler.errSetup = fmt.Errorf("%w: ListWebHooks only supported for HEAD queries", clients.ErrUnsupportedFeature)
 			return
 		}
-		hooks, _, err := handler.ghClient.Repositories.ListHooks(
+		hooks, resp, err := handler.ghClient.Repositories.ListHooks(
 			handler.ctx, handler.repourl.owner, handler.repourl.repo, &github.ListOptions{})
+		if ssoErr := ssoError(resp, err); ssoErr != nil {
+			handler.errSetup = fmt.Errorf("error during ListHooks: %w", ssoErr)
+			return
+		}
 		if err != nil {
 			handler.errSetup = fmt.Errorf("error during ListHooks: %w", err)
 			return
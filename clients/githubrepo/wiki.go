@@ -0,0 +1,89 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+const wikiSuffix = ".wiki"
+
+// wikiRepo returns the name of the repo whose wiki is referred to by repo,
+// which is the path following the owner in e.g. github.com/owner/repo/wiki,
+// github.com/owner/repo/wiki/Page or github.com/owner/repo.wiki.git.
+func wikiRepo(repo string) (string, bool) {
+	repo = strings.TrimSuffix(repo, ".git")
+	if name := strings.TrimSuffix(repo, wikiSuffix); name != repo {
+		return name, true
+	}
+	name, page, _ := strings.Cut(repo, "/")
+	if page == "wiki" || strings.HasPrefix(page, "wiki/") {
+		return name, true
+	}
+	return "", false
+}
+
+// IsWiki returns whether input refers to the wiki of a GitHub repo.
+func IsWiki(input string) bool {
+	var r repoURL
+	if err := r.parse(input); err != nil || r.host != "github.com" {
+		return false
+	}
+	_, ok := wikiRepo(r.repo)
+	return ok
+}
+
+// CloneWiki clones the default branch of the wiki referred to by input, or of
+// the wiki of the repo referred to by input, into dir and returns the URI of
+// the wiki, e.g. github.com/owner/repo.wiki.
+// Wikis are separate git repos without an API, so they can only be checked
+// from the files they contain.
+func CloneWiki(ctx context.Context, input, dir string) (string, error) {
+	var r repoURL
+	if err := r.parse(input); err != nil {
+		return "", err
+	}
+	if r.host != "github.com" {
+		return "", sce.WithMessage(sce.ErrorUnsupportedHost, r.host)
+	}
+	name, ok := wikiRepo(r.repo)
+	if !ok {
+		name = r.repo
+	}
+	uri := fmt.Sprintf("%s/%s/%s%s", r.host, r.owner, name, wikiSuffix)
+
+	_, err := git.PlainCloneContext(ctx, dir, false /*isBare*/, &git.CloneOptions{
+		URL:   fmt.Sprintf("https://%s.git", uri),
+		Depth: 1,
+	})
+	switch {
+	case err == nil:
+		return uri, nil
+	// GitHub asks for credentials instead of returning not found for wikis
+	// which do not exist or are not public.
+	case errors.Is(err, transport.ErrRepositoryNotFound), errors.Is(err, transport.ErrAuthenticationRequired):
+		return "", sce.WithMessage(sce.ErrRepoUnreachable, fmt.Sprintf("%s: wiki not found or not public", uri))
+	default:
+		return "", sce.WithMessage(sce.ErrRepoUnreachable, fmt.Sprintf("git.PlainClone: %v", err))
+	}
+}
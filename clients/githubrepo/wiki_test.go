@@ -0,0 +1,43 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import "testing"
+
+func TestIsWiki(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "github.com/foo/bar/wiki", want: true},
+		{input: "https://github.com/foo/bar/wiki/Security", want: true},
+		{input: "https://github.com/foo/bar.wiki.git", want: true},
+		{input: "github.com/foo/bar.wiki", want: true},
+		{input: "github.com/foo/bar", want: false},
+		{input: "foo/wiki", want: false},
+		{input: "github.com/foo/bar/tree/main/wiki", want: false},
+		{input: "gitlab.com/foo/bar/wiki", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			if got := IsWiki(tt.input); got != tt.want {
+				t.Errorf("IsWiki(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
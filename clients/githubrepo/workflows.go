@@ -55,8 +55,11 @@ func workflowsRunsFrom(data *github.WorkflowRuns) []clients.WorkflowRun {
 	var workflowRuns []clients.WorkflowRun
 	for _, workflowRun := range data.WorkflowRuns {
 		workflowRuns = append(workflowRuns, clients.WorkflowRun{
-			URL:     workflowRun.GetURL(),
-			HeadSHA: workflowRun.HeadSHA,
+			URL:       workflowRun.GetURL(),
+			HeadSHA:   workflowRun.HeadSHA,
+			Ref:       workflowRun.GetHeadBranch(),
+			Event:     workflowRun.GetEvent(),
+			CreatedAt: workflowRun.GetCreatedAt().Time,
 		})
 	}
 	return workflowRuns
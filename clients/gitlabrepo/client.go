@@ -20,11 +20,15 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/xanzy/go-gitlab"
 
 	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/gitlabrepo/roundtripper"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
 
@@ -69,7 +73,7 @@ func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string, commitD
 		return sce.WithMessage(sce.ErrRepoUnreachable, proj+"\t"+err.Error())
 	}
 	if commitDepth <= 0 {
-		client.commitDepth = 30 // default
+		client.commitDepth = clients.DefaultCommitDepth
 	} else {
 		client.commitDepth = commitDepth
 	}
@@ -132,6 +136,11 @@ func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string, commitD
 }
 
 func (client *Client) URI() string {
+	// repourl.project holds the numeric project ID used for API calls,
+	// report the canonical path of the project instead.
+	if client.repo != nil && client.repo.PathWithNamespace != "" {
+		return fmt.Sprintf("%s/%s", client.repourl.host, client.repo.PathWithNamespace)
+	}
 	return fmt.Sprintf("%s/%s/%s", client.repourl.host, client.repourl.owner, client.repourl.project)
 }
 
@@ -159,6 +168,10 @@ func (client *Client) ListReleases() ([]clients.Release, error) {
 	return client.releases.getReleases()
 }
 
+func (client *Client) GetReleaseAsset(asset *clients.ReleaseAsset) ([]byte, error) {
+	return nil, fmt.Errorf("GetReleaseAsset: %w", clients.ErrUnsupportedFeature)
+}
+
 func (client *Client) ListContributors() ([]clients.User, error) {
 	return client.contributors.getContributors()
 }
@@ -167,6 +180,34 @@ func (client *Client) IsArchived() (bool, error) {
 	return client.project.isArchived()
 }
 
+func (client *Client) GetSecurityPolicyURL() (string, error) {
+	return "", fmt.Errorf("GetSecurityPolicyURL: %w", clients.ErrUnsupportedFeature)
+}
+
+func (client *Client) IsPrivate() (bool, error) {
+	return client.repo.Visibility != gitlab.PublicVisibility, nil
+}
+
+func (client *Client) GetForkParent() (clients.Repo, error) {
+	parent := client.repo.ForkedFromProject
+	if parent == nil {
+		return nil, nil
+	}
+	const splitLen = 2
+	split := strings.SplitN(parent.PathWithNamespace, "/", splitLen)
+	if len(split) != splitLen {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("unexpected fork parent path: %s", parent.PathWithNamespace))
+	}
+	return &repoURL{
+		scheme:    client.repourl.scheme,
+		host:      client.repourl.host,
+		owner:     split[0],
+		project:   split[1],
+		commitSHA: clients.HeadSHA,
+	}, nil
+}
+
 func (client *Client) GetDefaultBranch() (*clients.BranchRef, error) {
 	return client.branches.getDefaultBranch()
 }
@@ -179,6 +220,23 @@ func (client *Client) GetBranch(branch string) (*clients.BranchRef, error) {
 	return client.branches.getBranch(branch)
 }
 
+func (client *Client) GetBranches(branches []string) ([]*clients.BranchRef, error) {
+	refs := make([]*clients.BranchRef, len(branches))
+	for i, branch := range branches {
+		ref, err := client.branches.getBranch(branch)
+		if err != nil {
+			return nil, err
+		}
+		refs[i] = ref
+	}
+	return refs, nil
+}
+
+// ListBranches implements RepoClient.ListBranches.
+func (client *Client) ListBranches() ([]clients.BranchInfo, error) {
+	return nil, fmt.Errorf("ListBranches: %w", clients.ErrUnsupportedFeature)
+}
+
 func (client *Client) GetCreatedAt() (time.Time, error) {
 	return client.project.getCreatedAt()
 }
@@ -187,6 +245,30 @@ func (client *Client) ListWebhooks() ([]clients.Webhook, error) {
 	return client.webhook.listWebhooks()
 }
 
+func (client *Client) GetActionsPolicy() (*clients.ActionsPolicy, error) {
+	return nil, fmt.Errorf("GetActionsPolicy: %w", clients.ErrUnsupportedFeature)
+}
+
+func (client *Client) ListDependencyAlerts() ([]clients.SecurityAlert, error) {
+	return nil, fmt.Errorf("ListDependencyAlerts: %w", clients.ErrUnsupportedFeature)
+}
+
+func (client *Client) ListCodeScanningAlerts() ([]clients.SecurityAlert, error) {
+	return nil, fmt.Errorf("ListCodeScanningAlerts: %w", clients.ErrUnsupportedFeature)
+}
+
+func (client *Client) ListEnvironments() ([]clients.Environment, error) {
+	return nil, fmt.Errorf("ListEnvironments: %w", clients.ErrUnsupportedFeature)
+}
+
+func (client *Client) ListAuditLogEvents() ([]clients.AuditLogEvent, error) {
+	return nil, fmt.Errorf("ListAuditLogEvents: %w", clients.ErrUnsupportedFeature)
+}
+
+func (client *Client) ListForcePushes() ([]clients.ForcePush, error) {
+	return nil, fmt.Errorf("ListForcePushes: %w", clients.ErrUnsupportedFeature)
+}
+
 func (client *Client) ListSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
 	return client.workflows.listSuccessfulWorkflowRuns(filename)
 }
@@ -220,12 +302,33 @@ func (client *Client) Close() error {
 	return nil
 }
 
+// CreateGitlabClient returns a client for the GitLab instance hosting repo,
+// authenticated with the tokens configured for the instance, see
+// roundtripper.NewTransport.
+func CreateGitlabClient(ctx context.Context, repo clients.Repo) (clients.RepoClient, error) {
+	baseURL, err := url.Parse(repo.Host())
+	if err != nil {
+		return nil, fmt.Errorf("could not parse gitlab host: %w", err)
+	}
+	httpClient := &http.Client{
+		Transport: roundtripper.NewTransport(baseURL.Host),
+	}
+	client, err := gitlab.NewClient("", gitlab.WithBaseURL(repo.Host()), gitlab.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("could not create gitlab client with error: %w", err)
+	}
+	return newClient(ctx, client), nil
+}
+
 func CreateGitlabClientWithToken(ctx context.Context, token string, repo clients.Repo) (clients.RepoClient, error) {
 	client, err := gitlab.NewClient(token, gitlab.WithBaseURL(repo.Host()))
 	if err != nil {
 		return nil, fmt.Errorf("could not create gitlab client with error: %w", err)
 	}
+	return newClient(ctx, client), nil
+}
 
+func newClient(ctx context.Context, client *gitlab.Client) *Client {
 	return &Client{
 		ctx:      ctx,
 		glClient: client,
@@ -269,7 +372,7 @@ func CreateGitlabClientWithToken(ctx context.Context, token string, repo clients
 			glClient: client,
 		},
 		licenses: &licensesHandler{},
-	}, nil
+	}
 }
 
 // TODO(#2266): implement CreateOssFuzzRepoClient.
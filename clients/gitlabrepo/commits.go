@@ -97,6 +97,8 @@ func (handler *commitsHandler) setup() error {
 					CommittedDate: *commit.CommittedDate,
 					Message:       commit.Message,
 					SHA:           commit.ID,
+					CoAuthors:     clients.CoAuthorsFrom(commit.Message),
+					CIStatus:      ciStatusFrom(commit),
 				})
 				continue
 			}
@@ -106,6 +108,8 @@ func (handler *commitsHandler) setup() error {
 					CommittedDate: *commit.CommittedDate,
 					Message:       commit.Message,
 					SHA:           commit.ID,
+					CoAuthors:     clients.CoAuthorsFrom(commit.Message),
+					CIStatus:      ciStatusFrom(commit),
 				})
 			}
 
@@ -132,6 +136,8 @@ func (handler *commitsHandler) setup() error {
 					CommittedDate: *commit.CommittedDate,
 					Message:       commit.Message,
 					SHA:           commit.ID,
+					CoAuthors:     clients.CoAuthorsFrom(commit.Message),
+					CIStatus:      ciStatusFrom(commit),
 					AssociatedMergeRequest: clients.PullRequest{
 						Number:   mergeRequest.ID,
 						MergedAt: *mergeRequest.MergedAt,
@@ -164,3 +170,24 @@ func parseEmailToName(email string) string {
 	lastName := strings.Split(s[1], "@")[0]
 	return firstName + " " + lastName
 }
+
+// ciStatusFrom returns the status of the latest pipeline of a commit.
+func ciStatusFrom(commit *gitlab.Commit) clients.CIStatus {
+	status := ""
+	switch {
+	case commit.LastPipeline != nil:
+		status = commit.LastPipeline.Status
+	case commit.Status != nil:
+		status = string(*commit.Status)
+	}
+	switch gitlab.BuildStateValue(status) {
+	case gitlab.Success:
+		return clients.CIStatusSuccess
+	case gitlab.Failed:
+		return clients.CIStatusFailure
+	case gitlab.Created, gitlab.Pending, gitlab.Running:
+		return clients.CIStatusPending
+	default:
+		return clients.CIStatusUnknown
+	}
+}
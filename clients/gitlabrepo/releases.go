@@ -70,8 +70,14 @@ func releasesFrom(data []*gitlab.Release) []clients.Release {
 	for _, r := range data {
 		release := clients.Release{
 			TagName:         r.TagName,
-			URL:             r.Assets.Links[0].DirectAssetURL,
 			TargetCommitish: r.CommitPath,
+			Author: clients.User{
+				Login: r.Author.Username,
+				ID:    int64(r.Author.ID),
+			},
+		}
+		if len(r.Assets.Links) > 0 {
+			release.URL = r.Assets.Links[0].DirectAssetURL
 		}
 		for _, a := range r.Assets.Sources {
 			release.Assets = append(release.Assets, clients.ReleaseAsset{
@@ -79,6 +85,14 @@ func releasesFrom(data []*gitlab.Release) []clients.Release {
 				URL:  a.URL,
 			})
 		}
+		// Release links carry the uploaded artifacts, signatures and provenance.
+		for _, l := range r.Assets.Links {
+			release.Assets = append(release.Assets, clients.ReleaseAsset{
+				Name:        l.Name,
+				URL:         l.URL,
+				DownloadURL: l.DirectAssetURL,
+			})
+		}
 		releases = append(releases, release)
 	}
 	return releases
@@ -0,0 +1,60 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gitlabrepo
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+func TestReleasesFrom(t *testing.T) {
+	t.Parallel()
+	release := &gitlab.Release{TagName: "v1.0.0", CommitPath: "/owner/repo/-/commit/abc"}
+	release.Author.Username = "maintainer"
+	release.Author.ID = 7
+	release.Assets.Links = append(release.Assets.Links, &gitlab.ReleaseLink{
+		Name:           "bin.intoto.jsonl",
+		URL:            "https://gitlab.com/owner/repo/-/jobs/1/artifacts/bin.intoto.jsonl",
+		DirectAssetURL: "https://gitlab.com/owner/repo/-/releases/v1.0.0/downloads/bin.intoto.jsonl",
+	})
+	// Releases without links must not make the conversion fail.
+	bare := &gitlab.Release{TagName: "v0.1.0"}
+
+	got := releasesFrom([]*gitlab.Release{release, bare})
+	want := []clients.Release{
+		{
+			TagName:         "v1.0.0",
+			URL:             "https://gitlab.com/owner/repo/-/releases/v1.0.0/downloads/bin.intoto.jsonl",
+			TargetCommitish: "/owner/repo/-/commit/abc",
+			Author:          clients.User{Login: "maintainer", ID: 7},
+			Assets: []clients.ReleaseAsset{
+				{
+					Name:        "bin.intoto.jsonl",
+					URL:         "https://gitlab.com/owner/repo/-/jobs/1/artifacts/bin.intoto.jsonl",
+					DownloadURL: "https://gitlab.com/owner/repo/-/releases/v1.0.0/downloads/bin.intoto.jsonl",
+				},
+			},
+		},
+		{
+			TagName: "v0.1.0",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("releasesFrom() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -18,12 +18,14 @@ package gitlabrepo
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/xanzy/go-gitlab"
 
 	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/gitlabrepo/roundtripper"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
 
@@ -109,7 +111,14 @@ func (r *repoURL) IsValid() error {
 		return nil
 	}
 
-	client, err := gitlab.NewClient("", gitlab.WithBaseURL(fmt.Sprintf("%s://%s", r.scheme, r.host)))
+	// Self-hosted instances may not list any project without a token. Only
+	// the tokens configured for the host itself are sent, as it may not be
+	// GitLab at all.
+	httpClient := &http.Client{
+		Transport: roundtripper.NewTransport(r.host),
+	}
+	client, err := gitlab.NewClient("", gitlab.WithBaseURL(fmt.Sprintf("%s://%s", r.scheme, r.host)),
+		gitlab.WithHTTPClient(httpClient))
 	if err != nil {
 		return sce.WithMessage(err,
 			fmt.Sprintf("couldn't create gitlab client for %s", r.host),
@@ -0,0 +1,99 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package roundtripper has implementations of http.RoundTripper useful to the GitLab clients.RepoClient.
+package roundtripper
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// gitlabAuthTokens are the env vars read, in order, for the tokens used with
+// gitlab.com. Tokens for any other instance are only read from the first of
+// these suffixed with its host, e.g. GITLAB_AUTH_TOKEN_GITLAB_EXAMPLE_COM, so
+// that they are not sent to hosts which are merely probed for being GitLab.
+var gitlabAuthTokens = []string{"GITLAB_AUTH_TOKEN", "GITLAB_TOKEN"}
+
+// gitlabHost is the host the tokens of gitlabAuthTokens are used with.
+const gitlabHost = "gitlab.com"
+
+// tokenEnvVar returns the env var of the tokens for the GitLab instance at host.
+func tokenEnvVar(host string) string {
+	suffix := strings.ToUpper(strings.NewReplacer(".", "_", "-", "_", ":", "_").Replace(host))
+	return gitlabAuthTokens[0] + "_" + suffix
+}
+
+// readGitLabTokens returns the comma-separated personal, project or group
+// access tokens to use with the GitLab instance at host, if any.
+func readGitLabTokens(host string) []string {
+	names := []string{tokenEnvVar(host)}
+	if strings.EqualFold(host, gitlabHost) {
+		names = append(names, gitlabAuthTokens...)
+	}
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return strings.Split(value, ",")
+		}
+	}
+	return nil
+}
+
+// CredentialType returns the type of the credentials NewTransport
+// authenticates requests to host with, without revealing them.
+func CredentialType(host string) string {
+	if len(readGitLabTokens(host)) > 0 {
+		return "access-token"
+	}
+	return "none"
+}
+
+// NewTransport returns a http.RoundTripper authenticating requests to the
+// GitLab instance at host with the tokens configured for it. Requests are
+// not authenticated if no token is configured, which only gives access to
+// public projects. Rate limits are handled by the GitLab client itself.
+func NewTransport(host string) http.RoundTripper {
+	transport := http.DefaultTransport
+	if tokens := readGitLabTokens(host); len(tokens) > 0 {
+		transport = makeGitLabTransport(transport, tokens)
+	}
+	return transport
+}
+
+// makeGitLabTransport wraps input RoundTripper with GitLab authorization logic.
+func makeGitLabTransport(innerTransport http.RoundTripper, tokens []string) http.RoundTripper {
+	return &gitlabTransport{
+		innerTransport: innerTransport,
+		tokens:         tokens,
+	}
+}
+
+// gitlabTransport handles authorization using GitLab access tokens during
+// HTTP requests, spreading requests across the tokens in a round robin.
+type gitlabTransport struct {
+	innerTransport http.RoundTripper
+	tokens         []string
+	counter        uint64
+}
+
+func (gt *gitlabTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	index := atomic.AddUint64(&gt.counter, 1) % uint64(len(gt.tokens))
+	// The request must not be modified, see http.RoundTripper.
+	r = r.Clone(r.Context())
+	r.Header.Set("PRIVATE-TOKEN", gt.tokens[index])
+	//nolint:wrapcheck
+	return gt.innerTransport.RoundTrip(r)
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+//nolint:paralleltest // Since t.Setenv is used.
+func TestReadGitLabTokens(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		name string
+		host string
+		want []string
+	}{
+		{
+			name: "no tokens",
+			host: "gitlab.com",
+		},
+		{
+			name: "tokens for any instance",
+			host: "gitlab.com",
+			env: map[string]string{
+				"GITLAB_AUTH_TOKEN": "token1,token2",
+			},
+			want: []string{"token1", "token2"},
+		},
+		{
+			name: "fallback env var",
+			host: "gitlab.com",
+			env: map[string]string{
+				"GITLAB_TOKEN": "token",
+			},
+			want: []string{"token"},
+		},
+		{
+			name: "tokens for a self-hosted instance",
+			host: "gitlab.example.com:8443",
+			env: map[string]string{
+				"GITLAB_AUTH_TOKEN":                         "token",
+				"GITLAB_AUTH_TOKEN_GITLAB_EXAMPLE_COM_8443": "self-hosted",
+			},
+			want: []string{"self-hosted"},
+		},
+		{
+			name: "tokens for any instance are only used with gitlab.com",
+			host: "git.example.com",
+			env: map[string]string{
+				"GITLAB_AUTH_TOKEN": "token",
+				"GITLAB_TOKEN":      "token",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range append([]string{tokenEnvVar(tt.host)}, gitlabAuthTokens...) {
+				t.Setenv(name, tt.env[name])
+			}
+			if diff := cmp.Diff(tt.want, readGitLabTokens(tt.host)); diff != "" {
+				t.Errorf("readGitLabTokens() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGitLabTransport(t *testing.T) {
+	t.Parallel()
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("PRIVATE-TOKEN"))
+	}))
+	defer server.Close()
+
+	client := http.Client{
+		Transport: makeGitLabTransport(http.DefaultTransport, []string{"token1", "token2"}),
+	}
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatalf("http.NewRequest: %v", err)
+		}
+		// Set by the GitLab client when created without a token.
+		req.Header.Set("PRIVATE-TOKEN", "")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("client.Do: %v", err)
+		}
+		resp.Body.Close()
+		if req.Header.Get("PRIVATE-TOKEN") != "" {
+			t.Errorf("request was modified")
+		}
+	}
+	if diff := cmp.Diff([]string{"token2", "token1", "token2"}, got); diff != "" {
+		t.Errorf("tokens mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -50,10 +50,15 @@ func workflowsRunsFrom(data []*gitlab.Job, filename string) []clients.WorkflowRu
 		// Find a better way to do this.
 		for _, artifact := range job.Artifacts {
 			if strings.EqualFold(artifact.Filename, filename) {
-				workflowRuns = append(workflowRuns, clients.WorkflowRun{
+				run := clients.WorkflowRun{
 					HeadSHA: &job.Pipeline.Sha,
 					URL:     job.WebURL,
-				})
+					Ref:     job.Ref,
+				}
+				if job.CreatedAt != nil {
+					run.CreatedAt = *job.CreatedAt
+				}
+				workflowRuns = append(workflowRuns, run)
 				continue
 			}
 		}
@@ -0,0 +1,78 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// maxLFSPointerSize is the largest size of a Git LFS pointer file allowed by
+// https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md.
+const maxLFSPointerSize = 1024
+
+// lfsVersions are the versions of the pointer format, the second one used
+// by early Git LFS releases.
+var lfsVersions = []string{
+	"version https://git-lfs.github.com/spec/v1",
+	"version https://hawser.github.com/spec/v1",
+}
+
+// LFSPointer is a Git LFS pointer file, which is committed in place of a file
+// stored in Git LFS.
+type LFSPointer struct {
+	// OID is the hex-encoded SHA-256 digest of the content of the file.
+	OID  string
+	Size int64
+}
+
+// ParseLFSPointer returns the pointer in content if content is a Git LFS
+// pointer file.
+func ParseLFSPointer(content []byte) (LFSPointer, bool) {
+	if len(content) > maxLFSPointerSize || !hasLFSVersion(content) {
+		return LFSPointer{}, false
+	}
+	var pointer LFSPointer
+	size := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "oid":
+			pointer.OID = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			size = value
+		}
+	}
+	n, err := strconv.ParseInt(size, 10, 64)
+	if err != nil || n < 0 {
+		return LFSPointer{}, false
+	}
+	if oid, err := hex.DecodeString(pointer.OID); err != nil || len(oid) != 32 {
+		return LFSPointer{}, false
+	}
+	pointer.Size = n
+	return pointer, true
+}
+
+func hasLFSVersion(content []byte) bool {
+	for _, v := range lfsVersions {
+		if bytes.HasPrefix(content, []byte(v+"\n")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import "testing"
+
+const testLFSOID = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+
+func TestParseLFSPointer(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		want    LFSPointer
+		ok      bool
+	}{
+		{
+			name:    "pointer",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + testLFSOID + "\nsize 12345\n",
+			want:    LFSPointer{OID: testLFSOID, Size: 12345},
+			ok:      true,
+		},
+		{
+			name:    "legacy version",
+			content: "version https://hawser.github.com/spec/v1\noid sha256:" + testLFSOID + "\nsize 0\n",
+			want:    LFSPointer{OID: testLFSOID},
+			ok:      true,
+		},
+		{
+			name:    "missing size",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + testLFSOID + "\n",
+		},
+		{
+			name:    "invalid oid",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:1234\nsize 1\n",
+		},
+		{
+			name:    "not a pointer",
+			content: "package main\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := ParseLFSPointer([]byte(tt.content))
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("ParseLFSPointer() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
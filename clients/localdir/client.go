@@ -18,11 +18,12 @@ package localdir
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
-	"path"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -32,9 +33,15 @@ import (
 	"github.com/ossf/scorecard/v4/log"
 )
 
+// maxLFSObjectSize bounds the size of the Git LFS objects read in place of
+// their pointer files.
+const maxLFSObjectSize = 64 << 20
+
 var (
-	_                clients.RepoClient = &localDirClient{}
-	errInputRepoType                    = errors.New("input repo should be of type repoLocal")
+	_                  clients.RepoClient = &localDirClient{}
+	errInputRepoType                      = errors.New("input repo should be of type repoLocal")
+	errPathOutsideRepo                    = errors.New("path is outside of the repo")
+	errNotRegularFile                     = errors.New("not a regular file")
 )
 
 //nolint:govet
@@ -55,18 +62,18 @@ func (client *localDirClient) InitRepo(inputRepo clients.Repo, commitSHA string,
 		return fmt.Errorf("%w: %v", errInputRepoType, inputRepo)
 	}
 	if commitDepth <= 0 {
-		client.commitDepth = 30 // default
+		client.commitDepth = clients.DefaultCommitDepth
 	} else {
 		client.commitDepth = commitDepth
 	}
-	client.path = strings.TrimPrefix(localRepo.URI(), "file://")
+	client.path = localRepo.path
 
 	return nil
 }
 
 // URI implements RepoClient.URI.
 func (client *localDirClient) URI() string {
-	return fmt.Sprintf("file://%s", client.path)
+	return fmt.Sprintf("file://%s", filepath.ToSlash(client.path))
 }
 
 // IsArchived implements RepoClient.IsArchived.
@@ -74,39 +81,91 @@ func (client *localDirClient) IsArchived() (bool, error) {
 	return false, fmt.Errorf("IsArchived: %w", clients.ErrUnsupportedFeature)
 }
 
-func isDir(p string) (bool, error) {
-	fileInfo, err := os.Stat(p)
+// GetSecurityPolicyURL implements RepoClient.GetSecurityPolicyURL.
+func (client *localDirClient) GetSecurityPolicyURL() (string, error) {
+	return "", fmt.Errorf("GetSecurityPolicyURL: %w", clients.ErrUnsupportedFeature)
+}
+
+// IsPrivate implements RepoClient.IsPrivate.
+func (client *localDirClient) IsPrivate() (bool, error) {
+	return false, fmt.Errorf("IsPrivate: %w", clients.ErrUnsupportedFeature)
+}
+
+// GetForkParent implements RepoClient.GetForkParent.
+func (client *localDirClient) GetForkParent() (clients.Repo, error) {
+	return nil, fmt.Errorf("GetForkParent: %w", clients.ErrUnsupportedFeature)
+}
+
+// relativePath returns the slash-separated path of pathfn relative to the
+// root, which is how checks expect file names on every platform.
+// filepath.Rel compares volume names case-insensitively on Windows.
+func relativePath(root, pathfn string) (string, error) {
+	p, err := filepath.Rel(root, pathfn)
 	if err != nil {
-		return false, fmt.Errorf("%w", err)
+		return "", fmt.Errorf("error during filepath.Rel: %w", err)
 	}
+	return filepath.ToSlash(p), nil
+}
 
-	return fileInfo.IsDir(), nil
+// resolveInRepo resolves the links in pathfn and returns the resulting path
+// if it is within root, which must not contain links itself. Untrusted
+// repositories can contain links to files elsewhere on the host.
+func resolveInRepo(root, pathfn string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(pathfn)
+	if err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || !isLocal(rel) {
+		return "", fmt.Errorf("%w: %q", errPathOutsideRepo, pathfn)
+	}
+	return resolved, nil
 }
 
-func trimPrefix(pathfn, clientPath string) string {
-	cleanPath := path.Clean(pathfn)
-	prefix := fmt.Sprintf("%s%s", clientPath, string(os.PathSeparator))
-	return strings.TrimPrefix(cleanPath, prefix)
+// isLinkedFile returns true if the symbolic link or Windows junction at
+// pathfn points to a regular file within root. Links to directories are not
+// followed, so that checks cannot loop, and broken links are skipped.
+func isLinkedFile(root, pathfn string) bool {
+	resolved, err := resolveInRepo(root, pathfn)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(resolved)
+	return err == nil && info.Mode().IsRegular()
 }
 
+// specialFileModes are the file types which are never listed or read, as
+// reading devices or named pipes can block or never end.
+const specialFileModes = fs.ModeDevice | fs.ModeCharDevice | fs.ModeNamedPipe | fs.ModeSocket
+
 func listFiles(clientPath string) ([]string, error) {
+	// Resolve the root so that repositories behind a link are walked too.
+	root, err := filepath.EvalSymlinks(clientPath)
+	if err != nil {
+		return nil, fmt.Errorf("error walking the path %q: %w", clientPath, err)
+	}
 	files := []string{}
-	err := filepath.Walk(clientPath, func(pathfn string, info fs.FileInfo, err error) error {
+	err = filepath.WalkDir(root, func(pathfn string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return fmt.Errorf("failure accessing path %q: %w", pathfn, err)
 		}
 
 		// Skip directories.
-		d, err := isDir(pathfn)
-		if err != nil {
-			return err
+		if d.IsDir() {
+			return nil
+		}
+		if d.Type()&specialFileModes != 0 {
+			return nil
 		}
-		if d {
+		if d.Type()&(fs.ModeSymlink|fs.ModeIrregular) != 0 && !isLinkedFile(root, pathfn) {
 			return nil
 		}
 
 		// Remove prefix of the folder.
-		p := trimPrefix(pathfn, clientPath)
+		p, err := relativePath(root, pathfn)
+		if err != nil {
+			return err
+		}
 		files = append(files, p)
 
 		return nil
@@ -161,14 +220,78 @@ func (client *localDirClient) ListFiles(predicate func(string) (bool, error)) ([
 
 func getFileContent(clientpath, filename string) ([]byte, error) {
 	// Note: the filenames do not contain the original path - see ListFiles().
-	fn := path.Join(clientpath, filename)
+	name := filepath.FromSlash(filename)
+	if !isLocal(name) {
+		return nil, fmt.Errorf("%w: %q", errPathOutsideRepo, filename)
+	}
+	root, err := filepath.EvalSymlinks(clientpath)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	fn, err := resolveInRepo(root, filepath.Join(root, name))
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(fn)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%w: %q", errNotRegularFile, filename)
+	}
 	content, err := os.ReadFile(fn)
 	if err != nil {
 		return content, fmt.Errorf("%w", err)
 	}
+	if pointer, ok := clients.ParseLFSPointer(content); ok {
+		if object, ok := readLFSObject(root, pointer); ok {
+			return object, nil
+		}
+	}
 	return content, nil
 }
 
+// readLFSObject returns the content of the file stored in Git LFS for
+// pointer, if it was fetched into the Git LFS storage of the checkout at root
+// and is at most maxLFSObjectSize large. Otherwise the pointer file is
+// returned, so that checks can still tell Git LFS files apart.
+func readLFSObject(root string, pointer clients.LFSPointer) ([]byte, bool) {
+	if pointer.Size > maxLFSObjectSize {
+		return nil, false
+	}
+	oid := pointer.OID
+	fn, err := resolveInRepo(root, filepath.Join(root, ".git", "lfs", "objects", oid[0:2], oid[2:4], oid))
+	if err != nil {
+		return nil, false
+	}
+	info, err := os.Stat(fn)
+	if err != nil || !info.Mode().IsRegular() || info.Size() != pointer.Size {
+		return nil, false
+	}
+	content, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, false
+	}
+	digest := sha256.Sum256(content)
+	if hex.EncodeToString(digest[:]) != oid {
+		return nil, false
+	}
+	return content, true
+}
+
+// isLocal returns true if name is a relative path which stays within the
+// directory it is joined to.
+func isLocal(name string) bool {
+	// On Windows, `\dir` is relative to the current volume but not to the
+	// directory.
+	if name == "" || filepath.IsAbs(name) || filepath.VolumeName(name) != "" ||
+		strings.HasPrefix(name, string(filepath.Separator)) {
+		return false
+	}
+	clean := filepath.Clean(name)
+	return clean != ".." && !strings.HasPrefix(clean, ".."+string(filepath.Separator))
+}
+
 // GetFileContent implements RepoClient.GetFileContent.
 func (client *localDirClient) GetFileContent(filename string) ([]byte, error) {
 	return getFileContent(client.path, filename)
@@ -179,6 +302,16 @@ func (client *localDirClient) GetBranch(branch string) (*clients.BranchRef, erro
 	return nil, fmt.Errorf("ListBranches: %w", clients.ErrUnsupportedFeature)
 }
 
+// GetBranches implements RepoClient.GetBranches.
+func (client *localDirClient) GetBranches(branches []string) ([]*clients.BranchRef, error) {
+	return nil, fmt.Errorf("GetBranches: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListBranches implements RepoClient.ListBranches.
+func (client *localDirClient) ListBranches() ([]clients.BranchInfo, error) {
+	return nil, fmt.Errorf("ListBranches: %w", clients.ErrUnsupportedFeature)
+}
+
 // GetDefaultBranch implements RepoClient.GetDefaultBranch.
 func (client *localDirClient) GetDefaultBranch() (*clients.BranchRef, error) {
 	return nil, fmt.Errorf("GetDefaultBranch: %w", clients.ErrUnsupportedFeature)
@@ -204,6 +337,11 @@ func (client *localDirClient) ListReleases() ([]clients.Release, error) {
 	return nil, fmt.Errorf("ListReleases: %w", clients.ErrUnsupportedFeature)
 }
 
+// GetReleaseAsset implements RepoClient.GetReleaseAsset.
+func (client *localDirClient) GetReleaseAsset(asset *clients.ReleaseAsset) ([]byte, error) {
+	return nil, fmt.Errorf("GetReleaseAsset: %w", clients.ErrUnsupportedFeature)
+}
+
 // ListContributors implements RepoClient.ListContributors.
 func (client *localDirClient) ListContributors() ([]clients.User, error) {
 	return nil, fmt.Errorf("ListContributors: %w", clients.ErrUnsupportedFeature)
@@ -229,6 +367,36 @@ func (client *localDirClient) ListWebhooks() ([]clients.Webhook, error) {
 	return nil, fmt.Errorf("ListWebhooks: %w", clients.ErrUnsupportedFeature)
 }
 
+// GetActionsPolicy implements RepoClient.GetActionsPolicy.
+func (client *localDirClient) GetActionsPolicy() (*clients.ActionsPolicy, error) {
+	return nil, fmt.Errorf("GetActionsPolicy: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListDependencyAlerts implements RepoClient.ListDependencyAlerts.
+func (client *localDirClient) ListDependencyAlerts() ([]clients.SecurityAlert, error) {
+	return nil, fmt.Errorf("ListDependencyAlerts: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListCodeScanningAlerts implements RepoClient.ListCodeScanningAlerts.
+func (client *localDirClient) ListCodeScanningAlerts() ([]clients.SecurityAlert, error) {
+	return nil, fmt.Errorf("ListCodeScanningAlerts: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListEnvironments implements RepoClient.ListEnvironments.
+func (client *localDirClient) ListEnvironments() ([]clients.Environment, error) {
+	return nil, fmt.Errorf("ListEnvironments: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListAuditLogEvents implements RepoClient.ListAuditLogEvents.
+func (client *localDirClient) ListAuditLogEvents() ([]clients.AuditLogEvent, error) {
+	return nil, fmt.Errorf("ListAuditLogEvents: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListForcePushes implements RepoClient.ListForcePushes.
+func (client *localDirClient) ListForcePushes() ([]clients.ForcePush, error) {
+	return nil, fmt.Errorf("ListForcePushes: %w", clients.ErrUnsupportedFeature)
+}
+
 // Search implements RepoClient.Search.
 func (client *localDirClient) Search(request clients.SearchRequest) (clients.SearchResponse, error) {
 	return clients.SearchResponse{}, fmt.Errorf("Search: %w", clients.ErrUnsupportedFeature)
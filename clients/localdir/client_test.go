@@ -16,8 +16,12 @@ package localdir
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -200,3 +204,97 @@ func TestClient_GetFileListAndContent(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Links(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	root := filepath.Join(dir, "repo")
+	if err := os.MkdirAll(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", "file"), []byte("content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "secret"), []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	links := map[string]string{
+		filepath.Join(root, "escape"):       filepath.Join(dir, "secret"),
+		filepath.Join(root, "linked-file"):  filepath.Join(root, "sub", "file"),
+		filepath.Join(root, "linked-dir"):   filepath.Join(root, "sub"),
+		filepath.Join(root, "broken-link"):  filepath.Join(root, "does-not-exist"),
+		filepath.Join(dir, "linked-repo"):   root,
+		filepath.Join(root, "sub", "cycle"): root,
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, link); err != nil {
+			// Creating links requires privileges on Windows.
+			t.Skipf("os.Symlink: %v", err)
+		}
+	}
+
+	want := []string{"sub/file", "linked-file"}
+	for _, p := range []string{root, filepath.Join(dir, "linked-repo")} {
+		files, err := listFiles(p)
+		if err != nil {
+			t.Fatalf("listFiles(%q): %v", p, err)
+		}
+		if !cmp.Equal(want, files, cmpopts.SortSlices(isSortedString)) {
+			t.Errorf("listFiles(%q): %s", p, cmp.Diff(want, files))
+		}
+	}
+
+	if content, err := getFileContent(root, "linked-file"); err != nil || string(content) != "content" {
+		t.Errorf("getFileContent(linked-file): %q, %v", content, err)
+	}
+	if _, err := getFileContent(root, "escape"); !errors.Is(err, errPathOutsideRepo) {
+		t.Errorf("getFileContent(escape): %v, expected %v", err, errPathOutsideRepo)
+	}
+	if _, err := getFileContent(root, "sub"); !errors.Is(err, errNotRegularFile) {
+		t.Errorf("getFileContent(sub): %v, expected %v", err, errNotRegularFile)
+	}
+}
+
+func TestClient_GetFileContentOutsideRepo(t *testing.T) {
+	t.Parallel()
+	for _, filename := range []string{"../repo0/file0", "dir1/../../repo0/file0", "/etc/passwd", "", ".."} {
+		if _, err := getFileContent("testdata/repo0", filename); !errors.Is(err, errPathOutsideRepo) {
+			t.Errorf("getFileContent(%q): %v, expected %v", filename, err, errPathOutsideRepo)
+		}
+	}
+	content, err := getFileContent("testdata/repo0", "dir1/../file0")
+	if err != nil || string(content) != "content0\n" {
+		t.Errorf("getFileContent: %q, %v", content, err)
+	}
+}
+
+func TestClient_GetFileContentLFS(t *testing.T) {
+	t.Parallel()
+	object := []byte("stored in Git LFS")
+	digest := sha256.Sum256(object)
+	oid := hex.EncodeToString(digest[:])
+	pointer := fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", oid, len(object))
+	missing := strings.Replace(pointer, oid[:8], "00000000", 1)
+
+	root := t.TempDir()
+	objects := filepath.Join(root, ".git", "lfs", "objects", oid[0:2], oid[2:4])
+	if err := os.MkdirAll(objects, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for fn, content := range map[string]string{
+		filepath.Join(objects, oid):    string(object),
+		filepath.Join(root, "stored"):  pointer,
+		filepath.Join(root, "missing"): missing,
+	} {
+		if err := os.WriteFile(fn, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for filename, want := range map[string]string{"stored": string(object), "missing": missing} {
+		content, err := getFileContent(root, filename)
+		if err != nil || string(content) != want {
+			t.Errorf("getFileContent(%q) = %q, %v, want %q", filename, content, err, want)
+		}
+	}
+}
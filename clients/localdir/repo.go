@@ -20,7 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path"
+	"path/filepath"
 
 	clients "github.com/ossf/scorecard/v4/clients"
 )
@@ -34,7 +34,7 @@ type repoLocal struct {
 
 // URI implements Repo.URI().
 func (r *repoLocal) URI() string {
-	return fmt.Sprintf("file://%s", r.path)
+	return fmt.Sprintf("file://%s", filepath.ToSlash(r.path))
 }
 
 func (r *repoLocal) Host() string {
@@ -76,7 +76,7 @@ func (r *repoLocal) AppendMetadata(m ...string) {
 
 // MakeLocalDirRepo returns an implementation of clients.Repo interface.
 func MakeLocalDirRepo(pathfn string) (clients.Repo, error) {
-	p := path.Clean(pathfn)
+	p := filepath.Clean(pathfn)
 	repo := &repoLocal{
 		path: p,
 	}
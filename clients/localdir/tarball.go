@@ -0,0 +1,160 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdir
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// tarballLimits bound what is extracted from a tarball, which protects
+// against decompression bombs filling the disk.
+type tarballLimits struct {
+	// fileSize bounds the size of each file.
+	fileSize int64
+	// totalSize bounds the total size of the files.
+	totalSize int64
+	// entries bounds the number of entries, of any type.
+	entries int
+}
+
+var defaultTarballLimits = tarballLimits{
+	fileSize:  1 << 30, // 1 GiB
+	totalSize: 4 << 30, // 4 GiB
+	entries:   1 << 20,
+}
+
+var (
+	errTarballPath      = errors.New("tarball entry escapes the destination")
+	errTarballFileSize  = errors.New("tarball entry is too large")
+	errTarballTotalSize = errors.New("tarball content is too large")
+	errTarballEntries   = errors.New("tarball has too many entries")
+)
+
+// IsTarball returns true if path names a tarball which can be extracted by
+// ExtractTarball, based on its extension.
+func IsTarball(path string) bool {
+	for _, ext := range []string{".tar", ".tar.gz", ".tgz"} {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtractTarball extracts the regular files and directories of the tarball
+// at path into dest, and returns the root of the extracted source tree: the
+// top-level directory of the tarball if it has a single one, as generated by
+// `git archive --prefix` or the archives of forges, or dest otherwise.
+// Links and other special files are skipped.
+func ExtractTarball(path, dest string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if !strings.HasSuffix(path, ".tar") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return "", fmt.Errorf("gzip.NewReader: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	if err := extractTar(tar.NewReader(r), dest, defaultTarballLimits); err != nil {
+		return "", err
+	}
+	return sourceRoot(dest)
+}
+
+func extractTar(tr *tar.Reader, dest string, limits tarballLimits) error {
+	dest = filepath.Clean(dest)
+	var entries int
+	var totalSize int64
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("tar.Next: %w", err)
+		}
+		entries++
+		if entries > limits.entries {
+			return fmt.Errorf("%w: more than %d", errTarballEntries, limits.entries)
+		}
+		// Reject absolute names and names with `..` up front: even if joining
+		// them to dest stays within it, they are never produced by honest
+		// archivers.
+		name := filepath.FromSlash(hdr.Name)
+		target := filepath.Join(dest, name)
+		if !isLocal(name) || target != dest && !strings.HasPrefix(target, dest+string(filepath.Separator)) {
+			return fmt.Errorf("%w: %s", errTarballPath, hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("os.MkdirAll: %w", err)
+			}
+		case tar.TypeReg:
+			if hdr.Size > limits.fileSize {
+				return fmt.Errorf("%w: %s", errTarballFileSize, hdr.Name)
+			}
+			totalSize += hdr.Size
+			if totalSize > limits.totalSize {
+				return fmt.Errorf("%w: more than %d bytes", errTarballTotalSize, limits.totalSize)
+			}
+			if err := writeTarFile(tr, target, hdr.Size); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeTarFile writes the size bytes of the entry read from r to target. The
+// tar reader never returns more than the size of the entry.
+func writeTarFile(r io.Reader, target string, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("os.MkdirAll: %w", err)
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+	defer f.Close()
+	if _, err := io.CopyN(f, r, size); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("io.CopyN: %w", err)
+	}
+	return nil
+}
+
+func sourceRoot(dest string) (string, error) {
+	entries, err := os.ReadDir(dest)
+	if err != nil {
+		return "", fmt.Errorf("os.ReadDir: %w", err)
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(dest, entries[0].Name()), nil
+	}
+	return dest, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdir
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type tarEntry struct {
+	name     string
+	content  string
+	typeflag byte
+}
+
+func writeTarball(t *testing.T, path string, entries []tarEntry) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Typeflag: e.typeflag, Mode: 0o644, Size: int64(len(e.content))}
+		if e.typeflag == tar.TypeSymlink || e.typeflag == tar.TypeLink {
+			hdr.Linkname, hdr.Size = "/etc/passwd", 0
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if e.typeflag == tar.TypeReg {
+			if _, err := tw.Write([]byte(e.content)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractTarball(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		entries  []tarEntry
+		wantRoot string
+		wantFile string
+		err      error
+	}{
+		{
+			name: "single top-level directory",
+			entries: []tarEntry{
+				{name: "repo-1.0/", typeflag: tar.TypeDir},
+				{name: "repo-1.0/SECURITY.md", content: "report here", typeflag: tar.TypeReg},
+				{name: "repo-1.0/link", typeflag: tar.TypeSymlink},
+				{name: "repo-1.0/hardlink", typeflag: tar.TypeLink},
+				{name: "repo-1.0/device", typeflag: tar.TypeChar},
+				{name: "repo-1.0/pipe", typeflag: tar.TypeFifo},
+			},
+			wantRoot: "repo-1.0",
+			wantFile: "SECURITY.md",
+		},
+		{
+			name: "files at the root",
+			entries: []tarEntry{
+				{name: "LICENSE", content: "MIT", typeflag: tar.TypeReg},
+				{name: "src/main.go", content: "package main", typeflag: tar.TypeReg},
+			},
+			wantFile: "src/main.go",
+		},
+		{
+			name: "path traversal",
+			entries: []tarEntry{
+				{name: "../escape", content: "x", typeflag: tar.TypeReg},
+			},
+			err: errTarballPath,
+		},
+		{
+			name: "path traversal within the destination",
+			entries: []tarEntry{
+				{name: "repo/../../out/escape", content: "x", typeflag: tar.TypeReg},
+			},
+			err: errTarballPath,
+		},
+		{
+			name: "absolute path",
+			entries: []tarEntry{
+				{name: "/etc/cron.d/escape", content: "x", typeflag: tar.TypeReg},
+			},
+			err: errTarballPath,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			tarball := filepath.Join(dir, "repo.tar.gz")
+			writeTarball(t, tarball, tt.entries)
+			dest := filepath.Join(dir, "out")
+			if err := os.Mkdir(dest, 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			root, err := ExtractTarball(tarball, dest)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ExtractTarball() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if want := filepath.Join(dest, tt.wantRoot); root != want {
+				t.Errorf("ExtractTarball() root = %s, want %s", root, want)
+			}
+			if _, err := os.Stat(filepath.Join(root, tt.wantFile)); err != nil {
+				t.Errorf("extracted file: %v", err)
+			}
+			for _, special := range []string{"link", "hardlink", "device", "pipe"} {
+				if _, err := os.Lstat(filepath.Join(root, special)); !os.IsNotExist(err) {
+					t.Errorf("%s should be skipped, got %v", special, err)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractTarLimits(t *testing.T) {
+	t.Parallel()
+	entries := []tarEntry{
+		{name: "repo/", typeflag: tar.TypeDir},
+		{name: "repo/a", content: "1234", typeflag: tar.TypeReg},
+		{name: "repo/b", content: "5678", typeflag: tar.TypeReg},
+		{name: "repo/link", typeflag: tar.TypeSymlink},
+	}
+	tests := []struct {
+		err    error
+		name   string
+		limits tarballLimits
+	}{
+		{
+			name:   "within the limits",
+			limits: tarballLimits{fileSize: 4, totalSize: 8, entries: 4},
+		},
+		{
+			name:   "file too large",
+			limits: tarballLimits{fileSize: 3, totalSize: 8, entries: 4},
+			err:    errTarballFileSize,
+		},
+		{
+			name:   "total size too large",
+			limits: tarballLimits{fileSize: 4, totalSize: 7, entries: 4},
+			err:    errTarballTotalSize,
+		},
+		{
+			name:   "too many entries",
+			limits: tarballLimits{fileSize: 4, totalSize: 8, entries: 3},
+			err:    errTarballEntries,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			tarball := filepath.Join(dir, "repo.tar.gz")
+			writeTarball(t, tarball, entries)
+			f, err := os.Open(tarball)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			gz, err := gzip.NewReader(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := extractTar(tar.NewReader(gz), dir, tt.limits); !errors.Is(err, tt.err) {
+				t.Errorf("extractTar() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestIsTarball(t *testing.T) {
+	t.Parallel()
+	for path, want := range map[string]bool{
+		"repo.tar":    true,
+		"repo.tar.gz": true,
+		"repo.tgz":    true,
+		"repo":        false,
+		"repo.zip":    false,
+	} {
+		if got := IsTarball(path); got != want {
+			t.Errorf("IsTarball(%s) = %v, want %v", path, got, want)
+		}
+	}
+}
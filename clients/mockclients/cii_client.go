@@ -14,7 +14,7 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: clients/cii_client.go
+// Source: clients/api/cii.go
 
 // Package mockrepo is a generated GoMock package.
 package mockrepo
@@ -24,7 +24,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-	clients "github.com/ossf/scorecard/v4/clients"
+	api "github.com/ossf/scorecard/clients/api"
 )
 
 // MockCIIBestPracticesClient is a mock of CIIBestPracticesClient interface.
@@ -51,10 +51,10 @@ func (m *MockCIIBestPracticesClient) EXPECT() *MockCIIBestPracticesClientMockRec
 }
 
 // GetBadgeLevel mocks base method.
-func (m *MockCIIBestPracticesClient) GetBadgeLevel(ctx context.Context, uri string) (clients.BadgeLevel, error) {
+func (m *MockCIIBestPracticesClient) GetBadgeLevel(ctx context.Context, uri string) (api.BadgeLevel, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBadgeLevel", ctx, uri)
-	ret0, _ := ret[0].(clients.BadgeLevel)
+	ret0, _ := ret[0].(api.BadgeLevel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -14,7 +14,7 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: clients/repo.go
+// Source: clients/api/repo.go
 
 // Package mockrepo is a generated GoMock package.
 package mockrepo
@@ -23,7 +23,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-	clients "github.com/ossf/scorecard/v4/clients"
+	api "github.com/ossf/scorecard/clients/api"
 )
 
 // MockRepo is a mock of Repo interface.
@@ -65,6 +65,20 @@ func (mr *MockRepoMockRecorder) AppendMetadata(metadata ...interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendMetadata", reflect.TypeOf((*MockRepo)(nil).AppendMetadata), metadata...)
 }
 
+// Host mocks base method.
+func (m *MockRepo) Host() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Host")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Host indicates an expected call of Host.
+func (mr *MockRepoMockRecorder) Host() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Host", reflect.TypeOf((*MockRepo)(nil).Host))
+}
+
 // IsValid mocks base method.
 func (m *MockRepo) IsValid() error {
 	m.ctrl.T.Helper()
@@ -94,10 +108,10 @@ func (mr *MockRepoMockRecorder) Metadata() *gomock.Call {
 }
 
 // Org mocks base method.
-func (m *MockRepo) Org() clients.Repo {
+func (m *MockRepo) Org() api.Repo {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Org")
-	ret0, _ := ret[0].(clients.Repo)
+	ret0, _ := ret[0].(api.Repo)
 	return ret0
 }
 
@@ -129,14 +143,6 @@ func (m *MockRepo) URI() string {
 	return ret0
 }
 
-// URI mocks base method.
-func (m *MockRepo) Host() string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Host")
-	ret0, _ := ret[0].(string)
-	return ret0
-}
-
 // URI indicates an expected call of URI.
 func (mr *MockRepoMockRecorder) URI() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
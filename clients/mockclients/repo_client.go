@@ -14,7 +14,7 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: clients/repo_client.go
+// Source: clients/api/repo_client.go
 
 // Package mockrepo is a generated GoMock package.
 package mockrepo
@@ -24,7 +24,7 @@ import (
 	time "time"
 
 	gomock "github.com/golang/mock/gomock"
-	clients "github.com/ossf/scorecard/v4/clients"
+	api "github.com/ossf/scorecard/clients/api"
 )
 
 // MockRepoClient is a mock of RepoClient interface.
@@ -64,11 +64,26 @@ func (mr *MockRepoClientMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepoClient)(nil).Close))
 }
 
+// GetActionsPolicy mocks base method.
+func (m *MockRepoClient) GetActionsPolicy() (*api.ActionsPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActionsPolicy")
+	ret0, _ := ret[0].(*api.ActionsPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActionsPolicy indicates an expected call of GetActionsPolicy.
+func (mr *MockRepoClientMockRecorder) GetActionsPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionsPolicy", reflect.TypeOf((*MockRepoClient)(nil).GetActionsPolicy))
+}
+
 // GetBranch mocks base method.
-func (m *MockRepoClient) GetBranch(branch string) (*clients.BranchRef, error) {
+func (m *MockRepoClient) GetBranch(branch string) (*api.BranchRef, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetBranch", branch)
-	ret0, _ := ret[0].(*clients.BranchRef)
+	ret0, _ := ret[0].(*api.BranchRef)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -79,6 +94,21 @@ func (mr *MockRepoClientMockRecorder) GetBranch(branch interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranch", reflect.TypeOf((*MockRepoClient)(nil).GetBranch), branch)
 }
 
+// GetBranches mocks base method.
+func (m *MockRepoClient) GetBranches(branches []string) ([]*api.BranchRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranches", branches)
+	ret0, _ := ret[0].([]*api.BranchRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranches indicates an expected call of GetBranches.
+func (mr *MockRepoClientMockRecorder) GetBranches(branches interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranches", reflect.TypeOf((*MockRepoClient)(nil).GetBranches), branches)
+}
+
 // GetCreatedAt mocks base method.
 func (m *MockRepoClient) GetCreatedAt() (time.Time, error) {
 	m.ctrl.T.Helper()
@@ -95,10 +125,10 @@ func (mr *MockRepoClientMockRecorder) GetCreatedAt() *gomock.Call {
 }
 
 // GetDefaultBranch mocks base method.
-func (m *MockRepoClient) GetDefaultBranch() (*clients.BranchRef, error) {
+func (m *MockRepoClient) GetDefaultBranch() (*api.BranchRef, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetDefaultBranch")
-	ret0, _ := ret[0].(*clients.BranchRef)
+	ret0, _ := ret[0].(*api.BranchRef)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -139,8 +169,53 @@ func (mr *MockRepoClientMockRecorder) GetFileContent(filename interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileContent", reflect.TypeOf((*MockRepoClient)(nil).GetFileContent), filename)
 }
 
+// GetForkParent mocks base method.
+func (m *MockRepoClient) GetForkParent() (api.Repo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForkParent")
+	ret0, _ := ret[0].(api.Repo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForkParent indicates an expected call of GetForkParent.
+func (mr *MockRepoClientMockRecorder) GetForkParent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForkParent", reflect.TypeOf((*MockRepoClient)(nil).GetForkParent))
+}
+
+// GetReleaseAsset mocks base method.
+func (m *MockRepoClient) GetReleaseAsset(asset *api.ReleaseAsset) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReleaseAsset", asset)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReleaseAsset indicates an expected call of GetReleaseAsset.
+func (mr *MockRepoClientMockRecorder) GetReleaseAsset(asset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReleaseAsset", reflect.TypeOf((*MockRepoClient)(nil).GetReleaseAsset), asset)
+}
+
+// GetSecurityPolicyURL mocks base method.
+func (m *MockRepoClient) GetSecurityPolicyURL() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityPolicyURL")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurityPolicyURL indicates an expected call of GetSecurityPolicyURL.
+func (mr *MockRepoClientMockRecorder) GetSecurityPolicyURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityPolicyURL", reflect.TypeOf((*MockRepoClient)(nil).GetSecurityPolicyURL))
+}
+
 // InitRepo mocks base method.
-func (m *MockRepoClient) InitRepo(repo clients.Repo, commitSHA string, commitDepth int) error {
+func (m *MockRepoClient) InitRepo(repo api.Repo, commitSHA string, commitDepth int) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InitRepo", repo, commitSHA, commitDepth)
 	ret0, _ := ret[0].(error)
@@ -168,11 +243,56 @@ func (mr *MockRepoClientMockRecorder) IsArchived() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsArchived", reflect.TypeOf((*MockRepoClient)(nil).IsArchived))
 }
 
+// IsPrivate mocks base method.
+func (m *MockRepoClient) IsPrivate() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPrivate")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsPrivate indicates an expected call of IsPrivate.
+func (mr *MockRepoClientMockRecorder) IsPrivate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPrivate", reflect.TypeOf((*MockRepoClient)(nil).IsPrivate))
+}
+
+// ListAuditLogEvents mocks base method.
+func (m *MockRepoClient) ListAuditLogEvents() ([]api.AuditLogEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogEvents")
+	ret0, _ := ret[0].([]api.AuditLogEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditLogEvents indicates an expected call of ListAuditLogEvents.
+func (mr *MockRepoClientMockRecorder) ListAuditLogEvents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogEvents", reflect.TypeOf((*MockRepoClient)(nil).ListAuditLogEvents))
+}
+
+// ListBranches mocks base method.
+func (m *MockRepoClient) ListBranches() ([]api.BranchInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBranches")
+	ret0, _ := ret[0].([]api.BranchInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBranches indicates an expected call of ListBranches.
+func (mr *MockRepoClientMockRecorder) ListBranches() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranches", reflect.TypeOf((*MockRepoClient)(nil).ListBranches))
+}
+
 // ListCheckRunsForRef mocks base method.
-func (m *MockRepoClient) ListCheckRunsForRef(ref string) ([]clients.CheckRun, error) {
+func (m *MockRepoClient) ListCheckRunsForRef(ref string) ([]api.CheckRun, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListCheckRunsForRef", ref)
-	ret0, _ := ret[0].([]clients.CheckRun)
+	ret0, _ := ret[0].([]api.CheckRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -183,11 +303,26 @@ func (mr *MockRepoClientMockRecorder) ListCheckRunsForRef(ref interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCheckRunsForRef", reflect.TypeOf((*MockRepoClient)(nil).ListCheckRunsForRef), ref)
 }
 
+// ListCodeScanningAlerts mocks base method.
+func (m *MockRepoClient) ListCodeScanningAlerts() ([]api.SecurityAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCodeScanningAlerts")
+	ret0, _ := ret[0].([]api.SecurityAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCodeScanningAlerts indicates an expected call of ListCodeScanningAlerts.
+func (mr *MockRepoClientMockRecorder) ListCodeScanningAlerts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCodeScanningAlerts", reflect.TypeOf((*MockRepoClient)(nil).ListCodeScanningAlerts))
+}
+
 // ListCommits mocks base method.
-func (m *MockRepoClient) ListCommits() ([]clients.Commit, error) {
+func (m *MockRepoClient) ListCommits() ([]api.Commit, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListCommits")
-	ret0, _ := ret[0].([]clients.Commit)
+	ret0, _ := ret[0].([]api.Commit)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -199,10 +334,10 @@ func (mr *MockRepoClientMockRecorder) ListCommits() *gomock.Call {
 }
 
 // ListContributors mocks base method.
-func (m *MockRepoClient) ListContributors() ([]clients.User, error) {
+func (m *MockRepoClient) ListContributors() ([]api.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListContributors")
-	ret0, _ := ret[0].([]clients.User)
+	ret0, _ := ret[0].([]api.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -213,6 +348,36 @@ func (mr *MockRepoClientMockRecorder) ListContributors() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContributors", reflect.TypeOf((*MockRepoClient)(nil).ListContributors))
 }
 
+// ListDependencyAlerts mocks base method.
+func (m *MockRepoClient) ListDependencyAlerts() ([]api.SecurityAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDependencyAlerts")
+	ret0, _ := ret[0].([]api.SecurityAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDependencyAlerts indicates an expected call of ListDependencyAlerts.
+func (mr *MockRepoClientMockRecorder) ListDependencyAlerts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDependencyAlerts", reflect.TypeOf((*MockRepoClient)(nil).ListDependencyAlerts))
+}
+
+// ListEnvironments mocks base method.
+func (m *MockRepoClient) ListEnvironments() ([]api.Environment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnvironments")
+	ret0, _ := ret[0].([]api.Environment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnvironments indicates an expected call of ListEnvironments.
+func (mr *MockRepoClientMockRecorder) ListEnvironments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvironments", reflect.TypeOf((*MockRepoClient)(nil).ListEnvironments))
+}
+
 // ListFiles mocks base method.
 func (m *MockRepoClient) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -228,11 +393,26 @@ func (mr *MockRepoClientMockRecorder) ListFiles(predicate interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockRepoClient)(nil).ListFiles), predicate)
 }
 
+// ListForcePushes mocks base method.
+func (m *MockRepoClient) ListForcePushes() ([]api.ForcePush, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListForcePushes")
+	ret0, _ := ret[0].([]api.ForcePush)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListForcePushes indicates an expected call of ListForcePushes.
+func (mr *MockRepoClientMockRecorder) ListForcePushes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForcePushes", reflect.TypeOf((*MockRepoClient)(nil).ListForcePushes))
+}
+
 // ListIssues mocks base method.
-func (m *MockRepoClient) ListIssues() ([]clients.Issue, error) {
+func (m *MockRepoClient) ListIssues() ([]api.Issue, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListIssues")
-	ret0, _ := ret[0].([]clients.Issue)
+	ret0, _ := ret[0].([]api.Issue)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -244,10 +424,10 @@ func (mr *MockRepoClientMockRecorder) ListIssues() *gomock.Call {
 }
 
 // ListLicenses mocks base method.
-func (m *MockRepoClient) ListLicenses() ([]clients.License, error) {
+func (m *MockRepoClient) ListLicenses() ([]api.License, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListLicenses")
-	ret0, _ := ret[0].([]clients.License)
+	ret0, _ := ret[0].([]api.License)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -259,10 +439,10 @@ func (mr *MockRepoClientMockRecorder) ListLicenses() *gomock.Call {
 }
 
 // ListProgrammingLanguages mocks base method.
-func (m *MockRepoClient) ListProgrammingLanguages() ([]clients.Language, error) {
+func (m *MockRepoClient) ListProgrammingLanguages() ([]api.Language, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListProgrammingLanguages")
-	ret0, _ := ret[0].([]clients.Language)
+	ret0, _ := ret[0].([]api.Language)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -274,10 +454,10 @@ func (mr *MockRepoClientMockRecorder) ListProgrammingLanguages() *gomock.Call {
 }
 
 // ListReleases mocks base method.
-func (m *MockRepoClient) ListReleases() ([]clients.Release, error) {
+func (m *MockRepoClient) ListReleases() ([]api.Release, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListReleases")
-	ret0, _ := ret[0].([]clients.Release)
+	ret0, _ := ret[0].([]api.Release)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -289,10 +469,10 @@ func (mr *MockRepoClientMockRecorder) ListReleases() *gomock.Call {
 }
 
 // ListStatuses mocks base method.
-func (m *MockRepoClient) ListStatuses(ref string) ([]clients.Status, error) {
+func (m *MockRepoClient) ListStatuses(ref string) ([]api.Status, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListStatuses", ref)
-	ret0, _ := ret[0].([]clients.Status)
+	ret0, _ := ret[0].([]api.Status)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -304,10 +484,10 @@ func (mr *MockRepoClientMockRecorder) ListStatuses(ref interface{}) *gomock.Call
 }
 
 // ListSuccessfulWorkflowRuns mocks base method.
-func (m *MockRepoClient) ListSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
+func (m *MockRepoClient) ListSuccessfulWorkflowRuns(filename string) ([]api.WorkflowRun, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListSuccessfulWorkflowRuns", filename)
-	ret0, _ := ret[0].([]clients.WorkflowRun)
+	ret0, _ := ret[0].([]api.WorkflowRun)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -319,10 +499,10 @@ func (mr *MockRepoClientMockRecorder) ListSuccessfulWorkflowRuns(filename interf
 }
 
 // ListWebhooks mocks base method.
-func (m *MockRepoClient) ListWebhooks() ([]clients.Webhook, error) {
+func (m *MockRepoClient) ListWebhooks() ([]api.Webhook, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListWebhooks")
-	ret0, _ := ret[0].([]clients.Webhook)
+	ret0, _ := ret[0].([]api.Webhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -349,10 +529,10 @@ func (mr *MockRepoClientMockRecorder) LocalPath() *gomock.Call {
 }
 
 // Search mocks base method.
-func (m *MockRepoClient) Search(request clients.SearchRequest) (clients.SearchResponse, error) {
+func (m *MockRepoClient) Search(request api.SearchRequest) (api.SearchResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Search", request)
-	ret0, _ := ret[0].(clients.SearchResponse)
+	ret0, _ := ret[0].(api.SearchResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -364,10 +544,10 @@ func (mr *MockRepoClientMockRecorder) Search(request interface{}) *gomock.Call {
 }
 
 // SearchCommits mocks base method.
-func (m *MockRepoClient) SearchCommits(request clients.SearchCommitsOptions) ([]clients.Commit, error) {
+func (m *MockRepoClient) SearchCommits(request api.SearchCommitsOptions) ([]api.Commit, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SearchCommits", request)
-	ret0, _ := ret[0].([]clients.Commit)
+	ret0, _ := ret[0].([]api.Commit)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -14,7 +14,7 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: clients/vulnerabilities.go
+// Source: clients/api/vulnerabilities.go
 
 // Package mockrepo is a generated GoMock package.
 package mockrepo
@@ -24,7 +24,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-	clients "github.com/ossf/scorecard/v4/clients"
+	api "github.com/ossf/scorecard/clients/api"
 )
 
 // MockVulnerabilitiesClient is a mock of VulnerabilitiesClient interface.
@@ -51,10 +51,10 @@ func (m *MockVulnerabilitiesClient) EXPECT() *MockVulnerabilitiesClientMockRecor
 }
 
 // ListUnfixedVulnerabilities mocks base method.
-func (m *MockVulnerabilitiesClient) ListUnfixedVulnerabilities(context context.Context, commit, localDir string) (clients.VulnerabilitiesResponse, error) {
+func (m *MockVulnerabilitiesClient) ListUnfixedVulnerabilities(context context.Context, commit, localDir string) (api.VulnerabilitiesResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListUnfixedVulnerabilities", context, commit, localDir)
-	ret0, _ := ret[0].(clients.VulnerabilitiesResponse)
+	ret0, _ := ret[0].(api.VulnerabilitiesResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
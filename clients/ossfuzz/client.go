@@ -21,6 +21,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -37,16 +38,28 @@ var (
 	errMalformedURL          = errors.New("malformed repo url")
 )
 
+// buildLogURL is the URL of the log of an OSS-Fuzz build.
+const buildLogURL = "https://oss-fuzz-build-logs.storage.googleapis.com/log-%s.txt"
+
 type client struct {
 	err       error
 	projects  map[string]bool
+	builds    map[string][]clients.CheckRun
 	statusURL string
 	once      sync.Once
 }
 
+type ossFuzzBuild struct {
+	BuildID    string `json:"build_id"`
+	FinishTime string `json:"finish_time"`
+	Success    bool   `json:"success"`
+}
+
 type ossFuzzStatus struct {
 	Projects []struct {
-		RepoURI string `json:"main_repo"`
+		LastSuccessfulBuild *ossFuzzBuild  `json:"last_successful_build"`
+		RepoURI             string         `json:"main_repo"`
+		History             []ossFuzzBuild `json:"history"`
 	} `json:"projects"`
 }
 
@@ -55,6 +68,7 @@ func CreateOSSFuzzClient(ossFuzzStatusURL string) clients.RepoClient {
 	return &client{
 		statusURL: ossFuzzStatusURL,
 		projects:  map[string]bool{},
+		builds:    map[string][]clients.CheckRun{},
 	}
 }
 
@@ -63,6 +77,7 @@ func CreateOSSFuzzClientEager(ossFuzzStatusURL string) (clients.RepoClient, erro
 	c := client{
 		statusURL: ossFuzzStatusURL,
 		projects:  map[string]bool{},
+		builds:    map[string][]clients.CheckRun{},
 	}
 	c.once.Do(func() {
 		c.init()
@@ -94,13 +109,13 @@ func (c *client) init() {
 		c.err = err
 		return
 	}
-	if err = parseStatusFile(b, c.projects); err != nil {
+	if err = parseStatusFile(b, c.projects, c.builds); err != nil {
 		c.err = err
 		return
 	}
 }
 
-func parseStatusFile(contents []byte, m map[string]bool) error {
+func parseStatusFile(contents []byte, m map[string]bool, builds map[string][]clients.CheckRun) error {
 	status := ossFuzzStatus{}
 	if err := json.Unmarshal(contents, &status); err != nil {
 		return fmt.Errorf("parse status file: %w", err)
@@ -112,10 +127,55 @@ func parseStatusFile(contents []byte, m map[string]bool) error {
 			continue
 		}
 		m[normalizedRepoURI] = true
+		builds[normalizedRepoURI] = append(builds[normalizedRepoURI],
+			buildCheckRuns(status.Projects[i].History, status.Projects[i].LastSuccessfulBuild)...)
 	}
 	return nil
 }
 
+// buildCheckRuns converts the build history of a project to check runs,
+// most recent first. The last successful build is included even when it is
+// older than the history.
+func buildCheckRuns(history []ossFuzzBuild, lastSuccessful *ossFuzzBuild) []clients.CheckRun {
+	builds := history
+	if lastSuccessful != nil && !containsBuild(history, lastSuccessful.BuildID) {
+		build := *lastSuccessful
+		build.Success = true
+		builds = append(builds, build)
+	}
+	var checkRuns []clients.CheckRun
+	for i := range builds {
+		finishTime, err := time.Parse(time.RFC3339Nano, builds[i].FinishTime)
+		if err != nil {
+			continue
+		}
+		conclusion := "failure"
+		if builds[i].Success {
+			conclusion = "success"
+		}
+		checkRuns = append(checkRuns, clients.CheckRun{
+			Status:      "completed",
+			Conclusion:  conclusion,
+			URL:         fmt.Sprintf(buildLogURL, builds[i].BuildID),
+			CompletedAt: finishTime,
+			App:         clients.CheckRunApp{Slug: "oss-fuzz"},
+		})
+	}
+	sort.SliceStable(checkRuns, func(i, j int) bool {
+		return checkRuns[i].CompletedAt.After(checkRuns[j].CompletedAt)
+	})
+	return checkRuns
+}
+
+func containsBuild(builds []ossFuzzBuild, buildID string) bool {
+	for i := range builds {
+		if builds[i].BuildID == buildID {
+			return true
+		}
+	}
+	return false
+}
+
 func fetchStatusFile(uri string) ([]byte, error) {
 	//nolint:gosec // URI comes from a constant or a test HTTP server, not user input
 	resp, err := http.Get(uri)
@@ -163,6 +223,21 @@ func (c *client) IsArchived() (bool, error) {
 	return false, fmt.Errorf("IsArchived: %w", clients.ErrUnsupportedFeature)
 }
 
+// GetSecurityPolicyURL implements RepoClient.GetSecurityPolicyURL.
+func (c *client) GetSecurityPolicyURL() (string, error) {
+	return "", fmt.Errorf("GetSecurityPolicyURL: %w", clients.ErrUnsupportedFeature)
+}
+
+// IsPrivate implements RepoClient.IsPrivate.
+func (c *client) IsPrivate() (bool, error) {
+	return false, fmt.Errorf("IsPrivate: %w", clients.ErrUnsupportedFeature)
+}
+
+// GetForkParent implements RepoClient.GetForkParent.
+func (c *client) GetForkParent() (clients.Repo, error) {
+	return nil, fmt.Errorf("GetForkParent: %w", clients.ErrUnsupportedFeature)
+}
+
 // LocalPath implements RepoClient.LocalPath.
 func (c *client) LocalPath() (string, error) {
 	return "", fmt.Errorf("LocalPath: %w", clients.ErrUnsupportedFeature)
@@ -183,6 +258,16 @@ func (c *client) GetBranch(branch string) (*clients.BranchRef, error) {
 	return nil, fmt.Errorf("GetBranch: %w", clients.ErrUnsupportedFeature)
 }
 
+// GetBranches implements RepoClient.GetBranches.
+func (c *client) GetBranches(branches []string) ([]*clients.BranchRef, error) {
+	return nil, fmt.Errorf("GetBranches: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListBranches implements RepoClient.ListBranches.
+func (c *client) ListBranches() ([]clients.BranchInfo, error) {
+	return nil, fmt.Errorf("ListBranches: %w", clients.ErrUnsupportedFeature)
+}
+
 // GetDefaultBranch implements RepoClient.GetDefaultBranch.
 func (c *client) GetDefaultBranch() (*clients.BranchRef, error) {
 	return nil, fmt.Errorf("GetDefaultBranch: %w", clients.ErrUnsupportedFeature)
@@ -208,6 +293,11 @@ func (c *client) ListReleases() ([]clients.Release, error) {
 	return nil, fmt.Errorf("ListReleases: %w", clients.ErrUnsupportedFeature)
 }
 
+// GetReleaseAsset implements RepoClient.GetReleaseAsset.
+func (c *client) GetReleaseAsset(asset *clients.ReleaseAsset) ([]byte, error) {
+	return nil, fmt.Errorf("GetReleaseAsset: %w", clients.ErrUnsupportedFeature)
+}
+
 // ListContributors implements RepoClient.ListContributors.
 func (c *client) ListContributors() ([]clients.User, error) {
 	return nil, fmt.Errorf("ListContributors: %w", clients.ErrUnsupportedFeature)
@@ -219,8 +309,16 @@ func (c *client) ListSuccessfulWorkflowRuns(filename string) ([]clients.Workflow
 }
 
 // ListCheckRunsForRef implements RepoClient.ListCheckRunsForRef.
+// ref is the URI of the main repo of an OSS-Fuzz project, as for Search, and
+// the check runs are the recent builds of the project, most recent first.
 func (c *client) ListCheckRunsForRef(ref string) ([]clients.CheckRun, error) {
-	return nil, fmt.Errorf("ListCheckRunsForRef: %w", clients.ErrUnsupportedFeature)
+	c.once.Do(func() {
+		c.init()
+	})
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.builds[ref], nil
 }
 
 // ListStatuses implements RepoClient.ListStatuses.
@@ -233,6 +331,36 @@ func (c *client) ListWebhooks() ([]clients.Webhook, error) {
 	return nil, fmt.Errorf("ListWebhooks: %w", clients.ErrUnsupportedFeature)
 }
 
+// GetActionsPolicy implements RepoClient.GetActionsPolicy.
+func (c *client) GetActionsPolicy() (*clients.ActionsPolicy, error) {
+	return nil, fmt.Errorf("GetActionsPolicy: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListDependencyAlerts implements RepoClient.ListDependencyAlerts.
+func (c *client) ListDependencyAlerts() ([]clients.SecurityAlert, error) {
+	return nil, fmt.Errorf("ListDependencyAlerts: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListCodeScanningAlerts implements RepoClient.ListCodeScanningAlerts.
+func (c *client) ListCodeScanningAlerts() ([]clients.SecurityAlert, error) {
+	return nil, fmt.Errorf("ListCodeScanningAlerts: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListEnvironments implements RepoClient.ListEnvironments.
+func (c *client) ListEnvironments() ([]clients.Environment, error) {
+	return nil, fmt.Errorf("ListEnvironments: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListAuditLogEvents implements RepoClient.ListAuditLogEvents.
+func (c *client) ListAuditLogEvents() ([]clients.AuditLogEvent, error) {
+	return nil, fmt.Errorf("ListAuditLogEvents: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListForcePushes implements RepoClient.ListForcePushes.
+func (c *client) ListForcePushes() ([]clients.ForcePush, error) {
+	return nil, fmt.Errorf("ListForcePushes: %w", clients.ErrUnsupportedFeature)
+}
+
 // SearchCommits implements RepoClient.SearchCommits.
 func (c *client) SearchCommits(request clients.SearchCommitsOptions) ([]clients.Commit, error) {
 	return nil, fmt.Errorf("SearchCommits: %w", clients.ErrUnsupportedFeature)
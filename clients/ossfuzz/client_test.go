@@ -20,6 +20,9 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/ossf/scorecard/v4/clients"
 )
@@ -164,6 +167,66 @@ func TestClientEager(t *testing.T) {
 	}
 }
 
+func TestClientBuilds(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		project string
+		want    []clients.CheckRun
+	}{
+		{
+			name:    "project with build history",
+			project: "github.com/ossf/scorecard",
+			want: []clients.CheckRun{
+				{
+					Status:      "completed",
+					Conclusion:  "failure",
+					URL:         "https://oss-fuzz-build-logs.storage.googleapis.com/log-b3.txt",
+					CompletedAt: time.Date(2023, 6, 3, 8, 0, 0, 0, time.UTC),
+					App:         clients.CheckRunApp{Slug: "oss-fuzz"},
+				},
+				{
+					Status:      "completed",
+					Conclusion:  "failure",
+					URL:         "https://oss-fuzz-build-logs.storage.googleapis.com/log-b2.txt",
+					CompletedAt: time.Date(2023, 6, 2, 8, 0, 0, 0, time.UTC),
+					App:         clients.CheckRunApp{Slug: "oss-fuzz"},
+				},
+				{
+					Status:      "completed",
+					Conclusion:  "success",
+					URL:         "https://oss-fuzz-build-logs.storage.googleapis.com/log-b1.txt",
+					CompletedAt: time.Date(2023, 1, 1, 8, 0, 0, 0, time.UTC),
+					App:         clients.CheckRunApp{Slug: "oss-fuzz"},
+				},
+			},
+		},
+		{
+			name:    "project without build history",
+			project: "github.com/ossf/scorecard-webapp",
+		},
+		{
+			name:    "non existent project",
+			project: "github.com/not/here",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			url := setupServer(t)
+			c := CreateOSSFuzzClient(fmt.Sprintf("%s/%s", url, "status.json"))
+			got, err := c.ListCheckRunsForRef(tt.project)
+			if err != nil {
+				t.Fatalf("ListCheckRunsForRef: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected builds (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func setupServer(t *testing.T) string {
 	t.Helper()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -16,9 +16,14 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/osv"
 	"github.com/google/osv-scanner/pkg/osvscanner"
 )
 
@@ -56,7 +61,11 @@ func (v osvClient) ListUnfixedVulnerabilities(
 	// If vulnerabilities are found, err will be set to osvscanner.VulnerabilitiesFoundErr
 	if errors.Is(err, osvscanner.VulnerabilitiesFoundErr) {
 		vulns := res.Flatten()
+		withdrawn := withdrawnVulnerabilities(ctx, http.DefaultClient, osv.GetEndpoint, vulns)
 		for i := range vulns {
+			if withdrawn[vulns[i].Vulnerability.ID] || isFixed(&vulns[i], commit) {
+				continue
+			}
 			response.Vulnerabilities = append(response.Vulnerabilities, Vulnerability{
 				ID:      vulns[i].Vulnerability.ID,
 				Aliases: vulns[i].Vulnerability.Aliases,
@@ -89,3 +98,70 @@ func removeDuplicate[T any, K comparable](sliceList []T, keyExtract func(T) K) [
 	}
 	return list
 }
+
+// isFixed returns true if the advisory lists the scanned package version
+// or commit as the one fixing it.
+func isFixed(vuln *models.VulnerabilityFlattened, commit string) bool {
+	for _, affected := range vuln.Vulnerability.Affected {
+		samePackage := affected.Package.Name == vuln.Package.Name &&
+			affected.Package.Ecosystem == vuln.Package.Ecosystem
+		for _, r := range affected.Ranges {
+			for _, event := range r.Events {
+				if event.Fixed == "" {
+					continue
+				}
+				if r.Type == "GIT" && commit != "" && event.Fixed == commit {
+					return true
+				}
+				if r.Type != "GIT" && samePackage && vuln.Package.Version != "" &&
+					event.Fixed == vuln.Package.Version {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// withdrawnVulnerabilities returns the IDs of the advisories withdrawn from OSV.
+// The scanner does not keep the withdrawn field, so each advisory is fetched again.
+// Advisories which cannot be fetched are treated as not withdrawn.
+func withdrawnVulnerabilities(ctx context.Context, client *http.Client, endpoint string,
+	vulns []models.VulnerabilityFlattened,
+) map[string]bool {
+	withdrawn := map[string]bool{}
+	seen := map[string]bool{}
+	for i := range vulns {
+		id := vulns[i].Vulnerability.ID
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if isWithdrawn(ctx, client, endpoint, id) {
+			withdrawn[id] = true
+		}
+	}
+	return withdrawn
+}
+
+func isWithdrawn(ctx context.Context, client *http.Client, endpoint, id string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/"+id, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	var v struct {
+		Withdrawn *time.Time `json:"withdrawn"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return false
+	}
+	return v.Withdrawn != nil && !v.Withdrawn.After(time.Now())
+}
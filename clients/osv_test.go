@@ -14,8 +14,15 @@
 package clients
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
 )
 
 func TestRemoveDuplicate(t *testing.T) {
@@ -46,3 +53,92 @@ func TestRemoveDuplicate(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFixed(t *testing.T) {
+	t.Parallel()
+	const advisory = `{
+		"id": "GHSA-xxxx-yyyy-zzzz",
+		"affected": [{
+			"package": {"ecosystem": "Go", "name": "example.com/mod"},
+			"ranges": [
+				{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.2.0"}]},
+				{"type": "GIT", "events": [{"introduced": "0"}, {"fixed": "abc123"}]}
+			]
+		}]
+	}`
+	var vuln models.Vulnerability
+	if err := json.Unmarshal([]byte(advisory), &vuln); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	tests := []struct {
+		name   string
+		pkg    models.PackageInfo
+		commit string
+		want   bool
+	}{
+		{
+			name: "affected version",
+			pkg:  models.PackageInfo{Ecosystem: "Go", Name: "example.com/mod", Version: "1.1.0"},
+			want: false,
+		},
+		{
+			name: "fixed version",
+			pkg:  models.PackageInfo{Ecosystem: "Go", Name: "example.com/mod", Version: "1.2.0"},
+			want: true,
+		},
+		{
+			name: "fixed version of another package",
+			pkg:  models.PackageInfo{Ecosystem: "Go", Name: "example.com/other", Version: "1.2.0"},
+			want: false,
+		},
+		{
+			name:   "fixed commit",
+			commit: "abc123",
+			want:   true,
+		},
+		{
+			name:   "affected commit",
+			commit: "def456",
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := models.VulnerabilityFlattened{Package: tt.pkg, Vulnerability: vuln}
+			if got := isFixed(&v, tt.commit); got != tt.want {
+				t.Errorf("isFixed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithdrawnVulnerabilities(t *testing.T) {
+	t.Parallel()
+	responses := map[string]string{
+		"/GHSA-withdrawn": `{"id": "GHSA-withdrawn", "withdrawn": "2023-01-02T00:00:00Z"}`,
+		"/GHSA-active":    `{"id": "GHSA-active"}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	vulns := []models.VulnerabilityFlattened{
+		{Vulnerability: models.Vulnerability{ID: "GHSA-withdrawn"}},
+		{Vulnerability: models.Vulnerability{ID: "GHSA-active"}},
+		{Vulnerability: models.Vulnerability{ID: "GHSA-missing"}},
+		{Vulnerability: models.Vulnerability{ID: "GHSA-withdrawn"}},
+	}
+	got := withdrawnVulnerabilities(context.Background(), srv.Client(), srv.URL, vulns)
+	want := map[string]bool{"GHSA-withdrawn": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
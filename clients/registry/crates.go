@@ -0,0 +1,74 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const cratesURL = "https://crates.io"
+
+var _ PackageClient = &cratesClient{}
+
+type cratesClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// crate is the subset of the API response for a crate used by Scorecard.
+type crate struct {
+	Crate struct {
+		Repository       string `json:"repository"`
+		MaxStableVersion string `json:"max_stable_version"`
+		NewestVersion    string `json:"newest_version"`
+	} `json:"crate"`
+}
+
+// GetPackage implements PackageClient.GetPackage. crates.io does not expose
+// the provenance of crates.
+func (c *cratesClient) GetPackage(ctx context.Context, name string) (*Package, error) {
+	if name == "" {
+		return nil, errInvalidName
+	}
+	var doc crate
+	u := fmt.Sprintf("%s/api/v1/crates/%s", c.baseURL, url.PathEscape(name))
+	if err := getJSON(ctx, c.httpClient, u, &doc); err != nil {
+		return nil, fmt.Errorf("crate %s: %w", name, err)
+	}
+	// Owners are users and teams of a forge allowed to publish the crate.
+	var owners struct {
+		Users []struct {
+			Login string `json:"login"`
+		} `json:"users"`
+	}
+	if err := getJSON(ctx, c.httpClient, u+"/owners", &owners); err != nil {
+		return nil, fmt.Errorf("crate %s owners: %w", name, err)
+	}
+	version := doc.Crate.MaxStableVersion
+	if version == "" {
+		version = doc.Crate.NewestVersion
+	}
+	pkg := &Package{
+		Ecosystem:     CratesIO,
+		Name:          name,
+		LatestVersion: version,
+		Maintainers:   len(owners.Users),
+	}
+	pkg.setRepo(repoCandidate{url: doc.Crate.Repository, confidence: ConfidenceHigh})
+	return pkg, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const mavenURL = "https://repo1.maven.org/maven2"
+
+var _ PackageClient = &mavenClient{}
+
+type mavenClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// mavenMetadata is the subset of the maven-metadata.xml of an artifact used
+// by Scorecard.
+type mavenMetadata struct {
+	Versioning struct {
+		Release string `xml:"release"`
+		Latest  string `xml:"latest"`
+	} `xml:"versioning"`
+}
+
+// mavenPOM is the subset of the POM of a version of an artifact used by
+// Scorecard. Values inherited from parent POMs are not resolved.
+type mavenPOM struct {
+	URL string `xml:"url"`
+	SCM struct {
+		URL        string `xml:"url"`
+		Connection string `xml:"connection"`
+	} `xml:"scm"`
+	Developers []struct {
+		ID string `xml:"id"`
+	} `xml:"developers>developer"`
+}
+
+// GetPackage implements PackageClient.GetPackage for packages named
+// groupId:artifactId. Maven Central does not list the accounts allowed to
+// publish artifacts: the developers declared by the POM are counted
+// instead. Provenance is a Sigstore bundle published along the POM.
+func (c *mavenClient) GetPackage(ctx context.Context, name string) (*Package, error) {
+	group, artifact, ok := strings.Cut(name, ":")
+	if !ok || group == "" || artifact == "" || strings.Contains(artifact, ":") {
+		return nil, fmt.Errorf("%w: %s: expected groupId:artifactId", errInvalidName, name)
+	}
+	base := fmt.Sprintf("%s/%s/%s", c.baseURL, strings.ReplaceAll(group, ".", "/"), artifact)
+	var metadata mavenMetadata
+	if err := getXML(ctx, c.httpClient, base+"/maven-metadata.xml", &metadata); err != nil {
+		return nil, fmt.Errorf("maven package %s: %w", name, err)
+	}
+	version := metadata.Versioning.Release
+	if version == "" {
+		version = metadata.Versioning.Latest
+	}
+	pkg := &Package{
+		Ecosystem:     Maven,
+		Name:          name,
+		LatestVersion: version,
+		Maintainers:   -1,
+	}
+	if version == "" {
+		return pkg, nil
+	}
+	pomURL := fmt.Sprintf("%s/%s/%s-%s.pom", base, version, artifact, version)
+	var pom mavenPOM
+	if err := getXML(ctx, c.httpClient, pomURL, &pom); err != nil {
+		return nil, fmt.Errorf("maven package %s POM: %w", name, err)
+	}
+	pkg.Maintainers = len(pom.Developers)
+	pkg.setRepo(
+		repoCandidate{url: pom.SCM.URL, confidence: ConfidenceHigh},
+		repoCandidate{url: pom.SCM.Connection, confidence: ConfidenceHigh},
+		repoCandidate{url: pom.URL, confidence: ConfidenceLow},
+	)
+	provenance, err := exists(ctx, c.httpClient, pomURL+".sigstore.json")
+	if err != nil {
+		return nil, fmt.Errorf("maven package %s provenance: %w", name, err)
+	}
+	pkg.Provenance = provenance
+	return pkg, nil
+}
+
+// getXML decodes the XML document at url into v.
+func getXML(ctx context.Context, c *http.Client, url string, v interface{}) error {
+	body, err := get(ctx, c, url)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("xml.Unmarshal: %s: %w", url, err)
+	}
+	return nil
+}
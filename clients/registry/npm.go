@@ -0,0 +1,100 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const npmURL = "https://registry.npmjs.org"
+
+var _ PackageClient = &npmClient{}
+
+type npmClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// npmVersion is the subset of the document describing the latest version of
+// a package used by Scorecard, see https://github.com/npm/registry/blob/master/docs/responses/package-metadata.md.
+// The document of all the versions of popular packages is tens of MB large.
+type npmVersion struct {
+	Version     string          `json:"version"`
+	Repository  json.RawMessage `json:"repository"`
+	Maintainers []struct {
+		Name string `json:"name"`
+	} `json:"maintainers"`
+	Dist struct {
+		Attestations *struct {
+			Provenance *struct {
+				PredicateType string `json:"predicateType"`
+			} `json:"provenance"`
+		} `json:"attestations"`
+	} `json:"dist"`
+}
+
+// GetPackage implements PackageClient.GetPackage.
+func (c *npmClient) GetPackage(ctx context.Context, name string) (*Package, error) {
+	if name == "" {
+		return nil, errInvalidName
+	}
+	var doc npmVersion
+	if err := getJSON(ctx, c.httpClient, fmt.Sprintf("%s/%s/latest", c.baseURL, npmPath(name)), &doc); err != nil {
+		return nil, fmt.Errorf("npm package %s: %w", name, err)
+	}
+	attestations := doc.Dist.Attestations
+	pkg := &Package{
+		Ecosystem:     NPM,
+		Name:          name,
+		LatestVersion: doc.Version,
+		Maintainers:   len(doc.Maintainers),
+		Provenance:    attestations != nil && attestations.Provenance != nil,
+	}
+	pkg.setRepo(repoCandidate{url: npmRepository(doc.Repository), confidence: ConfidenceHigh})
+	return pkg, nil
+}
+
+// npmPath escapes the name of the package for the path of a URL, keeping
+// the slash of scoped packages, e.g. @scope/name.
+func npmPath(name string) string {
+	if scope, pkg, ok := strings.Cut(name, "/"); ok {
+		return url.PathEscape(scope) + "/" + url.PathEscape(pkg)
+	}
+	return url.PathEscape(name)
+}
+
+// npmRepository returns the URL of the repository field of package.json,
+// which is either a URL or an object with a url field.
+func npmRepository(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var u string
+	if err := json.Unmarshal(raw, &u); err == nil {
+		return u
+	}
+	var repo struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(raw, &repo); err != nil {
+		return ""
+	}
+	return repo.URL
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const pypiURL = "https://pypi.org"
+
+// pypiSourceLabels are the lower case labels of the project URLs pointing to
+// the source repo.
+var pypiSourceLabels = map[string]bool{
+	"source":      true,
+	"source code": true,
+	"repository":  true,
+	"code":        true,
+}
+
+var _ PackageClient = &pypiClient{}
+
+type pypiClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// pypiProject is the subset of the JSON API response for a project used by
+// Scorecard, see https://docs.pypi.org/api/json/.
+type pypiProject struct {
+	Info struct {
+		Version     string            `json:"version"`
+		HomePage    string            `json:"home_page"`
+		ProjectURLs map[string]string `json:"project_urls"`
+	} `json:"info"`
+	// URLs are the files of the latest version.
+	URLs []struct {
+		Filename string `json:"filename"`
+	} `json:"urls"`
+}
+
+// GetPackage implements PackageClient.GetPackage. The JSON API does not list
+// the maintainers of projects.
+func (c *pypiClient) GetPackage(ctx context.Context, name string) (*Package, error) {
+	if name == "" {
+		return nil, errInvalidName
+	}
+	var doc pypiProject
+	u := fmt.Sprintf("%s/pypi/%s/json", c.baseURL, url.PathEscape(name))
+	if err := getJSON(ctx, c.httpClient, u, &doc); err != nil {
+		return nil, fmt.Errorf("pypi package %s: %w", name, err)
+	}
+	pkg := &Package{
+		Ecosystem:     PyPI,
+		Name:          name,
+		LatestVersion: doc.Info.Version,
+		Maintainers:   -1,
+	}
+	pkg.setRepo(pypiRepoCandidates(&doc)...)
+	// Provenance is attached to each file, see PEP 740.
+	if len(doc.URLs) > 0 {
+		u := fmt.Sprintf("%s/integrity/%s/%s/%s/provenance", c.baseURL, url.PathEscape(name),
+			url.PathEscape(doc.Info.Version), url.PathEscape(doc.URLs[0].Filename))
+		ok, err := exists(ctx, c.httpClient, u)
+		if err != nil {
+			return nil, fmt.Errorf("pypi package %s provenance: %w", name, err)
+		}
+		pkg.Provenance = ok
+	}
+	return pkg, nil
+}
+
+func pypiRepoCandidates(doc *pypiProject) []repoCandidate {
+	candidates := []repoCandidate{{url: doc.Info.HomePage, confidence: ConfidenceLow}}
+	for label, u := range doc.Info.ProjectURLs {
+		label = strings.ToLower(label)
+		switch {
+		case pypiSourceLabels[label]:
+			candidates = append(candidates, repoCandidate{url: u, confidence: ConfidenceHigh})
+		case label == "homepage":
+			candidates = append(candidates, repoCandidate{url: u, confidence: ConfidenceLow})
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,294 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registry defines clients for the registries packages are
+// published to, e.g. npm and PyPI.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Ecosystem is the ecosystem of a registry.
+type Ecosystem string
+
+const (
+	// NPM is the npm registry.
+	NPM Ecosystem = "npm"
+	// PyPI is the Python Package Index.
+	PyPI Ecosystem = "pypi"
+	// RubyGems is the RubyGems registry.
+	RubyGems Ecosystem = "rubygems"
+	// CratesIO is the crates.io registry of Rust crates.
+	CratesIO Ecosystem = "crates.io"
+	// Maven is the Maven Central repository. Packages are named
+	// groupId:artifactId.
+	Maven Ecosystem = "maven"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	userAgent      = "scorecard (https://github.com/ossf/scorecard)"
+	// maxResponseSize bounds the size of the documents read from registries,
+	// so that a hostile registry cannot exhaust the memory of Scorecard.
+	maxResponseSize = 32 << 20
+)
+
+var (
+	// ErrPackageNotFound is returned when a package is not in the registry.
+	ErrPackageNotFound = errors.New("package not found")
+	// ErrUnsupportedEcosystem is returned for ecosystems without a client.
+	ErrUnsupportedEcosystem = errors.New("unsupported ecosystem")
+
+	errUnexpectedStatus = errors.New("unexpected status")
+	errResponseTooLarge = errors.New("response too large")
+	errInvalidName      = errors.New("invalid package name")
+)
+
+// Package is the metadata of a package published to a registry.
+type Package struct {
+	Ecosystem Ecosystem
+	Name      string
+	// Repo is the URL of the source repo declared by the package, if any.
+	Repo string
+	// RepoConfidence is how confident the mapping of the package to Repo
+	// is, one of ConfidenceHigh, ConfidenceMedium or ConfidenceLow.
+	RepoConfidence string
+	// RepoCandidates are the repos declared by the package when they
+	// disagree, in which case Repo is empty as the mapping is ambiguous.
+	RepoCandidates []string
+	// LatestVersion is the latest stable version of the package.
+	LatestVersion string
+	// Maintainers is the number of maintainers of the package, usually the
+	// accounts allowed to publish it, or -1 if the registry does not tell.
+	Maintainers int
+	// Provenance is true when the latest version was published with a
+	// provenance attestation or signature verifiable by the registry.
+	Provenance bool
+}
+
+// Confidence levels of the mapping of a package to its source repo.
+const (
+	// ConfidenceHigh is for repos declared by a field dedicated to the
+	// source repo of the version, e.g. the repository of package.json.
+	ConfidenceHigh = "high"
+	// ConfidenceMedium is for repos declared by the package but not by its
+	// latest version.
+	ConfidenceMedium = "medium"
+	// ConfidenceLow is for repos guessed from other URLs, e.g. a home page
+	// hosted on a forge.
+	ConfidenceLow = "low"
+)
+
+// PURL returns the package URL of the latest version of p, see
+// https://github.com/package-url/purl-spec.
+func (p *Package) PURL() string {
+	var typ, name string
+	switch p.Ecosystem {
+	case NPM:
+		typ, name = "npm", strings.Replace(p.Name, "@", "%40", 1)
+	case PyPI:
+		typ, name = "pypi", strings.ToLower(strings.ReplaceAll(p.Name, "_", "-"))
+	case RubyGems:
+		typ, name = "gem", p.Name
+	case CratesIO:
+		typ, name = "cargo", p.Name
+	case Maven:
+		typ, name = "maven", strings.Replace(p.Name, ":", "/", 1)
+	default:
+		typ, name = string(p.Ecosystem), p.Name
+	}
+	purl := fmt.Sprintf("pkg:%s/%s", typ, name)
+	if p.LatestVersion != "" {
+		purl += "@" + p.LatestVersion
+	}
+	return purl
+}
+
+// repoCandidate is a URL declared by a package which may be its source repo.
+type repoCandidate struct {
+	url        string
+	confidence string
+}
+
+var confidenceRanks = map[string]int{ConfidenceHigh: 3, ConfidenceMedium: 2, ConfidenceLow: 1}
+
+// setRepo maps p to the repo of the candidates with the highest confidence.
+// Candidates of low confidence must be hosted on a forge. When candidates of
+// the same confidence declare distinct repos, the mapping is ambiguous and
+// they are recorded in p.RepoCandidates instead.
+func (p *Package) setRepo(candidates ...repoCandidate) {
+	var best []string
+	rank := 0
+	seen := map[string]bool{}
+	for _, c := range candidates {
+		u := normalizeRepoURL(c.url)
+		if u == "" || (c.confidence == ConfidenceLow && !isForgeURL(u)) {
+			continue
+		}
+		r := confidenceRanks[c.confidence]
+		if r < rank {
+			continue
+		}
+		if r > rank {
+			rank, best, seen = r, nil, map[string]bool{}
+			p.RepoConfidence = c.confidence
+		}
+		// Forges are case-insensitive.
+		if key := strings.ToLower(u); !seen[key] {
+			seen[key] = true
+			best = append(best, u)
+		}
+	}
+	switch len(best) {
+	case 0:
+		p.RepoConfidence = ""
+	case 1:
+		p.Repo = best[0]
+	default:
+		sort.Strings(best)
+		p.RepoCandidates = best
+	}
+}
+
+// PackageClient reads the metadata of packages from a registry.
+type PackageClient interface {
+	// GetPackage returns the metadata of the package with the given name,
+	// or ErrPackageNotFound.
+	GetPackage(ctx context.Context, name string) (*Package, error)
+}
+
+// NewPackageClient returns the client of the registry of the ecosystem,
+// sending requests with httpClient, or a client with a default timeout if
+// nil.
+func NewPackageClient(ecosystem Ecosystem, httpClient *http.Client) (PackageClient, error) {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: requestTimeout}
+	}
+	switch ecosystem {
+	case NPM:
+		return &npmClient{httpClient: httpClient, baseURL: npmURL}, nil
+	case PyPI:
+		return &pypiClient{httpClient: httpClient, baseURL: pypiURL}, nil
+	case RubyGems:
+		return &rubyGemsClient{httpClient: httpClient, baseURL: rubyGemsURL}, nil
+	case CratesIO:
+		return &cratesClient{httpClient: httpClient, baseURL: cratesURL}, nil
+	case Maven:
+		return &mavenClient{httpClient: httpClient, baseURL: mavenURL}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEcosystem, ecosystem)
+	}
+}
+
+// get sends a GET request for url, returning the response body. It returns
+// ErrPackageNotFound when the server answers with 404, and an error for
+// bodies larger than maxResponseSize.
+func get(ctx context.Context, c *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http.Do: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrPackageNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%w: %s: %s", errUnexpectedStatus, url, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
+	}
+	if len(body) > maxResponseSize {
+		return nil, fmt.Errorf("%w: %s: more than %d bytes", errResponseTooLarge, url, maxResponseSize)
+	}
+	return body, nil
+}
+
+// getJSON decodes the JSON document at url into v.
+func getJSON(ctx context.Context, c *http.Client, url string, v interface{}) error {
+	body, err := get(ctx, c, url)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("json.Unmarshal: %s: %w", url, err)
+	}
+	return nil
+}
+
+// exists returns whether the document at url exists.
+func exists(ctx context.Context, c *http.Client, url string) (bool, error) {
+	_, err := get(ctx, c, url)
+	if errors.Is(err, ErrPackageNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+var gitHubShorthandRegex = regexp.MustCompile(`^(?:github:)?([\w.-]+/[\w.-]+)$`)
+
+// normalizeRepoURL turns the repo URLs declared by packages, e.g.
+// git+https://github.com/owner/repo.git or github:owner/repo, into the URL
+// of the repo. It returns "" for URLs which do not point to a repo.
+func normalizeRepoURL(u string) string {
+	u = strings.TrimSpace(u)
+	if m := gitHubShorthandRegex.FindStringSubmatch(u); m != nil {
+		u = "https://github.com/" + m[1]
+	}
+	// Maven SCM URLs are prefixed with the type of SCM, e.g. scm:git:.
+	u = strings.TrimPrefix(u, "scm:")
+	if !strings.HasPrefix(u, "git://") {
+		u = strings.TrimPrefix(u, "git:")
+	}
+	u = strings.TrimPrefix(u, "git+")
+	for _, prefix := range []string{"git://", "ssh://git@", "git@"} {
+		if strings.HasPrefix(u, prefix) {
+			u = "https://" + strings.Replace(strings.TrimPrefix(u, prefix), ":", "/", 1)
+		}
+	}
+	u = strings.Replace(u, "http://", "https://", 1)
+	if !strings.HasPrefix(u, "https://") {
+		return ""
+	}
+	u = strings.SplitN(u, "#", 2)[0]
+	u = strings.TrimSuffix(u, "/")
+	u = strings.TrimSuffix(u, ".git")
+	// Drop paths into the repo, e.g. github.com/owner/repo/tree/main/pkg.
+	if parts := strings.SplitN(u, "/", 6); len(parts) > 5 && parts[2] == "github.com" {
+		u = strings.Join(parts[:5], "/")
+	}
+	return u
+}
+
+// isForgeURL returns whether u is the URL of a repo hosted on a forge, as
+// opposed to e.g. the home page of a project.
+func isForgeURL(u string) bool {
+	return strings.HasPrefix(u, "https://github.com/") || strings.HasPrefix(u, "https://gitlab.com/")
+}
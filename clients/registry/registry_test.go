@@ -0,0 +1,345 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormalizeRepoURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://github.com/owner/repo", want: "https://github.com/owner/repo"},
+		{url: "git+https://github.com/owner/repo.git", want: "https://github.com/owner/repo"},
+		{url: "git://github.com/owner/repo.git", want: "https://github.com/owner/repo"},
+		{url: "git+ssh://git@github.com/owner/repo.git", want: "https://github.com/owner/repo"},
+		{url: "git@github.com:owner/repo.git", want: "https://github.com/owner/repo"},
+		{url: "scm:git:https://github.com/owner/repo.git", want: "https://github.com/owner/repo"},
+		{url: "scm:git:git@github.com:owner/repo.git", want: "https://github.com/owner/repo"},
+		{url: "github:owner/repo", want: "https://github.com/owner/repo"},
+		{url: "owner/repo", want: "https://github.com/owner/repo"},
+		{url: "http://github.com/owner/repo/", want: "https://github.com/owner/repo"},
+		{url: "https://github.com/owner/repo/tree/main/packages/pkg", want: "https://github.com/owner/repo"},
+		{url: "https://github.com/owner/repo#readme", want: "https://github.com/owner/repo"},
+		{url: "https://gitlab.com/group/subgroup/repo", want: "https://gitlab.com/group/subgroup/repo"},
+		{url: "", want: ""},
+		{url: "not a url", want: ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			if got := normalizeRepoURL(tt.url); got != tt.want {
+				t.Errorf("normalizeRepoURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func newTestClient(ecosystem Ecosystem, baseURL string) PackageClient {
+	c := http.DefaultClient
+	switch ecosystem {
+	case NPM:
+		return &npmClient{httpClient: c, baseURL: baseURL}
+	case PyPI:
+		return &pypiClient{httpClient: c, baseURL: baseURL}
+	case RubyGems:
+		return &rubyGemsClient{httpClient: c, baseURL: baseURL}
+	case CratesIO:
+		return &cratesClient{httpClient: c, baseURL: baseURL}
+	case Maven:
+		return &mavenClient{httpClient: c, baseURL: baseURL}
+	}
+	return nil
+}
+
+//nolint:lll
+func TestGetPackage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		ecosystem Ecosystem
+		pkg       string
+		// responses maps the escaped paths of requests to their responses.
+		responses map[string]string
+		want      *Package
+		wantErr   error
+	}{
+		{
+			name:      "npm package with provenance",
+			ecosystem: NPM,
+			pkg:       "@scope/pkg",
+			responses: map[string]string{
+				"/@scope/pkg/latest": `{
+  "version": "2.0.0",
+  "repository": {"type": "git", "url": "git+https://github.com/owner/repo.git", "directory": "packages/pkg"},
+  "maintainers": [{"name": "alice"}, {"name": "bob"}],
+  "dist": {"attestations": {"url": "https://registry.npmjs.org/-/npm/v1/attestations/@scope%2fpkg@2.0.0", "provenance": {"predicateType": "https://slsa.dev/provenance/v1"}}}
+}`,
+			},
+			want: &Package{
+				Ecosystem:      NPM,
+				Name:           "@scope/pkg",
+				Repo:           "https://github.com/owner/repo",
+				RepoConfidence: "high",
+				LatestVersion:  "2.0.0",
+				Maintainers:    2,
+				Provenance:     true,
+			},
+		},
+		{
+			name:      "npm package with repository shorthand",
+			ecosystem: NPM,
+			pkg:       "pkg",
+			responses: map[string]string{
+				"/pkg/latest": `{"version": "1.0.0", "repository": "github:owner/repo", "maintainers": [{"name": "alice"}], "dist": {}}`,
+			},
+			want: &Package{
+				Ecosystem:      NPM,
+				Name:           "pkg",
+				Repo:           "https://github.com/owner/repo",
+				RepoConfidence: "high",
+				LatestVersion:  "1.0.0",
+				Maintainers:    1,
+			},
+		},
+		{
+			name:      "npm package not found",
+			ecosystem: NPM,
+			pkg:       "missing",
+			wantErr:   ErrPackageNotFound,
+		},
+		{
+			name:      "pypi package with provenance",
+			ecosystem: PyPI,
+			pkg:       "pkg",
+			responses: map[string]string{
+				"/pypi/pkg/json": `{
+  "info": {"version": "1.2.0", "home_page": "", "project_urls": {"Homepage": "https://pkg.example.com", "Source Code": "https://github.com/owner/repo"}},
+  "urls": [{"filename": "pkg-1.2.0-py3-none-any.whl"}]
+}`,
+				"/integrity/pkg/1.2.0/pkg-1.2.0-py3-none-any.whl/provenance": `{"version": 1}`,
+			},
+			want: &Package{
+				Ecosystem:      PyPI,
+				Name:           "pkg",
+				Repo:           "https://github.com/owner/repo",
+				RepoConfidence: "high",
+				LatestVersion:  "1.2.0",
+				Maintainers:    -1,
+				Provenance:     true,
+			},
+		},
+		{
+			name:      "pypi package with a home page on a forge",
+			ecosystem: PyPI,
+			pkg:       "pkg",
+			responses: map[string]string{
+				"/pypi/pkg/json": `{"info": {"version": "0.1", "home_page": "https://github.com/owner/repo", "project_urls": null}, "urls": [{"filename": "pkg-0.1.tar.gz"}]}`,
+			},
+			want: &Package{
+				Ecosystem:      PyPI,
+				Name:           "pkg",
+				Repo:           "https://github.com/owner/repo",
+				RepoConfidence: "low",
+				LatestVersion:  "0.1",
+				Maintainers:    -1,
+			},
+		},
+		{
+			name:      "pypi package with an ambiguous source repo",
+			ecosystem: PyPI,
+			pkg:       "pkg",
+			responses: map[string]string{
+				"/pypi/pkg/json": `{"info": {"version": "0.1", "project_urls": {"Source": "https://github.com/owner/repo", "Repository": "https://github.com/other/fork", "Homepage": "https://github.com/owner/docs"}}, "urls": []}`,
+			},
+			want: &Package{
+				Ecosystem:      PyPI,
+				Name:           "pkg",
+				RepoConfidence: "high",
+				RepoCandidates: []string{"https://github.com/other/fork", "https://github.com/owner/repo"},
+				LatestVersion:  "0.1",
+				Maintainers:    -1,
+			},
+		},
+		{
+			name:      "ruby gem with a home page not on a forge",
+			ecosystem: RubyGems,
+			pkg:       "gem",
+			responses: map[string]string{
+				"/api/v1/gems/gem.json":        `{"version": "1.0.0", "homepage_uri": "https://gem.example.com"}`,
+				"/api/v1/gems/gem/owners.json": `[]`,
+			},
+			want: &Package{
+				Ecosystem:     RubyGems,
+				Name:          "gem",
+				LatestVersion: "1.0.0",
+			},
+		},
+		{
+			name:      "ruby gem",
+			ecosystem: RubyGems,
+			pkg:       "gem",
+			responses: map[string]string{
+				"/api/v1/gems/gem.json":        `{"version": "3.1.0", "source_code_uri": "https://github.com/owner/repo/tree/v3.1.0", "homepage_uri": "https://gem.example.com"}`,
+				"/api/v1/gems/gem/owners.json": `[{"handle": "alice"}, {"handle": "bob"}, {"handle": "carol"}]`,
+			},
+			want: &Package{
+				Ecosystem:      RubyGems,
+				Name:           "gem",
+				Repo:           "https://github.com/owner/repo",
+				RepoConfidence: "high",
+				LatestVersion:  "3.1.0",
+				Maintainers:    3,
+			},
+		},
+		{
+			name:      "crate",
+			ecosystem: CratesIO,
+			pkg:       "krate",
+			responses: map[string]string{
+				"/api/v1/crates/krate":        `{"crate": {"repository": "https://github.com/owner/repo", "max_stable_version": "1.4.2", "newest_version": "2.0.0-rc.1"}}`,
+				"/api/v1/crates/krate/owners": `{"users": [{"login": "alice"}, {"login": "github:owner:publishers"}]}`,
+			},
+			want: &Package{
+				Ecosystem:      CratesIO,
+				Name:           "krate",
+				Repo:           "https://github.com/owner/repo",
+				RepoConfidence: "high",
+				LatestVersion:  "1.4.2",
+				Maintainers:    2,
+			},
+		},
+		{
+			name:      "maven artifact with provenance",
+			ecosystem: Maven,
+			pkg:       "org.example:artifact",
+			responses: map[string]string{
+				"/org/example/artifact/maven-metadata.xml": `<metadata><groupId>org.example</groupId><artifactId>artifact</artifactId><versioning><latest>2.0-SNAPSHOT</latest><release>1.5</release></versioning></metadata>`,
+				"/org/example/artifact/1.5/artifact-1.5.pom": `<?xml version="1.0"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <url>https://example.org/artifact</url>
+  <scm><connection>scm:git:git://github.com/owner/repo.git</connection><url>https://github.com/owner/repo/tree/main</url></scm>
+  <developers><developer><id>alice</id></developer></developers>
+</project>`,
+				"/org/example/artifact/1.5/artifact-1.5.pom.sigstore.json": `{}`,
+			},
+			want: &Package{
+				Ecosystem:      Maven,
+				Name:           "org.example:artifact",
+				Repo:           "https://github.com/owner/repo",
+				RepoConfidence: "high",
+				LatestVersion:  "1.5",
+				Maintainers:    1,
+				Provenance:     true,
+			},
+		},
+		{
+			name:      "maven artifact without groupId",
+			ecosystem: Maven,
+			pkg:       "artifact",
+			wantErr:   errInvalidName,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, ok := tt.responses[r.URL.EscapedPath()]
+				if !ok {
+					http.NotFound(w, r)
+					return
+				}
+				w.Write([]byte(body)) //nolint:errcheck
+			}))
+			defer server.Close()
+
+			got, err := newTestClient(tt.ecosystem, server.URL).GetPackage(context.Background(), tt.pkg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetPackage() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetPackage() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetResponseTooLarge(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.CopyN(w, zeroReader{}, maxResponseSize+1) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	if _, err := get(context.Background(), http.DefaultClient, server.URL); !errors.Is(err, errResponseTooLarge) {
+		t.Errorf("get() error = %v, want %v", err, errResponseTooLarge)
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestNewPackageClient(t *testing.T) {
+	t.Parallel()
+	for _, e := range []Ecosystem{NPM, PyPI, RubyGems, CratesIO, Maven} {
+		if _, err := NewPackageClient(e, nil); err != nil {
+			t.Errorf("NewPackageClient(%s): %v", e, err)
+		}
+	}
+	if _, err := NewPackageClient("cpan", nil); !errors.Is(err, ErrUnsupportedEcosystem) {
+		t.Errorf("NewPackageClient(cpan) error = %v, want %v", err, ErrUnsupportedEcosystem)
+	}
+}
+
+func TestPURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pkg  Package
+		want string
+	}{
+		{pkg: Package{Ecosystem: NPM, Name: "@scope/pkg", LatestVersion: "1.0.0"}, want: "pkg:npm/%40scope/pkg@1.0.0"},
+		{pkg: Package{Ecosystem: PyPI, Name: "Django_Rest"}, want: "pkg:pypi/django-rest"},
+		{pkg: Package{Ecosystem: RubyGems, Name: "rails", LatestVersion: "7.1.0"}, want: "pkg:gem/rails@7.1.0"},
+		{pkg: Package{Ecosystem: CratesIO, Name: "serde", LatestVersion: "1.0.0"}, want: "pkg:cargo/serde@1.0.0"},
+		{pkg: Package{Ecosystem: Maven, Name: "org.example:artifact", LatestVersion: "1.5"}, want: "pkg:maven/org.example/artifact@1.5"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.pkg.PURL(); got != tt.want {
+				t.Errorf("PURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const rubyGemsURL = "https://rubygems.org"
+
+var _ PackageClient = &rubyGemsClient{}
+
+type rubyGemsClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// rubyGem is the subset of the API response for a gem used by Scorecard,
+// see https://guides.rubygems.org/rubygems-org-api/.
+type rubyGem struct {
+	Version       string `json:"version"`
+	SourceCodeURI string `json:"source_code_uri"`
+	HomepageURI   string `json:"homepage_uri"`
+}
+
+// GetPackage implements PackageClient.GetPackage. RubyGems does not expose
+// the provenance of gems.
+func (c *rubyGemsClient) GetPackage(ctx context.Context, name string) (*Package, error) {
+	if name == "" {
+		return nil, errInvalidName
+	}
+	var gem rubyGem
+	u := fmt.Sprintf("%s/api/v1/gems/%s.json", c.baseURL, url.PathEscape(name))
+	if err := getJSON(ctx, c.httpClient, u, &gem); err != nil {
+		return nil, fmt.Errorf("ruby gem %s: %w", name, err)
+	}
+	var owners []struct {
+		Handle string `json:"handle"`
+	}
+	u = fmt.Sprintf("%s/api/v1/gems/%s/owners.json", c.baseURL, url.PathEscape(name))
+	if err := getJSON(ctx, c.httpClient, u, &owners); err != nil {
+		return nil, fmt.Errorf("ruby gem %s owners: %w", name, err)
+	}
+	pkg := &Package{
+		Ecosystem:     RubyGems,
+		Name:          name,
+		LatestVersion: gem.Version,
+		Maintainers:   len(owners),
+	}
+	pkg.setRepo(
+		repoCandidate{url: gem.SourceCodeURI, confidence: ConfidenceHigh},
+		repoCandidate{url: gem.HomepageURI, confidence: ConfidenceLow},
+	)
+	return pkg, nil
+}
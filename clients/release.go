@@ -1,29 +0,0 @@
-// Copyright 2021 OpenSSF Scorecard Authors
-//
-// Licensed under the Apache License, Version 2.0 (the "License");
-// you may not use this file except in compliance with the License.
-// You may obtain a copy of the License at
-//
-//      http://www.apache.org/licenses/LICENSE-2.0
-//
-// Unless required by applicable law or agreed to in writing, software
-// distributed under the License is distributed on an "AS IS" BASIS,
-// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-// See the License for the specific language governing permissions and
-// limitations under the License.
-
-package clients
-
-// Release represents a release version of a package/repo.
-type Release struct {
-	TagName         string
-	URL             string
-	TargetCommitish string
-	Assets          []ReleaseAsset
-}
-
-// ReleaseAsset is part of the Release bundle.
-type ReleaseAsset struct {
-	Name string
-	URL  string
-}
@@ -14,31 +14,7 @@
 
 package clients
 
-import (
-	"context"
-)
-
-// VulnerabilitiesClient checks for vulnerabilities in vuln DB.
-type VulnerabilitiesClient interface {
-	ListUnfixedVulnerabilities(
-		context context.Context,
-		commit string,
-		localDir string,
-	) (VulnerabilitiesResponse, error)
-}
-
 // DefaultVulnerabilitiesClient returns a new OSV Vulnerabilities client.
 func DefaultVulnerabilitiesClient() VulnerabilitiesClient {
 	return osvClient{}
 }
-
-// VulnerabilitiesResponse is the response from the vuln DB.
-type VulnerabilitiesResponse struct {
-	Vulnerabilities []Vulnerability
-}
-
-// Vulnerability uniquely identifies a reported security vuln.
-type Vulnerability struct {
-	ID      string
-	Aliases []string
-}
@@ -0,0 +1,215 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	docs "github.com/ossf/scorecard/v4/docs/checks"
+	sclog "github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/pkg"
+	"github.com/ossf/scorecard/v4/policy"
+	"github.com/ossf/scorecard/v4/publish"
+)
+
+const (
+	// Environment set by GitHub Actions, see
+	// https://docs.github.com/en/actions/learn-github-actions/variables#default-environment-variables.
+	envGitHubRepository  = "GITHUB_REPOSITORY"
+	envGitHubRef         = "GITHUB_REF"
+	envGitHubServerURL   = "GITHUB_SERVER_URL"
+	envGitHubStepSummary = "GITHUB_STEP_SUMMARY"
+	envGitHubAuthToken   = "GITHUB_AUTH_TOKEN"
+
+	// Inputs of the action are passed as INPUT_<NAME> environment variables.
+	envInputRepoToken     = "INPUT_REPO_TOKEN"
+	envInputResultsFile   = "INPUT_RESULTS_FILE"
+	envInputResultsFormat = "INPUT_RESULTS_FORMAT"
+	envInputPolicyFile    = "INPUT_POLICY_FILE"
+
+	defaultActionResultsFile = "results.sarif"
+	defaultGitHubServerURL   = "https://github.com"
+)
+
+var (
+	errActionNoRepository = errors.New(envGitHubRepository + " is not set, not running in GitHub Actions?")
+	errActionFormat       = errors.New("unsupported results_format, expected sarif or json")
+)
+
+// actionConfig is the configuration of a run as a GitHub Action.
+type actionConfig struct {
+	repo          string
+	ref           string
+	token         string
+	resultsFile   string
+	resultsFormat string
+	policyFile    string
+	stepSummary   string
+}
+
+func actionCmd(o *options.Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "action",
+		Short: "Run as a GitHub Action",
+		Long: `Action runs Scorecard from a GitHub Actions workflow. The repo and the token
+are read from the standard Actions environment and inputs, the results are
+written as SARIF to results.sarif (or the results_file input) for upload to
+code scanning, and a summary of the scores is added to the job summary.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := actionConfigFromEnv(os.Getenv)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+			return runAction(context.Background(), o, &cfg)
+		},
+	}
+}
+
+// actionConfigFromEnv reads the configuration of the action from the environment.
+func actionConfigFromEnv(getenv func(string) string) (actionConfig, error) {
+	cfg := actionConfig{
+		ref:           getenv(envGitHubRef),
+		token:         getenv(envInputRepoToken),
+		resultsFile:   getenv(envInputResultsFile),
+		resultsFormat: strings.ToLower(getenv(envInputResultsFormat)),
+		policyFile:    getenv(envInputPolicyFile),
+		stepSummary:   getenv(envGitHubStepSummary),
+	}
+	repository := getenv(envGitHubRepository)
+	if repository == "" {
+		return actionConfig{}, errActionNoRepository
+	}
+	serverURL := getenv(envGitHubServerURL)
+	if serverURL == "" {
+		serverURL = defaultGitHubServerURL
+	}
+	server, err := url.Parse(serverURL)
+	if err != nil {
+		return actionConfig{}, fmt.Errorf("parsing %s: %w", envGitHubServerURL, err)
+	}
+	cfg.repo = fmt.Sprintf("%s/%s", server.Host, repository)
+
+	if cfg.resultsFile == "" {
+		cfg.resultsFile = defaultActionResultsFile
+	}
+	switch cfg.resultsFormat {
+	case "":
+		cfg.resultsFormat = options.FormatSarif
+	case options.FormatSarif, options.FormatJSON:
+	default:
+		return actionConfig{}, fmt.Errorf("%w: %s", errActionFormat, cfg.resultsFormat)
+	}
+	return cfg, nil
+}
+
+func runAction(ctx context.Context, o *options.Options, cfg *actionConfig) error {
+	// The clients read the token from the environment.
+	if cfg.token != "" {
+		if err := os.Setenv(envGitHubAuthToken, cfg.token); err != nil {
+			return fmt.Errorf("os.Setenv: %w", err)
+		}
+	}
+	o.Repo = cfg.repo
+	o.Format = cfg.resultsFormat
+	o.PolicyFile = cfg.policyFile
+	o.ShowDetails = true
+	if cfg.ref != "" {
+		o.Metadata = append(o.Metadata, "ref="+cfg.ref)
+	}
+	if cfg.resultsFormat == options.FormatSarif {
+		// Policy files only apply to SARIF, which the action allows whatever SCORECARD_V6/ENABLE_SARIF say.
+		o.EnableSarif = true
+	}
+	if err := o.Validate(); err != nil {
+		return fmt.Errorf("validating options: %w", err)
+	}
+
+	result, checkDocs, pol, err := runScorecard(ctx, o)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(cfg.resultsFile)
+	if err != nil {
+		return fmt.Errorf("os.Create: %w", err)
+	}
+	defer f.Close()
+	if err := writeActionResults(f, cfg.resultsFormat, &result, checkDocs, pol, o); err != nil {
+		return err
+	}
+
+	if cfg.stepSummary != "" {
+		summary, err := os.OpenFile(cfg.stepSummary, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("os.OpenFile: %w", err)
+		}
+		defer summary.Close()
+		if err := writeStepSummary(summary, &result, checkDocs, cfg.resultsFile); err != nil {
+			return err
+		}
+	}
+
+	// Like the upload of the results, the job should not fail for a check
+	// which could not run, so runtime errors are only surfaced as warnings.
+	for _, check := range result.Checks {
+		if check.Error != nil {
+			fmt.Printf("::warning title=%s::%v\n", check.Name, check.Error)
+		}
+	}
+	return nil
+}
+
+func writeActionResults(w io.Writer, format string, result *pkg.ScorecardResult, checkDocs docs.Doc,
+	pol *policy.ScorecardPolicy, o *options.Options,
+) error {
+	logLevel := sclog.ParseLevel(o.LogLevel)
+	var err error
+	if format == options.FormatJSON {
+		err = result.AsJSON2(o.ShowDetails, o.ShowAnnotations, logLevel, checkDocs, w)
+	} else {
+		err = result.AsSARIF(o.ShowDetails, o.ShowAnnotations, logLevel, w, checkDocs, pol)
+	}
+	if err != nil {
+		return fmt.Errorf("writing results: %w", err)
+	}
+	return nil
+}
+
+// writeStepSummary writes the scores in markdown to the summary of the job.
+func writeStepSummary(w io.Writer, result *pkg.ScorecardResult, checkDocs docs.Doc, resultsFile string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## OpenSSF Scorecard for %s\n\n", result.Repo.Name)
+	score, err := publish.AggregateScore(result, checkDocs)
+	if err != nil {
+		return fmt.Errorf("publish.AggregateScore: %w", err)
+	}
+	fmt.Fprintf(&b, "Aggregate score: **%s**\n\n", score)
+	b.WriteString(publish.Table(result, checkDocs, true))
+	fmt.Fprintf(&b, "\nDetailed results were written to `%s`.\n", resultsFile)
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("io.WriteString: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	docs "github.com/ossf/scorecard/v4/docs/checks"
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+func Test_actionConfigFromEnv(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		env     map[string]string
+		wantErr error
+		name    string
+		want    actionConfig
+	}{
+		{
+			name: "defaults",
+			env: map[string]string{
+				"GITHUB_REPOSITORY":   "ossf/scorecard",
+				"GITHUB_REF":          "refs/heads/main",
+				"GITHUB_STEP_SUMMARY": "/tmp/summary.md",
+				"INPUT_REPO_TOKEN":    "token",
+			},
+			want: actionConfig{
+				repo:          "github.com/ossf/scorecard",
+				ref:           "refs/heads/main",
+				token:         "token",
+				resultsFile:   "results.sarif",
+				resultsFormat: "sarif",
+				stepSummary:   "/tmp/summary.md",
+			},
+		},
+		{
+			name: "inputs",
+			env: map[string]string{
+				"GITHUB_REPOSITORY":    "acme/widgets",
+				"GITHUB_SERVER_URL":    "https://github.example.com",
+				"INPUT_RESULTS_FILE":   "out/results.json",
+				"INPUT_RESULTS_FORMAT": "JSON",
+				"INPUT_POLICY_FILE":    "policy.yml",
+			},
+			want: actionConfig{
+				repo:          "github.example.com/acme/widgets",
+				resultsFile:   "out/results.json",
+				resultsFormat: "json",
+				policyFile:    "policy.yml",
+			},
+		},
+		{
+			name:    "not in actions",
+			env:     map[string]string{},
+			wantErr: errActionNoRepository,
+		},
+		{
+			name: "unsupported format",
+			env: map[string]string{
+				"GITHUB_REPOSITORY":    "ossf/scorecard",
+				"INPUT_RESULTS_FORMAT": "default",
+			},
+			wantErr: errActionFormat,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := actionConfigFromEnv(func(name string) string { return tt.env[name] })
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("actionConfigFromEnv() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(actionConfig{})); diff != "" {
+				t.Errorf("actionConfigFromEnv() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_writeStepSummary(t *testing.T) {
+	t.Parallel()
+	checkDocs, err := docs.Read()
+	if err != nil {
+		t.Fatalf("docs.Read: %v", err)
+	}
+	result := pkg.ScorecardResult{
+		Repo: pkg.RepoInfo{Name: "github.com/ossf/scorecard"},
+		Checks: []checker.CheckResult{
+			{Name: "Binary-Artifacts", Score: 10, Reason: "no binaries found in the repo"},
+			{Name: "Fuzzing", Score: checker.InconclusiveResultScore, Reason: "internal error | retry"},
+		},
+	}
+	var b bytes.Buffer
+	if err := writeStepSummary(&b, &result, checkDocs, "results.sarif"); err != nil {
+		t.Fatalf("writeStepSummary: %v", err)
+	}
+	for _, want := range []string{
+		"## OpenSSF Scorecard for github.com/ossf/scorecard",
+		"Aggregate score: **10.0 / 10**",
+		"[Binary-Artifacts](",
+		") | 10 / 10 | no binaries found in the repo |",
+		"| ? | internal error \\| retry |",
+		"`results.sarif`",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("writeStepSummary() = %s, want it to contain %q", b.String(), want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/digest"
+	"github.com/ossf/scorecard/v4/options"
+)
+
+var (
+	errCompareTooFewTargets = errors.New("at least two repos or results files must be compared")
+	errCompareFormat        = errors.New("unsupported format for compare, expected default or json")
+)
+
+// comparedTarget is the score of a compared repo and of each of its checks.
+type comparedTarget struct {
+	Checks map[string]int `json:"checks"`
+	Name   string         `json:"name"`
+	Score  float64        `json:"score"`
+}
+
+// comparison is the side-by-side matrix of the scores of the compared repos.
+type comparison struct {
+	Checks  []string         `json:"checks"`
+	Targets []comparedTarget `json:"targets"`
+}
+
+func compareCmd(o *options.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare <repo|results.json>...",
+		Short: "Compare the results of several repos side by side",
+		Long: `Compare renders the scores of several repos side by side, e.g. to choose
+between competing dependencies. Each argument is either a repo, which is
+scanned, or a file ending with .json holding results previously exported with
+--format=json, which is loaded instead of scanning the repo again.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errCompareTooFewTargets
+			}
+			return nil
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if o.Format != options.FormatDefault && o.Format != options.FormatJSON {
+				return errCompareFormat
+			}
+			cmd.SilenceUsage = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := runCompare(context.Background(), o, args)
+			if err != nil {
+				return err
+			}
+			return c.write(os.Stdout, o.Format)
+		},
+	}
+	cmd.Flags().StringVar(&o.Format, options.FlagFormat, o.Format,
+		"output format. allowed values are default and json")
+	cmd.Flags().StringSliceVar(&o.ChecksToRun, options.FlagChecks, o.ChecksToRun,
+		"checks to run when scanning repos, all checks by default")
+	return cmd
+}
+
+func runCompare(ctx context.Context, o *options.Options, args []string) (*comparison, error) {
+	targets := make([]comparedTarget, 0, len(args))
+	for _, arg := range args {
+		var (
+			target *comparedTarget
+			err    error
+		)
+		if strings.HasSuffix(arg, ".json") {
+			target, err = loadComparedTarget(arg)
+		} else {
+			target, err = scanComparedTarget(ctx, o, arg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", arg, err)
+		}
+		targets = append(targets, *target)
+	}
+	return newComparison(targets), nil
+}
+
+// loadComparedTarget reads results exported with --format=json.
+func loadComparedTarget(path string) (*comparedTarget, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+	result, err := digest.ParseResult(content)
+	if err != nil {
+		return nil, fmt.Errorf("ParseResult: %w", err)
+	}
+	target := comparedTarget{
+		Name:   result.Repo.Name,
+		Score:  result.Score,
+		Checks: map[string]int{},
+	}
+	if target.Name == "" {
+		target.Name = path
+	}
+	for _, c := range result.Checks {
+		target.Checks[c.Name] = c.Score
+	}
+	return &target, nil
+}
+
+func scanComparedTarget(ctx context.Context, o *options.Options, repo string) (*comparedTarget, error) {
+	to, err := targetOptions(o, &manifestTarget{Repo: repo, Checks: o.ChecksToRun})
+	if err != nil {
+		return nil, err
+	}
+	result, checkDocs, _, err := runScorecard(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	score, err := result.GetAggregateScore(checkDocs)
+	if err != nil {
+		return nil, fmt.Errorf("GetAggregateScore: %w", err)
+	}
+	target := comparedTarget{
+		Name:   result.Repo.Name,
+		Score:  score,
+		Checks: map[string]int{},
+	}
+	for i := range result.Checks {
+		target.Checks[result.Checks[i].Name] = result.Checks[i].Score
+	}
+	return &target, nil
+}
+
+// newComparison lists the checks run for any of the targets, sorted by name.
+func newComparison(targets []comparedTarget) *comparison {
+	names := map[string]bool{}
+	for i := range targets {
+		for name := range targets[i].Checks {
+			names[name] = true
+		}
+	}
+	c := comparison{Targets: targets}
+	for name := range names {
+		c.Checks = append(c.Checks, name)
+	}
+	sort.Strings(c.Checks)
+	return &c
+}
+
+// rows returns the matrix of scores, with one column per target. Checks not
+// run for a target are "-" and inconclusive scores are "?".
+func (c *comparison) rows() [][]string {
+	aggregate := []string{"Aggregate score"}
+	for i := range c.Targets {
+		s := c.Targets[i].Score
+		if s == checker.InconclusiveResultScore {
+			aggregate = append(aggregate, "?")
+			continue
+		}
+		aggregate = append(aggregate, fmt.Sprintf("%.1f", s))
+	}
+	rows := [][]string{aggregate}
+	for _, name := range c.Checks {
+		row := []string{name}
+		for i := range c.Targets {
+			s, ok := c.Targets[i].Checks[name]
+			switch {
+			case !ok:
+				row = append(row, "-")
+			case s == checker.InconclusiveResultScore:
+				row = append(row, "?")
+			default:
+				row = append(row, fmt.Sprintf("%d", s))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (c *comparison) write(w io.Writer, format string) error {
+	if format == options.FormatJSON {
+		if err := json.NewEncoder(w).Encode(c); err != nil {
+			return fmt.Errorf("encoder.Encode: %w", err)
+		}
+		return nil
+	}
+	header := []string{"Check"}
+	for i := range c.Targets {
+		header = append(header, c.Targets[i].Name)
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetRowSeparator("-")
+	table.SetCenterSeparator("|")
+	table.SetAutoFormatHeaders(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.AppendBulk(c.rows())
+	table.Render()
+	return nil
+}
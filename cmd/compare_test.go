@@ -0,0 +1,108 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/options"
+)
+
+func Test_loadComparedTarget(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "results.json")
+	content := `{"repo":{"name":"github.com/owner/lib-a"},"score":7.5,` +
+		`"checks":[{"name":"Binary-Artifacts","score":10},{"name":"Fuzzing","score":-1}]}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	got, err := loadComparedTarget(path)
+	if err != nil {
+		t.Fatalf("loadComparedTarget() error = %v", err)
+	}
+	want := &comparedTarget{
+		Name:  "github.com/owner/lib-a",
+		Score: 7.5,
+		Checks: map[string]int{
+			"Binary-Artifacts": 10,
+			"Fuzzing":          checker.InconclusiveResultScore,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("loadComparedTarget() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_comparison_rows(t *testing.T) {
+	t.Parallel()
+	c := newComparison([]comparedTarget{
+		{
+			Name:   "lib-a",
+			Score:  7.5,
+			Checks: map[string]int{"Fuzzing": 0, "Binary-Artifacts": 10},
+		},
+		{
+			Name:   "lib-b",
+			Score:  checker.InconclusiveResultScore,
+			Checks: map[string]int{"Fuzzing": checker.InconclusiveResultScore},
+		},
+	})
+	want := [][]string{
+		{"Aggregate score", "7.5", "?"},
+		{"Binary-Artifacts", "10", "-"},
+		{"Fuzzing", "0", "?"},
+	}
+	if diff := cmp.Diff(want, c.rows()); diff != "" {
+		t.Errorf("rows() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_comparison_write(t *testing.T) {
+	t.Parallel()
+	c := newComparison([]comparedTarget{
+		{Name: "lib-a", Score: 10, Checks: map[string]int{"Fuzzing": 10}},
+		{Name: "lib-b", Score: 0, Checks: map[string]int{"Fuzzing": 0}},
+	})
+
+	var table bytes.Buffer
+	if err := c.write(&table, options.FormatDefault); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+	for _, s := range []string{"lib-a", "lib-b", "Fuzzing", "Aggregate score"} {
+		if !strings.Contains(table.String(), s) {
+			t.Errorf("write() table = %q, want %q", table.String(), s)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := c.write(&out, options.FormatJSON); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+	var got comparison
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if diff := cmp.Diff(c, &got); diff != "" {
+		t.Errorf("write() JSON mismatch (-want +got):\n%s", diff)
+	}
+}
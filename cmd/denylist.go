@@ -0,0 +1,119 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/config"
+	sclog "github.com/ossf/scorecard/v4/log"
+)
+
+// denylistTimeout bounds fetching the denylist feed, after which the
+// snapshot is used instead.
+const denylistTimeout = 30 * time.Second
+
+// maxDenylistSize bounds the size of the denylist feed, so that a feed
+// serving an endless response does not exhaust the memory of the scan.
+const maxDenylistSize = 10 << 20
+
+var errDenylistFeed = errors.New("fetching the denylist feed failed")
+
+// readDenylist reads the denylist of --denylist, a file or a http(s) URL,
+// and returns its entries and the hash of its content. A feed fetched from
+// a URL is saved to snapshot, if set, and the snapshot is read instead if
+// the feed cannot be fetched, e.g. when offline.
+func readDenylist(ctx context.Context, logger *sclog.Logger, source, snapshot string,
+) ([]checker.DenylistEntry, string, error) {
+	if source == "" {
+		return nil, "", nil
+	}
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		content, err := os.ReadFile(source)
+		if err != nil {
+			return nil, "", fmt.Errorf("os.ReadFile: %w", err)
+		}
+		return parseDenylist(content)
+	}
+
+	content, err := fetchDenylist(ctx, source)
+	var entries []checker.DenylistEntry
+	var hash string
+	if err == nil {
+		entries, hash, err = parseDenylist(content)
+	}
+	switch {
+	case err == nil && snapshot != "":
+		if err := os.WriteFile(snapshot, content, 0o600); err != nil {
+			return nil, "", fmt.Errorf("os.WriteFile: %w", err)
+		}
+		return entries, hash, nil
+	case err == nil:
+		return entries, hash, nil
+	case snapshot == "":
+		return nil, "", err
+	}
+	logger.Info(fmt.Sprintf("reading the denylist from the snapshot %s: %v", snapshot, err))
+	content, err = os.ReadFile(snapshot)
+	if err != nil {
+		return nil, "", fmt.Errorf("os.ReadFile: %w", err)
+	}
+	return parseDenylist(content)
+}
+
+func fetchDenylist(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, denylistTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errDenylistFeed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s: %s", errDenylistFeed, url, resp.Status)
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxDenylistSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errDenylistFeed, err)
+	}
+	if len(content) > maxDenylistSize {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", errDenylistFeed, url, maxDenylistSize)
+	}
+	return content, nil
+}
+
+func parseDenylist(content []byte) ([]checker.DenylistEntry, string, error) {
+	entries, err := config.ParseDenylist(bytes.NewReader(content))
+	if err != nil {
+		return nil, "", fmt.Errorf("ParseDenylist: %w", err)
+	}
+	sum := sha256.Sum256(content)
+	return entries, hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sclog "github.com/ossf/scorecard/v4/log"
+)
+
+const testDenylist = `entries:
+  - id: ctx-2022
+    ecosystem: pypi
+    name: ctx
+`
+
+func TestReadDenylist(t *testing.T) {
+	t.Parallel()
+	feed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oversized.yml" {
+			w.Write(bytes.Repeat([]byte("#"), maxDenylistSize+1)) //nolint:errcheck
+			return
+		}
+		if r.URL.Path != "/denylist.yml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testDenylist)) //nolint:errcheck
+	}))
+	defer feed.Close()
+	logger := sclog.NewLogger(sclog.DefaultLevel)
+	dir := t.TempDir()
+	snapshot := filepath.Join(dir, "snapshot.yml")
+
+	// Fetching the feed saves the snapshot.
+	entries, hash, err := readDenylist(context.Background(), logger, feed.URL+"/denylist.yml", snapshot)
+	if err != nil {
+		t.Fatalf("readDenylist: %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != "ctx-2022" || hash == "" {
+		t.Errorf("readDenylist() = %v, %q", entries, hash)
+	}
+	content, err := os.ReadFile(snapshot)
+	if err != nil || string(content) != testDenylist {
+		t.Errorf("snapshot = %q, %v", content, err)
+	}
+
+	// The snapshot is read if the feed cannot be fetched.
+	entries, snapshotHash, err := readDenylist(context.Background(), logger, feed.URL+"/missing.yml", snapshot)
+	if err != nil {
+		t.Fatalf("readDenylist from the snapshot: %v", err)
+	}
+	if len(entries) != 1 || snapshotHash != hash {
+		t.Errorf("readDenylist() from the snapshot = %v, %q, want hash %q", entries, snapshotHash, hash)
+	}
+	if _, _, err := readDenylist(context.Background(), logger, feed.URL+"/missing.yml", ""); err == nil {
+		t.Error("readDenylist of a missing feed without snapshot: expected an error")
+	}
+	// So is it if the feed is too large.
+	entries, _, err = readDenylist(context.Background(), logger, feed.URL+"/oversized.yml", snapshot)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("readDenylist of an oversized feed from the snapshot = %v, %v", entries, err)
+	}
+	if _, _, err := readDenylist(context.Background(), logger, feed.URL+"/oversized.yml", ""); !errors.Is(err, errDenylistFeed) {
+		t.Errorf("readDenylist of an oversized feed without snapshot: error = %v, want %v", err, errDenylistFeed)
+	}
+
+	// Files are read as is.
+	entries, _, err = readDenylist(context.Background(), logger, snapshot, "")
+	if err != nil || len(entries) != 1 {
+		t.Errorf("readDenylist of a file = %v, %v", entries, err)
+	}
+	if entries, _, err := readDenylist(context.Background(), logger, "", ""); err != nil || entries != nil {
+		t.Errorf("readDenylist without denylist = %v, %v", entries, err)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v4/digest"
+)
+
+const (
+	digestFormatHTML = "html"
+	digestFormatAtom = "atom"
+)
+
+var (
+	errDigestFlagsMustBeSet = errors.New("`repos`, `results` and `snapshots` must be set")
+	errDigestFormat         = errors.New("unsupported format for digest")
+)
+
+type digestOptions struct {
+	repos     string
+	results   string
+	snapshots string
+	format    string
+	output    string
+	title     string
+	feedURL   string
+	dryRun    bool
+}
+
+func digestCmd() *cobra.Command {
+	o := digestOptions{
+		format: digestFormatHTML,
+		title:  "Scorecard weekly digest",
+	}
+	cmd := &cobra.Command{
+		Use:   "digest --repos=<file> --results=<bucket> --snapshots=<bucket>",
+		Short: "Summarize the changes of the results of a set of repos",
+		Long: `Digest compares the latest results of a list of repos with the results
+seen by the previous digest, and renders the score changes and new findings as
+an HTML page (e.g. to be emailed) or an Atom feed.
+
+The results bucket is laid out as exported by the cron job, with the latest
+results of each repo at <repo>/results.json. The snapshot bucket keeps the
+results seen by the previous digest and is updated after each run.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if o.repos == "" || o.results == "" || o.snapshots == "" {
+				return errDigestFlagsMustBeSet
+			}
+			if o.format != digestFormatHTML && o.format != digestFormatAtom {
+				return fmt.Errorf("%w: %s", errDigestFormat, o.format)
+			}
+			cmd.SilenceUsage = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w := os.Stdout
+			if o.output != "" {
+				f, err := os.Create(o.output)
+				if err != nil {
+					return fmt.Errorf("os.Create: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+			return runDigest(context.Background(), &o, w, time.Now())
+		},
+	}
+	cmd.Flags().StringVar(&o.repos, "repos", o.repos, "file listing the repos to summarize, one per line")
+	cmd.Flags().StringVar(&o.results, "results", o.results,
+		"bucket URL of the latest results, e.g. gs://bucket or file:///path/to/dir")
+	cmd.Flags().StringVar(&o.snapshots, "snapshots", o.snapshots,
+		"bucket URL keeping the results seen by the previous digest")
+	cmd.Flags().StringVar(&o.format, "format", o.format,
+		fmt.Sprintf("output format. Possible values are: %s, %s", digestFormatHTML, digestFormatAtom))
+	cmd.Flags().StringVar(&o.output, "output", o.output, "file to write the digest to, defaults to stdout")
+	cmd.Flags().StringVar(&o.title, "title", o.title, "title of the digest")
+	cmd.Flags().StringVar(&o.feedURL, "feed-url", o.feedURL, "URL the Atom feed is published at")
+	cmd.Flags().BoolVar(&o.dryRun, "dry-run", o.dryRun, "do not update the snapshots")
+	return cmd
+}
+
+func runDigest(ctx context.Context, o *digestOptions, w io.Writer, now time.Time) error {
+	f, err := os.Open(o.repos)
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+	repos, err := digest.ReadRepoList(f)
+	if err != nil {
+		return fmt.Errorf("ReadRepoList: %w", err)
+	}
+
+	d, snapshot, err := digest.Generate(ctx, o.results, o.snapshots, o.title, repos, now)
+	if err != nil {
+		return fmt.Errorf("Generate: %w", err)
+	}
+	switch o.format {
+	case digestFormatAtom:
+		err = d.WriteAtom(w, o.feedURL)
+	default:
+		err = d.WriteHTML(w)
+	}
+	if err != nil {
+		return fmt.Errorf("writing digest: %w", err)
+	}
+	if o.dryRun {
+		return nil
+	}
+	if err := snapshot.Save(ctx, o.snapshots); err != nil {
+		return fmt.Errorf("Snapshot.Save: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/languages"
+	sclog "github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/options"
+)
+
+var (
+	errDiscoverRepoOptionMustBeSet = errors.New("exactly one of `repo` or `local` must be set")
+	errDiscoverFormat              = errors.New("unsupported format for discover")
+)
+
+// subproject is a directory of the repo containing at least one project manifest.
+type subproject struct {
+	Path       string   `json:"path"`
+	Ecosystems []string `json:"ecosystems"`
+}
+
+func discoverCmd(o *options.Options) *cobra.Command {
+	var scan bool
+	cmd := &cobra.Command{
+		Use:   "discover (--repo=<repo> | --local=<folder>)",
+		Short: "Discover the subprojects of a monorepo",
+		Long: `Discover lists the directories of a repository which contain a project
+manifest (go.mod, package.json, pom.xml, ...), along with their ecosystems.
+
+With --scan, each subproject is scanned instead, with the files scanned scoped
+to its directory, like the targets of a manifest with a path.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if (o.Repo == "") == (o.Local == "") {
+				return errDiscoverRepoOptionMustBeSet
+			}
+			cmd.SilenceUsage = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiscover(o, scan, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&o.Repo, options.FlagRepo, o.Repo, "repository to discover subprojects of")
+	cmd.Flags().StringVar(&o.Local, options.FlagLocal, o.Local, "local folder to discover subprojects of")
+	cmd.Flags().StringVar(&o.Commit, options.FlagCommit, o.Commit, "commit to analyze")
+	cmd.Flags().StringVar(&o.Format, options.FlagFormat, o.Format,
+		fmt.Sprintf("output format. Possible values are: %s, %s", options.FormatDefault, options.FormatJSON))
+	cmd.Flags().BoolVar(&scan, "scan", scan, "scan each subproject, scoped to its directory")
+	cmd.Flags().StringSliceVar(&o.ChecksToRun, options.FlagChecks, o.ChecksToRun,
+		"checks to run with --scan, defaults to all the checks")
+	return cmd
+}
+
+func runDiscover(o *options.Options, scan bool, w io.Writer) error {
+	ctx := context.Background()
+	logger := sclog.NewLogger(sclog.ParseLevel(o.LogLevel))
+	repo, repoClient, ossFuzzRepoClient, _, _, err := checker.GetClients(ctx, o.Repo, o.Local, logger)
+	if err != nil {
+		return fmt.Errorf("GetClients: %w", err)
+	}
+	defer repoClient.Close()
+	if ossFuzzRepoClient != nil {
+		defer ossFuzzRepoClient.Close()
+	}
+
+	if err := repoClient.InitRepo(repo, o.Commit, o.CommitDepth); err != nil {
+		return fmt.Errorf("InitRepo: %w", err)
+	}
+	files, err := repoClient.ListFiles(isManifest)
+	if err != nil {
+		return fmt.Errorf("ListFiles: %w", err)
+	}
+	projects := discoverSubprojects(files)
+	if scan {
+		return scanManifest(ctx, o, subprojectsManifest(o, projects), "", "", "")
+	}
+
+	switch o.Format {
+	case options.FormatJSON:
+		encoder := json.NewEncoder(w)
+		if err := encoder.Encode(projects); err != nil {
+			return fmt.Errorf("encoder.Encode: %w", err)
+		}
+	case options.FormatDefault:
+		for _, p := range projects {
+			fmt.Fprintf(w, "%s\t%s\n", p.Path, strings.Join(p.Ecosystems, ","))
+		}
+	default:
+		return fmt.Errorf("%w: %s", errDiscoverFormat, o.Format)
+	}
+	return nil
+}
+
+// subprojectsManifest returns a manifest scanning each of the projects of the
+// repo of o, scoped to its directory.
+func subprojectsManifest(o *options.Options, projects []subproject) *manifest {
+	m := &manifest{Targets: make([]manifestTarget, 0, len(projects))}
+	for _, p := range projects {
+		t := manifestTarget{Repo: o.Repo, Local: o.Local, Commit: o.Commit, Checks: o.ChecksToRun}
+		if p.Path != "." {
+			t.Path = p.Path
+		}
+		m.Targets = append(m.Targets, t)
+	}
+	return m
+}
+
+func isManifest(fullpath string) (bool, error) {
+	_, ok := languages.EcosystemOf(fullpath)
+	return ok && !languages.IsVendored(fullpath), nil
+}
+
+// discoverSubprojects groups manifest files by directory. The repo root is
+// reported as ".".
+func discoverSubprojects(files []string) []subproject {
+	ecosystems := map[string]map[string]bool{}
+	for _, f := range files {
+		ecosystem, ok := languages.EcosystemOf(f)
+		if !ok {
+			continue
+		}
+		dir := path.Dir(f)
+		if ecosystems[dir] == nil {
+			ecosystems[dir] = map[string]bool{}
+		}
+		ecosystems[dir][string(ecosystem)] = true
+	}
+
+	projects := make([]subproject, 0, len(ecosystems))
+	for dir, set := range ecosystems {
+		p := subproject{Path: dir}
+		for e := range set {
+			p.Ecosystems = append(p.Ecosystems, e)
+		}
+		sort.Strings(p.Ecosystems)
+		projects = append(projects, p)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Path < projects[j].Path
+	})
+	return projects
+}
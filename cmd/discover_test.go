@@ -0,0 +1,116 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/options"
+)
+
+func Test_isManifest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "go.mod", want: true},
+		{path: "web/package.json", want: true},
+		{path: "services/api/pom.xml", want: true},
+		{path: "main.go", want: false},
+		{path: "web/node_modules/left-pad/package.json", want: false},
+		{path: "vendor/github.com/foo/bar/go.mod", want: false},
+		{path: "pkg/testdata/go.mod", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			got, err := isManifest(tt.path)
+			if err != nil {
+				t.Fatalf("isManifest() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("isManifest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_discoverSubprojects(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		files []string
+		want  []subproject
+	}{
+		{
+			name:  "no manifests",
+			files: []string{"README.md"},
+			want:  []subproject{},
+		},
+		{
+			name: "monorepo",
+			files: []string{
+				"go.mod",
+				"web/package.json",
+				"tools/setup.py",
+				"tools/pyproject.toml",
+				"android/build.gradle",
+				"android/app/build.gradle.kts",
+				"android/pom.xml",
+			},
+			want: []subproject{
+				{Path: ".", Ecosystems: []string{"go"}},
+				{Path: "android", Ecosystems: []string{"gradle", "maven"}},
+				{Path: "android/app", Ecosystems: []string{"gradle"}},
+				{Path: "tools", Ecosystems: []string{"pypi"}},
+				{Path: "web", Ecosystems: []string{"npm"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := discoverSubprojects(tt.files)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("discoverSubprojects() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_subprojectsManifest(t *testing.T) {
+	t.Parallel()
+	o := &options.Options{
+		Repo:        "github.com/ossf/scorecard",
+		Commit:      "HEAD",
+		ChecksToRun: []string{"Pinned-Dependencies"},
+	}
+	projects := []subproject{
+		{Path: ".", Ecosystems: []string{"go"}},
+		{Path: "tools", Ecosystems: []string{"go"}},
+	}
+	want := &manifest{Targets: []manifestTarget{
+		{Repo: "github.com/ossf/scorecard", Commit: "HEAD", Checks: []string{"Pinned-Dependencies"}},
+		{Repo: "github.com/ossf/scorecard", Commit: "HEAD", Checks: []string{"Pinned-Dependencies"}, Path: "tools"},
+	}}
+	if diff := cmp.Diff(want, subprojectsManifest(o, projects)); diff != "" {
+		t.Errorf("subprojectsManifest() mismatch (-want +got):\n%s", diff)
+	}
+}
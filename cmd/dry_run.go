@@ -0,0 +1,74 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	ghrepo "github.com/ossf/scorecard/v4/clients/githubrepo"
+	glrepo "github.com/ossf/scorecard/v4/clients/gitlabrepo"
+	"github.com/ossf/scorecard/v4/clients/localdir"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+// dryRun writes the requests the enabled checks would make to scan repoURI,
+// or the local folder, to w instead of running them.
+func dryRun(w io.Writer, o *options.Options, repoURI, local string,
+	enabledChecks checker.CheckNameToFnMap,
+) error {
+	repo, err := dryRunRepo(repoURI, local)
+	if err != nil {
+		return err
+	}
+	plan := pkg.PlanDryRun(repo, o.CommitDepth, o.Shallow, enabledChecks)
+	if len(o.Mirrors) > 0 {
+		plan.Warnings = append(plan.Warnings,
+			fmt.Sprintf("the %d mirrors are scanned with the same checks, with the clients of their forges", len(o.Mirrors)))
+	}
+	if o.Format == options.FormatJSON {
+		if err := json.NewEncoder(w).Encode(plan); err != nil {
+			return fmt.Errorf("json.Encode: %w", err)
+		}
+		return nil
+	}
+	if err := plan.Write(w); err != nil {
+		return fmt.Errorf("Write: %w", err)
+	}
+	return nil
+}
+
+// dryRunRepo returns the repo checker.GetClients would scan, without
+// creating its clients.
+func dryRunRepo(repoURI, local string) (clients.Repo, error) {
+	var repo clients.Repo
+	var err error
+	switch {
+	case local != "":
+		repo, err = localdir.MakeLocalDirRepo(local)
+	case checker.IsExperimentalEnabled() && glrepo.DetectGitLab(repoURI):
+		repo, err = glrepo.MakeGitlabRepo(repoURI)
+	default:
+		repo, err = ghrepo.MakeGithubRepo(repoURI)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("making repo: %w", err)
+	}
+	return repo, nil
+}
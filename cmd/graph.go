@@ -0,0 +1,135 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/graph"
+	sclog "github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/options"
+)
+
+const (
+	graphFormatDOT  = "dot"
+	graphFormatJSON = "json"
+)
+
+var (
+	errGraphRepoOptionMustBeSet = errors.New("exactly one of `repo` or `local` must be set")
+	errGraphFormat              = errors.New("unsupported format for graph, expected dot or json")
+)
+
+type graphOptions struct {
+	format string
+	output string
+	scores bool
+}
+
+func graphCmd(o *options.Options) *cobra.Command {
+	g := graphOptions{
+		format: graphFormatDOT,
+		scores: true,
+	}
+	cmd := &cobra.Command{
+		Use:   "graph (--repo=<repo> | --local=<folder>)",
+		Short: "Graph the trust relationships of the CI of a repo",
+		Long: `Graph emits the trust graph of the CI of a repository: its workflows, the
+actions and reusable workflows their jobs use, whether those are pinned to a
+commit, and the repos of the actions along with their Scorecard scores.
+
+The graph is written in the DOT language of Graphviz, e.g. to be rendered
+with "dot -Tsvg", or as JSON. The repos of the actions are scored with the
+checks selected by --checks, unless --scores=false.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if (o.Repo == "") == (o.Local == "") {
+				return errGraphRepoOptionMustBeSet
+			}
+			if g.format != graphFormatDOT && g.format != graphFormatJSON {
+				return fmt.Errorf("%w: %s", errGraphFormat, g.format)
+			}
+			cmd.SilenceUsage = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w := os.Stdout
+			if g.output != "" {
+				f, err := os.Create(g.output)
+				if err != nil {
+					return fmt.Errorf("os.Create: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+			return runGraph(context.Background(), o, &g, w)
+		},
+	}
+	cmd.Flags().StringVar(&o.Repo, options.FlagRepo, o.Repo, "repository to graph the CI of")
+	cmd.Flags().StringVar(&o.Local, options.FlagLocal, o.Local, "local folder to graph the CI of")
+	cmd.Flags().StringVar(&o.Commit, options.FlagCommit, o.Commit, "commit to analyze")
+	cmd.Flags().StringSliceVar(&o.ChecksToRun, options.FlagChecks, o.ChecksToRun,
+		"checks to score the repos of the actions with, defaults to all checks")
+	cmd.Flags().StringVar(&g.format, "format", g.format, "output format. allowed values are dot and json")
+	cmd.Flags().StringVar(&g.output, "output", g.output, "file to write the graph to, defaults to stdout")
+	cmd.Flags().BoolVar(&g.scores, "scores", g.scores, "score the repos of the actions")
+	return cmd
+}
+
+func runGraph(ctx context.Context, o *options.Options, g *graphOptions, w io.Writer) error {
+	logger := sclog.NewLogger(sclog.ParseLevel(o.LogLevel))
+	repo, repoClient, ossFuzzRepoClient, _, _, err := checker.GetClients(ctx, o.Repo, o.Local, logger)
+	if err != nil {
+		return fmt.Errorf("GetClients: %w", err)
+	}
+	defer repoClient.Close()
+	if ossFuzzRepoClient != nil {
+		defer ossFuzzRepoClient.Close()
+	}
+
+	if err := repoClient.InitRepo(repo, o.Commit, o.CommitDepth); err != nil {
+		return fmt.Errorf("InitRepo: %w", err)
+	}
+	trust, err := graph.Build(repo.URI(), repoClient)
+	if err != nil {
+		return fmt.Errorf("graph.Build: %w", err)
+	}
+	if g.scores {
+		trust.Score(func(name string) (float64, error) {
+			target, err := scanComparedTarget(ctx, o, name)
+			if err != nil {
+				logger.Info(fmt.Sprintf("scoring %s: %v", name, err))
+				return 0, err
+			}
+			return target.Score, nil
+		})
+	}
+
+	if g.format == graphFormatJSON {
+		err = trust.WriteJSON(w)
+	} else {
+		err = trust.WriteDOT(w)
+	}
+	if err != nil {
+		return fmt.Errorf("writing graph: %w", err)
+	}
+	return nil
+}
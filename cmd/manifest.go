@@ -0,0 +1,346 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sclog "github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/pkg/format"
+)
+
+var (
+	errManifestFileMustBeSet = errors.New("`file` must be set")
+	errManifestNoTargets     = errors.New("manifest has no targets")
+	errManifestFormat        = errors.New("unsupported format for manifests, expected default or json")
+	errManifestTargetsFailed = errors.New("some targets could not be scanned")
+	errManifestInterrupted   = errors.New("scan interrupted")
+)
+
+// manifest lists the targets scanned by a single invocation.
+type manifest struct {
+	Targets []manifestTarget `yaml:"targets"`
+}
+
+// manifestTarget is a target of a manifest. Exactly one of Repo, Local, NPM,
+// PyPI, RubyGems, Cargo and Maven must be set, which selects the client used
+// to scan it.
+type manifestTarget struct {
+	Repo     string   `yaml:"repo"`
+	Local    string   `yaml:"local"`
+	NPM      string   `yaml:"npm"`
+	PyPI     string   `yaml:"pypi"`
+	RubyGems string   `yaml:"rubygems"`
+	Cargo    string   `yaml:"cargo"`
+	Maven    string   `yaml:"maven"`
+	Commit   string   `yaml:"commit"`
+	Path     string   `yaml:"path"`
+	Checks   []string `yaml:"checks"`
+	Policy   string   `yaml:"policy"`
+	Preset   string   `yaml:"preset"`
+}
+
+func (t *manifestTarget) name() string {
+	for _, name := range []string{t.Repo, t.Local, t.NPM, t.PyPI, t.RubyGems, t.Cargo, t.Maven} {
+		if name != "" {
+			if t.Path != "" {
+				return fmt.Sprintf("%s (%s)", name, t.Path)
+			}
+			return name
+		}
+	}
+	return ""
+}
+
+func manifestCmd(o *options.Options) *cobra.Command {
+	var file, resumeFile, activityFile string
+	cmd := &cobra.Command{
+		Use:   "manifest --file=<manifest.yaml>",
+		Short: "Scan the targets listed in a manifest",
+		Long: `Manifest scans all the targets listed in a YAML manifest in one invocation.
+Each target is a repo, local folder or package with its own commit, path scope,
+checks and policy. The results of all the targets are printed one after the
+other, as one JSON document per line with --format=json.
+
+With --resume, the targets scanned without errors are recorded in a state file,
+so that a scan interrupted by a signal or by the exhausted rate limit of the API
+continues where it left off when run again with the same --resume file.
+
+With --skip-unchanged, the last push to the GitHub repos scanned at their HEAD
+is recorded in a state file. The repos are first queried in batches, and the
+ones which are archived or were not pushed to since their last complete scan
+are skipped, with "skipped: unchanged" or "skipped: archived" as their results.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if file == "" {
+				return errManifestFileMustBeSet
+			}
+			if o.Format != options.FormatDefault && o.Format != options.FormatJSON {
+				return errManifestFormat
+			}
+			cmd.SilenceUsage = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runManifest(context.Background(), o, file, resumeFile, activityFile)
+		},
+	}
+	cmd.Flags().StringVar(&file, "file", "", "manifest listing the targets to scan")
+	cmd.Flags().StringVar(&resumeFile, "resume", "",
+		"state file recording the completed targets, to continue an interrupted scan")
+	cmd.Flags().StringVar(&activityFile, "skip-unchanged", "",
+		"state file recording the last push to the repos, to skip the archived repos and those not pushed to since")
+	cmd.Flags().StringVar(&o.Format, options.FlagFormat, o.Format,
+		"output format. allowed values are default and json")
+	cmd.Flags().BoolVar(&o.ShowDetails, options.FlagShowDetails, o.ShowDetails, "show extra details about each check")
+	return cmd
+}
+
+func readManifest(path string) (*manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+	return parseManifest(f, filepath.Dir(path))
+}
+
+// parseManifest parses a manifest. Relative local folders and policy files
+// are resolved from dir, the folder of the manifest.
+func parseManifest(r io.Reader, dir string) (*manifest, error) {
+	var m manifest
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&m); err != nil {
+		return nil, fmt.Errorf("yaml.Decode: %w", err)
+	}
+	if len(m.Targets) == 0 {
+		return nil, errManifestNoTargets
+	}
+	for i := range m.Targets {
+		t := &m.Targets[i]
+		if t.Local != "" && !filepath.IsAbs(t.Local) {
+			t.Local = filepath.Join(dir, t.Local)
+		}
+		if t.Policy != "" && !filepath.IsAbs(t.Policy) {
+			t.Policy = filepath.Join(dir, t.Policy)
+		}
+	}
+	return &m, nil
+}
+
+// targetOptions returns the options to scan t with, inheriting the output
+// and feature flag options from o.
+func targetOptions(o *options.Options, t *manifestTarget) (*options.Options, error) {
+	ret := *o
+	ret.Repo, ret.Local, ret.NPM, ret.PyPI, ret.RubyGems = t.Repo, t.Local, t.NPM, t.PyPI, t.RubyGems
+	ret.Cargo, ret.Maven = t.Cargo, t.Maven
+	ret.Commit = t.Commit
+	if ret.Commit == "" {
+		ret.Commit = options.DefaultCommit
+	}
+	ret.PathScope = t.Path
+	ret.ChecksToRun = t.Checks
+	ret.PolicyFile = t.Policy
+	if t.Preset != "" {
+		ret.Preset = t.Preset
+	}
+	ret.Mirrors = nil
+	ret.Wiki = false
+	if err := ret.Validate(); err != nil {
+		return nil, fmt.Errorf("validating options: %w", err)
+	}
+	return &ret, nil
+}
+
+func runManifest(ctx context.Context, o *options.Options, file, resumeFile, activityFile string) error {
+	m, err := readManifest(file)
+	if err != nil {
+		return fmt.Errorf("readManifest: %w", err)
+	}
+	manifestHash, err := hashFile(file)
+	if err != nil {
+		return fmt.Errorf("hashFile: %w", err)
+	}
+	return scanManifest(ctx, o, m, manifestHash, resumeFile, activityFile)
+}
+
+// scanManifest scans the targets of m one after the other. manifestHash
+// identifies the targets in the resume state.
+//
+//nolint:gocognit
+func scanManifest(ctx context.Context, o *options.Options, m *manifest,
+	manifestHash, resumeFile, activityFile string,
+) error {
+	state, err := loadResumeState(resumeFile, manifestHash, o.Format)
+	if err != nil {
+		return fmt.Errorf("loadResumeState: %w", err)
+	}
+	var activity *activityState
+	if activityFile != "" {
+		logger := sclog.NewLogger(sclog.ParseLevel(o.LogLevel))
+		activity, err = loadActivityState(ctx, activityFile, m, logger)
+		if err != nil {
+			return fmt.Errorf("loadActivityState: %w", err)
+		}
+	}
+
+	// Interrupted scans stop at the current target, whose partial results
+	// are not recorded, so that --resume scans it again.
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	type targetScore struct {
+		err     error
+		name    string
+		skipped string
+		score   float64
+	}
+	scores := make([]targetScore, 0, len(m.Targets))
+	failed, incomplete := 0, 0
+	for i := range m.Targets {
+		t := &m.Targets[i]
+		if done, ok := state.find(i, t.name()); ok {
+			fmt.Print(done.Output)
+			scores = append(scores, targetScore{name: t.name(), score: done.Score})
+			continue
+		}
+		if ctx.Err() != nil {
+			return interruptedError(resumeFile)
+		}
+		if activity != nil {
+			if reason := activity.skipReason(t); reason != "" {
+				fmt.Print(skippedOutput(o.Format, t.name(), reason))
+				scores = append(scores, targetScore{name: t.name(), skipped: reason})
+				continue
+			}
+		}
+
+		output, score, complete, err := scanTarget(ctx, o, t)
+		fmt.Print(output)
+		if ctx.Err() != nil {
+			return interruptedError(resumeFile)
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "error scanning %s: %v\n", t.name(), err)
+		}
+		if err == nil && !complete {
+			incomplete++
+		}
+		if err == nil && complete && activity != nil {
+			activity.scanned(t)
+			if err := activity.save(activityFile); err != nil {
+				return fmt.Errorf("saving activity state: %w", err)
+			}
+		}
+		if err == nil && complete && resumeFile != "" {
+			state.Completed = append(state.Completed, completedTarget{
+				Index:  i,
+				Name:   t.name(),
+				Score:  score,
+				Output: output,
+			})
+			if err := state.save(resumeFile); err != nil {
+				return fmt.Errorf("saving resume state: %w", err)
+			}
+		}
+		scores = append(scores, targetScore{name: t.name(), score: score, err: err})
+	}
+
+	if o.Format == options.FormatDefault {
+		fmt.Println("\nSUMMARY\n-------")
+		for _, s := range scores {
+			switch {
+			case s.err != nil:
+				fmt.Printf("%s: error\n", s.name)
+			case s.skipped != "":
+				fmt.Printf("%s: skipped (%s)\n", s.name, s.skipped)
+			case s.score == checker.InconclusiveResultScore:
+				fmt.Printf("%s: ?\n", s.name)
+			default:
+				fmt.Printf("%s: %.1f / %d\n", s.name, s.score, checker.MaxResultScore)
+			}
+		}
+	}
+	if resumeFile != "" {
+		if failed == 0 && incomplete == 0 {
+			// Nothing is left to resume.
+			if err := os.Remove(resumeFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("os.Remove: %w", err)
+			}
+		} else {
+			fmt.Fprintf(os.Stderr, "%d targets with errors are scanned again with --resume=%s\n",
+				failed+incomplete, resumeFile)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errManifestTargetsFailed, failed, len(m.Targets))
+	}
+	return nil
+}
+
+func interruptedError(resumeFile string) error {
+	if resumeFile == "" {
+		return errManifestInterrupted
+	}
+	return fmt.Errorf("%w: continue with --resume=%s", errManifestInterrupted, resumeFile)
+}
+
+// scanTarget scans t and returns its formatted results and aggregate score.
+// Runtime errors of the checks are part of the results, like for a single
+// target, and only make the scan incomplete, e.g. when the rate limit of the
+// API was exhausted.
+func scanTarget(ctx context.Context, o *options.Options, t *manifestTarget) (string, float64, bool, error) {
+	to, err := targetOptions(o, t)
+	if err != nil {
+		return "", 0, false, err
+	}
+	result, checkDocs, pol, err := runScorecard(ctx, to)
+	if err != nil {
+		return "", 0, false, err
+	}
+	var b strings.Builder
+	if to.Format == options.FormatDefault {
+		fmt.Fprintf(&b, "\nRESULTS for %s\n-------\n", t.name())
+	}
+	if err := format.Write(&b, to, &result, checkDocs, pol); err != nil {
+		return "", 0, false, fmt.Errorf("failed to format results: %w", err)
+	}
+	score, err := result.GetAggregateScore(checkDocs)
+	if err != nil {
+		return "", 0, false, fmt.Errorf("GetAggregateScore: %w", err)
+	}
+	complete := true
+	for i := range result.Checks {
+		if result.Checks[i].Error != nil {
+			complete = false
+		}
+	}
+	return b.String(), score, complete, nil
+}
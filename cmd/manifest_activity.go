@@ -0,0 +1,138 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/githubrepo"
+	sclog "github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/options"
+)
+
+// Reasons targets are not scanned by manifests with --skip-unchanged.
+const (
+	skippedUnchanged = "unchanged"
+	skippedArchived  = "archived"
+)
+
+// fetchRepoActivity is replaced by tests.
+var fetchRepoActivity = githubrepo.FetchRepoActivity
+
+// activityState records the last push to the repos of a manifest when they
+// were last scanned completely, by target name.
+type activityState struct {
+	PushedAt map[string]time.Time `json:"pushedAt"`
+	// activity is the current activity of the targets, by repo.
+	activity map[string]githubrepo.RepoActivity
+}
+
+// loadActivityState reads the state at path, or returns an empty state if
+// path does not exist yet. The activity of the GitHub repos of m at their
+// HEAD is fetched in batches.
+func loadActivityState(ctx context.Context, path string, m *manifest, logger *sclog.Logger,
+) (*activityState, error) {
+	state := &activityState{PushedAt: map[string]time.Time{}}
+	content, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	default:
+		if err := json.Unmarshal(content, state); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+		if state.PushedAt == nil {
+			state.PushedAt = map[string]time.Time{}
+		}
+	}
+
+	var repos []string
+	for i := range m.Targets {
+		if t := &m.Targets[i]; isHeadRepo(t) {
+			repos = append(repos, t.Repo)
+		}
+	}
+	if len(repos) == 0 {
+		return state, nil
+	}
+	state.activity, err = fetchRepoActivity(ctx, logger, repos)
+	if err != nil {
+		// Targets whose activity is unknown are scanned.
+		logger.Info(fmt.Sprintf("fetching the activity of the repos: %v", err))
+	}
+	return state, nil
+}
+
+// isHeadRepo returns true if t is the HEAD of a repo.
+func isHeadRepo(t *manifestTarget) bool {
+	return t.Repo != "" && (t.Commit == "" || t.Commit == clients.HeadSHA)
+}
+
+// skipReason returns why t needn't be scanned, or "" if it must be.
+func (s *activityState) skipReason(t *manifestTarget) string {
+	if !isHeadRepo(t) {
+		return ""
+	}
+	activity, ok := s.activity[t.Repo]
+	switch {
+	case !ok:
+		return ""
+	case activity.Archived:
+		return skippedArchived
+	}
+	scanned, ok := s.PushedAt[t.name()]
+	if ok && !activity.PushedAt.IsZero() && !activity.PushedAt.After(scanned) {
+		return skippedUnchanged
+	}
+	return ""
+}
+
+// scanned records that t was scanned completely.
+func (s *activityState) scanned(t *manifestTarget) {
+	if activity, ok := s.activity[t.Repo]; ok && isHeadRepo(t) && !activity.PushedAt.IsZero() {
+		s.PushedAt[t.name()] = activity.PushedAt
+	}
+}
+
+// save writes the state to path.
+func (s *activityState) save(path string) error {
+	content, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	return writeFileAtomic(path, content)
+}
+
+// skippedOutput is the output of a target which was not scanned.
+func skippedOutput(format, name, reason string) string {
+	if format == options.FormatJSON {
+		content, err := json.Marshal(map[string]interface{}{
+			"repo":    map[string]string{"name": name},
+			"skipped": reason,
+		})
+		if err == nil {
+			return string(content) + "\n"
+		}
+	}
+	return fmt.Sprintf("\nRESULTS for %s\n-------\nskipped: %s\n", name, reason)
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ossf/scorecard/v4/clients/githubrepo"
+	sclog "github.com/ossf/scorecard/v4/log"
+)
+
+//nolint:paralleltest // fetchRepoActivity is replaced.
+func TestActivityState(t *testing.T) {
+	lastPush := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	var fetched []string
+	fetchRepoActivity = func(ctx context.Context, logger *sclog.Logger, repos []string,
+	) (map[string]githubrepo.RepoActivity, error) {
+		fetched = repos
+		return map[string]githubrepo.RepoActivity{
+			"github.com/owner/active":   {PushedAt: lastPush},
+			"github.com/owner/archived": {PushedAt: lastPush, Archived: true},
+		}, nil
+	}
+	defer func() { fetchRepoActivity = githubrepo.FetchRepoActivity }()
+
+	m := &manifest{Targets: []manifestTarget{
+		{Repo: "github.com/owner/active"},
+		{Repo: "github.com/owner/archived"},
+		{Repo: "github.com/owner/active", Commit: "abcdef"},
+		{Local: "/src"},
+	}}
+	path := filepath.Join(t.TempDir(), "activity.json")
+	logger := sclog.NewLogger(sclog.InfoLevel)
+	state, err := loadActivityState(context.Background(), path, m, logger)
+	if err != nil {
+		t.Fatalf("loadActivityState: %v", err)
+	}
+	if len(fetched) != 2 {
+		t.Errorf("fetched the activity of %v, want the repos at HEAD", fetched)
+	}
+	wantReasons := []string{"", skippedArchived, "", ""}
+	for i, want := range wantReasons {
+		if got := state.skipReason(&m.Targets[i]); got != want {
+			t.Errorf("skipReason(%s) = %q, want %q", m.Targets[i].name(), got, want)
+		}
+	}
+
+	state.scanned(&m.Targets[0])
+	if err := state.save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	state, err = loadActivityState(context.Background(), path, m, logger)
+	if err != nil {
+		t.Fatalf("loadActivityState: %v", err)
+	}
+	if got := state.skipReason(&m.Targets[0]); got != skippedUnchanged {
+		t.Errorf("skipReason() of a repo not pushed to since = %q, want %q", got, skippedUnchanged)
+	}
+	state.activity["github.com/owner/active"] = githubrepo.RepoActivity{PushedAt: lastPush.Add(time.Hour)}
+	if got := state.skipReason(&m.Targets[0]); got != "" {
+		t.Errorf("skipReason() of a repo pushed to since = %q, want none", got)
+	}
+}
+
+func Test_skippedOutput(t *testing.T) {
+	t.Parallel()
+	if got, want := skippedOutput("json", "github.com/owner/repo", skippedUnchanged),
+		`{"repo":{"name":"github.com/owner/repo"},"skipped":"unchanged"}`+"\n"; got != want {
+		t.Errorf("skippedOutput(json) = %q, want %q", got, want)
+	}
+	if got, want := skippedOutput("default", "github.com/owner/repo", skippedArchived),
+		"\nRESULTS for github.com/owner/repo\n-------\nskipped: archived\n"; got != want {
+		t.Errorf("skippedOutput(default) = %q, want %q", got, want)
+	}
+}
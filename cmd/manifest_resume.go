@@ -0,0 +1,108 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+var errResumeStateMismatch = errors.New("resume state was recorded for another manifest or format")
+
+// resumeState records the targets of a manifest scanned so far.
+type resumeState struct {
+	// Manifest is the SHA-256 of the manifest the state was recorded for.
+	Manifest  string            `json:"manifest"`
+	Format    string            `json:"format"`
+	Completed []completedTarget `json:"completed"`
+}
+
+// completedTarget is a target scanned without errors, with its formatted
+// results, so that resumed scans output the results of all the targets.
+type completedTarget struct {
+	Index  int     `json:"index"`
+	Name   string  `json:"name"`
+	Score  float64 `json:"score"`
+	Output string  `json:"output"`
+}
+
+// loadResumeState reads the state at path, or returns an empty state if path
+// is empty or does not exist yet.
+func loadResumeState(path, manifestHash, format string) (*resumeState, error) {
+	state := &resumeState{Manifest: manifestHash, Format: format}
+	if path == "" {
+		return state, nil
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+	var saved resumeState
+	if err := json.Unmarshal(content, &saved); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	if saved.Manifest != manifestHash || saved.Format != format {
+		return nil, fmt.Errorf("%w: %s", errResumeStateMismatch, path)
+	}
+	return &saved, nil
+}
+
+// find returns the target at index if it was completed, as long as the
+// manifest still names it the same way.
+func (s *resumeState) find(index int, name string) (completedTarget, bool) {
+	for _, c := range s.Completed {
+		if c.Index == index && c.Name == name {
+			return c, true
+		}
+	}
+	return completedTarget{}, false
+}
+
+// save writes the state to path atomically, so that an interruption never
+// leaves a truncated state behind.
+func (s *resumeState) save(path string) error {
+	content, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	return writeFileAtomic(path, content)
+}
+
+// writeFileAtomic replaces the file at path with content.
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("os.CreateTemp: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("tmp.Write: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("tmp.Close: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("os.Rename: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResumeState(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	state, err := loadResumeState(path, "hash", "json")
+	if err != nil {
+		t.Fatalf("loadResumeState() of a missing file: %v", err)
+	}
+	if len(state.Completed) != 0 {
+		t.Fatalf("expected an empty state, got %v", state)
+	}
+
+	state.Completed = append(state.Completed, completedTarget{
+		Index:  1,
+		Name:   "github.com/ossf/scorecard",
+		Score:  7.5,
+		Output: "{}\n",
+	})
+	if err := state.save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	got, err := loadResumeState(path, "hash", "json")
+	if err != nil {
+		t.Fatalf("loadResumeState: %v", err)
+	}
+	if diff := cmp.Diff(state, got); diff != "" {
+		t.Errorf("loadResumeState() mismatch (-want +got):\n%s", diff)
+	}
+	if _, ok := got.find(1, "github.com/ossf/scorecard"); !ok {
+		t.Error("expected the completed target to be found")
+	}
+	if _, ok := got.find(1, "github.com/ossf/other"); ok {
+		t.Error("expected a renamed target not to be found")
+	}
+
+	if _, err := loadResumeState(path, "other-hash", "json"); !errors.Is(err, errResumeStateMismatch) {
+		t.Errorf("loadResumeState() for another manifest = %v, want %v", err, errResumeStateMismatch)
+	}
+	if _, err := loadResumeState(path, "hash", "default"); !errors.Is(err, errResumeStateMismatch) {
+		t.Errorf("loadResumeState() for another format = %v, want %v", err, errResumeStateMismatch)
+	}
+}
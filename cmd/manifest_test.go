@@ -0,0 +1,122 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/options"
+)
+
+func Test_parseManifest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		want    *manifest
+		wantErr bool
+	}{
+		{
+			name: "heterogeneous targets",
+			content: `targets:
+- repo: github.com/ossf/scorecard
+  commit: 7b5a6b0f
+  path: cron
+  checks: [Binary-Artifacts, Pinned-Dependencies]
+  policy: policy.yaml
+- local: src
+- npm: left-pad
+`,
+			want: &manifest{
+				Targets: []manifestTarget{
+					{
+						Repo:   "github.com/ossf/scorecard",
+						Commit: "7b5a6b0f",
+						Path:   "cron",
+						Checks: []string{"Binary-Artifacts", "Pinned-Dependencies"},
+						Policy: filepath.Join("manifests", "policy.yaml"),
+					},
+					{Local: filepath.Join("manifests", "src")},
+					{NPM: "left-pad"},
+				},
+			},
+		},
+		{
+			name:    "unknown key",
+			content: "targets:\n- repository: github.com/ossf/scorecard\n",
+			wantErr: true,
+		},
+		{
+			name:    "no targets",
+			content: "targets: []\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseManifest(strings.NewReader(tt.content), "manifests")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(manifest{})); diff != "" {
+				t.Errorf("parseManifest() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_targetOptions(t *testing.T) {
+	t.Parallel()
+	o := &options.Options{
+		Format:      options.FormatJSON,
+		LogLevel:    options.DefaultLogLevel,
+		Mirrors:     []string{"gitlab.com/ossf/scorecard"},
+		ChecksToRun: []string{"License"},
+		ShowDetails: true,
+	}
+	got, err := targetOptions(o, &manifestTarget{Repo: "github.com/ossf/scorecard", Path: "cron"})
+	if err != nil {
+		t.Fatalf("targetOptions: %v", err)
+	}
+	if got.Repo != "github.com/ossf/scorecard" || got.Commit != options.DefaultCommit ||
+		got.PathScope != "cron" || got.ChecksToRun != nil || got.Mirrors != nil ||
+		got.Format != options.FormatJSON || !got.ShowDetails {
+		t.Errorf("targetOptions() = %+v", got)
+	}
+
+	_, err = targetOptions(o, &manifestTarget{Repo: "github.com/ossf/scorecard", NPM: "left-pad"})
+	if err == nil {
+		t.Error("expected an error for a target with two clients")
+	}
+}
+
+func Test_runManifestFormat(t *testing.T) {
+	t.Parallel()
+	o := &options.Options{Format: options.FormatSarif}
+	cmd := manifestCmd(o)
+	if err := cmd.Flags().Set("file", "manifest.yaml"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.PreRunE(cmd, nil); !errors.Is(err, errManifestFormat) {
+		t.Errorf("PreRunE() = %v, want %v", err, errManifestFormat)
+	}
+}
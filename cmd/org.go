@@ -0,0 +1,148 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	ghrepo "github.com/ossf/scorecard/v4/clients/githubrepo"
+	sclog "github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/options"
+)
+
+var errOrgNoRepos = errors.New("no repos of the organization match the filters")
+
+// listOwnerRepos is replaced by tests.
+var listOwnerRepos = ghrepo.ListOwnerRepos
+
+// orgFilter selects the repos of an organization to scan.
+type orgFilter struct {
+	// include matches the names of the repos to scan, without their owner.
+	include         *regexp.Regexp
+	excludeArchived bool
+	minStars        int
+}
+
+func (f *orgFilter) matches(r *ghrepo.OwnerRepo) bool {
+	name := r.Name[strings.LastIndex(r.Name, "/")+1:]
+	if f.include != nil && !f.include.MatchString(name) {
+		return false
+	}
+	if f.excludeArchived && r.Archived {
+		return false
+	}
+	return r.Stars >= f.minStars
+}
+
+func orgCmd(o *options.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "org",
+		Short: "Scan the repos of a GitHub organization",
+	}
+	cmd.AddCommand(orgScanCmd(o))
+	return cmd
+}
+
+func orgScanCmd(o *options.Options) *cobra.Command {
+	var include, resumeFile, activityFile string
+	var filter orgFilter
+	cmd := &cobra.Command{
+		Use:   "scan <org>",
+		Short: "Scan the repos of a GitHub organization or user",
+		Long: `Scan lists the repos of a GitHub organization or user and scans the ones
+matching the filters at their HEAD, one after the other, like the targets of a
+manifest. E.g. to scan the active services with at least 10 stars:
+
+  scorecard org scan myorg --include="^svc-" --exclude-archived --min-stars=10
+
+--resume and --skip-unchanged work like for manifests.`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if o.Format != options.FormatDefault && o.Format != options.FormatJSON {
+				return errManifestFormat
+			}
+			if include != "" {
+				var err error
+				filter.include, err = regexp.Compile(include)
+				if err != nil {
+					return fmt.Errorf("invalid --include: %w", err)
+				}
+			}
+			cmd.SilenceUsage = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOrgScan(context.Background(), o, args[0], &filter, resumeFile, activityFile)
+		},
+	}
+	cmd.Flags().StringVar(&include, "include", "", "regular expression matching the names of the repos to scan")
+	cmd.Flags().BoolVar(&filter.excludeArchived, "exclude-archived", false, "do not scan archived repos")
+	cmd.Flags().IntVar(&filter.minStars, "min-stars", 0, "minimum number of stars of the repos to scan")
+	cmd.Flags().StringVar(&resumeFile, "resume", "",
+		"state file recording the completed repos, to continue an interrupted scan")
+	cmd.Flags().StringVar(&activityFile, "skip-unchanged", "",
+		"state file recording the last push to the repos, to skip the archived repos and those not pushed to since")
+	cmd.Flags().StringVar(&o.Format, options.FlagFormat, o.Format,
+		"output format. allowed values are default and json")
+	cmd.Flags().BoolVar(&o.ShowDetails, options.FlagShowDetails, o.ShowDetails, "show extra details about each check")
+	return cmd
+}
+
+func runOrgScan(ctx context.Context, o *options.Options, org string, filter *orgFilter,
+	resumeFile, activityFile string,
+) error {
+	logger := sclog.NewLogger(sclog.ParseLevel(o.LogLevel))
+	repos, err := listOwnerRepos(ctx, logger, org)
+	if err != nil {
+		return fmt.Errorf("ListOwnerRepos: %w", err)
+	}
+	m, err := orgManifest(repos, filter)
+	if err != nil {
+		return err
+	}
+	return scanManifest(ctx, o, m, targetsHash(m), resumeFile, activityFile)
+}
+
+// orgManifest returns the manifest of the repos matching filter.
+func orgManifest(repos []ghrepo.OwnerRepo, filter *orgFilter) (*manifest, error) {
+	var m manifest
+	for i := range repos {
+		if filter.matches(&repos[i]) {
+			m.Targets = append(m.Targets, manifestTarget{Repo: repos[i].Name})
+		}
+	}
+	if len(m.Targets) == 0 {
+		return nil, errOrgNoRepos
+	}
+	return &m, nil
+}
+
+// targetsHash identifies the targets of m, like the hash of a manifest file
+// does, so that --resume starts over when the repos listed change.
+func targetsHash(m *manifest) string {
+	h := sha256.New()
+	for i := range m.Targets {
+		fmt.Fprintln(h, m.Targets[i].name())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	ghrepo "github.com/ossf/scorecard/v4/clients/githubrepo"
+)
+
+func Test_orgManifest(t *testing.T) {
+	t.Parallel()
+	repos := []ghrepo.OwnerRepo{
+		{Name: "github.com/org/svc-api", Stars: 12},
+		{Name: "github.com/org/svc-old", Stars: 40, Archived: true},
+		{Name: "github.com/org/svc-new", Stars: 2},
+		{Name: "github.com/org/docs", Stars: 100},
+	}
+	tests := []struct {
+		wantErr error
+		filter  orgFilter
+		name    string
+		want    []string
+	}{
+		{
+			name: "no filters",
+			want: []string{
+				"github.com/org/svc-api", "github.com/org/svc-old", "github.com/org/svc-new", "github.com/org/docs",
+			},
+		},
+		{
+			name: "all filters",
+			filter: orgFilter{
+				include:         regexp.MustCompile("^svc-"),
+				excludeArchived: true,
+				minStars:        10,
+			},
+			want: []string{"github.com/org/svc-api"},
+		},
+		{
+			name:    "no matching repos",
+			filter:  orgFilter{minStars: 1000},
+			wantErr: errOrgNoRepos,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m, err := orgManifest(repos, &tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("orgManifest() error = %v, want %v", err, tt.wantErr)
+			}
+			var got []string
+			if m != nil {
+				for i := range m.Targets {
+					got = append(got, m.Targets[i].Repo)
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("orgManifest() (-want,+got): %s", diff)
+			}
+		})
+	}
+}
@@ -16,125 +16,70 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 
+	"github.com/ossf/scorecard/v4/clients/registry"
 	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/options"
 )
 
-type packageMangerResponse struct {
-	associatedRepo string
-	exists         bool
-}
-
-func fetchGitRepositoryFromPackageManagers(npm, pypi, rubygems string,
-	manager packageManagerClient,
-) (packageMangerResponse, error) {
-	if npm != "" {
-		gitRepo, err := fetchGitRepositoryFromNPM(npm, manager)
-		return packageMangerResponse{
-			exists:         true,
-			associatedRepo: gitRepo,
-		}, err
-	}
-	if pypi != "" {
-		gitRepo, err := fetchGitRepositoryFromPYPI(pypi, manager)
-		return packageMangerResponse{
-			exists:         true,
-			associatedRepo: gitRepo,
-		}, err
-	}
-	if rubygems != "" {
-		gitRepo, err := fetchGitRepositoryFromRubyGems(rubygems, manager)
-		return packageMangerResponse{
-			exists:         true,
-			associatedRepo: gitRepo,
-		}, err
-	}
-
-	return packageMangerResponse{}, nil
-}
-
-type npmSearchResults struct {
-	Objects []struct {
-		Package struct {
-			Links struct {
-				Repository string `json:"repository"`
-			} `json:"links"`
-		} `json:"package"`
-	} `json:"objects"`
-}
-
-type pypiSearchResults struct {
-	Info struct {
-		ProjectUrls struct {
-			Source string `json:"Source"`
-		} `json:"project_urls"`
-	} `json:"info"`
-}
-
-type rubyGemsSearchResults struct {
-	SourceCodeURI string `json:"source_code_uri"`
-}
+var errAmbiguousPackageRepo = errors.New("ambiguous source repo")
 
-// Gets the GitHub repository URL for the npm package.
-func fetchGitRepositoryFromNPM(packageName string, packageManager packageManagerClient) (string, error) {
-	npmSearchURL := "https://registry.npmjs.org/-/v1/search?text=%s&size=1"
-	resp, err := packageManager.Get(npmSearchURL, packageName)
-	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to get npm package json: %v", err))
-	}
+// newPackageClientFn returns the client of the registry of an ecosystem,
+// see registry.NewPackageClient.
+type newPackageClientFn func(registry.Ecosystem, *http.Client) (registry.PackageClient, error)
 
-	defer resp.Body.Close()
-	v := &npmSearchResults{}
-	err = json.NewDecoder(resp.Body).Decode(v)
-	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to parse npm package json: %v", err))
-	}
-	if len(v.Objects) == 0 {
-		return "", sce.WithMessage(sce.ErrScorecardInternal,
-			fmt.Sprintf("could not find source repo for npm package: %s", packageName))
+// optionsPackage returns the ecosystem and name of the package set by o with
+// e.g. --npm, if any.
+func optionsPackage(o *options.Options) (registry.Ecosystem, string, bool) {
+	for _, p := range []struct {
+		ecosystem registry.Ecosystem
+		name      string
+	}{
+		{registry.NPM, o.NPM},
+		{registry.PyPI, o.PyPI},
+		{registry.RubyGems, o.RubyGems},
+		{registry.CratesIO, o.Cargo},
+		{registry.Maven, o.Maven},
+	} {
+		if p.name != "" {
+			return p.ecosystem, p.name, true
+		}
 	}
-	return v.Objects[0].Package.Links.Repository, nil
+	return "", "", false
 }
 
-// Gets the GitHub repository URL for the pypi package.
-func fetchGitRepositoryFromPYPI(packageName string, manager packageManagerClient) (string, error) {
-	pypiSearchURL := "https://pypi.org/pypi/%s/json"
-	resp, err := manager.Get(pypiSearchURL, packageName)
-	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to get pypi package json: %v", err))
+// fetchPackageFromPackageManagers returns the registry metadata of the
+// package set by o, whose source repo is scanned, or nil if o sets none. It
+// fails when the package declares distinct source repos, as scanning either
+// could score the wrong project.
+func fetchPackageFromPackageManagers(ctx context.Context, o *options.Options,
+	newClient newPackageClientFn,
+) (*registry.Package, error) {
+	ecosystem, name, ok := optionsPackage(o)
+	if !ok {
+		return nil, nil
 	}
-
-	defer resp.Body.Close()
-	v := &pypiSearchResults{}
-	err = json.NewDecoder(resp.Body).Decode(v)
+	client, err := newClient(ecosystem, nil)
 	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to parse pypi package json: %v", err))
-	}
-	if v.Info.ProjectUrls.Source == "" {
-		return "", sce.WithMessage(sce.ErrScorecardInternal,
-			fmt.Sprintf("could not find source repo for pypi package: %s", packageName))
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, err.Error())
 	}
-	return v.Info.ProjectUrls.Source, nil
-}
-
-// Gets the GitHub repository URL for the rubygems package.
-func fetchGitRepositoryFromRubyGems(packageName string, manager packageManagerClient) (string, error) {
-	rubyGemsSearchURL := "https://rubygems.org/api/v1/gems/%s.json"
-	resp, err := manager.Get(rubyGemsSearchURL, packageName)
+	p, err := client.GetPackage(ctx, name)
 	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to get ruby gem json: %v", err))
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("failed to get %s package: %v", ecosystem, err))
 	}
-
-	defer resp.Body.Close()
-	v := &rubyGemsSearchResults{}
-	err = json.NewDecoder(resp.Body).Decode(v)
-	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to parse ruby gem json: %v", err))
+	if len(p.RepoCandidates) > 0 {
+		return nil, fmt.Errorf("%w for %s package %s: it declares %s, use --%s to scan one of them",
+			errAmbiguousPackageRepo, ecosystem, name, strings.Join(p.RepoCandidates, ", "), options.FlagRepo)
 	}
-	if v.SourceCodeURI == "" {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("could not find source repo for ruby gem: %v", err))
+	if p.Repo == "" {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("could not find source repo for %s package: %s", ecosystem, name))
 	}
-	return v.SourceCodeURI, nil
+	return p, nil
 }
@@ -0,0 +1,133 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v4/digest"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/portfolio"
+)
+
+var (
+	errPortfolioFlagsMustBeSet = errors.New("`manifest` and `results` must be set")
+	errPortfolioFormat         = errors.New("unsupported format for portfolio, expected default or json")
+	errPortfolioWorst          = errors.New("`worst` should not be negative")
+)
+
+type portfolioOptions struct {
+	manifest string
+	results  string
+	previous string
+	format   string
+	output   string
+	worst    int
+}
+
+func portfolioCmd() *cobra.Command {
+	o := portfolioOptions{
+		format: options.FormatDefault,
+		worst:  5,
+	}
+	cmd := &cobra.Command{
+		Use:   "portfolio --manifest=<file> --results=<bucket>",
+		Short: "Aggregate the results of the repos of an organization by owning team",
+		Long: `Portfolio reads the latest results of the repos owned by the teams of a
+manifest and reports, for each team, the average score of its repos and of
+each check, the number of failing checks and the worst findings.
+
+The results bucket is laid out as exported by the cron job, with the latest
+results of each repo at <repo>/results.json. Trends are computed against the
+results of an earlier run in the same layout, e.g. the snapshots of digest.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if o.manifest == "" || o.results == "" {
+				return errPortfolioFlagsMustBeSet
+			}
+			if o.format != options.FormatDefault && o.format != options.FormatJSON {
+				return fmt.Errorf("%w: %s", errPortfolioFormat, o.format)
+			}
+			if o.worst < 0 {
+				return errPortfolioWorst
+			}
+			cmd.SilenceUsage = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w := os.Stdout
+			if o.output != "" {
+				f, err := os.Create(o.output)
+				if err != nil {
+					return fmt.Errorf("os.Create: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+			return runPortfolio(context.Background(), &o, w, time.Now())
+		},
+	}
+	cmd.Flags().StringVar(&o.manifest, "manifest", o.manifest, "YAML file mapping the repos to the teams owning them")
+	cmd.Flags().StringVar(&o.results, "results", o.results,
+		"bucket URL of the latest results, e.g. gs://bucket or file:///path/to/dir")
+	cmd.Flags().StringVar(&o.previous, "previous", o.previous,
+		"bucket URL of earlier results to compute the trends against, e.g. the snapshots of digest")
+	cmd.Flags().StringVar(&o.format, "format", o.format, "output format. allowed values are default and json")
+	cmd.Flags().StringVar(&o.output, "output", o.output, "file to write the report to, defaults to stdout")
+	cmd.Flags().IntVar(&o.worst, "worst", o.worst, "number of worst findings reported for each team")
+	return cmd
+}
+
+func runPortfolio(ctx context.Context, o *portfolioOptions, w io.Writer, now time.Time) error {
+	f, err := os.Open(o.manifest)
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+	m, err := portfolio.ParseManifest(f)
+	if err != nil {
+		return fmt.Errorf("ParseManifest: %w", err)
+	}
+
+	repos := m.Repos()
+	current, err := digest.ReadResults(ctx, o.results, repos)
+	if err != nil {
+		return fmt.Errorf("ReadResults: %w", err)
+	}
+	var previous map[string]*digest.Result
+	if o.previous != "" {
+		previous, err = digest.ReadResults(ctx, o.previous, repos)
+		if err != nil {
+			return fmt.Errorf("ReadResults: %w", err)
+		}
+	}
+
+	r := portfolio.Build(m, current, previous, o.worst, now)
+	if o.format == options.FormatJSON {
+		err = r.WriteJSON(w)
+	} else {
+		err = r.WriteText(w)
+	}
+	if err != nil {
+		return fmt.Errorf("writing portfolio: %w", err)
+	}
+	return nil
+}
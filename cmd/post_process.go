@@ -0,0 +1,67 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+var (
+	errPostProcessFailed = errors.New("post-process command failed")
+	errPostProcessOutput = errors.New("post-process command did not write JSON")
+)
+
+// postProcess pipes the results through command, as given to
+// --post-process: its arguments are separated by spaces and it is not run
+// by a shell. It reads the results in format on stdin and writes the results
+// to output instead on stdout, which must be JSON. Besides the environment of
+// scorecard, it gets SCORECARD_FORMAT, SCORECARD_REPO and SCORECARD_COMMIT.
+// Its stderr is passed through and a non-zero exit fails the run.
+func postProcess(ctx context.Context, command, format string, result *pkg.ScorecardResult,
+	results []byte, stderr io.Writer,
+) ([]byte, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return results, nil
+	}
+	//nolint:gosec // the command is given by the user running scorecard.
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Env = append(os.Environ(),
+		"SCORECARD_FORMAT="+format,
+		"SCORECARD_REPO="+result.Repo.Name,
+		"SCORECARD_COMMIT="+result.Repo.CommitSHA,
+	)
+	var stdout bytes.Buffer
+	cmd.Stdin = bytes.NewReader(results)
+	cmd.Stdout = &stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errPostProcessFailed, command, err)
+	}
+	if !json.Valid(stdout.Bytes()) {
+		return nil, fmt.Errorf("%w: %s", errPostProcessOutput, command)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+func TestPostProcess(t *testing.T) {
+	t.Parallel()
+	result := &pkg.ScorecardResult{Repo: pkg.RepoInfo{Name: "github.com/owner/repo", CommitSHA: "abc"}}
+	tests := []struct {
+		wantErr error
+		name    string
+		command string
+		want    string
+	}{
+		{
+			name:    "unchanged",
+			command: "cat",
+			want:    `{"score":5}`,
+		},
+		{
+			name:    "transformed",
+			command: "sed s/score/points/",
+			want:    `{"points":5}`,
+		},
+		{
+			name:    "failed",
+			command: "false",
+			wantErr: errPostProcessFailed,
+		},
+		{
+			name:    "not JSON",
+			command: "echo done",
+			wantErr: errPostProcessOutput,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var stderr bytes.Buffer
+			got, err := postProcess(context.Background(), tt.command, "json", result, []byte(`{"score":5}`), &stderr)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("postProcess() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("postProcess() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostProcess_environment(t *testing.T) {
+	t.Parallel()
+	result := &pkg.ScorecardResult{Repo: pkg.RepoInfo{Name: "github.com/owner/repo", CommitSHA: "abc"}}
+	got, err := postProcess(context.Background(), "./testdata/post_process_env.sh", "sarif", result,
+		[]byte("{}"), nil)
+	if err != nil {
+		t.Fatalf("postProcess: %v", err)
+	}
+	want := `{"repo":"github.com/owner/repo","commit":"abc","format":"sarif"}`
+	if string(got) != want {
+		t.Errorf("postProcess() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+	ghrepo "github.com/ossf/scorecard/v4/clients/githubrepo"
+	sclog "github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+var (
+	errPROptionsMustBeSet = errors.New("`repo` and `number` must be set")
+	errPRFormat           = errors.New("unsupported format for pr, expected default or json")
+	errPRFindings         = errors.New("the pull request introduces findings")
+)
+
+// prChecks are the checks run by default on pull requests: those reporting
+// findings located in the files of the repo.
+var prChecks = []string{
+	checks.CheckBinaryArtifacts,
+	checks.CheckDangerousWorkflow,
+	checks.CheckPinnedDependencies,
+	checks.CheckTokenPermissions,
+}
+
+// prFinding is a warning of a check located in a file of a pull request.
+type prFinding struct {
+	Check   string `json:"check"`
+	Path    string `json:"path"`
+	Text    string `json:"text"`
+	Snippet string `json:"snippet,omitempty"`
+	Offset  uint   `json:"offset,omitempty"`
+}
+
+// prReport lists the findings a pull request introduces.
+type prReport struct {
+	Repo     string      `json:"repo"`
+	Base     string      `json:"base"`
+	Head     string      `json:"head"`
+	Findings []prFinding `json:"findings"`
+	Number   int         `json:"number"`
+}
+
+func prCmd(o *options.Options) *cobra.Command {
+	var number int
+	cmd := &cobra.Command{
+		Use:   "pr --repo=<repo> --number=<number>",
+		Short: "Report the findings introduced by a pull request",
+		Long: `PR scans the files a pull request of a GitHub repo adds or modifies, at
+its head commit and at the commit of the base branch it is compared with, and
+reports the findings of the head commit which the base commit does not have,
+e.g. an unpinned action added to a workflow.
+
+Only the files of the pull request are fetched, instead of the whole repo, and
+by default only Binary-Artifacts, Dangerous-Workflow, Pinned-Dependencies and
+Token-Permissions are run, which makes it fast enough for a merge check. It
+exits with an error if the pull request introduces any findings.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if o.Repo == "" || number <= 0 {
+				return errPROptionsMustBeSet
+			}
+			if o.Format != options.FormatDefault && o.Format != options.FormatJSON {
+				return errPRFormat
+			}
+			cmd.SilenceUsage = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := runPR(context.Background(), o, number)
+			if err != nil {
+				return err
+			}
+			if err := report.write(os.Stdout, o.Format); err != nil {
+				return err
+			}
+			if len(report.Findings) > 0 {
+				return fmt.Errorf("%w: %d", errPRFindings, len(report.Findings))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&o.Repo, options.FlagRepo, o.Repo, "repository of the pull request")
+	cmd.Flags().IntVar(&number, "number", number, "number of the pull request")
+	cmd.Flags().StringVar(&o.Format, options.FlagFormat, o.Format,
+		"output format. allowed values are default and json")
+	cmd.Flags().StringSliceVar(&o.ChecksToRun, options.FlagChecks, o.ChecksToRun,
+		"checks to run, defaults to the checks locating findings in files")
+	return cmd
+}
+
+func runPR(ctx context.Context, o *options.Options, number int) (*prReport, error) {
+	logger := sclog.NewLogger(sclog.ParseLevel(o.LogLevel))
+	pr, err := ghrepo.GetPullRequest(ctx, logger, o.Repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("GetPullRequest: %w", err)
+	}
+	report := &prReport{
+		Repo:     o.Repo,
+		Number:   pr.Number,
+		Base:     pr.BaseCommit,
+		Head:     pr.HeadCommit,
+		Findings: []prFinding{},
+	}
+	if len(pr.Files) == 0 {
+		return report, nil
+	}
+
+	checksToRun := o.ChecksToRun
+	if len(checksToRun) == 0 {
+		checksToRun = prChecks
+	}
+	scan := func(commit string) (*pkg.ScorecardResult, error) {
+		to, err := targetOptions(o, &manifestTarget{Repo: o.Repo, Commit: commit, Checks: checksToRun})
+		if err != nil {
+			return nil, err
+		}
+		// Only the files of the pull request are listed, and fetched one by
+		// one rather than downloading the repo.
+		to.Shallow = true
+		to.FileScope = pr.Files
+		result, _, _, err := runScorecard(ctx, to)
+		if err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", commit, err)
+		}
+		return &result, nil
+	}
+	head, err := scan(pr.HeadCommit)
+	if err != nil {
+		return nil, err
+	}
+	base, err := scan(pr.BaseCommit)
+	if err != nil {
+		return nil, err
+	}
+	report.Findings = introducedFindings(base, head, pr.Files)
+	return report, nil
+}
+
+// introducedFindings returns the warnings of head located in files which base
+// does not have. Warnings are matched regardless of their line, which the
+// changes of the pull request may move.
+func introducedFindings(base, head *pkg.ScorecardResult, files []string) []prFinding {
+	changed := map[string]bool{}
+	for _, f := range files {
+		changed[f] = true
+	}
+	type key struct {
+		check, path, text, snippet string
+	}
+	findings := func(r *pkg.ScorecardResult, f func(key, *checker.CheckDetail)) {
+		for i := range r.Checks {
+			for j := range r.Checks[i].Details {
+				d := &r.Checks[i].Details[j]
+				if d.Type != checker.DetailWarn || !changed[d.Msg.Path] {
+					continue
+				}
+				f(key{r.Checks[i].Name, d.Msg.Path, d.Msg.Text, d.Msg.Snippet}, d)
+			}
+		}
+	}
+
+	existing := map[key]int{}
+	findings(base, func(k key, _ *checker.CheckDetail) {
+		existing[k]++
+	})
+	ret := []prFinding{}
+	findings(head, func(k key, d *checker.CheckDetail) {
+		if existing[k] > 0 {
+			existing[k]--
+			return
+		}
+		ret = append(ret, prFinding{
+			Check:   k.check,
+			Path:    k.path,
+			Offset:  d.Msg.Offset,
+			Text:    k.text,
+			Snippet: k.snippet,
+		})
+	})
+	return ret
+}
+
+func (r *prReport) write(w io.Writer, format string) error {
+	if format == options.FormatJSON {
+		if err := json.NewEncoder(w).Encode(r); err != nil {
+			return fmt.Errorf("encoder.Encode: %w", err)
+		}
+		return nil
+	}
+	fmt.Fprintf(w, "Pull request %s#%d (base %s, head %s) ", r.Repo, r.Number, shortSHA(r.Base), shortSHA(r.Head))
+	if len(r.Findings) == 0 {
+		fmt.Fprintln(w, "introduces no findings.")
+		return nil
+	}
+	fmt.Fprintf(w, "introduces %d finding(s):\n", len(r.Findings))
+	for i := range r.Findings {
+		f := &r.Findings[i]
+		location := f.Path
+		if f.Offset > 0 {
+			location = fmt.Sprintf("%s:%d", f.Path, f.Offset)
+		}
+		fmt.Fprintf(w, "  %s: [%s] %s\n", location, f.Check, f.Text)
+	}
+	return nil
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
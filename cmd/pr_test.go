@@ -0,0 +1,102 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+func TestIntroducedFindings(t *testing.T) {
+	t.Parallel()
+	warn := func(path string, offset uint, text string) checker.CheckDetail {
+		return checker.CheckDetail{
+			Type: checker.DetailWarn,
+			Msg:  checker.LogMessage{Path: path, Offset: offset, Text: text},
+		}
+	}
+	const unpinned = "third-party GitHubAction not pinned by hash"
+	base := &pkg.ScorecardResult{Checks: []checker.CheckResult{
+		{Name: "Pinned-Dependencies", Details: []checker.CheckDetail{
+			warn(".github/workflows/ci.yml", 10, unpinned),
+			warn("Dockerfile", 1, "containerImage not pinned by hash"),
+		}},
+	}}
+	head := &pkg.ScorecardResult{Checks: []checker.CheckResult{
+		{Name: "Pinned-Dependencies", Details: []checker.CheckDetail{
+			// Moved by the changes of the pull request.
+			warn(".github/workflows/ci.yml", 14, unpinned),
+			// Added by the pull request.
+			warn(".github/workflows/ci.yml", 20, unpinned),
+			// Not changed by the pull request.
+			warn("Dockerfile", 1, "containerImage not pinned by hash"),
+			warn("build.sh", 3, "downloadThenRun not pinned by hash"),
+			{
+				Type: checker.DetailInfo,
+				Msg:  checker.LogMessage{Path: ".github/workflows/ci.yml", Text: "GitHub-owned GitHubAction pinned"},
+			},
+		}},
+		{Name: "Binary-Artifacts", Details: []checker.CheckDetail{
+			warn("tools/protoc", 0, "binary detected"),
+		}},
+	}}
+	got := introducedFindings(base, head, []string{".github/workflows/ci.yml", "tools/protoc"})
+	want := []prFinding{
+		{Check: "Pinned-Dependencies", Path: ".github/workflows/ci.yml", Offset: 20, Text: unpinned},
+		{Check: "Binary-Artifacts", Path: "tools/protoc", Text: "binary detected"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("introducedFindings() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPRReportWrite(t *testing.T) {
+	t.Parallel()
+	report := prReport{
+		Repo:   "github.com/owner/repo",
+		Number: 123,
+		Base:   "0123456789abcdef",
+		Head:   "fedcba9876543210",
+		Findings: []prFinding{
+			{Check: "Pinned-Dependencies", Path: ".github/workflows/ci.yml", Offset: 20, Text: "not pinned"},
+			{Check: "Binary-Artifacts", Path: "tools/protoc", Text: "binary detected"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := report.write(&buf, options.FormatDefault); err != nil {
+		t.Fatalf("write() = %v", err)
+	}
+	want := "Pull request github.com/owner/repo#123 (base 0123456, head fedcba9) introduces 2 finding(s):\n" +
+		"  .github/workflows/ci.yml:20: [Pinned-Dependencies] not pinned\n" +
+		"  tools/protoc: [Binary-Artifacts] binary detected\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("write() mismatch (-want +got):\n%s", diff)
+	}
+
+	buf.Reset()
+	report.Findings = nil
+	if err := report.write(&buf, options.FormatDefault); err != nil {
+		t.Fatalf("write() = %v", err)
+	}
+	if got := buf.String(); got != "Pull request github.com/owner/repo#123 (base 0123456, head fedcba9) introduces no findings.\n" {
+		t.Errorf("write() = %q", got)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	ghrepo "github.com/ossf/scorecard/v4/clients/githubrepo"
+	sclog "github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/publish"
+)
+
+const (
+	defaultPublishPath     = "README.md"
+	defaultPublishGistFile = "scorecard.md"
+	defaultPublishMessage  = "Update OpenSSF Scorecard results"
+)
+
+var (
+	errPublishRepoOptionMustBeSet = errors.New("`repo` must be set")
+	errPublishTarget              = errors.New("`publish-repo` must be set to publish the results of a repo not on GitHub")
+)
+
+type publishOptions struct {
+	// repo is the GitHub repo to commit the summary to, e.g. owner/repo,
+	// defaulting to the scanned repo.
+	repo     string
+	branch   string
+	path     string
+	gist     string
+	gistFile string
+	message  string
+}
+
+// summaryPublisher is implemented by ghrepo.Publisher.
+type summaryPublisher interface {
+	PublishFile(ctx context.Context, repo, branch, path, message string,
+		update func(current []byte) ([]byte, error)) (bool, error)
+	PublishGist(ctx context.Context, id, file string, update func(current []byte) ([]byte, error)) (bool, error)
+}
+
+func publishCmd(o *options.Options) *cobra.Command {
+	p := publishOptions{
+		path:     defaultPublishPath,
+		gistFile: defaultPublishGistFile,
+		message:  defaultPublishMessage,
+	}
+	cmd := &cobra.Command{
+		Use:   "publish --repo=<repo>",
+		Short: "Publish a summary of the results to a file of a repo or a gist",
+		Long: `Publish scans a repository and commits a compact markdown table of its scores
+to a file of a GitHub repository, README.md of the scanned repository by
+default, or to a file of a gist with --gist. Run on a schedule, e.g. from a
+GitHub Actions workflow, it keeps a score table visible in the README without
+external services.
+
+The table is placed between <!-- scorecard:begin --> and <!-- scorecard:end -->
+markers, which can be added anywhere in the file; files without the markers
+get the table appended. Nothing is committed if the table is unchanged.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if o.Repo == "" {
+				return errPublishRepoOptionMustBeSet
+			}
+			cmd.SilenceUsage = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			logger := sclog.NewLogger(sclog.ParseLevel(o.LogLevel))
+			return runPublish(ctx, o, &p, ghrepo.CreatePublisher(ctx, logger), os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&o.Repo, options.FlagRepo, o.Repo, "repository to scan")
+	cmd.Flags().StringSliceVar(&o.ChecksToRun, options.FlagChecks, o.ChecksToRun,
+		"checks to run, defaults to all checks")
+	cmd.Flags().StringVar(&p.repo, "publish-repo", p.repo,
+		"GitHub repository to commit the summary to, e.g. owner/repo, defaults to the scanned repository")
+	cmd.Flags().StringVar(&p.branch, "branch", p.branch,
+		"branch to commit the summary to, defaults to the default branch")
+	cmd.Flags().StringVar(&p.path, "path", p.path, "file to commit the summary to")
+	cmd.Flags().StringVar(&p.gist, "gist", p.gist, "ID of a gist to publish the summary to instead of a repository")
+	cmd.Flags().StringVar(&p.gistFile, "gist-file", p.gistFile, "file of the gist to publish the summary to")
+	cmd.Flags().StringVar(&p.message, "message", p.message, "message of the commit")
+	return cmd
+}
+
+func runPublish(ctx context.Context, o *options.Options, p *publishOptions, publisher summaryPublisher,
+	w io.Writer,
+) error {
+	result, checkDocs, _, err := runScorecard(ctx, o)
+	if err != nil {
+		return err
+	}
+	summary, err := publish.Summary(&result, checkDocs)
+	if err != nil {
+		return fmt.Errorf("publish.Summary: %w", err)
+	}
+	return publishSummary(ctx, p, publisher, result.Repo.Name, summary, w)
+}
+
+// publishSummary embeds summary in the file published to, by default
+// README.md of scanned, the name of the scanned repo.
+func publishSummary(ctx context.Context, p *publishOptions, publisher summaryPublisher, scanned, summary string,
+	w io.Writer,
+) error {
+	update := func(current []byte) ([]byte, error) {
+		return publish.Embed(current, summary)
+	}
+	if p.gist != "" {
+		edited, err := publisher.PublishGist(ctx, p.gist, p.gistFile, update)
+		if err != nil {
+			return fmt.Errorf("PublishGist: %w", err)
+		}
+		if edited {
+			fmt.Fprintf(w, "published the results of %s to gist %s\n", scanned, p.gist)
+		} else {
+			fmt.Fprintf(w, "results of %s unchanged in gist %s\n", scanned, p.gist)
+		}
+		return nil
+	}
+
+	repo := p.repo
+	if repo == "" {
+		if !strings.HasPrefix(scanned, "github.com/") {
+			return fmt.Errorf("%w: %s", errPublishTarget, scanned)
+		}
+		repo = strings.TrimPrefix(scanned, "github.com/")
+	}
+	committed, err := publisher.PublishFile(ctx, repo, p.branch, p.path, p.message, update)
+	if err != nil {
+		return fmt.Errorf("PublishFile: %w", err)
+	}
+	if committed {
+		fmt.Fprintf(w, "published the results of %s to %s of %s\n", scanned, p.path, repo)
+	} else {
+		fmt.Fprintf(w, "results of %s unchanged in %s of %s\n", scanned, p.path, repo)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakePublisher records the files and gists published to.
+type fakePublisher struct {
+	published map[string]string
+}
+
+func (p *fakePublisher) PublishFile(ctx context.Context, repo, branch, path, message string,
+	update func(current []byte) ([]byte, error),
+) (bool, error) {
+	content, err := update(nil)
+	if err != nil {
+		return false, err
+	}
+	p.published[repo+"@"+branch+":"+path] = string(content)
+	return true, nil
+}
+
+func (p *fakePublisher) PublishGist(ctx context.Context, id, file string,
+	update func(current []byte) ([]byte, error),
+) (bool, error) {
+	content, err := update(nil)
+	if err != nil {
+		return false, err
+	}
+	p.published["gist:"+id+":"+file] = string(content)
+	return true, nil
+}
+
+func Test_publishSummary(t *testing.T) {
+	t.Parallel()
+	const section = "<!-- scorecard:begin -->\nscores\n<!-- scorecard:end -->\n"
+	tests := []struct {
+		name    string
+		opts    publishOptions
+		scanned string
+		wantKey string
+		wantErr error
+	}{
+		{
+			name:    "README of the scanned repo",
+			opts:    publishOptions{path: defaultPublishPath},
+			scanned: "github.com/owner/repo",
+			wantKey: "owner/repo@:README.md",
+		},
+		{
+			name:    "file of another repo",
+			opts:    publishOptions{repo: "owner/docs", branch: "gh-pages", path: "scores.md"},
+			scanned: "github.com/owner/repo",
+			wantKey: "owner/docs@gh-pages:scores.md",
+		},
+		{
+			name:    "gist",
+			opts:    publishOptions{gist: "aa5a315d61ae9438b18d", gistFile: defaultPublishGistFile},
+			scanned: "gitlab.com/owner/repo",
+			wantKey: "gist:aa5a315d61ae9438b18d:scorecard.md",
+		},
+		{
+			name:    "repo not on GitHub",
+			opts:    publishOptions{path: defaultPublishPath},
+			scanned: "gitlab.com/owner/repo",
+			wantErr: errPublishTarget,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := fakePublisher{published: map[string]string{}}
+			var out bytes.Buffer
+			err := publishSummary(context.Background(), &tt.opts, &p, tt.scanned, "scores\n", &out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("publishSummary() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got := p.published[tt.wantKey]; got != section {
+				t.Errorf("publishSummary() published %v, want %q at %s", p.published, section, tt.wantKey)
+			}
+		})
+	}
+}
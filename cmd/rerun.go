@@ -0,0 +1,100 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	docs "github.com/ossf/scorecard/v4/docs/checks"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/pkg"
+	"github.com/ossf/scorecard/v4/pkg/format"
+	"github.com/ossf/scorecard/v4/policy"
+)
+
+var errRerunFlagsMustBeSet = errors.New("`check` and `from` must be set")
+
+type rerunOptions struct {
+	check string
+	from  string
+}
+
+func rerunCmd(o *options.Options) *cobra.Command {
+	var ro rerunOptions
+	cmd := &cobra.Command{
+		Use:   "rerun --check=<check> --from=<results.json>",
+		Short: "Evaluate a check again from the raw results of a previous run",
+		Long: `Rerun evaluates a single check again from the results of a previous run with
+--format=raw, without collecting any data from the repo. This is useful to
+iterate on the scoring of a check or on a policy without calling the APIs again.
+
+Only checks whose raw results are fully exported by the raw format can be
+evaluated again.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if ro.check == "" || ro.from == "" {
+				return errRerunFlagsMustBeSet
+			}
+			cmd.SilenceUsage = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRerun(o, &ro)
+		},
+	}
+	cmd.Flags().StringVar(&ro.check, "check", "", "check to evaluate again")
+	cmd.Flags().StringVar(&ro.from, "from", "", "results of a previous run with --format=raw")
+	cmd.Flags().StringVar(&o.Format, options.FlagFormat, o.Format,
+		"output format. allowed values are default, json, sjson, sarif and raw")
+	cmd.Flags().StringVar(&o.PolicyFile, options.FlagPolicyFile, o.PolicyFile, "policy to enforce")
+	cmd.Flags().BoolVar(&o.ShowDetails, options.FlagShowDetails, o.ShowDetails, "show extra details about each check")
+	return cmd
+}
+
+func runRerun(o *options.Options, ro *rerunOptions) error {
+	f, err := os.Open(ro.from)
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	result, err := pkg.RerunCheck(ro.check, f)
+	if err != nil {
+		return fmt.Errorf("RerunCheck: %w", err)
+	}
+
+	pol, err := policy.ParseFromFile(o.PolicyFile)
+	if err != nil {
+		return fmt.Errorf("readPolicy: %w", err)
+	}
+	checkDocs, err := docs.Read()
+	if err != nil {
+		return fmt.Errorf("cannot read yaml file: %w", err)
+	}
+
+	if err := format.Write(os.Stdout, o, &result, checkDocs, pol); err != nil {
+		return fmt.Errorf("failed to format results: %w", err)
+	}
+	for _, res := range result.Checks {
+		if res.Error != nil {
+			return sce.WithMessage(sce.ErrorCheckRuntime, fmt.Sprintf("%s: %v", res.Name, res.Error))
+		}
+	}
+	return nil
+}
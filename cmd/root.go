@@ -16,22 +16,35 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/release-utils/version"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
 	"github.com/ossf/scorecard/v4/clients"
+	ghrepo "github.com/ossf/scorecard/v4/clients/githubrepo"
+	"github.com/ossf/scorecard/v4/clients/localdir"
+	"github.com/ossf/scorecard/v4/clients/registry"
+	"github.com/ossf/scorecard/v4/config"
 	docs "github.com/ossf/scorecard/v4/docs/checks"
 	sce "github.com/ossf/scorecard/v4/errors"
 	sclog "github.com/ossf/scorecard/v4/log"
 	"github.com/ossf/scorecard/v4/options"
 	"github.com/ossf/scorecard/v4/pkg"
+	"github.com/ossf/scorecard/v4/pkg/format"
 	"github.com/ossf/scorecard/v4/policy"
 )
 
@@ -66,44 +79,236 @@ func New(o *options.Options) *cobra.Command {
 
 	// Add sub-commands.
 	cmd.AddCommand(serveCmd(o))
+	cmd.AddCommand(discoverCmd(o))
+	cmd.AddCommand(digestCmd())
+	cmd.AddCommand(portfolioCmd())
+	cmd.AddCommand(actionCmd(o))
+	cmd.AddCommand(rerunCmd(o))
+	cmd.AddCommand(manifestCmd(o))
+	cmd.AddCommand(orgCmd(o))
+	cmd.AddCommand(compareCmd(o))
+	cmd.AddCommand(graphCmd(o))
+	cmd.AddCommand(publishCmd(o))
+	cmd.AddCommand(prCmd(o))
+	cmd.AddCommand(verifyCmd())
 	cmd.AddCommand(version.Version())
 	return cmd
 }
 
 // rootCmd runs scorecard checks given a set of arguments.
 func rootCmd(o *options.Options) error {
-	p := &packageManager{}
+	ctx := context.Background()
+	repoResult, checkDocs, pol, err := runScorecard(ctx, o)
+	if err != nil {
+		return err
+	}
+	if o.DryRun {
+		return nil
+	}
+
+	if o.Format == options.FormatDefault {
+		fmt.Println("\nRESULTS\n-------")
+	}
+
+	var out io.Writer = os.Stdout
+	var results bytes.Buffer
+	if o.PostProcess != "" {
+		out = &results
+	}
+	resultsErr := format.Write(
+		out,
+		o,
+		&repoResult,
+		checkDocs,
+		pol,
+	)
+	if resultsErr != nil {
+		return fmt.Errorf("failed to format results: %w", resultsErr)
+	}
+	if o.PostProcess != "" {
+		processed, err := postProcess(ctx, o.PostProcess, o.Format, &repoResult, results.Bytes(), os.Stderr)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stdout.Write(processed); err != nil {
+			return fmt.Errorf("os.Stdout.Write: %w", err)
+		}
+	}
+
+	// intentionally placed at end to preserve outputting results, even if a check has a runtime error
+	for _, result := range repoResult.Checks {
+		if result.Error != nil {
+			return sce.WithMessage(sce.ErrorCheckRuntime, fmt.Sprintf("%s: %v", result.Name, result.Error))
+		}
+	}
+	return nil
+}
+
+// runScorecard runs the checks selected by the options and returns the
+// results sorted by check name, along with the docs and policy to format them.
+func runScorecard(ctx context.Context, o *options.Options) (
+	pkg.ScorecardResult, docs.Doc, *policy.ScorecardPolicy, error,
+) {
 	// Set `repo` from package managers.
-	pkgResp, err := fetchGitRepositoryFromPackageManagers(o.NPM, o.PyPI, o.RubyGems, p)
+	registryPackage, err := fetchPackageFromPackageManagers(ctx, o, registry.NewPackageClient)
 	if err != nil {
-		return fmt.Errorf("fetchGitRepositoryFromPackageManagers: %w", err)
+		return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("fetchPackageFromPackageManagers: %w", err)
 	}
-	if pkgResp.exists {
-		o.Repo = pkgResp.associatedRepo
+	if registryPackage != nil {
+		o.Repo = registryPackage.Repo
+	}
+
+	logger := sclog.NewLogger(sclog.ParseLevel(o.LogLevel))
+	if o.AsOf != "" {
+		// Checks run at the commit the default branch was at then. Only those
+		// reading files and commits can be evaluated at a commit.
+		asOf, err := options.ParseAsOf(o.AsOf)
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("ParseAsOf: %w", err)
+		}
+		o.Commit, err = ghrepo.CommitAsOf(ctx, logger, o.Repo, asOf)
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("CommitAsOf: %w", err)
+		}
 	}
 
 	pol, err := policy.ParseFromFile(o.PolicyFile)
 	if err != nil {
-		return fmt.Errorf("readPolicy: %w", err)
+		return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("readPolicy: %w", err)
 	}
 
-	ctx := context.Background()
-	logger := sclog.NewLogger(sclog.ParseLevel(o.LogLevel))
-	repoURI, repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, err := checker.GetClients(
-		ctx, o.Repo, o.Local, logger) // MODIFIED
+	archivedPolicy, err := pkg.ParseArchivedPolicy(o.ArchivedPolicy)
+	if err != nil {
+		return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("ParseArchivedPolicy: %w", err)
+	}
+	forkPolicy, err := pkg.ParseForkPolicy(o.ForkPolicy)
+	if err != nil {
+		return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("ParseForkPolicy: %w", err)
+	}
+	progressFormat, err := pkg.ParseProgressFormat(o.Progress)
 	if err != nil {
-		return fmt.Errorf("GetClients: %w", err)
+		return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("ParseProgressFormat: %w", err)
+	}
+	var preset *pkg.Preset
+	if o.Preset != "" {
+		preset, err = pkg.GetPreset(o.Preset)
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("GetPreset: %w", err)
+		}
 	}
 
-	defer repoClient.Close()
-	if ossFuzzRepoClient != nil {
-		defer ossFuzzRepoClient.Close()
+	workflowRules, err := readWorkflowRules(o.WorkflowRulesFile)
+	if err != nil {
+		return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("readWorkflowRules: %w", err)
+	}
+	customProbes, err := readCustomProbes(o.ProbesFile)
+	if err != nil {
+		return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("readCustomProbes: %w", err)
+	}
+	denylist, denylistHash, err := readDenylist(ctx, logger, o.Denylist, o.DenylistSnapshot)
+	if err != nil {
+		return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("readDenylist: %w", err)
+	}
+
+	policyHash, err := hashFile(o.PolicyFile)
+	if err != nil {
+		return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("hashFile: %w", err)
+	}
+	runOpts := []pkg.Option{
+		pkg.WithArchivedPolicy(archivedPolicy),
+		pkg.WithForkPolicy(forkPolicy),
+		pkg.WithWorkflowRules(workflowRules),
+		pkg.WithCustomProbes(customProbes),
+		pkg.WithDenylist(denylist),
+		pkg.WithProgress(os.Stderr, progressFormat),
+		pkg.WithPolicyDigest(policyHash),
+		pkg.WithPackage(registryPackage),
+		pkg.WithQuarantine(o.QuarantineDays),
+		pkg.WithLogger(logger),
+	}
+	if o.VerifyActionPins {
+		// The tags of actions are cached across the scans of mirrors.
+		actionTags := ghrepo.CreateActionTagsClient(ctx, logger)
+		runOpts = append(runOpts, pkg.WithActionTags(func() clients.ActionTagsClient {
+			return actionTags.WithBudget(o.ActionPinBudget)
+		}))
+	}
+	if o.ResultCache != "" {
+		cache, err := pkg.OpenResultCache(ctx, o.ResultCache)
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("OpenResultCache: %w", err)
+		}
+		defer cache.Close()
+		// Custom rules change the results as much as the policy does.
+		rulesHash, err := hashFile(o.WorkflowRulesFile)
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("hashFile: %w", err)
+		}
+		probesHash, err := hashFile(o.ProbesFile)
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("hashFile: %w", err)
+		}
+		// So does scoping the scan to a directory.
+		cacheKey := policyHash + rulesHash + probesHash + denylistHash
+		if o.PathScope != "" {
+			cacheKey += ":" + o.PathScope
+		}
+		if len(o.FileScope) > 0 {
+			cacheKey += ":files=" + strings.Join(o.FileScope, ",")
+		}
+		if o.VerifyActionPins {
+			cacheKey += ":verify-action-pins"
+		}
+		if o.QuarantineDays > 0 {
+			cacheKey += fmt.Sprintf(":quarantine-days=%d", o.QuarantineDays)
+		}
+		runOpts = append(runOpts, pkg.WithResultCache(cache, cacheKey))
+	}
+
+	repo, local, checksToRun := o.Repo, o.Local, o.ChecksToRun
+	var wikiURI string
+	if o.Wiki {
+		checksToRun, err = wikiChecksToRun(o.ChecksToRun)
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, err
+		}
+		local, err = os.MkdirTemp("", "wiki*")
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("os.MkdirTemp: %w", err)
+		}
+		defer os.RemoveAll(local)
+		wikiURI, err = ghrepo.CloneWiki(ctx, o.Repo, local)
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("CloneWiki: %w", err)
+		}
+		repo = ""
+	}
+	// File-scoped scans only fetch the few files in scope, so shallow scans
+	// of them support all the checks.
+	if o.Shallow && len(o.FileScope) == 0 {
+		checksToRun, err = shallowChecksToRun(o.ChecksToRun)
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, err
+		}
+	}
+	var tarballURI string
+	if localdir.IsTarball(o.Local) {
+		dir, err := os.MkdirTemp("", "tarball*")
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("os.MkdirTemp: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		local, err = localdir.ExtractTarball(o.Local, dir)
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("ExtractTarball: %w", err)
+		}
+		tarballURI = fmt.Sprintf("file://%s", filepath.Clean(o.Local))
 	}
 
 	// Read docs.
 	checkDocs, err := docs.Read()
 	if err != nil {
-		return fmt.Errorf("cannot read yaml file: %w", err)
+		return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("cannot read yaml file: %w", err)
 	}
 
 	var requiredRequestTypes []checker.RequestType
@@ -113,9 +318,18 @@ func rootCmd(o *options.Options) error {
 	if !strings.EqualFold(o.Commit, clients.HeadSHA) {
 		requiredRequestTypes = append(requiredRequestTypes, checker.CommitBased)
 	}
-	enabledChecks, err := policy.GetEnabled(pol, o.ChecksToRun, requiredRequestTypes)
+	// Presets select the checks to run unless they are given explicitly or
+	// by the policy.
+	var unsupportedPresetChecks []string
+	if preset != nil && len(checksToRun) == 0 && pol == nil {
+		checksToRun, unsupportedPresetChecks = policy.SplitSupported(preset.Checks, requiredRequestTypes)
+	}
+	enabledChecks, err := policy.GetEnabled(pol, checksToRun, requiredRequestTypes)
 	if err != nil {
-		return fmt.Errorf("GetEnabled: %w", err)
+		return pkg.ScorecardResult{}, nil, nil, fmt.Errorf("GetEnabled: %w", err)
+	}
+	if o.DryRun {
+		return pkg.ScorecardResult{}, nil, nil, dryRun(os.Stdout, o, repo, local, enabledChecks)
 	}
 
 	if o.Format == options.FormatDefault {
@@ -124,22 +338,53 @@ func rootCmd(o *options.Options) error {
 		}
 	}
 
-	repoResult, err := pkg.RunScorecard(
-		ctx,
-		repoURI,
-		o.Commit,
-		o.CommitDepth,
-		enabledChecks,
-		repoClient,
-		ossFuzzRepoClient,
-		ciiClient,
-		vulnsClient,
-	)
+	repoResult, err := scanRepo(ctx, o, repo, local, enabledChecks, logger, runOpts)
 	if err != nil {
-		return fmt.Errorf("RunScorecard: %w", err)
+		return pkg.ScorecardResult{}, nil, nil, err
+	}
+	if len(o.Mirrors) > 0 {
+		repoResult, err = scanMirrors(ctx, o, repoResult, enabledChecks, logger, runOpts)
+		if err != nil {
+			return pkg.ScorecardResult{}, nil, nil, err
+		}
+	}
+
+	// Checks which need API access cannot run on a local directory. They are
+	// reported as inconclusive rather than left out of the results silently.
+	if o.Local != "" {
+		unsupported := policy.GetUnsupported(pol, checksToRun, requiredRequestTypes)
+		for _, checkName := range append(unsupported, unsupportedPresetChecks...) {
+			repoResult.Checks = append(repoResult.Checks, checker.CreateInconclusiveResult(checkName,
+				"check requires API access, which is not available for local directories"))
+		}
+	}
+	// Likewise, the settings a check reads from the API are only known as
+	// they are now.
+	if o.AsOf != "" {
+		unsupported := policy.GetUnsupported(pol, checksToRun, requiredRequestTypes)
+		for _, checkName := range append(unsupported, unsupportedPresetChecks...) {
+			repoResult.Checks = append(repoResult.Checks, checker.CreateInconclusiveResult(checkName,
+				"check requires the current settings of the repo, which are not available as of a past date"))
+		}
+		repoResult.Metadata = append(repoResult.Metadata, pkg.MetadataAsOf+o.AsOf)
 	}
 
+	// A custom probe only runs with its check, so a policy relying on one
+	// would otherwise silently pass when the check does not run.
+	if notRun := repoResult.ProbesNotRun(); len(notRun) > 0 {
+		logger.Info(fmt.Sprintf("custom probes not run, as their checks were not run or failed: %s",
+			strings.Join(notRun, ", ")))
+	}
 	repoResult.Metadata = append(repoResult.Metadata, o.Metadata...)
+	if preset != nil {
+		repoResult.Weights = preset.Weights
+	}
+	if wikiURI != "" {
+		repoResult.Repo.Name = wikiURI
+	}
+	if tarballURI != "" {
+		repoResult.Repo.Name = tarballURI
+	}
 
 	// Sort them by name
 	sort.Slice(repoResult.Checks, func(i, j int) bool {
@@ -150,24 +395,131 @@ func rootCmd(o *options.Options) error {
 		for checkName := range enabledChecks {
 			fmt.Fprintf(os.Stderr, "Finished [%s]\n", checkName)
 		}
-		fmt.Println("\nRESULTS\n-------")
 	}
+	return repoResult, checkDocs, pol, nil
+}
 
-	resultsErr := pkg.FormatResults(
-		o,
-		&repoResult,
-		checkDocs,
-		pol,
+// scanRepo runs the enabled checks on the repo at repoURI, or the local
+// folder, with the clients for the forge it is hosted on.
+func scanRepo(ctx context.Context, o *options.Options, repoURI, local string,
+	enabledChecks checker.CheckNameToFnMap, logger *sclog.Logger, runOpts []pkg.Option,
+) (pkg.ScorecardResult, error) {
+	repo, repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, err := checker.GetClients(
+		ctx, repoURI, local, logger) // MODIFIED
+	if err != nil {
+		return pkg.ScorecardResult{}, fmt.Errorf("GetClients: %w", err)
+	}
+	if o.Shallow {
+		if clientType, _ := checker.ClientType(repo); clientType != "github" {
+			return pkg.ScorecardResult{}, fmt.Errorf("%w: %s", errShallowGitHubOnly, repoURI)
+		}
+		repoClient = ghrepo.CreateShallowGithubRepoClient(ctx, logger)
+	}
+
+	defer repoClient.Close()
+	if ossFuzzRepoClient != nil {
+		defer ossFuzzRepoClient.Close()
+	}
+	repoClient = checker.NewPathScopedRepoClient(repoClient, o.PathScope)
+	repoClient = checker.NewFileScopedRepoClient(repoClient, o.FileScope)
+	// Mirrors are scanned concurrently with the same options, so copy them.
+	runOpts = append(runOpts[:len(runOpts):len(runOpts)], pkg.WithClientType(checker.ClientType(repo)))
+
+	repoResult, err := pkg.RunScorecard(
+		ctx,
+		repo,
+		o.Commit,
+		o.CommitDepth,
+		enabledChecks,
+		repoClient,
+		ossFuzzRepoClient,
+		ciiClient,
+		vulnsClient,
+		runOpts...,
 	)
-	if resultsErr != nil {
-		return fmt.Errorf("failed to format results: %w", resultsErr)
+	if err != nil {
+		return pkg.ScorecardResult{}, fmt.Errorf("RunScorecard: %w", err)
 	}
+	return repoResult, nil
+}
 
-	// intentionally placed at end to preserve outputting results, even if a check has a runtime error
-	for _, result := range repoResult.Checks {
-		if result.Error != nil {
-			return sce.WithMessage(sce.ErrorCheckRuntime, fmt.Sprintf("%s: %v", result.Name, result.Error))
+// scanMirrors scans the mirrors of the repo in parallel and merges their
+// results with the results of the repo.
+func scanMirrors(ctx context.Context, o *options.Options, repoResult pkg.ScorecardResult,
+	enabledChecks checker.CheckNameToFnMap, logger *sclog.Logger, runOpts []pkg.Option,
+) (pkg.ScorecardResult, error) {
+	results := make([]pkg.ScorecardResult, len(o.Mirrors)+1)
+	errs := make([]error, len(o.Mirrors))
+	results[0] = repoResult
+	wg := sync.WaitGroup{}
+	for i, mirror := range o.Mirrors {
+		i, mirror := i, mirror
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i+1], errs[i] = scanRepo(ctx, o, mirror, "", enabledChecks, logger, runOpts)
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return pkg.ScorecardResult{}, fmt.Errorf("mirror %s: %w", o.Mirrors[i], err)
 		}
 	}
-	return nil
+	return pkg.MergeMirrorResults(results), nil
+}
+
+func readWorkflowRules(path string) ([]checker.WorkflowRule, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+	rules, err := config.ParseWorkflowRules(f)
+	if err != nil {
+		return nil, fmt.Errorf("ParseWorkflowRules: %w", err)
+	}
+	return rules, nil
+}
+
+var errUnknownProbeCheck = errors.New("custom probe is reported with an unknown check")
+
+// readCustomProbes reads the custom probes at path, which must each name one
+// of the checks.
+func readCustomProbes(path string) ([]checker.CustomProbe, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+	probes, err := config.ParseCustomProbes(f)
+	if err != nil {
+		return nil, fmt.Errorf("ParseCustomProbes: %w", err)
+	}
+	all := checks.GetAllWithExperimental()
+	for i := range probes {
+		if _, ok := all[probes[i].Check]; !ok {
+			return nil, fmt.Errorf("%w: probe %s names %s", errUnknownProbeCheck, probes[i].ID, probes[i].Check)
+		}
+	}
+	return probes, nil
+}
+
+// hashFile returns the SHA-256 of the file at path, or "" if path is empty.
+func hashFile(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("os.ReadFile: %w", err)
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
 }
@@ -15,6 +15,10 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"net/http"
@@ -26,7 +30,12 @@ import (
 	"github.com/ossf/scorecard/v4/checks"
 	"github.com/ossf/scorecard/v4/clients"
 	"github.com/ossf/scorecard/v4/clients/githubrepo"
+	"github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper"
+	"github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper/tokens"
 	"github.com/ossf/scorecard/v4/clients/ossfuzz"
+	"github.com/ossf/scorecard/v4/dsse"
+	"github.com/ossf/scorecard/v4/internal/admin"
+	"github.com/ossf/scorecard/v4/internal/tenant"
 	"github.com/ossf/scorecard/v4/log"
 	"github.com/ossf/scorecard/v4/options"
 	"github.com/ossf/scorecard/v4/pkg"
@@ -34,7 +43,8 @@ import (
 
 // TODO(cmd): Determine if this should be exported.
 func serveCmd(o *options.Options) *cobra.Command {
-	return &cobra.Command{
+	var adminAddr, adminTokenFile, signingKeyFile, tenantsFile, cacheURL string
+	cmd := &cobra.Command{
 		Use:   "serve",
 		Short: "Serve the scorecard program over http",
 		Long:  ``,
@@ -48,48 +58,64 @@ func serveCmd(o *options.Options) *cobra.Command {
 				panic(err)
 			}
 
-			http.HandleFunc("/", func(rw http.ResponseWriter, r *http.Request) {
-				repoParam := r.URL.Query().Get("repo")
-				const length = 3
-				s := strings.SplitN(repoParam, "/", length)
-				if len(s) != length {
-					rw.WriteHeader(http.StatusBadRequest)
+			adminConfig := admin.Config{Addr: adminAddr}
+			if adminTokenFile != "" {
+				if adminConfig.Token, err = admin.ReadToken(adminTokenFile); err != nil {
+					logger.Error(err, "reading admin token")
+					panic(err)
 				}
-				repo, err := githubrepo.MakeGithubRepo(repoParam)
-				if err != nil {
-					rw.WriteHeader(http.StatusBadRequest)
-				}
-				ctx := r.Context()
-				repoClient := githubrepo.CreateGithubRepoClient(ctx, logger)
-				ossFuzzRepoClient, err := ossfuzz.CreateOSSFuzzClientEager(ossfuzz.StatusURL)
-				vulnsClient := clients.DefaultVulnerabilitiesClient()
-				if err != nil {
-					logger.Error(err, "initializing clients")
-					rw.WriteHeader(http.StatusInternalServerError)
+			}
+			var signer crypto.Signer
+			if signingKeyFile != "" {
+				if signer, err = readSigningKey(signingKeyFile); err != nil {
+					logger.Error(err, "reading signing key")
+					panic(err)
 				}
-				defer ossFuzzRepoClient.Close()
-				ciiClient := clients.DefaultCIIBestPracticesClient()
-				checksToRun := checks.GetAll()
-				repoResult, err := pkg.RunScorecard(
-					ctx, repo, clients.HeadSHA /*commitSHA*/, o.CommitDepth, checksToRun, repoClient,
-					ossFuzzRepoClient, ciiClient, vulnsClient)
-				if err != nil {
-					logger.Error(err, "running enabled scorecard checks on repo")
-					rw.WriteHeader(http.StatusInternalServerError)
+			}
+			var tenants *tenant.Registry
+			transports := map[*tenant.Tenant]http.RoundTripper{}
+			if tenantsFile != "" {
+				if tenants, err = tenant.ReadFile(tenantsFile); err != nil {
+					logger.Error(err, "reading tenants")
+					panic(err)
 				}
-
-				if r.Header.Get("Content-Type") == "application/json" {
-					if err := repoResult.AsJSON(o.ShowDetails, log.ParseLevel(o.LogLevel), rw); err != nil {
-						// TODO(log): Improve error message
-						logger.Error(err, "")
-						rw.WriteHeader(http.StatusInternalServerError)
+				// The tokens of each tenant are pooled across its scans.
+				for _, t := range tenants.Tenants {
+					if tokenList := t.Tokens(); tokenList != nil {
+						transports[t] = roundtripper.NewTokenTransport(context.Background(), logger,
+							tokens.MakeTokenPool(tokenList))
 					}
-					return
 				}
-				if err := t.Execute(rw, repoResult); err != nil {
-					// TODO(log): Improve error message
-					logger.Error(err, "")
+			}
+			var cache pkg.ResultCache
+			if cacheURL != "" {
+				if cache, err = pkg.OpenResultCache(context.Background(), cacheURL); err != nil {
+					logger.Error(err, "opening result cache")
+					panic(err)
 				}
+				defer cache.Close()
+			}
+			adminServer, err := admin.Start(&adminConfig)
+			if err != nil {
+				logger.Error(err, "starting admin server")
+				panic(err)
+			}
+			if adminServer != nil {
+				defer adminServer.Close()
+				fmt.Printf("Admin server listening on %s\n", adminAddr)
+			}
+
+			// The admin handlers are registered on http.DefaultServeMux by
+			// net/http/pprof, so they must not be served here.
+			mux := http.NewServeMux()
+			mux.Handle("/", &serveHandler{
+				o:          o,
+				logger:     logger,
+				tpl:        t,
+				signer:     signer,
+				tenants:    tenants,
+				transports: transports,
+				cache:      cache,
 			})
 			port := os.Getenv("PORT")
 			if port == "" {
@@ -97,7 +123,7 @@ func serveCmd(o *options.Options) *cobra.Command {
 			}
 			fmt.Printf("Listening on localhost:%s\n", port)
 			//nolint: gosec // unsused.
-			err = http.ListenAndServe(fmt.Sprintf("0.0.0.0:%s", port), nil)
+			err = http.ListenAndServe(fmt.Sprintf("0.0.0.0:%s", port), mux)
 			if err != nil {
 				// TODO(log): Should this actually panic?
 				logger.Error(err, "listening and serving")
@@ -105,6 +131,155 @@ func serveCmd(o *options.Options) *cobra.Command {
 			}
 		},
 	}
+	cmd.Flags().StringVar(&adminAddr, "admin-addr", "",
+		"address of the admin server exposing pprof and runtime metrics, e.g. localhost:6060, disabled if empty")
+	cmd.Flags().StringVar(&adminTokenFile, "admin-token-file", "",
+		"file holding the bearer token required by the admin server, needed on non-loopback addresses")
+	cmd.Flags().StringVar(&signingKeyFile, "signing-key", "",
+		"PEM file of the private key JSON results are signed with, served in DSSE envelopes, unsigned if empty")
+	cmd.Flags().StringVar(&tenantsFile, "tenants", "",
+		"YAML file of the tenants whose API keys are required, each with its own GitHub tokens, "+
+			"rate limit and namespace of cached results, open to all if empty")
+	cmd.Flags().StringVar(&cacheURL, "cache", "",
+		"URL of a cache to reuse results from, e.g. file:///dir, gs://bucket or redis://host:port, "+
+			"namespaced by tenant")
+	return cmd
+}
+
+// serveHandler serves the results of the repo of the requests, signed if it
+// has a signer.
+type serveHandler struct {
+	o          *options.Options
+	logger     *log.Logger
+	tpl        *template.Template
+	signer     crypto.Signer
+	tenants    *tenant.Registry
+	transports map[*tenant.Tenant]http.RoundTripper
+	cache      pkg.ResultCache
+	// run scans the repo, with the transport of the tenant if not nil. It
+	// defaults to runServedScorecard.
+	run func(ctx context.Context, repo clients.Repo, transport http.RoundTripper,
+		opts []pkg.Option) (pkg.ScorecardResult, error)
+}
+
+func (h *serveHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var runOpts []pkg.Option
+	var tenantTransport http.RoundTripper
+	resultCache := h.cache
+	if h.tenants != nil {
+		t := h.tenants.Authenticate(r)
+		if t == nil {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if !t.Allow() {
+			rw.Header().Set("Retry-After", "60")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		tenantTransport = h.transports[t]
+		if h.cache != nil {
+			resultCache = pkg.NamespacedResultCache(h.cache, t.Namespace)
+		}
+	}
+	if resultCache != nil {
+		runOpts = append(runOpts, pkg.WithResultCache(resultCache, ""))
+	}
+
+	repoParam := r.URL.Query().Get("repo")
+	const length = 3
+	s := strings.SplitN(repoParam, "/", length)
+	if len(s) != length {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	repo, err := githubrepo.MakeGithubRepo(repoParam)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	run := h.run
+	if run == nil {
+		run = h.runServedScorecard
+	}
+	repoResult, err := run(r.Context(), repo, tenantTransport, runOpts)
+	if err != nil {
+		h.logger.Error(err, "running enabled scorecard checks on repo")
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if r.Header.Get("Content-Type") == "application/json" && h.signer != nil {
+		if err := writeSignedResult(rw, &repoResult, h.o, h.signer); err != nil {
+			h.logger.Error(err, "signing result")
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	if r.Header.Get("Content-Type") == "application/json" {
+		if err := repoResult.AsJSON(h.o.ShowDetails, log.ParseLevel(h.o.LogLevel), rw); err != nil {
+			// TODO(log): Improve error message
+			h.logger.Error(err, "")
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	if err := h.tpl.Execute(rw, repoResult); err != nil {
+		// TODO(log): Improve error message
+		h.logger.Error(err, "")
+	}
+}
+
+// runServedScorecard runs all the checks on the repo with the GitHub clients.
+func (h *serveHandler) runServedScorecard(ctx context.Context, repo clients.Repo, transport http.RoundTripper,
+	opts []pkg.Option,
+) (pkg.ScorecardResult, error) {
+	var repoClient clients.RepoClient
+	if transport != nil {
+		repoClient = githubrepo.CreateGithubRepoClientWithTransport(ctx, transport)
+	} else {
+		repoClient = githubrepo.CreateGithubRepoClient(ctx, h.logger)
+	}
+	ossFuzzRepoClient, err := ossfuzz.CreateOSSFuzzClientEager(ossfuzz.StatusURL)
+	if err != nil {
+		return pkg.ScorecardResult{}, fmt.Errorf("initializing clients: %w", err)
+	}
+	defer ossFuzzRepoClient.Close()
+	vulnsClient := clients.DefaultVulnerabilitiesClient()
+	ciiClient := clients.DefaultCIIBestPracticesClient()
+	//nolint:wrapcheck
+	return pkg.RunScorecard(ctx, repo, clients.HeadSHA /*commitSHA*/, h.o.CommitDepth, checks.GetAll(),
+		repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, opts...)
+}
+
+func readSigningKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+	signer, err := dsse.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("dsse.ParsePrivateKey: %w", err)
+	}
+	return signer, nil
+}
+
+// writeSignedResult writes the JSON result in a DSSE envelope signed with
+// signer, which `scorecard verify` checks.
+func writeSignedResult(w http.ResponseWriter, r *pkg.ScorecardResult, o *options.Options, signer crypto.Signer) error {
+	var payload bytes.Buffer
+	if err := r.AsJSON(o.ShowDetails, log.ParseLevel(o.LogLevel), &payload); err != nil {
+		return fmt.Errorf("AsJSON: %w", err)
+	}
+	env, err := dsse.Sign(dsse.PayloadTypeResult, payload.Bytes(), signer)
+	if err != nil {
+		return fmt.Errorf("dsse.Sign: %w", err)
+	}
+	w.Header().Set("Content-Type", dsse.MediaType)
+	if err := json.NewEncoder(w).Encode(env); err != nil {
+		return fmt.Errorf("json.Encode: %w", err)
+	}
+	return nil
 }
 
 const tpl = `
@@ -0,0 +1,101 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/dsse"
+	"github.com/ossf/scorecard/v4/log"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+func TestServeHandlerSigning(t *testing.T) {
+	t.Parallel()
+	errScan := errors.New("scan failed")
+	tests := []struct {
+		name       string
+		repo       string
+		err        error
+		wantStatus int
+		wantSigned bool
+	}{
+		{
+			name:       "signed result",
+			repo:       "github.com/owner/repo",
+			wantStatus: http.StatusOK,
+			wantSigned: true,
+		},
+		{
+			name:       "invalid repo",
+			repo:       "owner",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "scan failed",
+			repo:       "github.com/owner/repo",
+			err:        errScan,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if err != nil {
+				t.Fatalf("ecdsa.GenerateKey: %v", err)
+			}
+			h := &serveHandler{
+				o:      &options.Options{LogLevel: options.DefaultLogLevel},
+				logger: log.NewLogger(log.DefaultLevel),
+				signer: key,
+				run: func(context.Context, clients.Repo, http.RoundTripper, []pkg.Option) (pkg.ScorecardResult, error) {
+					return pkg.ScorecardResult{Repo: pkg.RepoInfo{Name: tt.repo}}, tt.err
+				},
+			}
+			req := httptest.NewRequest(http.MethodGet, "/?repo="+tt.repo, nil)
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if !tt.wantSigned {
+				if rec.Body.Len() != 0 {
+					t.Errorf("body = %q, want nothing signed", rec.Body.String())
+				}
+				return
+			}
+			var envelope dsse.Envelope
+			if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+				t.Fatalf("json.Unmarshal: %v", err)
+			}
+			if envelope.PayloadType != dsse.PayloadTypeResult || len(envelope.Signatures) != 1 {
+				t.Errorf("envelope = %+v, want a signed result", envelope)
+			}
+		})
+	}
+}
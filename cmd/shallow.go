@@ -0,0 +1,36 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/ossf/scorecard/v4/checks"
+)
+
+// shallowChecks are the checks which mostly look for the presence of files,
+// and so can be answered from the git tree of a repo by shallow scans.
+var shallowChecks = []string{checks.CheckSecurityPolicy, checks.CheckLicense, checks.CheckDependencyUpdateTool}
+
+var (
+	errShallowChecks     = errors.New("check is not supported by shallow scans")
+	errShallowGitHubOnly = errors.New("shallow scans are only supported for GitHub repos")
+)
+
+// shallowChecksToRun returns the checks to run by a shallow scan, which are
+// all the shallow checks unless a subset of them was requested.
+func shallowChecksToRun(requested []string) ([]string, error) {
+	return restrictChecks(requested, shallowChecks, errShallowChecks)
+}
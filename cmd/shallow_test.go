@@ -0,0 +1,60 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestShallowChecksToRun(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err       error
+		name      string
+		requested []string
+		want      []string
+	}{
+		{
+			name: "all shallow checks by default",
+			want: []string{"Security-Policy", "License", "Dependency-Update-Tool"},
+		},
+		{
+			name:      "subset of the shallow checks",
+			requested: []string{"Dependency-Update-Tool"},
+			want:      []string{"Dependency-Update-Tool"},
+		},
+		{
+			name:      "check which needs the content of all files",
+			requested: []string{"License", "Pinned-Dependencies"},
+			err:       errShallowChecks,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := shallowChecksToRun(tt.requested)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("shallowChecksToRun() error = %v, want %v", err, tt.err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("shallowChecksToRun() (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+#!/bin/sh
+# Replaces the results with the environment post-process commands get.
+cat > /dev/null
+printf '{"repo":"%s","commit":"%s","format":"%s"}' "$SCORECARD_REPO" "$SCORECARD_COMMIT" "$SCORECARD_FORMAT"
@@ -0,0 +1,162 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v4/dsse"
+)
+
+// verifyTimeout bounds the fetch of a result served over http(s).
+const verifyTimeout = 5 * time.Minute
+
+var (
+	errVerifyKeyMustBeSet = errors.New("`key` must be set")
+	errVerifyFetch        = errors.New("fetching the signed result failed")
+)
+
+func verifyCmd() *cobra.Command {
+	var keyFile, apiKeyFile, output string
+	cmd := &cobra.Command{
+		Use:   "verify --key=<public key> <file|url|->",
+		Short: "Verify the signature of a result signed by scorecard serve",
+		Long: `Verify checks the DSSE envelope of a result signed by ` + "`scorecard serve --signing-key`" + `,
+read from a file, fetched from a URL, e.g. http://host:8080/?repo=github.com/owner/repo,
+or read from stdin with "-", against a PEM public key, and writes the verified
+JSON result. It fails if the signature is not valid, so that results from a
+cache or a third party can be trusted in gating decisions.`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if keyFile == "" {
+				return errVerifyKeyMustBeSet
+			}
+			cmd.SilenceUsage = true
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w := os.Stdout
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("os.Create: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+			var apiKey string
+			if apiKeyFile != "" {
+				content, err := os.ReadFile(apiKeyFile)
+				if err != nil {
+					return fmt.Errorf("os.ReadFile: %w", err)
+				}
+				apiKey = strings.TrimSpace(string(content))
+			}
+			return runVerify(cmd.Context(), keyFile, apiKey, args[0], w)
+		},
+	}
+	cmd.Flags().StringVar(&keyFile, "key", keyFile, "PEM file of the public key the result must be signed with")
+	cmd.Flags().StringVar(&apiKeyFile, "api-key-file", apiKeyFile,
+		"file holding the API key of the tenant the result is fetched for, when scorecard serve has --tenants")
+	cmd.Flags().StringVar(&output, "output", output, "file to write the verified result to, defaults to stdout")
+	return cmd
+}
+
+func runVerify(ctx context.Context, keyFile, apiKey, source string, w io.Writer) error {
+	keyData, err := os.ReadFile(keyFile)
+	if err != nil {
+		return fmt.Errorf("os.ReadFile: %w", err)
+	}
+	pub, err := dsse.ParsePublicKey(keyData)
+	if err != nil {
+		return fmt.Errorf("dsse.ParsePublicKey: %w", err)
+	}
+	data, err := readSignedResult(ctx, apiKey, source)
+	if err != nil {
+		return err
+	}
+	var env dsse.Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	payload, err := dsse.Verify(&env, dsse.PayloadTypeResult, pub)
+	if err != nil {
+		return fmt.Errorf("dsse.Verify: %w", err)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return fmt.Errorf("Write: %w", err)
+	}
+	return nil
+}
+
+// readSignedResult reads the envelope from source, a file, a http(s) URL of
+// scorecard serve, fetched with apiKey if set, or "-" for stdin.
+func readSignedResult(ctx context.Context, apiKey, source string) ([]byte, error) {
+	switch {
+	case source == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("io.ReadAll: %w", err)
+		}
+		return data, nil
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		return fetchSignedResult(ctx, apiKey, source)
+	default:
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("os.ReadFile: %w", err)
+		}
+		return data, nil
+	}
+}
+
+func fetchSignedResult(ctx context.Context, apiKey, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, verifyTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	// scorecard serve answers with JSON, signed if it has a signing key,
+	// based on the Content-Type of the request.
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", dsse.MediaType)
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errVerifyFetch, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s: %s", errVerifyFetch, url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errVerifyFetch, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ossf/scorecard/v4/dsse"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+func writePublicKey(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("x509.MarshalPKIXPublicKey: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pub")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestVerifyServedResult(t *testing.T) {
+	t.Parallel()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	result := pkg.ScorecardResult{Repo: pkg.RepoInfo{Name: "github.com/owner/repo"}}
+	o := &options.Options{LogLevel: options.DefaultLogLevel}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" || r.Header.Get("Authorization") != "Bearer api-key" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := writeSignedResult(w, &result, o, key); err != nil {
+			t.Errorf("writeSignedResult: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	url := server.URL + "/?repo=github.com/owner/repo"
+	if err := runVerify(context.Background(), writePublicKey(t, key), "api-key", url, &out); err != nil {
+		t.Fatalf("runVerify: %v", err)
+	}
+	if !strings.Contains(out.String(), `"github.com/owner/repo"`) {
+		t.Errorf("runVerify() wrote %s", out.String())
+	}
+
+	// A result signed with another key is rejected.
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	if err := runVerify(context.Background(), writePublicKey(t, other), "api-key", url, &out); err == nil {
+		t.Error("runVerify() with another key succeeded")
+	}
+}
+
+func TestVerifyTamperedFile(t *testing.T) {
+	t.Parallel()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	env, err := dsse.Sign(dsse.PayloadTypeResult, []byte(`{"score":3}`), key)
+	if err != nil {
+		t.Fatalf("dsse.Sign: %v", err)
+	}
+	env.Payload = []byte(`{"score":10}`)
+	data, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "result.dsse.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	var out bytes.Buffer
+	if err := runVerify(context.Background(), writePublicKey(t, key), "", path, &out); err == nil {
+		t.Errorf("runVerify() of a tampered result succeeded: %s", out.String())
+	}
+}
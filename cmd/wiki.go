@@ -0,0 +1,53 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checks"
+)
+
+// wikiChecks are the checks which only need the files of a repo, and so can
+// be run on a wiki cloned to a local directory.
+var wikiChecks = []string{checks.CheckSecurityPolicy, checks.CheckLicense}
+
+var errWikiChecks = errors.New("check is not supported for wikis")
+
+// wikiChecksToRun returns the checks to run on a wiki, which are all the
+// wiki checks unless a subset of them was requested.
+func wikiChecksToRun(requested []string) ([]string, error) {
+	return restrictChecks(requested, wikiChecks, errWikiChecks)
+}
+
+// restrictChecks returns the supported checks, or the requested ones if they
+// are all supported, for scans which can only run some checks.
+func restrictChecks(requested, supported []string, errUnsupported error) ([]string, error) {
+	if len(requested) == 0 {
+		return supported, nil
+	}
+	for _, name := range requested {
+		ok := false
+		for _, c := range supported {
+			ok = ok || strings.EqualFold(name, c)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: %s, expected one of %s", errUnsupported, name, strings.Join(supported, ", "))
+		}
+	}
+	return requested, nil
+}
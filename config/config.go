@@ -0,0 +1,109 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config parses the configuration file maintainers can commit to
+// their repo to provide context on Scorecard results, such as annotations
+// and the declared maintenance status, and the custom rules
+// security teams can check workflows against.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+// Files are the paths, relative to the root of a repo, searched for the
+// config in order. Only the first one found is used.
+var Files = []string{
+	"scorecard.yml",
+	".scorecard.yml",
+	".github/scorecard.yml",
+}
+
+var errInvalidConfig = errors.New("invalid scorecard config")
+
+// Annotation is a maintainer response to the results of one or more checks,
+// e.g. to explain why a finding does not apply. Annotations are shown
+// alongside the results and never change scores.
+type Annotation struct {
+	// Reason is the free-text explanation of the maintainer.
+	Reason string `yaml:"reason" json:"reason"`
+	// Checks are the names of the checks the annotation applies to.
+	Checks []string `yaml:"checks" json:"checks"`
+}
+
+// Maintenance is the maintenance status declared by the maintainers, e.g.
+// that the project is feature-complete and only receives security fixes.
+// It is shown by the Maintained check so low activity can be interpreted.
+type Maintenance struct {
+	// Status is one of "active", "feature-complete", "security-fixes-only"
+	// or "unmaintained".
+	Status string `yaml:"status" json:"status"`
+	// Reason is an optional free-text explanation of the status.
+	Reason string `yaml:"reason" json:"reason,omitempty"`
+}
+
+// Config is the content of a repo's scorecard config file.
+type Config struct {
+	Annotations []Annotation `yaml:"annotations" json:"annotations,omitempty"`
+	Maintenance *Maintenance `yaml:"maintenance" json:"maintenance,omitempty"`
+}
+
+// Parse reads and validates a config. An empty file is a valid, empty config.
+func Parse(r io.Reader) (Config, error) {
+	var c Config
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, fmt.Errorf("%w: %v", errInvalidConfig, err)
+	}
+	for i, a := range c.Annotations {
+		if len(a.Checks) == 0 {
+			return Config{}, fmt.Errorf("%w: annotation %d does not list any checks", errInvalidConfig, i)
+		}
+		if strings.TrimSpace(a.Reason) == "" {
+			return Config{}, fmt.Errorf("%w: annotation %d has no reason", errInvalidConfig, i)
+		}
+	}
+	if c.Maintenance != nil {
+		switch checker.MaintenanceStatus(c.Maintenance.Status) {
+		case checker.MaintenanceActive, checker.MaintenanceFeatureComplete,
+			checker.MaintenanceSecurityFixesOnly, checker.MaintenanceUnmaintained:
+		default:
+			return Config{}, fmt.Errorf("%w: unknown maintenance status %q", errInvalidConfig, c.Maintenance.Status)
+		}
+	}
+	return c, nil
+}
+
+// AnnotationsFor returns the reasons of the annotations which apply to a
+// check. Check names are compared case-insensitively.
+func (c Config) AnnotationsFor(check string) []string {
+	var reasons []string
+	for _, a := range c.Annotations {
+		for _, name := range a.Checks {
+			if strings.EqualFold(name, check) {
+				reasons = append(reasons, strings.TrimSpace(a.Reason))
+				break
+			}
+		}
+	}
+	return reasons
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		file    string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			file: "testdata/valid.yml",
+			want: Config{
+				Annotations: []Annotation{
+					{
+						Checks: []string{"Binary-Artifacts"},
+						Reason: "the .jar files are test fixtures",
+					},
+					{
+						Checks: []string{"binary-artifacts", "Pinned-Dependencies"},
+						Reason: "vendored from upstream, see third_party/README.md\n",
+					},
+				},
+			},
+		},
+		{
+			name: "empty",
+			file: "testdata/empty.yml",
+		},
+		{
+			name:    "unknown field",
+			file:    "testdata/unknown_field.yml",
+			wantErr: true,
+		},
+		{
+			name:    "no checks",
+			file:    "testdata/no_checks.yml",
+			wantErr: true,
+		},
+		{
+			name: "maintenance",
+			file: "testdata/maintenance.yml",
+			want: Config{
+				Maintenance: &Maintenance{
+					Status: "security-fixes-only",
+					Reason: "the library is feature-complete, only security fixes are released",
+				},
+			},
+		},
+		{
+			name:    "unknown maintenance status",
+			file:    "testdata/unknown_maintenance_status.yml",
+			wantErr: true,
+		},
+		{
+			name:    "no reason",
+			file:    "testdata/no_reason.yml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatalf("os.Open: %v", err)
+			}
+			defer f.Close()
+			got, err := Parse(f)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAnnotationsFor(t *testing.T) {
+	t.Parallel()
+	f, err := os.Open("testdata/valid.yml")
+	if err != nil {
+		t.Fatalf("os.Open: %v", err)
+	}
+	defer f.Close()
+	c, err := Parse(f)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	want := []string{
+		"the .jar files are test fixtures",
+		"vendored from upstream, see third_party/README.md",
+	}
+	if diff := cmp.Diff(want, c.AnnotationsFor("Binary-Artifacts")); diff != "" {
+		t.Errorf("AnnotationsFor() mismatch (-want +got):\n%s", diff)
+	}
+	if got := c.AnnotationsFor("Code-Review"); got != nil {
+		t.Errorf("AnnotationsFor(Code-Review) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/rule"
+)
+
+var errInvalidCustomProbes = errors.New("invalid custom probes")
+
+type customProbeCondition struct {
+	Regex    string `yaml:"regex"`
+	YAMLPath string `yaml:"yamlPath"`
+	Value    string `yaml:"value"`
+}
+
+type customProbeRemediation struct {
+	Text   string                 `yaml:"text"`
+	Effort rule.RemediationEffort `yaml:"effort"`
+}
+
+type customProbe struct {
+	Remediation *customProbeRemediation `yaml:"remediation"`
+	Risk        *rule.Risk              `yaml:"risk"`
+	ID          string                  `yaml:"id"`
+	Description string                  `yaml:"description"`
+	Check       string                  `yaml:"check"`
+	OnMatch     string                  `yaml:"onMatch"`
+	OnNoMatch   string                  `yaml:"onNoMatch"`
+	Files       []string                `yaml:"files"`
+	Conditions  []customProbeCondition  `yaml:"conditions"`
+}
+
+type customProbes struct {
+	Probes []customProbe `yaml:"probes"`
+}
+
+var outcomes = map[string]finding.Outcome{
+	"positive":      finding.OutcomePositive,
+	"negative":      finding.OutcomeNegative,
+	"notApplicable": finding.OutcomeNotApplicable,
+}
+
+// ParseCustomProbes reads the probes users define without writing Go. Each
+// probe has an id, the check it is reported with, the globs of the files it
+// looks at and optional conditions on their content, each one of:
+//
+//   - regex: a regexp the content must match,
+//   - yamlPath: a dot-separated path to a node of a YAML file, "*" matching
+//     any key or item, with an optional value regexp for the scalar there.
+//
+// A file matches if all conditions hold. onMatch is the outcome of the files
+// which match, positive by default, and onNoMatch the outcome if none does,
+// the opposite of onMatch by default.
+func ParseCustomProbes(r io.Reader) ([]checker.CustomProbe, error) {
+	var cp customProbes
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cp); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", errInvalidCustomProbes, err)
+	}
+
+	ids := make(map[string]bool)
+	probes := make([]checker.CustomProbe, 0, len(cp.Probes))
+	for i := range cp.Probes {
+		p := &cp.Probes[i]
+		if strings.TrimSpace(p.ID) == "" {
+			return nil, fmt.Errorf("%w: probe %d has no id", errInvalidCustomProbes, i)
+		}
+		if ids[p.ID] {
+			return nil, fmt.Errorf("%w: probe '%s' is defined more than once", errInvalidCustomProbes, p.ID)
+		}
+		ids[p.ID] = true
+		probe, err := parseCustomProbe(p)
+		if err != nil {
+			return nil, fmt.Errorf("%w: probe '%s': %v", errInvalidCustomProbes, p.ID, err)
+		}
+		probes = append(probes, probe)
+	}
+	return probes, nil
+}
+
+func parseCustomProbe(p *customProbe) (checker.CustomProbe, error) {
+	probe := checker.CustomProbe{
+		ID:          p.ID,
+		Description: strings.TrimSpace(p.Description),
+		Check:       p.Check,
+		Files:       p.Files,
+		Risk:        rule.RiskMedium,
+	}
+	if p.Check == "" {
+		//nolint:goerr113
+		return probe, errors.New("no check")
+	}
+	if len(p.Files) == 0 {
+		//nolint:goerr113
+		return probe, errors.New("no files")
+	}
+	for _, glob := range p.Files {
+		if err := fileparser.ValidatePattern(glob); err != nil {
+			return probe, fmt.Errorf("files: %w", err)
+		}
+	}
+	for i, c := range p.Conditions {
+		condition, err := parseCustomProbeCondition(c)
+		if err != nil {
+			return probe, fmt.Errorf("condition %d: %w", i, err)
+		}
+		probe.Conditions = append(probe.Conditions, condition)
+	}
+
+	var ok bool
+	probe.OnMatch = finding.OutcomePositive
+	if p.OnMatch != "" {
+		if probe.OnMatch, ok = outcomes[p.OnMatch]; !ok {
+			//nolint:goerr113
+			return probe, fmt.Errorf("unknown onMatch outcome '%s'", p.OnMatch)
+		}
+	}
+	probe.OnNoMatch = finding.OutcomeNegative
+	if probe.OnMatch == finding.OutcomeNegative {
+		probe.OnNoMatch = finding.OutcomePositive
+	}
+	if p.OnNoMatch != "" {
+		if probe.OnNoMatch, ok = outcomes[p.OnNoMatch]; !ok {
+			//nolint:goerr113
+			return probe, fmt.Errorf("unknown onNoMatch outcome '%s'", p.OnNoMatch)
+		}
+	}
+
+	if p.Risk != nil {
+		probe.Risk = *p.Risk
+	}
+	if p.Remediation != nil {
+		probe.Remediation = &rule.Remediation{
+			Text:   strings.TrimSpace(p.Remediation.Text),
+			Effort: p.Remediation.Effort,
+		}
+	}
+	return probe, nil
+}
+
+func parseCustomProbeCondition(c customProbeCondition) (checker.CustomProbeCondition, error) {
+	var condition checker.CustomProbeCondition
+	switch {
+	case (c.Regex == "") == (c.YAMLPath == ""):
+		//nolint:goerr113
+		return condition, errors.New("must set exactly one of regex and yamlPath")
+	case c.Regex != "" && c.Value != "":
+		//nolint:goerr113
+		return condition, errors.New("value is only allowed with yamlPath")
+	}
+
+	if c.Regex != "" {
+		re, err := regexp.Compile(c.Regex)
+		if err != nil {
+			return condition, fmt.Errorf("regex: %w", err)
+		}
+		condition.Regex = re
+		return condition, nil
+	}
+
+	condition.YAMLPath = strings.Split(c.YAMLPath, ".")
+	for _, key := range condition.YAMLPath {
+		if key == "" {
+			//nolint:goerr113
+			return condition, fmt.Errorf("yamlPath '%s' has an empty key", c.YAMLPath)
+		}
+	}
+	if c.Value != "" {
+		re, err := regexp.Compile(c.Value)
+		if err != nil {
+			return condition, fmt.Errorf("value: %w", err)
+		}
+		condition.Value = re
+	}
+	return condition, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/rule"
+)
+
+func TestParseCustomProbes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		file    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			file: "testdata/custom_probes/valid.yml",
+			want: []string{"codeowners", "no-curl-bash", "write-all"},
+		},
+		{
+			name: "empty",
+			file: "testdata/empty.yml",
+		},
+		{
+			name:    "regex and yamlPath",
+			file:    "testdata/custom_probes/two_conditions.yml",
+			wantErr: true,
+		},
+		{
+			name:    "no files",
+			file:    "testdata/custom_probes/no_files.yml",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate id",
+			file:    "testdata/custom_probes/duplicate.yml",
+			wantErr: true,
+		},
+		{
+			name:    "unknown outcome",
+			file:    "testdata/custom_probes/unknown_outcome.yml",
+			wantErr: true,
+		},
+		{
+			name:    "invalid glob",
+			file:    "testdata/custom_probes/invalid_glob.yml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatalf("os.Open: %v", err)
+			}
+			defer f.Close()
+			probes, err := ParseCustomProbes(f)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCustomProbes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var ids []string
+			for i := range probes {
+				ids = append(ids, probes[i].ID)
+			}
+			if diff := cmp.Diff(tt.want, ids); diff != "" {
+				t.Errorf("ParseCustomProbes() ids (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseCustomProbesDefaults(t *testing.T) {
+	t.Parallel()
+	f, err := os.Open("testdata/custom_probes/valid.yml")
+	if err != nil {
+		t.Fatalf("os.Open: %v", err)
+	}
+	defer f.Close()
+	probes, err := ParseCustomProbes(f)
+	if err != nil {
+		t.Fatalf("ParseCustomProbes: %v", err)
+	}
+
+	type outcomes struct {
+		OnMatch, OnNoMatch finding.Outcome
+		Risk               rule.Risk
+	}
+	want := []outcomes{
+		{finding.OutcomePositive, finding.OutcomeNegative, rule.RiskMedium},
+		{finding.OutcomeNegative, finding.OutcomePositive, rule.RiskHigh},
+		{finding.OutcomeNegative, finding.OutcomeNotApplicable, rule.RiskMedium},
+	}
+	got := make([]outcomes, 0, len(probes))
+	for i := range probes {
+		got = append(got, outcomes{probes[i].OnMatch, probes[i].OnNoMatch, probes[i].Risk})
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseCustomProbes() outcomes (-want +got): %s", diff)
+	}
+	if r := probes[1].Remediation; r == nil || r.Effort != rule.RemediationEffortLow {
+		t.Errorf("ParseCustomProbes() remediation = %v", r)
+	}
+	if c := probes[2].Conditions[0]; cmp.Diff([]string{"permissions"}, c.YAMLPath) != "" || c.Value == nil {
+		t.Errorf("ParseCustomProbes() condition = %v", c)
+	}
+}
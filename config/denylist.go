@@ -0,0 +1,96 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+var errInvalidDenylist = errors.New("invalid denylist")
+
+var denylistEcosystems = map[string]checker.DenylistEcosystem{
+	string(checker.DenylistGitHubActions): checker.DenylistGitHubActions,
+	string(checker.DenylistNPM):           checker.DenylistNPM,
+	string(checker.DenylistPyPI):          checker.DenylistPyPI,
+	string(checker.DenylistGo):            checker.DenylistGo,
+	string(checker.DenylistCratesIO):      checker.DenylistCratesIO,
+	string(checker.DenylistRubyGems):      checker.DenylistRubyGems,
+}
+
+type denylistEntry struct {
+	ID          string   `yaml:"id"`
+	Description string   `yaml:"description"`
+	Ecosystem   string   `yaml:"ecosystem"`
+	Name        string   `yaml:"name"`
+	Versions    []string `yaml:"versions"`
+}
+
+type denylist struct {
+	Entries []denylistEntry `yaml:"entries"`
+}
+
+// ParseDenylist reads a feed of dependencies known to be malicious or
+// compromised, checked by Compromised-Dependencies. As JSON is YAML, feeds
+// may be written in either. Each entry has an id, e.g. of an advisory, a
+// description, an ecosystem (one of github-actions, npm, pypi, go, crates.io
+// and rubygems), the name of the package or the owner/repo of the action,
+// and the compromised versions: all versions if none are listed.
+func ParseDenylist(r io.Reader) ([]checker.DenylistEntry, error) {
+	var dl denylist
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&dl); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", errInvalidDenylist, err)
+	}
+
+	ids := make(map[string]bool)
+	entries := make([]checker.DenylistEntry, 0, len(dl.Entries))
+	for i, e := range dl.Entries {
+		if strings.TrimSpace(e.ID) == "" {
+			return nil, fmt.Errorf("%w: entry %d has no id", errInvalidDenylist, i)
+		}
+		if ids[e.ID] {
+			return nil, fmt.Errorf("%w: entry '%s' is defined more than once", errInvalidDenylist, e.ID)
+		}
+		ids[e.ID] = true
+
+		ecosystem, ok := denylistEcosystems[e.Ecosystem]
+		if !ok {
+			return nil, fmt.Errorf("%w: entry '%s' has unknown ecosystem '%s'", errInvalidDenylist, e.ID, e.Ecosystem)
+		}
+		name := strings.TrimSpace(e.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: entry '%s' has no name", errInvalidDenylist, e.ID)
+		}
+		if ecosystem == checker.DenylistGitHubActions && strings.Count(name, "/") != 1 {
+			return nil, fmt.Errorf("%w: entry '%s' must name an action by owner/repo", errInvalidDenylist, e.ID)
+		}
+		entries = append(entries, checker.DenylistEntry{
+			ID:          e.ID,
+			Description: strings.TrimSpace(e.Description),
+			Ecosystem:   ecosystem,
+			Name:        name,
+			Versions:    e.Versions,
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseDenylist(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		file    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			file: "testdata/denylist/valid.yml",
+			want: []string{"GHSA-mrrh-fwg8-r2c3", "MAL-2021-event-stream", "ctx-2022"},
+		},
+		{
+			name: "json feed",
+			file: "testdata/denylist/valid.json",
+			want: []string{"MAL-2021-ua-parser-js"},
+		},
+		{
+			name: "empty",
+			file: "testdata/empty.yml",
+		},
+		{
+			name:    "duplicate id",
+			file:    "testdata/denylist/duplicate.yml",
+			wantErr: true,
+		},
+		{
+			name:    "unknown ecosystem",
+			file:    "testdata/denylist/unknown_ecosystem.yml",
+			wantErr: true,
+		},
+		{
+			name:    "action without owner",
+			file:    "testdata/denylist/invalid_action.yml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatalf("os.Open: %v", err)
+			}
+			defer f.Close()
+			entries, err := ParseDenylist(f)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDenylist() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(entries) != len(tt.want) {
+				t.Fatalf("ParseDenylist() returned %d entries, want %d", len(entries), len(tt.want))
+			}
+			for i, e := range entries {
+				if e.ID != tt.want[i] {
+					t.Errorf("entry %d: got id %q, want %q", i, e.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+var errInvalidWorkflowRules = errors.New("invalid workflow rules")
+
+type workflowRule struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description"`
+	Uses        string `yaml:"uses"`
+	Run         string `yaml:"run"`
+	RequireUses string `yaml:"requireUses"`
+}
+
+type workflowRules struct {
+	Rules []workflowRule `yaml:"rules"`
+}
+
+// ParseWorkflowRules reads the custom rules checked by Dangerous-Workflow,
+// which security teams can use to flag patterns specific to their
+// organization. Each rule has an id, a description and exactly one of:
+//
+//   - uses: a regexp for actions and reusable workflows that must not be used,
+//   - run: a regexp for scripts that must not be run,
+//   - requireUses: a regexp for an action every job with steps must use.
+func ParseWorkflowRules(r io.Reader) ([]checker.WorkflowRule, error) {
+	var wr workflowRules
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&wr); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", errInvalidWorkflowRules, err)
+	}
+
+	ids := make(map[string]bool)
+	rules := make([]checker.WorkflowRule, 0, len(wr.Rules))
+	for i, r := range wr.Rules {
+		if strings.TrimSpace(r.ID) == "" {
+			return nil, fmt.Errorf("%w: rule %d has no id", errInvalidWorkflowRules, i)
+		}
+		if ids[r.ID] {
+			return nil, fmt.Errorf("%w: rule '%s' is defined more than once", errInvalidWorkflowRules, r.ID)
+		}
+		ids[r.ID] = true
+
+		rule := checker.WorkflowRule{
+			ID:          r.ID,
+			Description: strings.TrimSpace(r.Description),
+		}
+		patterns := 0
+		for _, p := range []struct {
+			dst  **regexp.Regexp
+			expr string
+		}{
+			{&rule.Uses, r.Uses},
+			{&rule.Run, r.Run},
+			{&rule.RequireUses, r.RequireUses},
+		} {
+			if p.expr == "" {
+				continue
+			}
+			re, err := regexp.Compile(p.expr)
+			if err != nil {
+				return nil, fmt.Errorf("%w: rule '%s': %v", errInvalidWorkflowRules, r.ID, err)
+			}
+			*p.dst = re
+			patterns++
+		}
+		if patterns != 1 {
+			return nil, fmt.Errorf("%w: rule '%s' must set exactly one of uses, run and requireUses",
+				errInvalidWorkflowRules, r.ID)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
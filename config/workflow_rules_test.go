@@ -0,0 +1,78 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseWorkflowRules(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		file    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			file: "testdata/workflow_rules/valid.yml",
+			want: []string{"no-curl-bash", "banned-action", "harden-runner"},
+		},
+		{
+			name: "empty",
+			file: "testdata/empty.yml",
+		},
+		{
+			name:    "more than one pattern",
+			file:    "testdata/workflow_rules/two_patterns.yml",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate id",
+			file:    "testdata/workflow_rules/duplicate.yml",
+			wantErr: true,
+		},
+		{
+			name:    "invalid regexp",
+			file:    "testdata/workflow_rules/invalid_regexp.yml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := os.Open(tt.file)
+			if err != nil {
+				t.Fatalf("os.Open: %v", err)
+			}
+			defer f.Close()
+			rules, err := ParseWorkflowRules(f)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWorkflowRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(rules) != len(tt.want) {
+				t.Fatalf("ParseWorkflowRules() returned %d rules, want %d", len(rules), len(tt.want))
+			}
+			for i, r := range rules {
+				if r.ID != tt.want[i] {
+					t.Errorf("rule %d: got id %q, want %q", i, r.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -266,6 +266,12 @@ func GetRawResultDataBucketURL() (string, error) {
 	return getScorecardParam("raw-result-data-bucket-url")
 }
 
+// GetArchiveBucketURL returns the bucketURL for archiving cron job results as
+// compressed, content-addressed blobs. Results are not archived if it is empty.
+func GetArchiveBucketURL() (string, error) {
+	return getScorecardParam("archive-bucket-url")
+}
+
 // GetShardSize returns the shard_size for the cron job.
 func GetShardSize() (int, error) {
 	return getIntConfigValue(shardSize, configYAML, "ShardSize", "shard-size")
@@ -350,6 +356,21 @@ func GetScorecardValues() (map[string]string, error) {
 	return GetAdditionalParams("scorecard")
 }
 
+// GetDigestValues() returns a map of key, value pairs configuring the digest job.
+func GetDigestValues() (map[string]string, error) {
+	return GetAdditionalParams("digest")
+}
+
+// GetStaleResultsValues() returns a map of key, value pairs configuring the invalidation of stale results.
+func GetStaleResultsValues() (map[string]string, error) {
+	return GetAdditionalParams("stale-results")
+}
+
+// GetExportValues() returns a map of key, value pairs configuring how results are exported to BigQuery.
+func GetExportValues() (map[string]string, error) {
+	return GetAdditionalParams("export")
+}
+
 // GetCriticalityValues() returns a map of key, value pairs containing additional, criticality specific values.
 func GetCriticalityValues() (map[string]string, error) {
 	return GetAdditionalParams("criticality")
@@ -55,14 +55,35 @@ var (
 	}
 	prodScorecardParams = map[string]string{
 		"api-results-bucket-url":     prodAPIBucketURL,
+		"archive-bucket-url":         "",
 		"blacklisted-checks":         prodBlacklistedChecks,
 		"cii-data-bucket-url":        prodCIIDataBucket,
 		"raw-bigquery-table":         prodRawBigQueryTable,
 		"raw-result-data-bucket-url": prodRawBucket,
 	}
+	prodDigestParams = map[string]string{
+		"repos-bucket-url":    "gs://ossf-scorecard-digest",
+		"repos-file":          "repos.txt",
+		"output-bucket-url":   "gs://ossf-scorecard-digest",
+		"snapshot-bucket-url": "gs://ossf-scorecard-digest?prefix=snapshots/",
+		"title":               "Scorecard weekly digest",
+		"feed-url":            "https://storage.googleapis.com/ossf-scorecard-digest/feed.atom",
+	}
+	prodStaleResultsParams = map[string]string{
+		"bucket-url":     "gs://ossf-scorecard-stale-results",
+		"webhook-secret": "",
+	}
+	prodExportParams = map[string]string{
+		"mode":               "full",
+		"staging-bucket-url": "gs://ossf-scorecard-export-staging",
+		"salt":               "",
+	}
 	prodAdditionalParams = map[string]map[string]string{
-		"input-bucket": prodInputBucketParams,
-		"scorecard":    prodScorecardParams,
+		"input-bucket":  prodInputBucketParams,
+		"scorecard":     prodScorecardParams,
+		"digest":        prodDigestParams,
+		"export":        prodExportParams,
+		"stale-results": prodStaleResultsParams,
 	}
 )
 
@@ -510,6 +531,24 @@ func TestGetAdditionalParams(t *testing.T) {
 			want:    prodScorecardParams,
 			wantErr: false,
 		},
+		{
+			name:    "digest values",
+			mapName: "digest",
+			want:    prodDigestParams,
+			wantErr: false,
+		},
+		{
+			name:    "stale results values",
+			mapName: "stale-results",
+			want:    prodStaleResultsParams,
+			wantErr: false,
+		},
+		{
+			name:    "export values",
+			mapName: "export",
+			want:    prodExportParams,
+			wantErr: false,
+		},
 		{
 			name:    "nonexistant value",
 			mapName: "this-value-should-never-exist",
@@ -0,0 +1,135 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// archiveBlobPrefix is the prefix of the content-addressed blobs of an
+	// archive, named after the SHA-256 of their uncompressed content.
+	archiveBlobPrefix = "blobs/sha256/"
+	archiveBlobSuffix = ".zst"
+	// archiveManifestPrefix is the prefix of the manifests indexing the blobs
+	// of each shard.
+	archiveManifestPrefix = "manifests/"
+)
+
+var errArchiveBlobCorrupt = errors.New("archived blob does not match its key")
+
+// ArchiveEntry indexes the archived results of a repo.
+type ArchiveEntry struct {
+	Repo   string `json:"repo"`
+	Commit string `json:"commit"`
+	// Date is the date of the run, which is cleared from the archived results
+	// so unchanged results are stored once.
+	Date string `json:"date"`
+	// Result and Raw are the keys of the blobs of the JSON and raw JSON results.
+	Result string `json:"result"`
+	Raw    string `json:"raw"`
+}
+
+// ArchiveManifest indexes the archived results of a shard.
+type ArchiveManifest struct {
+	Entries []ArchiveEntry `json:"entries"`
+}
+
+// ArchiveBlobKey returns the key content is archived under.
+func ArchiveBlobKey(content []byte) string {
+	sum := sha256.Sum256(content)
+	return archiveBlobPrefix + hex.EncodeToString(sum[:]) + archiveBlobSuffix
+}
+
+// ArchiveManifestKey returns the key of the manifest of a shard.
+func ArchiveManifestKey(filename string) string {
+	return archiveManifestPrefix + filename + ".json"
+}
+
+// WriteArchiveBlob writes zstd-compressed content to bucketURL under its
+// content address and returns its key. Content which was archived before,
+// e.g. results which did not change since the previous run, is not written
+// again.
+func WriteArchiveBlob(ctx context.Context, bucketURL string, content []byte) (string, error) {
+	key := ArchiveBlobKey(content)
+	exists, err := BlobExists(ctx, bucketURL, key)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return key, nil
+	}
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return "", fmt.Errorf("error from zstd.NewWriter: %w", err)
+	}
+	defer encoder.Close()
+	if err := WriteToBlobStore(ctx, bucketURL, key, encoder.EncodeAll(content, nil)); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// ReadArchiveBlob returns the decompressed content of the blob at key.
+func ReadArchiveBlob(ctx context.Context, bucketURL, key string) ([]byte, error) {
+	compressed, err := GetBlobContent(ctx, bucketURL, key)
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error from zstd.NewReader: %w", err)
+	}
+	defer decoder.Close()
+	content, err := decoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during decoder.DecodeAll: %w", err)
+	}
+	if strings.HasPrefix(key, archiveBlobPrefix) && ArchiveBlobKey(content) != key {
+		return nil, fmt.Errorf("%w: %s", errArchiveBlobCorrupt, key)
+	}
+	return content, nil
+}
+
+// WriteArchiveManifest writes the manifest of the shard filename to bucketURL.
+func WriteArchiveManifest(ctx context.Context, bucketURL, filename string, manifest *ArchiveManifest) error {
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("error during json.Marshal: %w", err)
+	}
+	return WriteToBlobStore(ctx, bucketURL, ArchiveManifestKey(filename), content)
+}
+
+// ReadArchiveManifest returns the manifest of the shard filename.
+func ReadArchiveManifest(ctx context.Context, bucketURL, filename string) (*ArchiveManifest, error) {
+	content, err := GetBlobContent(ctx, bucketURL, ArchiveManifestKey(filename))
+	if err != nil {
+		return nil, err
+	}
+	var manifest ArchiveManifest
+	if err := json.NewDecoder(bytes.NewReader(content)).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("error during json.Decode: %w", err)
+	}
+	return &manifest, nil
+}
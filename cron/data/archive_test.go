@@ -0,0 +1,102 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestArchiveBlob(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bucketURL := "file:///" + t.TempDir()
+	content := []byte(`{"repo":{"name":"github.com/owner/repo"}}`)
+
+	key, err := WriteArchiveBlob(ctx, bucketURL, content)
+	if err != nil {
+		t.Fatalf("WriteArchiveBlob: %v", err)
+	}
+	if key != ArchiveBlobKey(content) {
+		t.Errorf("WriteArchiveBlob() = %s, want %s", key, ArchiveBlobKey(content))
+	}
+	// Unchanged content is stored once.
+	again, err := WriteArchiveBlob(ctx, bucketURL, content)
+	if err != nil {
+		t.Fatalf("WriteArchiveBlob: %v", err)
+	}
+	if again != key {
+		t.Errorf("WriteArchiveBlob() = %s, want %s", again, key)
+	}
+	keys, err := GetBlobKeys(ctx, bucketURL)
+	if err != nil {
+		t.Fatalf("GetBlobKeys: %v", err)
+	}
+	if diff := cmp.Diff([]string{key}, keys); diff != "" {
+		t.Errorf("GetBlobKeys() mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err := ReadArchiveBlob(ctx, bucketURL, key)
+	if err != nil {
+		t.Fatalf("ReadArchiveBlob: %v", err)
+	}
+	if diff := cmp.Diff(content, got); diff != "" {
+		t.Errorf("ReadArchiveBlob() mismatch (-want +got):\n%s", diff)
+	}
+
+	// A blob whose content does not match its key is rejected.
+	other := ArchiveBlobKey([]byte("other"))
+	compressed, err := GetBlobContent(ctx, bucketURL, key)
+	if err != nil {
+		t.Fatalf("GetBlobContent: %v", err)
+	}
+	if err := WriteToBlobStore(ctx, bucketURL, other, compressed); err != nil {
+		t.Fatalf("WriteToBlobStore: %v", err)
+	}
+	if _, err := ReadArchiveBlob(ctx, bucketURL, other); !errors.Is(err, errArchiveBlobCorrupt) {
+		t.Errorf("ReadArchiveBlob() error = %v, want %v", err, errArchiveBlobCorrupt)
+	}
+}
+
+func TestArchiveManifest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bucketURL := "file:///" + t.TempDir()
+	manifest := ArchiveManifest{
+		Entries: []ArchiveEntry{
+			{
+				Repo:   "github.com/owner/repo",
+				Commit: "abc",
+				Date:   "2023-03-06",
+				Result: ArchiveBlobKey([]byte("result")),
+				Raw:    ArchiveBlobKey([]byte("raw")),
+			},
+		},
+	}
+	filename := "2023.03.06/120000/shard-0000000"
+	if err := WriteArchiveManifest(ctx, bucketURL, filename, &manifest); err != nil {
+		t.Fatalf("WriteArchiveManifest: %v", err)
+	}
+	got, err := ReadArchiveManifest(ctx, bucketURL, filename)
+	if err != nil {
+		t.Fatalf("ReadArchiveManifest: %v", err)
+	}
+	if diff := cmp.Diff(&manifest, got); diff != "" {
+		t.Errorf("ReadArchiveManifest() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -23,12 +23,10 @@ import (
 	"time"
 
 	"gocloud.dev/blob"
-	// Needed to read file:/// buckets. Intended primarily for testing, though needed here for tests outside the package.
-	_ "gocloud.dev/blob/fileblob"
-	// Needed to link in GCP drivers.
-	_ "gocloud.dev/blob/gcsblob"
+	"gocloud.dev/gcerrors"
 
 	"github.com/ossf/scorecard/v4/cron/config"
+	"github.com/ossf/scorecard/v4/cron/internal/auth"
 )
 
 const (
@@ -63,9 +61,9 @@ func blobKeysPrefix(ctx context.Context, bucket *blob.Bucket, filePrefix string)
 // GetBlobKeysWithPrefix returns all object keys for a given bucketURL which start with prefix.
 // The prefix can be used to specify directories.
 func GetBlobKeysWithPrefix(ctx context.Context, bucketURL, filePrefix string) ([]string, error) {
-	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	bucket, err := auth.OpenBucket(ctx, bucketURL)
 	if err != nil {
-		return nil, fmt.Errorf("error from blob.OpenBucket: %w", err)
+		return nil, fmt.Errorf("error from auth.OpenBucket: %w", err)
 	}
 	defer bucket.Close()
 	return blobKeysPrefix(ctx, bucket, filePrefix)
@@ -78,9 +76,9 @@ func GetBlobKeys(ctx context.Context, bucketURL string) ([]string, error) {
 
 // GetBlobContent returns the file content given a bucketURL and object key.
 func GetBlobContent(ctx context.Context, bucketURL, key string) ([]byte, error) {
-	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	bucket, err := auth.OpenBucket(ctx, bucketURL)
 	if err != nil {
-		return nil, fmt.Errorf("error from blob.OpenBucket: %w", err)
+		return nil, fmt.Errorf("error from auth.OpenBucket: %w", err)
 	}
 	defer bucket.Close()
 
@@ -93,9 +91,9 @@ func GetBlobContent(ctx context.Context, bucketURL, key string) ([]byte, error)
 
 // BlobExists checks whether a given `bucketURL/key` blob exists.
 func BlobExists(ctx context.Context, bucketURL, key string) (bool, error) {
-	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	bucket, err := auth.OpenBucket(ctx, bucketURL)
 	if err != nil {
-		return false, fmt.Errorf("error from blob.OpenBucket: %w", err)
+		return false, fmt.Errorf("error from auth.OpenBucket: %w", err)
 	}
 	defer bucket.Close()
 
@@ -106,11 +104,30 @@ func BlobExists(ctx context.Context, bucketURL, key string) (bool, error) {
 	return ret, nil
 }
 
+// BlobModTime returns the time a given `bucketURL/key` blob was last modified.
+// The returned bool is false if the blob does not exist.
+func BlobModTime(ctx context.Context, bucketURL, key string) (time.Time, bool, error) {
+	bucket, err := auth.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("error from auth.OpenBucket: %w", err)
+	}
+	defer bucket.Close()
+
+	attrs, err := bucket.Attributes(ctx, key)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("error during bucket.Attributes: %w", err)
+	}
+	return attrs.ModTime, true, nil
+}
+
 // WriteToBlobStore creates and writes data to filename in bucketURL.
 func WriteToBlobStore(ctx context.Context, bucketURL, filename string, data []byte) error {
-	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	bucket, err := auth.OpenBucket(ctx, bucketURL)
 	if err != nil {
-		return fmt.Errorf("error from blob.OpenBucket: %w", err)
+		return fmt.Errorf("error from auth.OpenBucket: %w", err)
 	}
 	defer bucket.Close()
 
@@ -127,6 +144,20 @@ func WriteToBlobStore(ctx context.Context, bucketURL, filename string, data []by
 	return nil
 }
 
+// DeleteBlob deletes the `bucketURL/key` blob. Deleting a blob which does not exist is not an error.
+func DeleteBlob(ctx context.Context, bucketURL, key string) error {
+	bucket, err := auth.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return fmt.Errorf("error from auth.OpenBucket: %w", err)
+	}
+	defer bucket.Close()
+
+	if err := bucket.Delete(ctx, key); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+		return fmt.Errorf("error during bucket.Delete: %w", err)
+	}
+	return nil
+}
+
 // GetBlobFilename returns a blob key for a shard. Takes Time object and filename as input.
 func GetBlobFilename(filename string, datetime time.Time) string {
 	return datetime.Format(filePrefixFormat) + filename
@@ -173,3 +173,24 @@ func TestBlobKeysPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestBlobModTime(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bucketURL := "file:///" + t.TempDir()
+
+	if _, exists, err := BlobModTime(ctx, bucketURL, "owner/repo/results.json"); err != nil || exists {
+		t.Fatalf("BlobModTime() = %v, %v, want false, nil", exists, err)
+	}
+	before := time.Now().Add(-time.Minute)
+	if err := WriteToBlobStore(ctx, bucketURL, "owner/repo/results.json", []byte("{}")); err != nil {
+		t.Fatalf("WriteToBlobStore: %v", err)
+	}
+	modTime, exists, err := BlobModTime(ctx, bucketURL, "owner/repo/results.json")
+	if err != nil || !exists {
+		t.Fatalf("BlobModTime() = %v, %v, want true, nil", exists, err)
+	}
+	if modTime.Before(before) {
+		t.Errorf("BlobModTime() = %v, want after %v", modTime, before)
+	}
+}
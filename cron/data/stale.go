@@ -0,0 +1,106 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// staleMarkerPrefix is the prefix of the markers of repos whose results
+	// are stale and should be rescored before the next scheduled run.
+	staleMarkerPrefix = "stale/"
+	staleMarkerSuffix = ".json"
+)
+
+// StaleMarker records that the results of a repo are stale.
+type StaleMarker struct {
+	// Time is when the latest event making the results stale was received.
+	Time time.Time `json:"time"`
+	Repo string    `json:"repo"`
+	// Event is the type of the latest event, e.g. push or branch_protection_rule.
+	Event string `json:"event"`
+}
+
+// StaleMarkerKey returns the key of the marker of repo.
+func StaleMarkerKey(repo string) string {
+	return staleMarkerPrefix + repo + staleMarkerSuffix
+}
+
+// MarkStale writes marker to bucketURL. A marker replaces the previous marker
+// of the same repo, so a repo changing many times is rescored once.
+func MarkStale(ctx context.Context, bucketURL string, marker *StaleMarker) error {
+	content, err := json.Marshal(marker)
+	if err != nil {
+		return fmt.Errorf("error during json.Marshal: %w", err)
+	}
+	return WriteToBlobStore(ctx, bucketURL, StaleMarkerKey(marker.Repo), content)
+}
+
+// GetStaleMarkers returns the markers in bucketURL, sorted by repo.
+func GetStaleMarkers(ctx context.Context, bucketURL string) ([]StaleMarker, error) {
+	keys, err := GetBlobKeysWithPrefix(ctx, bucketURL, staleMarkerPrefix)
+	if err != nil {
+		return nil, err
+	}
+	markers := make([]StaleMarker, 0, len(keys))
+	for _, key := range keys {
+		if !strings.HasSuffix(key, staleMarkerSuffix) {
+			continue
+		}
+		marker, err := readStaleMarker(ctx, bucketURL, key)
+		if err != nil {
+			return nil, err
+		}
+		markers = append(markers, *marker)
+	}
+	sort.Slice(markers, func(i, j int) bool {
+		return markers[i].Repo < markers[j].Repo
+	})
+	return markers, nil
+}
+
+// ClearStale deletes the marker of a repo once its rescoring was requested.
+// The marker is kept if it was renewed after marker.Time, so events received
+// in the meantime are not lost.
+func ClearStale(ctx context.Context, bucketURL string, marker *StaleMarker) error {
+	key := StaleMarkerKey(marker.Repo)
+	current, err := readStaleMarker(ctx, bucketURL, key)
+	if err != nil {
+		return err
+	}
+	if current.Time.After(marker.Time) {
+		return nil
+	}
+	return DeleteBlob(ctx, bucketURL, key)
+}
+
+func readStaleMarker(ctx context.Context, bucketURL, key string) (*StaleMarker, error) {
+	content, err := GetBlobContent(ctx, bucketURL, key)
+	if err != nil {
+		return nil, err
+	}
+	var marker StaleMarker
+	if err := json.NewDecoder(bytes.NewReader(content)).Decode(&marker); err != nil {
+		return nil, fmt.Errorf("error during json.Decode of %s: %w", key, err)
+	}
+	return &marker, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStaleMarkers(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bucketURL := "file:///" + t.TempDir()
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	push := StaleMarker{Time: now, Repo: "github.com/owner/repo", Event: "push"}
+	other := StaleMarker{Time: now, Repo: "github.com/owner/another", Event: "repository"}
+	for _, marker := range []StaleMarker{push, other} {
+		marker := marker
+		if err := MarkStale(ctx, bucketURL, &marker); err != nil {
+			t.Fatalf("MarkStale: %v", err)
+		}
+	}
+	markers, err := GetStaleMarkers(ctx, bucketURL)
+	if err != nil {
+		t.Fatalf("GetStaleMarkers: %v", err)
+	}
+	if diff := cmp.Diff([]StaleMarker{other, push}, markers); diff != "" {
+		t.Errorf("GetStaleMarkers() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The repo changes again while it is rescored.
+	renewed := StaleMarker{Time: now.Add(time.Minute), Repo: push.Repo, Event: "branch_protection_rule"}
+	if err := MarkStale(ctx, bucketURL, &renewed); err != nil {
+		t.Fatalf("MarkStale: %v", err)
+	}
+	for i := range markers {
+		if err := ClearStale(ctx, bucketURL, &markers[i]); err != nil {
+			t.Fatalf("ClearStale: %v", err)
+		}
+	}
+	markers, err = GetStaleMarkers(ctx, bucketURL)
+	if err != nil {
+		t.Fatalf("GetStaleMarkers: %v", err)
+	}
+	if diff := cmp.Diff([]StaleMarker{renewed}, markers); diff != "" {
+		t.Errorf("GetStaleMarkers() after ClearStale mismatch (-want +got):\n%s", diff)
+	}
+}
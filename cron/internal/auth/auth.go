@@ -0,0 +1,235 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth resolves the Google Cloud credentials used by the cron job components.
+//
+// Credentials are always discovered through Application Default Credentials, which on
+// GKE resolve to the Google service account bound to the pod's Kubernetes service
+// account via Workload Identity. No JSON key file is needed. When
+// SCORECARD_IMPERSONATE_SERVICE_ACCOUNT is set, those credentials are used to mint
+// short-lived tokens for the named service account instead.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/fileblob"
+	"gocloud.dev/blob/gcsblob"
+	"gocloud.dev/blob/memblob"
+	"gocloud.dev/gcp"
+	"gocloud.dev/pubsub"
+	"gocloud.dev/pubsub/gcppubsub"
+	"gocloud.dev/pubsub/mempubsub"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+)
+
+const (
+	// EnvImpersonateServiceAccount is the email of the service account to impersonate.
+	EnvImpersonateServiceAccount = "SCORECARD_IMPERSONATE_SERVICE_ACCOUNT"
+	// EnvImpersonateDelegates is a comma-separated delegation chain used to reach
+	// the impersonated service account.
+	EnvImpersonateDelegates = "SCORECARD_IMPERSONATE_DELEGATES"
+
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+var (
+	credsOnce sync.Once
+	creds     *google.Credentials
+	errCreds  error
+
+	bucketMux = newBucketMux()
+	pubsubMux = newPubSubMux()
+)
+
+type impersonationConfig struct {
+	targetPrincipal string
+	delegates       []string
+}
+
+func readImpersonationConfig() impersonationConfig {
+	cfg := impersonationConfig{
+		targetPrincipal: strings.TrimSpace(os.Getenv(EnvImpersonateServiceAccount)),
+	}
+	for _, delegate := range strings.Split(os.Getenv(EnvImpersonateDelegates), ",") {
+		if delegate = strings.TrimSpace(delegate); delegate != "" {
+			cfg.delegates = append(cfg.delegates, delegate)
+		}
+	}
+	return cfg
+}
+
+// Credentials returns the credentials shared by all Google Cloud clients of the process.
+// They are resolved once, and are refreshed independently of any request context.
+func Credentials() (*google.Credentials, error) {
+	credsOnce.Do(func() {
+		creds, errCreds = findCredentials(context.Background(), readImpersonationConfig())
+	})
+	return creds, errCreds
+}
+
+func findCredentials(ctx context.Context, cfg impersonationConfig) (*google.Credentials, error) {
+	base, err := google.FindDefaultCredentials(ctx, cloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("error during google.FindDefaultCredentials: %w", err)
+	}
+	if cfg.targetPrincipal == "" {
+		return base, nil
+	}
+	ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: cfg.targetPrincipal,
+		Scopes:          []string{cloudPlatformScope},
+		Delegates:       cfg.delegates,
+	}, option.WithCredentials(base))
+	if err != nil {
+		return nil, fmt.Errorf("error during impersonate.CredentialsTokenSource: %w", err)
+	}
+	return &google.Credentials{
+		ProjectID:   base.ProjectID,
+		TokenSource: ts,
+	}, nil
+}
+
+// ClientOptions returns the options to pass to Google Cloud API clients.
+func ClientOptions() ([]option.ClientOption, error) {
+	c, err := Credentials()
+	if err != nil {
+		return nil, err
+	}
+	return []option.ClientOption{option.WithCredentials(c)}, nil
+}
+
+// OpenBucket opens bucketURL like blob.OpenBucket, authenticating gs:// buckets with Credentials.
+func OpenBucket(ctx context.Context, bucketURL string) (*blob.Bucket, error) {
+	bucket, err := bucketMux.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return nil, fmt.Errorf("error during OpenBucket: %w", err)
+	}
+	return bucket, nil
+}
+
+// OpenTopic opens topicURL like pubsub.OpenTopic, authenticating gcppubsub:// topics with Credentials.
+func OpenTopic(ctx context.Context, topicURL string) (*pubsub.Topic, error) {
+	topic, err := pubsubMux.OpenTopic(ctx, topicURL)
+	if err != nil {
+		return nil, fmt.Errorf("error during OpenTopic: %w", err)
+	}
+	return topic, nil
+}
+
+// OpenSubscription opens subscriptionURL like pubsub.OpenSubscription,
+// authenticating gcppubsub:// subscriptions with Credentials.
+func OpenSubscription(ctx context.Context, subscriptionURL string) (*pubsub.Subscription, error) {
+	subscription, err := pubsubMux.OpenSubscription(ctx, subscriptionURL)
+	if err != nil {
+		return nil, fmt.Errorf("error during OpenSubscription: %w", err)
+	}
+	return subscription, nil
+}
+
+func newBucketMux() *blob.URLMux {
+	mux := new(blob.URLMux)
+	mux.RegisterBucket(gcsblob.Scheme, &gcsOpener{})
+	mux.RegisterBucket(fileblob.Scheme, &fileblob.URLOpener{})
+	mux.RegisterBucket(memblob.Scheme, &memblob.URLOpener{})
+	return mux
+}
+
+func newPubSubMux() *pubsub.URLMux {
+	mux := new(pubsub.URLMux)
+	gcpOpener := &gcpPubSubOpener{}
+	mux.RegisterTopic(gcppubsub.Scheme, gcpOpener)
+	mux.RegisterSubscription(gcppubsub.Scheme, gcpOpener)
+	mem := &mempubsub.URLOpener{}
+	mux.RegisterTopic(mempubsub.Scheme, mem)
+	mux.RegisterSubscription(mempubsub.Scheme, mem)
+	return mux
+}
+
+// gcsOpener defers resolving credentials until a gs:// bucket is opened,
+// so that processes only using file:// or mem:// buckets never need them.
+type gcsOpener struct {
+	once   sync.Once
+	opener *gcsblob.URLOpener
+	err    error
+}
+
+func (o *gcsOpener) OpenBucketURL(ctx context.Context, u *url.URL) (*blob.Bucket, error) {
+	o.once.Do(func() {
+		c, err := Credentials()
+		if err != nil {
+			o.err = err
+			return
+		}
+		client, err := gcp.NewHTTPClient(gcp.DefaultTransport(), gcp.CredentialsTokenSource(c))
+		if err != nil {
+			o.err = fmt.Errorf("error during gcp.NewHTTPClient: %w", err)
+			return
+		}
+		o.opener = &gcsblob.URLOpener{Client: client}
+	})
+	if o.err != nil {
+		return nil, o.err
+	}
+	//nolint:wrapcheck
+	return o.opener.OpenBucketURL(ctx, u)
+}
+
+// gcpPubSubOpener lazily dials a single gRPC connection shared by all topics and subscriptions.
+type gcpPubSubOpener struct {
+	once   sync.Once
+	opener *gcppubsub.URLOpener
+	err    error
+}
+
+func (o *gcpPubSubOpener) init() error {
+	o.once.Do(func() {
+		c, err := Credentials()
+		if err != nil {
+			o.err = err
+			return
+		}
+		conn, _, err := gcppubsub.Dial(context.Background(), gcp.CredentialsTokenSource(c))
+		if err != nil {
+			o.err = fmt.Errorf("error during gcppubsub.Dial: %w", err)
+			return
+		}
+		o.opener = &gcppubsub.URLOpener{Conn: conn}
+	})
+	return o.err
+}
+
+func (o *gcpPubSubOpener) OpenTopicURL(ctx context.Context, u *url.URL) (*pubsub.Topic, error) {
+	if err := o.init(); err != nil {
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return o.opener.OpenTopicURL(ctx, u)
+}
+
+func (o *gcpPubSubOpener) OpenSubscriptionURL(ctx context.Context, u *url.URL) (*pubsub.Subscription, error) {
+	if err := o.init(); err != nil {
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return o.opener.OpenSubscriptionURL(ctx, u)
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadImpersonationConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		account   string
+		delegates string
+		want      impersonationConfig
+	}{
+		{
+			name: "no impersonation",
+			want: impersonationConfig{},
+		},
+		{
+			name:    "target only",
+			account: " worker@project.iam.gserviceaccount.com ",
+			want: impersonationConfig{
+				targetPrincipal: "worker@project.iam.gserviceaccount.com",
+			},
+		},
+		{
+			name:      "delegation chain",
+			account:   "worker@project.iam.gserviceaccount.com",
+			delegates: "a@project.iam.gserviceaccount.com, ,b@project.iam.gserviceaccount.com",
+			want: impersonationConfig{
+				targetPrincipal: "worker@project.iam.gserviceaccount.com",
+				delegates: []string{
+					"a@project.iam.gserviceaccount.com",
+					"b@project.iam.gserviceaccount.com",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvImpersonateServiceAccount, tt.account)
+			t.Setenv(EnvImpersonateDelegates, tt.delegates)
+			got := readImpersonationConfig()
+			if !cmp.Equal(tt.want, got, cmp.AllowUnexported(impersonationConfig{})) {
+				t.Errorf("readImpersonationConfig() diff: %s", cmp.Diff(tt.want, got, cmp.AllowUnexported(impersonationConfig{})))
+			}
+		})
+	}
+}
+
+func TestOpenBucketWithoutCredentials(t *testing.T) {
+	t.Parallel()
+	// Non-GCS buckets must not trigger credential discovery.
+	bucket, err := OpenBucket(context.Background(), "mem://")
+	if err != nil {
+		t.Fatalf("OpenBucket: %v", err)
+	}
+	defer bucket.Close()
+	if err := bucket.WriteAll(context.Background(), "key", []byte("value"), nil); err != nil {
+		t.Errorf("WriteAll: %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/ossf/scorecard/v4/cron/data"
+)
+
+const (
+	exportModeFull       = "full"
+	exportModeAnonymized = "anonymized"
+
+	pseudonymPrefix = "user-"
+	// pseudonymLength is the number of hex digits of the HMAC kept in pseudonyms.
+	pseudonymLength = 16
+	redactedEmail   = "[redacted email]"
+)
+
+var (
+	errUnknownExportMode = errors.New("unknown export mode")
+	errMissingSalt       = errors.New("anonymized exports require a salt")
+	errSameStagingBucket = errors.New("anonymized shards must be staged in a bucket other than the results bucket")
+
+	emailRegex = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+	// identityKeys are the keys of the values identifying contributors,
+	// e.g. the login of commit authors and reviewers.
+	identityKeys = map[string]bool{
+		"login": true,
+		"email": true,
+	}
+	// personalKeys are the keys of free-form values written by contributors,
+	// which may contain names or emails, e.g. commit messages.
+	personalKeys = map[string]bool{
+		"message": true,
+	}
+)
+
+// anonymizer strips personal data from exported results while keeping
+// aggregate signals: contributors are replaced by pseudonyms, which are stable
+// across repos and runs for a given salt, so e.g. the number of distinct
+// reviewers of a repo or whether a change was reviewed by its author can still
+// be computed.
+type anonymizer struct {
+	salt []byte
+}
+
+// newAnonymizer returns the anonymizer of the export mode, or nil if results
+// are exported in full.
+func newAnonymizer(mode, salt string) (*anonymizer, error) {
+	switch mode {
+	case "", exportModeFull:
+		return nil, nil //nolint:nilnil
+	case exportModeAnonymized:
+		if salt == "" {
+			return nil, errMissingSalt
+		}
+		return &anonymizer{salt: []byte(salt)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownExportMode, mode)
+	}
+}
+
+func (a *anonymizer) pseudonym(identity string) string {
+	if identity == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, a.salt)
+	mac.Write([]byte(identity))
+	return pseudonymPrefix + hex.EncodeToString(mac.Sum(nil))[:pseudonymLength]
+}
+
+func (a *anonymizer) anonymizeValue(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = a.anonymizeValue(k, e)
+		}
+		return v
+	case []interface{}:
+		for i, e := range v {
+			v[i] = a.anonymizeValue(key, e)
+		}
+		return v
+	case string:
+		switch {
+		case identityKeys[key]:
+			return a.pseudonym(v)
+		case personalKeys[key]:
+			return ""
+		default:
+			return emailRegex.ReplaceAllString(v, redactedEmail)
+		}
+	default:
+		return value
+	}
+}
+
+// anonymizeShard anonymizes the newline-delimited JSON results of a shard.
+func (a *anonymizer) anonymizeShard(content []byte) ([]byte, error) {
+	var ret bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		// Keep numbers as they were written.
+		decoder.UseNumber()
+		var result interface{}
+		if err := decoder.Decode(&result); err != nil {
+			return nil, fmt.Errorf("error during json.Decode: %w", err)
+		}
+		anonymized, err := json.Marshal(a.anonymizeValue("", result))
+		if err != nil {
+			return nil, fmt.Errorf("error during json.Marshal: %w", err)
+		}
+		ret.Write(anonymized)
+		ret.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error during scanner.Scan: %w", err)
+	}
+	return ret.Bytes(), nil
+}
+
+// stageAnonymizedShards writes the anonymized shards created at creationTime
+// to stagingBucketURL, under the same keys, to be loaded from there.
+func (a *anonymizer) stageAnonymizedShards(ctx context.Context,
+	bucketURL, stagingBucketURL string, creationTime time.Time,
+) error {
+	keys, err := data.GetBlobKeysWithPrefix(ctx, bucketURL, data.GetBlobFilename("shard-", creationTime))
+	if err != nil {
+		return fmt.Errorf("error during GetBlobKeysWithPrefix: %w", err)
+	}
+	for _, key := range keys {
+		content, err := data.GetBlobContent(ctx, bucketURL, key)
+		if err != nil {
+			return fmt.Errorf("error during GetBlobContent: %w", err)
+		}
+		anonymized, err := a.anonymizeShard(content)
+		if err != nil {
+			return fmt.Errorf("error anonymizing %s: %w", key, err)
+		}
+		if err := data.WriteToBlobStore(ctx, stagingBucketURL, key, anonymized); err != nil {
+			return fmt.Errorf("error during WriteToBlobStore: %w", err)
+		}
+	}
+	return nil
+}
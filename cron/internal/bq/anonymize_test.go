@@ -0,0 +1,146 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/cron/data"
+)
+
+func TestNewAnonymizer(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		wantErr error
+		name    string
+		mode    string
+		salt    string
+		wantNil bool
+	}{
+		{
+			name:    "default",
+			wantNil: true,
+		},
+		{
+			name:    "full",
+			mode:    exportModeFull,
+			wantNil: true,
+		},
+		{
+			name: "anonymized",
+			mode: exportModeAnonymized,
+			salt: "salt",
+		},
+		{
+			name:    "anonymized without salt",
+			mode:    exportModeAnonymized,
+			wantErr: errMissingSalt,
+		},
+		{
+			name:    "unknown mode",
+			mode:    "partial",
+			wantErr: errUnknownExportMode,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := newAnonymizer(tt.mode, tt.salt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("newAnonymizer() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (got == nil) != tt.wantNil {
+				t.Errorf("newAnonymizer() = %v, want nil: %v", got, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestAnonymizeShard(t *testing.T) {
+	t.Parallel()
+	a := anonymizer{salt: []byte("salt")}
+	alice, bob := a.pseudonym("alice"), a.pseudonym("bob")
+	if alice == bob || !strings.HasPrefix(alice, pseudonymPrefix) {
+		t.Fatalf("pseudonyms %s and %s are not distinct", alice, bob)
+	}
+	other := anonymizer{salt: []byte("other")}
+	if other.pseudonym("alice") == alice {
+		t.Errorf("pseudonym does not depend on the salt")
+	}
+
+	//nolint:lll
+	shard := `{"repo":{"name":"github.com/owner/repo"},"score":7.5,"checks":[{"name":"Security-Policy","score":10,"details":["Info: security contact: alice@example.com"]}]}
+
+{"results":{"defaultBranchChangesets":[{"number":"1","authors":[{"login":"alice"}],"reviews":[{"reviewer":{"login":"bob"},"state":"APPROVED"}],"commits":[{"committer":{"login":"alice"},"message":"fix\n\nSigned-off-by: Alice <alice@example.com>","sha":"abc"}]}]}}
+`
+	//nolint:lll
+	want := `{"checks":[{"details":["Info: security contact: [redacted email]"],"name":"Security-Policy","score":10}],"repo":{"name":"github.com/owner/repo"},"score":7.5}
+{"results":{"defaultBranchChangesets":[{"authors":[{"login":"` + alice + `"}],"commits":[{"committer":{"login":"` + alice + `"},"message":"","sha":"abc"}],"number":"1","reviews":[{"reviewer":{"login":"` + bob + `"},"state":"APPROVED"}]}]}}
+`
+	got, err := a.anonymizeShard([]byte(shard))
+	if err != nil {
+		t.Fatalf("anonymizeShard: %v", err)
+	}
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("anonymizeShard() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := a.anonymizeShard([]byte("{")); err == nil {
+		t.Errorf("anonymizeShard() of invalid JSON: want error")
+	}
+}
+
+func TestStageAnonymizedShards(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bucketURL := "file:///" + t.TempDir()
+	stagingBucketURL := "file:///" + t.TempDir()
+	creationTime := time.Date(2023, 3, 6, 12, 0, 0, 0, time.UTC)
+	key := data.GetBlobFilename("shard-0000000", creationTime)
+	if err := data.WriteToBlobStore(ctx, bucketURL, key, []byte(`{"login":"alice"}`+"\n")); err != nil {
+		t.Fatalf("WriteToBlobStore: %v", err)
+	}
+	// Shards of other runs are not staged.
+	otherKey := data.GetBlobFilename("shard-0000000", creationTime.Add(time.Hour))
+	if err := data.WriteToBlobStore(ctx, bucketURL, otherKey, []byte(`{"login":"bob"}`+"\n")); err != nil {
+		t.Fatalf("WriteToBlobStore: %v", err)
+	}
+
+	a := anonymizer{salt: []byte("salt")}
+	if err := a.stageAnonymizedShards(ctx, bucketURL, stagingBucketURL, creationTime); err != nil {
+		t.Fatalf("stageAnonymizedShards: %v", err)
+	}
+	keys, err := data.GetBlobKeys(ctx, stagingBucketURL)
+	if err != nil {
+		t.Fatalf("GetBlobKeys: %v", err)
+	}
+	if diff := cmp.Diff([]string{key}, keys); diff != "" {
+		t.Errorf("staged shards mismatch (-want +got):\n%s", diff)
+	}
+	got, err := data.GetBlobContent(ctx, stagingBucketURL, key)
+	if err != nil {
+		t.Fatalf("GetBlobContent: %v", err)
+	}
+	if want := `{"login":"` + a.pseudonym("alice") + `"}` + "\n"; string(got) != want {
+		t.Errorf("staged shard = %s, want %s", got, want)
+	}
+}
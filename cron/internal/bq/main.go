@@ -30,7 +30,7 @@ import (
 
 func transferDataToBq(ctx context.Context,
 	bucketURL, projectID, datasetName, tableName string, completionThreshold float64, webhookURL string,
-	summary *data.BucketSummary,
+	summary *data.BucketSummary, exporter *anonymizer, stagingBucketURL string,
 ) error {
 	for _, shards := range summary.Shards() {
 		if shards.IsTransferred() || !shards.IsCompleted(completionThreshold) {
@@ -38,8 +38,15 @@ func transferDataToBq(ctx context.Context,
 		}
 
 		shardFileURI := data.GetBlobFilename("shard-*", shards.CreationTime())
+		loadBucketURL := bucketURL
+		if exporter != nil {
+			if err := exporter.stageAnonymizedShards(ctx, bucketURL, stagingBucketURL, shards.CreationTime()); err != nil {
+				return fmt.Errorf("error during stageAnonymizedShards: %w", err)
+			}
+			loadBucketURL = stagingBucketURL
+		}
 		if err := startDataTransferJob(ctx,
-			bucketURL, shardFileURI, projectID, datasetName, tableName,
+			loadBucketURL, shardFileURI, projectID, datasetName, tableName,
 			shards.CreationTime()); err != nil {
 			return fmt.Errorf("error during StartDataTransferJob: %w", err)
 		}
@@ -81,6 +88,23 @@ func getBQConfig() (projectID, datasetName, tableName string, err error) {
 	return
 }
 
+func getExportConfig(bucketURL string) (exporter *anonymizer, stagingBucketURL string, err error) {
+	values, err := config.GetExportValues()
+	if err != nil {
+		// Results are exported in full without an export config.
+		return nil, "", nil //nolint:nilerr
+	}
+	exporter, err = newAnonymizer(values["mode"], values["salt"])
+	if err != nil {
+		return nil, "", fmt.Errorf("error getting export mode: %w", err)
+	}
+	stagingBucketURL = values["staging-bucket-url"]
+	if exporter != nil && (stagingBucketURL == "" || stagingBucketURL == bucketURL) {
+		return nil, "", errSameStagingBucket
+	}
+	return exporter, stagingBucketURL, nil
+}
+
 func main() {
 	ctx := context.Background()
 
@@ -105,6 +129,10 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	exporter, stagingBucketURL, err := getExportConfig(bucketURL)
+	if err != nil {
+		panic(err)
+	}
 
 	summary, err := data.GetBucketSummary(ctx, bucketURL)
 	if err != nil {
@@ -113,7 +141,7 @@ func main() {
 
 	if err := transferDataToBq(ctx,
 		bucketURL, projectID, datasetName, tableName, completionThreshold, webhookURL,
-		summary); err != nil {
+		summary, exporter, stagingBucketURL); err != nil {
 		panic(err)
 	}
 }
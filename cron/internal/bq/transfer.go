@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"cloud.google.com/go/bigquery"
+
+	"github.com/ossf/scorecard/v4/cron/internal/auth"
 )
 
 const partitionDateFormat = "20060102"
@@ -34,7 +36,11 @@ func createGCSRef(bucketURL, fileURI string) *bigquery.GCSReference {
 func createBQLoader(ctx context.Context, projectID, datasetName, tableName string,
 	partitionDate time.Time, gcsRef *bigquery.GCSReference,
 ) (*bigquery.Client, *bigquery.Loader, error) {
-	bqClient, err := bigquery.NewClient(ctx, projectID)
+	opts, err := auth.ClientOptions()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get client credentials: %w", err)
+	}
+	bqClient, err := bigquery.NewClient(ctx, projectID, opts...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create bigquery client: %w", err)
 	}
@@ -32,7 +32,11 @@ import (
 	"github.com/ossf/scorecard/v4/cron/internal/pubsub"
 )
 
-var headSHA = clients.HeadSHA
+var (
+	headSHA = clients.HeadSHA
+	stale   = flag.Bool("stale", false,
+		"rescore the repos marked stale by the invalidation webhook instead of the input files")
+)
 
 func publishToRepoRequestTopic(iter data.Iterator, topicPublisher pubsub.Publisher,
 	shardSize int, datetime time.Time,
@@ -137,7 +141,18 @@ func main() {
 	}
 
 	var reader data.Iterator
-	if useLocalFiles := len(flag.Args()) > 0; useLocalFiles {
+	var staleBucket string
+	var staleMarkers []data.StaleMarker
+	if *stale {
+		if staleBucket, err = staleBucketURL(); err != nil {
+			panic(err)
+		}
+		staleMarkers, reader, err = staleRepos(ctx, staleBucket)
+		if err == nil && len(staleMarkers) == 0 {
+			fmt.Println("No stale results to rescore")
+			return
+		}
+	} else if useLocalFiles := len(flag.Args()) > 0; useLocalFiles {
 		reader, err = localFiles(flag.Args())
 	} else {
 		reader, err = bucketFiles(ctx)
@@ -180,4 +195,10 @@ func main() {
 			panic(fmt.Errorf("error writing to BlobStore raw: %w", err))
 		}
 	}
+
+	// Markers are only cleared once the job is published, so a run failing
+	// before requests the rescoring again.
+	if err := clearStale(ctx, staleBucket, staleMarkers); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,67 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/cron/config"
+	"github.com/ossf/scorecard/v4/cron/data"
+)
+
+// staleRepos returns the markers of the repos whose results were marked stale
+// by the invalidation webhook, and an iterator over their repos.
+func staleRepos(ctx context.Context, bucketURL string) ([]data.StaleMarker, data.Iterator, error) {
+	markers, err := data.GetStaleMarkers(ctx, bucketURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("data.GetStaleMarkers: %w", err)
+	}
+	repos := make([]data.RepoFormat, 0, len(markers))
+	for i := range markers {
+		repos = append(repos, data.RepoFormat{Repo: markers[i].Repo})
+	}
+	var buf bytes.Buffer
+	if err := data.WriteTo(&buf, repos); err != nil {
+		return nil, nil, fmt.Errorf("data.WriteTo: %w", err)
+	}
+	iter, err := data.MakeIteratorFrom(&buf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("data.MakeIteratorFrom: %w", err)
+	}
+	return markers, iter, nil
+}
+
+// clearStale deletes the markers of repos whose rescoring was requested.
+func clearStale(ctx context.Context, bucketURL string, markers []data.StaleMarker) error {
+	for i := range markers {
+		if err := data.ClearStale(ctx, bucketURL, &markers[i]); err != nil {
+			return fmt.Errorf("data.ClearStale: %w", err)
+		}
+	}
+	return nil
+}
+
+func staleBucketURL() (string, error) {
+	values, err := config.GetStaleResultsValues()
+	if err != nil {
+		return "", fmt.Errorf("config.GetStaleResultsValues: %w", err)
+	}
+	if values["bucket-url"] == "" {
+		return "", fmt.Errorf("%w: stale-results bucket-url", config.ErrorEmptyConfigValue)
+	}
+	return values["bucket-url"], nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/cron/data"
+)
+
+func TestStaleRepos(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bucketURL := "file:///" + t.TempDir()
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, repo := range []string{"github.com/owner/repo", "github.com/owner/another"} {
+		if err := data.MarkStale(ctx, bucketURL, &data.StaleMarker{Time: now, Repo: repo, Event: "push"}); err != nil {
+			t.Fatalf("MarkStale: %v", err)
+		}
+	}
+
+	markers, iter, err := staleRepos(ctx, bucketURL)
+	if err != nil {
+		t.Fatalf("staleRepos: %v", err)
+	}
+	var repos []string
+	for iter.HasNext() {
+		repo, err := iter.Next()
+		if err != nil {
+			t.Fatalf("iter.Next: %v", err)
+		}
+		repos = append(repos, repo.Repo)
+	}
+	if diff := cmp.Diff([]string{"github.com/owner/another", "github.com/owner/repo"}, repos); diff != "" {
+		t.Errorf("staleRepos() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := clearStale(ctx, bucketURL, markers); err != nil {
+		t.Fatalf("clearStale: %v", err)
+	}
+	if markers, _, err = staleRepos(ctx, bucketURL); err != nil || len(markers) != 0 {
+		t.Errorf("staleRepos() after clearStale = %v, %v, want no markers", markers, err)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main implements the job publishing weekly digests of the results of
+// a configured list of repos.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ossf/scorecard/v4/cron/config"
+	"github.com/ossf/scorecard/v4/cron/data"
+	"github.com/ossf/scorecard/v4/digest"
+)
+
+type digestConfig struct {
+	reposBucketURL    string
+	reposFile         string
+	resultsBucketURL  string
+	outputBucketURL   string
+	snapshotBucketURL string
+	title             string
+	feedURL           string
+}
+
+func getDigestConfig() (*digestConfig, error) {
+	values, err := config.GetDigestValues()
+	if err != nil {
+		return nil, fmt.Errorf("error getting digest config: %w", err)
+	}
+	resultsBucketURL, err := config.GetAPIResultsBucketURL()
+	if err != nil {
+		return nil, fmt.Errorf("error getting API results bucket: %w", err)
+	}
+	c := &digestConfig{
+		reposBucketURL:    values["repos-bucket-url"],
+		reposFile:         values["repos-file"],
+		resultsBucketURL:  resultsBucketURL,
+		outputBucketURL:   values["output-bucket-url"],
+		snapshotBucketURL: values["snapshot-bucket-url"],
+		title:             values["title"],
+		feedURL:           values["feed-url"],
+	}
+	if c.reposBucketURL == "" || c.reposFile == "" || c.outputBucketURL == "" || c.snapshotBucketURL == "" {
+		return nil, fmt.Errorf("%w: digest repos and buckets", config.ErrorEmptyConfigValue)
+	}
+	return c, nil
+}
+
+func publishDigest(ctx context.Context, c *digestConfig, now time.Time) error {
+	content, err := data.GetBlobContent(ctx, c.reposBucketURL, c.reposFile)
+	if err != nil {
+		return fmt.Errorf("error reading repo list: %w", err)
+	}
+	repos, err := digest.ReadRepoList(bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("error during ReadRepoList: %w", err)
+	}
+
+	d, snapshot, err := digest.Generate(ctx, c.resultsBucketURL, c.snapshotBucketURL, c.title, repos, now)
+	if err != nil {
+		return fmt.Errorf("error during digest.Generate: %w", err)
+	}
+	var html, atom bytes.Buffer
+	if err := d.WriteHTML(&html); err != nil {
+		return fmt.Errorf("error during WriteHTML: %w", err)
+	}
+	if err := d.WriteAtom(&atom, c.feedURL); err != nil {
+		return fmt.Errorf("error during WriteAtom: %w", err)
+	}
+
+	// Dated copies are kept as an archive, the undated ones are the latest digest.
+	date := now.UTC().Format("2006.01.02")
+	outputs := map[string][]byte{
+		fmt.Sprintf("%s/digest.html", date): html.Bytes(),
+		fmt.Sprintf("%s/digest.atom", date): atom.Bytes(),
+		"digest.html":                       html.Bytes(),
+		"feed.atom":                         atom.Bytes(),
+	}
+	for key, content := range outputs {
+		if err := data.WriteToBlobStore(ctx, c.outputBucketURL, key, content); err != nil {
+			return fmt.Errorf("error writing %s: %w", key, err)
+		}
+	}
+
+	// Only move the snapshot forward once the digest is published, so that
+	// a failed run reports the same changes when retried.
+	if err := snapshot.Save(ctx, c.snapshotBucketURL); err != nil {
+		return fmt.Errorf("error during Snapshot.Save: %w", err)
+	}
+	log.Printf("Published digest of %d repos, %d changed", len(d.Repos), len(d.ChangedRepos()))
+	return nil
+}
+
+func main() {
+	ctx := context.Background()
+
+	flag.Parse()
+	if err := config.ReadConfig(); err != nil {
+		panic(err)
+	}
+	c, err := getDigestConfig()
+	if err != nil {
+		panic(err)
+	}
+	if err := publishDigest(ctx, c, time.Now()); err != nil {
+		panic(err)
+	}
+}
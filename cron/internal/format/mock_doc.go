@@ -17,6 +17,7 @@ package format
 import (
 	"strings"
 
+	"github.com/ossf/scorecard/v4/checker"
 	docs "github.com/ossf/scorecard/v4/docs/checks"
 )
 
@@ -61,6 +62,14 @@ func (c *mockCheck) GetSupportedRepoTypes() []string {
 	return l
 }
 
+func (c *mockCheck) GetInputs() []string {
+	return nil
+}
+
+func (c *mockCheck) GetScoring() []checker.ScoringRule {
+	return nil
+}
+
 func (c *mockCheck) GetDocumentationURL(commitish string) string {
 	return c.url
 }
@@ -0,0 +1,106 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v4/clients/githubrepo"
+	"github.com/ossf/scorecard/v4/cron/data"
+)
+
+// maxPayloadSize is the size GitHub caps webhook payloads at.
+const maxPayloadSize = 25 << 20
+
+// staleEvents are the webhook events which may change the results of a repo.
+var staleEvents = map[string]bool{
+	"branch_protection_rule": true,
+	"member":                 true,
+	"push":                   true,
+	"release":                true,
+	"repository":             true,
+	"repository_ruleset":     true,
+	"security_and_analysis":  true,
+}
+
+// event holds the fields shared by the payloads of staleEvents.
+type event struct {
+	// Ref is only set for push events.
+	Ref        string `json:"ref"`
+	Repository struct {
+		HTMLURL       string `json:"html_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"repository"`
+}
+
+// staleHandler receives GitHub webhook deliveries and marks the results of
+// their repos stale.
+type staleHandler struct {
+	markStale func(ctx context.Context, marker *data.StaleMarker) error
+	now       func() time.Time
+	secret    []byte
+}
+
+func (h *staleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxPayloadSize)
+	payload, err := github.ValidatePayload(r, h.secret)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid payload: %v", err), http.StatusUnauthorized)
+		return
+	}
+	eventType := github.WebHookType(r)
+	if !staleEvents[eventType] {
+		// Includes the ping sent when the webhook is created.
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	var e event
+	if err := json.Unmarshal(payload, &e); err != nil {
+		http.Error(w, fmt.Sprintf("unable to parse %s event: %v", eventType, err), http.StatusBadRequest)
+		return
+	}
+	// Results are computed at the head of the default branch.
+	if eventType == "push" && e.Ref != "refs/heads/"+e.Repository.DefaultBranch {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	repo, err := githubrepo.MakeGithubRepo(e.Repository.HTMLURL)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid repository: %v", err), http.StatusBadRequest)
+		return
+	}
+	marker := data.StaleMarker{
+		Time:  h.now(),
+		Repo:  repo.URI(),
+		Event: eventType,
+	}
+	if err := h.markStale(r.Context(), &marker); err != nil {
+		http.Error(w, fmt.Sprintf("error during MarkStale: %v", err), http.StatusInternalServerError)
+		return
+	}
+	log.Printf("marked results of %s stale after %s event", marker.Repo, eventType)
+	w.WriteHeader(http.StatusAccepted)
+}
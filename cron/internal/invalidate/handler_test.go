@@ -0,0 +1,132 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/cron/data"
+)
+
+const testSecret = "secret"
+
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestStaleHandler(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	pushPayload := `{"ref":"refs/heads/main","repository":` +
+		`{"html_url":"https://github.com/owner/repo","default_branch":"main"}}`
+	//nolint:govet
+	tests := []struct {
+		name      string
+		event     string
+		payload   string
+		signature string
+		markErr   error
+		want      *data.StaleMarker
+		status    int
+	}{
+		{
+			name:    "push to the default branch",
+			event:   "push",
+			payload: pushPayload,
+			want:    &data.StaleMarker{Time: now, Repo: "github.com/owner/repo", Event: "push"},
+			status:  http.StatusAccepted,
+		},
+		{
+			name:  "push to another branch",
+			event: "push",
+			payload: `{"ref":"refs/heads/feature","repository":` +
+				`{"html_url":"https://github.com/owner/repo","default_branch":"main"}}`,
+			status: http.StatusNoContent,
+		},
+		{
+			name:    "settings change",
+			event:   "branch_protection_rule",
+			payload: `{"action":"edited","repository":{"html_url":"https://github.com/owner/repo"}}`,
+			want:    &data.StaleMarker{Time: now, Repo: "github.com/owner/repo", Event: "branch_protection_rule"},
+			status:  http.StatusAccepted,
+		},
+		{
+			name:    "ping",
+			event:   "ping",
+			payload: `{"zen":"Keep it logically awesome."}`,
+			status:  http.StatusNoContent,
+		},
+		{
+			name:      "invalid signature",
+			event:     "push",
+			payload:   pushPayload,
+			signature: sign("another payload"),
+			status:    http.StatusUnauthorized,
+		},
+		{
+			name:    "error marking stale",
+			event:   "push",
+			payload: pushPayload,
+			markErr: errors.New("bucket unavailable"), //nolint:goerr113
+			status:  http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got *data.StaleMarker
+			h := &staleHandler{
+				markStale: func(ctx context.Context, marker *data.StaleMarker) error {
+					if tt.markErr == nil {
+						got = marker
+					}
+					return tt.markErr
+				},
+				now:    func() time.Time { return now },
+				secret: []byte(testSecret),
+			}
+			signature := tt.signature
+			if signature == "" {
+				signature = sign(tt.payload)
+			}
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.payload))
+			r.Header.Set("Content-Type", "application/json")
+			r.Header.Set("X-GitHub-Event", tt.event)
+			r.Header.Set("X-Hub-Signature-256", signature)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("marker mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
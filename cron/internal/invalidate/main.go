@@ -0,0 +1,77 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main implements a GitHub webhook receiver which marks the results
+// of repos stale when they change, so they are rescored before the next
+// scheduled run.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ossf/scorecard/v4/cron/config"
+	"github.com/ossf/scorecard/v4/cron/data"
+)
+
+const readHeaderTimeout = 10 * time.Second
+
+var errNoSecret = errors.New("stale-results webhook-secret must be set")
+
+func newStaleHandler() (*staleHandler, error) {
+	values, err := config.GetStaleResultsValues()
+	if err != nil {
+		return nil, fmt.Errorf("error getting stale-results config: %w", err)
+	}
+	bucketURL := values["bucket-url"]
+	if bucketURL == "" {
+		return nil, fmt.Errorf("%w: stale-results bucket-url", config.ErrorEmptyConfigValue)
+	}
+	// Unsigned deliveries would let anyone trigger rescoring.
+	if values["webhook-secret"] == "" {
+		return nil, errNoSecret
+	}
+	return &staleHandler{
+		markStale: func(ctx context.Context, marker *data.StaleMarker) error {
+			return data.MarkStale(ctx, bucketURL, marker)
+		},
+		now:    time.Now,
+		secret: []byte(values["webhook-secret"]),
+	}, nil
+}
+
+func main() {
+	flag.Parse()
+	if err := config.ReadConfig(); err != nil {
+		panic(err)
+	}
+	handler, err := newStaleHandler()
+	if err != nil {
+		panic(err)
+	}
+	http.Handle("/", handler)
+	fmt.Printf("Starting HTTP server on port 8080 ...\n")
+	server := &http.Server{
+		Addr:              ":8080",
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	if err := server.ListenAndServe(); err != nil {
+		log.Fatal(err)
+	}
+}
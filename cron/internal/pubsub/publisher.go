@@ -24,11 +24,10 @@ import (
 	"sync/atomic"
 
 	"gocloud.dev/pubsub"
-	// Needed to link in GCP drivers.
-	_ "gocloud.dev/pubsub/gcppubsub"
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/ossf/scorecard/v4/cron/data"
+	"github.com/ossf/scorecard/v4/cron/internal/auth"
 )
 
 var errorPublish = errors.New("total errors when publishing")
@@ -42,9 +41,9 @@ type Publisher interface {
 // CreatePublisher returns an implementation of the Publisher interface.
 func CreatePublisher(ctx context.Context, topicURL string) (Publisher, error) {
 	ret := publisherImpl{}
-	topic, err := pubsub.OpenTopic(ctx, topicURL)
+	topic, err := auth.OpenTopic(ctx, topicURL)
 	if err != nil {
-		return &ret, fmt.Errorf("error from pubsub.OpenTopic: %w", err)
+		return &ret, fmt.Errorf("error from auth.OpenTopic: %w", err)
 	}
 	return &publisherImpl{
 		ctx:   ctx,
@@ -25,6 +25,7 @@ import (
 	pubsubpb "google.golang.org/genproto/googleapis/pubsub/v1"
 
 	"github.com/ossf/scorecard/v4/cron/data"
+	"github.com/ossf/scorecard/v4/cron/internal/auth"
 )
 
 const (
@@ -43,7 +44,11 @@ type gcsSubscriber struct {
 }
 
 func createGCSSubscriber(ctx context.Context, subscriptionURL string) (*gcsSubscriber, error) {
-	client, err := pubsub.NewSubscriberClient(ctx)
+	opts, err := auth.ClientOptions()
+	if err != nil {
+		return nil, fmt.Errorf("error during auth.ClientOptions: %w", err)
+	}
+	client, err := pubsub.NewSubscriberClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("error during NewSubscriberClient: %w", err)
 	}
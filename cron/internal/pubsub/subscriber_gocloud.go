@@ -20,10 +20,9 @@ import (
 	"log"
 
 	"gocloud.dev/pubsub"
-	// Needed to link in GCP drivers.
-	_ "gocloud.dev/pubsub/gcppubsub"
 
 	"github.com/ossf/scorecard/v4/cron/data"
+	"github.com/ossf/scorecard/v4/cron/internal/auth"
 )
 
 type receiver interface {
@@ -39,9 +38,9 @@ type gocloudSubscriber struct {
 
 //nolint:unused,deadcode
 func createGocloudSubscriber(ctx context.Context, subscriptionURL string) (*gocloudSubscriber, error) {
-	subscription, err := pubsub.OpenSubscription(ctx, subscriptionURL)
+	subscription, err := auth.OpenSubscription(ctx, subscriptionURL)
 	if err != nil {
-		return nil, fmt.Errorf("error during pubsub.OpenSubscription: %w", err)
+		return nil, fmt.Errorf("error during auth.OpenSubscription: %w", err)
 	}
 	ret := gocloudSubscriber{
 		ctx:          ctx,
@@ -22,9 +22,12 @@ import (
 	"flag"
 	"fmt"
 	"net/http"
-	_ "net/http/pprof" //nolint:gosec
+	"time"
 
+	opencensusstats "go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"sigs.k8s.io/release-utils/version"
 
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/clients"
@@ -38,6 +41,8 @@ import (
 	"github.com/ossf/scorecard/v4/cron/worker"
 	docs "github.com/ossf/scorecard/v4/docs/checks"
 	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/internal/admin"
+	"github.com/ossf/scorecard/v4/internal/profiler"
 	"github.com/ossf/scorecard/v4/log"
 	"github.com/ossf/scorecard/v4/pkg"
 	"github.com/ossf/scorecard/v4/policy"
@@ -49,19 +54,30 @@ const (
 	rawResultsFile = "raw.json"
 )
 
-var ignoreRuntimeErrors = flag.Bool("ignoreRuntimeErrors", false, "if set to true any runtime errors will be ignored")
+var (
+	ignoreRuntimeErrors = flag.Bool("ignoreRuntimeErrors", false, "if set to true any runtime errors will be ignored")
+	adminAddr           = flag.String("adminAddr", "",
+		"address of the admin server exposing pprof and runtime metrics, e.g. localhost:6060, disabled if empty")
+	adminTokenFile = flag.String("adminTokenFile", "",
+		"file holding the bearer token required by the admin server, needed on non-loopback addresses")
+	profilerBackend = flag.String("profiler", "",
+		"continuous profiling backend, cloud-profiler or pyroscope, disabled if empty")
+	pyroscopeURL = flag.String("pyroscopeURL", "", "URL of the Pyroscope server profiles are pushed to")
+)
 
 type ScorecardWorker struct {
 	ctx               context.Context
 	logger            *log.Logger
 	checkDocs         docs.Doc
 	exporter          monitoring.Exporter
+	adminServer       *http.Server
 	repoClient        clients.RepoClient
 	ciiClient         clients.CIIBestPracticesClient
 	ossFuzzRepoClient clients.RepoClient
 	vulnsClient       clients.VulnerabilitiesClient
 	apiBucketURL      string
 	rawBucketURL      string
+	archiveBucketURL  string
 	blacklistedChecks []string
 }
 
@@ -76,6 +92,10 @@ func newScorecardWorker() (*ScorecardWorker, error) {
 		return nil, fmt.Errorf("docs.GetRawResultDataBucketURL: %w", err)
 	}
 
+	if sw.archiveBucketURL, err = config.GetArchiveBucketURL(); err != nil {
+		return nil, fmt.Errorf("config.GetArchiveBucketURL: %w", err)
+	}
+
 	if sw.blacklistedChecks, err = config.GetBlacklistedChecks(); err != nil {
 		return nil, fmt.Errorf("config.GetBlacklistedChecks: %w", err)
 	}
@@ -103,33 +123,66 @@ func newScorecardWorker() (*ScorecardWorker, error) {
 		return nil, fmt.Errorf("startMetricsExporter: %w", err)
 	}
 
-	// Exposed for monitoring runtime profiles
-	go func() {
-		// TODO(log): Previously Fatal. Need to handle the error here.
-		//nolint:gosec // not internet facing.
-		sw.logger.Info(fmt.Sprintf("%v", http.ListenAndServe(":8080", nil)))
-	}()
+	// Exposed for diagnosing the worker live.
+	adminConfig := admin.Config{Addr: *adminAddr}
+	if *adminTokenFile != "" {
+		if adminConfig.Token, err = admin.ReadToken(*adminTokenFile); err != nil {
+			return nil, fmt.Errorf("admin.ReadToken: %w", err)
+		}
+	}
+	if sw.adminServer, err = admin.Start(&adminConfig); err != nil {
+		return nil, fmt.Errorf("admin.Start: %w", err)
+	}
+
+	// Profiled across the fleet so regressions show up in aggregate.
+	profilerConfig := profiler.Config{
+		Backend:   *profilerBackend,
+		Service:   "scorecard-cron-worker",
+		Version:   version.GetVersionInfo().GitVersion,
+		ServerURL: *pyroscopeURL,
+	}
+	if profilerConfig.Backend == profiler.BackendCloudProfiler {
+		if profilerConfig.ProjectID, err = config.GetProjectID(); err != nil {
+			return nil, fmt.Errorf("config.GetProjectID: %w", err)
+		}
+	}
+	if err := profiler.Start(sw.ctx, &profilerConfig, sw.logger); err != nil {
+		return nil, fmt.Errorf("profiler.Start: %w", err)
+	}
 	return sw, nil
 }
 
 func (sw *ScorecardWorker) Close() {
+	if sw.adminServer != nil {
+		if err := sw.adminServer.Close(); err != nil {
+			sw.logger.Error(err, "closing admin server")
+		}
+	}
 	sw.exporter.StopMetricsExporter()
 	sw.ossFuzzRepoClient.Close()
 }
 
 func (sw *ScorecardWorker) Process(ctx context.Context, req *data.ScorecardBatchRequest, bucketURL string) error {
 	return processRequest(ctx, req, sw.blacklistedChecks, bucketURL, sw.rawBucketURL, sw.apiBucketURL,
-		sw.checkDocs, sw.repoClient, sw.ossFuzzRepoClient, sw.ciiClient, sw.vulnsClient, sw.logger)
+		sw.archiveBucketURL, sw.checkDocs, sw.repoClient, sw.ossFuzzRepoClient, sw.ciiClient, sw.vulnsClient, sw.logger)
 }
 
 func (sw *ScorecardWorker) PostProcess() {
 	sw.exporter.Flush()
+	alerts, err := monitoring.DefaultObjectives.Evaluate(view.RetrieveData)
+	if err != nil {
+		sw.logger.Info(fmt.Sprintf("error evaluating objectives: %v", err))
+		return
+	}
+	for _, alert := range alerts {
+		sw.logger.Info(alert.String())
+	}
 }
 
 //nolint:gocognit
 func processRequest(ctx context.Context,
 	batchRequest *data.ScorecardBatchRequest,
-	blacklistedChecks []string, bucketURL, rawBucketURL, apiBucketURL string,
+	blacklistedChecks []string, bucketURL, rawBucketURL, apiBucketURL, archiveBucketURL string,
 	checkDocs docs.Doc,
 	repoClient clients.RepoClient, ossFuzzRepoClient clients.RepoClient,
 	ciiClient clients.CIIBestPracticesClient,
@@ -140,6 +193,15 @@ func processRequest(ctx context.Context,
 
 	var buffer2 bytes.Buffer
 	var rawBuffer bytes.Buffer
+	var manifest data.ArchiveManifest
+	// pending is set while a repo is processed, so an early return is
+	// recorded as a failure of its request.
+	pending := false
+	defer func() {
+		if pending {
+			recordRepoRequest(ctx, monitoring.OutcomeFailure)
+		}
+	}()
 	// TODO: run Scorecard for each repo in a separate thread.
 	for _, repoReq := range batchRequest.GetRepos() {
 		logger.Info(fmt.Sprintf("Running Scorecard for repo: %s", *repoReq.Url))
@@ -150,6 +212,7 @@ func processRequest(ctx context.Context,
 			continue
 		}
 		repo.AppendMetadata(repoReq.Metadata...)
+		pending = true
 
 		commitSHA := clients.HeadSHA
 		requiredRequestType := []checker.RequestType{}
@@ -169,6 +232,8 @@ func processRequest(ctx context.Context,
 			repoClient, ossFuzzRepoClient, ciiClient, vulnsClient)
 		if errors.Is(err, sce.ErrRepoUnreachable) {
 			// Not accessible repo - continue.
+			pending = false
+			recordRepoRequest(ctx, monitoring.OutcomeUnreachable)
 			continue
 		}
 		if err != nil {
@@ -202,16 +267,24 @@ func processRequest(ctx context.Context,
 		if err := format.AsRawJSON(&result, &exportRawBuffer); err != nil {
 			return fmt.Errorf("error during result.AsRawJSON for export: %w", err)
 		}
-		exportPath := fmt.Sprintf("%s/%s", repo.URI(), resultsFile)
-		exportCommitSHAPath := fmt.Sprintf("%s/%s/%s", repo.URI(), result.Repo.CommitSHA, resultsFile)
-		exportRawPath := fmt.Sprintf("%s/%s", repo.URI(), rawResultsFile)
-		exportRawCommitSHAPath := fmt.Sprintf("%s/%s/%s", repo.URI(), result.Repo.CommitSHA, rawResultsFile)
+		// Export under the canonical name so renamed or transferred repos
+		// don't leave stale results behind under their old name.
+		if result.Repo.RequestedName != "" {
+			logger.Info(fmt.Sprintf("repo %s has moved to %s", result.Repo.RequestedName, result.Repo.Name))
+		}
+		exportPath := fmt.Sprintf("%s/%s", result.Repo.Name, resultsFile)
+		exportCommitSHAPath := fmt.Sprintf("%s/%s/%s", result.Repo.Name, result.Repo.CommitSHA, resultsFile)
+		exportRawPath := fmt.Sprintf("%s/%s", result.Repo.Name, rawResultsFile)
+		exportRawCommitSHAPath := fmt.Sprintf("%s/%s/%s", result.Repo.Name, result.Repo.CommitSHA, rawResultsFile)
 
 		// Raw result.
 		if err := format.AsRawJSON(&result, &rawBuffer); err != nil {
 			return fmt.Errorf("error during result.AsRawJSON: %w", err)
 		}
 
+		if err := recordStaleness(ctx, apiBucketURL, exportPath); err != nil {
+			return err
+		}
 		// These are results without the commit SHA which represents the latest commit.
 		if err := data.WriteToBlobStore(ctx, apiBucketURL, exportPath, exportBuffer.Bytes()); err != nil {
 			return fmt.Errorf("error during writing to exportBucketURL: %w", err)
@@ -227,6 +300,22 @@ func processRequest(ctx context.Context,
 		if err := data.WriteToBlobStore(ctx, apiBucketURL, exportRawCommitSHAPath, exportRawBuffer.Bytes()); err != nil {
 			return fmt.Errorf("error during exportBucketURL for raw results with commit SHA: %w", err)
 		}
+
+		if archiveBucketURL != "" {
+			entry, err := archiveResult(ctx, archiveBucketURL, &result, checkDocs)
+			if err != nil {
+				return fmt.Errorf("error during archiveResult: %w", err)
+			}
+			manifest.Entries = append(manifest.Entries, entry)
+		}
+		pending = false
+		recordRepoRequest(ctx, monitoring.OutcomeSuccess)
+	}
+
+	if archiveBucketURL != "" {
+		if err := data.WriteArchiveManifest(ctx, archiveBucketURL, filename, &manifest); err != nil {
+			return fmt.Errorf("error during WriteArchiveManifest: %w", err)
+		}
 	}
 
 	// Raw result.
@@ -245,6 +334,59 @@ func processRequest(ctx context.Context,
 	return nil
 }
 
+// recordRepoRequest records the outcome of a repo request.
+func recordRepoRequest(ctx context.Context, outcome string) {
+	ctx, err := tag.New(ctx, tag.Upsert(stats.Outcome, outcome))
+	if err != nil {
+		return
+	}
+	opencensusstats.Record(ctx, stats.RepoRequests.M(1))
+}
+
+// recordStaleness records the age of the result at exportPath, which is the
+// newest result of the repo, before it is replaced.
+func recordStaleness(ctx context.Context, apiBucketURL, exportPath string) error {
+	modTime, exists, err := data.BlobModTime(ctx, apiBucketURL, exportPath)
+	if err != nil {
+		return fmt.Errorf("error during BlobModTime: %w", err)
+	}
+	if exists {
+		opencensusstats.Record(ctx, stats.ResultStalenessInSec.M(int64(time.Since(modTime).Seconds())))
+	}
+	return nil
+}
+
+// archiveResult archives the results and raw results of a repo and returns
+// their entry in the manifest of the shard. The date of the run is recorded in
+// the entry only, so results which did not change since the previous run are
+// not stored again.
+func archiveResult(ctx context.Context, archiveBucketURL string,
+	result *pkg.ScorecardResult, checkDocs docs.Doc,
+) (data.ArchiveEntry, error) {
+	entry := data.ArchiveEntry{
+		Repo:   result.Repo.Name,
+		Commit: result.Repo.CommitSHA,
+		Date:   result.Date.Format("2006-01-02"),
+	}
+	undated := *result
+	undated.Date = time.Time{}
+	var resultBuffer, rawBuffer bytes.Buffer
+	if err := format.AsJSON2(&undated, true /*showDetails*/, log.InfoLevel, checkDocs, &resultBuffer); err != nil {
+		return entry, fmt.Errorf("error during result.AsJSON2: %w", err)
+	}
+	if err := format.AsRawJSON(&undated, &rawBuffer); err != nil {
+		return entry, fmt.Errorf("error during result.AsRawJSON: %w", err)
+	}
+	var err error
+	if entry.Result, err = data.WriteArchiveBlob(ctx, archiveBucketURL, resultBuffer.Bytes()); err != nil {
+		return entry, fmt.Errorf("error during WriteArchiveBlob: %w", err)
+	}
+	if entry.Raw, err = data.WriteArchiveBlob(ctx, archiveBucketURL, rawBuffer.Bytes()); err != nil {
+		return entry, fmt.Errorf("error during WriteArchiveBlob for raw results: %w", err)
+	}
+	return entry, nil
+}
+
 func startMetricsExporter() (monitoring.Exporter, error) {
 	exporter, err := monitoring.GetExporter()
 	if err != nil {
@@ -256,9 +398,15 @@ func startMetricsExporter() (monitoring.Exporter, error) {
 
 	if err := view.Register(
 		&stats.CheckRuntime,
+		&stats.CheckRunCount,
 		&stats.CheckErrorCount,
+		&stats.CheckPanicCount,
+		&stats.RepoRequestCount,
+		&stats.ResultStaleness,
 		&stats.OutgoingHTTPRequests,
-		&githubstats.GithubTokens); err != nil {
+		&stats.OutgoingHTTPRequestsByEndpoint,
+		&githubstats.GithubTokens,
+		&githubstats.GithubTokenRotations); err != nil {
 		return nil, fmt.Errorf("error during view.Register: %w", err)
 	}
 	return exporter, nil
@@ -25,6 +25,7 @@ import (
 	"go.opencensus.io/stats/view"
 
 	"github.com/ossf/scorecard/v4/cron/config"
+	"github.com/ossf/scorecard/v4/cron/internal/auth"
 )
 
 var errorUndefinedExporter = errors.New("unsupported exporterType")
@@ -36,6 +37,7 @@ const (
 	stackdriverTimeoutMinutes               = 10
 	stackDriver                exporterType = "stackdriver"
 	printer                    exporterType = "printer"
+	prometheus                 exporterType = "prometheus"
 )
 
 // Exporter interface is a custom wrapper to represent an opencensus exporter.
@@ -58,6 +60,8 @@ func GetExporter() (Exporter, error) {
 		return newStackDriverExporter()
 	case printer:
 		return new(printerExporter), nil
+	case prometheus:
+		return newPrometheusExporter(), nil
 	default:
 		return nil, fmt.Errorf("%w: %s", errorUndefinedExporter, exporter)
 	}
@@ -72,6 +76,10 @@ func newStackDriverExporter() (*stackdriver.Exporter, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error getting stackdriver prefix: %w", err)
 	}
+	opts, err := auth.ClientOptions()
+	if err != nil {
+		return nil, fmt.Errorf("error getting client credentials: %w", err)
+	}
 	exporter, err := stackdriver.NewExporter(stackdriver.Options{
 		ProjectID:         projectID,
 		MetricPrefix:      prefix,
@@ -79,7 +87,9 @@ func newStackDriverExporter() (*stackdriver.Exporter, error) {
 		Timeout:           stackdriverTimeoutMinutes * time.Minute,
 		// Stackdriver specific quotas based on https://cloud.google.com/monitoring/quotas
 		// `Time series included in a request`
-		BundleCountThreshold: stackdriverTimeSeriesQuota,
+		BundleCountThreshold:    stackdriverTimeSeriesQuota,
+		MonitoringClientOptions: opts,
+		TraceClientOptions:      opts,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error during stackdriver.NewExporter: %w", err)
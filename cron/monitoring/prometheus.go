@@ -0,0 +1,165 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats/view"
+)
+
+const (
+	// prometheusAddr is the address the metrics are served on for scraping.
+	prometheusAddr = ":9090"
+	// prometheusPath is the path the metrics are served on.
+	prometheusPath          = "/metrics"
+	prometheusReadTimeout   = 10 * time.Second
+	prometheusShutdownDelay = 5 * time.Second
+)
+
+// prometheusExporter serves the latest data of each view in the
+// Prometheus text exposition format.
+type prometheusExporter struct {
+	server *http.Server
+	data   map[string]*view.Data
+	mu     sync.Mutex
+}
+
+func newPrometheusExporter() *prometheusExporter {
+	pe := &prometheusExporter{
+		data: make(map[string]*view.Data),
+	}
+	mux := http.NewServeMux()
+	mux.Handle(prometheusPath, pe)
+	pe.server = &http.Server{
+		Addr:              prometheusAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: prometheusReadTimeout,
+	}
+	return pe
+}
+
+func (pe *prometheusExporter) ExportView(viewData *view.Data) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	pe.data[viewData.View.Name] = viewData
+}
+
+func (pe *prometheusExporter) StartMetricsExporter() error {
+	view.RegisterExporter(pe)
+	go func() {
+		if err := pe.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("error serving prometheus metrics: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (pe *prometheusExporter) StopMetricsExporter() {
+	view.UnregisterExporter(pe)
+	// Give scrapers a chance to collect the final values.
+	time.Sleep(prometheusShutdownDelay)
+	if err := pe.server.Close(); err != nil {
+		log.Printf("error closing prometheus server: %v", err)
+	}
+}
+
+func (pe *prometheusExporter) Flush() {}
+
+// ServeHTTP implements http.Handler.
+func (pe *prometheusExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	pe.writeMetrics(w)
+}
+
+func (pe *prometheusExporter) writeMetrics(w io.Writer) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	names := make([]string, 0, len(pe.data))
+	for name := range pe.data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeView(w, pe.data[name])
+	}
+}
+
+func writeView(w io.Writer, vd *view.Data) {
+	name := sanitizeMetricName(vd.View.Name)
+	fmt.Fprintf(w, "# HELP %s %s\n", name, vd.View.Description)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType(vd.View.Aggregation.Type))
+	for _, row := range vd.Rows {
+		labels := make([]string, 0, len(row.Tags))
+		for _, t := range row.Tags {
+			labels = append(labels, fmt.Sprintf("%s=%q", sanitizeMetricName(t.Key.Name()), t.Value))
+		}
+		switch data := row.Data.(type) {
+		case *view.CountData:
+			fmt.Fprintf(w, "%s%s %d\n", name, formatLabels(labels), data.Value)
+		case *view.SumData:
+			fmt.Fprintf(w, "%s%s %g\n", name, formatLabels(labels), data.Value)
+		case *view.LastValueData:
+			fmt.Fprintf(w, "%s%s %g\n", name, formatLabels(labels), data.Value)
+		case *view.DistributionData:
+			var cumulative int64
+			for i, bound := range vd.View.Aggregation.Buckets {
+				cumulative += data.CountPerBucket[i]
+				le := append(labels[:len(labels):len(labels)], fmt.Sprintf("le=\"%g\"", bound))
+				fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(le), cumulative)
+			}
+			le := append(labels[:len(labels):len(labels)], "le=\"+Inf\"")
+			fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(le), data.Count)
+			fmt.Fprintf(w, "%s_sum%s %g\n", name, formatLabels(labels), data.Mean*float64(data.Count))
+			fmt.Fprintf(w, "%s_count%s %d\n", name, formatLabels(labels), data.Count)
+		}
+	}
+}
+
+func metricType(t view.AggType) string {
+	switch t {
+	case view.AggTypeCount, view.AggTypeSum:
+		return "counter"
+	case view.AggTypeDistribution:
+		return "histogram"
+	default:
+		return "gauge"
+	}
+}
+
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+// sanitizeMetricName replaces the characters not allowed in Prometheus names.
+func sanitizeMetricName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == ':' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"strings"
+	"testing"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func TestPrometheusExporter(t *testing.T) {
+	t.Parallel()
+	key := tag.MustNewKey("endpointCategory")
+	measure := stats.Int64("requests", "requests", stats.UnitDimensionless)
+	pe := newPrometheusExporter()
+	pe.ExportView(&view.Data{
+		View: &view.View{
+			Name:        "Outgoing.Requests",
+			Description: "HTTPRequests made",
+			Measure:     measure,
+			TagKeys:     []tag.Key{key},
+			Aggregation: view.Count(),
+		},
+		Rows: []*view.Row{
+			{Tags: []tag.Tag{{Key: key, Value: "contents"}}, Data: &view.CountData{Value: 3}},
+		},
+	})
+	pe.ExportView(&view.Data{
+		View: &view.View{
+			Name:        "Runtime",
+			Description: "runtime",
+			Measure:     measure,
+			Aggregation: view.Distribution(1, 10),
+		},
+		Rows: []*view.Row{
+			{Data: &view.DistributionData{Count: 3, Mean: 4, CountPerBucket: []int64{1, 1, 1}}},
+		},
+	})
+
+	var b strings.Builder
+	pe.writeMetrics(&b)
+	want := `# HELP Outgoing_Requests HTTPRequests made
+# TYPE Outgoing_Requests counter
+Outgoing_Requests{endpointCategory="contents"} 3
+# HELP Runtime runtime
+# TYPE Runtime histogram
+Runtime_bucket{le="1"} 1
+Runtime_bucket{le="10"} 2
+Runtime_bucket{le="+Inf"} 3
+Runtime_sum 12
+Runtime_count 3
+`
+	if got := b.String(); got != want {
+		t.Errorf("writeMetrics() =\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/ossf/scorecard/v4/stats"
+)
+
+const (
+	// OutcomeSuccess is the outcome of a repo request whose results were exported.
+	OutcomeSuccess = "success"
+	// OutcomeFailure is the outcome of a repo request which failed and will be retried.
+	OutcomeFailure = "failure"
+	// OutcomeUnreachable is the outcome of a repo request for a repo which is not accessible.
+	OutcomeUnreachable = "unreachable"
+)
+
+// Objectives are the service level objectives of the cron service and the
+// thresholds at which operators are alerted when their error budgets are
+// being spent.
+type Objectives struct {
+	// SuccessRate is the minimum fraction of repo requests which succeed.
+	// Requests for unreachable repos are not counted.
+	SuccessRate float64
+	// FreshRate is the minimum fraction of results replaced before they are
+	// older than MaxStaleness.
+	FreshRate float64
+	// MaxStaleness is the age after which the newest result of a repo is stale.
+	MaxStaleness time.Duration
+	// CheckErrorRate is the maximum fraction of runs of any one check which
+	// may end in an error.
+	CheckErrorRate float64
+	// BurnRateAlert is the rate at which an error budget may be spent before
+	// an alert is raised. A rate of 1 spends the budget exactly.
+	BurnRateAlert float64
+	// MinSamples is the number of samples below which an objective is not
+	// evaluated, so a single failure on an idle worker does not alert.
+	MinSamples int64
+}
+
+// DefaultObjectives are the objectives the public cron service is run with.
+var DefaultObjectives = Objectives{
+	SuccessRate:    0.99,
+	FreshRate:      0.95,
+	MaxStaleness:   7 * 24 * time.Hour,
+	CheckErrorRate: 0.05,
+	BurnRateAlert:  2,
+	MinSamples:     100,
+}
+
+// Alert is raised for an objective whose error budget is spent faster than allowed.
+type Alert struct {
+	// Objective is the name of the objective, e.g. SuccessRate or
+	// CheckErrorRate/Maintained.
+	Objective string
+	// BurnRate is the observed failure rate relative to the error budget.
+	BurnRate float64
+}
+
+func (a Alert) String() string {
+	return fmt.Sprintf("objective %s is burning its error budget at %.2fx", a.Objective, a.BurnRate)
+}
+
+// RetrieveFunc returns the rows of a registered view, see view.RetrieveData.
+type RetrieveFunc func(viewName string) ([]*view.Row, error)
+
+// Evaluate returns an alert for each objective whose error budget is spent at
+// more than o.BurnRateAlert times its allowed rate, according to the views
+// of the stats package returned by retrieve.
+func (o *Objectives) Evaluate(retrieve RetrieveFunc) ([]Alert, error) {
+	var alerts []Alert
+
+	rows, err := retrieve(stats.RepoRequestCount.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s: %w", stats.RepoRequestCount.Name, err)
+	}
+	outcomes := countByTag(rows, stats.Outcome)
+	total := outcomes[OutcomeSuccess] + outcomes[OutcomeFailure]
+	alerts = o.appendAlert(alerts, "SuccessRate", outcomes[OutcomeFailure], total, 1-o.SuccessRate)
+
+	rows, err = retrieve(stats.ResultStaleness.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s: %w", stats.ResultStaleness.Name, err)
+	}
+	stale, total := countStale(rows, stats.ResultStaleness.Aggregation.Buckets, o.MaxStaleness)
+	alerts = o.appendAlert(alerts, "FreshRate", stale, total, 1-o.FreshRate)
+
+	rows, err = retrieve(stats.CheckRunCount.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s: %w", stats.CheckRunCount.Name, err)
+	}
+	runs := countByTag(rows, stats.CheckName)
+	rows, err = retrieve(stats.CheckErrorCount.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s: %w", stats.CheckErrorCount.Name, err)
+	}
+	checkErrors := countByTag(rows, stats.CheckName)
+	checks := make([]string, 0, len(runs))
+	for check := range runs {
+		checks = append(checks, check)
+	}
+	sort.Strings(checks)
+	for _, check := range checks {
+		alerts = o.appendAlert(alerts, "CheckErrorRate/"+check, checkErrors[check], runs[check], o.CheckErrorRate)
+	}
+	return alerts, nil
+}
+
+func (o *Objectives) appendAlert(alerts []Alert, objective string, bad, total int64, budget float64) []Alert {
+	if total == 0 || total < o.MinSamples || budget <= 0 {
+		return alerts
+	}
+	burnRate := float64(bad) / float64(total) / budget
+	if burnRate <= o.BurnRateAlert {
+		return alerts
+	}
+	return append(alerts, Alert{Objective: objective, BurnRate: burnRate})
+}
+
+// countByTag sums the counts of rows by the value of key.
+func countByTag(rows []*view.Row, key tag.Key) map[string]int64 {
+	counts := make(map[string]int64)
+	for _, row := range rows {
+		count, ok := row.Data.(*view.CountData)
+		if !ok {
+			continue
+		}
+		for _, t := range row.Tags {
+			if t.Key == key {
+				counts[t.Value] += count.Value
+			}
+		}
+	}
+	return counts
+}
+
+// countStale returns the number of observations in a distribution of ages in
+// seconds which were at least maxAge, and the total number of observations.
+// Observations are only counted as stale if their whole bucket is, so
+// maxAge should be one of the bounds.
+func countStale(rows []*view.Row, bounds []float64, maxAge time.Duration) (stale, total int64) {
+	for _, row := range rows {
+		dist, ok := row.Data.(*view.DistributionData)
+		if !ok {
+			continue
+		}
+		total += dist.Count
+		for i, count := range dist.CountPerBucket {
+			if i > 0 && i <= len(bounds) && bounds[i-1] >= maxAge.Seconds() {
+				stale += count
+			}
+		}
+	}
+	return stale, total
+}
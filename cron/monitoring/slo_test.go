@@ -0,0 +1,137 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/ossf/scorecard/v4/stats"
+)
+
+func countRow(key tag.Key, value string, count int64) *view.Row {
+	return &view.Row{
+		Tags: []tag.Tag{{Key: key, Value: value}},
+		Data: &view.CountData{Value: count},
+	}
+}
+
+func TestObjectivesEvaluate(t *testing.T) {
+	t.Parallel()
+	objectives := Objectives{
+		SuccessRate:    0.9,
+		FreshRate:      0.9,
+		MaxStaleness:   DefaultObjectives.MaxStaleness,
+		CheckErrorRate: 0.1,
+		BurnRateAlert:  2,
+		MinSamples:     10,
+	}
+	tests := []struct {
+		data map[string][]*view.Row
+		name string
+		want []Alert
+	}{
+		{
+			name: "no data",
+			data: map[string][]*view.Row{},
+		},
+		{
+			name: "within budget",
+			data: map[string][]*view.Row{
+				stats.RepoRequestCount.Name: {
+					countRow(stats.Outcome, OutcomeSuccess, 90),
+					countRow(stats.Outcome, OutcomeFailure, 10),
+				},
+				stats.CheckRunCount.Name: {
+					countRow(stats.CheckName, "Maintained", 100),
+				},
+			},
+		},
+		{
+			name: "too few samples",
+			data: map[string][]*view.Row{
+				stats.RepoRequestCount.Name: {
+					countRow(stats.Outcome, OutcomeFailure, 9),
+				},
+			},
+		},
+		{
+			name: "unreachable repos do not count",
+			data: map[string][]*view.Row{
+				stats.RepoRequestCount.Name: {
+					countRow(stats.Outcome, OutcomeSuccess, 10),
+					countRow(stats.Outcome, OutcomeUnreachable, 100),
+				},
+			},
+		},
+		{
+			name: "budgets burning",
+			data: map[string][]*view.Row{
+				stats.RepoRequestCount.Name: {
+					countRow(stats.Outcome, OutcomeSuccess, 50),
+					countRow(stats.Outcome, OutcomeFailure, 50),
+				},
+				stats.ResultStaleness.Name: {
+					// 7 stale results of 20: one in the 7-14 day bucket and six older.
+					{Data: &view.DistributionData{Count: 20, CountPerBucket: []int64{10, 2, 1, 0, 0, 1, 0, 6}}},
+				},
+				stats.CheckRunCount.Name: {
+					countRow(stats.CheckName, "Maintained", 100),
+					countRow(stats.CheckName, "Binary-Artifacts", 100),
+				},
+				stats.CheckErrorCount.Name: {
+					countRow(stats.CheckName, "Maintained", 30),
+					countRow(stats.CheckName, "Binary-Artifacts", 5),
+				},
+			},
+			want: []Alert{
+				{Objective: "SuccessRate", BurnRate: 5},
+				{Objective: "FreshRate", BurnRate: 3.5},
+				{Objective: "CheckErrorRate/Maintained", BurnRate: 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := objectives.Evaluate(func(viewName string) ([]*view.Row, error) {
+				return tt.data[viewName], nil
+			})
+			if err != nil {
+				t.Fatalf("Evaluate: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("Evaluate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectivesEvaluate_error(t *testing.T) {
+	t.Parallel()
+	errRetrieve := errors.New("not registered") //nolint:goerr113
+	_, err := DefaultObjectives.Evaluate(func(string) ([]*view.Row, error) {
+		return nil, errRetrieve
+	})
+	if !errors.Is(err, errRetrieve) {
+		t.Errorf("Evaluate() error = %v, want %v", err, errRetrieve)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package digest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gocloud.dev/blob"
+	// Needed to read file:/// buckets.
+	_ "gocloud.dev/blob/fileblob"
+	// Needed to read gs:// buckets.
+	_ "gocloud.dev/blob/gcsblob"
+	"gocloud.dev/gcerrors"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// ResultsFile is the name of the latest results of a repo in a results
+// bucket, as exported by the cron job: <repo>/results.json.
+const ResultsFile = "results.json"
+
+// Snapshot is the content of the latest results of each repo.
+type Snapshot map[string][]byte
+
+// Generate compares the latest results of the repos in the results bucket
+// with the snapshot of the previous run in the snapshot bucket. The returned
+// snapshot should be saved once the digest was delivered, so that the next
+// digest starts from the current results.
+func Generate(ctx context.Context, resultsBucketURL, snapshotBucketURL, title string,
+	repos []string, now time.Time,
+) (*Digest, Snapshot, error) {
+	results, err := blob.OpenBucket(ctx, resultsBucketURL)
+	if err != nil {
+		return nil, nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("blob.OpenBucket: %v", err))
+	}
+	defer results.Close()
+	snapshots, err := blob.OpenBucket(ctx, snapshotBucketURL)
+	if err != nil {
+		return nil, nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("blob.OpenBucket: %v", err))
+	}
+	defer snapshots.Close()
+
+	d := &Digest{
+		Title:     title,
+		Generated: now,
+	}
+	snapshot := make(Snapshot)
+	for _, repo := range repos {
+		key := fmt.Sprintf("%s/%s", repo, ResultsFile)
+		current, currentContent, err := readResult(ctx, results, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		previous, previousContent, err := readResult(ctx, snapshots, key)
+		if err != nil {
+			return nil, nil, err
+		}
+		d.Repos = append(d.Repos, Compare(repo, previous, current))
+		// Keep the previous results of repos which were not scanned
+		// since, so that their changes are reported once they are.
+		switch {
+		case currentContent != nil:
+			snapshot[key] = currentContent
+		case previousContent != nil:
+			snapshot[key] = previousContent
+		}
+	}
+	return d, snapshot, nil
+}
+
+// ReadResults returns the latest results of the repos in a results bucket,
+// by repo. Repos without results are left out.
+func ReadResults(ctx context.Context, bucketURL string, repos []string) (map[string]*Result, error) {
+	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("blob.OpenBucket: %v", err))
+	}
+	defer bucket.Close()
+	results := make(map[string]*Result, len(repos))
+	for _, repo := range repos {
+		r, _, err := readResult(ctx, bucket, fmt.Sprintf("%s/%s", repo, ResultsFile))
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			results[repo] = r
+		}
+	}
+	return results, nil
+}
+
+// readResult returns nil if the result does not exist.
+func readResult(ctx context.Context, bucket *blob.Bucket, key string) (*Result, []byte, error) {
+	content, err := bucket.ReadAll(ctx, key)
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("bucket.ReadAll: %s: %v", key, err))
+	}
+	r, err := ParseResult(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", key, err)
+	}
+	return r, content, nil
+}
+
+// Save writes the snapshot to a bucket.
+func (s Snapshot) Save(ctx context.Context, bucketURL string) error {
+	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("blob.OpenBucket: %v", err))
+	}
+	defer bucket.Close()
+	for key, content := range s {
+		if err := bucket.WriteAll(ctx, key, content, nil); err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("bucket.WriteAll: %s: %v", key, err))
+		}
+	}
+	return nil
+}
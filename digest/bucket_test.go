@@ -0,0 +1,91 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package digest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestFile(t *testing.T, path, src string) {
+	t.Helper()
+	content, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatalf("os.ReadFile: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("os.MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	results, snapshots := t.TempDir(), t.TempDir()
+	writeTestFile(t, filepath.Join(results, "github.com/org/repo", ResultsFile), "testdata/current.json")
+	writeTestFile(t, filepath.Join(snapshots, "github.com/org/repo", ResultsFile), "testdata/previous.json")
+	writeTestFile(t, filepath.Join(snapshots, "github.com/org/gone", ResultsFile), "testdata/previous.json")
+	resultsURL, snapshotsURL := "file://"+results, "file://"+snapshots
+	repos := []string{"github.com/org/repo", "github.com/org/gone", "github.com/org/unknown"}
+
+	d, snapshot, err := Generate(ctx, resultsURL, snapshotsURL, "digest", repos, time.Now())
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(d.Repos) != 3 {
+		t.Fatalf("got %d repos, want 3", len(d.Repos))
+	}
+	if !d.Repos[0].Changed() || d.Repos[0].Score != 4.5 {
+		t.Errorf("repo digest = %+v", d.Repos[0])
+	}
+	if !d.Repos[1].Missing || !d.Repos[2].Missing {
+		t.Errorf("repos without results not reported as missing: %+v", d.Repos[1:])
+	}
+	if len(snapshot) != 2 {
+		t.Errorf("snapshot has %d results, want 2", len(snapshot))
+	}
+
+	if err := snapshot.Save(ctx, snapshotsURL); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	// The next digest starts from the saved snapshot.
+	d, _, err = Generate(ctx, resultsURL, snapshotsURL, "digest", repos[:1], time.Now())
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if d.Repos[0].Changed() {
+		t.Errorf("repo digest after saving the snapshot = %+v, want unchanged", d.Repos[0])
+	}
+}
+
+func TestReadResults(t *testing.T) {
+	t.Parallel()
+	results := t.TempDir()
+	writeTestFile(t, filepath.Join(results, "github.com/org/repo", ResultsFile), "testdata/current.json")
+
+	got, err := ReadResults(context.Background(), "file://"+results,
+		[]string{"github.com/org/repo", "github.com/org/unknown"})
+	if err != nil {
+		t.Fatalf("ReadResults: %v", err)
+	}
+	if len(got) != 1 || got["github.com/org/repo"] == nil || got["github.com/org/repo"].Score != 4.5 {
+		t.Errorf("ReadResults() = %+v", got)
+	}
+}
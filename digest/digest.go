@@ -0,0 +1,213 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package digest summarizes the changes of the Scorecard results of a set of
+// repos between two runs, for teams which consume reports rather than
+// dashboards.
+package digest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// CheckResult is the result of a check as exported in the JSON results.
+type CheckResult struct {
+	Name    string   `json:"name"`
+	Reason  string   `json:"reason"`
+	Details []string `json:"details"`
+	Score   int      `json:"score"`
+}
+
+// Result is the subset of the JSON results used by digests.
+type Result struct {
+	Date string `json:"date"`
+	Repo struct {
+		Name   string `json:"name"`
+		Commit string `json:"commit"`
+	} `json:"repo"`
+	Checks []CheckResult `json:"checks"`
+	Score  float64       `json:"score"`
+}
+
+// ParseResult parses results in the `--format=json` format.
+func ParseResult(content []byte) (*Result, error) {
+	var r Result
+	if err := json.Unmarshal(content, &r); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Unmarshal: %v", err))
+	}
+	return &r, nil
+}
+
+// CheckChange is the change of the score of a check. Scores of checks which
+// were inconclusive or not run are checker.InconclusiveResultScore.
+type CheckChange struct {
+	Name     string
+	Previous int
+	Current  int
+}
+
+// Finding is a warning of a check which was not reported by the previous run.
+type Finding struct {
+	Check string
+	Text  string
+}
+
+// RepoDigest summarizes the changes of the results of a repo.
+type RepoDigest struct {
+	Repo   string
+	Commit string
+	Date   string
+	// Changes lists the checks whose score changed, by name.
+	Changes     []CheckChange
+	NewFindings []Finding
+	// PreviousScore and Score are the aggregate scores.
+	PreviousScore float64
+	Score         float64
+	// New is set when the repo has no previous results.
+	New bool
+	// Missing is set when the repo has no current results.
+	Missing bool
+}
+
+// Changed returns whether anything changed for the repo.
+func (r *RepoDigest) Changed() bool {
+	return r.New || r.Missing || r.PreviousScore != r.Score ||
+		len(r.Changes) > 0 || len(r.NewFindings) > 0
+}
+
+// Compare summarizes the changes between two results of a repo. Either
+// result can be nil if it does not exist.
+func Compare(repo string, previous, current *Result) RepoDigest {
+	d := RepoDigest{
+		Repo:          repo,
+		PreviousScore: checker.InconclusiveResultScore,
+		Score:         checker.InconclusiveResultScore,
+	}
+	switch {
+	case current == nil:
+		d.Missing = true
+	case previous == nil:
+		d.New = true
+	}
+	if current != nil {
+		d.Commit = current.Repo.Commit
+		d.Date = current.Date
+		d.Score = current.Score
+	}
+	if previous != nil {
+		d.PreviousScore = previous.Score
+	}
+	if current == nil || previous == nil {
+		return d
+	}
+
+	before := make(map[string]*CheckResult)
+	for i := range previous.Checks {
+		before[previous.Checks[i].Name] = &previous.Checks[i]
+	}
+	after := make(map[string]*CheckResult)
+	for i := range current.Checks {
+		after[current.Checks[i].Name] = &current.Checks[i]
+	}
+	names := make(map[string]bool)
+	for name := range before {
+		names[name] = true
+	}
+	for name := range after {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		change := CheckChange{
+			Name:     name,
+			Previous: checker.InconclusiveResultScore,
+			Current:  checker.InconclusiveResultScore,
+		}
+		prev, cur := before[name], after[name]
+		if prev != nil {
+			change.Previous = prev.Score
+		}
+		if cur != nil {
+			change.Current = cur.Score
+		}
+		if change.Previous != change.Current {
+			d.Changes = append(d.Changes, change)
+		}
+		if cur == nil {
+			continue
+		}
+		known := make(map[string]bool)
+		if prev != nil {
+			for _, detail := range prev.Details {
+				known[detail] = true
+			}
+		}
+		for _, detail := range cur.Details {
+			if strings.HasPrefix(detail, "Warn:") && !known[detail] {
+				d.NewFindings = append(d.NewFindings, Finding{Check: name, Text: detail})
+			}
+		}
+	}
+	return d
+}
+
+// Digest summarizes the changes of a set of repos.
+type Digest struct {
+	Generated time.Time
+	Title     string
+	Repos     []RepoDigest
+}
+
+// ChangedRepos returns the repos for which something changed.
+func (d *Digest) ChangedRepos() []RepoDigest {
+	var repos []RepoDigest
+	for i := range d.Repos {
+		if d.Repos[i].Changed() {
+			repos = append(repos, d.Repos[i])
+		}
+	}
+	return repos
+}
+
+// ReadRepoList reads a list of repos, one per line. Empty lines and lines
+// starting with # are ignored.
+func ReadRepoList(r io.Reader) ([]string, error) {
+	var repos []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		repos = append(repos, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("scanner.Scan: %v", err))
+	}
+	return repos, nil
+}
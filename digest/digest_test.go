@@ -0,0 +1,133 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package digest
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+func readTestResult(t *testing.T, file string) *Result {
+	t.Helper()
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("os.ReadFile: %v", err)
+	}
+	r, err := ParseResult(content)
+	if err != nil {
+		t.Fatalf("ParseResult: %v", err)
+	}
+	return r
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+	previous := readTestResult(t, "testdata/previous.json")
+	current := readTestResult(t, "testdata/current.json")
+	tests := []struct {
+		previous *Result
+		current  *Result
+		name     string
+		want     RepoDigest
+	}{
+		{
+			name:     "changed",
+			previous: previous,
+			current:  current,
+			want: RepoDigest{
+				Repo:          "github.com/org/repo",
+				Commit:        "bbb",
+				Date:          "2023-03-13T00:00:00Z",
+				PreviousScore: 5,
+				Score:         4.5,
+				Changes: []CheckChange{
+					{Name: "Binary-Artifacts", Previous: 10, Current: 9},
+					{Name: "Token-Permissions", Previous: checker.InconclusiveResultScore, Current: 0},
+				},
+				NewFindings: []Finding{
+					{Check: "Binary-Artifacts", Text: "Warn: binary detected: lib/tool.jar"},
+				},
+			},
+		},
+		{
+			name:     "unchanged",
+			previous: current,
+			current:  current,
+			want: RepoDigest{
+				Repo:          "github.com/org/repo",
+				Commit:        "bbb",
+				Date:          "2023-03-13T00:00:00Z",
+				PreviousScore: 4.5,
+				Score:         4.5,
+			},
+		},
+		{
+			name:    "new repo",
+			current: current,
+			want: RepoDigest{
+				Repo:          "github.com/org/repo",
+				Commit:        "bbb",
+				Date:          "2023-03-13T00:00:00Z",
+				PreviousScore: checker.InconclusiveResultScore,
+				Score:         4.5,
+				New:           true,
+			},
+		},
+		{
+			name:     "missing results",
+			previous: previous,
+			want: RepoDigest{
+				Repo:          "github.com/org/repo",
+				PreviousScore: 5,
+				Score:         checker.InconclusiveResultScore,
+				Missing:       true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Compare("github.com/org/repo", tt.previous, tt.current)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Compare() mismatch (-want +got):\n%s", diff)
+			}
+			if wantChanged := tt.name != "unchanged"; got.Changed() != wantChanged {
+				t.Errorf("Changed() = %v, want %v", got.Changed(), wantChanged)
+			}
+		})
+	}
+}
+
+func TestReadRepoList(t *testing.T) {
+	t.Parallel()
+	got, err := ReadRepoList(strings.NewReader(`
+# Critical dependencies.
+github.com/ossf/scorecard
+
+  github.com/ossf/scorecard-action
+`))
+	if err != nil {
+		t.Fatalf("ReadRepoList: %v", err)
+	}
+	want := []string{"github.com/ossf/scorecard", "github.com/ossf/scorecard-action"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadRepoList() mismatch (-want +got):\n%s", diff)
+	}
+}
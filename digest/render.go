@@ -0,0 +1,164 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package digest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// The HTML is kept simple, with inline styles only, so that it renders in
+// email clients.
+var htmlTemplate = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"score":    scoreString,
+	"intScore": intScoreString,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Title}}</title></head>
+<body style="font-family: sans-serif">
+<h1>{{.Title}}</h1>
+<p>Generated on {{.Generated.Format "2006-01-02"}} for {{len .Repos}} repositories.</p>
+{{- $changed := .ChangedRepos}}
+{{- if not $changed}}
+<p>No changes.</p>
+{{- end}}
+{{- range $changed}}
+{{template "repo" .}}
+{{- end}}
+</body>
+</html>
+{{define "repo" -}}
+<h2>{{.Repo}}</h2>
+{{- if .Missing}}
+<p>No current results.</p>
+{{- else}}
+<p>Score: {{score .PreviousScore}} &rarr; <b>{{score .Score}}</b>{{if .Commit}} at <code>{{.Commit}}</code>{{end}}</p>
+{{- end}}
+{{- if .Changes}}
+<table style="border-collapse: collapse">
+<tr><th align="left">Check</th><th>Before</th><th>After</th></tr>
+{{- range .Changes}}
+<tr><td>{{.Name}}</td><td align="center">{{intScore .Previous}}</td><td align="center">{{intScore .Current}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .NewFindings}}
+<p>New findings:</p>
+<ul>
+{{- range .NewFindings}}
+<li>{{.Check}}: {{.Text}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+`))
+
+func scoreString(s float64) string {
+	if s == checker.InconclusiveResultScore {
+		return "?"
+	}
+	return fmt.Sprintf("%.1f", s)
+}
+
+func intScoreString(s int) string {
+	if s == checker.InconclusiveResultScore {
+		return "?"
+	}
+	return fmt.Sprintf("%d", s)
+}
+
+// WriteHTML renders the digest as an HTML page, e.g. to be sent by email.
+func (d *Digest) WriteHTML(w io.Writer) error {
+	if err := htmlTemplate.Execute(w, d); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("template.Execute: %v", err))
+	}
+	return nil
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Content atomContent `xml:"content"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Link    *atomLink   `xml:"link,omitempty"`
+	Author  atomAuthor  `xml:"author"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// WriteAtom renders the digest as an Atom feed with one entry per changed
+// repo. feedURL is the URL the feed is published at, it can be empty.
+func (d *Digest) WriteAtom(w io.Writer, feedURL string) error {
+	updated := d.Generated.UTC().Format(time.RFC3339)
+	id := fmt.Sprintf("urn:scorecard:digest:%s", d.Generated.UTC().Format("2006-01-02"))
+	feed := atomFeed{
+		ID:      id,
+		Title:   d.Title,
+		Updated: updated,
+		Author:  atomAuthor{Name: "OpenSSF Scorecard"},
+	}
+	if feedURL != "" {
+		feed.Link = &atomLink{Href: feedURL, Rel: "self"}
+	}
+	for _, repo := range d.ChangedRepos() {
+		repo := repo
+		var body bytes.Buffer
+		if err := htmlTemplate.ExecuteTemplate(&body, "repo", &repo); err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("template.ExecuteTemplate: %v", err))
+		}
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:      fmt.Sprintf("%s:%s", id, repo.Repo),
+			Title:   fmt.Sprintf("%s: %s -> %s", repo.Repo, scoreString(repo.PreviousScore), scoreString(repo.Score)),
+			Updated: updated,
+			Content: atomContent{Type: "html", Body: body.String()},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("io.WriteString: %v", err))
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("xml.Encode: %v", err))
+	}
+	return nil
+}
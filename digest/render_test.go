@@ -0,0 +1,88 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package digest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testDigest(t *testing.T) *Digest {
+	t.Helper()
+	return &Digest{
+		Title:     "Weekly digest",
+		Generated: time.Date(2023, 3, 13, 6, 0, 0, 0, time.UTC),
+		Repos: []RepoDigest{
+			Compare("github.com/org/repo",
+				readTestResult(t, "testdata/previous.json"), readTestResult(t, "testdata/current.json")),
+			Compare("github.com/org/unchanged",
+				readTestResult(t, "testdata/current.json"), readTestResult(t, "testdata/current.json")),
+		},
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if err := testDigest(t).WriteHTML(&out); err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+	html := out.String()
+	for _, s := range []string{
+		"<h1>Weekly digest</h1>",
+		"for 2 repositories",
+		"<h2>github.com/org/repo</h2>",
+		"Score: 5.0 &rarr; <b>4.5</b>",
+		"<tr><td>Token-Permissions</td><td align=\"center\">?</td><td align=\"center\">0</td></tr>",
+		"<li>Binary-Artifacts: Warn: binary detected: lib/tool.jar</li>",
+	} {
+		if !strings.Contains(html, s) {
+			t.Errorf("WriteHTML() output does not contain %q:\n%s", s, html)
+		}
+	}
+	if strings.Contains(html, "github.com/org/unchanged") {
+		t.Errorf("WriteHTML() output contains an unchanged repo:\n%s", html)
+	}
+}
+
+func TestWriteAtom(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if err := testDigest(t).WriteAtom(&out, "https://example.com/feed.atom"); err != nil {
+		t.Fatalf("WriteAtom: %v", err)
+	}
+	var feed atomFeed
+	if err := xml.Unmarshal(out.Bytes(), &feed); err != nil {
+		t.Fatalf("xml.Unmarshal: %v\n%s", err, out.String())
+	}
+	if feed.ID != "urn:scorecard:digest:2023-03-13" || feed.Updated != "2023-03-13T06:00:00Z" {
+		t.Errorf("feed id, updated = %q, %q", feed.ID, feed.Updated)
+	}
+	if feed.Link == nil || feed.Link.Href != "https://example.com/feed.atom" {
+		t.Errorf("feed link = %v", feed.Link)
+	}
+	if len(feed.Entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(feed.Entries))
+	}
+	entry := feed.Entries[0]
+	if entry.Title != "github.com/org/repo: 5.0 -> 4.5" {
+		t.Errorf("entry title = %q", entry.Title)
+	}
+	if entry.Content.Type != "html" || !strings.Contains(entry.Content.Body, "lib/tool.jar") {
+		t.Errorf("entry content = %+v", entry.Content)
+	}
+}
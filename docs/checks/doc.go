@@ -15,6 +15,10 @@
 // Package checks contains documentation about checks.
 package checks
 
+import "github.com/ossf/scorecard/v4/checker"
+
+//go:generate go run ./internal/generate ../checks.md ../checks.json
+
 // Doc defines the documentation interface.
 type Doc interface {
 	GetCheck(name string) (CheckDoc, error)
@@ -31,5 +35,7 @@ type CheckDoc interface {
 	GetRemediation() []string
 	GetTags() []string
 	GetSupportedRepoTypes() []string
+	GetInputs() []string
+	GetScoring() []checker.ScoringRule
 	GetDocumentationURL(commitish string) string
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/docs/checks/internal"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
@@ -111,20 +112,22 @@ func (c *CheckDocImpl) GetRemediation() []string {
 // GetSupportedRepoTypes returns the list of repo
 // types the check supports.
 func (c *CheckDocImpl) GetSupportedRepoTypes() []string {
-	l := strings.Split(c.internalCheck.Repos, ",")
-	for i := range l {
-		l[i] = strings.TrimSpace(l[i])
-	}
-	return l
+	return c.internalCheck.Repos
 }
 
 // GetTags returns the list of tags or the check.
 func (c *CheckDocImpl) GetTags() []string {
-	l := strings.Split(c.internalCheck.Tags, ",")
-	for i := range l {
-		l[i] = strings.TrimSpace(l[i])
-	}
-	return l
+	return c.internalCheck.Tags
+}
+
+// GetInputs returns the client methods the check reads the repo with.
+func (c *CheckDocImpl) GetInputs() []string {
+	return c.internalCheck.Inputs
+}
+
+// GetScoring returns the scoring rubric of the check.
+func (c *CheckDocImpl) GetScoring() []checker.ScoringRule {
+	return c.internalCheck.Scoring
 }
 
 // GetDocumentationURL returns the URL for the documentation of check `name`.
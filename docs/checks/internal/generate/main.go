@@ -15,74 +15,33 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
-	"sort"
 
 	docs "github.com/ossf/scorecard/v4/docs/checks"
 )
 
 func main() {
-	if len(os.Args) != 2 {
+	if len(os.Args) != 3 {
 		//nolint: goerr113
-		panic(fmt.Errorf("usage: %s filename", os.Args[0]))
+		panic(fmt.Errorf("usage: %s checks.md checks.json", os.Args[0]))
 	}
-	yamlFile := os.Args[1]
 
 	m, err := docs.Read()
 	if err != nil {
 		panic(err)
 	}
-	checks := m.GetChecks()
-	keys := make([]string, 0, len(checks))
-	for _, v := range checks {
-		keys = append(keys, v.GetName())
-	}
-	sort.Strings(keys)
+	write(os.Args[1], m, docs.WriteMarkdown)
+	write(os.Args[2], m, docs.WriteJSON)
+}
 
-	f, err := os.Create(yamlFile)
+func write(filename string, m docs.Doc, render func(io.Writer, docs.Doc) error) {
+	f, err := os.Create(filename)
 	if err != nil {
 		panic(err)
 	}
 	defer f.Close()
-	_, err = f.WriteString(`
-<!-- Do not edit this file manually! Edit checks.yaml instead. --> 
-# Check Documentation
-
-This page describes each Scorecard check in detail, including scoring criteria,
-remediation steps to improve the score, and an explanation of the risks
-associated with a low score. The checks are continually changing and we welcome
-community feedback. If you have ideas for additions or new detection techniques,
-please [contribute](../CONTRIBUTING.md)!
-`)
-	if err != nil {
+	if err := render(f, m); err != nil {
 		panic(err)
 	}
-	for _, k := range keys {
-		_, err := f.WriteString("## " + k + " \n\n")
-		if err != nil {
-			panic(err)
-		}
-		c, err := m.GetCheck(k)
-		if err != nil {
-			panic(err)
-		}
-		_, err = f.WriteString(c.GetDescription() + " \n\n")
-		if err != nil {
-			panic(err)
-		}
-		_, err = f.WriteString("**Remediation steps**\n")
-		if err != nil {
-			panic(err)
-		}
-		for _, r := range c.GetRemediation() {
-			_, err = f.WriteString("- " + r + "\n")
-			if err != nil {
-				panic(err)
-			}
-		}
-		_, err = f.WriteString("\n")
-		if err != nil {
-			panic(err)
-		}
-	}
 }
@@ -12,44 +12,33 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package internal contains internal functions for reading input YAML file.
+// Package internal contains internal functions for reading the metadata
+// registered with the checks.
 package internal
 
 import (
-	// Used to embed `checks.yaml` file.
-	_ "embed"
-	"fmt"
-
-	"gopkg.in/yaml.v2"
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
 )
 
-//go:embed checks.yaml
-var checksYAML []byte
-
 // Check stores a check's information.
-//
-//nolint:govet
 type Check struct {
-	Risk        string   `yaml:"risk"`
-	Short       string   `yaml:"short"`
-	Description string   `yaml:"description"`
-	Tags        string   `yaml:"tags"`
-	Repos       string   `yaml:"repos"`
-	Remediation []string `yaml:"remediation"`
-	Name        string   `yaml:"-"`
-	URL         string   `yaml:"-"`
+	checker.CheckMetadata
+	Name string
+	URL  string
 }
 
 // Doc stores the documentation for all checks.
 type Doc struct {
-	InternalChecks map[string]Check `yaml:"checks"`
+	InternalChecks map[string]Check
 }
 
-// ReadDoc reads documentation from the `checks.yaml` file.
+// ReadDoc reads the documentation from the metadata of the registered
+// checks, including the experimental ones.
 func ReadDoc() (Doc, error) {
-	var m Doc
-	if err := yaml.Unmarshal(checksYAML, &m); err != nil {
-		return Doc{}, fmt.Errorf("yaml.Unmarshal: %w", err)
+	m := Doc{InternalChecks: map[string]Check{}}
+	for name, c := range checks.GetAllWithExperimental() {
+		m.InternalChecks[name] = Check{CheckMetadata: c.Metadata}
 	}
 	return m, nil
 }
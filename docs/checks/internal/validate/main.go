@@ -15,14 +15,41 @@ package main
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
+	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/clients"
 	docs "github.com/ossf/scorecard/v4/docs/checks"
 )
 
 var allowedRisks = map[string]bool{"Critical": true, "High": true, "Medium": true, "Low": true}
 
+// inputTypes are the types declaring the methods named by the inputs of the
+// checks, by the name of the field of checker.CheckRequest holding them.
+var inputTypes = map[string]reflect.Type{
+	"RepoClient":             reflect.TypeOf((*clients.RepoClient)(nil)).Elem(),
+	"OssFuzzRepo":            reflect.TypeOf((*clients.RepoClient)(nil)).Elem(),
+	"CIIBestPracticesClient": reflect.TypeOf((*clients.CIIBestPracticesClient)(nil)).Elem(),
+	"VulnerabilitiesClient":  reflect.TypeOf((*clients.VulnerabilitiesClient)(nil)).Elem(),
+	"ActionTags":             reflect.TypeOf((*clients.ActionTagsClient)(nil)).Elem(),
+}
+
+func validInput(input string) bool {
+	// Inputs which are not clients, e.g. CheckRequest.Denylist.
+	if strings.HasPrefix(input, "CheckRequest.") {
+		_, ok := reflect.TypeOf(checker.CheckRequest{}).FieldByName(strings.TrimPrefix(input, "CheckRequest."))
+		return ok
+	}
+	typ, method, found := strings.Cut(input, ".")
+	if !found || inputTypes[typ] == nil {
+		return false
+	}
+	_, ok := inputTypes[typ].MethodByName(method)
+	return ok
+}
+
 func main() {
 	m, err := docs.Read()
 	if err != nil {
@@ -51,6 +78,20 @@ func main() {
 		if len(c.GetTags()) == 0 {
 			panic(fmt.Sprintf("tags for checkName: %s is empty", check))
 		}
+		if len(c.GetSupportedRepoTypes()) == 0 {
+			panic(fmt.Sprintf("repos for checkName: %s is empty", check))
+		}
+		if len(c.GetScoring()) == 0 {
+			panic(fmt.Sprintf("scoring for checkName: %s is empty", check))
+		}
+		if len(c.GetInputs()) == 0 {
+			panic(fmt.Sprintf("inputs for checkName: %s is empty", check))
+		}
+		for _, input := range c.GetInputs() {
+			if !validInput(input) {
+				panic(fmt.Sprintf("input for checkName: %s is not a client method: '%s'", check, input))
+			}
+		}
 		r := c.GetRisk()
 		if _, exists := allowedRisks[r]; !exists {
 			panic(fmt.Sprintf("risk for checkName: %s is invalid: '%s'", check, r))
@@ -58,7 +99,7 @@ func main() {
 	}
 	for _, check := range m.GetChecks() {
 		if _, exists := allChecks[check.GetName()]; !exists {
-			panic(fmt.Sprintf("check present in checks.yaml is not part of `checks.GetAll()`: %s", check.GetName()))
+			panic(fmt.Sprintf("documented check is not part of `checks.GetAll()`: %s", check.GetName()))
 		}
 	}
 }
@@ -0,0 +1,119 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const markdownHeader = `
+<!-- Do not edit this file manually! Edit the metadata of the checks in checks/ instead. --> 
+# Check Documentation
+
+This page describes each Scorecard check in detail, including scoring criteria,
+remediation steps to improve the score, and an explanation of the risks
+associated with a low score. The checks are continually changing and we welcome
+community feedback. If you have ideas for additions or new detection techniques,
+please [contribute](../CONTRIBUTING.md)!
+`
+
+type jsonScoringRule struct {
+	Score    string `json:"score"`
+	Criteria string `json:"criteria"`
+}
+
+//nolint:govet
+type jsonCheck struct {
+	Name             string            `json:"name"`
+	Risk             string            `json:"risk"`
+	Short            string            `json:"short"`
+	Description      string            `json:"description"`
+	Tags             []string          `json:"tags"`
+	Repos            []string          `json:"repos"`
+	Inputs           []string          `json:"inputs"`
+	Scoring          []jsonScoringRule `json:"scoring"`
+	Remediation      []string          `json:"remediation"`
+	DocumentationURL string            `json:"documentationURL"`
+}
+
+type jsonChecks struct {
+	Checks []jsonCheck `json:"checks"`
+}
+
+func sortedChecks(d Doc) []CheckDoc {
+	checks := d.GetChecks()
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].GetName() < checks[j].GetName()
+	})
+	return checks
+}
+
+// WriteMarkdown writes the documentation of the checks in d as Markdown,
+// the format of docs/checks.md.
+func WriteMarkdown(w io.Writer, d Doc) error {
+	var sb strings.Builder
+	sb.WriteString(markdownHeader)
+	for _, c := range sortedChecks(d) {
+		sb.WriteString("## " + c.GetName() + " \n\n")
+		sb.WriteString(c.GetDescription() + " \n\n")
+		sb.WriteString("**Scoring**\n")
+		for _, r := range c.GetScoring() {
+			sb.WriteString("- **" + r.Score + "**: " + r.Criteria + "\n")
+		}
+		sb.WriteString("\n**Remediation steps**\n")
+		for _, r := range c.GetRemediation() {
+			sb.WriteString("- " + r + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return fmt.Errorf("io.WriteString: %w", err)
+	}
+	return nil
+}
+
+// WriteJSON writes the documentation of the checks in d as JSON, the format
+// of docs/checks.json.
+func WriteJSON(w io.Writer, d Doc) error {
+	var out jsonChecks
+	for _, c := range sortedChecks(d) {
+		jc := jsonCheck{
+			Name:             c.GetName(),
+			Risk:             c.GetRisk(),
+			Short:            c.GetShort(),
+			Description:      c.GetDescription(),
+			Tags:             c.GetTags(),
+			Repos:            c.GetSupportedRepoTypes(),
+			Inputs:           c.GetInputs(),
+			Remediation:      c.GetRemediation(),
+			DocumentationURL: c.GetDocumentationURL(""),
+		}
+		for _, r := range c.GetScoring() {
+			jc.Scoring = append(jc.Scoring, jsonScoringRule{Score: r.Score, Criteria: r.Criteria})
+		}
+		out.Checks = append(out.Checks, jc)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(out); err != nil {
+		return fmt.Errorf("encoder.Encode: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+// TestGeneratedDocs fails when docs/checks.md or docs/checks.json is not up
+// to date with the metadata of the checks.
+func TestGeneratedDocs(t *testing.T) {
+	t.Parallel()
+	m, err := Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	tests := []struct {
+		render   func(io.Writer, Doc) error
+		filename string
+	}{
+		{filename: "../checks.md", render: WriteMarkdown},
+		{filename: "../checks.json", render: WriteJSON},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.filename, func(t *testing.T) {
+			t.Parallel()
+			want, err := os.ReadFile(tt.filename)
+			if err != nil {
+				t.Fatalf("os.ReadFile: %v", err)
+			}
+			var got bytes.Buffer
+			if err := tt.render(&got, m); err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("%s is out of date, run `make generate-docs`", tt.filename)
+			}
+		})
+	}
+}
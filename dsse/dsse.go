@@ -0,0 +1,175 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dsse signs and verifies documents, e.g. scorecard results, in
+// Dead Simple Signing Envelopes, see
+// https://github.com/secure-systems-lab/dsse/blob/master/envelope.md.
+package dsse
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// PayloadTypeResult is the payload type of signed scorecard results.
+const PayloadTypeResult = "application/vnd.ossf.scorecard.result+json"
+
+// MediaType is the media type of an envelope served over HTTP.
+const MediaType = "application/vnd.dsse.envelope.v1+json"
+
+var (
+	errNoKey            = errors.New("no PEM key found")
+	errUnsupportedKey   = errors.New("unsupported key type")
+	errPayloadType      = errors.New("unexpected payload type")
+	errNoValidSignature = errors.New("no valid signature")
+)
+
+// Envelope is a DSSE envelope. Payload and signatures are base64-encoded in
+// JSON.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     []byte      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is a signature of an envelope.
+type Signature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   []byte `json:"sig"`
+}
+
+// pae returns the pre-authentication encoding of the payload, which is what
+// is actually signed.
+func pae(payloadType string, payload []byte) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	b.Write(payload)
+	return b.Bytes()
+}
+
+// KeyID returns the ID of a public key, the hex SHA-256 digest of its PKIX
+// encoding.
+func KeyID(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("x509.MarshalPKIXPublicKey: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Sign returns an envelope of payload signed with signer, an ECDSA, Ed25519
+// or RSA private key.
+func Sign(payloadType string, payload []byte, signer crypto.Signer) (*Envelope, error) {
+	keyID, err := KeyID(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	message := pae(payloadType, payload)
+	var sig []byte
+	switch signer.Public().(type) {
+	case ed25519.PublicKey:
+		sig, err = signer.Sign(rand.Reader, message, crypto.Hash(0))
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		sig, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedKey, signer.Public())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Sign: %w", err)
+	}
+	return &Envelope{
+		PayloadType: payloadType,
+		Payload:     payload,
+		Signatures:  []Signature{{KeyID: keyID, Sig: sig}},
+	}, nil
+}
+
+// Verify returns the payload of the envelope if it has the given type and
+// one of its signatures is valid for pub.
+func Verify(env *Envelope, payloadType string, pub crypto.PublicKey) ([]byte, error) {
+	if env.PayloadType != payloadType {
+		return nil, fmt.Errorf("%w: %q", errPayloadType, env.PayloadType)
+	}
+	message := pae(env.PayloadType, env.Payload)
+	digest := sha256.Sum256(message)
+	for _, s := range env.Signatures {
+		var valid bool
+		switch key := pub.(type) {
+		case ed25519.PublicKey:
+			valid = ed25519.Verify(key, message, s.Sig)
+		case *ecdsa.PublicKey:
+			valid = ecdsa.VerifyASN1(key, digest[:], s.Sig)
+		case *rsa.PublicKey:
+			valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], s.Sig) == nil
+		default:
+			return nil, fmt.Errorf("%w: %T", errUnsupportedKey, pub)
+		}
+		if valid {
+			return env.Payload, nil
+		}
+	}
+	return nil, errNoValidSignature
+}
+
+// ParsePrivateKey parses a PEM-encoded PKCS #8, SEC 1 (EC) or PKCS #1 (RSA)
+// private key.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errNoKey
+	}
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", block.Type, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errUnsupportedKey, key)
+	}
+	return signer, nil
+}
+
+// ParsePublicKey parses a PEM-encoded PKIX public key.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errNoKey
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("x509.ParsePKIXPublicKey: %w", err)
+	}
+	return pub, nil
+}
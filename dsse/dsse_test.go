@@ -0,0 +1,145 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsse
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"testing"
+)
+
+func testSigners(t *testing.T) map[string]crypto.Signer {
+	t.Helper()
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey: %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	return map[string]crypto.Signer{"ecdsa": ecKey, "ed25519": edKey, "rsa": rsaKey}
+}
+
+func TestSignVerify(t *testing.T) {
+	t.Parallel()
+	payload := []byte(`{"repo":{"name":"github.com/ossf/scorecard"}}`)
+	signers := testSigners(t)
+	for name, signer := range signers {
+		signer := signer
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			env, err := Sign(PayloadTypeResult, payload, signer)
+			if err != nil {
+				t.Fatalf("Sign: %v", err)
+			}
+			// The envelope survives a JSON round trip.
+			b, err := json.Marshal(env)
+			if err != nil {
+				t.Fatalf("json.Marshal: %v", err)
+			}
+			var decoded Envelope
+			if err := json.Unmarshal(b, &decoded); err != nil {
+				t.Fatalf("json.Unmarshal: %v", err)
+			}
+			got, err := Verify(&decoded, PayloadTypeResult, signer.Public())
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if string(got) != string(payload) {
+				t.Errorf("Verify() = %s, want %s", got, payload)
+			}
+
+			if _, err := Verify(&decoded, "application/json", signer.Public()); !errors.Is(err, errPayloadType) {
+				t.Errorf("Verify() with another payload type error = %v, want %v", err, errPayloadType)
+			}
+			tampered := decoded
+			tampered.Payload = []byte(`{"repo":{"name":"github.com/evil/scorecard"}}`)
+			if _, err := Verify(&tampered, PayloadTypeResult, signer.Public()); !errors.Is(err, errNoValidSignature) {
+				t.Errorf("Verify() of a tampered payload error = %v, want %v", err, errNoValidSignature)
+			}
+		})
+	}
+}
+
+func TestVerifyOtherKey(t *testing.T) {
+	t.Parallel()
+	signers := testSigners(t)
+	env, err := Sign(PayloadTypeResult, []byte("{}"), signers["ecdsa"])
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	if _, err := Verify(env, PayloadTypeResult, other.Public()); !errors.Is(err, errNoValidSignature) {
+		t.Errorf("Verify() error = %v, want %v", err, errNoValidSignature)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	t.Parallel()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	sec1, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("x509.MarshalECPrivateKey: %v", err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("x509.MarshalPKCS8PrivateKey: %v", err)
+	}
+	pkix, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("x509.MarshalPKIXPublicKey: %v", err)
+	}
+	for _, block := range []*pem.Block{
+		{Type: "EC PRIVATE KEY", Bytes: sec1},
+		{Type: "PRIVATE KEY", Bytes: pkcs8},
+	} {
+		signer, err := ParsePrivateKey(pem.EncodeToMemory(block))
+		if err != nil {
+			t.Fatalf("ParsePrivateKey(%s): %v", block.Type, err)
+		}
+		if !key.Equal(signer) {
+			t.Errorf("ParsePrivateKey(%s) returned another key", block.Type)
+		}
+	}
+	pub, err := ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}))
+	if err != nil {
+		t.Fatalf("ParsePublicKey: %v", err)
+	}
+	if !key.PublicKey.Equal(pub) {
+		t.Error("ParsePublicKey returned another key")
+	}
+	if _, err := ParsePublicKey([]byte("not a key")); !errors.Is(err, errNoKey) {
+		t.Errorf("ParsePublicKey() error = %v, want %v", err, errNoKey)
+	}
+}
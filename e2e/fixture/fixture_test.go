@@ -0,0 +1,134 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixture
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/githubrepo"
+	"github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper"
+	"github.com/ossf/scorecard/v4/log"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+const (
+	manifestPath = "testdata/fixtures.yaml"
+	cassetteDir  = "testdata/cassettes"
+)
+
+// Checks which depend on services other than GitHub cannot be replayed.
+var unsupportedChecks = map[string]bool{
+	checks.CheckCIIBestPractices: true,
+	checks.CheckFuzzing:          true,
+	checks.CheckVulnerabilities:  true,
+}
+
+//nolint:paralleltest // t.Setenv is not compatible with t.Parallel.
+func TestFixtures(t *testing.T) {
+	t.Setenv("SCORECARD_EXPERIMENTAL", "true")
+	m, err := LoadManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	mode := os.Getenv(ModeEnv)
+	if mode == "" {
+		mode = ModeReplay
+	}
+	if mode != ModeReplay && mode != ModeRecord {
+		t.Fatalf("invalid %s: %q", ModeEnv, mode)
+	}
+	allChecks := checks.GetAllWithExperimental()
+	for i := range m.Repos {
+		r := &m.Repos[i]
+		name := r.Name
+		if r.Commit != "" {
+			name += "@" + r.Commit
+		}
+		t.Run(name, func(t *testing.T) {
+			for name := range r.Checks {
+				if _, ok := allChecks[name]; !ok {
+					t.Fatalf("unknown check %q", name)
+				}
+				if unsupportedChecks[name] {
+					t.Fatalf("check %q cannot be run against a cassette", name)
+				}
+			}
+			path := r.CassettePath(cassetteDir)
+			var transport *Transport
+			if mode == ModeRecord {
+				ctx := context.Background()
+				transport = NewRecorder(path, roundtripper.NewTransport(ctx, log.NewLogger(log.DefaultLevel)))
+			} else {
+				if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+					t.Fatalf("no cassette recorded at %s, record it with make e2e-fixtures-record", path)
+				}
+				transport, err = NewReplayer(path)
+				if err != nil {
+					t.Fatalf("NewReplayer: %v", err)
+				}
+			}
+			runFixture(t, r, transport, allChecks)
+			if mode == ModeRecord {
+				if err := transport.Save(); err != nil {
+					t.Fatalf("Save: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func runFixture(t *testing.T, r *Repo, rt http.RoundTripper, allChecks checker.CheckNameToFnMap) {
+	t.Helper()
+	ctx := context.Background()
+	repo, err := githubrepo.MakeGithubRepo(r.Name)
+	if err != nil {
+		t.Fatalf("MakeGithubRepo: %v", err)
+	}
+	commit := r.Commit
+	if commit == "" {
+		commit = clients.HeadSHA
+	}
+	repoClient := githubrepo.CreateGithubRepoClientWithTransport(ctx, rt)
+	if err := repoClient.InitRepo(repo, commit, 0); err != nil {
+		t.Fatalf("InitRepo: %v", err)
+	}
+	defer repoClient.Close()
+	for name, want := range r.Checks {
+		dl := scut.TestDetailLogger{}
+		req := checker.CheckRequest{
+			Ctx:        ctx,
+			RepoClient: repoClient,
+			Repo:       repo,
+			Dlogger:    &dl,
+		}
+		expected := scut.TestReturn{
+			Score:         want.Score,
+			NumberOfWarn:  want.Warn,
+			NumberOfInfo:  want.Info,
+			NumberOfDebug: want.Debug,
+		}
+		result := allChecks[name].Fn(&req)
+		if !scut.ValidateTestReturn(t, name, &expected, &result, &dl) {
+			t.Errorf("%s: got score %d, reason %q", name, result.Score, result.Reason)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixture
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ModeEnv is the environment variable which selects how fixtures are run.
+// Set it to ModeRecord to refresh the cassettes against the live fixture org.
+const ModeEnv = "SCORECARD_FIXTURE_MODE"
+
+const (
+	// ModeReplay replays the recorded cassettes. This is the default.
+	ModeReplay = "replay"
+	// ModeRecord sends requests to GitHub and overwrites the cassettes.
+	ModeRecord = "record"
+)
+
+var errInvalidManifest = errors.New("invalid fixture manifest")
+
+// Manifest lists the repos of the fixture org and the results expected for them.
+type Manifest struct {
+	Repos []Repo `yaml:"repos"`
+}
+
+// Repo is a fixture repo with the exact results expected from its checks.
+type Repo struct {
+	Checks map[string]Expected `yaml:"checks"`
+	Name   string              `yaml:"name"`
+	Commit string              `yaml:"commit"`
+}
+
+// Expected is the result expected from a single check.
+type Expected struct {
+	Score int `yaml:"score"`
+	Warn  int `yaml:"warn"`
+	Info  int `yaml:"info"`
+	Debug int `yaml:"debug"`
+}
+
+// LoadManifest reads and validates the manifest at path.
+func LoadManifest(path string) (*Manifest, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+	var m Manifest
+	if err := yaml.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("yaml.Unmarshal: %w", err)
+	}
+	seen := make(map[string]bool)
+	for _, r := range m.Repos {
+		if strings.Count(r.Name, "/") != 1 {
+			return nil, fmt.Errorf("%w: repo name %q is not owner/repo", errInvalidManifest, r.Name)
+		}
+		if seen[r.Name+"@"+r.Commit] {
+			return nil, fmt.Errorf("%w: duplicate repo %s@%s", errInvalidManifest, r.Name, r.Commit)
+		}
+		seen[r.Name+"@"+r.Commit] = true
+		if len(r.Checks) == 0 {
+			return nil, fmt.Errorf("%w: repo %s has no checks", errInvalidManifest, r.Name)
+		}
+	}
+	return &m, nil
+}
+
+// CassettePath returns the path of the cassette of the repo in dir.
+func (r *Repo) CassettePath(dir string) string {
+	name := strings.ReplaceAll(r.Name, "/", "_")
+	if r.Commit != "" {
+		name += "@" + r.Commit
+	}
+	return filepath.Join(dir, name+".json")
+}
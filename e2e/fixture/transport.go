@@ -0,0 +1,218 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fixture replays recorded GitHub API traffic for a fixture organization
+// so that check results can be compared against exact expected scores.
+package fixture
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrNoInteraction is returned when a replayed request was not recorded.
+var ErrNoInteraction = errors.New("no recorded interaction")
+
+// Headers that are never written to a cassette.
+var redactedHeaders = []string{"Authorization", "Set-Cookie", "X-Github-Request-Id"}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request identifies a recorded request.
+type Request struct {
+	Method   string `json:"method"`
+	URL      string `json:"url"`
+	BodyHash string `json:"bodyHash,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+	Status int         `json:"status"`
+}
+
+// Cassette is the on-disk format of the recorded traffic of one fixture repo.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Transport is an http.RoundTripper which either records the traffic going
+// through an inner transport or replays a previously recorded cassette.
+type Transport struct {
+	inner    http.RoundTripper
+	recorded map[string][]*Interaction
+	served   map[string]int
+	path     string
+	cassette Cassette
+	mu       sync.Mutex
+}
+
+// NewRecorder returns a Transport which sends requests through inner and records
+// them. Call Save to write the cassette to path.
+func NewRecorder(path string, inner http.RoundTripper) *Transport {
+	return &Transport{
+		inner: inner,
+		path:  path,
+	}
+}
+
+// NewReplayer returns a Transport which serves the responses of the cassette at path.
+// Requests which were not recorded fail with ErrNoInteraction.
+func NewReplayer(path string) (*Transport, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+	t := &Transport{
+		path:     path,
+		recorded: make(map[string][]*Interaction),
+		served:   make(map[string]int),
+	}
+	if err := json.Unmarshal(content, &t.cassette); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	for _, i := range t.cassette.Interactions {
+		k := i.Request.key()
+		t.recorded[k] = append(t.recorded[k], i)
+	}
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	req, err := newRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	if t.inner == nil {
+		return t.replay(r, req)
+	}
+	return t.record(r, req)
+}
+
+// Save writes the recorded interactions to the cassette path.
+func (t *Transport) Save() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	content, err := json.MarshalIndent(&t.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json.MarshalIndent: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0o755); err != nil {
+		return fmt.Errorf("os.MkdirAll: %w", err)
+	}
+	if err := os.WriteFile(t.path, content, 0o600); err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+	return nil
+}
+
+func (t *Transport) record(r *http.Request, req Request) (*http.Response, error) {
+	resp, err := t.inner.RoundTrip(r)
+	if err != nil {
+		return nil, fmt.Errorf("inner.RoundTrip: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("io.ReadAll: %w", err)
+	}
+	header := resp.Header.Clone()
+	for _, h := range redactedHeaders {
+		header.Del(h)
+	}
+	i := &Interaction{
+		Request: req,
+		Response: Response{
+			Status: resp.StatusCode,
+			Header: header,
+			Body:   body,
+		},
+	}
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, i)
+	t.mu.Unlock()
+	return i.Response.toHTTP(r), nil
+}
+
+// replay serves the recorded interactions of a request in the order they were
+// recorded, repeating the last one once they are exhausted.
+func (t *Transport) replay(r *http.Request, req Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	k := req.key()
+	recorded := t.recorded[k]
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+	}
+	n := t.served[k]
+	if n >= len(recorded) {
+		n = len(recorded) - 1
+	}
+	t.served[k] = n + 1
+	return recorded[n].Response.toHTTP(r), nil
+}
+
+func newRequest(r *http.Request) (Request, error) {
+	req := Request{
+		Method: r.Method,
+		URL:    r.URL.String(),
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return req, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return req, fmt.Errorf("io.ReadAll: %w", err)
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) > 0 {
+		sum := sha256.Sum256(body)
+		req.BodyHash = hex.EncodeToString(sum[:])
+	}
+	return req, nil
+}
+
+func (r Request) key() string {
+	return r.Method + " " + r.URL + " " + r.BodyHash
+}
+
+func (r Response) toHTTP(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixture
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTransportRecordReplay(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Link", `<https://api.github.com/next>; rel="next"`)
+		w.Header().Set("X-Github-Request-Id", "secret")
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("io.ReadAll: %v", err)
+		}
+		fmt.Fprintf(w, "%s %s %s %d", r.Method, r.URL.Path, body, calls)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder := NewRecorder(path, http.DefaultTransport)
+	requests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{method: http.MethodGet, path: "/repos/o/r"},
+		{method: http.MethodGet, path: "/repos/o/r"},
+		{method: http.MethodPost, path: "/graphql", body: "query1"},
+		{method: http.MethodPost, path: "/graphql", body: "query2"},
+	}
+	do := func(rt http.RoundTripper, method, path, body string) (*http.Response, string, error) {
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("http.NewRequest: %v", err)
+		}
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			return nil, "", err
+		}
+		defer resp.Body.Close()
+		content, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("io.ReadAll: %v", err)
+		}
+		return resp, string(content), nil
+	}
+
+	var recorded []string
+	for _, r := range requests {
+		_, body, err := do(recorder, r.method, r.path, r.body)
+		if err != nil {
+			t.Fatalf("record %s %s: %v", r.method, r.path, err)
+		}
+		recorded = append(recorded, body)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatalf("NewReplayer: %v", err)
+	}
+	for i, r := range requests {
+		resp, body, err := do(replayer, r.method, r.path, r.body)
+		if err != nil {
+			t.Fatalf("replay %s %s: %v", r.method, r.path, err)
+		}
+		if body != recorded[i] {
+			t.Errorf("replay %d: got body %q, want %q", i, body, recorded[i])
+		}
+		if resp.Header.Get("Link") == "" {
+			t.Errorf("replay %d: Link header was not recorded", i)
+		}
+		if resp.Header.Get("X-Github-Request-Id") != "" {
+			t.Errorf("replay %d: redacted header was recorded", i)
+		}
+	}
+	if calls != len(requests) {
+		t.Errorf("server received %d requests during replay, want %d", calls, len(requests))
+	}
+
+	if _, _, err := do(replayer, http.MethodPost, "/graphql", "query3"); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("unrecorded request: got %v, want %v", err, ErrNoInteraction)
+	}
+}
@@ -56,6 +56,8 @@ func GetName(err error) string {
 		return "ErrRepoUnreachable"
 	case errors.Is(err, ErrorShellParsing):
 		return "ErrorShellParsing"
+	case errors.Is(err, ErrorCheckRuntime):
+		return "ErrorCheckRuntime"
 	default:
 		return "ErrUnknown"
 	}
@@ -0,0 +1,210 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graph builds the trust graph of the CI of a repo: its workflows,
+// the actions and reusable workflows they use, whether those are pinned, and
+// the repos of the actions along with their Scorecard scores, so security
+// engineers can see which upstream repos their builds trust.
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rhysd/actionlint"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks/fileparser"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// commitRegex matches the refs pinning an action to a commit.
+var commitRegex = regexp.MustCompile(`^[a-fA-F\d]{40}$`)
+
+// Graph is the trust graph of the CI of a repo.
+type Graph struct {
+	Repo      string     `json:"repo"`
+	Workflows []Workflow `json:"workflows"`
+	// Repos are the repos of the actions used by the workflows, sorted by
+	// name.
+	Repos []ActionRepo `json:"repos"`
+}
+
+// Workflow is a workflow of the repo and the actions its jobs use.
+type Workflow struct {
+	Path string `json:"path"`
+	Uses []Use  `json:"uses"`
+}
+
+// Use is an action, or a reusable workflow, used by a job of a workflow.
+type Use struct {
+	// Action is the action or reusable workflow, e.g. actions/checkout or
+	// org/repo/.github/workflows/build.yml.
+	Action string `json:"action"`
+	Ref    string `json:"ref"`
+	// Repo is the repo of the action, e.g. github.com/actions/checkout.
+	Repo string `json:"repo"`
+	Job  string `json:"job"`
+	Line uint   `json:"line"`
+	// Pinned is whether Ref is a commit, rather than a tag or a branch which
+	// can be moved to another commit.
+	Pinned bool `json:"pinned"`
+	// ReusableWorkflow is whether the job calls a reusable workflow.
+	ReusableWorkflow bool `json:"reusableWorkflow,omitempty"`
+}
+
+// ActionRepo is the repo of actions used by the workflows.
+type ActionRepo struct {
+	Name string `json:"name"`
+	// Error is why the repo could not be scored, if it was not.
+	Error string `json:"error,omitempty"`
+	// Score is the aggregate score of the repo, or
+	// checker.InconclusiveResultScore if it was not scored.
+	Score float64 `json:"score"`
+	// Uses are how many times the workflows use the actions of the repo, and
+	// Unpinned how many of those uses are not pinned to a commit.
+	Uses     int `json:"uses"`
+	Unpinned int `json:"unpinned"`
+}
+
+// Build lists the actions used by the workflows of the repo of c, which
+// must be initialized. repo is the name of the repo, e.g.
+// github.com/owner/repo. Local actions and container images are not listed.
+func Build(repo string, c clients.RepoClient) (*Graph, error) {
+	g := Graph{Repo: repo, Workflows: []Workflow{}, Repos: []ActionRepo{}}
+	err := fileparser.OnMatchingFileContentDo(c, fileparser.PathMatcher{
+		Pattern:       fileparser.ActionsWorkflowPattern,
+		CaseSensitive: true,
+	}, func(path string, content []byte, args ...interface{}) (bool, error) {
+		if !fileparser.IsActionsWorkflowFile(path) {
+			return true, nil
+		}
+		workflow, err := parseWorkflow(path, content)
+		if err != nil {
+			return false, err
+		}
+		g.Workflows = append(g.Workflows, workflow)
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+	sort.Slice(g.Workflows, func(i, j int) bool {
+		return g.Workflows[i].Path < g.Workflows[j].Path
+	})
+
+	repos := map[string]*ActionRepo{}
+	for i := range g.Workflows {
+		for j := range g.Workflows[i].Uses {
+			u := &g.Workflows[i].Uses[j]
+			r, ok := repos[u.Repo]
+			if !ok {
+				r = &ActionRepo{Name: u.Repo, Score: checker.InconclusiveResultScore}
+				repos[u.Repo] = r
+			}
+			r.Uses++
+			if !u.Pinned {
+				r.Unpinned++
+			}
+		}
+	}
+	for _, r := range repos {
+		g.Repos = append(g.Repos, *r)
+	}
+	sort.Slice(g.Repos, func(i, j int) bool {
+		return g.Repos[i].Name < g.Repos[j].Name
+	})
+	return &g, nil
+}
+
+func parseWorkflow(path string, content []byte) (Workflow, error) {
+	w := Workflow{Path: path, Uses: []Use{}}
+	workflow, errs := actionlint.Parse(content)
+	if len(errs) > 0 && workflow == nil {
+		return Workflow{}, fileparser.FormatActionlintError(errs)
+	}
+	ids := make([]string, 0, len(workflow.Jobs))
+	for id := range workflow.Jobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		job := workflow.Jobs[id]
+		if job == nil {
+			continue
+		}
+		if job.WorkflowCall != nil && job.WorkflowCall.Uses != nil {
+			if u, ok := newUse(id, job.WorkflowCall.Uses); ok {
+				u.ReusableWorkflow = true
+				w.Uses = append(w.Uses, u)
+			}
+		}
+		for _, step := range job.Steps {
+			if uses := fileparser.GetUses(step); uses != nil {
+				if u, ok := newUse(id, uses); ok {
+					w.Uses = append(w.Uses, u)
+				}
+			}
+		}
+	}
+	return w, nil
+}
+
+// newUse returns the use of the action of uses, unless it is a local action
+// or a container image.
+func newUse(job string, uses *actionlint.String) (Use, bool) {
+	value := uses.Value
+	if strings.HasPrefix(value, "./") || strings.HasPrefix(value, "docker://") {
+		return Use{}, false
+	}
+	i := strings.LastIndex(value, "@")
+	if i < 0 {
+		return Use{}, false
+	}
+	action, ref := value[:i], value[i+1:]
+	// Forgejo workflows may use actions of other forges by URL.
+	host := "github.com"
+	if rest := strings.TrimPrefix(action, "https://"); rest != action {
+		host, action, _ = strings.Cut(rest, "/")
+	}
+	parts := strings.SplitN(action, "/", 3)
+	if len(parts) < 2 {
+		return Use{}, false
+	}
+	return Use{
+		Action: action,
+		Ref:    ref,
+		Repo:   host + "/" + parts[0] + "/" + parts[1],
+		Job:    job,
+		Line:   fileparser.GetLineNumber(uses.Pos),
+		Pinned: commitRegex.MatchString(ref),
+	}, true
+}
+
+// Score sets the scores of the repos of the actions to those returned by
+// score, or records why they could not be scored.
+func (g *Graph) Score(score func(repo string) (float64, error)) {
+	for i := range g.Repos {
+		r := &g.Repos[i]
+		s, err := score(r.Name)
+		if err != nil {
+			r.Error = err.Error()
+			continue
+		}
+		r.Score = s
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+const (
+	checkoutSHA = "8e5e7e5ab8b370d6c329ec480221332ada57f0ab"
+	buildYAML   = `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@` + checkoutSHA + `
+      - uses: actions/setup-go@v4
+      - uses: ./.github/actions/local
+      - uses: docker://alpine:3.18
+  lint:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: golangci/golangci-lint-action@v3
+      - uses: actions/setup-go@v4
+`
+	releaseYAML = `on: push
+jobs:
+  release:
+    uses: org/shared/.github/workflows/release.yml@main
+  checkout:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: https://gitea.com/actions/checkout@v3
+`
+)
+
+var errScore = errors.New("repo not found")
+
+func testGraph(t *testing.T) *Graph {
+	t.Helper()
+	files := map[string]string{
+		".github/workflows/release.yml": releaseYAML,
+		".github/workflows/build.yml":   buildYAML,
+		"README.md":                     "",
+	}
+	ctrl := gomock.NewController(t)
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(func(predicate func(string) (bool, error)) ([]string, error) {
+		var ret []string
+		for f := range files {
+			if ok, _ := predicate(f); ok {
+				ret = append(ret, f)
+			}
+		}
+		return ret, nil
+	}).AnyTimes()
+	mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(f string) ([]byte, error) {
+		return []byte(files[f]), nil
+	}).AnyTimes()
+
+	g, err := Build("github.com/owner/repo", mockRepoClient)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return g
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+	want := &Graph{
+		Repo: "github.com/owner/repo",
+		Workflows: []Workflow{
+			{
+				Path: ".github/workflows/build.yml",
+				Uses: []Use{
+					{
+						Action: "golangci/golangci-lint-action", Ref: "v3",
+						Repo: "github.com/golangci/golangci-lint-action", Job: "lint", Line: 13,
+					},
+					{
+						Action: "actions/setup-go", Ref: "v4",
+						Repo: "github.com/actions/setup-go", Job: "lint", Line: 14,
+					},
+					{
+						Action: "actions/checkout", Ref: checkoutSHA,
+						Repo: "github.com/actions/checkout", Job: "test", Line: 6, Pinned: true,
+					},
+					{
+						Action: "actions/setup-go", Ref: "v4",
+						Repo: "github.com/actions/setup-go", Job: "test", Line: 7,
+					},
+				},
+			},
+			{
+				Path: ".github/workflows/release.yml",
+				Uses: []Use{
+					{
+						Action: "actions/checkout", Ref: "v3",
+						Repo: "gitea.com/actions/checkout", Job: "checkout", Line: 8,
+					},
+					{
+						Action: "org/shared/.github/workflows/release.yml", Ref: "main",
+						Repo: "github.com/org/shared", Job: "release", Line: 4, ReusableWorkflow: true,
+					},
+				},
+			},
+		},
+		Repos: []ActionRepo{
+			{Name: "gitea.com/actions/checkout", Score: checker.InconclusiveResultScore, Uses: 1, Unpinned: 1},
+			{Name: "github.com/actions/checkout", Score: checker.InconclusiveResultScore, Uses: 1},
+			{Name: "github.com/actions/setup-go", Score: checker.InconclusiveResultScore, Uses: 2, Unpinned: 2},
+			{Name: "github.com/golangci/golangci-lint-action", Score: checker.InconclusiveResultScore, Uses: 1, Unpinned: 1},
+			{Name: "github.com/org/shared", Score: checker.InconclusiveResultScore, Uses: 1, Unpinned: 1},
+		},
+	}
+	if diff := cmp.Diff(want, testGraph(t)); diff != "" {
+		t.Errorf("Build() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestScore(t *testing.T) {
+	t.Parallel()
+	g := testGraph(t)
+	g.Score(func(repo string) (float64, error) {
+		if strings.HasPrefix(repo, "gitea.com/") {
+			return 0, errScore
+		}
+		return 7.5, nil
+	})
+	for _, r := range g.Repos {
+		switch {
+		case r.Name == "gitea.com/actions/checkout":
+			if r.Error != errScore.Error() || r.Score != checker.InconclusiveResultScore {
+				t.Errorf("repo %s: got score %v and error %q, want an error", r.Name, r.Score, r.Error)
+			}
+		case r.Score != 7.5 || r.Error != "":
+			t.Errorf("repo %s: got score %v and error %q, want 7.5", r.Name, r.Score, r.Error)
+		}
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	t.Parallel()
+	g := testGraph(t)
+	g.Score(func(repo string) (float64, error) {
+		if repo == "github.com/actions/checkout" {
+			return 9.1, nil
+		}
+		return 0, errScore
+	})
+	var buf bytes.Buffer
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT: %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		`digraph "github.com/owner/repo" {`,
+		`"workflow:.github/workflows/build.yml" [label=".github/workflows/build.yml", shape=note];`,
+		`"action:actions/checkout@` + checkoutSHA + `" [label="actions/checkout@` + checkoutSHA + `", color=darkgreen];`,
+		`"action:actions/setup-go@v4" [label="actions/setup-go@v4", color=red];`,
+		`"workflow:.github/workflows/build.yml" -> "action:actions/setup-go@v4" [label="test", style=dashed, color=red];`,
+		`"action:actions/setup-go@v4" -> "actionrepo:github.com/actions/setup-go";`,
+		`"actionrepo:github.com/actions/checkout" [label="github.com/actions/checkout\nscore: 9.1", ` +
+			`shape=ellipse, color=darkgreen];`,
+		`"actionrepo:github.com/actions/setup-go" [label="github.com/actions/setup-go\nscore: ?", ` +
+			`shape=ellipse, color=gray];`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteDOT() does not contain %s:\n%s", want, got)
+		}
+	}
+	// Actions used by several jobs are drawn once.
+	if n := strings.Count(got, `"action:actions/setup-go@v4" [label="actions/setup-go@v4"`); n != 1 {
+		t.Errorf("action drawn %d times, want 1", n)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+	g := testGraph(t)
+	var buf bytes.Buffer
+	if err := g.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var got Graph
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if diff := cmp.Diff(g, &got); diff != "" {
+		t.Errorf("WriteJSON() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDOTQuote(t *testing.T) {
+	t.Parallel()
+	if got, want := dotQuote(`a"b\c`), `"a\"b\\c"`; got != want {
+		t.Errorf("dotQuote() = %s, want %s", got, want)
+	}
+}
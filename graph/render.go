@@ -0,0 +1,109 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+// Scores at or above which the repo of an action is drawn as trusted, and
+// below which it is drawn as untrusted, in the DOT output.
+const (
+	trustedScore   = 8
+	untrustedScore = 5
+)
+
+// WriteJSON writes the graph as JSON.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	if err := json.NewEncoder(w).Encode(g); err != nil {
+		return fmt.Errorf("encoder.Encode: %w", err)
+	}
+	return nil
+}
+
+// WriteDOT writes the graph in the DOT language of Graphviz, from the repo
+// to its workflows, the actions they use and the repos of those. Unpinned
+// uses are drawn in red, and the repos of actions colored by their score.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(g.Repo))
+	b.WriteString("  rankdir=LR;\n  node [shape=box];\n")
+	fmt.Fprintf(&b, "  %s [label=%s, shape=house];\n", dotQuote("repo:"+g.Repo), dotQuote(g.Repo))
+
+	actions := map[string]bool{}
+	for i := range g.Workflows {
+		wf := &g.Workflows[i]
+		workflowID := dotQuote("workflow:" + wf.Path)
+		fmt.Fprintf(&b, "  %s [label=%s, shape=note];\n", workflowID, dotQuote(wf.Path))
+		fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote("repo:"+g.Repo), workflowID)
+		for j := range wf.Uses {
+			u := &wf.Uses[j]
+			action := u.Action + "@" + u.Ref
+			actionID := dotQuote("action:" + action)
+			if !actions[action] {
+				actions[action] = true
+				color := "darkgreen"
+				if !u.Pinned {
+					color = "red"
+				}
+				fmt.Fprintf(&b, "  %s [label=%s, color=%s];\n", actionID, dotQuote(action), color)
+				fmt.Fprintf(&b, "  %s -> %s;\n", actionID, dotQuote("actionrepo:"+u.Repo))
+			}
+			style := "solid"
+			if !u.Pinned {
+				style = "dashed, color=red"
+			}
+			fmt.Fprintf(&b, "  %s -> %s [label=%s, style=%s];\n", workflowID, actionID, dotQuote(u.Job), style)
+		}
+	}
+	for i := range g.Repos {
+		r := &g.Repos[i]
+		label := r.Name + `\n` + "score: ?"
+		color := "gray"
+		if r.Score != checker.InconclusiveResultScore {
+			label = fmt.Sprintf(`%s\nscore: %.1f`, r.Name, r.Score)
+			switch {
+			case r.Score >= trustedScore:
+				color = "darkgreen"
+			case r.Score < untrustedScore:
+				color = "red"
+			default:
+				color = "orange"
+			}
+		}
+		fmt.Fprintf(&b, "  %s [label=\"%s\", shape=ellipse, color=%s];\n",
+			dotQuote("actionrepo:"+r.Name), dotEscape(label), color)
+	}
+	b.WriteString("}\n")
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("io.WriteString: %w", err)
+	}
+	return nil
+}
+
+// dotEscape escapes the quotes of a DOT string. Backslashes are kept, as
+// they start the escapes of labels such as \n.
+func dotEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
+
+func dotQuote(s string) string {
+	return `"` + dotEscape(strings.ReplaceAll(s, `\`, `\\`)) + `"`
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin implements the optional admin server of the long-running
+// modes of Scorecard, exposing Go profiles and runtime metrics so that memory
+// leaks and goroutine pileups can be diagnosed live.
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime/metrics"
+	"strings"
+	"time"
+)
+
+const readHeaderTimeout = 10 * time.Second
+
+var (
+	// ErrTokenRequired is returned when the admin server would listen on a
+	// non-loopback address without a token.
+	ErrTokenRequired = errors.New("an admin token is required to listen on a non-loopback address")
+	errEmptyToken    = errors.New("empty admin token")
+)
+
+// Config configures the admin server.
+type Config struct {
+	// Addr is the address the server listens on, e.g. localhost:6060. The
+	// server is disabled when it is empty.
+	Addr string
+	// Token, if set, must be sent as a bearer token with every request. It is
+	// required when Addr is not a loopback address.
+	Token string
+}
+
+// Enabled reports whether the admin server is enabled.
+func (c *Config) Enabled() bool {
+	return c.Addr != ""
+}
+
+// Validate checks that the admin server is not exposed without a token.
+func (c *Config) Validate() error {
+	if !c.Enabled() || c.Token != "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(c.Addr)
+	if err != nil {
+		return fmt.Errorf("invalid admin address %q: %w", c.Addr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrTokenRequired, c.Addr)
+}
+
+// ReadToken reads the admin token from the file at path, ignoring surrounding
+// whitespace, so that it does not show in the command line of the process.
+func ReadToken(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("os.ReadFile: %w", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("%w in %s", errEmptyToken, path)
+	}
+	return token, nil
+}
+
+// Handler returns the handler of the admin server: the profiles of
+// net/http/pprof under /debug/pprof/ and the Go runtime metrics as JSON under
+// /debug/runtime. Requests must send token as a bearer token if it is set.
+func Handler(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/runtime", serveRuntimeMetrics)
+	if token == "" {
+		return mux
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "Bearer "
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, prefix) ||
+			subtle.ConstantTimeCompare([]byte(auth[len(prefix):]), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// Start starts the admin server configured by c in the background, and
+// returns it so that it can be closed. It returns nil if the server is
+// disabled.
+func Start(c *Config) (*http.Server, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", c.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("net.Listen: %w", err)
+	}
+	server := &http.Server{
+		Handler:           Handler(c.Token),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	go func() {
+		// The error is always http.ErrServerClosed once the server is closed.
+		//nolint:errcheck
+		server.Serve(listener)
+	}()
+	return server, nil
+}
+
+// runtimeMetrics reads the current value of all Go runtime metrics. Histograms,
+// e.g. of GC pauses, are summarized by their total count.
+func runtimeMetrics() map[string]interface{} {
+	descs := metrics.All()
+	samples := make([]metrics.Sample, len(descs))
+	for i := range descs {
+		samples[i].Name = descs[i].Name
+	}
+	metrics.Read(samples)
+
+	values := make(map[string]interface{}, len(samples))
+	for _, s := range samples {
+		switch s.Value.Kind() {
+		case metrics.KindUint64:
+			values[s.Name] = s.Value.Uint64()
+		case metrics.KindFloat64:
+			values[s.Name] = s.Value.Float64()
+		case metrics.KindFloat64Histogram:
+			var count uint64
+			for _, c := range s.Value.Float64Histogram().Counts {
+				count += c
+			}
+			values[s.Name] = map[string]uint64{"count": count}
+		case metrics.KindBad:
+			// The metric is not supported by this Go version.
+		}
+	}
+	return values
+}
+
+func serveRuntimeMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(runtimeMetrics()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
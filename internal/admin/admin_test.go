@@ -0,0 +1,173 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigValidate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr error
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:   "localhost",
+			config: Config{Addr: "localhost:6060"},
+		},
+		{
+			name:   "loopback address",
+			config: Config{Addr: "127.0.0.1:6060"},
+		},
+		{
+			name:   "IPv6 loopback address",
+			config: Config{Addr: "[::1]:6060"},
+		},
+		{
+			name:    "all interfaces without a token",
+			config:  Config{Addr: ":6060"},
+			wantErr: ErrTokenRequired,
+		},
+		{
+			name:    "remote address without a token",
+			config:  Config{Addr: "10.0.0.1:6060"},
+			wantErr: ErrTokenRequired,
+		},
+		{
+			name:   "remote address with a token",
+			config: Config{Addr: "10.0.0.1:6060", Token: "secret"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.config.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandlerToken(t *testing.T) {
+	t.Parallel()
+	handler := Handler("secret")
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{
+			name: "no token",
+			want: http.StatusUnauthorized,
+		},
+		{
+			name:          "wrong token",
+			authorization: "Bearer wrong",
+			want:          http.StatusUnauthorized,
+		},
+		{
+			name:          "token without scheme",
+			authorization: "secret",
+			want:          http.StatusUnauthorized,
+		},
+		{
+			name:          "token",
+			authorization: "Bearer secret",
+			want:          http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuntimeMetrics(t *testing.T) {
+	t.Parallel()
+	rec := httptest.NewRecorder()
+	Handler("").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/runtime", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	goroutines, ok := got["/sched/goroutines:goroutines"].(float64)
+	if !ok || goroutines < 1 {
+		t.Errorf("unexpected goroutine count %v", got["/sched/goroutines:goroutines"])
+	}
+}
+
+func TestStart(t *testing.T) {
+	t.Parallel()
+	server, err := Start(&Config{})
+	if err != nil || server != nil {
+		t.Fatalf("Start() of a disabled server = %v, %v", server, err)
+	}
+	if _, err := Start(&Config{Addr: ":0"}); !errors.Is(err, ErrTokenRequired) {
+		t.Errorf("Start() on all interfaces = %v, want %v", err, ErrTokenRequired)
+	}
+	server, err = Start(&Config{Addr: "127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("Start(): %v", err)
+	}
+	if err := server.Close(); err != nil {
+		t.Errorf("Close(): %v", err)
+	}
+}
+
+func TestReadToken(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	if err := os.WriteFile(path, []byte("  secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	token, err := ReadToken(path)
+	if err != nil || token != "secret" {
+		t.Errorf("ReadToken() = %q, %v", token, err)
+	}
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadToken(empty); !errors.Is(err, errEmptyToken) {
+		t.Errorf("ReadToken() of an empty file = %v, want %v", err, errEmptyToken)
+	}
+}
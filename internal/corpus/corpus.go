@@ -0,0 +1,227 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package corpus anonymizes the raw results of scans, written with
+// --format=raw, into fixtures of a benchmark corpus: the repo, the identities
+// of people and the commits are replaced by pseudonyms, so that the corpus can
+// be committed while keeping the structure the checks are evaluated on.
+package corpus
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var errNoRepo = errors.New("raw results without a repo name")
+
+// identityKeys are the keys whose string values identify people.
+var identityKeys = map[string]bool{
+	"login":        true,
+	"email":        true,
+	"actor":        true,
+	"author":       true,
+	"emailDomains": true,
+}
+
+// identityNameParents are the keys under which a "name" identifies people or
+// their employers.
+var identityNameParents = map[string]bool{
+	"company":   true,
+	"coAuthors": true,
+}
+
+// commitKeys are the keys whose values are commit SHAs, which identify the
+// repo as much as its name does.
+var commitKeys = map[string]bool{
+	"sha":     true,
+	"headSHA": true,
+	"commit":  true,
+	"newest":  true,
+	"oldest":  true,
+}
+
+// droppedKeys are the keys whose values are the content of files, which
+// are only displayed and not evaluated.
+var droppedKeys = map[string]bool{
+	"snippet": true,
+}
+
+// messageTrailers are the trailers of commit messages kept, with their value
+// replaced, since they tell what platform changes were reviewed on.
+var messageTrailers = []string{
+	"Reviewed-on", "Reviewed-by", "Differential Revision", "PiperOrigin-RevId", "Signed-off-by", "Co-authored-by",
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// Anonymizer replaces identifying values by pseudonyms derived from a salt,
+// so that equal values, e.g. the author and the reviewer of a change, have
+// equal pseudonyms in all the results anonymized with the same salt.
+type Anonymizer struct {
+	salt []byte
+}
+
+// NewAnonymizer returns an Anonymizer. The salt must be kept secret, or
+// pseudonyms of guessable values such as logins can be reversed.
+func NewAnonymizer(salt string) *Anonymizer {
+	return &Anonymizer{salt: []byte(salt)}
+}
+
+func (a *Anonymizer) hash(kind, value string) string {
+	mac := hmac.New(sha256.New, a.salt)
+	mac.Write([]byte(kind + "\x00" + value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (a *Anonymizer) pseudonym(kind, value string) string {
+	return kind + "-" + a.hash(kind, value)[:8]
+}
+
+// Name returns the pseudonym of the repo named repo, e.g.
+// github.com/owner-1a2b3c4d/repo-5e6f7a8b, which fixtures are named after.
+func (a *Anonymizer) Name(repo string) string {
+	host, owner, name := splitRepo(repo)
+	return strings.Join([]string{host, a.pseudonym("owner", owner), a.pseudonym("repo", name)}, "/")
+}
+
+func splitRepo(repo string) (host, owner, name string) {
+	parts := strings.Split(repo, "/")
+	if len(parts) < 3 {
+		return "github.com", parts[0], parts[len(parts)-1]
+	}
+	return parts[0], parts[1], strings.Join(parts[2:], "/")
+}
+
+// Anonymize returns the anonymized copy of the raw results in raw.
+func (a *Anonymizer) Anonymize(raw []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+	repo, _ := doc["repo"].(map[string]interface{})
+	name, _ := repo["name"].(string)
+	if name == "" {
+		return nil, errNoRepo
+	}
+	_, owner, project := splitRepo(name)
+	_, pOwner, pProject := splitRepo(a.Name(name))
+	// Matches are tried in order at each position, so owner/repo comes first
+	// to be replaced as a whole.
+	s := &scrubber{
+		Anonymizer: a,
+		repo: strings.NewReplacer(
+			"/"+owner+"/"+project, "/"+pOwner+"/"+pProject,
+			owner+"/"+project, pOwner+"/"+pProject,
+			"/"+owner+"/", "/"+pOwner+"/",
+		),
+		project: strings.NewReplacer(project, pProject),
+	}
+	out := s.walk(doc, "", "")
+	ret, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("json.MarshalIndent: %w", err)
+	}
+	return append(ret, '\n'), nil
+}
+
+type scrubber struct {
+	*Anonymizer
+	repo    *strings.Replacer
+	project *strings.Replacer
+}
+
+// walk returns the anonymized copy of v, the value of key in an object which
+// is itself the value of parent.
+func (s *scrubber) walk(v interface{}, key, parent string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			if droppedKeys[k] {
+				continue
+			}
+			ret[k] = s.walk(child, k, key)
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for i, child := range v {
+			// Elements are anonymized like the value of the array.
+			ret[i] = s.walk(child, key, parent)
+		}
+		return ret
+	case string:
+		return s.scrub(v, key, parent)
+	default:
+		return v
+	}
+}
+
+func (s *scrubber) scrub(v, key, parent string) string {
+	switch {
+	case v == "":
+		return v
+	case (key == "name" || key == "requestedName") && parent == "repo":
+		return s.Name(v)
+	case identityKeys[key] || (key == "name" && identityNameParents[parent]):
+		return s.identity(v)
+	case commitKeys[key] && len(v) <= sha256.Size*2:
+		// Keep the length, e.g. of abbreviated SHAs.
+		return s.hash("commit", v)[:len(v)]
+	case key == "message":
+		return s.message(v)
+	case (key == "path" || key == "url") && parent == "assets":
+		// Release assets are commonly named after the project, e.g.
+		// widget-v1.0.0.tar.gz.sig, and keep their suffix.
+		return s.project.Replace(s.repo.Replace(v))
+	default:
+		return emailPattern.ReplaceAllStringFunc(s.repo.Replace(v), s.identity)
+	}
+}
+
+// identity returns the pseudonym of a login, email or name. Bots keep their
+// suffix, which the checks rely on.
+func (s *scrubber) identity(v string) string {
+	if strings.Contains(v, "@") {
+		return s.pseudonym("user", v) + "@example.com"
+	}
+	if strings.HasSuffix(v, "[bot]") {
+		return s.pseudonym("bot", v) + "[bot]"
+	}
+	return s.pseudonym("user", v)
+}
+
+// message returns the trailers of a commit message which tell how it was
+// reviewed, with their values replaced, and drops the rest of the message.
+func (s *scrubber) message(v string) string {
+	var kept []string
+	for _, line := range strings.Split(v, "\n") {
+		line = strings.TrimSpace(line)
+		for _, trailer := range messageTrailers {
+			if strings.HasPrefix(line, trailer+":") {
+				kept = append(kept, trailer+": "+s.pseudonym("value", line))
+			}
+		}
+	}
+	return strings.Join(kept, "\n")
+}
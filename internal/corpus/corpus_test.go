@@ -0,0 +1,136 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package corpus
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const rawResults = `{
+  "date": "2023-05-01",
+  "repo": {"name": "github.com/acme/widget", "commit": "0123456789abcdef0123456789abcdef01234567"},
+  "scorecard": {"version": "v4.10.0", "commit": "fedcba"},
+  "metadata": null,
+  "results": {
+    "defaultBranchChangesets": [{
+      "number": "12",
+      "platform": "GitHub",
+      "authors": [{"login": "alice", "isBot": false, "company": [{"name": "Acme Corp"}]}],
+      "reviews": [{"state": "APPROVED", "reviewer": {"login": "alice", "repoAssociation": "MEMBER"}}],
+      "commits": [{
+        "sha": "89abcdef",
+        "message": "Fix the widget\n\nReviewed-on: https://review.acme.dev/12\nReviewed-by: Alice <alice@acme.dev>",
+        "committer": {"login": "dependabot[bot]", "isBot": true}
+      }]
+    }],
+    "releases": [{"tag": "v1.0.0", "url": "https://github.com/acme/widget/releases/tag/v1.0.0",
+      "assets": [{"path": "widget.tar.gz.sig", "url": "https://github.com/acme/widget/releases/download/v1.0.0/widget.tar.gz.sig"}]}],
+    "securityPolicies": [{
+      "file": {"path": "SECURITY.md", "snippet": "mail alice@acme.dev"},
+      "information": [{"type": "emailAddress", "match": "security@acme.dev", "lineNumber": 3}]
+    }]
+  }
+}`
+
+func TestAnonymize(t *testing.T) {
+	t.Parallel()
+	a := NewAnonymizer("salt")
+	got, err := a.Anonymize([]byte(rawResults))
+	if err != nil {
+		t.Fatalf("Anonymize: %v", err)
+	}
+	for _, leaked := range []string{"acme", "Acme", "alice", "Alice", "widget", "0123456789abcdef", "Fix the"} {
+		if strings.Contains(string(got), leaked) {
+			t.Errorf("anonymized results contain %q:\n%s", leaked, got)
+		}
+	}
+
+	var doc struct {
+		Repo struct {
+			Name   string `json:"name"`
+			Commit string `json:"commit"`
+		} `json:"repo"`
+		Results struct {
+			Changesets []struct {
+				Number  string `json:"number"`
+				Authors []struct {
+					Login string `json:"login"`
+				} `json:"authors"`
+				Reviews []struct {
+					Reviewer struct {
+						Login           string `json:"login"`
+						RepoAssociation string `json:"repoAssociation"`
+					} `json:"reviewer"`
+				} `json:"reviews"`
+				Commits []struct {
+					SHA       string `json:"sha"`
+					Message   string `json:"message"`
+					Committer struct {
+						Login string `json:"login"`
+					} `json:"committer"`
+				} `json:"commits"`
+			} `json:"defaultBranchChangesets"`
+			SecurityPolicies []struct {
+				File struct {
+					Path    string `json:"path"`
+					Snippet string `json:"snippet"`
+				} `json:"file"`
+				Information []struct {
+					Match string `json:"match"`
+				} `json:"information"`
+			} `json:"securityPolicies"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(got, &doc); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if doc.Repo.Name != a.Name("github.com/acme/widget") || len(doc.Repo.Commit) != 40 {
+		t.Errorf("repo = %+v", doc.Repo)
+	}
+	changeset := doc.Results.Changesets[0]
+	author, reviewer := changeset.Authors[0].Login, changeset.Reviews[0].Reviewer
+	// The evaluation of Code-Review depends on who reviewed whose changes.
+	if author != reviewer.Login || reviewer.RepoAssociation != "MEMBER" || changeset.Number != "12" {
+		t.Errorf("author %q, reviewer %+v, changeset %s", author, reviewer, changeset.Number)
+	}
+	commit := changeset.Commits[0]
+	if !strings.HasSuffix(commit.Committer.Login, "[bot]") || len(commit.SHA) != 8 ||
+		!strings.HasPrefix(commit.Message, "Reviewed-on: ") || !strings.Contains(commit.Message, "\nReviewed-by: ") {
+		t.Errorf("commit = %+v", commit)
+	}
+	policy := doc.Results.SecurityPolicies[0]
+	if policy.File.Path != "SECURITY.md" || policy.File.Snippet != "" ||
+		!strings.HasSuffix(policy.Information[0].Match, "@example.com") {
+		t.Errorf("security policy = %+v", policy)
+	}
+
+	again, err := a.Anonymize([]byte(rawResults))
+	if err != nil || string(again) != string(got) {
+		t.Errorf("Anonymize() is not deterministic: %v", err)
+	}
+	other, err := NewAnonymizer("other salt").Anonymize([]byte(rawResults))
+	if err != nil || string(other) == string(got) {
+		t.Errorf("Anonymize() does not depend on the salt: %v", err)
+	}
+}
+
+func TestAnonymize_noRepo(t *testing.T) {
+	t.Parallel()
+	if _, err := NewAnonymizer("salt").Anonymize([]byte(`{"results": {}}`)); err == nil {
+		t.Error("Anonymize() succeeded without a repo")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command generate anonymizes the raw results of scans into fixtures of the
+// benchmark corpus:
+//
+//	go run ./internal/corpus/generate -out=pkg/testdata/corpus results/*.json
+//
+// The inputs hold one or more results written with --format=raw. The salt of
+// the pseudonyms is read from SCORECARD_CORPUS_SALT and must be kept secret.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/internal/corpus"
+)
+
+const saltEnvVar = "SCORECARD_CORPUS_SALT"
+
+func main() {
+	out := flag.String("out", "pkg/testdata/corpus", "directory the fixtures are written to")
+	flag.Parse()
+	salt := os.Getenv(saltEnvVar)
+	if salt == "" || flag.NArg() == 0 {
+		//nolint: goerr113
+		panic(fmt.Errorf("usage: %s=<salt> %s [-out=dir] results.json...", saltEnvVar, os.Args[0]))
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		panic(fmt.Errorf("os.MkdirAll: %w", err))
+	}
+	a := corpus.NewAnonymizer(salt)
+	for _, input := range flag.Args() {
+		if err := generate(a, input, *out); err != nil {
+			panic(fmt.Errorf("%s: %w", input, err))
+		}
+	}
+}
+
+func generate(a *corpus.Anonymizer, input, out string) error {
+	content, err := os.ReadFile(input)
+	if err != nil {
+		return fmt.Errorf("os.ReadFile: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("json.Decode: %w", err)
+		}
+		var doc struct {
+			Repo struct {
+				Name string `json:"name"`
+			} `json:"repo"`
+		}
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return fmt.Errorf("json.Unmarshal: %w", err)
+		}
+		fixture, err := a.Anonymize(raw)
+		if err != nil {
+			return fmt.Errorf("Anonymize: %w", err)
+		}
+		name := strings.ReplaceAll(a.Name(doc.Repo.Name), "/", "_") + ".json"
+		if err := os.WriteFile(filepath.Join(out, name), fixture, 0o600); err != nil {
+			return fmt.Errorf("os.WriteFile: %w", err)
+		}
+		fmt.Println(name)
+	}
+}
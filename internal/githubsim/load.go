@@ -0,0 +1,130 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubsim
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Report summarizes a load test.
+type Report struct {
+	Requests int
+	// Statuses counts the responses by status code, and Errors the
+	// requests which failed without a response.
+	Statuses map[int]int
+	Errors   int
+	Duration time.Duration
+	// The percentiles of the latency of the requests, including the waits
+	// and retries of the transport.
+	P50, P95, P99, Max time.Duration
+}
+
+// Succeeded returns the number of requests answered with 200 OK.
+func (r *Report) Succeeded() int {
+	return r.Statuses[http.StatusOK]
+}
+
+// String formats the report on a line.
+func (r *Report) String() string {
+	codes := make([]int, 0, len(r.Statuses))
+	for code := range r.Statuses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	statuses := make([]string, 0, len(codes))
+	for _, code := range codes {
+		statuses = append(statuses, fmt.Sprintf("%d=%d", code, r.Statuses[code]))
+	}
+	return fmt.Sprintf("%d requests in %s: statuses %s, errors %d, latency p50 %s p95 %s p99 %s max %s",
+		r.Requests, r.Duration.Round(time.Millisecond), strings.Join(statuses, " "), r.Errors,
+		r.P50.Round(time.Millisecond), r.P95.Round(time.Millisecond), r.P99.Round(time.Millisecond),
+		r.Max.Round(time.Millisecond))
+}
+
+// Load sends requests GET requests to url with client, concurrency at a
+// time, and reports their outcome.
+func Load(ctx context.Context, client *http.Client, url string, requests, concurrency int) *Report {
+	report := Report{Requests: requests, Statuses: map[int]int{}}
+	latencies := make([]time.Duration, requests)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	next := make(chan int)
+	start := time.Now()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				began := time.Now()
+				status, err := get(ctx, client, fmt.Sprintf("%s/repos/owner/repo-%d", url, i))
+				latencies[i] = time.Since(began)
+				mu.Lock()
+				if err != nil {
+					report.Errors++
+				} else {
+					report.Statuses[status]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := 0; i < requests; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	report.Duration = time.Since(start)
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	if requests > 0 {
+		report.P50 = percentile(latencies, 50)
+		report.P95 = percentile(latencies, 95)
+		report.P99 = percentile(latencies, 99)
+		report.Max = latencies[requests-1]
+	}
+	return &report
+}
+
+func get(ctx context.Context, client *http.Client, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return 0, fmt.Errorf("reading response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
+
+// percentile returns the p-th percentile of sorted, which is not empty.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	i := (len(sorted)*p+99)/100 - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
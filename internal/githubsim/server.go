@@ -0,0 +1,194 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package githubsim simulates the GitHub API for load tests of the
+// transport stack: the primary rate limit of each token, the secondary rate
+// limit on concurrent requests, server errors and slow responses.
+package githubsim
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config configures the simulated API. The zero value serves every request.
+type Config struct {
+	// Quota is the number of requests each token can make per Window, the
+	// primary rate limit, 0 for no limit.
+	Quota  int
+	Window time.Duration
+	// Concurrency is the number of requests each token can have in flight
+	// before the secondary rate limit rejects more, 0 for no limit.
+	Concurrency int
+	// RetryAfter is sent in the Retry-After header of rejections by the
+	// secondary rate limit, unless 0, in rounded up seconds.
+	RetryAfter time.Duration
+	// ErrorRate is the fraction of requests failing with a server error.
+	ErrorRate float64
+	// Latency is how long every response takes, and SlowLatency how long
+	// the SlowRate fraction of responses take instead.
+	Latency     time.Duration
+	SlowRate    float64
+	SlowLatency time.Duration
+	// Seed seeds the choices of the failing and slow requests.
+	Seed int64
+}
+
+// Stats counts the requests received by the simulated API by outcome.
+type Stats struct {
+	Requests         int
+	Served           int
+	PrimaryLimited   int
+	SecondaryLimited int
+	ServerErrors     int
+}
+
+// Server is a simulated GitHub API.
+type Server struct {
+	*httptest.Server
+	cfg Config
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	quotas   map[string]*quota
+	inFlight map[string]int
+	stats    Stats
+}
+
+// quota is the primary rate limit left for a token in the current window.
+type quota struct {
+	remaining int
+	reset     time.Time
+}
+
+// NewServer starts a simulated API, which must be closed.
+func NewServer(cfg Config) *Server {
+	s := &Server{
+		cfg: cfg,
+		//nolint:gosec // Only picks the simulated failures.
+		rand:     rand.New(rand.NewSource(cfg.Seed)),
+		quotas:   map[string]*quota{},
+		inFlight: map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Stats returns the requests received so far by outcome.
+func (s *Server) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// outcome is how a request is answered.
+type outcome int
+
+const (
+	served outcome = iota
+	primaryLimited
+	secondaryLimited
+	serverError
+)
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	result, q, latency := s.admit(token)
+	defer s.release(token)
+	time.Sleep(latency)
+
+	h := w.Header()
+	h.Set("Content-Type", "application/json")
+	h.Set("X-RateLimit-Resource", "core")
+	if q != nil {
+		h.Set("X-RateLimit-Limit", strconv.Itoa(s.cfg.Quota))
+		h.Set("X-RateLimit-Remaining", strconv.Itoa(q.remaining))
+		h.Set("X-RateLimit-Reset", strconv.FormatInt(q.reset.Unix(), 10))
+	}
+	switch result {
+	case primaryLimited:
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "API rate limit exceeded for user."}`)
+	case secondaryLimited:
+		if s.cfg.RetryAfter > 0 {
+			seconds := (s.cfg.RetryAfter + time.Second - 1) / time.Second
+			h.Set("Retry-After", strconv.Itoa(int(seconds)))
+		}
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."}`)
+	case serverError:
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{"message": "Server Error"}`)
+	default:
+		fmt.Fprintf(w, `{"path": %q}`, r.URL.Path)
+	}
+}
+
+// admit decides the outcome of a request of token, returning the quota of
+// the token, if limited, and how long the response takes.
+func (s *Server) admit(token string) (outcome, *quota, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Requests++
+	s.inFlight[token]++
+
+	latency := s.cfg.Latency
+	if s.cfg.SlowRate > 0 && s.rand.Float64() < s.cfg.SlowRate {
+		latency = s.cfg.SlowLatency
+	}
+
+	var q *quota
+	if s.cfg.Quota > 0 {
+		now := time.Now()
+		q = s.quotas[token]
+		if q == nil || !now.Before(q.reset) {
+			q = &quota{remaining: s.cfg.Quota, reset: now.Add(s.cfg.Window)}
+			s.quotas[token] = q
+		}
+		if q.remaining == 0 {
+			s.stats.PrimaryLimited++
+			ret := *q
+			return primaryLimited, &ret, 0
+		}
+		q.remaining--
+	}
+	var ret *quota
+	if q != nil {
+		c := *q
+		ret = &c
+	}
+
+	if s.cfg.Concurrency > 0 && s.inFlight[token] > s.cfg.Concurrency {
+		s.stats.SecondaryLimited++
+		return secondaryLimited, ret, 0
+	}
+	if s.cfg.ErrorRate > 0 && s.rand.Float64() < s.cfg.ErrorRate {
+		s.stats.ServerErrors++
+		return serverError, ret, latency
+	}
+	s.stats.Served++
+	return served, ret, latency
+}
+
+func (s *Server) release(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlight[token]--
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubsim
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// tokenTransport authorizes requests with a token, like the transports of
+// the clients.
+type tokenTransport string
+
+func (t tokenTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	req := r.Clone(r.Context())
+	req.Header.Set("Authorization", "Bearer "+string(t))
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		cfg          Config
+		tokens       []string
+		requests     int
+		concurrency  int
+		wantStats    Stats
+		wantStatuses map[int]int
+	}{
+		{
+			name:         "no limits",
+			tokens:       []string{"a"},
+			requests:     10,
+			concurrency:  2,
+			wantStats:    Stats{Requests: 10, Served: 10},
+			wantStatuses: map[int]int{http.StatusOK: 10},
+		},
+		{
+			name:         "primary rate limit per token",
+			cfg:          Config{Quota: 3, Window: time.Hour},
+			tokens:       []string{"a", "b"},
+			requests:     5,
+			concurrency:  1,
+			wantStats:    Stats{Requests: 10, Served: 6, PrimaryLimited: 4},
+			wantStatuses: map[int]int{http.StatusOK: 3, http.StatusForbidden: 2},
+		},
+		{
+			name:         "secondary rate limit",
+			cfg:          Config{Concurrency: 1, Latency: 100 * time.Millisecond},
+			tokens:       []string{"a"},
+			requests:     2,
+			concurrency:  2,
+			wantStats:    Stats{Requests: 2, Served: 1, SecondaryLimited: 1},
+			wantStatuses: map[int]int{http.StatusOK: 1, http.StatusForbidden: 1},
+		},
+		{
+			name:         "server errors",
+			cfg:          Config{ErrorRate: 1},
+			tokens:       []string{"a"},
+			requests:     3,
+			concurrency:  1,
+			wantStats:    Stats{Requests: 3, ServerErrors: 3},
+			wantStatuses: map[int]int{http.StatusBadGateway: 3},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := NewServer(tt.cfg)
+			defer s.Close()
+			var last *Report
+			for _, token := range tt.tokens {
+				client := &http.Client{Transport: tokenTransport(token)}
+				last = Load(context.Background(), client, s.URL, tt.requests, tt.concurrency)
+			}
+			if diff := cmp.Diff(tt.wantStats, s.Stats()); diff != "" {
+				t.Errorf("Stats() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantStatuses, last.Statuses); diff != "" {
+				t.Errorf("Load() statuses mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServer_headers(t *testing.T) {
+	t.Parallel()
+	s := NewServer(Config{Quota: 1, Window: time.Hour, Concurrency: 1, RetryAfter: 1500 * time.Millisecond})
+	defer s.Close()
+	client := &http.Client{Transport: tokenTransport("a")}
+	get := func() *http.Response {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, s.URL+"/rate_limit", nil)
+		if err != nil {
+			t.Fatalf("http.NewRequestWithContext: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("client.Do: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+	for _, wantStatus := range []int{http.StatusOK, http.StatusForbidden} {
+		resp := get()
+		if resp.StatusCode != wantStatus {
+			t.Errorf("status = %d, want %d", resp.StatusCode, wantStatus)
+		}
+		if got := resp.Header.Get("X-RateLimit-Remaining"); got != "0" {
+			t.Errorf("X-RateLimit-Remaining = %s, want 0", got)
+		}
+		if resp.Header.Get("X-RateLimit-Reset") == "" {
+			t.Errorf("X-RateLimit-Reset not set")
+		}
+		if resp.Header.Get("Retry-After") != "" {
+			t.Errorf("Retry-After set for the primary rate limit")
+		}
+	}
+
+	s = NewServer(Config{Concurrency: 1, Latency: 200 * time.Millisecond, RetryAfter: 1500 * time.Millisecond})
+	defer s.Close()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, s.URL+"/rate_limit", nil)
+		if err != nil {
+			return
+		}
+		if resp, err := client.Do(req); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	resp := get()
+	<-done
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+	if got := resp.Header.Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After = %s, want 2", got)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+	for p, want := range map[int]time.Duration{50: 50 * time.Millisecond, 99: 99 * time.Millisecond, 100: 100 * time.Millisecond} {
+		if got := percentile(sorted, p); got != want {
+			t.Errorf("percentile(%d) = %s, want %s", p, got, want)
+		}
+	}
+	if got := percentile(sorted[:1], 50); got != time.Millisecond {
+		t.Errorf("percentile() of a single latency = %s, want 1ms", got)
+	}
+}
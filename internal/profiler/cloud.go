@@ -0,0 +1,143 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/option"
+	gtransport "google.golang.org/api/transport/grpc"
+	pb "google.golang.org/genproto/googleapis/devtools/cloudprofiler/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+
+	"github.com/ossf/scorecard/v4/log"
+)
+
+const (
+	cloudProfilerEndpoint = "cloudprofiler.googleapis.com:443"
+	cloudProfilerScope    = "https://www.googleapis.com/auth/monitoring.write"
+	minBackoff            = time.Minute
+	maxBackoff            = time.Hour
+)
+
+var errUnsupportedProfileType = errors.New("unsupported profile type")
+
+// cloudAgent implements the protocol of the Cloud Profiler agents: the
+// server picks the type and duration of the next profile in its response to
+// CreateProfile, which long-polls, and the agent uploads it with
+// UpdateProfile.
+type cloudAgent struct {
+	client     pb.ProfilerServiceClient
+	logger     *log.Logger
+	deployment *pb.Deployment
+}
+
+func startCloudProfiler(ctx context.Context, cfg *Config, logger *log.Logger) error {
+	if cfg.ProjectID == "" {
+		return fmt.Errorf("%w: no project for %s", errMissingConfig, BackendCloudProfiler)
+	}
+	conn, err := gtransport.Dial(ctx,
+		option.WithEndpoint(cloudProfilerEndpoint),
+		option.WithScopes(cloudProfilerScope))
+	if err != nil {
+		return fmt.Errorf("grpc.Dial: %w", err)
+	}
+	a := newCloudAgent(pb.NewProfilerServiceClient(conn), cfg, logger)
+	go func() {
+		defer conn.Close()
+		a.run(ctx)
+	}()
+	return nil
+}
+
+func newCloudAgent(client pb.ProfilerServiceClient, cfg *Config, logger *log.Logger) *cloudAgent {
+	deployment := &pb.Deployment{
+		ProjectId: cfg.ProjectID,
+		Target:    cfg.Service,
+	}
+	if cfg.Version != "" {
+		deployment.Labels = map[string]string{"version": cfg.Version}
+	}
+	return &cloudAgent{
+		client:     client,
+		logger:     logger,
+		deployment: deployment,
+	}
+}
+
+func (a *cloudAgent) run(ctx context.Context) {
+	backoff := minBackoff
+	for ctx.Err() == nil {
+		err := a.profileOnce(ctx)
+		if err == nil {
+			backoff = minBackoff
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		delay := retryDelay(err, backoff)
+		a.logger.Info(fmt.Sprintf("cloud profiler: %v, retrying in %v", err, delay))
+		sleep(ctx, delay)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (a *cloudAgent) profileOnce(ctx context.Context) error {
+	p, err := a.client.CreateProfile(ctx, &pb.CreateProfileRequest{
+		Parent:      "projects/" + a.deployment.ProjectId,
+		Deployment:  a.deployment,
+		ProfileType: []pb.ProfileType{pb.ProfileType_CPU, pb.ProfileType_HEAP},
+	})
+	if err != nil {
+		return fmt.Errorf("CreateProfile: %w", err)
+	}
+	switch p.ProfileType {
+	case pb.ProfileType_CPU:
+		p.ProfileBytes, err = profileCPU(ctx, p.Duration.AsDuration())
+	case pb.ProfileType_HEAP:
+		p.ProfileBytes, err = profileHeap()
+	default:
+		err = fmt.Errorf("%w: %v", errUnsupportedProfileType, p.ProfileType)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := a.client.UpdateProfile(ctx, &pb.UpdateProfileRequest{Profile: p}); err != nil {
+		return fmt.Errorf("UpdateProfile: %w", err)
+	}
+	return nil
+}
+
+// retryDelay returns the delay the server asked for, if any, as the agents
+// must honor it, or else backoff.
+func retryDelay(err error, backoff time.Duration) time.Duration {
+	st, ok := status.FromError(errors.Unwrap(err))
+	if !ok {
+		return backoff
+	}
+	for _, d := range st.Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok && ri.RetryDelay != nil {
+			return ri.RetryDelay.AsDuration()
+		}
+	}
+	return backoff
+}
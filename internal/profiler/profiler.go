@@ -0,0 +1,109 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package profiler continuously profiles the long-running modes of
+// Scorecard, e.g. the cron workers, and uploads the profiles to Cloud
+// Profiler or Pyroscope. CPU samples of checks are labeled with the name of
+// the check, see checker.Runner, so regressions can be attributed to checks.
+package profiler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"runtime/pprof"
+	"time"
+
+	"github.com/ossf/scorecard/v4/log"
+)
+
+const (
+	// BackendCloudProfiler uploads profiles to Google Cloud Profiler.
+	BackendCloudProfiler = "cloud-profiler"
+	// BackendPyroscope pushes profiles to a Pyroscope server.
+	BackendPyroscope = "pyroscope"
+)
+
+var (
+	errUnknownBackend = errors.New("unknown profiler backend")
+	errMissingConfig  = errors.New("incomplete profiler config")
+)
+
+// Config configures continuous profiling.
+type Config struct {
+	// Backend is one of BackendCloudProfiler and BackendPyroscope. Profiling
+	// is disabled when it is empty.
+	Backend string
+	// Service is the name the profiles are reported under, e.g. cron-worker.
+	Service string
+	// Version is the version of the service, e.g. the commit of scorecard.
+	Version string
+	// ProjectID is the Google Cloud project of Cloud Profiler.
+	ProjectID string
+	// ServerURL is the URL of the Pyroscope server.
+	ServerURL string
+}
+
+// Start starts profiling in the background until ctx is done. It does
+// nothing if profiling is disabled. Errors while profiling are logged and
+// retried, so that profiling never stops the process it profiles.
+func Start(ctx context.Context, cfg *Config, logger *log.Logger) error {
+	if cfg.Backend == "" {
+		return nil
+	}
+	if cfg.Service == "" {
+		return fmt.Errorf("%w: no service", errMissingConfig)
+	}
+	switch cfg.Backend {
+	case BackendCloudProfiler:
+		return startCloudProfiler(ctx, cfg, logger)
+	case BackendPyroscope:
+		return startPyroscope(ctx, cfg, logger)
+	default:
+		return fmt.Errorf("%w: %s", errUnknownBackend, cfg.Backend)
+	}
+}
+
+// profileCPU records a CPU profile for d, or until ctx is done. It fails if
+// another CPU profile is being recorded, e.g. by the admin server.
+func profileCPU(ctx context.Context, d time.Duration) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		return nil, fmt.Errorf("pprof.StartCPUProfile: %w", err)
+	}
+	sleep(ctx, d)
+	pprof.StopCPUProfile()
+	return buf.Bytes(), nil
+}
+
+// profileHeap returns a heap profile, with both the in-use and the allocated
+// memory since the process started.
+func profileHeap() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("heap").WriteTo(&buf, 0); err != nil {
+		return nil, fmt.Errorf("heap profile: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "google.golang.org/genproto/googleapis/devtools/cloudprofiler/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/ossf/scorecard/v4/log"
+)
+
+func TestStart(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		wantErr error
+		cfg     Config
+		name    string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:    "unknown backend",
+			cfg:     Config{Backend: "datadog", Service: "cron-worker"},
+			wantErr: errUnknownBackend,
+		},
+		{
+			name:    "no service",
+			cfg:     Config{Backend: BackendPyroscope, ServerURL: "http://localhost:4040"},
+			wantErr: errMissingConfig,
+		},
+		{
+			name:    "cloud profiler without a project",
+			cfg:     Config{Backend: BackendCloudProfiler, Service: "cron-worker"},
+			wantErr: errMissingConfig,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := Start(context.Background(), &tt.cfg, log.NewLogger(log.InfoLevel))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Start() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type fakeProfilerClient struct {
+	pb.ProfilerServiceClient
+	next     *pb.Profile
+	uploaded *pb.Profile
+}
+
+func (c *fakeProfilerClient) CreateProfile(ctx context.Context, in *pb.CreateProfileRequest,
+	opts ...grpc.CallOption,
+) (*pb.Profile, error) {
+	if in.Parent != "projects/"+in.Deployment.ProjectId {
+		return nil, status.Error(codes.InvalidArgument, in.Parent)
+	}
+	return c.next, nil
+}
+
+func (c *fakeProfilerClient) UpdateProfile(ctx context.Context, in *pb.UpdateProfileRequest,
+	opts ...grpc.CallOption,
+) (*pb.Profile, error) {
+	c.uploaded = in.Profile
+	return in.Profile, nil
+}
+
+func TestCloudAgent_profileOnce(t *testing.T) {
+	// Not parallel, as only one CPU profile can be recorded at a time.
+	cfg := &Config{Service: "cron-worker", Version: "v4.10.0", ProjectID: "openssf"}
+	tests := []struct {
+		profile *pb.Profile
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "cpu",
+			profile: &pb.Profile{ProfileType: pb.ProfileType_CPU, Duration: durationpb.New(10 * time.Millisecond)},
+		},
+		{
+			name:    "heap",
+			profile: &pb.Profile{ProfileType: pb.ProfileType_HEAP},
+		},
+		{
+			name:    "unsupported",
+			profile: &pb.Profile{ProfileType: pb.ProfileType_WALL},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		client := &fakeProfilerClient{next: tt.profile}
+		a := newCloudAgent(client, cfg, log.NewLogger(log.InfoLevel))
+		err := a.profileOnce(context.Background())
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%s: profileOnce() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if !tt.wantErr && (client.uploaded == nil || len(client.uploaded.ProfileBytes) == 0) {
+			t.Errorf("%s: no profile uploaded", tt.name)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+	st, err := status.New(codes.Aborted, "backoff").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(5 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("WithDetails: %v", err)
+	}
+	if got := retryDelay(fmt.Errorf("CreateProfile: %w", st.Err()), minBackoff); got != 5*time.Minute {
+		t.Errorf("retryDelay() = %v, want the delay of the server", got)
+	}
+	//nolint:goerr113
+	if got := retryDelay(errors.New("connection reset"), minBackoff); got != minBackoff {
+		t.Errorf("retryDelay() = %v, want the backoff", got)
+	}
+}
+
+func TestPyroscopeAgent_push(t *testing.T) {
+	t.Parallel()
+	var name, format string
+	var profile []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, format = r.URL.Query().Get("name"), r.URL.Query().Get("format")
+		f, _, err := r.FormFile("profile")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		profile, _ = io.ReadAll(f)
+	}))
+	defer srv.Close()
+
+	a := newPyroscopeAgent(&Config{Service: "cron-worker", Version: "v4.10.0", ServerURL: srv.URL},
+		log.NewLogger(log.InfoLevel))
+	now := time.Now()
+	if err := a.push(context.Background(), []byte("pprof"), now.Add(-10*time.Second), now); err != nil {
+		t.Fatalf("push: %v", err)
+	}
+	if name != "cron-worker{version=v4.10.0}" || format != "pprof" || string(profile) != "pprof" {
+		t.Errorf("pushed %q as %s in the %s format", profile, name, format)
+	}
+
+	srv.Config.Handler = http.NotFoundHandler()
+	if err := a.push(context.Background(), nil, now, now); !errors.Is(err, errPyroscopeStatus) {
+		t.Errorf("push() error = %v, want %v", err, errPyroscopeStatus)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ossf/scorecard/v4/log"
+)
+
+// pyroscopeInterval is the duration of each CPU profile pushed to Pyroscope,
+// which is also how often heap profiles are pushed.
+const pyroscopeInterval = 10 * time.Second
+
+var errPyroscopeStatus = errors.New("unexpected status from pyroscope")
+
+// pyroscopeAgent pushes profiles to the ingestion API of Pyroscope.
+type pyroscopeAgent struct {
+	client   *http.Client
+	logger   *log.Logger
+	endpoint string
+	name     string
+	interval time.Duration
+}
+
+func startPyroscope(ctx context.Context, cfg *Config, logger *log.Logger) error {
+	if cfg.ServerURL == "" {
+		return fmt.Errorf("%w: no server URL for %s", errMissingConfig, BackendPyroscope)
+	}
+	a := newPyroscopeAgent(cfg, logger)
+	go a.run(ctx)
+	return nil
+}
+
+func newPyroscopeAgent(cfg *Config, logger *log.Logger) *pyroscopeAgent {
+	name := cfg.Service
+	if cfg.Version != "" {
+		name += "{version=" + cfg.Version + "}"
+	}
+	return &pyroscopeAgent{
+		client:   &http.Client{Timeout: time.Minute},
+		logger:   logger,
+		endpoint: cfg.ServerURL + "/ingest",
+		name:     name,
+		interval: pyroscopeInterval,
+	}
+}
+
+func (a *pyroscopeAgent) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		from := time.Now()
+		cpu, err := profileCPU(ctx, a.interval)
+		until := time.Now()
+		if err != nil {
+			a.logger.Info(fmt.Sprintf("pyroscope: %v", err))
+			sleep(ctx, a.interval)
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err := a.push(ctx, cpu, from, until); err != nil {
+			a.logger.Info(fmt.Sprintf("pyroscope: %v", err))
+		}
+		heap, err := profileHeap()
+		if err == nil {
+			err = a.push(ctx, heap, from, until)
+		}
+		if err != nil {
+			a.logger.Info(fmt.Sprintf("pyroscope: %v", err))
+		}
+	}
+}
+
+// push uploads a profile in the pprof format, which the server splits by
+// sample type.
+func (a *pyroscopeAgent) push(ctx context.Context, profile []byte, from, until time.Time) error {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("profile", "profile.pprof")
+	if err != nil {
+		return fmt.Errorf("multipart.CreateFormFile: %w", err)
+	}
+	if _, err := part.Write(profile); err != nil {
+		return fmt.Errorf("multipart.Write: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("multipart.Close: %w", err)
+	}
+
+	q := url.Values{}
+	q.Set("name", a.name)
+	q.Set("from", strconv.FormatInt(from.Unix(), 10))
+	q.Set("until", strconv.FormatInt(until.Unix(), 10))
+	q.Set("format", "pprof")
+	q.Set("spyName", "gospy")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint+"?"+q.Encode(), &body)
+	if err != nil {
+		return fmt.Errorf("http.NewRequest: %w", err)
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", errPyroscopeStatus, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redis is a minimal client of the Redis protocol (RESP) which
+// supports the few commands Scorecard needs to store state.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+const (
+	defaultPort    = "6379"
+	defaultTimeout = 10 * time.Second
+)
+
+// ErrRedis is returned for error replies of the server.
+var ErrRedis = errors.New("redis error")
+
+// Client sends commands to the server at a redis://[:password@]host:port[/db] URL.
+type Client struct {
+	addr     string
+	password string
+	db       int
+}
+
+// NewClient returns a Client of the server at u.
+func NewClient(u *url.URL) (*Client, error) {
+	c := &Client{addr: u.Host}
+	if u.Port() == "" {
+		c.addr = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+	if password, ok := u.User.Password(); ok {
+		c.password = password
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		n, err := strconv.Atoi(db)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid redis db %q", db))
+		}
+		c.db = n
+	}
+	return c, nil
+}
+
+// Do runs a command on a new connection and returns its reply, which is nil
+// for a nil bulk string.
+func (c *Client) Do(ctx context.Context, args ...string) ([]byte, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("net.Dial: %w", err)
+	}
+	defer conn.Close()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("conn.SetDeadline: %w", err)
+	}
+
+	var commands [][]string
+	if c.password != "" {
+		commands = append(commands, []string{"AUTH", c.password})
+	}
+	if c.db != 0 {
+		commands = append(commands, []string{"SELECT", strconv.Itoa(c.db)})
+	}
+	commands = append(commands, args)
+
+	w := bufio.NewWriter(conn)
+	for _, command := range commands {
+		fmt.Fprintf(w, "*%d\r\n", len(command))
+		for _, arg := range command {
+			fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("write: %w", err)
+	}
+
+	r := bufio.NewReader(conn)
+	var reply []byte
+	for range commands {
+		if reply, err = readReply(r); err != nil {
+			return nil, err
+		}
+	}
+	return reply, nil
+}
+
+func readReply(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("%w: empty reply", ErrRedis)
+	}
+	switch line[0] {
+	case '+', ':':
+		return []byte(line[1:]), nil
+	case '-':
+		return nil, fmt.Errorf("%w: %s", ErrRedis, line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid bulk length %q", ErrRedis, line[1:])
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		value := make([]byte, n+2)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+		return value[:n], nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected reply %q", ErrRedis, line)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		want    Client
+		url     string
+		wantErr bool
+	}{
+		{
+			url:  "redis://localhost/3",
+			want: Client{addr: "localhost:6379", db: 3},
+		},
+		{
+			url:  "redis://:secret@redis.example.com:6380",
+			want: Client{addr: "redis.example.com:6380", password: "secret"},
+		},
+		{
+			url:     "redis://localhost/default",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatalf("url.Parse: %v", err)
+			}
+			c, err := NewClient(u)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *c != tt.want {
+				t.Errorf("NewClient() = %+v, want %+v", *c, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tenant implements the tenants of the multi-tenant serve mode, each
+// with its own API keys, GitHub tokens, rate limit and namespace of cached
+// results, so that one deployment can serve many teams.
+package tenant
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"gopkg.in/yaml.v3"
+)
+
+// APIKeyHeader is the header API keys can be sent in, instead of as a bearer
+// token.
+const APIKeyHeader = "X-API-Key"
+
+var (
+	errNoTenants     = errors.New("no tenants")
+	errInvalidTenant = errors.New("invalid tenant")
+)
+
+// Tenant is a team the serve mode scans repos for.
+type Tenant struct {
+	// Name identifies the tenant in logs.
+	Name string `yaml:"name"`
+	// Namespace prefixes the keys of the cached results of the tenant,
+	// defaulting to Name.
+	Namespace string `yaml:"namespace"`
+	// TokensEnv is the environment variable holding the comma-separated
+	// GitHub tokens of the tenant. The tokens of the server are used if it
+	// is empty.
+	TokensEnv string `yaml:"tokensEnv"`
+	// APIKeyHashes are the hex SHA-256 digests of the API keys of the
+	// tenant, so that the keys themselves are not stored in the config.
+	APIKeyHashes []string `yaml:"apiKeyHashes"`
+	// RequestsPerMinute caps the scans requested by the tenant, unlimited
+	// if 0.
+	RequestsPerMinute int `yaml:"requestsPerMinute"`
+	limiter           *rate.Limiter
+	keyHashes         [][]byte
+}
+
+// Tokens returns the GitHub tokens of the tenant, or nil if it uses the
+// tokens of the server.
+func (t *Tenant) Tokens() []string {
+	if t.TokensEnv == "" {
+		return nil
+	}
+	var ret []string
+	for _, token := range strings.Split(os.Getenv(t.TokensEnv), ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			ret = append(ret, token)
+		}
+	}
+	return ret
+}
+
+// Allow reports whether the tenant may request another scan now.
+func (t *Tenant) Allow() bool {
+	return t.limiter == nil || t.limiter.Allow()
+}
+
+// Registry holds the tenants of the serve mode.
+type Registry struct {
+	Tenants []*Tenant `yaml:"tenants"`
+}
+
+// ReadFile reads the tenants from the YAML file at path.
+func ReadFile(path string) (*Registry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse parses and validates the tenants of a YAML config, e.g.:
+//
+//	tenants:
+//	  - name: payments
+//	    apiKeyHashes: [<hex sha256 of the key>]
+//	    tokensEnv: PAYMENTS_GITHUB_TOKENS
+//	    requestsPerMinute: 30
+func Parse(r io.Reader) (*Registry, error) {
+	var reg Registry
+	if err := yaml.NewDecoder(r).Decode(&reg); err != nil {
+		return nil, fmt.Errorf("yaml.Decode: %w", err)
+	}
+	if len(reg.Tenants) == 0 {
+		return nil, errNoTenants
+	}
+	names := map[string]bool{}
+	namespaces := map[string]bool{}
+	keys := map[string]bool{}
+	for i, t := range reg.Tenants {
+		if t == nil || t.Name == "" {
+			return nil, fmt.Errorf("%w %d: no name", errInvalidTenant, i)
+		}
+		if names[t.Name] {
+			return nil, fmt.Errorf("%w %s: duplicate name", errInvalidTenant, t.Name)
+		}
+		names[t.Name] = true
+		if t.Namespace == "" {
+			t.Namespace = t.Name
+		}
+		if namespaces[t.Namespace] || strings.Contains(t.Namespace, "/") {
+			return nil, fmt.Errorf("%w %s: invalid or duplicate namespace %q", errInvalidTenant, t.Name, t.Namespace)
+		}
+		namespaces[t.Namespace] = true
+		if len(t.APIKeyHashes) == 0 {
+			return nil, fmt.Errorf("%w %s: no API key", errInvalidTenant, t.Name)
+		}
+		for _, h := range t.APIKeyHashes {
+			h = strings.ToLower(h)
+			b, err := hex.DecodeString(h)
+			if err != nil || len(b) != sha256.Size {
+				return nil, fmt.Errorf("%w %s: API key hash %q is not a hex SHA-256 digest", errInvalidTenant, t.Name, h)
+			}
+			// A key shared by two tenants would be ambiguous.
+			if keys[h] {
+				return nil, fmt.Errorf("%w %s: duplicate API key hash", errInvalidTenant, t.Name)
+			}
+			keys[h] = true
+			t.keyHashes = append(t.keyHashes, b)
+		}
+		if t.TokensEnv != "" && len(t.Tokens()) == 0 {
+			return nil, fmt.Errorf("%w %s: %s is not set", errInvalidTenant, t.Name, t.TokensEnv)
+		}
+		switch {
+		case t.RequestsPerMinute < 0:
+			return nil, fmt.Errorf("%w %s: negative requestsPerMinute", errInvalidTenant, t.Name)
+		case t.RequestsPerMinute > 0:
+			t.limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(t.RequestsPerMinute)), t.RequestsPerMinute)
+		}
+	}
+	return &reg, nil
+}
+
+// Authenticate returns the tenant whose API key the request is sent with,
+// as a bearer token or in the X-API-Key header, or nil.
+func (reg *Registry) Authenticate(r *http.Request) *Tenant {
+	key := r.Header.Get(APIKeyHeader)
+	if auth := r.Header.Get("Authorization"); key == "" && strings.HasPrefix(auth, "Bearer ") {
+		key = strings.TrimPrefix(auth, "Bearer ")
+	}
+	if key == "" {
+		return nil
+	}
+	sum := sha256.Sum256([]byte(key))
+	for _, t := range reg.Tenants {
+		for _, h := range t.keyHashes {
+			if subtle.ConstantTimeCompare(sum[:], h) == 1 {
+				return t
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func keyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestParse(t *testing.T) {
+	t.Setenv("SEARCH_GITHUB_TOKENS", "ghp_a, ghp_b")
+	config := `
+tenants:
+  - name: payments
+    apiKeyHashes: [` + keyHash("payments-key") + `]
+    requestsPerMinute: 2
+  - name: search
+    namespace: search-team
+    apiKeyHashes: [` + strings.ToUpper(keyHash("search-key")) + `]
+    tokensEnv: SEARCH_GITHUB_TOKENS
+`
+	reg, err := Parse(strings.NewReader(config))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	payments, search := reg.Tenants[0], reg.Tenants[1]
+	if payments.Namespace != "payments" || search.Namespace != "search-team" {
+		t.Errorf("namespaces = %q, %q", payments.Namespace, search.Namespace)
+	}
+	if payments.Tokens() != nil {
+		t.Errorf("Tokens() = %v, want the tokens of the server", payments.Tokens())
+	}
+	if got := search.Tokens(); len(got) != 2 || got[0] != "ghp_a" || got[1] != "ghp_b" {
+		t.Errorf("Tokens() = %v", got)
+	}
+
+	// The burst is one minute of requests.
+	if !payments.Allow() || !payments.Allow() || payments.Allow() {
+		t.Error("Allow() does not enforce requestsPerMinute")
+	}
+	for i := 0; i < 10; i++ {
+		if !search.Allow() {
+			t.Fatal("Allow() limits a tenant without requestsPerMinute")
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Setenv("EMPTY_TOKENS", "")
+	hash := keyHash("key")
+	tests := map[string]string{
+		"no tenants":        `tenants: []`,
+		"no name":           `tenants: [{apiKeyHashes: [` + hash + `]}]`,
+		"no API key":        `tenants: [{name: a}]`,
+		"invalid hash":      `tenants: [{name: a, apiKeyHashes: [secret]}]`,
+		"duplicate name":    `tenants: [{name: a, apiKeyHashes: [` + hash + `]}, {name: a, apiKeyHashes: [` + keyHash("b") + `]}]`,
+		"duplicate key":     `tenants: [{name: a, apiKeyHashes: [` + hash + `]}, {name: b, apiKeyHashes: [` + hash + `]}]`,
+		"invalid namespace": `tenants: [{name: a, namespace: a/b, apiKeyHashes: [` + hash + `]}]`,
+		"unset tokens":      `tenants: [{name: a, tokensEnv: EMPTY_TOKENS, apiKeyHashes: [` + hash + `]}]`,
+		"negative limit":    `tenants: [{name: a, requestsPerMinute: -1, apiKeyHashes: [` + hash + `]}]`,
+	}
+	for name, config := range tests {
+		if _, err := Parse(strings.NewReader(config)); !errors.Is(err, errInvalidTenant) && !errors.Is(err, errNoTenants) {
+			t.Errorf("%s: Parse() error = %v", name, err)
+		}
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+	reg, err := Parse(strings.NewReader(`
+tenants:
+  - name: payments
+    apiKeyHashes: [` + keyHash("old-key") + `, ` + keyHash("new-key") + `]
+`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	tests := []struct {
+		header, value string
+		want          bool
+	}{
+		{header: "Authorization", value: "Bearer new-key", want: true},
+		{header: APIKeyHeader, value: "old-key", want: true},
+		{header: "Authorization", value: "Bearer wrong-key"},
+		{header: "Authorization", value: "new-key"},
+		{},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/?repo=github.com/o/r", nil)
+		if tt.header != "" {
+			r.Header.Set(tt.header, tt.value)
+		}
+		if got := reg.Authenticate(r); (got != nil) != tt.want {
+			t.Errorf("Authenticate(%s: %s) = %v, want a tenant: %v", tt.header, tt.value, got, tt.want)
+		}
+	}
+}
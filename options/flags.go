@@ -45,12 +45,21 @@ const (
 	// FlagRubyGems is the flag name for specifying a RubyGems repository.
 	FlagRubyGems = "rubygems"
 
+	// FlagCargo is the flag name for specifying a crates.io crate.
+	FlagCargo = "cargo"
+
+	// FlagMaven is the flag name for specifying a Maven Central artifact.
+	FlagMaven = "maven"
+
 	// FlagMetadata is the flag name for specifying metadata for the project.
 	FlagMetadata = "metadata"
 
 	// FlagShowDetails is the flag name for outputting additional check info.
 	FlagShowDetails = "show-details"
 
+	// FlagShowAnnotations is the flag name for outputting maintainer annotations.
+	FlagShowAnnotations = "show-annotations"
+
 	// FlagChecks is the flag name for specifying which checks to run.
 	FlagChecks = "checks"
 
@@ -61,6 +70,60 @@ const (
 	FlagFormat = "format"
 
 	FlagCommitDepth = "commit-depth"
+
+	// FlagArchived is the flag name for specifying how archived repos are handled.
+	FlagArchived = "archived"
+
+	// FlagForks is the flag name for specifying how forked repos are handled.
+	FlagForks = "forks"
+
+	// FlagCache is the flag name for specifying the result cache.
+	FlagCache = "cache"
+
+	// FlagWiki is the flag name for checking the wiki of a repo.
+	FlagWiki = "wiki"
+
+	// FlagWorkflowRules is the flag name for specifying custom Dangerous-Workflow rules.
+	FlagWorkflowRules = "workflow-rules"
+
+	// FlagPostProcess is the flag name for specifying the command the results are piped through.
+	FlagPostProcess = "post-process"
+
+	// FlagProbes is the flag name for specifying custom probes.
+	FlagProbes = "probes"
+
+	// FlagMirrors is the flag name for specifying mirrors of the repo on other forges.
+	FlagMirrors = "mirrors"
+
+	// FlagPreset is the flag name for specifying the preset of checks to run.
+	FlagPreset = "preset"
+
+	// FlagProgress is the flag name for specifying the format of the progress output.
+	FlagProgress = "progress"
+
+	// FlagAsOf is the flag name for specifying the date to score a repo as of.
+	FlagAsOf = "as-of"
+
+	// FlagShallow is the flag name for enabling shallow scans.
+	FlagShallow = "shallow"
+
+	// FlagDryRun is the flag name for printing the API requests of the checks without running them.
+	FlagDryRun = "dry-run"
+
+	// FlagVerifyActionPins is the flag name for verifying the commits actions are pinned to.
+	FlagVerifyActionPins = "verify-action-pins"
+
+	// FlagActionPinBudget is the flag name for capping the repos of actions whose tags are listed.
+	FlagActionPinBudget = "action-pin-budget"
+
+	// FlagQuarantineDays is the flag name for quarantining young repos.
+	FlagQuarantineDays = "quarantine-days"
+
+	// FlagDenylist is the flag name for specifying the feed of compromised dependencies.
+	FlagDenylist = "denylist"
+
+	// FlagDenylistSnapshot is the flag name for specifying the offline snapshot of the denylist feed.
+	FlagDenylistSnapshot = "denylist-snapshot"
 )
 
 // Command is an interface for handling options for command-line utilities.
@@ -120,6 +183,20 @@ func (o *Options) AddFlags(cmd *cobra.Command) {
 		"rubygems package to check, given that the rubygems package has a GitHub repository",
 	)
 
+	cmd.Flags().StringVar(
+		&o.Cargo,
+		FlagCargo,
+		o.Cargo,
+		"crates.io crate to check, given that the crate has a GitHub repository",
+	)
+
+	cmd.Flags().StringVar(
+		&o.Maven,
+		FlagMaven,
+		o.Maven,
+		"Maven Central artifact to check as groupId:artifactId, given that the artifact has a GitHub repository",
+	)
+
 	cmd.Flags().StringSliceVar(
 		&o.Metadata,
 		FlagMetadata,
@@ -134,11 +211,148 @@ func (o *Options) AddFlags(cmd *cobra.Command) {
 		"show extra details about each check",
 	)
 
+	cmd.Flags().BoolVar(
+		&o.ShowAnnotations,
+		FlagShowAnnotations,
+		o.ShowAnnotations,
+		"show maintainer annotations from the repo's scorecard config alongside check results",
+	)
+
 	cmd.Flags().IntVar(
 		&o.CommitDepth,
 		FlagCommitDepth,
 		o.CommitDepth,
-		"number of commits to check, commits begin backwards from the HEAD",
+		"number of commits to check, commits begin backwards from --commit (default 30)",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ArchivedPolicy,
+		FlagArchived,
+		o.ArchivedPolicy,
+		"how to handle archived repos. Possible values are: score, skip",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ForkPolicy,
+		FlagForks,
+		o.ForkPolicy,
+		"how to handle forked repos. Possible values are: score, skip, parent",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ResultCache,
+		FlagCache,
+		o.ResultCache,
+		"URL of a cache to reuse results from when scanning the same commit again: "+
+			"file:///dir, gs://bucket or redis://host:port",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.Wiki,
+		FlagWiki,
+		o.Wiki,
+		"check the wiki of the repo, e.g. github.com/owner/repo/wiki, for a security policy and a license",
+	)
+
+	cmd.Flags().StringVar(
+		&o.WorkflowRulesFile,
+		FlagWorkflowRules,
+		o.WorkflowRulesFile,
+		"YAML file of custom rules for the Dangerous-Workflow check, e.g. banned actions or scripts",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ProbesFile,
+		FlagProbes,
+		o.ProbesFile,
+		"YAML file of custom probes on the files of the repo, reported with the checks they name",
+	)
+
+	cmd.Flags().StringVar(
+		&o.Denylist,
+		FlagDenylist,
+		o.Denylist,
+		"file or http(s) URL of a YAML or JSON feed of compromised actions and packages, for the Compromised-Dependencies check",
+	)
+
+	cmd.Flags().StringVar(
+		&o.DenylistSnapshot,
+		FlagDenylistSnapshot,
+		o.DenylistSnapshot,
+		"file the --denylist feed is saved to when fetched, and read from when it cannot be fetched",
+	)
+
+	cmd.Flags().StringVar(
+		&o.PostProcess,
+		FlagPostProcess,
+		o.PostProcess,
+		"command, e.g. ./enrich.sh, which reads the JSON results on stdin and writes the results to output on stdout",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&o.Mirrors,
+		FlagMirrors,
+		o.Mirrors,
+		"mirrors of the repo on other forges, e.g. gitlab.com/owner/repo, scanned in parallel with it. "+
+			"Each check is reported from the mirror it found the most evidence on",
+	)
+
+	cmd.Flags().StringVar(
+		&o.Preset,
+		FlagPreset,
+		o.Preset,
+		"run and weight the checks appropriate to the type of the project. "+
+			"Possible values are: go-library, container-image, github-action, npm-package",
+	)
+
+	cmd.Flags().StringVar(
+		&o.Progress,
+		FlagProgress,
+		o.Progress,
+		"write the progress of scans to stderr, e.g. for CI logs. Possible values are: plain, json",
+	)
+
+	cmd.Flags().StringVar(
+		&o.AsOf,
+		FlagAsOf,
+		o.AsOf,
+		"score the repo as of a date, e.g. 2023-01-01, at the last commit of the default branch before it",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.Shallow,
+		FlagShallow,
+		o.Shallow,
+		"only run the checks looking for files, from the git tree of the repo without downloading it",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.DryRun,
+		FlagDryRun,
+		o.DryRun,
+		"print the API requests each check would make, given the repo and the credentials, without running the checks",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.VerifyActionPins,
+		FlagVerifyActionPins,
+		o.VerifyActionPins,
+		"verify that the commits GitHub Actions are pinned to are tagged by the repos of the actions",
+	)
+
+	cmd.Flags().IntVar(
+		&o.ActionPinBudget,
+		FlagActionPinBudget,
+		o.ActionPinBudget,
+		"maximum number of repos of actions whose tags are listed per scan by --verify-action-pins",
+	)
+
+	cmd.Flags().IntVar(
+		&o.QuarantineDays,
+		FlagQuarantineDays,
+		o.QuarantineDays,
+		"give the minimum aggregate score to repos created fewer than this many days ago "+
+			"which have a single contributor or a squatting-style name (default 0, disabled)",
 	)
 
 	checkNames := []string{}
@@ -156,7 +370,12 @@ func (o *Options) AddFlags(cmd *cobra.Command) {
 	allowedFormats := []string{
 		FormatDefault,
 		FormatJSON,
+		FormatPlan,
+		FormatRaw,
+		FormatSarif,
+		FormatTFData,
 	}
+	allowedFormats = append(allowedFormats, CustomFormats()...)
 
 	if o.isSarifEnabled() {
 		cmd.Flags().StringVar(
@@ -165,8 +384,6 @@ func (o *Options) AddFlags(cmd *cobra.Command) {
 			o.PolicyFile,
 			"policy to enforce",
 		)
-
-		allowedFormats = append(allowedFormats, FormatSarif)
 	}
 
 	cmd.Flags().StringVar(
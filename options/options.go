@@ -19,6 +19,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 
@@ -36,13 +40,69 @@ type Options struct {
 	NPM        string
 	PyPI       string
 	RubyGems   string
+	Cargo      string
+	Maven      string
 	PolicyFile string
+	// ArchivedPolicy and ForkPolicy control how archived and forked repos are handled.
+	ArchivedPolicy string
+	ForkPolicy     string
+	// ResultCache is the URL of the cache results are reused from, if any.
+	ResultCache string
+	// Wiki checks the wiki of the GitHub repo given by Repo instead of the repo.
+	Wiki bool
+	// WorkflowRulesFile is the path of the custom rules for Dangerous-Workflow.
+	WorkflowRulesFile string
+	// ProbesFile is the path of the custom probes.
+	ProbesFile string
+	// Denylist is the path or the http(s) URL of the feed of dependencies
+	// known to be compromised, checked by Compromised-Dependencies.
+	Denylist string
+	// DenylistSnapshot is the file a feed fetched from a URL is saved to, and
+	// read from if the feed cannot be fetched.
+	DenylistSnapshot string
+	// PostProcess is the command the results are piped through before they
+	// are written, see the README for its contract.
+	PostProcess string
+	// Mirrors are the URIs of mirrors of the repo on other forges, whose
+	// results are merged with the results of the repo.
+	Mirrors []string
+	// PathScope restricts the files scanned to those under a directory of
+	// the repo, e.g. a project of a monorepo.
+	PathScope string
+	// FileScope restricts the files scanned to the given files of the repo,
+	// e.g. the files changed by a pull request.
+	FileScope []string
+	// Preset is the name of the preset selecting and weighting the checks
+	// appropriate to the type of the artifact, see pkg.GetPreset.
+	Preset string
+	// Progress is the format of the progress lines written to stderr during
+	// scans, see pkg.ParseProgressFormat.
+	Progress string
+	// AsOf is the date the repo is scored as of, see ParseAsOf.
+	AsOf string
+	// Shallow answers the checks looking for the presence of files from the
+	// git tree of the repo, without downloading it.
+	Shallow bool
+	// DryRun prints the API requests the selected checks would make instead
+	// of running them.
+	DryRun bool
+	// VerifyActionPins looks up the commits GitHub Actions are pinned to in
+	// the tags of their repos, listing the tags of at most ActionPinBudget
+	// repos per scan.
+	VerifyActionPins bool
+	ActionPinBudget  int
+	// QuarantineDays is the age in days below which repos with few
+	// contributors or a squatting-style name are quarantined, or 0.
+	QuarantineDays int
 	// TODO(action): Add logic for writing results to file
 	ResultsFile string
 	ChecksToRun []string
 	Metadata    []string
 	CommitDepth int
 	ShowDetails bool
+	// ShowAnnotations includes the maintainer annotations of the repo's
+	// scorecard config in the results.
+	ShowAnnotations bool
 	// Feature flags.
 	EnableSarif                 bool `env:"ENABLE_SARIF"`
 	EnableScorecardV6           bool `env:"SCORECARD_V6"`
@@ -66,6 +126,15 @@ func New() *Options {
 	if opts.LogLevel == "" {
 		opts.LogLevel = DefaultLogLevel
 	}
+	if opts.ArchivedPolicy == "" {
+		opts.ArchivedPolicy = DefaultArchivedPolicy
+	}
+	if opts.ForkPolicy == "" {
+		opts.ForkPolicy = DefaultForkPolicy
+	}
+	if opts.ActionPinBudget == 0 {
+		opts.ActionPinBudget = DefaultActionPinBudget
+	}
 	return opts
 }
 
@@ -73,6 +142,14 @@ const (
 	// DefaultCommit specifies the default commit reference to use.
 	DefaultCommit = clients.HeadSHA
 
+	// DefaultArchivedPolicy specifies that archived repos are scored as usual.
+	DefaultArchivedPolicy = "score"
+	// DefaultForkPolicy specifies that forked repos are scored as usual.
+	DefaultForkPolicy = "score"
+	// DefaultActionPinBudget is how many repos of actions the tags are
+	// listed of per scan to verify the pins of the actions.
+	DefaultActionPinBudget = 50
+
 	// Formats.
 	// FormatJSON specifies that results should be output in JSON format.
 	FormatJSON = "json"
@@ -85,6 +162,11 @@ const (
 	FormatDefault = "default"
 	// FormatRaw specifies that results should be output in raw format.
 	FormatRaw = "raw"
+	// FormatPlan specifies that results should be output as a remediation plan.
+	FormatPlan = "plan"
+	// FormatTFData specifies that results should be output as the flat JSON
+	// object read by the external data source of Terraform and OpenTofu.
+	FormatTFData = "tfdata"
 
 	// Environment variables.
 	// EnvVarEnableSarif is the environment variable which controls enabling
@@ -102,16 +184,25 @@ var (
 	// DefaultLogLevel retrieves the default log level.
 	DefaultLogLevel = log.DefaultLevel.String()
 
+	errCommitDepthNegative             = errors.New("commit depth should not be negative")
 	errCommitIsEmpty                   = errors.New("commit should be non-empty")
 	errFormatNotSupported              = errors.New("unsupported format")
 	errFormatSupportedWithExperimental = errors.New("format supported only with SCORECARD_EXPERIMENTAL=1")
 	errPolicyFileNotSupported          = errors.New("policy file is not supported yet")
-	errRawOptionNotSupported           = errors.New("raw option is not supported yet")
 	errRepoOptionMustBeSet             = errors.New(
-		"exactly one of `repo`, `npm`, `pypi`, `rubygems` or `local` must be set",
+		"exactly one of `repo`, `npm`, `pypi`, `rubygems`, `cargo`, `maven` or `local` must be set",
 	)
-	errSARIFNotSupported = errors.New("SARIF format is not supported yet")
-	errValidate          = errors.New("some options could not be validated")
+	errValidate         = errors.New("some options could not be validated")
+	errWikiRequiresRepo = errors.New("`wiki` is only supported with `repo` and the HEAD commit")
+	errMirrorsNotLocal  = errors.New("`mirrors` is not supported with `local` or `wiki`")
+	errAsOfRequiresRepo = errors.New("`as-of` is only supported with `repo` and the HEAD commit")
+	errAsOfInvalid      = errors.New("invalid `as-of` date")
+	errShallowNotLocal  = errors.New("`shallow` is not supported with `local`, `wiki` or `mirrors`")
+	errPostProcessJSON  = errors.New("`post-process` is only supported with the json, raw and sarif formats")
+	errDryRunFormat     = errors.New("`dry-run` is only supported with the default and json formats")
+	errActionPinBudget  = errors.New("`action-pin-budget` should not be negative")
+	errDenylistSnapshot = errors.New("`denylist-snapshot` requires `denylist` to be a URL")
+	errQuarantineDays   = errors.New("`quarantine-days` should not be negative")
 )
 
 // Validate validates scorecard configuration options.
@@ -119,11 +210,14 @@ var (
 func (o *Options) Validate() error {
 	var errs []error
 
-	// Validate exactly one of `--repo`, `--npm`, `--pypi`, `--rubygems`, `--local` is enabled.
+	// Validate exactly one of `--repo`, `--npm`, `--pypi`, `--rubygems`,
+	// `--cargo`, `--maven`, `--local` is enabled.
 	if boolSum(o.Repo != "",
 		o.NPM != "",
 		o.PyPI != "",
 		o.RubyGems != "",
+		o.Cargo != "",
+		o.Maven != "",
 		o.Local != "") != 1 {
 		errs = append(
 			errs,
@@ -131,14 +225,8 @@ func (o *Options) Validate() error {
 		)
 	}
 
-	// Validate SARIF features are flag-guarded.
+	// Validate policy files are flag-guarded.
 	if !o.isSarifEnabled() {
-		if o.Format == FormatSarif {
-			errs = append(
-				errs,
-				errSARIFNotSupported,
-			)
-		}
 		if o.PolicyFile != "" {
 			errs = append(
 				errs,
@@ -147,16 +235,6 @@ func (o *Options) Validate() error {
 		}
 	}
 
-	// Validate V6 features are flag-guarded.
-	if !o.isV6Enabled() {
-		if o.Format == FormatRaw {
-			errs = append(
-				errs,
-				errRawOptionNotSupported,
-			)
-		}
-	}
-
 	if !o.isExperimentalEnabled() {
 		if o.Format == FormatSJSON {
 			errs = append(
@@ -182,6 +260,81 @@ func (o *Options) Validate() error {
 		)
 	}
 
+	if o.Wiki && (o.Repo == "" || o.Commit != DefaultCommit) {
+		errs = append(
+			errs,
+			errWikiRequiresRepo,
+		)
+	}
+
+	if len(o.Mirrors) > 0 && (o.Local != "" || o.Wiki) {
+		errs = append(
+			errs,
+			errMirrorsNotLocal,
+		)
+	}
+
+	if o.AsOf != "" {
+		if _, err := ParseAsOf(o.AsOf); err != nil {
+			errs = append(errs, err)
+		}
+		if o.Local != "" || o.Wiki || o.Commit != DefaultCommit {
+			errs = append(
+				errs,
+				errAsOfRequiresRepo,
+			)
+		}
+	}
+
+	if o.Shallow && (o.Local != "" || o.Wiki || len(o.Mirrors) > 0) {
+		errs = append(
+			errs,
+			errShallowNotLocal,
+		)
+	}
+
+	if o.PostProcess != "" && o.Format != FormatJSON && o.Format != FormatRaw && o.Format != FormatSarif {
+		errs = append(
+			errs,
+			errPostProcessJSON,
+		)
+	}
+
+	if o.DryRun && ((o.Format != FormatDefault && o.Format != FormatJSON) || o.PostProcess != "") {
+		errs = append(
+			errs,
+			errDryRunFormat,
+		)
+	}
+
+	if o.ActionPinBudget < 0 {
+		errs = append(
+			errs,
+			errActionPinBudget,
+		)
+	}
+
+	if o.QuarantineDays < 0 {
+		errs = append(
+			errs,
+			errQuarantineDays,
+		)
+	}
+
+	if o.DenylistSnapshot != "" && !strings.HasPrefix(o.Denylist, "https://") && !strings.HasPrefix(o.Denylist, "http://") {
+		errs = append(
+			errs,
+			errDenylistSnapshot,
+		)
+	}
+
+	if o.CommitDepth < 0 {
+		errs = append(
+			errs,
+			errCommitDepthNegative,
+		)
+	}
+
 	if len(errs) != 0 {
 		return fmt.Errorf(
 			"%w: %+v",
@@ -193,6 +346,19 @@ func (o *Options) Validate() error {
 	return nil
 }
 
+// ParseAsOf parses the date of --as-of, either a day, e.g. 2023-01-01, which
+// is midnight UTC, or an RFC 3339 time.
+func ParseAsOf(s string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", errAsOfInvalid, s)
+	}
+	return t, nil
+}
+
 func boolSum(bools ...bool) int {
 	sum := 0
 	for _, b := range bools {
@@ -220,18 +386,52 @@ func (o *Options) isSarifEnabled() bool {
 	return o.EnableSarif || enabled
 }
 
-// isV6Enabled returns true if v6 functionality was specified in options or via
-// environment variable.
-func (o *Options) isV6Enabled() bool {
-	_, enabled := os.LookupEnv(EnvVarScorecardV6)
-	return o.EnableScorecardV6 || enabled
+func validateFormat(format string) bool {
+	if isBuiltinFormat(format) {
+		return true
+	}
+	customFormatsMu.RLock()
+	defer customFormatsMu.RUnlock()
+	return customFormats[format]
 }
 
-func validateFormat(format string) bool {
+func isBuiltinFormat(format string) bool {
 	switch format {
-	case FormatJSON, FormatSJSON, FormatSarif, FormatDefault, FormatRaw:
+	case FormatJSON, FormatSJSON, FormatSarif, FormatDefault, FormatRaw, FormatPlan, FormatTFData:
 		return true
 	default:
 		return false
 	}
 }
+
+var (
+	// customFormats are the formats registered with RegisterFormat.
+	customFormats   = map[string]bool{}
+	customFormatsMu sync.RWMutex
+
+	errFormatRegistered = errors.New("format is already registered")
+)
+
+// RegisterFormat makes name a valid value of --format. It is called by
+// pkg/format.Register, which also registers how results are formatted.
+func RegisterFormat(name string) error {
+	customFormatsMu.Lock()
+	defer customFormatsMu.Unlock()
+	if isBuiltinFormat(name) || customFormats[name] {
+		return fmt.Errorf("%w: %s", errFormatRegistered, name)
+	}
+	customFormats[name] = true
+	return nil
+}
+
+// CustomFormats returns the formats registered with RegisterFormat, sorted.
+func CustomFormats() []string {
+	customFormatsMu.RLock()
+	defer customFormatsMu.RUnlock()
+	ret := make([]string, 0, len(customFormats))
+	for name := range customFormats {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
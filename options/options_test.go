@@ -32,13 +32,23 @@ func TestOptions_Validate(t *testing.T) {
 		NPM               string
 		PyPI              string
 		RubyGems          string
+		Cargo             string
+		Maven             string
 		PolicyFile        string
 		ResultsFile       string
+		AsOf              string
 		ChecksToRun       []string
 		Metadata          []string
+		Mirrors           []string
+		PostProcess       string
+		Denylist          string
+		DenylistSnapshot  string
+		CommitDepth       int
 		ShowDetails       bool
 		EnableSarif       bool
 		EnableScorecardV6 bool
+		DryRun            bool
+		ActionPinBudget   int
 	}
 	tests := []struct {
 		name    string
@@ -50,6 +60,22 @@ func TestOptions_Validate(t *testing.T) {
 			fields:  fields{},
 			wantErr: true,
 		},
+		{
+			name: "maven artifact set",
+			fields: fields{
+				Maven:  "org.example:artifact",
+				Commit: "HEAD",
+				Format: "default",
+			},
+		},
+		{
+			name: "crate and maven artifact set",
+			fields: fields{
+				Cargo: "krate",
+				Maven: "org.example:artifact",
+			},
+			wantErr: true,
+		},
 		{
 			name: "format sarif but the enable sarif flag is not set",
 			fields: fields{
@@ -57,6 +83,15 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "format sarif without the enable sarif flag",
+			fields: fields{
+				Repo:   "github.com/oss/scorecard",
+				Commit: "HEAD",
+				Format: "sarif",
+			},
+			wantErr: false,
+		},
 		{
 			name: "format sarif and the enable sarif flag is set",
 			fields: fields{
@@ -78,12 +113,151 @@ func TestOptions_Validate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "format raw is not supported when V6 is not enabled",
+			name: "format raw",
 			fields: fields{
 				Repo:   "github.com/oss/scorecard",
 				Commit: "HEAD",
 				Format: "raw",
 			},
+			wantErr: false,
+		},
+		{
+			name: "format plan",
+			fields: fields{
+				Repo:   "github.com/oss/scorecard",
+				Commit: "HEAD",
+				Format: "plan",
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative commit depth",
+			fields: fields{
+				Repo:        "github.com/oss/scorecard",
+				Commit:      "HEAD",
+				CommitDepth: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "mirrors of a repo",
+			fields: fields{
+				Repo:    "github.com/oss/scorecard",
+				Commit:  "HEAD",
+				Format:  "default",
+				Mirrors: []string{"gitlab.com/oss/scorecard"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "mirrors of a local folder",
+			fields: fields{
+				Local:   "testdata",
+				Commit:  "HEAD",
+				Mirrors: []string{"gitlab.com/oss/scorecard"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "post-process json results",
+			fields: fields{
+				Repo:        "github.com/oss/scorecard",
+				Commit:      "HEAD",
+				Format:      "json",
+				PostProcess: "./enrich.sh",
+			},
+			wantErr: false,
+		},
+		{
+			name: "post-process default results",
+			fields: fields{
+				Repo:        "github.com/oss/scorecard",
+				Commit:      "HEAD",
+				Format:      "default",
+				PostProcess: "./enrich.sh",
+			},
+			wantErr: true,
+		},
+		{
+			name: "dry run",
+			fields: fields{
+				Repo:   "github.com/oss/scorecard",
+				Commit: "HEAD",
+				Format: "json",
+				DryRun: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "dry run remediation plan",
+			fields: fields{
+				Repo:   "github.com/oss/scorecard",
+				Commit: "HEAD",
+				Format: "plan",
+				DryRun: true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative action pin budget",
+			fields: fields{
+				Repo:            "github.com/oss/scorecard",
+				Commit:          "HEAD",
+				Format:          "default",
+				ActionPinBudget: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "denylist snapshot of a feed",
+			fields: fields{
+				Repo:             "github.com/oss/scorecard",
+				Commit:           "HEAD",
+				Format:           "default",
+				Denylist:         "https://example.com/denylist.json",
+				DenylistSnapshot: "denylist.json",
+			},
+			wantErr: false,
+		},
+		{
+			name: "denylist snapshot of a file",
+			fields: fields{
+				Repo:             "github.com/oss/scorecard",
+				Commit:           "HEAD",
+				Format:           "default",
+				Denylist:         "denylist.yml",
+				DenylistSnapshot: "denylist.json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "as of a date",
+			fields: fields{
+				Repo:   "github.com/oss/scorecard",
+				Commit: "HEAD",
+				Format: "default",
+				AsOf:   "2023-01-01",
+			},
+			wantErr: false,
+		},
+		{
+			name: "as of an invalid date",
+			fields: fields{
+				Repo:   "github.com/oss/scorecard",
+				Commit: "HEAD",
+				Format: "default",
+				AsOf:   "01/01/2023",
+			},
+			wantErr: true,
+		},
+		{
+			name: "as of a date and a commit",
+			fields: fields{
+				Repo:   "github.com/oss/scorecard",
+				Commit: "abc123",
+				Format: "default",
+				AsOf:   "2023-01-01",
+			},
 			wantErr: true,
 		},
 	}
@@ -94,18 +268,28 @@ func TestOptions_Validate(t *testing.T) {
 				Repo:              tt.fields.Repo,
 				Local:             tt.fields.Local,
 				Commit:            tt.fields.Commit,
+				CommitDepth:       tt.fields.CommitDepth,
 				LogLevel:          tt.fields.LogLevel,
 				Format:            tt.fields.Format,
 				NPM:               tt.fields.NPM,
 				PyPI:              tt.fields.PyPI,
 				RubyGems:          tt.fields.RubyGems,
+				Cargo:             tt.fields.Cargo,
+				Maven:             tt.fields.Maven,
 				PolicyFile:        tt.fields.PolicyFile,
 				ResultsFile:       tt.fields.ResultsFile,
+				AsOf:              tt.fields.AsOf,
 				ChecksToRun:       tt.fields.ChecksToRun,
 				Metadata:          tt.fields.Metadata,
+				Mirrors:           tt.fields.Mirrors,
+				PostProcess:       tt.fields.PostProcess,
+				Denylist:          tt.fields.Denylist,
+				DenylistSnapshot:  tt.fields.DenylistSnapshot,
 				ShowDetails:       tt.fields.ShowDetails,
 				EnableSarif:       tt.fields.EnableSarif,
 				EnableScorecardV6: tt.fields.EnableScorecardV6,
+				DryRun:            tt.fields.DryRun,
+				ActionPinBudget:   tt.fields.ActionPinBudget,
 			}
 			if o.EnableSarif {
 				os.Setenv(EnvVarEnableSarif, "1")
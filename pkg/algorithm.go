@@ -0,0 +1,62 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	semver "github.com/Masterminds/semver/v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+// addPreviousScores scores the checks of results whose scoring algorithm
+// changed recently with their previous algorithm as well, on the raw results
+// of the run, until the last release reporting it.
+func addPreviousScores(checksToRun checker.CheckNameToFnMap, results []checker.CheckResult,
+	raw *checker.RawResults, scorecardVersion string,
+) {
+	for i := range results {
+		check, ok := checksToRun[results[i].Name]
+		if !ok || results[i].Error != nil {
+			continue
+		}
+		previous := check.Metadata.Previous
+		if previous == nil || !reportsPrevious(previous, scorecardVersion) {
+			continue
+		}
+		// Only the score is reported, the details are dropped.
+		res := previous.Evaluate(results[i].Name, checker.NewLogger(), raw)
+		if res.Error != nil {
+			continue
+		}
+		results[i].PreviousScore = &checker.AlgorithmScore{
+			Algorithm: previous.Version,
+			Score:     res.Score,
+		}
+	}
+}
+
+// reportsPrevious reports whether the release scorecardVersion still reports
+// the scores of previous. Builds which are not releases always report them.
+func reportsPrevious(previous *checker.PreviousAlgorithm, scorecardVersion string) bool {
+	current, err := semver.NewVersion(scorecardVersion)
+	if err != nil {
+		return true
+	}
+	until, err := semver.NewVersion(previous.Until)
+	if err != nil {
+		return true
+	}
+	return !current.GreaterThan(until)
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+func Test_addPreviousScores(t *testing.T) {
+	t.Parallel()
+	previous := func(score int, err error) *checker.PreviousAlgorithm {
+		return &checker.PreviousAlgorithm{
+			Version: 1,
+			Until:   "v4.12.0",
+			Evaluate: func(name string, dl checker.DetailLogger, raw *checker.RawResults) checker.CheckResult {
+				return checker.CheckResult{Name: name, Score: score, Error: err}
+			},
+		}
+	}
+	checksToRun := checker.CheckNameToFnMap{
+		"Changed":   {Metadata: checker.CheckMetadata{Algorithm: 2, Previous: previous(7, nil)}},
+		"Unchanged": {},
+		"Failing": {Metadata: checker.CheckMetadata{
+			Algorithm: 2,
+			Previous:  previous(0, sce.WithMessage(sce.ErrScorecardInternal, "failed")),
+		}},
+	}
+	tests := []struct {
+		name    string
+		version string
+		results []checker.CheckResult
+		want    []*checker.AlgorithmScore
+	}{
+		{
+			name:    "until the last release",
+			version: "v4.12.0",
+			results: []checker.CheckResult{
+				{Name: "Changed", Score: 9},
+				{Name: "Unchanged", Score: 9},
+				{Name: "Failing", Score: 9},
+			},
+			want: []*checker.AlgorithmScore{{Algorithm: 1, Score: 7}, nil, nil},
+		},
+		{
+			name:    "development build",
+			version: "devel",
+			results: []checker.CheckResult{{Name: "Changed", Score: 9}},
+			want:    []*checker.AlgorithmScore{{Algorithm: 1, Score: 7}},
+		},
+		{
+			name:    "after the last release",
+			version: "v4.13.0",
+			results: []checker.CheckResult{{Name: "Changed", Score: 9}},
+			want:    []*checker.AlgorithmScore{nil},
+		},
+		{
+			name:    "check failed",
+			version: "v4.12.0",
+			results: []checker.CheckResult{
+				{Name: "Changed", Error: errors.New("check failed")}, //nolint:goerr113
+			},
+			want: []*checker.AlgorithmScore{nil},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			addPreviousScores(checksToRun, tt.results, &checker.RawResults{}, tt.version)
+			var got []*checker.AlgorithmScore
+			for i := range tt.results {
+				got = append(got, tt.results[i].PreviousScore)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("addPreviousScores() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAlgorithmJSON(t *testing.T) {
+	t.Parallel()
+	r := ScorecardResult{Checks: []checker.CheckResult{
+		{
+			Name:          "Check-Name",
+			Score:         9,
+			Algorithm:     2,
+			PreviousScore: &checker.AlgorithmScore{Algorithm: 1, Score: 7},
+		},
+	}}
+	var buf bytes.Buffer
+	if err := r.AsJSON2(false, false, log.DefaultLevel, &mockDoc{checks: map[string]mockCheck{
+		"Check-Name": {name: "Check-Name", risk: "High"},
+	}}, &buf); err != nil {
+		t.Fatalf("AsJSON2: %v", err)
+	}
+	var got struct {
+		Checks []jsonCheckResultV2 `json:"checks"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if len(got.Checks) != 1 {
+		t.Fatalf("expected 1 check, got %d", len(got.Checks))
+	}
+	if got.Checks[0].Algorithm != "v2" {
+		t.Errorf("algorithm = %q, want v2", got.Checks[0].Algorithm)
+	}
+	want := &jsonAlgorithmScore{Algorithm: "v1", Score: 7}
+	if diff := cmp.Diff(want, got.Checks[0].PreviousScore); diff != "" {
+		t.Errorf("previousScore mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// applicabilityRule marks the checks which make no sense for repos with a
+// given characteristic, e.g. Fuzzing for a repo without code, as not
+// applicable.
+type applicabilityRule struct {
+	// metadata is recorded in the results metadata when the rule applies.
+	metadata string
+	checks   []string
+	// reason is the reason of the results of the checks it skips.
+	reason string
+	// matches returns whether the repo has the characteristic. It returns
+	// false when that cannot be told, so that the checks are run.
+	matches func(clients.RepoClient) bool
+}
+
+// applicabilityRules are applied in order. A check skipped by a rule is not
+// considered by the next ones.
+var applicabilityRules = []applicabilityRule{emptyRepoRule, noCodeRule, applicationRule}
+
+// skip returns the checks to run without those the rule applies to, and the
+// not applicable results of the latter. The repo is only inspected if some of
+// the checks of the rule are to run.
+func (a *applicabilityRule) skip(repoClient clients.RepoClient, checksToRun checker.CheckNameToFnMap,
+) (checker.CheckNameToFnMap, []checker.CheckResult) {
+	var skipped []string
+	for _, name := range a.checks {
+		if _, ok := checksToRun[name]; ok {
+			skipped = append(skipped, name)
+		}
+	}
+	if len(skipped) == 0 || !a.matches(repoClient) {
+		return checksToRun, nil
+	}
+
+	remaining := make(checker.CheckNameToFnMap, len(checksToRun))
+	for name, check := range checksToRun {
+		remaining[name] = check
+	}
+	results := make([]checker.CheckResult, 0, len(skipped))
+	for _, name := range skipped {
+		delete(remaining, name)
+		results = append(results, checker.CreateNotApplicableResult(name, a.reason))
+	}
+	return remaining, results
+}
+
+// skipNotApplicableChecks returns the checks to run without those which are
+// not applicable to the repo, their results, and the metadata of the rules
+// which applied.
+func skipNotApplicableChecks(repoClient clients.RepoClient, checksToRun checker.CheckNameToFnMap,
+) (checker.CheckNameToFnMap, []checker.CheckResult, []string) {
+	var results []checker.CheckResult
+	var metadata []string
+	for i := range applicabilityRules {
+		var skipped []checker.CheckResult
+		checksToRun, skipped = applicabilityRules[i].skip(repoClient, checksToRun)
+		if len(skipped) > 0 {
+			results = append(results, skipped...)
+			metadata = append(metadata, applicabilityRules[i].metadata)
+		}
+	}
+	return checksToRun, results, metadata
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func Test_skipNotApplicableChecks(t *testing.T) {
+	t.Parallel()
+	all := checker.CheckNameToFnMap{
+		checks.CheckFuzzing:            {},
+		checks.CheckPackaging:          {},
+		checks.CheckMaintained:         {},
+		checks.CheckCodeReview:         {},
+		checks.CheckPinnedDependencies: {},
+	}
+	ctrl := gomock.NewController(t)
+	repoClient := mockrepo.NewMockRepoClient(ctrl)
+	// Packaging is skipped for the empty repo, so it is not checked for
+	// being an application.
+	repoClient.EXPECT().ListCommits().Return([]clients.Commit{}, nil)
+	repoClient.EXPECT().ListProgrammingLanguages().Return(nil, nil)
+	got, results, metadata := skipNotApplicableChecks(repoClient, all)
+	if len(got) != 2 || len(results) != 3 {
+		t.Fatalf("skipNotApplicableChecks() = %d checks, %d results, want 2, 3", len(got), len(results))
+	}
+	for _, r := range results {
+		if !r.NotApplicable || r.Score != checker.InconclusiveResultScore {
+			t.Errorf("result = %+v, want not applicable", r)
+		}
+	}
+	if len(metadata) != 2 || metadata[0] != MetadataEmptyRepo || metadata[1] != MetadataNoCode {
+		t.Errorf("metadata = %v, want [%s %s]", metadata, MetadataEmptyRepo, MetadataNoCode)
+	}
+}
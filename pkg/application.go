@@ -0,0 +1,125 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// MetadataApplication marks results for a repo which builds an application
+// rather than a package, for which the Packaging check is not run.
+const MetadataApplication = "application"
+
+// applicationRule skips Packaging for applications: there is no package to
+// publish, so it would always fail.
+var applicationRule = applicabilityRule{
+	metadata: MetadataApplication,
+	checks:   []string{checks.CheckPackaging},
+	reason:   "the repository builds an application which is not published as a package",
+	matches:  isApplication,
+}
+
+// privateManifests parse the manifests at the root of a repo which can mark
+// it as not published, and return whether they do.
+var privateManifests = map[string]func([]byte) (bool, error){
+	"package.json": isPrivateNPMPackage,
+	"Cargo.toml":   isPrivateCrate,
+}
+
+// publishableManifests are manifests at the root of a repo which declare
+// something others consume, e.g. a Python package or a container image.
+var publishableManifests = map[string]bool{
+	"pyproject.toml":   true,
+	"setup.py":         true,
+	"setup.cfg":        true,
+	"pom.xml":          true,
+	"build.gradle":     true,
+	"build.gradle.kts": true,
+	"go.mod":           true,
+	"composer.json":    true,
+	"Dockerfile":       true,
+	"Containerfile":    true,
+}
+
+func isRootManifest(p string) (bool, error) {
+	if strings.Contains(p, "/") {
+		return false, nil
+	}
+	_, private := privateManifests[p]
+	ext := path.Ext(p)
+	return private || publishableManifests[p] || ext == ".gemspec" || ext == ".nuspec", nil
+}
+
+// isApplication tells whether every manifest at the root of the repo marks it
+// as not published, e.g. a package.json with "private": true. Repos without
+// manifests, or with a manifest which can't be read, are not reported as
+// applications.
+func isApplication(repoClient clients.RepoClient) bool {
+	files, err := repoClient.ListFiles(isRootManifest)
+	if err != nil || len(files) == 0 {
+		return false
+	}
+	for _, f := range files {
+		parse, ok := privateManifests[f]
+		if !ok {
+			return false
+		}
+		content, err := repoClient.GetFileContent(f)
+		if err != nil {
+			return false
+		}
+		if private, err := parse(content); err != nil || !private {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrivateNPMPackage returns whether the package.json is that of a private
+// package which is not the root of workspaces, whose packages may be public.
+func isPrivateNPMPackage(content []byte) (bool, error) {
+	var manifest struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+		Private    bool            `json:"private"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return false, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	return manifest.Private && manifest.Workspaces == nil, nil
+}
+
+// isPrivateCrate returns whether the Cargo.toml declares a crate with
+// publish = false which is not the root of a workspace.
+func isPrivateCrate(content []byte) (bool, error) {
+	var manifest struct {
+		Workspace map[string]interface{} `toml:"workspace"`
+		Package   struct {
+			Publish interface{} `toml:"publish"`
+		} `toml:"package"`
+	}
+	if err := toml.Unmarshal(content, &manifest); err != nil {
+		return false, fmt.Errorf("toml.Unmarshal: %w", err)
+	}
+	publish, ok := manifest.Package.Publish.(bool)
+	return ok && !publish && manifest.Workspace == nil, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func Test_isApplication(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  bool
+	}{
+		{
+			name:  "no manifest",
+			files: map[string]string{"README.md": ""},
+		},
+		{
+			name:  "private npm package",
+			files: map[string]string{"package.json": `{"name": "app", "private": true}`},
+			want:  true,
+		},
+		{
+			name:  "public npm package",
+			files: map[string]string{"package.json": `{"name": "lib"}`},
+		},
+		{
+			name: "private workspaces root",
+			files: map[string]string{
+				"package.json": `{"private": true, "workspaces": ["packages/*"]}`,
+			},
+		},
+		{
+			name: "unpublished crate",
+			files: map[string]string{
+				"Cargo.toml": "[package]\nname = \"app\"\npublish = false\n",
+			},
+			want: true,
+		},
+		{
+			name: "crate published to a registry",
+			files: map[string]string{
+				"Cargo.toml": "[package]\nname = \"lib\"\npublish = [\"my-registry\"]\n",
+			},
+		},
+		{
+			name: "private package with a container image",
+			files: map[string]string{
+				"package.json": `{"private": true}`,
+				"Dockerfile":   "FROM scratch",
+			},
+		},
+		{
+			name: "nested manifests are ignored",
+			files: map[string]string{
+				"package.json":     `{"private": true}`,
+				"lib/package.json": `{"name": "lib"}`,
+			},
+			want: true,
+		},
+		{
+			name:  "invalid manifest",
+			files: map[string]string{"package.json": "{"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			repoClient := mockrepo.NewMockRepoClient(ctrl)
+			repoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					var files []string
+					for f := range tt.files {
+						if ok, _ := predicate(f); ok {
+							files = append(files, f)
+						}
+					}
+					return files, nil
+				})
+			repoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(
+				func(f string) ([]byte, error) {
+					return []byte(tt.files[f]), nil
+				}).AnyTimes()
+			if got := isApplication(repoClient); got != tt.want {
+				t.Errorf("isApplication() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
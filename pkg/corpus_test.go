@@ -0,0 +1,70 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// corpusFixtures returns the anonymized raw results of the benchmark corpus,
+// generated by internal/corpus/generate.
+func corpusFixtures(tb testing.TB) map[string][]byte {
+	tb.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", "corpus", "*.json"))
+	if err != nil || len(paths) == 0 {
+		tb.Fatalf("no corpus fixtures: %v", err)
+	}
+	ret := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			tb.Fatalf("os.ReadFile: %v", err)
+		}
+		ret[filepath.Base(path)] = content
+	}
+	return ret
+}
+
+func TestRerunCorpus(t *testing.T) {
+	t.Parallel()
+	for name, content := range corpusFixtures(t) {
+		for check := range rerunChecks {
+			got, err := RerunCheck(check, bytes.NewReader(content))
+			if err != nil {
+				t.Fatalf("%s: RerunCheck(%s): %v", name, check, err)
+			}
+			if res := got.Checks[0]; res.Error != nil {
+				t.Errorf("%s: %s: %v", name, check, res.Error)
+			}
+		}
+	}
+}
+
+func BenchmarkRerunCorpus(b *testing.B) {
+	fixtures := corpusFixtures(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, content := range fixtures {
+			for check := range rerunChecks {
+				if _, err := RerunCheck(check, bytes.NewReader(content)); err != nil {
+					b.Fatalf("RerunCheck(%s): %v", check, err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+// MetadataProbesNotRun prefixes the comma-separated IDs of the custom probes
+// which did not run because their check was not run, e.g. not selected or not
+// applicable, or failed, see WithCustomProbes.
+const MetadataProbesNotRun = "probes-not-run="
+
+// probesNotRun returns the IDs of the probes whose check is not in
+// checksToRun or has no result without error in results, sorted.
+func probesNotRun(probes []checker.CustomProbe, checksToRun checker.CheckNameToFnMap,
+	results []checker.CheckResult,
+) []string {
+	ran := map[string]bool{}
+	for i := range results {
+		if _, ok := checksToRun[results[i].Name]; ok && results[i].Error == nil {
+			ran[results[i].Name] = true
+		}
+	}
+	var ret []string
+	for i := range probes {
+		if !ran[probes[i].Check] {
+			ret = append(ret, probes[i].ID)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// ProbesNotRun returns the IDs of the custom probes the result records as not
+// run, see MetadataProbesNotRun.
+func (r *ScorecardResult) ProbesNotRun() []string {
+	for _, m := range r.Metadata {
+		if strings.HasPrefix(m, MetadataProbesNotRun) {
+			return strings.Split(strings.TrimPrefix(m, MetadataProbesNotRun), ",")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+func TestProbesNotRun(t *testing.T) {
+	t.Parallel()
+	probes := []checker.CustomProbe{
+		{ID: "codeowners", Check: "Code-Review"},
+		{ID: "no-write-all", Check: "Token-Permissions"},
+		{ID: "release-notes", Check: "Packaging"},
+		{ID: "sbom", Check: "SBOM"},
+	}
+	checksToRun := checker.CheckNameToFnMap{
+		"Code-Review":       {},
+		"Token-Permissions": {},
+	}
+	results := []checker.CheckResult{
+		{Name: "Code-Review", Score: 10},
+		{Name: "Token-Permissions", Score: checker.InconclusiveResultScore, Error: errors.New("internal error")},
+		// Skipped as not applicable, so not in checksToRun.
+		checker.CreateNotApplicableResult("Packaging", "no published packages"),
+	}
+	want := []string{"no-write-all", "release-notes", "sbom"}
+	got := probesNotRun(probes, checksToRun, results)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("probesNotRun() mismatch (-want +got):\n%s", diff)
+	}
+
+	result := ScorecardResult{Metadata: []string{MetadataCached, MetadataProbesNotRun + "no-write-all,sbom"}}
+	if diff := cmp.Diff([]string{"no-write-all", "sbom"}, result.ProbesNotRun()); diff != "" {
+		t.Errorf("ProbesNotRun() mismatch (-want +got):\n%s", diff)
+	}
+	if got := (&ScorecardResult{}).ProbesNotRun(); got != nil {
+		t.Errorf("ProbesNotRun() = %v without metadata, want none", got)
+	}
+}
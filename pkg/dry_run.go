@@ -0,0 +1,454 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// APIs the requests of a scan are made to.
+const (
+	APIREST     = "rest"
+	APIGraphQL  = "graphql"
+	APIDownload = "download"
+)
+
+// APIRequest is a request, or a set of paginated requests, a client makes to
+// serve one of its methods. The clients cache the responses, so a request is
+// made once per scan however many checks need it.
+type APIRequest struct {
+	// API is the API the request is made to, e.g. APIREST.
+	API string `json:"api"`
+	// Endpoint identifies the request, e.g. GET /repos/{owner}/{repo}/releases.
+	Endpoint string `json:"endpoint"`
+	// Per is what the request is repeated for, e.g. "branch", if it is made
+	// on every call of the method rather than once.
+	Per string `json:"per,omitempty"`
+	// Permission is the access needed beyond reading the repo, if any.
+	Permission string `json:"permission,omitempty"`
+	// Count is the approximate number of requests, including pagination.
+	Count int `json:"count"`
+}
+
+// CheckPlan lists the requests a check would make.
+type CheckPlan struct {
+	Name     string       `json:"name"`
+	Inputs   []string     `json:"inputs"`
+	Requests []APIRequest `json:"requests"`
+	// Unsupported lists the inputs the client does not implement. Checks
+	// skip them, or are inconclusive if they can't do without.
+	Unsupported []string `json:"unsupported,omitempty"`
+}
+
+// DryRun is the plan of a scan, printed by --dry-run instead of running it.
+type DryRun struct {
+	Repo        string `json:"repo"`
+	ClientType  string `json:"clientType"`
+	Credentials string `json:"credentials"`
+	CommitDepth int    `json:"commitDepth"`
+	// Setup lists the requests made before running the checks.
+	Setup  []APIRequest `json:"setup"`
+	Checks []CheckPlan  `json:"checks"`
+	// Requests is the approximate number of requests of the scan by API.
+	// Requests shared by checks are counted once, and those repeated per
+	// call once per endpoint, so it is a lower bound.
+	Requests map[string]int `json:"requests"`
+	Warnings []string       `json:"warnings,omitempty"`
+}
+
+// PlanDryRun returns the requests the enabled checks would make to scan repo,
+// with the clients and credentials GetClients would use, without making any.
+func PlanDryRun(repo clients.Repo, commitDepth int, shallow bool,
+	enabledChecks checker.CheckNameToFnMap,
+) *DryRun {
+	clientType, credentials := checker.ClientType(repo)
+	return planDryRun(repo.URI(), clientType, credentials, commitDepth, shallow, enabledChecks)
+}
+
+func planDryRun(repo, clientType, credentials string, commitDepth int, shallow bool,
+	enabledChecks checker.CheckNameToFnMap,
+) *DryRun {
+	if commitDepth <= 0 {
+		commitDepth = clients.DefaultCommitDepth
+	}
+	plan := &DryRun{
+		Repo:        repo,
+		ClientType:  clientType,
+		Credentials: credentials,
+		CommitDepth: commitDepth,
+		Setup:       setupRequests(clientType),
+		Checks:      []CheckPlan{},
+		Requests:    map[string]int{},
+	}
+	if clientType == "github" && credentials == "none" {
+		plan.Warnings = append(plan.Warnings, "no GitHub credentials are set: GraphQL requests fail and "+
+			"REST requests are limited to 60 per hour, set GITHUB_AUTH_TOKEN")
+	}
+
+	names := make([]string, 0, len(enabledChecks))
+	for name := range enabledChecks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		check := enabledChecks[name]
+		checkPlan := CheckPlan{
+			Name:     name,
+			Inputs:   check.Metadata.Inputs,
+			Requests: []APIRequest{},
+		}
+		warned := map[string]bool{}
+		for _, input := range check.Metadata.Inputs {
+			requests, ok := inputRequests(clientType, input, commitDepth, shallow)
+			if !ok {
+				checkPlan.Unsupported = append(checkPlan.Unsupported, input)
+				continue
+			}
+			for _, request := range requests {
+				checkPlan.Requests = appendRequest(checkPlan.Requests, request)
+				if request.Permission != "" && !warned[request.Endpoint] {
+					warned[request.Endpoint] = true
+					plan.Warnings = append(plan.Warnings, fmt.Sprintf(
+						"%s requests %s, which needs %s access", name, request.Endpoint, request.Permission))
+				}
+			}
+		}
+		plan.Checks = append(plan.Checks, checkPlan)
+	}
+
+	counted := map[string]bool{}
+	count := func(requests []APIRequest) {
+		for _, request := range requests {
+			if counted[request.Endpoint] {
+				continue
+			}
+			counted[request.Endpoint] = true
+			plan.Requests[request.API] += request.Count
+		}
+	}
+	count(plan.Setup)
+	for i := range plan.Checks {
+		count(plan.Checks[i].Requests)
+	}
+	return plan
+}
+
+// appendRequest appends request to requests unless a check needs it for
+// several of its inputs.
+func appendRequest(requests []APIRequest, request APIRequest) []APIRequest {
+	for _, r := range requests {
+		if r.Endpoint == request.Endpoint {
+			return requests
+		}
+	}
+	return append(requests, request)
+}
+
+func setupRequests(clientType string) []APIRequest {
+	switch clientType {
+	case "github":
+		return []APIRequest{{API: APIREST, Endpoint: "GET /repos/{owner}/{repo}", Count: 1}}
+	case "gitlab":
+		return []APIRequest{{API: APIREST, Endpoint: "GET /projects/{id}", Count: 1}}
+	default:
+		return []APIRequest{}
+	}
+}
+
+// inputRequests returns the requests made to serve input, a client method
+// listed in the inputs of a check, and false if the client does not
+// implement it.
+func inputRequests(clientType, input string, commitDepth int, shallow bool) ([]APIRequest, bool) {
+	switch input {
+	case "CIIBestPracticesClient.GetBadgeLevel":
+		return []APIRequest{{API: APIREST, Endpoint: "GET https://www.bestpractices.dev/projects.json", Count: 1}}, true
+	case "VulnerabilitiesClient.ListUnfixedVulnerabilities":
+		return []APIRequest{{API: APIREST, Endpoint: "POST https://api.osv.dev/v1/query", Count: 1}}, true
+	case "ActionTags.ListActionTags":
+		// From GitHub, up to 5 pages of tags.
+		return []APIRequest{{
+			API:      APIREST,
+			Endpoint: "GET /repos/{action}/tags",
+			Per:      "action, with --verify-action-pins",
+			Count:    5,
+		}}, true
+	case "CheckRequest.FetchChecksum":
+		return []APIRequest{{
+			API:      APIDownload,
+			Endpoint: "GET {official wrapper jar checksum}",
+			Per:      "Gradle or Maven wrapper jar",
+			Count:    1,
+		}}, true
+	case "CheckRequest.Denylist":
+		// The denylist is read once, before the scan.
+		return []APIRequest{}, true
+	case "OssFuzzRepo.Search", "OssFuzzRepo.ListCheckRunsForRef":
+		return []APIRequest{{
+			API:      APIDownload,
+			Endpoint: "GET https://oss-fuzz-build-logs.storage.googleapis.com/status.json",
+			Count:    1,
+		}}, true
+	}
+	switch clientType {
+	case "github":
+		return githubRequests(input, commitDepth, shallow)
+	case "gitlab":
+		return gitlabRequests(input)
+	default:
+		// Local directories are read from the disk.
+		return []APIRequest{}, strings.HasPrefix(input, "RepoClient.")
+	}
+}
+
+func githubRequests(input string, commitDepth int, shallow bool) ([]APIRequest, bool) {
+	rest := func(endpoint string, count int) APIRequest {
+		return APIRequest{API: APIREST, Endpoint: endpoint, Count: count}
+	}
+	switch input {
+	case "RepoClient.ListFiles", "RepoClient.GetFileContent", "RepoClient.LocalPath":
+		if !shallow {
+			return []APIRequest{{API: APIDownload, Endpoint: "GET /repos/{owner}/{repo}/tarball/{ref}", Count: 1}}, true
+		}
+		switch input {
+		case "RepoClient.ListFiles":
+			return []APIRequest{rest("GET /repos/{owner}/{repo}/git/trees/{ref}", 1)}, true
+		case "RepoClient.GetFileContent":
+			request := rest("GET /repos/{owner}/{repo}/contents/{path}", 1)
+			request.Per = "file"
+			return []APIRequest{request}, true
+		default:
+			return nil, false
+		}
+	case "RepoClient.ListCommits", "RepoClient.ListIssues", "RepoClient.IsArchived",
+		"RepoClient.GetSecurityPolicyURL":
+		// Commits are paged by 100 beyond the first 99.
+		pages := 1
+		if commitDepth > 99 {
+			pages = (commitDepth + 99) / 100
+		}
+		return []APIRequest{{API: APIGraphQL, Endpoint: "query repository { history issues }", Count: pages}}, true
+	case "RepoClient.GetCreatedAt", "RepoClient.IsPrivate", "RepoClient.GetDefaultBranchName":
+		// Read from the response of the setup.
+		return []APIRequest{}, true
+	case "RepoClient.GetDefaultBranch":
+		return []APIRequest{
+			{API: APIGraphQL, Endpoint: "query repository { defaultBranchRef }", Count: 1},
+			rest("GET /repos/{owner}/{repo}/rules/branches/{branch}", 1),
+		}, true
+	case "RepoClient.GetBranch":
+		return []APIRequest{
+			{API: APIGraphQL, Endpoint: "query repository { ref }", Per: "branch", Count: 1},
+			{API: APIREST, Endpoint: "GET /repos/{owner}/{repo}/rules/branches/{branch}", Per: "branch", Count: 1},
+		}, true
+	case "RepoClient.ListReleases":
+		return []APIRequest{rest("GET /repos/{owner}/{repo}/releases", 1)}, true
+	case "RepoClient.GetReleaseAsset":
+		return []APIRequest{{API: APIDownload, Endpoint: "GET {asset}", Per: "release asset", Count: 1}}, true
+	case "RepoClient.ListContributors":
+		// The organizations and company of the first page of contributors.
+		return []APIRequest{
+			rest("GET /repos/{owner}/{repo}/contributors", 1),
+			rest("GET /users/{login}", 30),
+			rest("GET /users/{login}/orgs", 30),
+		}, true
+	case "RepoClient.ListSuccessfulWorkflowRuns":
+		request := rest("GET /repos/{owner}/{repo}/actions/workflows/{file}/runs", 1)
+		request.Per = "workflow"
+		return []APIRequest{request}, true
+	case "RepoClient.ListCheckRunsForRef":
+		request := rest("GET /repos/{owner}/{repo}/commits/{ref}/check-runs", 1)
+		request.Per = "ref"
+		return []APIRequest{request}, true
+	case "RepoClient.ListStatuses":
+		request := rest("GET /repos/{owner}/{repo}/commits/{ref}/statuses", 1)
+		request.Per = "ref"
+		return []APIRequest{request}, true
+	case "RepoClient.ListWebhooks":
+		request := rest("GET /repos/{owner}/{repo}/hooks", 1)
+		request.Permission = "admin"
+		return []APIRequest{request}, true
+	case "RepoClient.GetActionsPolicy":
+		request := rest("GET /repos/{owner}/{repo}/actions/permissions/...", 3)
+		request.Permission = "admin"
+		return []APIRequest{request}, true
+	case "RepoClient.ListDependencyAlerts":
+		request := rest("GET /repos/{owner}/{repo}/dependabot/alerts", 1)
+		request.Permission = "Dependabot alerts"
+		return []APIRequest{request}, true
+	case "RepoClient.ListCodeScanningAlerts":
+		request := rest("GET /repos/{owner}/{repo}/code-scanning/alerts", 1)
+		request.Permission = "security events"
+		return []APIRequest{request}, true
+	case "RepoClient.ListEnvironments":
+		return []APIRequest{
+			rest("GET /repos/{owner}/{repo}/environments", 1),
+			{API: APIREST, Endpoint: "GET /repos/{owner}/{repo}/environments/{name}/secrets", Per: "environment", Count: 1},
+		}, true
+	case "RepoClient.ListForcePushes":
+		return []APIRequest{rest("GET /repos/{owner}/{repo}/activity", 1)}, true
+	case "RepoClient.ListBranches":
+		return []APIRequest{{API: APIGraphQL, Endpoint: "query repository { refs }", Count: 1}}, true
+	case "RepoClient.ListAuditLogEvents":
+		// Up to 10 pages of 100 events.
+		request := rest("GET /orgs/{owner}/audit-log", 10)
+		request.Permission = "organization owner"
+		return []APIRequest{request}, true
+	case "RepoClient.ListProgrammingLanguages":
+		return []APIRequest{rest("GET /repos/{owner}/{repo}/languages", 1)}, true
+	case "RepoClient.ListLicenses":
+		return []APIRequest{rest("GET /repos/{owner}/{repo}/license", 1)}, true
+	case "RepoClient.Search":
+		request := rest("GET /search/code", 1)
+		request.Per = "search"
+		return []APIRequest{request}, true
+	case "RepoClient.SearchCommits":
+		request := rest("GET /search/commits", 1)
+		request.Per = "search"
+		return []APIRequest{request}, true
+	case "CheckRequest.NewOrgRepoClient":
+		return []APIRequest{
+			rest("GET /repos/{owner}/.github", 1),
+			{API: APIDownload, Endpoint: "GET /repos/{owner}/.github/tarball/{ref}", Count: 1},
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+func gitlabRequests(input string) ([]APIRequest, bool) {
+	rest := func(endpoint string, count int) APIRequest {
+		return APIRequest{API: APIREST, Endpoint: endpoint, Count: count}
+	}
+	switch input {
+	case "RepoClient.ListCommits":
+		// The authors and merge requests of the first page of 20 commits.
+		return []APIRequest{
+			rest("GET /projects/{id}/repository/commits", 1),
+			rest("GET /search?scope=users", 40),
+			rest("GET /projects/{id}/repository/commits/{sha}/merge_requests", 20),
+		}, true
+	case "RepoClient.ListIssues":
+		return []APIRequest{
+			rest("GET /projects/{id}/issues", 1),
+			rest("GET /projects/{id}/access_tokens", 1),
+		}, true
+	case "RepoClient.IsArchived", "RepoClient.GetCreatedAt":
+		return []APIRequest{rest("GET /projects/{id}", 1)}, true
+	case "RepoClient.IsPrivate", "RepoClient.GetDefaultBranchName", "RepoClient.ListLicenses":
+		return []APIRequest{}, true
+	case "RepoClient.GetDefaultBranch", "RepoClient.GetBranch":
+		requests := []APIRequest{
+			rest("GET /projects/{id}/repository/branches/{branch}", 1),
+			rest("GET /projects/{id}/protected_branches/{branch}", 1),
+			rest("GET /projects/{id}/external_status_checks", 1),
+			rest("GET /projects/{id}/approvals", 1),
+		}
+		if input == "RepoClient.GetBranch" {
+			for i := range requests {
+				requests[i].Per = "branch"
+			}
+		}
+		return requests, true
+	case "RepoClient.ListReleases":
+		return []APIRequest{rest("GET /projects/{id}/releases", 1)}, true
+	case "RepoClient.ListContributors":
+		return []APIRequest{
+			rest("GET /projects/{id}/repository/contributors", 1),
+			rest("GET /search?scope=users", 40),
+		}, true
+	case "RepoClient.ListSuccessfulWorkflowRuns":
+		return []APIRequest{rest("GET /projects/{id}/jobs", 1)}, true
+	case "RepoClient.ListCheckRunsForRef":
+		request := rest("GET /projects/{id}/pipelines?sha={ref}", 1)
+		request.Per = "ref"
+		return []APIRequest{request}, true
+	case "RepoClient.ListStatuses":
+		request := rest("GET /projects/{id}/repository/commits/{ref}/statuses", 1)
+		request.Per = "ref"
+		return []APIRequest{request}, true
+	case "RepoClient.ListWebhooks":
+		request := rest("GET /projects/{id}/hooks", 1)
+		request.Permission = "maintainer"
+		return []APIRequest{request}, true
+	case "RepoClient.ListProgrammingLanguages":
+		return []APIRequest{rest("GET /projects/{id}/languages", 1)}, true
+	case "RepoClient.Search":
+		request := rest("GET /projects/{id}/search?scope=blobs", 1)
+		request.Per = "search"
+		return []APIRequest{request}, true
+	case "RepoClient.SearchCommits":
+		request := rest("GET /projects/{id}/search?scope=commits", 1)
+		request.Per = "search"
+		return []APIRequest{request}, true
+	default:
+		// Files, release assets and the GitHub specific settings are not
+		// implemented by the GitLab client.
+		return nil, false
+	}
+}
+
+// Write writes the plan as text, one table of requests per check.
+func (d *DryRun) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Dry run of %s with the %s client (credentials: %s, commit depth: %d)\n\n",
+		d.Repo, d.ClientType, d.Credentials, d.CommitDepth)
+	writeRequests := func(requests []APIRequest) {
+		for _, r := range requests {
+			count := fmt.Sprint(r.Count)
+			if r.Per != "" {
+				count += " per " + r.Per
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s", r.API, r.Endpoint, count)
+			if r.Permission != "" {
+				fmt.Fprintf(tw, "\tneeds %s", r.Permission)
+			}
+			fmt.Fprintln(tw)
+		}
+	}
+	if len(d.Setup) > 0 {
+		fmt.Fprintln(tw, "Setup")
+		writeRequests(d.Setup)
+	}
+	for i := range d.Checks {
+		check := &d.Checks[i]
+		fmt.Fprintln(tw, check.Name)
+		writeRequests(check.Requests)
+		if len(check.Requests) == 0 {
+			fmt.Fprintln(tw, "  no requests")
+		}
+		if len(check.Unsupported) > 0 {
+			fmt.Fprintf(tw, "  not supported by the client: %s\n", strings.Join(check.Unsupported, ", "))
+		}
+	}
+	fmt.Fprintf(tw, "\nAt least %d REST, %d GraphQL requests and %d downloads.\n",
+		d.Requests[APIREST], d.Requests[APIGraphQL], d.Requests[APIDownload])
+	if len(d.Warnings) > 0 {
+		fmt.Fprintln(tw, "\nWarnings:")
+		for _, warning := range d.Warnings {
+			fmt.Fprintf(tw, "  - %s\n", warning)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("tabwriter.Flush: %w", err)
+	}
+	return nil
+}
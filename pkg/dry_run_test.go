@@ -0,0 +1,148 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+func withInputs(inputs ...string) checker.Check {
+	return checker.Check{Metadata: checker.CheckMetadata{Inputs: inputs}}
+}
+
+func Test_planDryRun(t *testing.T) {
+	t.Parallel()
+	enabledChecks := checker.CheckNameToFnMap{
+		"Files":    withInputs("RepoClient.ListFiles", "RepoClient.GetFileContent"),
+		"Commits":  withInputs("RepoClient.ListCommits", "RepoClient.ListFiles"),
+		"Webhooks": withInputs("RepoClient.ListWebhooks"),
+		"Policy":   withInputs("RepoClient.GetActionsPolicy"),
+	}
+	tests := []struct {
+		name            string
+		clientType      string
+		credentials     string
+		want            map[string]int
+		wantUnsupported map[string][]string
+		commitDepth     int
+		wantWarnings    int
+		shallow         bool
+	}{
+		{
+			name:         "github",
+			clientType:   "github",
+			credentials:  "classic-pat",
+			want:         map[string]int{APIREST: 5, APIGraphQL: 1, APIDownload: 1},
+			wantWarnings: 2,
+		},
+		{
+			name:         "github without credentials",
+			clientType:   "github",
+			credentials:  "none",
+			want:         map[string]int{APIREST: 5, APIGraphQL: 1, APIDownload: 1},
+			wantWarnings: 3,
+		},
+		{
+			name:         "commits paged by 100",
+			clientType:   "github",
+			credentials:  "classic-pat",
+			commitDepth:  250,
+			want:         map[string]int{APIREST: 5, APIGraphQL: 3, APIDownload: 1},
+			wantWarnings: 2,
+		},
+		{
+			name:         "shallow",
+			clientType:   "github",
+			credentials:  "classic-pat",
+			shallow:      true,
+			want:         map[string]int{APIREST: 7, APIGraphQL: 1},
+			wantWarnings: 2,
+		},
+		{
+			name:        "gitlab",
+			clientType:  "gitlab",
+			credentials: "access-token",
+			want:        map[string]int{APIREST: 63},
+			wantUnsupported: map[string][]string{
+				"Files":   {"RepoClient.ListFiles", "RepoClient.GetFileContent"},
+				"Commits": {"RepoClient.ListFiles"},
+				"Policy":  {"RepoClient.GetActionsPolicy"},
+			},
+			wantWarnings: 1,
+		},
+		{
+			name:        "local directory",
+			clientType:  "localdir",
+			credentials: "none",
+			want:        map[string]int{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			plan := planDryRun("repo", tt.clientType, tt.credentials, tt.commitDepth, tt.shallow, enabledChecks)
+			if diff := cmp.Diff(tt.want, plan.Requests); diff != "" {
+				t.Errorf("planDryRun() requests mismatch (-want +got):\n%s", diff)
+			}
+			unsupported := map[string][]string{}
+			for _, check := range plan.Checks {
+				if len(check.Unsupported) > 0 {
+					unsupported[check.Name] = check.Unsupported
+				}
+			}
+			if tt.wantUnsupported == nil {
+				tt.wantUnsupported = map[string][]string{}
+			}
+			if diff := cmp.Diff(tt.wantUnsupported, unsupported); diff != "" {
+				t.Errorf("planDryRun() unsupported mismatch (-want +got):\n%s", diff)
+			}
+			if len(plan.Warnings) != tt.wantWarnings {
+				t.Errorf("planDryRun() warnings = %v, want %d", plan.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestDryRun_Write(t *testing.T) {
+	t.Parallel()
+	enabledChecks := checker.CheckNameToFnMap{
+		"Branch-Protection": withInputs("RepoClient.GetBranch", "RepoClient.ListAuditLogEvents"),
+		"License":           withInputs("RepoClient.GetCreatedAt"),
+	}
+	plan := planDryRun("github.com/owner/repo", "github", "classic-pat", 0, false, enabledChecks)
+	var buf bytes.Buffer
+	if err := plan.Write(&buf); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+	for _, want := range []string{
+		"Dry run of github.com/owner/repo with the github client (credentials: classic-pat, commit depth: 30)",
+		"1 per branch",
+		"10  needs organization owner",
+		"License\n  no requests",
+		"At least 12 REST, 1 GraphQL requests and 0 downloads.",
+		"Branch-Protection requests GET /orgs/{owner}/audit-log, which needs organization owner access",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Write() = %q, want it to contain %q", buf.String(), want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// MetadataEmptyRepo marks results for a repo without any commit on its
+// default branch, for which the checks in fileChecks are not run.
+const MetadataEmptyRepo = "empty-repo"
+
+// fileChecks look for files on the default branch. A repo whose default
+// branch has no commits, either because the repo is empty or HEAD points at
+// an unborn branch, has no files to find.
+var fileChecks = []string{
+	checks.CheckBinaryArtifacts,
+	checks.CheckDangerousWorkflow,
+	checks.CheckDependencyUpdateTool,
+	checks.CheckHiddenCharacters,
+	checks.CheckLicense,
+	checks.CheckPackaging,
+	checks.CheckPinnedDependencies,
+	checks.CheckTokenPermissions,
+}
+
+// emptyRepoRule skips the checks in fileChecks for repos without commits on
+// their default branch.
+var emptyRepoRule = applicabilityRule{
+	metadata: MetadataEmptyRepo,
+	checks:   fileChecks,
+	reason:   "the default branch of the repository has no commits",
+	matches:  isEmptyRepo,
+}
+
+// isEmptyRepo tells whether the default branch has no commits. Clients which
+// can't list commits, like the local directory one, never report a repo as
+// empty.
+func isEmptyRepo(repoClient clients.RepoClient) bool {
+	commits, err := repoClient.ListCommits()
+	return err == nil && len(commits) == 0
+}
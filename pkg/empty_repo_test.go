@@ -0,0 +1,97 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func Test_emptyRepoRule(t *testing.T) {
+	t.Parallel()
+	all := checker.CheckNameToFnMap{
+		checks.CheckLicense:      {},
+		checks.CheckMaintained:   {},
+		checks.CheckCodeReview:   {},
+		checks.CheckPackaging:    {},
+		checks.CheckWebHooks:     {},
+		checks.CheckCITests:      {},
+		checks.CheckContributors: {},
+	}
+	tests := []struct {
+		name        string
+		err         error
+		commits     []clients.Commit
+		wantChecks  int
+		wantSkipped int
+	}{
+		{
+			name:       "repo with commits",
+			commits:    []clients.Commit{{SHA: "sha"}},
+			wantChecks: 7,
+		},
+		{
+			name:       "commits not supported",
+			err:        clients.ErrUnsupportedFeature,
+			wantChecks: 7,
+		},
+		{
+			name:        "empty repo",
+			commits:     []clients.Commit{},
+			wantChecks:  5,
+			wantSkipped: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			repoClient := mockrepo.NewMockRepoClient(ctrl)
+			repoClient.EXPECT().ListCommits().Return(tt.commits, tt.err)
+			got, skipped := emptyRepoRule.skip(repoClient, all)
+			if len(got) != tt.wantChecks || len(skipped) != tt.wantSkipped {
+				t.Fatalf("emptyRepoRule.skip() = %d checks, %d skipped, want %d, %d",
+					len(got), len(skipped), tt.wantChecks, tt.wantSkipped)
+			}
+			for _, r := range skipped {
+				if r.Score != checker.InconclusiveResultScore ||
+					(r.Name != checks.CheckLicense && r.Name != checks.CheckPackaging) {
+					t.Errorf("skipped result = %+v", r)
+				}
+			}
+			if len(all) != 7 {
+				t.Error("emptyRepoRule.skip() modified the checks to run")
+			}
+		})
+	}
+}
+
+func Test_emptyRepoRule_noFileChecks(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	// Commits are not listed when no check depends on them.
+	repoClient := mockrepo.NewMockRepoClient(ctrl)
+	checksToRun := checker.CheckNameToFnMap{checks.CheckMaintained: {}}
+	if got, skipped := emptyRepoRule.skip(repoClient, checksToRun); len(got) != 1 || skipped != nil {
+		t.Errorf("emptyRepoRule.skip() = %v, %v", got, skipped)
+	}
+}
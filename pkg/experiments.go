@@ -0,0 +1,77 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"sort"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+// WithExperiments sets the experiments evaluated after the checks. Defaults to
+// the experiments enabled via the SCORECARD_EXPERIMENTS environment variable.
+func WithExperiments(experiments checker.ExperimentNameToFnMap) Option {
+	return func(c *runConfig) {
+		c.experiments = experiments
+	}
+}
+
+// runExperiments evaluates the experiments whose check succeeded on the raw
+// results of the run, next to the outcome of the check.
+func runExperiments(experiments checker.ExperimentNameToFnMap, results []checker.CheckResult,
+	raw *checker.RawResults,
+) []checker.ExperimentResult {
+	controls := make(map[string]*checker.CheckResult, len(results))
+	for i := range results {
+		controls[results[i].Name] = &results[i]
+	}
+
+	var ret []checker.ExperimentResult
+	for _, name := range experimentNames(experiments) {
+		experiment := experiments[name]
+		control, ok := controls[experiment.Check]
+		if !ok || control.Error != nil {
+			continue
+		}
+		// Experiments are shipped dark, their details are dropped.
+		treatment := experiment.Fn(experiment.Check, checker.NewLogger(), raw)
+		result := checker.ExperimentResult{
+			Name:  name,
+			Check: experiment.Check,
+			Control: checker.ExperimentOutcome{
+				Score:  control.Score,
+				Reason: control.Reason,
+			},
+			Treatment: checker.ExperimentOutcome{
+				Score:  treatment.Score,
+				Reason: treatment.Reason,
+			},
+		}
+		if treatment.Error != nil {
+			result.Treatment.Reason = treatment.Error.Error()
+		}
+		ret = append(ret, result)
+	}
+	return ret
+}
+
+func experimentNames(experiments checker.ExperimentNameToFnMap) []string {
+	var names []string
+	for name := range experiments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+func TestRunExperiments(t *testing.T) {
+	t.Parallel()
+	variant := func(score int) checker.ExperimentFn {
+		return func(name string, dl checker.DetailLogger, raw *checker.RawResults) checker.CheckResult {
+			return checker.CreateResultWithScore(name, "variant", score)
+		}
+	}
+	experiments := checker.ExperimentNameToFnMap{
+		"b-variant":     {Check: "Check-A", Fn: variant(3)},
+		"a-variant":     {Check: "Check-A", Fn: variant(7)},
+		"failed-check":  {Check: "Check-B", Fn: variant(1)},
+		"missing-check": {Check: "Check-C", Fn: variant(1)},
+		"failing-variant": {Check: "Check-A", Fn: func(name string, dl checker.DetailLogger,
+			raw *checker.RawResults,
+		) checker.CheckResult {
+			//nolint:goerr113
+			return checker.CreateRuntimeErrorResult(name, errors.New("variant error"))
+		}},
+	}
+	results := []checker.CheckResult{
+		checker.CreateResultWithScore("Check-A", "default", 5),
+		//nolint:goerr113
+		checker.CreateRuntimeErrorResult("Check-B", errors.New("check error")),
+	}
+	want := []checker.ExperimentResult{
+		{
+			Name:      "a-variant",
+			Check:     "Check-A",
+			Control:   checker.ExperimentOutcome{Score: 5, Reason: "default"},
+			Treatment: checker.ExperimentOutcome{Score: 7, Reason: "variant"},
+		},
+		{
+			Name:      "b-variant",
+			Check:     "Check-A",
+			Control:   checker.ExperimentOutcome{Score: 5, Reason: "default"},
+			Treatment: checker.ExperimentOutcome{Score: 3, Reason: "variant"},
+		},
+		{
+			Name:      "failing-variant",
+			Check:     "Check-A",
+			Control:   checker.ExperimentOutcome{Score: 5, Reason: "default"},
+			Treatment: checker.ExperimentOutcome{Score: checker.InconclusiveResultScore, Reason: "variant error"},
+		},
+	}
+	got := runExperiments(experiments, results, &checker.RawResults{})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("runExperiments() mismatch (-want +got):\n%s", diff)
+	}
+}
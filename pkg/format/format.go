@@ -0,0 +1,84 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package format lets library consumers and plugins add output formats, e.g.
+// company-internal schemas, which become available via --format.
+package format
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ossf/scorecard/v4/docs/checks"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/pkg"
+	spol "github.com/ossf/scorecard/v4/policy"
+)
+
+// Formatter writes results to writer. It gets the same arguments as
+// pkg.FormatResultsTo does for the built-in formats.
+type Formatter func(
+	writer io.Writer,
+	opts *options.Options,
+	results *pkg.ScorecardResult,
+	doc checks.Doc,
+	policy *spol.ScorecardPolicy,
+) error
+
+var (
+	formatters   = map[string]Formatter{}
+	formattersMu sync.RWMutex
+
+	errInvalidFormatter = errors.New("invalid formatter")
+)
+
+// Register registers f as the formatter of the format name. It is typically
+// called from the init function of a package, so that the format is accepted
+// by --format. Built-in formats can't be replaced.
+func Register(name string, f Formatter) error {
+	if name == "" || f == nil {
+		return fmt.Errorf("%w: both a name and a formatter are required", errInvalidFormatter)
+	}
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+	if err := options.RegisterFormat(name); err != nil {
+		return fmt.Errorf("options.RegisterFormat: %w", err)
+	}
+	formatters[name] = f
+	return nil
+}
+
+// Write formats results to writer in the format of opts, with its registered
+// formatter or else the built-in one.
+func Write(
+	writer io.Writer,
+	opts *options.Options,
+	results *pkg.ScorecardResult,
+	doc checks.Doc,
+	policy *spol.ScorecardPolicy,
+) error {
+	formattersMu.RLock()
+	f, ok := formatters[opts.Format]
+	formattersMu.RUnlock()
+	if !ok {
+		//nolint:wrapcheck // the errors of the built-in formats are returned as-is.
+		return pkg.FormatResultsTo(writer, opts, results, doc, policy)
+	}
+	if err := f(writer, opts, results, doc, policy); err != nil {
+		return fmt.Errorf("failed to output results: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/docs/checks"
+	"github.com/ossf/scorecard/v4/options"
+	"github.com/ossf/scorecard/v4/pkg"
+	spol "github.com/ossf/scorecard/v4/policy"
+)
+
+func names(writer io.Writer, opts *options.Options, results *pkg.ScorecardResult,
+	doc checks.Doc, policy *spol.ScorecardPolicy,
+) error {
+	for i := range results.Checks {
+		fmt.Fprintln(writer, results.Checks[i].Name)
+	}
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+	if err := Register("test-names", names); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := Register("test-names", names); err == nil {
+		t.Error("Register() of a registered format succeeded")
+	}
+	if err := Register(options.FormatJSON, names); err == nil {
+		t.Error("Register() of a built-in format succeeded")
+	}
+	if err := Register("test-nil", nil); !errors.Is(err, errInvalidFormatter) {
+		t.Errorf("Register() of a nil formatter error = %v, want %v", err, errInvalidFormatter)
+	}
+
+	opts := options.New()
+	opts.Repo = "github.com/owner/repo"
+	opts.Format = "test-names"
+	if err := opts.Validate(); err != nil {
+		t.Errorf("Validate() with a registered format: %v", err)
+	}
+	var out bytes.Buffer
+	results := &pkg.ScorecardResult{Checks: []checker.CheckResult{{Name: "License"}}}
+	if err := Write(&out, opts, results, nil, nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := out.String(); got != "License\n" {
+		t.Errorf("Write() = %q, want the output of the registered formatter", got)
+	}
+}
+
+func TestWrite_builtin(t *testing.T) {
+	t.Parallel()
+	opts := options.New()
+	opts.Format = options.FormatRaw
+	var out bytes.Buffer
+	if err := Write(&out, opts, &pkg.ScorecardResult{}, nil, nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if out.Len() == 0 {
+		t.Error("Write() with a built-in format wrote nothing")
+	}
+}
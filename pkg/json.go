@@ -30,10 +30,11 @@ import (
 
 // nolint: govet
 type jsonCheckResult struct {
-	Name       string
-	Details    []string
-	Confidence int
-	Pass       bool
+	Name          string
+	Details       []string
+	Confidence    int
+	Pass          bool
+	NotApplicable bool `json:",omitempty"`
 }
 
 type jsonScorecardResult struct {
@@ -51,16 +52,102 @@ type jsonCheckDocumentationV2 struct {
 
 // nolint: govet
 type jsonCheckResultV2 struct {
-	Details []string                 `json:"details"`
-	Score   int                      `json:"score"`
-	Reason  string                   `json:"reason"`
-	Name    string                   `json:"name"`
-	Doc     jsonCheckDocumentationV2 `json:"documentation"`
+	Details     []string                 `json:"details"`
+	Annotations []string                 `json:"annotations,omitempty"`
+	Score       int                      `json:"score"`
+	Reason      string                   `json:"reason"`
+	Name        string                   `json:"name"`
+	Doc         jsonCheckDocumentationV2 `json:"documentation"`
+	Incomplete  bool                     `json:"incomplete,omitempty"`
+	// NotApplicable tells apart checks not run on the repo because they make
+	// no sense for it from inconclusive ones.
+	NotApplicable bool   `json:"notApplicable,omitempty"`
+	Source        string `json:"source,omitempty"`
+	// Algorithm is the version of the scoring algorithm, e.g. v2.
+	Algorithm     string              `json:"algorithm,omitempty"`
+	PreviousScore *jsonAlgorithmScore `json:"previousScore,omitempty"`
+}
+
+type jsonAlgorithmScore struct {
+	Algorithm string `json:"algorithm"`
+	Score     int    `json:"score"`
+}
+
+func algorithmName(version int) string {
+	if version == 0 {
+		return ""
+	}
+	return fmt.Sprintf("v%d", version)
+}
+
+func asJSONAlgorithmScore(s *checker.AlgorithmScore) *jsonAlgorithmScore {
+	if s == nil {
+		return nil
+	}
+	return &jsonAlgorithmScore{
+		Algorithm: algorithmName(s.Algorithm),
+		Score:     s.Score,
+	}
+}
+
+type jsonScoreConfidence struct {
+	Level        string         `json:"level"`
+	Inconclusive []string       `json:"inconclusive"`
+	Incomplete   []string       `json:"incomplete"`
+	Completeness float64        `json:"completeness"`
+	Lower        jsonFloatScore `json:"lower"`
+	Upper        jsonFloatScore `json:"upper"`
+}
+
+func asJSONScoreConfidence(c *ScoreConfidence) jsonScoreConfidence {
+	ret := jsonScoreConfidence{
+		Level:        c.Level,
+		Inconclusive: c.Inconclusive,
+		Incomplete:   c.Incomplete,
+		Completeness: c.Completeness,
+		Lower:        jsonFloatScore(c.Lower),
+		Upper:        jsonFloatScore(c.Upper),
+	}
+	if ret.Inconclusive == nil {
+		ret.Inconclusive = []string{}
+	}
+	if ret.Incomplete == nil {
+		ret.Incomplete = []string{}
+	}
+	return ret
 }
 
 type jsonRepoV2 struct {
-	Name   string `json:"name"`
-	Commit string `json:"commit"`
+	Commits       *jsonCommitWindow `json:"commits,omitempty"`
+	Name          string            `json:"name"`
+	Commit        string            `json:"commit"`
+	RequestedName string            `json:"requestedName,omitempty"`
+	Mirrors       []string          `json:"mirrors,omitempty"`
+}
+
+type jsonCommitWindow struct {
+	Newest string `json:"newest"`
+	Oldest string `json:"oldest"`
+	Depth  int    `json:"depth"`
+	Count  int    `json:"count"`
+}
+
+func asJSONRepoV2(r *RepoInfo) jsonRepoV2 {
+	ret := jsonRepoV2{
+		Name:          r.Name,
+		Commit:        r.CommitSHA,
+		RequestedName: r.RequestedName,
+		Mirrors:       r.Mirrors,
+	}
+	if r.Commits != nil {
+		ret.Commits = &jsonCommitWindow{
+			Newest: r.Commits.Newest,
+			Oldest: r.Commits.Oldest,
+			Depth:  r.Commits.Depth,
+			Count:  r.Commits.Count,
+		}
+	}
+	return ret
 }
 
 type jsonScorecardV2 struct {
@@ -83,18 +170,75 @@ type JSONScorecardResultV2 struct {
 	Repo           jsonRepoV2          `json:"repo"`
 	Scorecard      jsonScorecardV2     `json:"scorecard"`
 	AggregateScore jsonFloatScore      `json:"score"`
+	Confidence     jsonScoreConfidence `json:"confidence"`
 	Checks         []jsonCheckResultV2 `json:"checks"`
 	Metadata       []string            `json:"metadata"`
+	Provenance     *jsonProvenance     `json:"provenance,omitempty"`
+	// SecurityContact is only known when the Security-Policy check ran.
+	SecurityContact *jsonSecurityContact `json:"securityContact,omitempty"`
+}
+
+type jsonSecurityContact struct {
+	Value  string `json:"value"`
+	Type   string `json:"type"`
+	Source string `json:"source"`
+}
+
+func asJSONSecurityContact(c *SecurityContact) *jsonSecurityContact {
+	if c == nil {
+		return nil
+	}
+	return &jsonSecurityContact{
+		Value:  c.Value,
+		Type:   string(c.Type),
+		Source: c.Source,
+	}
+}
+
+type jsonProvenance struct {
+	StartedAt      string          `json:"startedAt"`
+	FinishedAt     string          `json:"finishedAt"`
+	Scorecard      jsonScorecardV2 `json:"scorecard"`
+	Client         string          `json:"client"`
+	CredentialType string          `json:"credentialType"`
+	PolicyDigest   string          `json:"policyDigest,omitempty"`
+	ConfigDigest   string          `json:"configDigest"`
+	Package        string          `json:"package,omitempty"`
+	RepoConfidence string          `json:"repoConfidence,omitempty"`
+}
+
+func asJSONProvenance(p *Provenance) *jsonProvenance {
+	if p == nil {
+		return nil
+	}
+	return &jsonProvenance{
+		StartedAt:  p.StartedAt.Format(time.RFC3339),
+		FinishedAt: p.FinishedAt.Format(time.RFC3339),
+		Scorecard: jsonScorecardV2{
+			Version: p.ScorecardVersion,
+			Commit:  p.ScorecardCommit,
+		},
+		Client:         p.ClientType,
+		CredentialType: p.CredentialType,
+		PolicyDigest:   p.PolicyDigest,
+		ConfigDigest:   p.ConfigDigest,
+		Package:        p.Package,
+		RepoConfidence: p.RepoConfidence,
+	}
 }
 
 // nolint: govet
 type jsonCheckResultV3 struct {
-	Risk     rules.Risk        `json:"risk"`
-	Outcome  finding.Outcome   `json:"outcome"`
-	Findings []finding.Finding `json:"findings"`
-	Score    int               `json:"score"`
-	Reason   string            `json:"reason"`
-	Name     string            `json:"name"`
+	Risk        rules.Risk        `json:"risk"`
+	Outcome     finding.Outcome   `json:"outcome"`
+	Findings    []finding.Finding `json:"findings"`
+	Annotations []string          `json:"annotations,omitempty"`
+	Score       int               `json:"score"`
+	Reason      string            `json:"reason"`
+	Name        string            `json:"name"`
+	// NotApplicable tells apart checks not run on the repo because they make
+	// no sense for it from inconclusive ones.
+	NotApplicable bool `json:"notApplicable,omitempty"`
 	// TODO(X): list of rules run.
 	// TODO(X): simplify the documentation for the overall check
 	// and add the rules that are used in the description.
@@ -109,6 +253,7 @@ type JSONScorecardResultV3 struct {
 	Repo           jsonRepoV2          `json:"repo"`
 	Scorecard      jsonScorecardV2     `json:"scorecard"`
 	AggregateScore jsonFloatScore      `json:"score"`
+	Confidence     jsonScoreConfidence `json:"confidence"`
 	Checks         []jsonCheckResultV3 `json:"checks"`
 	Metadata       []string            `json:"metadata"`
 }
@@ -125,7 +270,8 @@ func (r *ScorecardResult) AsJSON(showDetails bool, logLevel log.Level, writer io
 
 	for _, checkResult := range r.Checks {
 		tmpResult := jsonCheckResult{
-			Name: checkResult.Name,
+			Name:          checkResult.Name,
+			NotApplicable: checkResult.NotApplicable,
 		}
 		if showDetails {
 			for i := range checkResult.Details {
@@ -146,20 +292,21 @@ func (r *ScorecardResult) AsJSON(showDetails bool, logLevel log.Level, writer io
 }
 
 // AsJSON2 exports results as JSON for new detail format.
-func (r *ScorecardResult) AsJSON2(showDetails bool,
+func (r *ScorecardResult) AsJSON2(showDetails, showAnnotations bool,
 	logLevel log.Level, checkDocs docs.Doc, writer io.Writer,
 ) error {
 	score, err := r.GetAggregateScore(checkDocs)
 	if err != nil {
 		return err
 	}
+	confidence, err := r.GetScoreConfidence(checkDocs)
+	if err != nil {
+		return err
+	}
 
 	encoder := json.NewEncoder(writer)
 	out := JSONScorecardResultV2{
-		Repo: jsonRepoV2{
-			Name:   r.Repo.Name,
-			Commit: r.Repo.CommitSHA,
-		},
+		Repo: asJSONRepoV2(&r.Repo),
 		Scorecard: jsonScorecardV2{
 			Version: r.Scorecard.Version,
 			Commit:  r.Scorecard.CommitSHA,
@@ -167,7 +314,10 @@ func (r *ScorecardResult) AsJSON2(showDetails bool,
 		Date:           r.Date.Format(time.RFC3339),
 		Metadata:       r.Metadata,
 		AggregateScore: jsonFloatScore(score),
+		Confidence:     asJSONScoreConfidence(&confidence),
+		Provenance:     asJSONProvenance(r.Provenance),
 	}
+	out.SecurityContact = asJSONSecurityContact(r.SecurityContact())
 
 	for _, checkResult := range r.Checks {
 		doc, e := checkDocs.GetCheck(checkResult.Name)
@@ -181,8 +331,16 @@ func (r *ScorecardResult) AsJSON2(showDetails bool,
 				URL:   doc.GetDocumentationURL(r.Scorecard.CommitSHA),
 				Short: doc.GetShort(),
 			},
-			Reason: checkResult.Reason,
-			Score:  checkResult.Score,
+			Reason:        checkResult.Reason,
+			Score:         checkResult.Score,
+			Incomplete:    checkResult.Incomplete,
+			NotApplicable: checkResult.NotApplicable,
+			Source:        checkResult.Source,
+			Algorithm:     algorithmName(checkResult.Algorithm),
+			PreviousScore: asJSONAlgorithmScore(checkResult.PreviousScore),
+		}
+		if showAnnotations {
+			tmpResult.Annotations = r.Config.AnnotationsFor(checkResult.Name)
 		}
 		if showDetails {
 			for i := range checkResult.Details {
@@ -203,20 +361,21 @@ func (r *ScorecardResult) AsJSON2(showDetails bool,
 	return nil
 }
 
-func (r *ScorecardResult) AsSJSON(showDetails bool,
+func (r *ScorecardResult) AsSJSON(showDetails, showAnnotations bool,
 	logLevel log.Level, checkDocs docs.Doc, writer io.Writer,
 ) error {
 	score, err := r.GetAggregateScore(checkDocs)
 	if err != nil {
 		return err
 	}
+	confidence, err := r.GetScoreConfidence(checkDocs)
+	if err != nil {
+		return err
+	}
 
 	encoder := json.NewEncoder(writer)
 	out := JSONScorecardResultV3{
-		Repo: jsonRepoV2{
-			Name:   r.Repo.Name,
-			Commit: r.Repo.CommitSHA,
-		},
+		Repo: asJSONRepoV2(&r.Repo),
 		Scorecard: jsonScorecardV2{
 			Version: r.Scorecard.Version,
 			Commit:  r.Scorecard.CommitSHA,
@@ -224,6 +383,7 @@ func (r *ScorecardResult) AsSJSON(showDetails bool,
 		Date:           r.Date.Format("2006-01-02"),
 		Metadata:       r.Metadata,
 		AggregateScore: jsonFloatScore(score),
+		Confidence:     asJSONScoreConfidence(&confidence),
 	}
 
 	for _, checkResult := range r.Checks {
@@ -238,10 +398,17 @@ func (r *ScorecardResult) AsSJSON(showDetails bool,
 				URL:   doc.GetDocumentationURL(r.Scorecard.CommitSHA),
 				Short: doc.GetShort(),
 			},
-			Reason:  checkResult.Reason,
-			Score:   checkResult.Score,
-			Risk:    rules.RiskNone,
-			Outcome: finding.OutcomePositive,
+			Reason:        checkResult.Reason,
+			Score:         checkResult.Score,
+			Risk:          rules.RiskNone,
+			Outcome:       finding.OutcomePositive,
+			NotApplicable: checkResult.NotApplicable,
+		}
+		if checkResult.NotApplicable {
+			tmpResult.Outcome = finding.OutcomeNotApplicable
+		}
+		if showAnnotations {
+			tmpResult.Annotations = r.Config.AnnotationsFor(checkResult.Name)
 		}
 		if showDetails {
 			for i := range checkResult.Details {
@@ -23,6 +23,9 @@ import (
 	"time"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/registry"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
 
@@ -36,6 +39,9 @@ type jsonScorecardRawResult struct {
 	Scorecard jsonScorecardV2 `json:"scorecard"`
 	Metadata  []string        `json:"metadata"`
 	Results   jsonRawResults  `json:"results"`
+	// NotApplicable are the checks not run on the repo, which have no raw
+	// results, because they make no sense for it.
+	NotApplicable []string `json:"notApplicable,omitempty"`
 }
 
 // TODO: separate each check extraction into its own file.
@@ -55,6 +61,12 @@ type jsonTool struct {
 	// TODO: Runs, Issues, Merge requests.
 }
 
+type jsonDependencyManifest struct {
+	Ecosystem string   `json:"ecosystem"`
+	File      jsonFile `json:"file"`
+	Covered   bool     `json:"covered"`
+}
+
 type jsonBranchProtectionSettings struct {
 	RequiredApprovingReviewCount        *int32   `json:"requiredReviewerCount"`
 	AllowsDeletions                     *bool    `json:"allowsDeletions"`
@@ -65,6 +77,7 @@ type jsonBranchProtectionSettings struct {
 	EnforcesAdmins                      *bool    `json:"enforcesAdmin"`
 	RequiresStatusChecks                *bool    `json:"requiresStatuChecks"`
 	RequiresUpToDateBranchBeforeMerging *bool    `json:"requiresUpdatedBranchesToMerge"`
+	RequiresMergeQueue                  *bool    `json:"requiresMergeQueue,omitempty"`
 	StatusCheckContexts                 []string `json:"statusChecksContexts"`
 }
 
@@ -76,6 +89,43 @@ type jsonBranchProtection struct {
 type jsonBranchProtectionMetadata struct {
 	Branches        []jsonBranchProtection `json:"branches"`
 	CodeownersFiles []string               `json:"codeownersFiles"`
+	// AuditLogEvents are the changes of the branch protections found in the
+	// audit log of the organization, if available.
+	AuditLogEvents []jsonAuditLogEvent `json:"auditLogEvents,omitempty"`
+	// ReleaseBranches are null if the branches could not be listed.
+	ReleaseBranches []jsonReleaseBranch `json:"releaseBranches"`
+	DeployBranches  []jsonDeployBranch  `json:"deployBranches"`
+	// ForcePushes are null if the activity of the repo is not available.
+	ForcePushes []jsonForcePush `json:"forcePushes"`
+}
+
+type jsonForcePush struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Actor     string    `json:"actor"`
+	Branch    string    `json:"branch"`
+	Before    string    `json:"before"`
+	After     string    `json:"after"`
+}
+
+type jsonReleaseBranch struct {
+	LastCommit time.Time `json:"lastCommit"`
+	Name       string    `json:"name"`
+	BehindBy   int       `json:"behindBy"`
+}
+
+type jsonDeployBranch struct {
+	File *jsonFile `json:"file"`
+	// Protected is null if the protection could not be read.
+	Protected *bool  `json:"protected"`
+	Name      string `json:"name"`
+}
+
+type jsonAuditLogEvent struct {
+	CreatedAt  time.Time `json:"createdAt"`
+	Action     string    `json:"action"`
+	Actor      string    `json:"actor"`
+	Branch     string    `json:"branch,omitempty"`
+	Resolution string    `json:"resolution,omitempty"`
 }
 
 type jsonReview struct {
@@ -89,9 +139,11 @@ type jsonUser struct {
 	// Orgnization refers to a GitHub org.
 	Organizations []jsonOrganization `json:"organization,omitempty"`
 	// Companies refer to a claim by a user in their profile.
-	Companies        []jsonCompany `json:"company,omitempty"`
-	NumContributions int           `json:"NumContributions,omitempty"`
-	IsBot            bool          `json:"isBot"`
+	Companies []jsonCompany `json:"company,omitempty"`
+	// EmailDomains refer to the corporate domains of verified commit emails.
+	EmailDomains     []string `json:"emailDomains,omitempty"`
+	NumContributions int      `json:"NumContributions,omitempty"`
+	IsBot            bool     `json:"isBot"`
 }
 
 type jsonContributors struct {
@@ -109,6 +161,25 @@ type jsonCompany struct {
 	// TODO: other info.
 }
 
+type jsonCodeReviewSummary struct {
+	SelfMerged               jsonReviewMetric `json:"selfMerged"`
+	SingleMaintainerApproved jsonReviewMetric `json:"singleMaintainerApproved"`
+	IndependentlyReviewed    jsonReviewMetric `json:"independentlyReviewed"`
+	Changesets               int              `json:"changesets"`
+}
+
+type jsonCommitIntegrity struct {
+	WebEdits []string `json:"webEdits"`
+	// UnlinkedAuthors is null if the forge does not link commits to accounts.
+	UnlinkedAuthors []string `json:"unlinkedAuthors"`
+}
+
+type jsonReviewMetric struct {
+	Count int `json:"count"`
+	// Percentage of the changesets, from 0 to 100.
+	Percentage float64 `json:"percentage"`
+}
+
 type jsonDefaultBranchChangeset struct {
 	// ApprovedReviews *jsonApprovedReviews `json:"approved-reviews"`
 	RevisionID     string       `json:"number"`
@@ -120,13 +191,26 @@ type jsonDefaultBranchChangeset struct {
 }
 
 type jsonCommit struct {
-	Message   string   `json:"message"`
-	SHA       string   `json:"sha"`
-	Committer jsonUser `json:"committer"`
+	Signature *jsonCommitSignature `json:"signature,omitempty"`
+	Message   string               `json:"message"`
+	SHA       string               `json:"sha"`
+	CIStatus  string               `json:"ciStatus,omitempty"`
+	Committer jsonUser             `json:"committer"`
+	CoAuthors []jsonCoAuthor       `json:"coAuthors,omitempty"`
 
 	// TODO: check runs, etc.
 }
 
+type jsonCoAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type jsonCommitSignature struct {
+	Type  string `json:"type"`
+	Valid bool   `json:"valid"`
+}
+
 type jsonDatabaseVulnerability struct {
 	// For OSV: OSV-2020-484
 	// For CVE: CVE-2022-23945
@@ -134,11 +218,31 @@ type jsonDatabaseVulnerability struct {
 	// TODO: additional information
 }
 
+type jsonVEXStatement struct {
+	Vulnerability string   `json:"vulnerability"`
+	Status        string   `json:"status"`
+	Justification string   `json:"justification,omitempty"`
+	Aliases       []string `json:"aliases,omitempty"`
+	File          jsonFile `json:"file"`
+}
+
 type jsonArchivedStatus struct {
 	Status bool `json:"status"`
 	// TODO: add fields, e.g. date of archival, etc.
 }
 
+type jsonMaintenanceDeclaration struct {
+	Status string   `json:"status"`
+	Reason string   `json:"reason,omitempty"`
+	File   jsonFile `json:"file"`
+}
+
+type jsonOSSFuzzBuild struct {
+	LastBuild           time.Time  `json:"lastBuild"`
+	LastSuccessfulBuild *time.Time `json:"lastSuccessfulBuild"`
+	LastBuildSucceeded  bool       `json:"lastBuildSucceeded"`
+}
+
 type jsonCreatedAtTime struct {
 	Time time.Time `json:"timestamp"`
 }
@@ -158,15 +262,41 @@ type jsonIssue struct {
 }
 
 type jsonRelease struct {
-	Tag    string             `json:"tag"`
-	URL    string             `json:"url"`
-	Assets []jsonReleaseAsset `json:"assets"`
-	// TODO: add needed fields, e.g. Path.
+	Tag             string             `json:"tag"`
+	URL             string             `json:"url"`
+	Author          string             `json:"author,omitempty"`
+	TagVerification string             `json:"tagVerification,omitempty"`
+	Assets          []jsonReleaseAsset `json:"assets"`
+}
+
+type jsonReleaseChecksum struct {
+	Tag      string `json:"tag"`
+	File     string `json:"file"`
+	Asset    string `json:"asset"`
+	Verified bool   `json:"verified"`
+}
+
+type jsonProvenanceSubject struct {
+	Name     string `json:"name"`
+	Verified bool   `json:"verified"`
+}
+
+type jsonReleaseProvenance struct {
+	Tag         string                  `json:"tag"`
+	File        string                  `json:"file"`
+	BuilderID   string                  `json:"builderId"`
+	SourceURI   string                  `json:"sourceUri"`
+	RepoMatches bool                    `json:"repoMatches"`
+	RefMatches  bool                    `json:"refMatches"`
+	Subjects    []jsonProvenanceSubject `json:"subjects"`
 }
 
 type jsonReleaseAsset struct {
-	Path string `json:"path"`
-	URL  string `json:"url"`
+	Path        string `json:"path"`
+	URL         string `json:"url"`
+	DownloadURL string `json:"downloadURL,omitempty"`
+	Digest      string `json:"digest,omitempty"`
+	Size        int64  `json:"size,omitempty"`
 }
 
 type jsonOssfBestPractices struct {
@@ -186,17 +316,132 @@ type jsonLicense struct {
 }
 
 type jsonWorkflow struct {
-	Job  *jsonWorkflowJob `json:"job"`
-	File *jsonFile        `json:"file"`
+	Job  *jsonWorkflowJob  `json:"job"`
+	File *jsonFile         `json:"file"`
+	Rule *jsonWorkflowRule `json:"rule,omitempty"`
 	// Type is a string to allow different types for permissions, unpinned dependencies, etc.
 	Type string `json:"type"`
 }
 
+// jsonSuppression is a finding suppressed by a `scorecard:ignore` comment.
+type jsonSuppression struct {
+	Location *jsonFile `json:"location"`
+	Check    string    `json:"check"`
+	ID       string    `json:"id"`
+	Reason   string    `json:"reason"`
+	Type     string    `json:"type"`
+}
+
+type jsonWorkflowRule struct {
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+}
+
 type jsonWorkflowJob struct {
 	Name *string `json:"name"`
 	ID   *string `json:"id"`
 }
 
+type jsonWebhook struct {
+	Path           string `json:"path"`
+	ID             int64  `json:"id"`
+	UsesAuthSecret bool   `json:"usesAuthSecret"`
+}
+
+type jsonCheckRun struct {
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	URL        string `json:"url"`
+	App        string `json:"app"`
+}
+
+type jsonStatus struct {
+	State     string `json:"state"`
+	Context   string `json:"context"`
+	URL       string `json:"url"`
+	TargetURL string `json:"targetURL"`
+}
+
+type jsonRevisionCI struct {
+	HeadSHA           string         `json:"headSHA"`
+	CheckRuns         []jsonCheckRun `json:"checkRuns"`
+	Statuses          []jsonStatus   `json:"statuses"`
+	PullRequestNumber int            `json:"pullRequestNumber"`
+}
+
+type jsonCIConfig struct {
+	System    string `json:"system"`
+	Path      string `json:"path"`
+	RunsTests bool   `json:"runsTests"`
+}
+
+type jsonActionsPolicy struct {
+	AllowedActions     string   `json:"allowedActions"`
+	PatternsAllowed    []string `json:"patternsAllowed"`
+	ForkPRApproval     string   `json:"forkPullRequestApproval"`
+	GithubOwnedAllowed bool     `json:"githubOwnedAllowed"`
+	VerifiedAllowed    bool     `json:"verifiedAllowed"`
+	Enabled            bool     `json:"enabled"`
+}
+
+type jsonDeployment struct {
+	Job         *jsonWorkflowJob `json:"job,omitempty"`
+	File        *jsonFile        `json:"file"`
+	Environment string           `json:"environment"`
+}
+
+type jsonEnvironment struct {
+	Name                 string `json:"name"`
+	RequiredReviewers    int    `json:"requiredReviewers"`
+	WaitTimer            int    `json:"waitTimer"`
+	ProtectedBranches    bool   `json:"protectedBranches"`
+	CustomBranchPolicies bool   `json:"customBranchPolicies"`
+	// Secrets is null if the secrets could not be listed.
+	Secrets *int `json:"secrets"`
+}
+
+type jsonDeploymentProtection struct {
+	Deployments []jsonDeployment `json:"deployments"`
+	// Environments is null if the environments could not be retrieved.
+	Environments []jsonEnvironment `json:"environments"`
+}
+
+type jsonRunnerJob struct {
+	Job          *jsonWorkflowJob `json:"job,omitempty"`
+	File         *jsonFile        `json:"file"`
+	Labels       []string         `json:"labels"`
+	ForkTriggers []string         `json:"forkTriggers"`
+	SelfHosted   bool             `json:"selfHosted"`
+}
+
+type jsonSelfHostedRunners struct {
+	Jobs    []jsonRunnerJob `json:"jobs"`
+	Private bool            `json:"private"`
+}
+
+type jsonHiddenCharacter struct {
+	File *jsonFile `json:"file"`
+	Type string    `json:"type"`
+	// CodePoint is the Unicode code point of the character.
+	CodePoint int32 `json:"codePoint"`
+	Column    uint  `json:"column"`
+}
+
+type jsonCompromisedDependencies struct {
+	Dependencies []jsonCompromisedDependency `json:"dependencies"`
+	// Entries is the number of entries of the denylist.
+	Entries int `json:"entries"`
+}
+
+type jsonCompromisedDependency struct {
+	File        *jsonFile `json:"file"`
+	ID          string    `json:"id"`
+	Description string    `json:"description,omitempty"`
+	Ecosystem   string    `json:"ecosystem"`
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+}
+
 type jsonPackage struct {
 	Name *string          `json:"name,omitempty"`
 	Job  *jsonWorkflowJob `json:"job,omitempty"`
@@ -204,8 +449,44 @@ type jsonPackage struct {
 	Runs []jsonRun        `json:"runs,omitempty"`
 }
 
+type jsonRegistryPackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Repo      string `json:"repo"`
+	// RepoConfidence is how confident the mapping of the package to Repo is.
+	RepoConfidence string   `json:"repoConfidence"`
+	RepoCandidates []string `json:"repoCandidates,omitempty"`
+	LatestVersion  string   `json:"latestVersion"`
+	// Maintainers is -1 when the registry does not tell.
+	Maintainers int  `json:"maintainers"`
+	Provenance  bool `json:"provenance"`
+}
+
+// jsonManifestPackage is a package declared by a manifest of the repo.
+type jsonManifestPackage struct {
+	Manifest    jsonFile            `json:"manifest"`
+	Package     jsonRegistryPackage `json:"package"`
+	RepoMatches bool                `json:"repoMatches"`
+}
+
+func asJSONRegistryPackage(p *registry.Package) jsonRegistryPackage {
+	return jsonRegistryPackage{
+		Ecosystem:      string(p.Ecosystem),
+		Name:           p.Name,
+		Repo:           p.Repo,
+		RepoConfidence: p.RepoConfidence,
+		RepoCandidates: p.RepoCandidates,
+		LatestVersion:  p.LatestVersion,
+		Maintainers:    p.Maintainers,
+		Provenance:     p.Provenance,
+	}
+}
+
 type jsonRun struct {
-	URL string `json:"url"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	URL       string     `json:"url"`
+	HeadSHA   string     `json:"headSHA,omitempty"`
+	Version   string     `json:"version,omitempty"`
 	// TODO: add fields, e.g., Result=["success", "failure"]
 }
 
@@ -232,6 +513,7 @@ type jsonTokenPermission struct {
 	Name         *string          `json:"name,omitempty"`
 	Value        *string          `json:"value,omitempty"`
 	File         *jsonFile        `json:"file,omitempty"`
+	Patch        *string          `json:"patch,omitempty"`
 	Type         string           `json:"type"`
 }
 
@@ -241,6 +523,11 @@ type jsonSecurityFile struct {
 	ContentLength uint                     `json:"contentLength,omitempty"`
 }
 
+type jsonSecurityTxt struct {
+	Path     string   `json:"path"`
+	Contacts []string `json:"contacts"`
+}
+
 type jsonSecurityPolicyHits struct {
 	Type       string `json:"type"`
 	Match      string `json:"match,omitempty"`
@@ -249,27 +536,58 @@ type jsonSecurityPolicyHits struct {
 }
 
 //nolint:govet
+type jsonExperiment struct {
+	Name      string                `json:"name"`
+	Check     string                `json:"check"`
+	Control   jsonExperimentOutcome `json:"control"`
+	Treatment jsonExperimentOutcome `json:"treatment"`
+}
+
+type jsonExperimentOutcome struct {
+	Reason string `json:"reason"`
+	Score  int    `json:"score"`
+}
+
 type jsonRawResults struct {
+	// Outcomes of the experiments run alongside the checks.
+	Experiments []jsonExperiment `json:"experiments,omitempty"`
 	// Workflow results.
 	Workflows []jsonWorkflow `json:"workflows"`
 	// Permissions.
 	Permissions jsonPermissionsData `json:"permissions"`
 	// License.
 	Licenses []jsonLicense `json:"licenses"`
+	// Licenses of the subdirectories, e.g. the components of a monorepo.
+	SubdirectoryLicenses []jsonLicense `json:"subdirectoryLicenses"`
 	// List of recent issues.
 	RecentIssues []jsonIssue `json:"issues"`
 	// OSSF best practices badge.
 	OssfBestPractices jsonOssfBestPractices `json:"openssfBestPracticesBadge"`
 	// Vulnerabilities.
 	DatabaseVulnerabilities []jsonDatabaseVulnerability `json:"databaseVulnerabilities"`
+	// Statements of the VEX documents published in the repo.
+	VEXStatements []jsonVEXStatement `json:"vexStatements,omitempty"`
 	// List of binaries found in the repo.
 	Binaries []jsonFile `json:"binaries"`
+	// Binaries stored in Git LFS.
+	LFSBinaries []jsonFile `json:"lfsBinaries"`
+	// List of binaries found in the archives of the latest release.
+	ReleaseBinaries []jsonFile `json:"releaseBinaries"`
+	// Wrapper jars matching the checksums of the official distributions.
+	VerifiedWrappers []jsonFile `json:"verifiedWrappers"`
 	// List of security policy files found in the repo.
 	// Note: we return one at most.
 	SecurityPolicies []jsonSecurityFile `json:"securityPolicies"`
+	// URL of the security policy reported by the hosting platform when no
+	// policy file was found.
+	SecurityPolicyURL string `json:"securityPolicyUrl,omitempty"`
+	// security.txt file found in the repo.
+	SecurityTxt *jsonSecurityTxt `json:"securityTxt,omitempty"`
 	// List of update tools.
 	// Note: we return one at most.
 	DependencyUpdateTools []jsonTool `json:"dependencyUpdateTools"`
+	// Dependency manifests and whether the update tool covers them.
+	DependencyManifests []jsonDependencyManifest `json:"dependencyManifests,omitempty"`
 	// Branch protection settings for development and release branches.
 	BranchProtections jsonBranchProtectionMetadata `json:"branchProtections"`
 	// Contributors. Note: we could use the list of commits instead to store this data.
@@ -278,18 +596,54 @@ type jsonRawResults struct {
 	Contributors jsonContributors `json:"Contributors"`
 	// Commits.
 	DefaultBranchChangesets []jsonDefaultBranchChangeset `json:"defaultBranchChangesets"`
+	// How independently the changesets were reviewed.
+	CodeReviewSummary *jsonCodeReviewSummary `json:"codeReviewSummary,omitempty"`
+	CommitIntegrity   *jsonCommitIntegrity   `json:"commitIntegrity,omitempty"`
 	// Archived status of the repo.
 	ArchivedStatus jsonArchivedStatus `json:"archived"`
 	// Repo creation time
 	CreatedAtTime jsonCreatedAtTime `json:"createdAt"`
+	// Maintenance status declared by the maintainers.
+	MaintenanceDeclaration *jsonMaintenanceDeclaration `json:"maintenanceDeclaration,omitempty"`
+	// Secret scanning alerts dismissed according to the audit log of the
+	// organization, if available.
+	SecretScanningDismissals []jsonAuditLogEvent `json:"secretScanningDismissals,omitempty"`
 	// Fuzzers.
 	Fuzzers []jsonTool `json:"fuzzers"`
+	// Status of the OSS-Fuzz builds.
+	OSSFuzzBuild *jsonOSSFuzzBuild `json:"ossFuzzBuild,omitempty"`
 	// Releases.
 	Releases []jsonRelease `json:"releases"`
+	// Results of verifying release assets against their checksum files.
+	ReleaseChecksums []jsonReleaseChecksum `json:"releaseChecksums"`
+	// Content of the SLSA provenance files of the releases.
+	ReleaseProvenance []jsonReleaseProvenance `json:"releaseProvenance"`
 	// Packages.
 	Packages []jsonPackage `json:"packages"`
+	// Registry metadata of the package the scan was started from.
+	RegistryPackage *jsonRegistryPackage `json:"registryPackage,omitempty"`
+	// Packages declared by the manifests of the repo and published to a registry.
+	ManifestPackages []jsonManifestPackage `json:"manifestPackages,omitempty"`
 	// Dependency pinning.
 	DependencyPinning jsonPinningDependenciesData `json:"dependencyPinning"`
+	// Webhooks.
+	Webhooks []jsonWebhook `json:"webhooks"`
+	// CI results of recently merged pull requests.
+	CITests []jsonRevisionCI `json:"ciTests"`
+	// Configuration files of CI systems like Jenkins.
+	CIConfigs []jsonCIConfig `json:"ciConfigs,omitempty"`
+	// GitHub Actions policy, null if it could not be retrieved.
+	ActionsPolicy *jsonActionsPolicy `json:"actionsPolicy"`
+	// Deployment jobs and environments.
+	DeploymentProtection jsonDeploymentProtection `json:"deploymentProtection"`
+	// Jobs which do not run on standard GitHub-hosted runners.
+	SelfHostedRunners jsonSelfHostedRunners `json:"selfHostedRunners"`
+	// Hidden and confusable characters of source files.
+	HiddenCharacters []jsonHiddenCharacter `json:"hiddenCharacters"`
+	// Dependencies matching the denylist of compromised dependencies.
+	CompromisedDependencies jsonCompromisedDependencies `json:"compromisedDependencies"`
+	// Findings suppressed by `scorecard:ignore` comments.
+	Suppressions []jsonSuppression `json:"suppressions,omitempty"`
 }
 
 func asPointer(s string) *string {
@@ -315,6 +669,7 @@ func (r *jsonScorecardRawResult) addTokenPermissionsRawResults(tp *checker.Token
 			LocationType: asPointer(string(*t.LocationType)),
 			Name:         t.Name,
 			Value:        t.Value,
+			Patch:        t.Patch,
 			Type:         string(t.Type),
 		}
 
@@ -342,6 +697,18 @@ func (r *jsonScorecardRawResult) addTokenPermissionsRawResults(tp *checker.Token
 
 func (r *jsonScorecardRawResult) addPackagingRawResults(pk *checker.PackagingData) error {
 	r.Results.Packages = []jsonPackage{}
+	if pk.Registry != nil {
+		p := asJSONRegistryPackage(pk.Registry)
+		r.Results.RegistryPackage = &p
+	}
+	for i := range pk.RegistryPackages {
+		rp := &pk.RegistryPackages[i]
+		r.Results.ManifestPackages = append(r.Results.ManifestPackages, jsonManifestPackage{
+			Manifest:    jsonFile{Path: rp.Manifest.Path, Offset: rp.Manifest.Offset},
+			Package:     asJSONRegistryPackage(rp.Package),
+			RepoMatches: rp.RepoMatches,
+		})
+	}
 
 	for _, p := range pk.Packages {
 		var jpk jsonPackage
@@ -365,11 +732,16 @@ func (r *jsonScorecardRawResult) addPackagingRawResults(pk *checker.PackagingDat
 		}
 
 		for _, run := range p.Runs {
-			jpk.Runs = append(jpk.Runs,
-				jsonRun{
-					URL: run.URL,
-				},
-			)
+			jr := jsonRun{
+				URL:     run.URL,
+				HeadSHA: run.HeadSHA,
+				Version: run.Version,
+			}
+			if !run.CreatedAt.IsZero() {
+				createdAt := run.CreatedAt
+				jr.CreatedAt = &createdAt
+			}
+			jpk.Runs = append(jpk.Runs, jr)
 		}
 
 		r.Results.Packages = append(r.Results.Packages, jpk)
@@ -403,9 +775,31 @@ func (r *jsonScorecardRawResult) addDependencyPinningRawResults(pd *checker.Pinn
 
 		r.Results.DependencyPinning.Dependencies = append(r.Results.DependencyPinning.Dependencies, v)
 	}
+	r.addSuppressions(checks.CheckPinnedDependencies, pd.Suppressed)
 	return nil
 }
 
+func (r *jsonScorecardRawResult) addSuppressions(check string, suppressions []checker.Suppression) {
+	for i := range suppressions {
+		s := &suppressions[i]
+		v := jsonSuppression{
+			Location: &jsonFile{
+				Path:      s.File.Path,
+				Offset:    s.File.Offset,
+				EndOffset: s.File.EndOffset,
+			},
+			Check:  check,
+			ID:     s.ID,
+			Reason: s.Reason,
+			Type:   s.Type,
+		}
+		if s.File.Snippet != "" {
+			v.Location.Snippet = &s.File.Snippet
+		}
+		r.Results.Suppressions = append(r.Results.Suppressions, v)
+	}
+}
+
 //nolint:unparam
 func (r *jsonScorecardRawResult) addDangerousWorkflowRawResults(df *checker.DangerousWorkflowData) error {
 	r.Results.Workflows = []jsonWorkflow{}
@@ -427,9 +821,16 @@ func (r *jsonScorecardRawResult) addDangerousWorkflowRawResults(df *checker.Dang
 				ID:   e.Job.ID,
 			}
 		}
+		if e.Rule != nil {
+			v.Rule = &jsonWorkflowRule{
+				ID:          e.Rule.ID,
+				Description: e.Rule.Description,
+			}
+		}
 
 		r.Results.Workflows = append(r.Results.Workflows, v)
 	}
+	r.addSuppressions(checks.CheckDangerousWorkflow, df.Suppressed)
 
 	return nil
 }
@@ -442,6 +843,7 @@ func (r *jsonScorecardRawResult) addContributorsRawResults(cr *checker.Contribut
 		u := jsonUser{
 			Login:            user.Login,
 			NumContributions: user.NumContributions,
+			EmailDomains:     user.EmailDomains,
 		}
 
 		for _, org := range user.Organizations {
@@ -472,18 +874,52 @@ func (r *jsonScorecardRawResult) addSignedReleasesRawResults(sr *checker.SignedR
 	for i, release := range sr.Releases {
 		r.Results.Releases = append(r.Results.Releases,
 			jsonRelease{
-				Tag: release.TagName,
-				URL: release.URL,
+				Tag:             release.TagName,
+				URL:             release.URL,
+				Author:          release.Author.Login,
+				TagVerification: release.TagVerification,
 			})
 		for _, asset := range release.Assets {
 			r.Results.Releases[i].Assets = append(r.Results.Releases[i].Assets,
 				jsonReleaseAsset{
-					Path: asset.Name,
-					URL:  asset.URL,
+					Path:        asset.Name,
+					URL:         asset.URL,
+					DownloadURL: asset.DownloadURL,
+					Digest:      asset.Digest,
+					Size:        asset.Size,
 				},
 			)
 		}
 	}
+	r.Results.ReleaseChecksums = []jsonReleaseChecksum{}
+	for _, c := range sr.Checksums {
+		r.Results.ReleaseChecksums = append(r.Results.ReleaseChecksums, jsonReleaseChecksum{
+			Tag:      c.Tag,
+			File:     c.File,
+			Asset:    c.Asset,
+			Verified: c.Verified,
+		})
+	}
+	r.Results.ReleaseProvenance = []jsonReleaseProvenance{}
+	for i := range sr.Provenance {
+		p := &sr.Provenance[i]
+		jp := jsonReleaseProvenance{
+			Tag:         p.Tag,
+			File:        p.File,
+			BuilderID:   p.BuilderID,
+			SourceURI:   p.SourceURI,
+			RepoMatches: p.RepoMatches,
+			RefMatches:  p.RefMatches,
+			Subjects:    []jsonProvenanceSubject{},
+		}
+		for _, subject := range p.Subjects {
+			jp.Subjects = append(jp.Subjects, jsonProvenanceSubject{
+				Name:     subject.Name,
+				Verified: subject.Verified,
+			})
+		}
+		r.Results.ReleaseProvenance = append(r.Results.ReleaseProvenance, jp)
+	}
 	return nil
 }
 
@@ -494,6 +930,18 @@ func (r *jsonScorecardRawResult) addMaintainedRawResults(mr *checker.MaintainedD
 
 	r.Results.CreatedAtTime = jsonCreatedAtTime{Time: mr.CreatedAt}
 
+	if mr.AuditLog != nil {
+		r.Results.SecretScanningDismissals = asJSONAuditLogEvents(mr.AuditLog.Events)
+	}
+
+	if d := mr.MaintenanceDeclaration; d != nil {
+		r.Results.MaintenanceDeclaration = &jsonMaintenanceDeclaration{
+			Status: string(d.Status),
+			Reason: d.Reason,
+			File:   jsonFile{Path: d.File.Path},
+		}
+	}
+
 	// Issues.
 	for i := range mr.Issues {
 		issue := jsonIssue{
@@ -545,13 +993,27 @@ func (r *jsonScorecardRawResult) setDefaultCommitData(changesets []checker.Chang
 		commits := []jsonCommit{}
 		for j := range cs.Commits {
 			commit := cs.Commits[j]
-			commits = append(commits, jsonCommit{
+			jc := jsonCommit{
 				Committer: jsonUser{
 					Login: commit.Committer.Login,
 				},
-				Message: commit.Message,
-				SHA:     commit.SHA,
-			})
+				Message:  commit.Message,
+				SHA:      commit.SHA,
+				CIStatus: string(commit.CIStatus),
+			}
+			for _, ca := range commit.CoAuthors {
+				jc.CoAuthors = append(jc.CoAuthors, jsonCoAuthor{
+					Name:  ca.Name,
+					Email: ca.Email,
+				})
+			}
+			if commit.Signature.Type != clients.SignatureTypeNone {
+				jc.Signature = &jsonCommitSignature{
+					Type:  string(commit.Signature.Type),
+					Valid: commit.Signature.Valid,
+				}
+			}
+			commits = append(commits, jc)
 		}
 
 		reviews := []jsonReview{}
@@ -569,6 +1031,7 @@ func (r *jsonScorecardRawResult) setDefaultCommitData(changesets []checker.Chang
 		// Only add the Merge Request opener as the PR author
 		authors := []jsonUser{{
 			Login: cs.Author.Login,
+			IsBot: cs.Author.IsBot,
 		}}
 
 		r.Results.DefaultBranchChangesets = append(r.Results.DefaultBranchChangesets,
@@ -591,26 +1054,53 @@ func (r *jsonScorecardRawResult) addOssfBestPracticesRawResults(cbp *checker.CII
 }
 
 func (r *jsonScorecardRawResult) addCodeReviewRawResults(cr *checker.CodeReviewData) error {
+	summary := cr.Summary
+	metric := func(count int) jsonReviewMetric {
+		m := jsonReviewMetric{Count: count}
+		if summary.Changesets > 0 {
+			m.Percentage = float64(count) * 100 / float64(summary.Changesets)
+		}
+		return m
+	}
+	r.Results.CodeReviewSummary = &jsonCodeReviewSummary{
+		Changesets:               summary.Changesets,
+		SelfMerged:               metric(summary.SelfMerged),
+		SingleMaintainerApproved: metric(summary.SingleMaintainerApproved),
+		IndependentlyReviewed:    metric(summary.IndependentlyReviewed),
+	}
+	r.Results.CommitIntegrity = &jsonCommitIntegrity{
+		WebEdits:        cr.Integrity.WebEdits,
+		UnlinkedAuthors: cr.Integrity.UnlinkedAuthors,
+	}
+	if r.Results.CommitIntegrity.WebEdits == nil {
+		r.Results.CommitIntegrity.WebEdits = []string{}
+	}
 	return r.setDefaultCommitData(cr.DefaultBranchChangesets)
 }
 
 //nolint:unparam
 func (r *jsonScorecardRawResult) addLicenseRawResults(ld *checker.LicenseData) error {
-	r.Results.Licenses = []jsonLicense{}
-	for idx := range ld.LicenseFiles {
-		r.Results.Licenses = append(r.Results.Licenses,
+	r.Results.Licenses = jsonLicenses(ld.LicenseFiles)
+	r.Results.SubdirectoryLicenses = jsonLicenses(ld.SubdirectoryLicenses)
+	return nil
+}
+
+func jsonLicenses(files []checker.LicenseFile) []jsonLicense {
+	licenses := []jsonLicense{}
+	for idx := range files {
+		licenses = append(licenses,
 			jsonLicense{
 				License: jsonLicenseInfo{
-					File:        ld.LicenseFiles[idx].File.Path,
-					Name:        ld.LicenseFiles[idx].LicenseInformation.Name,
-					SpdxID:      ld.LicenseFiles[idx].LicenseInformation.SpdxID,
-					Attribution: string(ld.LicenseFiles[idx].LicenseInformation.Attribution),
-					Approved:    strconv.FormatBool(ld.LicenseFiles[idx].LicenseInformation.Approved),
+					File:        files[idx].File.Path,
+					Name:        files[idx].LicenseInformation.Name,
+					SpdxID:      files[idx].LicenseInformation.SpdxID,
+					Attribution: string(files[idx].LicenseInformation.Attribution),
+					Approved:    strconv.FormatBool(files[idx].LicenseInformation.Approved),
 				},
 			},
 		)
 	}
-	return nil
+	return licenses
 }
 
 //nolint:unparam
@@ -622,6 +1112,16 @@ func (r *jsonScorecardRawResult) addVulnerbilitiesRawResults(vd *checker.Vulnera
 				ID: v.ID,
 			})
 	}
+	for i := range vd.VEXStatements {
+		v := &vd.VEXStatements[i]
+		r.Results.VEXStatements = append(r.Results.VEXStatements, jsonVEXStatement{
+			Vulnerability: v.Vulnerability,
+			Status:        string(v.Status),
+			Justification: v.Justification,
+			Aliases:       v.Aliases,
+			File:          jsonFile{Path: v.File.Path},
+		})
+	}
 	return nil
 }
 
@@ -633,12 +1133,37 @@ func (r *jsonScorecardRawResult) addBinaryArtifactRawResults(ba *checker.BinaryA
 			Path: v.Path,
 		})
 	}
+	r.Results.LFSBinaries = []jsonFile{}
+	for _, v := range ba.LFSFiles {
+		r.Results.LFSBinaries = append(r.Results.LFSBinaries, jsonFile{
+			Path: v.Path,
+		})
+	}
+	r.Results.ReleaseBinaries = []jsonFile{}
+	for _, v := range ba.ReleaseFiles {
+		r.Results.ReleaseBinaries = append(r.Results.ReleaseBinaries, jsonFile{
+			Path: v.Path,
+		})
+	}
+	r.Results.VerifiedWrappers = []jsonFile{}
+	for _, v := range ba.VerifiedWrappers {
+		r.Results.VerifiedWrappers = append(r.Results.VerifiedWrappers, jsonFile{
+			Path: v.Path,
+		})
+	}
 	return nil
 }
 
 //nolint:unparam
 func (r *jsonScorecardRawResult) addSecurityPolicyRawResults(sp *checker.SecurityPolicyData) error {
 	r.Results.SecurityPolicies = []jsonSecurityFile{}
+	r.Results.SecurityPolicyURL = sp.PolicyURL
+	if sp.SecurityTxt != nil {
+		r.Results.SecurityTxt = &jsonSecurityTxt{
+			Path:     sp.SecurityTxt.File.Path,
+			Contacts: sp.SecurityTxt.Contacts,
+		}
+	}
 	if len(sp.PolicyFiles) > 0 {
 		for idx := range sp.PolicyFiles {
 			r.Results.SecurityPolicies = append(r.Results.SecurityPolicies, jsonSecurityFile{
@@ -678,6 +1203,16 @@ func (r *jsonScorecardRawResult) addFuzzingRawResults(fd *checker.FuzzingData) e
 		}
 		r.Results.Fuzzers = append(r.Results.Fuzzers, jt)
 	}
+	if b := fd.OSSFuzzBuild; b != nil {
+		r.Results.OSSFuzzBuild = &jsonOSSFuzzBuild{
+			LastBuild:          b.LastBuild,
+			LastBuildSucceeded: b.LastBuildSucceeded,
+		}
+		if !b.LastSuccessfulBuild.IsZero() {
+			lastSuccessfulBuild := b.LastSuccessfulBuild
+			r.Results.OSSFuzzBuild.LastSuccessfulBuild = &lastSuccessfulBuild
+		}
+	}
 	return nil
 }
 
@@ -700,6 +1235,14 @@ func (r *jsonScorecardRawResult) addDependencyUpdateToolRawResults(dut *checker.
 		}
 		r.Results.DependencyUpdateTools = append(r.Results.DependencyUpdateTools, jt)
 	}
+	for i := range dut.Manifests {
+		m := &dut.Manifests[i]
+		r.Results.DependencyManifests = append(r.Results.DependencyManifests, jsonDependencyManifest{
+			Ecosystem: m.Ecosystem,
+			File:      jsonFile{Path: m.File.Path},
+			Covered:   m.Covered,
+		})
+	}
 	return nil
 }
 
@@ -718,6 +1261,7 @@ func (r *jsonScorecardRawResult) addBranchProtectionRawResults(bp *checker.Branc
 				EnforcesAdmins:                      v.BranchProtectionRule.EnforceAdmins,
 				RequiresStatusChecks:                v.BranchProtectionRule.CheckRules.RequiresStatusChecks,
 				RequiresUpToDateBranchBeforeMerging: v.BranchProtectionRule.CheckRules.UpToDateBeforeMerge,
+				RequiresMergeQueue:                  v.BranchProtectionRule.RequireMergeQueue,
 				RequiredApprovingReviewCount:        v.BranchProtectionRule.RequiredPullRequestReviews.RequiredApprovingReviewCount,
 				StatusCheckContexts:                 v.BranchProtectionRule.CheckRules.Contexts,
 			}
@@ -730,7 +1274,198 @@ func (r *jsonScorecardRawResult) addBranchProtectionRawResults(bp *checker.Branc
 	r.Results.BranchProtections.Branches = branches
 
 	r.Results.BranchProtections.CodeownersFiles = bp.CodeownersFiles
+	if bp.AuditLog != nil {
+		r.Results.BranchProtections.AuditLogEvents = asJSONAuditLogEvents(bp.AuditLog.Events)
+	}
+	if bp.ReleaseBranches != nil {
+		r.Results.BranchProtections.ReleaseBranches = []jsonReleaseBranch{}
+		for _, b := range bp.ReleaseBranches {
+			r.Results.BranchProtections.ReleaseBranches = append(r.Results.BranchProtections.ReleaseBranches,
+				jsonReleaseBranch{
+					Name:       b.Name,
+					LastCommit: b.LastCommit,
+					BehindBy:   b.BehindBy,
+				})
+		}
+	}
+	if bp.ForcePushes != nil {
+		r.Results.BranchProtections.ForcePushes = []jsonForcePush{}
+		for _, p := range bp.ForcePushes {
+			r.Results.BranchProtections.ForcePushes = append(r.Results.BranchProtections.ForcePushes,
+				jsonForcePush{
+					CreatedAt: p.CreatedAt,
+					Actor:     p.Actor,
+					Branch:    p.Branch,
+					Before:    p.Before,
+					After:     p.After,
+				})
+		}
+	}
+	r.Results.BranchProtections.DeployBranches = []jsonDeployBranch{}
+	for i := range bp.DeployBranches {
+		b := &bp.DeployBranches[i]
+		r.Results.BranchProtections.DeployBranches = append(r.Results.BranchProtections.DeployBranches,
+			jsonDeployBranch{
+				Name:      b.Name,
+				File:      asJSONFile(&b.File),
+				Protected: b.Protected,
+			})
+	}
+
+	return nil
+}
+
+func asJSONAuditLogEvents(events []clients.AuditLogEvent) []jsonAuditLogEvent {
+	ret := make([]jsonAuditLogEvent, 0, len(events))
+	for i := range events {
+		ret = append(ret, jsonAuditLogEvent{
+			CreatedAt:  events[i].CreatedAt,
+			Action:     events[i].Action,
+			Actor:      events[i].Actor,
+			Branch:     events[i].Branch,
+			Resolution: events[i].Resolution,
+		})
+	}
+	return ret
+}
+
+func asJSONFile(f *checker.File) *jsonFile {
+	ret := &jsonFile{
+		Path:      f.Path,
+		Offset:    f.Offset,
+		EndOffset: f.EndOffset,
+	}
+	if f.Snippet != "" {
+		ret.Snippet = &f.Snippet
+	}
+	return ret
+}
+
+func asJSONWorkflowJob(j *checker.WorkflowJob) *jsonWorkflowJob {
+	if j == nil {
+		return nil
+	}
+	return &jsonWorkflowJob{
+		Name: j.Name,
+		ID:   j.ID,
+	}
+}
+
+//nolint:unparam
+func (r *jsonScorecardRawResult) addWebhooksRawResults(wd *checker.WebhooksData) error {
+	r.Results.Webhooks = []jsonWebhook{}
+	for _, w := range wd.Webhooks {
+		r.Results.Webhooks = append(r.Results.Webhooks, jsonWebhook{
+			Path:           w.Path,
+			ID:             w.ID,
+			UsesAuthSecret: w.UsesAuthSecret,
+		})
+	}
+	return nil
+}
+
+//nolint:unparam
+func (r *jsonScorecardRawResult) addCITestsRawResults(cd *checker.CITestData) error {
+	r.Results.CITests = []jsonRevisionCI{}
+	for _, info := range cd.CIInfo {
+		v := jsonRevisionCI{
+			HeadSHA:           info.HeadSHA,
+			PullRequestNumber: info.PullRequestNumber,
+			CheckRuns:         []jsonCheckRun{},
+			Statuses:          []jsonStatus{},
+		}
+		for _, cr := range info.CheckRuns {
+			v.CheckRuns = append(v.CheckRuns, jsonCheckRun{
+				Status:     cr.Status,
+				Conclusion: cr.Conclusion,
+				URL:        cr.URL,
+				App:        cr.App.Slug,
+			})
+		}
+		for _, st := range info.Statuses {
+			v.Statuses = append(v.Statuses, jsonStatus{
+				State:     st.State,
+				Context:   st.Context,
+				URL:       st.URL,
+				TargetURL: st.TargetURL,
+			})
+		}
+		r.Results.CITests = append(r.Results.CITests, v)
+	}
+	for _, config := range cd.Configs {
+		r.Results.CIConfigs = append(r.Results.CIConfigs, jsonCIConfig{
+			System:    config.System,
+			Path:      config.File.Path,
+			RunsTests: config.RunsTests,
+		})
+	}
+	return nil
+}
+
+//nolint:unparam
+func (r *jsonScorecardRawResult) addActionsPolicyRawResults(ad *checker.ActionsPolicyData) error {
+	if ad.Policy == nil {
+		return nil
+	}
+	r.Results.ActionsPolicy = &jsonActionsPolicy{
+		AllowedActions:     string(ad.Policy.AllowedActions),
+		PatternsAllowed:    ad.Policy.PatternsAllowed,
+		ForkPRApproval:     string(ad.Policy.ForkPRApproval),
+		GithubOwnedAllowed: ad.Policy.GithubOwnedAllowed,
+		VerifiedAllowed:    ad.Policy.VerifiedAllowed,
+		Enabled:            ad.Policy.Enabled,
+	}
+	return nil
+}
+
+//nolint:unparam
+func (r *jsonScorecardRawResult) addDeploymentProtectionRawResults(dd *checker.DeploymentProtectionData) error {
+	r.Results.DeploymentProtection = jsonDeploymentProtection{
+		Deployments: []jsonDeployment{},
+	}
+	for i := range dd.Deployments {
+		d := &dd.Deployments[i]
+		r.Results.DeploymentProtection.Deployments = append(r.Results.DeploymentProtection.Deployments,
+			jsonDeployment{
+				Job:         asJSONWorkflowJob(d.Job),
+				File:        asJSONFile(&d.File),
+				Environment: d.Environment,
+			})
+	}
+	if dd.Environments == nil {
+		return nil
+	}
+	r.Results.DeploymentProtection.Environments = []jsonEnvironment{}
+	for _, e := range dd.Environments {
+		r.Results.DeploymentProtection.Environments = append(r.Results.DeploymentProtection.Environments,
+			jsonEnvironment{
+				Name:                 e.Name,
+				RequiredReviewers:    e.RequiredReviewers,
+				WaitTimer:            e.WaitTimer,
+				ProtectedBranches:    e.ProtectedBranches,
+				CustomBranchPolicies: e.CustomBranchPolicies,
+				Secrets:              e.Secrets,
+			})
+	}
+	return nil
+}
 
+//nolint:unparam
+func (r *jsonScorecardRawResult) addSelfHostedRunnersRawResults(sd *checker.SelfHostedRunnersData) error {
+	r.Results.SelfHostedRunners = jsonSelfHostedRunners{
+		Jobs:    []jsonRunnerJob{},
+		Private: sd.Private,
+	}
+	for i := range sd.Jobs {
+		j := &sd.Jobs[i]
+		r.Results.SelfHostedRunners.Jobs = append(r.Results.SelfHostedRunners.Jobs, jsonRunnerJob{
+			Job:          asJSONWorkflowJob(j.Job),
+			File:         asJSONFile(&j.File),
+			Labels:       j.Labels,
+			ForkTriggers: j.ForkTriggers,
+			SelfHosted:   j.SelfHosted,
+		})
+	}
 	return nil
 }
 
@@ -814,17 +1549,95 @@ func (r *jsonScorecardRawResult) fillJSONRawResults(raw *checker.RawResults) err
 		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
 	}
 
+	// Webhooks.
+	if err := r.addWebhooksRawResults(&raw.WebhookResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
+	// CI-Tests.
+	if err := r.addCITestsRawResults(&raw.CITestResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
+	// Actions-Policy.
+	if err := r.addActionsPolicyRawResults(&raw.ActionsPolicyResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
+	// Deployment-Protection.
+	if err := r.addDeploymentProtectionRawResults(&raw.DeploymentProtectionResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
+	// Self-Hosted-Runners.
+	if err := r.addSelfHostedRunnersRawResults(&raw.SelfHostedRunnersResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
+	// Hidden-Characters.
+	if err := r.addHiddenCharactersRawResults(&raw.HiddenCharactersResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
+	// Compromised-Dependencies.
+	r.addCompromisedDependenciesRawResults(&raw.CompromisedDependenciesResults)
+
+	r.addExperimentsRawResults(raw.Experiments)
+
+	return nil
+}
+
+//nolint:unparam
+func (r *jsonScorecardRawResult) addHiddenCharactersRawResults(hd *checker.HiddenCharactersData) error {
+	r.Results.HiddenCharacters = []jsonHiddenCharacter{}
+	for i := range hd.Characters {
+		c := &hd.Characters[i]
+		r.Results.HiddenCharacters = append(r.Results.HiddenCharacters, jsonHiddenCharacter{
+			File:      asJSONFile(&c.File),
+			Type:      string(c.Type),
+			CodePoint: c.CodePoint,
+			Column:    c.Column,
+		})
+	}
 	return nil
 }
 
+func (r *jsonScorecardRawResult) addCompromisedDependenciesRawResults(cd *checker.CompromisedDependenciesData) {
+	r.Results.CompromisedDependencies = jsonCompromisedDependencies{
+		Dependencies: []jsonCompromisedDependency{},
+		Entries:      cd.Entries,
+	}
+	for i := range cd.Dependencies {
+		d := &cd.Dependencies[i]
+		r.Results.CompromisedDependencies.Dependencies = append(r.Results.CompromisedDependencies.Dependencies,
+			jsonCompromisedDependency{
+				File:        asJSONFile(&d.File),
+				ID:          d.Entry.ID,
+				Description: d.Entry.Description,
+				Ecosystem:   string(d.Entry.Ecosystem),
+				Name:        d.Name,
+				Version:     d.Version,
+			})
+	}
+}
+
+func (r *jsonScorecardRawResult) addExperimentsRawResults(experiments []checker.ExperimentResult) {
+	for i := range experiments {
+		e := &experiments[i]
+		r.Results.Experiments = append(r.Results.Experiments, jsonExperiment{
+			Name:      e.Name,
+			Check:     e.Check,
+			Control:   jsonExperimentOutcome{Score: e.Control.Score, Reason: e.Control.Reason},
+			Treatment: jsonExperimentOutcome{Score: e.Treatment.Score, Reason: e.Treatment.Reason},
+		})
+	}
+}
+
 // AsRawJSON exports results as JSON for raw results.
 func (r *ScorecardResult) AsRawJSON(writer io.Writer) error {
 	encoder := json.NewEncoder(writer)
 	out := jsonScorecardRawResult{
-		Repo: jsonRepoV2{
-			Name:   r.Repo.Name,
-			Commit: r.Repo.CommitSHA,
-		},
+		Repo: asJSONRepoV2(&r.Repo),
 		Scorecard: jsonScorecardV2{
 			Version: r.Scorecard.Version,
 			Commit:  r.Scorecard.CommitSHA,
@@ -832,6 +1645,11 @@ func (r *ScorecardResult) AsRawJSON(writer io.Writer) error {
 		Date:     r.Date.Format("2006-01-02"),
 		Metadata: r.Metadata,
 	}
+	for i := range r.Checks {
+		if r.Checks[i].NotApplicable {
+			out.NotApplicable = append(out.NotApplicable, r.Checks[i].Name)
+		}
+	}
 
 	if err := out.fillJSONRawResults(&r.RawResults); err != nil {
 		return err
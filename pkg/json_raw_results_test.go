@@ -0,0 +1,188 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mcuadros/go-jsonschema-generator"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// TestRawSchemaUpToDate ensures json.raw.schema documents the current
+// raw results format. Regenerate the file when changing the json* types.
+func TestRawSchemaUpToDate(t *testing.T) {
+	t.Parallel()
+	content, err := os.ReadFile("json.raw.schema")
+	if err != nil {
+		t.Fatalf("os.ReadFile: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	s := &jsonschema.Document{}
+	s.Read(&jsonScorecardRawResult{})
+	var want map[string]interface{}
+	if err := json.Unmarshal([]byte(s.String()), &want); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("json.raw.schema is out of date (-want +got):\n%s", diff)
+	}
+}
+
+func TestAsRawJSON(t *testing.T) {
+	t.Parallel()
+	name, id := "deploy", "deploy"
+	result := ScorecardResult{
+		Repo: RepoInfo{
+			Name:      "github.com/foo/bar",
+			CommitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32",
+		},
+		Scorecard: ScorecardInfo{
+			Version:   "1.2.3",
+			CommitSHA: "ccbc59901773ab4c051dfcea0cc4201a1567abdd",
+		},
+		Date: time.Date(2023, time.March, 2, 0, 0, 0, 0, time.UTC),
+		RawResults: checker.RawResults{
+			WebhookResults: checker.WebhooksData{
+				Webhooks: []clients.Webhook{
+					{Path: "https://example.com/hook", ID: 12, UsesAuthSecret: true},
+				},
+			},
+			CITestResults: checker.CITestData{
+				CIInfo: []checker.RevisionCIInfo{
+					{
+						HeadSHA:           "abc",
+						PullRequestNumber: 7,
+						CheckRuns: []clients.CheckRun{
+							{Status: "completed", Conclusion: "success", App: clients.CheckRunApp{Slug: "github-actions"}},
+						},
+					},
+				},
+			},
+			ActionsPolicyResults: checker.ActionsPolicyData{
+				Policy: &clients.ActionsPolicy{
+					Enabled:        true,
+					AllowedActions: clients.AllowedActionsAll,
+				},
+			},
+			DeploymentProtectionResults: checker.DeploymentProtectionData{
+				Deployments: []checker.Deployment{
+					{
+						Job:         &checker.WorkflowJob{Name: &name, ID: &id},
+						Environment: "production",
+						File:        checker.File{Path: ".github/workflows/release.yml", Offset: 10},
+					},
+				},
+			},
+			SelfHostedRunnersResults: checker.SelfHostedRunnersData{
+				Jobs: []checker.RunnerJob{
+					{
+						Labels:       []string{"self-hosted"},
+						ForkTriggers: []string{"pull_request"},
+						File:         checker.File{Path: ".github/workflows/ci.yml", Offset: 3},
+						SelfHosted:   true,
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := result.AsRawJSON(&buf); err != nil {
+		t.Fatalf("AsRawJSON: %v", err)
+	}
+	var got jsonScorecardRawResult
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	if got.Date != "2023-03-02" {
+		t.Errorf("date: got %q", got.Date)
+	}
+	if diff := cmp.Diff([]jsonWebhook{
+		{Path: "https://example.com/hook", ID: 12, UsesAuthSecret: true},
+	}, got.Results.Webhooks); diff != "" {
+		t.Errorf("webhooks (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]jsonRevisionCI{
+		{
+			HeadSHA:           "abc",
+			PullRequestNumber: 7,
+			CheckRuns:         []jsonCheckRun{{Status: "completed", Conclusion: "success", App: "github-actions"}},
+			Statuses:          []jsonStatus{},
+		},
+	}, got.Results.CITests); diff != "" {
+		t.Errorf("ciTests (-want +got):\n%s", diff)
+	}
+	if got.Results.ActionsPolicy == nil || got.Results.ActionsPolicy.AllowedActions != "all" {
+		t.Errorf("actionsPolicy: got %+v", got.Results.ActionsPolicy)
+	}
+	if diff := cmp.Diff(jsonDeploymentProtection{
+		Deployments: []jsonDeployment{
+			{
+				Job:         &jsonWorkflowJob{Name: &name, ID: &id},
+				File:        &jsonFile{Path: ".github/workflows/release.yml", Offset: 10},
+				Environment: "production",
+			},
+		},
+	}, got.Results.DeploymentProtection); diff != "" {
+		t.Errorf("deploymentProtection (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(jsonSelfHostedRunners{
+		Jobs: []jsonRunnerJob{
+			{
+				File:         &jsonFile{Path: ".github/workflows/ci.yml", Offset: 3},
+				Labels:       []string{"self-hosted"},
+				ForkTriggers: []string{"pull_request"},
+				SelfHosted:   true,
+			},
+		},
+	}, got.Results.SelfHostedRunners); diff != "" {
+		t.Errorf("selfHostedRunners (-want +got):\n%s", diff)
+	}
+}
+
+func TestAsRawJSONNotApplicable(t *testing.T) {
+	t.Parallel()
+	result := ScorecardResult{
+		Checks: []checker.CheckResult{
+			checker.CreateNotApplicableResult("Fuzzing", "reason"),
+			checker.CreateResultWithScore("License", "reason", 9),
+		},
+	}
+	var buf bytes.Buffer
+	if err := result.AsRawJSON(&buf); err != nil {
+		t.Fatalf("AsRawJSON: %v", err)
+	}
+	var got jsonScorecardRawResult
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if diff := cmp.Diff([]string{"Fuzzing"}, got.NotApplicable); diff != "" {
+		t.Errorf("notApplicable mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/config"
 	"github.com/ossf/scorecard/v4/finding"
 	"github.com/ossf/scorecard/v4/log"
 )
@@ -82,11 +83,12 @@ func TestJSONOutput(t *testing.T) {
 	checkDocs := jsonMockDocRead()
 
 	tests := []struct {
-		name        string
-		expected    string
-		showDetails bool
-		logLevel    log.Level
-		result      ScorecardResult
+		name            string
+		expected        string
+		showDetails     bool
+		showAnnotations bool
+		logLevel        log.Level
+		result          ScorecardResult
 	}{
 		{
 			name:        "check-1",
@@ -413,6 +415,50 @@ func TestJSONOutput(t *testing.T) {
 				Metadata: []string{},
 			},
 		},
+		{
+			name:            "check with annotations",
+			showDetails:     true,
+			showAnnotations: true,
+			expected:        "./testdata/check7.json",
+			logLevel:        log.DebugLevel,
+			result: ScorecardResult{
+				Repo: RepoInfo{
+					Name:      repoName,
+					CommitSHA: repoCommit,
+				},
+				Scorecard: ScorecardInfo{
+					Version:   scorecardVersion,
+					CommitSHA: scorecardCommit,
+				},
+				Date: date,
+				Checks: []checker.CheckResult{
+					{
+						Details: []checker.CheckDetail{
+							{
+								Type: checker.DetailWarn,
+								Msg: checker.LogMessage{
+									Text: "binary detected",
+									Path: "lib/fixture.jar",
+									Type: finding.FileTypeBinary,
+								},
+							},
+						},
+						Score:  9,
+						Reason: "binaries present in source code",
+						Name:   "Check-Name",
+					},
+				},
+				Metadata: []string{},
+				Config: config.Config{
+					Annotations: []config.Annotation{
+						{
+							Checks: []string{"check-name"},
+							Reason: "the .jar files are test fixtures",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	// Load the JSON schema.
@@ -446,7 +492,7 @@ func TestJSONOutput(t *testing.T) {
 			}
 
 			var result bytes.Buffer
-			err = tt.result.AsJSON2(tt.showDetails, tt.logLevel, checkDocs, &result)
+			err = tt.result.AsJSON2(tt.showDetails, tt.showAnnotations, tt.logLevel, checkDocs, &result)
 			if err != nil {
 				t.Fatalf("%s: AsJSON2: %v", tt.name, err)
 			}
@@ -490,3 +536,64 @@ func TestJSONOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONOutputNotApplicable(t *testing.T) {
+	t.Parallel()
+	result := ScorecardResult{
+		Checks: []checker.CheckResult{
+			checker.CreateNotApplicableResult("Check-Name", "reason"),
+			checker.CreateResultWithScore("Check-Name2", "reason", 5),
+		},
+	}
+	tests := []struct {
+		name  string
+		write func(*bytes.Buffer) error
+		// key is the key of the flag in the checks of the format.
+		key string
+	}{
+		{
+			name:  "json v1",
+			write: func(b *bytes.Buffer) error { return result.AsJSON(false, log.DefaultLevel, b) },
+			key:   "NotApplicable",
+		},
+		{
+			name: "json v2",
+			write: func(b *bytes.Buffer) error {
+				return result.AsJSON2(false, false, log.DefaultLevel, jsonMockDocRead(), b)
+			},
+			key: "notApplicable",
+		},
+		{
+			name: "structured json",
+			write: func(b *bytes.Buffer) error {
+				return result.AsSJSON(false, false, log.DefaultLevel, jsonMockDocRead(), b)
+			},
+			key: "notApplicable",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			if err := tt.write(&buf); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			var got struct {
+				Checks []map[string]interface{}
+			}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal: %v", err)
+			}
+			if len(got.Checks) != 2 {
+				t.Fatalf("got %d checks, want 2", len(got.Checks))
+			}
+			if got.Checks[0][tt.key] != true {
+				t.Errorf("check %v not flagged %s", got.Checks[0], tt.key)
+			}
+			if _, ok := got.Checks[1][tt.key]; ok {
+				t.Errorf("check %v flagged %s", got.Checks[1], tt.key)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+)
+
+// MergeStrategy decides between results of a check found in several of the
+// results merged by MergeResults which are based on evidence of the same rank.
+type MergeStrategy int
+
+const (
+	// MergePreferFirst keeps the check result of the earliest merged result.
+	MergePreferFirst MergeStrategy = iota
+	// MergePreferLatest keeps the check result of the most recent run.
+	MergePreferLatest
+	// MergeFailOnConflict fails the merge if the check results differ in score.
+	MergeFailOnConflict
+)
+
+var (
+	// ErrMergeCommitMismatch is returned by MergeResults for results of different commits.
+	ErrMergeCommitMismatch = errors.New("results are for different commits")
+	// ErrMergeConflict is returned by MergeResults for conflicting check results.
+	ErrMergeConflict = errors.New("conflicting check results")
+)
+
+// rawResultMergers copy the raw results of a check between results, so the
+// merged raw results are those of the check results which were kept.
+var rawResultMergers = map[string]func(dst, src *checker.RawResults){
+	checks.CheckActionsPolicy: func(dst, src *checker.RawResults) {
+		dst.ActionsPolicyResults = src.ActionsPolicyResults
+	},
+	checks.CheckBinaryArtifacts: func(dst, src *checker.RawResults) {
+		dst.BinaryArtifactResults = src.BinaryArtifactResults
+	},
+	checks.CheckBranchProtection: func(dst, src *checker.RawResults) {
+		dst.BranchProtectionResults = src.BranchProtectionResults
+	},
+	checks.CheckCIIBestPractices: func(dst, src *checker.RawResults) {
+		dst.CIIBestPracticesResults = src.CIIBestPracticesResults
+	},
+	checks.CheckCITests: func(dst, src *checker.RawResults) {
+		dst.CITestResults = src.CITestResults
+	},
+	checks.CheckCodeReview: func(dst, src *checker.RawResults) {
+		dst.CodeReviewResults = src.CodeReviewResults
+	},
+	checks.CheckCompromisedDependencies: func(dst, src *checker.RawResults) {
+		dst.CompromisedDependenciesResults = src.CompromisedDependenciesResults
+	},
+	checks.CheckContributors: func(dst, src *checker.RawResults) {
+		dst.ContributorsResults = src.ContributorsResults
+	},
+	checks.CheckDangerousWorkflow: func(dst, src *checker.RawResults) {
+		dst.DangerousWorkflowResults = src.DangerousWorkflowResults
+	},
+	checks.CheckDependencyUpdateTool: func(dst, src *checker.RawResults) {
+		dst.DependencyUpdateToolResults = src.DependencyUpdateToolResults
+	},
+	checks.CheckDeploymentProtection: func(dst, src *checker.RawResults) {
+		dst.DeploymentProtectionResults = src.DeploymentProtectionResults
+	},
+	checks.CheckFuzzing: func(dst, src *checker.RawResults) {
+		dst.FuzzingResults = src.FuzzingResults
+	},
+	checks.CheckHiddenCharacters: func(dst, src *checker.RawResults) {
+		dst.HiddenCharactersResults = src.HiddenCharactersResults
+	},
+	checks.CheckLicense: func(dst, src *checker.RawResults) {
+		dst.LicenseResults = src.LicenseResults
+	},
+	checks.CheckMaintained: func(dst, src *checker.RawResults) {
+		dst.MaintainedResults = src.MaintainedResults
+	},
+	checks.CheckPackaging: func(dst, src *checker.RawResults) {
+		dst.PackagingResults = src.PackagingResults
+	},
+	checks.CheckPinnedDependencies: func(dst, src *checker.RawResults) {
+		dst.PinningDependenciesResults = src.PinningDependenciesResults
+	},
+	checks.CheckSecurityPolicy: func(dst, src *checker.RawResults) {
+		dst.SecurityPolicyResults = src.SecurityPolicyResults
+	},
+	checks.CheckSelfHostedRunners: func(dst, src *checker.RawResults) {
+		dst.SelfHostedRunnersResults = src.SelfHostedRunnersResults
+	},
+	checks.CheckSignedReleases: func(dst, src *checker.RawResults) {
+		dst.SignedReleasesResults = src.SignedReleasesResults
+	},
+	checks.CheckTokenPermissions: func(dst, src *checker.RawResults) {
+		dst.TokenPermissionsResults = src.TokenPermissionsResults
+	},
+	checks.CheckVulnerabilities: func(dst, src *checker.RawResults) {
+		dst.VulnerabilitiesResults = src.VulnerabilitiesResults
+	},
+	checks.CheckWebHooks: func(dst, src *checker.RawResults) {
+		dst.WebhookResults = src.WebhookResults
+	},
+}
+
+// MergeResults combines results of the same commit produced by different
+// clients or runs, e.g. the file-based checks of a local checkout and the
+// API-based checks of the GitHub repo, into one result.
+//
+// Results whose commit is known must be for the same commit, otherwise
+// ErrMergeCommitMismatch is returned. The repo information, version and
+// config are those of the first result with a known commit. Each check is
+// taken from the result with the best evidence for it, see
+// MergeMirrorResults; strategy decides between results of the same rank. The
+// raw results and experiments of a check come from the result it was taken
+// from. The merged result is dated after the most recent run, and its
+// metadata is the union of the metadata of all results.
+func MergeResults(results []ScorecardResult, strategy MergeStrategy) (ScorecardResult, error) {
+	if len(results) == 0 {
+		return ScorecardResult{}, nil
+	}
+	primary := 0
+	for i := range results {
+		if knownCommit(results[i].Repo.CommitSHA) {
+			primary = i
+			break
+		}
+	}
+	commit := results[primary].Repo.CommitSHA
+	for i := range results {
+		if c := results[i].Repo.CommitSHA; knownCommit(c) && c != commit {
+			return ScorecardResult{}, fmt.Errorf("%w: %s and %s", ErrMergeCommitMismatch, commit, c)
+		}
+	}
+
+	ret := results[primary]
+	ret.Checks = nil
+	ret.Metadata = nil
+	ret.RawResults.Experiments = nil
+	// from is the index of the result each check of ret was taken from.
+	var from []int
+	index := map[string]int{}
+	metadata := map[string]bool{}
+	for i := range results {
+		r := &results[i]
+		if r.Date.After(ret.Date) {
+			ret.Date = r.Date
+		}
+		if len(ret.Config.Annotations) == 0 && ret.Config.Maintenance == nil {
+			ret.Config = r.Config
+		}
+		if ret.Weights == nil {
+			ret.Weights = r.Weights
+		}
+		if ret.Provenance == nil {
+			ret.Provenance = r.Provenance
+		}
+		for _, m := range r.Metadata {
+			if !metadata[m] {
+				metadata[m] = true
+				ret.Metadata = append(ret.Metadata, m)
+			}
+		}
+		for _, check := range r.Checks {
+			j, ok := index[check.Name]
+			if !ok {
+				index[check.Name] = len(ret.Checks)
+				ret.Checks = append(ret.Checks, check)
+				from = append(from, i)
+				continue
+			}
+			replace, err := replaceCheck(&ret.Checks[j], &check, &results[from[j]], r, strategy)
+			if err != nil {
+				return ScorecardResult{}, err
+			}
+			if replace {
+				ret.Checks[j] = check
+				from[j] = i
+			}
+		}
+	}
+
+	for j := range ret.Checks {
+		src := &results[from[j]].RawResults
+		if merge, ok := rawResultMergers[ret.Checks[j].Name]; ok {
+			merge(&ret.RawResults, src)
+		}
+		for _, e := range src.Experiments {
+			if e.Check == ret.Checks[j].Name {
+				ret.RawResults.Experiments = append(ret.RawResults.Experiments, e)
+			}
+		}
+	}
+	return ret, nil
+}
+
+func knownCommit(commit string) bool {
+	return commit != "" && commit != "unknown"
+}
+
+// replaceCheck returns whether the check result next of result r replaces the
+// check result kept so far, current of result kept.
+func replaceCheck(current, next *checker.CheckResult, kept, r *ScorecardResult,
+	strategy MergeStrategy,
+) (bool, error) {
+	if rank, currentRank := evidenceRank(next), evidenceRank(current); rank != currentRank {
+		return rank > currentRank, nil
+	}
+	switch strategy {
+	case MergePreferLatest:
+		return r.Date.After(kept.Date), nil
+	case MergeFailOnConflict:
+		if next.Score != current.Score {
+			return false, fmt.Errorf("%w: %s scored %d and %d", ErrMergeConflict, next.Name, current.Score, next.Score)
+		}
+		return false, nil
+	default:
+		return false, nil
+	}
+}
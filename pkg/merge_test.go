@@ -0,0 +1,158 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+func TestMergeResults(t *testing.T) {
+	t.Parallel()
+	earlier := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+	local := ScorecardResult{
+		Repo:     RepoInfo{Name: "file:///src/repo", CommitSHA: "unknown"},
+		Date:     earlier,
+		Metadata: []string{"local"},
+		Checks: []checker.CheckResult{
+			checker.CreateMaxScoreResult("Binary-Artifacts", "local"),
+			checker.CreateInconclusiveResult("Branch-Protection", "local"),
+		},
+		RawResults: checker.RawResults{
+			BinaryArtifactResults: checker.BinaryArtifactData{Files: []checker.File{}},
+			Experiments:           []checker.ExperimentResult{{Name: "local", Check: "Binary-Artifacts"}},
+		},
+	}
+	api := ScorecardResult{
+		Repo:     RepoInfo{Name: "github.com/owner/repo", CommitSHA: "abc"},
+		Date:     later,
+		Metadata: []string{"api", "local"},
+		Checks: []checker.CheckResult{
+			checker.CreateInconclusiveResult("Binary-Artifacts", "api"),
+			checker.CreateResultWithScore("Branch-Protection", "api", 5),
+		},
+		RawResults: checker.RawResults{
+			BranchProtectionResults: checker.BranchProtectionsData{
+				Branches: []clients.BranchRef{{}},
+			},
+			Experiments: []checker.ExperimentResult{{Name: "api", Check: "Binary-Artifacts"}},
+		},
+	}
+	tests := []struct {
+		err      error
+		name     string
+		results  []ScorecardResult
+		want     ScorecardResult
+		strategy MergeStrategy
+	}{
+		{
+			name: "no results",
+		},
+		{
+			name:    "split collection",
+			results: []ScorecardResult{local, api},
+			want: ScorecardResult{
+				Repo:     api.Repo,
+				Date:     later,
+				Metadata: []string{"local", "api"},
+				Checks: []checker.CheckResult{
+					local.Checks[0],
+					api.Checks[1],
+				},
+				RawResults: checker.RawResults{
+					BinaryArtifactResults:   local.RawResults.BinaryArtifactResults,
+					BranchProtectionResults: api.RawResults.BranchProtectionResults,
+					Experiments:             local.RawResults.Experiments,
+				},
+			},
+		},
+		{
+			name: "different commits",
+			results: []ScorecardResult{api, {
+				Repo: RepoInfo{Name: "github.com/owner/repo", CommitSHA: "def"},
+			}},
+			err: ErrMergeCommitMismatch,
+		},
+		{
+			name: "prefer first",
+			results: []ScorecardResult{
+				{Date: later, Checks: []checker.CheckResult{checker.CreateMaxScoreResult("Code-Review", "first")}},
+				{Date: earlier, Checks: []checker.CheckResult{checker.CreateMinScoreResult("Code-Review", "second")}},
+			},
+			want: ScorecardResult{
+				Date:   later,
+				Checks: []checker.CheckResult{checker.CreateMaxScoreResult("Code-Review", "first")},
+			},
+		},
+		{
+			name:     "prefer latest",
+			strategy: MergePreferLatest,
+			results: []ScorecardResult{
+				{Date: earlier, Checks: []checker.CheckResult{checker.CreateMaxScoreResult("Code-Review", "first")}},
+				{Date: later, Checks: []checker.CheckResult{checker.CreateMinScoreResult("Code-Review", "second")}},
+			},
+			want: ScorecardResult{
+				Date:   later,
+				Checks: []checker.CheckResult{checker.CreateMinScoreResult("Code-Review", "second")},
+			},
+		},
+		{
+			name:     "conflict",
+			strategy: MergeFailOnConflict,
+			results: []ScorecardResult{
+				{Checks: []checker.CheckResult{checker.CreateMaxScoreResult("Code-Review", "first")}},
+				{Checks: []checker.CheckResult{checker.CreateMinScoreResult("Code-Review", "second")}},
+			},
+			err: ErrMergeConflict,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := MergeResults(tt.results, tt.strategy)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("MergeResults() error = %v, want %v", err, tt.err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("MergeResults() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRawResultMergers(t *testing.T) {
+	t.Parallel()
+	// Every raw result but the experiments belongs to a check and must be merged with it.
+	fields := reflect.TypeOf(checker.RawResults{})
+	want := 0
+	for i := 0; i < fields.NumField(); i++ {
+		if fields.Field(i).Name != "Experiments" {
+			want++
+		}
+	}
+	if len(rawResultMergers) != want {
+		t.Errorf("rawResultMergers merges the raw results of %d checks, want %d", len(rawResultMergers), want)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+// Ranks of the evidence a check result is based on, from the weakest.
+const (
+	evidenceRuntimeError = iota
+	evidenceInconclusive
+	evidenceIncomplete
+	evidenceComplete
+)
+
+// evidenceRank ranks how much of the data a check needed it was scored on.
+func evidenceRank(c *checker.CheckResult) int {
+	switch {
+	case c.Error != nil:
+		return evidenceRuntimeError
+	case c.Score == checker.InconclusiveResultScore:
+		return evidenceInconclusive
+	case c.Incomplete:
+		return evidenceIncomplete
+	default:
+		return evidenceComplete
+	}
+}
+
+// MergeMirrorResults merges the results of scanning the mirrors of a project
+// hosted on several forges, e.g. on GitHub and GitLab. The first result is
+// the primary one the merged result is named after.
+//
+// Each check is taken from the mirror with the best evidence for it, i.e. a
+// check which could not run on the primary repo, say for lack of API support
+// or permissions, is taken from a mirror it completed on. The primary repo is
+// preferred on ties. Checks taken from a mirror record it as their source.
+// Raw results and metadata are those of the primary repo.
+func MergeMirrorResults(results []ScorecardResult) ScorecardResult {
+	if len(results) == 0 {
+		return ScorecardResult{}
+	}
+	ret := results[0]
+	ret.Checks = nil
+	index := map[string]int{}
+	for i := range results {
+		r := &results[i]
+		if i > 0 {
+			ret.Repo.Mirrors = append(ret.Repo.Mirrors, r.Repo.Name)
+		}
+		for _, check := range r.Checks {
+			if i > 0 {
+				check.Source = r.Repo.Name
+			}
+			j, ok := index[check.Name]
+			if !ok {
+				index[check.Name] = len(ret.Checks)
+				ret.Checks = append(ret.Checks, check)
+				continue
+			}
+			if evidenceRank(&check) > evidenceRank(&ret.Checks[j]) {
+				ret.Checks[j] = check
+			}
+		}
+	}
+	return ret
+}
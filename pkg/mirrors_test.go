@@ -0,0 +1,116 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+func TestMergeMirrorResults(t *testing.T) {
+	t.Parallel()
+	//nolint:goerr113
+	errCheck := errors.New("error")
+	incomplete := checker.CreateResultWithScore("Branch-Protection", "incomplete", 8)
+	incomplete.Incomplete = true
+	complete := checker.CreateResultWithScore("Branch-Protection", "complete", 3)
+	tests := []struct {
+		name    string
+		results []ScorecardResult
+		want    ScorecardResult
+	}{
+		{
+			name: "no results",
+		},
+		{
+			name: "no mirrors",
+			results: []ScorecardResult{
+				{
+					Repo:   RepoInfo{Name: "github.com/owner/repo"},
+					Checks: []checker.CheckResult{complete},
+				},
+			},
+			want: ScorecardResult{
+				Repo:   RepoInfo{Name: "github.com/owner/repo"},
+				Checks: []checker.CheckResult{complete},
+			},
+		},
+		{
+			name: "best evidence per check",
+			results: []ScorecardResult{
+				{
+					Repo:     RepoInfo{Name: "github.com/owner/repo"},
+					Metadata: []string{"primary"},
+					Checks: []checker.CheckResult{
+						checker.CreateMaxScoreResult("Code-Review", "primary"),
+						incomplete,
+						checker.CreateRuntimeErrorResult("Packaging", errCheck),
+						checker.CreateInconclusiveResult("CI-Tests", "primary"),
+					},
+				},
+				{
+					Repo:     RepoInfo{Name: "gitlab.com/owner/repo"},
+					Metadata: []string{"mirror"},
+					Checks: []checker.CheckResult{
+						checker.CreateMinScoreResult("Code-Review", "mirror"),
+						complete,
+						checker.CreateInconclusiveResult("Packaging", "mirror"),
+						checker.CreateRuntimeErrorResult("CI-Tests", errCheck),
+					},
+				},
+			},
+			want: ScorecardResult{
+				Repo: RepoInfo{
+					Name:    "github.com/owner/repo",
+					Mirrors: []string{"gitlab.com/owner/repo"},
+				},
+				Metadata: []string{"primary"},
+				Checks: []checker.CheckResult{
+					checker.CreateMaxScoreResult("Code-Review", "primary"),
+					{
+						Name:    "Branch-Protection",
+						Version: complete.Version,
+						Score:   3,
+						Reason:  "complete",
+						Source:  "gitlab.com/owner/repo",
+					},
+					{
+						Name:    "Packaging",
+						Version: complete.Version,
+						Score:   checker.InconclusiveResultScore,
+						Reason:  "mirror",
+						Source:  "gitlab.com/owner/repo",
+					},
+					checker.CreateInconclusiveResult("CI-Tests", "primary"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := MergeMirrorResults(tt.results)
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("MergeMirrorResults() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
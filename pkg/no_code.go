@@ -0,0 +1,41 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/checks/languages"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// MetadataNoCode marks results for a repo with only documentation or
+// configuration, for which the checks in codeChecks are not run.
+const MetadataNoCode = "no-code"
+
+// codeChecks only apply to repos with code. Repos without any would fail
+// them, which users read as false alarms.
+var codeChecks = []string{checks.CheckSAST, checks.CheckFuzzing, checks.CheckCITests}
+
+// noCodeRule skips the checks in codeChecks for repos without code. They are
+// run if the languages of the repo can't be listed.
+var noCodeRule = applicabilityRule{
+	metadata: MetadataNoCode,
+	checks:   codeChecks,
+	reason:   "the repository only contains documentation or configuration",
+	matches: func(repoClient clients.RepoClient) bool {
+		hasCode, err := languages.HasCode(repoClient)
+		return err == nil && !hasCode
+	},
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+)
+
+func Test_noCodeRule(t *testing.T) {
+	t.Parallel()
+	all := checker.CheckNameToFnMap{
+		checks.CheckSAST:    {},
+		checks.CheckLicense: {},
+	}
+	tests := []struct {
+		name        string
+		langs       []clients.Language
+		wantChecks  int
+		wantSkipped int
+	}{
+		{
+			name:       "repo with code",
+			langs:      []clients.Language{{Name: clients.Go, NumLines: 100}},
+			wantChecks: 2,
+		},
+		{
+			name:        "documentation only",
+			langs:       []clients.Language{{Name: "html", NumLines: 100}},
+			wantChecks:  1,
+			wantSkipped: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			repoClient := mockrepo.NewMockRepoClient(ctrl)
+			repoClient.EXPECT().ListProgrammingLanguages().Return(tt.langs, nil)
+			got, skipped := noCodeRule.skip(repoClient, all)
+			if len(got) != tt.wantChecks || len(skipped) != tt.wantSkipped {
+				t.Fatalf("noCodeRule.skip() = %d checks, %d skipped, want %d, %d",
+					len(got), len(skipped), tt.wantChecks, tt.wantSkipped)
+			}
+			for _, r := range skipped {
+				if r.Score != checker.InconclusiveResultScore || r.Name != checks.CheckSAST {
+					t.Errorf("skipped result = %+v", r)
+				}
+			}
+			if len(all) != 2 {
+				t.Error("noCodeRule.skip() modified the checks to run")
+			}
+		})
+	}
+}
+
+func Test_noCodeRule_noCodeChecks(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	// Languages are not listed when no check depends on them.
+	repoClient := mockrepo.NewMockRepoClient(ctrl)
+	checksToRun := checker.CheckNameToFnMap{checks.CheckLicense: {}}
+	if got, skipped := noCodeRule.skip(repoClient, checksToRun); len(got) != 1 || skipped != nil {
+		t.Errorf("noCodeRule.skip() = %v, %v", got, skipped)
+	}
+}
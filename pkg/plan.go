@@ -0,0 +1,252 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/docs/checks"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+	rules "github.com/ossf/scorecard/v4/rule"
+)
+
+// PlanItem is one action of a remediation plan. Identical findings of a check
+// are merged into a single item listing all their locations.
+type PlanItem struct {
+	Check       string
+	Action      string
+	Remediation string
+	Locations   []string
+	// Occurrences is the number of findings merged into the item.
+	Occurrences int
+	// Impact is the increase of the aggregate score if all the items of the
+	// check are addressed.
+	Impact float64
+	Effort rules.RemediationEffort
+}
+
+// Plan lists the actions to improve the results, with the highest score
+// impact and lowest effort first.
+func (r *ScorecardResult) Plan(checkDocs checks.Doc) ([]PlanItem, error) {
+	total := float64(0)
+	for i := range r.Checks {
+		if r.Checks[i].Score < checker.MinResultScore {
+			continue
+		}
+		w, err := r.checkWeight(checkDocs, r.Checks[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		total += w
+	}
+
+	var items []PlanItem
+	for i := range r.Checks {
+		check := &r.Checks[i]
+		if check.Score < checker.MinResultScore || check.Score >= checker.MaxResultScore {
+			continue
+		}
+		doc, err := checkDocs.GetCheck(check.Name)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetCheck: %s: %v", check.Name, err))
+		}
+		w, err := r.checkWeight(checkDocs, check.Name)
+		if err != nil {
+			return nil, err
+		}
+		impact := w * float64(checker.MaxResultScore-check.Score) / total
+
+		checkItems := planItemsFromDetails(check)
+		if len(checkItems) == 0 {
+			// The check did not report individual findings, fall back
+			// to its generic remediation.
+			item := PlanItem{
+				Action:      check.Reason,
+				Occurrences: 1,
+			}
+			if remediation := doc.GetRemediation(); len(remediation) > 0 {
+				item.Remediation = remediation[0]
+			}
+			checkItems = append(checkItems, item)
+		}
+		for j := range checkItems {
+			checkItems[j].Check = check.Name
+			checkItems[j].Impact = impact
+		}
+		items = append(items, checkItems...)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Impact != items[j].Impact {
+			return items[i].Impact > items[j].Impact
+		}
+		if ei, ej := effortRank(items[i].Effort), effortRank(items[j].Effort); ei != ej {
+			return ei < ej
+		}
+		if items[i].Occurrences != items[j].Occurrences {
+			return items[i].Occurrences > items[j].Occurrences
+		}
+		return items[i].Check < items[j].Check
+	})
+	return items, nil
+}
+
+// planItemsFromDetails merges the warnings of a check by message, in the
+// order they were first reported.
+func planItemsFromDetails(check *checker.CheckResult) []PlanItem {
+	var items []PlanItem
+	index := make(map[string]int)
+	for i := range check.Details {
+		d := &check.Details[i]
+		var text, location string
+		var remediation *rules.Remediation
+		if f := d.Msg.Finding; f != nil {
+			if f.Outcome != finding.OutcomeNegative {
+				continue
+			}
+			text = f.Message
+			remediation = f.Remediation
+			if f.Location != nil {
+				location = f.Location.Value
+				if f.Location.LineStart != nil {
+					location = fmt.Sprintf("%s:%d", location, *f.Location.LineStart)
+				}
+			}
+		} else {
+			if d.Type != checker.DetailWarn {
+				continue
+			}
+			text = d.Msg.Text
+			remediation = d.Msg.Remediation
+			location = d.Msg.Path
+			if location != "" && d.Msg.Offset != 0 {
+				location = fmt.Sprintf("%s:%d", location, d.Msg.Offset)
+			}
+		}
+
+		pos, ok := index[text]
+		if !ok {
+			pos = len(items)
+			index[text] = pos
+			items = append(items, PlanItem{Action: text})
+		}
+		item := &items[pos]
+		item.Occurrences++
+		if location != "" {
+			item.Locations = append(item.Locations, location)
+		}
+		if remediation != nil {
+			if item.Remediation == "" {
+				item.Remediation = remediation.Text
+			}
+			if remediation.Effort > item.Effort {
+				item.Effort = remediation.Effort
+			}
+		}
+	}
+	return items
+}
+
+// checkWeight returns the weight of a check in the aggregate score, which
+// depends on its risk unless r.Weights sets it.
+func (r *ScorecardResult) checkWeight(checkDocs checks.Doc, name string) (float64, error) {
+	doc, err := checkDocs.GetCheck(name)
+	if err != nil {
+		return 0, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetCheck: %s: %v", name, err))
+	}
+	if w, ok := r.Weights[doc.GetName()]; ok {
+		return w, nil
+	}
+	w, exists := riskWeights[doc.GetRisk()]
+	if !exists {
+		return 0, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Invalid risk for %s: '%s'", name, doc.GetRisk()))
+	}
+	return w, nil
+}
+
+// effortRank orders efforts from lowest to highest. Items of unknown effort
+// are ranked as medium effort.
+func effortRank(e rules.RemediationEffort) rules.RemediationEffort {
+	if e == rules.RemediationEffortNone {
+		return rules.RemediationEffortMedium
+	}
+	return e
+}
+
+func effortToString(e rules.RemediationEffort) string {
+	switch e {
+	case rules.RemediationEffortLow:
+		return "low"
+	case rules.RemediationEffortMedium:
+		return "medium"
+	case rules.RemediationEffortHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// AsPlan outputs the remediation plan of the ScorecardResult as a numbered
+// TODO list.
+func (r *ScorecardResult) AsPlan(checkDocs checks.Doc, writer io.Writer) error {
+	items, err := r.Plan(checkDocs)
+	if err != nil {
+		return err
+	}
+	score, err := r.GetAggregateScore(checkDocs)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Remediation plan for %s (aggregate score: %s / %d)\n",
+		r.Repo.Name, scoreToString(score), checker.MaxResultScore)
+	if len(items) == 0 {
+		sb.WriteString("\nNothing to do.\n")
+	}
+	var notApplicable []string
+	for i := range r.Checks {
+		if r.Checks[i].NotApplicable {
+			notApplicable = append(notApplicable, r.Checks[i].Name)
+		}
+	}
+	for i := range items {
+		item := &items[i]
+		action := item.Action
+		if item.Occurrences > 1 {
+			action = fmt.Sprintf("%s (%d occurrences)", action, item.Occurrences)
+		}
+		fmt.Fprintf(&sb, "\n%d. [%s] %s\n", i+1, item.Check, action)
+		fmt.Fprintf(&sb, "   impact: up to +%.1f, effort: %s\n", item.Impact, effortToString(item.Effort))
+		for _, l := range item.Locations {
+			fmt.Fprintf(&sb, "   - %s\n", l)
+		}
+		if item.Remediation != "" {
+			fmt.Fprintf(&sb, "   how: %s\n", item.Remediation)
+		}
+	}
+	if len(notApplicable) > 0 {
+		sort.Strings(notApplicable)
+		fmt.Fprintf(&sb, "\nNot applicable, nothing to do: %s\n", strings.Join(notApplicable, ", "))
+	}
+	if _, err := io.WriteString(writer, sb.String()); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("io.WriteString: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pkg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	rules "github.com/ossf/scorecard/v4/rule"
+)
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+	unpinned := func(path string, offset uint) checker.CheckDetail {
+		return checker.CheckDetail{
+			Type: checker.DetailWarn,
+			Msg: checker.LogMessage{
+				Text:   "third-party GitHubAction not pinned by hash",
+				Path:   path,
+				Offset: offset,
+				Remediation: &rules.Remediation{
+					Text:   "pin the action by hash",
+					Effort: rules.RemediationEffortLow,
+				},
+			},
+		}
+	}
+	result := ScorecardResult{
+		Repo: RepoInfo{Name: "org/name"},
+		Checks: []checker.CheckResult{
+			{
+				Name:   "Check-Name",
+				Score:  4,
+				Reason: "dependencies not pinned",
+				Details: []checker.CheckDetail{
+					unpinned(".github/workflows/ci.yml", 12),
+					{
+						Type: checker.DetailInfo,
+						Msg:  checker.LogMessage{Text: "GitHub-owned GitHubAction pinned"},
+					},
+					unpinned(".github/workflows/ci.yml", 30),
+					unpinned(".github/workflows/release.yml", 8),
+					{
+						Type: checker.DetailWarn,
+						Msg: checker.LogMessage{
+							Text: "pipCommand not pinned by hash",
+							Path: "Dockerfile",
+						},
+					},
+				},
+			},
+			{
+				Name:   "Check-Name2",
+				Score:  0,
+				Reason: "no policy found",
+			},
+			{
+				Name:   "Check-Name3",
+				Score:  checker.MaxResultScore,
+				Reason: "all good",
+			},
+		},
+	}
+
+	got, err := result.Plan(jsonMockDocRead())
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	// Weights: High 7.5, Medium 5, Low 2.5.
+	want := []PlanItem{
+		{
+			Check:       "Check-Name2",
+			Action:      "no policy found",
+			Remediation: "not-used1",
+			Occurrences: 1,
+			Impact:      10 * 5 / 15.0,
+		},
+		{
+			Check:       "Check-Name",
+			Action:      "third-party GitHubAction not pinned by hash",
+			Remediation: "pin the action by hash",
+			Locations: []string{
+				".github/workflows/ci.yml:12",
+				".github/workflows/ci.yml:30",
+				".github/workflows/release.yml:8",
+			},
+			Occurrences: 3,
+			Impact:      3,
+			Effort:      rules.RemediationEffortLow,
+		},
+		{
+			Check:       "Check-Name",
+			Action:      "pipCommand not pinned by hash",
+			Locations:   []string{"Dockerfile"},
+			Occurrences: 1,
+			Impact:      3,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Plan() mismatch (-want +got):\n%s", diff)
+	}
+
+	var out bytes.Buffer
+	if err := result.AsPlan(jsonMockDocRead(), &out); err != nil {
+		t.Fatalf("AsPlan: %v", err)
+	}
+	for _, s := range []string{
+		"1. [Check-Name2] no policy found\n",
+		"2. [Check-Name] third-party GitHubAction not pinned by hash (3 occurrences)\n" +
+			"   impact: up to +3.0, effort: low\n",
+		"3. [Check-Name] pipCommand not pinned by hash\n",
+	} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("AsPlan() output does not contain %q:\n%s", s, out.String())
+		}
+	}
+}
+
+func TestAsPlanNotApplicable(t *testing.T) {
+	t.Parallel()
+	result := ScorecardResult{
+		Repo: RepoInfo{Name: "org/name"},
+		Checks: []checker.CheckResult{
+			checker.CreateNotApplicableResult("Check-Name3", "reason"),
+			checker.CreateNotApplicableResult("Check-Name", "reason"),
+			{Name: "Check-Name2", Score: checker.MaxResultScore, Reason: "all good"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := result.AsPlan(jsonMockDocRead(), &buf); err != nil {
+		t.Fatalf("AsPlan: %v", err)
+	}
+	want := "Not applicable, nothing to do: Check-Name, Check-Name3\n"
+	if !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("AsPlan() = %q, want it to end with %q", buf.String(), want)
+	}
+}
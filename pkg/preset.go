@@ -0,0 +1,165 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ossf/scorecard/v4/checks"
+)
+
+var errInvalidPreset = errors.New("invalid preset")
+
+// Preset selects and weights the checks appropriate to a type of artifact,
+// e.g. a Go library or a container image.
+type Preset struct {
+	// Weights replace the risk weights of the checks in the aggregate score.
+	Weights map[string]float64
+	Name    string
+	// Checks are the checks run for the artifact type.
+	Checks []string
+}
+
+// presets are the presets selectable with --preset. Checks which do not
+// apply to the artifact type are left out, e.g. Packaging for Go modules
+// which are published by tagging the repo, and the weights of the checks
+// of the main risks of the artifact type are raised.
+var presets = map[string]Preset{
+	"go-library": {
+		Checks: []string{
+			checks.CheckBinaryArtifacts,
+			checks.CheckBranchProtection,
+			checks.CheckCITests,
+			checks.CheckCIIBestPractices,
+			checks.CheckCodeReview,
+			checks.CheckContributors,
+			checks.CheckDangerousWorkflow,
+			checks.CheckDependencyUpdateTool,
+			checks.CheckFuzzing,
+			checks.CheckLicense,
+			checks.CheckMaintained,
+			checks.CheckPinnedDependencies,
+			checks.CheckSAST,
+			checks.CheckSecurityPolicy,
+			checks.CheckTokenPermissions,
+			checks.CheckVulnerabilities,
+		},
+		Weights: map[string]float64{
+			// Go modules are pinned by go.sum, leaving the workflows.
+			checks.CheckPinnedDependencies: 2.5,
+			// Native fuzzing makes fuzzing libraries cheap.
+			checks.CheckFuzzing:         7.5,
+			checks.CheckVulnerabilities: 10,
+		},
+	},
+	"container-image": {
+		Checks: []string{
+			checks.CheckBinaryArtifacts,
+			checks.CheckBranchProtection,
+			checks.CheckCITests,
+			checks.CheckCodeReview,
+			checks.CheckDangerousWorkflow,
+			checks.CheckDependencyUpdateTool,
+			checks.CheckLicense,
+			checks.CheckMaintained,
+			checks.CheckPackaging,
+			checks.CheckPinnedDependencies,
+			checks.CheckSecurityPolicy,
+			checks.CheckSignedReleases,
+			checks.CheckTokenPermissions,
+			checks.CheckVulnerabilities,
+		},
+		Weights: map[string]float64{
+			// Base images not pinned by digest change under the image.
+			checks.CheckPinnedDependencies:   10,
+			checks.CheckDependencyUpdateTool: 7.5,
+			checks.CheckPackaging:            7.5,
+			checks.CheckSignedReleases:       10,
+		},
+	},
+	"github-action": {
+		Checks: []string{
+			checks.CheckBinaryArtifacts,
+			checks.CheckBranchProtection,
+			checks.CheckCITests,
+			checks.CheckCodeReview,
+			checks.CheckDangerousWorkflow,
+			checks.CheckDependencyUpdateTool,
+			checks.CheckLicense,
+			checks.CheckMaintained,
+			checks.CheckPinnedDependencies,
+			checks.CheckSAST,
+			checks.CheckSecurityPolicy,
+			checks.CheckTokenPermissions,
+			checks.CheckVulnerabilities,
+		},
+		Weights: map[string]float64{
+			// Actions run with the permissions of the workflows using them,
+			// and are consumed from tags anyone with write access can move.
+			checks.CheckBranchProtection:   10,
+			checks.CheckCodeReview:         10,
+			checks.CheckPinnedDependencies: 10,
+			checks.CheckTokenPermissions:   10,
+		},
+	},
+	"npm-package": {
+		Checks: []string{
+			checks.CheckBinaryArtifacts,
+			checks.CheckBranchProtection,
+			checks.CheckCITests,
+			checks.CheckCIIBestPractices,
+			checks.CheckCodeReview,
+			checks.CheckContributors,
+			checks.CheckDangerousWorkflow,
+			checks.CheckDependencyUpdateTool,
+			checks.CheckLicense,
+			checks.CheckMaintained,
+			checks.CheckPackaging,
+			checks.CheckPinnedDependencies,
+			checks.CheckSAST,
+			checks.CheckSecurityPolicy,
+			checks.CheckTokenPermissions,
+			checks.CheckVulnerabilities,
+		},
+		Weights: map[string]float64{
+			// Packages published from a workflow can be traced to their source.
+			checks.CheckPackaging:            10,
+			checks.CheckDependencyUpdateTool: 7.5,
+			checks.CheckVulnerabilities:      10,
+		},
+	},
+}
+
+// PresetNames returns the names of the presets, sorted.
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetPreset returns the preset with the given name.
+func GetPreset(name string) (*Preset, error) {
+	p, ok := presets[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errInvalidPreset, name)
+	}
+	p.Name = name
+	return &p, nil
+}
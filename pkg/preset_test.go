@@ -0,0 +1,90 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+)
+
+func TestPresets(t *testing.T) {
+	t.Parallel()
+	all := checks.GetAll()
+	for _, name := range PresetNames() {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p, err := GetPreset(name)
+			if err != nil {
+				t.Fatalf("GetPreset: %v", err)
+			}
+			if p.Name != name {
+				t.Errorf("Name = %q, want %q", p.Name, name)
+			}
+			selected := map[string]bool{}
+			for _, c := range p.Checks {
+				if _, ok := all[c]; !ok {
+					t.Errorf("preset selects unknown or experimental check %q", c)
+				}
+				selected[c] = true
+			}
+			for c, w := range p.Weights {
+				if !selected[c] {
+					t.Errorf("preset weights check %q it does not select", c)
+				}
+				if w <= 0 {
+					t.Errorf("weight of %q = %v, want a positive weight", c, w)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPresetInvalid(t *testing.T) {
+	t.Parallel()
+	if _, err := GetPreset("rust-crate"); !errors.Is(err, errInvalidPreset) {
+		t.Errorf("GetPreset() error = %v, want %v", err, errInvalidPreset)
+	}
+}
+
+func TestGetAggregateScoreWeights(t *testing.T) {
+	t.Parallel()
+	r := ScorecardResult{
+		Checks: []checker.CheckResult{
+			checker.CreateResultWithScore("Check-Name", "reason", 8),
+			checker.CreateResultWithScore("Check-Name2", "reason", 5),
+		},
+	}
+	got, err := r.GetAggregateScore(jsonMockDocRead())
+	if err != nil {
+		t.Fatalf("GetAggregateScore: %v", err)
+	}
+	// Risk weights: High 7.5, Medium 5.
+	if want := 6.8; got != want {
+		t.Errorf("GetAggregateScore() = %v, want %v", got, want)
+	}
+
+	r.Weights = map[string]float64{"Check-Name2": 15}
+	got, err = r.GetAggregateScore(jsonMockDocRead())
+	if err != nil {
+		t.Fatalf("GetAggregateScore: %v", err)
+	}
+	if want := 6.0; got != want {
+		t.Errorf("GetAggregateScore() with weights = %v, want %v", got, want)
+	}
+}
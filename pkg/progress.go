@@ -0,0 +1,227 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats/view"
+
+	"github.com/ossf/scorecard/v4/stats"
+)
+
+// ProgressFormat is the format of the progress lines written during a scan.
+type ProgressFormat string
+
+const (
+	// ProgressNone writes no progress.
+	ProgressNone ProgressFormat = ""
+	// ProgressPlain writes lines of key=value pairs.
+	ProgressPlain ProgressFormat = "plain"
+	// ProgressJSON writes one JSON object per line.
+	ProgressJSON ProgressFormat = "json"
+)
+
+// defaultProgressInterval is how often progress is written while no check
+// completes, so that long scans don't look hung.
+const defaultProgressInterval = 10 * time.Second
+
+var errInvalidProgressFormat = errors.New("invalid progress format")
+
+// progressRequests counts the HTTP requests of the process for the progress
+// lines.
+var (
+	progressRequests = view.View{
+		Name:        "ProgressHTTPRequests",
+		Description: "HTTP requests reported in the progress of scans",
+		Measure:     stats.HTTPRequests,
+		Aggregation: view.Count(),
+	}
+	registerProgressRequests sync.Once
+)
+
+// ParseProgressFormat validates the format of the progress lines.
+func ParseProgressFormat(s string) (ProgressFormat, error) {
+	switch f := ProgressFormat(s); f {
+	case ProgressNone, ProgressPlain, ProgressJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: %q", errInvalidProgressFormat, s)
+	}
+}
+
+// WithProgress makes RunScorecard write its progress to w: a line each time
+// a check completes, and periodically in between.
+func WithProgress(w io.Writer, format ProgressFormat) Option {
+	return func(c *runConfig) {
+		c.progress = w
+		c.progressFormat = format
+	}
+}
+
+// progressLine is a line of progress in the json format.
+type progressLine struct {
+	Repo            string  `json:"repo"`
+	Check           string  `json:"check,omitempty"`
+	ETASeconds      *int64  `json:"etaSeconds,omitempty"`
+	ChecksCompleted int     `json:"checksCompleted"`
+	ChecksTotal     int     `json:"checksTotal"`
+	APICalls        int64   `json:"apiCalls"`
+	ElapsedSeconds  float64 `json:"elapsedSeconds"`
+	Done            bool    `json:"done"`
+}
+
+// progress writes the progress of the checks run on a repo.
+type progress struct {
+	w            io.Writer
+	started      time.Time
+	stop         chan struct{}
+	format       ProgressFormat
+	repo         string
+	wg           sync.WaitGroup
+	mu           sync.Mutex
+	total        int
+	completed    int
+	baseRequests int64
+}
+
+// startProgress starts writing the progress of total checks run on repo, or
+// returns nil if no progress is written.
+func startProgress(cfg *runConfig, repo string, total int) *progress {
+	if cfg.progress == nil || cfg.progressFormat == ProgressNone {
+		return nil
+	}
+	registerProgressRequests.Do(func() {
+		// Without the view, API calls are reported as 0.
+		//nolint:errcheck
+		view.Register(&progressRequests)
+	})
+	p := &progress{
+		w:            cfg.progress,
+		format:       cfg.progressFormat,
+		repo:         repo,
+		total:        total,
+		started:      time.Now(),
+		stop:         make(chan struct{}),
+		baseRequests: countedRequests(),
+	}
+	p.write("", false)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(cfg.progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.write("", false)
+			}
+		}
+	}()
+	return p
+}
+
+// countedRequests returns the HTTP requests made by the process so far.
+func countedRequests() int64 {
+	rows, err := view.RetrieveData(progressRequests.Name)
+	if err != nil {
+		return 0
+	}
+	var n int64
+	for _, row := range rows {
+		if data, ok := row.Data.(*view.CountData); ok {
+			n += data.Value
+		}
+	}
+	return n
+}
+
+// checkDone records that check completed.
+func (p *progress) checkDone(check string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.completed++
+	p.mu.Unlock()
+	p.write(check, false)
+}
+
+// finish stops the periodic progress and writes the last line.
+func (p *progress) finish() {
+	if p == nil {
+		return
+	}
+	close(p.stop)
+	p.wg.Wait()
+	p.write("", true)
+}
+
+func (p *progress) write(check string, done bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	elapsed := time.Since(p.started)
+	line := progressLine{
+		Repo:            p.repo,
+		Check:           check,
+		ChecksCompleted: p.completed,
+		ChecksTotal:     p.total,
+		APICalls:        countedRequests() - p.baseRequests,
+		ElapsedSeconds:  elapsed.Seconds(),
+		Done:            done,
+	}
+	// The checks run in parallel, so the ETA assumes they take as long as
+	// the ones completed so far.
+	if p.completed > 0 && !done {
+		eta := int64((elapsed * time.Duration(p.total-p.completed) / time.Duration(p.completed)).Seconds())
+		line.ETASeconds = &eta
+	}
+	if p.format == ProgressJSON {
+		if content, err := json.Marshal(line); err == nil {
+			fmt.Fprintf(p.w, "%s\n", content)
+		}
+		return
+	}
+	fmt.Fprintln(p.w, plainProgress(&line))
+}
+
+// plainProgress formats line in the plain format.
+func plainProgress(line *progressLine) string {
+	fields := []string{
+		"progress",
+		"repo=" + line.Repo,
+		fmt.Sprintf("checks=%d/%d", line.ChecksCompleted, line.ChecksTotal),
+		fmt.Sprintf("api_calls=%d", line.APICalls),
+		fmt.Sprintf("elapsed=%s", time.Duration(line.ElapsedSeconds*float64(time.Second)).Round(time.Second)),
+	}
+	if line.ETASeconds != nil {
+		fields = append(fields, fmt.Sprintf("eta=%s", time.Duration(*line.ETASeconds)*time.Second))
+	}
+	if line.Check != "" {
+		fields = append(fields, "check="+line.Check)
+	}
+	if line.Done {
+		fields = append(fields, "done")
+	}
+	return strings.Join(fields, " ")
+}
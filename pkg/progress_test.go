@@ -0,0 +1,98 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseProgressFormat(t *testing.T) {
+	t.Parallel()
+	for _, s := range []string{"", "plain", "json"} {
+		if f, err := ParseProgressFormat(s); err != nil || string(f) != s {
+			t.Errorf("ParseProgressFormat(%q) = %q, %v", s, f, err)
+		}
+	}
+	if _, err := ParseProgressFormat("bar"); !errors.Is(err, errInvalidProgressFormat) {
+		t.Errorf("ParseProgressFormat(bar) error = %v, want %v", err, errInvalidProgressFormat)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	cfg := newRunConfig([]Option{WithProgress(&out, ProgressJSON)})
+	cfg.progressInterval = time.Hour
+	p := startProgress(&cfg, "github.com/owner/repo", 2)
+	p.checkDone("Binary-Artifacts")
+	p.checkDone("License")
+	p.finish()
+
+	var lines []progressLine
+	for _, l := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var line progressLine
+		if err := json.Unmarshal([]byte(l), &line); err != nil {
+			t.Fatalf("json.Unmarshal(%q): %v", l, err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4: %s", len(lines), out.String())
+	}
+	if first := lines[0]; first.Repo != "github.com/owner/repo" || first.ChecksTotal != 2 || first.ETASeconds != nil {
+		t.Errorf("first line = %+v", first)
+	}
+	if second := lines[1]; second.Check != "Binary-Artifacts" || second.ChecksCompleted != 1 || second.ETASeconds == nil {
+		t.Errorf("second line = %+v", second)
+	}
+	if last := lines[3]; !last.Done || last.ChecksCompleted != 2 {
+		t.Errorf("last line = %+v", last)
+	}
+}
+
+func TestProgressDisabled(t *testing.T) {
+	t.Parallel()
+	cfg := newRunConfig(nil)
+	p := startProgress(&cfg, "github.com/owner/repo", 1)
+	if p != nil {
+		t.Fatalf("startProgress() = %v without WithProgress", p)
+	}
+	// A nil progress is a no-op.
+	p.checkDone("License")
+	p.finish()
+}
+
+func Test_plainProgress(t *testing.T) {
+	t.Parallel()
+	eta := int64(90)
+	got := plainProgress(&progressLine{
+		Repo:            "github.com/owner/repo",
+		Check:           "License",
+		ETASeconds:      &eta,
+		ChecksCompleted: 3,
+		ChecksTotal:     18,
+		APICalls:        120,
+		ElapsedSeconds:  12.4,
+	})
+	want := "progress repo=github.com/owner/repo checks=3/18 api_calls=120 elapsed=12s eta=1m30s check=License"
+	if got != want {
+		t.Errorf("plainProgress() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/config"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// Provenance describes how a result was produced, for audit trails and for
+// reproducing the result. It never contains credentials, only their type.
+type Provenance struct {
+	StartedAt        time.Time
+	FinishedAt       time.Time
+	ScorecardVersion string
+	ScorecardCommit  string
+	// ClientType is the type of client the repo was scanned with, e.g.
+	// "github", "gitlab" or "localdir".
+	ClientType string
+	// CredentialType is the type of credentials the client authenticated
+	// with, e.g. "personal-access-token", "github-app" or "none".
+	CredentialType string
+	// PolicyDigest is the digest of the policy file the result was scored
+	// with, if any.
+	PolicyDigest string
+	// ConfigDigest is the digest of the configuration of the run: the checks,
+	// options and repo config which determine the result.
+	ConfigDigest string
+	// Package is the package URL of the package the repo was resolved from,
+	// if any, and RepoConfidence how confident the mapping of the package to
+	// the repo is, see registry.Package.
+	Package        string
+	RepoConfidence string
+}
+
+// WithClientType sets the type of client and credentials recorded in the
+// provenance of results. See checker.ClientType.
+func WithClientType(clientType, credentialType string) Option {
+	return func(c *runConfig) {
+		c.clientType = clientType
+		c.credentialType = credentialType
+	}
+}
+
+// WithPolicyDigest sets the sha256 digest, hex encoded, of the policy file
+// recorded in the provenance of results.
+func WithPolicyDigest(digest string) Option {
+	return func(c *runConfig) {
+		c.policyDigest = digest
+	}
+}
+
+func newProvenance(cfg *runConfig, checksToRun checker.CheckNameToFnMap, commitDepth int,
+	repoConfig *config.Config, startedAt time.Time,
+) *Provenance {
+	p := &Provenance{
+		StartedAt:        startedAt,
+		ScorecardVersion: cfg.version.GitVersion,
+		ScorecardCommit:  cfg.version.GitCommit,
+		ClientType:       cfg.clientType,
+		CredentialType:   cfg.credentialType,
+		ConfigDigest:     configDigest(cfg, checksToRun, commitDepth, repoConfig),
+	}
+	if cfg.policyDigest != "" {
+		p.PolicyDigest = "sha256:" + cfg.policyDigest
+	}
+	if cfg.pkg != nil {
+		p.Package = cfg.pkg.PURL()
+		p.RepoConfidence = cfg.pkg.RepoConfidence
+	}
+	return p
+}
+
+type workflowRuleConfig struct {
+	ID          string
+	Uses        string `json:",omitempty"`
+	Run         string `json:",omitempty"`
+	RequireUses string `json:",omitempty"`
+}
+
+type customProbeConfig struct {
+	ID         string
+	Check      string
+	Files      []string
+	Conditions []string
+	OnMatch    finding.Outcome
+	OnNoMatch  finding.Outcome
+}
+
+func customProbeConfigs(probes []checker.CustomProbe) []customProbeConfig {
+	configs := make([]customProbeConfig, 0, len(probes))
+	for i := range probes {
+		p := &probes[i]
+		c := customProbeConfig{
+			ID:        p.ID,
+			Check:     p.Check,
+			Files:     p.Files,
+			OnMatch:   p.OnMatch,
+			OnNoMatch: p.OnNoMatch,
+		}
+		for j := range p.Conditions {
+			condition := &p.Conditions[j]
+			switch {
+			case condition.Regex != nil:
+				c.Conditions = append(c.Conditions, "regex="+condition.Regex.String())
+			case condition.Value != nil:
+				c.Conditions = append(c.Conditions,
+					"yamlPath="+strings.Join(condition.YAMLPath, ".")+" value="+condition.Value.String())
+			default:
+				c.Conditions = append(c.Conditions, "yamlPath="+strings.Join(condition.YAMLPath, "."))
+			}
+		}
+		configs = append(configs, c)
+	}
+	return configs
+}
+
+// configDigest returns the digest of the inputs of a run other than the
+// repo, its commit and the version of scorecard, which are recorded as is.
+func configDigest(cfg *runConfig, checksToRun checker.CheckNameToFnMap, commitDepth int,
+	repoConfig *config.Config,
+) string {
+	checks := make([]string, 0, len(checksToRun))
+	for name := range checksToRun {
+		checks = append(checks, name)
+	}
+	sort.Strings(checks)
+	rules := make([]workflowRuleConfig, 0, len(cfg.workflowRules))
+	for i := range cfg.workflowRules {
+		r := &cfg.workflowRules[i]
+		rule := workflowRuleConfig{ID: r.ID}
+		if r.Uses != nil {
+			rule.Uses = r.Uses.String()
+		}
+		if r.Run != nil {
+			rule.Run = r.Run.String()
+		}
+		if r.RequireUses != nil {
+			rule.RequireUses = r.RequireUses.String()
+		}
+		rules = append(rules, rule)
+	}
+	// Encoding a struct is deterministic, its fields are written in order.
+	inputs, err := json.Marshal(struct {
+		Checks           []string
+		CommitDepth      int
+		ArchivedPolicy   RepoPolicy
+		ForkPolicy       RepoPolicy
+		QuarantineDays   int `json:",omitempty"`
+		Experiments      []string
+		WorkflowRules    []workflowRuleConfig
+		CustomProbes     []customProbeConfig     `json:",omitempty"`
+		Denylist         []checker.DenylistEntry `json:",omitempty"`
+		VerifyActionPins bool                    `json:",omitempty"`
+		RepoConfig       *config.Config
+	}{
+		Checks:           checks,
+		CommitDepth:      commitDepth,
+		ArchivedPolicy:   cfg.archivedPolicy,
+		ForkPolicy:       cfg.forkPolicy,
+		QuarantineDays:   cfg.quarantineDays,
+		Experiments:      experimentNames(cfg.experiments),
+		WorkflowRules:    rules,
+		CustomProbes:     customProbeConfigs(cfg.customProbes),
+		Denylist:         cfg.denylist,
+		VerifyActionPins: cfg.newActionTags != nil,
+		RepoConfig:       repoConfig,
+	})
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(inputs)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/clients/registry"
+	"github.com/ossf/scorecard/v4/config"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+func Test_configDigest(t *testing.T) {
+	t.Parallel()
+	checksToRun := checker.CheckNameToFnMap{checks.CheckLicense: {}, checks.CheckSAST: {}}
+	cfg := newRunConfig(nil)
+	digest := configDigest(&cfg, checksToRun, 30, &config.Config{})
+	if !strings.HasPrefix(digest, "sha256:") {
+		t.Fatalf("configDigest() = %q, want a sha256 digest", digest)
+	}
+	if again := configDigest(&cfg, checksToRun, 30, &config.Config{}); again != digest {
+		t.Errorf("configDigest() = %q, then %q", digest, again)
+	}
+	if other := configDigest(&cfg, checksToRun, 10, &config.Config{}); other == digest {
+		t.Error("configDigest() does not depend on the commit depth")
+	}
+	rules := newRunConfig([]Option{WithWorkflowRules([]checker.WorkflowRule{
+		{ID: "no-curl", Run: regexp.MustCompile("curl")},
+	})})
+	if other := configDigest(&rules, checksToRun, 30, &config.Config{}); other == digest {
+		t.Error("configDigest() does not depend on the workflow rules")
+	}
+	probes := newRunConfig([]Option{WithCustomProbes([]checker.CustomProbe{
+		{ID: "codeowners", Check: checks.CheckCodeReview, Files: []string{"CODEOWNERS"}},
+	})})
+	if other := configDigest(&probes, checksToRun, 30, &config.Config{}); other == digest {
+		t.Error("configDigest() does not depend on the custom probes")
+	}
+}
+
+func Test_newProvenance(t *testing.T) {
+	t.Parallel()
+	cfg := newRunConfig([]Option{
+		WithClientType("github", "personal-access-token"),
+		WithPolicyDigest("abc"),
+		WithPackage(&registry.Package{
+			Ecosystem:      registry.NPM,
+			Name:           "left-pad",
+			LatestVersion:  "1.3.0",
+			RepoConfidence: registry.ConfidenceHigh,
+		}),
+	})
+	startedAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	p := newProvenance(&cfg, checker.CheckNameToFnMap{}, 30, &config.Config{}, startedAt)
+	p.FinishedAt = startedAt.Add(time.Minute)
+	if p.PolicyDigest != "sha256:abc" || p.ClientType != "github" ||
+		p.CredentialType != "personal-access-token" || p.ConfigDigest == "" {
+		t.Fatalf("newProvenance() = %+v", p)
+	}
+
+	result := ScorecardResult{Provenance: p}
+	var buf bytes.Buffer
+	if err := result.AsJSON2(false, false, log.DefaultLevel, nil, &buf); err != nil {
+		t.Fatalf("AsJSON2: %v", err)
+	}
+	var got struct {
+		Provenance jsonProvenance `json:"provenance"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	want := jsonProvenance{
+		StartedAt:      "2023-01-02T03:04:05Z",
+		FinishedAt:     "2023-01-02T03:05:05Z",
+		Scorecard:      jsonScorecardV2{Version: p.ScorecardVersion, Commit: p.ScorecardCommit},
+		Client:         "github",
+		CredentialType: "personal-access-token",
+		PolicyDigest:   "sha256:abc",
+		ConfigDigest:   p.ConfigDigest,
+		Package:        "pkg:npm/left-pad@1.3.0",
+		RepoConfidence: "high",
+	}
+	if got.Provenance != want {
+		t.Errorf("provenance = %+v, want %+v", got.Provenance, want)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// MetadataQuarantined prefixes the comma-separated reasons a young repo is
+// quarantined for, see WithQuarantine.
+const MetadataQuarantined = "quarantined="
+
+// Reasons recorded in the MetadataQuarantined annotation.
+const (
+	quarantineYoung           = "young"
+	quarantineFewContributors = "few-contributors"
+	quarantineSquattingName   = "squatting-name"
+)
+
+// quarantineMinContributors is the number of contributors below which a
+// young repo is likely the work of a single, possibly throwaway, account.
+const quarantineMinContributors = 2
+
+// squattingNameWords are words package squatters append to the names of
+// popular projects, e.g. "requests-official" or "lodash-fixed".
+var squattingNameWords = map[string]bool{
+	"cracked":  true,
+	"fixed":    true,
+	"free":     true,
+	"hacked":   true,
+	"official": true,
+	"original": true,
+	"patched":  true,
+	"premium":  true,
+	"real":     true,
+	"secure":   true,
+	"unlocked": true,
+	"updated":  true,
+}
+
+// WithQuarantine quarantines repos created fewer than days ago, which either
+// have fewer than two contributors or have a squatting-style name. The
+// aggregate score of a quarantined repo is the minimum score whatever its
+// check scores, and its result metadata records why it is quarantined.
+// Repos are not quarantined by default.
+func WithQuarantine(days int) Option {
+	return func(c *runConfig) {
+		c.quarantineDays = days
+	}
+}
+
+// quarantineReasons returns why the repo should be quarantined, or nil if it
+// should not.
+func quarantineReasons(cfg *runConfig, repo clients.Repo, repoClient clients.RepoClient) ([]string, error) {
+	if cfg.quarantineDays <= 0 {
+		return nil, nil
+	}
+	createdAt, err := repoClient.GetCreatedAt()
+	switch {
+	case errors.Is(err, clients.ErrUnsupportedFeature):
+		return nil, nil
+	case err != nil:
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetCreatedAt: %v", err))
+	case time.Since(createdAt) >= time.Duration(cfg.quarantineDays)*24*time.Hour:
+		return nil, nil
+	}
+
+	reasons := []string{quarantineYoung}
+	contributors, err := repoClient.ListContributors()
+	switch {
+	case errors.Is(err, clients.ErrUnsupportedFeature):
+	case err != nil:
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListContributors: %v", err))
+	case len(contributors) < quarantineMinContributors:
+		reasons = append(reasons, quarantineFewContributors)
+	}
+	if isSquattingName(path.Base(repo.URI())) {
+		reasons = append(reasons, quarantineSquattingName)
+	}
+	if len(reasons) == 1 {
+		return nil, nil
+	}
+	return reasons, nil
+}
+
+// isSquattingName returns true if name looks like the name of a popular
+// project with a word appended to lure users into taking it for the real one.
+func isSquattingName(name string) bool {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	})
+	// A name made of a single squatting word, e.g. "official", is not
+	// impersonating another project.
+	if len(words) < 2 {
+		return false
+	}
+	return squattingNameWords[words[len(words)-1]]
+}
+
+// addQuarantine records in the metadata of the result whether the repo is
+// quarantined. It is run on cached results too, which are cached without it:
+// the verdict depends on the age of the repo at the time of the scan rather
+// than on its commit.
+func addQuarantine(cfg *runConfig, repo clients.Repo, repoClient clients.RepoClient, r *ScorecardResult) error {
+	reasons, err := quarantineReasons(cfg, repo, repoClient)
+	if err != nil {
+		return err
+	}
+	metadata := make([]string, 0, len(r.Metadata)+1)
+	for _, m := range r.Metadata {
+		if !strings.HasPrefix(m, MetadataQuarantined) {
+			metadata = append(metadata, m)
+		}
+	}
+	if len(reasons) > 0 {
+		metadata = append(metadata, MetadataQuarantined+strings.Join(reasons, ","))
+	}
+	r.Metadata = metadata
+	return nil
+}
+
+// isQuarantined returns true if the metadata marks the repo as quarantined.
+func isQuarantined(metadata []string) bool {
+	for _, m := range metadata {
+		if strings.HasPrefix(m, MetadataQuarantined) {
+			return true
+		}
+	}
+	return false
+}
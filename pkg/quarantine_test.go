@@ -0,0 +1,148 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/githubrepo"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+func Test_quarantineReasons(t *testing.T) {
+	t.Parallel()
+	const day = 24 * time.Hour
+	tests := []struct {
+		createdErr   error
+		createdAt    time.Time
+		name         string
+		repo         string
+		contributors []clients.User
+		want         []string
+		wantErr      error
+	}{
+		{
+			name:      "old repo",
+			repo:      "owner/requests-official",
+			createdAt: time.Now().Add(-60 * day),
+		},
+		{
+			name:         "young repo with contributors",
+			repo:         "owner/project",
+			createdAt:    time.Now().Add(-day),
+			contributors: []clients.User{{Login: "a"}, {Login: "b"}},
+		},
+		{
+			name:         "young repo with a single contributor",
+			repo:         "owner/project",
+			createdAt:    time.Now().Add(-day),
+			contributors: []clients.User{{Login: "a"}},
+			want:         []string{quarantineYoung, quarantineFewContributors},
+		},
+		{
+			name:         "young repo with a squatting name",
+			repo:         "owner/requests-official",
+			createdAt:    time.Now().Add(-day),
+			contributors: []clients.User{{Login: "a"}, {Login: "b"}},
+			want:         []string{quarantineYoung, quarantineSquattingName},
+		},
+		{
+			name:       "creation date not supported",
+			repo:       "owner/project",
+			createdErr: clients.ErrUnsupportedFeature,
+		},
+		{
+			name:       "creation date error",
+			repo:       "owner/project",
+			createdErr: errors.New("boom"),
+			wantErr:    sce.ErrScorecardInternal,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			repoClient := mockrepo.NewMockRepoClient(ctrl)
+			repoClient.EXPECT().GetCreatedAt().Return(tt.createdAt, tt.createdErr)
+			repoClient.EXPECT().ListContributors().Return(tt.contributors, nil).AnyTimes()
+			repo, err := githubrepo.MakeGithubRepo(tt.repo)
+			if err != nil {
+				t.Fatalf("MakeGithubRepo: %v", err)
+			}
+			cfg := newRunConfig([]Option{WithQuarantine(30)})
+			got, err := quarantineReasons(&cfg, repo, repoClient)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("quarantineReasons() error = %v, want %v", err, tt.wantErr)
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("quarantineReasons() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_quarantineReasons_disabled(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	// The creation date is not fetched unless quarantine is enabled.
+	repoClient := mockrepo.NewMockRepoClient(ctrl)
+	cfg := newRunConfig(nil)
+	if got, err := quarantineReasons(&cfg, nil, repoClient); got != nil || err != nil {
+		t.Errorf("quarantineReasons() = %v, %v", got, err)
+	}
+}
+
+func Test_isSquattingName(t *testing.T) {
+	t.Parallel()
+	tests := map[string]bool{
+		"requests-official": true,
+		"Lodash_Official":   true,
+		"lodash.fixed":      true,
+		"official":          false,
+		"free-software-x":   false,
+		"scorecard":         false,
+	}
+	for name, want := range tests {
+		if got := isSquattingName(name); got != want {
+			t.Errorf("isSquattingName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestGetAggregateScoreQuarantined(t *testing.T) {
+	t.Parallel()
+	r := ScorecardResult{
+		Checks: []checker.CheckResult{
+			checker.CreateResultWithScore("Check-Name", "reason", 10),
+		},
+		Metadata: []string{MetadataQuarantined + quarantineYoung + "," + quarantineFewContributors},
+	}
+	got, err := r.GetAggregateScore(jsonMockDocRead())
+	if err != nil {
+		t.Fatalf("GetAggregateScore: %v", err)
+	}
+	if got != checker.MinResultScore {
+		t.Errorf("GetAggregateScore() = %v, want %v", got, checker.MinResultScore)
+	}
+}
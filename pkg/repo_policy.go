@@ -0,0 +1,238 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"sigs.k8s.io/release-utils/version"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/registry"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+// RepoPolicy controls how RunScorecard handles archived or forked repos.
+type RepoPolicy string
+
+const (
+	// RepoPolicyScore scores the repo as usual and annotates the result metadata.
+	RepoPolicyScore RepoPolicy = "score"
+	// RepoPolicySkip skips running checks, only the result metadata is populated.
+	RepoPolicySkip RepoPolicy = "skip"
+	// RepoPolicyParent scores the repo a fork was created from instead of the fork.
+	// Only valid for forks.
+	RepoPolicyParent RepoPolicy = "parent"
+)
+
+// Metadata annotations added to ScorecardResult.Metadata by the repo policies.
+const (
+	// MetadataArchived marks results for an archived repo.
+	MetadataArchived = "archived"
+	// MetadataForkOf prefixes the name of the parent repo for results of a fork.
+	MetadataForkOf = "fork-of="
+	// MetadataSkipped prefixes the reason checks were not run.
+	MetadataSkipped = "skipped="
+)
+
+var errInvalidRepoPolicy = errors.New("invalid repo policy")
+
+// ParseArchivedPolicy validates the policy to use for archived repos.
+func ParseArchivedPolicy(s string) (RepoPolicy, error) {
+	switch p := RepoPolicy(s); p {
+	case RepoPolicyScore, RepoPolicySkip:
+		return p, nil
+	default:
+		return "", fmt.Errorf("%w for archived repos: %q", errInvalidRepoPolicy, s)
+	}
+}
+
+// ParseForkPolicy validates the policy to use for forked repos.
+func ParseForkPolicy(s string) (RepoPolicy, error) {
+	switch p := RepoPolicy(s); p {
+	case RepoPolicyScore, RepoPolicySkip, RepoPolicyParent:
+		return p, nil
+	default:
+		return "", fmt.Errorf("%w for forked repos: %q", errInvalidRepoPolicy, s)
+	}
+}
+
+// Option configures optional behavior of RunScorecard.
+type Option func(*runConfig)
+
+type runConfig struct {
+	cache          ResultCache
+	version        version.Info
+	archivedPolicy RepoPolicy
+	forkPolicy     RepoPolicy
+	// quarantineDays is the age in days below which repos are considered
+	// for quarantine, or 0.
+	quarantineDays int
+	policyHash     string
+	workflowRules  []checker.WorkflowRule
+	customProbes   []checker.CustomProbe
+	denylist       []checker.DenylistEntry
+	// newActionTags returns the client verifying the pins of actions of a
+	// scan, if enabled.
+	newActionTags func() clients.ActionTagsClient
+	experiments   checker.ExperimentNameToFnMap
+	// pkg is the package the repo was resolved from, if any.
+	pkg *registry.Package
+	// progress is where the progress of the scan is written, if anywhere.
+	progress         io.Writer
+	progressFormat   ProgressFormat
+	progressInterval time.Duration
+	// clientType, credentialType and policyDigest are recorded in the
+	// provenance of results.
+	clientType     string
+	credentialType string
+	policyDigest   string
+	// logger logs the errors which do not fail the scan.
+	logger *log.Logger
+}
+
+func newRunConfig(opts []Option) runConfig {
+	cfg := runConfig{
+		archivedPolicy: RepoPolicyScore,
+		forkPolicy:     RepoPolicyScore,
+		version:        version.GetVersionInfo(),
+		experiments:    checks.GetExperiments(),
+		// Overridden by tests.
+		progressInterval: defaultProgressInterval,
+		logger:           log.NewLogger(log.DefaultLevel),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithArchivedPolicy sets how archived repos are handled. Defaults to RepoPolicyScore.
+func WithArchivedPolicy(p RepoPolicy) Option {
+	return func(c *runConfig) {
+		c.archivedPolicy = p
+	}
+}
+
+// WithForkPolicy sets how forked repos are handled. Defaults to RepoPolicyScore.
+func WithForkPolicy(p RepoPolicy) Option {
+	return func(c *runConfig) {
+		c.forkPolicy = p
+	}
+}
+
+// WithWorkflowRules sets the custom rules Dangerous-Workflow checks
+// workflows against, see config.ParseWorkflowRules.
+func WithWorkflowRules(rules []checker.WorkflowRule) Option {
+	return func(c *runConfig) {
+		c.workflowRules = rules
+	}
+}
+
+// WithDenylist sets the dependencies known to be malicious or compromised
+// Compromised-Dependencies checks the repo against, see config.ParseDenylist.
+func WithDenylist(entries []checker.DenylistEntry) Option {
+	return func(c *runConfig) {
+		c.denylist = entries
+	}
+}
+
+// WithCustomProbes sets the probes defined by users which are run after
+// the checks they name, see config.ParseCustomProbes.
+func WithCustomProbes(probes []checker.CustomProbe) Option {
+	return func(c *runConfig) {
+		c.customProbes = probes
+	}
+}
+
+// WithActionTags enables the verification of the commits GitHub Actions are
+// pinned to against the tags of their repos by Pinned-Dependencies, with the
+// client newClient returns for each scan, e.g. one capping its requests.
+func WithActionTags(newClient func() clients.ActionTagsClient) Option {
+	return func(c *runConfig) {
+		c.newActionTags = newClient
+	}
+}
+
+// WithLogger sets the logger of the errors which do not fail the scan.
+// Defaults to a logger at log.DefaultLevel.
+func WithLogger(l *log.Logger) Option {
+	return func(c *runConfig) {
+		c.logger = l
+	}
+}
+
+// WithPackage sets the registry metadata of the package whose source repo
+// is scanned, which Packaging reports.
+func WithPackage(p *registry.Package) Option {
+	return func(c *runConfig) {
+		c.pkg = p
+	}
+}
+
+// repoPolicyResult describes the outcome of applying the repo policies.
+type repoPolicyResult struct {
+	// parent is set when the parent of a fork should be scored instead.
+	parent   clients.Repo
+	metadata []string
+	skip     bool
+}
+
+func applyRepoPolicies(cfg runConfig, repoClient clients.RepoClient) (repoPolicyResult, error) {
+	var ret repoPolicyResult
+
+	archived, err := repoClient.IsArchived()
+	switch {
+	case errors.Is(err, clients.ErrUnsupportedFeature):
+	case err != nil:
+		// Only fail the run if the policy depends on the answer.
+		if cfg.archivedPolicy != RepoPolicyScore {
+			return ret, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("IsArchived: %v", err))
+		}
+	case archived:
+		ret.metadata = append(ret.metadata, MetadataArchived)
+		if cfg.archivedPolicy == RepoPolicySkip {
+			ret.metadata = append(ret.metadata, MetadataSkipped+"archived")
+			ret.skip = true
+			return ret, nil
+		}
+	}
+
+	parent, err := repoClient.GetForkParent()
+	switch {
+	case errors.Is(err, clients.ErrUnsupportedFeature):
+	case err != nil:
+		if cfg.forkPolicy != RepoPolicyScore {
+			return ret, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetForkParent: %v", err))
+		}
+	case parent != nil:
+		ret.metadata = append(ret.metadata, MetadataForkOf+parent.URI())
+		switch cfg.forkPolicy {
+		case RepoPolicySkip:
+			ret.metadata = append(ret.metadata, MetadataSkipped+"fork")
+			ret.skip = true
+		case RepoPolicyParent:
+			ret.parent = parent
+		case RepoPolicyScore:
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+var errTest = errors.New("test error")
+
+func Test_applyRepoPolicies(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		opts         []Option
+		archived     bool
+		archivedErr  error
+		isFork       bool
+		forkErr      error
+		wantMetadata []string
+		wantSkip     bool
+		wantParent   bool
+		wantErr      error
+	}{
+		{
+			name: "regular repo",
+		},
+		{
+			name:         "archived repo scored by default",
+			archived:     true,
+			wantMetadata: []string{MetadataArchived},
+		},
+		{
+			name:         "archived repo skipped",
+			opts:         []Option{WithArchivedPolicy(RepoPolicySkip)},
+			archived:     true,
+			wantMetadata: []string{MetadataArchived, MetadataSkipped + "archived"},
+			wantSkip:     true,
+		},
+		{
+			name:         "fork scored by default",
+			isFork:       true,
+			wantMetadata: []string{MetadataForkOf + "github.com/ossf/scorecard"},
+		},
+		{
+			name:         "fork skipped",
+			opts:         []Option{WithForkPolicy(RepoPolicySkip)},
+			isFork:       true,
+			wantMetadata: []string{MetadataForkOf + "github.com/ossf/scorecard", MetadataSkipped + "fork"},
+			wantSkip:     true,
+		},
+		{
+			name:         "fork parent scored",
+			opts:         []Option{WithForkPolicy(RepoPolicyParent)},
+			isFork:       true,
+			wantMetadata: []string{MetadataForkOf + "github.com/ossf/scorecard"},
+			wantParent:   true,
+		},
+		{
+			name:        "unsupported features are ignored",
+			opts:        []Option{WithArchivedPolicy(RepoPolicySkip), WithForkPolicy(RepoPolicySkip)},
+			archivedErr: fmt.Errorf("IsArchived: %w", clients.ErrUnsupportedFeature),
+			forkErr:     fmt.Errorf("GetForkParent: %w", clients.ErrUnsupportedFeature),
+		},
+		{
+			name:        "errors ignored when scoring",
+			archivedErr: errTest,
+			forkErr:     errTest,
+		},
+		{
+			name:        "archived error with skip policy",
+			opts:        []Option{WithArchivedPolicy(RepoPolicySkip)},
+			archivedErr: errTest,
+			wantErr:     sce.ErrScorecardInternal,
+		},
+		{
+			name:    "fork error with parent policy",
+			opts:    []Option{WithForkPolicy(RepoPolicyParent)},
+			forkErr: errTest,
+			wantErr: sce.ErrScorecardInternal,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			parent := mockrepo.NewMockRepo(ctrl)
+			parent.EXPECT().URI().Return("github.com/ossf/scorecard").AnyTimes()
+
+			mockRepoClient.EXPECT().IsArchived().Return(tt.archived, tt.archivedErr)
+			mockRepoClient.EXPECT().GetForkParent().DoAndReturn(func() (clients.Repo, error) {
+				if tt.isFork {
+					return parent, nil
+				}
+				return nil, tt.forkErr
+			}).AnyTimes()
+
+			got, err := applyRepoPolicies(newRunConfig(tt.opts), mockRepoClient)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("applyRepoPolicies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.wantMetadata, got.metadata); diff != "" {
+				t.Errorf("metadata mismatch (-want +got):\n%s", diff)
+			}
+			if got.skip != tt.wantSkip {
+				t.Errorf("skip = %v, want %v", got.skip, tt.wantSkip)
+			}
+			if (got.parent != nil) != tt.wantParent {
+				t.Errorf("parent = %v, wantParent %v", got.parent, tt.wantParent)
+			}
+		})
+	}
+}
+
+func TestParseRepoPolicies(t *testing.T) {
+	t.Parallel()
+	if _, err := ParseArchivedPolicy(string(RepoPolicyParent)); !errors.Is(err, errInvalidRepoPolicy) {
+		t.Errorf("ParseArchivedPolicy(parent) error = %v, want %v", err, errInvalidRepoPolicy)
+	}
+	if _, err := ParseForkPolicy("unknown"); !errors.Is(err, errInvalidRepoPolicy) {
+		t.Errorf("ParseForkPolicy(unknown) error = %v, want %v", err, errInvalidRepoPolicy)
+	}
+	if p, err := ParseForkPolicy(string(RepoPolicyParent)); err != nil || p != RepoPolicyParent {
+		t.Errorf("ParseForkPolicy(parent) = %v, %v", p, err)
+	}
+}
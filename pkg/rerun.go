@@ -0,0 +1,670 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/release-utils/version"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/checks/evaluation"
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/registry"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// rerunFunc converts the raw results of a check back from their JSON
+// representation, stores them in raw and evaluates them.
+type rerunFunc func(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults) checker.CheckResult
+
+// rerunChecks lists the checks whose raw results are exported by the raw
+// format with everything their evaluation needs. Checks such as Maintained
+// or Branch-Protection evaluate data the raw format does not include yet.
+var rerunChecks = map[string]rerunFunc{
+	checks.CheckActionsPolicy:           rerunActionsPolicy,
+	checks.CheckBinaryArtifacts:         rerunBinaryArtifacts,
+	checks.CheckCIIBestPractices:        rerunCIIBestPractices,
+	checks.CheckCITests:                 rerunCITests,
+	checks.CheckCodeReview:              rerunCodeReview,
+	checks.CheckCompromisedDependencies: rerunCompromisedDependencies,
+	checks.CheckContributors:            rerunContributors,
+	checks.CheckDangerousWorkflow:       rerunDangerousWorkflow,
+	checks.CheckDependencyUpdateTool:    rerunDependencyUpdateTool,
+	checks.CheckDeploymentProtection:    rerunDeploymentProtection,
+	checks.CheckFuzzing:                 rerunFuzzing,
+	checks.CheckHiddenCharacters:        rerunHiddenCharacters,
+	checks.CheckLicense:                 rerunLicense,
+	checks.CheckPackaging:               rerunPackaging,
+	checks.CheckSecurityPolicy:          rerunSecurityPolicy,
+	checks.CheckSelfHostedRunners:       rerunSelfHostedRunners,
+	checks.CheckSignedReleases:          rerunSignedReleases,
+	checks.CheckWebHooks:                rerunWebhooks,
+}
+
+// RerunCheck evaluates checkName again from the raw results written by a
+// previous run with --format=raw, without collecting any data from the repo.
+// The returned result contains the repo information of the previous run, the
+// new result of the check and its raw results.
+func RerunCheck(checkName string, reader io.Reader) (ScorecardResult, error) {
+	var name string
+	var rerun rerunFunc
+	for n, f := range rerunChecks {
+		if strings.EqualFold(n, checkName) {
+			name, rerun = n, f
+			break
+		}
+	}
+	if rerun == nil {
+		return ScorecardResult{}, sce.WithMessage(sce.ErrorUnsupportedCheck,
+			fmt.Sprintf("check %s cannot be evaluated from raw results", checkName))
+	}
+
+	var in jsonScorecardRawResult
+	if err := json.NewDecoder(reader).Decode(&in); err != nil {
+		return ScorecardResult{}, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Decode: %v", err))
+	}
+
+	ret := ScorecardResult{
+		Repo: RepoInfo{
+			Name:          in.Repo.Name,
+			CommitSHA:     in.Repo.Commit,
+			RequestedName: in.Repo.RequestedName,
+			Mirrors:       in.Repo.Mirrors,
+		},
+		Scorecard: ScorecardInfo{
+			Version:   in.Scorecard.Version,
+			CommitSHA: in.Scorecard.Commit,
+		},
+		Metadata: in.Metadata,
+	}
+	if in.Repo.Commits != nil {
+		ret.Repo.Commits = &CommitWindow{
+			Newest: in.Repo.Commits.Newest,
+			Oldest: in.Repo.Commits.Oldest,
+			Depth:  in.Repo.Commits.Depth,
+			Count:  in.Repo.Commits.Count,
+		}
+	}
+	if in.Date != "" {
+		date, err := time.Parse("2006-01-02", in.Date)
+		if err != nil {
+			return ScorecardResult{}, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("time.Parse: %v", err))
+		}
+		ret.Date = date
+	}
+
+	dl := checker.NewLogger()
+	res := rerun(name, dl, &in.Results, &ret.RawResults)
+	res.Details = dl.Flush()
+	allChecks := checks.GetAllWithExperimental()
+	if check, ok := allChecks[name]; ok {
+		res.Algorithm = check.Metadata.AlgorithmVersion()
+	}
+	ret.Checks = []checker.CheckResult{res}
+	addPreviousScores(allChecks, ret.Checks, &ret.RawResults, version.GetVersionInfo().GitVersion)
+	return ret, nil
+}
+
+func fromJSONFile(f *jsonFile, fileType finding.FileType) checker.File {
+	if f == nil {
+		return checker.File{}
+	}
+	ret := checker.File{
+		Path:      f.Path,
+		Offset:    f.Offset,
+		EndOffset: f.EndOffset,
+		Type:      fileType,
+	}
+	if f.Snippet != nil {
+		ret.Snippet = *f.Snippet
+	}
+	return ret
+}
+
+func fromJSONFiles(files []jsonFile, fileType finding.FileType) []checker.File {
+	var ret []checker.File
+	for i := range files {
+		ret = append(ret, fromJSONFile(&files[i], fileType))
+	}
+	return ret
+}
+
+func fromJSONWorkflowJob(j *jsonWorkflowJob) *checker.WorkflowJob {
+	if j == nil {
+		return nil
+	}
+	return &checker.WorkflowJob{
+		Name: j.Name,
+		ID:   j.ID,
+	}
+}
+
+func fromJSONTools(tools []jsonTool) []checker.Tool {
+	var ret []checker.Tool
+	for i := range tools {
+		ret = append(ret, checker.Tool{
+			Name:  tools[i].Name,
+			URL:   tools[i].URL,
+			Desc:  tools[i].Desc,
+			Files: fromJSONFiles(tools[i].Files, finding.FileTypeSource),
+		})
+	}
+	return ret
+}
+
+func fromJSONUser(u *jsonUser) clients.User {
+	return clients.User{
+		Login: u.Login,
+		IsBot: u.IsBot,
+	}
+}
+
+func rerunActionsPolicy(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	if p := r.ActionsPolicy; p != nil {
+		raw.ActionsPolicyResults.Policy = &clients.ActionsPolicy{
+			AllowedActions:     clients.AllowedActions(p.AllowedActions),
+			PatternsAllowed:    p.PatternsAllowed,
+			GithubOwnedAllowed: p.GithubOwnedAllowed,
+			VerifiedAllowed:    p.VerifiedAllowed,
+			ForkPRApproval:     clients.ForkPRApproval(p.ForkPRApproval),
+			Enabled:            p.Enabled,
+		}
+	}
+	return evaluation.ActionsPolicy(name, dl, &raw.ActionsPolicyResults)
+}
+
+func rerunBinaryArtifacts(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	raw.BinaryArtifactResults.Files = fromJSONFiles(r.Binaries, finding.FileTypeBinary)
+	raw.BinaryArtifactResults.LFSFiles = fromJSONFiles(r.LFSBinaries, finding.FileTypeBinary)
+	raw.BinaryArtifactResults.ReleaseFiles = fromJSONFiles(r.ReleaseBinaries, finding.FileTypeBinary)
+	raw.BinaryArtifactResults.VerifiedWrappers = fromJSONFiles(r.VerifiedWrappers, finding.FileTypeBinary)
+	return evaluation.BinaryArtifacts(name, dl, &raw.BinaryArtifactResults)
+}
+
+func rerunCIIBestPractices(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	raw.CIIBestPracticesResults.Badge = clients.Unknown
+	for _, badge := range []clients.BadgeLevel{
+		clients.NotFound, clients.InProgress, clients.Passing, clients.Silver, clients.Gold,
+	} {
+		if badge.String() == r.OssfBestPractices.Badge {
+			raw.CIIBestPracticesResults.Badge = badge
+		}
+	}
+	return evaluation.CIIBestPractices(name, dl, &raw.CIIBestPracticesResults)
+}
+
+func rerunCITests(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	for _, ci := range r.CITests {
+		info := checker.RevisionCIInfo{
+			HeadSHA:           ci.HeadSHA,
+			PullRequestNumber: ci.PullRequestNumber,
+		}
+		for _, cr := range ci.CheckRuns {
+			info.CheckRuns = append(info.CheckRuns, clients.CheckRun{
+				Status:     cr.Status,
+				Conclusion: cr.Conclusion,
+				URL:        cr.URL,
+				App:        clients.CheckRunApp{Slug: cr.App},
+			})
+		}
+		for _, st := range ci.Statuses {
+			info.Statuses = append(info.Statuses, clients.Status{
+				State:     st.State,
+				Context:   st.Context,
+				URL:       st.URL,
+				TargetURL: st.TargetURL,
+			})
+		}
+		raw.CITestResults.CIInfo = append(raw.CITestResults.CIInfo, info)
+	}
+	for _, config := range r.CIConfigs {
+		raw.CITestResults.Configs = append(raw.CITestResults.Configs, checker.CIConfig{
+			System: config.System,
+			File: checker.File{
+				Path: config.Path,
+				Type: finding.FileTypeSource,
+			},
+			RunsTests: config.RunsTests,
+		})
+	}
+	return evaluation.CITests(name, &raw.CITestResults, dl)
+}
+
+func rerunCodeReview(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	for i := range r.DefaultBranchChangesets {
+		cs := &r.DefaultBranchChangesets[i]
+		changeset := checker.Changeset{
+			ReviewPlatform: cs.ReviewPlatform,
+			RevisionID:     cs.RevisionID,
+		}
+		if len(cs.Authors) > 0 {
+			changeset.Author = fromJSONUser(&cs.Authors[0])
+		}
+		for j := range cs.Commits {
+			jc := &cs.Commits[j]
+			commit := clients.Commit{
+				Message:   jc.Message,
+				SHA:       jc.SHA,
+				Committer: fromJSONUser(&jc.Committer),
+				CIStatus:  clients.CIStatus(jc.CIStatus),
+			}
+			for _, ca := range jc.CoAuthors {
+				commit.CoAuthors = append(commit.CoAuthors, clients.CoAuthor{
+					Name:  ca.Name,
+					Email: ca.Email,
+				})
+			}
+			if jc.Signature != nil {
+				commit.Signature = clients.CommitSignature{
+					Type:  clients.SignatureType(jc.Signature.Type),
+					Valid: jc.Signature.Valid,
+				}
+			}
+			changeset.Commits = append(changeset.Commits, commit)
+		}
+		for j := range cs.Reviews {
+			author := fromJSONUser(&cs.Reviews[j].Reviewer)
+			changeset.Reviews = append(changeset.Reviews, clients.Review{
+				Author: &author,
+				State:  cs.Reviews[j].State,
+			})
+		}
+		raw.CodeReviewResults.DefaultBranchChangesets = append(raw.CodeReviewResults.DefaultBranchChangesets,
+			changeset)
+	}
+	if summary := r.CodeReviewSummary; summary != nil {
+		raw.CodeReviewResults.Summary = checker.CodeReviewSummary{
+			Changesets:               summary.Changesets,
+			SelfMerged:               summary.SelfMerged.Count,
+			SingleMaintainerApproved: summary.SingleMaintainerApproved.Count,
+			IndependentlyReviewed:    summary.IndependentlyReviewed.Count,
+		}
+	}
+	return evaluation.CodeReview(name, dl, &raw.CodeReviewResults)
+}
+
+func rerunContributors(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	for i := range r.Contributors.Users {
+		u := &r.Contributors.Users[i]
+		user := clients.User{
+			Login:            u.Login,
+			NumContributions: u.NumContributions,
+			EmailDomains:     u.EmailDomains,
+		}
+		for _, org := range u.Organizations {
+			user.Organizations = append(user.Organizations, clients.User{Login: org.Login})
+		}
+		for _, comp := range u.Companies {
+			user.Companies = append(user.Companies, comp.Name)
+		}
+		raw.ContributorsResults.Users = append(raw.ContributorsResults.Users, user)
+	}
+	return evaluation.Contributors(name, dl, &raw.ContributorsResults)
+}
+
+func rerunDangerousWorkflow(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	for _, w := range r.Workflows {
+		workflow := checker.DangerousWorkflow{
+			Job:  fromJSONWorkflowJob(w.Job),
+			Type: checker.DangerousWorkflowType(w.Type),
+			File: fromJSONFile(w.File, finding.FileTypeSource),
+		}
+		if w.Rule != nil {
+			workflow.Rule = &checker.WorkflowRule{ID: w.Rule.ID, Description: w.Rule.Description}
+		}
+		raw.DangerousWorkflowResults.Workflows = append(raw.DangerousWorkflowResults.Workflows, workflow)
+	}
+	for i := range r.Suppressions {
+		s := &r.Suppressions[i]
+		if s.Check != name {
+			continue
+		}
+		raw.DangerousWorkflowResults.Suppressed = append(raw.DangerousWorkflowResults.Suppressed, checker.Suppression{
+			ID:     s.ID,
+			Reason: s.Reason,
+			Type:   s.Type,
+			File:   fromJSONFile(s.Location, finding.FileTypeSource),
+		})
+	}
+	return evaluation.DangerousWorkflow(name, dl, &raw.DangerousWorkflowResults)
+}
+
+func rerunDependencyUpdateTool(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	raw.DependencyUpdateToolResults.Tools = fromJSONTools(r.DependencyUpdateTools)
+	for i := range r.DependencyManifests {
+		m := &r.DependencyManifests[i]
+		raw.DependencyUpdateToolResults.Manifests = append(raw.DependencyUpdateToolResults.Manifests,
+			checker.DependencyManifest{
+				Ecosystem: m.Ecosystem,
+				File:      fromJSONFile(&m.File, finding.FileTypeSource),
+				Covered:   m.Covered,
+			})
+	}
+	return evaluation.DependencyUpdateTool(name, dl, &raw.DependencyUpdateToolResults)
+}
+
+func rerunDeploymentProtection(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	for _, d := range r.DeploymentProtection.Deployments {
+		raw.DeploymentProtectionResults.Deployments = append(raw.DeploymentProtectionResults.Deployments,
+			checker.Deployment{
+				Job:         fromJSONWorkflowJob(d.Job),
+				Environment: d.Environment,
+				File:        fromJSONFile(d.File, finding.FileTypeSource),
+			})
+	}
+	if r.DeploymentProtection.Environments != nil {
+		raw.DeploymentProtectionResults.Environments = []clients.Environment{}
+	}
+	for _, e := range r.DeploymentProtection.Environments {
+		raw.DeploymentProtectionResults.Environments = append(raw.DeploymentProtectionResults.Environments,
+			clients.Environment{
+				Name:                 e.Name,
+				RequiredReviewers:    e.RequiredReviewers,
+				WaitTimer:            e.WaitTimer,
+				ProtectedBranches:    e.ProtectedBranches,
+				CustomBranchPolicies: e.CustomBranchPolicies,
+			})
+	}
+	return evaluation.DeploymentProtection(name, dl, &raw.DeploymentProtectionResults)
+}
+
+func rerunFuzzing(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	raw.FuzzingResults.Fuzzers = fromJSONTools(r.Fuzzers)
+	if b := r.OSSFuzzBuild; b != nil {
+		raw.FuzzingResults.OSSFuzzBuild = &checker.OSSFuzzBuildStatus{
+			LastBuild:          b.LastBuild,
+			LastBuildSucceeded: b.LastBuildSucceeded,
+		}
+		if b.LastSuccessfulBuild != nil {
+			raw.FuzzingResults.OSSFuzzBuild.LastSuccessfulBuild = *b.LastSuccessfulBuild
+		}
+	}
+	return evaluation.Fuzzing(name, dl, &raw.FuzzingResults)
+}
+
+func rerunLicense(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	licenseFiles, err := fromJSONLicenses(r.Licenses)
+	if err != nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+	raw.LicenseResults.LicenseFiles = licenseFiles
+	subdirectoryLicenses, err := fromJSONLicenses(r.SubdirectoryLicenses)
+	if err != nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+	raw.LicenseResults.SubdirectoryLicenses = subdirectoryLicenses
+	return evaluation.License(name, dl, &raw.LicenseResults)
+}
+
+func fromJSONLicenses(licenses []jsonLicense) ([]checker.LicenseFile, error) {
+	var ret []checker.LicenseFile
+	for _, l := range licenses {
+		approved, err := strconv.ParseBool(l.License.Approved)
+		if err != nil {
+			return nil, fmt.Errorf("strconv.ParseBool: %w", err)
+		}
+		ret = append(ret, checker.LicenseFile{
+			File: checker.File{
+				Path: l.License.File,
+				Type: finding.FileTypeSource,
+			},
+			LicenseInformation: checker.License{
+				Name:        l.License.Name,
+				SpdxID:      l.License.SpdxID,
+				Attribution: checker.LicenseAttributionType(l.License.Attribution),
+				Approved:    approved,
+			},
+		})
+	}
+	return ret, nil
+}
+
+func fromJSONRegistryPackage(p *jsonRegistryPackage) *registry.Package {
+	return &registry.Package{
+		Ecosystem:      registry.Ecosystem(p.Ecosystem),
+		Name:           p.Name,
+		Repo:           p.Repo,
+		RepoConfidence: p.RepoConfidence,
+		RepoCandidates: p.RepoCandidates,
+		LatestVersion:  p.LatestVersion,
+		Maintainers:    p.Maintainers,
+		Provenance:     p.Provenance,
+	}
+}
+
+func rerunPackaging(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	for _, p := range r.Packages {
+		pk := checker.Package{
+			Name: p.Name,
+			Job:  fromJSONWorkflowJob(p.Job),
+		}
+		if p.File != nil {
+			f := fromJSONFile(p.File, finding.FileTypeSource)
+			pk.File = &f
+		}
+		for _, run := range p.Runs {
+			r := checker.Run{
+				URL:     run.URL,
+				HeadSHA: run.HeadSHA,
+				Version: run.Version,
+			}
+			if run.CreatedAt != nil {
+				r.CreatedAt = *run.CreatedAt
+			}
+			pk.Runs = append(pk.Runs, r)
+		}
+		raw.PackagingResults.Packages = append(raw.PackagingResults.Packages, pk)
+	}
+	if r.RegistryPackage != nil {
+		raw.PackagingResults.Registry = fromJSONRegistryPackage(r.RegistryPackage)
+	}
+	for i := range r.ManifestPackages {
+		mp := &r.ManifestPackages[i]
+		raw.PackagingResults.RegistryPackages = append(raw.PackagingResults.RegistryPackages,
+			checker.RegistryPackage{
+				Manifest:    fromJSONFile(&mp.Manifest, finding.FileTypeSource),
+				Package:     fromJSONRegistryPackage(&mp.Package),
+				RepoMatches: mp.RepoMatches,
+			})
+	}
+	return evaluation.Packaging(name, dl, &raw.PackagingResults)
+}
+
+func rerunSecurityPolicy(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	for _, sp := range r.SecurityPolicies {
+		file := checker.SecurityPolicyFile{
+			File: checker.File{
+				Path:     sp.Path,
+				Type:     finding.FileTypeText,
+				FileSize: sp.ContentLength,
+			},
+			Information: []checker.SecurityPolicyInformation{},
+		}
+		for _, hit := range sp.Hits {
+			file.Information = append(file.Information, checker.SecurityPolicyInformation{
+				InformationType: checker.SecurityPolicyInformationType(hit.Type),
+				InformationValue: checker.SecurityPolicyValueType{
+					Match:      hit.Match,
+					LineNumber: hit.LineNumber,
+					Offset:     hit.Offset,
+				},
+			})
+		}
+		raw.SecurityPolicyResults.PolicyFiles = append(raw.SecurityPolicyResults.PolicyFiles, file)
+	}
+	raw.SecurityPolicyResults.PolicyURL = r.SecurityPolicyURL
+	if r.SecurityTxt != nil {
+		raw.SecurityPolicyResults.SecurityTxt = &checker.SecurityTxtFile{
+			File: checker.File{
+				Path: r.SecurityTxt.Path,
+				Type: finding.FileTypeText,
+			},
+			Contacts: r.SecurityTxt.Contacts,
+		}
+	}
+	return evaluation.SecurityPolicy(name, dl, &raw.SecurityPolicyResults)
+}
+
+func rerunHiddenCharacters(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	for _, c := range r.HiddenCharacters {
+		raw.HiddenCharactersResults.Characters = append(raw.HiddenCharactersResults.Characters,
+			checker.HiddenCharacter{
+				Type:      checker.HiddenCharacterType(c.Type),
+				File:      fromJSONFile(c.File, finding.FileTypeSource),
+				Column:    c.Column,
+				CodePoint: c.CodePoint,
+			})
+	}
+	return evaluation.HiddenCharacters(name, dl, &raw.HiddenCharactersResults)
+}
+
+func rerunCompromisedDependencies(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	raw.CompromisedDependenciesResults.Entries = r.CompromisedDependencies.Entries
+	for _, d := range r.CompromisedDependencies.Dependencies {
+		raw.CompromisedDependenciesResults.Dependencies = append(raw.CompromisedDependenciesResults.Dependencies,
+			checker.CompromisedDependency{
+				Entry: &checker.DenylistEntry{
+					ID:          d.ID,
+					Description: d.Description,
+					Ecosystem:   checker.DenylistEcosystem(d.Ecosystem),
+					Name:        d.Name,
+				},
+				File:    fromJSONFile(d.File, finding.FileTypeSource),
+				Name:    d.Name,
+				Version: d.Version,
+			})
+	}
+	return evaluation.CompromisedDependencies(name, dl, &raw.CompromisedDependenciesResults)
+}
+
+func rerunSelfHostedRunners(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	raw.SelfHostedRunnersResults.Private = r.SelfHostedRunners.Private
+	for _, j := range r.SelfHostedRunners.Jobs {
+		raw.SelfHostedRunnersResults.Jobs = append(raw.SelfHostedRunnersResults.Jobs, checker.RunnerJob{
+			Job:          fromJSONWorkflowJob(j.Job),
+			Labels:       j.Labels,
+			ForkTriggers: j.ForkTriggers,
+			File:         fromJSONFile(j.File, finding.FileTypeSource),
+			SelfHosted:   j.SelfHosted,
+		})
+	}
+	return evaluation.SelfHostedRunners(name, dl, &raw.SelfHostedRunnersResults)
+}
+
+func rerunSignedReleases(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	for _, rel := range r.Releases {
+		release := clients.Release{
+			TagName:         rel.Tag,
+			URL:             rel.URL,
+			Author:          clients.User{Login: rel.Author},
+			TagVerification: rel.TagVerification,
+		}
+		for _, asset := range rel.Assets {
+			release.Assets = append(release.Assets, clients.ReleaseAsset{
+				Name:        asset.Path,
+				URL:         asset.URL,
+				DownloadURL: asset.DownloadURL,
+				Digest:      asset.Digest,
+				Size:        asset.Size,
+			})
+		}
+		raw.SignedReleasesResults.Releases = append(raw.SignedReleasesResults.Releases, release)
+	}
+	for _, c := range r.ReleaseChecksums {
+		raw.SignedReleasesResults.Checksums = append(raw.SignedReleasesResults.Checksums, checker.ReleaseChecksum{
+			Tag:      c.Tag,
+			File:     c.File,
+			Asset:    c.Asset,
+			Verified: c.Verified,
+		})
+	}
+	for i := range r.ReleaseProvenance {
+		p := &r.ReleaseProvenance[i]
+		provenance := checker.ReleaseProvenance{
+			Tag:         p.Tag,
+			File:        p.File,
+			BuilderID:   p.BuilderID,
+			SourceURI:   p.SourceURI,
+			RepoMatches: p.RepoMatches,
+			RefMatches:  p.RefMatches,
+		}
+		for _, subject := range p.Subjects {
+			provenance.Subjects = append(provenance.Subjects, checker.ProvenanceSubject{
+				Name:     subject.Name,
+				Verified: subject.Verified,
+			})
+		}
+		raw.SignedReleasesResults.Provenance = append(raw.SignedReleasesResults.Provenance, provenance)
+	}
+	return evaluation.SignedReleases(name, dl, &raw.SignedReleasesResults)
+}
+
+func rerunWebhooks(name string, dl checker.DetailLogger,
+	r *jsonRawResults, raw *checker.RawResults,
+) checker.CheckResult {
+	for _, w := range r.Webhooks {
+		raw.WebhookResults.Webhooks = append(raw.WebhookResults.Webhooks, clients.Webhook{
+			Path:           w.Path,
+			ID:             w.ID,
+			UsesAuthSecret: w.UsesAuthSecret,
+		})
+	}
+	return evaluation.Webhooks(name, dl, &raw.WebhookResults)
+}
@@ -0,0 +1,205 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
+	"github.com/ossf/scorecard/v4/checks/evaluation"
+	"github.com/ossf/scorecard/v4/clients"
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/finding"
+	scut "github.com/ossf/scorecard/v4/utests"
+)
+
+func TestRerunCheck(t *testing.T) {
+	t.Parallel()
+	raw := checker.RawResults{
+		CodeReviewResults: checker.CodeReviewData{
+			DefaultBranchChangesets: []checker.Changeset{
+				{
+					ReviewPlatform: checker.ReviewPlatformGitHub,
+					RevisionID:     "1",
+					Author:         clients.User{Login: "dependabot[bot]", IsBot: true},
+					Commits:        []clients.Commit{{SHA: "abc", Committer: clients.User{Login: "dependabot[bot]"}}},
+				},
+				{
+					ReviewPlatform: checker.ReviewPlatformGitHub,
+					RevisionID:     "2",
+					Author:         clients.User{Login: "alice"},
+					Reviews:        []clients.Review{{State: "APPROVED", Author: &clients.User{Login: "bob"}}},
+				},
+			},
+		},
+		SignedReleasesResults: checker.SignedReleasesData{
+			Releases: []clients.Release{
+				{
+					TagName: "v1.0.0",
+					URL:     "https://github.com/foo/bar/releases/tag/v1.0.0",
+					Assets: []clients.ReleaseAsset{
+						{Name: "bar.tar.gz", URL: "https://github.com/foo/bar/releases/download/v1.0.0/bar.tar.gz"},
+						{Name: "bar.tar.gz.sig", URL: "https://github.com/foo/bar/releases/download/v1.0.0/bar.tar.gz.sig"},
+					},
+				},
+			},
+		},
+		SecurityPolicyResults: checker.SecurityPolicyData{
+			PolicyFiles: []checker.SecurityPolicyFile{
+				{
+					File: checker.File{Path: "SECURITY.md", Type: finding.FileTypeText, FileSize: 120},
+					Information: []checker.SecurityPolicyInformation{
+						{
+							InformationType:  checker.SecurityPolicyInformationTypeEmail,
+							InformationValue: checker.SecurityPolicyValueType{Match: "security@foo.com", LineNumber: 3},
+						},
+					},
+				},
+			},
+		},
+		CIIBestPracticesResults: checker.CIIBestPracticesData{Badge: clients.Silver},
+		HiddenCharactersResults: checker.HiddenCharactersData{
+			Characters: []checker.HiddenCharacter{
+				{
+					Type:      checker.HiddenCharacterInvisible,
+					File:      checker.File{Path: "setup.py", Type: finding.FileTypeSource, Offset: 2},
+					Column:    7,
+					CodePoint: 0x200B,
+				},
+			},
+		},
+		CompromisedDependenciesResults: checker.CompromisedDependenciesData{
+			Entries: 2,
+			Dependencies: []checker.CompromisedDependency{
+				{
+					Entry: &checker.DenylistEntry{
+						ID:          "ctx-2022",
+						Description: "ctx was hijacked to leak environment variables",
+						Ecosystem:   checker.DenylistPyPI,
+						Name:        "ctx",
+					},
+					File:    checker.File{Path: "requirements.txt", Type: finding.FileTypeSource, Offset: 3},
+					Name:    "ctx",
+					Version: "0.2.6",
+				},
+			},
+		},
+		LicenseResults: checker.LicenseData{
+			LicenseFiles: []checker.LicenseFile{
+				{
+					File: checker.File{Path: "LICENSE", Type: finding.FileTypeSource},
+					LicenseInformation: checker.License{
+						SpdxID:      "Apache-2.0",
+						Attribution: checker.LicenseAttributionTypeAPI,
+						Approved:    true,
+					},
+				},
+			},
+		},
+	}
+	result := ScorecardResult{
+		Repo:       RepoInfo{Name: "github.com/foo/bar", CommitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32"},
+		Date:       time.Date(2023, time.March, 2, 0, 0, 0, 0, time.UTC),
+		RawResults: raw,
+	}
+	var buf bytes.Buffer
+	if err := result.AsRawJSON(&buf); err != nil {
+		t.Fatalf("AsRawJSON: %v", err)
+	}
+
+	tests := []struct {
+		want  func(dl checker.DetailLogger) checker.CheckResult
+		check string
+	}{
+		{
+			check: checks.CheckCodeReview,
+			want: func(dl checker.DetailLogger) checker.CheckResult {
+				return evaluation.CodeReview(checks.CheckCodeReview, dl, &raw.CodeReviewResults)
+			},
+		},
+		{
+			check: checks.CheckSignedReleases,
+			want: func(dl checker.DetailLogger) checker.CheckResult {
+				return evaluation.SignedReleases(checks.CheckSignedReleases, dl, &raw.SignedReleasesResults)
+			},
+		},
+		{
+			check: checks.CheckSecurityPolicy,
+			want: func(dl checker.DetailLogger) checker.CheckResult {
+				return evaluation.SecurityPolicy(checks.CheckSecurityPolicy, dl, &raw.SecurityPolicyResults)
+			},
+		},
+		{
+			check: "cii-best-practices",
+			want: func(dl checker.DetailLogger) checker.CheckResult {
+				return evaluation.CIIBestPractices(checks.CheckCIIBestPractices, dl, &raw.CIIBestPracticesResults)
+			},
+		},
+		{
+			check: checks.CheckHiddenCharacters,
+			want: func(dl checker.DetailLogger) checker.CheckResult {
+				return evaluation.HiddenCharacters(checks.CheckHiddenCharacters, dl, &raw.HiddenCharactersResults)
+			},
+		},
+		{
+			check: checks.CheckCompromisedDependencies,
+			want: func(dl checker.DetailLogger) checker.CheckResult {
+				return evaluation.CompromisedDependencies(checks.CheckCompromisedDependencies, dl,
+					&raw.CompromisedDependenciesResults)
+			},
+		},
+		{
+			check: checks.CheckLicense,
+			want: func(dl checker.DetailLogger) checker.CheckResult {
+				return evaluation.License(checks.CheckLicense, dl, &raw.LicenseResults)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.check, func(t *testing.T) {
+			t.Parallel()
+			got, err := RerunCheck(tt.check, bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("RerunCheck: %v", err)
+			}
+			if got.Repo.Name != result.Repo.Name || !got.Date.Equal(result.Date) {
+				t.Errorf("RerunCheck() repo = %v, date = %v", got.Repo, got.Date)
+			}
+			if len(got.Checks) != 1 {
+				t.Fatalf("RerunCheck() returned %d checks, want 1", len(got.Checks))
+			}
+			want := tt.want(&scut.TestDetailLogger{})
+			if got.Checks[0].Name != want.Name || got.Checks[0].Score != want.Score ||
+				got.Checks[0].Reason != want.Reason {
+				t.Errorf("RerunCheck() = %v, want %v", got.Checks[0], want)
+			}
+		})
+	}
+}
+
+func TestRerunCheckUnsupported(t *testing.T) {
+	t.Parallel()
+	for _, check := range []string{checks.CheckMaintained, checks.CheckBranchProtection, "Unknown-Check"} {
+		_, err := RerunCheck(check, bytes.NewReader([]byte("{}")))
+		if !errors.Is(err, sce.ErrorUnsupportedCheck) {
+			t.Errorf("RerunCheck(%s) error = %v, want %v", check, err, sce.ErrorUnsupportedCheck)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"gocloud.dev/blob"
+	// Needed to open file:// buckets.
+	_ "gocloud.dev/blob/fileblob"
+	// Needed to open gs:// buckets.
+	_ "gocloud.dev/blob/gcsblob"
+	// Needed to open mem:// buckets.
+	_ "gocloud.dev/blob/memblob"
+	"gocloud.dev/gcerrors"
+	"sigs.k8s.io/release-utils/version"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// MetadataCached marks results which were read from the result cache.
+const MetadataCached = "cached"
+
+// ErrCacheMiss is returned by ResultCache.Get when there is no entry for a key.
+var ErrCacheMiss = errors.New("cache miss")
+
+// ResultCache stores serialized results by cache key.
+type ResultCache interface {
+	// Get returns the entry stored for key, or ErrCacheMiss.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, value []byte) error
+	Close() error
+}
+
+// WithResultCache makes RunScorecard reuse results stored in cache instead of
+// running the checks again. policyHash identifies the scoring policy and is
+// part of the cache key, together with the repo, commit, Scorecard version and
+// the checks to run.
+func WithResultCache(cache ResultCache, policyHash string) Option {
+	return func(c *runConfig) {
+		c.cache = cache
+		c.policyHash = policyHash
+	}
+}
+
+// OpenResultCache opens the cache at cacheURL. Supported URLs are
+// redis://[:password@]host:port[/db][?ttl=duration] and the blob URLs of
+// gocloud.dev, e.g. file:///path/to/dir or gs://bucket?prefix=scorecard/.
+func OpenResultCache(ctx context.Context, cacheURL string) (ResultCache, error) {
+	u, err := url.Parse(cacheURL)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", err))
+	}
+	if u.Scheme == "redis" {
+		return newRedisCache(u)
+	}
+	bucket, err := blob.OpenBucket(ctx, cacheURL)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("blob.OpenBucket: %v", err))
+	}
+	return &blobCache{bucket: bucket}, nil
+}
+
+// NamespacedResultCache returns a view of cache whose keys are prefixed by
+// namespace, so that the results of one tenant of a shared cache, e.g. in the
+// serve mode, are never returned to another.
+func NamespacedResultCache(cache ResultCache, namespace string) ResultCache {
+	return &namespacedCache{cache: cache, prefix: namespace + "/"}
+}
+
+type namespacedCache struct {
+	cache  ResultCache
+	prefix string
+}
+
+func (n *namespacedCache) Get(ctx context.Context, key string) ([]byte, error) {
+	//nolint:wrapcheck
+	return n.cache.Get(ctx, n.prefix+key)
+}
+
+func (n *namespacedCache) Put(ctx context.Context, key string, value []byte) error {
+	//nolint:wrapcheck
+	return n.cache.Put(ctx, n.prefix+key, value)
+}
+
+// Close does not close the shared cache, which outlives its views.
+func (n *namespacedCache) Close() error {
+	return nil
+}
+
+type blobCache struct {
+	bucket *blob.Bucket
+}
+
+func (b *blobCache) Get(ctx context.Context, key string) ([]byte, error) {
+	content, err := b.bucket.ReadAll(ctx, key+".json")
+	if gcerrors.Code(err) == gcerrors.NotFound {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, fmt.Errorf("bucket.ReadAll: %w", err)
+	}
+	return content, nil
+}
+
+func (b *blobCache) Put(ctx context.Context, key string, value []byte) error {
+	if err := b.bucket.WriteAll(ctx, key+".json", value, nil); err != nil {
+		return fmt.Errorf("bucket.WriteAll: %w", err)
+	}
+	return nil
+}
+
+func (b *blobCache) Close() error {
+	if err := b.bucket.Close(); err != nil {
+		return fmt.Errorf("bucket.Close: %w", err)
+	}
+	return nil
+}
+
+// resultCacheKey hashes the inputs which affect the results of a run. Results
+// are not cacheable for local directories and for builds whose version does not
+// identify their source.
+func resultCacheKey(cfg *runConfig, repo *RepoInfo, checksToRun checker.CheckNameToFnMap,
+	commitDepth int,
+) (string, bool) {
+	if cfg.cache == nil || !cacheableVersion(cfg.version) || repo.CommitSHA == "unknown" {
+		return "", false
+	}
+	checks := make([]string, 0, len(checksToRun))
+	for name := range checksToRun {
+		checks = append(checks, name)
+	}
+	sort.Strings(checks)
+	// Encoding a struct is deterministic, its fields are written in order.
+	inputs, err := json.Marshal(struct {
+		Repo           string
+		Commit         string
+		Version        string
+		VersionCommit  string
+		Policy         string
+		ArchivedPolicy RepoPolicy
+		ForkPolicy     RepoPolicy
+		Checks         []string
+		CommitDepth    int
+		Experiments    []string `json:",omitempty"`
+		QuarantineDays int      `json:",omitempty"`
+	}{
+		Repo:           repo.Name,
+		Commit:         repo.CommitSHA,
+		Version:        cfg.version.GitVersion,
+		VersionCommit:  cfg.version.GitCommit,
+		Policy:         cfg.policyHash,
+		ArchivedPolicy: cfg.archivedPolicy,
+		ForkPolicy:     cfg.forkPolicy,
+		Checks:         checks,
+		CommitDepth:    commitDepth,
+		Experiments:    experimentNames(cfg.experiments),
+		QuarantineDays: cfg.quarantineDays,
+	})
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(inputs)
+	return hex.EncodeToString(sum[:]), true
+}
+
+func cacheableVersion(v version.Info) bool {
+	return v.GitCommit != "" && v.GitCommit != "unknown" && v.GitTreeState == "clean"
+}
+
+func getCachedResult(ctx context.Context, cache ResultCache, key string) (*ScorecardResult, error) {
+	content, err := cache.Get(ctx, key)
+	if errors.Is(err, ErrCacheMiss) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ResultCache.Get: %v", err))
+	}
+	var ret ScorecardResult
+	if err := json.Unmarshal(content, &ret); err != nil {
+		// A corrupted entry is overwritten by the next run.
+		return nil, nil
+	}
+	ret.Metadata = append(ret.Metadata, MetadataCached)
+	return &ret, nil
+}
+
+func putCachedResult(ctx context.Context, cache ResultCache, key string, result *ScorecardResult) error {
+	for i := range result.Checks {
+		// Runtime errors may be transient and cannot be serialized.
+		if result.Checks[i].Error != nil {
+			return nil
+		}
+	}
+	content, err := json.Marshal(result)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Marshal: %v", err))
+	}
+	if err := cache.Put(ctx, key, content); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ResultCache.Put: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/internal/redis"
+)
+
+const redisKeyPrefix = "scorecard:result:"
+
+// redisCache stores results in Redis, expiring them after ttl if it is set.
+type redisCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func newRedisCache(u *url.URL) (*redisCache, error) {
+	client, err := redis.NewClient(u)
+	if err != nil {
+		return nil, fmt.Errorf("redis.NewClient: %w", err)
+	}
+	c := &redisCache{client: client}
+	if ttl := u.Query().Get("ttl"); ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid redis ttl %q", ttl))
+		}
+		c.ttl = d
+	}
+	return c, nil
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Do(ctx, "GET", redisKeyPrefix+key)
+	if err != nil {
+		return nil, fmt.Errorf("GET: %w", err)
+	}
+	if value == nil {
+		return nil, ErrCacheMiss
+	}
+	return value, nil
+}
+
+func (c *redisCache) Put(ctx context.Context, key string, value []byte) error {
+	args := []string{"SET", redisKeyPrefix + key, string(value)}
+	if c.ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(c.ttl.Milliseconds(), 10))
+	}
+	if _, err := c.client.Do(ctx, args...); err != nil {
+		return fmt.Errorf("SET: %w", err)
+	}
+	return nil
+}
+
+func (c *redisCache) Close() error {
+	return nil
+}
@@ -0,0 +1,484 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pkg
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"sigs.k8s.io/release-utils/version"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/internal/redis"
+)
+
+var releaseVersion = version.Info{
+	GitVersion:   "v4.10.2",
+	GitCommit:    "abcdef",
+	GitTreeState: "clean",
+}
+
+func withVersion(v version.Info) Option {
+	return func(c *runConfig) {
+		c.version = v
+	}
+}
+
+func Test_resultCacheKey(t *testing.T) {
+	t.Parallel()
+	checks := checker.CheckNameToFnMap{"Code-Review": {}, "License": {}}
+	repo := RepoInfo{Name: "github.com/ossf/scorecard", CommitSHA: "c1"}
+	base := newRunConfig([]Option{WithResultCache(&memCache{}, "policy"), withVersion(releaseVersion)})
+	baseKey, ok := resultCacheKey(&base, &repo, checks, 0)
+	if !ok {
+		t.Fatal("expected the run to be cacheable")
+	}
+	if again, _ := resultCacheKey(&base, &repo, checker.CheckNameToFnMap{"License": {}, "Code-Review": {}}, 0); again != baseKey {
+		t.Errorf("key depends on the order of the checks")
+	}
+
+	dirty := releaseVersion
+	dirty.GitTreeState = "dirty"
+	tests := []struct {
+		name          string
+		opts          []Option
+		repo          RepoInfo
+		checks        checker.CheckNameToFnMap
+		commitDepth   int
+		wantCacheable bool
+	}{
+		{
+			name:          "other commit",
+			opts:          []Option{WithResultCache(&memCache{}, "policy"), withVersion(releaseVersion)},
+			repo:          RepoInfo{Name: repo.Name, CommitSHA: "c2"},
+			checks:        checks,
+			wantCacheable: true,
+		},
+		{
+			name:          "other policy",
+			opts:          []Option{WithResultCache(&memCache{}, "other"), withVersion(releaseVersion)},
+			repo:          repo,
+			checks:        checks,
+			wantCacheable: true,
+		},
+		{
+			name: "other version",
+			opts: []Option{
+				WithResultCache(&memCache{}, "policy"),
+				withVersion(version.Info{GitVersion: "v4.11.0", GitCommit: "123456", GitTreeState: "clean"}),
+			},
+			repo:          repo,
+			checks:        checks,
+			wantCacheable: true,
+		},
+		{
+			name:          "other checks",
+			opts:          []Option{WithResultCache(&memCache{}, "policy"), withVersion(releaseVersion)},
+			repo:          repo,
+			checks:        checker.CheckNameToFnMap{"License": {}},
+			wantCacheable: true,
+		},
+		{
+			name:          "other commit depth",
+			opts:          []Option{WithResultCache(&memCache{}, "policy"), withVersion(releaseVersion)},
+			repo:          repo,
+			checks:        checks,
+			commitDepth:   100,
+			wantCacheable: true,
+		},
+		{
+			name: "other repo policy",
+			opts: []Option{
+				WithResultCache(&memCache{}, "policy"), withVersion(releaseVersion), WithForkPolicy(RepoPolicyParent),
+			},
+			repo:          repo,
+			checks:        checks,
+			wantCacheable: true,
+		},
+		{
+			name: "other quarantine days",
+			opts: []Option{
+				WithResultCache(&memCache{}, "policy"), withVersion(releaseVersion), WithQuarantine(30),
+			},
+			repo:          repo,
+			checks:        checks,
+			wantCacheable: true,
+		},
+		{
+			name:   "no cache",
+			opts:   []Option{withVersion(releaseVersion)},
+			repo:   repo,
+			checks: checks,
+		},
+		{
+			name:   "dirty build",
+			opts:   []Option{WithResultCache(&memCache{}, "policy"), withVersion(dirty)},
+			repo:   repo,
+			checks: checks,
+		},
+		{
+			name:   "development build",
+			opts:   []Option{WithResultCache(&memCache{}, "policy"), withVersion(version.GetVersionInfo())},
+			repo:   repo,
+			checks: checks,
+		},
+		{
+			name:   "local directory",
+			opts:   []Option{WithResultCache(&memCache{}, "policy"), withVersion(releaseVersion)},
+			repo:   RepoInfo{Name: "file://testdata", CommitSHA: "unknown"},
+			checks: checks,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := newRunConfig(tt.opts)
+			key, cacheable := resultCacheKey(&cfg, &tt.repo, tt.checks, tt.commitDepth)
+			if cacheable != tt.wantCacheable {
+				t.Fatalf("resultCacheKey() cacheable = %v, want %v", cacheable, tt.wantCacheable)
+			}
+			if cacheable && key == baseKey {
+				t.Errorf("resultCacheKey() = %v, want a key different from the base one", key)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // The counter is shared by both runs.
+func TestRunScorecard_resultCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockrepo.NewMockRepo(ctrl)
+	repo.EXPECT().URI().Return("github.com/ossf/scorecard").AnyTimes()
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().InitRepo(repo, clients.HeadSHA, 0).Return(nil).Times(2)
+	mockRepoClient.EXPECT().Close().Return(nil).Times(2)
+	mockRepoClient.EXPECT().URI().Return("").AnyTimes()
+	mockRepoClient.EXPECT().ListCommits().Return([]clients.Commit{{SHA: "c1"}}, nil).AnyTimes()
+	mockRepoClient.EXPECT().IsArchived().Return(false, nil).AnyTimes()
+	mockRepoClient.EXPECT().GetForkParent().Return(nil, nil).AnyTimes()
+	mockRepoClient.EXPECT().GetFileContent(gomock.Any()).Return(nil, os.ErrNotExist).AnyTimes()
+
+	var mu sync.Mutex
+	runs := 0
+	checks := checker.CheckNameToFnMap{
+		"Fake-Check": {
+			Metadata: checker.CheckMetadata{Inputs: []string{"RepoClient.ListCommits"}},
+			Fn: func(*checker.CheckRequest) checker.CheckResult {
+				mu.Lock()
+				defer mu.Unlock()
+				runs++
+				return checker.CheckResult{Name: "Fake-Check", Score: 7, Reason: "reason"}
+			},
+		},
+	}
+	cache := &memCache{}
+	opts := []Option{WithResultCache(cache, ""), withVersion(releaseVersion)}
+
+	first, err := RunScorecard(context.Background(), repo, clients.HeadSHA, 0, checks,
+		mockRepoClient, nil, nil, nil, opts...)
+	if err != nil {
+		t.Fatalf("RunScorecard: %v", err)
+	}
+	second, err := RunScorecard(context.Background(), repo, clients.HeadSHA, 0, checks,
+		mockRepoClient, nil, nil, nil, opts...)
+	if err != nil {
+		t.Fatalf("RunScorecard: %v", err)
+	}
+	if runs != 1 {
+		t.Errorf("check ran %d times, want 1", runs)
+	}
+	if len(second.Checks) != 1 || second.Checks[0].Score != first.Checks[0].Score {
+		t.Errorf("cached checks = %v, want %v", second.Checks, first.Checks)
+	}
+	if second.Repo.CommitSHA != "c1" || second.Repo.Commits == nil {
+		t.Errorf("cached repo = %v, want commit c1 with its window", second.Repo)
+	}
+	if n := len(second.Metadata); n == 0 || second.Metadata[n-1] != MetadataCached {
+		t.Errorf("cached metadata = %v, want %q", second.Metadata, MetadataCached)
+	}
+}
+
+func Test_putCachedResult_skipsErrors(t *testing.T) {
+	t.Parallel()
+	cache := &memCache{}
+	result := ScorecardResult{
+		Checks: []checker.CheckResult{{Name: "Fake-Check", Error: errors.New("transient")}}, //nolint:goerr113
+	}
+	if err := putCachedResult(context.Background(), cache, "key", &result); err != nil {
+		t.Fatalf("putCachedResult: %v", err)
+	}
+	if _, err := cache.Get(context.Background(), "key"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get() error = %v, want %v", err, ErrCacheMiss)
+	}
+}
+
+func TestOpenResultCache_blob(t *testing.T) {
+	t.Parallel()
+	testResultCache(t, "mem://")
+	testResultCache(t, "file://"+t.TempDir())
+}
+
+func TestNamespacedResultCache(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cache, err := OpenResultCache(ctx, "mem://")
+	if err != nil {
+		t.Fatalf("OpenResultCache: %v", err)
+	}
+	defer cache.Close()
+	payments := NamespacedResultCache(cache, "payments")
+	search := NamespacedResultCache(cache, "search")
+	if err := payments.Put(ctx, "key", []byte("payments result")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got, err := payments.Get(ctx, "key"); err != nil || string(got) != "payments result" {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+	if _, err := search.Get(ctx, "key"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get() of another namespace error = %v, want %v", err, ErrCacheMiss)
+	}
+	if _, err := cache.Get(ctx, "key"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get() outside of the namespaces error = %v, want %v", err, ErrCacheMiss)
+	}
+	// Closing a view leaves the shared cache open.
+	if err := payments.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := search.Get(ctx, "key"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get() after closing a view error = %v, want %v", err, ErrCacheMiss)
+	}
+}
+
+func TestOpenResultCache_redis(t *testing.T) {
+	t.Parallel()
+	addr := fakeRedis(t, "secret")
+	testResultCache(t, fmt.Sprintf("redis://:secret@%s/2?ttl=1h", addr))
+
+	cache, err := OpenResultCache(context.Background(), fmt.Sprintf("redis://:wrong@%s", addr))
+	if err != nil {
+		t.Fatalf("OpenResultCache: %v", err)
+	}
+	if _, err := cache.Get(context.Background(), "key"); !errors.Is(err, redis.ErrRedis) {
+		t.Errorf("Get() with a wrong password error = %v, want %v", err, redis.ErrRedis)
+	}
+}
+
+func testResultCache(t *testing.T, cacheURL string) {
+	t.Helper()
+	ctx := context.Background()
+	cache, err := OpenResultCache(ctx, cacheURL)
+	if err != nil {
+		t.Fatalf("OpenResultCache(%s): %v", cacheURL, err)
+	}
+	defer cache.Close()
+	if _, err := cache.Get(ctx, "key"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("%s: Get() error = %v, want %v", cacheURL, err, ErrCacheMiss)
+	}
+	if err := cache.Put(ctx, "key", []byte("value")); err != nil {
+		t.Fatalf("%s: Put(): %v", cacheURL, err)
+	}
+	got, err := cache.Get(ctx, "key")
+	if err != nil || string(got) != "value" {
+		t.Errorf("%s: Get() = %q, %v, want %q", cacheURL, got, err, "value")
+	}
+}
+
+type memCache struct {
+	entries map[string][]byte
+	mu      sync.Mutex
+}
+
+func (m *memCache) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.entries[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+	return v, nil
+}
+
+func (m *memCache) Put(ctx context.Context, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = make(map[string][]byte)
+	}
+	m.entries[key] = value
+	return nil
+}
+
+func (m *memCache) Close() error {
+	return nil
+}
+
+// fakeRedis serves AUTH, SELECT, GET and SET and returns its address.
+func fakeRedis(t *testing.T, password string) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	var mu sync.Mutex
+	values := make(map[string]string)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				authenticated := false
+				for {
+					args, err := readRedisCommand(r)
+					if err != nil {
+						return
+					}
+					mu.Lock()
+					switch strings.ToUpper(args[0]) {
+					case "AUTH":
+						authenticated = args[1] == password
+						if authenticated {
+							fmt.Fprint(conn, "+OK\r\n")
+						} else {
+							fmt.Fprint(conn, "-WRONGPASS invalid password\r\n")
+						}
+					case "SELECT":
+						fmt.Fprint(conn, "+OK\r\n")
+					case "GET", "SET":
+						switch {
+						case !authenticated:
+							fmt.Fprint(conn, "-NOAUTH Authentication required.\r\n")
+						case args[0] == "SET":
+							values[args[1]] = args[2]
+							fmt.Fprint(conn, "+OK\r\n")
+						default:
+							if v, ok := values[args[1]]; ok {
+								fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(v), v)
+							} else {
+								fmt.Fprint(conn, "$-1\r\n")
+							}
+						}
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+	}()
+	return l.Addr().String()
+}
+
+func readRedisCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	args := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		if _, err := r.ReadString('\n'); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		args = append(args, strings.TrimSuffix(arg, "\r\n"))
+	}
+	return args, nil
+}
+
+func Test_newRedisCache(t *testing.T) {
+	t.Parallel()
+	u, err := url.Parse("redis://localhost/3?ttl=30m")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	c, err := newRedisCache(u)
+	if err != nil {
+		t.Fatalf("newRedisCache: %v", err)
+	}
+	if c.client == nil || c.ttl.Minutes() != 30 {
+		t.Errorf("newRedisCache() = %+v", c)
+	}
+}
+
+//nolint:paralleltest // The runs share the cache.
+func TestRunScorecard_resultCacheQuarantine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockrepo.NewMockRepo(ctrl)
+	repo.EXPECT().URI().Return("github.com/ossf/scorecard").AnyTimes()
+	mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+	mockRepoClient.EXPECT().InitRepo(repo, clients.HeadSHA, 0).Return(nil).Times(2)
+	mockRepoClient.EXPECT().Close().Return(nil).Times(2)
+	mockRepoClient.EXPECT().URI().Return("").AnyTimes()
+	mockRepoClient.EXPECT().ListCommits().Return([]clients.Commit{{SHA: "c1"}}, nil).AnyTimes()
+	mockRepoClient.EXPECT().IsArchived().Return(false, nil).AnyTimes()
+	mockRepoClient.EXPECT().GetForkParent().Return(nil, nil).AnyTimes()
+	mockRepoClient.EXPECT().GetFileContent(gomock.Any()).Return(nil, os.ErrNotExist).AnyTimes()
+	mockRepoClient.EXPECT().ListContributors().Return([]clients.User{{Login: "owner"}}, nil).AnyTimes()
+	// The repo ages past the quarantine between the scans of the same commit.
+	gomock.InOrder(
+		mockRepoClient.EXPECT().GetCreatedAt().Return(time.Now(), nil),
+		mockRepoClient.EXPECT().GetCreatedAt().Return(time.Now().AddDate(0, 0, -60), nil),
+	)
+
+	checks := checker.CheckNameToFnMap{
+		"Fake-Check": {
+			Fn: func(*checker.CheckRequest) checker.CheckResult {
+				return checker.CheckResult{Name: "Fake-Check", Score: 7, Reason: "reason"}
+			},
+		},
+	}
+	opts := []Option{WithResultCache(&memCache{}, ""), withVersion(releaseVersion), WithQuarantine(30)}
+
+	first, err := RunScorecard(context.Background(), repo, clients.HeadSHA, 0, checks,
+		mockRepoClient, nil, nil, nil, opts...)
+	if err != nil {
+		t.Fatalf("RunScorecard: %v", err)
+	}
+	if !isQuarantined(first.Metadata) {
+		t.Errorf("metadata = %v, want the young repo quarantined", first.Metadata)
+	}
+	second, err := RunScorecard(context.Background(), repo, clients.HeadSHA, 0, checks,
+		mockRepoClient, nil, nil, nil, opts...)
+	if err != nil {
+		t.Fatalf("RunScorecard: %v", err)
+	}
+	if isQuarantined(second.Metadata) {
+		t.Errorf("cached metadata = %v, want the quarantine lifted", second.Metadata)
+	}
+}
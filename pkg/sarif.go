@@ -89,6 +89,9 @@ type properties struct {
 	ProblemSeverity string   `json:"problem.severity"`
 	SeverityLevel   string   `json:"security-severity"`
 	Tags            []string `json:"tags"`
+	// NotApplicable is set on the rules of the checks not run because they
+	// make no sense for the repo.
+	NotApplicable bool `json:"notApplicable,omitempty"`
 }
 
 type help struct {
@@ -122,6 +125,15 @@ type tool struct {
 	Driver driver `json:"driver"`
 }
 
+// resultProperties is the property bag of a result, see
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/os/sarif-v2.1.0-os.html#_Toc34317448.
+type resultProperties struct {
+	Score      int    `json:"score"`
+	Confidence string `json:"confidence"`
+	// Algorithm is the version of the scoring algorithm of the check.
+	Algorithm string `json:"algorithm,omitempty"`
+}
+
 // nolint
 type result struct {
 	RuleID           string            `json:"ruleId"`
@@ -134,6 +146,7 @@ type result struct {
 	// https://docs.oasis-open.org/sarif/sarif/v2.1.0/cs01/sarif-v2.1.0-cs01.html#_Toc16012457.
 	// Not supported by GitHub, but possibly useful.
 	PartialFingerprints partialFingerprints `json:"partialFingerprints,omitempty"`
+	Properties          resultProperties    `json:"properties"`
 }
 
 type automationDetails struct {
@@ -329,6 +342,9 @@ func setRemediation(loc *location, d *checker.CheckDetail) {
 	f := d.Msg.Finding
 	if f != nil && f.Remediation != nil {
 		loc.Message.Text = fmt.Sprintf("%s\nRemediation tip: %s", loc.Message.Text, f.Remediation.Markdown)
+		if f.Remediation.Patch != nil {
+			loc.Message.Text = fmt.Sprintf("%s\n```yaml\n%s```", loc.Message.Text, *f.Remediation.Patch)
+		}
 		loc.HasRemediation = true
 		return
 	}
@@ -499,7 +515,16 @@ func createSARIFRule(checkName, checkID, descURL, longDesc, shortDesc, risk stri
 	}
 }
 
-func createSARIFCheckResult(pos int, checkID, message string, loc *location) result {
+// checkConfidence returns the confidence in the score of a check,
+// using the same levels as the aggregate score.
+func checkConfidence(check *checker.CheckResult) string {
+	if check.Incomplete {
+		return ConfidenceMedium
+	}
+	return ConfidenceHigh
+}
+
+func createSARIFCheckResult(pos int, checkID, message string, loc *location, check *checker.CheckResult) result {
 	t := fmt.Sprintf("%s\nClick Remediation section below to solve this issue", message)
 	if loc.HasRemediation {
 		t = fmt.Sprintf("%s\nClick Remediation section below for further remediation help", message)
@@ -512,9 +537,30 @@ func createSARIFCheckResult(pos int, checkID, message string, loc *location) res
 		RuleIndex: pos,
 		Message:   text{Text: t},
 		Locations: []location{*loc},
+		Properties: resultProperties{
+			Score:      check.Score,
+			Confidence: checkConfidence(check),
+			Algorithm:  algorithmName(check.Algorithm),
+		},
 	}
 }
 
+// defaultSARIFPolicy is used when no policy file is given. It reports any
+// check not getting the maximum score, so that all remediations show up.
+func defaultSARIFPolicy(checks []checker.CheckResult) *spol.ScorecardPolicy {
+	policy := &spol.ScorecardPolicy{
+		Version:  1,
+		Policies: map[string]*spol.CheckPolicy{},
+	}
+	for i := range checks {
+		policy.Policies[checks[i].Name] = &spol.CheckPolicy{
+			Mode:  spol.CheckPolicy_ENFORCED,
+			Score: checker.MaxResultScore,
+		}
+	}
+	return policy
+}
+
 func getCheckPolicyInfo(policy *spol.ScorecardPolicy, name string) (minScore int, enabled bool, err error) {
 	policies := policy.GetPolicies()
 	if _, exists := policies[name]; !exists {
@@ -606,8 +652,15 @@ func createDefaultLocationMessage(check *checker.CheckResult, score int) string
 	return messageWithScore(check.Reason, score)
 }
 
+func messageWithAnnotations(msg string, annotations []string) string {
+	for _, a := range annotations {
+		msg = fmt.Sprintf("%s\nMaintainer annotation: %s", msg, a)
+	}
+	return msg
+}
+
 // AsSARIF outputs ScorecardResult in SARIF 2.1.0 format.
-func (r *ScorecardResult) AsSARIF(showDetails bool, logLevel log.Level,
+func (r *ScorecardResult) AsSARIF(showDetails, showAnnotations bool, logLevel log.Level,
 	writer io.Writer, checkDocs docs.Doc, policy *spol.ScorecardPolicy,
 ) error {
 	//nolint
@@ -617,6 +670,9 @@ func (r *ScorecardResult) AsSARIF(showDetails bool, logLevel log.Level,
 	// https://github.com/microsoft/sarif-tutorials.
 	sarif := createSARIFHeader()
 	runs := make(map[string]*run)
+	if policy == nil {
+		policy = defaultSARIFPolicy(r.Checks)
+	}
 
 	//nolint
 	for _, check := range r.Checks {
@@ -645,6 +701,7 @@ func (r *ScorecardResult) AsSARIF(showDetails bool, logLevel log.Level,
 			doc.GetDocumentationURL(r.Scorecard.CommitSHA),
 			doc.GetDescription(), doc.GetShort(), doc.GetRisk(),
 			doc.GetRemediation(), doc.GetTags())
+		rule.Properties.NotApplicable = check.NotApplicable
 		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
 
 		// Check the policy configuration.
@@ -658,8 +715,8 @@ func (r *ScorecardResult) AsSARIF(showDetails bool, logLevel log.Level,
 			continue
 		}
 
-		// Skip check that do not violate the policy.
-		if check.Score >= minScore || check.Score == checker.InconclusiveResultScore {
+		// Skip check that do not violate the policy, or do not apply.
+		if check.NotApplicable || check.Score >= minScore || check.Score == checker.InconclusiveResultScore {
 			continue
 		}
 
@@ -680,18 +737,22 @@ func (r *ScorecardResult) AsSARIF(showDetails bool, logLevel log.Level,
 		// RuleIndex is the position of the corresponding rule in `run.Tool.Driver.Rules`,
 		// so it's the last position for us.
 		RuleIndex := len(run.Tool.Driver.Rules) - 1
+		var annotations []string
+		if showAnnotations {
+			annotations = r.Config.AnnotationsFor(check.Name)
+		}
 		if len(locs) == 0 {
 			// Note: this is not a valid URI but GitHub still accepts it.
 			// See https://sarifweb.azurewebsites.net/Validation to test verification.
 			locs = addDefaultLocation(locs, "no file associated with this alert")
-			msg := createDefaultLocationMessage(&check, check.Score)
-			cr := createSARIFCheckResult(RuleIndex, sarifCheckID, msg, &locs[0])
+			msg := messageWithAnnotations(createDefaultLocationMessage(&check, check.Score), annotations)
+			cr := createSARIFCheckResult(RuleIndex, sarifCheckID, msg, &locs[0], &check)
 			run.Results = append(run.Results, cr)
 		} else {
 			for _, loc := range locs {
 				// Use the location's message (check's detail's message) as message.
-				msg := messageWithScore(loc.Message.Text, check.Score)
-				cr := createSARIFCheckResult(RuleIndex, sarifCheckID, msg, &loc)
+				msg := messageWithAnnotations(messageWithScore(loc.Message.Text, check.Score), annotations)
+				cr := createSARIFCheckResult(RuleIndex, sarifCheckID, msg, &loc, &check)
 				run.Results = append(run.Results, cr)
 			}
 		}
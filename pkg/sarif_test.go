@@ -16,6 +16,7 @@ package pkg
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -846,7 +847,7 @@ func TestSARIFOutput(t *testing.T) {
 			}
 
 			var result bytes.Buffer
-			err = tt.result.AsSARIF(tt.showDetails, tt.logLevel, &result,
+			err = tt.result.AsSARIF(tt.showDetails, false, tt.logLevel, &result,
 				checkDocs, &tt.policy)
 			if err != nil {
 				t.Fatalf("%s: AsSARIF: %v", tt.name, err)
@@ -859,3 +860,67 @@ func TestSARIFOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestSARIFOutputWithoutPolicy(t *testing.T) {
+	t.Parallel()
+	scorecardResult := ScorecardResult{
+		Checks: []checker.CheckResult{
+			{Name: "Check-Name", Score: checker.MaxResultScore, Reason: "max score reason"},
+			{Name: "Check-Name2", Score: 4, Reason: "low score reason", Incomplete: true},
+		},
+	}
+	var out bytes.Buffer
+	if err := scorecardResult.AsSARIF(false, false, log.DefaultLevel, &out, sarifMockDocRead(), nil); err != nil {
+		t.Fatalf("AsSARIF: %v", err)
+	}
+	var got sarif210
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	var results []result
+	for i := range got.Runs {
+		results = append(results, got.Runs[i].Results...)
+	}
+	// Only the check without the maximum score is reported.
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	want := resultProperties{Score: 4, Confidence: ConfidenceMedium}
+	if results[0].Properties != want {
+		t.Errorf("got properties %+v, want %+v", results[0].Properties, want)
+	}
+}
+
+func TestSARIFOutputNotApplicable(t *testing.T) {
+	t.Parallel()
+	scorecardResult := ScorecardResult{
+		Checks: []checker.CheckResult{
+			checker.CreateNotApplicableResult("Check-Name", "reason"),
+			{Name: "Check-Name2", Score: 4, Reason: "low score reason"},
+		},
+	}
+	var out bytes.Buffer
+	if err := scorecardResult.AsSARIF(false, false, log.DefaultLevel, &out, sarifMockDocRead(), nil); err != nil {
+		t.Fatalf("AsSARIF: %v", err)
+	}
+	var got sarif210
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	notApplicable := map[string]bool{}
+	var results []result
+	for i := range got.Runs {
+		for _, r := range got.Runs[i].Tool.Driver.Rules {
+			notApplicable[r.Name] = r.Properties.NotApplicable
+		}
+		results = append(results, got.Runs[i].Results...)
+	}
+	want := map[string]bool{"Check-Name": true, "Check-Name2": false}
+	if diff := cmp.Diff(want, notApplicable); diff != "" {
+		t.Errorf("rules not applicable mismatch (-want +got):\n%s", diff)
+	}
+	// The check which does not apply is not reported.
+	if len(results) != 1 || results[0].Properties.Score != 4 {
+		t.Errorf("got results %+v, want the one of Check-Name2", results)
+	}
+}
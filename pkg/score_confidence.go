@@ -0,0 +1,107 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/docs/checks"
+)
+
+// Confidence levels of the aggregate score.
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+// Completeness thresholds of the confidence levels.
+const (
+	highConfidenceCompleteness   = 0.9
+	mediumConfidenceCompleteness = 0.6
+)
+
+// ScoreConfidence indicates how much of the data behind the aggregate score
+// was available, so that a solid 7.5 can be told apart from a 7.5 with half
+// the checks inconclusive.
+type ScoreConfidence struct {
+	// Level is one of ConfidenceHigh, ConfidenceMedium or ConfidenceLow.
+	Level string
+	// Inconclusive are the checks that did not contribute to the aggregate
+	// score because they were inconclusive or failed.
+	Inconclusive []string
+	// Incomplete are the checks scored on incomplete data, e.g. settings the
+	// credentials used could not read.
+	Incomplete []string
+	// Completeness is the share of the risk weight of the checks run that
+	// contributed to the aggregate score, from 0 to 1. Checks scored on
+	// incomplete data count for half their weight.
+	Completeness float64
+	// Lower and Upper bound the aggregate score had the inconclusive checks
+	// scored the minimum or the maximum score.
+	Lower float64
+	Upper float64
+}
+
+// GetScoreConfidence returns the confidence in the aggregate score.
+func (r *ScorecardResult) GetScoreConfidence(checkDocs checks.Doc) (ScoreConfidence, error) {
+	var ret ScoreConfidence
+	var total, conclusive, complete, score float64
+	for i := range r.Checks {
+		check := &r.Checks[i]
+		// Checks which do not apply to the repo leave no gap in the score.
+		if check.NotApplicable {
+			continue
+		}
+		weight, err := r.checkWeight(checkDocs, check.Name)
+		if err != nil {
+			return ScoreConfidence{}, err
+		}
+		total += weight
+
+		// This indicates an inconclusive score.
+		if check.Score < checker.MinResultScore {
+			ret.Inconclusive = append(ret.Inconclusive, check.Name)
+			continue
+		}
+		conclusive += weight
+		score += weight * float64(check.Score)
+		if check.Incomplete {
+			ret.Incomplete = append(ret.Incomplete, check.Name)
+			complete += weight / 2
+			continue
+		}
+		complete += weight
+	}
+
+	if total == 0 {
+		return ScoreConfidence{
+			Level: ConfidenceLow,
+			Lower: checker.InconclusiveResultScore,
+			Upper: checker.InconclusiveResultScore,
+		}, nil
+	}
+	ret.Completeness = complete / total
+	ret.Lower = score / total
+	ret.Upper = (score + (total-conclusive)*checker.MaxResultScore) / total
+	switch {
+	case ret.Completeness >= highConfidenceCompleteness:
+		ret.Level = ConfidenceHigh
+	case ret.Completeness >= mediumConfidenceCompleteness:
+		ret.Level = ConfidenceMedium
+	default:
+		ret.Level = ConfidenceLow
+	}
+	return ret, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+func TestGetScoreConfidence(t *testing.T) {
+	t.Parallel()
+	incomplete := checker.CreateResultWithScore("Check-Name2", "reason", 6)
+	incomplete.Incomplete = true
+	tests := []struct {
+		name   string
+		checks []checker.CheckResult
+		want   ScoreConfidence
+	}{
+		{
+			name: "all checks conclusive",
+			checks: []checker.CheckResult{
+				checker.CreateResultWithScore("Check-Name", "reason", 8),
+				checker.CreateResultWithScore("Check-Name2", "reason", 5),
+			},
+			want: ScoreConfidence{
+				Level:        ConfidenceHigh,
+				Completeness: 1,
+				Lower:        6.8,
+				Upper:        6.8,
+			},
+		},
+		{
+			name: "not applicable checks",
+			checks: []checker.CheckResult{
+				checker.CreateResultWithScore("Check-Name", "reason", 8),
+				checker.CreateResultWithScore("Check-Name2", "reason", 5),
+				checker.CreateNotApplicableResult("Check-Name3", "reason"),
+			},
+			want: ScoreConfidence{
+				Level:        ConfidenceHigh,
+				Completeness: 1,
+				Lower:        6.8,
+				Upper:        6.8,
+			},
+		},
+		{
+			name: "inconclusive and incomplete checks",
+			checks: []checker.CheckResult{
+				checker.CreateResultWithScore("Check-Name", "reason", 8),
+				incomplete,
+				checker.CreateInconclusiveResult("Check-Name3", "reason"),
+			},
+			want: ScoreConfidence{
+				Level:        ConfidenceMedium,
+				Inconclusive: []string{"Check-Name3"},
+				Incomplete:   []string{"Check-Name2"},
+				Completeness: 10.0 / 15,
+				Lower:        6,
+				Upper:        115.0 / 15,
+			},
+		},
+		{
+			name: "mostly failing checks",
+			checks: []checker.CheckResult{
+				//nolint:goerr113
+				checker.CreateRuntimeErrorResult("Check-Name", errors.New("error")),
+				checker.CreateResultWithScore("Check-Name2", "reason", 10),
+			},
+			want: ScoreConfidence{
+				Level:        ConfidenceLow,
+				Inconclusive: []string{"Check-Name"},
+				Completeness: 0.4,
+				Lower:        50.0 / 12.5,
+				Upper:        10,
+			},
+		},
+		{
+			name: "no checks",
+			want: ScoreConfidence{
+				Level: ConfidenceLow,
+				Lower: checker.InconclusiveResultScore,
+				Upper: checker.InconclusiveResultScore,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := ScorecardResult{Checks: tt.checks}
+			got, err := r.GetScoreConfidence(jsonMockDocRead())
+			if err != nil {
+				t.Fatalf("GetScoreConfidence: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("GetScoreConfidence() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -16,23 +16,51 @@
 package pkg
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
-	"sigs.k8s.io/release-utils/version"
-
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/checks"
 	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/githubrepo"
+	"github.com/ossf/scorecard/v4/clients/registry"
+	"github.com/ossf/scorecard/v4/config"
 	sce "github.com/ossf/scorecard/v4/errors"
+	"github.com/ossf/scorecard/v4/log"
 )
 
+// MetadataInvalidConfig marks results for a repo whose scorecard config
+// could not be parsed and was ignored.
+const MetadataInvalidConfig = "invalid-config"
+
+// MetadataAsOf, followed by the date, marks results for a repo scored as of
+// a past date, at the commit its default branch was at then.
+const MetadataAsOf = "as-of="
+
+// newOrgRepoClient returns the factory for clients of the repos of the owner
+// of the repo, e.g. its .github repo with default community health files.
+// TODO(1491): Make this non-GitHub specific.
+func newOrgRepoClient(ctx context.Context) func() clients.RepoClient {
+	return func() clients.RepoClient {
+		return githubrepo.CreateGithubRepoClient(ctx, log.NewLogger(log.InfoLevel))
+	}
+}
+
+// newPackageClient returns the client of the registry of the ecosystem.
+func newPackageClient(ecosystem registry.Ecosystem) (registry.PackageClient, error) {
+	//nolint:wrapcheck
+	return registry.NewPackageClient(ecosystem, nil)
+}
+
 func runEnabledChecks(ctx context.Context,
 	repo clients.Repo, raw *checker.RawResults, checksToRun checker.CheckNameToFnMap,
 	repoClient clients.RepoClient, ossFuzzRepoClient clients.RepoClient, ciiClient clients.CIIBestPracticesClient,
-	vulnsClient clients.VulnerabilitiesClient,
+	vulnsClient clients.VulnerabilitiesClient, cfg *runConfig,
 	resultsCh chan checker.CheckResult,
 ) {
 	request := checker.CheckRequest{
@@ -43,11 +71,20 @@ func runEnabledChecks(ctx context.Context,
 		VulnerabilitiesClient: vulnsClient,
 		Repo:                  repo,
 		RawResults:            raw,
+		NewOrgRepoClient:      newOrgRepoClient(ctx),
+		WorkflowRules:         cfg.workflowRules,
+		Denylist:              cfg.denylist,
+		Package:               cfg.pkg,
+		NewPackageClient:      newPackageClient,
+		FetchChecksum:         clients.FetchChecksum,
+	}
+	if cfg.newActionTags != nil {
+		request.ActionTags = cfg.newActionTags()
 	}
 	wg := sync.WaitGroup{}
 	for checkName, checkFn := range checksToRun {
 		checkName := checkName
-		checkFn := checkFn
+		checkFn := checks.WithCustomProbes(checkName, checkFn, cfg.customProbes)
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
@@ -80,6 +117,80 @@ func getRepoCommitHash(r clients.RepoClient) (string, error) {
 	return "", nil
 }
 
+// sampledCommits returns the window of commits analyzed by commit-based checks,
+// or nil if none of checksToRun lists commits or the client cannot list them.
+func sampledCommits(r clients.RepoClient, commitDepth int, checksToRun checker.CheckNameToFnMap,
+	logger *log.Logger,
+) *CommitWindow {
+	if !listsCommits(checksToRun) {
+		return nil
+	}
+	commits, err := r.ListCommits()
+	if err != nil {
+		if !errors.Is(err, clients.ErrUnsupportedFeature) {
+			logger.Error(err, "listing the sampled commits")
+		}
+		return nil
+	}
+	if len(commits) == 0 {
+		return nil
+	}
+	if commitDepth <= 0 {
+		commitDepth = clients.DefaultCommitDepth
+	}
+	return &CommitWindow{
+		Newest: commits[0].SHA,
+		Oldest: commits[len(commits)-1].SHA,
+		Depth:  commitDepth,
+		Count:  len(commits),
+	}
+}
+
+// listsCommits returns true if any of checksToRun lists the commits of the repo.
+func listsCommits(checksToRun checker.CheckNameToFnMap) bool {
+	for _, check := range checksToRun {
+		for _, input := range check.Metadata.Inputs {
+			if input == "RepoClient.ListCommits" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readRepoConfig returns the scorecard config committed to the repo, if any.
+// A config which cannot be parsed is ignored so that it cannot fail the run;
+// ok is false in that case.
+func readRepoConfig(r clients.RepoClient) (c config.Config, ok bool) {
+	for _, file := range config.Files {
+		content, err := r.GetFileContent(file)
+		if err != nil {
+			continue
+		}
+		c, err := config.Parse(bytes.NewReader(content))
+		if err != nil {
+			return config.Config{}, false
+		}
+		return c, true
+	}
+	return config.Config{}, true
+}
+
+// canonicalRepoInfo names the repo as reported by the client after InitRepo,
+// which follows renames and transfers. The originally requested name is
+// recorded too when it differs from the canonical one.
+func canonicalRepoInfo(repo clients.Repo, repoClient clients.RepoClient, commitSHA string) RepoInfo {
+	info := RepoInfo{
+		Name:      repo.URI(),
+		CommitSHA: commitSHA,
+	}
+	if canonical := repoClient.URI(); canonical != "" && canonical != info.Name {
+		info.RequestedName = info.Name
+		info.Name = canonical
+	}
+	return info
+}
+
 // RunScorecard runs enabled Scorecard checks on a Repo.
 func RunScorecard(ctx context.Context,
 	repo clients.Repo,
@@ -90,7 +201,10 @@ func RunScorecard(ctx context.Context,
 	ossFuzzRepoClient clients.RepoClient,
 	ciiClient clients.CIIBestPracticesClient,
 	vulnsClient clients.VulnerabilitiesClient,
+	opts ...Option,
 ) (ScorecardResult, error) {
+	startedAt := time.Now()
+	cfg := newRunConfig(opts)
 	if err := repoClient.InitRepo(repo, commitSHA, commitDepth); err != nil {
 		// No need to call sce.WithMessage() since InitRepo will do that for us.
 		//nolint:wrapcheck
@@ -102,24 +216,95 @@ func RunScorecard(ctx context.Context,
 	if err != nil || commitSHA == "" {
 		return ScorecardResult{}, err
 	}
-	versionInfo := version.GetVersionInfo()
+	policyResult, err := applyRepoPolicies(cfg, repoClient)
+	if err != nil {
+		return ScorecardResult{}, err
+	}
+	repoInfo := canonicalRepoInfo(repo, repoClient, commitSHA)
+	if policyResult.parent != nil {
+		// Score the parent of the fork instead, at its HEAD since the
+		// requested commit belongs to the fork.
+		requestedName := repo.URI()
+		if err := repoClient.Close(); err != nil {
+			return ScorecardResult{}, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Close: %v", err))
+		}
+		repo = policyResult.parent
+		if err := repoClient.InitRepo(repo, clients.HeadSHA, commitDepth); err != nil {
+			//nolint:wrapcheck
+			return ScorecardResult{}, err
+		}
+		commitSHA, err = getRepoCommitHash(repoClient)
+		if err != nil || commitSHA == "" {
+			return ScorecardResult{}, err
+		}
+		repoInfo = canonicalRepoInfo(repo, repoClient, commitSHA)
+		repoInfo.RequestedName = requestedName
+	}
+	repoInfo.Commits = sampledCommits(repoClient, commitDepth, checksToRun, cfg.logger)
+
+	key, cacheable := resultCacheKey(&cfg, &repoInfo, checksToRun, commitDepth)
+	if cacheable {
+		cached, err := getCachedResult(ctx, cfg.cache, key)
+		if err != nil {
+			return ScorecardResult{}, err
+		}
+		if cached != nil {
+			if err := addQuarantine(&cfg, repo, repoClient, cached); err != nil {
+				return ScorecardResult{}, err
+			}
+			return *cached, nil
+		}
+	}
+
+	versionInfo := cfg.version
 	ret := ScorecardResult{
-		Repo: RepoInfo{
-			Name:      repo.URI(),
-			CommitSHA: commitSHA,
-		},
+		Repo: repoInfo,
 		Scorecard: ScorecardInfo{
 			Version:   versionInfo.GitVersion,
 			CommitSHA: versionInfo.GitCommit,
 		},
-		Date: time.Now(),
+		Date:     time.Now(),
+		Metadata: policyResult.metadata,
+	}
+	repoConfig, ok := readRepoConfig(repoClient)
+	if !ok {
+		ret.Metadata = append(ret.Metadata, MetadataInvalidConfig)
 	}
+	ret.Config = repoConfig
+	ret.Provenance = newProvenance(&cfg, checksToRun, commitDepth, &repoConfig, startedAt)
+	if policyResult.skip {
+		ret.Provenance.FinishedAt = time.Now()
+		if err := addQuarantine(&cfg, repo, repoClient, &ret); err != nil {
+			return ScorecardResult{}, err
+		}
+		return ret, nil
+	}
+	checksToRun, skipped, metadata := skipNotApplicableChecks(repoClient, checksToRun)
+	ret.Metadata = append(ret.Metadata, metadata...)
+	ret.Checks = append(ret.Checks, skipped...)
+	progress := startProgress(&cfg, repo.URI(), len(checksToRun))
 	resultsCh := make(chan checker.CheckResult)
 	go runEnabledChecks(ctx, repo, &ret.RawResults, checksToRun, repoClient, ossFuzzRepoClient,
-		ciiClient, vulnsClient, resultsCh)
+		ciiClient, vulnsClient, &cfg, resultsCh)
 
 	for result := range resultsCh {
 		ret.Checks = append(ret.Checks, result)
+		progress.checkDone(result.Name)
+	}
+	progress.finish()
+	if notRun := probesNotRun(cfg.customProbes, checksToRun, ret.Checks); len(notRun) > 0 {
+		ret.Metadata = append(ret.Metadata, MetadataProbesNotRun+strings.Join(notRun, ","))
+	}
+	addPreviousScores(checksToRun, ret.Checks, &ret.RawResults, cfg.version.GitVersion)
+	ret.RawResults.Experiments = runExperiments(cfg.experiments, ret.Checks, &ret.RawResults)
+	ret.Provenance.FinishedAt = time.Now()
+	if cacheable {
+		if err := putCachedResult(ctx, cfg.cache, key, &ret); err != nil {
+			return ScorecardResult{}, err
+		}
+	}
+	if err := addQuarantine(&cfg, repo, repoClient, &ret); err != nil {
+		return ScorecardResult{}, err
 	}
 	return ret, nil
 }
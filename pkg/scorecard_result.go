@@ -18,11 +18,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/olekukonko/tablewriter"
 
 	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/config"
 	"github.com/ossf/scorecard/v4/docs/checks"
 	sce "github.com/ossf/scorecard/v4/errors"
 	"github.com/ossf/scorecard/v4/log"
@@ -38,8 +40,31 @@ type ScorecardInfo struct {
 
 // RepoInfo contains information about the repo that was analyzed.
 type RepoInfo struct {
+	// Name is the canonical name of the repo, after following renames and transfers.
 	Name      string
 	CommitSHA string
+	// RequestedName is the name the scan was requested for, if different from Name.
+	RequestedName string
+	// Commits is the window of commits sampled by commit-based checks, if known.
+	Commits *CommitWindow
+	// Mirrors are the names of the mirrors of the repo on other forges whose
+	// results were merged with the results of the repo.
+	Mirrors []string
+}
+
+// CommitWindow describes the commits sampled by checks which analyze the
+// recent history of a repo. Running Scorecard again with the same commit and
+// depth analyzes the same commits.
+type CommitWindow struct {
+	// Newest is the commit the window starts from.
+	Newest string
+	// Oldest is the last commit of the window.
+	Oldest string
+	// Depth is the requested number of commits.
+	Depth int
+	// Count is the number of commits sampled, which is less than Depth
+	// for repos with a shorter history.
+	Count int
 }
 
 // ScorecardResult struct is returned on a successful Scorecard run.
@@ -51,8 +76,18 @@ type ScorecardResult struct {
 	Checks     []checker.CheckResult
 	RawResults checker.RawResults
 	Metadata   []string
+	// Config is the scorecard config committed to the repo.
+	Config config.Config
+	// Weights replace the risk weights of checks in the aggregate score,
+	// e.g. those of a Preset.
+	Weights map[string]float64
+	// Provenance describes how the result was produced.
+	Provenance *Provenance
 }
 
+// riskWeights are the weights of the checks in the aggregate score by risk.
+var riskWeights = map[string]float64{"Critical": 10, "High": 7.5, "Medium": 5, "Low": 2.5}
+
 func scoreToString(s float64) string {
 	if s == checker.InconclusiveResultScore {
 		return "?"
@@ -64,23 +99,14 @@ func scoreToString(s float64) string {
 func (r *ScorecardResult) GetAggregateScore(checkDocs checks.Doc) (float64, error) {
 	// TODO: calculate the score and make it a field
 	// of ScorecardResult
-	weights := map[string]float64{"Critical": 10, "High": 7.5, "Medium": 5, "Low": 2.5}
 	// Note: aggregate score changes depending on which checks are run.
 	total := float64(0)
 	score := float64(0)
 	for i := range r.Checks {
 		check := r.Checks[i]
-		doc, e := checkDocs.GetCheck(check.Name)
-		if e != nil {
-			return checker.InconclusiveResultScore,
-				sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetCheck: %s: %v", check.Name, e))
-		}
-
-		risk := doc.GetRisk()
-		rs, exists := weights[risk]
-		if !exists {
-			return checker.InconclusiveResultScore,
-				sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Invalid risk for %s: '%s'", check.Name, risk))
+		rs, err := r.checkWeight(checkDocs, check.Name)
+		if err != nil {
+			return checker.InconclusiveResultScore, err
 		}
 
 		// This indicates an inconclusive score.
@@ -92,6 +118,10 @@ func (r *ScorecardResult) GetAggregateScore(checkDocs checks.Doc) (float64, erro
 		score += rs * float64(check.Score)
 	}
 
+	// Quarantined repos are high risk, whatever their checks say.
+	if isQuarantined(r.Metadata) {
+		return checker.MinResultScore, nil
+	}
 	// Inconclusive result.
 	if total == 0 {
 		return checker.InconclusiveResultScore, nil
@@ -106,21 +136,36 @@ func FormatResults(
 	results *ScorecardResult,
 	doc checks.Doc,
 	policy *spol.ScorecardPolicy,
+) error {
+	return FormatResultsTo(os.Stdout, opts, results, doc, policy)
+}
+
+// FormatResultsTo formats scorecard results to writer.
+func FormatResultsTo(
+	writer io.Writer,
+	opts *options.Options,
+	results *ScorecardResult,
+	doc checks.Doc,
+	policy *spol.ScorecardPolicy,
 ) error {
 	var err error
 
 	switch opts.Format {
 	case options.FormatDefault:
-		err = results.AsString(opts.ShowDetails, log.ParseLevel(opts.LogLevel), doc, os.Stdout)
+		err = results.AsString(opts.ShowDetails, opts.ShowAnnotations, log.ParseLevel(opts.LogLevel), doc, writer)
 	case options.FormatSarif:
 		// TODO: support config files and update checker.MaxResultScore.
-		err = results.AsSARIF(opts.ShowDetails, log.ParseLevel(opts.LogLevel), os.Stdout, doc, policy)
+		err = results.AsSARIF(opts.ShowDetails, opts.ShowAnnotations, log.ParseLevel(opts.LogLevel), writer, doc, policy)
 	case options.FormatJSON:
-		err = results.AsJSON2(opts.ShowDetails, log.ParseLevel(opts.LogLevel), doc, os.Stdout)
+		err = results.AsJSON2(opts.ShowDetails, opts.ShowAnnotations, log.ParseLevel(opts.LogLevel), doc, writer)
 	case options.FormatSJSON:
-		err = results.AsSJSON(opts.ShowDetails, log.ParseLevel(opts.LogLevel), doc, os.Stdout)
+		err = results.AsSJSON(opts.ShowDetails, opts.ShowAnnotations, log.ParseLevel(opts.LogLevel), doc, writer)
 	case options.FormatRaw:
-		err = results.AsRawJSON(os.Stdout)
+		err = results.AsRawJSON(writer)
+	case options.FormatPlan:
+		err = results.AsPlan(doc, writer)
+	case options.FormatTFData:
+		err = results.AsTFData(doc, writer)
 	default:
 		err = sce.WithMessage(
 			sce.ErrScorecardInternal,
@@ -139,27 +184,22 @@ func FormatResults(
 }
 
 // AsString returns ScorecardResult in string format.
-func (r *ScorecardResult) AsString(showDetails bool, logLevel log.Level,
+func (r *ScorecardResult) AsString(showDetails, showAnnotations bool, logLevel log.Level,
 	checkDocs checks.Doc, writer io.Writer,
 ) error {
 	data := make([][]string, len(r.Checks))
 
 	for i, row := range r.Checks {
-		const withdetails = 5
-		const withoutdetails = 4
 		var x []string
 
-		if showDetails {
-			x = make([]string, withdetails)
-		} else {
-			x = make([]string, withoutdetails)
-		}
-
 		// UPGRADEv2: rename variable.
-		if row.Score == checker.InconclusiveResultScore {
-			x[0] = "?"
-		} else {
-			x[0] = fmt.Sprintf("%d / %d", row.Score, checker.MaxResultScore)
+		switch {
+		case row.NotApplicable:
+			x = append(x, "N/A")
+		case row.Score == checker.InconclusiveResultScore:
+			x = append(x, "?")
+		default:
+			x = append(x, fmt.Sprintf("%d / %d", row.Score, checker.MaxResultScore))
 		}
 
 		cdoc, e := checkDocs.GetCheck(row.Name)
@@ -168,17 +208,22 @@ func (r *ScorecardResult) AsString(showDetails bool, logLevel log.Level,
 		}
 
 		doc := cdoc.GetDocumentationURL(r.Scorecard.CommitSHA)
-		x[1] = row.Name
-		x[2] = row.Reason
+		reason := row.Reason
+		if row.Source != "" {
+			reason = fmt.Sprintf("%s (from %s)", row.Reason, row.Source)
+		}
+		x = append(x, row.Name, reason)
 		if showDetails {
 			details, show := detailsToString(row.Details, logLevel)
-			if show {
-				x[3] = details
+			if !show {
+				details = ""
 			}
-			x[4] = doc
-		} else {
-			x[3] = doc
+			x = append(x, details)
 		}
+		if showAnnotations {
+			x = append(x, strings.Join(r.Config.AnnotationsFor(row.Name), "\n"))
+		}
+		x = append(x, doc)
 
 		data[i] = x
 	}
@@ -192,6 +237,14 @@ func (r *ScorecardResult) AsString(showDetails bool, logLevel log.Level,
 		s = "Aggregate score: ?\n\n"
 	}
 	fmt.Fprint(os.Stdout, s)
+	confidence, err := r.GetScoreConfidence(checkDocs)
+	if err != nil {
+		return err
+	}
+	if score != checker.InconclusiveResultScore && confidence.Level != ConfidenceHigh {
+		fmt.Fprintf(os.Stdout, "Confidence: %s (%.0f%% of the checks complete, score between %.1f and %.1f)\n\n",
+			confidence.Level, confidence.Completeness*100, confidence.Lower, confidence.Upper)
+	}
 	fmt.Fprintln(os.Stdout, "Check scores:")
 
 	table := tablewriter.NewWriter(os.Stdout)
@@ -199,6 +252,9 @@ func (r *ScorecardResult) AsString(showDetails bool, logLevel log.Level,
 	if showDetails {
 		header = append(header, "Details")
 	}
+	if showAnnotations {
+		header = append(header, "Annotations")
+	}
 	header = append(header, "Documentation/Remediation")
 	table.SetHeader(header)
 	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
@@ -15,14 +15,18 @@ package pkg
 
 import (
 	"context"
+	"errors"
+	"os"
 	"reflect"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/clients"
 	"github.com/ossf/scorecard/v4/clients/localdir"
 	mockrepo "github.com/ossf/scorecard/v4/clients/mockclients"
+	"github.com/ossf/scorecard/v4/config"
 	"github.com/ossf/scorecard/v4/log"
 )
 
@@ -118,6 +122,79 @@ func Test_getRepoCommitHashLocal(t *testing.T) {
 	}
 }
 
+func Test_sampledCommits(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err         error
+		want        *CommitWindow
+		name        string
+		commits     []clients.Commit
+		commitDepth int
+		// noCommitChecks runs no check listing commits.
+		noCommitChecks bool
+	}{
+		{
+			name:        "default depth",
+			commits:     []clients.Commit{{SHA: "c3"}, {SHA: "c2"}, {SHA: "c1"}},
+			commitDepth: 0,
+			want: &CommitWindow{
+				Newest: "c3",
+				Oldest: "c1",
+				Depth:  clients.DefaultCommitDepth,
+				Count:  3,
+			},
+		},
+		{
+			name:        "custom depth",
+			commits:     []clients.Commit{{SHA: "c2"}, {SHA: "c1"}},
+			commitDepth: 2,
+			want: &CommitWindow{
+				Newest: "c2",
+				Oldest: "c1",
+				Depth:  2,
+				Count:  2,
+			},
+		},
+		{
+			name: "no commits",
+		},
+		{
+			name: "unsupported",
+			err:  clients.ErrUnsupportedFeature,
+		},
+		{
+			name: "error",
+			err:  errors.New("transient"), //nolint:goerr113
+		},
+		{
+			name:           "no check listing commits",
+			commits:        []clients.Commit{{SHA: "c1"}},
+			noCommitChecks: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			checksToRun := checker.CheckNameToFnMap{"Binary-Artifacts": {}}
+			if !tt.noCommitChecks {
+				checksToRun["Maintained"] = checker.Check{
+					Metadata: checker.CheckMetadata{Inputs: []string{"RepoClient.ListCommits"}},
+				}
+				mockRepoClient.EXPECT().ListCommits().Return(tt.commits, tt.err)
+			}
+
+			got := sampledCommits(mockRepoClient, tt.commitDepth, checksToRun, log.NewLogger(log.DefaultLevel))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sampledCommits() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRunScorecard(t *testing.T) {
 	t.Parallel()
 	type args struct {
@@ -174,3 +251,128 @@ func TestRunScorecard(t *testing.T) {
 		})
 	}
 }
+
+func Test_canonicalRepoInfo(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		requestedURI string
+		canonicalURI string
+		want         RepoInfo
+	}{
+		{
+			name:         "same name",
+			requestedURI: "github.com/ossf/scorecard",
+			canonicalURI: "github.com/ossf/scorecard",
+			want: RepoInfo{
+				Name:      "github.com/ossf/scorecard",
+				CommitSHA: "abcdef",
+			},
+		},
+		{
+			name:         "renamed repo",
+			requestedURI: "github.com/ossf/old-name",
+			canonicalURI: "github.com/ossf/scorecard",
+			want: RepoInfo{
+				Name:          "github.com/ossf/scorecard",
+				CommitSHA:     "abcdef",
+				RequestedName: "github.com/ossf/old-name",
+			},
+		},
+		{
+			name:         "client without canonical name",
+			requestedURI: "github.com/ossf/scorecard",
+			canonicalURI: "",
+			want: RepoInfo{
+				Name:      "github.com/ossf/scorecard",
+				CommitSHA: "abcdef",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			repo := mockrepo.NewMockRepo(ctrl)
+			repo.EXPECT().URI().Return(tt.requestedURI).AnyTimes()
+			mockRepoClient.EXPECT().URI().Return(tt.canonicalURI).AnyTimes()
+
+			got := canonicalRepoInfo(repo, mockRepoClient, "abcdef")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("canonicalRepoInfo() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readRepoConfig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		files  map[string]string
+		name   string
+		want   config.Config
+		wantOK bool
+	}{
+		{
+			name:   "no config",
+			wantOK: true,
+		},
+		{
+			name: "config in .github",
+			files: map[string]string{
+				".github/scorecard.yml": "annotations:\n  - checks: [Binary-Artifacts]\n    reason: test fixtures\n",
+			},
+			want: config.Config{
+				Annotations: []config.Annotation{
+					{Checks: []string{"Binary-Artifacts"}, Reason: "test fixtures"},
+				},
+			},
+			wantOK: true,
+		},
+		{
+			name: "first config wins",
+			files: map[string]string{
+				"scorecard.yml":         "annotations:\n  - checks: [Code-Review]\n    reason: single maintainer\n",
+				".github/scorecard.yml": "annotations:\n  - checks: [Binary-Artifacts]\n    reason: test fixtures\n",
+			},
+			want: config.Config{
+				Annotations: []config.Annotation{
+					{Checks: []string{"Code-Review"}, Reason: "single maintainer"},
+				},
+			},
+			wantOK: true,
+		},
+		{
+			name: "invalid config",
+			files: map[string]string{
+				"scorecard.yml": "annotations: not-a-list\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(func(file string) ([]byte, error) {
+				content, ok := tt.files[file]
+				if !ok {
+					return nil, os.ErrNotExist
+				}
+				return []byte(content), nil
+			}).AnyTimes()
+
+			got, ok := readRepoConfig(mockRepoClient)
+			if ok != tt.wantOK {
+				t.Errorf("readRepoConfig() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readRepoConfig() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+)
+
+// SecurityContactType is the kind of a SecurityContact.
+type SecurityContactType string
+
+const (
+	// SecurityContactEmail is an email address.
+	SecurityContactEmail SecurityContactType = "email"
+	// SecurityContactURL is a web page, e.g. a vulnerability reporting form.
+	SecurityContactURL SecurityContactType = "url"
+)
+
+// SecurityContact is where the vulnerabilities of a repo are to be reported.
+type SecurityContact struct {
+	// Value is the email address or URL of the contact.
+	Value string
+	Type  SecurityContactType
+	// Source is the file the contact was found in, or the URL of the security
+	// policy reported by the hosting platform.
+	Source string
+}
+
+// reportingURL matches the links of security policies which are likely to be
+// about reporting vulnerabilities, rather than e.g. the docs of the project.
+var reportingURL = regexp.MustCompile(`(?i)security|vulnerab|disclos|advisor|report|bounty|hackerone|bugcrowd`)
+
+// SecurityContact returns the best-known security contact of the repo from
+// the raw results of the Security-Policy check, or nil if none is known. In
+// order of preference, it is an email address of the security policy of the
+// repo, the first contact of its security.txt file, a reporting link of its
+// security policy, then an email address or reporting link of the security
+// policy of its org, and lastly the security policy reported by the platform.
+func (r *ScorecardResult) SecurityContact() *SecurityContact {
+	sp := &r.RawResults.SecurityPolicyResults
+	var repoFiles, orgFiles []checker.SecurityPolicyFile
+	for i := range sp.PolicyFiles {
+		if sp.PolicyFiles[i].File.Type == finding.FileTypeURL {
+			orgFiles = append(orgFiles, sp.PolicyFiles[i])
+		} else {
+			repoFiles = append(repoFiles, sp.PolicyFiles[i])
+		}
+	}
+
+	if c := policyContact(repoFiles, checker.SecurityPolicyInformationTypeEmail); c != nil {
+		return c
+	}
+	if c := securityTxtContact(sp.SecurityTxt); c != nil {
+		return c
+	}
+	if c := policyContact(repoFiles, checker.SecurityPolicyInformationTypeLink); c != nil {
+		return c
+	}
+	if c := policyContact(orgFiles, checker.SecurityPolicyInformationTypeEmail); c != nil {
+		return c
+	}
+	if c := policyContact(orgFiles, checker.SecurityPolicyInformationTypeLink); c != nil {
+		return c
+	}
+	if sp.PolicyURL != "" {
+		return &SecurityContact{Value: sp.PolicyURL, Type: SecurityContactURL, Source: sp.PolicyURL}
+	}
+	return nil
+}
+
+// policyContact returns the first email address or reporting link of files,
+// depending on infoType.
+func policyContact(files []checker.SecurityPolicyFile,
+	infoType checker.SecurityPolicyInformationType,
+) *SecurityContact {
+	for i := range files {
+		for _, info := range files[i].Information {
+			if info.InformationType != infoType {
+				continue
+			}
+			value := info.InformationValue.Match
+			switch infoType {
+			case checker.SecurityPolicyInformationTypeEmail:
+				return &SecurityContact{Value: value, Type: SecurityContactEmail, Source: files[i].File.Path}
+			case checker.SecurityPolicyInformationTypeLink:
+				if reportingURL.MatchString(value) {
+					return &SecurityContact{Value: value, Type: SecurityContactURL, Source: files[i].File.Path}
+				}
+			case checker.SecurityPolicyInformationTypeText:
+			}
+		}
+	}
+	return nil
+}
+
+// securityTxtContact returns the first email address or web URL among the
+// contacts of a security.txt file. Other contacts, e.g. phone numbers, are
+// skipped.
+func securityTxtContact(f *checker.SecurityTxtFile) *SecurityContact {
+	if f == nil {
+		return nil
+	}
+	for _, contact := range f.Contacts {
+		scheme, value, found := strings.Cut(contact, ":")
+		if !found {
+			continue
+		}
+		switch strings.ToLower(scheme) {
+		case "mailto":
+			return &SecurityContact{Value: value, Type: SecurityContactEmail, Source: f.File.Path}
+		case "https", "http":
+			return &SecurityContact{Value: contact, Type: SecurityContactURL, Source: f.File.Path}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/finding"
+	"github.com/ossf/scorecard/v4/log"
+)
+
+func policyFile(path string, fileType finding.FileType, info ...checker.SecurityPolicyInformation,
+) checker.SecurityPolicyFile {
+	return checker.SecurityPolicyFile{
+		File:        checker.File{Path: path, Type: fileType},
+		Information: info,
+	}
+}
+
+func policyInfo(infoType checker.SecurityPolicyInformationType, match string) checker.SecurityPolicyInformation {
+	return checker.SecurityPolicyInformation{
+		InformationType:  infoType,
+		InformationValue: checker.SecurityPolicyValueType{Match: match},
+	}
+}
+
+func TestSecurityContact(t *testing.T) {
+	t.Parallel()
+	securityTxt := &checker.SecurityTxtFile{
+		File:     checker.File{Path: ".well-known/security.txt"},
+		Contacts: []string{"tel:+1-201-555-0123", "mailto:security@example.org"},
+	}
+	tests := []struct {
+		name string
+		data checker.SecurityPolicyData
+		want *SecurityContact
+	}{
+		{
+			name: "no security policy",
+		},
+		{
+			name: "email of the repo policy",
+			data: checker.SecurityPolicyData{
+				PolicyFiles: []checker.SecurityPolicyFile{
+					policyFile("SECURITY.md", finding.FileTypeText,
+						policyInfo(checker.SecurityPolicyInformationTypeLink, "https://example.org/security"),
+						policyInfo(checker.SecurityPolicyInformationTypeEmail, "security@example.com"),
+					),
+				},
+				SecurityTxt: securityTxt,
+			},
+			want: &SecurityContact{Value: "security@example.com", Type: SecurityContactEmail, Source: "SECURITY.md"},
+		},
+		{
+			name: "security.txt preferred over links",
+			data: checker.SecurityPolicyData{
+				PolicyFiles: []checker.SecurityPolicyFile{
+					policyFile("SECURITY.md", finding.FileTypeText,
+						policyInfo(checker.SecurityPolicyInformationTypeLink, "https://example.org/security"),
+					),
+				},
+				SecurityTxt: securityTxt,
+			},
+			want: &SecurityContact{
+				Value: "security@example.org", Type: SecurityContactEmail, Source: ".well-known/security.txt",
+			},
+		},
+		{
+			name: "reporting link of the repo policy",
+			data: checker.SecurityPolicyData{
+				PolicyFiles: []checker.SecurityPolicyFile{
+					policyFile("SECURITY.md", finding.FileTypeText,
+						policyInfo(checker.SecurityPolicyInformationTypeLink, "https://example.org/docs"),
+						policyInfo(checker.SecurityPolicyInformationTypeLink,
+							"https://github.com/owner/repo/security/advisories/new"),
+						policyInfo(checker.SecurityPolicyInformationTypeText, "90"),
+					),
+				},
+			},
+			want: &SecurityContact{
+				Value:  "https://github.com/owner/repo/security/advisories/new",
+				Type:   SecurityContactURL,
+				Source: "SECURITY.md",
+			},
+		},
+		{
+			name: "org security policy",
+			data: checker.SecurityPolicyData{
+				PolicyFiles: []checker.SecurityPolicyFile{
+					policyFile("github.com/owner/.github/SECURITY.md", finding.FileTypeURL,
+						policyInfo(checker.SecurityPolicyInformationTypeEmail, "security@owner.org"),
+					),
+				},
+			},
+			want: &SecurityContact{
+				Value: "security@owner.org", Type: SecurityContactEmail, Source: "github.com/owner/.github/SECURITY.md",
+			},
+		},
+		{
+			name: "policy reported by the platform",
+			data: checker.SecurityPolicyData{
+				PolicyURL: "https://github.com/owner/repo/security/policy",
+			},
+			want: &SecurityContact{
+				Value:  "https://github.com/owner/repo/security/policy",
+				Type:   SecurityContactURL,
+				Source: "https://github.com/owner/repo/security/policy",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := ScorecardResult{RawResults: checker.RawResults{SecurityPolicyResults: tt.data}}
+			if diff := cmp.Diff(tt.want, r.SecurityContact()); diff != "" {
+				t.Errorf("SecurityContact() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSecurityContactJSON(t *testing.T) {
+	t.Parallel()
+	r := ScorecardResult{RawResults: checker.RawResults{
+		SecurityPolicyResults: checker.SecurityPolicyData{PolicyURL: "https://example.org/security"},
+	}}
+	var buf bytes.Buffer
+	if err := r.AsJSON2(false, false, log.DefaultLevel, nil, &buf); err != nil {
+		t.Fatalf("AsJSON2: %v", err)
+	}
+	var got struct {
+		SecurityContact *jsonSecurityContact `json:"securityContact"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	want := &jsonSecurityContact{
+		Value:  "https://example.org/security",
+		Type:   "url",
+		Source: "https://example.org/security",
+	}
+	if diff := cmp.Diff(want, got.SecurityContact); diff != "" {
+		t.Errorf("securityContact mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/docs/checks"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// TFDataVersion is the version of the --format=tfdata output. It is only
+// increased by changes which break existing consumers, e.g. removing or
+// renaming a key.
+const TFDataVersion = "1"
+
+// TFDataCheckPrefix prefixes the keys of the check scores in the
+// --format=tfdata output, e.g. "check.Code-Review".
+const TFDataCheckPrefix = "check."
+
+// TFData returns the results as the flat map of strings the external data
+// source of Terraform and OpenTofu requires: the version of the format, the
+// repo, commit and date of the scan, the aggregate score, the names of the
+// checks run, the names of those not applicable to the repo, and the score of
+// each check. Inconclusive scores, including those of checks not applicable,
+// are "-1".
+func (r *ScorecardResult) TFData(checkDocs checks.Doc) (map[string]string, error) {
+	score, err := r.GetAggregateScore(checkDocs)
+	if err != nil {
+		return nil, err
+	}
+	ret := map[string]string{
+		"version":           TFDataVersion,
+		"repo":              r.Repo.Name,
+		"commit":            r.Repo.CommitSHA,
+		"date":              r.Date.Format(time.RFC3339),
+		"scorecard_version": r.Scorecard.Version,
+		"score":             tfDataScore(score),
+	}
+	names := make([]string, 0, len(r.Checks))
+	var notApplicable []string
+	for i := range r.Checks {
+		name := r.Checks[i].Name
+		if _, ok := ret[TFDataCheckPrefix+name]; ok {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("duplicate check: %s", name))
+		}
+		names = append(names, name)
+		if r.Checks[i].NotApplicable {
+			notApplicable = append(notApplicable, name)
+		}
+		ret[TFDataCheckPrefix+name] = strconv.Itoa(r.Checks[i].Score)
+	}
+	sort.Strings(names)
+	ret["checks"] = strings.Join(names, ",")
+	sort.Strings(notApplicable)
+	ret["not_applicable"] = strings.Join(notApplicable, ",")
+	return ret, nil
+}
+
+// AsTFData writes the results in the --format=tfdata format.
+func (r *ScorecardResult) AsTFData(checkDocs checks.Doc, writer io.Writer) error {
+	data, err := r.TFData(checkDocs)
+	if err != nil {
+		return err
+	}
+	// Maps are encoded with sorted keys, so the output is stable.
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("encoder.Encode: %v", err))
+	}
+	return nil
+}
+
+func tfDataScore(s float64) string {
+	if s == checker.InconclusiveResultScore {
+		return strconv.Itoa(checker.InconclusiveResultScore)
+	}
+	return fmt.Sprintf("%.1f", s)
+}
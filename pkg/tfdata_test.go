@@ -0,0 +1,82 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+func TestAsTFData(t *testing.T) {
+	t.Parallel()
+	result := ScorecardResult{
+		Repo: RepoInfo{Name: "github.com/org/name", CommitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32"},
+		Scorecard: ScorecardInfo{
+			Version:   "1.2.3",
+			CommitSHA: "ccbc59901773ab4c051dfcea0cc4201a1567ab32",
+		},
+		Date: time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+		Checks: []checker.CheckResult{
+			{Name: "Check-Name", Score: 4},
+			checker.CreateNotApplicableResult("Check-Name3", "reason"),
+			{Name: "Check-Name2", Score: checker.MaxResultScore},
+		},
+	}
+	var buf bytes.Buffer
+	if err := result.AsTFData(jsonMockDocRead(), &buf); err != nil {
+		t.Fatalf("AsTFData() = %v", err)
+	}
+	// The external data source of Terraform only accepts strings.
+	var got map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal() = %v", err)
+	}
+	want := map[string]string{
+		"version":           "1",
+		"repo":              "github.com/org/name",
+		"commit":            "68bc59901773ab4c051dfcea0cc4201a1567ab32",
+		"date":              "2023-05-01T12:00:00Z",
+		"scorecard_version": "1.2.3",
+		"score":             "6.4",
+		"checks":            "Check-Name,Check-Name2,Check-Name3",
+		"not_applicable":    "Check-Name3",
+		"check.Check-Name":  "4",
+		"check.Check-Name2": "10",
+		"check.Check-Name3": "-1",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("AsTFData() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAsTFDataInconclusive(t *testing.T) {
+	t.Parallel()
+	result := ScorecardResult{
+		Checks: []checker.CheckResult{{Name: "Check-Name", Score: checker.InconclusiveResultScore}},
+	}
+	got, err := result.TFData(jsonMockDocRead())
+	if err != nil {
+		t.Fatalf("TFData() = %v", err)
+	}
+	if got["score"] != "-1" {
+		t.Errorf("score = %q, want -1", got["score"])
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -198,6 +199,41 @@ func GetEnabled(
 	return enabledChecks, nil
 }
 
+// GetUnsupported returns the checks GetEnabled skips because they do not
+// support the required request types, e.g. the checks which need API access
+// when scanning a local directory. Checks named in argsChecks are never
+// skipped: GetEnabled fails if they are unsupported.
+func GetUnsupported(
+	sp *ScorecardPolicy,
+	argsChecks []string,
+	requiredRequestTypes []checker.RequestType,
+) []string {
+	var candidates []string
+	switch {
+	case len(argsChecks) != 0:
+		return nil
+	case sp != nil:
+		for checkName := range sp.GetPolicies() {
+			if _, exists := checks.GetAllWithExperimental()[checkName]; exists {
+				candidates = append(candidates, checkName)
+			}
+		}
+	default:
+		for checkName := range checks.GetAll() {
+			candidates = append(candidates, checkName)
+		}
+	}
+
+	var unsupported []string
+	for _, checkName := range candidates {
+		if !isSupportedCheck(checkName, requiredRequestTypes) {
+			unsupported = append(unsupported, checkName)
+		}
+	}
+	sort.Strings(unsupported)
+	return unsupported
+}
+
 func checksHavePolicies(sp *ScorecardPolicy, enabledChecks checker.CheckNameToFnMap) bool {
 	for checkName := range enabledChecks {
 		_, exists := sp.Policies[checkName]
@@ -209,6 +245,23 @@ func checksHavePolicies(sp *ScorecardPolicy, enabledChecks checker.CheckNameToFn
 	return true
 }
 
+// SplitSupported splits checkNames into the checks which support the required
+// request types and those which do not, e.g. to run the checks of a preset
+// which can run on a local directory and report the others as inconclusive.
+func SplitSupported(
+	checkNames []string,
+	requiredRequestTypes []checker.RequestType,
+) (supported, unsupported []string) {
+	for _, checkName := range checkNames {
+		if isSupportedCheck(checkName, requiredRequestTypes) {
+			supported = append(supported, checkName)
+		} else {
+			unsupported = append(unsupported, checkName)
+		}
+	}
+	return supported, unsupported
+}
+
 func isSupportedCheck(checkName string, requiredRequestTypes []checker.RequestType) bool {
 	unsupported := checker.ListUnsupported(
 		requiredRequestTypes,
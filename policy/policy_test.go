@@ -19,6 +19,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/ossf/scorecard/v4/checker"
 	sce "github.com/ossf/scorecard/v4/errors"
 )
 
@@ -131,3 +132,45 @@ func TestPolicyRead(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUnsupported(t *testing.T) {
+	t.Parallel()
+	fileBased := []checker.RequestType{checker.FileBased}
+
+	all := GetUnsupported(nil, nil, fileBased)
+	if !contains(all, "Branch-Protection") || contains(all, "Binary-Artifacts") {
+		t.Errorf("GetUnsupported() = %v, want API-dependent checks only", all)
+	}
+	if got := GetUnsupported(nil, []string{"Binary-Artifacts"}, fileBased); got != nil {
+		t.Errorf("GetUnsupported() with checks = %v, want none", got)
+	}
+	sp := &ScorecardPolicy{Policies: map[string]*CheckPolicy{
+		"Binary-Artifacts": {},
+		"Code-Review":      {},
+	}}
+	if got := GetUnsupported(sp, nil, fileBased); len(got) != 1 || got[0] != "Code-Review" {
+		t.Errorf("GetUnsupported() with policy = %v, want [Code-Review]", got)
+	}
+}
+
+func TestSplitSupported(t *testing.T) {
+	t.Parallel()
+	supported, unsupported := SplitSupported(
+		[]string{"Binary-Artifacts", "Branch-Protection", "License"},
+		[]checker.RequestType{checker.FileBased})
+	if len(supported) != 2 || supported[0] != "Binary-Artifacts" || supported[1] != "License" {
+		t.Errorf("SplitSupported() supported = %v, want [Binary-Artifacts License]", supported)
+	}
+	if len(unsupported) != 1 || unsupported[0] != "Branch-Protection" {
+		t.Errorf("SplitSupported() unsupported = %v, want [Branch-Protection]", unsupported)
+	}
+}
+
+func contains(l []string, elt string) bool {
+	for _, e := range l {
+		if e == elt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,248 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package portfolio aggregates the Scorecard results of the repos of an
+// organization by the teams owning them, for reports to security leadership.
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/digest"
+)
+
+var errInvalidManifest = errors.New("invalid portfolio manifest")
+
+// Team is a team and the repos it owns.
+type Team struct {
+	Name string `yaml:"name"`
+	// Owners are the contacts of the team, e.g. emails or chat handles.
+	Owners []string `yaml:"owners"`
+	Repos  []string `yaml:"repos"`
+}
+
+// Manifest maps repos to the teams owning them. A repo can be owned by more
+// than one team.
+type Manifest struct {
+	Teams []Team `yaml:"teams"`
+}
+
+// ParseManifest reads and validates a manifest.
+func ParseManifest(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&m); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", errInvalidManifest, err)
+	}
+	if len(m.Teams) == 0 {
+		return nil, fmt.Errorf("%w: no teams", errInvalidManifest)
+	}
+	names := make(map[string]bool)
+	for i, t := range m.Teams {
+		if strings.TrimSpace(t.Name) == "" {
+			return nil, fmt.Errorf("%w: team %d has no name", errInvalidManifest, i)
+		}
+		if names[t.Name] {
+			return nil, fmt.Errorf("%w: team '%s' is defined more than once", errInvalidManifest, t.Name)
+		}
+		names[t.Name] = true
+		if len(t.Repos) == 0 {
+			return nil, fmt.Errorf("%w: team '%s' owns no repos", errInvalidManifest, t.Name)
+		}
+	}
+	return &m, nil
+}
+
+// Repos returns the repos of all teams, each once.
+func (m *Manifest) Repos() []string {
+	var repos []string
+	seen := make(map[string]bool)
+	for i := range m.Teams {
+		for _, repo := range m.Teams[i].Repos {
+			if !seen[repo] {
+				seen[repo] = true
+				repos = append(repos, repo)
+			}
+		}
+	}
+	return repos
+}
+
+// CheckAggregate is the average score of a check over the repos of a team.
+type CheckAggregate struct {
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+	// Failing is the number of repos with a score below the passing score.
+	Failing int `json:"failing"`
+}
+
+// Finding is a low score of a check of a repo.
+type Finding struct {
+	Repo   string `json:"repo"`
+	Check  string `json:"check"`
+	Reason string `json:"reason"`
+	Score  int    `json:"score"`
+}
+
+// RepoScore is the score of a repo of a team. Scores are
+// checker.InconclusiveResultScore if unknown.
+type RepoScore struct {
+	Repo          string  `json:"repo"`
+	Score         float64 `json:"score"`
+	PreviousScore float64 `json:"previousScore"`
+}
+
+// TeamReport aggregates the results of the repos of a team. Scores are
+// checker.InconclusiveResultScore if no repo of the team has results.
+type TeamReport struct {
+	Team   string   `json:"team"`
+	Owners []string `json:"owners,omitempty"`
+	// Repos are sorted by score, lowest first.
+	Repos []RepoScore `json:"repos"`
+	// Missing are the repos without results.
+	Missing []string `json:"missing,omitempty"`
+	// Checks are sorted by name.
+	Checks []CheckAggregate `json:"checks"`
+	// WorstFindings are the lowest scores of checks across the repos.
+	WorstFindings []Finding `json:"worstFindings"`
+	// Score is the average score of the repos with results, and
+	// PreviousScore the average of their previous scores, if any.
+	Score         float64 `json:"score"`
+	PreviousScore float64 `json:"previousScore"`
+}
+
+// Trend returns the change of the score of the team since the previous
+// results, and false if it is unknown.
+func (t *TeamReport) Trend() (float64, bool) {
+	if t.Score == checker.InconclusiveResultScore || t.PreviousScore == checker.InconclusiveResultScore {
+		return 0, false
+	}
+	return t.Score - t.PreviousScore, true
+}
+
+// Report aggregates the results of the repos of a portfolio by team.
+type Report struct {
+	Generated time.Time    `json:"generated"`
+	Teams     []TeamReport `json:"teams"`
+}
+
+// passingScore is the score below which a check is counted as failing.
+const passingScore = 7
+
+// Build aggregates the current results of the repos of the manifest by team,
+// keeping the worst findings of each team up to worst. Previous results are
+// optional and give the trends.
+func Build(m *Manifest, current, previous map[string]*digest.Result, worst int, now time.Time) *Report {
+	r := &Report{Generated: now}
+	for i := range m.Teams {
+		r.Teams = append(r.Teams, buildTeam(&m.Teams[i], current, previous, worst))
+	}
+	return r
+}
+
+func buildTeam(t *Team, current, previous map[string]*digest.Result, worst int) TeamReport {
+	report := TeamReport{
+		Team:          t.Name,
+		Owners:        t.Owners,
+		Score:         checker.InconclusiveResultScore,
+		PreviousScore: checker.InconclusiveResultScore,
+	}
+	type checkSum struct {
+		total, count, failing int
+	}
+	checks := make(map[string]*checkSum)
+	var scores, previousScores []float64
+	var findings []Finding
+	for _, repo := range t.Repos {
+		cur := current[repo]
+		if cur == nil {
+			report.Missing = append(report.Missing, repo)
+			continue
+		}
+		score := RepoScore{Repo: repo, Score: cur.Score, PreviousScore: checker.InconclusiveResultScore}
+		if prev := previous[repo]; prev != nil {
+			score.PreviousScore = prev.Score
+			if prev.Score != checker.InconclusiveResultScore {
+				previousScores = append(previousScores, prev.Score)
+			}
+		}
+		report.Repos = append(report.Repos, score)
+		if cur.Score != checker.InconclusiveResultScore {
+			scores = append(scores, cur.Score)
+		}
+
+		for _, c := range cur.Checks {
+			if c.Score == checker.InconclusiveResultScore {
+				continue
+			}
+			sum, ok := checks[c.Name]
+			if !ok {
+				sum = &checkSum{}
+				checks[c.Name] = sum
+			}
+			sum.total += c.Score
+			sum.count++
+			if c.Score < passingScore {
+				sum.failing++
+			}
+			if c.Score < checker.MaxResultScore {
+				findings = append(findings, Finding{Repo: repo, Check: c.Name, Reason: c.Reason, Score: c.Score})
+			}
+		}
+	}
+
+	report.Score = average(scores)
+	report.PreviousScore = average(previousScores)
+	sort.SliceStable(report.Repos, func(i, j int) bool {
+		return report.Repos[i].Score < report.Repos[j].Score
+	})
+	for name, sum := range checks {
+		report.Checks = append(report.Checks, CheckAggregate{
+			Name:    name,
+			Score:   float64(sum.total) / float64(sum.count),
+			Failing: sum.failing,
+		})
+	}
+	sort.Slice(report.Checks, func(i, j int) bool {
+		return report.Checks[i].Name < report.Checks[j].Name
+	})
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Score < findings[j].Score
+	})
+	if len(findings) > worst {
+		findings = findings[:worst]
+	}
+	report.WorstFindings = findings
+	return report
+}
+
+func average(scores []float64) float64 {
+	if len(scores) == 0 {
+		return checker.InconclusiveResultScore
+	}
+	var sum float64
+	for _, s := range scores {
+		sum += s
+	}
+	return sum / float64(len(scores))
+}
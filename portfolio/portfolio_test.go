@@ -0,0 +1,182 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package portfolio
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/digest"
+)
+
+func readManifest(t *testing.T, path string) (*Manifest, error) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("os.Open: %v", err)
+	}
+	defer f.Close()
+	return ParseManifest(f)
+}
+
+func TestParseManifest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		file    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			file: "testdata/teams.yml",
+			want: []string{"github.com/acme/pay-api", "github.com/acme/pay-web", "github.com/acme/shared"},
+		},
+		{
+			name:    "team without repos",
+			file:    "testdata/no_repos.yml",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate team",
+			file:    "testdata/duplicate.yml",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			file:    "testdata/unknown_field.yml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m, err := readManifest(t, tt.file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, m.Repos()); diff != "" {
+				t.Errorf("Repos() (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func result(score float64, checks ...digest.CheckResult) *digest.Result {
+	return &digest.Result{Score: score, Checks: checks}
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+	m, err := readManifest(t, "testdata/teams.yml")
+	if err != nil {
+		t.Fatalf("ParseManifest: %v", err)
+	}
+	current := map[string]*digest.Result{
+		"github.com/acme/pay-api": result(4,
+			digest.CheckResult{Name: "Token-Permissions", Score: 0, Reason: "write-all"},
+			digest.CheckResult{Name: "License", Score: 10},
+		),
+		"github.com/acme/shared": result(8,
+			digest.CheckResult{Name: "Token-Permissions", Score: 8, Reason: "one job writes"},
+			digest.CheckResult{Name: "Fuzzing", Score: checker.InconclusiveResultScore},
+		),
+	}
+	previous := map[string]*digest.Result{
+		"github.com/acme/pay-api": result(3),
+		"github.com/acme/shared":  result(7),
+	}
+	now := time.Date(2023, 3, 13, 0, 0, 0, 0, time.UTC)
+	r := Build(m, current, previous, 1, now)
+
+	want := []TeamReport{
+		{
+			Team:   "payments",
+			Owners: []string{"payments-security@example.com"},
+			Repos: []RepoScore{
+				{Repo: "github.com/acme/pay-api", Score: 4, PreviousScore: 3},
+				{Repo: "github.com/acme/shared", Score: 8, PreviousScore: 7},
+			},
+			Missing: []string{"github.com/acme/pay-web"},
+			Checks: []CheckAggregate{
+				{Name: "License", Score: 10},
+				{Name: "Token-Permissions", Score: 4, Failing: 1},
+			},
+			WorstFindings: []Finding{
+				{Repo: "github.com/acme/pay-api", Check: "Token-Permissions", Reason: "write-all", Score: 0},
+			},
+			Score:         6,
+			PreviousScore: 5,
+		},
+		{
+			Team: "platform",
+			Repos: []RepoScore{
+				{Repo: "github.com/acme/shared", Score: 8, PreviousScore: 7},
+			},
+			Checks: []CheckAggregate{
+				{Name: "Token-Permissions", Score: 8},
+			},
+			WorstFindings: []Finding{
+				{Repo: "github.com/acme/shared", Check: "Token-Permissions", Reason: "one job writes", Score: 8},
+			},
+			Score:         8,
+			PreviousScore: 7,
+		},
+	}
+	if diff := cmp.Diff(want, r.Teams); diff != "" {
+		t.Errorf("Build() (-want +got): %s", diff)
+	}
+	if trend, ok := r.Teams[0].Trend(); !ok || trend != 1 {
+		t.Errorf("Trend() = %v, %v, want 1, true", trend, ok)
+	}
+
+	var out bytes.Buffer
+	if err := r.WriteText(&out); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	for _, s := range []string{
+		"payments-security@example.com",
+		"+1.0",
+		"0/10 github.com/acme/pay-api Token-Permissions: write-all",
+		"no results for github.com/acme/pay-web",
+	} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("WriteText() = %s, want it to contain %q", out.String(), s)
+		}
+	}
+}
+
+func TestBuild_noResults(t *testing.T) {
+	t.Parallel()
+	m := &Manifest{Teams: []Team{{Name: "payments", Repos: []string{"github.com/acme/pay-api"}}}}
+	r := Build(m, nil, nil, 5, time.Now())
+	team := r.Teams[0]
+	if team.Score != checker.InconclusiveResultScore || len(team.Missing) != 1 {
+		t.Errorf("Build() = %+v", team)
+	}
+	if _, ok := team.Trend(); ok {
+		t.Error("Trend() of a team without results is known")
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package portfolio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+// WriteJSON writes the report as JSON. Unknown scores are
+// checker.InconclusiveResultScore.
+func (r *Report) WriteJSON(w io.Writer) error {
+	if err := json.NewEncoder(w).Encode(r); err != nil {
+		return fmt.Errorf("encoder.Encode: %w", err)
+	}
+	return nil
+}
+
+// WriteText writes a table of the scores of the teams, followed by the worst
+// findings of each team.
+func (r *Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "Scorecard portfolio generated on %s\n\n", r.Generated.Format("2006-01-02"))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Team", "Owners", "Repos", "Score", "Trend", "Failing checks"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetRowSeparator("-")
+	table.SetCenterSeparator("|")
+	table.SetAutoFormatHeaders(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for i := range r.Teams {
+		t := &r.Teams[i]
+		failing := 0
+		for _, c := range t.Checks {
+			failing += c.Failing
+		}
+		repos := fmt.Sprintf("%d", len(t.Repos))
+		if len(t.Missing) > 0 {
+			repos += fmt.Sprintf(" (%d missing)", len(t.Missing))
+		}
+		table.Append([]string{
+			t.Team,
+			strings.Join(t.Owners, ", "),
+			repos,
+			scoreString(t.Score),
+			trendString(t),
+			fmt.Sprintf("%d", failing),
+		})
+	}
+	table.Render()
+
+	for i := range r.Teams {
+		t := &r.Teams[i]
+		if len(t.WorstFindings) == 0 && len(t.Missing) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s\n", t.Team)
+		for _, f := range t.WorstFindings {
+			fmt.Fprintf(w, "  %d/%d %s %s: %s\n", f.Score, checker.MaxResultScore, f.Repo, f.Check, f.Reason)
+		}
+		for _, repo := range t.Missing {
+			fmt.Fprintf(w, "  no results for %s\n", repo)
+		}
+	}
+	return nil
+}
+
+func scoreString(s float64) string {
+	if s == checker.InconclusiveResultScore {
+		return "?"
+	}
+	return fmt.Sprintf("%.1f", s)
+}
+
+func trendString(t *TeamReport) string {
+	trend, ok := t.Trend()
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f", trend)
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package publish renders a compact markdown summary of the results of a
+// repo, meant to be embedded in its README, or a gist, and kept up to date
+// by a scheduled job.
+package publish
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	docs "github.com/ossf/scorecard/v4/docs/checks"
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+// The markers delimiting the summary in the files it is embedded in. The
+// content between them is replaced when the summary is published again.
+const (
+	BeginMarker = "<!-- scorecard:begin -->"
+	EndMarker   = "<!-- scorecard:end -->"
+)
+
+// ErrMissingEndMarker is returned when embedding the summary in a file with
+// a begin marker but no end marker, to not replace the rest of the file.
+var ErrMissingEndMarker = errors.New("begin marker without end marker")
+
+// Summary renders the aggregate score of the result and the score of each
+// of its checks as a markdown table. It only depends on the scores, not on
+// when or at which commit the repo was scanned, so publishing the summary
+// of a repo which did not change commits nothing.
+func Summary(result *pkg.ScorecardResult, checkDocs docs.Doc) (string, error) {
+	score, err := AggregateScore(result, checkDocs)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("**OpenSSF Scorecard: %s**\n\n%s", score, Table(result, checkDocs, false)), nil
+}
+
+// AggregateScore renders the aggregate score of the result, or ? if it is
+// inconclusive.
+func AggregateScore(result *pkg.ScorecardResult, checkDocs docs.Doc) (string, error) {
+	score, err := result.GetAggregateScore(checkDocs)
+	if err != nil {
+		return "", fmt.Errorf("GetAggregateScore: %w", err)
+	}
+	if score == checker.InconclusiveResultScore {
+		return "?", nil
+	}
+	return fmt.Sprintf("%.1f / %d", score, checker.MaxResultScore), nil
+}
+
+// Table renders the score of each check of the result as a markdown table,
+// along with the reason of the score if withReasons is set. Checks link to
+// their documentation.
+func Table(result *pkg.ScorecardResult, checkDocs docs.Doc, withReasons bool) string {
+	var b strings.Builder
+	if withReasons {
+		b.WriteString("| Check | Score | Reason |\n|---|---|---|\n")
+	} else {
+		b.WriteString("| Check | Score |\n|---|---|\n")
+	}
+	for _, check := range result.Checks {
+		var checkScore string
+		switch {
+		case check.NotApplicable:
+			checkScore = "N/A"
+		case check.Score == checker.InconclusiveResultScore:
+			checkScore = "?"
+		default:
+			checkScore = fmt.Sprintf("%d / %d", check.Score, checker.MaxResultScore)
+		}
+		name := check.Name
+		if cdoc, err := checkDocs.GetCheck(check.Name); err == nil {
+			name = fmt.Sprintf("[%s](%s)", check.Name, cdoc.GetDocumentationURL(result.Scorecard.CommitSHA))
+		}
+		if withReasons {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", name, checkScore, strings.ReplaceAll(check.Reason, "|", "\\|"))
+		} else {
+			fmt.Fprintf(&b, "| %s | %s |\n", name, checkScore)
+		}
+	}
+	return b.String()
+}
+
+// Embed returns current with the summary between the markers replaced by
+// summary. Files without the markers get the summary, between markers,
+// appended, so it can be placed anywhere in a README by adding the markers
+// there first. Files with a begin marker but no end marker are left alone
+// and ErrMissingEndMarker is returned.
+func Embed(current []byte, summary string) ([]byte, error) {
+	section := BeginMarker + "\n" + summary + EndMarker
+	if begin := bytes.Index(current, []byte(BeginMarker)); begin >= 0 {
+		end := bytes.Index(current[begin:], []byte(EndMarker))
+		if end < 0 {
+			return nil, ErrMissingEndMarker
+		}
+		rest := current[begin+end+len(EndMarker):]
+		ret := make([]byte, 0, begin+len(section)+len(rest))
+		ret = append(ret, current[:begin]...)
+		ret = append(ret, section...)
+		return append(ret, rest...), nil
+	}
+	ret := append([]byte{}, current...)
+	if len(ret) > 0 {
+		if !bytes.HasSuffix(ret, []byte("\n")) {
+			ret = append(ret, '\n')
+		}
+		ret = append(ret, '\n')
+	}
+	ret = append(ret, section...)
+	return append(ret, '\n'), nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 OpenSSF Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publish
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v4/checker"
+	docs "github.com/ossf/scorecard/v4/docs/checks"
+	"github.com/ossf/scorecard/v4/pkg"
+)
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+	checkDocs, err := docs.Read()
+	if err != nil {
+		t.Fatalf("docs.Read: %v", err)
+	}
+	result := pkg.ScorecardResult{
+		Repo: pkg.RepoInfo{
+			Name:      "github.com/ossf/scorecard",
+			CommitSHA: "8e5e7e5ab8b370d6c329ec480221332ada57f0ab",
+		},
+		Date: time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+		Checks: []checker.CheckResult{
+			{Name: "Binary-Artifacts", Score: 10, Reason: "no binaries found in the repo"},
+			{Name: "Fuzzing", Score: checker.InconclusiveResultScore, Reason: "internal error"},
+			checker.CreateNotApplicableResult("Packaging", "no published packages"),
+		},
+	}
+	got, err := Summary(&result, checkDocs)
+	if err != nil {
+		t.Fatalf("Summary: %v", err)
+	}
+	for _, want := range []string{
+		"**OpenSSF Scorecard: 10.0 / 10**\n",
+		"| [Binary-Artifacts](",
+		") | 10 / 10 |\n",
+		") | ? |\n",
+		") | N/A |\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Summary() = %s, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "no binaries found") {
+		t.Errorf("Summary() = %s, want no reasons", got)
+	}
+
+	// A later scan of the same commit, or of a commit changing only the
+	// file the summary is published to, gives the same summary.
+	result.Date = result.Date.AddDate(0, 0, 7)
+	result.Repo.CommitSHA = "0f9b7a5d3c1e2b4a6f8d0c2e4a6b8d0f2a4c6e8b"
+	again, err := Summary(&result, checkDocs)
+	if err != nil {
+		t.Fatalf("Summary: %v", err)
+	}
+	if diff := cmp.Diff(got, again); diff != "" {
+		t.Errorf("Summary() of a later scan mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEmbed(t *testing.T) {
+	t.Parallel()
+	const summary = "scores\n"
+	tests := []struct {
+		wantErr error
+		name    string
+		current string
+		want    string
+	}{
+		{
+			name: "new file",
+			want: "<!-- scorecard:begin -->\nscores\n<!-- scorecard:end -->\n",
+		},
+		{
+			name:    "file without markers",
+			current: "# repo",
+			want:    "# repo\n\n<!-- scorecard:begin -->\nscores\n<!-- scorecard:end -->\n",
+		},
+		{
+			name:    "file with markers",
+			current: "# repo\n\n<!-- scorecard:begin -->\nold\nscores\n<!-- scorecard:end -->\n\n## Usage\n",
+			want:    "# repo\n\n<!-- scorecard:begin -->\nscores\n<!-- scorecard:end -->\n\n## Usage\n",
+		},
+		{
+			name:    "file without end marker",
+			current: "# repo\n<!-- scorecard:begin -->\n\n## Usage\n",
+			wantErr: ErrMissingEndMarker,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			embedded, err := Embed([]byte(tt.current), summary)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Embed() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got := string(embedded)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Embed() mismatch (-want +got):\n%s", diff)
+			}
+			// Publishing the same summary again changes nothing.
+			again, err := Embed(embedded, summary)
+			if err != nil {
+				t.Fatalf("Embed: %v", err)
+			}
+			if string(again) != got {
+				t.Errorf("Embed() of its output = %q, want %q", again, got)
+			}
+		})
+	}
+}
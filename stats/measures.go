@@ -22,6 +22,13 @@ var (
 		stats.UnitSeconds)
 	// CheckErrors measures the count of errors per check.
 	CheckErrors = stats.Int64("CheckErrors", "Measures the count of errors", stats.UnitDimensionless)
+	// CheckPanics measures the count of panics recovered per check.
+	CheckPanics = stats.Int64("CheckPanics", "Measures the count of recovered check panics", stats.UnitDimensionless)
+	// RepoRequests measures the count of repo requests processed by the cron worker.
+	RepoRequests = stats.Int64("RepoRequests", "Measures the count of repo requests processed", stats.UnitDimensionless)
+	// ResultStalenessInSec measures the age of the newest result of a repo when it is replaced.
+	ResultStalenessInSec = stats.Int64("ResultStalenessInSec",
+		"Measures the age in seconds of the newest result of a repo when it is replaced", stats.UnitSeconds)
 	// HTTPRequests measures the count of HTTP requests.
 	HTTPRequests = stats.Int64("HTTPRequests", "Measures the count of HTTP requests", stats.UnitDimensionless)
 )
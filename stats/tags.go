@@ -21,6 +21,13 @@ var (
 	CheckName = tag.MustNewKey("checkName")
 	// ErrorName is the tag key for errors.
 	ErrorName = tag.MustNewKey("errorName")
+	// Outcome is the tag key for the outcome of a repo request, e.g. success or failure.
+	Outcome = tag.MustNewKey("outcome")
 	// RequestTag is the tag key for the request type.
 	RequestTag = tag.MustNewKey("requestTag")
+	// EndpointCategory is the tag key for the category of the API endpoint requested,
+	// e.g. contents, pulls, checks or graphql.
+	EndpointCategory = tag.MustNewKey("endpointCategory")
+	// StatusClass is the tag key for the class of the HTTP response status, e.g. 2xx.
+	StatusClass = tag.MustNewKey("statusClass")
 )
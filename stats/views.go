@@ -20,6 +20,9 @@ import (
 	"go.opencensus.io/tag"
 )
 
+// day is the number of seconds in a day.
+const day = 24 * 60 * 60
+
 var (
 	// CheckRuntime tracks CPU runtime stats for checks.
 	CheckRuntime = view.View{
@@ -46,6 +49,16 @@ var (
 			1<<15),
 	}
 
+	// CheckRunCount tracks the number of runs per check, the denominator of the
+	// per-check error rate.
+	CheckRunCount = view.View{
+		Name:        "CheckRunCount",
+		Description: "Run count per check",
+		Measure:     CheckRuntimeInSec,
+		TagKeys:     []tag.Key{CheckName},
+		Aggregation: view.Count(),
+	}
+
 	// CheckErrorCount tracks error count stats for checks.
 	CheckErrorCount = view.View{
 		Name:        "CheckErrorCount",
@@ -55,6 +68,40 @@ var (
 		Aggregation: view.Count(),
 	}
 
+	// CheckPanicCount tracks recovered panic count stats for checks.
+	CheckPanicCount = view.View{
+		Name:        "CheckPanicCount",
+		Description: "Recovered panic count per check",
+		Measure:     CheckPanics,
+		TagKeys:     []tag.Key{CheckName},
+		Aggregation: view.Count(),
+	}
+
+	// RepoRequestCount tracks repo requests processed by the cron worker per outcome.
+	RepoRequestCount = view.View{
+		Name:        "RepoRequestCount",
+		Description: "Repo requests processed per outcome",
+		Measure:     RepoRequests,
+		TagKeys:     []tag.Key{Outcome},
+		Aggregation: view.Count(),
+	}
+
+	// ResultStaleness tracks the age of the newest result of a repo when it is replaced.
+	ResultStaleness = view.View{
+		Name:        "ResultStaleness",
+		Description: "Age of the newest result of a repo when it is replaced",
+		Measure:     ResultStalenessInSec,
+		//nolint:gomnd
+		Aggregation: view.Distribution(
+			1*day,
+			2*day,
+			3*day,
+			5*day,
+			7*day,
+			14*day,
+			30*day),
+	}
+
 	// OutgoingHTTPRequests tracks HTTPRequests made.
 	OutgoingHTTPRequests = view.View{
 		Name:        "OutgoingHTTPRequests",
@@ -63,4 +110,13 @@ var (
 		TagKeys:     []tag.Key{CheckName, RequestTag},
 		Aggregation: view.Count(),
 	}
+
+	// OutgoingHTTPRequestsByEndpoint tracks HTTPRequests made per endpoint category and status class.
+	OutgoingHTTPRequestsByEndpoint = view.View{
+		Name:        "OutgoingHTTPRequestsByEndpoint",
+		Description: "HTTPRequests made per endpoint category and response status class",
+		Measure:     HTTPRequests,
+		TagKeys:     []tag.Key{EndpointCategory, StatusClass, RequestTag},
+		Aggregation: view.Count(),
+	}
 )